@@ -64,7 +64,7 @@ func NewVerifiableCertificateFromBinary(data []byte) (*VerifiableCertificate, er
 //
 // Args:
 //
-//	verifierWallet: The wallet instance of the certificate's verifier (must implement wallet.Interface).
+//	verifierWallet: The wallet instance of the certificate's verifier (only its cipher operations are used).
 //	                Used to decrypt the field revelation keys stored in the KeyRing.
 //	privileged:     Whether this is a privileged request (optional, defaults to false).
 //	privilegedReason: Reason provided for privileged access (optional, required if privileged is true).
@@ -75,12 +75,64 @@ func NewVerifiableCertificateFromBinary(data []byte) (*VerifiableCertificate, er
 //	An error if the keyring is missing/empty or if any decryption operation fails.
 func (vc *VerifiableCertificate) DecryptFields(
 	ctx context.Context,
-	verifierWallet wallet.Interface, // Use the interface type
+	verifierWallet wallet.CipherOperations,
+	privileged bool,
+	privilegedReason string,
+) (map[string]string, error) {
+	decryptedFields, err := vc.decryptKeyring(ctx, verifierWallet, vc.Keyring, privileged, privilegedReason)
+	if err != nil {
+		return nil, err
+	}
+
+	// If all fields in the keyring were decrypted successfully, store the result and return.
+	vc.DecryptedFields = decryptedFields
+	return decryptedFields, nil
+}
+
+// VerifyRevealedFields checks the certifier's signature over the full certificate and
+// decrypts only the fields revealed by keyring, returning their plaintext values.
+// If keyring is nil the certificate's own Keyring is used. On success the keyring
+// and decrypted fields are stored on the certificate.
+func (vc *VerifiableCertificate) VerifyRevealedFields(
+	ctx context.Context,
+	verifierWallet wallet.CipherOperations,
+	keyring map[wallet.CertificateFieldNameUnder50Bytes]wallet.StringBase64,
+) (map[string]string, error) {
+	if keyring == nil {
+		keyring = vc.Keyring
+	}
+
+	// The signature covers every encrypted field, revealed or not.
+	if err := vc.Verify(ctx); err != nil {
+		return nil, fmt.Errorf("certificate verification failed: %w", err)
+	}
+
+	for fieldName := range keyring {
+		if _, exists := vc.Fields[fieldName]; !exists {
+			return nil, fmt.Errorf("%w: keyring reveals field %s", ErrFieldNotFound, fieldName)
+		}
+	}
+
+	decryptedFields, err := vc.decryptKeyring(ctx, verifierWallet, keyring, false, "")
+	if err != nil {
+		return nil, err
+	}
+
+	vc.Keyring = keyring
+	vc.DecryptedFields = decryptedFields
+	return decryptedFields, nil
+}
+
+// decryptKeyring decrypts the fields revealed by keyring using the verifier's wallet.
+func (vc *VerifiableCertificate) decryptKeyring(
+	ctx context.Context,
+	verifierWallet wallet.CipherOperations,
+	keyring map[wallet.CertificateFieldNameUnder50Bytes]wallet.StringBase64,
 	privileged bool,
 	privilegedReason string,
 ) (map[string]string, error) {
 	// Check if the KeyRing is nil or empty
-	if len(vc.Keyring) == 0 {
+	if len(keyring) == 0 {
 		return nil, errors.New("a keyring is required to decrypt certificate fields for the verifier")
 	}
 
@@ -100,7 +152,7 @@ func (vc *VerifiableCertificate) DecryptFields(
 	}
 
 	// Iterate through the fields specified in the verifier's KeyRing.
-	for fieldName, encryptedKeyBase64 := range vc.Keyring {
+	for fieldName, encryptedKeyBase64 := range keyring {
 		// 1. Decrypt the field revelation key using the verifier's wallet.
 		encryptedKeyBytes, err := base64.StdEncoding.DecodeString(string(encryptedKeyBase64))
 		if err != nil {
@@ -155,7 +207,5 @@ func (vc *VerifiableCertificate) DecryptFields(
 		decryptedFields[string(fieldName)] = string(decryptedFieldBytes)
 	}
 
-	// If all fields in the keyring were decrypted successfully, store the result and return.
-	vc.DecryptedFields = decryptedFields
 	return decryptedFields, nil
 }
@@ -427,3 +427,85 @@ func TestVerifiableCertificate(t *testing.T) {
 		})
 	})
 }
+
+func TestVerifyRevealedFields(t *testing.T) {
+	ctx := t.Context()
+	subjectPrivateKey, _ := ec.NewPrivateKey()
+	certifierPrivateKey, _ := ec.NewPrivateKey()
+	verifierPrivateKey, _ := ec.NewPrivateKey()
+
+	subjectWallet, _ := wallet.NewCompletedProtoWallet(subjectPrivateKey)
+	certifierWallet, _ := wallet.NewCompletedProtoWallet(certifierPrivateKey)
+	verifierWallet, _ := wallet.NewCompletedProtoWallet(verifierPrivateKey)
+
+	certifierCounterparty := wallet.Counterparty{Type: wallet.CounterpartyTypeOther, Counterparty: certifierPrivateKey.PubKey()}
+	verifierCounterparty := wallet.Counterparty{Type: wallet.CounterpartyTypeOther, Counterparty: verifierPrivateKey.PubKey()}
+
+	master, err := IssueCertificateForSubject(
+		ctx,
+		certifierWallet,
+		wallet.Counterparty{Type: wallet.CounterpartyTypeOther, Counterparty: subjectPrivateKey.PubKey()},
+		map[string]string{"name": "Alice", "email": "alice@example.com"},
+		base64.StdEncoding.EncodeToString(bytes.Repeat([]byte{1}, 32)),
+		nil,
+		"",
+	)
+	require.NoError(t, err)
+
+	keyring, err := CreateKeyringForVerifier(
+		ctx,
+		subjectWallet,
+		certifierCounterparty,
+		verifierCounterparty,
+		master.Fields,
+		[]wallet.CertificateFieldNameUnder50Bytes{"name"},
+		master.MasterKeyring,
+		master.SerialNumber,
+		false,
+		"",
+	)
+	require.NoError(t, err)
+
+	t.Run("decrypts only the revealed fields", func(t *testing.T) {
+		vc := NewVerifiableCertificate(&master.Certificate, nil)
+		fields, err := vc.VerifyRevealedFields(ctx, verifierWallet, keyring)
+		require.NoError(t, err)
+		require.Equal(t, map[string]string{"name": "Alice"}, fields)
+		require.Equal(t, fields, vc.DecryptedFields)
+	})
+
+	t.Run("uses the certificate keyring when none is given", func(t *testing.T) {
+		vc := NewVerifiableCertificate(&master.Certificate, keyring)
+		fields, err := vc.VerifyRevealedFields(ctx, verifierWallet, nil)
+		require.NoError(t, err)
+		require.Equal(t, "Alice", fields["name"])
+	})
+
+	t.Run("rejects a tampered unrevealed field", func(t *testing.T) {
+		tampered := master.Certificate
+		tampered.Fields = map[wallet.CertificateFieldNameUnder50Bytes]wallet.StringBase64{
+			"name":  master.Fields["name"],
+			"email": master.Fields["name"],
+		}
+		vc := NewVerifiableCertificate(&tampered, nil)
+		_, err := vc.VerifyRevealedFields(ctx, verifierWallet, keyring)
+		require.Error(t, err)
+		require.Nil(t, vc.DecryptedFields)
+	})
+
+	t.Run("rejects a keyring for fields not in the certificate", func(t *testing.T) {
+		vc := NewVerifiableCertificate(&master.Certificate, nil)
+		_, err := vc.VerifyRevealedFields(ctx, verifierWallet, map[wallet.CertificateFieldNameUnder50Bytes]wallet.StringBase64{
+			"phone": keyring["name"],
+		})
+		require.ErrorIs(t, err, ErrFieldNotFound)
+	})
+
+	t.Run("fails for another verifier", func(t *testing.T) {
+		otherKey, _ := ec.NewPrivateKey()
+		otherWallet, _ := wallet.NewCompletedProtoWallet(otherKey)
+		vc := NewVerifiableCertificate(&master.Certificate, nil)
+		_, err := vc.VerifyRevealedFields(ctx, otherWallet, keyring)
+		require.Error(t, err)
+	})
+}
@@ -0,0 +1,239 @@
+package certifier
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/bsv-blockchain/go-sdk/auth/brc104"
+	"github.com/bsv-blockchain/go-sdk/auth/certificates"
+	authhttp "github.com/bsv-blockchain/go-sdk/auth/clients/authhttp"
+	"github.com/bsv-blockchain/go-sdk/auth/utils"
+	"github.com/bsv-blockchain/go-sdk/wallet"
+)
+
+// ErrInvalidCertificate is returned by Acquire when the certificate returned by
+// the certifier does not match the request.
+var ErrInvalidCertificate = errors.New("certifier returned an invalid certificate")
+
+// CertificateStore persists the certificates acquired by a subject, along with the
+// master keyring the subject decrypts their fields with.
+type CertificateStore interface {
+	StoreCertificate(ctx context.Context, cert *certificates.MasterCertificate) error
+}
+
+type acquireOptions struct {
+	client *authhttp.AuthFetch
+	store  CertificateStore
+}
+
+// AcquireOption configures Acquire.
+type AcquireOption func(*acquireOptions)
+
+// WithAuthFetch sets the client used to reach the certifier, for example to
+// reuse its sessions. By default a new client is created for the subject wallet.
+func WithAuthFetch(client *authhttp.AuthFetch) AcquireOption {
+	return func(o *acquireOptions) {
+		o.client = client
+	}
+}
+
+// WithStore persists the acquired certificate.
+func WithStore(store CertificateStore) AcquireOption {
+	return func(o *acquireOptions) {
+		o.store = store
+	}
+}
+
+// Acquire requests a certificate from the certifier at args.CertifierUrl with the
+// "issuance" acquisition protocol. The plaintext fields are encrypted for the
+// certifier and posted with a fresh client nonce over an authenticated channel.
+// The returned certificate is checked against the request: its serial number must
+// derive from both nonces, it must be signed by args.Certifier for the wallet's
+// identity key, and its fields must be the ones sent and decryptable with the
+// returned master keyring.
+func Acquire(ctx context.Context, w wallet.Interface, args wallet.AcquireCertificateArgs, opts ...AcquireOption) (*certificates.MasterCertificate, error) {
+	o := acquireOptions{}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	if args.AcquisitionProtocol != wallet.AcquisitionProtocolIssuance {
+		return nil, fmt.Errorf("%w: acquisition protocol must be %q", ErrInvalidRequest, wallet.AcquisitionProtocolIssuance)
+	}
+	if args.Certifier == nil {
+		return nil, fmt.Errorf("%w: missing certifier", ErrInvalidRequest)
+	}
+	if args.CertifierUrl == "" {
+		return nil, fmt.Errorf("%w: missing certifier URL", ErrInvalidRequest)
+	}
+	if len(args.Fields) == 0 {
+		return nil, fmt.Errorf("%w: no fields", ErrInvalidRequest)
+	}
+	if err := args.ValidateFields(); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidFields, err)
+	}
+	if o.client == nil {
+		o.client = authhttp.New(w)
+	}
+	privileged := args.Privileged != nil && *args.Privileged
+
+	identity, err := w.GetPublicKey(ctx, wallet.GetPublicKeyArgs{IdentityKey: true}, "")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get identity key: %w", err)
+	}
+	certifier := wallet.Counterparty{Type: wallet.CounterpartyTypeOther, Counterparty: args.Certifier}
+
+	clientNonce, err := utils.CreateNonce(ctx, w, certifier)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create client nonce: %w", err)
+	}
+	plainFields := make(map[wallet.CertificateFieldNameUnder50Bytes]string, len(args.Fields))
+	for name, value := range args.Fields {
+		plainFields[wallet.CertificateFieldNameUnder50Bytes(name)] = value
+	}
+	encrypted, err := certificates.CreateCertificateFields(ctx, w, certifier, plainFields, privileged, args.PrivilegedReason)
+	if err != nil {
+		return nil, err
+	}
+
+	req := &SignCertificateRequest{
+		ClientNonce:   clientNonce,
+		Type:          wallet.StringBase64FromArray(args.Type),
+		Fields:        encrypted.CertificateFields,
+		MasterKeyring: encrypted.MasterKeyring,
+	}
+	resp, err := postSignCertificate(ctx, o.client, args, req)
+	if err != nil {
+		return nil, err
+	}
+
+	valid, err := utils.VerifyNonce(ctx, resp.ServerNonce, w, certifier)
+	if err != nil || !valid {
+		return nil, fmt.Errorf("%w: server nonce was not created by the certifier", ErrInvalidCertificate)
+	}
+	serialNumber, err := SerialNumber(ctx, w, certifier, clientNonce, resp.ServerNonce)
+	if err != nil {
+		return nil, err
+	}
+
+	cert := resp.Certificate
+	switch {
+	case cert == nil:
+		return nil, fmt.Errorf("%w: no certificate in the response", ErrInvalidCertificate)
+	case cert.Type != req.Type:
+		return nil, fmt.Errorf("%w: type %s was requested, got %s", ErrInvalidCertificate, req.Type, cert.Type)
+	case cert.SerialNumber != serialNumber:
+		return nil, fmt.Errorf("%w: serial number does not derive from the nonces", ErrInvalidCertificate)
+	case !cert.Subject.IsEqual(identity.PublicKey):
+		return nil, fmt.Errorf("%w: issued to another subject", ErrInvalidCertificate)
+	case !cert.Certifier.IsEqual(args.Certifier):
+		return nil, fmt.Errorf("%w: issued by another certifier", ErrInvalidCertificate)
+	case !maps.Equal(cert.Fields, req.Fields):
+		return nil, fmt.Errorf("%w: fields differ from the ones sent", ErrInvalidCertificate)
+	}
+	if err := cert.Verify(ctx); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidCertificate, err)
+	}
+
+	master, err := certificates.NewMasterCertificate(cert, encrypted.MasterKeyring)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := certificates.DecryptFields(ctx, w, master.MasterKeyring, cert.Fields, certifier, privileged, args.PrivilegedReason); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidCertificate, err)
+	}
+
+	if o.store != nil {
+		if err := o.store.StoreCertificate(ctx, master); err != nil {
+			return nil, fmt.Errorf("failed to store certificate: %w", err)
+		}
+	}
+	return master, nil
+}
+
+func postSignCertificate(ctx context.Context, client *authhttp.AuthFetch, args wallet.AcquireCertificateArgs, req *SignCertificateRequest) (*SignCertificateResponse, error) {
+	body, err := json.Marshal(req)
+	if err != nil {
+		return nil, err
+	}
+	res, err := client.Fetch(ctx, strings.TrimSuffix(args.CertifierUrl, "/")+SignCertificatePath, &authhttp.SimplifiedFetchRequestOptions{
+		Method:  http.MethodPost,
+		Headers: map[string]string{"Content-Type": "application/json"},
+		Body:    body,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to request certificate: %w", err)
+	}
+	defer res.Body.Close()
+
+	if identityKey := res.Header.Get(brc104.HeaderIdentityKey); identityKey != args.Certifier.ToDERHex() {
+		return nil, fmt.Errorf("%w: response was not sent by the certifier", ErrInvalidCertificate)
+	}
+	data, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read certifier response: %w", err)
+	}
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("certifier responded with status %d: %s", res.StatusCode, bytes.TrimSpace(data))
+	}
+	var resp SignCertificateResponse
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return nil, fmt.Errorf("failed to decode certifier response: %w", err)
+	}
+	return &resp, nil
+}
+
+// IssuingWallet wraps a wallet, handling "issuance" AcquireCertificate calls with
+// Acquire and persisting the certificates in Store. Every other call, including
+// "direct" acquisitions, is passed to the wrapped wallet.
+type IssuingWallet struct {
+	wallet.Interface
+	Store   CertificateStore
+	options []AcquireOption
+}
+
+// NewIssuingWallet wraps w, storing acquired certificates in store.
+func NewIssuingWallet(w wallet.Interface, store CertificateStore, opts ...AcquireOption) *IssuingWallet {
+	return &IssuingWallet{Interface: w, Store: store, options: opts}
+}
+
+// AcquireCertificate implements wallet.Interface.
+func (w *IssuingWallet) AcquireCertificate(ctx context.Context, args wallet.AcquireCertificateArgs, originator string) (*wallet.Certificate, error) {
+	if args.AcquisitionProtocol != wallet.AcquisitionProtocolIssuance {
+		return w.Interface.AcquireCertificate(ctx, args, originator)
+	}
+	opts := append([]AcquireOption{WithStore(w.Store)}, w.options...)
+	master, err := Acquire(ctx, w.Interface, args, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return master.ToWalletCertificate()
+}
+
+// MemoryStore is a CertificateStore keeping certificates in memory.
+type MemoryStore struct {
+	mu    sync.Mutex
+	certs []*certificates.MasterCertificate
+}
+
+// StoreCertificate implements CertificateStore.
+func (s *MemoryStore) StoreCertificate(_ context.Context, cert *certificates.MasterCertificate) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.certs = append(s.certs, cert)
+	return nil
+}
+
+// Certificates returns the stored certificates.
+func (s *MemoryStore) Certificates() []*certificates.MasterCertificate {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]*certificates.MasterCertificate(nil), s.certs...)
+}
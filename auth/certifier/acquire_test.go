@@ -0,0 +1,113 @@
+package certifier_test
+
+import (
+	"context"
+	"errors"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/bsv-blockchain/go-sdk/auth/certificates"
+	"github.com/bsv-blockchain/go-sdk/auth/certifier"
+	authhttp "github.com/bsv-blockchain/go-sdk/auth/clients/authhttp"
+	"github.com/bsv-blockchain/go-sdk/auth/middleware"
+	ec "github.com/bsv-blockchain/go-sdk/primitives/ec"
+	"github.com/bsv-blockchain/go-sdk/wallet"
+	"github.com/bsv-blockchain/go-sdk/wallet/certtypes"
+	"github.com/stretchr/testify/require"
+)
+
+func newCertifierServer(t *testing.T, c *certifier.Certifier, serverWallet wallet.Interface) *httptest.Server {
+	t.Helper()
+	m, err := middleware.New(middleware.Options{Wallet: serverWallet})
+	require.NoError(t, err)
+	server := httptest.NewServer(m.Handler(c.Handler()))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func identityKey(t *testing.T, w wallet.Interface) *ec.PublicKey {
+	t.Helper()
+	key, err := w.GetPublicKey(t.Context(), wallet.GetPublicKeyArgs{IdentityKey: true}, "")
+	require.NoError(t, err)
+	return key.PublicKey
+}
+
+func TestAcquireIssuance(t *testing.T) {
+	certifierWallet := wallet.NewTestWalletForRandomKey(t)
+	certifierKey := identityKey(t, certifierWallet)
+	server := newCertifierServer(t, certifier.NewCertifier(certifierWallet, certtypes.EmailCert), certifierWallet)
+
+	subjectWallet := wallet.NewTestWalletForRandomKey(t)
+	subjectKey := identityKey(t, subjectWallet)
+	certType, err := wallet.CertificateTypeFromBase64(certtypes.EmailCert)
+	require.NoError(t, err)
+
+	store := &certifier.MemoryStore{}
+	w := certifier.NewIssuingWallet(subjectWallet, store, certifier.WithAuthFetch(authhttp.New(subjectWallet, authhttp.WithoutLogging())))
+	cert, err := w.AcquireCertificate(t.Context(), wallet.AcquireCertificateArgs{
+		Type:                certType,
+		Certifier:           certifierKey,
+		AcquisitionProtocol: wallet.AcquisitionProtocolIssuance,
+		CertifierUrl:        server.URL,
+		Fields:              map[string]string{"email": "alice@example.com"},
+	}, "")
+	require.NoError(t, err)
+	require.Equal(t, certType, cert.Type)
+	require.True(t, cert.Subject.IsEqual(subjectKey))
+	require.True(t, cert.Certifier.IsEqual(certifierKey))
+
+	stored := store.Certificates()
+	require.Len(t, stored, 1)
+	fields, err := certificates.DecryptFields(t.Context(), subjectWallet, stored[0].MasterKeyring, stored[0].Fields,
+		wallet.Counterparty{Type: wallet.CounterpartyTypeOther, Counterparty: certifierKey}, false, "")
+	require.NoError(t, err)
+	require.Equal(t, "alice@example.com", fields["email"])
+}
+
+func TestAcquireRejectsInvalidIssuance(t *testing.T) {
+	certifierWallet := wallet.NewTestWalletForRandomKey(t)
+	certifierKey := identityKey(t, certifierWallet)
+	c := certifier.NewCertifier(certifierWallet)
+	server := newCertifierServer(t, c, certifierWallet)
+
+	subjectWallet := wallet.NewTestWalletForRandomKey(t)
+	client := authhttp.New(subjectWallet, authhttp.WithoutLogging())
+	args := wallet.AcquireCertificateArgs{
+		Type:                [32]byte{1},
+		Certifier:           certifierKey,
+		AcquisitionProtocol: wallet.AcquisitionProtocolIssuance,
+		CertifierUrl:        server.URL,
+		Fields:              map[string]string{"name": "Alice"},
+	}
+
+	t.Run("certifier rejects the fields", func(t *testing.T) {
+		c.ValidateFields = func(context.Context, *ec.PublicKey, wallet.StringBase64, map[wallet.CertificateFieldNameUnder50Bytes]string) error {
+			return errors.New("rejected")
+		}
+		t.Cleanup(func() { c.ValidateFields = nil })
+		_, err := certifier.Acquire(t.Context(), subjectWallet, args, certifier.WithAuthFetch(client))
+		require.ErrorContains(t, err, "status 400")
+	})
+
+	t.Run("response from another certifier", func(t *testing.T) {
+		other := args
+		other.Certifier = identityKey(t, wallet.NewTestWalletForRandomKey(t))
+		_, err := certifier.Acquire(t.Context(), subjectWallet, other, certifier.WithAuthFetch(client))
+		require.ErrorIs(t, err, certifier.ErrInvalidCertificate)
+	})
+
+	t.Run("fields failing the type schema", func(t *testing.T) {
+		invalid := args
+		invalid.Type, _ = wallet.CertificateTypeFromBase64(certtypes.EmailCert)
+		invalid.Fields = map[string]string{"email": "not an email"}
+		_, err := certifier.Acquire(t.Context(), subjectWallet, invalid, certifier.WithAuthFetch(client))
+		require.ErrorIs(t, err, certifier.ErrInvalidFields)
+	})
+
+	t.Run("direct acquisition is not issuance", func(t *testing.T) {
+		direct := args
+		direct.AcquisitionProtocol = wallet.AcquisitionProtocolDirect
+		_, err := certifier.Acquire(t.Context(), subjectWallet, direct)
+		require.ErrorIs(t, err, certifier.ErrInvalidRequest)
+	})
+}
@@ -0,0 +1,245 @@
+// Package certifier implements the server side of certificate issuance. A Certifier
+// answers "issuance" acquisition requests made by subject wallets: it checks the
+// subject's field payload, decrypts and validates the field values, derives the
+// serial number from the client and server nonces, assigns a revocation outpoint
+// and signs the certificate. It can also issue certificates directly, encrypting
+// the fields to the subject itself.
+//
+// The flow mirrors the certifier servers used with the TypeScript SDK so subjects
+// using either SDK can acquire certificates from a Go certifier. Handler serves it
+// over HTTP behind the auth middleware.
+//
+// Acquire implements the subject's side of the flow, and IssuingWallet plugs it
+// into a wallet's AcquireCertificate.
+package certifier
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/bsv-blockchain/go-sdk/auth/certificates"
+	"github.com/bsv-blockchain/go-sdk/auth/utils"
+	ec "github.com/bsv-blockchain/go-sdk/primitives/ec"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+	"github.com/bsv-blockchain/go-sdk/wallet"
+	"github.com/bsv-blockchain/go-sdk/wallet/certtypes"
+)
+
+var (
+	ErrInvalidRequest  = errors.New("invalid certificate signing request")
+	ErrUnsupportedType = errors.New("unsupported certificate type")
+	ErrInvalidFields   = errors.New("invalid certificate fields")
+)
+
+// IssuanceProtocol is the protocol used to derive serial numbers from the nonces.
+var IssuanceProtocol = wallet.Protocol{
+	SecurityLevel: wallet.SecurityLevelEveryAppAndCounterparty,
+	Protocol:      "certificate issuance",
+}
+
+// FieldValidator checks the decrypted field values of a certificate before it is
+// signed. Returning an error rejects the request.
+type FieldValidator func(ctx context.Context, subject *ec.PublicKey, certificateType wallet.StringBase64, fields map[wallet.CertificateFieldNameUnder50Bytes]string) error
+
+// RevocationOutpointFunc returns the revocation outpoint of a new certificate,
+// typically by creating an output the certifier can later spend to revoke it.
+type RevocationOutpointFunc func(ctx context.Context, serialNumber wallet.StringBase64) (*transaction.Outpoint, error)
+
+// SignCertificateRequest is the body a subject sends to request a certificate.
+type SignCertificateRequest struct {
+	ClientNonce   string                                                          `json:"clientNonce"`
+	Type          wallet.StringBase64                                             `json:"type"`
+	Fields        map[wallet.CertificateFieldNameUnder50Bytes]wallet.StringBase64 `json:"fields"`
+	MasterKeyring map[wallet.CertificateFieldNameUnder50Bytes]wallet.StringBase64 `json:"masterKeyring"`
+}
+
+// SignCertificateResponse is returned to the subject with the signed certificate.
+type SignCertificateResponse struct {
+	Certificate *certificates.Certificate `json:"certificate"`
+	ServerNonce string                    `json:"serverNonce"`
+}
+
+// Certifier issues certificates signed by its wallet's identity key.
+type Certifier struct {
+	Wallet wallet.KeyOperations
+	// Types lists the certificate types the certifier issues. Any type is accepted when empty.
+	Types []wallet.StringBase64
+	// Schemas checks the decrypted fields of every certificate against the schema
+	// of its type. Nil uses certtypes.Default.
+	Schemas *certtypes.Registry
+	// ValidateFields, when set, is called with the decrypted fields of every
+	// certificate that passes its schema.
+	ValidateFields FieldValidator
+	// RevocationOutpoint, when set, assigns revocation outpoints. Otherwise
+	// certificates get the all-zero outpoint and cannot be revoked.
+	RevocationOutpoint RevocationOutpointFunc
+}
+
+// NewCertifier creates a Certifier signing with w.
+func NewCertifier(w wallet.KeyOperations, types ...wallet.StringBase64) *Certifier {
+	return &Certifier{Wallet: w, Types: types}
+}
+
+// SignCertificate handles an issuance request from subject. The subject's identity
+// key must come from an authenticated channel, such as a BRC-103 session.
+func (c *Certifier) SignCertificate(ctx context.Context, subject *ec.PublicKey, req *SignCertificateRequest) (*SignCertificateResponse, error) {
+	if subject == nil {
+		return nil, fmt.Errorf("%w: missing subject", ErrInvalidRequest)
+	}
+	if req.ClientNonce == "" {
+		return nil, fmt.Errorf("%w: missing client nonce", ErrInvalidRequest)
+	}
+	if err := c.checkType(req.Type); err != nil {
+		return nil, err
+	}
+	if len(req.Fields) == 0 {
+		return nil, fmt.Errorf("%w: no fields", ErrInvalidRequest)
+	}
+	for name := range req.Fields {
+		if len(name) > 50 {
+			return nil, fmt.Errorf("%w: field name %q exceeds 50 bytes", ErrInvalidRequest, name)
+		}
+		if _, ok := req.MasterKeyring[name]; !ok {
+			return nil, fmt.Errorf("%w: master keyring is missing field %q", ErrInvalidRequest, name)
+		}
+	}
+
+	counterparty := wallet.Counterparty{Type: wallet.CounterpartyTypeOther, Counterparty: subject}
+	plainFields, err := certificates.DecryptFields(ctx, c.Wallet, req.MasterKeyring, req.Fields, counterparty, false, "")
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidFields, err)
+	}
+	if err := c.validate(ctx, subject, req.Type, plainFields); err != nil {
+		return nil, err
+	}
+
+	serverNonce, err := utils.CreateNonce(ctx, c.Wallet, counterparty)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create server nonce: %w", err)
+	}
+	serialNumber, err := SerialNumber(ctx, c.Wallet, counterparty, req.ClientNonce, serverNonce)
+	if err != nil {
+		return nil, err
+	}
+	revocationOutpoint, err := c.revocationOutpoint(ctx, serialNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	cert := &certificates.Certificate{
+		Type:               req.Type,
+		SerialNumber:       serialNumber,
+		Subject:            *subject,
+		RevocationOutpoint: revocationOutpoint,
+		Fields:             req.Fields,
+	}
+	if err := cert.Sign(ctx, c.Wallet); err != nil {
+		return nil, err
+	}
+
+	return &SignCertificateResponse{Certificate: cert, ServerNonce: serverNonce}, nil
+}
+
+// IssueCertificate directly issues a certificate to subject with the given
+// plaintext fields, encrypting them to the subject. The returned master
+// certificate's keyring lets the subject decrypt the fields.
+func (c *Certifier) IssueCertificate(ctx context.Context, subject *ec.PublicKey, certificateType wallet.StringBase64, fields map[string]string) (*certificates.MasterCertificate, error) {
+	if subject == nil {
+		return nil, fmt.Errorf("%w: missing subject", ErrInvalidRequest)
+	}
+	if err := c.checkType(certificateType); err != nil {
+		return nil, err
+	}
+	plainFields := make(map[wallet.CertificateFieldNameUnder50Bytes]string, len(fields))
+	for name, value := range fields {
+		plainFields[wallet.CertificateFieldNameUnder50Bytes(name)] = value
+	}
+	if err := c.validate(ctx, subject, certificateType, plainFields); err != nil {
+		return nil, err
+	}
+
+	return certificates.IssueCertificateForSubject(
+		ctx,
+		c.Wallet,
+		wallet.Counterparty{Type: wallet.CounterpartyTypeOther, Counterparty: subject},
+		fields,
+		string(certificateType),
+		func(serialNumber string) (*transaction.Outpoint, error) {
+			return c.revocationOutpoint(ctx, wallet.StringBase64(serialNumber))
+		},
+		"",
+	)
+}
+
+// SerialNumber derives the serial number of an issued certificate from the client
+// and server nonces. Certifiers and subjects derive the same value, which lets the
+// subject check the certificate was issued in response to its request.
+func SerialNumber(ctx context.Context, w wallet.HMACOperations, counterparty wallet.Counterparty, clientNonce, serverNonce string) (wallet.StringBase64, error) {
+	clientNonceBytes, err := base64.StdEncoding.DecodeString(clientNonce)
+	if err != nil {
+		return "", fmt.Errorf("%w: invalid client nonce encoding: %w", ErrInvalidRequest, err)
+	}
+	serverNonceBytes, err := base64.StdEncoding.DecodeString(serverNonce)
+	if err != nil {
+		return "", fmt.Errorf("%w: invalid server nonce encoding: %w", ErrInvalidRequest, err)
+	}
+	data := append(clientNonceBytes, serverNonceBytes...)
+	result, err := w.CreateHMAC(ctx, wallet.CreateHMACArgs{
+		EncryptionArgs: wallet.EncryptionArgs{
+			ProtocolID:   IssuanceProtocol,
+			KeyID:        serverNonce + clientNonce,
+			Counterparty: counterparty,
+		},
+		Data: data,
+	}, "")
+	if err != nil {
+		return "", fmt.Errorf("failed to derive serial number: %w", err)
+	}
+	return wallet.StringBase64(base64.StdEncoding.EncodeToString(result.HMAC[:])), nil
+}
+
+func (c *Certifier) checkType(certificateType wallet.StringBase64) error {
+	if _, err := certificateType.ToArray(); err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidRequest, err)
+	}
+	if len(c.Types) > 0 && !slices.Contains(c.Types, certificateType) {
+		return fmt.Errorf("%w: %s", ErrUnsupportedType, certificateType)
+	}
+	return nil
+}
+
+func (c *Certifier) validate(ctx context.Context, subject *ec.PublicKey, certificateType wallet.StringBase64, fields map[wallet.CertificateFieldNameUnder50Bytes]string) error {
+	schemas := c.Schemas
+	if schemas == nil {
+		schemas = certtypes.Default
+	}
+	plain := make(map[string]string, len(fields))
+	for name, value := range fields {
+		plain[string(name)] = value
+	}
+	if err := schemas.ValidateFields(string(certificateType), plain); err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidFields, err)
+	}
+
+	if c.ValidateFields == nil {
+		return nil
+	}
+	if err := c.ValidateFields(ctx, subject, certificateType, fields); err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidFields, err)
+	}
+	return nil
+}
+
+func (c *Certifier) revocationOutpoint(ctx context.Context, serialNumber wallet.StringBase64) (*transaction.Outpoint, error) {
+	if c.RevocationOutpoint == nil {
+		return &transaction.Outpoint{}, nil
+	}
+	outpoint, err := c.RevocationOutpoint(ctx, serialNumber)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get revocation outpoint: %w", err)
+	}
+	return outpoint, nil
+}
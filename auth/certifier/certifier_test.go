@@ -0,0 +1,186 @@
+package certifier_test
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"testing"
+
+	"github.com/bsv-blockchain/go-sdk/auth/certificates"
+	"github.com/bsv-blockchain/go-sdk/auth/certifier"
+	"github.com/bsv-blockchain/go-sdk/auth/utils"
+	"github.com/bsv-blockchain/go-sdk/chainhash"
+	ec "github.com/bsv-blockchain/go-sdk/primitives/ec"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+	"github.com/bsv-blockchain/go-sdk/wallet"
+	"github.com/bsv-blockchain/go-sdk/wallet/certtypes"
+	"github.com/stretchr/testify/require"
+)
+
+// testType has no schema in certtypes.Default, leaving field checks to the tests.
+const testType = wallet.StringBase64("Z28tc2RrIGNlcnRpZmllciB0ZXN0IHR5cGUAAAAAAAA=")
+
+func newWallet(t *testing.T) (*wallet.ProtoWallet, *ec.PublicKey) {
+	t.Helper()
+	key, err := ec.NewPrivateKey()
+	require.NoError(t, err)
+	w, err := wallet.NewProtoWallet(wallet.ProtoWalletArgs{Type: wallet.ProtoWalletArgsTypePrivateKey, PrivateKey: key})
+	require.NoError(t, err)
+	return w, key.PubKey()
+}
+
+func newRequest(t *testing.T, subjectWallet *wallet.ProtoWallet, certifierKey *ec.PublicKey, fields map[wallet.CertificateFieldNameUnder50Bytes]string) *certifier.SignCertificateRequest {
+	t.Helper()
+	ctx := context.Background()
+	counterparty := wallet.Counterparty{Type: wallet.CounterpartyTypeOther, Counterparty: certifierKey}
+	clientNonce, err := utils.CreateNonce(ctx, subjectWallet, counterparty)
+	require.NoError(t, err)
+	encrypted, err := certificates.CreateCertificateFields(ctx, subjectWallet, counterparty, fields, false, "")
+	require.NoError(t, err)
+	return &certifier.SignCertificateRequest{
+		ClientNonce:   clientNonce,
+		Type:          testType,
+		Fields:        encrypted.CertificateFields,
+		MasterKeyring: encrypted.MasterKeyring,
+	}
+}
+
+func TestSignCertificate(t *testing.T) {
+	ctx := context.Background()
+	certifierWallet, certifierKey := newWallet(t)
+	subjectWallet, subjectKey := newWallet(t)
+
+	revocation := &transaction.Outpoint{Txid: chainhash.DoubleHashH([]byte("revocation")), Index: 0}
+	c := certifier.NewCertifier(certifierWallet, testType)
+	c.RevocationOutpoint = func(context.Context, wallet.StringBase64) (*transaction.Outpoint, error) {
+		return revocation, nil
+	}
+	c.ValidateFields = func(_ context.Context, subject *ec.PublicKey, _ wallet.StringBase64, fields map[wallet.CertificateFieldNameUnder50Bytes]string) error {
+		require.True(t, subject.IsEqual(subjectKey))
+		if fields["email"] == "" {
+			return errors.New("email is required")
+		}
+		return nil
+	}
+
+	req := newRequest(t, subjectWallet, certifierKey, map[wallet.CertificateFieldNameUnder50Bytes]string{
+		"email": "alice@example.com",
+		"name":  "Alice",
+	})
+	resp, err := c.SignCertificate(ctx, subjectKey, req)
+	require.NoError(t, err)
+
+	cert := resp.Certificate
+	require.NoError(t, cert.Verify(ctx))
+	require.True(t, cert.Certifier.IsEqual(certifierKey))
+	require.True(t, cert.Subject.IsEqual(subjectKey))
+	require.Equal(t, revocation, cert.RevocationOutpoint)
+
+	// The subject derives the same serial number from the nonces.
+	counterparty := wallet.Counterparty{Type: wallet.CounterpartyTypeOther, Counterparty: certifierKey}
+	serial, err := certifier.SerialNumber(ctx, subjectWallet, counterparty, req.ClientNonce, resp.ServerNonce)
+	require.NoError(t, err)
+	require.Equal(t, serial, cert.SerialNumber)
+
+	fields, err := certificates.DecryptFields(ctx, subjectWallet, req.MasterKeyring, cert.Fields, counterparty, false, "")
+	require.NoError(t, err)
+	require.Equal(t, "alice@example.com", fields["email"])
+}
+
+func TestSignCertificateRejectsInvalidRequests(t *testing.T) {
+	ctx := context.Background()
+	certifierWallet, certifierKey := newWallet(t)
+	subjectWallet, subjectKey := newWallet(t)
+
+	c := certifier.NewCertifier(certifierWallet, testType)
+	c.ValidateFields = func(context.Context, *ec.PublicKey, wallet.StringBase64, map[wallet.CertificateFieldNameUnder50Bytes]string) error {
+		return errors.New("rejected")
+	}
+	fields := map[wallet.CertificateFieldNameUnder50Bytes]string{"email": "alice@example.com"}
+
+	req := newRequest(t, subjectWallet, certifierKey, fields)
+	_, err := c.SignCertificate(ctx, subjectKey, req)
+	require.ErrorIs(t, err, certifier.ErrInvalidFields)
+
+	c.ValidateFields = nil
+
+	req = newRequest(t, subjectWallet, certifierKey, fields)
+	req.Type = wallet.StringBase64("mffUklUzxbHr65xLohn0hRL0Tq2GjW1GYF/OPfzqJ6A=")
+	_, err = c.SignCertificate(ctx, subjectKey, req)
+	require.ErrorIs(t, err, certifier.ErrUnsupportedType)
+
+	req = newRequest(t, subjectWallet, certifierKey, fields)
+	req.ClientNonce = ""
+	_, err = c.SignCertificate(ctx, subjectKey, req)
+	require.ErrorIs(t, err, certifier.ErrInvalidRequest)
+
+	req = newRequest(t, subjectWallet, certifierKey, fields)
+	delete(req.MasterKeyring, "email")
+	_, err = c.SignCertificate(ctx, subjectKey, req)
+	require.ErrorIs(t, err, certifier.ErrInvalidRequest)
+
+	// Fields encrypted for another certifier can't be decrypted.
+	_, otherKey := newWallet(t)
+	req = newRequest(t, subjectWallet, otherKey, fields)
+	_, err = c.SignCertificate(ctx, subjectKey, req)
+	require.ErrorIs(t, err, certifier.ErrInvalidFields)
+}
+
+func TestIssueCertificate(t *testing.T) {
+	ctx := context.Background()
+	certifierWallet, certifierKey := newWallet(t)
+	subjectWallet, subjectKey := newWallet(t)
+
+	c := certifier.NewCertifier(certifierWallet)
+	cert, err := c.IssueCertificate(ctx, subjectKey, testType, map[string]string{"name": "Alice"})
+	require.NoError(t, err)
+	require.NoError(t, cert.Verify(ctx))
+	require.Equal(t, &transaction.Outpoint{}, cert.RevocationOutpoint)
+
+	fields, err := certificates.DecryptFields(ctx, subjectWallet, cert.MasterKeyring, cert.Fields,
+		wallet.Counterparty{Type: wallet.CounterpartyTypeOther, Counterparty: certifierKey}, false, "")
+	require.NoError(t, err)
+	require.Equal(t, "Alice", fields["name"])
+}
+
+func TestCertifierChecksSchemas(t *testing.T) {
+	ctx := context.Background()
+	certifierWallet, _ := newWallet(t)
+	_, subjectKey := newWallet(t)
+
+	c := certifier.NewCertifier(certifierWallet)
+	_, err := c.IssueCertificate(ctx, subjectKey, certtypes.EmailCert, map[string]string{"email": "not an email"})
+	require.ErrorIs(t, err, certifier.ErrInvalidFields)
+	require.ErrorIs(t, err, certtypes.ErrInvalidField)
+
+	_, err = c.IssueCertificate(ctx, subjectKey, certtypes.EmailCert, map[string]string{"email": "alice@example.com"})
+	require.NoError(t, err)
+
+	// An empty registry disables the schema checks.
+	c.Schemas = certtypes.NewRegistry()
+	_, err = c.IssueCertificate(ctx, subjectKey, certtypes.EmailCert, map[string]string{"email": "not an email"})
+	require.NoError(t, err)
+}
+
+func TestSerialNumber(t *testing.T) {
+	ctx := context.Background()
+	w, _ := newWallet(t)
+	counterparty := wallet.Counterparty{Type: wallet.CounterpartyTypeSelf}
+
+	// The nonces are decoded separately, so padding in the client nonce is valid.
+	serial, err := certifier.SerialNumber(ctx, w, counterparty, "YQ==", "YmM=")
+	require.NoError(t, err)
+	hmac, err := w.CreateHMAC(ctx, wallet.CreateHMACArgs{
+		EncryptionArgs: wallet.EncryptionArgs{
+			ProtocolID:   certifier.IssuanceProtocol,
+			KeyID:        "YmM=YQ==",
+			Counterparty: counterparty,
+		},
+		Data: []byte("abc"),
+	}, "")
+	require.NoError(t, err)
+	require.Equal(t, wallet.StringBase64(base64.StdEncoding.EncodeToString(hmac.HMAC[:])), serial)
+
+	_, err = certifier.SerialNumber(ctx, w, counterparty, "YQ==", "not base64")
+	require.ErrorIs(t, err, certifier.ErrInvalidRequest)
+}
@@ -0,0 +1,54 @@
+package certifier
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/bsv-blockchain/go-sdk/auth/middleware"
+)
+
+// SignCertificatePath is the path subjects post issuance requests to, relative to
+// the certifier URL.
+const SignCertificatePath = "/signCertificate"
+
+// Handler serves issuance requests posted to SignCertificatePath. It must be
+// placed behind the auth middleware, which authenticates the subject.
+func (c *Certifier) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST "+SignCertificatePath, c.handleSignCertificate)
+	return mux
+}
+
+func (c *Certifier) handleSignCertificate(w http.ResponseWriter, r *http.Request) {
+	subject, ok := middleware.IdentityKeyFromContext(r.Context())
+	if !ok {
+		writeError(w, http.StatusUnauthorized, "the request must be authenticated")
+		return
+	}
+	var req SignCertificateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body: "+err.Error())
+		return
+	}
+	resp, err := c.SignCertificate(r.Context(), subject, &req)
+	switch {
+	case errors.Is(err, ErrInvalidRequest), errors.Is(err, ErrUnsupportedType), errors.Is(err, ErrInvalidFields):
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	case err != nil:
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(resp)
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(map[string]string{
+		"status":  "error",
+		"message": message,
+	})
+}
@@ -14,6 +14,7 @@ import (
 	"net/url"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/bsv-blockchain/go-sdk/auth"
@@ -135,6 +136,7 @@ func WithHttpClientTransport(transport http.RoundTripper) func(*AuthFetchOptions
 type AuthFetch struct {
 	sessionManager        auth.SessionManager
 	wallet                wallet.Interface
+	callbacksMu           sync.Mutex
 	callbacks             map[string]struct{ resolve, reject func(interface{}) }
 	certificatesReceived  []*certificates.VerifiableCertificate
 	requestedCertificates *utils.RequestedCertificateSet
@@ -325,6 +327,7 @@ func (a *AuthFetch) Fetch(ctx context.Context, urlStr string, config *Simplified
 		}
 
 		// Setup callback for this request
+		a.callbacksMu.Lock()
 		a.callbacks[requestNonceBase64] = struct {
 			resolve func(any)
 			reject  func(any)
@@ -361,6 +364,7 @@ func (a *AuthFetch) Fetch(ctx context.Context, urlStr string, config *Simplified
 				}
 			},
 		}
+		a.callbacksMu.Unlock()
 
 		// Set up listener for response
 		var listenerID int32
@@ -384,9 +388,12 @@ func (a *AuthFetch) Fetch(ctx context.Context, urlStr string, config *Simplified
 			}
 
 			// Resolve with the response
-			if callback, ok := a.callbacks[requestNonceBase64]; ok {
+			a.callbacksMu.Lock()
+			callback, ok := a.callbacks[requestNonceBase64]
+			delete(a.callbacks, requestNonceBase64)
+			a.callbacksMu.Unlock()
+			if ok {
 				callback.resolve(response)
-				delete(a.callbacks, requestNonceBase64)
 			}
 
 			return nil
@@ -0,0 +1,280 @@
+// Package middleware provides net/http middleware performing BRC-103 mutual
+// authentication over the BRC-104 HTTP transport. It answers handshake messages
+// posted to /.well-known/auth, verifies the signature of every authenticated
+// request, makes the caller's identity key available to downstream handlers, and
+// signs their responses so clients such as authhttp.AuthFetch can verify them.
+//
+// PaymentMiddleware can be placed behind it to require BRC-105 payments for
+// requests, answering 402 Payment Required with the terms of the payment.
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+
+	"github.com/bsv-blockchain/go-sdk/auth"
+	"github.com/bsv-blockchain/go-sdk/auth/authpayload"
+	"github.com/bsv-blockchain/go-sdk/auth/brc104"
+	"github.com/bsv-blockchain/go-sdk/auth/utils"
+	ec "github.com/bsv-blockchain/go-sdk/primitives/ec"
+	"github.com/bsv-blockchain/go-sdk/wallet"
+)
+
+// WellKnownAuthPath is the path handshake messages are posted to.
+const WellKnownAuthPath = "/.well-known/auth"
+
+// DefaultMaxBodySize limits the size of handshake messages and request bodies.
+const DefaultMaxBodySize = 10 << 20
+
+// Options configures the middleware.
+type Options struct {
+	// Wallet is the server's wallet, used to sign responses and verify requests.
+	Wallet wallet.Interface
+	// SessionManager stores authenticated sessions. Defaults to an in-memory manager.
+	SessionManager auth.SessionManager
+	// CertificatesToRequest are requested from clients during the handshake.
+	CertificatesToRequest *utils.RequestedCertificateSet
+	// AllowUnauthenticated passes requests without auth headers to the next handler
+	// instead of rejecting them. Handlers can tell them apart with IdentityKeyFromContext.
+	AllowUnauthenticated bool
+	// MaxBodySize limits request bodies, DefaultMaxBodySize when zero.
+	MaxBodySize int64
+	Logger      *slog.Logger
+}
+
+// Middleware authenticates HTTP requests with BRC-103/104.
+type Middleware struct {
+	peer                 *auth.Peer
+	transport            *serverTransport
+	allowUnauthenticated bool
+	maxBodySize          int64
+	logger               *slog.Logger
+}
+
+type identityKeyContextKey struct{}
+
+// IdentityKeyFromContext returns the authenticated identity key of the caller.
+func IdentityKeyFromContext(ctx context.Context) (*ec.PublicKey, bool) {
+	key, ok := ctx.Value(identityKeyContextKey{}).(*ec.PublicKey)
+	return key, ok && key != nil
+}
+
+// New creates the middleware.
+func New(opts Options) (*Middleware, error) {
+	if opts.Wallet == nil {
+		return nil, errors.New("wallet is required for auth middleware")
+	}
+	logger := opts.Logger
+	if logger == nil {
+		logger = slog.Default()
+	}
+	maxBodySize := opts.MaxBodySize
+	if maxBodySize <= 0 {
+		maxBodySize = DefaultMaxBodySize
+	}
+
+	transport := &serverTransport{}
+	autoPersistLastSession := false
+	peer := auth.NewPeer(&auth.PeerOptions{
+		Wallet:                 opts.Wallet,
+		Transport:              transport,
+		SessionManager:         opts.SessionManager,
+		CertificatesToRequest:  opts.CertificatesToRequest,
+		AutoPersistLastSession: &autoPersistLastSession,
+		Logger:                 logger,
+	})
+
+	return &Middleware{
+		peer:                 peer,
+		transport:            transport,
+		allowUnauthenticated: opts.AllowUnauthenticated,
+		maxBodySize:          maxBodySize,
+		logger:               logger.With("component", "AuthMiddleware"),
+	}, nil
+}
+
+// Peer returns the peer used by the middleware, e.g. to listen for received certificates.
+func (m *Middleware) Peer() *auth.Peer {
+	return m.peer
+}
+
+// Handler wraps next so that it only receives authenticated requests.
+func (m *Middleware) Handler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.Body = http.MaxBytesReader(w, r.Body, m.maxBodySize)
+		if r.URL.Path == WellKnownAuthPath && r.Method == http.MethodPost {
+			m.handleHandshake(w, r)
+			return
+		}
+		if r.Header.Get(brc104.HeaderIdentityKey) == "" && r.Header.Get(brc104.HeaderRequestID) == "" {
+			if m.allowUnauthenticated {
+				next.ServeHTTP(w, r)
+				return
+			}
+			writeError(w, http.StatusUnauthorized, "mutual authentication is required")
+			return
+		}
+		m.handleGeneral(w, r, next)
+	})
+}
+
+// handleHandshake passes a non-general message to the peer, replying with
+// whatever message the peer sends back.
+func (m *Middleware) handleHandshake(w http.ResponseWriter, r *http.Request) {
+	var message auth.AuthMessage
+	if err := json.NewDecoder(r.Body).Decode(&message); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid auth message")
+		return
+	}
+	if message.MessageType == auth.MessageTypeGeneral {
+		writeError(w, http.StatusBadRequest, "general messages must be sent as http requests")
+		return
+	}
+
+	replied := false
+	ctx := withResponder(r.Context(), func(reply *auth.AuthMessage) error {
+		replied = true
+		w.Header().Set("Content-Type", "application/json")
+		return json.NewEncoder(w).Encode(reply)
+	})
+	if err := m.transport.receive(ctx, &message); err != nil {
+		m.logger.WarnContext(ctx, "Rejected auth message", "messageType", message.MessageType, "error", err)
+		if !replied {
+			writeError(w, http.StatusUnauthorized, err.Error())
+		}
+		return
+	}
+	if !replied {
+		w.WriteHeader(http.StatusOK)
+	}
+}
+
+// handleGeneral verifies a signed request, runs next with the caller's identity in
+// the context and signs the buffered response.
+func (m *Middleware) handleGeneral(w http.ResponseWriter, r *http.Request, next http.Handler) {
+	message, requestID, err := m.messageFromRequest(r)
+	if err != nil {
+		writeError(w, http.StatusUnauthorized, err.Error())
+		return
+	}
+
+	ctx := r.Context()
+	if err := m.transport.receive(ctx, message); err != nil {
+		m.logger.WarnContext(ctx, "Rejected authenticated request", "path", r.URL.Path, "error", err)
+		writeError(w, http.StatusUnauthorized, "request authentication failed")
+		return
+	}
+
+	recorder := newResponseRecorder()
+	ctx = context.WithValue(ctx, identityKeyContextKey{}, message.IdentityKey)
+	next.ServeHTTP(recorder, r.WithContext(ctx))
+
+	payload, err := authpayload.FromResponse(requestID, authpayload.SimplifiedHttpResponse{
+		StatusCode: recorder.status,
+		Header:     recorder.header,
+		Body:       recorder.body.Bytes(),
+	})
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to prepare response")
+		return
+	}
+
+	ctx = withResponder(ctx, func(reply *auth.AuthMessage) error {
+		header := w.Header()
+		for name, values := range recorder.header {
+			header[name] = values
+		}
+		header.Set(brc104.HeaderVersion, reply.Version)
+		header.Set(brc104.HeaderMessageType, string(reply.MessageType))
+		header.Set(brc104.HeaderIdentityKey, reply.IdentityKey.ToDERHex())
+		header.Set(brc104.HeaderNonce, reply.Nonce)
+		header.Set(brc104.HeaderYourNonce, reply.YourNonce)
+		header.Set(brc104.HeaderSignature, hex.EncodeToString(reply.Signature))
+		header.Set(brc104.HeaderRequestID, base64.StdEncoding.EncodeToString(requestID))
+		w.WriteHeader(recorder.status)
+		_, err := w.Write(recorder.body.Bytes())
+		return err
+	})
+	if err := m.peer.ToPeer(ctx, payload, message.IdentityKey, 0); err != nil {
+		m.logger.ErrorContext(ctx, "Failed to sign response", "path", r.URL.Path, "error", err)
+		writeError(w, http.StatusInternalServerError, "failed to sign response")
+	}
+}
+
+func (m *Middleware) messageFromRequest(r *http.Request) (*auth.AuthMessage, []byte, error) {
+	identityKey, err := ec.PublicKeyFromString(r.Header.Get(brc104.HeaderIdentityKey))
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid %s header", brc104.HeaderIdentityKey)
+	}
+	signature, err := hex.DecodeString(r.Header.Get(brc104.HeaderSignature))
+	if err != nil || len(signature) == 0 {
+		return nil, nil, fmt.Errorf("invalid %s header", brc104.HeaderSignature)
+	}
+	requestID, err := base64.StdEncoding.DecodeString(r.Header.Get(brc104.HeaderRequestID))
+	if err != nil || len(requestID) != brc104.RequestIDLength {
+		return nil, nil, fmt.Errorf("invalid %s header", brc104.HeaderRequestID)
+	}
+
+	payload, err := authpayload.FromHTTPRequest(requestID, r)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid request: %w", err)
+	}
+
+	return &auth.AuthMessage{
+		Version:     r.Header.Get(brc104.HeaderVersion),
+		MessageType: auth.MessageTypeGeneral,
+		IdentityKey: identityKey,
+		Nonce:       r.Header.Get(brc104.HeaderNonce),
+		YourNonce:   r.Header.Get(brc104.HeaderYourNonce),
+		Signature:   signature,
+		Payload:     payload,
+	}, requestID, nil
+}
+
+var _ http.ResponseWriter = (*responseRecorder)(nil)
+
+// responseRecorder buffers the downstream handler's response so it can be signed
+// before being written.
+type responseRecorder struct {
+	header      http.Header
+	body        bytes.Buffer
+	status      int
+	wroteHeader bool
+}
+
+func newResponseRecorder() *responseRecorder {
+	return &responseRecorder{header: make(http.Header), status: http.StatusOK}
+}
+
+func (r *responseRecorder) Header() http.Header {
+	return r.header
+}
+
+func (r *responseRecorder) WriteHeader(status int) {
+	if r.wroteHeader {
+		return
+	}
+	r.wroteHeader = true
+	r.status = status
+}
+
+func (r *responseRecorder) Write(b []byte) (int, error) {
+	r.WriteHeader(http.StatusOK)
+	return r.body.Write(b)
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(map[string]string{
+		"status":  "error",
+		"message": message,
+	})
+}
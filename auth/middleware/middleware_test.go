@@ -0,0 +1,117 @@
+package middleware_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	authhttp "github.com/bsv-blockchain/go-sdk/auth/clients/authhttp"
+	"github.com/bsv-blockchain/go-sdk/auth/middleware"
+	"github.com/bsv-blockchain/go-sdk/wallet"
+	"github.com/stretchr/testify/require"
+)
+
+func newServer(t *testing.T, opts middleware.Options) *httptest.Server {
+	t.Helper()
+	m, err := middleware.New(opts)
+	require.NoError(t, err)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/whoami", func(w http.ResponseWriter, r *http.Request) {
+		key, ok := middleware.IdentityKeyFromContext(r.Context())
+		if !ok {
+			w.WriteHeader(http.StatusTeapot)
+			_, _ = w.Write([]byte("anonymous"))
+			return
+		}
+		w.Header().Set("X-Bsv-Custom", "yes")
+		_, _ = w.Write([]byte(key.ToDERHex()))
+	})
+	mux.HandleFunc("/echo", func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write(body)
+	})
+
+	server := httptest.NewServer(m.Handler(mux))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestMiddlewareAuthenticatesRequests(t *testing.T) {
+	serverWallet := wallet.NewTestWalletForRandomKey(t)
+	clientWallet := wallet.NewTestWalletForRandomKey(t)
+	server := newServer(t, middleware.Options{Wallet: serverWallet})
+
+	clientKey, err := clientWallet.GetPublicKey(t.Context(), wallet.GetPublicKeyArgs{IdentityKey: true}, "")
+	require.NoError(t, err)
+
+	client := authhttp.New(clientWallet, authhttp.WithoutLogging())
+
+	res, err := client.Fetch(t.Context(), server.URL+"/whoami", &authhttp.SimplifiedFetchRequestOptions{Method: http.MethodGet})
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, res.StatusCode)
+	body, err := io.ReadAll(res.Body)
+	require.NoError(t, err)
+	require.Equal(t, clientKey.PublicKey.ToDERHex(), string(body))
+	require.Equal(t, "yes", res.Header.Get("X-Bsv-Custom"))
+
+	// The session is reused for subsequent requests.
+	res, err = client.Fetch(t.Context(), server.URL+"/echo", &authhttp.SimplifiedFetchRequestOptions{
+		Method:  http.MethodPost,
+		Headers: map[string]string{"Content-Type": "application/json"},
+		Body:    []byte(`{"hello":"world"}`),
+	})
+	require.NoError(t, err)
+	require.Equal(t, http.StatusCreated, res.StatusCode)
+	body, err = io.ReadAll(res.Body)
+	require.NoError(t, err)
+	require.JSONEq(t, `{"hello":"world"}`, string(body))
+}
+
+func TestMiddlewareRejectsUnauthenticatedRequests(t *testing.T) {
+	server := newServer(t, middleware.Options{Wallet: wallet.NewTestWalletForRandomKey(t)})
+
+	res, err := http.Get(server.URL + "/whoami")
+	require.NoError(t, err)
+	defer res.Body.Close()
+	require.Equal(t, http.StatusUnauthorized, res.StatusCode)
+}
+
+func TestMiddlewareRejectsForgedSignatures(t *testing.T) {
+	server := newServer(t, middleware.Options{Wallet: wallet.NewTestWalletForRandomKey(t)})
+	clientWallet := wallet.NewTestWalletForRandomKey(t)
+	clientKey, err := clientWallet.GetPublicKey(t.Context(), wallet.GetPublicKeyArgs{IdentityKey: true}, "")
+	require.NoError(t, err)
+
+	req, err := http.NewRequest(http.MethodGet, server.URL+"/whoami", nil)
+	require.NoError(t, err)
+	req.Header.Set("x-bsv-auth-version", "0.1")
+	req.Header.Set("x-bsv-auth-identity-key", clientKey.PublicKey.ToDERHex())
+	req.Header.Set("x-bsv-auth-nonce", "bm9uY2U=")
+	req.Header.Set("x-bsv-auth-your-nonce", "bm9uY2U=")
+	req.Header.Set("x-bsv-auth-signature", "3006020101020101")
+	req.Header.Set("x-bsv-auth-request-id", "AAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA=")
+
+	res, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer res.Body.Close()
+	require.Equal(t, http.StatusUnauthorized, res.StatusCode)
+}
+
+func TestMiddlewareAllowUnauthenticated(t *testing.T) {
+	server := newServer(t, middleware.Options{
+		Wallet:               wallet.NewTestWalletForRandomKey(t),
+		AllowUnauthenticated: true,
+	})
+
+	res, err := http.Get(server.URL + "/whoami")
+	require.NoError(t, err)
+	defer res.Body.Close()
+	require.Equal(t, http.StatusTeapot, res.StatusCode)
+	body, err := io.ReadAll(res.Body)
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(string(body), "anonymous"))
+}
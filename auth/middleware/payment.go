@@ -0,0 +1,214 @@
+package middleware
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/bsv-blockchain/go-sdk/auth/utils"
+	"github.com/bsv-blockchain/go-sdk/payments/brc29"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+	"github.com/bsv-blockchain/go-sdk/wallet"
+)
+
+// PaymentVersion is the version of the BRC-105 payment protocol spoken by the middleware.
+const PaymentVersion = "1.0"
+
+// BRC-105 HTTP header names.
+const (
+	HeaderPayment                 = "x-bsv-payment"
+	HeaderPaymentVersion          = "x-bsv-payment-version"
+	HeaderPaymentSatoshisRequired = "x-bsv-payment-satoshis-required"
+	HeaderPaymentDerivationPrefix = "x-bsv-payment-derivation-prefix"
+	HeaderPaymentSatoshisPaid     = "x-bsv-payment-satoshis-paid"
+)
+
+// Error codes returned in the body of rejected payment requests.
+const (
+	ErrCodePaymentRequired         = "ERR_PAYMENT_REQUIRED"
+	ErrCodeMalformedPayment        = "ERR_MALFORMED_PAYMENT"
+	ErrCodeInvalidDerivationPrefix = "ERR_INVALID_DERIVATION_PREFIX"
+	ErrCodeInsufficientPayment     = "ERR_INSUFFICIENT_PAYMENT"
+	ErrCodePaymentFailed           = "ERR_PAYMENT_FAILED"
+	ErrCodeServerMisconfigured     = "ERR_SERVER_MISCONFIGURED"
+	ErrCodePaymentInternal         = "ERR_PAYMENT_INTERNAL"
+)
+
+// PriceFunc returns the price of a request in satoshis. A price of zero lets the
+// request through without payment.
+type PriceFunc func(r *http.Request) (uint64, error)
+
+// PaymentOptions configures the payment middleware.
+type PaymentOptions struct {
+	// Wallet receives the payments. It is usually the same wallet the auth middleware uses.
+	Wallet wallet.Interface
+	// Price calculates the price of each request.
+	Price  PriceFunc
+	Logger *slog.Logger
+}
+
+// Payment describes the payment made for a request.
+type Payment struct {
+	SatoshisPaid uint64
+	Accepted     bool
+	// Tx is the BEEF encoded payment transaction, nil for free requests.
+	Tx []byte
+}
+
+// PaymentMiddleware requires BRC-105 payments for requests before passing them on.
+// It must be placed behind the auth middleware, which provides the payer's identity
+// and signs the 402 responses so clients can trust the payment terms.
+type PaymentMiddleware struct {
+	wallet wallet.Interface
+	price  PriceFunc
+	logger *slog.Logger
+}
+
+type paymentContextKey struct{}
+
+// PaymentFromContext returns the payment made for the request.
+func PaymentFromContext(ctx context.Context) (*Payment, bool) {
+	payment, ok := ctx.Value(paymentContextKey{}).(*Payment)
+	return payment, ok && payment != nil
+}
+
+// paymentHeader is the JSON content of the x-bsv-payment request header.
+type paymentHeader struct {
+	DerivationPrefix string `json:"derivationPrefix"`
+	DerivationSuffix string `json:"derivationSuffix"`
+	Transaction      string `json:"transaction"`
+}
+
+// NewPaymentMiddleware creates the payment middleware.
+func NewPaymentMiddleware(opts PaymentOptions) (*PaymentMiddleware, error) {
+	if opts.Wallet == nil {
+		return nil, errors.New("wallet is required for payment middleware")
+	}
+	if opts.Price == nil {
+		return nil, errors.New("price function is required for payment middleware")
+	}
+	logger := opts.Logger
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return &PaymentMiddleware{
+		wallet: opts.Wallet,
+		price:  opts.Price,
+		logger: logger.With("component", "PaymentMiddleware"),
+	}, nil
+}
+
+// Handler wraps next so that it only receives paid requests.
+func (m *PaymentMiddleware) Handler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		identityKey, ok := IdentityKeyFromContext(ctx)
+		if !ok {
+			writePaymentError(w, http.StatusInternalServerError, ErrCodeServerMisconfigured,
+				"the payment middleware must run behind the auth middleware", nil)
+			return
+		}
+
+		price, err := m.price(r)
+		if err != nil {
+			m.logger.ErrorContext(ctx, "Failed to calculate request price", "path", r.URL.Path, "error", err)
+			writePaymentError(w, http.StatusInternalServerError, ErrCodePaymentInternal, "failed to calculate request price", nil)
+			return
+		}
+		if price == 0 {
+			next.ServeHTTP(w, r.WithContext(context.WithValue(ctx, paymentContextKey{}, &Payment{Accepted: true})))
+			return
+		}
+
+		raw := r.Header.Get(HeaderPayment)
+		if raw == "" {
+			m.requirePayment(w, r, price)
+			return
+		}
+
+		var header paymentHeader
+		if err := json.Unmarshal([]byte(raw), &header); err != nil {
+			writePaymentError(w, http.StatusBadRequest, ErrCodeMalformedPayment, "invalid "+HeaderPayment+" header", nil)
+			return
+		}
+		prefix, errPrefix := base64.StdEncoding.DecodeString(header.DerivationPrefix)
+		suffix, errSuffix := base64.StdEncoding.DecodeString(header.DerivationSuffix)
+		tx, errTx := base64.StdEncoding.DecodeString(header.Transaction)
+		if errPrefix != nil || errSuffix != nil || errTx != nil || len(suffix) == 0 || len(tx) == 0 {
+			writePaymentError(w, http.StatusBadRequest, ErrCodeMalformedPayment, "invalid "+HeaderPayment+" header", nil)
+			return
+		}
+
+		valid, err := utils.VerifyNonce(ctx, header.DerivationPrefix, m.wallet, wallet.Counterparty{Type: wallet.CounterpartyTypeSelf})
+		if err != nil || !valid {
+			writePaymentError(w, http.StatusBadRequest, ErrCodeInvalidDerivationPrefix, "the derivation prefix was not issued by this server", nil)
+			return
+		}
+
+		parsed, err := transaction.NewTransactionFromBEEF(tx)
+		// An Atomic BEEF without its subject transaction parses to nil.
+		if err != nil || parsed == nil || len(parsed.Outputs) == 0 {
+			writePaymentError(w, http.StatusBadRequest, ErrCodeMalformedPayment, "invalid payment transaction", nil)
+			return
+		}
+		if paid := parsed.Outputs[0].Satoshis; paid < price {
+			writePaymentError(w, http.StatusBadRequest, ErrCodeInsufficientPayment, "payment is below the price of the request",
+				map[string]any{"satoshisRequired": price, "satoshisPaid": paid})
+			return
+		}
+
+		remittance := &wallet.Payment{
+			DerivationPrefix:  prefix,
+			DerivationSuffix:  suffix,
+			SenderIdentityKey: identityKey,
+		}
+		result, err := brc29.Internalize(ctx, m.wallet, tx, 0, remittance, "Payment for request", "")
+		if err != nil || !result.Accepted {
+			m.logger.WarnContext(ctx, "Rejected payment", "path", r.URL.Path, "error", err)
+			writePaymentError(w, http.StatusBadRequest, ErrCodePaymentFailed, "payment was not accepted", nil)
+			return
+		}
+
+		payment := &Payment{
+			SatoshisPaid: parsed.Outputs[0].Satoshis,
+			Accepted:     true,
+			Tx:           tx,
+		}
+		w.Header().Set(HeaderPaymentSatoshisPaid, strconv.FormatUint(payment.SatoshisPaid, 10))
+		next.ServeHTTP(w, r.WithContext(context.WithValue(ctx, paymentContextKey{}, payment)))
+	})
+}
+
+// requirePayment answers with 402 and the terms the client needs to build a payment.
+func (m *PaymentMiddleware) requirePayment(w http.ResponseWriter, r *http.Request, price uint64) {
+	prefix, err := utils.CreateNonce(r.Context(), m.wallet, wallet.Counterparty{Type: wallet.CounterpartyTypeSelf})
+	if err != nil {
+		m.logger.ErrorContext(r.Context(), "Failed to create derivation prefix", "error", err)
+		writePaymentError(w, http.StatusInternalServerError, ErrCodePaymentInternal, "failed to create derivation prefix", nil)
+		return
+	}
+	header := w.Header()
+	header.Set(HeaderPaymentVersion, PaymentVersion)
+	header.Set(HeaderPaymentSatoshisRequired, strconv.FormatUint(price, 10))
+	header.Set(HeaderPaymentDerivationPrefix, prefix)
+	writePaymentError(w, http.StatusPaymentRequired, ErrCodePaymentRequired, "a BSV payment is required to complete this request",
+		map[string]any{"satoshisRequired": price})
+}
+
+func writePaymentError(w http.ResponseWriter, status int, code string, description string, extra map[string]any) {
+	body := map[string]any{
+		"status":      "error",
+		"code":        code,
+		"description": description,
+	}
+	for k, v := range extra {
+		body[k] = v
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
@@ -0,0 +1,174 @@
+package middleware_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	authhttp "github.com/bsv-blockchain/go-sdk/auth/clients/authhttp"
+	"github.com/bsv-blockchain/go-sdk/auth/middleware"
+	"github.com/bsv-blockchain/go-sdk/script"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+	"github.com/bsv-blockchain/go-sdk/wallet"
+	"github.com/stretchr/testify/require"
+)
+
+const testPrice = 100
+
+func newPaidServer(t *testing.T, serverWallet wallet.Interface) *httptest.Server {
+	t.Helper()
+	auth, err := middleware.New(middleware.Options{Wallet: serverWallet})
+	require.NoError(t, err)
+	payments, err := middleware.NewPaymentMiddleware(middleware.PaymentOptions{
+		Wallet: serverWallet,
+		Price: func(r *http.Request) (uint64, error) {
+			if r.URL.Path == "/free" {
+				return 0, nil
+			}
+			return testPrice, nil
+		},
+	})
+	require.NoError(t, err)
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		payment, ok := middleware.PaymentFromContext(r.Context())
+		if !ok {
+			w.WriteHeader(http.StatusTeapot)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]uint64{"paid": payment.SatoshisPaid})
+	})
+
+	server := httptest.NewServer(auth.Handler(payments.Handler(handler)))
+	t.Cleanup(server.Close)
+	return server
+}
+
+// payingClientWallet builds the payment transaction requested by AuthFetch.
+func payingClientWallet(t *testing.T) *wallet.TestWallet {
+	clientWallet := wallet.NewTestWalletForRandomKey(t)
+	clientWallet.OnCreateAction().Do(func(_ context.Context, args wallet.CreateActionArgs, _ string) (*wallet.CreateActionResult, error) {
+		tx := transaction.NewTransaction()
+		for _, output := range args.Outputs {
+			tx.AddOutput(&transaction.TransactionOutput{
+				Satoshis:      output.Satoshis,
+				LockingScript: script.NewFromBytes(output.LockingScript),
+			})
+		}
+		beef, err := tx.AtomicBEEF(true)
+		if err != nil {
+			return nil, err
+		}
+		return &wallet.CreateActionResult{Tx: beef}, nil
+	})
+	return clientWallet
+}
+
+func TestPaymentMiddlewareRejectsMissingSubjectTransaction(t *testing.T) {
+	server := newPaidServer(t, wallet.NewTestWalletForRandomKey(t))
+
+	// The Atomic BEEF names a subject transaction that is not in the bundle.
+	clientWallet := wallet.NewTestWalletForRandomKey(t)
+	clientWallet.OnCreateAction().Do(func(_ context.Context, args wallet.CreateActionArgs, _ string) (*wallet.CreateActionResult, error) {
+		tx := transaction.NewTransaction()
+		tx.AddOutput(&transaction.TransactionOutput{
+			Satoshis:      args.Outputs[0].Satoshis,
+			LockingScript: script.NewFromBytes(args.Outputs[0].LockingScript),
+		})
+		beef, err := tx.AtomicBEEF(true)
+		if err != nil {
+			return nil, err
+		}
+		copy(beef[4:36], make([]byte, 32))
+		return &wallet.CreateActionResult{Tx: beef}, nil
+	})
+
+	client := authhttp.New(clientWallet, authhttp.WithoutLogging())
+	res, err := client.Fetch(t.Context(), server.URL+"/paid", &authhttp.SimplifiedFetchRequestOptions{Method: http.MethodGet})
+	require.NoError(t, err)
+	require.Equal(t, http.StatusBadRequest, res.StatusCode)
+	body, err := io.ReadAll(res.Body)
+	require.NoError(t, err)
+	require.Contains(t, string(body), middleware.ErrCodeMalformedPayment)
+}
+
+func TestPaymentMiddlewarePaidRequest(t *testing.T) {
+	serverWallet := wallet.NewTestWalletForRandomKey(t)
+	var internalized *wallet.InternalizeActionArgs
+	serverWallet.OnInternalizeAction().Do(func(_ context.Context, args wallet.InternalizeActionArgs, _ string) (*wallet.InternalizeActionResult, error) {
+		internalized = &args
+		return &wallet.InternalizeActionResult{Accepted: true}, nil
+	})
+	server := newPaidServer(t, serverWallet)
+
+	client := authhttp.New(payingClientWallet(t), authhttp.WithoutLogging())
+	res, err := client.Fetch(t.Context(), server.URL+"/paid", &authhttp.SimplifiedFetchRequestOptions{Method: http.MethodGet})
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, res.StatusCode)
+	body, err := io.ReadAll(res.Body)
+	require.NoError(t, err)
+	require.JSONEq(t, `{"paid":100}`, string(body))
+	require.Equal(t, "100", res.Header.Get(middleware.HeaderPaymentSatoshisPaid))
+
+	require.NotNil(t, internalized, "payment should be internalized into the server wallet")
+	require.Len(t, internalized.Outputs, 1)
+	require.Equal(t, wallet.InternalizeProtocolWalletPayment, internalized.Outputs[0].Protocol)
+}
+
+func TestPaymentMiddlewareFreeRequest(t *testing.T) {
+	server := newPaidServer(t, wallet.NewTestWalletForRandomKey(t))
+
+	client := authhttp.New(wallet.NewTestWalletForRandomKey(t), authhttp.WithoutLogging())
+	res, err := client.Fetch(t.Context(), server.URL+"/free", &authhttp.SimplifiedFetchRequestOptions{Method: http.MethodGet})
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, res.StatusCode)
+	body, err := io.ReadAll(res.Body)
+	require.NoError(t, err)
+	require.JSONEq(t, `{"paid":0}`, string(body))
+}
+
+func TestPaymentMiddlewareRejectsPayments(t *testing.T) {
+	serverWallet := wallet.NewTestWalletForRandomKey(t)
+	payments, err := middleware.NewPaymentMiddleware(middleware.PaymentOptions{
+		Wallet: serverWallet,
+		Price:  func(*http.Request) (uint64, error) { return testPrice, nil },
+	})
+	require.NoError(t, err)
+	handler := payments.Handler(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+
+	t.Run("requires the auth middleware", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+		require.Equal(t, http.StatusInternalServerError, rec.Code)
+		require.Contains(t, rec.Body.String(), middleware.ErrCodeServerMisconfigured)
+	})
+
+	t.Run("requires constructor arguments", func(t *testing.T) {
+		_, err := middleware.NewPaymentMiddleware(middleware.PaymentOptions{Wallet: serverWallet})
+		require.Error(t, err)
+		_, err = middleware.NewPaymentMiddleware(middleware.PaymentOptions{Price: func(*http.Request) (uint64, error) { return 0, nil }})
+		require.Error(t, err)
+	})
+}
+
+func TestPaymentMiddlewareRequiresPayment(t *testing.T) {
+	server := newPaidServer(t, wallet.NewTestWalletForRandomKey(t))
+
+	// A client that cannot pay sees the server's payment terms on the 402 response.
+	clientWallet := wallet.NewTestWalletForRandomKey(t)
+	var satoshis uint64
+	clientWallet.OnCreateAction().Do(func(_ context.Context, args wallet.CreateActionArgs, _ string) (*wallet.CreateActionResult, error) {
+		satoshis = args.Outputs[0].Satoshis
+		return nil, context.Canceled
+	})
+
+	client := authhttp.New(clientWallet, authhttp.WithoutLogging())
+	_, err := client.Fetch(t.Context(), server.URL+"/paid", &authhttp.SimplifiedFetchRequestOptions{Method: http.MethodGet})
+	require.Error(t, err)
+	require.Equal(t, uint64(testPrice), satoshis)
+}
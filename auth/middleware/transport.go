@@ -0,0 +1,67 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/bsv-blockchain/go-sdk/auth"
+)
+
+// responderKey is the context key of the function writing the peer's reply to the
+// HTTP request being served.
+type responderKey struct{}
+
+type responder func(message *auth.AuthMessage) error
+
+func withResponder(ctx context.Context, r responder) context.Context {
+	return context.WithValue(ctx, responderKey{}, r)
+}
+
+// serverTransport is the auth.Transport used by the middleware's peer. Incoming
+// messages come from HTTP requests, and every message the peer sends is a reply to
+// the request being handled, so Send writes to the responder carried in the context.
+type serverTransport struct {
+	mu     sync.RWMutex
+	onData func(context.Context, *auth.AuthMessage) error
+}
+
+var _ auth.Transport = (*serverTransport)(nil)
+
+// OnData registers the peer's handler for incoming messages.
+func (t *serverTransport) OnData(callback func(context.Context, *auth.AuthMessage) error) error {
+	if callback == nil {
+		return errors.New("callback cannot be nil")
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.onData = callback
+	return nil
+}
+
+// GetRegisteredOnData returns the peer's handler for incoming messages.
+func (t *serverTransport) GetRegisteredOnData() (func(context.Context, *auth.AuthMessage) error, error) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	if t.onData == nil {
+		return nil, errors.New("no handlers registered")
+	}
+	return t.onData, nil
+}
+
+// Send writes the message as the reply to the request carried by ctx.
+func (t *serverTransport) Send(ctx context.Context, message *auth.AuthMessage) error {
+	r, ok := ctx.Value(responderKey{}).(responder)
+	if !ok {
+		return errors.New("no pending http request to respond to")
+	}
+	return r(message)
+}
+
+func (t *serverTransport) receive(ctx context.Context, message *auth.AuthMessage) error {
+	onData, err := t.GetRegisteredOnData()
+	if err != nil {
+		return err
+	}
+	return onData(ctx, message)
+}
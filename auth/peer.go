@@ -394,7 +394,13 @@ func (p *Peer) initiateHandshake(ctx context.Context, peerIdentityKey *ec.Public
 	case <-responseChan:
 		close(responseChan)
 		p.StopListeningForInitialResponse(callbackID)
-		return session, nil
+		// Reload the session, as the session manager may hand out copies rather than
+		// the instance updated by handleInitialResponse.
+		updated, err := p.sessionManager.GetSession(sessionNonce)
+		if err != nil || updated == nil {
+			return nil, ErrSessionNotFound
+		}
+		return updated, nil
 	case <-ctxWithTimeout.Done():
 		p.StopListeningForInitialResponse(callbackID)
 		return nil, ErrTimeout
@@ -672,6 +678,16 @@ func (p *Peer) handleInitialResponse(ctx context.Context, message *AuthMessage,
 
 	p.lastInteractedWithPeer = message.IdentityKey
 
+	// The peer might also request certificates from us. They are sent before
+	// the handshake is reported complete, so that they reach the peer ahead of
+	// any general message, which it rejects until it has them.
+	if len(message.RequestedCertificates.Certifiers) > 0 || len(message.RequestedCertificates.CertificateTypes) > 0 {
+		err = p.sendCertificates(ctx, message)
+		if err != nil {
+			return NewAuthError("failed to send requested certificates", err)
+		}
+	}
+
 	for id, callback := range p.getInitialResponseCallbacks() {
 		if callback.SessionNonce == session.SessionNonce {
 			// Call the initial response callback with the peer's nonce
@@ -683,14 +699,6 @@ func (p *Peer) handleInitialResponse(ctx context.Context, message *AuthMessage,
 		}
 	}
 
-	// The peer might also request certificates from us
-	if len(message.RequestedCertificates.Certifiers) > 0 || len(message.RequestedCertificates.CertificateTypes) > 0 {
-		err = p.sendCertificates(ctx, message)
-		if err != nil {
-			return NewAuthError("failed to send requested certificates", err)
-		}
-	}
-
 	return nil
 }
 
@@ -0,0 +1,236 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// DefaultRedisKeyPrefix is the default prefix of all keys written by RedisSessionStore.
+const DefaultRedisKeyPrefix = "bsv:auth:"
+
+// RedisClient executes a single Redis command and returns its reply.
+// It matches the generic command method offered by common Go Redis clients, so no
+// particular client library is required. With go-redis, for example:
+//
+//	auth.RedisClientFunc(func(ctx context.Context, args ...any) (any, error) {
+//		return rdb.Do(ctx, args...).Result()
+//	})
+//
+// Bulk string replies may be returned as string or []byte and arrays as []any.
+type RedisClient interface {
+	Do(ctx context.Context, args ...any) (any, error)
+}
+
+// RedisClientFunc adapts a function to the RedisClient interface.
+type RedisClientFunc func(ctx context.Context, args ...any) (any, error)
+
+// Do calls f(ctx, args...).
+func (f RedisClientFunc) Do(ctx context.Context, args ...any) (any, error) {
+	return f(ctx, args...)
+}
+
+// RedisSessionStoreOptions configures a RedisSessionStore.
+type RedisSessionStoreOptions struct {
+	Client    RedisClient
+	KeyPrefix string        // Optional, defaults to DefaultRedisKeyPrefix
+	TTL       time.Duration // Optional, defaults to DefaultSessionTTL
+}
+
+// ensure that RedisSessionStore is implementing SessionStore
+var _ SessionStore = (*RedisSessionStore)(nil)
+
+// RedisSessionStore is a SessionStore backed by Redis. Each session is stored as JSON
+// under its nonce with a TTL, and a set per identity key indexes the session nonces.
+type RedisSessionStore struct {
+	client RedisClient
+	prefix string
+	ttl    time.Duration
+}
+
+// NewRedisSessionStore creates a Redis-backed session store.
+func NewRedisSessionStore(opts RedisSessionStoreOptions) (*RedisSessionStore, error) {
+	if opts.Client == nil {
+		return nil, errors.New("redis client is required")
+	}
+	prefix := opts.KeyPrefix
+	if prefix == "" {
+		prefix = DefaultRedisKeyPrefix
+	}
+	ttl := opts.TTL
+	if ttl <= 0 {
+		ttl = DefaultSessionTTL
+	}
+	return &RedisSessionStore{client: opts.Client, prefix: prefix, ttl: ttl}, nil
+}
+
+// Save stores the session and refreshes its expiry.
+func (s *RedisSessionStore) Save(ctx context.Context, session *PeerSession) error {
+	if session.SessionNonce == "" {
+		return errors.New("invalid session: sessionNonce is required")
+	}
+	data, err := json.Marshal(session)
+	if err != nil {
+		return fmt.Errorf("failed to marshal session: %w", err)
+	}
+	ttl := strconv.FormatInt(s.ttl.Milliseconds(), 10)
+	if _, err = s.client.Do(ctx, "SET", s.sessionKey(session.SessionNonce), string(data), "PX", ttl); err != nil {
+		return fmt.Errorf("failed to save session: %w", err)
+	}
+	if session.PeerIdentityKey == nil {
+		return nil
+	}
+	indexKey := s.identityKey(session.PeerIdentityKey.ToDERHex())
+	if _, err = s.client.Do(ctx, "SADD", indexKey, session.SessionNonce); err != nil {
+		return fmt.Errorf("failed to index session: %w", err)
+	}
+	if _, err = s.client.Do(ctx, "PEXPIRE", indexKey, ttl); err != nil {
+		return fmt.Errorf("failed to set session index expiry: %w", err)
+	}
+	return nil
+}
+
+// Load returns the session with the given nonce.
+func (s *RedisSessionStore) Load(ctx context.Context, sessionNonce string) (*PeerSession, error) {
+	sessions, err := s.load(ctx, []string{sessionNonce})
+	if err != nil {
+		return nil, err
+	}
+	if sessions[0] == nil {
+		return nil, ErrSessionNotFound
+	}
+	return sessions[0], nil
+}
+
+// LoadByIdentityKey returns all unexpired sessions for the identity key. Nonces of
+// expired sessions are removed from the index as they are found.
+func (s *RedisSessionStore) LoadByIdentityKey(ctx context.Context, identityKey string) ([]*PeerSession, error) {
+	indexKey := s.identityKey(identityKey)
+	reply, err := s.client.Do(ctx, "SMEMBERS", indexKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list sessions: %w", err)
+	}
+	nonces, err := redisStrings(reply)
+	if err != nil {
+		return nil, err
+	}
+	if len(nonces) == 0 {
+		return nil, nil
+	}
+
+	loaded, err := s.load(ctx, nonces)
+	if err != nil {
+		return nil, err
+	}
+	sessions := make([]*PeerSession, 0, len(loaded))
+	stale := []any{"SREM", indexKey}
+	for i, session := range loaded {
+		if session == nil {
+			stale = append(stale, nonces[i])
+			continue
+		}
+		sessions = append(sessions, session)
+	}
+	if len(stale) > 2 {
+		if _, err = s.client.Do(ctx, stale...); err != nil {
+			return nil, fmt.Errorf("failed to clean session index: %w", err)
+		}
+	}
+	return sessions, nil
+}
+
+// Delete removes the session and its index entry.
+func (s *RedisSessionStore) Delete(ctx context.Context, session *PeerSession) error {
+	identityKey := session.PeerIdentityKey
+	if identityKey == nil {
+		if stored, err := s.Load(ctx, session.SessionNonce); err == nil {
+			identityKey = stored.PeerIdentityKey
+		}
+	}
+	if _, err := s.client.Do(ctx, "DEL", s.sessionKey(session.SessionNonce)); err != nil {
+		return fmt.Errorf("failed to delete session: %w", err)
+	}
+	if identityKey == nil {
+		return nil
+	}
+	if _, err := s.client.Do(ctx, "SREM", s.identityKey(identityKey.ToDERHex()), session.SessionNonce); err != nil {
+		return fmt.Errorf("failed to remove session from index: %w", err)
+	}
+	return nil
+}
+
+// load fetches sessions with MGET so that missing keys come back as nil entries
+// rather than as a client specific "nil reply" error.
+func (s *RedisSessionStore) load(ctx context.Context, nonces []string) ([]*PeerSession, error) {
+	args := make([]any, 0, len(nonces)+1)
+	args = append(args, "MGET")
+	for _, nonce := range nonces {
+		args = append(args, s.sessionKey(nonce))
+	}
+	reply, err := s.client.Do(ctx, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load sessions: %w", err)
+	}
+	values, ok := reply.([]any)
+	if !ok || len(values) != len(nonces) {
+		return nil, fmt.Errorf("unexpected MGET reply %T", reply)
+	}
+
+	sessions := make([]*PeerSession, len(values))
+	for i, value := range values {
+		if value == nil {
+			continue
+		}
+		data, err := redisBytes(value)
+		if err != nil {
+			return nil, err
+		}
+		var session PeerSession
+		if err = json.Unmarshal(data, &session); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal session: %w", err)
+		}
+		sessions[i] = &session
+	}
+	return sessions, nil
+}
+
+func (s *RedisSessionStore) sessionKey(nonce string) string {
+	return s.prefix + "session:" + nonce
+}
+
+func (s *RedisSessionStore) identityKey(identityKey string) string {
+	return s.prefix + "identity:" + identityKey
+}
+
+func redisBytes(value any) ([]byte, error) {
+	switch v := value.(type) {
+	case string:
+		return []byte(v), nil
+	case []byte:
+		return v, nil
+	default:
+		return nil, fmt.Errorf("unexpected redis value %T", value)
+	}
+}
+
+func redisStrings(reply any) ([]string, error) {
+	if reply == nil {
+		return nil, nil
+	}
+	values, ok := reply.([]any)
+	if !ok {
+		return nil, fmt.Errorf("unexpected redis reply %T", reply)
+	}
+	result := make([]string, 0, len(values))
+	for _, value := range values {
+		b, err := redisBytes(value)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, string(b))
+	}
+	return result, nil
+}
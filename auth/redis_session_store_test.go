@@ -0,0 +1,181 @@
+package auth_test
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/bsv-blockchain/go-sdk/auth"
+	ec "github.com/bsv-blockchain/go-sdk/primitives/ec"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeRedis implements the handful of commands used by RedisSessionStore, replying the
+// way go-redis does (bulk strings as string, arrays as []any).
+type fakeRedis struct {
+	mu      sync.Mutex
+	strings map[string]string
+	sets    map[string]map[string]struct{}
+	expires map[string]time.Time
+}
+
+func newFakeRedis() *fakeRedis {
+	return &fakeRedis{
+		strings: make(map[string]string),
+		sets:    make(map[string]map[string]struct{}),
+		expires: make(map[string]time.Time),
+	}
+}
+
+func (r *fakeRedis) Do(_ context.Context, args ...any) (any, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	str := func(i int) string { return args[i].(string) }
+	for key, expires := range r.expires {
+		if !time.Now().Before(expires) {
+			delete(r.strings, key)
+			delete(r.sets, key)
+			delete(r.expires, key)
+		}
+	}
+
+	switch str(0) {
+	case "SET":
+		r.strings[str(1)] = str(2)
+		if len(args) == 5 && str(3) == "PX" {
+			ms, _ := strconv.Atoi(str(4))
+			r.expires[str(1)] = time.Now().Add(time.Duration(ms) * time.Millisecond)
+		}
+		return "OK", nil
+	case "MGET":
+		values := make([]any, 0, len(args)-1)
+		for i := 1; i < len(args); i++ {
+			if v, ok := r.strings[str(i)]; ok {
+				values = append(values, v)
+			} else {
+				values = append(values, nil)
+			}
+		}
+		return values, nil
+	case "DEL":
+		delete(r.strings, str(1))
+		delete(r.expires, str(1))
+		return int64(1), nil
+	case "SADD":
+		if r.sets[str(1)] == nil {
+			r.sets[str(1)] = make(map[string]struct{})
+		}
+		for i := 2; i < len(args); i++ {
+			r.sets[str(1)][str(i)] = struct{}{}
+		}
+		return int64(1), nil
+	case "SREM":
+		for i := 2; i < len(args); i++ {
+			delete(r.sets[str(1)], str(i))
+		}
+		return int64(1), nil
+	case "SMEMBERS":
+		members := []any{}
+		for member := range r.sets[str(1)] {
+			members = append(members, member)
+		}
+		return members, nil
+	case "PEXPIRE":
+		ms, _ := strconv.Atoi(str(2))
+		r.expires[str(1)] = time.Now().Add(time.Duration(ms) * time.Millisecond)
+		return int64(1), nil
+	}
+	return nil, fmt.Errorf("unsupported command %v", args[0])
+}
+
+func TestRedisSessionStore(t *testing.T) {
+	ctx := t.Context()
+	privKey, err := ec.NewPrivateKey()
+	require.NoError(t, err)
+	identityKey := privKey.PubKey()
+
+	t.Run("requires client", func(t *testing.T) {
+		_, err := auth.NewRedisSessionStore(auth.RedisSessionStoreOptions{})
+		require.Error(t, err)
+	})
+
+	t.Run("save, load and delete", func(t *testing.T) {
+		redis := newFakeRedis()
+		store, err := auth.NewRedisSessionStore(auth.RedisSessionStoreOptions{Client: redis})
+		require.NoError(t, err)
+
+		session := &auth.PeerSession{
+			IsAuthenticated: true,
+			SessionNonce:    "nonce-1",
+			PeerNonce:       "peer-nonce",
+			PeerIdentityKey: identityKey,
+			LastUpdate:      42,
+		}
+		require.NoError(t, store.Save(ctx, session))
+		require.Contains(t, redis.strings, auth.DefaultRedisKeyPrefix+"session:nonce-1")
+
+		loaded, err := store.Load(ctx, "nonce-1")
+		require.NoError(t, err)
+		require.Equal(t, session, loaded)
+
+		byKey, err := store.LoadByIdentityKey(ctx, identityKey.ToDERHex())
+		require.NoError(t, err)
+		require.Equal(t, []*auth.PeerSession{session}, byKey)
+
+		require.NoError(t, store.Delete(ctx, &auth.PeerSession{SessionNonce: "nonce-1"}))
+		_, err = store.Load(ctx, "nonce-1")
+		require.ErrorIs(t, err, auth.ErrSessionNotFound)
+		require.Empty(t, redis.sets[auth.DefaultRedisKeyPrefix+"identity:"+identityKey.ToDERHex()])
+	})
+
+	t.Run("expired sessions are dropped from the index", func(t *testing.T) {
+		redis := newFakeRedis()
+		store, err := auth.NewRedisSessionStore(auth.RedisSessionStoreOptions{Client: redis, KeyPrefix: "test:"})
+		require.NoError(t, err)
+
+		require.NoError(t, store.Save(ctx, &auth.PeerSession{SessionNonce: "nonce-1", PeerIdentityKey: identityKey}))
+		require.NoError(t, store.Save(ctx, &auth.PeerSession{SessionNonce: "nonce-2", PeerIdentityKey: identityKey}))
+		_, err = redis.Do(ctx, "DEL", "test:session:nonce-1")
+		require.NoError(t, err)
+
+		sessions, err := store.LoadByIdentityKey(ctx, identityKey.ToDERHex())
+		require.NoError(t, err)
+		require.Len(t, sessions, 1)
+		require.Equal(t, "nonce-2", sessions[0].SessionNonce)
+		require.NotContains(t, redis.sets["test:identity:"+identityKey.ToDERHex()], "nonce-1")
+	})
+
+	t.Run("peers share sessions through redis", func(t *testing.T) {
+		store, err := auth.NewRedisSessionStore(auth.RedisSessionStoreOptions{Client: newFakeRedis()})
+		require.NoError(t, err)
+		withStore := func(options *auth.PeerOptions) {
+			options.SessionManager = auth.NewStoreSessionManager(store, 0, nil)
+		}
+		bob1 := NewActor(t, bobName+"1", bobPrivKeyHex, withStore)
+		bob2 := NewActor(t, bobName+"2", bobPrivKeyHex, withStore)
+		alice := NewActor(t, aliceName, alicePrivKeyHex)
+
+		received := make(chan []byte, 1)
+		bob2.ListenForGeneralMessages(func(_ context.Context, _ *ec.PublicKey, payload []byte) error {
+			received <- payload
+			return nil
+		})
+
+		alice.ConnectWith(bob1)
+		require.NoError(t, alice.ToPeer(ctx, anyMessage, bob1.IdentityKey, 5000))
+
+		alice.ConnectWith(bob2)
+		require.NoError(t, alice.ToPeer(ctx, anyMessage, bob2.IdentityKey, 5000))
+
+		select {
+		case payload := <-received:
+			require.Equal(t, anyMessage, payload)
+		case <-time.After(500 * time.Millisecond):
+			require.Fail(t, "timed out waiting for message")
+		}
+	})
+}
@@ -93,25 +93,31 @@ func (sm *DefaultSessionManager) GetSession(identifier string) (*PeerSession, er
 		return nil, errors.New("session-not-found")
 	}
 
-	// Pick the "best" session
-	// - Choose the most recently updated, preferring authenticated sessions
-	var best *PeerSession
+	sessions := make([]*PeerSession, 0, len(nonces))
 	for nonce := range nonces {
 		if s, ok := sm.sessionNonceToSession.Load(nonce); ok {
-			s := s.(*PeerSession)
-			if best == nil {
-				best = s
-			} else if s.LastUpdate > best.LastUpdate {
-				if s.IsAuthenticated || !best.IsAuthenticated {
-					best = s
-				}
-			} else if s.IsAuthenticated && !best.IsAuthenticated {
+			sessions = append(sessions, s.(*PeerSession))
+		}
+	}
+
+	return bestSession(sessions), nil
+}
+
+// bestSession picks the most recently updated session, preferring authenticated sessions.
+func bestSession(sessions []*PeerSession) *PeerSession {
+	var best *PeerSession
+	for _, s := range sessions {
+		if best == nil {
+			best = s
+		} else if s.LastUpdate > best.LastUpdate {
+			if s.IsAuthenticated || !best.IsAuthenticated {
 				best = s
 			}
+		} else if s.IsAuthenticated && !best.IsAuthenticated {
+			best = s
 		}
 	}
-
-	return best, nil
+	return best
 }
 
 // RemoveSession removes a session from the manager by clearing all associated identifiers.
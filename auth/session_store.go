@@ -0,0 +1,239 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+// DefaultSessionTTL is how long a stored session lives without being updated.
+const DefaultSessionTTL = 24 * time.Hour
+
+// SessionStore persists peer sessions (nonces, peer identity and authentication state)
+// outside of a single Peer, so that several instances of a service can share them.
+// Sessions expire when they have not been saved for longer than the store's TTL.
+type SessionStore interface {
+	// Save stores the session under its session nonce, replacing any previous version
+	// and resetting its expiry.
+	Save(ctx context.Context, session *PeerSession) error
+
+	// Load returns the session with the given session nonce, or ErrSessionNotFound.
+	Load(ctx context.Context, sessionNonce string) (*PeerSession, error)
+
+	// LoadByIdentityKey returns all unexpired sessions for the given DER hex identity key.
+	LoadByIdentityKey(ctx context.Context, identityKey string) ([]*PeerSession, error)
+
+	// Delete removes the session. Deleting a missing session is not an error.
+	Delete(ctx context.Context, session *PeerSession) error
+}
+
+// ensure that StoreSessionManager is implementing SessionManager
+var _ SessionManager = (*StoreSessionManager)(nil)
+
+// StoreSessionManager implements SessionManager on top of a SessionStore.
+// Sessions are returned as copies, so changes must be persisted with UpdateSession.
+type StoreSessionManager struct {
+	store   SessionStore
+	timeout time.Duration
+	logger  *slog.Logger
+}
+
+// NewStoreSessionManager creates a session manager backed by the given store.
+// The timeout bounds every store operation; zero means 5 seconds.
+func NewStoreSessionManager(store SessionStore, timeout time.Duration, logger *slog.Logger) *StoreSessionManager {
+	if timeout <= 0 {
+		timeout = 5 * time.Second
+	}
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return &StoreSessionManager{
+		store:   store,
+		timeout: timeout,
+		logger:  logger.With("component", "StoreSessionManager"),
+	}
+}
+
+// AddSession persists a new session.
+func (sm *StoreSessionManager) AddSession(session *PeerSession) error {
+	if session.SessionNonce == "" {
+		return errors.New("invalid session: sessionNonce is required to add a session")
+	}
+	ctx, cancel := sm.context()
+	defer cancel()
+	return sm.store.Save(ctx, session)
+}
+
+// UpdateSession persists the latest state of a session. Failures are logged.
+func (sm *StoreSessionManager) UpdateSession(session *PeerSession) {
+	if err := sm.AddSession(session); err != nil {
+		sm.logger.Error("Failed to update session", "error", err)
+	}
+}
+
+// GetSession retrieves a session by session nonce or, failing that, the best session
+// for a peer identity key.
+func (sm *StoreSessionManager) GetSession(identifier string) (*PeerSession, error) {
+	ctx, cancel := sm.context()
+	defer cancel()
+
+	session, err := sm.store.Load(ctx, identifier)
+	if err == nil {
+		return session, nil
+	}
+	if !errors.Is(err, ErrSessionNotFound) {
+		return nil, err
+	}
+
+	sessions, err := sm.store.LoadByIdentityKey(ctx, identifier)
+	if err != nil {
+		return nil, err
+	}
+	if len(sessions) == 0 {
+		return nil, ErrSessionNotFound
+	}
+	return bestSession(sessions), nil
+}
+
+// RemoveSession deletes a session from the store. Failures are logged.
+func (sm *StoreSessionManager) RemoveSession(session *PeerSession) {
+	ctx, cancel := sm.context()
+	defer cancel()
+	if err := sm.store.Delete(ctx, session); err != nil {
+		sm.logger.Error("Failed to remove session", "error", err)
+	}
+}
+
+// HasSession checks if a session exists for a session nonce or identity key.
+func (sm *StoreSessionManager) HasSession(identifier string) bool {
+	session, err := sm.GetSession(identifier)
+	return err == nil && session != nil
+}
+
+func (sm *StoreSessionManager) context() (context.Context, context.CancelFunc) {
+	return context.WithTimeout(context.Background(), sm.timeout)
+}
+
+// ensure that MemorySessionStore is implementing SessionStore
+var _ SessionStore = (*MemorySessionStore)(nil)
+
+type storedSession struct {
+	session PeerSession
+	expires time.Time
+}
+
+// MemorySessionStore is a SessionStore that keeps sessions in process memory.
+type MemorySessionStore struct {
+	mu         sync.RWMutex
+	ttl        time.Duration
+	sessions   map[string]storedSession
+	byIdentity map[string]map[string]struct{}
+	now        func() time.Time
+}
+
+// NewMemorySessionStore creates an in-memory session store. A ttl of zero uses DefaultSessionTTL.
+func NewMemorySessionStore(ttl time.Duration) *MemorySessionStore {
+	if ttl <= 0 {
+		ttl = DefaultSessionTTL
+	}
+	return &MemorySessionStore{
+		ttl:        ttl,
+		sessions:   make(map[string]storedSession),
+		byIdentity: make(map[string]map[string]struct{}),
+		now:        time.Now,
+	}
+}
+
+// Save stores a copy of the session.
+func (s *MemorySessionStore) Save(_ context.Context, session *PeerSession) error {
+	if session.SessionNonce == "" {
+		return errors.New("invalid session: sessionNonce is required")
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if previous, ok := s.sessions[session.SessionNonce]; ok {
+		s.unindex(&previous.session)
+	}
+	s.sessions[session.SessionNonce] = storedSession{session: *session, expires: s.now().Add(s.ttl)}
+	if session.PeerIdentityKey != nil {
+		key := session.PeerIdentityKey.ToDERHex()
+		if s.byIdentity[key] == nil {
+			s.byIdentity[key] = make(map[string]struct{})
+		}
+		s.byIdentity[key][session.SessionNonce] = struct{}{}
+	}
+	return nil
+}
+
+// Load returns a copy of the session with the given nonce.
+func (s *MemorySessionStore) Load(_ context.Context, sessionNonce string) (*PeerSession, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	stored, ok := s.sessions[sessionNonce]
+	if !ok || !s.now().Before(stored.expires) {
+		return nil, ErrSessionNotFound
+	}
+	session := stored.session
+	return &session, nil
+}
+
+// LoadByIdentityKey returns copies of all unexpired sessions for the identity key.
+func (s *MemorySessionStore) LoadByIdentityKey(_ context.Context, identityKey string) ([]*PeerSession, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	now := s.now()
+	var sessions []*PeerSession
+	for nonce := range s.byIdentity[identityKey] {
+		stored, ok := s.sessions[nonce]
+		if !ok || !now.Before(stored.expires) {
+			continue
+		}
+		session := stored.session
+		sessions = append(sessions, &session)
+	}
+	return sessions, nil
+}
+
+// Delete removes the session.
+func (s *MemorySessionStore) Delete(_ context.Context, session *PeerSession) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if stored, ok := s.sessions[session.SessionNonce]; ok {
+		s.unindex(&stored.session)
+		delete(s.sessions, session.SessionNonce)
+	}
+	return nil
+}
+
+// Prune drops expired sessions and returns how many were removed.
+func (s *MemorySessionStore) Prune() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := s.now()
+	removed := 0
+	for nonce, stored := range s.sessions {
+		if now.Before(stored.expires) {
+			continue
+		}
+		s.unindex(&stored.session)
+		delete(s.sessions, nonce)
+		removed++
+	}
+	return removed
+}
+
+func (s *MemorySessionStore) unindex(session *PeerSession) {
+	if session.PeerIdentityKey == nil {
+		return
+	}
+	key := session.PeerIdentityKey.ToDERHex()
+	if nonces := s.byIdentity[key]; nonces != nil {
+		delete(nonces, session.SessionNonce)
+		if len(nonces) == 0 {
+			delete(s.byIdentity, key)
+		}
+	}
+}
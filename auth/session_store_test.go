@@ -0,0 +1,130 @@
+package auth_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/bsv-blockchain/go-sdk/auth"
+	ec "github.com/bsv-blockchain/go-sdk/primitives/ec"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemorySessionStore(t *testing.T) {
+	ctx := t.Context()
+	privKey, err := ec.NewPrivateKey()
+	require.NoError(t, err)
+	identityKey := privKey.PubKey()
+
+	t.Run("save, load and delete", func(t *testing.T) {
+		store := auth.NewMemorySessionStore(0)
+		session := &auth.PeerSession{SessionNonce: "nonce-1", PeerIdentityKey: identityKey, LastUpdate: 1}
+		require.NoError(t, store.Save(ctx, session))
+
+		loaded, err := store.Load(ctx, "nonce-1")
+		require.NoError(t, err)
+		require.Equal(t, session, loaded)
+		require.NotSame(t, session, loaded)
+
+		byKey, err := store.LoadByIdentityKey(ctx, identityKey.ToDERHex())
+		require.NoError(t, err)
+		require.Len(t, byKey, 1)
+
+		require.NoError(t, store.Delete(ctx, session))
+		_, err = store.Load(ctx, "nonce-1")
+		require.ErrorIs(t, err, auth.ErrSessionNotFound)
+		byKey, err = store.LoadByIdentityKey(ctx, identityKey.ToDERHex())
+		require.NoError(t, err)
+		require.Empty(t, byKey)
+	})
+
+	t.Run("sessions expire", func(t *testing.T) {
+		store := auth.NewMemorySessionStore(10 * time.Millisecond)
+		require.NoError(t, store.Save(ctx, &auth.PeerSession{SessionNonce: "nonce-1", PeerIdentityKey: identityKey}))
+
+		time.Sleep(20 * time.Millisecond)
+
+		_, err := store.Load(ctx, "nonce-1")
+		require.ErrorIs(t, err, auth.ErrSessionNotFound)
+		require.Equal(t, 1, store.Prune())
+	})
+
+	t.Run("requires session nonce", func(t *testing.T) {
+		store := auth.NewMemorySessionStore(0)
+		require.Error(t, store.Save(ctx, &auth.PeerSession{}))
+	})
+}
+
+func TestStoreSessionManager(t *testing.T) {
+	privKey, err := ec.NewPrivateKey()
+	require.NoError(t, err)
+	identityKey := privKey.PubKey()
+
+	sm := auth.NewStoreSessionManager(auth.NewMemorySessionStore(0), 0, nil)
+
+	older := &auth.PeerSession{SessionNonce: "older", PeerIdentityKey: identityKey, IsAuthenticated: true, LastUpdate: 1}
+	newer := &auth.PeerSession{SessionNonce: "newer", PeerIdentityKey: identityKey, IsAuthenticated: false, LastUpdate: 2}
+	require.NoError(t, sm.AddSession(older))
+	require.NoError(t, sm.AddSession(newer))
+
+	// then: lookup by identity key prefers the authenticated session
+	best, err := sm.GetSession(identityKey.ToDERHex())
+	require.NoError(t, err)
+	require.Equal(t, "older", best.SessionNonce)
+
+	// when: the newer session becomes authenticated
+	newer.IsAuthenticated = true
+	sm.UpdateSession(newer)
+
+	best, err = sm.GetSession(identityKey.ToDERHex())
+	require.NoError(t, err)
+	require.Equal(t, "newer", best.SessionNonce)
+
+	sm.RemoveSession(newer)
+	sm.RemoveSession(older)
+	require.False(t, sm.HasSession(identityKey.ToDERHex()))
+	_, err = sm.GetSession("older")
+	require.ErrorIs(t, err, auth.ErrSessionNotFound)
+}
+
+func TestPeerAuthenticationWithSharedSessionStore(t *testing.T) {
+	// given: two instances of Bob's service sharing one session store
+	bobStore := auth.NewMemorySessionStore(0)
+	withBobStore := func(options *auth.PeerOptions) {
+		options.SessionManager = auth.NewStoreSessionManager(bobStore, 0, nil)
+	}
+	bob1 := NewActor(t, bobName+"1", bobPrivKeyHex, withBobStore)
+	bob2 := NewActor(t, bobName+"2", bobPrivKeyHex, withBobStore)
+
+	alice := NewActor(t, aliceName, alicePrivKeyHex, func(options *auth.PeerOptions) {
+		options.SessionManager = auth.NewStoreSessionManager(auth.NewMemorySessionStore(0), 0, nil)
+	})
+
+	received := make(chan string, 2)
+	for _, bob := range []*Actor{bob1, bob2} {
+		bob.ListenForGeneralMessages(func(_ context.Context, _ *ec.PublicKey, payload []byte) error {
+			received <- bob.Name + ":" + string(payload)
+			return nil
+		})
+	}
+
+	// when: Alice authenticates with the first instance
+	alice.ConnectWith(bob1)
+	err := alice.ToPeer(t.Context(), []byte("first"), bob1.IdentityKey, 5000)
+	require.NoError(t, err)
+
+	// and: her next message is routed to the second instance
+	alice.ConnectWith(bob2)
+	err = alice.ToPeer(t.Context(), []byte("second"), bob2.IdentityKey, 5000)
+	require.NoError(t, err)
+
+	// then: both instances accept the messages on the same session
+	for _, expected := range []string{bob1.Name + ":first", bob2.Name + ":second"} {
+		select {
+		case msg := <-received:
+			require.Equal(t, expected, msg)
+		case <-time.After(500 * time.Millisecond):
+			require.Fail(t, "timed out waiting for message", expected)
+		}
+	}
+}
@@ -19,3 +19,9 @@ type Transport interface {
 	// OnData registers a callback function to handle incoming AuthMessages
 	OnData(callback func(context.Context, *auth.AuthMessage) error) error
 }
+
+var (
+	_ auth.Transport = (*SimplifiedHTTPTransport)(nil)
+	_ auth.Transport = (*WebSocketTransport)(nil)
+	_ auth.Transport = (*wsServerConn)(nil)
+)
@@ -0,0 +1,223 @@
+package transports
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/bsv-blockchain/go-sdk/auth"
+	"github.com/bsv-blockchain/go-sdk/auth/certificates"
+	"github.com/bsv-blockchain/go-sdk/auth/utils"
+	ec "github.com/bsv-blockchain/go-sdk/primitives/ec"
+	"github.com/bsv-blockchain/go-sdk/wallet"
+	"golang.org/x/net/websocket"
+)
+
+// ErrPeerNotConnected is returned when sending to an identity that has no open WebSocket connection
+var ErrPeerNotConnected = errors.New("peer is not connected")
+
+// WebSocketMessageHandler is called for every authenticated general message received by a
+// WebSocketServer. The peer is the server side of the sender's connection and can be used to
+// reply with peer.ToPeer.
+type WebSocketMessageHandler func(ctx context.Context, peer *auth.Peer, senderPublicKey *ec.PublicKey, payload []byte) error
+
+// WebSocketServerOptions contains configuration options for the WebSocketServer.
+type WebSocketServerOptions struct {
+	Wallet                 wallet.Interface
+	SessionManager         auth.SessionManager // Optional, shared by all connections so sessions survive reconnects
+	CertificatesToRequest  *utils.RequestedCertificateSet
+	OnGeneralMessage       WebSocketMessageHandler
+	OnCertificatesReceived auth.OnCertificateReceivedCallback
+	WriteDeadline          int // seconds, default 30
+	Logger                 *slog.Logger
+}
+
+// WebSocketServer is an http.Handler that accepts WebSocket connections and runs a BRC-103
+// peer over each of them. All connections share a single SessionManager, so a client that
+// reconnects with an existing session does not need to repeat the handshake.
+type WebSocketServer struct {
+	wallet                 wallet.Interface
+	sessionManager         auth.SessionManager
+	certificatesToRequest  *utils.RequestedCertificateSet
+	onGeneralMessage       WebSocketMessageHandler
+	onCertificatesReceived auth.OnCertificateReceivedCallback
+	writeDeadline          time.Duration
+	logger                 *slog.Logger
+
+	mu    sync.RWMutex
+	peers map[string]*wsServerConn
+}
+
+// NewWebSocketServer creates a new WebSocket server with the given options.
+// The Wallet is required.
+func NewWebSocketServer(options *WebSocketServerOptions) (*WebSocketServer, error) {
+	if options.Wallet == nil {
+		return nil, errors.New("Wallet is required for WebSocket server")
+	}
+	sessionManager := options.SessionManager
+	if sessionManager == nil {
+		sessionManager = auth.NewSessionManager()
+	}
+	writeDeadline := time.Duration(options.WriteDeadline) * time.Second
+	if writeDeadline <= 0 {
+		writeDeadline = 30 * time.Second
+	}
+	logger := options.Logger
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return &WebSocketServer{
+		wallet:                 options.Wallet,
+		sessionManager:         sessionManager,
+		certificatesToRequest:  options.CertificatesToRequest,
+		onGeneralMessage:       options.OnGeneralMessage,
+		onCertificatesReceived: options.OnCertificatesReceived,
+		writeDeadline:          writeDeadline,
+		logger:                 logger.With("component", "WebSocketServer"),
+		peers:                  make(map[string]*wsServerConn),
+	}, nil
+}
+
+// ServeHTTP upgrades the request to a WebSocket connection and serves it until it closes.
+func (s *WebSocketServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	websocket.Handler(func(conn *websocket.Conn) {
+		s.serve(r.Context(), conn)
+	}).ServeHTTP(w, r)
+}
+
+// ToPeer sends an authenticated general message to a connected client identified by its identity key.
+// A client becomes addressable once it has sent at least one authenticated message.
+func (s *WebSocketServer) ToPeer(ctx context.Context, identityKey *ec.PublicKey, payload []byte) error {
+	if identityKey == nil {
+		return errors.New("identity key is required")
+	}
+	s.mu.RLock()
+	c, ok := s.peers[identityKey.ToDERHex()]
+	s.mu.RUnlock()
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrPeerNotConnected, identityKey.ToDERHex())
+	}
+	return c.peer.ToPeer(ctx, payload, identityKey, 0)
+}
+
+func (s *WebSocketServer) serve(ctx context.Context, conn *websocket.Conn) {
+	c := &wsServerConn{conn: conn, writeDeadline: s.writeDeadline}
+	autoPersist := false
+	c.peer = auth.NewPeer(&auth.PeerOptions{
+		Wallet:                 s.wallet,
+		Transport:              c,
+		SessionManager:         s.sessionManager,
+		CertificatesToRequest:  s.certificatesToRequest,
+		AutoPersistLastSession: &autoPersist,
+		Logger:                 s.logger,
+	})
+	c.peer.ListenForGeneralMessages(func(ctx context.Context, senderPublicKey *ec.PublicKey, payload []byte) error {
+		s.register(senderPublicKey, c)
+		if s.onGeneralMessage == nil {
+			return nil
+		}
+		return s.onGeneralMessage(ctx, c.peer, senderPublicKey, payload)
+	})
+	c.peer.ListenForCertificatesReceived(func(ctx context.Context, senderPublicKey *ec.PublicKey, certs []*certificates.VerifiableCertificate) error {
+		s.register(senderPublicKey, c)
+		if s.onCertificatesReceived == nil {
+			return nil
+		}
+		return s.onCertificatesReceived(ctx, senderPublicKey, certs)
+	})
+
+	defer func() {
+		s.unregister(c)
+		_ = conn.Close()
+	}()
+
+	for {
+		var data []byte
+		if err := websocket.Message.Receive(conn, &data); err != nil {
+			return
+		}
+		var message auth.AuthMessage
+		if err := json.Unmarshal(data, &message); err != nil {
+			s.logger.Warn("Dropping malformed auth message", "error", err)
+			continue
+		}
+		if err := c.receive(ctx, &message); err != nil {
+			s.logger.Debug("Failed to handle auth message", "messageType", message.MessageType, "error", err)
+		}
+	}
+}
+
+func (s *WebSocketServer) register(identityKey *ec.PublicKey, c *wsServerConn) {
+	s.mu.Lock()
+	s.peers[identityKey.ToDERHex()] = c
+	s.mu.Unlock()
+}
+
+func (s *WebSocketServer) unregister(c *wsServerConn) {
+	s.mu.Lock()
+	for key, existing := range s.peers {
+		if existing == c {
+			delete(s.peers, key)
+		}
+	}
+	s.mu.Unlock()
+}
+
+// wsServerConn is the auth.Transport for a single server-side WebSocket connection.
+type wsServerConn struct {
+	conn          *websocket.Conn
+	peer          *auth.Peer
+	writeDeadline time.Duration
+	writeMu       sync.Mutex
+	mu            sync.Mutex
+	onData        func(context.Context, *auth.AuthMessage) error
+}
+
+// Send writes an AuthMessage to the client.
+func (c *wsServerConn) Send(_ context.Context, message *auth.AuthMessage) error {
+	jsonData, err := json.Marshal(message)
+	if err != nil {
+		return fmt.Errorf("failed to marshal auth message: %w", err)
+	}
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+	_ = c.conn.SetWriteDeadline(time.Now().Add(c.writeDeadline))
+	if err = websocket.Message.Send(c.conn, jsonData); err != nil {
+		return fmt.Errorf("failed to send WebSocket message: %w", err)
+	}
+	return nil
+}
+
+// OnData registers the callback for incoming messages. Only one callback is kept.
+func (c *wsServerConn) OnData(callback func(context.Context, *auth.AuthMessage) error) error {
+	if callback == nil {
+		return errors.New("callback cannot be nil")
+	}
+	c.mu.Lock()
+	c.onData = callback
+	c.mu.Unlock()
+	return nil
+}
+
+// GetRegisteredOnData returns the registered callback.
+func (c *wsServerConn) GetRegisteredOnData() (func(context.Context, *auth.AuthMessage) error, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.onData == nil {
+		return nil, errors.New("no handlers registered")
+	}
+	return c.onData, nil
+}
+
+func (c *wsServerConn) receive(ctx context.Context, message *auth.AuthMessage) error {
+	onData, err := c.GetRegisteredOnData()
+	if err != nil {
+		return err
+	}
+	return onData(ctx, message)
+}
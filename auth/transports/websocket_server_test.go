@@ -0,0 +1,176 @@
+package transports
+
+import (
+	"context"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bsv-blockchain/go-sdk/auth"
+	"github.com/bsv-blockchain/go-sdk/auth/certificates"
+	"github.com/bsv-blockchain/go-sdk/auth/utils"
+	ec "github.com/bsv-blockchain/go-sdk/primitives/ec"
+	"github.com/bsv-blockchain/go-sdk/wallet"
+	"github.com/bsv-blockchain/go-sdk/wallet/testcertificates"
+	"github.com/stretchr/testify/require"
+)
+
+type wsTestClient struct {
+	peer           *auth.Peer
+	transport      *WebSocketTransport
+	wallet         *wallet.TestWallet
+	sessionManager auth.SessionManager
+	received       chan []byte
+}
+
+func newWsTestServer(t *testing.T, opts *WebSocketServerOptions) (*WebSocketServer, string) {
+	if opts.Wallet == nil {
+		opts.Wallet = wallet.NewTestWalletForRandomKey(t)
+	}
+	if opts.OnGeneralMessage == nil {
+		opts.OnGeneralMessage = func(ctx context.Context, peer *auth.Peer, sender *ec.PublicKey, payload []byte) error {
+			return peer.ToPeer(ctx, append([]byte("echo: "), payload...), sender, 0)
+		}
+	}
+	server, err := NewWebSocketServer(opts)
+	require.NoError(t, err)
+
+	httpServer := httptest.NewServer(server)
+	t.Cleanup(httpServer.Close)
+	return server, "ws" + strings.TrimPrefix(httpServer.URL, "http")
+}
+
+func newWsTestClient(t *testing.T, url string, clientWallet *wallet.TestWallet) *wsTestClient {
+	if clientWallet == nil {
+		clientWallet = wallet.NewTestWalletForRandomKey(t)
+	}
+	transport, err := NewWebSocketTransport(&WebSocketTransportOptions{BaseURL: url, ReadDeadline: 5})
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = transport.Close() })
+
+	client := &wsTestClient{
+		transport:      transport,
+		wallet:         clientWallet,
+		sessionManager: auth.NewSessionManager(),
+		received:       make(chan []byte, 10),
+	}
+	client.peer = auth.NewPeer(&auth.PeerOptions{
+		Wallet:         clientWallet,
+		Transport:      transport,
+		SessionManager: client.sessionManager,
+	})
+	client.peer.ListenForGeneralMessages(func(_ context.Context, _ *ec.PublicKey, payload []byte) error {
+		client.received <- payload
+		return nil
+	})
+	return client
+}
+
+func (c *wsTestClient) requireReceived(t *testing.T, expected string) {
+	select {
+	case payload := <-c.received:
+		require.Equal(t, expected, string(payload))
+	case <-time.After(5 * time.Second):
+		require.Fail(t, "timed out waiting for message from server")
+	}
+}
+
+func serverIdentityKey(t *testing.T, w wallet.Interface) *ec.PublicKey {
+	res, err := w.GetPublicKey(t.Context(), wallet.GetPublicKeyArgs{IdentityKey: true}, "")
+	require.NoError(t, err)
+	return res.PublicKey
+}
+
+func TestWebSocketServerGeneralMessage(t *testing.T) {
+	serverWallet := wallet.NewTestWalletForRandomKey(t)
+	_, url := newWsTestServer(t, &WebSocketServerOptions{Wallet: serverWallet})
+	client := newWsTestClient(t, url, nil)
+
+	err := client.peer.ToPeer(t.Context(), []byte("hello"), serverIdentityKey(t, serverWallet), 5000)
+	require.NoError(t, err)
+	client.requireReceived(t, "echo: hello")
+}
+
+func TestWebSocketServerToPeer(t *testing.T) {
+	serverWallet := wallet.NewTestWalletForRandomKey(t)
+	server, url := newWsTestServer(t, &WebSocketServerOptions{Wallet: serverWallet})
+	client := newWsTestClient(t, url, nil)
+	clientKey := serverIdentityKey(t, client.wallet)
+
+	err := server.ToPeer(t.Context(), clientKey, []byte("too early"))
+	require.ErrorIs(t, err, ErrPeerNotConnected)
+
+	err = client.peer.ToPeer(t.Context(), []byte("hello"), serverIdentityKey(t, serverWallet), 5000)
+	require.NoError(t, err)
+	client.requireReceived(t, "echo: hello")
+
+	err = server.ToPeer(t.Context(), clientKey, []byte("pushed"))
+	require.NoError(t, err)
+	client.requireReceived(t, "pushed")
+}
+
+func TestWebSocketServerSessionResumption(t *testing.T) {
+	serverWallet := wallet.NewTestWalletForRandomKey(t)
+	_, url := newWsTestServer(t, &WebSocketServerOptions{Wallet: serverWallet})
+	client := newWsTestClient(t, url, nil)
+	serverKey := serverIdentityKey(t, serverWallet)
+
+	err := client.peer.ToPeer(t.Context(), []byte("first"), serverKey, 5000)
+	require.NoError(t, err)
+	client.requireReceived(t, "echo: first")
+
+	session, err := client.sessionManager.GetSession(serverKey.ToDERHex())
+	require.NoError(t, err)
+	sessionNonce := session.SessionNonce
+
+	// when: the connection drops
+	require.NoError(t, client.transport.Close())
+
+	// then: the next message reconnects and reuses the authenticated session
+	err = client.peer.ToPeer(t.Context(), []byte("second"), serverKey, 5000)
+	require.NoError(t, err)
+	client.requireReceived(t, "echo: second")
+
+	session, err = client.sessionManager.GetSession(serverKey.ToDERHex())
+	require.NoError(t, err)
+	require.Equal(t, sessionNonce, session.SessionNonce)
+}
+
+func TestWebSocketServerCertificateExchange(t *testing.T) {
+	const certType = "contact"
+
+	clientWallet := wallet.NewTestWalletForRandomKey(t)
+	clientCert := testcertificates.NewManager(t, clientWallet).CertificateForTest().WithType(certType).
+		WithFieldValue("email", "alice@example.com").
+		Issue()
+
+	received := make(chan []*certificates.VerifiableCertificate, 1)
+	serverWallet := wallet.NewTestWalletForRandomKey(t)
+	_, url := newWsTestServer(t, &WebSocketServerOptions{
+		Wallet: serverWallet,
+		CertificatesToRequest: &utils.RequestedCertificateSet{
+			Certifiers: []*ec.PublicKey{clientCert.WalletCert.Certifier},
+			CertificateTypes: utils.RequestedCertificateTypeIDAndFieldList{
+				clientCert.WalletCert.Type: []string{"email"},
+			},
+		},
+		OnCertificatesReceived: func(_ context.Context, _ *ec.PublicKey, certs []*certificates.VerifiableCertificate) error {
+			received <- certs
+			return nil
+		},
+	})
+	client := newWsTestClient(t, url, clientWallet)
+
+	err := client.peer.ToPeer(t.Context(), []byte("hello"), serverIdentityKey(t, serverWallet), 5000)
+	require.NoError(t, err)
+	client.requireReceived(t, "echo: hello")
+
+	select {
+	case certs := <-received:
+		require.Len(t, certs, 1)
+		require.Contains(t, certs[0].Fields, wallet.CertificateFieldNameUnder50Bytes("email"))
+	case <-time.After(5 * time.Second):
+		require.Fail(t, "timed out waiting for client certificates")
+	}
+}
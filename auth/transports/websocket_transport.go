@@ -1,6 +1,7 @@
 package transports
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -12,28 +13,33 @@ import (
 	"golang.org/x/net/websocket"
 )
 
-// WebSocketTransport implements the Transport interface for WebSocket communication
-// Parity with TypeScript: only Send and OnData methods
-// Connection is managed internally and established on first Send
-// No explicit Connect/Disconnect/IsConnected
-
+// WebSocketTransport implements the Transport interface for WebSocket communication.
+// The connection is managed internally: it is established on first Send and
+// re-established on the next Send after it drops. Because sessions live in the
+// peer's SessionManager rather than on the connection, a reconnect resumes the
+// existing authenticated session without a new handshake.
 type WebSocketTransport struct {
-	baseUrl      string
-	conn         *websocket.Conn
-	onDataFuncs  []func(*auth.AuthMessage) error
-	mu           sync.Mutex
-	readDeadline time.Duration
+	baseUrl       string
+	origin        string
+	conn          *websocket.Conn
+	onDataFuncs   []func(context.Context, *auth.AuthMessage) error
+	mu            sync.Mutex
+	writeMu       sync.Mutex
+	readDeadline  time.Duration
+	writeDeadline time.Duration
 }
 
 // WebSocketTransportOptions contains configuration options for the WebSocketTransport.
 type WebSocketTransportOptions struct {
-	BaseURL      string
-	ReadDeadline int // seconds, default 30
+	BaseURL       string
+	Origin        string // Optional, defaults to http://localhost
+	ReadDeadline  int    // seconds without a message before the connection is dropped, default 30
+	WriteDeadline int    // seconds, default 30
 }
 
 // NewWebSocketTransport creates a new WebSocket transport instance with the given options.
 // The BaseURL is required and must be a valid WebSocket URL.
-// If ReadDeadline is not specified or is zero, it defaults to 30 seconds.
+// If ReadDeadline or WriteDeadline is not specified or is zero, it defaults to 30 seconds.
 func NewWebSocketTransport(options *WebSocketTransportOptions) (*WebSocketTransport, error) {
 	if options.BaseURL == "" {
 		return nil, errors.New("BaseURL is required for WebSocket transport")
@@ -46,33 +52,33 @@ func NewWebSocketTransport(options *WebSocketTransportOptions) (*WebSocketTransp
 	if readDeadline <= 0 {
 		readDeadline = 30 * time.Second
 	}
+	writeDeadline := time.Duration(options.WriteDeadline) * time.Second
+	if writeDeadline <= 0 {
+		writeDeadline = 30 * time.Second
+	}
+	origin := options.Origin
+	if origin == "" {
+		origin = "http://localhost"
+	}
 	return &WebSocketTransport{
-		baseUrl:      options.BaseURL,
-		readDeadline: readDeadline,
+		baseUrl:       options.BaseURL,
+		origin:        origin,
+		readDeadline:  readDeadline,
+		writeDeadline: writeDeadline,
 	}, nil
 }
 
 // Send sends an AuthMessage via WebSocket
-func (t *WebSocketTransport) Send(message *auth.AuthMessage) error {
+func (t *WebSocketTransport) Send(ctx context.Context, message *auth.AuthMessage) error {
 	t.mu.Lock()
 	if len(t.onDataFuncs) == 0 {
 		t.mu.Unlock()
-		return errors.New("no handler registered")
+		return ErrNoHandlerRegistered
 	}
-	conn := t.conn
 	t.mu.Unlock()
 
-	if conn == nil {
-		// Establish connection on first send
-		c, err := websocket.Dial(t.baseUrl, "", "http://localhost")
-		if err != nil {
-			return fmt.Errorf("failed to connect to WebSocket: %w", err)
-		}
-		t.mu.Lock()
-		t.conn = c
-		t.mu.Unlock()
-		go t.receiveMessages()
-		conn = c
+	if message.IdentityKey == nil {
+		return errors.New("IdentityKey is required")
 	}
 
 	jsonData, err := json.Marshal(message)
@@ -80,19 +86,24 @@ func (t *WebSocketTransport) Send(message *auth.AuthMessage) error {
 		return fmt.Errorf("failed to marshal auth message: %w", err)
 	}
 
-	_ = conn.SetDeadline(time.Now().Add(t.readDeadline))
+	conn, err := t.connect(ctx)
+	if err != nil {
+		return err
+	}
+
+	t.writeMu.Lock()
+	defer t.writeMu.Unlock()
+	_ = conn.SetWriteDeadline(time.Now().Add(t.writeDeadline))
 	err = websocket.Message.Send(conn, jsonData)
 	if err != nil {
-		t.mu.Lock()
-		t.conn = nil // Drop connection on error
-		t.mu.Unlock()
+		t.dropConn(conn)
 		return fmt.Errorf("failed to send WebSocket message: %w", err)
 	}
 	return nil
 }
 
 // OnData registers a callback for incoming messages
-func (t *WebSocketTransport) OnData(callback func(*auth.AuthMessage) error) error {
+func (t *WebSocketTransport) OnData(callback func(context.Context, *auth.AuthMessage) error) error {
 	if callback == nil {
 		return errors.New("callback cannot be nil")
 	}
@@ -102,20 +113,68 @@ func (t *WebSocketTransport) OnData(callback func(*auth.AuthMessage) error) erro
 	return nil
 }
 
-func (t *WebSocketTransport) receiveMessages() {
+// GetRegisteredOnData returns the first registered callback function for handling incoming AuthMessages.
+// Returns an error if no handlers are registered.
+func (t *WebSocketTransport) GetRegisteredOnData() (func(context.Context, *auth.AuthMessage) error, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if len(t.onDataFuncs) == 0 {
+		return nil, errors.New("no handlers registered")
+	}
+	return t.onDataFuncs[0], nil
+}
+
+// Close closes the underlying connection, if any. A later Send reconnects.
+func (t *WebSocketTransport) Close() error {
 	t.mu.Lock()
 	conn := t.conn
-	handlers := make([]func(*auth.AuthMessage) error, len(t.onDataFuncs))
-	copy(handlers, t.onDataFuncs)
+	t.conn = nil
 	t.mu.Unlock()
+	if conn == nil {
+		return nil
+	}
+	return conn.Close()
+}
+
+// connect returns the current connection, dialing a new one if needed.
+func (t *WebSocketTransport) connect(ctx context.Context) (*websocket.Conn, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.conn != nil {
+		return t.conn, nil
+	}
+
+	config, err := websocket.NewConfig(t.baseUrl, t.origin)
+	if err != nil {
+		return nil, fmt.Errorf("invalid WebSocket configuration: %w", err)
+	}
+	conn, err := config.DialContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to WebSocket: %w", err)
+	}
+	t.conn = conn
+	go t.receiveMessages(conn)
+	return conn, nil
+}
+
+// dropConn forgets conn if it is still the active connection and closes it.
+func (t *WebSocketTransport) dropConn(conn *websocket.Conn) {
+	t.mu.Lock()
+	if t.conn == conn {
+		t.conn = nil
+	}
+	t.mu.Unlock()
+	_ = conn.Close()
+}
+
+func (t *WebSocketTransport) receiveMessages(conn *websocket.Conn) {
+	defer t.dropConn(conn)
 
 	for {
 		var messageData []byte
+		_ = conn.SetReadDeadline(time.Now().Add(t.readDeadline))
 		err := websocket.Message.Receive(conn, &messageData)
 		if err != nil {
-			t.mu.Lock()
-			t.conn = nil // Drop connection on error
-			t.mu.Unlock()
 			return
 		}
 		var authMessage auth.AuthMessage
@@ -123,8 +182,14 @@ func (t *WebSocketTransport) receiveMessages() {
 		if err != nil {
 			continue
 		}
+
+		t.mu.Lock()
+		handlers := make([]func(context.Context, *auth.AuthMessage) error, len(t.onDataFuncs))
+		copy(handlers, t.onDataFuncs)
+		t.mu.Unlock()
+
 		for _, handler := range handlers {
-			_ = handler(&authMessage)
+			_ = handler(context.Background(), &authMessage)
 		}
 	}
 }
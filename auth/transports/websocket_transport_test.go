@@ -1,6 +1,7 @@
 package transports
 
 import (
+	"context"
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
@@ -129,7 +130,7 @@ func TestWebSocketTransportSendReceive(t *testing.T) {
 
 	// Register OnData handler
 	receivedMsgChan := make(chan *auth.AuthMessage, 1)
-	err = transport.OnData(func(message *auth.AuthMessage) error {
+	err = transport.OnData(func(_ context.Context, message *auth.AuthMessage) error {
 		select {
 		case receivedMsgChan <- message:
 		default:
@@ -147,7 +148,7 @@ func TestWebSocketTransportSendReceive(t *testing.T) {
 		Nonce:       "test-nonce",
 		IdentityKey: nil, // Explicitly missing
 	}
-	err = transport.Send(testMessageMissingKey)
+	err = transport.Send(t.Context(), testMessageMissingKey)
 	require.Error(t, err, "Send should return error if IdentityKey is missing")
 	require.Contains(t, err.Error(), "IdentityKey is required", "Error message should mention IdentityKey")
 
@@ -163,7 +164,7 @@ func TestWebSocketTransportSendReceive(t *testing.T) {
 		IdentityKey: pubKey,
 	}
 
-	err = transport.Send(testMessage)
+	err = transport.Send(t.Context(), testMessage)
 	require.NoError(t, err, "Send failed with valid IdentityKey")
 
 	// Wait for the message to be received back by the handler (with timeout)
@@ -196,11 +197,11 @@ func TestWebSocketTransportSendWithNoHandler(t *testing.T) {
 	}
 
 	// Send without registering a handler should fail
-	err = transport.Send(testMessage)
+	err = transport.Send(t.Context(), testMessage)
 	require.Error(t, err, "Send should return error when no handler is registered")
 
 	// Now register a handler
-	err = transport.OnData(func(message *auth.AuthMessage) error {
+	err = transport.OnData(func(_ context.Context, message *auth.AuthMessage) error {
 		return nil // Do nothing in this test
 	})
 	require.NoError(t, err, "OnData registration should succeed")
@@ -211,6 +212,39 @@ func TestWebSocketTransportSendWithNoHandler(t *testing.T) {
 	pubKey, err := ec.PublicKeyFromString(pubKeyHex)
 	require.NoError(t, err, "Failed to parse public key")
 	testMessage.IdentityKey = pubKey
-	err = transport.Send(testMessage)
+	err = transport.Send(t.Context(), testMessage)
 	require.NoError(t, err, "Send should not return error after a handler is registered")
 }
+
+func TestWebSocketTransportReadDeadline(t *testing.T) {
+	server := newTestWsServer(t)
+	defer server.Close()
+
+	transport, err := NewWebSocketTransport(&WebSocketTransportOptions{
+		BaseURL:      server.URL(),
+		ReadDeadline: 1,
+	})
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = transport.Close() })
+	received := make(chan struct{}, 1)
+	require.NoError(t, transport.OnData(func(context.Context, *auth.AuthMessage) error {
+		received <- struct{}{}
+		return nil
+	}))
+
+	pubKey, err := ec.PublicKeyFromString("02bbc996771abe50be940a9cfd91d6f28a70d139f340bedc8cdd4f236e5e9c9889")
+	require.NoError(t, err)
+	require.NoError(t, transport.Send(t.Context(), &auth.AuthMessage{MessageType: "test-type", IdentityKey: pubKey}))
+	select {
+	case <-received:
+	case <-time.After(2 * time.Second):
+		t.Fatal("Timeout waiting for message reception")
+	}
+
+	// An idle connection is dropped once the read deadline passes.
+	require.Eventually(t, func() bool {
+		transport.mu.Lock()
+		defer transport.mu.Unlock()
+		return transport.conn == nil
+	}, 3*time.Second, 50*time.Millisecond)
+}
@@ -88,19 +88,19 @@ type Transport interface {
 // PeerSession represents a session with a peer
 type PeerSession struct {
 	// Whether the session is authenticated
-	IsAuthenticated bool
+	IsAuthenticated bool `json:"isAuthenticated"`
 
 	// The session nonce
-	SessionNonce string
+	SessionNonce string `json:"sessionNonce"`
 
 	// The peer's nonce
-	PeerNonce string
+	PeerNonce string `json:"peerNonce,omitempty"`
 
 	// The peer's identity key
-	PeerIdentityKey *ec.PublicKey
+	PeerIdentityKey *ec.PublicKey `json:"peerIdentityKey,omitempty"`
 
 	// The last time the session was updated (milliseconds since epoch)
-	LastUpdate int64
+	LastUpdate int64 `json:"lastUpdate"`
 }
 
 // CertificateQuery defines criteria for retrieving certificates
@@ -0,0 +1,381 @@
+// Package inspect describes the contents of BEEF payloads for debugging: the
+// transaction graph, the merkle proofs (BUMPs), fees, script types and whether
+// the payload is valid. It accepts BEEF V1 (BRC-64), BEEF V2 (BRC-96) and
+// Atomic BEEF (BRC-95), as produced by any SDK.
+package inspect
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/bsv-blockchain/go-sdk/chainhash"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+	"github.com/bsv-blockchain/go-sdk/transaction/chaintracker"
+)
+
+// ErrNotBEEF is returned by Inspect when the payload doesn't start with a BEEF
+// version.
+var ErrNotBEEF = errors.New("not a BEEF payload")
+
+// Transaction statuses.
+const (
+	// StatusValid is the status of transactions proven by a BUMP or whose inputs
+	// all descend from proven transactions.
+	StatusValid = "valid"
+	// StatusTxidOnly is the status of transactions included by txid only.
+	StatusTxidOnly = "txid only"
+	// StatusMissingInputs is the status of transactions spending outputs of
+	// transactions the BEEF doesn't include.
+	StatusMissingInputs = "missing inputs"
+	// StatusNotValid is the status of the other transactions, which descend from
+	// transactions that are neither proven nor included.
+	StatusNotValid = "not valid"
+)
+
+// Report describes a BEEF payload.
+type Report struct {
+	// Version is the BEEF version: "BEEF_V1", "BEEF_V2" or "ATOMIC_BEEF".
+	Version string `json:"version"`
+	// Subject is the txid an Atomic BEEF is about.
+	Subject string `json:"subject,omitempty"`
+	// SubjectFound reports whether the subject of an Atomic BEEF is included.
+	SubjectFound bool          `json:"subjectFound,omitempty"`
+	Bumps        []Bump        `json:"bumps"`
+	Transactions []Transaction `json:"transactions"`
+	Totals       Totals        `json:"totals"`
+	Validity     Validity      `json:"validity"`
+
+	beef *transaction.Beef
+}
+
+// Bump describes a BUMP, the merkle proof of transactions in a block.
+type Bump struct {
+	Index       int    `json:"index"`
+	BlockHeight uint32 `json:"blockHeight"`
+	// MerkleRoot is the root computed from the proof, empty if it can't be.
+	MerkleRoot string `json:"merkleRoot,omitempty"`
+	// Txids are the transactions the BUMP proves.
+	Txids []string `json:"txids"`
+}
+
+// Transaction describes a transaction of the BEEF.
+type Transaction struct {
+	Txid string `json:"txid"`
+	// Format is how the transaction is included: "raw", "raw with bump" or
+	// "txid only".
+	Format string `json:"format"`
+	// BumpIndex is the BUMP proving the transaction, if any.
+	BumpIndex *int   `json:"bumpIndex,omitempty"`
+	Status    string `json:"status"`
+	// Size is the size of the raw transaction in bytes.
+	Size    int      `json:"size,omitempty"`
+	Inputs  []Input  `json:"inputs,omitempty"`
+	Outputs []Output `json:"outputs,omitempty"`
+	// Fee is the fee paid by the transaction, known when all its inputs spend
+	// transactions included in the BEEF.
+	Fee *uint64 `json:"fee,omitempty"`
+	// FeeRate is the fee in satoshis per kilobyte.
+	FeeRate float64 `json:"feeRate,omitempty"`
+	// Parents are the transactions of the BEEF spent by the transaction.
+	Parents []string `json:"parents,omitempty"`
+}
+
+// Input describes a transaction input.
+type Input struct {
+	Outpoint string `json:"outpoint"`
+	// Satoshis and ScriptType describe the spent output, known when its
+	// transaction is included in the BEEF.
+	Satoshis   *uint64 `json:"satoshis,omitempty"`
+	ScriptType string  `json:"scriptType,omitempty"`
+}
+
+// Output describes a transaction output.
+type Output struct {
+	Index      uint32 `json:"index"`
+	Satoshis   uint64 `json:"satoshis"`
+	ScriptType string `json:"scriptType"`
+	// SpentBy is the input of a BEEF transaction spending the output, if any.
+	SpentBy string `json:"spentBy,omitempty"`
+}
+
+// Totals sums up the transactions of the BEEF.
+type Totals struct {
+	Transactions int `json:"transactions"`
+	// Size is the total size of the raw transactions.
+	Size int `json:"size"`
+	// Fees is the total fee of the transactions whose fee is known.
+	Fees uint64 `json:"fees"`
+	// FeesKnown is the number of transactions whose fee is known.
+	FeesKnown int `json:"feesKnown"`
+}
+
+// Validity reports whether the BEEF is valid. A BEEF is valid when its
+// transactions are all valid and its BUMPs are consistent. Whether the merkle
+// roots belong to the chain is only checked by VerifyRoots.
+type Validity struct {
+	Valid bool `json:"valid"`
+	// ValidWithTxidOnly reports whether the BEEF is valid when transactions
+	// included by txid only are trusted.
+	ValidWithTxidOnly bool     `json:"validWithTxidOnly"`
+	NotValid          []string `json:"notValid,omitempty"`
+	WithMissingInputs []string `json:"withMissingInputs,omitempty"`
+	// MissingInputs are the txids spent by transactions of the BEEF but not
+	// included in it.
+	MissingInputs []string `json:"missingInputs,omitempty"`
+	// RootsVerified is set by VerifyRoots to whether every merkle root was
+	// confirmed by the chain tracker.
+	RootsVerified *bool `json:"rootsVerified,omitempty"`
+}
+
+// Inspect parses a BEEF payload and describes it.
+func Inspect(data []byte) (*Report, error) {
+	if len(data) < 4 {
+		return nil, ErrNotBEEF
+	}
+	r := &Report{}
+	payload := data
+	switch binary.LittleEndian.Uint32(data) {
+	case transaction.ATOMIC_BEEF:
+		if len(data) < 40 {
+			return nil, fmt.Errorf("%w: atomic BEEF is too short", ErrNotBEEF)
+		}
+		subject, err := chainhash.NewHash(data[4:36])
+		if err != nil {
+			return nil, err
+		}
+		r.Version = "ATOMIC_BEEF"
+		r.Subject = subject.String()
+		payload = data[36:]
+		if v := binary.LittleEndian.Uint32(payload); v != transaction.BEEF_V1 && v != transaction.BEEF_V2 {
+			return nil, fmt.Errorf("%w: atomic BEEF holds version %d", ErrNotBEEF, v)
+		}
+	case transaction.BEEF_V1:
+		r.Version = "BEEF_V1"
+	case transaction.BEEF_V2:
+		r.Version = "BEEF_V2"
+	default:
+		return nil, ErrNotBEEF
+	}
+
+	beef, err := transaction.NewBeefFromBytes(payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse BEEF: %w", err)
+	}
+	r.beef = beef
+
+	r.describeBumps()
+	r.describeTransactions()
+	r.describeValidity()
+	if r.Subject != "" {
+		r.SubjectFound = slices.ContainsFunc(r.Transactions, func(tx Transaction) bool {
+			return tx.Txid == r.Subject
+		})
+	}
+	return r, nil
+}
+
+// Beef returns the parsed BEEF.
+func (r *Report) Beef() *transaction.Beef {
+	return r.beef
+}
+
+// VerifyRoots checks the merkle roots of the BUMPs against the chain tracker,
+// recording the result in Validity.RootsVerified.
+func (r *Report) VerifyRoots(ctx context.Context, tracker chaintracker.ChainTracker) error {
+	verified := true
+	for _, bump := range r.Bumps {
+		if bump.MerkleRoot == "" {
+			verified = false
+			break
+		}
+		root, err := chainhash.NewHashFromHex(bump.MerkleRoot)
+		if err != nil {
+			return err
+		}
+		ok, err := tracker.IsValidRootForHeight(ctx, root, bump.BlockHeight)
+		if err != nil {
+			return fmt.Errorf("failed to verify the root of BUMP %d: %w", bump.Index, err)
+		}
+		if !ok {
+			verified = false
+			break
+		}
+	}
+	r.Validity.RootsVerified = &verified
+	return nil
+}
+
+func (r *Report) describeBumps() {
+	r.Bumps = make([]Bump, 0, len(r.beef.BUMPs))
+	for i, mp := range r.beef.BUMPs {
+		bump := Bump{Index: i, BlockHeight: mp.BlockHeight, Txids: []string{}}
+		if len(mp.Path) > 0 {
+			for _, leaf := range mp.Path[0] {
+				if leaf.Hash != nil && leaf.Txid != nil && *leaf.Txid {
+					bump.Txids = append(bump.Txids, leaf.Hash.String())
+				}
+			}
+			if root, err := mp.ComputeRoot(nil); err == nil {
+				bump.MerkleRoot = root.String()
+			}
+		}
+		r.Bumps = append(r.Bumps, bump)
+	}
+}
+
+func (r *Report) describeTransactions() {
+	// Map the outputs spent within the BEEF to their spenders.
+	spentBy := make(map[transaction.Outpoint]string)
+	for txid, btx := range r.beef.Transactions {
+		if btx.DataFormat == transaction.TxIDOnly {
+			continue
+		}
+		for vin, in := range btx.Transaction.Inputs {
+			spentBy[transaction.Outpoint{Txid: *in.SourceTXID, Index: in.SourceTxOutIndex}] = fmt.Sprintf("%s.%d", txid, vin)
+		}
+	}
+
+	r.Transactions = make([]Transaction, 0, len(r.beef.Transactions))
+	for _, txid := range r.order() {
+		btx := r.beef.Transactions[txid]
+		tx := Transaction{Txid: txid.String()}
+		switch btx.DataFormat {
+		case transaction.TxIDOnly:
+			tx.Format = "txid only"
+			r.Transactions = append(r.Transactions, tx)
+			continue
+		case transaction.RawTxAndBumpIndex:
+			tx.Format = "raw with bump"
+			bumpIndex := btx.BumpIndex
+			tx.BumpIndex = &bumpIndex
+		default:
+			tx.Format = "raw"
+		}
+
+		raw := btx.Transaction
+		tx.Size = len(raw.Bytes())
+		r.Totals.Size += tx.Size
+
+		var inputSats uint64
+		feeKnown := len(raw.Inputs) > 0
+		for _, in := range raw.Inputs {
+			input := Input{Outpoint: fmt.Sprintf("%s.%d", in.SourceTXID, in.SourceTxOutIndex)}
+			if parent, ok := r.beef.Transactions[*in.SourceTXID]; ok && parent.DataFormat != transaction.TxIDOnly {
+				if !slices.Contains(tx.Parents, in.SourceTXID.String()) {
+					tx.Parents = append(tx.Parents, in.SourceTXID.String())
+				}
+				if int(in.SourceTxOutIndex) < len(parent.Transaction.Outputs) {
+					out := parent.Transaction.Outputs[in.SourceTxOutIndex]
+					sats := out.Satoshis
+					input.Satoshis = &sats
+					input.ScriptType = out.LockingScript.Type()
+					inputSats += sats
+				} else {
+					feeKnown = false
+				}
+			} else {
+				feeKnown = false
+			}
+			tx.Inputs = append(tx.Inputs, input)
+		}
+
+		var outputSats uint64
+		for vout, out := range raw.Outputs {
+			outputSats += out.Satoshis
+			tx.Outputs = append(tx.Outputs, Output{
+				Index:      uint32(vout),
+				Satoshis:   out.Satoshis,
+				ScriptType: out.LockingScript.Type(),
+				SpentBy:    spentBy[transaction.Outpoint{Txid: txid, Index: uint32(vout)}],
+			})
+		}
+
+		if feeKnown && inputSats >= outputSats {
+			fee := inputSats - outputSats
+			tx.Fee = &fee
+			if tx.Size > 0 {
+				tx.FeeRate = float64(fee) * 1000 / float64(tx.Size)
+			}
+			r.Totals.Fees += fee
+			r.Totals.FeesKnown++
+		}
+		r.Transactions = append(r.Transactions, tx)
+	}
+	r.Totals.Transactions = len(r.Transactions)
+}
+
+// order sorts the txids of the BEEF with parents before their children, and by
+// txid otherwise, so reports are stable even though the BEEF is a map.
+func (r *Report) order() []chainhash.Hash {
+	txids := make([]chainhash.Hash, 0, len(r.beef.Transactions))
+	for txid := range r.beef.Transactions {
+		txids = append(txids, txid)
+	}
+	slices.SortFunc(txids, func(a, b chainhash.Hash) int {
+		return strings.Compare(a.String(), b.String())
+	})
+
+	ordered := make([]chainhash.Hash, 0, len(txids))
+	visited := make(map[chainhash.Hash]bool, len(txids))
+	var visit func(txid chainhash.Hash)
+	visit = func(txid chainhash.Hash) {
+		if visited[txid] {
+			return
+		}
+		visited[txid] = true
+		btx := r.beef.Transactions[txid]
+		if btx.DataFormat != transaction.TxIDOnly {
+			for _, in := range btx.Transaction.Inputs {
+				if _, ok := r.beef.Transactions[*in.SourceTXID]; ok {
+					visit(*in.SourceTXID)
+				}
+			}
+		}
+		ordered = append(ordered, txid)
+	}
+	for _, txid := range txids {
+		visit(txid)
+	}
+	return ordered
+}
+
+func (r *Report) describeValidity() {
+	result := r.beef.ValidateTransactions()
+	r.Validity = Validity{
+		Valid:             r.beef.IsValid(false),
+		ValidWithTxidOnly: r.beef.IsValid(true),
+		NotValid:          sorted(result.NotValid),
+		WithMissingInputs: sorted(result.WithMissingInputs),
+		MissingInputs:     sorted(result.MissingInputs),
+	}
+
+	status := make(map[string]string, len(r.Transactions))
+	for _, txid := range result.NotValid {
+		status[txid] = StatusNotValid
+	}
+	for _, txid := range result.WithMissingInputs {
+		status[txid] = StatusMissingInputs
+	}
+	for _, txid := range result.TxidOnly {
+		status[txid] = StatusTxidOnly
+	}
+	for _, txid := range result.Valid {
+		status[txid] = StatusValid
+	}
+	for i := range r.Transactions {
+		if s, ok := status[r.Transactions[i].Txid]; ok {
+			r.Transactions[i].Status = s
+		} else {
+			r.Transactions[i].Status = StatusNotValid
+		}
+	}
+}
+
+func sorted(s []string) []string {
+	slices.Sort(s)
+	return s
+}
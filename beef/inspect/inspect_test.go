@@ -0,0 +1,128 @@
+package inspect
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"testing"
+
+	"github.com/bsv-blockchain/go-sdk/chainhash"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+	"github.com/stretchr/testify/require"
+)
+
+// BRC62Hex is the BEEF example of BRC-62: a mined transaction and a child spending it.
+const BRC62Hex = "0100beef01fe636d0c0007021400fe507c0c7aa754cef1f7889d5fd395cf1f785dd7de98eed895dbedfe4e5bc70d1502ac4e164f5bc16746bb0868404292ac8318bbac3800e4aad13a014da427adce3e010b00bc4ff395efd11719b277694cface5aa50d085a0bb81f613f70313acd28cf4557010400574b2d9142b8d28b61d88e3b2c3f44d858411356b49a28a4643b6d1a6a092a5201030051a05fc84d531b5d250c23f4f886f6812f9fe3f402d61607f977b4ecd2701c19010000fd781529d58fc2523cf396a7f25440b409857e7e221766c57214b1d38c7b481f01010062f542f45ea3660f86c013ced80534cb5fd4c19d66c56e7e8c5d4bf2d40acc5e010100b121e91836fd7cd5102b654e9f72f3cf6fdbfd0b161c53a9c54b12c841126331020100000001cd4e4cac3c7b56920d1e7655e7e260d31f29d9a388d04910f1bbd72304a79029010000006b483045022100e75279a205a547c445719420aa3138bf14743e3f42618e5f86a19bde14bb95f7022064777d34776b05d816daf1699493fcdf2ef5a5ab1ad710d9c97bfb5b8f7cef3641210263e2dee22b1ddc5e11f6fab8bcd2378bdd19580d640501ea956ec0e786f93e76ffffffff013e660000000000001976a9146bfd5c7fbe21529d45803dbcf0c87dd3c71efbc288ac0000000001000100000001ac4e164f5bc16746bb0868404292ac8318bbac3800e4aad13a014da427adce3e000000006a47304402203a61a2e931612b4bda08d541cfb980885173b8dcf64a3471238ae7abcd368d6402204cbf24f04b9aa2256d8901f0ed97866603d2be8324c2bfb7a37bf8fc90edd5b441210263e2dee22b1ddc5e11f6fab8bcd2378bdd19580d640501ea956ec0e786f93e76ffffffff013c660000000000001976a9146bfd5c7fbe21529d45803dbcf0c87dd3c71efbc288ac0000000000"
+
+type rootTracker struct {
+	valid bool
+}
+
+func (r rootTracker) IsValidRootForHeight(context.Context, *chainhash.Hash, uint32) (bool, error) {
+	return r.valid, nil
+}
+
+func (r rootTracker) CurrentHeight(context.Context) (uint32, error) {
+	return 800000, nil
+}
+
+func TestInspect(t *testing.T) {
+	data, err := hex.DecodeString(BRC62Hex)
+	require.NoError(t, err)
+
+	report, err := Inspect(data)
+	require.NoError(t, err)
+	require.Equal(t, "BEEF_V1", report.Version)
+	require.Len(t, report.Bumps, 1)
+	require.Equal(t, uint32(814435), report.Bumps[0].BlockHeight)
+	require.NotEmpty(t, report.Bumps[0].MerkleRoot)
+
+	require.Len(t, report.Transactions, 2)
+	parent, child := report.Transactions[0], report.Transactions[1]
+	require.Equal(t, []string{parent.Txid}, report.Bumps[0].Txids)
+	require.Equal(t, "raw with bump", parent.Format)
+	require.Equal(t, StatusValid, parent.Status)
+	require.Nil(t, parent.Fee, "the inputs of the parent are not in the BEEF")
+	require.Equal(t, "raw", child.Format)
+	require.Equal(t, StatusValid, child.Status)
+	require.Equal(t, []string{parent.Txid}, child.Parents)
+	require.Equal(t, "pubkeyhash", parent.Outputs[0].ScriptType)
+	require.Equal(t, child.Txid+".0", parent.Outputs[0].SpentBy)
+	require.Equal(t, "pubkeyhash", child.Inputs[0].ScriptType)
+	require.NotNil(t, child.Fee)
+	require.Equal(t, uint64(2), *child.Fee)
+	require.Equal(t, 1, report.Totals.FeesKnown)
+	require.Equal(t, uint64(2), report.Totals.Fees)
+	require.True(t, report.Validity.Valid)
+
+	require.NoError(t, report.VerifyRoots(t.Context(), rootTracker{valid: true}))
+	require.True(t, *report.Validity.RootsVerified)
+	require.NoError(t, report.VerifyRoots(t.Context(), rootTracker{valid: false}))
+	require.False(t, *report.Validity.RootsVerified)
+
+	var text bytes.Buffer
+	require.NoError(t, report.WriteText(&text))
+	require.Contains(t, text.String(), "BEEF_V1 with 1 BUMPs and 2 transactions, valid\n")
+	require.Contains(t, text.String(), "  proves "+parent.Txid+"\n")
+	require.Contains(t, text.String(), "  fee 2 sat")
+	require.Contains(t, text.String(), "merkle roots NOT verified\n")
+
+	t.Run("atomic", func(t *testing.T) {
+		txid, err := chainhash.NewHashFromHex(child.Txid)
+		require.NoError(t, err)
+		// Beef.Bytes writes the V2 layout whatever the version.
+		beef := report.Beef().Clone()
+		beef.Version = transaction.BEEF_V2
+		atomic, err := beef.AtomicBytes(txid)
+		require.NoError(t, err)
+
+		report, err := Inspect(atomic)
+		require.NoError(t, err)
+		require.Equal(t, "ATOMIC_BEEF", report.Version)
+		require.Equal(t, child.Txid, report.Subject)
+		require.True(t, report.SubjectFound)
+		require.Len(t, report.Transactions, 2)
+	})
+
+	t.Run("missing inputs", func(t *testing.T) {
+		beef := transaction.NewBeefV2()
+		raw, err := hex.DecodeString(BRC62Hex)
+		require.NoError(t, err)
+		orphan, err := transaction.NewTransactionFromBEEF(raw)
+		require.NoError(t, err)
+		_, err = beef.MergeRawTx(orphan.Bytes(), nil)
+		require.NoError(t, err)
+		data, err := beef.Bytes()
+		require.NoError(t, err)
+
+		report, err := Inspect(data)
+		require.NoError(t, err)
+		require.Equal(t, "BEEF_V2", report.Version)
+		require.Len(t, report.Transactions, 1)
+		require.Equal(t, StatusMissingInputs, report.Transactions[0].Status)
+		require.Nil(t, report.Transactions[0].Inputs[0].Satoshis)
+		require.False(t, report.Validity.Valid)
+		require.Equal(t, []string{parent.Txid}, report.Validity.MissingInputs)
+	})
+
+	t.Run("not BEEF", func(t *testing.T) {
+		for _, data := range [][]byte{nil, {1, 2}, {1, 0, 0, 0, 0}, {1, 1, 1, 1}} {
+			_, err := Inspect(data)
+			require.ErrorIs(t, err, ErrNotBEEF)
+		}
+	})
+
+	t.Run("bad bump index", func(t *testing.T) {
+		// The child claims to be proven by a BUMP the BEEF doesn't have.
+		beef := transaction.NewBeefV2()
+		_, err := beef.MergeRawTx(report.Beef().FindTransaction(child.Txid).Bytes(), nil)
+		require.NoError(t, err)
+		data, err := beef.Bytes()
+		require.NoError(t, err)
+		data[6] = byte(transaction.RawTxAndBumpIndex)
+		data = append(data[:7], append([]byte{5}, data[7:]...)...)
+
+		_, err = Inspect(data)
+		require.ErrorContains(t, err, "invalid bump index 5")
+	})
+}
@@ -0,0 +1,93 @@
+package inspect
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// WriteText pretty-prints the report for humans, listing the transactions with
+// their parents first.
+func (r *Report) WriteText(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+
+	validity := "not valid"
+	switch {
+	case r.Validity.Valid:
+		validity = "valid"
+	case r.Validity.ValidWithTxidOnly:
+		validity = "valid when trusting txid only transactions"
+	}
+	fmt.Fprintf(bw, "%s with %d BUMPs and %d transactions, %s\n", r.Version, len(r.Bumps), len(r.Transactions), validity)
+	if r.Subject != "" {
+		found := "included"
+		if !r.SubjectFound {
+			found = "NOT included"
+		}
+		fmt.Fprintf(bw, "subject %s (%s)\n", r.Subject, found)
+	}
+
+	for _, bump := range r.Bumps {
+		root := bump.MerkleRoot
+		if root == "" {
+			root = "cannot be computed"
+		}
+		fmt.Fprintf(bw, "\nBUMP %d at block %d, merkle root %s\n", bump.Index, bump.BlockHeight, root)
+		for _, txid := range bump.Txids {
+			fmt.Fprintf(bw, "  proves %s\n", txid)
+		}
+	}
+
+	for _, tx := range r.Transactions {
+		fmt.Fprintf(bw, "\nTX %s\n", tx.Txid)
+		format := tx.Format
+		if tx.BumpIndex != nil {
+			format = fmt.Sprintf("%s %d", format, *tx.BumpIndex)
+		}
+		if tx.Size > 0 {
+			fmt.Fprintf(bw, "  %s, %d bytes, %s\n", format, tx.Size, tx.Status)
+		} else {
+			fmt.Fprintf(bw, "  %s, %s\n", format, tx.Status)
+		}
+		for i, in := range tx.Inputs {
+			fmt.Fprintf(bw, "  in  %d  %s", i, in.Outpoint)
+			if in.Satoshis != nil {
+				fmt.Fprintf(bw, "  %d sat %s", *in.Satoshis, in.ScriptType)
+			} else {
+				fmt.Fprint(bw, "  (not in BEEF)")
+			}
+			fmt.Fprintln(bw)
+		}
+		for _, out := range tx.Outputs {
+			fmt.Fprintf(bw, "  out %d  %d sat %s", out.Index, out.Satoshis, out.ScriptType)
+			if out.SpentBy != "" {
+				fmt.Fprintf(bw, "  spent by %s", out.SpentBy)
+			}
+			fmt.Fprintln(bw)
+		}
+		if tx.Fee != nil {
+			fmt.Fprintf(bw, "  fee %d sat (%.3f sat/kB)\n", *tx.Fee, tx.FeeRate)
+		}
+	}
+
+	fmt.Fprintf(bw, "\ntotal %d bytes, fees %d sat over %d of %d transactions\n",
+		r.Totals.Size, r.Totals.Fees, r.Totals.FeesKnown, r.Totals.Transactions)
+	writeList(bw, "not valid", r.Validity.NotValid)
+	writeList(bw, "with missing inputs", r.Validity.WithMissingInputs)
+	writeList(bw, "missing inputs", r.Validity.MissingInputs)
+	if verified := r.Validity.RootsVerified; verified != nil {
+		if *verified {
+			fmt.Fprintln(bw, "merkle roots verified")
+		} else {
+			fmt.Fprintln(bw, "merkle roots NOT verified")
+		}
+	}
+	return bw.Flush()
+}
+
+func writeList(w io.Writer, title string, txids []string) {
+	if len(txids) > 0 {
+		fmt.Fprintf(w, "%s: %s\n", title, strings.Join(txids, ", "))
+	}
+}
@@ -0,0 +1,111 @@
+package store
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"slices"
+	"sync"
+)
+
+// ErrNotFound is returned for keys and transactions absent from the store.
+var ErrNotFound = errors.New("not found")
+
+// KV is the key-value storage persisting a Store. Keys are compared bytewise.
+// It is small enough for any embedded database to implement in a few lines.
+// With badger, for example:
+//
+//	func (kv badgerKV) Get(_ context.Context, key []byte) (value []byte, err error) {
+//		err = kv.db.View(func(txn *badger.Txn) error {
+//			item, err := txn.Get(key)
+//			if errors.Is(err, badger.ErrKeyNotFound) {
+//				return store.ErrNotFound
+//			} else if err != nil {
+//				return err
+//			}
+//			value, err = item.ValueCopy(nil)
+//			return err
+//		})
+//		return value, err
+//	}
+//
+// SQLKV persists the store in an SQL database such as SQLite.
+type KV interface {
+	// Get returns the value of key, or ErrNotFound.
+	Get(ctx context.Context, key []byte) ([]byte, error)
+	// Put sets the value of key.
+	Put(ctx context.Context, key, value []byte) error
+	// Delete removes key. Deleting a missing key is not an error.
+	Delete(ctx context.Context, key []byte) error
+	// Scan calls fn with the keys starting with prefix and their values, in
+	// the order of the keys, stopping at the first error.
+	Scan(ctx context.Context, prefix []byte, fn func(key, value []byte) error) error
+}
+
+// ensure that MemoryKV is implementing KV
+var _ KV = (*MemoryKV)(nil)
+
+// MemoryKV is a KV kept in memory, for tests and short-lived processes.
+type MemoryKV struct {
+	mu     sync.RWMutex
+	values map[string][]byte
+}
+
+// NewMemoryKV creates an empty MemoryKV.
+func NewMemoryKV() *MemoryKV {
+	return &MemoryKV{values: make(map[string][]byte)}
+}
+
+// Get returns the value of key, or ErrNotFound.
+func (kv *MemoryKV) Get(_ context.Context, key []byte) ([]byte, error) {
+	kv.mu.RLock()
+	defer kv.mu.RUnlock()
+	value, ok := kv.values[string(key)]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return bytes.Clone(value), nil
+}
+
+// Put sets the value of key.
+func (kv *MemoryKV) Put(_ context.Context, key, value []byte) error {
+	kv.mu.Lock()
+	defer kv.mu.Unlock()
+	kv.values[string(key)] = bytes.Clone(value)
+	return nil
+}
+
+// Delete removes key.
+func (kv *MemoryKV) Delete(_ context.Context, key []byte) error {
+	kv.mu.Lock()
+	defer kv.mu.Unlock()
+	delete(kv.values, string(key))
+	return nil
+}
+
+// Scan calls fn with the keys starting with prefix and their values. fn may
+// modify the store.
+func (kv *MemoryKV) Scan(_ context.Context, prefix []byte, fn func(key, value []byte) error) error {
+	kv.mu.RLock()
+	var keys []string
+	for key := range kv.values {
+		if bytes.HasPrefix([]byte(key), prefix) {
+			keys = append(keys, key)
+		}
+	}
+	kv.mu.RUnlock()
+	slices.Sort(keys)
+
+	for _, key := range keys {
+		kv.mu.RLock()
+		value, ok := kv.values[key]
+		kv.mu.RUnlock()
+		if !ok {
+			continue
+		}
+		if err := fn([]byte(key), bytes.Clone(value)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,90 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"regexp"
+)
+
+// DefaultSQLTable is the table of SQLKV by default.
+const DefaultSQLTable = "beef_store"
+
+var tableName = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// ensure that SQLKV is implementing KV
+var _ KV = (*SQLKV)(nil)
+
+// SQLKV is a KV stored in a table of an SQL database with ? placeholders and
+// upserts, such as SQLite. The driver is registered by the application, with
+// modernc.org/sqlite or github.com/mattn/go-sqlite3 for example.
+type SQLKV struct {
+	db    *sql.DB
+	table string
+}
+
+// NewSQLKV creates the table of the store in db, DefaultSQLTable for an empty
+// table, unless it exists.
+func NewSQLKV(ctx context.Context, db *sql.DB, table string) (*SQLKV, error) {
+	if table == "" {
+		table = DefaultSQLTable
+	}
+	if !tableName.MatchString(table) {
+		return nil, fmt.Errorf("invalid table name %q", table)
+	}
+	if _, err := db.ExecContext(ctx, "CREATE TABLE IF NOT EXISTS "+table+" (k BLOB PRIMARY KEY, v BLOB NOT NULL)"); err != nil {
+		return nil, fmt.Errorf("failed to create table %s: %w", table, err)
+	}
+	return &SQLKV{db: db, table: table}, nil
+}
+
+// Get returns the value of key, or ErrNotFound.
+func (kv *SQLKV) Get(ctx context.Context, key []byte) ([]byte, error) {
+	var value []byte
+	err := kv.db.QueryRowContext(ctx, "SELECT v FROM "+kv.table+" WHERE k = ?", key).Scan(&value)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrNotFound
+	}
+	return value, err
+}
+
+// Put sets the value of key.
+func (kv *SQLKV) Put(ctx context.Context, key, value []byte) error {
+	_, err := kv.db.ExecContext(ctx, "INSERT INTO "+kv.table+" (k, v) VALUES (?, ?) ON CONFLICT (k) DO UPDATE SET v = excluded.v", key, value)
+	return err
+}
+
+// Delete removes key.
+func (kv *SQLKV) Delete(ctx context.Context, key []byte) error {
+	_, err := kv.db.ExecContext(ctx, "DELETE FROM "+kv.table+" WHERE k = ?", key)
+	return err
+}
+
+// Scan calls fn with the keys starting with prefix and their values. The rows
+// are read before fn is called, so fn may modify the store.
+func (kv *SQLKV) Scan(ctx context.Context, prefix []byte, fn func(key, value []byte) error) error {
+	rows, err := kv.db.QueryContext(ctx, "SELECT k, v FROM "+kv.table+" WHERE substr(k, 1, ?) = ? ORDER BY k", len(prefix), prefix)
+	if err != nil {
+		return err
+	}
+	type entry struct{ key, value []byte }
+	var entries []entry
+	for rows.Next() {
+		var e entry
+		if err = rows.Scan(&e.key, &e.value); err != nil {
+			rows.Close()
+			return err
+		}
+		entries = append(entries, e)
+	}
+	if err = errors.Join(rows.Err(), rows.Close()); err != nil {
+		return err
+	}
+	for _, e := range entries {
+		if err = fn(e.key, e.value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
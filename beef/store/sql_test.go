@@ -0,0 +1,208 @@
+package store
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"regexp"
+	"slices"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// The statements of SQLKV understood by the test driver, with the table name as
+// first submatch.
+var (
+	createTableQuery = regexp.MustCompile(`^CREATE TABLE IF NOT EXISTS (\w+) \(k BLOB PRIMARY KEY, v BLOB NOT NULL\)$`)
+	getQuery         = regexp.MustCompile(`^SELECT v FROM (\w+) WHERE k = \?$`)
+	putQuery         = regexp.MustCompile(`^INSERT INTO (\w+) \(k, v\) VALUES \(\?, \?\) ON CONFLICT \(k\) DO UPDATE SET v = excluded\.v$`)
+	deleteQuery      = regexp.MustCompile(`^DELETE FROM (\w+) WHERE k = \?$`)
+	scanQuery        = regexp.MustCompile(`^SELECT k, v FROM (\w+) WHERE substr\(k, 1, \?\) = \? ORDER BY k$`)
+)
+
+// kvDriver is an in-memory database/sql driver executing the statements of
+// SQLKV as SQLite would, with one database per data source name.
+type kvDriver struct {
+	mu  sync.Mutex
+	dbs map[string]map[string]map[string][]byte
+}
+
+var testKVDriver = &kvDriver{dbs: make(map[string]map[string]map[string][]byte)}
+
+func init() {
+	sql.Register("beefstoretest", testKVDriver)
+}
+
+func (d *kvDriver) Open(name string) (driver.Conn, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.dbs[name] == nil {
+		d.dbs[name] = make(map[string]map[string][]byte)
+	}
+	return &kvConn{driver: d, tables: d.dbs[name]}, nil
+}
+
+type kvConn struct {
+	driver *kvDriver
+	tables map[string]map[string][]byte
+}
+
+func (c *kvConn) Prepare(string) (driver.Stmt, error) {
+	return nil, errors.New("prepared statements not supported")
+}
+
+func (c *kvConn) Close() error { return nil }
+
+func (c *kvConn) Begin() (driver.Tx, error) {
+	return nil, errors.New("transactions not supported")
+}
+
+// table returns the table named by the first submatch of query in sql.
+func (c *kvConn) table(query *regexp.Regexp, sql string) (map[string][]byte, error) {
+	name := query.FindStringSubmatch(sql)[1]
+	table, ok := c.tables[name]
+	if !ok {
+		return nil, fmt.Errorf("no such table: %s", name)
+	}
+	return table, nil
+}
+
+func (c *kvConn) ExecContext(_ context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	c.driver.mu.Lock()
+	defer c.driver.mu.Unlock()
+	if m := createTableQuery.FindStringSubmatch(query); m != nil {
+		if c.tables[m[1]] == nil {
+			c.tables[m[1]] = make(map[string][]byte)
+		}
+		return driver.RowsAffected(0), nil
+	}
+	switch {
+	case putQuery.MatchString(query):
+		table, err := c.table(putQuery, query)
+		if err != nil {
+			return nil, err
+		}
+		table[string(args[0].Value.([]byte))] = bytes.Clone(args[1].Value.([]byte))
+	case deleteQuery.MatchString(query):
+		table, err := c.table(deleteQuery, query)
+		if err != nil {
+			return nil, err
+		}
+		delete(table, string(args[0].Value.([]byte)))
+	default:
+		return nil, fmt.Errorf("unexpected statement %q", query)
+	}
+	return driver.RowsAffected(1), nil
+}
+
+func (c *kvConn) QueryContext(_ context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	c.driver.mu.Lock()
+	defer c.driver.mu.Unlock()
+	switch {
+	case getQuery.MatchString(query):
+		table, err := c.table(getQuery, query)
+		if err != nil {
+			return nil, err
+		}
+		rows := &kvRows{columns: []string{"v"}}
+		if value, ok := table[string(args[0].Value.([]byte))]; ok {
+			rows.rows = append(rows.rows, []driver.Value{bytes.Clone(value)})
+		}
+		return rows, nil
+	case scanQuery.MatchString(query):
+		table, err := c.table(scanQuery, query)
+		if err != nil {
+			return nil, err
+		}
+		length, prefix := args[0].Value.(int64), args[1].Value.([]byte)
+		rows := &kvRows{columns: []string{"k", "v"}}
+		for _, key := range slices.Sorted(maps.Keys(table)) {
+			// substr(k, 1, n) is the first n bytes of the blob k.
+			if bytes.Equal([]byte(key)[:min(int(length), len(key))], prefix) {
+				rows.rows = append(rows.rows, []driver.Value{[]byte(key), bytes.Clone(table[key])})
+			}
+		}
+		return rows, nil
+	default:
+		return nil, fmt.Errorf("unexpected query %q", query)
+	}
+}
+
+type kvRows struct {
+	columns []string
+	rows    [][]driver.Value
+}
+
+func (r *kvRows) Columns() []string { return r.columns }
+
+func (r *kvRows) Close() error { return nil }
+
+func (r *kvRows) Next(dest []driver.Value) error {
+	if len(r.rows) == 0 {
+		return io.EOF
+	}
+	copy(dest, r.rows[0])
+	r.rows = r.rows[1:]
+	return nil
+}
+
+func openTestSQLKV(t *testing.T, table string) *SQLKV {
+	t.Helper()
+	db, err := sql.Open("beefstoretest", t.Name())
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+	kv, err := NewSQLKV(t.Context(), db, table)
+	require.NoError(t, err)
+	return kv
+}
+
+func TestSQLKV(t *testing.T) {
+	ctx := t.Context()
+	kv := openTestSQLKV(t, "")
+	require.Equal(t, DefaultSQLTable, kv.table)
+
+	_, err := kv.Get(ctx, []byte("a"))
+	require.ErrorIs(t, err, ErrNotFound)
+
+	require.NoError(t, kv.Put(ctx, []byte("tx/b"), []byte("2")))
+	require.NoError(t, kv.Put(ctx, []byte("tx/a"), []byte("1")))
+	require.NoError(t, kv.Put(ctx, []byte("other"), []byte("3")))
+	require.NoError(t, kv.Put(ctx, []byte("tx/a"), []byte("one")))
+	value, err := kv.Get(ctx, []byte("tx/a"))
+	require.NoError(t, err)
+	require.Equal(t, []byte("one"), value)
+
+	var keys []string
+	require.NoError(t, kv.Scan(ctx, []byte("tx/"), func(key, value []byte) error {
+		keys = append(keys, string(key))
+		// The rows are read before fn is called, so fn may modify the store.
+		return kv.Delete(ctx, key)
+	}))
+	require.Equal(t, []string{"tx/a", "tx/b"}, keys)
+	_, err = kv.Get(ctx, []byte("tx/b"))
+	require.ErrorIs(t, err, ErrNotFound)
+	require.NoError(t, kv.Delete(ctx, []byte("tx/b")))
+
+	stop := errors.New("stop")
+	require.ErrorIs(t, kv.Scan(ctx, nil, func(key, value []byte) error { return stop }), stop)
+
+	t.Run("table name", func(t *testing.T) {
+		db, err := sql.Open("beefstoretest", t.Name())
+		require.NoError(t, err)
+		t.Cleanup(func() { db.Close() })
+		_, err = NewSQLKV(ctx, db, "beef; DROP TABLE beef_store")
+		require.Error(t, err)
+
+		other := openTestSQLKV(t, "other_store")
+		require.NoError(t, other.Put(ctx, []byte("k"), []byte("v")))
+		_, err = kv.Get(ctx, []byte("k"))
+		require.ErrorIs(t, err, ErrNotFound)
+	})
+}
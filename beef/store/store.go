@@ -0,0 +1,292 @@
+// Package store persists known transactions and their merkle proofs (BUMPs)
+// for building the BEEF of new actions. It answers the KnownTxids of
+// CreateActionOptions, so wallets can send the transactions the application
+// already has by txid only, builds BEEF stopping at proven ancestors, and prunes
+// the ancestors which proofs made unnecessary, keeping both the store and the
+// BEEF sent with each action small.
+//
+// The store is persisted by a KV, such as MemoryKV, SQLKV or an adapter of an
+// embedded database like badger.
+package store
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/bsv-blockchain/go-sdk/chainhash"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+	"github.com/bsv-blockchain/go-sdk/util"
+)
+
+// recordVersion is the version of the stored records.
+const recordVersion = 1
+
+// txPrefix prefixes the keys of transaction records, followed by the txid.
+var txPrefix = []byte("tx:")
+
+// ErrInvalidRecord is returned for stored records which cannot be decoded.
+var ErrInvalidRecord = errors.New("invalid transaction record")
+
+// Store persists transactions and their merkle paths in a KV.
+type Store struct {
+	kv KV
+}
+
+// New creates a store persisted in kv.
+func New(kv KV) *Store {
+	return &Store{kv: kv}
+}
+
+func txKey(txid *chainhash.Hash) []byte {
+	return append(bytes.Clone(txPrefix), txid[:]...)
+}
+
+// encodeRecord writes the record of tx: the version, the raw transaction and
+// the merkle path, if any.
+func encodeRecord(tx *transaction.Transaction) []byte {
+	w := util.NewWriter()
+	w.WriteByte(recordVersion)
+	w.WriteIntBytes(tx.Bytes())
+	if tx.MerklePath != nil {
+		w.WriteBytes(tx.MerklePath.Bytes())
+	}
+	return w.Buf
+}
+
+func decodeRecord(data []byte) (*transaction.Transaction, error) {
+	r := util.NewReader(data)
+	if version, err := r.ReadByte(); err != nil || version != recordVersion {
+		return nil, fmt.Errorf("%w: unsupported version", ErrInvalidRecord)
+	}
+	raw, err := r.ReadIntBytes()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidRecord, err)
+	}
+	tx, err := transaction.NewTransactionFromBytes(raw)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidRecord, err)
+	}
+	if path := r.ReadRemaining(); len(path) > 0 {
+		if tx.MerklePath, err = transaction.NewMerklePathFromBinary(path); err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrInvalidRecord, err)
+		}
+	}
+	return tx, nil
+}
+
+// Transaction returns the stored transaction with its merkle path, if proven,
+// or ErrNotFound. The source transactions of its inputs are not set.
+func (s *Store) Transaction(ctx context.Context, txid *chainhash.Hash) (*transaction.Transaction, error) {
+	data, err := s.kv.Get(ctx, txKey(txid))
+	if errors.Is(err, ErrNotFound) {
+		return nil, fmt.Errorf("transaction %s: %w", txid, ErrNotFound)
+	} else if err != nil {
+		return nil, err
+	}
+	return decodeRecord(data)
+}
+
+// Has reports whether the transaction is stored.
+func (s *Store) Has(ctx context.Context, txid *chainhash.Hash) (bool, error) {
+	_, err := s.kv.Get(ctx, txKey(txid))
+	if errors.Is(err, ErrNotFound) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+// put stores tx, keeping the merkle path of a stored version of tx when it has
+// none.
+func (s *Store) put(ctx context.Context, tx *transaction.Transaction) error {
+	if tx.MerklePath == nil {
+		stored, err := s.Transaction(ctx, tx.TxID())
+		if err == nil && stored.MerklePath != nil {
+			return nil
+		} else if err != nil && !errors.Is(err, ErrNotFound) {
+			return err
+		}
+	}
+	return s.kv.Put(ctx, txKey(tx.TxID()), encodeRecord(tx))
+}
+
+// AddTransaction stores tx and the source transactions of its inputs, up to
+// the proven ones.
+func (s *Store) AddTransaction(ctx context.Context, tx *transaction.Transaction) error {
+	if err := s.put(ctx, tx); err != nil {
+		return err
+	}
+	if tx.MerklePath != nil {
+		return nil
+	}
+	for _, input := range tx.Inputs {
+		if input.SourceTransaction != nil {
+			if err := s.AddTransaction(ctx, input.SourceTransaction); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// AddBeef stores the transactions of a BEEF or Atomic BEEF, with their merkle
+// paths. Transactions included by txid only are skipped.
+func (s *Store) AddBeef(ctx context.Context, beef []byte) error {
+	b, err := transaction.NewBeefFromBytes(beef)
+	if err != nil {
+		return fmt.Errorf("invalid BEEF: %w", err)
+	}
+	for _, btx := range b.Transactions {
+		if btx.DataFormat == transaction.TxIDOnly || btx.Transaction == nil {
+			continue
+		}
+		tx := btx.Transaction
+		if btx.DataFormat == transaction.RawTxAndBumpIndex && tx.MerklePath == nil {
+			if btx.BumpIndex < 0 || btx.BumpIndex >= len(b.BUMPs) {
+				return fmt.Errorf("invalid BEEF: bump index %d out of range", btx.BumpIndex)
+			}
+			tx.MerklePath = b.BUMPs[btx.BumpIndex]
+		}
+		if err = s.put(ctx, tx); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// SetMerklePath records the proof of a stored transaction once it is mined.
+func (s *Store) SetMerklePath(ctx context.Context, txid *chainhash.Hash, path *transaction.MerklePath) error {
+	tx, err := s.Transaction(ctx, txid)
+	if err != nil {
+		return err
+	}
+	if !provesTxid(path, txid) {
+		return fmt.Errorf("merkle path does not prove %s", txid)
+	}
+	tx.MerklePath = path
+	return s.kv.Put(ctx, txKey(txid), encodeRecord(tx))
+}
+
+// provesTxid reports whether txid is a leaf of path.
+func provesTxid(path *transaction.MerklePath, txid *chainhash.Hash) bool {
+	if path == nil || len(path.Path) == 0 {
+		return false
+	}
+	for _, leaf := range path.Path[0] {
+		if leaf.Hash != nil && leaf.Hash.Equal(*txid) {
+			return true
+		}
+	}
+	return false
+}
+
+// KnownTxids returns the txids of the stored transactions, for the KnownTxids
+// of CreateActionOptions.
+func (s *Store) KnownTxids(ctx context.Context) ([]chainhash.Hash, error) {
+	var txids []chainhash.Hash
+	err := s.kv.Scan(ctx, txPrefix, func(key, _ []byte) error {
+		txid, err := chainhash.NewHash(key[len(txPrefix):])
+		if err != nil {
+			return fmt.Errorf("%w: %w", ErrInvalidRecord, err)
+		}
+		txids = append(txids, *txid)
+		return nil
+	})
+	return txids, err
+}
+
+// Beef builds the BEEF of the stored transaction txid: the transaction and its
+// ancestors up to the proven ones, with their merkle paths. Ancestors whose
+// txid is in known are included by txid only, as the recipient has them. It
+// fails with ErrNotFound when an ancestor is neither stored nor known.
+func (s *Store) Beef(ctx context.Context, txid *chainhash.Hash, known ...chainhash.Hash) (*transaction.Beef, error) {
+	knownSet := make(map[chainhash.Hash]bool, len(known))
+	for _, k := range known {
+		knownSet[k] = true
+	}
+	b := transaction.NewBeefV2()
+	var merge func(txid *chainhash.Hash) error
+	merge = func(txid *chainhash.Hash) error {
+		if _, ok := b.Transactions[*txid]; ok {
+			return nil
+		}
+		tx, err := s.Transaction(ctx, txid)
+		if err != nil {
+			return err
+		}
+		if tx.MerklePath == nil {
+			for _, input := range tx.Inputs {
+				if knownSet[*input.SourceTXID] {
+					b.MergeTxidOnly(input.SourceTXID)
+				} else if err = merge(input.SourceTXID); err != nil {
+					return err
+				}
+			}
+		}
+		_, err = b.MergeTransaction(tx)
+		return err
+	}
+	if err := merge(txid); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+// Prune deletes the ancestors of proven transactions: the stored transactions
+// all of whose stored spenders are proven or pruned themselves. Transactions
+// no stored transaction spends are kept, as are the proven ancestors of
+// unproven transactions, which their BEEF needs. It returns the number of
+// transactions deleted.
+func (s *Store) Prune(ctx context.Context) (int, error) {
+	proven := make(map[chainhash.Hash]bool)
+	spenders := make(map[chainhash.Hash][]chainhash.Hash)
+	err := s.kv.Scan(ctx, txPrefix, func(_, value []byte) error {
+		tx, err := decodeRecord(value)
+		if err != nil {
+			return err
+		}
+		txid := *tx.TxID()
+		proven[txid] = tx.MerklePath != nil
+		for _, input := range tx.Inputs {
+			spenders[*input.SourceTXID] = append(spenders[*input.SourceTXID], txid)
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	prunable := make(map[chainhash.Hash]bool)
+	var isPrunable func(txid chainhash.Hash) bool
+	isPrunable = func(txid chainhash.Hash) bool {
+		if p, ok := prunable[txid]; ok {
+			return p
+		}
+		p := false
+		for _, spender := range spenders[txid] {
+			if _, ok := proven[spender]; !ok {
+				// Not stored.
+				continue
+			}
+			p = proven[spender] || isPrunable(spender)
+			if !p {
+				break
+			}
+		}
+		prunable[txid] = p
+		return p
+	}
+
+	pruned := 0
+	for txid := range proven {
+		if !isPrunable(txid) {
+			continue
+		}
+		if err = s.kv.Delete(ctx, txKey(&txid)); err != nil {
+			return pruned, err
+		}
+		pruned++
+	}
+	return pruned, nil
+}
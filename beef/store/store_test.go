@@ -0,0 +1,147 @@
+package store
+
+import (
+	"testing"
+
+	"github.com/bsv-blockchain/go-sdk/chainhash"
+	"github.com/bsv-blockchain/go-sdk/script"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+	"github.com/stretchr/testify/require"
+)
+
+// spend returns a transaction spending the first output of source.
+func spend(source *transaction.Transaction, satoshis uint64) *transaction.Transaction {
+	tx := transaction.NewTransaction()
+	tx.AddInput(&transaction.TransactionInput{
+		SourceTXID:        source.TxID(),
+		SourceTransaction: source,
+		UnlockingScript:   &script.Script{script.OpTRUE},
+		SequenceNumber:    0xffffffff,
+	})
+	tx.AddOutput(&transaction.TransactionOutput{Satoshis: satoshis, LockingScript: &script.Script{script.OpTRUE}})
+	return tx
+}
+
+// proof returns the merkle path of tx alone in the block at height.
+func proof(tx *transaction.Transaction, height uint32) *transaction.MerklePath {
+	isTxid := true
+	return &transaction.MerklePath{BlockHeight: height, Path: [][]*transaction.PathElement{{
+		{Offset: 0, Hash: tx.TxID(), Txid: &isTxid},
+	}}}
+}
+
+// chain returns a proven grandparent, an unproven parent and child.
+func chain() (grandparent, parent, child *transaction.Transaction) {
+	grandparent = transaction.NewTransaction()
+	grandparent.AddInput(&transaction.TransactionInput{
+		SourceTXID:      &chainhash.Hash{1},
+		UnlockingScript: &script.Script{script.OpTRUE},
+		SequenceNumber:  0xffffffff,
+	})
+	grandparent.AddOutput(&transaction.TransactionOutput{Satoshis: 3000, LockingScript: &script.Script{script.OpTRUE}})
+	grandparent.MerklePath = proof(grandparent, 100)
+	parent = spend(grandparent, 2000)
+	child = spend(parent, 1000)
+	return grandparent, parent, child
+}
+
+func TestStore(t *testing.T) {
+	for name, newKV := range map[string]func(t *testing.T) KV{
+		"memory": func(*testing.T) KV { return NewMemoryKV() },
+		"sql":    func(t *testing.T) KV { return openTestSQLKV(t, "") },
+	} {
+		t.Run(name, func(t *testing.T) {
+			testStore(t, New(newKV(t)))
+		})
+	}
+}
+
+func testStore(t *testing.T, s *Store) {
+	ctx := t.Context()
+	grandparent, parent, child := chain()
+	require.NoError(t, s.AddTransaction(ctx, child))
+
+	known, err := s.KnownTxids(ctx)
+	require.NoError(t, err)
+	require.ElementsMatch(t, []chainhash.Hash{*grandparent.TxID(), *parent.TxID(), *child.TxID()}, known)
+
+	stored, err := s.Transaction(ctx, grandparent.TxID())
+	require.NoError(t, err)
+	require.Equal(t, grandparent.MerklePath.Bytes(), stored.MerklePath.Bytes())
+	_, err = s.Transaction(ctx, &chainhash.Hash{2})
+	require.ErrorIs(t, err, ErrNotFound)
+
+	beef, err := s.Beef(ctx, child.TxID())
+	require.NoError(t, err)
+	require.Len(t, beef.Transactions, 3)
+	require.Len(t, beef.BUMPs, 1)
+	require.True(t, beef.IsValid(false))
+
+	// The recipient knowing the grandparent gets it by txid only.
+	beef, err = s.Beef(ctx, child.TxID(), *grandparent.TxID())
+	require.NoError(t, err)
+	require.Equal(t, transaction.TxIDOnly, beef.Transactions[*grandparent.TxID()].DataFormat)
+	require.Empty(t, beef.BUMPs)
+	beefBytes, err := beef.Bytes()
+	require.NoError(t, err)
+	parsed, err := transaction.NewBeefFromBytes(beefBytes)
+	require.NoError(t, err)
+	require.Len(t, parsed.Transactions, 3)
+
+	// Nothing is pruned while the parent is unproven.
+	pruned, err := s.Prune(ctx)
+	require.NoError(t, err)
+	require.Zero(t, pruned)
+
+	require.NoError(t, s.SetMerklePath(ctx, parent.TxID(), proof(parent, 101)))
+	require.Error(t, s.SetMerklePath(ctx, child.TxID(), proof(parent, 101)))
+	pruned, err = s.Prune(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 1, pruned)
+	has, err := s.Has(ctx, grandparent.TxID())
+	require.NoError(t, err)
+	require.False(t, has)
+
+	beef, err = s.Beef(ctx, child.TxID())
+	require.NoError(t, err)
+	require.Len(t, beef.Transactions, 2)
+	require.True(t, beef.IsValid(false))
+}
+
+func TestStoreAddBeef(t *testing.T) {
+	ctx := t.Context()
+	grandparent, parent, child := chain()
+	beefBytes, err := child.BEEF()
+	require.NoError(t, err)
+
+	s := New(NewMemoryKV())
+	require.NoError(t, s.AddBeef(ctx, beefBytes))
+	known, err := s.KnownTxids(ctx)
+	require.NoError(t, err)
+	require.ElementsMatch(t, []chainhash.Hash{*grandparent.TxID(), *parent.TxID(), *child.TxID()}, known)
+
+	stored, err := s.Transaction(ctx, grandparent.TxID())
+	require.NoError(t, err)
+	require.NotNil(t, stored.MerklePath)
+
+	// A later version of a transaction without proof keeps the stored proof.
+	unproven, err := transaction.NewTransactionFromBytes(grandparent.Bytes())
+	require.NoError(t, err)
+	require.NoError(t, s.AddTransaction(ctx, unproven))
+	stored, err = s.Transaction(ctx, grandparent.TxID())
+	require.NoError(t, err)
+	require.NotNil(t, stored.MerklePath)
+
+	require.Error(t, s.AddBeef(ctx, []byte{1, 2, 3, 4}))
+}
+
+func TestStoreBeefMissingAncestor(t *testing.T) {
+	ctx := t.Context()
+	_, parent, child := chain()
+	s := New(NewMemoryKV())
+	require.NoError(t, s.AddTransaction(ctx, child))
+	require.NoError(t, s.kv.Delete(ctx, txKey(parent.TxID())))
+
+	_, err := s.Beef(ctx, child.TxID())
+	require.ErrorIs(t, err, ErrNotFound)
+}
@@ -0,0 +1,102 @@
+// Package block parses serialized blocks for indexers and other tools that need to
+// walk every transaction in a block. Transactions are located with the lazy
+// transaction parser so scanning a block only allocates what the caller touches.
+package block
+
+import (
+	"fmt"
+
+	"github.com/bsv-blockchain/go-sdk/transaction"
+	"github.com/bsv-blockchain/go-sdk/util"
+)
+
+// Block is a parsed block header over the serialized transactions that follow it.
+// Transactions are only decoded as they are iterated.
+type Block struct {
+	Header  *Header
+	TxCount uint64
+
+	raw     []byte
+	txStart int
+}
+
+// NewBlockFromBytes parses the header and transaction count of a serialized block.
+// The Block references b, which must not be modified while the Block is in use.
+func NewBlockFromBytes(b []byte) (*Block, error) {
+	if len(b) < HeaderSize+1 {
+		return nil, fmt.Errorf("%w: got %d bytes", ErrBlockTooShort, len(b))
+	}
+	header, err := NewHeaderFromBytes(b[:HeaderSize])
+	if err != nil {
+		return nil, err
+	}
+
+	countLen := 1
+	switch b[HeaderSize] {
+	case 0xff:
+		countLen = 9
+	case 0xfe:
+		countLen = 5
+	case 0xfd:
+		countLen = 3
+	}
+	if len(b) < HeaderSize+countLen {
+		return nil, fmt.Errorf("%w: truncated transaction count", ErrBlockTooShort)
+	}
+	txCount, _ := util.NewVarIntFromBytes(b[HeaderSize:])
+
+	return &Block{
+		Header:  header,
+		TxCount: uint64(txCount),
+		raw:     b,
+		txStart: HeaderSize + countLen,
+	}, nil
+}
+
+// ForEachLazyTx calls fn with each transaction in the block, in order, without
+// fully parsing it. Iteration stops at the first error returned by fn, which is
+// returned to the caller.
+func (b *Block) ForEachLazyTx(fn func(*transaction.LazyTransaction) error) error {
+	pos := b.txStart
+	for i := uint64(0); i < b.TxCount; i++ {
+		tx, used, err := transaction.NewLazyTransactionFromStream(b.raw[pos:])
+		if err != nil {
+			return fmt.Errorf("failed to parse transaction %d: %w", i, err)
+		}
+		pos += used
+		if err := fn(tx); err != nil {
+			return err
+		}
+	}
+	if pos != len(b.raw) {
+		return fmt.Errorf("%w: %d bytes", ErrTrailingBytes, len(b.raw)-pos)
+	}
+	return nil
+}
+
+// ForEachTx calls fn with each fully parsed transaction in the block, in order.
+// Iteration stops at the first error returned by fn, which is returned to the caller.
+func (b *Block) ForEachTx(fn func(*transaction.Transaction) error) error {
+	var index int
+	return b.ForEachLazyTx(func(lazy *transaction.LazyTransaction) error {
+		tx, err := lazy.Transaction()
+		if err != nil {
+			return fmt.Errorf("failed to parse transaction %d: %w", index, err)
+		}
+		index++
+		return fn(tx)
+	})
+}
+
+// Transactions parses and returns every transaction in the block.
+func (b *Block) Transactions() ([]*transaction.Transaction, error) {
+	txs := make([]*transaction.Transaction, 0, min(b.TxCount, uint64(len(b.raw))/60))
+	err := b.ForEachTx(func(tx *transaction.Transaction) error {
+		txs = append(txs, tx)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return txs, nil
+}
@@ -0,0 +1,93 @@
+package block_test
+
+import (
+	"encoding/hex"
+	"errors"
+	"testing"
+
+	"github.com/bsv-blockchain/go-sdk/block"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+	"github.com/stretchr/testify/require"
+)
+
+const genesisBlockHex = "0100000000000000000000000000000000000000000000000000000000000000000000003ba3edfd7a7b12b27ac72c3e67768f617fc81bc3888a51323a9fb8aa4b1e5e4a29ab5f49ffff001d1dac2b7c0101000000010000000000000000000000000000000000000000000000000000000000000000ffffffff4d04ffff001d0104455468652054696d65732030332f4a616e2f32303039204368616e63656c6c6f72206f6e206272696e6b206f66207365636f6e64206261696c6f757420666f722062616e6b73ffffffff0100f2052a01000000434104678afdb0fe5548271967f1a67130b7105cd6a828e03909a67962e0ea1f61deb649f6bc3f4cef38c4f35504e51ec112de5c384df7ba0b8d578a4c702b6bf11d5fac00000000"
+
+const genesisCoinbaseTxID = "4a5e1e4baab89f3a32518a88c31bc87f618f76673e2cc77ab2127b7afdeda33b"
+
+func genesisBlockBytes(t *testing.T) []byte {
+	b, err := hex.DecodeString(genesisBlockHex)
+	require.NoError(t, err)
+	return b
+}
+
+func TestNewBlockFromBytes(t *testing.T) {
+	blk, err := block.NewBlockFromBytes(genesisBlockBytes(t))
+	require.NoError(t, err)
+
+	require.Equal(t, uint32(1), blk.Header.Version)
+	require.Equal(t, uint32(0x1d00ffff), blk.Header.Bits)
+	require.Equal(t, uint32(2083236893), blk.Header.Nonce)
+	require.Equal(t, uint32(1231006505), blk.Header.Timestamp)
+	require.Equal(t, genesisCoinbaseTxID, blk.Header.MerkleRoot.String())
+	require.Equal(t, uint64(1), blk.TxCount)
+
+	var lazyCount int
+	err = blk.ForEachLazyTx(func(tx *transaction.LazyTransaction) error {
+		lazyCount++
+		require.True(t, tx.IsCoinbase())
+		require.Equal(t, genesisCoinbaseTxID, tx.TxID().String())
+		sats, err := tx.OutputSatoshis(0)
+		require.NoError(t, err)
+		require.Equal(t, uint64(5000000000), sats)
+		return nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, 1, lazyCount)
+
+	txs, err := blk.Transactions()
+	require.NoError(t, err)
+	require.Len(t, txs, 1)
+	require.Equal(t, genesisCoinbaseTxID, txs[0].TxID().String())
+}
+
+func TestBlockForEachTxStops(t *testing.T) {
+	raw := genesisBlockBytes(t)
+	tx, err := transaction.NewTransactionFromBytes(raw[81:])
+	require.NoError(t, err)
+
+	// Build a block with three copies of the coinbase transaction
+	multi := append([]byte{}, raw[:80]...)
+	multi = append(multi, 3)
+	for i := 0; i < 3; i++ {
+		multi = append(multi, tx.Bytes()...)
+	}
+	blk, err := block.NewBlockFromBytes(multi)
+	require.NoError(t, err)
+
+	stop := errors.New("stop")
+	var seen int
+	err = blk.ForEachTx(func(*transaction.Transaction) error {
+		seen++
+		if seen == 2 {
+			return stop
+		}
+		return nil
+	})
+	require.ErrorIs(t, err, stop)
+	require.Equal(t, 2, seen)
+}
+
+func TestBlockErrors(t *testing.T) {
+	raw := genesisBlockBytes(t)
+
+	_, err := block.NewBlockFromBytes(raw[:80])
+	require.ErrorIs(t, err, block.ErrBlockTooShort)
+
+	blk, err := block.NewBlockFromBytes(raw[:len(raw)-1])
+	require.NoError(t, err)
+	require.ErrorIs(t, blk.ForEachLazyTx(func(*transaction.LazyTransaction) error { return nil }), transaction.ErrTxTooShort)
+
+	blk, err = block.NewBlockFromBytes(append(append([]byte{}, raw...), 0))
+	require.NoError(t, err)
+	require.ErrorIs(t, blk.ForEachLazyTx(func(*transaction.LazyTransaction) error { return nil }), block.ErrTrailingBytes)
+}
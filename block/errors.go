@@ -0,0 +1,11 @@
+package block
+
+import "errors"
+
+var (
+	ErrHeaderLength  = errors.New("invalid block header length")
+	ErrBlockTooShort = errors.New("block too short")
+	ErrTrailingBytes = errors.New("unexpected bytes after last transaction")
+	ErrInvalidTarget = errors.New("invalid proof of work target")
+	ErrProofOfWork   = errors.New("proof of work check failed")
+)
@@ -0,0 +1,155 @@
+package block
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math/big"
+
+	"github.com/bsv-blockchain/go-sdk/chainhash"
+)
+
+// HeaderSize is the size of a serialized block header in bytes.
+const HeaderSize = 80
+
+// Header holds the fields of a block header.
+type Header struct {
+	Version    uint32
+	PrevBlock  chainhash.Hash
+	MerkleRoot chainhash.Hash
+	Timestamp  uint32
+	Bits       uint32
+	Nonce      uint32
+}
+
+// NewHeaderFromBytes parses an 80 byte serialized block header.
+func NewHeaderFromBytes(b []byte) (*Header, error) {
+	if len(b) != HeaderSize {
+		return nil, fmt.Errorf("%w: got %d bytes, want %d", ErrHeaderLength, len(b), HeaderSize)
+	}
+	h := &Header{
+		Version:   binary.LittleEndian.Uint32(b[0:4]),
+		Timestamp: binary.LittleEndian.Uint32(b[68:72]),
+		Bits:      binary.LittleEndian.Uint32(b[72:76]),
+		Nonce:     binary.LittleEndian.Uint32(b[76:80]),
+	}
+	copy(h.PrevBlock[:], b[4:36])
+	copy(h.MerkleRoot[:], b[36:68])
+	return h, nil
+}
+
+// Bytes returns the 80 byte serialized block header.
+func (h *Header) Bytes() []byte {
+	b := make([]byte, HeaderSize)
+	binary.LittleEndian.PutUint32(b[0:4], h.Version)
+	copy(b[4:36], h.PrevBlock[:])
+	copy(b[36:68], h.MerkleRoot[:])
+	binary.LittleEndian.PutUint32(b[68:72], h.Timestamp)
+	binary.LittleEndian.PutUint32(b[72:76], h.Bits)
+	binary.LittleEndian.PutUint32(b[76:80], h.Nonce)
+	return b
+}
+
+// Hash returns the block hash, the double sha256 of the serialized header.
+func (h *Header) Hash() chainhash.Hash {
+	return chainhash.DoubleHashH(h.Bytes())
+}
+
+// Target returns the proof of work target encoded in the header's Bits field.
+func (h *Header) Target() (*big.Int, error) {
+	target, negative := CompactToBig(h.Bits)
+	if negative {
+		return nil, fmt.Errorf("%w: target is negative", ErrInvalidTarget)
+	}
+	if target.Sign() == 0 {
+		return nil, fmt.Errorf("%w: target is zero", ErrInvalidTarget)
+	}
+	if target.BitLen() > 256 {
+		return nil, fmt.Errorf("%w: target exceeds 256 bits", ErrInvalidTarget)
+	}
+	return target, nil
+}
+
+// Difficulty returns the difficulty of the header's target relative to the
+// minimum difficulty target (Bits 0x1d00ffff).
+func (h *Header) Difficulty() (float64, error) {
+	target, err := h.Target()
+	if err != nil {
+		return 0, err
+	}
+	difficulty, _ := new(big.Float).Quo(new(big.Float).SetInt(diffOneTarget), new(big.Float).SetInt(target)).Float64()
+	return difficulty, nil
+}
+
+// CheckProofOfWork verifies that the header's hash satisfies the target it commits to.
+func (h *Header) CheckProofOfWork() error {
+	target, err := h.Target()
+	if err != nil {
+		return err
+	}
+	hash := h.Hash()
+	if HashToBig(&hash).Cmp(target) > 0 {
+		return fmt.Errorf("%w: block hash %s is above target %064x", ErrProofOfWork, hash, target)
+	}
+	return nil
+}
+
+// diffOneTarget is the target corresponding to a difficulty of 1.
+var diffOneTarget, _ = CompactToBig(0x1d00ffff)
+
+// HashToBig interprets a hash as a little-endian 256 bit number so it can be
+// compared against a proof of work target.
+func HashToBig(hash *chainhash.Hash) *big.Int {
+	buf := *hash
+	for i := 0; i < chainhash.HashSize/2; i++ {
+		buf[i], buf[chainhash.HashSize-1-i] = buf[chainhash.HashSize-1-i], buf[i]
+	}
+	return new(big.Int).SetBytes(buf[:])
+}
+
+// CompactToBig decodes the compact representation of a target used in block
+// headers. The compact form is a 3 byte mantissa with a sign bit and an 8 bit
+// base-256 exponent. The second return value reports whether the sign bit is set.
+func CompactToBig(compact uint32) (*big.Int, bool) {
+	mantissa := compact & 0x007fffff
+	negative := compact&0x00800000 != 0
+	exponent := uint(compact >> 24)
+
+	var n *big.Int
+	if exponent <= 3 {
+		mantissa >>= 8 * (3 - exponent)
+		n = big.NewInt(int64(mantissa))
+	} else {
+		n = big.NewInt(int64(mantissa))
+		n.Lsh(n, 8*(exponent-3))
+	}
+	return n, negative && mantissa != 0
+}
+
+// BigToCompact encodes a target into the compact representation used in block headers.
+func BigToCompact(n *big.Int) uint32 {
+	if n.Sign() == 0 {
+		return 0
+	}
+
+	var mantissa uint32
+	exponent := uint(len(n.Bytes()))
+	if exponent <= 3 {
+		mantissa = uint32(n.Bits()[0])
+		mantissa <<= 8 * (3 - exponent)
+	} else {
+		tn := new(big.Int).Rsh(n, 8*(exponent-3))
+		mantissa = uint32(tn.Bits()[0])
+	}
+
+	// Avoid setting the sign bit by moving to a larger exponent
+	if mantissa&0x00800000 != 0 {
+		mantissa >>= 8
+		exponent++
+	}
+
+	compact := uint32(exponent<<24) | mantissa
+	if n.Sign() < 0 {
+		compact |= 0x00800000
+	}
+	return compact
+}
@@ -0,0 +1,69 @@
+package block_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/bsv-blockchain/go-sdk/block"
+	"github.com/stretchr/testify/require"
+)
+
+const genesisBlockHash = "000000000019d6689c085ae165831e934ff763ae46a2a6c172b3f1b60a8ce26f"
+
+func TestHeaderRoundTrip(t *testing.T) {
+	raw := genesisBlockBytes(t)
+	header, err := block.NewHeaderFromBytes(raw[:block.HeaderSize])
+	require.NoError(t, err)
+	require.Equal(t, raw[:block.HeaderSize], header.Bytes())
+	hash := header.Hash()
+	require.Equal(t, genesisBlockHash, hash.String())
+
+	_, err = block.NewHeaderFromBytes(raw[:block.HeaderSize-1])
+	require.ErrorIs(t, err, block.ErrHeaderLength)
+}
+
+func TestHeaderProofOfWork(t *testing.T) {
+	header, err := block.NewHeaderFromBytes(genesisBlockBytes(t)[:block.HeaderSize])
+	require.NoError(t, err)
+
+	require.NoError(t, header.CheckProofOfWork())
+
+	difficulty, err := header.Difficulty()
+	require.NoError(t, err)
+	require.InDelta(t, 1.0, difficulty, 1e-9)
+
+	t.Run("bad nonce", func(t *testing.T) {
+		bad := *header
+		bad.Nonce++
+		require.ErrorIs(t, bad.CheckProofOfWork(), block.ErrProofOfWork)
+	})
+
+	t.Run("invalid targets", func(t *testing.T) {
+		for _, bits := range []uint32{0, 0x1d800000 | 0x00ffff, 0xff00ffff} {
+			bad := *header
+			bad.Bits = bits
+			require.ErrorIs(t, bad.CheckProofOfWork(), block.ErrInvalidTarget)
+		}
+	})
+}
+
+func TestCompactConversion(t *testing.T) {
+	tests := []struct {
+		compact uint32
+		target  string
+	}{
+		{0x1d00ffff, "ffff0000000000000000000000000000000000000000000000000000"},
+		{0x1b0404cb, "404cb000000000000000000000000000000000000000000000000"},
+		{0x207fffff, "7fffff0000000000000000000000000000000000000000000000000000000000"},
+		{0x03123456, "123456"},
+		{0x02123400, "1234"},
+	}
+	for _, tt := range tests {
+		expected, ok := new(big.Int).SetString(tt.target, 16)
+		require.True(t, ok)
+		target, negative := block.CompactToBig(tt.compact)
+		require.False(t, negative)
+		require.Equal(t, 0, expected.Cmp(target), "compact %08x", tt.compact)
+		require.Equal(t, tt.compact, block.BigToCompact(target))
+	}
+}
@@ -71,10 +71,20 @@ func (hash *Hash) IsEqual(target *Hash) bool {
 	return *hash == *target
 }
 
-func (hash *Hash) MarshalJSON() ([]byte, error) {
+// ShortID returns the first 4 bytes of the byte-reversed hash as hexadecimal,
+// which is the prefix shown by block explorers. It is meant for logs and
+// messages, and is not unique.
+func (hash Hash) ShortID() string {
+	return hash.String()[:8]
+}
+
+// MarshalJSON encodes the hash as the hexadecimal string of the byte-reversed
+// hash.
+func (hash Hash) MarshalJSON() ([]byte, error) {
 	return json.Marshal(hash.String())
 }
 
+// UnmarshalJSON decodes a hash encoded with MarshalJSON.
 func (hash *Hash) UnmarshalJSON(data []byte) error {
 	var s string
 	if err := json.Unmarshal(data, &s); err != nil {
@@ -116,6 +126,16 @@ func NewHashFromHex(hash string) (*Hash, error) {
 	return ret, nil
 }
 
+// MustHashFromHex is like NewHashFromHex but panics if the string is not a valid
+// hash. It simplifies the initialization of variables holding known hashes.
+func MustHashFromHex(hash string) *Hash {
+	h, err := NewHashFromHex(hash)
+	if err != nil {
+		panic(fmt.Sprintf("chainhash: invalid hash %q: %v", hash, err))
+	}
+	return h
+}
+
 // func NewHashFromStrNoError(hash string) *Hash {
 // 	sh, _ := NewHashFromHex(hash)
 // 	return sh
@@ -220,3 +220,47 @@ func TestMarshalling(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, "24988b93623304735e42a71f5c1e161b9ee2b9c52a3be8260ea3b05fba4df22c", myData2.Hash.String())
 }
+
+func TestMarshallingValues(t *testing.T) {
+	genesis := "000000000019d6689c085ae165831e934ff763ae46a2a6c172b3f1b60a8ce26f"
+
+	// Hashes held by value in maps and slices are not addressable
+	b, err := json.Marshal(map[string]Hash{"genesis": mainNetGenesisHash})
+	require.NoError(t, err)
+	require.JSONEq(t, `{"genesis":"`+genesis+`"}`, string(b))
+
+	b, err = json.Marshal(struct {
+		Hash *Hash `json:"hash"`
+	}{})
+	require.NoError(t, err)
+	require.JSONEq(t, `{"hash":null}`, string(b))
+}
+
+func TestMustHashFromHex(t *testing.T) {
+	require.Equal(t, mainNetGenesisHash, *MustHashFromHex("000000000019d6689c085ae165831e934ff763ae46a2a6c172b3f1b60a8ce26f"))
+	require.Panics(t, func() { MustHashFromHex("xyz") })
+}
+
+func TestShortID(t *testing.T) {
+	require.Equal(t, "00000000", mainNetGenesisHash.ShortID())
+	require.Equal(t, "24988b93", HashH([]byte("hello")).ShortID())
+}
+
+func TestSQL(t *testing.T) {
+	genesis := "000000000019d6689c085ae165831e934ff763ae46a2a6c172b3f1b60a8ce26f"
+
+	v, err := mainNetGenesisHash.Value()
+	require.NoError(t, err)
+	require.Equal(t, genesis, v)
+
+	for _, src := range []any{genesis, []byte(genesis), mainNetGenesisHash[:]} {
+		var h Hash
+		require.NoError(t, h.Scan(src))
+		require.Equal(t, mainNetGenesisHash, h)
+	}
+
+	var h Hash
+	require.EqualError(t, h.Scan(nil), "cannot scan NULL into *chainhash.Hash")
+	require.EqualError(t, h.Scan(int64(1)), "cannot scan int64 into *chainhash.Hash")
+	require.Error(t, h.Scan("abcd"))
+}
@@ -0,0 +1,38 @@
+package chainhash
+
+import (
+	"database/sql/driver"
+	"fmt"
+)
+
+// Value implements driver.Valuer, storing the hash as the hexadecimal string
+// of the byte-reversed hash, the form shown by block explorers.
+func (hash Hash) Value() (driver.Value, error) {
+	return hash.String(), nil
+}
+
+// Scan implements sql.Scanner. It accepts the hexadecimal string written by
+// Value, and raw bytes in internal byte order as stored in binary columns. Use
+// sql.Null[Hash] or a *Hash for nullable columns.
+func (hash *Hash) Scan(src any) error {
+	switch v := src.(type) {
+	case string:
+		return hash.scanHex(v)
+	case []byte:
+		if len(v) == HashSize {
+			return hash.SetBytes(v)
+		}
+		return hash.scanHex(string(v))
+	case nil:
+		return fmt.Errorf("cannot scan NULL into %T", hash)
+	default:
+		return fmt.Errorf("cannot scan %T into %T", src, hash)
+	}
+}
+
+func (hash *Hash) scanHex(s string) error {
+	if len(s) != MaxHashStringSize {
+		return fmt.Errorf("invalid hash string length of %d, want %d", len(s), MaxHashStringSize)
+	}
+	return Decode(hash, s)
+}
@@ -0,0 +1,120 @@
+// Command beef pretty-prints BEEF payloads: their transaction graph, merkle
+// proofs, fees, script types and validity. It helps debugging BEEF exchanged
+// with other SDKs.
+//
+// Usage:
+//
+//	beef [-json] [-verify network] [file]
+//
+// The payload is read from the file, or from stdin when none is given, as
+// binary, hex or base64. The exit status is 3 when the payload parses but is not
+// valid, or its merkle roots fail verification.
+package main
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strings"
+
+	"github.com/bsv-blockchain/go-sdk/beef/inspect"
+	"github.com/bsv-blockchain/go-sdk/transaction/chaintracker"
+)
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	code := run(ctx, os.Args[1:], os.Stdin, os.Stdout, os.Stderr, nil)
+	stop()
+	os.Exit(code)
+}
+
+// run runs beef with args, returning its exit code. The tracker verifies the
+// merkle roots, WhatsOnChain being used when it is nil.
+func run(ctx context.Context, args []string, stdin io.Reader, stdout, stderr io.Writer, tracker chaintracker.ChainTracker) int {
+	fs := flag.NewFlagSet("beef", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	asJSON := fs.Bool("json", false, "print the report as JSON")
+	network := fs.String("verify", "", "verify the merkle roots with WhatsOnChain on the network: main, test or stn")
+	apiKey := fs.String("woc-key", "", "WhatsOnChain API key")
+	fs.Usage = func() {
+		fmt.Fprintln(stderr, "usage: beef [flags] [file]")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return 0
+		}
+		return 2
+	}
+	if fs.NArg() > 1 {
+		fs.Usage()
+		return 2
+	}
+
+	in := stdin
+	if fs.NArg() == 1 {
+		f, err := os.Open(fs.Arg(0))
+		if err != nil {
+			fmt.Fprintf(stderr, "beef: %v\n", err)
+			return 1
+		}
+		defer f.Close()
+		in = f
+	}
+	data, err := io.ReadAll(in)
+	if err != nil {
+		fmt.Fprintf(stderr, "beef: %v\n", err)
+		return 1
+	}
+
+	report, err := inspect.Inspect(decode(data))
+	if err != nil {
+		fmt.Fprintf(stderr, "beef: %v\n", err)
+		return 1
+	}
+	if *network != "" {
+		if tracker == nil {
+			tracker = chaintracker.NewWhatsOnChain(chaintracker.Network(*network), *apiKey)
+		}
+		if err := report.VerifyRoots(ctx, tracker); err != nil {
+			fmt.Fprintf(stderr, "beef: %v\n", err)
+			return 1
+		}
+	}
+
+	if *asJSON {
+		enc := json.NewEncoder(stdout)
+		enc.SetIndent("", "  ")
+		err = enc.Encode(report)
+	} else {
+		err = report.WriteText(stdout)
+	}
+	if err != nil {
+		fmt.Fprintf(stderr, "beef: %v\n", err)
+		return 1
+	}
+	if !report.Validity.Valid || (report.Validity.RootsVerified != nil && !*report.Validity.RootsVerified) {
+		return 3
+	}
+	return 0
+}
+
+// decode returns the payload encoded in data as hex or base64, or data itself
+// when it is neither.
+func decode(data []byte) []byte {
+	text := strings.TrimSpace(string(data))
+	if b, err := hex.DecodeString(text); err == nil {
+		return b
+	}
+	if b, err := base64.StdEncoding.DecodeString(text); err == nil {
+		return b
+	}
+	return data
+}
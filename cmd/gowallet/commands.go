@@ -0,0 +1,410 @@
+package main
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"flag"
+	"fmt"
+	"strconv"
+	"strings"
+
+	ec "github.com/bsv-blockchain/go-sdk/primitives/ec"
+	"github.com/bsv-blockchain/go-sdk/script"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+	"github.com/bsv-blockchain/go-sdk/transaction/broadcaster"
+	"github.com/bsv-blockchain/go-sdk/transaction/template/p2pkh"
+	"github.com/bsv-blockchain/go-sdk/wallet"
+)
+
+// arcFlags are the flags selecting the ARC endpoint transactions are broadcast to.
+type arcFlags struct {
+	url string
+	key string
+}
+
+func (a *arcFlags) register(fs *flag.FlagSet) {
+	fs.StringVar(&a.url, "arc", "", "ARC endpoint to broadcast with, such as https://arc.gorillapool.io")
+	fs.StringVar(&a.key, "arc-key", "", "ARC API key")
+}
+
+// broadcast broadcasts the BEEF encoded transaction with ARC.
+func (a *arcFlags) broadcast(ctx context.Context, beef []byte) (*transaction.BroadcastSuccess, error) {
+	tx, err := transaction.NewTransactionFromBEEF(beef)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse BEEF: %w", err)
+	}
+	if tx == nil {
+		return nil, errors.New("BEEF holds no transaction to broadcast")
+	}
+	arc := &broadcaster.Arc{ApiUrl: a.url, ApiKey: a.key}
+	success, failure := arc.BroadcastCtx(ctx, tx)
+	if failure != nil {
+		return nil, fmt.Errorf("broadcast failed: %w", failure)
+	}
+	return success, nil
+}
+
+func runKeygen(_ context.Context, e *env, args []string) error {
+	fs := e.newFlagSet("keygen")
+	testnet := fs.Bool("testnet", false, "print the testnet address")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+
+	key, err := ec.NewPrivateKey()
+	if err != nil {
+		return err
+	}
+	address, err := script.NewAddressFromPublicKey(key.PubKey(), !*testnet)
+	if err != nil {
+		return err
+	}
+	return e.print(struct {
+		WIF       string `json:"wif"`
+		PublicKey string `json:"publicKey"`
+		Address   string `json:"address"`
+	}{key.Wif(), key.PubKey().ToDERHex(), address.AddressString})
+}
+
+func runPubKey(ctx context.Context, e *env, args []string) error {
+	fs := e.newFlagSet("pubkey")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+
+	result, err := e.wallet.GetPublicKey(ctx, wallet.GetPublicKeyArgs{IdentityKey: true}, e.originator)
+	if err != nil {
+		return err
+	}
+	return e.print(result)
+}
+
+func runOutputs(ctx context.Context, e *env, args []string) error {
+	fs := e.newFlagSet("outputs")
+	basket := fs.String("basket", "default", "basket to list")
+	limit := fs.Uint("limit", 0, "maximum number of outputs to list (default set by the wallet)")
+	var tags listFlag
+	fs.Var(&tags, "tag", "only list outputs with the tag (repeatable)")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+
+	listArgs := wallet.ListOutputsArgs{
+		Basket:       *basket,
+		Tags:         tags,
+		TagQueryMode: wallet.QueryModeAll,
+		Include:      wallet.OutputIncludeLockingScripts,
+	}
+	if *limit > 0 {
+		listArgs.Limit = toUint32Ptr(*limit)
+	}
+	result, err := e.wallet.ListOutputs(ctx, listArgs, e.originator)
+	if err != nil {
+		return err
+	}
+	return e.print(result)
+}
+
+func runActions(ctx context.Context, e *env, args []string) error {
+	fs := e.newFlagSet("actions")
+	limit := fs.Uint("limit", 0, "maximum number of actions to list (default set by the wallet)")
+	var labels listFlag
+	fs.Var(&labels, "label", "only list actions with the label (repeatable)")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+
+	listArgs := wallet.ListActionsArgs{
+		Labels:         labels,
+		LabelQueryMode: wallet.QueryModeAny,
+		IncludeOutputs: toBoolPtr(true),
+	}
+	if *limit > 0 {
+		listArgs.Limit = toUint32Ptr(*limit)
+	}
+	result, err := e.wallet.ListActions(ctx, listArgs, e.originator)
+	if err != nil {
+		return err
+	}
+	return e.print(result)
+}
+
+func runFund(ctx context.Context, e *env, args []string) error {
+	fs := e.newFlagSet("fund")
+	beefHex := fs.String("beef", "", "hex encoded Atomic BEEF of the transaction paying the wallet")
+	vout := fs.Uint("vout", 0, "index of the output to internalize")
+	basket := fs.String("basket", "default", "basket to insert the output into")
+	description := fs.String("description", "gowallet funding", "description of the action")
+	var tags listFlag
+	fs.Var(&tags, "tag", "tag the output (repeatable)")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if *beefHex == "" {
+		return required(fs, "beef")
+	}
+
+	beef, err := hex.DecodeString(*beefHex)
+	if err != nil {
+		return fmt.Errorf("invalid -beef: %w", err)
+	}
+	result, err := e.wallet.InternalizeAction(ctx, wallet.InternalizeActionArgs{
+		Tx:          beef,
+		Description: *description,
+		Outputs: []wallet.InternalizeOutput{{
+			OutputIndex: uint32(*vout),
+			Protocol:    wallet.InternalizeProtocolBasketInsertion,
+			InsertionRemittance: &wallet.BasketInsertion{
+				Basket: *basket,
+				Tags:   tags,
+			},
+		}},
+	}, e.originator)
+	if err != nil {
+		return err
+	}
+	return e.print(result)
+}
+
+func runSend(ctx context.Context, e *env, args []string) error {
+	fs := e.newFlagSet("send")
+	to := fs.String("to", "", "address to pay")
+	satoshis := fs.Uint64("satoshis", 0, "amount to pay")
+	description := fs.String("description", "gowallet payment", "description of the action")
+	noSend := fs.Bool("nosend", false, "create and sign the action without the wallet broadcasting it")
+	var labels listFlag
+	fs.Var(&labels, "label", "label the action (repeatable)")
+	var arc arcFlags
+	arc.register(fs)
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if *to == "" {
+		return required(fs, "to")
+	}
+	if *satoshis == 0 {
+		return required(fs, "satoshis")
+	}
+
+	address, err := script.NewAddressFromString(*to)
+	if err != nil {
+		return fmt.Errorf("invalid -to: %w", err)
+	}
+	lockingScript, err := p2pkh.Lock(address)
+	if err != nil {
+		return err
+	}
+
+	// The transaction is broadcast either by the wallet or with ARC, not both.
+	createArgs := wallet.CreateActionArgs{
+		Description: *description,
+		Outputs: []wallet.CreateActionOutput{{
+			LockingScript:     lockingScript.Bytes(),
+			Satoshis:          *satoshis,
+			OutputDescription: "payment to " + address.AddressString,
+		}},
+		Labels: labels,
+		Options: &wallet.CreateActionOptions{
+			NoSend: toBoolPtr(*noSend || arc.url != ""),
+		},
+	}
+	result, err := e.wallet.CreateAction(ctx, createArgs, e.originator)
+	if err != nil {
+		return err
+	}
+
+	out := actionOutput{Txid: result.Txid.String()}
+	if result.SignableTransaction != nil {
+		// The wallet needs unlocking scripts it can't produce itself, which are
+		// then provided with the sign command.
+		out.Reference = base64.StdEncoding.EncodeToString(result.SignableTransaction.Reference)
+		out.Tx = hex.EncodeToString(result.SignableTransaction.Tx)
+		return e.print(out)
+	}
+	out.Tx = hex.EncodeToString(result.Tx)
+	if arc.url != "" {
+		if out.Broadcast, err = arc.broadcast(ctx, result.Tx); err != nil {
+			return err
+		}
+	}
+	return e.print(out)
+}
+
+func runSign(ctx context.Context, e *env, args []string) error {
+	fs := e.newFlagSet("sign")
+	reference := fs.String("reference", "", "base64 reference of the action returned by send")
+	noSend := fs.Bool("nosend", false, "sign the action without the wallet broadcasting it")
+	var spends listFlag
+	fs.Var(&spends, "spend", "input=unlocking script hex (repeatable)")
+	var arc arcFlags
+	arc.register(fs)
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if *reference == "" {
+		return required(fs, "reference")
+	}
+
+	ref, err := base64.StdEncoding.DecodeString(*reference)
+	if err != nil {
+		return fmt.Errorf("invalid -reference: %w", err)
+	}
+	signArgs := wallet.SignActionArgs{
+		Reference: ref,
+		Spends:    make(map[uint32]wallet.SignActionSpend, len(spends)),
+		Options: &wallet.SignActionOptions{
+			NoSend: toBoolPtr(*noSend || arc.url != ""),
+		},
+	}
+	for _, spend := range spends {
+		idx, unlock, ok := strings.Cut(spend, "=")
+		if !ok {
+			return fmt.Errorf("invalid -spend %q: expected input=script", spend)
+		}
+		n, err := strconv.ParseUint(idx, 10, 32)
+		if err != nil {
+			return fmt.Errorf("invalid -spend input %q: %w", idx, err)
+		}
+		unlockingScript, err := hex.DecodeString(unlock)
+		if err != nil {
+			return fmt.Errorf("invalid -spend script for input %d: %w", n, err)
+		}
+		signArgs.Spends[uint32(n)] = wallet.SignActionSpend{UnlockingScript: unlockingScript}
+	}
+
+	result, err := e.wallet.SignAction(ctx, signArgs, e.originator)
+	if err != nil {
+		return err
+	}
+	out := actionOutput{Txid: result.Txid.String(), Tx: hex.EncodeToString(result.Tx)}
+	if arc.url != "" {
+		if out.Broadcast, err = arc.broadcast(ctx, result.Tx); err != nil {
+			return err
+		}
+	}
+	return e.print(out)
+}
+
+// actionOutput is the output of the send and sign commands.
+type actionOutput struct {
+	Txid string `json:"txid"`
+	// Tx is the hex encoded Atomic BEEF of the transaction, or the partial
+	// transaction to sign when a reference is returned.
+	Tx        string                        `json:"tx,omitempty"`
+	Reference string                        `json:"reference,omitempty"`
+	Broadcast *transaction.BroadcastSuccess `json:"broadcast,omitempty"`
+}
+
+func runBroadcast(ctx context.Context, e *env, args []string) error {
+	fs := e.newFlagSet("broadcast")
+	beefHex := fs.String("beef", "", "hex encoded BEEF of the transaction")
+	var arc arcFlags
+	arc.register(fs)
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if *beefHex == "" {
+		return required(fs, "beef")
+	}
+	if arc.url == "" {
+		return required(fs, "arc")
+	}
+
+	beef, err := hex.DecodeString(*beefHex)
+	if err != nil {
+		return fmt.Errorf("invalid -beef: %w", err)
+	}
+	success, err := arc.broadcast(ctx, beef)
+	if err != nil {
+		return err
+	}
+	return e.print(success)
+}
+
+func runCerts(ctx context.Context, e *env, args []string) error {
+	fs := e.newFlagSet("certs")
+	var certifiers, types listFlag
+	fs.Var(&certifiers, "certifier", "only list certificates from the certifier public key (repeatable)")
+	fs.Var(&types, "type", "only list certificates of the type name (repeatable)")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+
+	var listArgs wallet.ListCertificatesArgs
+	for _, c := range certifiers {
+		certifier, err := ec.PublicKeyFromString(c)
+		if err != nil {
+			return fmt.Errorf("invalid -certifier %q: %w", c, err)
+		}
+		listArgs.Certifiers = append(listArgs.Certifiers, certifier)
+	}
+	for _, t := range types {
+		certType, err := wallet.CertificateTypeFromString(t)
+		if err != nil {
+			return err
+		}
+		listArgs.Types = append(listArgs.Types, certType)
+	}
+
+	result, err := e.wallet.ListCertificates(ctx, listArgs, e.originator)
+	if err != nil {
+		return err
+	}
+	return e.print(result)
+}
+
+func runAcquire(ctx context.Context, e *env, args []string) error {
+	fs := e.newFlagSet("acquire")
+	typeName := fs.String("type", "", "certificate type name")
+	certifierHex := fs.String("certifier", "", "certifier public key")
+	certifierURL := fs.String("url", "", "URL of the certifier issuing the certificate")
+	var fields listFlag
+	fs.Var(&fields, "field", "name=value of a certificate field (repeatable)")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	switch {
+	case *typeName == "":
+		return required(fs, "type")
+	case *certifierHex == "":
+		return required(fs, "certifier")
+	case *certifierURL == "":
+		return required(fs, "url")
+	}
+
+	certType, err := wallet.CertificateTypeFromString(*typeName)
+	if err != nil {
+		return err
+	}
+	certifier, err := ec.PublicKeyFromString(*certifierHex)
+	if err != nil {
+		return fmt.Errorf("invalid -certifier: %w", err)
+	}
+	fieldValues, err := fields.keyValues()
+	if err != nil {
+		return fmt.Errorf("invalid -field: %w", err)
+	}
+
+	cert, err := e.wallet.AcquireCertificate(ctx, wallet.AcquireCertificateArgs{
+		Type:                certType,
+		Certifier:           certifier,
+		AcquisitionProtocol: wallet.AcquisitionProtocolIssuance,
+		Fields:              fieldValues,
+		CertifierUrl:        *certifierURL,
+	}, e.originator)
+	if err != nil {
+		return err
+	}
+	return e.print(cert)
+}
+
+func toBoolPtr(b bool) *bool {
+	return &b
+}
+
+func toUint32Ptr(n uint) *uint32 {
+	v := uint32(n)
+	return &v
+}
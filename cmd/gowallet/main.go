@@ -0,0 +1,202 @@
+// Command gowallet is a minimal wallet client built on the SDK. It talks to a
+// wallet over the HTTP wallet wire substrate, so it works against any wallet
+// serving the BRC-100 wire protocol, such as a wallet backed by a storage
+// server. It doubles as a reference for wiring the SDK together and as a driver
+// for integration tests.
+//
+// Usage:
+//
+//	gowallet [-wallet URL] [-originator NAME] <command> [flags]
+//
+// Every command prints its result as JSON. Run gowallet -h for the commands.
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strings"
+
+	"github.com/bsv-blockchain/go-sdk/wallet"
+	"github.com/bsv-blockchain/go-sdk/wallet/substrates"
+)
+
+const defaultOriginator = "gowallet"
+
+// env is what a command runs with.
+type env struct {
+	wallet     wallet.Interface
+	originator string
+	stdout     io.Writer
+	stderr     io.Writer
+}
+
+// print writes v to stdout as indented JSON.
+func (e *env) print(v any) error {
+	enc := json.NewEncoder(e.stdout)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
+
+type command struct {
+	name  string
+	usage string
+	run   func(ctx context.Context, e *env, args []string) error
+}
+
+var commands = []command{
+	{"keygen", "generate a private key and print its WIF, public key and address", runKeygen},
+	{"pubkey", "print the wallet's identity key", runPubKey},
+	{"outputs", "list the outputs of a basket", runOutputs},
+	{"actions", "list the actions with the given labels", runActions},
+	{"fund", "internalize an output paying the wallet into a basket", runFund},
+	{"send", "create an action paying an address, broadcasting it with ARC if requested", runSend},
+	{"sign", "sign a previously created action", runSign},
+	{"broadcast", "broadcast a BEEF transaction with ARC", runBroadcast},
+	{"certs", "list the wallet's certificates", runCerts},
+	{"acquire", "acquire a certificate from a certifier", runAcquire},
+}
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	code := run(ctx, os.Args[1:], os.Stdout, os.Stderr)
+	stop()
+	os.Exit(code)
+}
+
+// run runs gowallet with args, returning its exit code.
+func run(ctx context.Context, args []string, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("gowallet", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	walletURL := fs.String("wallet", "", "wallet wire endpoint (default http://localhost:3301)")
+	originator := fs.String("originator", defaultOriginator, "originator the calls are made on behalf of")
+	fs.Usage = func() {
+		fmt.Fprintln(stderr, "usage: gowallet [flags] <command> [command flags]")
+		fmt.Fprintln(stderr, "\ncommands:")
+		for _, c := range commands {
+			fmt.Fprintf(stderr, "  %-10s %s\n", c.name, c.usage)
+		}
+		fmt.Fprintln(stderr, "\nflags:")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return 0
+		}
+		return 2
+	}
+	if fs.NArg() == 0 {
+		fs.Usage()
+		return 2
+	}
+
+	name := fs.Arg(0)
+	for _, c := range commands {
+		if c.name != name {
+			continue
+		}
+		e := &env{
+			wallet: &substrates.WalletWireTransceiver{
+				Wire: substrates.NewHTTPWalletWire(*originator, *walletURL, nil),
+			},
+			originator: *originator,
+			stdout:     stdout,
+			stderr:     stderr,
+		}
+		err := c.run(ctx, e, fs.Args()[1:])
+		var usageErr usageError
+		switch {
+		case err == nil:
+			return 0
+		case errors.Is(err, flag.ErrHelp):
+			return 0
+		case errors.As(err, &usageErr):
+			// The flag set has already reported it.
+			return 2
+		}
+		fmt.Fprintf(stderr, "gowallet %s: %v\n", name, err)
+		return 1
+	}
+	fmt.Fprintf(stderr, "gowallet: unknown command %q\n", name)
+	fs.Usage()
+	return 2
+}
+
+// usageError is a command line error that has already been reported along with
+// the usage of the command.
+type usageError struct {
+	err error
+}
+
+func (e usageError) Error() string {
+	return e.err.Error()
+}
+
+func (e usageError) Unwrap() error {
+	return e.err
+}
+
+// newFlagSet creates the flag set of a command.
+func (e *env) newFlagSet(name string) *flag.FlagSet {
+	fs := flag.NewFlagSet("gowallet "+name, flag.ContinueOnError)
+	fs.SetOutput(e.stderr)
+	return fs
+}
+
+// parseFlags parses the flags of a command, rejecting positional arguments.
+func parseFlags(fs *flag.FlagSet, args []string) error {
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return err
+		}
+		return usageError{err}
+	}
+	if fs.NArg() > 0 {
+		err := fmt.Errorf("unexpected argument %q", fs.Arg(0))
+		fmt.Fprintln(fs.Output(), err)
+		fs.Usage()
+		return usageError{err}
+	}
+	return nil
+}
+
+// required reports a missing required flag along with the usage of the command.
+func required(fs *flag.FlagSet, name string) error {
+	err := fmt.Errorf("-%s is required", name)
+	fmt.Fprintln(fs.Output(), err)
+	fs.Usage()
+	return usageError{err}
+}
+
+// listFlag is a repeatable string flag.
+type listFlag []string
+
+func (l *listFlag) String() string {
+	return strings.Join(*l, ",")
+}
+
+func (l *listFlag) Set(s string) error {
+	*l = append(*l, s)
+	return nil
+}
+
+// keyValues splits each key=value entry of l into a map.
+func (l listFlag) keyValues() (map[string]string, error) {
+	if len(l) == 0 {
+		return nil, nil
+	}
+	m := make(map[string]string, len(l))
+	for _, kv := range l {
+		k, v, ok := strings.Cut(kv, "=")
+		if !ok || k == "" {
+			return nil, fmt.Errorf("expected key=value, got %q", kv)
+		}
+		m[k] = v
+	}
+	return m, nil
+}
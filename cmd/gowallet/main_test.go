@@ -0,0 +1,285 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	ec "github.com/bsv-blockchain/go-sdk/primitives/ec"
+	"github.com/bsv-blockchain/go-sdk/script"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+	"github.com/bsv-blockchain/go-sdk/transaction/template/p2pkh"
+	"github.com/bsv-blockchain/go-sdk/wallet"
+	"github.com/bsv-blockchain/go-sdk/wallet/serializer"
+	"github.com/bsv-blockchain/go-sdk/wallet/substrates"
+	"github.com/stretchr/testify/require"
+)
+
+// wireCalls maps the endpoints of the HTTP wallet wire to their call codes.
+var wireCalls = map[string]substrates.Call{
+	"createAction":       substrates.CallCreateAction,
+	"signAction":         substrates.CallSignAction,
+	"listActions":        substrates.CallListActions,
+	"internalizeAction":  substrates.CallInternalizeAction,
+	"listOutputs":        substrates.CallListOutputs,
+	"getPublicKey":       substrates.CallGetPublicKey,
+	"acquireCertificate": substrates.CallAcquireCertificate,
+	"listCertificates":   substrates.CallListCertificates,
+}
+
+// walletServer serves w over the HTTP wallet wire.
+func walletServer(t *testing.T, w wallet.Interface) *httptest.Server {
+	processor := substrates.NewWalletWireProcessor(w)
+	srv := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		call, ok := wireCalls[strings.TrimPrefix(r.URL.Path, "/")]
+		if !ok {
+			http.NotFound(rw, r)
+			return
+		}
+		params, err := io.ReadAll(r.Body)
+		if err != nil {
+			http.Error(rw, err.Error(), http.StatusBadRequest)
+			return
+		}
+		frame := serializer.WriteRequestFrame(serializer.RequestFrame{
+			Call:       byte(call),
+			Originator: r.Header.Get("Origin"),
+			Params:     params,
+		})
+		response, err := processor.TransmitToWallet(r.Context(), frame)
+		if err != nil {
+			http.Error(rw, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		_, _ = rw.Write(response)
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+// memoryWallet is a test wallet keeping its outputs, actions and certificates in
+// memory, standing in for a wallet backed by a storage server.
+type memoryWallet struct {
+	*wallet.TestWallet
+
+	mu           sync.Mutex
+	outputs      map[string][]wallet.Output
+	actions      []wallet.Action
+	certificates []wallet.CertificateResult
+}
+
+func newMemoryWallet(t *testing.T) *memoryWallet {
+	m := &memoryWallet{
+		TestWallet: wallet.NewTestWalletForRandomKey(t),
+		outputs:    make(map[string][]wallet.Output),
+	}
+	m.ExpectOriginator(defaultOriginator)
+
+	m.OnInternalizeAction().Do(func(_ context.Context, args wallet.InternalizeActionArgs, _ string) (*wallet.InternalizeActionResult, error) {
+		tx, err := transaction.NewTransactionFromBEEF(args.Tx)
+		if err != nil {
+			return nil, err
+		}
+		m.mu.Lock()
+		defer m.mu.Unlock()
+		for _, out := range args.Outputs {
+			insertion := out.InsertionRemittance
+			m.outputs[insertion.Basket] = append(m.outputs[insertion.Basket], wallet.Output{
+				Satoshis:      tx.Outputs[out.OutputIndex].Satoshis,
+				LockingScript: tx.Outputs[out.OutputIndex].LockingScript.Bytes(),
+				Spendable:     true,
+				Tags:          insertion.Tags,
+				Outpoint:      transaction.Outpoint{Txid: *tx.TxID(), Index: out.OutputIndex},
+			})
+		}
+		return &wallet.InternalizeActionResult{Accepted: true}, nil
+	})
+	m.OnListOutputs().Do(func(_ context.Context, args wallet.ListOutputsArgs, _ string) (*wallet.ListOutputsResult, error) {
+		m.mu.Lock()
+		defer m.mu.Unlock()
+		outputs := m.outputs[args.Basket]
+		return &wallet.ListOutputsResult{TotalOutputs: uint32(len(outputs)), Outputs: outputs}, nil
+	})
+	m.OnCreateAction().Do(func(_ context.Context, args wallet.CreateActionArgs, _ string) (*wallet.CreateActionResult, error) {
+		tx := transaction.NewTransaction()
+		for _, out := range args.Outputs {
+			tx.AddOutput(&transaction.TransactionOutput{
+				Satoshis:      out.Satoshis,
+				LockingScript: script.NewFromBytes(out.LockingScript),
+			})
+		}
+		beef, err := tx.AtomicBEEF(false)
+		if err != nil {
+			return nil, err
+		}
+		m.mu.Lock()
+		defer m.mu.Unlock()
+		m.actions = append(m.actions, wallet.Action{
+			Txid:        *tx.TxID(),
+			Satoshis:    -int64(args.Outputs[0].Satoshis),
+			Status:      wallet.ActionStatusNoSend,
+			Description: args.Description,
+			Labels:      args.Labels,
+		})
+		return &wallet.CreateActionResult{Txid: *tx.TxID(), Tx: beef}, nil
+	})
+	m.OnListActions().Do(func(_ context.Context, _ wallet.ListActionsArgs, _ string) (*wallet.ListActionsResult, error) {
+		m.mu.Lock()
+		defer m.mu.Unlock()
+		return &wallet.ListActionsResult{TotalActions: uint32(len(m.actions)), Actions: m.actions}, nil
+	})
+	m.OnAcquireCertificate().Do(func(_ context.Context, args wallet.AcquireCertificateArgs, _ string) (*wallet.Certificate, error) {
+		subject, err := ec.PublicKeyFromString(m.Name)
+		if err != nil {
+			return nil, err
+		}
+		cert := wallet.Certificate{
+			Type:      args.Type,
+			Subject:   subject,
+			Certifier: args.Certifier,
+			Fields:    args.Fields,
+			// Issued certificates are not revocable.
+			RevocationOutpoint: &transaction.Outpoint{},
+		}
+		m.mu.Lock()
+		defer m.mu.Unlock()
+		m.certificates = append(m.certificates, wallet.CertificateResult{Certificate: cert})
+		return &cert, nil
+	})
+	m.OnListCertificates().Do(func(_ context.Context, _ wallet.ListCertificatesArgs, _ string) (*wallet.ListCertificatesResult, error) {
+		m.mu.Lock()
+		defer m.mu.Unlock()
+		return &wallet.ListCertificatesResult{TotalCertificates: uint32(len(m.certificates)), Certificates: m.certificates}, nil
+	})
+	return m
+}
+
+// runGowallet runs gowallet against the wallet served at url, decoding its output
+// into out.
+func runGowallet(t *testing.T, url string, out any, args ...string) {
+	t.Helper()
+	var stdout, stderr bytes.Buffer
+	code := run(t.Context(), append([]string{"-wallet", url}, args...), &stdout, &stderr)
+	require.Equal(t, 0, code, "gowallet %v: %s", args, stderr.String())
+	require.NoError(t, json.Unmarshal(stdout.Bytes(), out), stdout.String())
+}
+
+func TestGowallet(t *testing.T) {
+	w := newMemoryWallet(t)
+	srv := walletServer(t, w)
+
+	var arcCalls atomic.Int32
+	arc := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/tx" {
+			http.NotFound(rw, r)
+			return
+		}
+		arcCalls.Add(1)
+		_, _ = rw.Write([]byte(`{"status":200,"title":"OK","txid":"broadcast"}`))
+	}))
+	t.Cleanup(arc.Close)
+
+	t.Run("keygen", func(t *testing.T) {
+		var key struct {
+			WIF       string `json:"wif"`
+			PublicKey string `json:"publicKey"`
+			Address   string `json:"address"`
+		}
+		runGowallet(t, srv.URL, &key, "keygen")
+
+		priv, err := ec.PrivateKeyFromWif(key.WIF)
+		require.NoError(t, err)
+		require.Equal(t, priv.PubKey().ToDERHex(), key.PublicKey)
+		address, err := script.NewAddressFromPublicKey(priv.PubKey(), true)
+		require.NoError(t, err)
+		require.Equal(t, address.AddressString, key.Address)
+	})
+
+	t.Run("pubkey", func(t *testing.T) {
+		var result wallet.GetPublicKeyResult
+		runGowallet(t, srv.URL, &result, "pubkey")
+		require.Equal(t, w.Name, result.PublicKey.ToDERHex())
+	})
+
+	t.Run("fund and list outputs", func(t *testing.T) {
+		identity, err := ec.PublicKeyFromString(w.Name)
+		require.NoError(t, err)
+		address, err := script.NewAddressFromPublicKey(identity, true)
+		require.NoError(t, err)
+		lockingScript, err := p2pkh.Lock(address)
+		require.NoError(t, err)
+		funding := transaction.NewTransaction()
+		funding.AddOutput(&transaction.TransactionOutput{Satoshis: 5000, LockingScript: lockingScript})
+		beef, err := funding.AtomicBEEF(false)
+		require.NoError(t, err)
+
+		var internalized wallet.InternalizeActionResult
+		runGowallet(t, srv.URL, &internalized, "fund", "-beef", hex.EncodeToString(beef), "-basket", "savings", "-tag", "cli")
+		require.True(t, internalized.Accepted)
+
+		var listed wallet.ListOutputsResult
+		runGowallet(t, srv.URL, &listed, "outputs", "-basket", "savings")
+		require.Len(t, listed.Outputs, 1)
+		require.Equal(t, uint64(5000), listed.Outputs[0].Satoshis)
+		require.Equal(t, funding.TxID().String(), listed.Outputs[0].Outpoint.Txid.String())
+		require.Equal(t, []string{"cli"}, listed.Outputs[0].Tags)
+	})
+
+	t.Run("send and broadcast", func(t *testing.T) {
+		var sent actionOutput
+		runGowallet(t, srv.URL, &sent, "send", "-to", "1AdZmoAQUw4XCsCihukoHMvNWXcsd8jDN6",
+			"-satoshis", "1000", "-label", "cli", "-arc", arc.URL)
+		require.NotEmpty(t, sent.Txid)
+		require.NotEmpty(t, sent.Tx)
+		require.Empty(t, sent.Reference)
+		require.NotNil(t, sent.Broadcast)
+		require.Equal(t, int32(1), arcCalls.Load())
+
+		var actions wallet.ListActionsResult
+		runGowallet(t, srv.URL, &actions, "actions", "-label", "cli")
+		require.Len(t, actions.Actions, 1)
+		require.Equal(t, sent.Txid, actions.Actions[0].Txid.String())
+	})
+
+	t.Run("acquire and list certificates", func(t *testing.T) {
+		certifier, err := ec.NewPrivateKey()
+		require.NoError(t, err)
+
+		var cert wallet.Certificate
+		runGowallet(t, srv.URL, &cert, "acquire", "-type", "email", "-certifier", certifier.PubKey().ToDERHex(),
+			"-url", "https://certifier.example.com", "-field", "email=alice@example.com")
+		require.Equal(t, "alice@example.com", cert.Fields["email"])
+
+		var listed wallet.ListCertificatesResult
+		runGowallet(t, srv.URL, &listed, "certs")
+		require.Len(t, listed.Certificates, 1)
+		require.True(t, certifier.PubKey().IsEqual(listed.Certificates[0].Certifier))
+	})
+}
+
+func TestGowalletUsage(t *testing.T) {
+	tests := []struct {
+		args []string
+		code int
+	}{
+		{nil, 2},
+		{[]string{"-h"}, 0},
+		{[]string{"unknown"}, 2},
+		{[]string{"send", "-h"}, 0},
+		{[]string{"send", "-satoshis", "1"}, 2},
+		{[]string{"keygen", "extra"}, 2},
+		{[]string{"send", "-to", "not an address", "-satoshis", "1"}, 1},
+	}
+	for _, tt := range tests {
+		var stdout, stderr bytes.Buffer
+		require.Equal(t, tt.code, run(t.Context(), tt.args, &stdout, &stderr), "%v: %s", tt.args, stderr.String())
+	}
+}
@@ -0,0 +1,159 @@
+// Command scriptrepl is an interactive script evaluator. Each line of script
+// entered in ASM is executed on top of the lines before it, printing the stacks
+// after every opcode, for learning and prototyping scripts.
+//
+// Usage:
+//
+//	scriptrepl [-pregenesis] [-quiet]
+//
+// Besides script, the following commands are accepted:
+//
+//	:undo    discard the last line
+//	:reset   discard every line
+//	:script  print the script built so far
+//	:stack   print the stacks
+//	:help    print the commands
+//	:quit    exit
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/hex"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strings"
+
+	"github.com/bsv-blockchain/go-sdk/script/interpreter"
+)
+
+const help = `Enter script in ASM, such as OP_2 OP_3 OP_ADD, or one of the commands:
+  :undo    discard the last line
+  :reset   discard every line
+  :script  print the script built so far
+  :stack   print the stacks
+  :help    print this help
+  :quit    exit`
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	code := run(ctx, os.Args[1:], os.Stdin, os.Stdout, os.Stderr)
+	stop()
+	os.Exit(code)
+}
+
+// run runs scriptrepl with args, returning its exit code.
+func run(ctx context.Context, args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("scriptrepl", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	preGenesis := fs.Bool("pregenesis", false, "execute with the rules before the Genesis upgrade")
+	quiet := fs.Bool("quiet", false, "print the stacks after each line rather than after each opcode")
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return 0
+		}
+		return 2
+	}
+	if fs.NArg() > 0 {
+		fmt.Fprintf(stderr, "scriptrepl: unexpected argument %q\n", fs.Arg(0))
+		return 2
+	}
+
+	opts := []interpreter.ExecutionOptionFunc{interpreter.WithForkID()}
+	if !*preGenesis {
+		opts = append(opts, interpreter.WithAfterGenesis())
+	}
+	r := interpreter.NewREPL(opts...)
+
+	fmt.Fprintln(stdout, `Type :help for help.`)
+	in := bufio.NewScanner(stdin)
+	for {
+		fmt.Fprint(stdout, "> ")
+		if !in.Scan() {
+			fmt.Fprintln(stdout)
+			break
+		}
+		line := strings.TrimSpace(in.Text())
+
+		switch line {
+		case ":quit", ":q":
+			return 0
+		case ":help":
+			fmt.Fprintln(stdout, help)
+		case ":reset":
+			r.Reset()
+		case ":undo":
+			if ok, err := r.Undo(ctx); err != nil {
+				fmt.Fprintf(stdout, "error: %v\n", err)
+			} else if !ok {
+				fmt.Fprintln(stdout, "nothing to undo")
+			} else {
+				printStacks(stdout, r.State())
+			}
+		case ":script":
+			fmt.Fprintln(stdout, r.Script().ToASM())
+		case ":stack":
+			printStacks(stdout, r.State())
+		default:
+			if strings.HasPrefix(line, ":") {
+				fmt.Fprintf(stdout, "unknown command %s, type :help for help\n", line)
+				continue
+			}
+			states, err := r.Eval(ctx, line)
+			if err != nil {
+				var execErr *interpreter.ExecutionError
+				if errors.As(err, &execErr) {
+					fmt.Fprintf(stdout, "error: %s\n", execErr.Detail())
+				} else {
+					fmt.Fprintf(stdout, "error: %v\n", err)
+				}
+				continue
+			}
+			if *quiet || len(states) == 0 {
+				if len(states) > 0 {
+					printStacks(stdout, r.State())
+				}
+				continue
+			}
+			for _, s := range states {
+				fmt.Fprintf(stdout, "%-22s ", s.Opcode().Name())
+				printStacks(stdout, s)
+			}
+		}
+	}
+	if err := in.Err(); err != nil {
+		fmt.Fprintf(stderr, "scriptrepl: %v\n", err)
+		return 1
+	}
+	return 0
+}
+
+// printStacks prints the data stack of s with its top last, followed by the alt
+// stack when it isn't empty.
+func printStacks(w io.Writer, s *interpreter.State) {
+	if s == nil {
+		fmt.Fprintln(w, "[]")
+		return
+	}
+	line := formatStack(s.DataStack)
+	if len(s.AltStack) > 0 {
+		line += " alt: " + formatStack(s.AltStack)
+	}
+	fmt.Fprintln(w, line)
+}
+
+func formatStack(stack [][]byte) string {
+	items := make([]string, len(stack))
+	for i, item := range stack {
+		if len(item) == 0 {
+			items[i] = `""`
+			continue
+		}
+		items[i] = hex.EncodeToString(item)
+	}
+	return "[" + strings.Join(items, " ") + "]"
+}
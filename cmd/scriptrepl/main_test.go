@@ -0,0 +1,45 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestScriptREPL(t *testing.T) {
+	input := strings.Join([]string{
+		"OP_2 OP_3",
+		"OP_ADD OP_DUP OP_TOALTSTACK",
+		"OP_DROP OP_DROP",
+		":undo",
+		":script",
+		"OP_0",
+		":stack",
+		":bogus",
+		":quit",
+		"OP_1",
+	}, "\n")
+
+	var stdout, stderr bytes.Buffer
+	require.Equal(t, 0, run(t.Context(), nil, strings.NewReader(input), &stdout, &stderr), stderr.String())
+
+	out := stdout.String()
+	require.Contains(t, out, "OP_3                   [02 03]\n")
+	require.Contains(t, out, "OP_TOALTSTACK          [05] alt: [05]\n")
+	require.Contains(t, out, "error: ")
+	require.Contains(t, out, "OP_DROP")
+	require.Contains(t, out, "> [02 03]\n")
+	require.Contains(t, out, "> OP_2 OP_3\n")
+	require.Contains(t, out, `> [02 03 ""]`+"\n")
+	require.Contains(t, out, "unknown command :bogus")
+	require.NotContains(t, out, "OP_1 ")
+}
+
+func TestScriptREPLQuiet(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	code := run(t.Context(), []string{"-quiet", "-pregenesis"}, strings.NewReader("OP_2 OP_3 OP_ADD\n"), &stdout, &stderr)
+	require.Equal(t, 0, code, stderr.String())
+	require.Equal(t, "Type :help for help.\n> [05]\n> \n", stdout.String())
+}
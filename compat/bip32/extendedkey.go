@@ -22,6 +22,7 @@ import (
 	base58 "github.com/bsv-blockchain/go-sdk/compat/base58"
 	ec "github.com/bsv-blockchain/go-sdk/primitives/ec"
 	crypto "github.com/bsv-blockchain/go-sdk/primitives/hash"
+	"github.com/bsv-blockchain/go-sdk/primitives/secure"
 	chaincfg "github.com/bsv-blockchain/go-sdk/transaction/chaincfg"
 )
 
@@ -115,6 +116,10 @@ type ExtendedKey struct {
 	depth     uint8
 	isPrivate bool
 	o         sync.Once
+
+	// secret holds the key and chain code of extended private keys, which
+	// key and chainCode point into, so they are wiped with the key.
+	secret *secure.SecretBytes
 }
 
 // NewExtendedKey returns a new instance of an extended key with the given
@@ -122,12 +127,15 @@ type ExtendedKey struct {
 // convenience method used to create a populated struct. This function should
 // only by used by applications that need to create custom ExtendedKeys. All
 // other applications should just use NewMaster, Child, or Neuter.
+//
+// The key and chain code of extended private keys are copied, so that they are
+// zeroed by Zero or once the extended key is garbage collected.
 func NewExtendedKey(version, key, chainCode, parentFP []byte, depth uint8,
 	childNum uint32, isPrivate bool) *ExtendedKey {
 
 	// NOTE: The pubKey field is intentionally left nil so it is only
 	// computed and memoized as required.
-	return &ExtendedKey{
+	k := &ExtendedKey{
 		key:       key,
 		chainCode: chainCode,
 		depth:     depth,
@@ -136,6 +144,14 @@ func NewExtendedKey(version, key, chainCode, parentFP []byte, depth uint8,
 		version:   version,
 		isPrivate: isPrivate,
 	}
+	if isPrivate {
+		secret := make([]byte, 0, len(key)+len(chainCode))
+		secret = append(append(secret, key...), chainCode...)
+		k.secret = secure.NewSecretBytes(secret)
+		k.key = secret[:len(key):len(key)]
+		k.chainCode = secret[len(key):]
+	}
+	return k
 }
 
 // pubKeyBytes returns bytes for the serialized compressed public key associated
@@ -262,6 +278,10 @@ func (k *ExtendedKey) Child(i uint32) (*ExtendedKey, error) {
 		return nil, err
 	}
 	ilr := hmac512.Sum(nil)
+	if k.isPrivate {
+		// The child key and chain code are copied by NewExtendedKey.
+		defer secure.Wipe(ilr)
+	}
 
 	// Split "I" into two 32-byte sequences Il and Ir where:
 	//   Il = intermediate key used to derive the child
@@ -276,6 +296,7 @@ func (k *ExtendedKey) Child(i uint32) (*ExtendedKey, error) {
 	// a child extended key can't be created for this index and the caller
 	// should simply increment to the next index.
 	ilNum := new(big.Int).SetBytes(il)
+	defer secure.WipeInt(ilNum)
 	if ilNum.Cmp(ec.S256().N) >= 0 || ilNum.Sign() == 0 {
 		return nil, ErrInvalidChild
 	}
@@ -299,7 +320,9 @@ func (k *ExtendedKey) Child(i uint32) (*ExtendedKey, error) {
 		keyNum := new(big.Int).SetBytes(k.key)
 		ilNum.Add(ilNum, keyNum)
 		ilNum.Mod(ilNum, ec.S256().N)
+		secure.WipeInt(keyNum)
 		childKey = ilNum.Bytes()
+		defer secure.Wipe(childKey)
 		isPrivate = true
 	} else {
 		// Case #3.
@@ -358,8 +381,9 @@ func (k *ExtendedKey) Neuter() (*ExtendedKey, error) {
 	// key will simply be the pubkey of the current extended private key.
 	//
 	// This is the function N((k,c)) -> (K, c) from [BIP32].
-	return NewExtendedKey(version, k.pubKeyBytes(), k.chainCode, k.parentFP,
-		k.depth, k.childNum, false), nil
+	// The fields are copied since Zero clears those of the private key.
+	return NewExtendedKey(version, bytes.Clone(k.pubKeyBytes()), bytes.Clone(k.chainCode),
+		bytes.Clone(k.parentFP), k.depth, k.childNum, false), nil
 }
 
 // ECPubKey converts the extended key to a bec public key and returns it.
@@ -383,20 +407,14 @@ func (k *ExtendedKey) ECPrivKey() (*ec.PrivateKey, error) {
 // Address converts the extended key to a standard bitcoin pay-to-pubkey-hash
 // address for the passed network.
 func (k *ExtendedKey) Address(net *chaincfg.Params) string {
-	return k.addressFromPublicKeyHash(crypto.Hash160(k.pubKeyBytes()), net.Name == chaincfg.NetworkMain)
+	return k.addressFromPublicKeyHash(crypto.Hash160(k.pubKeyBytes()), net.LegacyPubKeyHashAddrID)
 }
 
 // addressFromPublicKeyHash creates a pay-to-pubkey-hash address
 // avoids dependency on the transaction package
-func (k *ExtendedKey) addressFromPublicKeyHash(hash []byte, mainnet bool) string {
-	// regtest := 111
-	// mainnet: 0
-
-	bb := make([]byte, 1)
-	if !mainnet {
-		bb[0] = 111
-	}
-	//nolint:makezero // ignore
+func (k *ExtendedKey) addressFromPublicKeyHash(hash []byte, addrID byte) string {
+	bb := make([]byte, 0, len(hash)+1)
+	bb = append(bb, addrID)
 	bb = append(bb, hash...)
 	b := make([]byte, 0, len(bb)+4)
 	b = append(b, bb[:]...)
@@ -486,6 +504,7 @@ func (k *ExtendedKey) Zero() {
 	zero(k.pubKey)
 	zero(k.chainCode)
 	zero(k.parentFP)
+	k.secret.Wipe()
 	k.version = nil
 	k.key = nil
 	k.depth = 0
@@ -514,6 +533,7 @@ func NewMaster(seed []byte, net *chaincfg.Params) (*ExtendedKey, error) {
 		return nil, err
 	}
 	lr := hmac512.Sum(nil)
+	defer secure.Wipe(lr)
 
 	// Split "I" into two 32-byte sequences Il and Ir where:
 	//   Il = master secret key
@@ -523,6 +543,7 @@ func NewMaster(seed []byte, net *chaincfg.Params) (*ExtendedKey, error) {
 
 	// Ensure the key in usable.
 	secretKeyNum := new(big.Int).SetBytes(secretKey)
+	defer secure.WipeInt(secretKeyNum)
 	if secretKeyNum.Cmp(ec.S256().N) >= 0 || secretKeyNum.Sign() == 0 {
 		return nil, ErrUnusableSeed
 	}
@@ -571,8 +592,11 @@ func NewKeyFromString(key string) (*ExtendedKey, error) {
 	if isPrivate {
 		// Ensure the private key is valid.  It must be within the range
 		// of the order of the secp256k1 curve and not be 0.
+		// The key and chain code are copied by NewExtendedKey.
+		defer secure.Wipe(payload[13:78])
 		keyData = keyData[1:]
 		keyNum := new(big.Int).SetBytes(keyData)
+		defer secure.WipeInt(keyNum)
 		if keyNum.Cmp(ec.S256().N) >= 0 || keyNum.Sign() == 0 {
 			return nil, ErrUnusableSeed
 		}
@@ -5,6 +5,7 @@ import (
 
 	"github.com/bsv-blockchain/go-sdk/compat/bip39"
 	ec "github.com/bsv-blockchain/go-sdk/primitives/ec"
+	"github.com/bsv-blockchain/go-sdk/primitives/secure"
 	script "github.com/bsv-blockchain/go-sdk/script"
 	chaincfg "github.com/bsv-blockchain/go-sdk/transaction/chaincfg"
 )
@@ -38,9 +39,14 @@ func GenerateHDKey(seedLength uint8) (hdKey *ExtendedKey, err error) {
 	if seed, err = GenerateSeed(seedLength); err != nil {
 		return
 	}
+	secret := secure.NewSecretBytes(seed)
+	defer secret.Wipe()
 
 	// Generate a new master key
-	return NewMaster(seed, &chaincfg.MainNet)
+	secret.Use(func(seed []byte) {
+		hdKey, err = NewMaster(seed, &chaincfg.MainNet)
+	})
+	return
 }
 
 // GenerateHDKeyFromString will create a new master node for use in creating a
@@ -50,8 +56,12 @@ func GenerateHDKeyFromString(xPriv string) (hdKey *ExtendedKey, err error) {
 }
 
 func GenerateHDKeyFromMnemonic(mnemonic string, password string, net *chaincfg.Params) (hdKey *ExtendedKey, err error) {
-	seed := bip39.NewSeed(mnemonic, password)
-	return NewMaster(seed, net)
+	seed := secure.NewSecretBytes(bip39.NewSeed(mnemonic, password))
+	defer seed.Wipe()
+	seed.Use(func(b []byte) {
+		hdKey, err = NewMaster(b, net)
+	})
+	return
 }
 
 // GenerateHDKeyPair will generate a new xPub HD master node (xPrivateKey & xPublicKey)
@@ -8,6 +8,7 @@ import (
 	compat "github.com/bsv-blockchain/go-sdk/compat/bip32"
 	ec "github.com/bsv-blockchain/go-sdk/primitives/ec"
 	script "github.com/bsv-blockchain/go-sdk/script"
+	chaincfg "github.com/bsv-blockchain/go-sdk/transaction/chaincfg"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -1072,3 +1073,55 @@ func BenchmarkGetHDKeyFromExtendedPublicKey(b *testing.B) {
 		require.NotNil(b, key)
 	}
 }
+
+// TestExtendedKeyAddressForNetwork will test Address() encodes with the network's prefix
+func TestExtendedKeyAddressForNetwork(t *testing.T) {
+	t.Parallel()
+
+	key, err := compat.GenerateHDKey(compat.RecommendedSeedLength)
+	require.NoError(t, err)
+	pubKey, err := key.ECPubKey()
+	require.NoError(t, err)
+
+	mainnet, err := script.NewAddressFromPublicKey(pubKey, true)
+	require.NoError(t, err)
+	testnet, err := script.NewAddressFromPublicKey(pubKey, false)
+	require.NoError(t, err)
+
+	assert.Equal(t, mainnet.AddressString, key.Address(&chaincfg.MainNet))
+	assert.Equal(t, testnet.AddressString, key.Address(&chaincfg.TestNet))
+	assert.Equal(t, testnet.AddressString, key.Address(&chaincfg.TestNet3))
+	assert.Equal(t, testnet.AddressString, key.Address(&chaincfg.STN))
+}
+
+// TestExtendedKeyZero will test that zeroing a private key wipes its key material
+// without affecting keys derived from it
+func TestExtendedKeyZero(t *testing.T) {
+	t.Parallel()
+
+	key := make([]byte, 32)
+	key[31] = 1
+	chainCode := make([]byte, 32)
+	chainCode[0] = 2
+	hdKey := compat.NewExtendedKey(chaincfg.MainNet.HDPrivateKeyID[:], key, chainCode, []byte{0, 0, 0, 0}, 0, 0, true)
+	xPriv := hdKey.String()
+
+	// The key material is copied
+	key[31], chainCode[0] = 0, 0
+	require.Equal(t, xPriv, hdKey.String())
+
+	child, err := hdKey.Child(1)
+	require.NoError(t, err)
+	childXPriv := child.String()
+	pub, err := hdKey.Neuter()
+	require.NoError(t, err)
+	xPub := pub.String()
+
+	hdKey.Zero()
+	assert.Equal(t, childXPriv, child.String())
+	assert.Equal(t, xPub, pub.String())
+
+	parsed, err := compat.NewKeyFromString(xPriv)
+	require.NoError(t, err)
+	assert.Equal(t, xPriv, parsed.String())
+}
@@ -24,6 +24,15 @@ func SignMessageWithCompression(privateKey *ec.PrivateKey, message []byte, sigRe
 		return nil, errors.New("private key is required")
 	}
 
+	messageHash := MagicHash(message)
+
+	// Sign
+	return ec.SignCompact(ec.S256(), privateKey, messageHash, sigRefCompressedKey)
+}
+
+// MagicHash returns the double SHA-256 of message framed with the varint-prefixed
+// "Bitcoin Signed Message" magic, which is the digest signed by BSM signatures.
+func MagicHash(message []byte) []byte {
 	b := new(bytes.Buffer)
 
 	varInt := util.VarInt(len(hBSV))
@@ -38,11 +47,7 @@ func SignMessageWithCompression(privateKey *ec.PrivateKey, message []byte, sigRe
 	// append the data to buff
 	b.Write(message)
 
-	// Create the hash
-	messageHash := crypto.Sha256d(b.Bytes())
-
-	// Sign
-	return ec.SignCompact(ec.S256(), privateKey, messageHash, sigRefCompressedKey)
+	return crypto.Sha256d(b.Bytes())
 }
 
 // SignMessageString signs the message and returns the signature as a base64-encoded string
@@ -8,6 +8,7 @@ import (
 	compat "github.com/bsv-blockchain/go-sdk/compat/bsm"
 	ec "github.com/bsv-blockchain/go-sdk/primitives/ec"
 	"github.com/bsv-blockchain/go-sdk/script"
+	"github.com/stretchr/testify/require"
 )
 
 func TestSigningCompression(t *testing.T) {
@@ -240,3 +241,34 @@ func BenchmarkSignMessage(b *testing.B) {
 		_, _ = compat.SignMessage(key, []byte("This is a test message"))
 	}
 }
+
+func TestVerifyMessageInterop(t *testing.T) {
+	testKey, err := ec.PrivateKeyFromHex("0499f8239bfe10eb0f5e53d543635a423c96529dd85fa4bad42049a0b435ebdd")
+	require.NoError(t, err)
+	// Signature produced by other SDKs for "test message" with the key above.
+	signature := "IFxPx8JHsCiivB+DW/RgNpCLT6yG3j436cUNWKekV3ORBrHNChIjeVReyAco7PVmmDtVD3POs9FhDlm/nk5I6O8="
+	address, err := script.NewAddressFromPublicKey(testKey.PubKey(), true)
+	require.NoError(t, err)
+
+	require.NoError(t, compat.VerifyMessageString(address.AddressString, signature, []byte("test message")))
+	require.Error(t, compat.VerifyMessageString(address.AddressString, signature, []byte("other message")))
+	require.Error(t, compat.VerifyMessageString(address.AddressString, "not base64!", []byte("test message")))
+
+	sig, err := base64.StdEncoding.DecodeString(signature)
+	require.NoError(t, err)
+	require.NoError(t, compat.VerifyMessageWithPublicKey(testKey.PubKey(), sig, []byte("test message")))
+
+	uncompressed, err := compat.SignMessageWithCompression(testKey, []byte("test message"), false)
+	require.NoError(t, err)
+	require.NoError(t, compat.VerifyMessageWithPublicKey(testKey.PubKey(), uncompressed, []byte("test message")))
+
+	otherKey, err := ec.NewPrivateKey()
+	require.NoError(t, err)
+	require.Error(t, compat.VerifyMessageWithPublicKey(otherKey.PubKey(), sig, []byte("test message")))
+
+	hash := compat.MagicHash([]byte("test message"))
+	pubKey, compressed, err := ec.RecoverCompact(sig, hash)
+	require.NoError(t, err)
+	require.True(t, compressed)
+	require.True(t, pubKey.IsEqual(testKey.PubKey()))
+}
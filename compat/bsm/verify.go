@@ -1,36 +1,18 @@
 package compat
 
 import (
-	"bytes"
+	"encoding/base64"
 	"encoding/hex"
+	"errors"
 	"fmt"
 
 	ec "github.com/bsv-blockchain/go-sdk/primitives/ec"
-	crypto "github.com/bsv-blockchain/go-sdk/primitives/hash"
 	"github.com/bsv-blockchain/go-sdk/script"
-	"github.com/bsv-blockchain/go-sdk/util"
 )
 
 // PubKeyFromSignature gets a publickey for a signature and tells you whether is was compressed
 func PubKeyFromSignature(sig, data []byte) (pubKey *ec.PublicKey, wasCompressed bool, err error) {
-
-	// Validate the signature - this just shows that it was valid at all
-	// we will compare it with the key next
-	var buf bytes.Buffer
-
-	varInt := util.VarInt(len(hBSV))
-	buf.Write(varInt.Bytes())
-	// append the hBsv to buff
-	buf.WriteString(hBSV)
-
-	varInt = util.VarInt(len(data))
-	buf.Write(varInt.Bytes())
-	// append the data to buff
-	buf.Write(data)
-
-	// Create the hash
-	expectedMessageHash := crypto.Sha256d(buf.Bytes())
-	return ec.RecoverCompact(sig, expectedMessageHash)
+	return ec.RecoverCompact(sig, MagicHash(data))
 }
 
 // VerifyMessage verifies a string and address against the provided
@@ -66,6 +48,33 @@ func VerifyMessage(address string, sig, data []byte) error {
 	)
 }
 
+// VerifyMessageString verifies a base64 encoded signature, as produced by
+// SignMessageString and by other SDKs and wallets, against an address.
+func VerifyMessageString(address, signature string, data []byte) error {
+	sig, err := base64.StdEncoding.DecodeString(signature)
+	if err != nil {
+		return fmt.Errorf("invalid signature encoding: %w", err)
+	}
+	return VerifyMessage(address, sig, data)
+}
+
+// VerifyMessageWithPublicKey verifies a signature against a known public key rather
+// than an address. The signature may reference either the compressed or the
+// uncompressed form of the key.
+func VerifyMessageWithPublicKey(pubKey *ec.PublicKey, sig, data []byte) error {
+	if pubKey == nil {
+		return errors.New("public key is required")
+	}
+	recovered, _, err := PubKeyFromSignature(sig, data)
+	if err != nil {
+		return err
+	}
+	if !recovered.IsEqual(pubKey) {
+		return fmt.Errorf("signature was made by %x, not %x", recovered.Compressed(), pubKey.Compressed())
+	}
+	return nil
+}
+
 // VerifyMessageDER will take a message string, a public key string and a signature string
 // (in strict DER format) and verify that the message was signed by the public key.
 func VerifyMessageDER(hash [32]byte, pubKey string, signature string) (verified bool, err error) {
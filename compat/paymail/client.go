@@ -0,0 +1,249 @@
+package paymail
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	compat "github.com/bsv-blockchain/go-sdk/compat/bsm"
+	ec "github.com/bsv-blockchain/go-sdk/primitives/ec"
+	"github.com/bsv-blockchain/go-sdk/script"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+	"github.com/bsv-blockchain/go-sdk/util"
+)
+
+// SRVLookupFunc resolves the _bsvalias._tcp SRV records of a domain.
+type SRVLookupFunc func(ctx context.Context, service, proto, name string) (string, []*net.SRV, error)
+
+// Client talks to paymail hosts.
+type Client struct {
+	HTTPClient util.HTTPClient
+	// LookupSRV overrides SRV resolution; by default the system resolver is used.
+	// Set it to a function returning an error to always use the paymail domain directly.
+	LookupSRV SRVLookupFunc
+	// Scheme is the URL scheme used for capability discovery, "https" by default.
+	Scheme string
+}
+
+// NewClient creates a Client using the default HTTP client and resolver.
+func NewClient() *Client {
+	return &Client{}
+}
+
+func (c *Client) httpClient() util.HTTPClient {
+	if c.HTTPClient != nil {
+		return c.HTTPClient
+	}
+	return http.DefaultClient
+}
+
+// resolveHost returns the host and port serving the paymail domain, honouring
+// the _bsvalias SRV record when one exists. SRV targets which are not host names
+// within the domain are ignored, so that a spoofed record cannot redirect
+// requests elsewhere.
+func (c *Client) resolveHost(ctx context.Context, domain string) string {
+	lookup := c.LookupSRV
+	if lookup == nil {
+		lookup = net.DefaultResolver.LookupSRV
+	}
+	_, records, err := lookup(ctx, "bsvalias", "tcp", domain)
+	if err != nil || len(records) == 0 {
+		return domain
+	}
+	target := strings.ToLower(strings.TrimSuffix(records[0].Target, "."))
+	if !validHostname(target) || (target != domain && !strings.HasSuffix(target, "."+domain)) {
+		return domain
+	}
+	if records[0].Port == 443 || records[0].Port == 0 {
+		return target
+	}
+	return net.JoinHostPort(target, strconv.Itoa(int(records[0].Port)))
+}
+
+// GetCapabilities fetches the capability discovery document for domain.
+func (c *Client) GetCapabilities(ctx context.Context, domain string) (*Capabilities, error) {
+	scheme := c.Scheme
+	if scheme == "" {
+		scheme = "https"
+	}
+	url := fmt.Sprintf("%s://%s/.well-known/bsvalias", scheme, c.resolveHost(ctx, domain))
+	var capabilities Capabilities
+	if err := c.do(ctx, http.MethodGet, url, nil, &capabilities); err != nil {
+		return nil, fmt.Errorf("failed to discover capabilities for %s: %w", domain, err)
+	}
+	return &capabilities, nil
+}
+
+// capabilityURL discovers the address's capabilities and resolves the endpoint for
+// the first matching capability id.
+func (c *Client) capabilityURL(ctx context.Context, address *Address, ids ...string) (string, error) {
+	capabilities, err := c.GetCapabilities(ctx, address.Domain)
+	if err != nil {
+		return "", err
+	}
+	template, ok := capabilities.URL(ids...)
+	if !ok {
+		return "", fmt.Errorf("%w: %s", ErrCapabilityNotFound, ids[0])
+	}
+	return ResolveTemplate(template, address, ""), nil
+}
+
+// GetPublicKey resolves the identity public key of a paymail handle.
+func (c *Client) GetPublicKey(ctx context.Context, handle string) (*ec.PublicKey, error) {
+	address, err := ParseAddress(handle)
+	if err != nil {
+		return nil, err
+	}
+	url, err := c.capabilityURL(ctx, address, BRFCPki, BRFCPkiAlternate)
+	if err != nil {
+		return nil, err
+	}
+	var pki PKIResponse
+	if err := c.do(ctx, http.MethodGet, url, nil, &pki); err != nil {
+		return nil, fmt.Errorf("failed to resolve public key for %s: %w", handle, err)
+	}
+	if !strings.EqualFold(pki.Handle, address.String()) {
+		return nil, fmt.Errorf("pki response is for %q, expected %q", pki.Handle, address.String())
+	}
+	return ec.PublicKeyFromString(pki.PubKey)
+}
+
+// SignPaymentDestinationRequest fills in the request's timestamp and signs it with
+// the sender's key, as required by hosts that enable sender validation.
+func SignPaymentDestinationRequest(req *PaymentDestinationRequest, senderKey *ec.PrivateKey) error {
+	if req.Dt == "" {
+		req.Dt = time.Now().UTC().Format(time.RFC3339)
+	}
+	message := req.SenderHandle + strconv.FormatUint(req.Amount, 10) + req.Dt + req.Purpose
+	signature, err := compat.SignMessageString(senderKey, []byte(message))
+	if err != nil {
+		return fmt.Errorf("failed to sign payment destination request: %w", err)
+	}
+	req.Signature = signature
+	return nil
+}
+
+// GetPaymentDestination requests an output script to pay the paymail handle using
+// basic address resolution.
+func (c *Client) GetPaymentDestination(ctx context.Context, handle string, req *PaymentDestinationRequest) (*script.Script, error) {
+	address, err := ParseAddress(handle)
+	if err != nil {
+		return nil, err
+	}
+	url, err := c.capabilityURL(ctx, address, BRFCPaymentDestination, BRFCPaymentDestinationAlt)
+	if err != nil {
+		return nil, err
+	}
+	if req.Dt == "" {
+		req.Dt = time.Now().UTC().Format(time.RFC3339)
+	}
+	var resp PaymentDestinationResponse
+	if err := c.do(ctx, http.MethodPost, url, req, &resp); err != nil {
+		return nil, fmt.Errorf("failed to get payment destination for %s: %w", handle, err)
+	}
+	return script.NewFromHex(resp.Output)
+}
+
+// GetP2PPaymentDestination requests the outputs to pay satoshis to the paymail
+// handle using the P2P payment destination capability.
+func (c *Client) GetP2PPaymentDestination(ctx context.Context, handle string, satoshis uint64) (*P2PPaymentDestinationResponse, error) {
+	address, err := ParseAddress(handle)
+	if err != nil {
+		return nil, err
+	}
+	url, err := c.capabilityURL(ctx, address, BRFCP2PPaymentDestination)
+	if err != nil {
+		return nil, err
+	}
+	var resp P2PPaymentDestinationResponse
+	if err := c.do(ctx, http.MethodPost, url, &P2PPaymentDestinationRequest{Satoshis: satoshis}, &resp); err != nil {
+		return nil, fmt.Errorf("failed to get p2p payment destination for %s: %w", handle, err)
+	}
+	return &resp, nil
+}
+
+// TransactionOutputs converts the requested P2P outputs into transaction outputs.
+func (r *P2PPaymentDestinationResponse) TransactionOutputs() ([]*transaction.TransactionOutput, error) {
+	outputs := make([]*transaction.TransactionOutput, 0, len(r.Outputs))
+	for _, o := range r.Outputs {
+		lockingScript, err := script.NewFromHex(o.Script)
+		if err != nil {
+			return nil, fmt.Errorf("invalid output script: %w", err)
+		}
+		outputs = append(outputs, &transaction.TransactionOutput{
+			Satoshis:      o.Satoshis,
+			LockingScript: lockingScript,
+		})
+	}
+	return outputs, nil
+}
+
+// SendP2PTransaction submits a transaction paying a P2P payment destination to the
+// receiver. When tx.Beef is set the host must support BEEF transactions.
+func (c *Client) SendP2PTransaction(ctx context.Context, handle string, tx *P2PTransaction) (*P2PTransactionResponse, error) {
+	address, err := ParseAddress(handle)
+	if err != nil {
+		return nil, err
+	}
+	ids := []string{BRFCP2PTransactions}
+	if tx.Beef != "" {
+		ids = []string{BRFCBeefTransactions}
+	}
+	url, err := c.capabilityURL(ctx, address, ids...)
+	if err != nil {
+		return nil, err
+	}
+	var resp P2PTransactionResponse
+	if err := c.do(ctx, http.MethodPost, url, tx, &resp); err != nil {
+		return nil, fmt.Errorf("failed to send p2p transaction to %s: %w", handle, err)
+	}
+	return &resp, nil
+}
+
+// do performs a JSON request, decoding a successful response into result.
+func (c *Client) do(ctx context.Context, method, url string, body any, result any) error {
+	var reqBody io.Reader
+	if body != nil {
+		payload, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("error marshaling request: %w", err)
+		}
+		reqBody = bytes.NewReader(payload)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, url, reqBody)
+	if err != nil {
+		return fmt.Errorf("error creating request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.httpClient().Do(req)
+	if err != nil {
+		return fmt.Errorf("error sending request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("error reading response body: %w", err)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return &util.HTTPError{
+			StatusCode: resp.StatusCode,
+			Err:        fmt.Errorf("%w: %s", ErrUnexpectedStatusCode, strings.TrimSpace(string(respBody))),
+		}
+	}
+	if err := json.Unmarshal(respBody, result); err != nil {
+		return fmt.Errorf("error unmarshaling response: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,188 @@
+package paymail_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/bsv-blockchain/go-sdk/compat/paymail"
+	ec "github.com/bsv-blockchain/go-sdk/primitives/ec"
+	"github.com/bsv-blockchain/go-sdk/util"
+	"github.com/stretchr/testify/require"
+)
+
+const testScript = "76a914e2a623699e81b291c0327f408fea765d534baa2a88ac"
+
+func newTestServer(t *testing.T, key *ec.PrivateKey) (*httptest.Server, *paymail.Client) {
+	t.Helper()
+	mux := http.NewServeMux()
+	var server *httptest.Server
+	mux.HandleFunc("/.well-known/bsvalias", func(w http.ResponseWriter, r *http.Request) {
+		base := server.URL
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"bsvalias": "1.0",
+			"capabilities": map[string]any{
+				paymail.BRFCPki:                   base + "/id/{alias}@{domain.tld}",
+				paymail.BRFCPaymentDestination:    base + "/address/{alias}@{domain.tld}",
+				paymail.BRFCSenderValidation:      false,
+				paymail.BRFCP2PPaymentDestination: base + "/p2p-destination/{alias}@{domain.tld}",
+				paymail.BRFCP2PTransactions:       base + "/receive-tx/{alias}@{domain.tld}",
+			},
+		})
+	})
+	mux.HandleFunc("/id/alice@example.com", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(paymail.PKIResponse{
+			BsvAlias: "1.0",
+			Handle:   "alice@example.com",
+			PubKey:   key.PubKey().ToDERHex(),
+		})
+	})
+	mux.HandleFunc("/address/alice@example.com", func(w http.ResponseWriter, r *http.Request) {
+		var req paymail.PaymentDestinationRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		require.Equal(t, "bob@example.com", req.SenderHandle)
+		require.NotEmpty(t, req.Dt)
+		_ = json.NewEncoder(w).Encode(paymail.PaymentDestinationResponse{Output: testScript})
+	})
+	mux.HandleFunc("/p2p-destination/alice@example.com", func(w http.ResponseWriter, r *http.Request) {
+		var req paymail.P2PPaymentDestinationRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		_ = json.NewEncoder(w).Encode(paymail.P2PPaymentDestinationResponse{
+			Outputs:   []paymail.P2POutput{{Script: testScript, Satoshis: req.Satoshis}},
+			Reference: "ref-1",
+		})
+	})
+	mux.HandleFunc("/receive-tx/alice@example.com", func(w http.ResponseWriter, r *http.Request) {
+		var req paymail.P2PTransaction
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		if req.Reference != "ref-1" {
+			http.Error(w, "unknown reference", http.StatusBadRequest)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(paymail.P2PTransactionResponse{TxID: "abcd", Note: "thanks"})
+	})
+	server = httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	// Every host name dials the test server, so the SRV target can be a host
+	// within the paymail domain.
+	transport := server.Client().Transport.(*http.Transport).Clone()
+	transport.DialContext = func(ctx context.Context, network, _ string) (net.Conn, error) {
+		return (&net.Dialer{}).DialContext(ctx, network, server.Listener.Addr().String())
+	}
+	client := &paymail.Client{
+		HTTPClient: &http.Client{Transport: transport},
+		Scheme:     "http",
+		LookupSRV: func(ctx context.Context, service, proto, name string) (string, []*net.SRV, error) {
+			require.Equal(t, "bsvalias", service)
+			require.Equal(t, "example.com", name)
+			return "", []*net.SRV{{Target: "paymail.example.com.", Port: 8080}}, nil
+		},
+	}
+	return server, client
+}
+
+func TestParseAddress(t *testing.T) {
+	address, err := paymail.ParseAddress(" Alice@Example.COM ")
+	require.NoError(t, err)
+	require.Equal(t, "alice", address.Alias)
+	require.Equal(t, "example.com", address.Domain)
+	require.Equal(t, "alice@example.com", address.String())
+
+	for _, handle := range []string{"", "alice", "@example.com", "alice@", "a@b@c",
+		"al/ice@example.com", "alice?x@example.com", "alice@example.com/x", "alice@example.com#x",
+		"alice@-example.com", "alice@example..com"} {
+		_, err := paymail.ParseAddress(handle)
+		require.ErrorIs(t, err, paymail.ErrInvalidAddress, handle)
+	}
+}
+
+func TestResolveTemplate(t *testing.T) {
+	address := &paymail.Address{Alias: "a/b?c", Domain: "example.com#x"}
+	require.Equal(t, "https://host/id/a%2Fb%3Fc@example.com%23x",
+		paymail.ResolveTemplate("https://host/id/{alias}@{domain.tld}", address, ""))
+}
+
+type clientFunc func(req *http.Request) (*http.Response, error)
+
+func (f clientFunc) Do(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func TestClientIgnoresForeignSRVTargets(t *testing.T) {
+	for _, target := range []string{"evil.com.", "example.com.evil.com.", "127.0.0.1.", "bad_host.example.com."} {
+		var requested string
+		client := &paymail.Client{
+			HTTPClient: clientFunc(func(req *http.Request) (*http.Response, error) {
+				requested = req.URL.Host
+				return nil, errors.New("offline")
+			}),
+			LookupSRV: func(context.Context, string, string, string) (string, []*net.SRV, error) {
+				return "", []*net.SRV{{Target: target, Port: 8443}}, nil
+			},
+		}
+		_, err := client.GetCapabilities(t.Context(), "example.com")
+		require.Error(t, err)
+		require.Equal(t, "example.com", requested, target)
+	}
+}
+
+func TestClientPaymentFlow(t *testing.T) {
+	key, err := ec.NewPrivateKey()
+	require.NoError(t, err)
+	_, client := newTestServer(t, key)
+	ctx := context.Background()
+
+	capabilities, err := client.GetCapabilities(ctx, "example.com")
+	require.NoError(t, err)
+	require.True(t, capabilities.Has(paymail.BRFCP2PTransactions))
+	require.False(t, capabilities.Has(paymail.BRFCSenderValidation))
+
+	pubKey, err := client.GetPublicKey(ctx, "alice@example.com")
+	require.NoError(t, err)
+	require.True(t, pubKey.IsEqual(key.PubKey()))
+
+	lockingScript, err := client.GetPaymentDestination(ctx, "alice@example.com", &paymail.PaymentDestinationRequest{
+		SenderHandle: "bob@example.com",
+		Amount:       1000,
+	})
+	require.NoError(t, err)
+	require.Equal(t, testScript, lockingScript.String())
+
+	destination, err := client.GetP2PPaymentDestination(ctx, "alice@example.com", 5000)
+	require.NoError(t, err)
+	require.Equal(t, "ref-1", destination.Reference)
+	outputs, err := destination.TransactionOutputs()
+	require.NoError(t, err)
+	require.Len(t, outputs, 1)
+	require.Equal(t, uint64(5000), outputs[0].Satoshis)
+
+	resp, err := client.SendP2PTransaction(ctx, "alice@example.com", &paymail.P2PTransaction{
+		Hex:       "00",
+		Reference: destination.Reference,
+	})
+	require.NoError(t, err)
+	require.Equal(t, "abcd", resp.TxID)
+
+	_, err = client.SendP2PTransaction(ctx, "alice@example.com", &paymail.P2PTransaction{Hex: "00", Reference: "bad"})
+	var httpErr *util.HTTPError
+	require.True(t, errors.As(err, &httpErr))
+	require.Equal(t, http.StatusBadRequest, httpErr.StatusCode)
+	require.ErrorContains(t, err, "unknown reference")
+
+	_, err = client.SendP2PTransaction(ctx, "alice@example.com", &paymail.P2PTransaction{Beef: "00", Reference: "ref-1"})
+	require.ErrorIs(t, err, paymail.ErrCapabilityNotFound)
+}
+
+func TestSignPaymentDestinationRequest(t *testing.T) {
+	key, err := ec.NewPrivateKey()
+	require.NoError(t, err)
+	req := &paymail.PaymentDestinationRequest{SenderHandle: "bob@example.com", Amount: 1}
+	require.NoError(t, paymail.SignPaymentDestinationRequest(req, key))
+	require.NotEmpty(t, req.Dt)
+	require.NotEmpty(t, req.Signature)
+}
@@ -0,0 +1,198 @@
+// Package paymail implements a paymail (bsvalias) client: capability discovery,
+// public key (PKI) resolution, payment destination requests and P2P transaction
+// submission, so wallets can pay user@domain handles.
+//
+// See https://bsvalias.org for the protocol specifications.
+package paymail
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// BRFC identifiers of the capabilities used by this client.
+const (
+	BRFCPki                   = "pki"
+	BRFCPkiAlternate          = "0c4339ef99c2"
+	BRFCPaymentDestination    = "paymentDestination"
+	BRFCPaymentDestinationAlt = "759684b1a19a"
+	BRFCSenderValidation      = "6745385c3fc0"
+	BRFCP2PPaymentDestination = "2a40af698840"
+	BRFCP2PTransactions       = "5f1323cddf31"
+	BRFCBeefTransactions      = "5c55a7fdb7bb"
+)
+
+var (
+	ErrInvalidAddress       = errors.New("invalid paymail address")
+	ErrCapabilityNotFound   = errors.New("paymail capability not supported by host")
+	ErrUnexpectedStatusCode = errors.New("unexpected paymail response status")
+)
+
+// Address is a paymail handle split into its alias and domain.
+type Address struct {
+	Alias  string
+	Domain string
+}
+
+// ParseAddress parses a paymail handle of the form alias@domain.tld. The handle is
+// lower-cased as paymail aliases and domains are case-insensitive. A leading $ or
+// 1 handle prefix is not supported.
+//
+// Aliases may only contain letters, digits and the characters . _ - +, and
+// domains must be valid host names, so that neither can alter the capability
+// URLs they are substituted into.
+func ParseAddress(handle string) (*Address, error) {
+	handle = strings.ToLower(strings.TrimSpace(handle))
+	alias, domain, ok := strings.Cut(handle, "@")
+	if !ok || !validAlias(alias) || !strings.Contains(domain, ".") || !validHostname(domain) {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidAddress, handle)
+	}
+	return &Address{Alias: alias, Domain: domain}, nil
+}
+
+func validAlias(alias string) bool {
+	if alias == "" {
+		return false
+	}
+	for _, c := range alias {
+		switch {
+		case c >= 'a' && c <= 'z', c >= '0' && c <= '9', c == '.', c == '_', c == '-', c == '+':
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// validHostname reports whether host is a lower-case DNS host name.
+func validHostname(host string) bool {
+	if host == "" || len(host) > 253 {
+		return false
+	}
+	for _, label := range strings.Split(host, ".") {
+		if label == "" || len(label) > 63 || label[0] == '-' || label[len(label)-1] == '-' {
+			return false
+		}
+		for _, c := range label {
+			if (c < 'a' || c > 'z') && (c < '0' || c > '9') && c != '-' {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// String returns the paymail handle.
+func (a *Address) String() string {
+	return a.Alias + "@" + a.Domain
+}
+
+// Capabilities is the capability discovery document served at /.well-known/bsvalias.
+type Capabilities struct {
+	BsvAlias     string         `json:"bsvalias"`
+	Capabilities map[string]any `json:"capabilities"`
+}
+
+// URL returns the endpoint template for the first of the given capability ids
+// that the host advertises as a URL.
+func (c *Capabilities) URL(ids ...string) (string, bool) {
+	for _, id := range ids {
+		if v, ok := c.Capabilities[id].(string); ok && v != "" {
+			return v, true
+		}
+	}
+	return "", false
+}
+
+// Has reports whether the host advertises any of the given capability ids, either
+// as an endpoint or as an enabled flag.
+func (c *Capabilities) Has(ids ...string) bool {
+	for _, id := range ids {
+		switch v := c.Capabilities[id].(type) {
+		case string:
+			if v != "" {
+				return true
+			}
+		case bool:
+			if v {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// ResolveTemplate substitutes the address (and optional public key) into a
+// capability URL template. The values are escaped as URL path segments.
+func ResolveTemplate(template string, address *Address, pubKey string) string {
+	return strings.NewReplacer(
+		"{alias}", url.PathEscape(address.Alias),
+		"{domain.tld}", url.PathEscape(address.Domain),
+		"{pubkey}", url.PathEscape(pubKey),
+	).Replace(template)
+}
+
+// PKIResponse is the response of the pki capability.
+type PKIResponse struct {
+	BsvAlias string `json:"bsvalias"`
+	Handle   string `json:"handle"`
+	PubKey   string `json:"pubkey"`
+}
+
+// PaymentDestinationRequest is the body of a basic address resolution request.
+type PaymentDestinationRequest struct {
+	SenderName   string `json:"senderName,omitempty"`
+	SenderHandle string `json:"senderHandle"`
+	Dt           string `json:"dt"`
+	Amount       uint64 `json:"amount,omitempty"`
+	Purpose      string `json:"purpose,omitempty"`
+	Signature    string `json:"signature,omitempty"`
+}
+
+// PaymentDestinationResponse is the response of a basic address resolution request.
+type PaymentDestinationResponse struct {
+	Output string `json:"output"`
+}
+
+// P2PPaymentDestinationRequest is the body of a P2P payment destination request.
+type P2PPaymentDestinationRequest struct {
+	Satoshis uint64 `json:"satoshis"`
+}
+
+// P2POutput is a single output the receiver asks to be paid.
+type P2POutput struct {
+	Script   string `json:"script"`
+	Satoshis uint64 `json:"satoshis"`
+}
+
+// P2PPaymentDestinationResponse lists the outputs to pay and the reference to
+// quote when submitting the transaction.
+type P2PPaymentDestinationResponse struct {
+	Outputs   []P2POutput `json:"outputs"`
+	Reference string      `json:"reference"`
+}
+
+// P2PMetadata is optional sender information attached to a P2P transaction.
+type P2PMetadata struct {
+	Sender    string `json:"sender,omitempty"`
+	PubKey    string `json:"pubkey,omitempty"`
+	Signature string `json:"signature,omitempty"`
+	Note      string `json:"note,omitempty"`
+}
+
+// P2PTransaction is the body of a P2P transaction submission. Exactly one of
+// Hex or Beef should be set.
+type P2PTransaction struct {
+	Hex       string       `json:"hex,omitempty"`
+	Beef      string       `json:"beef,omitempty"`
+	Metadata  *P2PMetadata `json:"metadata,omitempty"`
+	Reference string       `json:"reference"`
+}
+
+// P2PTransactionResponse is the receiver's acknowledgement of a P2P transaction.
+type P2PTransactionResponse struct {
+	TxID string `json:"txid"`
+	Note string `json:"note,omitempty"`
+}
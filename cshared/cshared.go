@@ -0,0 +1,109 @@
+// Command cshared exposes the SDK's consensus-critical code through a C ABI, so
+// that applications written in other languages, such as Swift or Kotlin, can
+// sign, verify signatures, parse BEEF and verify scripts with the same code as
+// Go applications. Build it as a shared library, which also writes the C header
+// declaring the exported functions:
+//
+//	go build -buildmode=c-shared -o libbsv.so ./cshared
+//
+// Every exported function returns BSV_OK on success, BSV_INVALID when a
+// well-formed signature or script does not verify, and BSV_ERROR when its
+// arguments cannot be parsed. On any other result than BSV_OK the err argument,
+// if not NULL, receives a description of the failure. Buffers and strings
+// returned through out arguments are allocated with malloc and must be released
+// with bsv_free.
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+
+	ec "github.com/bsv-blockchain/go-sdk/primitives/ec"
+	"github.com/bsv-blockchain/go-sdk/script"
+	"github.com/bsv-blockchain/go-sdk/script/interpreter"
+	"github.com/bsv-blockchain/go-sdk/script/interpreter/errs"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+)
+
+var (
+	errInvalidSignature = errors.New("signature does not verify")
+	errHashLength       = errors.New("hash must be 32 bytes")
+	errPrivateKey       = errors.New("private key must be a 32 byte scalar in the range of the curve order")
+	errMissingSubjectTx = errors.New("BEEF does not contain its subject transaction")
+)
+
+// The library is only useful when built with -buildmode=c-shared, which ignores
+// main.
+func main() {}
+
+func sign(privateKey, hash []byte) ([]byte, error) {
+	if len(hash) != 32 {
+		return nil, errHashLength
+	}
+	if d := new(big.Int).SetBytes(privateKey); len(privateKey) != ec.PrivateKeyBytesLen || d.Sign() == 0 || d.Cmp(ec.S256().N) >= 0 {
+		return nil, errPrivateKey
+	}
+	key, _ := ec.PrivateKeyFromBytes(privateKey)
+	sig, err := key.Sign(hash)
+	if err != nil {
+		return nil, err
+	}
+	return sig.Serialize(), nil
+}
+
+func verify(publicKey, hash, signature []byte) error {
+	if len(hash) != 32 {
+		return errHashLength
+	}
+	key, err := ec.ParsePubKey(publicKey)
+	if err != nil {
+		return fmt.Errorf("invalid public key: %w", err)
+	}
+	sig, err := ec.FromDER(signature)
+	if err != nil {
+		return fmt.Errorf("invalid signature encoding: %w", err)
+	}
+	if !sig.Verify(hash, key) {
+		return errInvalidSignature
+	}
+	return nil
+}
+
+// parseBEEF returns the raw bytes and txid of the transaction BEEF is about.
+func parseBEEF(beef []byte) ([]byte, string, error) {
+	tx, err := transaction.NewTransactionFromBEEF(beef)
+	if err != nil {
+		return nil, "", err
+	}
+	if tx == nil {
+		return nil, "", errMissingSubjectTx
+	}
+	return tx.Bytes(), tx.TxID().String(), nil
+}
+
+// verifyScript verifies the unlocking script of input inputIndex of the raw
+// transaction against the output it spends, given by lockingScript and
+// satoshis.
+func verifyScript(rawTx []byte, inputIndex uint32, lockingScript []byte, satoshis uint64) error {
+	tx, err := transaction.NewTransactionFromBytes(rawTx)
+	if err != nil {
+		return fmt.Errorf("invalid transaction: %w", err)
+	}
+	if int(inputIndex) >= len(tx.Inputs) {
+		return transaction.ErrInputNoExist
+	}
+	tx.Inputs[inputIndex].SetSourceTxOutput(&transaction.TransactionOutput{
+		Satoshis:      satoshis,
+		LockingScript: script.NewFromBytes(lockingScript),
+	})
+	return interpreter.VerifyInput(context.Background(), tx, inputIndex)
+}
+
+// isInvalid reports whether err is a verification failure of well-formed
+// arguments rather than a failure to parse them.
+func isInvalid(err error) bool {
+	var scriptErr errs.Error
+	return errors.Is(err, errInvalidSignature) || errors.As(err, &scriptErr)
+}
@@ -0,0 +1,94 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+
+	ec "github.com/bsv-blockchain/go-sdk/primitives/ec"
+	crypto "github.com/bsv-blockchain/go-sdk/primitives/hash"
+	"github.com/bsv-blockchain/go-sdk/script"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+	"github.com/bsv-blockchain/go-sdk/transaction/merkle"
+	"github.com/bsv-blockchain/go-sdk/transaction/template/p2pkh"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSignVerify(t *testing.T) {
+	key, err := ec.NewPrivateKey()
+	require.NoError(t, err)
+	hash := crypto.Sha256d([]byte("message"))
+
+	sig, err := sign(key.Serialize(), hash)
+	require.NoError(t, err)
+	require.NoError(t, verify(key.PubKey().Compressed(), hash, sig))
+	require.NoError(t, verify(key.PubKey().Uncompressed(), hash, sig))
+
+	other := crypto.Sha256d([]byte("other message"))
+	err = verify(key.PubKey().Compressed(), other, sig)
+	require.ErrorIs(t, err, errInvalidSignature)
+	require.True(t, isInvalid(err))
+
+	err = verify(key.PubKey().Compressed(), hash, sig[1:])
+	require.Error(t, err)
+	require.False(t, isInvalid(err))
+
+	_, err = sign(key.Serialize(), hash[1:])
+	require.ErrorIs(t, err, errHashLength)
+	_, err = sign(make([]byte, 32), hash)
+	require.ErrorIs(t, err, errPrivateKey)
+	_, err = sign(ec.S256().N.Bytes(), hash)
+	require.ErrorIs(t, err, errPrivateKey)
+}
+
+func TestBEEFAndScript(t *testing.T) {
+	key, err := ec.NewPrivateKey()
+	require.NoError(t, err)
+	address, err := script.NewAddressFromPublicKey(key.PubKey(), true)
+	require.NoError(t, err)
+	lockingScript, err := p2pkh.Lock(address)
+	require.NoError(t, err)
+	unlocker, err := p2pkh.Unlock(key, nil)
+	require.NoError(t, err)
+
+	source := transaction.NewTransaction()
+	source.AddOutput(&transaction.TransactionOutput{Satoshis: 1000, LockingScript: lockingScript})
+	builder := merkle.NewBuilder(1)
+	builder.Add(*source.TxID())
+	source.MerklePath, err = builder.MerklePath(1, 0)
+	require.NoError(t, err)
+
+	tx := transaction.NewTransaction()
+	tx.AddInputFromTx(source, 0, unlocker)
+	tx.AddOutput(&transaction.TransactionOutput{Satoshis: 900, LockingScript: lockingScript})
+	require.NoError(t, tx.Sign())
+	beef, err := tx.AtomicBEEF(false)
+	require.NoError(t, err)
+
+	raw, txid, err := parseBEEF(beef)
+	require.NoError(t, err)
+	require.Equal(t, tx.Bytes(), raw)
+	require.Equal(t, tx.TxID().String(), txid)
+
+	_, _, err = parseBEEF(beef[:len(beef)/2])
+	require.Error(t, err)
+
+	// An Atomic BEEF naming a transaction it does not contain
+	missing := bytes.Clone(beef)
+	copy(missing[4:36], make([]byte, 32))
+	_, _, err = parseBEEF(missing)
+	require.ErrorIs(t, err, errMissingSubjectTx)
+
+	require.NoError(t, verifyScript(raw, 0, lockingScript.Bytes(), 1000))
+
+	err = verifyScript(raw, 0, lockingScript.Bytes(), 999)
+	require.Error(t, err)
+	require.True(t, isInvalid(err))
+
+	err = verifyScript(raw, 1, lockingScript.Bytes(), 1000)
+	require.ErrorIs(t, err, transaction.ErrInputNoExist)
+	require.False(t, isInvalid(err))
+
+	err = verifyScript(raw[1:], 0, lockingScript.Bytes(), 1000)
+	require.Error(t, err)
+	require.False(t, isInvalid(err))
+}
@@ -0,0 +1,110 @@
+package main
+
+/*
+#include <stdint.h>
+#include <stdlib.h>
+
+enum {
+	BSV_OK = 0,
+	BSV_INVALID = 1,
+	BSV_ERROR = 2,
+};
+*/
+import "C"
+
+import (
+	"fmt"
+	"unsafe"
+)
+
+func goBytes(p *C.uint8_t, n C.size_t) []byte {
+	if p == nil || n == 0 {
+		return nil
+	}
+	return C.GoBytes(unsafe.Pointer(p), C.int(n))
+}
+
+func setBytes(out **C.uint8_t, outLen *C.size_t, b []byte) {
+	*out = (*C.uint8_t)(C.CBytes(b))
+	*outLen = C.size_t(len(b))
+}
+
+// result converts err to a status code, describing it in errOut when given.
+func result(err error, errOut **C.char) C.int {
+	if err == nil {
+		return C.BSV_OK
+	}
+	if errOut != nil {
+		*errOut = C.CString(err.Error())
+	}
+	if isInvalid(err) {
+		return C.BSV_INVALID
+	}
+	return C.BSV_ERROR
+}
+
+// recoverError reports a panic, which would otherwise abort the host
+// application, as BSV_ERROR.
+func recoverError(status *C.int, errOut **C.char) {
+	if r := recover(); r != nil {
+		*status = result(fmt.Errorf("panic: %v", r), errOut)
+	}
+}
+
+// bsv_free releases a buffer or string returned by this library.
+//
+//export bsv_free
+func bsv_free(p unsafe.Pointer) {
+	C.free(p)
+}
+
+// bsv_sign signs the 32 byte hash with the 32 byte private key, writing the
+// DER encoded low-S signature to sig.
+//
+//export bsv_sign
+func bsv_sign(privateKey *C.uint8_t, privateKeyLen C.size_t, hash *C.uint8_t, hashLen C.size_t,
+	sig **C.uint8_t, sigLen *C.size_t, err **C.char) (status C.int) {
+	defer recoverError(&status, err)
+	signature, e := sign(goBytes(privateKey, privateKeyLen), goBytes(hash, hashLen))
+	if e == nil {
+		setBytes(sig, sigLen, signature)
+	}
+	return result(e, err)
+}
+
+// bsv_verify verifies the DER encoded signature of the 32 byte hash against the
+// compressed or uncompressed public key.
+//
+//export bsv_verify
+func bsv_verify(publicKey *C.uint8_t, publicKeyLen C.size_t, hash *C.uint8_t, hashLen C.size_t,
+	sig *C.uint8_t, sigLen C.size_t, err **C.char) (status C.int) {
+	defer recoverError(&status, err)
+	return result(verify(goBytes(publicKey, publicKeyLen), goBytes(hash, hashLen), goBytes(sig, sigLen)), err)
+}
+
+// bsv_beef_parse parses BEEF, including Atomic BEEF, writing the raw bytes of
+// the transaction it is about to tx and its hex txid to txid.
+//
+//export bsv_beef_parse
+func bsv_beef_parse(beef *C.uint8_t, beefLen C.size_t, tx **C.uint8_t, txLen *C.size_t,
+	txid **C.char, err **C.char) (status C.int) {
+	defer recoverError(&status, err)
+	raw, id, e := parseBEEF(goBytes(beef, beefLen))
+	if e == nil {
+		setBytes(tx, txLen, raw)
+		*txid = C.CString(id)
+	}
+	return result(e, err)
+}
+
+// bsv_verify_script verifies the unlocking script of input inputIndex of the raw
+// transaction against the output it spends, with the standard consensus rules
+// after the Genesis upgrade.
+//
+//export bsv_verify_script
+func bsv_verify_script(tx *C.uint8_t, txLen C.size_t, inputIndex C.uint32_t,
+	lockingScript *C.uint8_t, lockingScriptLen C.size_t, satoshis C.uint64_t, err **C.char) (status C.int) {
+	defer recoverError(&status, err)
+	e := verifyScript(goBytes(tx, txLen), uint32(inputIndex), goBytes(lockingScript, lockingScriptLen), uint64(satoshis))
+	return result(e, err)
+}
@@ -0,0 +1,223 @@
+// Package policy prices transactions with the fees miners currently charge,
+// discovered from the policy endpoint of ARC or the fee quote endpoint of mAPI.
+//
+// A Client caches the quote it fetches until it expires and implements
+// transaction.FeeModel, so wallets can use it wherever a fixed rate such as
+// feemodel.SatoshisPerKilobyte is accepted:
+//
+//	fees := policy.New([]policy.Source{&policy.ARC{ApiUrl: "https://arc.taal.com"}})
+//	err := tx.Fee(fees, transaction.ChangeDistributionEqual)
+package policy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/bsv-blockchain/go-sdk/transaction"
+)
+
+// DefaultTTL is how long a Client caches a quote by default.
+const DefaultTTL = 10 * time.Minute
+
+// DefaultTimeout bounds the fetching of a quote by ComputeFee by default.
+const DefaultTimeout = 10 * time.Second
+
+// DefaultRetryBackoff is how long a Client waits by default before fetching
+// again after every source failed. The wait doubles with every further failure,
+// up to the TTL.
+const DefaultRetryBackoff = 10 * time.Second
+
+var (
+	// ErrNoQuote is returned when no source provides a quote and no fallback
+	// is set.
+	ErrNoQuote = errors.New("no fee quote available")
+	// ErrQuoteTooHigh is returned for quotes above the maximum rate of a
+	// Client.
+	ErrQuoteTooHigh = errors.New("fee quote above maximum rate")
+)
+
+// Option configures a Client.
+type Option func(*Client)
+
+// WithTTL sets how long quotes are cached, DefaultTTL by default. Quotes
+// expiring earlier are refreshed when they expire.
+func WithTTL(ttl time.Duration) Option {
+	return func(c *Client) {
+		c.ttl = ttl
+	}
+}
+
+// WithTimeout bounds the fetching of a quote by ComputeFee, which has no
+// context, DefaultTimeout by default.
+func WithTimeout(timeout time.Duration) Option {
+	return func(c *Client) {
+		c.timeout = timeout
+	}
+}
+
+// WithRetryBackoff sets how long to wait before fetching again after every
+// source failed, DefaultRetryBackoff by default. Meanwhile, the expired quote
+// or the fallback is used without asking the sources.
+func WithRetryBackoff(backoff time.Duration) Option {
+	return func(c *Client) {
+		c.backoff = backoff
+	}
+}
+
+// WithMaxRate rejects quotes charging more than satoshisPerKB satoshis per
+// kilobyte, so that a misbehaving or compromised source cannot make wallets
+// overpay. Rejected quotes count as failures of their source.
+func WithMaxRate(satoshisPerKB uint64) Option {
+	return func(c *Client) {
+		c.maxRate = satoshisPerKB
+	}
+}
+
+// WithFallback sets the quote used when the sources fail and no quote was
+// fetched before, so that transactions can still be priced.
+func WithFallback(quote Quote) Option {
+	return func(c *Client) {
+		c.fallback = &quote
+	}
+}
+
+// Client fetches fee quotes from its sources and caches them.
+type Client struct {
+	sources  []Source
+	ttl      time.Duration
+	timeout  time.Duration
+	backoff  time.Duration
+	maxRate  uint64
+	fallback *Quote
+	now      func() time.Time
+
+	mu       sync.Mutex
+	quote    *Quote
+	expires  time.Time
+	failures int
+	err      error
+}
+
+// ensure that Client is implementing transaction.FeeModel
+var _ transaction.FeeModel = (*Client)(nil)
+
+// New creates a Client fetching quotes from sources, tried in order until one
+// succeeds.
+func New(sources []Source, opts ...Option) *Client {
+	c := &Client{
+		sources: sources,
+		ttl:     DefaultTTL,
+		timeout: DefaultTimeout,
+		backoff: DefaultRetryBackoff,
+		now:     time.Now,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// Quote returns the cached quote, fetching a new one when it has expired. When
+// every source fails, the expired quote is returned if there is one, or else
+// the fallback, so that a temporary outage does not stop transactions from
+// being priced; ErrNoQuote is returned when there is neither. The sources are
+// not asked again until the retry backoff has passed.
+func (c *Client) Quote(ctx context.Context) (*Quote, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	now := c.now()
+	if now.Before(c.expires) {
+		return c.cached()
+	}
+
+	var errs []error
+	for _, source := range c.sources {
+		quote, err := source.FetchQuote(ctx)
+		if err == nil {
+			err = c.check(quote)
+		}
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		c.quote = quote
+		c.failures = 0
+		c.err = nil
+		c.expires = now.Add(c.ttl)
+		if !quote.ExpiresAt.IsZero() && quote.ExpiresAt.Before(c.expires) {
+			c.expires = quote.ExpiresAt
+		}
+		return quote, nil
+	}
+
+	c.err = errors.Join(errs...)
+	c.expires = now.Add(c.retryBackoff())
+	c.failures++
+	return c.cached()
+}
+
+// cached returns the quote to use until the next fetch.
+func (c *Client) cached() (*Quote, error) {
+	if c.quote != nil {
+		return c.quote, nil
+	}
+	if c.fallback != nil {
+		return c.fallback, nil
+	}
+	return nil, fmt.Errorf("%w: %w", ErrNoQuote, c.err)
+}
+
+// retryBackoff returns how long to wait before fetching again after the
+// sources failed, doubling with every consecutive failure up to the TTL.
+func (c *Client) retryBackoff() time.Duration {
+	backoff := c.backoff
+	for range c.failures {
+		if backoff >= c.ttl/2 {
+			return c.ttl
+		}
+		backoff *= 2
+	}
+	return min(backoff, c.ttl)
+}
+
+// check rejects quotes above the maximum rate.
+func (c *Client) check(quote *Quote) error {
+	if c.maxRate == 0 {
+		return nil
+	}
+	rate, err := quote.Fee(1000)
+	if err != nil {
+		return err
+	}
+	if rate > c.maxRate {
+		return fmt.Errorf("%w: %s charges %d satoshis per kilobyte, more than %d", ErrQuoteTooHigh, quote.Source, rate, c.maxRate)
+	}
+	return nil
+}
+
+// Invalidate drops the cached quote, for example after a miner rejected a
+// transaction for its fee, so that the next quote is fetched right away.
+func (c *Client) Invalidate() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.quote = nil
+	c.expires = time.Time{}
+}
+
+// ComputeFee returns the fee of tx at the current quote.
+func (c *Client) ComputeFee(tx *transaction.Transaction) (uint64, error) {
+	size, err := tx.EstimateSize()
+	if err != nil {
+		return 0, err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+	defer cancel()
+	quote, err := c.Quote(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return quote.Fee(uint64(size))
+}
@@ -0,0 +1,195 @@
+package policy
+
+import (
+	"context"
+	"errors"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/bsv-blockchain/go-sdk/chainhash"
+	"github.com/bsv-blockchain/go-sdk/script"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+	"github.com/stretchr/testify/require"
+)
+
+func serve(t *testing.T, path, body string, requests *int) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*requests++
+		if r.URL.Path != path {
+			http.NotFound(w, r)
+			return
+		}
+		require.Equal(t, "Bearer secret", r.Header.Get("Authorization"))
+		_, _ = w.Write([]byte(body))
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestARC(t *testing.T) {
+	var requests int
+	srv := serve(t, "/v1/policy", `{"policy":{"maxtxsizepolicy":100000000,"miningFee":{"satoshis":1,"bytes":1000}},"timestamp":"2026-10-18T00:00:00Z"}`, &requests)
+
+	quote, err := (&ARC{ApiUrl: srv.URL, ApiKey: "secret"}).FetchQuote(t.Context())
+	require.NoError(t, err)
+	require.Equal(t, Rate{Satoshis: 1, Bytes: 1000}, quote.MiningFee)
+	require.True(t, quote.ExpiresAt.IsZero())
+	for size, fee := range map[uint64]uint64{1: 1, 1000: 1, 1001: 2} {
+		actual, err := quote.Fee(size)
+		require.NoError(t, err)
+		require.Equal(t, fee, actual, size)
+	}
+
+	_, err = (&ARC{ApiUrl: srv.URL + "/missing"}).FetchQuote(t.Context())
+	require.Error(t, err)
+}
+
+func TestMAPI(t *testing.T) {
+	var requests int
+	srv := serve(t, "/mapi/feeQuote", `{"payload":"{\"expiryTime\":\"2026-10-18T00:10:00Z\",\"fees\":[{\"feeType\":\"data\",\"miningFee\":{\"satoshis\":2,\"bytes\":1000}},{\"feeType\":\"standard\",\"miningFee\":{\"satoshis\":50,\"bytes\":1000}}]}","signature":null,"publicKey":null,"encoding":"UTF-8","mimetype":"application/json"}`, &requests)
+
+	quote, err := (&MAPI{ApiUrl: srv.URL, Token: "secret"}).FetchQuote(t.Context())
+	require.NoError(t, err)
+	require.Equal(t, Rate{Satoshis: 50, Bytes: 1000}, quote.MiningFee)
+	require.Equal(t, time.Date(2026, 10, 18, 0, 10, 0, 0, time.UTC), quote.ExpiresAt)
+
+	bad := serve(t, "/mapi/feeQuote", `{"payload":"{\"fees\":[{\"feeType\":\"standard\",\"miningFee\":{\"satoshis\":50,\"bytes\":0}}]}"}`, &requests)
+	_, err = (&MAPI{ApiUrl: bad.URL, Token: "secret"}).FetchQuote(t.Context())
+	require.ErrorIs(t, err, ErrInvalidQuote)
+}
+
+func TestQuoteFee(t *testing.T) {
+	quote := &Quote{MiningFee: Rate{Satoshis: math.MaxUint64, Bytes: 1000}}
+	fee, err := quote.Fee(1000)
+	require.NoError(t, err)
+	require.Equal(t, uint64(math.MaxUint64), fee)
+	_, err = quote.Fee(1001)
+	require.ErrorIs(t, err, ErrFeeOverflow)
+
+	quote = &Quote{MiningFee: Rate{Satoshis: 1 << 40, Bytes: 1 << 20}}
+	fee, err = quote.Fee(1<<30 + 1)
+	require.NoError(t, err)
+	require.Equal(t, uint64(1<<50+1<<20), fee)
+
+	_, err = (&Quote{}).Fee(1)
+	require.ErrorIs(t, err, ErrInvalidQuote)
+}
+
+type sourceFunc func(ctx context.Context) (*Quote, error)
+
+func (f sourceFunc) FetchQuote(ctx context.Context) (*Quote, error) {
+	return f(ctx)
+}
+
+func TestClient(t *testing.T) {
+	now := time.Date(2026, 10, 18, 0, 0, 0, 0, time.UTC)
+	fetches := 0
+	var failure error
+	source := sourceFunc(func(context.Context) (*Quote, error) {
+		fetches++
+		if failure != nil {
+			return nil, failure
+		}
+		return &Quote{Source: "test", MiningFee: Rate{Satoshis: uint64(fetches), Bytes: 1000}, ExpiresAt: now.Add(time.Hour)}, nil
+	})
+	down := sourceFunc(func(context.Context) (*Quote, error) {
+		return nil, errors.New("down")
+	})
+	c := New([]Source{down, source}, WithTTL(time.Minute))
+	c.now = func() time.Time { return now }
+
+	quote, err := c.Quote(t.Context())
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), quote.MiningFee.Satoshis)
+
+	// Cached until the TTL, which is earlier than the quote expiry.
+	now = now.Add(59 * time.Second)
+	quote, err = c.Quote(t.Context())
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), quote.MiningFee.Satoshis)
+	require.Equal(t, 1, fetches)
+	now = now.Add(time.Second)
+	quote, err = c.Quote(t.Context())
+	require.NoError(t, err)
+	require.Equal(t, uint64(2), quote.MiningFee.Satoshis)
+
+	// Expired quotes are used while the sources are down, which are only
+	// asked again after a backoff doubling with every failure.
+	failure = errors.New("unavailable")
+	now = now.Add(time.Hour)
+	quote, err = c.Quote(t.Context())
+	require.NoError(t, err)
+	require.Equal(t, uint64(2), quote.MiningFee.Satoshis)
+	require.Equal(t, 3, fetches)
+	for _, wait := range []time.Duration{DefaultRetryBackoff, 2 * DefaultRetryBackoff, 4 * DefaultRetryBackoff, time.Minute, time.Minute} {
+		now = now.Add(wait - time.Nanosecond)
+		_, err = c.Quote(t.Context())
+		require.NoError(t, err)
+		fetches0 := fetches
+		now = now.Add(time.Nanosecond)
+		_, err = c.Quote(t.Context())
+		require.NoError(t, err)
+		require.Equal(t, fetches0+1, fetches, wait)
+	}
+
+	c.Invalidate()
+	_, err = c.Quote(t.Context())
+	require.ErrorIs(t, err, ErrNoQuote)
+	require.ErrorContains(t, err, "unavailable")
+	fetches0 := fetches
+	_, err = c.Quote(t.Context())
+	require.ErrorIs(t, err, ErrNoQuote)
+	require.Equal(t, fetches0, fetches)
+
+	failure = nil
+	now = now.Add(time.Minute)
+	quote, err = c.Quote(t.Context())
+	require.NoError(t, err)
+	require.Equal(t, uint64(fetches), quote.MiningFee.Satoshis)
+
+	fallback := New([]Source{down}, WithFallback(Quote{MiningFee: Rate{Satoshis: 1, Bytes: 1000}}))
+	quote, err = fallback.Quote(t.Context())
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), quote.MiningFee.Satoshis)
+}
+
+func TestClientMaxRate(t *testing.T) {
+	expensive := sourceFunc(func(context.Context) (*Quote, error) {
+		return &Quote{Source: "expensive", MiningFee: Rate{Satoshis: 1001, Bytes: 1000}}, nil
+	})
+	cheap := sourceFunc(func(context.Context) (*Quote, error) {
+		return &Quote{Source: "cheap", MiningFee: Rate{Satoshis: 1, Bytes: 1}}, nil
+	})
+
+	quote, err := New([]Source{expensive, cheap}, WithMaxRate(1000)).Quote(t.Context())
+	require.NoError(t, err)
+	require.Equal(t, "cheap", quote.Source)
+
+	_, err = New([]Source{expensive}, WithMaxRate(1000)).Quote(t.Context())
+	require.ErrorIs(t, err, ErrNoQuote)
+	require.ErrorIs(t, err, ErrQuoteTooHigh)
+}
+
+func TestClientComputeFee(t *testing.T) {
+	c := New([]Source{sourceFunc(func(context.Context) (*Quote, error) {
+		return &Quote{MiningFee: Rate{Satoshis: 100, Bytes: 1000}}, nil
+	})})
+
+	tx := transaction.NewTransaction()
+	tx.AddInput(&transaction.TransactionInput{
+		SourceTXID:      &chainhash.Hash{1},
+		UnlockingScript: &script.Script{script.OpTRUE},
+		SequenceNumber:  0xffffffff,
+	})
+	tx.AddOutput(&transaction.TransactionOutput{Satoshis: 1000, LockingScript: &script.Script{script.OpTRUE}})
+	size, err := tx.EstimateSize()
+	require.NoError(t, err)
+
+	fee, err := c.ComputeFee(tx)
+	require.NoError(t, err)
+	require.Equal(t, uint64(size*100+999)/1000, fee)
+}
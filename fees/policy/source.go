@@ -0,0 +1,172 @@
+package policy
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/bits"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/bsv-blockchain/go-sdk/util"
+)
+
+var (
+	// ErrInvalidQuote is returned for fee quotes which cannot be read or
+	// charge for zero bytes.
+	ErrInvalidQuote = errors.New("invalid fee quote")
+	// ErrFeeOverflow is returned when the fee of a transaction at a quote does
+	// not fit in a uint64.
+	ErrFeeOverflow = errors.New("fee overflows uint64")
+)
+
+// Source fetches the current fee quote of a miner.
+type Source interface {
+	FetchQuote(ctx context.Context) (*Quote, error)
+}
+
+// Rate is a mining fee of Satoshis for every Bytes of a transaction.
+type Rate struct {
+	Satoshis uint64 `json:"satoshis"`
+	Bytes    uint64 `json:"bytes"`
+}
+
+// Quote is the fee policy of a miner.
+type Quote struct {
+	// Source identifies the endpoint the quote was fetched from.
+	Source string
+	// MiningFee is the rate transactions must pay to be mined.
+	MiningFee Rate
+	// ExpiresAt is when the miner stops honouring the quote, zero when the
+	// miner did not say.
+	ExpiresAt time.Time
+}
+
+// Fee returns the fee of a transaction of size bytes, rounded up to the next
+// satoshi, or ErrFeeOverflow when it does not fit in a uint64.
+func (q *Quote) Fee(size uint64) (uint64, error) {
+	if err := q.validate(); err != nil {
+		return 0, err
+	}
+	hi, lo := bits.Mul64(size, q.MiningFee.Satoshis)
+	lo, carry := bits.Add64(lo, q.MiningFee.Bytes-1, 0)
+	hi += carry
+	if hi >= q.MiningFee.Bytes {
+		return 0, fmt.Errorf("%w: %d bytes at %d satoshis per %d bytes", ErrFeeOverflow, size, q.MiningFee.Satoshis, q.MiningFee.Bytes)
+	}
+	fee, _ := bits.Div64(hi, lo, q.MiningFee.Bytes)
+	return fee, nil
+}
+
+func (q *Quote) validate() error {
+	if q.MiningFee.Bytes == 0 {
+		return fmt.Errorf("%w: mining fee is for 0 bytes", ErrInvalidQuote)
+	}
+	return nil
+}
+
+// ARC fetches the fee quote from the policy endpoint of an ARC instance.
+type ARC struct {
+	ApiUrl string
+	ApiKey string
+	// Client sends the requests, http.DefaultClient when nil.
+	Client util.HTTPClient
+}
+
+type arcPolicyResponse struct {
+	Policy struct {
+		MiningFee Rate `json:"miningFee"`
+	} `json:"policy"`
+}
+
+// FetchQuote fetches the policy of the ARC instance.
+func (a *ARC) FetchQuote(ctx context.Context) (*Quote, error) {
+	url := strings.TrimSuffix(a.ApiUrl, "/") + "/v1/policy"
+	var response arcPolicyResponse
+	if err := getJSON(ctx, a.Client, url, a.ApiKey, &response); err != nil {
+		return nil, err
+	}
+	quote := &Quote{Source: url, MiningFee: response.Policy.MiningFee}
+	if err := quote.validate(); err != nil {
+		return nil, err
+	}
+	return quote, nil
+}
+
+// MAPI fetches the fee quote from a Merchant API endpoint. The signature of the
+// JSON envelope is not checked.
+type MAPI struct {
+	ApiUrl string
+	Token  string
+	// Client sends the requests, http.DefaultClient when nil.
+	Client util.HTTPClient
+}
+
+type mapiEnvelope struct {
+	Payload string `json:"payload"`
+}
+
+type mapiFeeQuote struct {
+	ExpiryTime time.Time `json:"expiryTime"`
+	Fees       []struct {
+		FeeType   string `json:"feeType"`
+		MiningFee Rate   `json:"miningFee"`
+	} `json:"fees"`
+}
+
+// FetchQuote fetches the fee quote of the standard fee type.
+func (m *MAPI) FetchQuote(ctx context.Context) (*Quote, error) {
+	url := strings.TrimSuffix(m.ApiUrl, "/") + "/mapi/feeQuote"
+	var envelope mapiEnvelope
+	if err := getJSON(ctx, m.Client, url, m.Token, &envelope); err != nil {
+		return nil, err
+	}
+	var payload mapiFeeQuote
+	if err := json.Unmarshal([]byte(envelope.Payload), &payload); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidQuote, err)
+	}
+	for _, fee := range payload.Fees {
+		if fee.FeeType != "standard" {
+			continue
+		}
+		quote := &Quote{Source: url, MiningFee: fee.MiningFee, ExpiresAt: payload.ExpiryTime}
+		if err := quote.validate(); err != nil {
+			return nil, err
+		}
+		return quote, nil
+	}
+	return nil, fmt.Errorf("%w: no standard fee", ErrInvalidQuote)
+}
+
+func getJSON(ctx context.Context, client util.HTTPClient, url, token string, v any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return fmt.Errorf("error creating request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("error fetching fee quote: %w", err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("error reading fee quote: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return &util.HTTPError{StatusCode: resp.StatusCode, Err: fmt.Errorf("failed to fetch fee quote: %s", body)}
+	}
+	if err = json.Unmarshal(body, v); err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidQuote, err)
+	}
+	return nil
+}
@@ -0,0 +1,196 @@
+package identity
+
+import (
+	"encoding/hex"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/bsv-blockchain/go-sdk/transaction"
+	"github.com/bsv-blockchain/go-sdk/wallet"
+)
+
+// DefaultCacheTTL is how long resolved certificates are cached when no TTL is given.
+const DefaultCacheTTL = 10 * time.Minute
+
+// CacheEntry holds the certificates returned for a single discovery query.
+type CacheEntry struct {
+	Certificates []wallet.IdentityCertificate
+	Expires      time.Time
+}
+
+// CacheStore is the storage backend of a CertificateCache. Implementations must be
+// safe for concurrent use.
+type CacheStore interface {
+	Get(key string) (CacheEntry, bool)
+	Set(key string, entry CacheEntry)
+	Delete(key string)
+	// Range calls fn for each entry until fn returns false.
+	Range(fn func(key string, entry CacheEntry) bool)
+}
+
+// MemoryCacheStore is an in-memory CacheStore.
+type MemoryCacheStore struct {
+	mu      sync.RWMutex
+	entries map[string]CacheEntry
+}
+
+// NewMemoryCacheStore creates an empty MemoryCacheStore.
+func NewMemoryCacheStore() *MemoryCacheStore {
+	return &MemoryCacheStore{entries: make(map[string]CacheEntry)}
+}
+
+// Get returns the entry stored under key.
+func (s *MemoryCacheStore) Get(key string) (CacheEntry, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	entry, ok := s.entries[key]
+	return entry, ok
+}
+
+// Set stores entry under key.
+func (s *MemoryCacheStore) Set(key string, entry CacheEntry) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.entries[key] = entry
+}
+
+// Delete removes the entry stored under key.
+func (s *MemoryCacheStore) Delete(key string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.entries, key)
+}
+
+// Range calls fn for each entry until fn returns false. fn must not modify the store.
+func (s *MemoryCacheStore) Range(fn func(key string, entry CacheEntry) bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	for key, entry := range s.entries {
+		if !fn(key, entry) {
+			return
+		}
+	}
+}
+
+// CertificateCache caches the certificates discovered by the identity client so hot
+// identities are not re-queried from the overlay on every resolution.
+type CertificateCache struct {
+	store CacheStore
+	ttl   time.Duration
+	now   func() time.Time
+}
+
+// NewCertificateCache creates a cache backed by store, using an in-memory store if
+// store is nil and DefaultCacheTTL if ttl is not positive.
+func NewCertificateCache(store CacheStore, ttl time.Duration) *CertificateCache {
+	if store == nil {
+		store = NewMemoryCacheStore()
+	}
+	if ttl <= 0 {
+		ttl = DefaultCacheTTL
+	}
+	return &CertificateCache{store: store, ttl: ttl, now: time.Now}
+}
+
+func (c *CertificateCache) get(key string) ([]wallet.IdentityCertificate, bool) {
+	entry, ok := c.store.Get(key)
+	if !ok {
+		return nil, false
+	}
+	if !c.now().Before(entry.Expires) {
+		c.store.Delete(key)
+		return nil, false
+	}
+	return entry.Certificates, true
+}
+
+func (c *CertificateCache) set(key string, certificates []wallet.IdentityCertificate) {
+	c.store.Set(key, CacheEntry{Certificates: certificates, Expires: c.now().Add(c.ttl)})
+}
+
+// Invalidate removes all cached results containing a certificate for identityKey.
+func (c *CertificateCache) Invalidate(identityKey string) {
+	c.deleteMatching(func(cert *wallet.IdentityCertificate) bool {
+		return cert.Subject != nil && cert.Subject.ToDERHex() == identityKey
+	})
+}
+
+// InvalidateRevocationOutpoint removes all cached results containing a certificate
+// with the given revocation outpoint. Call it when the outpoint is seen spent.
+func (c *CertificateCache) InvalidateRevocationOutpoint(outpoint *transaction.Outpoint) {
+	c.deleteMatching(func(cert *wallet.IdentityCertificate) bool {
+		return cert.RevocationOutpoint != nil && cert.RevocationOutpoint.Equal(outpoint)
+	})
+}
+
+// Clear removes every cached entry.
+func (c *CertificateCache) Clear() {
+	c.deleteKeys(func(string, CacheEntry) bool { return true })
+}
+
+func (c *CertificateCache) deleteMatching(match func(cert *wallet.IdentityCertificate) bool) {
+	c.deleteKeys(func(_ string, entry CacheEntry) bool {
+		for i := range entry.Certificates {
+			if match(&entry.Certificates[i]) {
+				return true
+			}
+		}
+		return false
+	})
+}
+
+// deleteKeys collects the matching keys before deleting so stores are not
+// modified while they are being ranged over.
+func (c *CertificateCache) deleteKeys(match func(key string, entry CacheEntry) bool) {
+	var keys []string
+	c.store.Range(func(key string, entry CacheEntry) bool {
+		if match(key, entry) {
+			keys = append(keys, key)
+		}
+		return true
+	})
+	for _, key := range keys {
+		c.store.Delete(key)
+	}
+}
+
+func identityKeyCacheKey(args wallet.DiscoverByIdentityKeyArgs) string {
+	var sb strings.Builder
+	sb.WriteString("key:")
+	if args.IdentityKey != nil {
+		sb.WriteString(hex.EncodeToString(args.IdentityKey.Compressed()))
+	}
+	writePage(&sb, args.Limit, args.Offset)
+	return sb.String()
+}
+
+func attributesCacheKey(args wallet.DiscoverByAttributesArgs) string {
+	names := make([]string, 0, len(args.Attributes))
+	for name := range args.Attributes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var sb strings.Builder
+	sb.WriteString("attr:")
+	for _, name := range names {
+		sb.WriteString(strconv.Quote(name))
+		sb.WriteByte('=')
+		sb.WriteString(strconv.Quote(args.Attributes[name]))
+		sb.WriteByte(';')
+	}
+	writePage(&sb, args.Limit, args.Offset)
+	return sb.String()
+}
+
+func writePage(sb *strings.Builder, limit, offset *uint32) {
+	for _, v := range []*uint32{limit, offset} {
+		sb.WriteByte('|')
+		if v != nil {
+			sb.WriteString(strconv.FormatUint(uint64(*v), 10))
+		}
+	}
+}
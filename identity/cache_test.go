@@ -0,0 +1,102 @@
+package identity
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/bsv-blockchain/go-sdk/chainhash"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+	"github.com/bsv-blockchain/go-sdk/wallet"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClientCache(t *testing.T) {
+	ctx := context.Background()
+	_, subject := privateKeyFromInt(123)
+	outpoint := &transaction.Outpoint{Txid: chainhash.DoubleHashH([]byte("revocation")), Index: 1}
+
+	mockWallet := wallet.NewTestWalletForRandomKey(t)
+	calls := 0
+	certs := &wallet.DiscoverCertificatesResult{
+		Certificates: []wallet.IdentityCertificate{{
+			Certificate: wallet.Certificate{
+				Subject:            subject,
+				RevocationOutpoint: outpoint,
+			},
+		}},
+	}
+	mockWallet.OnDiscoverByIdentityKey().Do(func(context.Context, wallet.DiscoverByIdentityKeyArgs, string) (*wallet.DiscoverCertificatesResult, error) {
+		calls++
+		return certs, nil
+	})
+	mockWallet.OnDiscoverByAttributes().Do(func(context.Context, wallet.DiscoverByAttributesArgs, string) (*wallet.DiscoverCertificatesResult, error) {
+		calls++
+		return certs, nil
+	})
+
+	now := time.Unix(1700000000, 0)
+	cache := NewCertificateCache(nil, time.Minute)
+	cache.now = func() time.Time { return now }
+
+	client, err := NewClient(mockWallet, &IdentityClientOptions{Cache: cache}, "")
+	require.NoError(t, err)
+
+	byKey := wallet.DiscoverByIdentityKeyArgs{IdentityKey: subject}
+	byAttr := wallet.DiscoverByAttributesArgs{Attributes: map[string]string{"email": "alice@example.com"}}
+
+	resolve := func() {
+		_, err := client.ResolveByIdentityKey(ctx, byKey)
+		require.NoError(t, err)
+		_, err = client.ResolveByAttributes(ctx, byAttr)
+		require.NoError(t, err)
+	}
+
+	t.Run("repeat resolutions are served from the cache", func(t *testing.T) {
+		resolve()
+		resolve()
+		require.Equal(t, 2, calls)
+	})
+
+	t.Run("expired entries are re-queried", func(t *testing.T) {
+		now = now.Add(time.Minute)
+		resolve()
+		require.Equal(t, 4, calls)
+	})
+
+	t.Run("revocation outpoint invalidates matching entries", func(t *testing.T) {
+		cache.InvalidateRevocationOutpoint(&transaction.Outpoint{Txid: outpoint.Txid, Index: 2})
+		resolve()
+		require.Equal(t, 4, calls)
+
+		cache.InvalidateRevocationOutpoint(outpoint)
+		resolve()
+		require.Equal(t, 6, calls)
+	})
+
+	t.Run("identity key invalidates matching entries", func(t *testing.T) {
+		cache.Invalidate(subject.ToDERHex())
+		resolve()
+		require.Equal(t, 8, calls)
+	})
+
+	t.Run("different pages are cached separately", func(t *testing.T) {
+		limit := uint32(5)
+		_, err := client.ResolveByIdentityKey(ctx, wallet.DiscoverByIdentityKeyArgs{IdentityKey: subject, Limit: &limit})
+		require.NoError(t, err)
+		require.Equal(t, 9, calls)
+
+		cache.Clear()
+		resolve()
+		require.Equal(t, 11, calls)
+	})
+}
+
+func TestAttributesCacheKeyIsOrderIndependent(t *testing.T) {
+	a := attributesCacheKey(wallet.DiscoverByAttributesArgs{Attributes: map[string]string{"a": "1", "b": "2"}})
+	b := attributesCacheKey(wallet.DiscoverByAttributesArgs{Attributes: map[string]string{"b": "2", "a": "1"}})
+	require.Equal(t, a, b)
+
+	c := attributesCacheKey(wallet.DiscoverByAttributesArgs{Attributes: map[string]string{"a": "1;\"b\"=\"2"}})
+	require.NotEqual(t, a, c)
+}
@@ -13,6 +13,7 @@ import (
 	"fmt"
 
 	"github.com/bsv-blockchain/go-sdk/auth/certificates"
+	"github.com/bsv-blockchain/go-sdk/internal/logging"
 	"github.com/bsv-blockchain/go-sdk/overlay"
 	"github.com/bsv-blockchain/go-sdk/overlay/topic"
 	ec "github.com/bsv-blockchain/go-sdk/primitives/ec"
@@ -203,13 +204,19 @@ func (c *Client) PubliclyRevealAttributes(
 
 	broadcaster, err := topic.NewBroadcaster([]string{"tm_identity"}, &topic.BroadcasterConfig{
 		NetworkPreset: network,
+		Logger:        c.Options.Logger,
+		HTTPClient:    c.Options.HTTPClient,
 	})
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to create broadcaster: %w", err)
 	}
 
 	// Broadcast the transaction
-	success, failure := broadcaster.Broadcast(tx)
+	success, failure := broadcaster.BroadcastCtx(ctx, tx)
+	if failure != nil {
+		logging.OrDefault(c.Options.Logger).DebugContext(ctx, "identity certificate broadcast failed",
+			"txid", tx.TxID().String(), "code", failure.Code, "description", failure.Description)
+	}
 	return success, failure, nil
 }
 
@@ -241,17 +248,24 @@ func (c *Client) ResolveByIdentityKey(
 	ctx context.Context,
 	args wallet.DiscoverByIdentityKeyArgs,
 ) ([]DisplayableIdentity, error) {
+	cache := c.Options.Cache
+	key := identityKeyCacheKey(args)
+	if cache != nil {
+		if certificates, ok := cache.get(key); ok {
+			logging.OrDefault(c.Options.Logger).DebugContext(ctx, "resolved identities from cache", "certificates", len(certificates))
+			return c.parseIdentities(certificates), nil
+		}
+	}
+
 	result, err := c.Wallet.DiscoverByIdentityKey(ctx, args, string(c.Originator))
 	if err != nil {
 		return nil, err
 	}
-
-	identities := make([]DisplayableIdentity, len(result.Certificates))
-	for i, cert := range result.Certificates {
-		identities[i] = c.parseIdentity(&cert)
+	if cache != nil {
+		cache.set(key, result.Certificates)
 	}
 
-	return identities, nil
+	return c.parseIdentities(result.Certificates), nil
 }
 
 // ResolveByAttributes resolves displayable identity certificates by specific identity attributes, issued by a trusted entity.
@@ -259,17 +273,32 @@ func (c *Client) ResolveByAttributes(
 	ctx context.Context,
 	args wallet.DiscoverByAttributesArgs,
 ) ([]DisplayableIdentity, error) {
+	cache := c.Options.Cache
+	key := attributesCacheKey(args)
+	if cache != nil {
+		if certificates, ok := cache.get(key); ok {
+			logging.OrDefault(c.Options.Logger).DebugContext(ctx, "resolved identities from cache", "certificates", len(certificates))
+			return c.parseIdentities(certificates), nil
+		}
+	}
+
 	result, err := c.Wallet.DiscoverByAttributes(ctx, args, string(c.Originator))
 	if err != nil {
 		return nil, err
 	}
-
-	identities := make([]DisplayableIdentity, len(result.Certificates))
-	for i, cert := range result.Certificates {
-		identities[i] = c.parseIdentity(&cert)
+	if cache != nil {
+		cache.set(key, result.Certificates)
 	}
 
-	return identities, nil
+	return c.parseIdentities(result.Certificates), nil
+}
+
+func (c *Client) parseIdentities(certificates []wallet.IdentityCertificate) []DisplayableIdentity {
+	identities := make([]DisplayableIdentity, len(certificates))
+	for i := range certificates {
+		identities[i] = c.parseIdentity(&certificates[i])
+	}
+	return identities
 }
 
 // ParseIdentity parse out identity and certifier attributes to display from an IdentityCertificate
@@ -0,0 +1,99 @@
+package identity
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/bsv-blockchain/go-sdk/internal/logging"
+	"github.com/bsv-blockchain/go-sdk/overlay/lookup"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+	"github.com/bsv-blockchain/go-sdk/wallet"
+)
+
+// ErrNoUnspentChecker is returned by CheckRevocation when the client has no UnspentChecker.
+var ErrNoUnspentChecker = errors.New("no unspent checker configured for revocation checks")
+
+// UnspentChecker reports whether an outpoint is still unspent.
+type UnspentChecker interface {
+	IsUnspent(ctx context.Context, outpoint *transaction.Outpoint) (bool, error)
+}
+
+// UnspentCheckerFunc adapts a function to the UnspentChecker interface.
+type UnspentCheckerFunc func(ctx context.Context, outpoint *transaction.Outpoint) (bool, error)
+
+// IsUnspent calls f(ctx, outpoint).
+func (f UnspentCheckerFunc) IsUnspent(ctx context.Context, outpoint *transaction.Outpoint) (bool, error) {
+	return f(ctx, outpoint)
+}
+
+// LookupQuerier executes overlay lookup questions, as implemented by lookup.LookupResolver.
+type LookupQuerier interface {
+	Query(ctx context.Context, question *lookup.LookupQuestion) (*lookup.LookupAnswer, error)
+}
+
+// LookupUnspentChecker checks outpoints against an overlay lookup service tracking
+// revocation outputs. The service is asked {"outpoint": "<txid>.<index>"} and the
+// outpoint is unspent if it is present in the returned output list.
+type LookupUnspentChecker struct {
+	Resolver LookupQuerier
+	Service  string
+	// Logger receives the outputs skipped as invalid, slog.Default when nil.
+	Logger *slog.Logger
+}
+
+var _ UnspentChecker = (*LookupUnspentChecker)(nil)
+
+// IsUnspent implements UnspentChecker.
+func (p *LookupUnspentChecker) IsUnspent(ctx context.Context, outpoint *transaction.Outpoint) (bool, error) {
+	query, err := json.Marshal(map[string]string{"outpoint": outpoint.String()})
+	if err != nil {
+		return false, err
+	}
+	answer, err := p.Resolver.Query(ctx, &lookup.LookupQuestion{Service: p.Service, Query: query})
+	if err != nil {
+		return false, fmt.Errorf("failed to look up revocation outpoint: %w", err)
+	}
+	if answer.Type != lookup.AnswerTypeOutputList {
+		return false, fmt.Errorf("unexpected lookup answer type %q", answer.Type)
+	}
+	for _, output := range answer.Outputs {
+		if output.OutputIndex != outpoint.Index {
+			continue
+		}
+		tx, err := transaction.NewTransactionFromBEEF(output.Beef)
+		if err != nil || tx == nil {
+			logging.OrDefault(p.Logger).DebugContext(ctx, "skipping revocation output with invalid BEEF",
+				"service", p.Service, "error", err)
+			continue
+		}
+		if tx.TxID().Equal(outpoint.Txid) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// CheckRevocation reports whether the certificate has been revoked, which is the
+// case once its revocation outpoint has been spent. Certificates without a
+// revocation outpoint, or with the all-zero placeholder, cannot be revoked.
+// Revoked certificates are dropped from the client's cache.
+func (c *Client) CheckRevocation(ctx context.Context, cert *wallet.Certificate) (bool, error) {
+	outpoint := cert.RevocationOutpoint
+	if outpoint == nil || outpoint.Equal(&transaction.Outpoint{}) {
+		return false, nil
+	}
+	if c.Options.UnspentChecker == nil {
+		return false, ErrNoUnspentChecker
+	}
+	unspent, err := c.Options.UnspentChecker.IsUnspent(ctx, outpoint)
+	if err != nil {
+		return false, fmt.Errorf("failed to check revocation outpoint %s: %w", outpoint, err)
+	}
+	if !unspent && c.Options.Cache != nil {
+		c.Options.Cache.InvalidateRevocationOutpoint(outpoint)
+	}
+	return !unspent, nil
+}
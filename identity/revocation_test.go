@@ -0,0 +1,107 @@
+package identity
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/bsv-blockchain/go-sdk/overlay/lookup"
+	"github.com/bsv-blockchain/go-sdk/script"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+	"github.com/bsv-blockchain/go-sdk/wallet"
+	"github.com/stretchr/testify/require"
+)
+
+type lookupQuerierFunc func(ctx context.Context, question *lookup.LookupQuestion) (*lookup.LookupAnswer, error)
+
+func (f lookupQuerierFunc) Query(ctx context.Context, question *lookup.LookupQuestion) (*lookup.LookupAnswer, error) {
+	return f(ctx, question)
+}
+
+func TestCheckRevocation(t *testing.T) {
+	ctx := context.Background()
+	mockWallet := wallet.NewTestWalletForRandomKey(t)
+
+	revocationTx := transaction.NewTransaction()
+	revocationTx.AddOutput(&transaction.TransactionOutput{Satoshis: 1, LockingScript: &script.Script{script.OpTRUE}})
+	outpoint := &transaction.Outpoint{Txid: *revocationTx.TxID(), Index: 0}
+	cert := &wallet.Certificate{RevocationOutpoint: outpoint}
+
+	t.Run("certificates without a revocation outpoint are never revoked", func(t *testing.T) {
+		client, err := NewClient(mockWallet, &IdentityClientOptions{}, "")
+		require.NoError(t, err)
+
+		revoked, err := client.CheckRevocation(ctx, &wallet.Certificate{})
+		require.NoError(t, err)
+		require.False(t, revoked)
+
+		revoked, err = client.CheckRevocation(ctx, &wallet.Certificate{RevocationOutpoint: &transaction.Outpoint{}})
+		require.NoError(t, err)
+		require.False(t, revoked)
+
+		_, err = client.CheckRevocation(ctx, cert)
+		require.ErrorIs(t, err, ErrNoUnspentChecker)
+	})
+
+	t.Run("spent outpoint reports revoked and invalidates the cache", func(t *testing.T) {
+		cache := NewCertificateCache(nil, 0)
+		cache.set("key:test", []wallet.IdentityCertificate{{Certificate: *cert}})
+
+		client, err := NewClient(mockWallet, &IdentityClientOptions{
+			Cache: cache,
+			UnspentChecker: UnspentCheckerFunc(func(_ context.Context, op *transaction.Outpoint) (bool, error) {
+				require.True(t, op.Equal(outpoint))
+				return false, nil
+			}),
+		}, "")
+		require.NoError(t, err)
+
+		revoked, err := client.CheckRevocation(ctx, cert)
+		require.NoError(t, err)
+		require.True(t, revoked)
+		_, ok := cache.get("key:test")
+		require.False(t, ok)
+	})
+
+	t.Run("checker errors are returned", func(t *testing.T) {
+		client, err := NewClient(mockWallet, &IdentityClientOptions{
+			UnspentChecker: UnspentCheckerFunc(func(context.Context, *transaction.Outpoint) (bool, error) {
+				return false, errors.New("unavailable")
+			}),
+		}, "")
+		require.NoError(t, err)
+
+		_, err = client.CheckRevocation(ctx, cert)
+		require.ErrorContains(t, err, "unavailable")
+	})
+
+	t.Run("lookup checker", func(t *testing.T) {
+		beef, err := revocationTx.BEEF()
+		require.NoError(t, err)
+
+		var outputs []*lookup.OutputListItem
+		checker := &LookupUnspentChecker{
+			Service: "ls_revocation",
+			Resolver: lookupQuerierFunc(func(_ context.Context, question *lookup.LookupQuestion) (*lookup.LookupAnswer, error) {
+				require.Equal(t, "ls_revocation", question.Service)
+				var query map[string]string
+				require.NoError(t, json.Unmarshal(question.Query, &query))
+				require.Equal(t, outpoint.String(), query["outpoint"])
+				return &lookup.LookupAnswer{Type: lookup.AnswerTypeOutputList, Outputs: outputs}, nil
+			}),
+		}
+		client, err := NewClient(mockWallet, &IdentityClientOptions{UnspentChecker: checker}, "")
+		require.NoError(t, err)
+
+		outputs = []*lookup.OutputListItem{{Beef: beef, OutputIndex: 0}}
+		revoked, err := client.CheckRevocation(ctx, cert)
+		require.NoError(t, err)
+		require.False(t, revoked)
+
+		outputs = nil
+		revoked, err = client.CheckRevocation(ctx, cert)
+		require.NoError(t, err)
+		require.True(t, revoked)
+	})
+}
@@ -1,7 +1,11 @@
 package identity
 
 import (
+	"log/slog"
+
+	"github.com/bsv-blockchain/go-sdk/util"
 	"github.com/bsv-blockchain/go-sdk/wallet"
+	"github.com/bsv-blockchain/go-sdk/wallet/certtypes"
 )
 
 // DisplayableIdentity contains formatted identity information for display in UIs
@@ -32,6 +36,17 @@ type IdentityClientOptions struct {
 	KeyID       string
 	TokenAmount uint64
 	OutputIndex uint32
+	// Cache, when set, caches certificates resolved by ResolveByIdentityKey and
+	// ResolveByAttributes.
+	Cache *CertificateCache
+	// UnspentChecker is used by CheckRevocation to look up revocation outpoints.
+	UnspentChecker UnspentChecker
+	// Logger receives the diagnostics of the client and of the overlay broadcaster
+	// it creates, slog.Default when nil.
+	Logger *slog.Logger
+	// HTTPClient is used for overlay requests, for example to route them through a
+	// proxy or use custom TLS settings. http.DefaultClient when nil.
+	HTTPClient util.HTTPClient
 }
 
 // KnownIdentityTypes catalogs recognized certificate types
@@ -46,15 +61,15 @@ var KnownIdentityTypes = struct {
 	Self        string
 	CoolCert    string
 }{
-	IdentiCert:  "z40BOInXkI8m7f/wBrv4MJ09bZfzZbTj2fJqCtONqCY=",
-	DiscordCert: "2TgqRC35B1zehGmB21xveZNc7i5iqHc0uxMb+1NMPW4=",
-	PhoneCert:   "mffUklUzxbHr65xLohn0hRL0Tq2GjW1GYF/OPfzqJ6A=",
-	XCert:       "vdDWvftf1H+5+ZprUw123kjHlywH+v20aPQTuXgMpNc=",
-	Registrant:  "YoPsbfR6YQczjzPdHCoGC7nJsOdPQR50+SYqcWpJ0y0=",
-	EmailCert:   "exOl3KM0dIJ04EW5pZgbZmPag6MdJXd3/a1enmUU/BA=",
-	Anyone:      "mfkOMfLDQmrr3SBxBQ5WeE+6Hy3VJRFq6w4A5Ljtlis=",
-	Self:        "Hkge6X5JRxt1cWXtHLCrSTg6dCVTxjQJJ48iOYd7n3g=",
-	CoolCert:    "AGfk/WrT1eBDXpz3mcw386Zww2HmqcIn3uY6x4Af1eo=",
+	IdentiCert:  certtypes.IdentiCert,
+	DiscordCert: certtypes.DiscordCert,
+	PhoneCert:   certtypes.PhoneCert,
+	XCert:       certtypes.XCert,
+	Registrant:  certtypes.Registrant,
+	EmailCert:   certtypes.EmailCert,
+	Anyone:      certtypes.Anyone,
+	Self:        certtypes.Self,
+	CoolCert:    certtypes.CoolCert,
 }
 
 // CertificateFieldNameUnder50Bytes represents a certificate field name
@@ -0,0 +1,13 @@
+package logging
+
+import "log/slog"
+
+// OrDefault returns l, or slog.Default if l is nil. Components with an optional
+// Logger field resolve it through OrDefault when logging, so that the field can be
+// left unset and the zero value of the component remains usable.
+func OrDefault(l *slog.Logger) *slog.Logger {
+	if l == nil {
+		return slog.Default()
+	}
+	return l
+}
@@ -10,6 +10,7 @@ import (
 	"crypto/rand"
 	"crypto/sha256"
 	"encoding/base64"
+	"errors"
 	"fmt"
 
 	ec "github.com/bsv-blockchain/go-sdk/primitives/ec"
@@ -18,6 +19,8 @@ import (
 // https://github.com/bitcoin-sv/BRCs/blob/master/peer-to-peer/0077.md
 var VERSION_BYTES = []byte{0x42, 0x42, 0x33, 0x01}
 
+// SignedMessage is a parsed BRC-77 signature. RecipientPublicKey is nil when
+// anyone can verify the signature.
 type SignedMessage struct {
 	Version            []byte
 	SenderPublicKey    *ec.PublicKey
@@ -26,20 +29,30 @@ type SignedMessage struct {
 	Signature          *ec.Signature
 }
 
-func Sign(message []byte, signer *ec.PrivateKey, verifier *ec.PublicKey) ([]byte, error) {
-	recipientAnyone := verifier == nil
-	if recipientAnyone {
-		_, verifier = ec.PrivateKeyFromBytes([]byte{1})
+// anyoneKey returns the key pair used as counterparty when anyone can verify a signature.
+func anyoneKey() (*ec.PrivateKey, *ec.PublicKey) {
+	return ec.PrivateKeyFromBytes([]byte{1})
+}
+
+func invoiceNumber(keyID []byte) string {
+	return "2-message signing-" + base64.StdEncoding.EncodeToString(keyID)
+}
+
+// NewSignedMessage signs message for verifier, or for anyone when verifier is nil.
+func NewSignedMessage(message []byte, signer *ec.PrivateKey, verifier *ec.PublicKey) (*SignedMessage, error) {
+	if signer == nil {
+		return nil, errors.New("signer is required")
+	}
+	counterparty := verifier
+	if counterparty == nil {
+		_, counterparty = anyoneKey()
 	}
 
 	keyID := make([]byte, 32)
-	_, err := rand.Read(keyID)
-	if err != nil {
+	if _, err := rand.Read(keyID); err != nil {
 		return nil, err
 	}
-	keyIDBase64 := base64.StdEncoding.EncodeToString(keyID)
-	invoiceNumber := "2-message signing-" + keyIDBase64
-	signingPriv, err := signer.DeriveChild(verifier, invoiceNumber)
+	signingPriv, err := signer.DeriveChild(counterparty, invoiceNumber(keyID))
 	if err != nil {
 		return nil, err
 	}
@@ -48,69 +61,122 @@ func Sign(message []byte, signer *ec.PrivateKey, verifier *ec.PublicKey) ([]byte
 	if err != nil {
 		return nil, err
 	}
-	senderPublicKey := signer.PubKey()
+	return &SignedMessage{
+		Version:            VERSION_BYTES,
+		SenderPublicKey:    signer.PubKey(),
+		RecipientPublicKey: verifier,
+		KeyID:              keyID,
+		Signature:          signature,
+	}, nil
+}
 
-	sig := append(VERSION_BYTES, senderPublicKey.Compressed()...)
-	if recipientAnyone {
-		sig = append(sig, 0)
+// ParseSignedMessage parses a serialized BRC-77 signature.
+func ParseSignedMessage(sig []byte) (*SignedMessage, error) {
+	if len(sig) < 4 {
+		return nil, errors.New("signature too short")
+	}
+	counter := 4
+	messageVersion := sig[:counter]
+	if !bytes.Equal(messageVersion, VERSION_BYTES) {
+		return nil, fmt.Errorf("message version mismatch: Expected %x, received %x", VERSION_BYTES, messageVersion)
+	}
+	if len(sig) < counter+33+1 {
+		return nil, errors.New("signature too short")
+	}
+	signer, err := ec.ParsePubKey(sig[counter : counter+33])
+	if err != nil {
+		return nil, err
+	}
+	counter += 33
+
+	var recipient *ec.PublicKey
+	if sig[counter] == 0 {
+		counter++
 	} else {
-		sig = append(sig, verifier.Compressed()...)
+		if len(sig) < counter+33 {
+			return nil, errors.New("signature too short")
+		}
+		if recipient, err = ec.ParsePubKey(sig[counter : counter+33]); err != nil {
+			return nil, err
+		}
+		counter += 33
+	}
+
+	if len(sig) < counter+32 {
+		return nil, errors.New("signature too short")
 	}
-	sig = append(sig, keyID...)
-	signatureDER, err := signature.ToDER()
+	keyID := sig[counter : counter+32]
+	counter += 32
+	signature, err := ec.FromDER(sig[counter:])
 	if err != nil {
 		return nil, err
 	}
-	sig = append(sig, signatureDER...)
-	return sig, nil
+	return &SignedMessage{
+		Version:            messageVersion,
+		SenderPublicKey:    signer,
+		RecipientPublicKey: recipient,
+		KeyID:              keyID,
+		Signature:          signature,
+	}, nil
 }
 
-func Verify(message []byte, sig []byte, recipient *ec.PrivateKey) (bool, error) {
-	counter := 4
-	messageVersion := sig[:counter]
-	if !bytes.Equal(messageVersion, VERSION_BYTES) {
-		return false, fmt.Errorf("message version mismatch: Expected %x, received %x", VERSION_BYTES, messageVersion)
-	}
-	pubKeyBytes := sig[counter : counter+33]
-	counter += 33
-	signer, err := ec.ParsePubKey(pubKeyBytes)
+// Bytes serializes the signature in the BRC-77 wire format.
+func (m *SignedMessage) Bytes() ([]byte, error) {
+	signatureDER, err := m.Signature.ToDER()
 	if err != nil {
-		return false, err
+		return nil, err
 	}
-	verifierFirst := sig[counter]
-	if verifierFirst == 0 {
-		recipient, _ = ec.PrivateKeyFromBytes([]byte{1})
-		counter++
+	sig := make([]byte, 0, len(VERSION_BYTES)+33+33+len(m.KeyID)+len(signatureDER))
+	sig = append(sig, VERSION_BYTES...)
+	sig = append(sig, m.SenderPublicKey.Compressed()...)
+	if m.RecipientPublicKey == nil {
+		sig = append(sig, 0)
 	} else {
-		counter++
-		verifierRest := sig[counter : counter+32]
-		counter += 32
-		verifierDER := append([]byte{verifierFirst}, verifierRest...)
+		sig = append(sig, m.RecipientPublicKey.Compressed()...)
+	}
+	sig = append(sig, m.KeyID...)
+	return append(sig, signatureDER...), nil
+}
+
+// Verify checks the signature over message. recipient must be the private key of
+// RecipientPublicKey, and is ignored when anyone can verify the signature.
+func (m *SignedMessage) Verify(message []byte, recipient *ec.PrivateKey) (bool, error) {
+	if m.RecipientPublicKey == nil {
+		recipient, _ = anyoneKey()
+	} else {
+		verifierDER := m.RecipientPublicKey.Compressed()
 		if recipient == nil {
 			return false, fmt.Errorf("this signature can only be verified with knowledge of a specific private key. The associated public key is: %x", verifierDER)
 		}
 		recipientDER := recipient.PubKey().Compressed()
 		if !bytes.Equal(verifierDER, recipientDER) {
 			errorStr := "the recipient public key is %x but the signature requires the recipient to have public key %x"
-			err = fmt.Errorf(errorStr, recipientDER, verifierDER)
-			return false, err
+			return false, fmt.Errorf(errorStr, recipientDER, verifierDER)
 		}
 	}
-	keyID := sig[counter : counter+32]
-	counter += 32
-	signatureDER := sig[counter:]
-	signature, err := ec.FromDER(signatureDER)
+	signingKey, err := m.SenderPublicKey.DeriveChild(recipient, invoiceNumber(m.KeyID))
 	if err != nil {
 		return false, err
 	}
-	keyIDBase64 := base64.StdEncoding.EncodeToString(keyID)
-	invoiceNumber := "2-message signing-" + keyIDBase64
-	signingKey, err := signer.DeriveChild(recipient, invoiceNumber)
+	hashedMessage := sha256.Sum256(message)
+	return m.Signature.Verify(hashedMessage[:], signingKey), nil
+}
+
+// Sign signs message for verifier, or for anyone when verifier is nil, and returns
+// the serialized signature.
+func Sign(message []byte, signer *ec.PrivateKey, verifier *ec.PublicKey) ([]byte, error) {
+	signed, err := NewSignedMessage(message, signer, verifier)
 	if err != nil {
-		return false, err
+		return nil, err
 	}
-	hashedMessage := sha256.Sum256(message)
-	verified := signature.Verify(hashedMessage[:], signingKey)
-	return verified, nil
+	return signed.Bytes()
+}
 
+// Verify parses a serialized signature and checks it over message.
+func Verify(message []byte, sig []byte, recipient *ec.PrivateKey) (bool, error) {
+	signed, err := ParseSignedMessage(sig)
+	if err != nil {
+		return false, err
+	}
+	return signed.Verify(message, recipient)
 }
@@ -146,3 +146,40 @@ func TestEdgeCases(t *testing.T) {
 		require.Equal(t, len(signatureSerialized), len(signatureDER))
 	}
 }
+
+func TestParseSignedMessage(t *testing.T) {
+	senderPriv, _ := ec.PrivateKeyFromBytes([]byte{15})
+	recipientPriv, recipientPub := ec.PrivateKeyFromBytes([]byte{21})
+	message := []byte{1, 2, 4, 8, 16, 32}
+
+	t.Run("round trips", func(t *testing.T) {
+		for _, verifier := range []*ec.PublicKey{recipientPub, nil} {
+			sig, err := Sign(message, senderPriv, verifier)
+			require.NoError(t, err)
+			signed, err := ParseSignedMessage(sig)
+			require.NoError(t, err)
+			require.True(t, signed.SenderPublicKey.IsEqual(senderPriv.PubKey()))
+			if verifier == nil {
+				require.Nil(t, signed.RecipientPublicKey)
+			} else {
+				require.True(t, signed.RecipientPublicKey.IsEqual(verifier))
+			}
+			reserialized, err := signed.Bytes()
+			require.NoError(t, err)
+			require.Equal(t, sig, reserialized)
+
+			verified, err := signed.Verify(message, recipientPriv)
+			require.NoError(t, err)
+			require.True(t, verified)
+		}
+	})
+
+	t.Run("rejects truncated signatures", func(t *testing.T) {
+		sig, err := Sign(message, senderPriv, recipientPub)
+		require.NoError(t, err)
+		for _, n := range []int{0, 3, 4, 37, 70, 102} {
+			_, err := Verify(message, sig[:n], recipientPriv)
+			require.Error(t, err, "length %d", n)
+		}
+	})
+}
@@ -0,0 +1,294 @@
+// Package messagebox implements a client for message box servers, which store and
+// forward messages addressed to identity keys. Requests are authenticated with
+// BRC-103/104 using the client's wallet, and message bodies are encrypted to the
+// recipient with the wallet's key derivation, so the server only ever sees ciphertext.
+//
+// The wire format matches the message box servers used with the TypeScript
+// message-box-client, so Go and TypeScript peers can exchange messages.
+package messagebox
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+
+	authhttp "github.com/bsv-blockchain/go-sdk/auth/clients/authhttp"
+	ec "github.com/bsv-blockchain/go-sdk/primitives/ec"
+	"github.com/bsv-blockchain/go-sdk/wallet"
+)
+
+// DefaultHost is the message box server used when none is configured.
+const DefaultHost = "https://messagebox.babbage.systems"
+
+// Protocol is used to derive the keys that encrypt message bodies and message IDs.
+var Protocol = wallet.Protocol{
+	SecurityLevel: wallet.SecurityLevelEveryAppAndCounterparty,
+	Protocol:      "messagebox",
+}
+
+const keyID = "1"
+
+var (
+	ErrInvalidArgs = errors.New("invalid message box arguments")
+	ErrDecryption  = errors.New("failed to decrypt message")
+)
+
+// ServerError is returned when the message box server rejects a request.
+type ServerError struct {
+	StatusCode  int
+	Code        string
+	Description string
+}
+
+func (e *ServerError) Error() string {
+	if e.Code == "" {
+		return fmt.Sprintf("message box server responded with status %d: %s", e.StatusCode, e.Description)
+	}
+	return fmt.Sprintf("message box server responded with %s: %s", e.Code, e.Description)
+}
+
+// PeerMessage is a message stored in a message box.
+type PeerMessage struct {
+	MessageID string `json:"messageId"`
+	// Body is the decrypted message body. Messages that were not encrypted are returned as sent.
+	Body      string `json:"body"`
+	Sender    string `json:"sender"`
+	CreatedAt string `json:"created_at,omitempty"`
+	UpdatedAt string `json:"updated_at,omitempty"`
+}
+
+// SendMessageArgs describes a message to send.
+type SendMessageArgs struct {
+	Recipient  *ec.PublicKey
+	MessageBox string
+	Body       string
+	// MessageID overrides the ID derived from the body. IDs must be unique per recipient.
+	MessageID string
+	// SkipEncryption sends the body in plaintext.
+	SkipEncryption bool
+}
+
+// SendMessageResult is returned by the server for an accepted message.
+type SendMessageResult struct {
+	Status    string `json:"status"`
+	MessageID string `json:"messageId"`
+}
+
+// Options configures a Client.
+type Options struct {
+	Wallet wallet.Interface
+	// Host is the message box server, DefaultHost when empty.
+	Host       string
+	Originator string
+	// Fetch performs authenticated requests. Defaults to an AuthFetch for Wallet.
+	Fetch *authhttp.AuthFetch
+}
+
+// Client sends, lists and acknowledges messages on a message box server.
+type Client struct {
+	wallet     wallet.Interface
+	host       string
+	originator string
+
+	mu    sync.Mutex
+	fetch *authhttp.AuthFetch
+}
+
+// NewClient creates a Client.
+func NewClient(opts Options) (*Client, error) {
+	if opts.Wallet == nil {
+		return nil, fmt.Errorf("%w: wallet is required", ErrInvalidArgs)
+	}
+	host := strings.TrimRight(opts.Host, "/")
+	if host == "" {
+		host = DefaultHost
+	}
+	fetch := opts.Fetch
+	if fetch == nil {
+		fetch = authhttp.New(opts.Wallet, authhttp.WithoutLogging())
+	}
+	return &Client{
+		wallet:     opts.Wallet,
+		host:       host,
+		originator: opts.Originator,
+		fetch:      fetch,
+	}, nil
+}
+
+// Host returns the message box server the client talks to.
+func (c *Client) Host() string {
+	return c.host
+}
+
+// SendMessage sends a message to the recipient's message box.
+func (c *Client) SendMessage(ctx context.Context, args SendMessageArgs) (*SendMessageResult, error) {
+	if args.Recipient == nil {
+		return nil, fmt.Errorf("%w: recipient is required", ErrInvalidArgs)
+	}
+	if args.MessageBox == "" {
+		return nil, fmt.Errorf("%w: message box is required", ErrInvalidArgs)
+	}
+	if args.Body == "" {
+		return nil, fmt.Errorf("%w: message body is required", ErrInvalidArgs)
+	}
+
+	counterparty := wallet.Counterparty{Type: wallet.CounterpartyTypeOther, Counterparty: args.Recipient}
+	messageID := args.MessageID
+	if messageID == "" {
+		hmac, err := c.wallet.CreateHMAC(ctx, wallet.CreateHMACArgs{
+			EncryptionArgs: wallet.EncryptionArgs{ProtocolID: Protocol, KeyID: keyID, Counterparty: counterparty},
+			Data:           []byte(args.Body),
+		}, c.originator)
+		if err != nil {
+			return nil, fmt.Errorf("failed to derive message id: %w", err)
+		}
+		messageID = hex.EncodeToString(hmac.HMAC[:])
+	}
+
+	body := args.Body
+	if !args.SkipEncryption {
+		encrypted, err := c.wallet.Encrypt(ctx, wallet.EncryptArgs{
+			EncryptionArgs: wallet.EncryptionArgs{ProtocolID: Protocol, KeyID: keyID, Counterparty: counterparty},
+			Plaintext:      []byte(args.Body),
+		}, c.originator)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encrypt message: %w", err)
+		}
+		envelope, err := json.Marshal(encryptedBody{EncryptedMessage: base64.StdEncoding.EncodeToString(encrypted.Ciphertext)})
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode message: %w", err)
+		}
+		body = string(envelope)
+	}
+
+	request := map[string]any{
+		"message": map[string]string{
+			"recipient":  args.Recipient.ToDERHex(),
+			"messageBox": args.MessageBox,
+			"messageId":  messageID,
+			"body":       body,
+		},
+	}
+	result := &SendMessageResult{}
+	if err := c.post(ctx, "/sendMessage", request, result); err != nil {
+		return nil, err
+	}
+	if result.MessageID == "" {
+		result.MessageID = messageID
+	}
+	return result, nil
+}
+
+// ListMessages returns the messages in one of the caller's message boxes, decrypting
+// encrypted bodies with the sender as counterparty.
+func (c *Client) ListMessages(ctx context.Context, messageBox string) ([]PeerMessage, error) {
+	if messageBox == "" {
+		return nil, fmt.Errorf("%w: message box is required", ErrInvalidArgs)
+	}
+	var response struct {
+		Messages []PeerMessage `json:"messages"`
+	}
+	if err := c.post(ctx, "/listMessages", map[string]string{"messageBox": messageBox}, &response); err != nil {
+		return nil, err
+	}
+	for i := range response.Messages {
+		body, err := c.decryptBody(ctx, &response.Messages[i])
+		if err != nil {
+			return nil, err
+		}
+		response.Messages[i].Body = body
+	}
+	return response.Messages, nil
+}
+
+// AcknowledgeMessages marks messages as received, removing them from the server.
+func (c *Client) AcknowledgeMessages(ctx context.Context, messageIDs ...string) error {
+	if len(messageIDs) == 0 {
+		return fmt.Errorf("%w: at least one message id is required", ErrInvalidArgs)
+	}
+	return c.post(ctx, "/acknowledgeMessage", map[string][]string{"messageIds": messageIDs}, nil)
+}
+
+type encryptedBody struct {
+	EncryptedMessage string `json:"encryptedMessage"`
+}
+
+func (c *Client) decryptBody(ctx context.Context, message *PeerMessage) (string, error) {
+	var envelope encryptedBody
+	if err := json.Unmarshal([]byte(message.Body), &envelope); err != nil || envelope.EncryptedMessage == "" {
+		return message.Body, nil
+	}
+	ciphertext, err := base64.StdEncoding.DecodeString(envelope.EncryptedMessage)
+	if err != nil {
+		return "", fmt.Errorf("%w %s: %w", ErrDecryption, message.MessageID, err)
+	}
+	sender, err := ec.PublicKeyFromString(message.Sender)
+	if err != nil {
+		return "", fmt.Errorf("%w %s: invalid sender: %w", ErrDecryption, message.MessageID, err)
+	}
+	decrypted, err := c.wallet.Decrypt(ctx, wallet.DecryptArgs{
+		EncryptionArgs: wallet.EncryptionArgs{
+			ProtocolID:   Protocol,
+			KeyID:        keyID,
+			Counterparty: wallet.Counterparty{Type: wallet.CounterpartyTypeOther, Counterparty: sender},
+		},
+		Ciphertext: ciphertext,
+	}, c.originator)
+	if err != nil {
+		return "", fmt.Errorf("%w %s: %w", ErrDecryption, message.MessageID, err)
+	}
+	return string(decrypted.Plaintext), nil
+}
+
+// post sends an authenticated JSON request and decodes a successful response into out.
+func (c *Client) post(ctx context.Context, path string, request, out any) error {
+	body, err := json.Marshal(request)
+	if err != nil {
+		return fmt.Errorf("failed to encode request: %w", err)
+	}
+
+	// AuthFetch keeps per-host peers in an unsynchronized map.
+	c.mu.Lock()
+	res, err := c.fetch.Fetch(ctx, c.host+path, &authhttp.SimplifiedFetchRequestOptions{
+		Method:  http.MethodPost,
+		Headers: map[string]string{"Content-Type": "application/json"},
+		Body:    body,
+	})
+	c.mu.Unlock()
+	if err != nil {
+		return fmt.Errorf("request to %s failed: %w", path, err)
+	}
+	defer func() { _ = res.Body.Close() }()
+
+	data, err := io.ReadAll(res.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read response from %s: %w", path, err)
+	}
+	var status struct {
+		Status      string `json:"status"`
+		Code        string `json:"code"`
+		Description string `json:"description"`
+	}
+	_ = json.Unmarshal(data, &status)
+	if res.StatusCode >= http.StatusBadRequest || status.Status == "error" {
+		description := status.Description
+		if description == "" {
+			description = string(bytes.TrimSpace(data))
+		}
+		return &ServerError{StatusCode: res.StatusCode, Code: status.Code, Description: description}
+	}
+	if out != nil {
+		if err := json.Unmarshal(data, out); err != nil {
+			return fmt.Errorf("invalid response from %s: %w", path, err)
+		}
+	}
+	return nil
+}
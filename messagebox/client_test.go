@@ -0,0 +1,179 @@
+package messagebox_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"sync"
+	"testing"
+
+	"github.com/bsv-blockchain/go-sdk/auth/middleware"
+	"github.com/bsv-blockchain/go-sdk/messagebox"
+	ec "github.com/bsv-blockchain/go-sdk/primitives/ec"
+	"github.com/bsv-blockchain/go-sdk/wallet"
+	"github.com/stretchr/testify/require"
+)
+
+type storedMessage struct {
+	messagebox.PeerMessage
+	recipient  string
+	messageBox string
+}
+
+// messageBoxServer is a minimal in-memory message box server behind the auth middleware.
+type messageBoxServer struct {
+	mu       sync.Mutex
+	messages []storedMessage
+}
+
+func (s *messageBoxServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	identity, ok := middleware.IdentityKeyFromContext(r.Context())
+	if !ok {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+	caller := identity.ToDERHex()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	switch r.URL.Path {
+	case "/sendMessage":
+		var req struct {
+			Message struct {
+				Recipient  string `json:"recipient"`
+				MessageBox string `json:"messageBox"`
+				MessageID  string `json:"messageId"`
+				Body       string `json:"body"`
+			} `json:"message"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		for _, m := range s.messages {
+			if m.MessageID == req.Message.MessageID {
+				w.WriteHeader(http.StatusBadRequest)
+				_ = json.NewEncoder(w).Encode(map[string]string{"status": "error", "code": "ERR_DUPLICATE_MESSAGE", "description": "duplicate message"})
+				return
+			}
+		}
+		s.messages = append(s.messages, storedMessage{
+			PeerMessage: messagebox.PeerMessage{MessageID: req.Message.MessageID, Body: req.Message.Body, Sender: caller},
+			recipient:   req.Message.Recipient,
+			messageBox:  req.Message.MessageBox,
+		})
+		_ = json.NewEncoder(w).Encode(map[string]string{"status": "success", "messageId": req.Message.MessageID})
+	case "/listMessages":
+		var req struct {
+			MessageBox string `json:"messageBox"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		messages := []messagebox.PeerMessage{}
+		for _, m := range s.messages {
+			if m.recipient == caller && m.messageBox == req.MessageBox {
+				messages = append(messages, m.PeerMessage)
+			}
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{"status": "success", "messages": messages})
+	case "/acknowledgeMessage":
+		var req struct {
+			MessageIDs []string `json:"messageIds"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		s.messages = slices.DeleteFunc(s.messages, func(m storedMessage) bool {
+			return m.recipient == caller && slices.Contains(req.MessageIDs, m.MessageID)
+		})
+		_ = json.NewEncoder(w).Encode(map[string]string{"status": "success"})
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func newServer(t *testing.T) (*httptest.Server, *messageBoxServer) {
+	t.Helper()
+	auth, err := middleware.New(middleware.Options{Wallet: wallet.NewTestWalletForRandomKey(t)})
+	require.NoError(t, err)
+	store := &messageBoxServer{}
+	server := httptest.NewServer(auth.Handler(store))
+	t.Cleanup(server.Close)
+	return server, store
+}
+
+func newClient(t *testing.T, host string) (*messagebox.Client, *ec.PublicKey) {
+	t.Helper()
+	w := wallet.NewTestWalletForRandomKey(t)
+	client, err := messagebox.NewClient(messagebox.Options{Wallet: w, Host: host})
+	require.NoError(t, err)
+	identity, err := w.GetPublicKey(t.Context(), wallet.GetPublicKeyArgs{IdentityKey: true}, "")
+	require.NoError(t, err)
+	return client, identity.PublicKey
+}
+
+func TestSendListAcknowledge(t *testing.T) {
+	server, store := newServer(t)
+	alice, aliceKey := newClient(t, server.URL)
+	bob, bobKey := newClient(t, server.URL)
+
+	sent, err := alice.SendMessage(t.Context(), messagebox.SendMessageArgs{
+		Recipient:  bobKey,
+		MessageBox: "inbox",
+		Body:       "hello bob",
+	})
+	require.NoError(t, err)
+	require.NotEmpty(t, sent.MessageID)
+
+	require.Len(t, store.messages, 1)
+	require.NotContains(t, store.messages[0].Body, "hello bob", "the server should only see ciphertext")
+
+	messages, err := bob.ListMessages(t.Context(), "inbox")
+	require.NoError(t, err)
+	require.Len(t, messages, 1)
+	require.Equal(t, sent.MessageID, messages[0].MessageID)
+	require.Equal(t, "hello bob", messages[0].Body)
+	require.Equal(t, aliceKey.ToDERHex(), messages[0].Sender)
+
+	messages, err = alice.ListMessages(t.Context(), "inbox")
+	require.NoError(t, err)
+	require.Empty(t, messages)
+
+	require.NoError(t, bob.AcknowledgeMessages(t.Context(), sent.MessageID))
+	messages, err = bob.ListMessages(t.Context(), "inbox")
+	require.NoError(t, err)
+	require.Empty(t, messages)
+}
+
+func TestSendMessageOptions(t *testing.T) {
+	server, _ := newServer(t)
+	alice, _ := newClient(t, server.URL)
+	bob, bobKey := newClient(t, server.URL)
+
+	t.Run("plaintext", func(t *testing.T) {
+		_, err := alice.SendMessage(t.Context(), messagebox.SendMessageArgs{
+			Recipient:      bobKey,
+			MessageBox:     "plain",
+			Body:           `{"hello":"bob"}`,
+			SkipEncryption: true,
+		})
+		require.NoError(t, err)
+		messages, err := bob.ListMessages(t.Context(), "plain")
+		require.NoError(t, err)
+		require.Len(t, messages, 1)
+		require.JSONEq(t, `{"hello":"bob"}`, messages[0].Body)
+	})
+
+	t.Run("server errors", func(t *testing.T) {
+		args := messagebox.SendMessageArgs{Recipient: bobKey, MessageBox: "inbox", Body: "again", MessageID: "fixed"}
+		_, err := alice.SendMessage(t.Context(), args)
+		require.NoError(t, err)
+		_, err = alice.SendMessage(t.Context(), args)
+		var serverErr *messagebox.ServerError
+		require.ErrorAs(t, err, &serverErr)
+		require.Equal(t, "ERR_DUPLICATE_MESSAGE", serverErr.Code)
+	})
+
+	t.Run("invalid arguments", func(t *testing.T) {
+		_, err := alice.SendMessage(t.Context(), messagebox.SendMessageArgs{MessageBox: "inbox", Body: "x"})
+		require.ErrorIs(t, err, messagebox.ErrInvalidArgs)
+		_, err = alice.ListMessages(t.Context(), "")
+		require.ErrorIs(t, err, messagebox.ErrInvalidArgs)
+		require.ErrorIs(t, alice.AcknowledgeMessages(t.Context()), messagebox.ErrInvalidArgs)
+	})
+}
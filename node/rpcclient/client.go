@@ -0,0 +1,235 @@
+// Package rpcclient is a minimal JSON-RPC client for an SV Node, intended for
+// regtest integration tests and local development flows that should not depend
+// on external services.
+package rpcclient
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sync/atomic"
+
+	"github.com/bsv-blockchain/go-sdk/block"
+	"github.com/bsv-blockchain/go-sdk/chainhash"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+	"github.com/bsv-blockchain/go-sdk/transaction/chaintracker"
+	"github.com/bsv-blockchain/go-sdk/util"
+)
+
+// Error is an error returned by the node in a JSON-RPC response.
+type Error struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("rpc error %d: %s", e.Code, e.Message)
+}
+
+var (
+	_ chaintracker.ChainTracker = (*Client)(nil)
+	_ transaction.UtxoProvider  = (*Client)(nil)
+)
+
+// errNoSuchTransaction is the code of the error returned by getrawtransaction
+// for unknown transactions.
+const errNoSuchTransaction = -5
+
+// Client calls the JSON-RPC interface of an SV Node.
+type Client struct {
+	URL      string
+	User     string
+	Password string
+	Client   util.HTTPClient
+
+	nextID atomic.Uint64
+}
+
+// New creates a Client for the node at url, authenticating with the given rpcuser and rpcpassword.
+func New(url, user, password string) *Client {
+	return &Client{
+		URL:      url,
+		User:     user,
+		Password: password,
+	}
+}
+
+type request struct {
+	JSONRPC string `json:"jsonrpc"`
+	ID      uint64 `json:"id"`
+	Method  string `json:"method"`
+	Params  []any  `json:"params"`
+}
+
+type response struct {
+	Result json.RawMessage `json:"result"`
+	Error  *Error          `json:"error"`
+	ID     uint64          `json:"id"`
+}
+
+// Call invokes method with params and decodes the result into result, which may be nil.
+func (c *Client) Call(ctx context.Context, method string, params []any, result any) error {
+	if params == nil {
+		params = []any{}
+	}
+	body, err := json.Marshal(request{
+		JSONRPC: "1.0",
+		ID:      c.nextID.Add(1),
+		Method:  method,
+		Params:  params,
+	})
+	if err != nil {
+		return fmt.Errorf("error marshaling request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.URL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("error creating request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if c.User != "" || c.Password != "" {
+		req.SetBasicAuth(c.User, c.Password)
+	}
+
+	client := c.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("error sending request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("error reading response body: %w", err)
+	}
+
+	// The node reports RPC errors with a non-200 status and a JSON body
+	var rpcResp response
+	if err := json.Unmarshal(respBody, &rpcResp); err != nil {
+		return &util.HTTPError{StatusCode: resp.StatusCode, Err: fmt.Errorf("error unmarshaling response: %w", err)}
+	}
+	if rpcResp.Error != nil {
+		return rpcResp.Error
+	}
+	if result == nil {
+		return nil
+	}
+	if err := json.Unmarshal(rpcResp.Result, result); err != nil {
+		return fmt.Errorf("error unmarshaling %s result: %w", method, err)
+	}
+	return nil
+}
+
+// SendRawTransaction submits tx to the node and returns its txid.
+func (c *Client) SendRawTransaction(ctx context.Context, tx *transaction.Transaction) (*chainhash.Hash, error) {
+	var txid string
+	if err := c.Call(ctx, "sendrawtransaction", []any{tx.Hex()}, &txid); err != nil {
+		return nil, err
+	}
+	return chainhash.NewHashFromHex(txid)
+}
+
+// GetRawTransaction fetches a transaction by txid. The node must run with -txindex
+// to find transactions that are not in the mempool.
+func (c *Client) GetRawTransaction(ctx context.Context, txid *chainhash.Hash) (*transaction.Transaction, error) {
+	var rawHex string
+	if err := c.Call(ctx, "getrawtransaction", []any{txid.String(), false}, &rawHex); err != nil {
+		return nil, err
+	}
+	return transaction.NewTransactionFromHex(rawHex)
+}
+
+// SourceTransaction implements transaction.UtxoProvider with GetRawTransaction,
+// so transactions spending node outputs can be hydrated with
+// Transaction.HydrateInputs.
+func (c *Client) SourceTransaction(ctx context.Context, txid *chainhash.Hash) (*transaction.Transaction, error) {
+	tx, err := c.GetRawTransaction(ctx, txid)
+	var rpcErr *Error
+	if errors.As(err, &rpcErr) && rpcErr.Code == errNoSuchTransaction {
+		return nil, fmt.Errorf("%w: %w", transaction.ErrSourceNotFound, err)
+	}
+	return tx, err
+}
+
+// Generate mines n blocks on a regtest node and returns their hashes.
+func (c *Client) Generate(ctx context.Context, n uint32) ([]*chainhash.Hash, error) {
+	return c.generate(ctx, "generate", []any{n})
+}
+
+// GenerateToAddress mines n blocks on a regtest node paying the coinbase to address.
+func (c *Client) GenerateToAddress(ctx context.Context, n uint32, address string) ([]*chainhash.Hash, error) {
+	return c.generate(ctx, "generatetoaddress", []any{n, address})
+}
+
+func (c *Client) generate(ctx context.Context, method string, params []any) ([]*chainhash.Hash, error) {
+	var hashes []string
+	if err := c.Call(ctx, method, params, &hashes); err != nil {
+		return nil, err
+	}
+	result := make([]*chainhash.Hash, 0, len(hashes))
+	for _, h := range hashes {
+		hash, err := chainhash.NewHashFromHex(h)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, hash)
+	}
+	return result, nil
+}
+
+// GetBlockHeader fetches the header of the block with the given hash.
+func (c *Client) GetBlockHeader(ctx context.Context, hash *chainhash.Hash) (*block.Header, error) {
+	var headerHex string
+	if err := c.Call(ctx, "getblockheader", []any{hash.String(), false}, &headerHex); err != nil {
+		return nil, err
+	}
+	raw, err := hex.DecodeString(headerHex)
+	if err != nil {
+		return nil, err
+	}
+	return block.NewHeaderFromBytes(raw)
+}
+
+// GetBlockHash returns the hash of the block at height in the active chain.
+func (c *Client) GetBlockHash(ctx context.Context, height uint32) (*chainhash.Hash, error) {
+	var hash string
+	if err := c.Call(ctx, "getblockhash", []any{height}, &hash); err != nil {
+		return nil, err
+	}
+	return chainhash.NewHashFromHex(hash)
+}
+
+// GetBlockCount returns the height of the active chain tip.
+func (c *Client) GetBlockCount(ctx context.Context) (uint32, error) {
+	var count uint32
+	if err := c.Call(ctx, "getblockcount", nil, &count); err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// IsValidRootForHeight implements chaintracker.ChainTracker using the node's active chain.
+func (c *Client) IsValidRootForHeight(ctx context.Context, root *chainhash.Hash, height uint32) (bool, error) {
+	hash, err := c.GetBlockHash(ctx, height)
+	if err != nil {
+		return false, err
+	}
+	header, err := c.GetBlockHeader(ctx, hash)
+	if err != nil {
+		return false, err
+	}
+	return header.MerkleRoot.IsEqual(root), nil
+}
+
+// CurrentHeight implements chaintracker.ChainTracker.
+func (c *Client) CurrentHeight(ctx context.Context) (uint32, error) {
+	return c.GetBlockCount(ctx)
+}
@@ -0,0 +1,147 @@
+package rpcclient
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/bsv-blockchain/go-sdk/chainhash"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	genesisHeaderHex = "0100000000000000000000000000000000000000000000000000000000000000000000003ba3edfd7a7b12b27ac72c3e67768f617fc81bc3888a51323a9fb8aa4b1e5e4a29ab5f49ffff001d1dac2b7c"
+	genesisHash      = "000000000019d6689c085ae165831e934ff763ae46a2a6c172b3f1b60a8ce26f"
+	genesisRoot      = "4a5e1e4baab89f3a32518a88c31bc87f618f76673e2cc77ab2127b7afdeda33b"
+	testTxHex        = "0100000001b1e5bf6e0649f299bb2b20964090b5b0a02e96db182eecedb0a9e4e7af03e06e000000006b483045022100ca75f7f664fa3086a3430b0f5d4a531d26e8d2ef3a72f086e890c5618d858fed022006e9a3c9f08e1743b033a55c27fb9d6c6cf1a1f6e0c40090e229d4ff8e5ecb31412102798913bc057b344de675dac34faafe3dc2f312c758cd9068209f810877306d66ffffffff01b0f9d804000000001976a9144bd8c375bdac70fb6eb7261d6e6c70450787e6af88ac00000000"
+)
+
+// newTestNode starts a server answering JSON-RPC calls from handlers keyed by method.
+func newTestNode(t *testing.T, handlers map[string]func(params []any) (any, *Error)) *Client {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		user, pass, ok := r.BasicAuth()
+		require.True(t, ok)
+		require.Equal(t, "user", user)
+		require.Equal(t, "pass", pass)
+
+		var req request
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		handler, ok := handlers[req.Method]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			_ = json.NewEncoder(w).Encode(map[string]any{"result": nil, "error": &Error{Code: -32601, Message: "Method not found"}, "id": req.ID})
+			return
+		}
+		result, rpcErr := handler(req.Params)
+		if rpcErr != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{"result": result, "error": rpcErr, "id": req.ID})
+	}))
+	t.Cleanup(server.Close)
+	return New(server.URL, "user", "pass")
+}
+
+func TestClientTransactions(t *testing.T) {
+	client := newTestNode(t, map[string]func(params []any) (any, *Error){
+		"sendrawtransaction": func(params []any) (any, *Error) {
+			if params[0] != testTxHex {
+				return nil, &Error{Code: -25, Message: "Missing inputs"}
+			}
+			return "0d3e0e5bc8cd5c8be4ac2b52d4f0bb2df2bab4a5c1a5b54ff5de1fdef71d1a6c", nil
+		},
+		"getrawtransaction": func(params []any) (any, *Error) {
+			return testTxHex, nil
+		},
+	})
+	ctx := t.Context()
+
+	tx, err := client.GetRawTransaction(ctx, &chainhash.Hash{})
+	require.NoError(t, err)
+	require.Equal(t, testTxHex, tx.Hex())
+
+	sent, err := client.SendRawTransaction(ctx, tx)
+	require.NoError(t, err)
+	require.Equal(t, "0d3e0e5bc8cd5c8be4ac2b52d4f0bb2df2bab4a5c1a5b54ff5de1fdef71d1a6c", sent.String())
+
+	source, err := client.SourceTransaction(ctx, &chainhash.Hash{})
+	require.NoError(t, err)
+	require.Equal(t, testTxHex, source.Hex())
+}
+
+func TestClientSourceNotFound(t *testing.T) {
+	client := newTestNode(t, map[string]func(params []any) (any, *Error){
+		"getrawtransaction": func(params []any) (any, *Error) {
+			return nil, &Error{Code: -5, Message: "No such mempool or blockchain transaction"}
+		},
+	})
+	_, err := client.SourceTransaction(t.Context(), &chainhash.Hash{})
+	require.ErrorIs(t, err, transaction.ErrSourceNotFound)
+	var rpcErr *Error
+	require.ErrorAs(t, err, &rpcErr)
+}
+
+func TestClientErrors(t *testing.T) {
+	client := newTestNode(t, map[string]func(params []any) (any, *Error){
+		"sendrawtransaction": func(params []any) (any, *Error) {
+			return nil, &Error{Code: -25, Message: "Missing inputs"}
+		},
+	})
+
+	err := client.Call(t.Context(), "sendrawtransaction", []any{"00"}, nil)
+	var rpcErr *Error
+	require.ErrorAs(t, err, &rpcErr)
+	require.Equal(t, -25, rpcErr.Code)
+
+	_, err = client.Generate(t.Context(), 1)
+	require.ErrorAs(t, err, &rpcErr)
+	require.Equal(t, -32601, rpcErr.Code)
+}
+
+func TestClientBlocks(t *testing.T) {
+	client := newTestNode(t, map[string]func(params []any) (any, *Error){
+		"generate": func(params []any) (any, *Error) {
+			require.Equal(t, float64(1), params[0])
+			return []string{genesisHash}, nil
+		},
+		"getblockcount": func(params []any) (any, *Error) {
+			return 0, nil
+		},
+		"getblockhash": func(params []any) (any, *Error) {
+			return genesisHash, nil
+		},
+		"getblockheader": func(params []any) (any, *Error) {
+			require.Equal(t, genesisHash, params[0])
+			require.Equal(t, false, params[1])
+			return genesisHeaderHex, nil
+		},
+	})
+	ctx := t.Context()
+
+	hashes, err := client.Generate(ctx, 1)
+	require.NoError(t, err)
+	require.Len(t, hashes, 1)
+	require.Equal(t, genesisHash, hashes[0].String())
+
+	header, err := client.GetBlockHeader(ctx, hashes[0])
+	require.NoError(t, err)
+	require.Equal(t, genesisHeaderHex, hex.EncodeToString(header.Bytes()))
+	require.NoError(t, header.CheckProofOfWork())
+
+	height, err := client.CurrentHeight(ctx)
+	require.NoError(t, err)
+	require.Equal(t, uint32(0), height)
+
+	root, err := chainhash.NewHashFromHex(genesisRoot)
+	require.NoError(t, err)
+	valid, err := client.IsValidRootForHeight(ctx, root, 0)
+	require.NoError(t, err)
+	require.True(t, valid)
+
+	valid, err = client.IsValidRootForHeight(ctx, &chainhash.Hash{}, 0)
+	require.NoError(t, err)
+	require.False(t, valid)
+}
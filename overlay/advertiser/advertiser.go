@@ -0,0 +1,275 @@
+// Package advertiser creates and parses SHIP and SLAP advertisements. A SHIP
+// advertisement announces that a host serves an overlay topic (tm_*), a SLAP
+// advertisement that it answers queries for a lookup service (ls_*). Both are
+// PushDrop tokens built with the admintoken template and paid for by a wallet.
+package advertiser
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+
+	"github.com/bsv-blockchain/go-sdk/overlay"
+	admintoken "github.com/bsv-blockchain/go-sdk/overlay/admin-token"
+	"github.com/bsv-blockchain/go-sdk/overlay/lookup"
+	ec "github.com/bsv-blockchain/go-sdk/primitives/ec"
+	"github.com/bsv-blockchain/go-sdk/script"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+	"github.com/bsv-blockchain/go-sdk/wallet"
+)
+
+const (
+	// TopicSHIP is the overlay topic SHIP advertisements are submitted to
+	TopicSHIP = "tm_ship"
+	// TopicSLAP is the overlay topic SLAP advertisements are submitted to
+	TopicSLAP = "tm_slap"
+	// ServiceSHIP is the lookup service answering queries for SHIP advertisements
+	ServiceSHIP = "ls_ship"
+	// ServiceSLAP is the lookup service answering queries for SLAP advertisements
+	ServiceSLAP = "ls_slap"
+
+	// AdvertisementSatoshis is the amount locked in each advertisement output
+	AdvertisementSatoshis = 1
+
+	// maxNameLength is the longest topic or service name allowed by BRC-87
+	maxNameLength = 50
+)
+
+var (
+	ErrInvalidAdvertisement = errors.New("invalid advertisement")
+	ErrNoAdvertisements     = errors.New("at least one advertisement is required")
+)
+
+// AdvertisementData describes an advertisement to create
+type AdvertisementData struct {
+	Protocol       overlay.Protocol
+	TopicOrService string
+}
+
+// Advertisement is a parsed SHIP or SLAP advertisement
+type Advertisement struct {
+	Protocol       overlay.Protocol
+	IdentityKey    *ec.PublicKey
+	Domain         string
+	TopicOrService string
+	// Beef and OutputIndex locate the advertisement token when it was found on the overlay
+	Beef        []byte
+	OutputIndex uint32
+}
+
+// WalletAdvertiser creates advertisements for a host, funding them from a wallet
+type WalletAdvertiser struct {
+	Wallet     wallet.Interface
+	Domain     string
+	Originator string
+	// Resolver is used by FindAllAdvertisements
+	Resolver *lookup.LookupResolver
+}
+
+// NewWalletAdvertiser creates an advertiser for the host at domain, which must be an http(s) URL
+func NewWalletAdvertiser(w wallet.Interface, domain string, originator string) (*WalletAdvertiser, error) {
+	if w == nil {
+		return nil, errors.New("wallet is required")
+	}
+	if err := validateDomain(domain); err != nil {
+		return nil, err
+	}
+	return &WalletAdvertiser{
+		Wallet:     w,
+		Domain:     domain,
+		Originator: originator,
+	}, nil
+}
+
+// CreateAdvertisements builds and funds a transaction with one advertisement output per
+// entry in ads. The returned tagged BEEF is ready to be submitted to the tm_ship and
+// tm_slap topics, e.g. with a topic.Broadcaster.
+func (a *WalletAdvertiser) CreateAdvertisements(ctx context.Context, ads []AdvertisementData) (*overlay.TaggedBEEF, error) {
+	if len(ads) == 0 {
+		return nil, ErrNoAdvertisements
+	}
+	template := admintoken.NewOverlayAdminToken(a.Wallet, a.Originator)
+
+	outputs := make([]wallet.CreateActionOutput, 0, len(ads))
+	var topics []string
+	for _, ad := range ads {
+		if err := validateTopicOrService(ad.Protocol, ad.TopicOrService); err != nil {
+			return nil, err
+		}
+		lockingScript, err := template.Lock(ctx, ad.Protocol, a.Domain, ad.TopicOrService)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create %s advertisement for %s: %w", ad.Protocol, ad.TopicOrService, err)
+		}
+		outputs = append(outputs, wallet.CreateActionOutput{
+			LockingScript:     lockingScript.Bytes(),
+			Satoshis:          AdvertisementSatoshis,
+			OutputDescription: fmt.Sprintf("%s advertisement of %s", ad.Protocol, ad.TopicOrService),
+		})
+		if topic := protocolTopic(ad.Protocol); !slices.Contains(topics, topic) {
+			topics = append(topics, topic)
+		}
+	}
+
+	randomizeOutputs := false
+	result, err := a.Wallet.CreateAction(ctx, wallet.CreateActionArgs{
+		Description: "SHIP/SLAP Advertisement Issuance",
+		Outputs:     outputs,
+		Options: &wallet.CreateActionOptions{
+			RandomizeOutputs: &randomizeOutputs,
+		},
+	}, a.Originator)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create advertisement transaction: %w", err)
+	}
+	if len(result.Tx) == 0 {
+		return nil, errors.New("wallet did not return the advertisement transaction")
+	}
+
+	return &overlay.TaggedBEEF{
+		Beef:   result.Tx,
+		Topics: topics,
+	}, nil
+}
+
+// FindAllAdvertisements looks up the advertisements of the given protocol published
+// under the wallet's identity key.
+func (a *WalletAdvertiser) FindAllAdvertisements(ctx context.Context, protocol overlay.Protocol) ([]*Advertisement, error) {
+	if a.Resolver == nil {
+		return nil, errors.New("a lookup resolver is required to find advertisements")
+	}
+	service := protocolService(protocol)
+	if service == "" {
+		return nil, fmt.Errorf("%w: unsupported protocol %s", ErrInvalidAdvertisement, protocol)
+	}
+	identity, err := a.Wallet.GetPublicKey(ctx, wallet.GetPublicKeyArgs{IdentityKey: true}, a.Originator)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get identity key: %w", err)
+	}
+	query, err := json.Marshal(map[string]string{"identityKey": identity.PublicKey.ToDERHex()})
+	if err != nil {
+		return nil, err
+	}
+
+	outputs, err := a.Resolver.QueryOutputs(ctx, &lookup.LookupQuestion{Service: service, Query: query})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query %s: %w", service, err)
+	}
+	ads := make([]*Advertisement, 0, len(outputs))
+	for _, output := range outputs {
+		ad, err := ParseAdvertisement(output.Output.LockingScript)
+		if err != nil || ad.Protocol != protocol {
+			continue
+		}
+		if ad.Beef, err = output.Tx.AtomicBEEF(false); err != nil {
+			return nil, err
+		}
+		ad.OutputIndex = output.OutputIndex
+		ads = append(ads, ad)
+	}
+	return ads, nil
+}
+
+// ParseAdvertisement decodes and validates a SHIP or SLAP advertisement locking script
+func ParseAdvertisement(s *script.Script) (*Advertisement, error) {
+	data := admintoken.Decode(s)
+	if data == nil {
+		return nil, fmt.Errorf("%w: not an overlay admin token", ErrInvalidAdvertisement)
+	}
+	if err := validateDomain(data.Domain); err != nil {
+		return nil, err
+	}
+	if err := validateTopicOrService(data.Protocol, data.TopicOrService); err != nil {
+		return nil, err
+	}
+	keyBytes, err := hex.DecodeString(data.IdentityKey)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid identity key", ErrInvalidAdvertisement)
+	}
+	identityKey, err := ec.PublicKeyFromBytes(keyBytes)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid identity key", ErrInvalidAdvertisement)
+	}
+	return &Advertisement{
+		Protocol:       data.Protocol,
+		IdentityKey:    identityKey,
+		Domain:         data.Domain,
+		TopicOrService: data.TopicOrService,
+	}, nil
+}
+
+// ParseAdvertisementOutput parses the advertisement in output outputIndex of a BEEF encoded transaction
+func ParseAdvertisementOutput(beef []byte, outputIndex uint32) (*Advertisement, error) {
+	parsed, err := (&lookup.OutputListItem{Beef: beef, OutputIndex: outputIndex}).Parse()
+	if err != nil {
+		return nil, err
+	}
+	ad, err := ParseAdvertisement(parsed.Output.LockingScript)
+	if err != nil {
+		return nil, err
+	}
+	ad.Beef = beef
+	ad.OutputIndex = outputIndex
+	return ad, nil
+}
+
+// Outpoint returns the outpoint of an advertisement found on the overlay
+func (ad *Advertisement) Outpoint() (*transaction.Outpoint, error) {
+	parsed, err := (&lookup.OutputListItem{Beef: ad.Beef, OutputIndex: ad.OutputIndex}).Parse()
+	if err != nil {
+		return nil, err
+	}
+	return parsed.Outpoint(), nil
+}
+
+func validateDomain(domain string) error {
+	u, err := url.Parse(domain)
+	if err != nil || (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" {
+		return fmt.Errorf("%w: domain %q must be an http(s) URL", ErrInvalidAdvertisement, domain)
+	}
+	return nil
+}
+
+func validateTopicOrService(protocol overlay.Protocol, topicOrService string) error {
+	var prefix string
+	switch protocol {
+	case overlay.ProtocolSHIP:
+		prefix = "tm_"
+	case overlay.ProtocolSLAP:
+		prefix = "ls_"
+	default:
+		return fmt.Errorf("%w: unsupported protocol %s", ErrInvalidAdvertisement, protocol)
+	}
+	name := strings.TrimPrefix(topicOrService, prefix)
+	if name == topicOrService || name == "" || len(topicOrService) > maxNameLength {
+		return fmt.Errorf("%w: %s name %q must start with %q and be at most %d characters", ErrInvalidAdvertisement, protocol, topicOrService, prefix, maxNameLength)
+	}
+	for _, r := range name {
+		if (r < 'a' || r > 'z') && r != '_' {
+			return fmt.Errorf("%w: %s name %q may only contain lowercase letters and underscores", ErrInvalidAdvertisement, protocol, topicOrService)
+		}
+	}
+	return nil
+}
+
+func protocolTopic(protocol overlay.Protocol) string {
+	if protocol == overlay.ProtocolSLAP {
+		return TopicSLAP
+	}
+	return TopicSHIP
+}
+
+func protocolService(protocol overlay.Protocol) string {
+	switch protocol {
+	case overlay.ProtocolSHIP:
+		return ServiceSHIP
+	case overlay.ProtocolSLAP:
+		return ServiceSLAP
+	default:
+		return ""
+	}
+}
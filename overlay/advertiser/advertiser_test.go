@@ -0,0 +1,149 @@
+package advertiser_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/bsv-blockchain/go-sdk/overlay"
+	"github.com/bsv-blockchain/go-sdk/overlay/advertiser"
+	"github.com/bsv-blockchain/go-sdk/overlay/lookup"
+	"github.com/bsv-blockchain/go-sdk/script"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+	"github.com/bsv-blockchain/go-sdk/wallet"
+	"github.com/stretchr/testify/require"
+)
+
+const testDomain = "https://overlay.example.com"
+
+// fundingWallet returns a test wallet whose CreateAction builds an unsigned transaction
+// with the requested outputs.
+func fundingWallet(t *testing.T) *wallet.TestWallet {
+	w := wallet.NewTestWalletForRandomKey(t)
+	w.OnCreateAction().Do(func(_ context.Context, args wallet.CreateActionArgs, _ string) (*wallet.CreateActionResult, error) {
+		tx := transaction.NewTransaction()
+		for _, output := range args.Outputs {
+			tx.AddOutput(&transaction.TransactionOutput{
+				Satoshis:      output.Satoshis,
+				LockingScript: script.NewFromBytes(output.LockingScript),
+			})
+		}
+		beef, err := tx.AtomicBEEF(true)
+		if err != nil {
+			return nil, err
+		}
+		return &wallet.CreateActionResult{Tx: beef}, nil
+	})
+	return w
+}
+
+func TestCreateAndParseAdvertisements(t *testing.T) {
+	w := fundingWallet(t)
+	a, err := advertiser.NewWalletAdvertiser(w, testDomain, "test")
+	require.NoError(t, err)
+
+	tagged, err := a.CreateAdvertisements(t.Context(), []advertiser.AdvertisementData{
+		{Protocol: overlay.ProtocolSHIP, TopicOrService: "tm_meter"},
+		{Protocol: overlay.ProtocolSLAP, TopicOrService: "ls_meter"},
+		{Protocol: overlay.ProtocolSHIP, TopicOrService: "tm_other"},
+	})
+	require.NoError(t, err)
+	require.Equal(t, []string{advertiser.TopicSHIP, advertiser.TopicSLAP}, tagged.Topics)
+
+	identity, err := w.GetPublicKey(t.Context(), wallet.GetPublicKeyArgs{IdentityKey: true}, "")
+	require.NoError(t, err)
+
+	expected := []struct {
+		protocol overlay.Protocol
+		name     string
+	}{
+		{overlay.ProtocolSHIP, "tm_meter"},
+		{overlay.ProtocolSLAP, "ls_meter"},
+		{overlay.ProtocolSHIP, "tm_other"},
+	}
+	for i, e := range expected {
+		ad, err := advertiser.ParseAdvertisementOutput(tagged.Beef, uint32(i))
+		require.NoError(t, err)
+		require.Equal(t, e.protocol, ad.Protocol)
+		require.Equal(t, e.name, ad.TopicOrService)
+		require.Equal(t, testDomain, ad.Domain)
+		require.True(t, identity.PublicKey.IsEqual(ad.IdentityKey))
+	}
+}
+
+func TestAdvertisementValidation(t *testing.T) {
+	w := fundingWallet(t)
+
+	_, err := advertiser.NewWalletAdvertiser(w, "overlay.example.com", "")
+	require.ErrorIs(t, err, advertiser.ErrInvalidAdvertisement)
+
+	a, err := advertiser.NewWalletAdvertiser(w, testDomain, "")
+	require.NoError(t, err)
+
+	_, err = a.CreateAdvertisements(t.Context(), nil)
+	require.ErrorIs(t, err, advertiser.ErrNoAdvertisements)
+
+	for _, ad := range []advertiser.AdvertisementData{
+		{Protocol: overlay.ProtocolSHIP, TopicOrService: "ls_wrong_prefix"},
+		{Protocol: overlay.ProtocolSLAP, TopicOrService: "tm_wrong_prefix"},
+		{Protocol: overlay.ProtocolSHIP, TopicOrService: "tm_Upper"},
+		{Protocol: overlay.ProtocolSHIP, TopicOrService: "tm_"},
+		{Protocol: "OTHER", TopicOrService: "tm_x"},
+	} {
+		_, err = a.CreateAdvertisements(t.Context(), []advertiser.AdvertisementData{ad})
+		require.ErrorIs(t, err, advertiser.ErrInvalidAdvertisement, "%s %s", ad.Protocol, ad.TopicOrService)
+	}
+
+	_, err = advertiser.ParseAdvertisement(&script.Script{script.OpTRUE})
+	require.ErrorIs(t, err, advertiser.ErrInvalidAdvertisement)
+}
+
+type mockFacilitator struct {
+	answer   *lookup.LookupAnswer
+	question *lookup.LookupQuestion
+}
+
+func (f *mockFacilitator) Lookup(_ context.Context, _ string, question *lookup.LookupQuestion) (*lookup.LookupAnswer, error) {
+	f.question = question
+	return f.answer, nil
+}
+
+func TestFindAllAdvertisements(t *testing.T) {
+	w := fundingWallet(t)
+	a, err := advertiser.NewWalletAdvertiser(w, testDomain, "")
+	require.NoError(t, err)
+
+	tagged, err := a.CreateAdvertisements(t.Context(), []advertiser.AdvertisementData{
+		{Protocol: overlay.ProtocolSHIP, TopicOrService: "tm_meter"},
+		{Protocol: overlay.ProtocolSLAP, TopicOrService: "ls_meter"},
+	})
+	require.NoError(t, err)
+
+	facilitator := &mockFacilitator{answer: &lookup.LookupAnswer{
+		Type: lookup.AnswerTypeOutputList,
+		Outputs: []*lookup.OutputListItem{
+			{Beef: tagged.Beef, OutputIndex: 0},
+			{Beef: tagged.Beef, OutputIndex: 1},
+		},
+	}}
+	a.Resolver = lookup.NewLookupResolver(&lookup.LookupResolver{
+		Facilitator:   facilitator,
+		HostOverrides: map[string][]string{advertiser.ServiceSHIP: {"https://tracker.example.com"}},
+	})
+
+	ads, err := a.FindAllAdvertisements(t.Context(), overlay.ProtocolSHIP)
+	require.NoError(t, err)
+	require.Len(t, ads, 1)
+	require.Equal(t, "tm_meter", ads[0].TopicOrService)
+	require.Equal(t, uint32(0), ads[0].OutputIndex)
+
+	outpoint, err := ads[0].Outpoint()
+	require.NoError(t, err)
+	require.Equal(t, uint32(0), outpoint.Index)
+
+	identity, err := w.GetPublicKey(t.Context(), wallet.GetPublicKeyArgs{IdentityKey: true}, "")
+	require.NoError(t, err)
+	var query map[string]string
+	require.NoError(t, json.Unmarshal(facilitator.question.Query, &query))
+	require.Equal(t, identity.PublicKey.ToDERHex(), query["identityKey"])
+}
@@ -0,0 +1,74 @@
+package lookup
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/bsv-blockchain/go-sdk/transaction"
+	"github.com/bsv-blockchain/go-sdk/transaction/chaintracker"
+)
+
+var (
+	ErrNotOutputList  = errors.New("lookup answer is not an output list")
+	ErrOutputNotFound = errors.New("output not found in BEEF")
+	ErrInvalidProof   = errors.New("BEEF merkle proofs are invalid")
+)
+
+// ParsedOutput is an output list item decoded from its BEEF
+type ParsedOutput struct {
+	Beef        *transaction.Beef
+	Tx          *transaction.Transaction
+	OutputIndex uint32
+	Output      *transaction.TransactionOutput
+}
+
+// Outpoint returns the outpoint of the parsed output
+func (p *ParsedOutput) Outpoint() *transaction.Outpoint {
+	return &transaction.Outpoint{Txid: *p.Tx.TxID(), Index: p.OutputIndex}
+}
+
+// Verify checks the merkle proofs in the output's BEEF against the chain tracker
+func (p *ParsedOutput) Verify(ctx context.Context, chainTracker chaintracker.ChainTracker) error {
+	valid, err := p.Beef.Verify(ctx, chainTracker, false)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidProof, err)
+	} else if !valid {
+		return ErrInvalidProof
+	}
+	return nil
+}
+
+// Parse decodes the item's BEEF and locates the referenced output
+func (o *OutputListItem) Parse() (*ParsedOutput, error) {
+	beef, tx, _, err := transaction.ParseBeef(o.Beef)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing BEEF: %w", err)
+	} else if tx == nil {
+		return nil, fmt.Errorf("%w: no transaction in BEEF", ErrOutputNotFound)
+	} else if int(o.OutputIndex) >= len(tx.Outputs) {
+		return nil, fmt.Errorf("%w: output index %d out of range", ErrOutputNotFound, o.OutputIndex)
+	}
+	return &ParsedOutput{
+		Beef:        beef,
+		Tx:          tx,
+		OutputIndex: o.OutputIndex,
+		Output:      tx.Outputs[o.OutputIndex],
+	}, nil
+}
+
+// ParseOutputs decodes every output of an output-list answer
+func (a *LookupAnswer) ParseOutputs() ([]*ParsedOutput, error) {
+	if a.Type != AnswerTypeOutputList {
+		return nil, ErrNotOutputList
+	}
+	parsed := make([]*ParsedOutput, 0, len(a.Outputs))
+	for i, output := range a.Outputs {
+		p, err := output.Parse()
+		if err != nil {
+			return nil, fmt.Errorf("output %d: %w", i, err)
+		}
+		parsed = append(parsed, p)
+	}
+	return parsed, nil
+}
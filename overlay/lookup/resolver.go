@@ -10,9 +10,10 @@ import (
 	"sync"
 	"time"
 
+	"github.com/bsv-blockchain/go-sdk/internal/logging"
 	"github.com/bsv-blockchain/go-sdk/overlay"
 	admintoken "github.com/bsv-blockchain/go-sdk/overlay/admin-token"
-	"github.com/bsv-blockchain/go-sdk/transaction"
+	"github.com/bsv-blockchain/go-sdk/transaction/chaintracker"
 )
 
 const MAX_TRACKER_WAIT_TIME = time.Second
@@ -27,6 +28,12 @@ type LookupResolver struct {
 	HostOverrides   map[string][]string
 	AdditionalHosts map[string][]string
 	NetworkPreset   overlay.Network
+	// ChainTracker, when set, is used to verify the merkle proofs of returned outputs.
+	// Outputs that fail verification are dropped from the answer.
+	ChainTracker chaintracker.ChainTracker
+	// Logger receives the errors of individual hosts and outputs that the resolver
+	// skips over, slog.Default when nil.
+	Logger *slog.Logger
 }
 
 // NewLookupResolver creates a new LookupResolver with the provided configuration
@@ -37,6 +44,8 @@ func NewLookupResolver(cfg *LookupResolver) *LookupResolver {
 		HostOverrides:   cfg.HostOverrides,
 		AdditionalHosts: cfg.AdditionalHosts,
 		NetworkPreset:   cfg.NetworkPreset,
+		ChainTracker:    cfg.ChainTracker,
+		Logger:          cfg.Logger,
 	}
 	if resolver.Facilitator == nil {
 		resolver.Facilitator = &HTTPSOverlayLookupFacilitator{
@@ -88,7 +97,7 @@ func (l *LookupResolver) Query(ctx context.Context, question *LookupQuestion) (*
 		go func(host string) {
 			defer wg.Done()
 			if answer, err := l.Facilitator.Lookup(ctx, host, question); err != nil {
-				slog.Error("Error querying host", "host", host, "error", err)
+				logging.OrDefault(l.Logger).ErrorContext(ctx, "Error querying host", "host", host, "error", err)
 			} else {
 				responses <- answer
 			}
@@ -118,13 +127,22 @@ func (l *LookupResolver) Query(ctx context.Context, question *LookupQuestion) (*
 			continue
 		}
 		for _, output := range response.Outputs {
-			if _, tx, _, err := transaction.ParseBeef(output.Beef); err != nil {
-				slog.Error(fmt.Sprintf("Error parsing BEEF: %v", err))
-			} else if tx == nil {
-				slog.Error(fmt.Sprintf("Error finding transaction for output index: %v", output.OutputIndex))
-			} else {
-				outputsMap[fmt.Sprintf("%s.%d", tx.TxID().String(), output.OutputIndex)] = output
+			parsed, err := output.Parse()
+			if err != nil {
+				logging.OrDefault(l.Logger).ErrorContext(ctx, "Error parsing output", "outputIndex", output.OutputIndex, "error", err)
+				continue
+			}
+			key := parsed.Outpoint().String()
+			if _, ok := outputsMap[key]; ok {
+				continue
+			}
+			if l.ChainTracker != nil {
+				if err := parsed.Verify(ctx, l.ChainTracker); err != nil {
+					logging.OrDefault(l.Logger).ErrorContext(ctx, "Dropping output with invalid proof", "outpoint", key, "error", err)
+					continue
+				}
 			}
+			outputsMap[key] = output
 		}
 	}
 	answer := &LookupAnswer{
@@ -137,6 +155,16 @@ func (l *LookupResolver) Query(ctx context.Context, question *LookupQuestion) (*
 	return answer, nil
 }
 
+// QueryOutputs executes a lookup question that is expected to return an output list and
+// returns the outputs decoded from their BEEF
+func (l *LookupResolver) QueryOutputs(ctx context.Context, question *LookupQuestion) ([]*ParsedOutput, error) {
+	answer, err := l.Query(ctx, question)
+	if err != nil {
+		return nil, err
+	}
+	return answer.ParseOutputs()
+}
+
 // FindCompetentHosts discovers overlay service hosts that can handle the specified service using SLAP trackers
 func (l *LookupResolver) FindCompetentHosts(ctx context.Context, service string) (competentHosts []string, err error) {
 	query := &LookupQuestion{
@@ -156,7 +184,7 @@ func (l *LookupResolver) FindCompetentHosts(ctx context.Context, service string)
 			defer cancel()
 
 			if answer, err := l.Facilitator.Lookup(ctxWithTimeout, url, query); err != nil {
-				slog.Error(fmt.Sprintf("Error querying tracker: %s %v", url, err))
+				logging.OrDefault(l.Logger).ErrorContext(ctx, "Error querying tracker", "tracker", url, "error", err)
 			} else {
 				responses <- answer
 			}
@@ -171,14 +199,10 @@ func (l *LookupResolver) FindCompetentHosts(ctx context.Context, service string)
 			continue
 		}
 		for _, output := range result.Outputs {
-			if _, tx, _, err := transaction.ParseBeef(output.Beef); err != nil {
-				slog.Error("Error parsing BEEF", "error", err)
-			} else if tx == nil {
-				slog.Error("No transaction found in BEEF")
-			} else if len(tx.Outputs) <= int(output.OutputIndex) {
-				slog.Error("Output index out of range", "outputIndex", output.OutputIndex)
+			if parsedOutput, err := output.Parse(); err != nil {
+				logging.OrDefault(l.Logger).ErrorContext(ctx, "Error parsing output", "outputIndex", output.OutputIndex, "error", err)
 			} else {
-				script := tx.Outputs[output.OutputIndex].LockingScript
+				script := parsedOutput.Output.LockingScript
 				if parsed := admintoken.Decode(script); parsed == nil || parsed.TopicOrService != service || parsed.Protocol != "SLAP" {
 					continue
 				} else if _, ok := hosts[parsed.Domain]; !ok {
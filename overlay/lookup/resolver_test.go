@@ -0,0 +1,99 @@
+package lookup_test
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"testing"
+
+	"github.com/bsv-blockchain/go-sdk/chainhash"
+	"github.com/bsv-blockchain/go-sdk/overlay/lookup"
+	"github.com/bsv-blockchain/go-sdk/script"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+	"github.com/stretchr/testify/require"
+)
+
+type mockFacilitator struct {
+	answers map[string]*lookup.LookupAnswer
+}
+
+func (f *mockFacilitator) Lookup(_ context.Context, url string, _ *lookup.LookupQuestion) (*lookup.LookupAnswer, error) {
+	return f.answers[url], nil
+}
+
+type mockChainTracker struct {
+	roots map[uint32]string
+}
+
+func (c *mockChainTracker) IsValidRootForHeight(_ context.Context, root *chainhash.Hash, height uint32) (bool, error) {
+	return c.roots[height] == root.String(), nil
+}
+
+func (c *mockChainTracker) CurrentHeight(context.Context) (uint32, error) {
+	return 1000, nil
+}
+
+// minedOutput returns an output list item for a tx mined at height, and the merkle root it was mined under.
+func minedOutput(t *testing.T, satoshis uint64, height uint32) (*lookup.OutputListItem, string) {
+	tx := transaction.NewTransaction()
+	tx.AddOutput(&transaction.TransactionOutput{Satoshis: satoshis, LockingScript: &script.Script{script.OpTRUE}})
+
+	isTxid := true
+	sibling := chainhash.DoubleHashH([]byte("sibling"))
+	tx.MerklePath = transaction.NewMerklePath(height, [][]*transaction.PathElement{{
+		{Offset: 0, Hash: tx.TxID(), Txid: &isTxid},
+		{Offset: 1, Hash: &sibling},
+	}})
+	root, err := tx.MerklePath.ComputeRoot(tx.TxID())
+	require.NoError(t, err)
+
+	beef, err := tx.BEEF()
+	require.NoError(t, err)
+	return &lookup.OutputListItem{Beef: beef, OutputIndex: 0}, root.String()
+}
+
+func TestOutputListItemParse(t *testing.T) {
+	item, _ := minedOutput(t, 42, 100)
+
+	parsed, err := item.Parse()
+	require.NoError(t, err)
+	require.Equal(t, uint64(42), parsed.Output.Satoshis)
+	require.Equal(t, parsed.Tx.TxID().String()+".0", parsed.Outpoint().String())
+
+	item.OutputIndex = 1
+	_, err = item.Parse()
+	require.ErrorIs(t, err, lookup.ErrOutputNotFound)
+
+	_, err = (&lookup.LookupAnswer{Type: lookup.AnswerTypeFreeform}).ParseOutputs()
+	require.ErrorIs(t, err, lookup.ErrNotOutputList)
+}
+
+func TestLookupResolverVerifiesProofs(t *testing.T) {
+	valid, validRoot := minedOutput(t, 1, 100)
+	invalid, _ := minedOutput(t, 2, 101)
+
+	facilitator := &mockFacilitator{answers: map[string]*lookup.LookupAnswer{
+		"https://a.example": {Type: lookup.AnswerTypeOutputList, Outputs: []*lookup.OutputListItem{valid}},
+		"https://b.example": {Type: lookup.AnswerTypeOutputList, Outputs: []*lookup.OutputListItem{valid, invalid}},
+	}}
+	var logs bytes.Buffer
+	resolver := lookup.NewLookupResolver(&lookup.LookupResolver{
+		Facilitator:   facilitator,
+		HostOverrides: map[string][]string{"ls_test": {"https://a.example", "https://b.example"}},
+		ChainTracker:  &mockChainTracker{roots: map[uint32]string{100: validRoot}},
+		Logger:        slog.New(slog.NewTextHandler(&logs, nil)),
+	})
+
+	outputs, err := resolver.QueryOutputs(t.Context(), &lookup.LookupQuestion{Service: "ls_test"})
+	require.NoError(t, err)
+	require.Len(t, outputs, 1, "duplicates are merged and outputs with invalid proofs dropped")
+	require.Equal(t, uint64(1), outputs[0].Output.Satoshis)
+	require.NoError(t, outputs[0].Verify(t.Context(), resolver.ChainTracker))
+	require.Contains(t, logs.String(), "Dropping output with invalid proof")
+
+	// Without a chain tracker, proofs are not checked.
+	resolver.ChainTracker = nil
+	outputs, err = resolver.QueryOutputs(t.Context(), &lookup.LookupQuestion{Service: "ls_test"})
+	require.NoError(t, err)
+	require.Len(t, outputs, 2)
+}
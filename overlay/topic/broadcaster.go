@@ -4,16 +4,19 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"log"
+	"log/slog"
 	"net/http"
 	"slices"
 	"strings"
 	"sync"
+	"time"
 
+	"github.com/bsv-blockchain/go-sdk/internal/logging"
 	"github.com/bsv-blockchain/go-sdk/overlay"
 	admintoken "github.com/bsv-blockchain/go-sdk/overlay/admin-token"
 	"github.com/bsv-blockchain/go-sdk/overlay/lookup"
 	"github.com/bsv-blockchain/go-sdk/transaction"
+	"github.com/bsv-blockchain/go-sdk/util"
 )
 
 // RequireAck specifies acknowledgment requirements for topic broadcasts
@@ -32,12 +35,35 @@ type AckFrom struct {
 	Topics     []string
 }
 
+// AckQuorum requires at least Hosts hosts to acknowledge the topics selected by RequireAck
+// (and Topics, for RequireAckSome). With RequireAckNone any host accepting the submission counts.
+type AckQuorum struct {
+	Hosts int
+	AckFrom
+}
+
 // Response represents the result of broadcasting to a specific overlay service host
 type Response struct {
 	Host    string
 	Success bool
 	Steak   *overlay.Steak
 	Error   error
+	// AcknowledgedTopics are the topics that admitted or retained outputs of the transaction
+	AcknowledgedTopics []string
+	Duration           time.Duration
+}
+
+// BroadcastResult is the structured outcome of a broadcast, including the response of every host
+type BroadcastResult struct {
+	Txid      string
+	Responses []*Response
+	// Failure is set when the broadcast did not satisfy the acknowledgment requirements
+	Failure *transaction.BroadcastFailure
+}
+
+// Succeeded reports whether the broadcast satisfied the acknowledgment requirements
+func (r *BroadcastResult) Succeeded() bool {
+	return r.Failure == nil
 }
 
 // BroadcasterConfig contains configuration options for creating a new Broadcaster
@@ -48,6 +74,15 @@ type BroadcasterConfig struct {
 	AckFromAll    *AckFrom
 	AckFromAny    *AckFrom
 	AckFromHost   map[string]AckFrom
+	AckQuorum     *AckQuorum
+	// HostTimeout bounds each host submission, MAX_SHIP_QUERY_TIMEOUT when zero
+	HostTimeout time.Duration
+	// Logger receives the diagnostics of the broadcaster and of its default
+	// resolver, slog.Default when nil
+	Logger *slog.Logger
+	// HTTPClient is used by the default facilitator and resolver, for example to
+	// route requests through a proxy. http.DefaultClient when nil
+	HTTPClient util.HTTPClient
 }
 
 // Broadcaster broadcasts transactions to overlay topics via SHIP (Service Host Interconnect Protocol)
@@ -58,7 +93,10 @@ type Broadcaster struct {
 	AckFromAll    AckFrom
 	AckFromAny    AckFrom
 	AckFromHost   map[string]AckFrom
+	AckQuorum     *AckQuorum
+	HostTimeout   time.Duration
 	NetworkPreset overlay.Network
+	Logger        *slog.Logger
 }
 
 // NewBroadcaster creates a new Broadcaster for the specified topics with the given configuration
@@ -75,15 +113,23 @@ func NewBroadcaster(topics []string, cfg *BroadcasterConfig) (*Broadcaster, erro
 		Topics:      topics,
 		Facilitator: cfg.Facilitator,
 	}
+	var client util.HTTPClient = http.DefaultClient
+	if cfg.HTTPClient != nil {
+		client = cfg.HTTPClient
+	}
 	if cfg.Facilitator == nil {
 		broadcaster.Facilitator = &HTTPSOverlayBroadcastFacilitator{
-			Client: http.DefaultClient,
+			Client: client,
 		}
 	}
 	if cfg.Resolver != nil {
 		broadcaster.Resolver = *cfg.Resolver
 	} else {
-		broadcaster.Resolver = *lookup.NewLookupResolver(&lookup.LookupResolver{})
+		broadcaster.Resolver = *lookup.NewLookupResolver(&lookup.LookupResolver{
+			Facilitator:   &lookup.HTTPSOverlayLookupFacilitator{Client: client},
+			NetworkPreset: cfg.NetworkPreset,
+			Logger:        cfg.Logger,
+		})
 	}
 	if cfg.AckFromAll != nil {
 		broadcaster.AckFromAll = *cfg.AckFromAll
@@ -100,6 +146,18 @@ func NewBroadcaster(topics []string, cfg *BroadcasterConfig) (*Broadcaster, erro
 	} else {
 		broadcaster.AckFromHost = make(map[string]AckFrom)
 	}
+	if cfg.AckQuorum != nil {
+		if cfg.AckQuorum.Hosts < 1 {
+			return nil, fmt.Errorf("ack quorum must require at least 1 host")
+		}
+		broadcaster.AckQuorum = cfg.AckQuorum
+	}
+	broadcaster.HostTimeout = cfg.HostTimeout
+	if broadcaster.HostTimeout <= 0 {
+		broadcaster.HostTimeout = MAX_SHIP_QUERY_TIMEOUT
+	}
+	broadcaster.NetworkPreset = cfg.NetworkPreset
+	broadcaster.Logger = cfg.Logger
 
 	return broadcaster, nil
 }
@@ -111,131 +169,197 @@ func (b *Broadcaster) Broadcast(tx *transaction.Transaction) (*transaction.Broad
 
 // BroadcastCtx broadcasts a transaction to the configured overlay topics using the provided context
 func (b *Broadcaster) BroadcastCtx(ctx context.Context, tx *transaction.Transaction) (*transaction.BroadcastSuccess, *transaction.BroadcastFailure) {
+	result := b.BroadcastWithResults(ctx, tx)
+	if result.Failure != nil {
+		return nil, result.Failure
+	}
+	successful := 0
+	for _, response := range result.Responses {
+		if response.Success {
+			successful++
+		}
+	}
+	return &transaction.BroadcastSuccess{
+		Txid:    result.Txid,
+		Message: fmt.Sprintf("Sent to %d Overlay Service host(s)", successful),
+	}, nil
+}
+
+// BroadcastWithResults broadcasts a transaction to the configured overlay topics and returns the
+// response of every interested host along with the outcome of the acknowledgment checks
+func (b *Broadcaster) BroadcastWithResults(ctx context.Context, tx *transaction.Transaction) *BroadcastResult {
+	result := &BroadcastResult{Txid: tx.TxID().String()}
 	taggedBeef := &overlay.TaggedBEEF{
 		Topics: b.Topics,
 	}
 	var err error
 	var interestedHosts []string
 	if taggedBeef.Beef, err = tx.AtomicBEEF(false); err != nil {
-		return nil, &transaction.BroadcastFailure{
+		result.Failure = &transaction.BroadcastFailure{
 			Code:        "400",
 			Description: err.Error(),
 		}
+		return result
 	} else if b.NetworkPreset == overlay.NetworkLocal {
 		interestedHosts = append(interestedHosts, "http://localhost:8080")
 	} else if interestedHosts, err = b.FindInterestedHosts(ctx); err != nil {
-		return nil, &transaction.BroadcastFailure{
+		result.Failure = &transaction.BroadcastFailure{
 			Code:        "500",
 			Description: err.Error(),
 		}
+		return result
 	}
 
 	if len(interestedHosts) == 0 {
-		return nil, &transaction.BroadcastFailure{
+		result.Failure = &transaction.BroadcastFailure{
 			Code:        "ERR_NO_HOSTS_INTERESTED",
 			Description: fmt.Sprintf("No %s hosts are interested in receiving this transaction.", overlay.NetworkNames[b.NetworkPreset]),
 		}
+		return result
 	}
+
+	result.Responses = make([]*Response, len(interestedHosts))
 	var wg sync.WaitGroup
-	results := make(chan *Response, len(interestedHosts))
-	for _, host := range interestedHosts {
+	for i, host := range interestedHosts {
 		wg.Add(1)
-		go func(host string) {
+		go func(i int, host string) {
 			defer wg.Done()
-			if steak, err := b.Facilitator.Send(host, taggedBeef); err != nil {
-				results <- &Response{
-					Host:  host,
-					Error: err,
-				}
-			} else {
-				results <- &Response{
-					Host:    host,
-					Success: true,
-					Steak:   steak,
-				}
-			}
-		}(host)
+			result.Responses[i] = b.sendToHost(ctx, host, taggedBeef)
+		}(i, host)
 	}
 	wg.Wait()
 
-	successfulHosts := make([]*Response, 0, len(interestedHosts))
-	for result := range results {
-		if result != nil {
-			successfulHosts = append(successfulHosts, result)
+	hostAcks := make(map[string]map[string]struct{})
+	for _, response := range result.Responses {
+		if !response.Success {
+			continue
 		}
+		ackTopics := make(map[string]struct{})
+		for _, topic := range response.AcknowledgedTopics {
+			ackTopics[topic] = struct{}{}
+		}
+		hostAcks[response.Host] = ackTopics
 	}
-	if len(successfulHosts) == 0 {
-		return nil, &transaction.BroadcastFailure{
+	if len(hostAcks) == 0 {
+		result.Failure = &transaction.BroadcastFailure{
 			Code:        "ERR_ALL_HOSTS_REJECTED",
-			Description: fmt.Sprintf("`All %s topical hosts have rejected the transaction.", overlay.NetworkNames[b.NetworkPreset]),
+			Description: fmt.Sprintf("All %s topical hosts have rejected the transaction.", overlay.NetworkNames[b.NetworkPreset]),
 		}
+		return result
 	}
-	hostAcks := make(map[string]map[string]struct{})
-	for _, result := range successfulHosts {
-		ackTopics := make(map[string]struct{})
-		for topic, admittance := range *result.Steak {
-			if len(admittance.OutputsToAdmit) > 0 || len(admittance.CoinsToRetain) > 0 || len(admittance.CoinsRemoved) > 0 {
-				ackTopics[topic] = struct{}{}
-			}
-		}
-		hostAcks[result.Host] = ackTopics
+	result.Failure = b.checkAcknowledgments(hostAcks)
+	return result
+}
+
+// sendToHost submits the tagged BEEF to a single host, bounded by the host timeout
+func (b *Broadcaster) sendToHost(ctx context.Context, host string, taggedBeef *overlay.TaggedBEEF) *Response {
+	timeout := b.HostTimeout
+	if timeout <= 0 {
+		timeout = MAX_SHIP_QUERY_TIMEOUT
 	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
 
-	var requireTopics []string
-	var requireHosts RequireAck
-	switch b.AckFromAll.RequireAck {
-	case RequireAckAny:
-		requireTopics = b.Topics
-		requireHosts = RequireAckAny
-	case RequireAckSome:
-		requireTopics = b.AckFromAll.Topics
-		requireHosts = RequireAckAll
-	default:
-		requireTopics = b.Topics
-		requireHosts = RequireAckAll
+	start := time.Now()
+	var steak *overlay.Steak
+	var err error
+	if facilitator, ok := b.Facilitator.(ContextFacilitator); ok {
+		steak, err = facilitator.SendCtx(ctx, host, taggedBeef)
+	} else {
+		type sendResult struct {
+			steak *overlay.Steak
+			err   error
+		}
+		done := make(chan sendResult, 1)
+		go func() {
+			steak, err := b.Facilitator.Send(host, taggedBeef)
+			done <- sendResult{steak, err}
+		}()
+		select {
+		case r := <-done:
+			steak, err = r.steak, r.err
+		case <-ctx.Done():
+			err = ctx.Err()
+		}
+	}
+	response := &Response{
+		Host:     host,
+		Duration: time.Since(start),
+	}
+	if err == nil && steak == nil {
+		err = fmt.Errorf("host returned no STEAK")
+	}
+	if err != nil {
+		logging.OrDefault(b.Logger).DebugContext(ctx, "Overlay host rejected submission", "host", host, "error", err)
+		response.Error = err
+		return response
+	}
+	response.Success = true
+	response.Steak = steak
+	for topic, admittance := range *steak {
+		if admittance != nil && (len(admittance.OutputsToAdmit) > 0 || len(admittance.CoinsToRetain) > 0 || len(admittance.CoinsRemoved) > 0) {
+			response.AcknowledgedTopics = append(response.AcknowledgedTopics, topic)
+		}
 	}
-	if len(requireTopics) > 0 {
+	slices.Sort(response.AcknowledgedTopics)
+	return response
+}
+
+// checkAcknowledgments applies the broadcaster's acknowledgment requirements to the topics
+// acknowledged by each successful host
+func (b *Broadcaster) checkAcknowledgments(hostAcks map[string]map[string]struct{}) *transaction.BroadcastFailure {
+	if requireTopics, requireHosts := b.requirement(b.AckFromAll); len(requireTopics) > 0 {
 		if !b.checkAcknowledgmentFromAllHosts(hostAcks, requireTopics, requireHosts) {
-			return nil, &transaction.BroadcastFailure{
+			return &transaction.BroadcastFailure{
 				Code:        "ERR_REQUIRE_ACK_FROM_ALL_HOSTS_FAILED",
 				Description: "Not all hosts acknowledged the required topics.",
 			}
 		}
 	}
 
-	switch b.AckFromAny.RequireAck {
-	case RequireAckAny:
-		requireTopics = b.Topics
-		requireHosts = RequireAckAny
-	case RequireAckSome:
-		requireTopics = b.AckFromAny.Topics
-		requireHosts = RequireAckAll
-	default:
-		requireTopics = b.Topics
-		requireHosts = RequireAckAll
-	}
-	if len(requireTopics) > 0 {
+	if requireTopics, requireHosts := b.requirement(b.AckFromAny); len(requireTopics) > 0 {
 		if !b.checkAcknowledgmentFromAnyHost(hostAcks, requireTopics, requireHosts) {
-			return nil, &transaction.BroadcastFailure{
+			return &transaction.BroadcastFailure{
 				Code:        "ERR_REQUIRE_ACK_FROM_ANY_HOST_FAILED",
 				Description: "No host acknowledged the required topics.",
 			}
 		}
 	}
 
+	if b.AckQuorum != nil {
+		requireTopics, requireHosts := b.requirement(b.AckQuorum.AckFrom)
+		if acked := b.countAcknowledgingHosts(hostAcks, requireTopics, requireHosts); acked < b.AckQuorum.Hosts {
+			return &transaction.BroadcastFailure{
+				Code:        "ERR_REQUIRE_ACK_QUORUM_FAILED",
+				Description: fmt.Sprintf("Only %d of the required %d hosts acknowledged the required topics.", acked, b.AckQuorum.Hosts),
+			}
+		}
+	}
+
 	if len(b.AckFromHost) > 0 {
 		if !b.checkAcknowledgmentFromSpecificHosts(hostAcks, b.AckFromHost) {
-			return nil, &transaction.BroadcastFailure{
+			return &transaction.BroadcastFailure{
 				Code:        "ERR_REQUIRE_ACK_FROM_SPECIFIC_HOSTS_FAILED",
 				Description: "Specific hosts did not acknowledge the required topics.",
 			}
 		}
 	}
+	return nil
+}
 
-	return &transaction.BroadcastSuccess{
-		Txid:    tx.TxID().String(),
-		Message: fmt.Sprintf("Sent to %d Overlay Service host(s)", len(successfulHosts)),
-	}, nil
+// requirement resolves an AckFrom into the topics to check and whether all or any of them
+// must be acknowledged. RequireAckNone yields no topics, disabling the check.
+func (b *Broadcaster) requirement(ack AckFrom) ([]string, RequireAck) {
+	switch ack.RequireAck {
+	case RequireAckAny:
+		return b.Topics, RequireAckAny
+	case RequireAckSome:
+		return ack.Topics, RequireAckAll
+	case RequireAckAll:
+		return b.Topics, RequireAckAll
+	default:
+		return nil, RequireAckNone
+	}
 }
 
 // FindInterestedHosts discovers overlay service hosts that are interested in the broadcaster's topics
@@ -265,12 +389,19 @@ func (b *Broadcaster) FindInterestedHosts(ctx context.Context) ([]string, error)
 	for _, output := range answer.Outputs {
 		tx, err := transaction.NewTransactionFromBEEF(output.Beef)
 		if err != nil {
+			logging.OrDefault(b.Logger).DebugContext(ctx, "Skipping SHIP advertisement with invalid BEEF", "error", err)
+			continue
+		}
+		if int(output.OutputIndex) >= len(tx.Outputs) {
+			logging.OrDefault(b.Logger).DebugContext(ctx, "Skipping SHIP advertisement with invalid output index",
+				"txid", tx.TxID().String(), "outputIndex", output.OutputIndex)
 			continue
 		}
 		script := tx.Outputs[output.OutputIndex].LockingScript
 		parsed := admintoken.Decode(script)
 		if parsed == nil {
-			log.Println(err)
+			logging.OrDefault(b.Logger).DebugContext(ctx, "Skipping undecodable SHIP advertisement",
+				"txid", tx.TxID().String(), "outputIndex", output.OutputIndex)
 			continue
 		} else if !slices.Contains(b.Topics, parsed.TopicOrService) || parsed.Protocol != "SHIP" {
 			continue
@@ -286,6 +417,16 @@ func (b *Broadcaster) FindInterestedHosts(ctx context.Context) ([]string, error)
 	return interestedHosts, nil
 }
 
+func (t *Broadcaster) countAcknowledgingHosts(hostAcks map[string]map[string]struct{}, topics []string, requireHost RequireAck) int {
+	count := 0
+	for _, acknowledgedTopics := range hostAcks {
+		if t.checkAcknowledgmentFromAllHosts(map[string]map[string]struct{}{"": acknowledgedTopics}, topics, requireHost) {
+			count++
+		}
+	}
+	return count
+}
+
 func (t *Broadcaster) checkAcknowledgmentFromAllHosts(hostAcks map[string]map[string]struct{}, topics []string, requireHost RequireAck) bool {
 	for _, acknowledgedTopics := range hostAcks {
 		if requireHost == RequireAckAll {
@@ -311,11 +452,12 @@ func (t *Broadcaster) checkAcknowledgmentFromAllHosts(hostAcks map[string]map[st
 }
 
 func (t *Broadcaster) checkAcknowledgmentFromAnyHost(hostAcks map[string]map[string]struct{}, topics []string, requireHost RequireAck) bool {
+hosts:
 	for _, acknowledgedTopics := range hostAcks {
 		if requireHost == RequireAckAll {
 			for _, topic := range topics {
 				if _, ok := acknowledgedTopics[topic]; !ok {
-					return false
+					continue hosts
 				}
 			}
 			return true
@@ -0,0 +1,202 @@
+package topic_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/bsv-blockchain/go-sdk/overlay"
+	"github.com/bsv-blockchain/go-sdk/overlay/advertiser"
+	"github.com/bsv-blockchain/go-sdk/overlay/lookup"
+	"github.com/bsv-blockchain/go-sdk/overlay/topic"
+	"github.com/bsv-blockchain/go-sdk/script"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+	"github.com/bsv-blockchain/go-sdk/wallet"
+	"github.com/stretchr/testify/require"
+)
+
+const testTopic = "tm_test"
+
+// shipResolver returns a resolver whose SHIP lookups advertise testTopic on the given hosts.
+func shipResolver(t *testing.T, hosts ...string) *lookup.LookupResolver {
+	w := wallet.NewTestWalletForRandomKey(t)
+	w.OnCreateAction().Do(func(_ context.Context, args wallet.CreateActionArgs, _ string) (*wallet.CreateActionResult, error) {
+		tx := transaction.NewTransaction()
+		for _, output := range args.Outputs {
+			tx.AddOutput(&transaction.TransactionOutput{Satoshis: output.Satoshis, LockingScript: script.NewFromBytes(output.LockingScript)})
+		}
+		beef, err := tx.AtomicBEEF(true)
+		return &wallet.CreateActionResult{Tx: beef}, err
+	})
+
+	answer := &lookup.LookupAnswer{Type: lookup.AnswerTypeOutputList}
+	for _, host := range hosts {
+		a, err := advertiser.NewWalletAdvertiser(w, host, "")
+		require.NoError(t, err)
+		tagged, err := a.CreateAdvertisements(t.Context(), []advertiser.AdvertisementData{{Protocol: overlay.ProtocolSHIP, TopicOrService: testTopic}})
+		require.NoError(t, err)
+		answer.Outputs = append(answer.Outputs, &lookup.OutputListItem{Beef: tagged.Beef})
+	}
+	return lookup.NewLookupResolver(&lookup.LookupResolver{
+		Facilitator:   lookupFacilitator{answer},
+		HostOverrides: map[string][]string{"ls_ship": {"https://tracker.example.com"}},
+	})
+}
+
+type lookupFacilitator struct {
+	answer *lookup.LookupAnswer
+}
+
+func (f lookupFacilitator) Lookup(context.Context, string, *lookup.LookupQuestion) (*lookup.LookupAnswer, error) {
+	return f.answer, nil
+}
+
+// hostBehaviour is how a mock host responds to a submission.
+type hostBehaviour int
+
+const (
+	hostAdmits hostBehaviour = iota
+	hostIgnores
+	hostFails
+	hostHangs
+)
+
+type submitFacilitator struct {
+	hosts map[string]hostBehaviour
+}
+
+func (f *submitFacilitator) Send(url string, taggedBEEF *overlay.TaggedBEEF) (*overlay.Steak, error) {
+	return f.SendCtx(context.Background(), url, taggedBEEF)
+}
+
+func (f *submitFacilitator) SendCtx(ctx context.Context, url string, taggedBEEF *overlay.TaggedBEEF) (*overlay.Steak, error) {
+	switch f.hosts[url] {
+	case hostAdmits:
+		return &overlay.Steak{testTopic: {OutputsToAdmit: []uint32{0}}}, nil
+	case hostIgnores:
+		return &overlay.Steak{testTopic: {}}, nil
+	case hostHangs:
+		<-ctx.Done()
+		return nil, ctx.Err()
+	default:
+		return nil, errors.New("rejected")
+	}
+}
+
+func testTx() *transaction.Transaction {
+	tx := transaction.NewTransaction()
+	tx.AddOutput(&transaction.TransactionOutput{Satoshis: 1, LockingScript: &script.Script{script.OpTRUE}})
+	return tx
+}
+
+func newBroadcaster(t *testing.T, hosts map[string]hostBehaviour, cfg topic.BroadcasterConfig) *topic.Broadcaster {
+	names := make([]string, 0, len(hosts))
+	for host := range hosts {
+		names = append(names, host)
+	}
+	cfg.Resolver = shipResolver(t, names...)
+	cfg.Facilitator = &submitFacilitator{hosts: hosts}
+	b, err := topic.NewBroadcaster([]string{testTopic}, &cfg)
+	require.NoError(t, err)
+	return b
+}
+
+func TestBroadcasterResults(t *testing.T) {
+	hosts := map[string]hostBehaviour{
+		"https://a.example.com": hostAdmits,
+		"https://b.example.com": hostIgnores,
+		"https://c.example.com": hostFails,
+	}
+	b := newBroadcaster(t, hosts, topic.BroadcasterConfig{})
+
+	result := b.BroadcastWithResults(t.Context(), testTx())
+	require.True(t, result.Succeeded(), "by default one host acknowledging all topics is enough")
+	require.Len(t, result.Responses, 3)
+	for _, response := range result.Responses {
+		switch hosts[response.Host] {
+		case hostAdmits:
+			require.True(t, response.Success)
+			require.Equal(t, []string{testTopic}, response.AcknowledgedTopics)
+		case hostIgnores:
+			require.True(t, response.Success)
+			require.Empty(t, response.AcknowledgedTopics)
+		case hostFails:
+			require.False(t, response.Success)
+			require.Error(t, response.Error)
+		}
+	}
+
+	success, failure := b.BroadcastCtx(t.Context(), testTx())
+	require.Nil(t, failure)
+	require.Equal(t, "Sent to 2 Overlay Service host(s)", success.Message)
+}
+
+func TestBroadcasterAckPolicies(t *testing.T) {
+	hosts := map[string]hostBehaviour{
+		"https://a.example.com": hostAdmits,
+		"https://b.example.com": hostAdmits,
+		"https://c.example.com": hostIgnores,
+	}
+	tests := map[string]struct {
+		cfg  topic.BroadcasterConfig
+		code string
+	}{
+		"all hosts": {
+			cfg:  topic.BroadcasterConfig{AckFromAll: &topic.AckFrom{RequireAck: topic.RequireAckAll}},
+			code: "ERR_REQUIRE_ACK_FROM_ALL_HOSTS_FAILED",
+		},
+		"any host": {
+			cfg: topic.BroadcasterConfig{AckFromAny: &topic.AckFrom{RequireAck: topic.RequireAckAny}},
+		},
+		"quorum met": {
+			cfg: topic.BroadcasterConfig{AckQuorum: &topic.AckQuorum{Hosts: 2, AckFrom: topic.AckFrom{RequireAck: topic.RequireAckAll}}},
+		},
+		"quorum not met": {
+			cfg:  topic.BroadcasterConfig{AckQuorum: &topic.AckQuorum{Hosts: 3, AckFrom: topic.AckFrom{RequireAck: topic.RequireAckAll}}},
+			code: "ERR_REQUIRE_ACK_QUORUM_FAILED",
+		},
+		"quorum of accepting hosts": {
+			cfg: topic.BroadcasterConfig{AckQuorum: &topic.AckQuorum{Hosts: 3}},
+		},
+		"specific host": {
+			cfg: topic.BroadcasterConfig{AckFromHost: map[string]topic.AckFrom{
+				"https://c.example.com": {RequireAck: topic.RequireAckSome, Topics: []string{testTopic}},
+			}},
+			code: "ERR_REQUIRE_ACK_FROM_SPECIFIC_HOSTS_FAILED",
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			result := newBroadcaster(t, hosts, test.cfg).BroadcastWithResults(t.Context(), testTx())
+			if test.code == "" {
+				require.True(t, result.Succeeded(), "%v", result.Failure)
+			} else {
+				require.NotNil(t, result.Failure)
+				require.Equal(t, test.code, result.Failure.Code)
+			}
+		})
+	}
+
+	_, err := topic.NewBroadcaster([]string{testTopic}, &topic.BroadcasterConfig{AckQuorum: &topic.AckQuorum{}})
+	require.Error(t, err)
+}
+
+func TestBroadcasterHostTimeout(t *testing.T) {
+	hosts := map[string]hostBehaviour{
+		"https://a.example.com":    hostAdmits,
+		"https://slow.example.com": hostHangs,
+	}
+	b := newBroadcaster(t, hosts, topic.BroadcasterConfig{HostTimeout: 50 * time.Millisecond})
+
+	start := time.Now()
+	result := b.BroadcastWithResults(t.Context(), testTx())
+	require.Less(t, time.Since(start), time.Second)
+	require.True(t, result.Succeeded())
+	for _, response := range result.Responses {
+		if response.Host == "https://slow.example.com" {
+			require.ErrorIs(t, response.Error, context.DeadlineExceeded, fmt.Sprint(response))
+		}
+	}
+}
@@ -24,12 +24,23 @@ type HTTPSOverlayBroadcastFacilitator struct {
 	Client util.HTTPClient
 }
 
+// ContextFacilitator is a Facilitator that honours a context, which the Broadcaster uses
+// to apply per-host timeouts
+type ContextFacilitator interface {
+	Facilitator
+	SendCtx(ctx context.Context, url string, taggedBEEF *overlay.TaggedBEEF) (*overlay.Steak, error)
+}
+
 // Send broadcasts a tagged BEEF transaction to the specified overlay service URL and returns the STEAK response
 func (f *HTTPSOverlayBroadcastFacilitator) Send(url string, taggedBEEF *overlay.TaggedBEEF) (*overlay.Steak, error) {
 	timeoutContext, cancel := context.WithTimeout(context.Background(), MAX_SHIP_QUERY_TIMEOUT)
 	defer cancel()
+	return f.SendCtx(timeoutContext, url, taggedBEEF)
+}
 
-	req, err := http.NewRequestWithContext(timeoutContext, "POST", url+"/submit", bytes.NewBuffer(taggedBEEF.Beef))
+// SendCtx broadcasts a tagged BEEF transaction to the specified overlay service URL using the provided context
+func (f *HTTPSOverlayBroadcastFacilitator) SendCtx(ctx context.Context, url string, taggedBEEF *overlay.TaggedBEEF) (*overlay.Steak, error) {
+	req, err := http.NewRequestWithContext(ctx, "POST", url+"/submit", bytes.NewBuffer(taggedBEEF.Beef))
 	if err != nil {
 		return nil, err
 	}
@@ -46,7 +57,7 @@ func (f *HTTPSOverlayBroadcastFacilitator) Send(url string, taggedBEEF *overlay.
 		if resp.StatusCode != http.StatusOK {
 			return nil, &util.HTTPError{
 				StatusCode: resp.StatusCode,
-				Err:        errors.New("lookup failed"),
+				Err:        errors.New("submit failed"),
 			}
 		}
 		steak := &overlay.Steak{}
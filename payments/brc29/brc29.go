@@ -0,0 +1,178 @@
+// Package brc29 implements the BRC-29 simple payment protocol. A sender derives a
+// one-time P2PKH output for the recipient from both parties' identity keys and a
+// random derivation prefix and suffix, and hands the recipient the remittance data
+// needed to recognize and internalize the output into their wallet.
+//
+// Key IDs are built from the base64 encoded prefix and suffix separated by a space,
+// matching the TypeScript SDK and wallet toolbox so payments interoperate.
+package brc29
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+
+	ec "github.com/bsv-blockchain/go-sdk/primitives/ec"
+	"github.com/bsv-blockchain/go-sdk/script"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+	"github.com/bsv-blockchain/go-sdk/transaction/template/p2pkh"
+	"github.com/bsv-blockchain/go-sdk/wallet"
+)
+
+// ProtocolName is the BRC-29 protocol identifier used for key derivation.
+const ProtocolName = "3241645161d8"
+
+// DerivationLength is the number of random bytes used for generated derivation
+// prefixes and suffixes.
+const DerivationLength = 8
+
+// Protocol is the wallet protocol used to derive BRC-29 payment keys.
+var Protocol = wallet.Protocol{
+	SecurityLevel: wallet.SecurityLevelEveryAppAndCounterparty,
+	Protocol:      ProtocolName,
+}
+
+var (
+	ErrInvalidRemittance = errors.New("invalid payment remittance")
+	ErrOutputMismatch    = errors.New("output does not match payment remittance")
+)
+
+// Payment is a BRC-29 payment output prepared by a sender.
+type Payment struct {
+	// Remittance is sent to the recipient alongside the transaction.
+	Remittance wallet.Payment
+	// LockingScript is the P2PKH script paying the recipient.
+	LockingScript *script.Script
+}
+
+// KeyID returns the key ID for a derivation prefix and suffix.
+func KeyID(prefix, suffix []byte) string {
+	return base64.StdEncoding.EncodeToString(prefix) + " " + base64.StdEncoding.EncodeToString(suffix)
+}
+
+// NewDerivation returns a random derivation prefix and suffix.
+func NewDerivation() (prefix []byte, suffix []byte, err error) {
+	b := make([]byte, 2*DerivationLength)
+	if _, err := rand.Read(b); err != nil {
+		return nil, nil, fmt.Errorf("failed to generate derivation: %w", err)
+	}
+	return b[:DerivationLength], b[DerivationLength:], nil
+}
+
+// NewPayment prepares a payment to recipient using a fresh random derivation. The
+// sender's identity key is recorded in the remittance.
+func NewPayment(ctx context.Context, sender wallet.KeyOperations, recipient *ec.PublicKey, originator string) (*Payment, error) {
+	identity, err := sender.GetPublicKey(ctx, wallet.GetPublicKeyArgs{IdentityKey: true}, originator)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get sender identity key: %w", err)
+	}
+	prefix, suffix, err := NewDerivation()
+	if err != nil {
+		return nil, err
+	}
+	payment := &Payment{
+		Remittance: wallet.Payment{
+			DerivationPrefix:  prefix,
+			DerivationSuffix:  suffix,
+			SenderIdentityKey: identity.PublicKey,
+		},
+	}
+	if payment.LockingScript, err = LockingScript(ctx, sender, recipient, &payment.Remittance, originator); err != nil {
+		return nil, err
+	}
+	return payment, nil
+}
+
+// LockingScript derives the P2PKH locking script paying recipient for the given
+// remittance, from the sender's side.
+func LockingScript(ctx context.Context, sender wallet.KeyOperations, recipient *ec.PublicKey, remittance *wallet.Payment, originator string) (*script.Script, error) {
+	if recipient == nil {
+		return nil, fmt.Errorf("%w: missing recipient", ErrInvalidRemittance)
+	}
+	return lockingScript(ctx, sender, recipient, false, remittance, originator)
+}
+
+// ReceiverLockingScript derives the P2PKH locking script a remittance pays to, from
+// the recipient's side.
+func ReceiverLockingScript(ctx context.Context, recipient wallet.KeyOperations, remittance *wallet.Payment, originator string) (*script.Script, error) {
+	if remittance == nil || remittance.SenderIdentityKey == nil {
+		return nil, fmt.Errorf("%w: missing sender identity key", ErrInvalidRemittance)
+	}
+	return lockingScript(ctx, recipient, remittance.SenderIdentityKey, true, remittance, originator)
+}
+
+func lockingScript(ctx context.Context, w wallet.KeyOperations, counterparty *ec.PublicKey, forSelf bool, remittance *wallet.Payment, originator string) (*script.Script, error) {
+	if remittance == nil || len(remittance.DerivationPrefix) == 0 || len(remittance.DerivationSuffix) == 0 {
+		return nil, fmt.Errorf("%w: missing derivation prefix or suffix", ErrInvalidRemittance)
+	}
+	key, err := w.GetPublicKey(ctx, wallet.GetPublicKeyArgs{
+		EncryptionArgs: wallet.EncryptionArgs{
+			ProtocolID: Protocol,
+			KeyID:      KeyID(remittance.DerivationPrefix, remittance.DerivationSuffix),
+			Counterparty: wallet.Counterparty{
+				Type:         wallet.CounterpartyTypeOther,
+				Counterparty: counterparty,
+			},
+		},
+		ForSelf: &forSelf,
+	}, originator)
+	if err != nil {
+		return nil, fmt.Errorf("failed to derive payment key: %w", err)
+	}
+	// The locking script only commits to the key hash so the network is irrelevant here.
+	address, err := script.NewAddressFromPublicKey(key.PublicKey, true)
+	if err != nil {
+		return nil, err
+	}
+	return p2pkh.Lock(address)
+}
+
+// InternalizeArgs builds the arguments to internalize a BRC-29 payment output of
+// the BEEF encoded tx into the recipient's wallet.
+func InternalizeArgs(tx []byte, outputIndex uint32, remittance *wallet.Payment, description string) wallet.InternalizeActionArgs {
+	return wallet.InternalizeActionArgs{
+		Tx:          tx,
+		Description: description,
+		Outputs: []wallet.InternalizeOutput{{
+			OutputIndex:       outputIndex,
+			Protocol:          wallet.InternalizeProtocolWalletPayment,
+			PaymentRemittance: remittance,
+		}},
+	}
+}
+
+// Internalize checks that the output of the BEEF encoded tx pays the key derived
+// from remittance and then internalizes it into the recipient's wallet.
+func Internalize(ctx context.Context, recipient wallet.Interface, tx []byte, outputIndex uint32, remittance *wallet.Payment, description string, originator string) (*wallet.InternalizeActionResult, error) {
+	if err := VerifyOutput(ctx, recipient, tx, outputIndex, remittance, originator); err != nil {
+		return nil, err
+	}
+	result, err := recipient.InternalizeAction(ctx, InternalizeArgs(tx, outputIndex, remittance, description), originator)
+	if err != nil {
+		return nil, fmt.Errorf("failed to internalize payment: %w", err)
+	}
+	return result, nil
+}
+
+// VerifyOutput checks that the output of the BEEF encoded tx pays the key the
+// recipient derives from remittance.
+func VerifyOutput(ctx context.Context, recipient wallet.KeyOperations, tx []byte, outputIndex uint32, remittance *wallet.Payment, originator string) error {
+	parsed, err := transaction.NewTransactionFromBEEF(tx)
+	if err != nil {
+		return fmt.Errorf("failed to parse payment transaction: %w", err)
+	}
+	if parsed == nil || int(outputIndex) >= len(parsed.Outputs) {
+		return fmt.Errorf("%w: output %d does not exist", ErrOutputMismatch, outputIndex)
+	}
+	expected, err := ReceiverLockingScript(ctx, recipient, remittance, originator)
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(parsed.Outputs[outputIndex].LockingScript.Bytes(), expected.Bytes()) {
+		return fmt.Errorf("%w: output %d", ErrOutputMismatch, outputIndex)
+	}
+	return nil
+}
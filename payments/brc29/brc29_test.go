@@ -0,0 +1,81 @@
+package brc29_test
+
+import (
+	"context"
+	"encoding/base64"
+	"testing"
+
+	"github.com/bsv-blockchain/go-sdk/payments/brc29"
+	ec "github.com/bsv-blockchain/go-sdk/primitives/ec"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+	"github.com/bsv-blockchain/go-sdk/wallet"
+	"github.com/stretchr/testify/require"
+)
+
+func identityKey(t *testing.T, w wallet.KeyOperations) *ec.PublicKey {
+	t.Helper()
+	res, err := w.GetPublicKey(t.Context(), wallet.GetPublicKeyArgs{IdentityKey: true}, "")
+	require.NoError(t, err)
+	return res.PublicKey
+}
+
+func TestKeyID(t *testing.T) {
+	prefix, suffix, err := brc29.NewDerivation()
+	require.NoError(t, err)
+	require.Len(t, prefix, brc29.DerivationLength)
+	require.Len(t, suffix, brc29.DerivationLength)
+	require.Equal(t,
+		base64.StdEncoding.EncodeToString(prefix)+" "+base64.StdEncoding.EncodeToString(suffix),
+		brc29.KeyID(prefix, suffix))
+}
+
+func TestPaymentRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	sender := wallet.NewTestWalletForRandomKey(t)
+	recipient := wallet.NewTestWalletForRandomKey(t)
+
+	payment, err := brc29.NewPayment(ctx, sender, identityKey(t, recipient), "")
+	require.NoError(t, err)
+	require.True(t, payment.Remittance.SenderIdentityKey.IsEqual(identityKey(t, sender)))
+
+	received, err := brc29.ReceiverLockingScript(ctx, recipient, &payment.Remittance, "")
+	require.NoError(t, err)
+	require.Equal(t, payment.LockingScript.Bytes(), received.Bytes())
+
+	tx := transaction.NewTransaction()
+	tx.AddOutput(&transaction.TransactionOutput{Satoshis: 1000, LockingScript: payment.LockingScript})
+	beef, err := tx.AtomicBEEF(true)
+	require.NoError(t, err)
+
+	var internalized wallet.InternalizeActionArgs
+	recipient.OnInternalizeAction().Do(func(_ context.Context, args wallet.InternalizeActionArgs, _ string) (*wallet.InternalizeActionResult, error) {
+		internalized = args
+		return &wallet.InternalizeActionResult{Accepted: true}, nil
+	})
+
+	result, err := brc29.Internalize(ctx, recipient, beef, 0, &payment.Remittance, "incoming payment", "")
+	require.NoError(t, err)
+	require.True(t, result.Accepted)
+	require.Len(t, internalized.Outputs, 1)
+	require.Equal(t, wallet.InternalizeProtocolWalletPayment, internalized.Outputs[0].Protocol)
+	require.Equal(t, &payment.Remittance, internalized.Outputs[0].PaymentRemittance)
+
+	_, err = brc29.Internalize(ctx, recipient, beef, 1, &payment.Remittance, "incoming payment", "")
+	require.ErrorIs(t, err, brc29.ErrOutputMismatch)
+
+	// A third party can't claim the output.
+	other := wallet.NewTestWalletForRandomKey(t)
+	_, err = brc29.Internalize(ctx, other, beef, 0, &payment.Remittance, "incoming payment", "")
+	require.ErrorIs(t, err, brc29.ErrOutputMismatch)
+}
+
+func TestInvalidRemittance(t *testing.T) {
+	ctx := context.Background()
+	w := wallet.NewTestWalletForRandomKey(t)
+
+	_, err := brc29.ReceiverLockingScript(ctx, w, &wallet.Payment{}, "")
+	require.ErrorIs(t, err, brc29.ErrInvalidRemittance)
+
+	_, err = brc29.LockingScript(ctx, w, identityKey(t, w), &wallet.Payment{DerivationPrefix: []byte{1}}, "")
+	require.ErrorIs(t, err, brc29.ErrInvalidRemittance)
+}
@@ -0,0 +1,113 @@
+// Package p2c implements pay-to-contract invoice payments. The payer tweaks the
+// merchant's public key with the hash of the invoice being paid and pays the
+// resulting key with a P2PKH output, so the payment itself commits to the
+// invoice. Anyone holding the merchant key and the invoice, such as an auditor,
+// can check which outputs pay it, and only the merchant can spend them.
+//
+// The tweak is the BIP-341 style commitment of primitives/ec: the merchant key
+// with an even y plus TapTweak(xonly(merchant) || sha256(invoice))*G.
+package p2c
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	ec "github.com/bsv-blockchain/go-sdk/primitives/ec"
+	crypto "github.com/bsv-blockchain/go-sdk/primitives/hash"
+	"github.com/bsv-blockchain/go-sdk/script"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+	sighash "github.com/bsv-blockchain/go-sdk/transaction/sighash"
+	"github.com/bsv-blockchain/go-sdk/transaction/template/p2pkh"
+)
+
+var (
+	ErrInvalidInvoice = errors.New("invalid invoice")
+	ErrNoPayment      = errors.New("transaction does not pay the invoice")
+	ErrUnderpaid      = errors.New("invoice is underpaid")
+)
+
+// InvoiceHash returns the commitment to invoice tweaked into payment keys.
+func InvoiceHash(invoice []byte) []byte {
+	return crypto.Sha256(invoice)
+}
+
+// PaymentKey returns the key paying invoice to merchant.
+func PaymentKey(merchant *ec.PublicKey, invoice []byte) (*ec.PublicKey, error) {
+	if merchant == nil {
+		return nil, fmt.Errorf("%w: missing merchant key", ErrInvalidInvoice)
+	}
+	if len(invoice) == 0 {
+		return nil, fmt.Errorf("%w: empty invoice", ErrInvalidInvoice)
+	}
+	return merchant.CommitTo(InvoiceHash(invoice))
+}
+
+// PaymentPrivateKey returns the private key of PaymentKey, letting the merchant
+// spend the payments of invoice.
+func PaymentPrivateKey(merchant *ec.PrivateKey, invoice []byte) (*ec.PrivateKey, error) {
+	if merchant == nil {
+		return nil, fmt.Errorf("%w: missing merchant key", ErrInvalidInvoice)
+	}
+	if len(invoice) == 0 {
+		return nil, fmt.Errorf("%w: empty invoice", ErrInvalidInvoice)
+	}
+	return merchant.CommitTo(InvoiceHash(invoice))
+}
+
+// LockingScript returns the P2PKH locking script paying invoice to merchant.
+func LockingScript(merchant *ec.PublicKey, invoice []byte) (*script.Script, error) {
+	key, err := PaymentKey(merchant, invoice)
+	if err != nil {
+		return nil, err
+	}
+	// The locking script only commits to the key hash so the network is irrelevant here.
+	address, err := script.NewAddressFromPublicKey(key, true)
+	if err != nil {
+		return nil, err
+	}
+	return p2pkh.Lock(address)
+}
+
+// Unlock returns the template spending the payments of invoice to merchant.
+func Unlock(merchant *ec.PrivateKey, invoice []byte, sigHashFlag *sighash.Flag) (*p2pkh.P2PKH, error) {
+	key, err := PaymentPrivateKey(merchant, invoice)
+	if err != nil {
+		return nil, err
+	}
+	return p2pkh.Unlock(key, sigHashFlag)
+}
+
+// PaymentOutputs returns the indexes of the outputs of tx paying invoice to
+// merchant, with the satoshis they add up to.
+func PaymentOutputs(tx *transaction.Transaction, merchant *ec.PublicKey, invoice []byte) ([]uint32, uint64, error) {
+	expected, err := LockingScript(merchant, invoice)
+	if err != nil {
+		return nil, 0, err
+	}
+	var outputs []uint32
+	var total uint64
+	for vout, output := range tx.Outputs {
+		if output.LockingScript != nil && bytes.Equal(output.LockingScript.Bytes(), expected.Bytes()) {
+			outputs = append(outputs, uint32(vout))
+			total += output.Satoshis
+		}
+	}
+	return outputs, total, nil
+}
+
+// VerifyPayment checks that tx pays at least satoshis to the invoice of
+// merchant, returning the indexes of the paying outputs.
+func VerifyPayment(tx *transaction.Transaction, merchant *ec.PublicKey, invoice []byte, satoshis uint64) ([]uint32, error) {
+	outputs, total, err := PaymentOutputs(tx, merchant, invoice)
+	if err != nil {
+		return nil, err
+	}
+	if len(outputs) == 0 {
+		return nil, ErrNoPayment
+	}
+	if total < satoshis {
+		return outputs, fmt.Errorf("%w: paid %d of %d satoshis", ErrUnderpaid, total, satoshis)
+	}
+	return outputs, nil
+}
@@ -0,0 +1,82 @@
+package p2c_test
+
+import (
+	"testing"
+
+	"github.com/bsv-blockchain/go-sdk/payments/p2c"
+	ec "github.com/bsv-blockchain/go-sdk/primitives/ec"
+	"github.com/bsv-blockchain/go-sdk/script/interpreter"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPayment(t *testing.T) {
+	merchant, err := ec.NewPrivateKey()
+	require.NoError(t, err)
+	invoice := []byte(`{"id":"INV-1001","amount":1500,"memo":"2 coffees"}`)
+
+	key, err := p2c.PaymentKey(merchant.PubKey(), invoice)
+	require.NoError(t, err)
+	require.False(t, key.IsEqual(merchant.PubKey()))
+	priv, err := p2c.PaymentPrivateKey(merchant, invoice)
+	require.NoError(t, err)
+	require.True(t, priv.PubKey().IsEqual(key))
+
+	lockingScript, err := p2c.LockingScript(merchant.PubKey(), invoice)
+	require.NoError(t, err)
+	other, err := p2c.LockingScript(merchant.PubKey(), []byte(`{"id":"INV-1002","amount":1500,"memo":"2 coffees"}`))
+	require.NoError(t, err)
+	require.NotEqual(t, lockingScript.Bytes(), other.Bytes())
+
+	tx := transaction.NewTransaction()
+	tx.AddOutput(&transaction.TransactionOutput{Satoshis: 1000, LockingScript: lockingScript})
+	tx.AddOutput(&transaction.TransactionOutput{Satoshis: 700, LockingScript: other})
+	tx.AddOutput(&transaction.TransactionOutput{Satoshis: 500, LockingScript: lockingScript})
+
+	t.Run("verify", func(t *testing.T) {
+		outputs, err := p2c.VerifyPayment(tx, merchant.PubKey(), invoice, 1500)
+		require.NoError(t, err)
+		require.Equal(t, []uint32{0, 2}, outputs)
+
+		outputs, err = p2c.VerifyPayment(tx, merchant.PubKey(), invoice, 1501)
+		require.ErrorIs(t, err, p2c.ErrUnderpaid)
+		require.Equal(t, []uint32{0, 2}, outputs)
+
+		_, err = p2c.VerifyPayment(tx, merchant.PubKey(), []byte("INV-9999"), 1)
+		require.ErrorIs(t, err, p2c.ErrNoPayment)
+
+		stranger, err := ec.NewPrivateKey()
+		require.NoError(t, err)
+		_, err = p2c.VerifyPayment(tx, stranger.PubKey(), invoice, 1)
+		require.ErrorIs(t, err, p2c.ErrNoPayment)
+	})
+
+	t.Run("spend", func(t *testing.T) {
+		unlocker, err := p2c.Unlock(merchant, invoice, nil)
+		require.NoError(t, err)
+		spendTx := transaction.NewTransaction()
+		spendTx.AddInputFromTx(tx, 0, unlocker)
+		spendTx.AddOutput(&transaction.TransactionOutput{Satoshis: 900, LockingScript: other})
+		require.NoError(t, spendTx.Sign())
+
+		require.NoError(t, interpreter.NewEngine().Execute(
+			interpreter.WithTx(spendTx, 0, tx.Outputs[0]),
+			interpreter.WithForkID(),
+			interpreter.WithAfterGenesis(),
+		))
+	})
+}
+
+func TestInvalidInvoice(t *testing.T) {
+	merchant, err := ec.NewPrivateKey()
+	require.NoError(t, err)
+
+	_, err = p2c.PaymentKey(nil, []byte("INV-1"))
+	require.ErrorIs(t, err, p2c.ErrInvalidInvoice)
+	_, err = p2c.PaymentKey(merchant.PubKey(), nil)
+	require.ErrorIs(t, err, p2c.ErrInvalidInvoice)
+	_, err = p2c.Unlock(merchant, nil, nil)
+	require.ErrorIs(t, err, p2c.ErrInvalidInvoice)
+	_, _, err = p2c.PaymentOutputs(transaction.NewTransaction(), nil, []byte("INV-1"))
+	require.ErrorIs(t, err, p2c.ErrInvalidInvoice)
+}
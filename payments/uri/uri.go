@@ -0,0 +1,283 @@
+// Package uri builds and parses BIP21-style payment URIs using the bitcoin: and
+// pay: schemes. The target of a URI is a P2PKH address, a paymail handle or a
+// hex-encoded locking script. Parsed URIs are converted into transaction outputs
+// so they can be handed directly to the transaction builder.
+package uri
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/bsv-blockchain/go-sdk/compat/paymail"
+	"github.com/bsv-blockchain/go-sdk/script"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+	"github.com/bsv-blockchain/go-sdk/transaction/template/p2pkh"
+)
+
+// Scheme is the URI scheme of a payment URI.
+type Scheme string
+
+const (
+	SchemeBitcoin Scheme = "bitcoin"
+	SchemePay     Scheme = "pay"
+)
+
+// Well known query parameters.
+const (
+	ParamAmount  = "amount"
+	ParamLabel   = "label"
+	ParamMessage = "message"
+	// ParamRequest points at a payment request server (BIP270/BIP272 style).
+	ParamRequest = "r"
+	// requiredPrefix marks parameters the reader must understand (BIP21 req-).
+	requiredPrefix = "req-"
+)
+
+// SatoshisPerBitcoin is the number of satoshis in one bitcoin.
+const SatoshisPerBitcoin = 100_000_000
+
+var (
+	ErrInvalidScheme           = errors.New("unsupported payment uri scheme")
+	ErrInvalidAmount           = errors.New("invalid payment uri amount")
+	ErrMissingDestination      = errors.New("payment uri has neither a target nor a payment request url")
+	ErrInvalidTarget           = errors.New("payment uri target is not an address, a paymail or a script")
+	ErrUnknownRequiredParam    = errors.New("payment uri has an unsupported required parameter")
+	ErrDuplicateParam          = errors.New("payment uri has a duplicate parameter")
+	ErrNoAddressForOutput      = errors.New("payment uri has no address or script to pay")
+	ErrUnresolvedPaymail       = errors.New("payment uri pays a paymail, which must be resolved to a script first")
+	ErrAmountRequiredForOutput = errors.New("payment uri has no amount to pay")
+)
+
+// PaymentURI is a parsed payment URI. At most one of Address, Paymail and Script
+// is set, from the target of the URI.
+type PaymentURI struct {
+	Scheme  Scheme
+	Address string
+	// Paymail is a paymail handle, lower-cased. Resolve it with the compat/paymail
+	// client and set Script to the destination it returns to build an output.
+	Paymail string
+	// Script is a locking script given as hex.
+	Script *script.Script
+	// Amount is the requested amount in satoshis; zero when unspecified.
+	Amount            uint64
+	Label             string
+	Message           string
+	PaymentRequestURL string
+	// Extensions holds any other parameters, such as BRC-specific fields, keyed by
+	// name. Required (req-) parameters keep their prefix.
+	Extensions map[string]string
+}
+
+// SupportedRequiredParams lists req- parameters, without the prefix, that Parse
+// accepts. Applications that understand additional required parameters may add to it.
+var SupportedRequiredParams = map[string]struct{}{}
+
+// Parse parses a bitcoin: or pay: URI.
+func Parse(s string) (*PaymentURI, error) {
+	schemeEnd := strings.Index(s, ":")
+	if schemeEnd < 0 {
+		return nil, fmt.Errorf("%w: missing scheme", ErrInvalidScheme)
+	}
+	scheme := Scheme(strings.ToLower(s[:schemeEnd]))
+	if scheme != SchemeBitcoin && scheme != SchemePay {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidScheme, scheme)
+	}
+
+	rest := s[schemeEnd+1:]
+	rest = strings.TrimPrefix(rest, "//")
+	target, rawQuery, _ := strings.Cut(rest, "?")
+
+	p := &PaymentURI{Scheme: scheme}
+	if err := p.setTarget(target); err != nil {
+		return nil, err
+	}
+	query, err := url.ParseQuery(rawQuery)
+	if err != nil {
+		return nil, fmt.Errorf("invalid payment uri query: %w", err)
+	}
+	for key, values := range query {
+		if len(values) > 1 {
+			return nil, fmt.Errorf("%w: %s", ErrDuplicateParam, key)
+		}
+		value := values[0]
+		switch key {
+		case ParamAmount:
+			if p.Amount, err = ParseAmount(value); err != nil {
+				return nil, err
+			}
+		case ParamLabel:
+			p.Label = value
+		case ParamMessage:
+			p.Message = value
+		case ParamRequest:
+			p.PaymentRequestURL = value
+		default:
+			if name, ok := strings.CutPrefix(key, requiredPrefix); ok {
+				if _, supported := SupportedRequiredParams[name]; !supported {
+					return nil, fmt.Errorf("%w: %s", ErrUnknownRequiredParam, key)
+				}
+			}
+			if p.Extensions == nil {
+				p.Extensions = make(map[string]string)
+			}
+			p.Extensions[key] = value
+		}
+	}
+
+	if target == "" && p.PaymentRequestURL == "" {
+		return nil, ErrMissingDestination
+	}
+	return p, nil
+}
+
+// setTarget sets the address, paymail or script target is.
+func (p *PaymentURI) setTarget(target string) error {
+	switch {
+	case target == "":
+	case strings.Contains(target, "@"):
+		address, err := paymail.ParseAddress(target)
+		if err != nil {
+			return fmt.Errorf("%w: %w", ErrInvalidTarget, err)
+		}
+		p.Paymail = address.String()
+	default:
+		if _, err := script.NewAddressFromString(target); err == nil {
+			p.Address = target
+		} else if s, hexErr := script.NewFromHex(target); hexErr == nil && len(*s) > 0 {
+			p.Script = s
+		} else {
+			return fmt.Errorf("%w: %w", ErrInvalidTarget, err)
+		}
+	}
+	return nil
+}
+
+// target returns the target of the URI.
+func (p *PaymentURI) target() string {
+	switch {
+	case p.Address != "":
+		return p.Address
+	case p.Paymail != "":
+		return p.Paymail
+	case p.Script != nil:
+		return p.Script.String()
+	}
+	return ""
+}
+
+// String builds the URI. Parameters are written in a stable order: amount, label,
+// message, r and then extensions sorted by name.
+func (p *PaymentURI) String() string {
+	scheme := p.Scheme
+	if scheme == "" {
+		scheme = SchemeBitcoin
+	}
+
+	params := make([]string, 0, 4+len(p.Extensions))
+	if p.Amount > 0 {
+		params = append(params, ParamAmount+"="+FormatAmount(p.Amount))
+	}
+	if p.Label != "" {
+		params = append(params, ParamLabel+"="+escape(p.Label))
+	}
+	if p.Message != "" {
+		params = append(params, ParamMessage+"="+escape(p.Message))
+	}
+	if p.PaymentRequestURL != "" {
+		params = append(params, ParamRequest+"="+escape(p.PaymentRequestURL))
+	}
+	extensionKeys := make([]string, 0, len(p.Extensions))
+	for key := range p.Extensions {
+		extensionKeys = append(extensionKeys, key)
+	}
+	sort.Strings(extensionKeys)
+	for _, key := range extensionKeys {
+		params = append(params, escape(key)+"="+escape(p.Extensions[key]))
+	}
+
+	s := string(scheme) + ":" + p.target()
+	if len(params) > 0 {
+		s += "?" + strings.Join(params, "&")
+	}
+	return s
+}
+
+// Output returns an output paying Amount to Address with P2PKH, or to Script,
+// ready to add to a transaction. Paymail targets must be resolved to a Script first.
+func (p *PaymentURI) Output() (*transaction.TransactionOutput, error) {
+	if p.Address == "" && p.Script == nil {
+		if p.Paymail != "" {
+			return nil, ErrUnresolvedPaymail
+		}
+		return nil, ErrNoAddressForOutput
+	}
+	if p.Amount == 0 {
+		return nil, ErrAmountRequiredForOutput
+	}
+	lockingScript := p.Script
+	if p.Address != "" {
+		address, err := script.NewAddressFromString(p.Address)
+		if err != nil {
+			return nil, err
+		}
+		if lockingScript, err = p2pkh.Lock(address); err != nil {
+			return nil, err
+		}
+	}
+	return &transaction.TransactionOutput{
+		Satoshis:      p.Amount,
+		LockingScript: lockingScript,
+	}, nil
+}
+
+// ParseAmount converts a decimal bitcoin amount, as used in payment URIs, into
+// satoshis without floating point rounding.
+func ParseAmount(s string) (uint64, error) {
+	whole, frac, hasFrac := strings.Cut(s, ".")
+	if whole == "" && (!hasFrac || frac == "") {
+		return 0, fmt.Errorf("%w: %q", ErrInvalidAmount, s)
+	}
+	if len(frac) > 8 {
+		return 0, fmt.Errorf("%w: %q has more than 8 decimal places", ErrInvalidAmount, s)
+	}
+	frac += strings.Repeat("0", 8-len(frac))
+	if whole == "" {
+		whole = "0"
+	}
+	if strings.ContainsAny(whole+frac, "+-") {
+		return 0, fmt.Errorf("%w: %q", ErrInvalidAmount, s)
+	}
+	wholeSats, err := strconv.ParseUint(whole, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %q", ErrInvalidAmount, s)
+	}
+	fracSats, err := strconv.ParseUint(frac, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %q", ErrInvalidAmount, s)
+	}
+	if wholeSats > (^uint64(0)-fracSats)/SatoshisPerBitcoin {
+		return 0, fmt.Errorf("%w: %q overflows", ErrInvalidAmount, s)
+	}
+	return wholeSats*SatoshisPerBitcoin + fracSats, nil
+}
+
+// FormatAmount formats satoshis as a decimal bitcoin amount without trailing zeros.
+func FormatAmount(satoshis uint64) string {
+	whole := satoshis / SatoshisPerBitcoin
+	frac := satoshis % SatoshisPerBitcoin
+	if frac == 0 {
+		return strconv.FormatUint(whole, 10)
+	}
+	fracStr := strings.TrimRight(fmt.Sprintf("%08d", frac), "0")
+	return strconv.FormatUint(whole, 10) + "." + fracStr
+}
+
+// escape percent-encodes a query component, using %20 rather than + for spaces
+// as BIP21 readers expect.
+func escape(s string) string {
+	return strings.ReplaceAll(url.QueryEscape(s), "+", "%20")
+}
@@ -0,0 +1,132 @@
+package uri
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testAddress = "1AdZmoAQUw4XCsCihukoHMvNWXcsd8jDN6"
+	// testScript is an OP_RETURN script.
+	testScript = "006a0568656c6c6f"
+)
+
+func TestParse(t *testing.T) {
+	p, err := Parse("bitcoin:" + testAddress + "?amount=0.0012&label=Coffee%20Shop&message=Thanks&sv=&brc=29")
+	require.NoError(t, err)
+	require.Equal(t, SchemeBitcoin, p.Scheme)
+	require.Equal(t, testAddress, p.Address)
+	require.Equal(t, uint64(120000), p.Amount)
+	require.Equal(t, "Coffee Shop", p.Label)
+	require.Equal(t, "Thanks", p.Message)
+	require.Equal(t, map[string]string{"sv": "", "brc": "29"}, p.Extensions)
+
+	p, err = Parse("pay:?r=https%3A%2F%2Fexample.com%2Fpay%2F123")
+	require.NoError(t, err)
+	require.Equal(t, SchemePay, p.Scheme)
+	require.Empty(t, p.Address)
+	require.Equal(t, "https://example.com/pay/123", p.PaymentRequestURL)
+
+	p, err = Parse("pay:Alice@Example.com?amount=1")
+	require.NoError(t, err)
+	require.Equal(t, "alice@example.com", p.Paymail)
+	require.Empty(t, p.Address)
+	require.Nil(t, p.Script)
+
+	p, err = Parse("bitcoin:" + testScript + "?amount=1")
+	require.NoError(t, err)
+	require.Equal(t, testScript, p.Script.String())
+	require.Empty(t, p.Address)
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := map[string]error{
+		"litecoin:" + testAddress:                ErrInvalidScheme,
+		testAddress:                              ErrInvalidScheme,
+		"bitcoin:":                               ErrMissingDestination,
+		"bitcoin:" + testAddress + "?amount=abc": ErrInvalidAmount,
+		"bitcoin:" + testAddress + "?amount=0.123456789": ErrInvalidAmount,
+		"bitcoin:" + testAddress + "?amount=-1":          ErrInvalidAmount,
+		"bitcoin:" + testAddress + "?req-somethingnew=1": ErrUnknownRequiredParam,
+		"bitcoin:" + testAddress + "?label=a&label=b":    ErrDuplicateParam,
+		"bitcoin:notanaddress":                           ErrInvalidTarget,
+		"pay:alice@localhost":                            ErrInvalidTarget,
+		"pay:al/ice@example.com":                         ErrInvalidTarget,
+	}
+	for input, expected := range tests {
+		_, err := Parse(input)
+		require.ErrorIs(t, err, expected, input)
+	}
+}
+
+func TestStringRoundTrip(t *testing.T) {
+	p := &PaymentURI{
+		Address:    testAddress,
+		Amount:     150000000,
+		Label:      "Alice & Bob",
+		Message:    "rent?",
+		Extensions: map[string]string{"z": "last", "a": "first"},
+	}
+	s := p.String()
+	require.Equal(t, "bitcoin:"+testAddress+"?amount=1.5&label=Alice%20%26%20Bob&message=rent%3F&a=first&z=last", s)
+
+	parsed, err := Parse(s)
+	require.NoError(t, err)
+	p.Scheme = SchemeBitcoin
+	require.Equal(t, p, parsed)
+
+	for _, s := range []string{"pay:alice@example.com?amount=0.1", "bitcoin:" + testScript} {
+		p, err := Parse(s)
+		require.NoError(t, err)
+		require.Equal(t, s, p.String())
+	}
+}
+
+func TestOutput(t *testing.T) {
+	p, err := Parse("bitcoin:" + testAddress + "?amount=0.00001")
+	require.NoError(t, err)
+	output, err := p.Output()
+	require.NoError(t, err)
+	require.Equal(t, uint64(1000), output.Satoshis)
+	require.True(t, output.LockingScript.IsP2PKH())
+
+	p.Amount = 0
+	_, err = p.Output()
+	require.ErrorIs(t, err, ErrAmountRequiredForOutput)
+
+	_, err = (&PaymentURI{Scheme: SchemePay, PaymentRequestURL: "https://example.com"}).Output()
+	require.ErrorIs(t, err, ErrNoAddressForOutput)
+
+	p, err = Parse("bitcoin:" + testScript + "?amount=0.00000001")
+	require.NoError(t, err)
+	output, err = p.Output()
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), output.Satoshis)
+	require.Equal(t, testScript, output.LockingScript.String())
+
+	p, err = Parse("pay:alice@example.com?amount=1")
+	require.NoError(t, err)
+	_, err = p.Output()
+	require.ErrorIs(t, err, ErrUnresolvedPaymail)
+}
+
+func TestAmounts(t *testing.T) {
+	for s, sats := range map[string]uint64{
+		"1":           100000000,
+		"0.00000001":  1,
+		".5":          50000000,
+		"21000000":    2100000000000000,
+		"20.12345678": 2012345678,
+	} {
+		got, err := ParseAmount(s)
+		require.NoError(t, err, s)
+		require.Equal(t, sats, got, s)
+	}
+	require.Equal(t, "0.00000001", FormatAmount(1))
+	require.Equal(t, "20.12345678", FormatAmount(2012345678))
+	require.Equal(t, "3", FormatAmount(300000000))
+
+	_, err := ParseAmount("184467440737.09551616")
+	require.ErrorIs(t, err, ErrInvalidAmount)
+}
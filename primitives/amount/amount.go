@@ -0,0 +1,196 @@
+// Package amount provides Satoshis, a type for BSV amounts with overflow checked
+// arithmetic and conversion to and from BSV denominated strings. Using it in
+// place of a bare uint64 keeps amounts from being mixed up with other integers,
+// such as counts or fee rates, and BSV with satoshis.
+package amount
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/bits"
+	"strconv"
+	"strings"
+)
+
+// Satoshis is an amount of satoshis, the smallest unit of BSV.
+type Satoshis uint64
+
+const (
+	// SatoshisPerBSV is the number of satoshis in one BSV.
+	SatoshisPerBSV Satoshis = 100_000_000
+	// MaxSupply is the total number of satoshis that will ever exist.
+	MaxSupply Satoshis = 21_000_000 * SatoshisPerBSV
+)
+
+// decimals is the number of decimal places of a BSV amount.
+const decimals = 8
+
+var (
+	ErrOverflow  = errors.New("amount overflows")
+	ErrNegative  = errors.New("amount is negative")
+	ErrMalformed = errors.New("malformed amount")
+)
+
+// Add returns s + o, failing with ErrOverflow when it does not fit in a uint64.
+func (s Satoshis) Add(o Satoshis) (Satoshis, error) {
+	sum, carry := bits.Add64(uint64(s), uint64(o), 0)
+	if carry != 0 {
+		return 0, fmt.Errorf("%w: %d + %d", ErrOverflow, s, o)
+	}
+	return Satoshis(sum), nil
+}
+
+// Sub returns s - o, failing with ErrNegative when o is greater than s.
+func (s Satoshis) Sub(o Satoshis) (Satoshis, error) {
+	if o > s {
+		return 0, fmt.Errorf("%w: %d - %d", ErrNegative, s, o)
+	}
+	return s - o, nil
+}
+
+// Mul returns s * n, failing with ErrOverflow when it does not fit in a uint64.
+func (s Satoshis) Mul(n uint64) (Satoshis, error) {
+	hi, lo := bits.Mul64(uint64(s), n)
+	if hi != 0 {
+		return 0, fmt.Errorf("%w: %d * %d", ErrOverflow, s, n)
+	}
+	return Satoshis(lo), nil
+}
+
+// Sum returns the sum of amounts, failing with ErrOverflow when it does not fit
+// in a uint64.
+func Sum(amounts ...Satoshis) (Satoshis, error) {
+	var total Satoshis
+	for _, a := range amounts {
+		var err error
+		if total, err = total.Add(a); err != nil {
+			return 0, err
+		}
+	}
+	return total, nil
+}
+
+// Uint64 returns the amount as a plain number of satoshis.
+func (s Satoshis) Uint64() uint64 {
+	return uint64(s)
+}
+
+// BSV formats the amount in BSV with all 8 decimal places, as "0.00001500".
+func (s Satoshis) BSV() string {
+	return fmt.Sprintf("%d.%08d", s/SatoshisPerBSV, s%SatoshisPerBSV)
+}
+
+// String formats the amount in BSV, as "0.00001500 BSV". Amounts still format as
+// a number of satoshis with the %d verb.
+func (s Satoshis) String() string {
+	return s.BSV() + " BSV"
+}
+
+// ParseBSV parses a decimal amount of BSV, such as "0.000015", with at most 8
+// decimal places.
+func ParseBSV(str string) (Satoshis, error) {
+	whole, frac, hasFrac := strings.Cut(str, ".")
+	if whole == "" && (!hasFrac || frac == "") {
+		return 0, fmt.Errorf("%w: %q", ErrMalformed, str)
+	}
+	if strings.HasPrefix(str, "-") {
+		return 0, fmt.Errorf("%w: %q", ErrNegative, str)
+	}
+	if len(frac) > decimals || !isDigits(whole) || !isDigits(frac) {
+		return 0, fmt.Errorf("%w: %q", ErrMalformed, str)
+	}
+
+	var bsv uint64
+	if whole != "" {
+		var err error
+		if bsv, err = strconv.ParseUint(whole, 10, 64); err != nil {
+			return 0, fmt.Errorf("%w: %q", ErrOverflow, str)
+		}
+	}
+	sats, err := Satoshis(bsv).Mul(uint64(SatoshisPerBSV))
+	if err != nil {
+		return 0, err
+	}
+	if frac != "" {
+		f, _ := strconv.ParseUint(frac+strings.Repeat("0", decimals-len(frac)), 10, 64)
+		if sats, err = sats.Add(Satoshis(f)); err != nil {
+			return 0, err
+		}
+	}
+	return sats, nil
+}
+
+// Parse parses an amount given as a number of satoshis, optionally followed by
+// "sat" or "sats", or as a decimal amount followed by "BSV". The unit is case
+// insensitive and may be separated from the number by spaces.
+func Parse(str string) (Satoshis, error) {
+	s := strings.TrimSpace(str)
+	lower := strings.ToLower(s)
+	switch {
+	case strings.HasSuffix(lower, "bsv"):
+		return ParseBSV(strings.TrimSpace(s[:len(s)-3]))
+	case strings.HasSuffix(lower, "sats"):
+		s = strings.TrimSpace(s[:len(s)-4])
+	case strings.HasSuffix(lower, "sat"):
+		s = strings.TrimSpace(s[:len(s)-3])
+	}
+	if strings.HasPrefix(s, "-") {
+		return 0, fmt.Errorf("%w: %q", ErrNegative, str)
+	}
+	if s == "" || !isDigits(s) {
+		return 0, fmt.Errorf("%w: %q", ErrMalformed, str)
+	}
+	n, err := strconv.ParseUint(s, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %q", ErrOverflow, str)
+	}
+	return Satoshis(n), nil
+}
+
+// MarshalJSON encodes the amount as a JSON number of satoshis, the form used by
+// the wallet interfaces.
+func (s Satoshis) MarshalJSON() ([]byte, error) {
+	return strconv.AppendUint(nil, uint64(s), 10), nil
+}
+
+// UnmarshalJSON decodes a JSON number of satoshis, or a string accepted by Parse.
+func (s *Satoshis) UnmarshalJSON(data []byte) error {
+	if len(data) > 0 && data[0] == '"' {
+		var str string
+		if err := json.Unmarshal(data, &str); err != nil {
+			return err
+		}
+		parsed, err := Parse(str)
+		if err != nil {
+			return err
+		}
+		*s = parsed
+		return nil
+	}
+	var n json.Number
+	if err := json.Unmarshal(data, &n); err != nil {
+		return err
+	}
+	parsed, err := strconv.ParseUint(n.String(), 10, 64)
+	switch {
+	case err == nil:
+		*s = Satoshis(parsed)
+		return nil
+	case strings.HasPrefix(n.String(), "-"):
+		return fmt.Errorf("%w: %s", ErrNegative, n)
+	case isDigits(n.String()):
+		return fmt.Errorf("%w: %s", ErrOverflow, n)
+	default:
+		return fmt.Errorf("%w: %s is not a whole number of satoshis", ErrMalformed, n)
+	}
+}
+
+func isDigits(s string) bool {
+	for _, c := range s {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return true
+}
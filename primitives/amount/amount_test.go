@@ -0,0 +1,105 @@
+package amount
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestArithmetic(t *testing.T) {
+	sum, err := Satoshis(1500).Add(500)
+	require.NoError(t, err)
+	require.Equal(t, Satoshis(2000), sum)
+	_, err = Satoshis(math.MaxUint64).Add(1)
+	require.ErrorIs(t, err, ErrOverflow)
+
+	diff, err := Satoshis(1500).Sub(500)
+	require.NoError(t, err)
+	require.Equal(t, Satoshis(1000), diff)
+	_, err = Satoshis(500).Sub(501)
+	require.ErrorIs(t, err, ErrNegative)
+
+	product, err := Satoshis(250).Mul(4)
+	require.NoError(t, err)
+	require.Equal(t, Satoshis(1000), product)
+	_, err = MaxSupply.Mul(1 << 20)
+	require.ErrorIs(t, err, ErrOverflow)
+
+	total, err := Sum(1, 2, 3)
+	require.NoError(t, err)
+	require.Equal(t, Satoshis(6), total)
+	_, err = Sum(1, math.MaxUint64)
+	require.ErrorIs(t, err, ErrOverflow)
+}
+
+func TestFormat(t *testing.T) {
+	require.Equal(t, "0.00001500", Satoshis(1500).BSV())
+	require.Equal(t, "21000000.00000000", MaxSupply.BSV())
+	require.Equal(t, "1.50000000 BSV", Satoshis(150_000_000).String())
+	require.Equal(t, "1500", fmt.Sprintf("%d", Satoshis(1500)))
+	require.Equal(t, "0.00001500 BSV", fmt.Sprintf("%v", Satoshis(1500)))
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		in   string
+		want Satoshis
+		err  error
+	}{
+		{in: "1500", want: 1500},
+		{in: "1500 sat", want: 1500},
+		{in: " 1 sats ", want: 1},
+		{in: "0.000015 BSV", want: 1500},
+		{in: "1.5bsv", want: 150_000_000},
+		{in: ".5 BSV", want: 50_000_000},
+		{in: "21000000 BSV", want: MaxSupply},
+		{in: "", err: ErrMalformed},
+		{in: "sat", err: ErrMalformed},
+		{in: "1.5", err: ErrMalformed},
+		{in: "0.000000001 BSV", err: ErrMalformed},
+		{in: ". BSV", err: ErrMalformed},
+		{in: "1e3", err: ErrMalformed},
+		{in: "-5", err: ErrNegative},
+		{in: "-0.1 BSV", err: ErrNegative},
+		{in: "18446744073709551616", err: ErrOverflow},
+		{in: "200000000000 BSV", err: ErrOverflow},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := Parse(tt.in)
+			if tt.err != nil {
+				require.ErrorIs(t, err, tt.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+
+	got, err := ParseBSV("0.00001500")
+	require.NoError(t, err)
+	require.Equal(t, Satoshis(1500), got)
+}
+
+func TestJSON(t *testing.T) {
+	type payment struct {
+		Satoshis Satoshis `json:"satoshis"`
+	}
+	b, err := json.Marshal(payment{Satoshis: 1500})
+	require.NoError(t, err)
+	require.JSONEq(t, `{"satoshis":1500}`, string(b))
+
+	var p payment
+	require.NoError(t, json.Unmarshal(b, &p))
+	require.Equal(t, Satoshis(1500), p.Satoshis)
+	require.NoError(t, json.Unmarshal([]byte(`{"satoshis":"0.00002 BSV"}`), &p))
+	require.Equal(t, Satoshis(2000), p.Satoshis)
+
+	require.ErrorIs(t, json.Unmarshal([]byte(`{"satoshis":-1}`), &p), ErrNegative)
+	require.ErrorIs(t, json.Unmarshal([]byte(`{"satoshis":1.5}`), &p), ErrMalformed)
+	require.ErrorIs(t, json.Unmarshal([]byte(`{"satoshis":18446744073709551616}`), &p), ErrOverflow)
+	require.Error(t, json.Unmarshal([]byte(`{"satoshis":true}`), &p))
+}
@@ -0,0 +1,46 @@
+package primitives
+
+import (
+	"crypto/sha256"
+	"math/big"
+	"testing"
+
+	"github.com/bsv-blockchain/go-sdk/script/interpreter/errs"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateDEREncoding(t *testing.T) {
+	priv, err := NewPrivateKey()
+	require.NoError(t, err)
+	hash := sha256.Sum256([]byte("der"))
+	sig, err := priv.Sign(hash[:])
+	require.NoError(t, err)
+	der := sig.Serialize()
+	require.NoError(t, ValidateDEREncoding(der, true))
+
+	highS := &Signature{R: sig.R, S: new(big.Int).Sub(S256().N, sig.S)}
+	highSDER, err := highS.ToDER()
+	require.NoError(t, err)
+	require.NoError(t, ValidateDEREncoding(highSDER, false))
+	require.True(t, errs.IsErrorCode(ValidateDEREncoding(highSDER, true), errs.ErrSigHighS))
+
+	tests := map[string]struct {
+		sig  []byte
+		code errs.ErrorCode
+	}{
+		"too short":         {der[:7], errs.ErrSigTooShort},
+		"too long":          {append(append([]byte{}, der...), make([]byte, 73-len(der))...), errs.ErrSigTooLong},
+		"wrong sequence id": {append([]byte{0x31}, der[1:]...), errs.ErrSigInvalidSeqID},
+		"bad data length":   {append(append([]byte{}, der...), 0x01), errs.ErrSigInvalidDataLen},
+		"negative R":        {[]byte{0x30, 0x06, 0x02, 0x01, 0x80, 0x02, 0x01, 0x01}, errs.ErrSigNegativeR},
+		"R padding":         {[]byte{0x30, 0x07, 0x02, 0x02, 0x00, 0x01, 0x02, 0x01, 0x01}, errs.ErrSigTooMuchRPadding},
+		"zero length S":     {[]byte{0x30, 0x06, 0x02, 0x01, 0x01, 0x02, 0x00, 0x01}, errs.ErrSigInvalidSLen},
+		"S integer marker":  {[]byte{0x30, 0x06, 0x02, 0x01, 0x01, 0x03, 0x01, 0x01}, errs.ErrSigInvalidSIntID},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := ValidateDEREncoding(test.sig, false)
+			require.True(t, errs.IsErrorCode(err, test.code), "got %v", err)
+		})
+	}
+}
@@ -22,6 +22,7 @@ package primitives
 import (
 	"crypto/elliptic"
 	"math/big"
+	"math/bits"
 	"sync"
 )
 
@@ -760,11 +761,163 @@ func NAF(k []byte) ([]byte, []byte) {
 	return retPos[1:], retNeg[1:]
 }
 
-// ScalarMult returns k*(Bx, By) where k is a big endian integer.
-// Part of the elliptic.Curve interface.
-func (curve *KoblitzCurve) ScalarMult(Bx, By *big.Int, k []byte) (*big.Int, *big.Int) {
+// wnafWidth is the window width of the wNAF representation of the scalars in
+// ScalarMult, which needs a table of 2^(wnafWidth-2) odd multiples per point.
+const (
+	wnafWidth     = 5
+	wnafTableSize = 1 << (wnafWidth - 2)
+)
+
+// wNAF returns the width-w non-adjacent form of the big endian integer k, least
+// significant digit first. Every non-zero digit is odd and smaller than 2^(w-1)
+// in absolute value, and at most one of any w consecutive digits is non-zero,
+// so only about 1/(w+1) of the digits need a point addition. This is algorithm
+// 3.35 from [GECC].
+func wNAF(k []byte, w uint) []int8 {
+	// Load k into little endian 64-bit limbs, with a spare limb for the carry
+	// caused by negative digits.
+	limbs := make([]uint64, len(k)/8+2)
+	for i, b := range k {
+		pos := len(k) - 1 - i
+		limbs[pos/8] |= uint64(b) << (8 * (pos % 8))
+	}
+
+	window := uint64(1) << w
+	digits := make([]int8, 0, len(k)*8+1)
+	for !limbsZero(limbs) {
+		var digit int64
+		if limbs[0]&1 == 1 {
+			// The digit is k mods 2^w, and subtracting it clears the
+			// lowest w bits of k.
+			digit = int64(limbs[0] & (window - 1))
+			if digit >= int64(window>>1) {
+				digit -= int64(window)
+			}
+			if digit > 0 {
+				limbsSub(limbs, uint64(digit))
+			} else {
+				limbsAdd(limbs, uint64(-digit))
+			}
+		}
+		digits = append(digits, int8(digit))
+		limbsShr1(limbs)
+	}
+	return digits
+}
+
+func limbsZero(limbs []uint64) bool {
+	for _, l := range limbs {
+		if l != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+func limbsAdd(limbs []uint64, v uint64) {
+	for i := range limbs {
+		limbs[i], v = bits.Add64(limbs[i], v, 0)
+		if v == 0 {
+			return
+		}
+	}
+}
+
+func limbsSub(limbs []uint64, v uint64) {
+	for i := range limbs {
+		limbs[i], v = bits.Sub64(limbs[i], v, 0)
+		if v == 0 {
+			return
+		}
+	}
+}
+
+func limbsShr1(limbs []uint64) {
+	for i := range limbs {
+		limbs[i] >>= 1
+		if i+1 < len(limbs) {
+			limbs[i] |= limbs[i+1] << 63
+		}
+	}
+}
+
+// affinePoint is a point with a z coordinate of one along with its negated y
+// coordinate, which allows adding it with the faster mixed addition.
+type affinePoint struct {
+	x, y, yNeg fieldVal
+}
+
+// oddMultiples returns the affine points P, 3P, 5P, ... for the wNAF table of the
+// affine point P. The multiples are computed in Jacobian coordinates and then
+// converted with a single field inversion using Montgomery's trick. P is the
+// public operand of the multiplication, so the inversion need not be constant
+// time.
+func (curve *KoblitzCurve) oddMultiples(px, py *fieldVal) *[wnafTableSize]affinePoint {
+	var jacobian [wnafTableSize][3]fieldVal
+	jacobian[0][0].Set(px)
+	jacobian[0][1].Set(py)
+	jacobian[0][2].SetInt(1)
+
+	var twoPX, twoPY, twoPZ fieldVal
+	curve.doubleJacobian(px, py, new(fieldVal).SetInt(1), &twoPX, &twoPY, &twoPZ)
+	for i := 1; i < wnafTableSize; i++ {
+		prev, cur := &jacobian[i-1], &jacobian[i]
+		curve.addJacobian(&prev[0], &prev[1], &prev[2], &twoPX, &twoPY, &twoPZ,
+			&cur[0], &cur[1], &cur[2])
+	}
+
+	// acc[i] = z0 * ... * zi, so that a single inversion of the full product
+	// yields the inverse of every z.
+	var acc [wnafTableSize]fieldVal
+	acc[0].Set(&jacobian[0][2])
+	for i := 1; i < wnafTableSize; i++ {
+		acc[i].Mul2(&acc[i-1], &jacobian[i][2])
+	}
+	var inv, zInv, zInv2 fieldVal
+	inv.Set(&acc[wnafTableSize-1]).InverseVarTime()
+
+	var table [wnafTableSize]affinePoint
+	for i := wnafTableSize - 1; i >= 0; i-- {
+		if i > 0 {
+			zInv.Mul2(&inv, &acc[i-1]) // zInv = zi^-1
+			inv.Mul(&jacobian[i][2])   // inv = (z0 * ... * zi-1)^-1
+		} else {
+			zInv.Set(&inv)
+		}
+		zInv2.SquareVal(&zInv)
+		p := &table[i]
+		p.x.Mul2(&jacobian[i][0], &zInv2).Normalise()           // X/Z^2
+		p.y.Mul2(&jacobian[i][1], zInv2.Mul(&zInv)).Normalise() // Y/Z^3
+		p.yNeg.NegateVal(&p.y, 1).Normalise()
+	}
+	return &table
+}
+
+// addWNAFDigit adds digit*P to the Jacobian point (qx, qy, qz) using the table of
+// odd multiples of P. one must be the field value 1; the shared fieldOne is not
+// used as addJacobian normalises its arguments in place.
+func (curve *KoblitzCurve) addWNAFDigit(qx, qy, qz *fieldVal, table *[wnafTableSize]affinePoint, digit int8, one *fieldVal) {
+	if digit > 0 {
+		p := &table[digit>>1]
+		curve.addJacobian(qx, qy, qz, &p.x, &p.y, one, qx, qy, qz)
+	} else {
+		p := &table[(-digit)>>1]
+		curve.addJacobian(qx, qy, qz, &p.x, &p.yNeg, one, qx, qy, qz)
+	}
+}
+
+// scalarMultJacobian computes k*(Bx, By) and stores it in the Jacobian point
+// (qx, qy, qz).
+func (curve *KoblitzCurve) scalarMultJacobian(Bx, By *big.Int, k []byte, qx, qy, qz *fieldVal) {
 	// Point Q = ∞ (point at infinity).
-	qx, qy, qz := new(fieldVal), new(fieldVal), new(fieldVal)
+	qx.SetInt(0)
+	qy.SetInt(0)
+	qz.SetInt(0)
+
+	// The point at infinity multiplies to itself.
+	if Bx.Sign() == 0 && By.Sign() == 0 {
+		return
+	}
 
 	// Decompose K into k1 and k2 in order to halve the number of EC ops.
 	// See Algorithm 3.74 in [GECC].
@@ -773,106 +926,57 @@ func (curve *KoblitzCurve) ScalarMult(Bx, By *big.Int, k []byte) (*big.Int, *big
 	// The main equation here to remember is:
 	//   k * P = k1 * P + k2 * ϕ(P)
 	//
-	// P1 below is P in the equation, P2 below is ϕ(P) in the equation
+	// NOTE: ϕ(x,y) = (βx,y), so the table of ϕ(P) is the table of P with
+	// its x coordinates multiplied by β.
 	p1x, p1y := curve.bigAffineToField(Bx, By)
-	p1yNeg := new(fieldVal).NegateVal(p1y, 1)
-	p1z := new(fieldVal).SetInt(1)
-
-	// NOTE: ϕ(x,y) = (βx,y).  The Jacobian z coordinate is 1, so this math
-	// goes through.
-	p2x := new(fieldVal).Mul2(p1x, curve.beta)
-	p2y := new(fieldVal).Set(p1y)
-	p2yNeg := new(fieldVal).NegateVal(p2y, 1)
-	p2z := new(fieldVal).SetInt(1)
-
-	// Flip the positive and negative values of the points as needed
-	// depending on the signs of k1 and k2.  As mentioned in the equation
-	// above, each of k1 and k2 are multiplied by the respective point.
-	// Since -k * P is the same thing as k * -P, and the group law for
-	// elliptic curves states that P(x, y) = -P(x, -y), it's faster and
-	// simplifies the code to just make the point negative.
-	if signK1 == -1 {
-		p1y, p1yNeg = p1yNeg, p1y
-	}
-	if signK2 == -1 {
-		p2y, p2yNeg = p2yNeg, p2y
-	}
-
-	// NAF versions of k1 and k2 should have a lot more zeros.
-	//
-	// The Pos version of the bytes contain the +1s and the Neg versions
-	// contain the -1s.
-	k1PosNAF, k1NegNAF := NAF(k1)
-	k2PosNAF, k2NegNAF := NAF(k2)
-	k1Len := len(k1PosNAF)
-	k2Len := len(k2PosNAF)
-
-	m := k1Len
-	if m < k2Len {
-		m = k2Len
-	}
-
-	// Add left-to-right using the NAF optimization.  See algorithm 3.77
-	// from [GECC].  This should be faster overall since there will be a lot
-	// more instances of 0, hence reducing the number of Jacobian additions
-	// at the cost of 1 possible extra doubling.
-	var k1BytePos, k1ByteNeg, k2BytePos, k2ByteNeg byte
-	for i := 0; i < m; i++ {
-		// Since we're going left-to-right, pad the front with 0s.
-		if i < m-k1Len {
-			k1BytePos = 0
-			k1ByteNeg = 0
-		} else {
-			k1BytePos = k1PosNAF[i-(m-k1Len)]
-			k1ByteNeg = k1NegNAF[i-(m-k1Len)]
-		}
-		if i < m-k2Len {
-			k2BytePos = 0
-			k2ByteNeg = 0
-		} else {
-			k2BytePos = k2PosNAF[i-(m-k2Len)]
-			k2ByteNeg = k2NegNAF[i-(m-k2Len)]
-		}
-
-		for j := 7; j >= 0; j-- {
-			// Q = 2 * Q
-			curve.doubleJacobian(qx, qy, qz, qx, qy, qz)
-
-			if k1BytePos&0x80 == 0x80 {
-				curve.addJacobian(qx, qy, qz, p1x, p1y, p1z,
-					qx, qy, qz)
-			} else if k1ByteNeg&0x80 == 0x80 {
-				curve.addJacobian(qx, qy, qz, p1x, p1yNeg, p1z,
-					qx, qy, qz)
-			}
+	table1 := curve.oddMultiples(p1x, p1y)
+	table2 := *table1
+	for i := range table2 {
+		table2[i].x.Mul(curve.beta).Normalise()
+	}
 
-			if k2BytePos&0x80 == 0x80 {
-				curve.addJacobian(qx, qy, qz, p2x, p2y, p2z,
-					qx, qy, qz)
-			} else if k2ByteNeg&0x80 == 0x80 {
-				curve.addJacobian(qx, qy, qz, p2x, p2yNeg, p2z,
-					qx, qy, qz)
-			}
-			k1BytePos <<= 1
-			k1ByteNeg <<= 1
-			k2BytePos <<= 1
-			k2ByteNeg <<= 1
+	// Since -k * P is the same thing as k * -P, a negative k1 or k2 simply
+	// flips the sign of every digit.
+	naf1 := wNAF(k1, wnafWidth)
+	naf2 := wNAF(k2, wnafWidth)
+	sign1, sign2 := int8(signK1), int8(signK2)
+	one := new(fieldVal).SetInt(1)
+
+	// Add left-to-right using the wNAF digits.  See algorithm 3.36 from
+	// [GECC], interleaved for both scalars so they share the doublings.
+	for i := max(len(naf1), len(naf2)) - 1; i >= 0; i-- {
+		// Q = 2 * Q
+		curve.doubleJacobian(qx, qy, qz, qx, qy, qz)
+
+		if i < len(naf1) && naf1[i] != 0 {
+			curve.addWNAFDigit(qx, qy, qz, table1, naf1[i]*sign1, one)
+		}
+		if i < len(naf2) && naf2[i] != 0 {
+			curve.addWNAFDigit(qx, qy, qz, &table2, naf2[i]*sign2, one)
 		}
 	}
+}
+
+// ScalarMult returns k*(Bx, By) where k is a big endian integer.
+// Part of the elliptic.Curve interface.
+func (curve *KoblitzCurve) ScalarMult(Bx, By *big.Int, k []byte) (*big.Int, *big.Int) {
+	qx, qy, qz := new(fieldVal), new(fieldVal), new(fieldVal)
+	curve.scalarMultJacobian(Bx, By, k, qx, qy, qz)
 
 	// Convert the Jacobian coordinate field values back to affine big.Ints.
 	return curve.fieldJacobianToBigAffine(qx, qy, qz)
 }
 
-// ScalarBaseMult returns k*G where G is the base point of the group and k is a
-// big endian integer.
-// Part of the elliptic.Curve interface.
-func (curve *KoblitzCurve) ScalarBaseMult(k []byte) (*big.Int, *big.Int) {
+// scalarBaseMultJacobian computes k*G and stores it in the Jacobian point
+// (qx, qy, qz).
+func (curve *KoblitzCurve) scalarBaseMultJacobian(k []byte, qx, qy, qz *fieldVal) {
 	newK := curve.moduloReduce(k)
 	diff := len(curve.bytePoints) - len(newK)
 
 	// Point Q = ∞ (point at infinity).
-	qx, qy, qz := new(fieldVal), new(fieldVal), new(fieldVal)
+	qx.SetInt(0)
+	qy.SetInt(0)
+	qz.SetInt(0)
 
 	// curve.bytePoints has all 256 byte points for each 8-bit window. The
 	// strategy is to add up the byte points. This is best understood by
@@ -883,9 +987,81 @@ func (curve *KoblitzCurve) ScalarBaseMult(k []byte) (*big.Int, *big.Int) {
 		p := curve.bytePoints[diff+i][byteVal]
 		curve.addJacobian(qx, qy, qz, &p[0], &p[1], &p[2], qx, qy, qz)
 	}
+}
+
+// ScalarBaseMult returns k*G where G is the base point of the group and k is a
+// big endian integer.
+// Part of the elliptic.Curve interface.
+func (curve *KoblitzCurve) ScalarBaseMult(k []byte) (*big.Int, *big.Int) {
+	qx, qy, qz := new(fieldVal), new(fieldVal), new(fieldVal)
+	curve.scalarBaseMultJacobian(k, qx, qy, qz)
 	return curve.fieldJacobianToBigAffine(qx, qy, qz)
 }
 
+// combinedMultJacobian computes baseScalar*G + scalar*(Bx, By) and stores it in
+// the Jacobian point (qx, qy, qz).
+func (curve *KoblitzCurve) combinedMultJacobian(Bx, By *big.Int, baseScalar, scalar []byte, qx, qy, qz *fieldVal) {
+	var rx, ry, rz fieldVal
+	curve.scalarBaseMultJacobian(baseScalar, qx, qy, qz)
+	curve.scalarMultJacobian(Bx, By, scalar, &rx, &ry, &rz)
+	curve.addJacobian(qx, qy, qz, &rx, &ry, &rz, qx, qy, qz)
+}
+
+// CombinedMult returns baseScalar*G + scalar*(Bx, By), the computation at the
+// heart of signature verification. Staying in Jacobian coordinates until the
+// end saves the conversions to affine coordinates of computing both products
+// separately. The running time depends on the scalars, which must be public.
+func (curve *KoblitzCurve) CombinedMult(Bx, By *big.Int, baseScalar, scalar []byte) (*big.Int, *big.Int) {
+	var qx, qy, qz fieldVal
+	curve.combinedMultJacobian(Bx, By, baseScalar, scalar, &qx, &qy, &qz)
+	if qz.Normalise().IsZero() {
+		return new(big.Int), new(big.Int)
+	}
+
+	qz.InverseVarTime()
+	var zInv2 fieldVal
+	zInv2.SquareVal(&qz)
+	qx.Mul(&zInv2).Normalise()
+	qy.Mul(zInv2.Mul(&qz)).Normalise()
+	return new(big.Int).SetBytes(qx.Bytes()[:]), new(big.Int).SetBytes(qy.Bytes()[:])
+}
+
+// verify reports whether (r, s) is a valid ECDSA signature of hash for the public
+// key (Bx, By), as crypto/ecdsa does, but without converting the resulting point
+// to affine coordinates: its x coordinate X/Z^2 reduces to r modulo N exactly
+// when X equals r*Z^2 or, if r+N is still below P, (r+N)*Z^2.
+func (curve *KoblitzCurve) verify(Bx, By *big.Int, hash []byte, r, s *big.Int) bool {
+	if r.Sign() <= 0 || s.Sign() <= 0 || r.Cmp(curve.N) >= 0 || s.Cmp(curve.N) >= 0 {
+		return false
+	}
+
+	// u1 = e/s and u2 = r/s (mod N), see SEC 1, Version 2.0, Section 4.1.4.
+	e := hashToInt(hash, curve)
+	w := new(big.Int).ModInverse(s, curve.N)
+	u1 := e.Mul(e, w)
+	u1.Mod(u1, curve.N)
+	u2 := w.Mul(r, w)
+	u2.Mod(u2, curve.N)
+
+	var qx, qy, qz fieldVal
+	curve.combinedMultJacobian(Bx, By, u1.Bytes(), u2.Bytes(), &qx, &qy, &qz)
+	if qz.Normalise().IsZero() {
+		return false
+	}
+
+	var zz, rz fieldVal
+	zz.SquareVal(&qz)
+	qx.Normalise()
+	if rz.SetByteSlice(r.Bytes()).Mul(&zz).Normalise().Equals(&qx) {
+		return true
+	}
+	rn := new(big.Int).Add(r, curve.N)
+	if rn.Cmp(curve.P) >= 0 {
+		return false
+	}
+	return rz.SetByteSlice(rn.Bytes()).Mul(&zz).Normalise().Equals(&qx)
+}
+
 // QPlus1Div4 returns the (P+1)/4 constant for the curve for use in calculating
 // square roots via exponentiation.
 //
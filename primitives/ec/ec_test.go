@@ -0,0 +1,163 @@
+package primitives
+
+import (
+	"crypto/ecdsa"
+	"crypto/sha256"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// naiveScalarMult computes k*(x, y) with plain double-and-add as a reference.
+func naiveScalarMult(x, y *big.Int, k []byte) (*big.Int, *big.Int) {
+	curve := S256()
+	rx, ry := new(big.Int), new(big.Int)
+	for _, b := range k {
+		for bit := 7; bit >= 0; bit-- {
+			rx, ry = curve.Double(rx, ry)
+			if b>>bit&1 == 1 {
+				rx, ry = curve.Add(rx, ry, x, y)
+			}
+		}
+	}
+	return rx, ry
+}
+
+func testScalars() [][]byte {
+	n := S256().N
+	scalars := [][]byte{
+		{1},
+		{2},
+		{0xff},
+		new(big.Int).Sub(n, big.NewInt(1)).Bytes(),
+		new(big.Int).Rsh(n, 1).Bytes(),
+		new(big.Int).Lsh(big.NewInt(1), 128).Bytes(),
+		new(big.Int).Add(n, big.NewInt(5)).Bytes(),
+	}
+	for i := range 16 {
+		h := sha256.Sum256([]byte{byte(i)})
+		scalars = append(scalars, h[:])
+	}
+	return scalars
+}
+
+func TestScalarMult(t *testing.T) {
+	curve := S256()
+	px, py := curve.ScalarBaseMult([]byte{0x2a, 0x17})
+
+	for _, k := range testScalars() {
+		wantX, wantY := naiveScalarMult(px, py, new(big.Int).Mod(new(big.Int).SetBytes(k), curve.N).Bytes())
+		x, y := curve.ScalarMult(px, py, k)
+		require.Zero(t, wantX.Cmp(x), "k=%x", k)
+		require.Zero(t, wantY.Cmp(y), "k=%x", k)
+	}
+
+	x, y := curve.ScalarMult(px, py, curve.N.Bytes())
+	require.Zero(t, x.Sign())
+	require.Zero(t, y.Sign())
+}
+
+func TestCombinedMult(t *testing.T) {
+	curve := S256()
+	px, py := curve.ScalarBaseMult([]byte{0x01, 0x02, 0x03})
+
+	scalars := testScalars()
+	for i, u1 := range scalars {
+		u2 := scalars[len(scalars)-1-i]
+		x1, y1 := curve.ScalarBaseMult(u1)
+		x2, y2 := curve.ScalarMult(px, py, u2)
+		wantX, wantY := curve.Add(x1, y1, x2, y2)
+
+		x, y := curve.CombinedMult(px, py, u1, u2)
+		require.Zero(t, wantX.Cmp(x), "u1=%x u2=%x", u1, u2)
+		require.Zero(t, wantY.Cmp(y), "u1=%x u2=%x", u1, u2)
+	}
+
+	// u1*G + u2*P at infinity, with P = G and u2 = n - u1
+	gx, gy := curve.Gx, curve.Gy
+	u1 := big.NewInt(12345)
+	x, y := curve.CombinedMult(gx, gy, u1.Bytes(), new(big.Int).Sub(curve.N, u1).Bytes())
+	require.Zero(t, x.Sign())
+	require.Zero(t, y.Sign())
+}
+
+func TestWNAF(t *testing.T) {
+	for _, k := range testScalars() {
+		digits := wNAF(k, wnafWidth)
+		sum := new(big.Int)
+		for i := len(digits) - 1; i >= 0; i-- {
+			sum.Lsh(sum, 1).Add(sum, big.NewInt(int64(digits[i])))
+			if digits[i] != 0 {
+				require.Equal(t, int8(1), digits[i]&1, "digits are odd")
+				require.Less(t, abs8(digits[i]), int8(1)<<(wnafWidth-1))
+			}
+		}
+		require.Zero(t, new(big.Int).SetBytes(k).Cmp(sum), "k=%x", k)
+	}
+}
+
+func TestVerifyMatchesECDSA(t *testing.T) {
+	for i := range 20 {
+		key, err := NewPrivateKey()
+		require.NoError(t, err)
+		hash := sha256.Sum256([]byte{byte(i)})
+		sig, err := key.Sign(hash[:])
+		require.NoError(t, err)
+
+		require.True(t, sig.Verify(hash[:], key.PubKey()))
+		require.True(t, ecdsa.Verify(key.PubKey().ToECDSA(), hash[:], sig.R, sig.S))
+
+		other := sha256.Sum256([]byte{byte(i), 1})
+		require.False(t, sig.Verify(other[:], key.PubKey()))
+		tampered := &Signature{R: sig.R, S: new(big.Int).Add(sig.S, big.NewInt(1))}
+		require.False(t, tampered.Verify(hash[:], key.PubKey()))
+		outOfRange := &Signature{R: new(big.Int).Add(sig.R, S256().N), S: sig.S}
+		require.False(t, outOfRange.Verify(hash[:], key.PubKey()))
+	}
+}
+
+func abs8(d int8) int8 {
+	if d < 0 {
+		return -d
+	}
+	return d
+}
+
+func BenchmarkScalarBaseMult(b *testing.B) {
+	k := sha256.Sum256([]byte("scalar"))
+	curve := S256()
+	for b.Loop() {
+		curve.ScalarBaseMult(k[:])
+	}
+}
+
+func BenchmarkScalarMult(b *testing.B) {
+	k := sha256.Sum256([]byte("scalar"))
+	curve := S256()
+	px, py := curve.ScalarBaseMult([]byte{0x2a})
+	for b.Loop() {
+		curve.ScalarMult(px, py, k[:])
+	}
+}
+
+func BenchmarkSign(b *testing.B) {
+	key, _ := PrivateKeyFromBytes([]byte{0x2a})
+	hash := sha256.Sum256([]byte("message"))
+	for b.Loop() {
+		_, _ = key.Sign(hash[:])
+	}
+}
+
+func BenchmarkVerify(b *testing.B) {
+	key, _ := PrivateKeyFromBytes([]byte{0x2a})
+	hash := sha256.Sum256([]byte("message"))
+	sig, err := key.Sign(hash[:])
+	require.NoError(b, err)
+	pub := key.PubKey()
+	for b.Loop() {
+		if !sig.Verify(hash[:], pub) {
+			b.Fatal("signature did not verify")
+		}
+	}
+}
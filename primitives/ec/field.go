@@ -16,30 +16,27 @@ package primitives
 //
 // There are various ways to internally represent each finite field element.
 // For example, the most obvious representation would be to use an array of 4
-// uint64s (64 bits * 4 = 256 bits).  However, that representation suffers from
-// a couple of issues.  First, there is no native Go type large enough to handle
-// the intermediate results while adding or multiplying two 64-bit numbers, and
-// second there is no space left for overflows when performing the intermediate
-// arithmetic between each array element which would lead to expensive carry
-// propagation.
+// uint64s (64 bits * 4 = 256 bits).  However, that representation leaves no
+// space for overflows when performing the intermediate arithmetic between each
+// array element, which would lead to expensive carry propagation on every
+// addition.
 //
 // Given the above, this implementation represents the the field elements as
-// 10 uint32s with each word (array entry) treated as base 2^26.  This was
+// 5 uint64s with each word (array entry) treated as base 2^52.  This was
 // chosen for the following reasons:
-// 1) Most systems at the current time are 64-bit (or at least have 64-bit
-//    registers available for specialised purposes such as MMX) so the
-//    intermediate results can typically be done using a native register (and
-//    using uint64s to avoid the need for additional half-word arithmetic)
+// 1) Most systems at the current time are 64-bit and Go computes the full
+//    128-bit product of two uint64s with a single instruction (bits.Mul64), so
+//    the intermediate results of a multiplication can be accumulated in pairs
+//    of 64-bit registers
 // 2) In order to allow addition of the internal words without having to
 //    propagate the the carry, the max normalised value for each register must
 //    be less than the number of bits available in the register
-// 3) Since we're dealing with 32-bit values, 64-bits of overflow is a
-//    reasonable choice for #2
-// 4) Given the need for 256-bits of precision and the properties stated in #1,
-//    #2, and #3, the representation which best accommodates this is 10 uint32s
-//    with base 2^26 (26 bits * 10 = 260 bits, so the final word only needs 22
-//    bits) which leaves the desired 64 bits (32 * 10 = 320, 320 - 256 = 64) for
-//    overflow
+// 3) Given the need for 256-bits of precision and the properties stated in #1
+//    and #2, the representation which best accommodates this is 5 uint64s
+//    with base 2^52 (52 bits * 5 = 260 bits, so the final word only needs 48
+//    bits) which leaves 12 bits of overflow in each word (16 in the final one)
+// 4) Compared to 10 uint32s in base 2^26, a multiplication takes 25 instead of
+//    100 word products, which makes it several times faster
 //
 // Since it is so important that the field arithmetic is extremely fast for
 // high performance crypto, this package does not perform any validation where
@@ -50,26 +47,21 @@ package primitives
 // counts.
 
 import (
+	"encoding/binary"
 	"encoding/hex"
-)
-
-// Constants used to make the code more readable.
-const (
-	twoBitsMask   = 0x3
-	fourBitsMask  = 0xf
-	sixBitsMask   = 0x3f
-	eightBitsMask = 0xff
+	"math/big"
+	"math/bits"
 )
 
 // Constants related to the field representation.
 const (
 	// fieldWords is the number of words used to internally represent the
 	// 256-bit value.
-	fieldWords = 10
+	fieldWords = 5
 
 	// fieldBase is the exponent used to form the numeric base of each word.
 	// 2^(fieldBase*i) where i is the word position.
-	fieldBase = 26
+	fieldBase = 52
 
 	// fieldBaseMask is the mask for the bits in each word needed to
 	// represent the numeric base of each word (except the most significant
@@ -85,12 +77,18 @@ const (
 	fieldMSBMask = (1 << fieldMSBBits) - 1
 
 	// fieldPrimeWordZero is word zero of the secp256k1 prime in the
-	// internal field representation.  It is used during negation.
-	fieldPrimeWordZero = 0x3fffc2f
-
-	// fieldPrimeWordOne is word one of the secp256k1 prime in the
-	// internal field representation.  It is used during negation.
-	fieldPrimeWordOne = 0x3ffffbf
+	// internal field representation.  It is used during negation and
+	// normalisation.
+	fieldPrimeWordZero = 0xffffefffffc2f
+
+	// fieldReduction is 2^256 mod P = 4294968273, the value a carry past
+	// bit 256 is folded back in with.
+	fieldReduction = 0x1000003d1
+
+	// fieldReductionShifted is fieldReduction * 2^4, which folds a carry
+	// past bit 260, the top of the word following the most significant
+	// one, back in.
+	fieldReductionShifted = fieldReduction << 4
 )
 
 var (
@@ -105,43 +103,50 @@ var (
 		0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff,
 		0xff, 0xff, 0xff, 0xff, 0xbf, 0xff, 0xff, 0x0c,
 	}
+
+	// fieldPrime is the secp256k1 prime P, used by InverseVarTime.
+	fieldPrime = FromHex("fffffffffffffffffffffffffffffffffffffffffffffffffffffffefffffc2f")
 )
 
 // fieldVal implements optimized fixed-precision arithmetic over the
 // secp256k1 finite field.  This means all arithmetic is performed modulo
 // 0xfffffffffffffffffffffffffffffffffffffffffffffffffffffffefffffc2f.  It
-// represents each 256-bit value as 10 32-bit integers in base 2^26.  This
-// provides 6 bits of overflow in each word (10 bits in the most significant
-// word) for a total of 64 bits of overflow (9*6 + 10 = 64).  It only implements
-// the arithmetic needed for elliptic curve operations.
+// represents each 256-bit value as 5 64-bit integers in base 2^52.  This
+// provides 12 bits of overflow in each word (16 bits in the most significant
+// word) for a total of 64 bits of overflow (4*12 + 16 = 64).  It only
+// implements the arithmetic needed for elliptic curve operations.
 //
 // The following depicts the internal representation:
 //
 //	 -----------------------------------------------------------------
-//	|        n[9]       |        n[8]       | ... |        n[0]       |
-//	| 32 bits available | 32 bits available | ... | 32 bits available |
-//	| 22 bits for value | 26 bits for value | ... | 26 bits for value |
-//	| 10 bits overflow  |  6 bits overflow  | ... |  6 bits overflow  |
-//	| Mult: 2^(26*9)    | Mult: 2^(26*8)    | ... | Mult: 2^(26*0)    |
+//	|        n[4]       |        n[3]       | ... |        n[0]       |
+//	| 64 bits available | 64 bits available | ... | 64 bits available |
+//	| 48 bits for value | 52 bits for value | ... | 52 bits for value |
+//	| 16 bits overflow  | 12 bits overflow  | ... | 12 bits overflow  |
+//	| Mult: 2^(52*4)    | Mult: 2^(52*3)    | ... | Mult: 2^(52*0)    |
 //	 -----------------------------------------------------------------
 //
-// For example, consider the number 2^49 + 1.  It would be represented as:
+// For example, consider the number 2^75 + 1.  It would be represented as:
 //
 //	n[0] = 1
 //	n[1] = 2^23
-//	n[2..9] = 0
+//	n[2..4] = 0
+//
+// The full 256-bit value is then calculated by looping i from 4..0 and
+// doing sum(n[i] * 2^(52i)) like so:
 //
-// The full 256-bit value is then calculated by looping i from 9..0 and
-// doing sum(n[i] * 2^(26i)) like so:
+//	n[4] * 2^(52*4) = 0    * 2^208 = 0
+//	n[3] * 2^(52*3) = 0    * 2^156 = 0
+//	n[2] * 2^(52*2) = 0    * 2^104 = 0
+//	n[1] * 2^(52*1) = 2^23 * 2^52  = 2^75
+//	n[0] * 2^(52*0) = 1    * 2^0   = 1
+//	Sum: 0 + 0 + 0 + 2^75 + 1 = 2^75 + 1
 //
-//	n[9] * 2^(26*9) = 0    * 2^234 = 0
-//	n[8] * 2^(26*8) = 0    * 2^208 = 0
-//	...
-//	n[1] * 2^(26*1) = 2^23 * 2^26  = 2^49
-//	n[0] * 2^(26*0) = 1    * 2^0   = 1
-//	Sum: 0 + 0 + ... + 2^49 + 1 = 2^49 + 1
+// A field value of magnitude m has words of at most 2*m times the mask of their
+// position, so normalised values have a magnitude of one.  The magnitude
+// grows with additions and bounds the values the other operations accept.
 type fieldVal struct {
-	n [10]uint32
+	n [5]uint64
 }
 
 // String returns the field value as a human-readable hex string.
@@ -159,11 +164,6 @@ func (f *fieldVal) Zero() {
 	f.n[2] = 0
 	f.n[3] = 0
 	f.n[4] = 0
-	f.n[5] = 0
-	f.n[6] = 0
-	f.n[7] = 0
-	f.n[8] = 0
-	f.n[9] = 0
 }
 
 // Set sets the field value equal to the passed value.
@@ -184,7 +184,7 @@ func (f *fieldVal) Set(val *fieldVal) *fieldVal {
 // as f := new(fieldVal).SetInt(2).Mul(f2) so that f = 2 * f2.
 func (f *fieldVal) SetInt(ui uint) *fieldVal {
 	f.Zero()
-	f.n[0] = uint32(ui)
+	f.n[0] = uint64(ui)
 	return f
 }
 
@@ -194,29 +194,17 @@ func (f *fieldVal) SetInt(ui uint) *fieldVal {
 // The field value is returned to support chaining.  This enables syntax like:
 // f := new(fieldVal).SetBytes(byteArray).Mul(f2) so that f = ba * f2.
 func (f *fieldVal) SetBytes(b *[32]byte) *fieldVal {
-	// Pack the 256 total bits across the 10 uint32 words with a max of
-	// 26-bits per word.  This could be done with a couple of for loops,
-	// but this unrolled version is significantly faster.  Benchmarks show
-	// this is about 34 times faster than the variant which uses loops.
-	f.n[0] = uint32(b[31]) | uint32(b[30])<<8 | uint32(b[29])<<16 |
-		(uint32(b[28])&twoBitsMask)<<24
-	f.n[1] = uint32(b[28])>>2 | uint32(b[27])<<6 | uint32(b[26])<<14 |
-		(uint32(b[25])&fourBitsMask)<<22
-	f.n[2] = uint32(b[25])>>4 | uint32(b[24])<<4 | uint32(b[23])<<12 |
-		(uint32(b[22])&sixBitsMask)<<20
-	f.n[3] = uint32(b[22])>>6 | uint32(b[21])<<2 | uint32(b[20])<<10 |
-		uint32(b[19])<<18
-	f.n[4] = uint32(b[18]) | uint32(b[17])<<8 | uint32(b[16])<<16 |
-		(uint32(b[15])&twoBitsMask)<<24
-	f.n[5] = uint32(b[15])>>2 | uint32(b[14])<<6 | uint32(b[13])<<14 |
-		(uint32(b[12])&fourBitsMask)<<22
-	f.n[6] = uint32(b[12])>>4 | uint32(b[11])<<4 | uint32(b[10])<<12 |
-		(uint32(b[9])&sixBitsMask)<<20
-	f.n[7] = uint32(b[9])>>6 | uint32(b[8])<<2 | uint32(b[7])<<10 |
-		uint32(b[6])<<18
-	f.n[8] = uint32(b[5]) | uint32(b[4])<<8 | uint32(b[3])<<16 |
-		(uint32(b[2])&twoBitsMask)<<24
-	f.n[9] = uint32(b[2])>>2 | uint32(b[1])<<6 | uint32(b[0])<<14
+	// Pack the 256 total bits, read as 4 64-bit words, across the 5 uint64
+	// words with a max of 52-bits per word.
+	w0 := binary.BigEndian.Uint64(b[24:])
+	w1 := binary.BigEndian.Uint64(b[16:])
+	w2 := binary.BigEndian.Uint64(b[8:])
+	w3 := binary.BigEndian.Uint64(b[0:])
+	f.n[0] = w0 & fieldBaseMask
+	f.n[1] = (w0>>52 | w1<<12) & fieldBaseMask
+	f.n[2] = (w1>>40 | w2<<24) & fieldBaseMask
+	f.n[3] = (w2>>28 | w3<<36) & fieldBaseMask
+	f.n[4] = w3 >> 16
 	return f
 }
 
@@ -258,7 +246,7 @@ func (f *fieldVal) SetHex(hexString string) *fieldVal {
 // performs fast modular reduction over the secp256k1 prime by making use of the
 // special form of the prime.
 func (f *fieldVal) Normalise() *fieldVal {
-	// The field representation leaves 6 bits of overflow in each word so
+	// The field representation leaves 12 bits of overflow in each word so
 	// intermediate calculations can be performed without needing to
 	// propagate the carry to each higher word during the calculations.  In
 	// order to normalise, we need to "compact" the full 256-bit value to
@@ -275,94 +263,49 @@ func (f *fieldVal) Normalise() *fieldVal {
 	// The secp256k1 prime is equivalent to 2^256 - 4294968273, so it fits
 	// this criteria.
 	//
-	// 4294968273 in field representation (base 2^26) is:
-	// n[0] = 977
-	// n[1] = 64
-	// That is to say (2^26 * 64) + 977 = 4294968273
-	//
 	// The algorithm presented in the referenced section typically repeats
 	// until the quotient is zero.  However, due to our field representation
 	// we already know to within one reduction how many times we would need
 	// to repeat as it's the uppermost bits of the high order word.  Thus we
-	// can simply multiply the magnitude by the field representation of the
-	// prime and do a single iteration.  After this step there might be an
-	// additional carry to bit 256 (bit 22 of the high order word).
-	t9 := f.n[9]
-	m := t9 >> fieldMSBBits
-	t9 = t9 & fieldMSBMask
-	t0 := f.n[0] + m*977
-	t1 := (t0 >> fieldBase) + f.n[1] + (m << 6)
-	t0 = t0 & fieldBaseMask
+	// can simply multiply the magnitude by 4294968273 and do a single
+	// iteration.  After this step there might be an additional carry to bit
+	// 256 (bit 48 of the high order word).
+	t4 := f.n[4]
+	m := t4 >> fieldMSBBits
+	t4 &= fieldMSBMask
+	t0 := f.n[0] + m*fieldReduction
+	t1 := (t0 >> fieldBase) + f.n[1]
+	t0 &= fieldBaseMask
 	t2 := (t1 >> fieldBase) + f.n[2]
-	t1 = t1 & fieldBaseMask
+	t1 &= fieldBaseMask
 	t3 := (t2 >> fieldBase) + f.n[3]
-	t2 = t2 & fieldBaseMask
-	t4 := (t3 >> fieldBase) + f.n[4]
-	t3 = t3 & fieldBaseMask
-	t5 := (t4 >> fieldBase) + f.n[5]
-	t4 = t4 & fieldBaseMask
-	t6 := (t5 >> fieldBase) + f.n[6]
-	t5 = t5 & fieldBaseMask
-	t7 := (t6 >> fieldBase) + f.n[7]
-	t6 = t6 & fieldBaseMask
-	t8 := (t7 >> fieldBase) + f.n[8]
-	t7 = t7 & fieldBaseMask
-	t9 = (t8 >> fieldBase) + t9
-	t8 = t8 & fieldBaseMask
+	t2 &= fieldBaseMask
+	t4 += t3 >> fieldBase
+	t3 &= fieldBaseMask
 
 	// At this point, the magnitude is guaranteed to be one, however, the
 	// value could still be greater than the prime if there was either a
-	// carry through to bit 256 (bit 22 of the higher order word) or the
+	// carry through to bit 256 (bit 48 of the higher order word) or the
 	// value is greater than or equal to the field characteristic.  The
 	// following determines if either or these conditions are true and does
 	// the final reduction in constant time.
 	//
-	// Note that the if/else statements here intentionally do the bitwise
-	// operators even when it won't change the value to ensure constant time
-	// between the branches.  Also note that 'm' will be zero when neither
-	// of the aforementioned conditions are true and the value will not be
-	// changed when 'm' is zero.
-	m = 1
-	if t9 == fieldMSBMask {
-		m &= 1
-	} else {
-		m &= 0
-	}
-	if t2&t3&t4&t5&t6&t7&t8 == fieldBaseMask {
-		m &= 1
-	} else {
-		m &= 0
-	}
-	if ((t0+977)>>fieldBase + t1 + 64) > fieldBaseMask {
-		m &= 1
-	} else {
-		m &= 0
-	}
-	if t9>>fieldMSBBits != 0 {
-		m |= 1
-	} else {
-		m |= 0
-	}
-	t0 = t0 + m*977
-	t1 = (t0 >> fieldBase) + t1 + (m << 6)
-	t0 = t0 & fieldBaseMask
-	t2 = (t1 >> fieldBase) + t2
-	t1 = t1 & fieldBaseMask
-	t3 = (t2 >> fieldBase) + t3
-	t2 = t2 & fieldBaseMask
-	t4 = (t3 >> fieldBase) + t4
-	t3 = t3 & fieldBaseMask
-	t5 = (t4 >> fieldBase) + t5
-	t4 = t4 & fieldBaseMask
-	t6 = (t5 >> fieldBase) + t6
-	t5 = t5 & fieldBaseMask
-	t7 = (t6 >> fieldBase) + t7
-	t6 = t6 & fieldBaseMask
-	t8 = (t7 >> fieldBase) + t8
-	t7 = t7 & fieldBaseMask
-	t9 = (t8 >> fieldBase) + t9
-	t8 = t8 & fieldBaseMask
-	t9 = t9 & fieldMSBMask // Remove potential multiple of 2^256.
+	// Note that 'm' will be zero when neither of the aforementioned
+	// conditions are true and the value will not be changed when 'm' is
+	// zero.
+	m = t4 >> fieldMSBBits
+	m |= isEqual(t4, fieldMSBMask) & isEqual(t1&t2&t3, fieldBaseMask) &
+		isAtLeast(t0, fieldPrimeWordZero)
+	t0 += m * fieldReduction
+	t1 += t0 >> fieldBase
+	t0 &= fieldBaseMask
+	t2 += t1 >> fieldBase
+	t1 &= fieldBaseMask
+	t3 += t2 >> fieldBase
+	t2 &= fieldBaseMask
+	t4 += t3 >> fieldBase
+	t3 &= fieldBaseMask
+	t4 &= fieldMSBMask // Remove potential multiple of 2^256.
 
 	// Finally, set the normalised and reduced words.
 	f.n[0] = t0
@@ -370,14 +313,21 @@ func (f *fieldVal) Normalise() *fieldVal {
 	f.n[2] = t2
 	f.n[3] = t3
 	f.n[4] = t4
-	f.n[5] = t5
-	f.n[6] = t6
-	f.n[7] = t7
-	f.n[8] = t8
-	f.n[9] = t9
 	return f
 }
 
+// isEqual returns 1 when a equals b and 0 otherwise, in constant time.  Both
+// values must be below 2^63.
+func isEqual(a, b uint64) uint64 {
+	return ((a ^ b) - 1) >> 63
+}
+
+// isAtLeast returns 1 when a is at least b and 0 otherwise, in constant time.
+// Both values must be below 2^63 and b must be positive.
+func isAtLeast(a, b uint64) uint64 {
+	return (b - 1 - a) >> 63
+}
+
 // PutBytes unpacks the field value to a 32-byte big-endian value using the
 // passed byte array.  There is a similar function, Bytes, which unpacks the
 // field value into a new array and returns that.  This version is provided
@@ -387,42 +337,12 @@ func (f *fieldVal) Normalise() *fieldVal {
 // The field value must be normalised for this function to return the correct
 // result.
 func (f *fieldVal) PutBytes(b *[32]byte) {
-	// Unpack the 256 total bits from the 10 uint32 words with a max of
-	// 26-bits per word.  This could be done with a couple of for loops,
-	// but this unrolled version is a bit faster.  Benchmarks show this is
-	// about 10 times faster than the variant which uses loops.
-	b[31] = byte(f.n[0] & eightBitsMask)
-	b[30] = byte((f.n[0] >> 8) & eightBitsMask)
-	b[29] = byte((f.n[0] >> 16) & eightBitsMask)
-	b[28] = byte((f.n[0]>>24)&twoBitsMask | (f.n[1]&sixBitsMask)<<2)
-	b[27] = byte((f.n[1] >> 6) & eightBitsMask)
-	b[26] = byte((f.n[1] >> 14) & eightBitsMask)
-	b[25] = byte((f.n[1]>>22)&fourBitsMask | (f.n[2]&fourBitsMask)<<4)
-	b[24] = byte((f.n[2] >> 4) & eightBitsMask)
-	b[23] = byte((f.n[2] >> 12) & eightBitsMask)
-	b[22] = byte((f.n[2]>>20)&sixBitsMask | (f.n[3]&twoBitsMask)<<6)
-	b[21] = byte((f.n[3] >> 2) & eightBitsMask)
-	b[20] = byte((f.n[3] >> 10) & eightBitsMask)
-	b[19] = byte((f.n[3] >> 18) & eightBitsMask)
-	b[18] = byte(f.n[4] & eightBitsMask)
-	b[17] = byte((f.n[4] >> 8) & eightBitsMask)
-	b[16] = byte((f.n[4] >> 16) & eightBitsMask)
-	b[15] = byte((f.n[4]>>24)&twoBitsMask | (f.n[5]&sixBitsMask)<<2)
-	b[14] = byte((f.n[5] >> 6) & eightBitsMask)
-	b[13] = byte((f.n[5] >> 14) & eightBitsMask)
-	b[12] = byte((f.n[5]>>22)&fourBitsMask | (f.n[6]&fourBitsMask)<<4)
-	b[11] = byte((f.n[6] >> 4) & eightBitsMask)
-	b[10] = byte((f.n[6] >> 12) & eightBitsMask)
-	b[9] = byte((f.n[6]>>20)&sixBitsMask | (f.n[7]&twoBitsMask)<<6)
-	b[8] = byte((f.n[7] >> 2) & eightBitsMask)
-	b[7] = byte((f.n[7] >> 10) & eightBitsMask)
-	b[6] = byte((f.n[7] >> 18) & eightBitsMask)
-	b[5] = byte(f.n[8] & eightBitsMask)
-	b[4] = byte((f.n[8] >> 8) & eightBitsMask)
-	b[3] = byte((f.n[8] >> 16) & eightBitsMask)
-	b[2] = byte((f.n[8]>>24)&twoBitsMask | (f.n[9]&sixBitsMask)<<2)
-	b[1] = byte((f.n[9] >> 6) & eightBitsMask)
-	b[0] = byte((f.n[9] >> 14) & eightBitsMask)
+	// Unpack the 256 total bits from the 5 uint64 words with a max of
+	// 52-bits per word into 4 64-bit words.
+	binary.BigEndian.PutUint64(b[24:], f.n[0]|f.n[1]<<52)
+	binary.BigEndian.PutUint64(b[16:], f.n[1]>>12|f.n[2]<<40)
+	binary.BigEndian.PutUint64(b[8:], f.n[2]>>24|f.n[3]<<28)
+	binary.BigEndian.PutUint64(b[0:], f.n[3]>>36|f.n[4]<<16)
 }
 
 // Bytes unpacks the field value to a 32-byte big-endian value.  See PutBytes
@@ -442,8 +362,7 @@ func (f *fieldVal) Bytes() *[32]byte {
 func (f *fieldVal) IsZero() bool {
 	// The value can only be zero if no bits are set in any of the words.
 	// This is a constant time implementation.
-	bits := f.n[0] | f.n[1] | f.n[2] | f.n[3] | f.n[4] |
-		f.n[5] | f.n[6] | f.n[7] | f.n[8] | f.n[9]
+	bits := f.n[0] | f.n[1] | f.n[2] | f.n[3] | f.n[4]
 
 	return bits == 0
 }
@@ -465,9 +384,7 @@ func (f *fieldVal) Equals(val *fieldVal) bool {
 	// can only be the same if no bits are set after xoring each word.
 	// This is a constant time implementation.
 	bits := (f.n[0] ^ val.n[0]) | (f.n[1] ^ val.n[1]) | (f.n[2] ^ val.n[2]) |
-		(f.n[3] ^ val.n[3]) | (f.n[4] ^ val.n[4]) | (f.n[5] ^ val.n[5]) |
-		(f.n[6] ^ val.n[6]) | (f.n[7] ^ val.n[7]) | (f.n[8] ^ val.n[8]) |
-		(f.n[9] ^ val.n[9])
+		(f.n[3] ^ val.n[3]) | (f.n[4] ^ val.n[4])
 
 	return bits == 0
 }
@@ -496,16 +413,15 @@ func (f *fieldVal) NegateVal(val *fieldVal, magnitude uint32) *fieldVal {
 	// multiple of the modulus is conguent to zero (mod m), the answer can
 	// be shortcut by simply mulplying the magnitude by the modulus and
 	// subtracting.  Keeping with the example, this would be (2*12)-19 = 5.
-	f.n[0] = (magnitude+1)*fieldPrimeWordZero - val.n[0]
-	f.n[1] = (magnitude+1)*fieldPrimeWordOne - val.n[1]
-	f.n[2] = (magnitude+1)*fieldBaseMask - val.n[2]
-	f.n[3] = (magnitude+1)*fieldBaseMask - val.n[3]
-	f.n[4] = (magnitude+1)*fieldBaseMask - val.n[4]
-	f.n[5] = (magnitude+1)*fieldBaseMask - val.n[5]
-	f.n[6] = (magnitude+1)*fieldBaseMask - val.n[6]
-	f.n[7] = (magnitude+1)*fieldBaseMask - val.n[7]
-	f.n[8] = (magnitude+1)*fieldBaseMask - val.n[8]
-	f.n[9] = (magnitude+1)*fieldMSBMask - val.n[9]
+	//
+	// The words of a value of magnitude m are at most 2*m times the mask of
+	// their position, hence the doubled multiples of the prime.
+	m := 2 * (uint64(magnitude) + 1)
+	f.n[0] = m*fieldPrimeWordZero - val.n[0]
+	f.n[1] = m*fieldBaseMask - val.n[1]
+	f.n[2] = m*fieldBaseMask - val.n[2]
+	f.n[3] = m*fieldBaseMask - val.n[3]
+	f.n[4] = m*fieldMSBMask - val.n[4]
 
 	return f
 }
@@ -529,7 +445,7 @@ func (f *fieldVal) AddInt(ui uint) *fieldVal {
 	// Since the field representation intentionally provides overflow bits,
 	// it's ok to use carryless addition as the carry bit is safely part of
 	// the word and will be normalised out.
-	f.n[0] += uint32(ui)
+	f.n[0] += uint64(ui)
 
 	return f
 }
@@ -549,11 +465,6 @@ func (f *fieldVal) Add(val *fieldVal) *fieldVal {
 	f.n[2] += val.n[2]
 	f.n[3] += val.n[3]
 	f.n[4] += val.n[4]
-	f.n[5] += val.n[5]
-	f.n[6] += val.n[6]
-	f.n[7] += val.n[7]
-	f.n[8] += val.n[8]
-	f.n[9] += val.n[9]
 
 	return f
 }
@@ -572,18 +483,13 @@ func (f *fieldVal) Add2(val *fieldVal, val2 *fieldVal) *fieldVal {
 	f.n[2] = val.n[2] + val2.n[2]
 	f.n[3] = val.n[3] + val2.n[3]
 	f.n[4] = val.n[4] + val2.n[4]
-	f.n[5] = val.n[5] + val2.n[5]
-	f.n[6] = val.n[6] + val2.n[6]
-	f.n[7] = val.n[7] + val2.n[7]
-	f.n[8] = val.n[8] + val2.n[8]
-	f.n[9] = val.n[9] + val2.n[9]
 
 	return f
 }
 
 // MulInt multiplies the field value by the passed int and stores the result in
 // f.  Note that this function can overflow if multiplying the value by any of
-// the individual words exceeds a max uint32.  Therefore it is important that
+// the individual words exceeds a max uint64.  Therefore it is important that
 // the caller ensures no overflows will occur before using this function.
 //
 // The field value is returned to support chaining.  This enables syntax like:
@@ -592,27 +498,22 @@ func (f *fieldVal) MulInt(val uint) *fieldVal {
 	// Since each word of the field representation can hold up to
 	// fieldOverflowBits extra bits which will be normalised out, it's safe
 	// to multiply each word without using a larger type or carry
-	// propagation so long as the values won't overflow a uint32.  This
+	// propagation so long as the values won't overflow a uint64.  This
 	// could obviously be done in a loop, but the unrolled version is
 	// faster.
-	ui := uint32(val)
+	ui := uint64(val)
 	f.n[0] *= ui
 	f.n[1] *= ui
 	f.n[2] *= ui
 	f.n[3] *= ui
 	f.n[4] *= ui
-	f.n[5] *= ui
-	f.n[6] *= ui
-	f.n[7] *= ui
-	f.n[8] *= ui
-	f.n[9] *= ui
 
 	return f
 }
 
 // Mul multiplies the passed value to the existing field value and stores the
 // result in f.  Note that this function can overflow if multiplying any
-// of the individual words exceeds a max uint32.  In practice, this means the
+// of the individual words exceeds a max uint64.  In practice, this means the
 // magnitude of either value involved in the multiplication must be a max of
 // 8.
 //
@@ -622,279 +523,117 @@ func (f *fieldVal) Mul(val *fieldVal) *fieldVal {
 	return f.Mul2(f, val)
 }
 
+// uint128 is an unsigned 128-bit integer used to accumulate the products of
+// the words of field values.
+type uint128 struct {
+	lo, hi uint64
+}
+
+// mul128 returns the 128-bit product of a and b.
+func mul128(a, b uint64) uint128 {
+	hi, lo := bits.Mul64(a, b)
+	return uint128{lo, hi}
+}
+
+// addMul returns x + a*b.  The sum must not overflow.
+func (x uint128) addMul(a, b uint64) uint128 {
+	hi, lo := bits.Mul64(a, b)
+	var carry uint64
+	x.lo, carry = bits.Add64(x.lo, lo, 0)
+	x.hi, _ = bits.Add64(x.hi, hi, carry)
+	return x
+}
+
+// add64 returns x + a.  The sum must not overflow.
+func (x uint128) add64(a uint64) uint128 {
+	var carry uint64
+	x.lo, carry = bits.Add64(x.lo, a, 0)
+	x.hi, _ = bits.Add64(x.hi, 0, carry)
+	return x
+}
+
+// shr52 returns x shifted right by fieldBase bits.
+func (x uint128) shr52() uint128 {
+	return uint128{x.lo>>fieldBase | x.hi<<(64-fieldBase), x.hi >> fieldBase}
+}
+
 // Mul2 multiplies the passed two field values together and stores the result
 // result in f.  Note that this function can overflow if multiplying any of
-// the individual words exceeds a max uint32.  In practice, this means the
+// the individual words exceeds a max uint64.  In practice, this means the
 // magnitude of either value involved in the multiplication must be a max of
 // 8.
 //
 // The field value is returned to support chaining.  This enables syntax like:
 // f3.Mul2(f, f2).AddInt(1) so that f3 = (f * f2) + 1.
 func (f *fieldVal) Mul2(val, val2 *fieldVal) *fieldVal {
-	// This could be done with a couple of for loops and an array to store
-	// the intermediate terms, but this unrolled version is significantly
-	// faster.
-
-	// Terms for 2^(fieldBase*0).
-	m := uint64(val.n[0]) * uint64(val2.n[0])
-	t0 := m & fieldBaseMask
-
-	// Terms for 2^(fieldBase*1).
-	m = (m >> fieldBase) +
-		uint64(val.n[0])*uint64(val2.n[1]) +
-		uint64(val.n[1])*uint64(val2.n[0])
-	t1 := m & fieldBaseMask
-
-	// Terms for 2^(fieldBase*2).
-	m = (m >> fieldBase) +
-		uint64(val.n[0])*uint64(val2.n[2]) +
-		uint64(val.n[1])*uint64(val2.n[1]) +
-		uint64(val.n[2])*uint64(val2.n[0])
-	t2 := m & fieldBaseMask
-
-	// Terms for 2^(fieldBase*3).
-	m = (m >> fieldBase) +
-		uint64(val.n[0])*uint64(val2.n[3]) +
-		uint64(val.n[1])*uint64(val2.n[2]) +
-		uint64(val.n[2])*uint64(val2.n[1]) +
-		uint64(val.n[3])*uint64(val2.n[0])
-	t3 := m & fieldBaseMask
+	// The product has 9 terms p0..p8, where pi sums the products of the
+	// words whose positions add up to i.  Terms from 2^(52*5) up are folded
+	// back in by multiplying them with 2^260 mod P = fieldReductionShifted,
+	// interleaving the low and high terms so that the 128-bit accumulators
+	// c and d never overflow.  This is the approach of libsecp256k1, see its
+	// secp256k1_fe_mul_inner for the bounds of each step.
+	a0, a1, a2, a3, a4 := val.n[0], val.n[1], val.n[2], val.n[3], val.n[4]
+	b0, b1, b2, b3, b4 := val2.n[0], val2.n[1], val2.n[2], val2.n[3], val2.n[4]
+
+	// Terms for 2^(fieldBase*3), folding in the one for 2^(fieldBase*8).
+	d := mul128(a0, b3).addMul(a1, b2).addMul(a2, b1).addMul(a3, b0)
+	c := mul128(a4, b4)
+	d = d.addMul(fieldReductionShifted, c.lo)
+	c8 := c.hi
+	t3 := d.lo & fieldBaseMask
+	d = d.shr52()
 
 	// Terms for 2^(fieldBase*4).
-	m = (m >> fieldBase) +
-		uint64(val.n[0])*uint64(val2.n[4]) +
-		uint64(val.n[1])*uint64(val2.n[3]) +
-		uint64(val.n[2])*uint64(val2.n[2]) +
-		uint64(val.n[3])*uint64(val2.n[1]) +
-		uint64(val.n[4])*uint64(val2.n[0])
-	t4 := m & fieldBaseMask
-
-	// Terms for 2^(fieldBase*5).
-	m = (m >> fieldBase) +
-		uint64(val.n[0])*uint64(val2.n[5]) +
-		uint64(val.n[1])*uint64(val2.n[4]) +
-		uint64(val.n[2])*uint64(val2.n[3]) +
-		uint64(val.n[3])*uint64(val2.n[2]) +
-		uint64(val.n[4])*uint64(val2.n[1]) +
-		uint64(val.n[5])*uint64(val2.n[0])
-	t5 := m & fieldBaseMask
-
-	// Terms for 2^(fieldBase*6).
-	m = (m >> fieldBase) +
-		uint64(val.n[0])*uint64(val2.n[6]) +
-		uint64(val.n[1])*uint64(val2.n[5]) +
-		uint64(val.n[2])*uint64(val2.n[4]) +
-		uint64(val.n[3])*uint64(val2.n[3]) +
-		uint64(val.n[4])*uint64(val2.n[2]) +
-		uint64(val.n[5])*uint64(val2.n[1]) +
-		uint64(val.n[6])*uint64(val2.n[0])
-	t6 := m & fieldBaseMask
-
-	// Terms for 2^(fieldBase*7).
-	m = (m >> fieldBase) +
-		uint64(val.n[0])*uint64(val2.n[7]) +
-		uint64(val.n[1])*uint64(val2.n[6]) +
-		uint64(val.n[2])*uint64(val2.n[5]) +
-		uint64(val.n[3])*uint64(val2.n[4]) +
-		uint64(val.n[4])*uint64(val2.n[3]) +
-		uint64(val.n[5])*uint64(val2.n[2]) +
-		uint64(val.n[6])*uint64(val2.n[1]) +
-		uint64(val.n[7])*uint64(val2.n[0])
-	t7 := m & fieldBaseMask
-
-	// Terms for 2^(fieldBase*8).
-	m = (m >> fieldBase) +
-		uint64(val.n[0])*uint64(val2.n[8]) +
-		uint64(val.n[1])*uint64(val2.n[7]) +
-		uint64(val.n[2])*uint64(val2.n[6]) +
-		uint64(val.n[3])*uint64(val2.n[5]) +
-		uint64(val.n[4])*uint64(val2.n[4]) +
-		uint64(val.n[5])*uint64(val2.n[3]) +
-		uint64(val.n[6])*uint64(val2.n[2]) +
-		uint64(val.n[7])*uint64(val2.n[1]) +
-		uint64(val.n[8])*uint64(val2.n[0])
-	t8 := m & fieldBaseMask
-
-	// Terms for 2^(fieldBase*9).
-	m = (m >> fieldBase) +
-		uint64(val.n[0])*uint64(val2.n[9]) +
-		uint64(val.n[1])*uint64(val2.n[8]) +
-		uint64(val.n[2])*uint64(val2.n[7]) +
-		uint64(val.n[3])*uint64(val2.n[6]) +
-		uint64(val.n[4])*uint64(val2.n[5]) +
-		uint64(val.n[5])*uint64(val2.n[4]) +
-		uint64(val.n[6])*uint64(val2.n[3]) +
-		uint64(val.n[7])*uint64(val2.n[2]) +
-		uint64(val.n[8])*uint64(val2.n[1]) +
-		uint64(val.n[9])*uint64(val2.n[0])
-	t9 := m & fieldBaseMask
-
-	// Terms for 2^(fieldBase*10).
-	m = (m >> fieldBase) +
-		uint64(val.n[1])*uint64(val2.n[9]) +
-		uint64(val.n[2])*uint64(val2.n[8]) +
-		uint64(val.n[3])*uint64(val2.n[7]) +
-		uint64(val.n[4])*uint64(val2.n[6]) +
-		uint64(val.n[5])*uint64(val2.n[5]) +
-		uint64(val.n[6])*uint64(val2.n[4]) +
-		uint64(val.n[7])*uint64(val2.n[3]) +
-		uint64(val.n[8])*uint64(val2.n[2]) +
-		uint64(val.n[9])*uint64(val2.n[1])
-	t10 := m & fieldBaseMask
-
-	// Terms for 2^(fieldBase*11).
-	m = (m >> fieldBase) +
-		uint64(val.n[2])*uint64(val2.n[9]) +
-		uint64(val.n[3])*uint64(val2.n[8]) +
-		uint64(val.n[4])*uint64(val2.n[7]) +
-		uint64(val.n[5])*uint64(val2.n[6]) +
-		uint64(val.n[6])*uint64(val2.n[5]) +
-		uint64(val.n[7])*uint64(val2.n[4]) +
-		uint64(val.n[8])*uint64(val2.n[3]) +
-		uint64(val.n[9])*uint64(val2.n[2])
-	t11 := m & fieldBaseMask
-
-	// Terms for 2^(fieldBase*12).
-	m = (m >> fieldBase) +
-		uint64(val.n[3])*uint64(val2.n[9]) +
-		uint64(val.n[4])*uint64(val2.n[8]) +
-		uint64(val.n[5])*uint64(val2.n[7]) +
-		uint64(val.n[6])*uint64(val2.n[6]) +
-		uint64(val.n[7])*uint64(val2.n[5]) +
-		uint64(val.n[8])*uint64(val2.n[4]) +
-		uint64(val.n[9])*uint64(val2.n[3])
-	t12 := m & fieldBaseMask
-
-	// Terms for 2^(fieldBase*13).
-	m = (m >> fieldBase) +
-		uint64(val.n[4])*uint64(val2.n[9]) +
-		uint64(val.n[5])*uint64(val2.n[8]) +
-		uint64(val.n[6])*uint64(val2.n[7]) +
-		uint64(val.n[7])*uint64(val2.n[6]) +
-		uint64(val.n[8])*uint64(val2.n[5]) +
-		uint64(val.n[9])*uint64(val2.n[4])
-	t13 := m & fieldBaseMask
-
-	// Terms for 2^(fieldBase*14).
-	m = (m >> fieldBase) +
-		uint64(val.n[5])*uint64(val2.n[9]) +
-		uint64(val.n[6])*uint64(val2.n[8]) +
-		uint64(val.n[7])*uint64(val2.n[7]) +
-		uint64(val.n[8])*uint64(val2.n[6]) +
-		uint64(val.n[9])*uint64(val2.n[5])
-	t14 := m & fieldBaseMask
-
-	// Terms for 2^(fieldBase*15).
-	m = (m >> fieldBase) +
-		uint64(val.n[6])*uint64(val2.n[9]) +
-		uint64(val.n[7])*uint64(val2.n[8]) +
-		uint64(val.n[8])*uint64(val2.n[7]) +
-		uint64(val.n[9])*uint64(val2.n[6])
-	t15 := m & fieldBaseMask
-
-	// Terms for 2^(fieldBase*16).
-	m = (m >> fieldBase) +
-		uint64(val.n[7])*uint64(val2.n[9]) +
-		uint64(val.n[8])*uint64(val2.n[8]) +
-		uint64(val.n[9])*uint64(val2.n[7])
-	t16 := m & fieldBaseMask
-
-	// Terms for 2^(fieldBase*17).
-	m = (m >> fieldBase) +
-		uint64(val.n[8])*uint64(val2.n[9]) +
-		uint64(val.n[9])*uint64(val2.n[8])
-	t17 := m & fieldBaseMask
-
-	// Terms for 2^(fieldBase*18).
-	m = (m >> fieldBase) + uint64(val.n[9])*uint64(val2.n[9])
-	t18 := m & fieldBaseMask
-
-	// What's left is for 2^(fieldBase*19).
-	t19 := m >> fieldBase
-
-	// At this point, all of the terms are grouped into their respective
-	// base.
-	//
-	// Per [HAC] section 14.3.4: Reduction method of moduli of special form,
-	// when the modulus is of the special form m = b^t - c, highly efficient
-	// reduction can be achieved per the provided algorithm.
-	//
-	// The secp256k1 prime is equivalent to 2^256 - 4294968273, so it fits
-	// this criteria.
-	//
-	// 4294968273 in field representation (base 2^26) is:
-	// n[0] = 977
-	// n[1] = 64
-	// That is to say (2^26 * 64) + 977 = 4294968273
-	//
-	// Since each word is in base 26, the upper terms (t10 and up) start
-	// at 260 bits (versus the final desired range of 256 bits), so the
-	// field representation of 'c' from above needs to be adjusted for the
-	// extra 4 bits by multiplying it by 2^4 = 16.  4294968273 * 16 =
-	// 68719492368.  Thus, the adjusted field representation of 'c' is:
-	// n[0] = 977 * 16 = 15632
-	// n[1] = 64 * 16 = 1024
-	// That is to say (2^26 * 1024) + 15632 = 68719492368
-	//
-	// To reduce the final term, t19, the entire 'c' value is needed instead
-	// of only n[0] because there are no more terms left to handle n[1].
-	// This means there might be some magnitude left in the upper bits that
-	// is handled below.
-	m = t0 + t10*15632
-	t0 = m & fieldBaseMask
-	m = (m >> fieldBase) + t1 + t10*1024 + t11*15632
-	t1 = m & fieldBaseMask
-	m = (m >> fieldBase) + t2 + t11*1024 + t12*15632
-	t2 = m & fieldBaseMask
-	m = (m >> fieldBase) + t3 + t12*1024 + t13*15632
-	t3 = m & fieldBaseMask
-	m = (m >> fieldBase) + t4 + t13*1024 + t14*15632
-	t4 = m & fieldBaseMask
-	m = (m >> fieldBase) + t5 + t14*1024 + t15*15632
-	t5 = m & fieldBaseMask
-	m = (m >> fieldBase) + t6 + t15*1024 + t16*15632
-	t6 = m & fieldBaseMask
-	m = (m >> fieldBase) + t7 + t16*1024 + t17*15632
-	t7 = m & fieldBaseMask
-	m = (m >> fieldBase) + t8 + t17*1024 + t18*15632
-	t8 = m & fieldBaseMask
-	m = (m >> fieldBase) + t9 + t18*1024 + t19*68719492368
-	t9 = m & fieldMSBMask
-	m = m >> fieldMSBBits
-
-	// At this point, if the magnitude is greater than 0, the overall value
-	// is greater than the max possible 256-bit value.  In particular, it is
-	// "how many times larger" than the max value it is.
-	//
-	// The algorithm presented in [HAC] section 14.3.4 repeats until the
-	// quotient is zero.  However, due to the above, we already know at
-	// least how many times we would need to repeat as it's the value
-	// currently in m.  Thus we can simply multiply the magnitude by the
-	// field representation of the prime and do a single iteration.  Notice
-	// that nothing will be changed when the magnitude is zero, so we could
-	// skip this in that case, however always running regardless allows it
-	// to run in constant time.  The final result will be in the range
-	// 0 <= result <= prime + (2^64 - c), so it is guaranteed to have a
-	// magnitude of 1, but it is denormalised.
-	d := t0 + m*977
-	f.n[0] = uint32(d & fieldBaseMask)
-	d = (d >> fieldBase) + t1 + m*64
-	f.n[1] = uint32(d & fieldBaseMask)
-	f.n[2] = uint32((d >> fieldBase) + t2)
-	f.n[3] = uint32(t3)
-	f.n[4] = uint32(t4)
-	f.n[5] = uint32(t5)
-	f.n[6] = uint32(t6)
-	f.n[7] = uint32(t7)
-	f.n[8] = uint32(t8)
-	f.n[9] = uint32(t9)
+	d = d.addMul(a0, b4).addMul(a1, b3).addMul(a2, b2).addMul(a3, b1).addMul(a4, b0)
+	d = d.addMul(fieldReductionShifted<<12, c8)
+	t4 := d.lo & fieldBaseMask
+	d = d.shr52()
+	tx := t4 >> fieldMSBBits
+	t4 &= fieldMSBMask
+
+	// Terms for 2^(fieldBase*0), folding in the one for 2^(fieldBase*5).
+	c = mul128(a0, b0)
+	d = d.addMul(a1, b4).addMul(a2, b3).addMul(a3, b2).addMul(a4, b1)
+	u0 := d.lo & fieldBaseMask
+	d = d.shr52()
+	u0 = u0<<4 | tx
+	c = c.addMul(u0, fieldReduction)
+	r0 := c.lo & fieldBaseMask
+	c = c.shr52()
+
+	// Terms for 2^(fieldBase*1), folding in the one for 2^(fieldBase*6).
+	c = c.addMul(a0, b1).addMul(a1, b0)
+	d = d.addMul(a2, b4).addMul(a3, b3).addMul(a4, b2)
+	c = c.addMul(d.lo&fieldBaseMask, fieldReductionShifted)
+	d = d.shr52()
+	r1 := c.lo & fieldBaseMask
+	c = c.shr52()
+
+	// Terms for 2^(fieldBase*2), folding in the one for 2^(fieldBase*7).
+	c = c.addMul(a0, b2).addMul(a1, b1).addMul(a2, b0)
+	d = d.addMul(a3, b4).addMul(a4, b3)
+	c = c.addMul(fieldReductionShifted, d.lo)
+	d7 := d.hi
+	r2 := c.lo & fieldBaseMask
+	c = c.shr52()
+
+	// Carry into the terms for 2^(fieldBase*3) and 2^(fieldBase*4).
+	c = c.addMul(fieldReductionShifted<<12, d7).add64(t3)
+	r3 := c.lo & fieldBaseMask
+	c = c.shr52()
+
+	f.n[0] = r0
+	f.n[1] = r1
+	f.n[2] = r2
+	f.n[3] = r3
+	f.n[4] = c.lo + t4
 
 	return f
 }
 
 // Square squares the field value.  The existing field value is modified.  Note
 // that this function can overflow if multiplying any of the individual words
-// exceeds a max uint32.  In practice, this means the magnitude of the field
+// exceeds a max uint64.  In practice, this means the magnitude of the field
 // must be a max of 8 to prevent overflow.
 //
 // The field value is returned to support chaining.  This enables syntax like:
@@ -905,226 +644,84 @@ func (f *fieldVal) Square() *fieldVal {
 
 // SquareVal squares the passed value and stores the result in f.  Note that
 // this function can overflow if multiplying any of the individual words
-// exceeds a max uint32.  In practice, this means the magnitude of the field
+// exceeds a max uint64.  In practice, this means the magnitude of the field
 // being squred must be a max of 8 to prevent overflow.
 //
 // The field value is returned to support chaining.  This enables syntax like:
 // f3.SquareVal(f).Mul(f) so that f3 = f^2 * f = f^3.
 func (f *fieldVal) SquareVal(val *fieldVal) *fieldVal {
-	// This could be done with a couple of for loops and an array to store
-	// the intermediate terms, but this unrolled version is significantly
-	// faster.
-
-	// Terms for 2^(fieldBase*0).
-	m := uint64(val.n[0]) * uint64(val.n[0])
-	t0 := m & fieldBaseMask
-
-	// Terms for 2^(fieldBase*1).
-	m = (m >> fieldBase) + 2*uint64(val.n[0])*uint64(val.n[1])
-	t1 := m & fieldBaseMask
-
-	// Terms for 2^(fieldBase*2).
-	m = (m >> fieldBase) +
-		2*uint64(val.n[0])*uint64(val.n[2]) +
-		uint64(val.n[1])*uint64(val.n[1])
-	t2 := m & fieldBaseMask
-
-	// Terms for 2^(fieldBase*3).
-	m = (m >> fieldBase) +
-		2*uint64(val.n[0])*uint64(val.n[3]) +
-		2*uint64(val.n[1])*uint64(val.n[2])
-	t3 := m & fieldBaseMask
+	// This follows Mul2, computing the products of distinct words once and
+	// doubling them.
+	a0, a1, a2, a3, a4 := val.n[0], val.n[1], val.n[2], val.n[3], val.n[4]
+
+	// Terms for 2^(fieldBase*3), folding in the one for 2^(fieldBase*8).
+	d := mul128(a0*2, a3).addMul(a1*2, a2)
+	c := mul128(a4, a4)
+	d = d.addMul(fieldReductionShifted, c.lo)
+	c8 := c.hi
+	t3 := d.lo & fieldBaseMask
+	d = d.shr52()
 
 	// Terms for 2^(fieldBase*4).
-	m = (m >> fieldBase) +
-		2*uint64(val.n[0])*uint64(val.n[4]) +
-		2*uint64(val.n[1])*uint64(val.n[3]) +
-		uint64(val.n[2])*uint64(val.n[2])
-	t4 := m & fieldBaseMask
-
-	// Terms for 2^(fieldBase*5).
-	m = (m >> fieldBase) +
-		2*uint64(val.n[0])*uint64(val.n[5]) +
-		2*uint64(val.n[1])*uint64(val.n[4]) +
-		2*uint64(val.n[2])*uint64(val.n[3])
-	t5 := m & fieldBaseMask
-
-	// Terms for 2^(fieldBase*6).
-	m = (m >> fieldBase) +
-		2*uint64(val.n[0])*uint64(val.n[6]) +
-		2*uint64(val.n[1])*uint64(val.n[5]) +
-		2*uint64(val.n[2])*uint64(val.n[4]) +
-		uint64(val.n[3])*uint64(val.n[3])
-	t6 := m & fieldBaseMask
-
-	// Terms for 2^(fieldBase*7).
-	m = (m >> fieldBase) +
-		2*uint64(val.n[0])*uint64(val.n[7]) +
-		2*uint64(val.n[1])*uint64(val.n[6]) +
-		2*uint64(val.n[2])*uint64(val.n[5]) +
-		2*uint64(val.n[3])*uint64(val.n[4])
-	t7 := m & fieldBaseMask
-
-	// Terms for 2^(fieldBase*8).
-	m = (m >> fieldBase) +
-		2*uint64(val.n[0])*uint64(val.n[8]) +
-		2*uint64(val.n[1])*uint64(val.n[7]) +
-		2*uint64(val.n[2])*uint64(val.n[6]) +
-		2*uint64(val.n[3])*uint64(val.n[5]) +
-		uint64(val.n[4])*uint64(val.n[4])
-	t8 := m & fieldBaseMask
-
-	// Terms for 2^(fieldBase*9).
-	m = (m >> fieldBase) +
-		2*uint64(val.n[0])*uint64(val.n[9]) +
-		2*uint64(val.n[1])*uint64(val.n[8]) +
-		2*uint64(val.n[2])*uint64(val.n[7]) +
-		2*uint64(val.n[3])*uint64(val.n[6]) +
-		2*uint64(val.n[4])*uint64(val.n[5])
-	t9 := m & fieldBaseMask
-
-	// Terms for 2^(fieldBase*10).
-	m = (m >> fieldBase) +
-		2*uint64(val.n[1])*uint64(val.n[9]) +
-		2*uint64(val.n[2])*uint64(val.n[8]) +
-		2*uint64(val.n[3])*uint64(val.n[7]) +
-		2*uint64(val.n[4])*uint64(val.n[6]) +
-		uint64(val.n[5])*uint64(val.n[5])
-	t10 := m & fieldBaseMask
-
-	// Terms for 2^(fieldBase*11).
-	m = (m >> fieldBase) +
-		2*uint64(val.n[2])*uint64(val.n[9]) +
-		2*uint64(val.n[3])*uint64(val.n[8]) +
-		2*uint64(val.n[4])*uint64(val.n[7]) +
-		2*uint64(val.n[5])*uint64(val.n[6])
-	t11 := m & fieldBaseMask
-
-	// Terms for 2^(fieldBase*12).
-	m = (m >> fieldBase) +
-		2*uint64(val.n[3])*uint64(val.n[9]) +
-		2*uint64(val.n[4])*uint64(val.n[8]) +
-		2*uint64(val.n[5])*uint64(val.n[7]) +
-		uint64(val.n[6])*uint64(val.n[6])
-	t12 := m & fieldBaseMask
-
-	// Terms for 2^(fieldBase*13).
-	m = (m >> fieldBase) +
-		2*uint64(val.n[4])*uint64(val.n[9]) +
-		2*uint64(val.n[5])*uint64(val.n[8]) +
-		2*uint64(val.n[6])*uint64(val.n[7])
-	t13 := m & fieldBaseMask
-
-	// Terms for 2^(fieldBase*14).
-	m = (m >> fieldBase) +
-		2*uint64(val.n[5])*uint64(val.n[9]) +
-		2*uint64(val.n[6])*uint64(val.n[8]) +
-		uint64(val.n[7])*uint64(val.n[7])
-	t14 := m & fieldBaseMask
-
-	// Terms for 2^(fieldBase*15).
-	m = (m >> fieldBase) +
-		2*uint64(val.n[6])*uint64(val.n[9]) +
-		2*uint64(val.n[7])*uint64(val.n[8])
-	t15 := m & fieldBaseMask
-
-	// Terms for 2^(fieldBase*16).
-	m = (m >> fieldBase) +
-		2*uint64(val.n[7])*uint64(val.n[9]) +
-		uint64(val.n[8])*uint64(val.n[8])
-	t16 := m & fieldBaseMask
-
-	// Terms for 2^(fieldBase*17).
-	m = (m >> fieldBase) + 2*uint64(val.n[8])*uint64(val.n[9])
-	t17 := m & fieldBaseMask
-
-	// Terms for 2^(fieldBase*18).
-	m = (m >> fieldBase) + uint64(val.n[9])*uint64(val.n[9])
-	t18 := m & fieldBaseMask
-
-	// What's left is for 2^(fieldBase*19).
-	t19 := m >> fieldBase
-
-	// At this point, all of the terms are grouped into their respective
-	// base.
-	//
-	// Per [HAC] section 14.3.4: Reduction method of moduli of special form,
-	// when the modulus is of the special form m = b^t - c, highly efficient
-	// reduction can be achieved per the provided algorithm.
-	//
-	// The secp256k1 prime is equivalent to 2^256 - 4294968273, so it fits
-	// this criteria.
-	//
-	// 4294968273 in field representation (base 2^26) is:
-	// n[0] = 977
-	// n[1] = 64
-	// That is to say (2^26 * 64) + 977 = 4294968273
-	//
-	// Since each word is in base 26, the upper terms (t10 and up) start
-	// at 260 bits (versus the final desired range of 256 bits), so the
-	// field representation of 'c' from above needs to be adjusted for the
-	// extra 4 bits by multiplying it by 2^4 = 16.  4294968273 * 16 =
-	// 68719492368.  Thus, the adjusted field representation of 'c' is:
-	// n[0] = 977 * 16 = 15632
-	// n[1] = 64 * 16 = 1024
-	// That is to say (2^26 * 1024) + 15632 = 68719492368
-	//
-	// To reduce the final term, t19, the entire 'c' value is needed instead
-	// of only n[0] because there are no more terms left to handle n[1].
-	// This means there might be some magnitude left in the upper bits that
-	// is handled below.
-	m = t0 + t10*15632
-	t0 = m & fieldBaseMask
-	m = (m >> fieldBase) + t1 + t10*1024 + t11*15632
-	t1 = m & fieldBaseMask
-	m = (m >> fieldBase) + t2 + t11*1024 + t12*15632
-	t2 = m & fieldBaseMask
-	m = (m >> fieldBase) + t3 + t12*1024 + t13*15632
-	t3 = m & fieldBaseMask
-	m = (m >> fieldBase) + t4 + t13*1024 + t14*15632
-	t4 = m & fieldBaseMask
-	m = (m >> fieldBase) + t5 + t14*1024 + t15*15632
-	t5 = m & fieldBaseMask
-	m = (m >> fieldBase) + t6 + t15*1024 + t16*15632
-	t6 = m & fieldBaseMask
-	m = (m >> fieldBase) + t7 + t16*1024 + t17*15632
-	t7 = m & fieldBaseMask
-	m = (m >> fieldBase) + t8 + t17*1024 + t18*15632
-	t8 = m & fieldBaseMask
-	m = (m >> fieldBase) + t9 + t18*1024 + t19*68719492368
-	t9 = m & fieldMSBMask
-	m = m >> fieldMSBBits
-
-	// At this point, if the magnitude is greater than 0, the overall value
-	// is greater than the max possible 256-bit value.  In particular, it is
-	// "how many times larger" than the max value it is.
-	//
-	// The algorithm presented in [HAC] section 14.3.4 repeats until the
-	// quotient is zero.  However, due to the above, we already know at
-	// least how many times we would need to repeat as it's the value
-	// currently in m.  Thus we can simply multiply the magnitude by the
-	// field representation of the prime and do a single iteration.  Notice
-	// that nothing will be changed when the magnitude is zero, so we could
-	// skip this in that case, however always running regardless allows it
-	// to run in constant time.  The final result will be in the range
-	// 0 <= result <= prime + (2^64 - c), so it is guaranteed to have a
-	// magnitude of 1, but it is denormalised.
-	n := t0 + m*977
-	f.n[0] = uint32(n & fieldBaseMask)
-	n = (n >> fieldBase) + t1 + m*64
-	f.n[1] = uint32(n & fieldBaseMask)
-	f.n[2] = uint32((n >> fieldBase) + t2)
-	f.n[3] = uint32(t3)
-	f.n[4] = uint32(t4)
-	f.n[5] = uint32(t5)
-	f.n[6] = uint32(t6)
-	f.n[7] = uint32(t7)
-	f.n[8] = uint32(t8)
-	f.n[9] = uint32(t9)
+	a4 *= 2
+	d = d.addMul(a0, a4).addMul(a1*2, a3).addMul(a2, a2)
+	d = d.addMul(fieldReductionShifted<<12, c8)
+	t4 := d.lo & fieldBaseMask
+	d = d.shr52()
+	tx := t4 >> fieldMSBBits
+	t4 &= fieldMSBMask
+
+	// Terms for 2^(fieldBase*0), folding in the one for 2^(fieldBase*5).
+	c = mul128(a0, a0)
+	d = d.addMul(a1, a4).addMul(a2*2, a3)
+	u0 := d.lo & fieldBaseMask
+	d = d.shr52()
+	u0 = u0<<4 | tx
+	c = c.addMul(u0, fieldReduction)
+	r0 := c.lo & fieldBaseMask
+	c = c.shr52()
+
+	// Terms for 2^(fieldBase*1), folding in the one for 2^(fieldBase*6).
+	a0 *= 2
+	c = c.addMul(a0, a1)
+	d = d.addMul(a2, a4).addMul(a3, a3)
+	c = c.addMul(d.lo&fieldBaseMask, fieldReductionShifted)
+	d = d.shr52()
+	r1 := c.lo & fieldBaseMask
+	c = c.shr52()
+
+	// Terms for 2^(fieldBase*2), folding in the one for 2^(fieldBase*7).
+	c = c.addMul(a0, a2).addMul(a1, a1)
+	d = d.addMul(a3, a4)
+	c = c.addMul(fieldReductionShifted, d.lo)
+	d7 := d.hi
+	r2 := c.lo & fieldBaseMask
+	c = c.shr52()
+
+	// Carry into the terms for 2^(fieldBase*3) and 2^(fieldBase*4).
+	c = c.addMul(fieldReductionShifted<<12, d7).add64(t3)
+	r3 := c.lo & fieldBaseMask
+	c = c.shr52()
+
+	f.n[0] = r0
+	f.n[1] = r1
+	f.n[2] = r2
+	f.n[3] = r3
+	f.n[4] = c.lo + t4
 
 	return f
 }
 
+// InverseVarTime finds the modular multiplicative inverse of the field value
+// like Inverse, using math/big which is several times faster.  Its running time
+// depends on the value, so it must only be used on values derived from public
+// data, such as the points of a signature verification.
+func (f *fieldVal) InverseVarTime() *fieldVal {
+	v := new(big.Int).SetBytes(f.Normalise().Bytes()[:])
+	v.ModInverse(v, fieldPrime)
+	return f.SetByteSlice(v.Bytes())
+}
+
 // Inverse finds the modular multiplicative inverse of the field value.  The
 // existing field value is modified.
 //
@@ -1141,97 +738,40 @@ func (f *fieldVal) Inverse() *fieldVal {
 	// multiplications needed (since they are more costly than squarings).
 	// Intermediate results are saved and reused as well.
 	//
-	// The secp256k1 prime - 2 is 2^256 - 4294968275.
-	//
-	// This has a cost of 258 field squarings and 33 field multiplications.
-	var a2, a3, a4, a10, a11, a21, a42, a45, a63, a1019, a1023 fieldVal
-	a2.SquareVal(f)
-	a3.Mul2(&a2, f)
-	a4.SquareVal(&a2)
-	a10.SquareVal(&a4).Mul(&a2)
-	a11.Mul2(&a10, f)
-	a21.Mul2(&a10, &a11)
-	a42.SquareVal(&a21)
-	a45.Mul2(&a42, &a3)
-	a63.Mul2(&a42, &a21)
-	a1019.SquareVal(&a63).Square().Square().Square().Mul(&a11)
-	a1023.Mul2(&a1019, &a4)
-	f.Set(&a63)                                    // f = a^(2^6 - 1)
-	f.Square().Square().Square().Square().Square() // f = a^(2^11 - 32)
-	f.Square().Square().Square().Square().Square() // f = a^(2^16 - 1024)
-	f.Mul(&a1023)                                  // f = a^(2^16 - 1)
-	f.Square().Square().Square().Square().Square() // f = a^(2^21 - 32)
-	f.Square().Square().Square().Square().Square() // f = a^(2^26 - 1024)
-	f.Mul(&a1023)                                  // f = a^(2^26 - 1)
-	f.Square().Square().Square().Square().Square() // f = a^(2^31 - 32)
-	f.Square().Square().Square().Square().Square() // f = a^(2^36 - 1024)
-	f.Mul(&a1023)                                  // f = a^(2^36 - 1)
-	f.Square().Square().Square().Square().Square() // f = a^(2^41 - 32)
-	f.Square().Square().Square().Square().Square() // f = a^(2^46 - 1024)
-	f.Mul(&a1023)                                  // f = a^(2^46 - 1)
-	f.Square().Square().Square().Square().Square() // f = a^(2^51 - 32)
-	f.Square().Square().Square().Square().Square() // f = a^(2^56 - 1024)
-	f.Mul(&a1023)                                  // f = a^(2^56 - 1)
-	f.Square().Square().Square().Square().Square() // f = a^(2^61 - 32)
-	f.Square().Square().Square().Square().Square() // f = a^(2^66 - 1024)
-	f.Mul(&a1023)                                  // f = a^(2^66 - 1)
-	f.Square().Square().Square().Square().Square() // f = a^(2^71 - 32)
-	f.Square().Square().Square().Square().Square() // f = a^(2^76 - 1024)
-	f.Mul(&a1023)                                  // f = a^(2^76 - 1)
-	f.Square().Square().Square().Square().Square() // f = a^(2^81 - 32)
-	f.Square().Square().Square().Square().Square() // f = a^(2^86 - 1024)
-	f.Mul(&a1023)                                  // f = a^(2^86 - 1)
-	f.Square().Square().Square().Square().Square() // f = a^(2^91 - 32)
-	f.Square().Square().Square().Square().Square() // f = a^(2^96 - 1024)
-	f.Mul(&a1023)                                  // f = a^(2^96 - 1)
-	f.Square().Square().Square().Square().Square() // f = a^(2^101 - 32)
-	f.Square().Square().Square().Square().Square() // f = a^(2^106 - 1024)
-	f.Mul(&a1023)                                  // f = a^(2^106 - 1)
-	f.Square().Square().Square().Square().Square() // f = a^(2^111 - 32)
-	f.Square().Square().Square().Square().Square() // f = a^(2^116 - 1024)
-	f.Mul(&a1023)                                  // f = a^(2^116 - 1)
-	f.Square().Square().Square().Square().Square() // f = a^(2^121 - 32)
-	f.Square().Square().Square().Square().Square() // f = a^(2^126 - 1024)
-	f.Mul(&a1023)                                  // f = a^(2^126 - 1)
-	f.Square().Square().Square().Square().Square() // f = a^(2^131 - 32)
-	f.Square().Square().Square().Square().Square() // f = a^(2^136 - 1024)
-	f.Mul(&a1023)                                  // f = a^(2^136 - 1)
-	f.Square().Square().Square().Square().Square() // f = a^(2^141 - 32)
-	f.Square().Square().Square().Square().Square() // f = a^(2^146 - 1024)
-	f.Mul(&a1023)                                  // f = a^(2^146 - 1)
-	f.Square().Square().Square().Square().Square() // f = a^(2^151 - 32)
-	f.Square().Square().Square().Square().Square() // f = a^(2^156 - 1024)
-	f.Mul(&a1023)                                  // f = a^(2^156 - 1)
-	f.Square().Square().Square().Square().Square() // f = a^(2^161 - 32)
-	f.Square().Square().Square().Square().Square() // f = a^(2^166 - 1024)
-	f.Mul(&a1023)                                  // f = a^(2^166 - 1)
-	f.Square().Square().Square().Square().Square() // f = a^(2^171 - 32)
-	f.Square().Square().Square().Square().Square() // f = a^(2^176 - 1024)
-	f.Mul(&a1023)                                  // f = a^(2^176 - 1)
-	f.Square().Square().Square().Square().Square() // f = a^(2^181 - 32)
-	f.Square().Square().Square().Square().Square() // f = a^(2^186 - 1024)
-	f.Mul(&a1023)                                  // f = a^(2^186 - 1)
-	f.Square().Square().Square().Square().Square() // f = a^(2^191 - 32)
-	f.Square().Square().Square().Square().Square() // f = a^(2^196 - 1024)
-	f.Mul(&a1023)                                  // f = a^(2^196 - 1)
-	f.Square().Square().Square().Square().Square() // f = a^(2^201 - 32)
-	f.Square().Square().Square().Square().Square() // f = a^(2^206 - 1024)
-	f.Mul(&a1023)                                  // f = a^(2^206 - 1)
-	f.Square().Square().Square().Square().Square() // f = a^(2^211 - 32)
-	f.Square().Square().Square().Square().Square() // f = a^(2^216 - 1024)
-	f.Mul(&a1023)                                  // f = a^(2^216 - 1)
-	f.Square().Square().Square().Square().Square() // f = a^(2^221 - 32)
-	f.Square().Square().Square().Square().Square() // f = a^(2^226 - 1024)
-	f.Mul(&a1019)                                  // f = a^(2^226 - 5)
-	f.Square().Square().Square().Square().Square() // f = a^(2^231 - 160)
-	f.Square().Square().Square().Square().Square() // f = a^(2^236 - 5120)
-	f.Mul(&a1023)                                  // f = a^(2^236 - 4097)
-	f.Square().Square().Square().Square().Square() // f = a^(2^241 - 131104)
-	f.Square().Square().Square().Square().Square() // f = a^(2^246 - 4195328)
-	f.Mul(&a1023)                                  // f = a^(2^246 - 4194305)
-	f.Square().Square().Square().Square().Square() // f = a^(2^251 - 134217760)
-	f.Square().Square().Square().Square().Square() // f = a^(2^256 - 4294968320)
-	return f.Mul(&a45)                             // f = a^(2^256 - 4294968275) = a^(p-2)
+	// The secp256k1 prime - 2 is 2^256 - 4294968275, whose binary
+	// representation consists of blocks of ones of the lengths 223, 22, 1,
+	// 2 and 1.  The addition chain below, the one libsecp256k1 uses,
+	// computes the powers xn = a^(2^n - 1) those blocks are built from.
+	//
+	// This has a cost of 255 field squarings and 15 field multiplications.
+	var a, x2, x3, x6, x9, x11, x22, x44, x88, x176, x220, x223 fieldVal
+	a.Set(f)
+	x2.SquareVal(&a).Mul(&a)
+	x3.SquareVal(&x2).Mul(&a)
+	x6.Set(&x3).squareN(3).Mul(&x3)
+	x9.Set(&x6).squareN(3).Mul(&x3)
+	x11.Set(&x9).squareN(2).Mul(&x2)
+	x22.Set(&x11).squareN(11).Mul(&x11)
+	x44.Set(&x22).squareN(22).Mul(&x22)
+	x88.Set(&x44).squareN(44).Mul(&x44)
+	x176.Set(&x88).squareN(88).Mul(&x88)
+	x220.Set(&x176).squareN(44).Mul(&x44)
+	x223.Set(&x220).squareN(3).Mul(&x3)
+	f.Set(&x223).squareN(23).Mul(&x22) // f = a^(2^246 - 4194305)
+	f.squareN(5).Mul(&a)               // f = a^(2^251 - 134217759)
+	f.squareN(3).Mul(&x2)              // f = a^(2^254 - 1073742069)
+	return f.squareN(2).Mul(&a)        // f = a^(2^256 - 4294968275) = a^(p-2)
+}
+
+// squareN squares the field value n times.  The existing field value is
+// modified.
+//
+// The field value is returned to support chaining.
+func (f *fieldVal) squareN(n int) *fieldVal {
+	for range n {
+		f.Square()
+	}
+	return f
 }
 
 // SqrtVal computes the square root of x modulo the curve's prime, and stores
@@ -0,0 +1,102 @@
+package primitives
+
+import (
+	"crypto/sha256"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// fieldValInt returns the integer the words of f represent, without reducing it.
+func fieldValInt(f *fieldVal) *big.Int {
+	v := new(big.Int)
+	for i := len(f.n) - 1; i >= 0; i-- {
+		v.Lsh(v, fieldBase)
+		v.Add(v, new(big.Int).SetUint64(f.n[i]))
+	}
+	return v
+}
+
+// testFieldVals returns field values covering edge cases, random values and
+// values with every word at the bound of the largest magnitude multiplications
+// accept.
+func testFieldVals() []*fieldVal {
+	p := new(big.Int).Set(fieldPrime)
+	vals := []*fieldVal{
+		new(fieldVal),
+		new(fieldVal).SetInt(1),
+		new(fieldVal).SetByteSlice(new(big.Int).Sub(p, big.NewInt(1)).Bytes()),
+		new(fieldVal).SetByteSlice(p.Bytes()),
+		new(fieldVal).SetHex("ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff"),
+		{n: [5]uint64{16 * fieldBaseMask, 16 * fieldBaseMask, 16 * fieldBaseMask, 16 * fieldBaseMask, 16 * fieldMSBMask}},
+		{n: [5]uint64{16 * fieldBaseMask, 0, 16 * fieldBaseMask, 0, 16 * fieldMSBMask}},
+	}
+	for i := range 32 {
+		h := sha256.Sum256([]byte{byte(i)})
+		f := new(fieldVal).SetBytes(&h)
+		// Raise the magnitude of every other value with additions.
+		if i%2 == 1 {
+			for range i % 8 {
+				f.Add(new(fieldVal).SetBytes(&h))
+			}
+		}
+		vals = append(vals, f)
+	}
+	return vals
+}
+
+func TestFieldValArithmetic(t *testing.T) {
+	p := fieldPrime
+	mod := func(v *big.Int) *big.Int { return v.Mod(v, p) }
+	normalised := func(f *fieldVal) *big.Int {
+		g := new(fieldVal).Set(f).Normalise()
+		v := fieldValInt(g)
+		require.Equal(t, -1, v.Cmp(p), "normalised value %x not below the prime", v)
+		return v
+	}
+	equal := func(expected, actual *big.Int) {
+		t.Helper()
+		require.Zero(t, expected.Cmp(actual), "expected %x, got %x", expected, actual)
+	}
+
+	vals := testFieldVals()
+	for _, a := range vals {
+		ai := mod(fieldValInt(a))
+		equal(ai, normalised(a))
+		equal(mod(new(big.Int).Mul(ai, ai)), normalised(new(fieldVal).SquareVal(a)))
+		equal(mod(new(big.Int).Neg(ai)), normalised(new(fieldVal).NegateVal(a, 8)))
+
+		var b32 [32]byte
+		new(fieldVal).Set(a).Normalise().PutBytes(&b32)
+		equal(ai, new(big.Int).SetBytes(b32[:]))
+		equal(ai, fieldValInt(new(fieldVal).SetBytes(&b32)))
+
+		if ai.Sign() != 0 {
+			equal(new(big.Int).ModInverse(ai, p), normalised(new(fieldVal).Set(a).Inverse()))
+		}
+
+		for _, b := range vals {
+			bi := mod(fieldValInt(b))
+			equal(mod(new(big.Int).Mul(ai, bi)), normalised(new(fieldVal).Mul2(a, b)))
+			equal(mod(new(big.Int).Add(ai, bi)), normalised(new(fieldVal).Add2(a, b)))
+		}
+	}
+}
+
+func BenchmarkFieldMul(b *testing.B) {
+	h := sha256.Sum256([]byte("field"))
+	f := new(fieldVal).SetBytes(&h)
+	g := new(fieldVal).Set(f)
+	for b.Loop() {
+		f.Mul(g)
+	}
+}
+
+func BenchmarkFieldSquare(b *testing.B) {
+	h := sha256.Sum256([]byte("field"))
+	f := new(fieldVal).SetBytes(&h)
+	for b.Loop() {
+		f.Square()
+	}
+}
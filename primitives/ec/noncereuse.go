@@ -0,0 +1,133 @@
+package primitives
+
+import (
+	"math/big"
+)
+
+// SignatureWithMessage is a signature together with the message hash it signs
+// and, when known, the public key it was made with.
+type SignatureWithMessage struct {
+	Signature *Signature
+	Hash      []byte
+	// PublicKey is optional. When set, only keys matching it are recovered.
+	PublicKey *PublicKey
+}
+
+// NonceReuse is a set of signatures sharing the same R value, and thus made
+// with the same nonce.
+type NonceReuse struct {
+	R *big.Int
+	// Indexes are the positions of the signatures in the slice passed to
+	// DetectNonceReuse.
+	Indexes []int
+	// PrivateKey is the key recovered from the signatures, or nil when it
+	// cannot be: the signatures sign the same hash or come from different keys.
+	PrivateKey *PrivateKey
+}
+
+// DetectNonceReuse finds the signatures sharing an R value and recovers the
+// private key behind them where possible. Two signatures of different hashes
+// made by the same key with the same nonce k leak it, as
+//
+//	k = (z1 - z2) / (s1 - s2)  and  d = (s1*k - z1) / r  (mod N)
+//
+// It is meant for auditing wallets and signers: any result is a vulnerability.
+// The reuses are returned in the order of their first signature.
+func DetectNonceReuse(sigs []SignatureWithMessage) []NonceReuse {
+	var reuses []NonceReuse
+	byR := make(map[string]int)
+	for i, sig := range sigs {
+		if sig.Signature == nil || sig.Signature.R == nil || sig.Signature.S == nil {
+			continue
+		}
+		key := string(sig.Signature.R.Bytes())
+		idx, ok := byR[key]
+		if !ok {
+			byR[key] = len(reuses)
+			reuses = append(reuses, NonceReuse{R: sig.Signature.R, Indexes: []int{i}})
+			continue
+		}
+		reuses[idx].Indexes = append(reuses[idx].Indexes, i)
+	}
+
+	found := reuses[:0]
+	for _, reuse := range reuses {
+		if len(reuse.Indexes) < 2 {
+			continue
+		}
+		reuse.PrivateKey = recoverReusedNonceKey(sigs, reuse.Indexes)
+		found = append(found, reuse)
+	}
+	if len(found) == 0 {
+		return nil
+	}
+	return found
+}
+
+// recoverReusedNonceKey tries every pair of the signatures at indexes for the
+// private key that made both.
+func recoverReusedNonceKey(sigs []SignatureWithMessage, indexes []int) *PrivateKey {
+	for a, i := range indexes {
+		for _, j := range indexes[a+1:] {
+			if key := recoverFromPair(sigs[i], sigs[j]); key != nil {
+				return key
+			}
+		}
+	}
+	return nil
+}
+
+// recoverFromPair recovers the private key from two signatures sharing their
+// nonce, returning nil when they do not leak one. As S may have been negated to
+// make the signatures low S, both signs of the second S are tried and the
+// candidate key is kept only if it verifies the two signatures.
+func recoverFromPair(sig1, sig2 SignatureWithMessage) *PrivateKey {
+	curve := S256()
+	n := curve.N
+	z1 := hashToInt(sig1.Hash, curve)
+	z2 := hashToInt(sig2.Hash, curve)
+	dz := new(big.Int).Sub(z1, z2)
+	dz.Mod(dz, n)
+	if dz.Sign() == 0 {
+		return nil
+	}
+	rInv := new(big.Int).ModInverse(sig1.Signature.R, n)
+	if rInv == nil {
+		return nil
+	}
+
+	s1 := sig1.Signature.S
+	for _, s2 := range []*big.Int{sig2.Signature.S, new(big.Int).Sub(n, sig2.Signature.S)} {
+		ds := new(big.Int).Sub(s1, s2)
+		ds.Mod(ds, n)
+		dsInv := new(big.Int).ModInverse(ds, n)
+		if dsInv == nil {
+			continue
+		}
+		k := new(big.Int).Mul(dz, dsInv)
+		k.Mod(k, n)
+
+		d := new(big.Int).Mul(s1, k)
+		d.Sub(d, z1)
+		d.Mul(d, rInv)
+		d.Mod(d, n)
+		if d.Sign() == 0 {
+			continue
+		}
+
+		key, pub := PrivateKeyFromBytes(d.FillBytes(make([]byte, 32)))
+		if !signedBy(sig1, pub) || !signedBy(sig2, pub) {
+			continue
+		}
+		return key
+	}
+	return nil
+}
+
+// signedBy reports whether sig verifies with pub and pub is its expected key.
+func signedBy(sig SignatureWithMessage, pub *PublicKey) bool {
+	if sig.PublicKey != nil && !sig.PublicKey.IsEqual(pub) {
+		return false
+	}
+	return sig.Signature.Verify(sig.Hash, pub)
+}
@@ -0,0 +1,98 @@
+package primitives
+
+import (
+	"crypto/sha256"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// signWithNonce signs hash with the nonce k, as a faulty signer would.
+func signWithNonce(t *testing.T, key *PrivateKey, hash []byte, k int64) *Signature {
+	t.Helper()
+	n := S256().N
+	kb := big.NewInt(k)
+	r, _ := S256().ScalarBaseMult(kb.Bytes())
+	r.Mod(r, n)
+	s := new(big.Int).Mul(key.D, r)
+	s.Add(s, hashToInt(hash, S256()))
+	s.Mul(s, new(big.Int).ModInverse(kb, n))
+	s.Mod(s, n)
+	if s.Cmp(S256().halfOrder) == 1 {
+		s.Sub(n, s)
+	}
+	sig := &Signature{R: r, S: s}
+	require.True(t, sig.Verify(hash, key.PubKey()))
+	return sig
+}
+
+func TestDetectNonceReuse(t *testing.T) {
+	key, err := NewPrivateKey()
+	require.NoError(t, err)
+	other, err := NewPrivateKey()
+	require.NoError(t, err)
+
+	hash := func(msg string) []byte {
+		h := sha256.Sum256([]byte(msg))
+		return h[:]
+	}
+	sign := func(k *PrivateKey, msg string) *Signature {
+		sig, err := k.Sign(hash(msg))
+		require.NoError(t, err)
+		return sig
+	}
+
+	t.Run("no reuse", func(t *testing.T) {
+		reuses := DetectNonceReuse([]SignatureWithMessage{
+			{Signature: sign(key, "a"), Hash: hash("a")},
+			{Signature: sign(key, "b"), Hash: hash("b")},
+			{Signature: sign(other, "a"), Hash: hash("a")},
+		})
+		require.Empty(t, reuses)
+	})
+
+	t.Run("key recovered", func(t *testing.T) {
+		// Try several nonces so both signs of S are exercised.
+		for k := int64(1); k <= 8; k++ {
+			sigs := []SignatureWithMessage{
+				{Signature: sign(key, "unrelated"), Hash: hash("unrelated")},
+				{Signature: signWithNonce(t, key, hash("first"), 1000+k), Hash: hash("first")},
+				{Signature: signWithNonce(t, key, hash("second"), 1000+k), Hash: hash("second"), PublicKey: key.PubKey()},
+			}
+			reuses := DetectNonceReuse(sigs)
+			require.Len(t, reuses, 1)
+			require.Equal(t, []int{1, 2}, reuses[0].Indexes)
+			require.Equal(t, sigs[1].Signature.R, reuses[0].R)
+			require.NotNil(t, reuses[0].PrivateKey)
+			require.Equal(t, key.Serialize(), reuses[0].PrivateKey.Serialize())
+		}
+	})
+
+	t.Run("key not recoverable", func(t *testing.T) {
+		sigs := []SignatureWithMessage{
+			// The same hash signed twice leaks nothing.
+			{Signature: signWithNonce(t, key, hash("same"), 7), Hash: hash("same")},
+			{Signature: signWithNonce(t, key, hash("same"), 7), Hash: hash("same")},
+			// Different keys sharing a nonce.
+			{Signature: signWithNonce(t, key, hash("mine"), 9), Hash: hash("mine")},
+			{Signature: signWithNonce(t, other, hash("theirs"), 9), Hash: hash("theirs")},
+		}
+		reuses := DetectNonceReuse(sigs)
+		require.Len(t, reuses, 2)
+		require.Equal(t, []int{0, 1}, reuses[0].Indexes)
+		require.Nil(t, reuses[0].PrivateKey)
+		require.Equal(t, []int{2, 3}, reuses[1].Indexes)
+		require.Nil(t, reuses[1].PrivateKey)
+	})
+
+	t.Run("expected public key", func(t *testing.T) {
+		sigs := []SignatureWithMessage{
+			{Signature: signWithNonce(t, key, hash("first"), 11), Hash: hash("first"), PublicKey: other.PubKey()},
+			{Signature: signWithNonce(t, key, hash("second"), 11), Hash: hash("second")},
+		}
+		reuses := DetectNonceReuse(sigs)
+		require.Len(t, reuses, 1)
+		require.Nil(t, reuses[0].PrivateKey)
+	})
+}
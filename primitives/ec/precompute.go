@@ -47,27 +47,74 @@ func loadS256BytePoints() error {
 	for byteNum := 0; byteNum < 32; byteNum++ {
 		// All points in this window.
 		for i := 0; i < 256; i++ {
-			px := &bytePoints[byteNum][i][0]
-			py := &bytePoints[byteNum][i][1]
-			pz := &bytePoints[byteNum][i][2]
-
-			for i := 0; i < 10; i++ {
-				px.n[i] = binary.LittleEndian.Uint32(serialized[offset:])
-				offset += 4
+			for j := range bytePoints[byteNum][i] {
+				offset = readSerializedFieldVal(&bytePoints[byteNum][i][j], serialized, offset)
 			}
+		}
+	}
 
-			for i := 0; i < 10; i++ {
-				py.n[i] = binary.LittleEndian.Uint32(serialized[offset:])
-				offset += 4
-			}
+	// The points are serialized in Jacobian coordinates.  Convert them to
+	// affine coordinates so that scalar base multiplication can add them
+	// with the cheaper mixed addition.
+	jacobianToAffineBatch(bytePoints[:])
+
+	secp256k1.bytePoints = &bytePoints
+	return nil
+}
 
-			for i := 0; i < 10; i++ {
-				pz.n[i] = binary.LittleEndian.Uint32(serialized[offset:])
-				offset += 4
+// readSerializedFieldVal sets f to the field value serialized at offset as 10
+// little-endian uint32 words in base 2^26, the representation the byte points
+// were generated with, and returns the offset following it.
+func readSerializedFieldVal(f *fieldVal, serialized []byte, offset int) int {
+	var words [10]uint64
+	for i := range words {
+		words[i] = uint64(binary.LittleEndian.Uint32(serialized[offset:]))
+		offset += 4
+	}
+	for i := range f.n {
+		f.n[i] = words[2*i] + words[2*i+1]<<26
+	}
+	f.Normalise()
+	return offset
+}
+
+// jacobianToAffineBatch converts the Jacobian points of the windows in place to
+// affine coordinates, with a z of one.  Points at infinity are left untouched.
+// A single field inversion is shared by all of the points using Montgomery's
+// trick: with the running products acc[i] = z0*...*zi, the inverse of zi is
+// acc[i-1] * (z0*...*zi)^-1.
+func jacobianToAffineBatch(windows [][256][3]fieldVal) {
+	var points []*[3]fieldVal
+	for w := range windows {
+		for i := range windows[w] {
+			if !windows[w][i][2].Normalise().IsZero() {
+				points = append(points, &windows[w][i])
 			}
 		}
 	}
+	if len(points) == 0 {
+		return
+	}
 
-	secp256k1.bytePoints = &bytePoints
-	return nil
+	acc := make([]fieldVal, len(points))
+	acc[0].Set(&points[0][2])
+	for i := 1; i < len(points); i++ {
+		acc[i].Mul2(&acc[i-1], &points[i][2])
+	}
+
+	var inv, zInv, zInv2 fieldVal
+	inv.Set(&acc[len(acc)-1]).Inverse()
+	for i := len(points) - 1; i >= 0; i-- {
+		p := points[i]
+		if i > 0 {
+			zInv.Mul2(&inv, &acc[i-1]) // zInv = zi^-1
+			inv.Mul(&p[2])             // inv = (z0*...*zi-1)^-1
+		} else {
+			zInv.Set(&inv)
+		}
+		zInv2.SquareVal(&zInv)
+		p[0].Mul(&zInv2).Normalise()           // X = X/Z^2
+		p[1].Mul(zInv2.Mul(&zInv)).Normalise() // Y = Y/Z^3
+		p[2].SetInt(1)                         // Z = 1
+	}
 }
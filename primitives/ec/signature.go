@@ -32,6 +32,9 @@ func (sig *Signature) ToDER() ([]byte, error) {
 
 // Verify checks the validity of an ECDSA signature.
 func Verify(msg []byte, sig *Signature, pubKey *e.PublicKey) bool {
+	if curve, ok := pubKey.Curve.(*KoblitzCurve); ok {
+		return curve.verify(pubKey.X, pubKey.Y, msg, sig.R, sig.S)
+	}
 	return e.Verify(pubKey, msg, sig.R, sig.S)
 }
 
@@ -93,9 +96,13 @@ func (sig *Signature) Serialize() []byte {
 	return b
 }
 
-// Verify calls ecdsa.Verify to verify the signature of hash using the public
-// key.  It returns true if the signature is valid, false otherwise.
+// Verify verifies the signature of hash using the public key, with the same
+// rules as ecdsa.Verify.  It returns true if the signature is valid, false
+// otherwise.
 func (sig *Signature) Verify(hash []byte, pubKey *PublicKey) bool {
+	if curve, ok := pubKey.Curve.(*KoblitzCurve); ok {
+		return curve.verify(pubKey.X, pubKey.Y, hash, sig.R, sig.S)
+	}
 	return e.Verify(pubKey.ToECDSA(), hash, sig.R, sig.S)
 }
 
@@ -384,6 +391,15 @@ func recoverKeyFromSignature(curve *KoblitzCurve, sig *Signature, msg []byte,
 	}, nil
 }
 
+// Compact signature layout: a header byte followed by the 32-byte R and S values.
+// The header is CompactSigMagicOffset plus the public key recovery code (0-3),
+// plus CompactSigCompPubKey when the signature references a compressed key.
+const (
+	CompactSigSize        = 65
+	CompactSigMagicOffset = 27
+	CompactSigCompPubKey  = 4
+)
+
 // SignCompact produces a compact signature of the data in hash with the given
 // private key on the given koblitz curve. The isCompressed  parameter should
 // be used to detail if the given signature should reference a compressed
@@ -405,9 +421,9 @@ func SignCompact(curve *KoblitzCurve, key *PrivateKey,
 		pk, err := recoverKeyFromSignature(curve, sig, hash, i, true)
 		if err == nil && pk.X.Cmp(key.X) == 0 && pk.Y.Cmp(key.Y) == 0 {
 			result := make([]byte, 1, 2*curve.byteSize+1)
-			result[0] = 27 + byte(i)
+			result[0] = CompactSigMagicOffset + byte(i)
 			if isCompressedKey {
-				result[0] += 4
+				result[0] += CompactSigCompPubKey
 			}
 			// Not sure this needs rounding but safer to do so.
 			curvelen := (curve.BitSize + 7) / 8
@@ -444,8 +460,13 @@ func RecoverCompact(signature,
 	if len(signature) != 1+bitlen*2 {
 		return nil, false, errors.New("invalid compact signature size")
 	}
+	header := signature[0]
+	if header < CompactSigMagicOffset || header >= CompactSigMagicOffset+2*CompactSigCompPubKey {
+		return nil, false, fmt.Errorf("invalid compact signature header %d", header)
+	}
+	recoveryCode := header - CompactSigMagicOffset
 
-	iteration := int((signature[0] - 27) & ^byte(4))
+	iteration := int(recoveryCode & ^byte(CompactSigCompPubKey))
 
 	// format is <header byte><bitlen R><bitlen S>
 	sig := &Signature{
@@ -458,7 +479,7 @@ func RecoverCompact(signature,
 		return nil, false, err
 	}
 
-	return key, ((signature[0] - 27) & 4) == 4, nil
+	return key, recoveryCode&CompactSigCompPubKey == CompactSigCompPubKey, nil
 }
 
 // signRFC6979 generates a deterministic ECDSA signature according to RFC 6979 and BIP 62.
@@ -511,16 +532,18 @@ func nonceRFC6979(privkey *big.Int, hash []byte) *big.Int {
 	k := make([]byte, holen)
 
 	// Step D
-	k = mac(alg, k, append(append(v, 0x00), bx...))
+	k = mac(hmac.New(alg, k), k, v, []byte{0x00}, bx)
 
 	// Step E
-	v = mac(alg, k, v)
+	h := hmac.New(alg, k)
+	v = mac(h, v, v)
 
 	// Step F
-	k = mac(alg, k, append(append(v, 0x01), bx...))
+	k = mac(h, k, v, []byte{0x01}, bx)
 
 	// Step G
-	v = mac(alg, k, v)
+	h = hmac.New(alg, k)
+	v = mac(h, v, v)
 
 	// Step H
 	for {
@@ -529,7 +552,7 @@ func nonceRFC6979(privkey *big.Int, hash []byte) *big.Int {
 
 		// Step H2
 		for len(t)*8 < qlen {
-			v = mac(alg, k, v)
+			v = mac(h, v, v)
 			t = append(t, v...)
 		}
 
@@ -538,16 +561,21 @@ func nonceRFC6979(privkey *big.Int, hash []byte) *big.Int {
 		if secret.Cmp(one) >= 0 && secret.Cmp(q) < 0 {
 			return secret
 		}
-		k = mac(alg, k, append(v, 0x00))
-		v = mac(alg, k, v)
+		k = mac(h, k, v, []byte{0x00})
+		h = hmac.New(alg, k)
+		v = mac(h, v, v)
 	}
 }
 
-// mac returns an HMAC of the given key and message.
-func mac(alg func() hash.Hash, k, m []byte) []byte {
-	h := hmac.New(alg, k)
-	_, _ = h.Write(m)
-	return h.Sum(nil)
+// mac returns the HMAC h computes of the concatenated messages, appended to
+// dst[:0].  The HMAC is reset first, so that one created for a key is reused
+// as long as the key does not change.  dst may be one of the messages.
+func mac(h hash.Hash, dst []byte, messages ...[]byte) []byte {
+	h.Reset()
+	for _, m := range messages {
+		_, _ = h.Write(m)
+	}
+	return h.Sum(dst[:0])
 }
 
 // https://tools.ietf.org/html/rfc6979#section-2.3.3
@@ -561,9 +561,15 @@ var recoveryTests = []struct {
 		//
 		// Test case contributed by Ethereum Swarm: GH-1651
 		msg: "3060d2c77c1e192d62ad712fb400e04e6f779914a6876328ff3b213fa85d2012",
-		sig: "65000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000037a3",
+		sig: "00000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000037a3",
 		err: fmt.Errorf("signature R is 0"),
 	},
+	{
+		// Header out of range, rejected before R is checked.
+		msg: "3060d2c77c1e192d62ad712fb400e04e6f779914a6876328ff3b213fa85d2012",
+		sig: "65000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000037a3",
+		err: fmt.Errorf("invalid compact signature header 128"),
+	},
 	{
 		// Zero R value
 		//
@@ -576,6 +582,12 @@ var recoveryTests = []struct {
 		// R = N (curve order of secp256k1)
 		msg: "2bcebac60d8a78e520ae81c2ad586792df495ed429bd730dcd897b301932d054",
 		sig: "65fffffffffffffffffffffffffffffffebaaedce6af48a03bbfd25e8cd036414100000000000000000000000000000000000000000000000000000000000037a3",
+		err: fmt.Errorf("invalid compact signature header 128"),
+	},
+	{
+		// R = N (curve order of secp256k1) with a valid recovery code
+		msg: "2bcebac60d8a78e520ae81c2ad586792df495ed429bd730dcd897b301932d054",
+		sig: "00fffffffffffffffffffffffffffffffebaaedce6af48a03bbfd25e8cd036414100000000000000000000000000000000000000000000000000000000000037a3",
 		err: fmt.Errorf("signature R is >= curve order"),
 	},
 	{
@@ -729,3 +741,24 @@ func TestSignatureIsEqual(t *testing.T) {
 	}
 
 }
+
+func TestRecoverCompactHeader(t *testing.T) {
+	priv, err := NewPrivateKey()
+	require.NoError(t, err)
+	hash := sha256.Sum256([]byte("compact"))
+
+	sig, err := SignCompact(S256(), priv, hash[:], true)
+	require.NoError(t, err)
+	require.Len(t, sig, CompactSigSize)
+	require.GreaterOrEqual(t, sig[0], byte(CompactSigMagicOffset+CompactSigCompPubKey))
+
+	for _, header := range []byte{0, CompactSigMagicOffset - 1, CompactSigMagicOffset + 2*CompactSigCompPubKey, 0xff} {
+		invalid := bytes.Clone(sig)
+		invalid[0] = header
+		_, _, err := RecoverCompact(invalid, hash[:])
+		require.Error(t, err, "header %d", header)
+	}
+
+	_, _, err = RecoverCompact(sig[:CompactSigSize-1], hash[:])
+	require.Error(t, err)
+}
@@ -1,6 +1,7 @@
 package primitives
 
 import (
+	"bytes"
 	"crypto/rand"
 	"encoding/base64"
 	"errors"
@@ -8,10 +9,13 @@ import (
 	"log"
 
 	aesgcm "github.com/bsv-blockchain/go-sdk/primitives/aesgcm"
+	"github.com/bsv-blockchain/go-sdk/primitives/secure"
 )
 
+// SymmetricKey is an AES-256 key. It is redacted when printed and can be zeroed
+// with Wipe once no longer needed.
 type SymmetricKey struct {
-	key []byte
+	key *secure.SecretBytes
 }
 
 // EncryptString encrypts the given message using the symmetric key using AES-GCM
@@ -31,7 +35,10 @@ func (s *SymmetricKey) Encrypt(message []byte) (ciphertext []byte, err error) {
 	if err != nil {
 		return nil, err
 	}
-	ciphertext, tag, err := aesgcm.AESGCMEncrypt(message, s.ToBytes(), iv, []byte{})
+	var tag []byte
+	s.key.Use(func(key []byte) {
+		ciphertext, tag, err = aesgcm.AESGCMEncrypt(message, key, iv, []byte{})
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -64,35 +71,46 @@ func (s *SymmetricKey) Decrypt(message []byte) (plaintext []byte, err error) {
 	iv := message[:32]
 	ciphertext := message[32 : len(message)-16]
 	tag := message[len(message)-16:]
-	plaintext, err = aesgcm.AESGCMDecrypt(ciphertext, s.ToBytes(), iv, []byte{}, tag)
+	s.key.Use(func(key []byte) {
+		plaintext, err = aesgcm.AESGCMDecrypt(ciphertext, key, iv, []byte{}, tag)
+	})
 	if err != nil {
 		return nil, err
 	}
 	return plaintext, nil
 }
 
+// ToBytes returns a copy of the key bytes, which is not zeroed when the key is
+// wiped or collected.
 func (s *SymmetricKey) ToBytes() []byte {
-	return s.key
+	return s.key.Bytes()
 }
 
 func (s *SymmetricKey) FromBytes(b []byte) *SymmetricKey {
-	return &SymmetricKey{key: b}
+	return &SymmetricKey{key: secure.NewSecretBytes(bytes.Clone(b))}
+}
+
+// Wipe zeroes the key. It cannot be used afterwards.
+func (s *SymmetricKey) Wipe() {
+	s.key.Wipe()
+}
+
+// String returns a redacted placeholder rather than the key.
+func (s *SymmetricKey) String() string {
+	return s.key.String()
 }
 
 func NewSymmetricKey(key []byte) *SymmetricKey {
-	if len(key) < 32 {
-		// Pad the key to 32 bytes if it's shorter
-		paddedKey := make([]byte, 32)
-		copy(paddedKey[32-len(key):], key)
-		key = paddedKey
-	}
-	return &SymmetricKey{key: key}
+	// Copy the key, padding it to 32 bytes if it's shorter
+	b := make([]byte, max(len(key), 32))
+	copy(b[len(b)-len(key):], key)
+	return &SymmetricKey{key: secure.NewSecretBytes(b)}
 }
 
 func NewSymmetricKeyFromRandom() *SymmetricKey {
 	key := make([]byte, 32)
 	_, _ = rand.Read(key)
-	return &SymmetricKey{key: key}
+	return &SymmetricKey{key: secure.NewSecretBytes(key)}
 }
 
 func NewSymmetricKeyFromString(keyBase64String string) *SymmetricKey {
@@ -101,5 +119,5 @@ func NewSymmetricKeyFromString(keyBase64String string) *SymmetricKey {
 	if err != nil {
 		log.Fatalf("Failed to decode Base64 symmetric key string: %v", err)
 	}
-	return &SymmetricKey{key: keyBytes}
+	return &SymmetricKey{key: secure.NewSecretBytes(keyBytes)}
 }
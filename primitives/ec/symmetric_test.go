@@ -3,6 +3,7 @@ package primitives
 import (
 	"encoding/base64"
 	"encoding/json"
+	"fmt"
 	"log"
 	"os"
 	"path/filepath"
@@ -122,3 +123,37 @@ func TestSymmetricKeyWith32ByteKeyEncryption(t *testing.T) {
 	require.NoError(t, err, "Failed to decrypt with 32-byte key")
 	require.Equal(t, plaintext, decrypted, "Decrypted text does not match original")
 }
+
+func TestSymmetricKeyRedactedAndWiped(t *testing.T) {
+	raw := []byte("0123456789abcdef0123456789abcdef")
+	symmetricKey := NewSymmetricKey(raw)
+	require.Equal(t, raw, symmetricKey.ToBytes())
+
+	for _, verb := range []string{"%v", "%s", "%x", "%+v"} {
+		require.NotContains(t, fmt.Sprintf(verb, symmetricKey), "0123456789abcdef", verb)
+	}
+
+	key := symmetricKey.ToBytes()
+	symmetricKey.Wipe()
+	require.Equal(t, raw, key, "ToBytes returns a copy")
+	require.Nil(t, symmetricKey.ToBytes())
+	require.Equal(t, []byte("0123456789abcdef0123456789abcdef"), raw, "the caller's bytes are copied")
+}
+
+func TestSymmetricKeyBytesOutliveKey(t *testing.T) {
+	ciphertext, saved := func() ([]byte, []byte) {
+		symmetricKey := NewSymmetricKeyFromRandom()
+		ciphertext, err := symmetricKey.Encrypt([]byte("persisted"))
+		require.NoError(t, err)
+		return ciphertext, symmetricKey.ToBytes()
+	}()
+
+	// Collecting the key wipes its own bytes, but not those handed out.
+	for range 5 {
+		runtime.GC()
+	}
+	require.NotEqual(t, make([]byte, 32), saved)
+	plaintext, err := NewSymmetricKey(saved).Decrypt(ciphertext)
+	require.NoError(t, err)
+	require.Equal(t, []byte("persisted"), plaintext)
+}
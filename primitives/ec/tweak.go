@@ -0,0 +1,175 @@
+package primitives
+
+import (
+	"errors"
+	"math/big"
+
+	crypto "github.com/bsv-blockchain/go-sdk/primitives/hash"
+)
+
+// Tags of the tagged hashes used to derive the tweaks.
+const (
+	// TapTweakTag is the BIP-341 tag committing a key to a script merkle root.
+	TapTweakTag = "TapTweak"
+	// SignToContractTag is the tag committing a signature nonce to data.
+	SignToContractTag = "SignToContract"
+)
+
+// ErrInvalidTweak is returned when a tweak is not below the curve order, or
+// produces the point at infinity or a zero private key.
+var ErrInvalidTweak = errors.New("invalid tweak")
+
+// TweakAdd returns the key d + tweak, the tweak being a 32 byte big-endian
+// scalar. It matches PublicKey.TweakAdd: the public key of the result is
+// P + tweak*G.
+func (p *PrivateKey) TweakAdd(tweak []byte) (*PrivateKey, error) {
+	t, err := parseTweak(tweak)
+	if err != nil {
+		return nil, err
+	}
+	d := new(big.Int).Add(p.D, t)
+	d.Mod(d, S256().N)
+	if d.Sign() == 0 {
+		return nil, ErrInvalidTweak
+	}
+	key, _ := PrivateKeyFromBytes(d.FillBytes(make([]byte, PrivateKeyBytesLen)))
+	return key, nil
+}
+
+// TweakAdd returns the key P + tweak*G, the tweak being a 32 byte big-endian
+// scalar.
+func (p *PublicKey) TweakAdd(tweak []byte) (*PublicKey, error) {
+	t, err := parseTweak(tweak)
+	if err != nil {
+		return nil, err
+	}
+	tx, ty := S256().ScalarBaseMult(t.Bytes())
+	if t.Sign() == 0 {
+		tx, ty = new(big.Int), new(big.Int)
+	}
+	x, y := S256().Add(p.X, p.Y, tx, ty)
+	if x.Sign() == 0 && y.Sign() == 0 {
+		return nil, ErrInvalidTweak
+	}
+	return &PublicKey{Curve: S256(), X: x, Y: y}, nil
+}
+
+// XOnly returns the 32 byte x coordinate of the key, as used by BIP-340.
+func (p *PublicKey) XOnly() []byte {
+	return p.X.FillBytes(make([]byte, 32))
+}
+
+// HasEvenY reports whether the y coordinate of the key is even, making it the
+// key its x-only form stands for.
+func (p *PublicKey) HasEvenY() bool {
+	return !isOdd(p.Y)
+}
+
+// ParseXOnlyPubKey parses a 32 byte x-only key into the key with that x
+// coordinate and an even y.
+func ParseXOnlyPubKey(xOnly []byte) (*PublicKey, error) {
+	if len(xOnly) != 32 {
+		return nil, errors.New("x-only public key must be 32 bytes")
+	}
+	return ParsePubKey(append([]byte{pubkeyCompressed}, xOnly...))
+}
+
+// CommitmentTweak returns the tweak committing the x-only form of the key to
+// commitment, typically a script merkle root, as the BIP-341
+// TapTweak(xonly(P) || commitment). An empty commitment commits to no script.
+func (p *PublicKey) CommitmentTweak(commitment []byte) []byte {
+	return crypto.TaggedHash(TapTweakTag, p.XOnly(), commitment)
+}
+
+// CommitTo returns the pay-to-contract key committing to commitment: the key
+// with the x coordinate of P and an even y, plus CommitmentTweak(commitment)*G.
+// The committed key is spendable with PrivateKey.CommitTo, and anyone knowing
+// the original key and the commitment can check it.
+func (p *PublicKey) CommitTo(commitment []byte) (*PublicKey, error) {
+	even := p
+	if !p.HasEvenY() {
+		even = &PublicKey{Curve: S256(), X: p.X, Y: new(big.Int).Sub(S256().P, p.Y)}
+	}
+	return even.TweakAdd(p.CommitmentTweak(commitment))
+}
+
+// CommitTo returns the private key of PublicKey.CommitTo(commitment) for the
+// public key of this key.
+func (p *PrivateKey) CommitTo(commitment []byte) (*PrivateKey, error) {
+	pub := p.PubKey()
+	key := p
+	if !pub.HasEvenY() {
+		key, _ = PrivateKeyFromBytes(new(big.Int).Sub(S256().N, p.D).FillBytes(make([]byte, PrivateKeyBytesLen)))
+	}
+	return key.TweakAdd(pub.CommitmentTweak(commitment))
+}
+
+// SignToContract signs hash with a nonce committing to commitment, so that the
+// signature proves the commitment was made by the signer when it was produced.
+// It returns the signature and the untweaked nonce point R, which is needed with
+// the commitment to check it with VerifySignToContract.
+//
+// The nonce is derived from both the hash and the commitment: signing the same
+// hash with two commitments and the same base nonce would leak the key.
+func (p *PrivateKey) SignToContract(hash, commitment []byte) (*Signature, *PublicKey, error) {
+	curve := S256()
+	n := curve.N
+
+	k := nonceRFC6979(p.D, crypto.Sha256(append(append([]byte{}, hash...), commitment...)))
+	rx, ry := curve.ScalarBaseMult(k.Bytes())
+	R := &PublicKey{Curve: curve, X: rx, Y: ry}
+
+	t := new(big.Int).SetBytes(signToContractTweak(R, commitment))
+	k.Add(k, t)
+	k.Mod(k, n)
+	if k.Sign() == 0 {
+		return nil, nil, ErrInvalidTweak
+	}
+	r, _ := curve.ScalarBaseMult(k.Bytes())
+	r.Mod(r, n)
+	if r.Sign() == 0 {
+		return nil, nil, errors.New("calculated R is zero")
+	}
+
+	s := new(big.Int).Mul(p.D, r)
+	s.Add(s, hashToInt(hash, curve))
+	s.Mul(s, new(big.Int).ModInverse(k, n))
+	s.Mod(s, n)
+	if s.Cmp(curve.halfOrder) == 1 {
+		s.Sub(n, s)
+	}
+	if s.Sign() == 0 {
+		return nil, nil, errors.New("calculated S is zero")
+	}
+	return &Signature{R: r, S: s}, R, nil
+}
+
+// VerifySignToContract reports whether sig commits to commitment through the
+// nonce point R returned by SignToContract. It does not verify the signature
+// itself, which Signature.Verify does.
+func VerifySignToContract(sig *Signature, R *PublicKey, commitment []byte) bool {
+	if sig == nil || R == nil || !R.Validate() {
+		return false
+	}
+	tweaked, err := R.TweakAdd(signToContractTweak(R, commitment))
+	if err != nil {
+		return false
+	}
+	r := new(big.Int).Mod(tweaked.X, S256().N)
+	return r.Cmp(sig.R) == 0
+}
+
+func signToContractTweak(R *PublicKey, commitment []byte) []byte {
+	return crypto.TaggedHash(SignToContractTag, R.Compressed(), commitment)
+}
+
+func parseTweak(tweak []byte) (*big.Int, error) {
+	if len(tweak) != 32 {
+		return nil, ErrInvalidTweak
+	}
+	t := new(big.Int).SetBytes(tweak)
+	if t.Cmp(S256().N) >= 0 {
+		return nil, ErrInvalidTweak
+	}
+	return t, nil
+}
@@ -0,0 +1,123 @@
+package primitives
+
+import (
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTweakAdd(t *testing.T) {
+	key, err := NewPrivateKey()
+	require.NoError(t, err)
+	tweak := decodeHex("b86e7be8f39bab32a6f2c0443abbc210f0edac0e2c53d501b36b64437d9c6c70")
+
+	tweakedKey, err := key.TweakAdd(tweak)
+	require.NoError(t, err)
+	tweakedPub, err := key.PubKey().TweakAdd(tweak)
+	require.NoError(t, err)
+	require.True(t, tweakedKey.PubKey().IsEqual(tweakedPub))
+
+	// A zero tweak leaves the key unchanged.
+	same, err := key.PubKey().TweakAdd(make([]byte, 32))
+	require.NoError(t, err)
+	require.True(t, same.IsEqual(key.PubKey()))
+
+	_, err = key.TweakAdd(tweak[:31])
+	require.ErrorIs(t, err, ErrInvalidTweak)
+	_, err = key.PubKey().TweakAdd(S256().N.Bytes())
+	require.ErrorIs(t, err, ErrInvalidTweak)
+
+	// Tweaking by -d cancels the key out.
+	minusD := new(big.Int).Sub(S256().N, key.D).FillBytes(make([]byte, 32))
+	_, err = key.TweakAdd(minusD)
+	require.ErrorIs(t, err, ErrInvalidTweak)
+	_, err = key.PubKey().TweakAdd(minusD)
+	require.ErrorIs(t, err, ErrInvalidTweak)
+}
+
+func TestCommitTo(t *testing.T) {
+	// Vectors from BIP-86 and the BIP-341 wallet test vectors.
+	tests := []struct {
+		name       string
+		internal   string
+		commitment string
+		tweak      string
+		output     string
+	}{
+		{
+			name:     "bip86",
+			internal: "cc8a4bc64d897bddc5fbc2f670f7a8ba0b386779106cf1223c6fc5d7cd6fc115",
+			output:   "a60869f0dbcf1dc659c9cecbaf8050135ea9e8cdc487053f1dc6880949dc684c",
+		},
+		{
+			name:     "bip341 no scripts",
+			internal: "d6889cb081036e0faefa3a35157ad71086b123b2b144b649798b494c300a961d",
+			tweak:    "b86e7be8f39bab32a6f2c0443abbc210f0edac0e2c53d501b36b64437d9c6c70",
+			output:   "53a1f6e454df1aa2776a2814a721372d6258050de330b3c6d10ee8f4e0dda343",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			internal, err := ParseXOnlyPubKey(decodeHex(tt.internal))
+			require.NoError(t, err)
+			require.True(t, internal.HasEvenY())
+			commitment := decodeHex(tt.commitment)
+			if tt.tweak != "" {
+				require.Equal(t, tt.tweak, hex.EncodeToString(internal.CommitmentTweak(commitment)))
+			}
+			output, err := internal.CommitTo(commitment)
+			require.NoError(t, err)
+			require.Equal(t, tt.output, hex.EncodeToString(output.XOnly()))
+		})
+	}
+
+	t.Run("private key", func(t *testing.T) {
+		commitment := decodeHex("5b75adecf53548f3ec6ad7d78383bf84cc57b55a3127c72b9a2481752dd88b21")
+		for range 8 {
+			key, err := NewPrivateKey()
+			require.NoError(t, err)
+			committed, err := key.CommitTo(commitment)
+			require.NoError(t, err)
+			pub, err := key.PubKey().CommitTo(commitment)
+			require.NoError(t, err)
+			require.True(t, committed.PubKey().IsEqual(pub))
+
+			other, err := key.PubKey().CommitTo(decodeHex("00"))
+			require.NoError(t, err)
+			require.False(t, other.IsEqual(pub))
+		}
+	})
+
+	_, err := ParseXOnlyPubKey(decodeHex("02"))
+	require.Error(t, err)
+}
+
+func TestSignToContract(t *testing.T) {
+	key, err := NewPrivateKey()
+	require.NoError(t, err)
+	hash := decodeHex("e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855")
+	commitment := []byte("token transfer #42")
+
+	sig, R, err := key.SignToContract(hash, commitment)
+	require.NoError(t, err)
+	require.True(t, sig.Verify(hash, key.PubKey()))
+	require.True(t, VerifySignToContract(sig, R, commitment))
+	require.False(t, VerifySignToContract(sig, R, []byte("token transfer #43")))
+
+	// Another commitment to the same hash uses another base nonce.
+	other, otherR, err := key.SignToContract(hash, []byte("token transfer #43"))
+	require.NoError(t, err)
+	require.True(t, other.Verify(hash, key.PubKey()))
+	require.False(t, otherR.IsEqual(R))
+	require.Empty(t, DetectNonceReuse([]SignatureWithMessage{
+		{Signature: sig, Hash: hash},
+		{Signature: other, Hash: hash},
+	}))
+
+	plain, err := key.Sign(hash)
+	require.NoError(t, err)
+	require.False(t, VerifySignToContract(plain, R, commitment))
+	require.False(t, VerifySignToContract(sig, nil, commitment))
+}
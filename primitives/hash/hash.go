@@ -52,3 +52,16 @@ func Hash160(b []byte) []byte {
 	hash := Sha256(b)
 	return Ripemd160(hash[:])
 }
+
+// TaggedHash calculates the BIP-340 tagged hash
+// sha256(sha256(tag) || sha256(tag) || msgs...) and returns the resulting bytes.
+func TaggedHash(tag string, msgs ...[]byte) []byte {
+	tagHash := sha256.Sum256([]byte(tag))
+	h := sha256.New()
+	h.Write(tagHash[:])
+	h.Write(tagHash[:])
+	for _, msg := range msgs {
+		h.Write(msg)
+	}
+	return h.Sum(nil)
+}
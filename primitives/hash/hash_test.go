@@ -165,3 +165,10 @@ func TestSha512HMAC(t *testing.T) {
 		})
 	}
 }
+
+func TestTaggedHash(t *testing.T) {
+	tag := Sha256([]byte("TapTweak"))
+	msg := []byte(testData)
+	expected := Sha256(append(append(append([]byte{}, tag...), tag...), msg...))
+	require.Equal(t, expected, TaggedHash("TapTweak", msg[:4], msg[4:]))
+}
@@ -0,0 +1,97 @@
+// Package secure provides helpers for holding key material in memory.
+package secure
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"math/big"
+	"runtime"
+)
+
+const redacted = "[REDACTED]"
+
+// SecretBytes holds sensitive bytes such as private keys, seeds and symmetric keys.
+//
+// The bytes can be zeroed explicitly with Wipe, and are zeroed on a best-effort
+// basis when the SecretBytes is garbage collected. Go may still have copied them
+// elsewhere, for example while growing a slice, so this limits rather than rules
+// out their exposure. Printing a SecretBytes with the fmt package never reveals
+// its contents.
+type SecretBytes struct {
+	b []byte
+}
+
+// NewSecretBytes wraps b, taking ownership of it: b is zeroed when the
+// SecretBytes is wiped or collected, so the caller must not keep using it.
+func NewSecretBytes(b []byte) *SecretBytes {
+	s := &SecretBytes{b: b}
+	if len(b) > 0 {
+		runtime.AddCleanup(s, Wipe, b)
+	}
+	return s
+}
+
+// Bytes returns a copy of the secret bytes, nil once wiped. The copy outlives
+// the SecretBytes and is not wiped with it; prefer Use where possible.
+func (s *SecretBytes) Bytes() []byte {
+	if s == nil || s.b == nil {
+		return nil
+	}
+	return bytes.Clone(s.b)
+}
+
+// Use calls fn with the secret bytes without copying them, keeping the
+// SecretBytes alive until fn returns. fn must not retain the slice, which is
+// zeroed when the SecretBytes is wiped or collected.
+func (s *SecretBytes) Use(fn func(b []byte)) {
+	if s == nil {
+		fn(nil)
+		return
+	}
+	fn(s.b)
+	runtime.KeepAlive(s)
+}
+
+// Len returns the number of secret bytes.
+func (s *SecretBytes) Len() int {
+	if s == nil {
+		return 0
+	}
+	return len(s.b)
+}
+
+// Wipe zeroes the secret bytes and releases them.
+func (s *SecretBytes) Wipe() {
+	if s == nil {
+		return
+	}
+	Wipe(s.b)
+	s.b = nil
+}
+
+// String returns a redacted placeholder.
+func (s *SecretBytes) String() string {
+	return redacted
+}
+
+// Format implements fmt.Formatter so that no verb, including %x and %#v, prints
+// the secret bytes.
+func (s *SecretBytes) Format(f fmt.State, _ rune) {
+	_, _ = io.WriteString(f, redacted)
+}
+
+// Wipe zeroes b.
+func Wipe(b []byte) {
+	clear(b)
+	runtime.KeepAlive(b)
+}
+
+// WipeInt zeroes the words backing x and sets it to zero.
+func WipeInt(x *big.Int) {
+	if x == nil {
+		return
+	}
+	clear(x.Bits())
+	x.SetInt64(0)
+}
@@ -0,0 +1,89 @@
+package secure
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSecretBytesWipe(t *testing.T) {
+	b := []byte{1, 2, 3, 4}
+	s := NewSecretBytes(b)
+	require.Equal(t, []byte{1, 2, 3, 4}, s.Bytes())
+	require.Equal(t, 4, s.Len())
+
+	copied := s.Bytes()
+	s.Use(func(used []byte) {
+		require.Equal(t, []byte{1, 2, 3, 4}, used)
+		require.Same(t, &b[0], &used[0])
+	})
+
+	s.Wipe()
+	require.Equal(t, []byte{0, 0, 0, 0}, b)
+	require.Equal(t, []byte{1, 2, 3, 4}, copied, "Bytes returns a copy")
+	require.Nil(t, s.Bytes())
+	require.Zero(t, s.Len())
+
+	// Wiping twice, or a nil SecretBytes, is harmless
+	s.Wipe()
+	var nilSecret *SecretBytes
+	nilSecret.Wipe()
+	require.Nil(t, nilSecret.Bytes())
+}
+
+func TestSecretBytesRedacted(t *testing.T) {
+	s := NewSecretBytes([]byte("super secret"))
+	for _, verb := range []string{"%v", "%+v", "%#v", "%s", "%x", "%X", "%q", "%d"} {
+		out := fmt.Sprintf(verb, s)
+		require.Equal(t, redacted, out, verb)
+	}
+	require.Equal(t, redacted, s.String())
+
+	wrapped := struct{ Key *SecretBytes }{s}
+	require.NotContains(t, fmt.Sprintf("%+v", wrapped), "super secret")
+
+	out, err := json.Marshal(wrapped)
+	require.NoError(t, err)
+	require.NotContains(t, string(out), "super secret")
+}
+
+func TestSecretBytesWipedWhenCollected(t *testing.T) {
+	b := []byte{1, 2, 3, 4}
+	NewSecretBytes(b)
+
+	deadline := time.Now().Add(5 * time.Second)
+	for b[0] != 0 && time.Now().Before(deadline) {
+		runtime.GC()
+		time.Sleep(time.Millisecond)
+	}
+	require.Equal(t, []byte{0, 0, 0, 0}, b)
+}
+
+func TestSecretBytesCopyOutlivesCollection(t *testing.T) {
+	b := []byte{1, 2, 3, 4}
+	copied := NewSecretBytes(b).Bytes()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for b[0] != 0 && time.Now().Before(deadline) {
+		runtime.GC()
+		time.Sleep(time.Millisecond)
+	}
+	require.Equal(t, []byte{0, 0, 0, 0}, b)
+	require.Equal(t, []byte{1, 2, 3, 4}, copied)
+}
+
+func TestWipeInt(t *testing.T) {
+	x, _ := new(big.Int).SetString("123456789abcdef0123456789abcdef", 16)
+	words := x.Bits()
+	WipeInt(x)
+	require.Zero(t, x.Sign())
+	for _, w := range words {
+		require.Zero(t, w)
+	}
+	WipeInt(nil)
+}
@@ -2,12 +2,14 @@
 package script
 
 import (
+	"bytes"
 	"encoding/hex"
 	"fmt"
 
 	base58 "github.com/bsv-blockchain/go-sdk/compat/base58"
 	ec "github.com/bsv-blockchain/go-sdk/primitives/ec"
 	crypto "github.com/bsv-blockchain/go-sdk/primitives/hash"
+	chaincfg "github.com/bsv-blockchain/go-sdk/transaction/chaincfg"
 	"github.com/bsv-blockchain/go-sdk/util"
 )
 
@@ -16,6 +18,14 @@ const (
 	hashTestNetP2PKH = 0x6f
 )
 
+// paramsFor returns the network parameters selected by the legacy mainnet flag.
+func paramsFor(mainnet bool) *chaincfg.Params {
+	if mainnet {
+		return &chaincfg.MainNet
+	}
+	return &chaincfg.TestNet
+}
+
 // An Address struct contains the address string as well as the hash160 hex string of the public key.
 // The address string will be human-readable and specific to the network type, but the public key hash
 // is useful because it stays the same regardless of the network type (mainnet, testnet).
@@ -37,6 +47,35 @@ func NewAddressFromString(addr string) (*Address, error) {
 	}, nil
 }
 
+// NewAddressFromStringForNetwork takes a string address (P2PKH) and returns a pointer to an Address
+// only if the address was encoded for the given network. Addresses for other networks are
+// rejected with ErrWrongNetwork, so mainnet addresses cannot slip into testnet contexts.
+func NewAddressFromStringForNetwork(addr string, params *chaincfg.Params) (*Address, error) {
+	decoded, err := base58.Decode(addr)
+	if err != nil {
+		return nil, fmt.Errorf("%w for '%s'", ErrEncodingBadChar, addr)
+	}
+	if len(decoded) != 25 {
+		return nil, fmt.Errorf("%w for '%s'", ErrInvalidAddressLength, addr)
+	}
+	if ckSum := checksum(decoded[:21]); !bytes.Equal(ckSum[:], decoded[21:]) {
+		return nil, fmt.Errorf("%w for '%s'", ErrEncodingChecksumFailed, addr)
+	}
+	if decoded[0] != params.LegacyPubKeyHashAddrID {
+		return nil, fmt.Errorf("%w: '%s' is not a %s address", ErrWrongNetwork, addr, params.Name)
+	}
+	return &Address{
+		AddressString: addr,
+		PublicKeyHash: decoded[1:21],
+	}, nil
+}
+
+// IsForNetwork reports whether the address string was encoded for the given network.
+func (a *Address) IsForNetwork(params *chaincfg.Params) bool {
+	_, err := NewAddressFromStringForNetwork(a.AddressString, params)
+	return err == nil
+}
+
 func addressToPubKeyHash(address string) ([]byte, error) {
 	decoded, err := base58.Decode(address)
 	if err != nil {
@@ -74,15 +113,14 @@ func NewAddressFromPublicKeyString(pubKey string, mainnet bool) (*Address, error
 // If mainnet parameter is true it will return a mainnet address (starting with a 1).
 // Otherwise, (mainnet is false) it will return a testnet address (starting with an m or n).
 func NewAddressFromPublicKeyHash(hash []byte, mainnet bool) (*Address, error) {
+	return NewAddressFromPublicKeyHashForNetwork(hash, paramsFor(mainnet))
+}
 
-	// regtest := 111
-	// mainnet: 0
-
-	bb := make([]byte, 1)
-	if !mainnet {
-		bb[0] = 111
-	}
-	//nolint: makezero // we need to set up the array with 1
+// NewAddressFromPublicKeyHashForNetwork takes a public key hash in bytes and returns an Address
+// struct pointer encoded with the P2PKH prefix of the given network.
+func NewAddressFromPublicKeyHashForNetwork(hash []byte, params *chaincfg.Params) (*Address, error) {
+	bb := make([]byte, 0, len(hash)+1)
+	bb = append(bb, params.LegacyPubKeyHashAddrID)
 	bb = append(bb, hash...)
 
 	return &Address{
@@ -99,27 +137,25 @@ func NewAddressFromPublicKey(pubKey *ec.PublicKey, mainnet bool) (*Address, erro
 }
 
 func NewAddressFromPublicKeyWithCompression(pubKey *ec.PublicKey, mainnet bool, isCompressed bool) (*Address, error) {
+	return NewAddressFromPublicKeyWithCompressionForNetwork(pubKey, paramsFor(mainnet), isCompressed)
+}
+
+// NewAddressFromPublicKeyForNetwork takes a bec public key and returns an Address struct pointer
+// encoded with the P2PKH prefix of the given network.
+func NewAddressFromPublicKeyForNetwork(pubKey *ec.PublicKey, params *chaincfg.Params) (*Address, error) {
+	return NewAddressFromPublicKeyWithCompressionForNetwork(pubKey, params, true)
+}
+
+// NewAddressFromPublicKeyWithCompressionForNetwork is NewAddressFromPublicKeyForNetwork with
+// control over whether the compressed or uncompressed public key is hashed.
+func NewAddressFromPublicKeyWithCompressionForNetwork(pubKey *ec.PublicKey, params *chaincfg.Params, isCompressed bool) (*Address, error) {
 	var hash []byte
 	if isCompressed {
 		hash = pubKey.Hash()
 	} else {
 		hash = crypto.Hash160(pubKey.Uncompressed())
 	}
-
-	// regtest := 111
-	// mainnet: 0
-
-	bb := make([]byte, 1)
-	if !mainnet {
-		bb[0] = 111
-	}
-	//nolint: makezero // we need to set up the array with 1
-	bb = append(bb, hash...)
-
-	return &Address{
-		AddressString: Base58EncodeMissingChecksum(bb),
-		PublicKeyHash: hash,
-	}, nil
+	return NewAddressFromPublicKeyHashForNetwork(hash, params)
 }
 
 // Base58EncodeMissingChecksum appends a checksum to a byte sequence
@@ -6,6 +6,7 @@ import (
 
 	ec "github.com/bsv-blockchain/go-sdk/primitives/ec"
 	script "github.com/bsv-blockchain/go-sdk/script"
+	chaincfg "github.com/bsv-blockchain/go-sdk/transaction/chaincfg"
 	"github.com/stretchr/testify/require"
 )
 
@@ -142,6 +143,68 @@ func TestNewAddressFromPublicKeyHash(t *testing.T) {
 	})
 }
 
+func TestNewAddressFromStringForNetwork(t *testing.T) {
+	t.Parallel()
+
+	const mainnetAddr = "114ZWApV4EEU8frr7zygqQcB1V2BodGZuS"
+	const testnetAddr = "mfaWoDuTsFfiunLTqZx4fKpVsUctiDV9jk"
+
+	t.Run("matching network", func(t *testing.T) {
+		addr, err := script.NewAddressFromStringForNetwork(mainnetAddr, &chaincfg.MainNet)
+		require.NoError(t, err)
+		require.Equal(t, testPublicKeyHash, addr.PublicKeyHash.String())
+		require.True(t, addr.IsForNetwork(&chaincfg.MainNet))
+		require.False(t, addr.IsForNetwork(&chaincfg.TestNet))
+
+		for _, params := range []*chaincfg.Params{&chaincfg.TestNet, &chaincfg.TestNet3, &chaincfg.STN} {
+			addr, err := script.NewAddressFromStringForNetwork(testnetAddr, params)
+			require.NoError(t, err)
+			require.Equal(t, testPublicKeyHash, addr.PublicKeyHash.String())
+		}
+	})
+
+	t.Run("wrong network", func(t *testing.T) {
+		_, err := script.NewAddressFromStringForNetwork(mainnetAddr, &chaincfg.TestNet)
+		require.ErrorIs(t, err, script.ErrWrongNetwork)
+
+		_, err = script.NewAddressFromStringForNetwork(testnetAddr, &chaincfg.MainNet)
+		require.ErrorIs(t, err, script.ErrWrongNetwork)
+	})
+
+	t.Run("bad checksum", func(t *testing.T) {
+		_, err := script.NewAddressFromStringForNetwork("114ZWApV4EEU8frr7zygqQcB1V2BodGZuT", &chaincfg.MainNet)
+		require.ErrorIs(t, err, script.ErrEncodingChecksumFailed)
+	})
+
+	t.Run("custom prefix", func(t *testing.T) {
+		custom := &chaincfg.Params{Name: "custom", LegacyPubKeyHashAddrID: 0x30}
+		hash, err := hex.DecodeString(testPublicKeyHash)
+		require.NoError(t, err)
+
+		addr, err := script.NewAddressFromPublicKeyHashForNetwork(hash, custom)
+		require.NoError(t, err)
+		require.True(t, addr.IsForNetwork(custom))
+		require.False(t, addr.IsForNetwork(&chaincfg.MainNet))
+
+		parsed, err := script.NewAddressFromStringForNetwork(addr.AddressString, custom)
+		require.NoError(t, err)
+		require.Equal(t, addr.PublicKeyHash, parsed.PublicKeyHash)
+	})
+}
+
+func TestNewAddressFromPublicKeyForNetwork(t *testing.T) {
+	t.Parallel()
+
+	privKey, err := ec.NewPrivateKey()
+	require.NoError(t, err)
+
+	legacy, err := script.NewAddressFromPublicKey(privKey.PubKey(), false)
+	require.NoError(t, err)
+	addr, err := script.NewAddressFromPublicKeyForNetwork(privKey.PubKey(), &chaincfg.TestNet)
+	require.NoError(t, err)
+	require.Equal(t, legacy, addr)
+}
+
 func TestNewAddressFromPublicKeyStringInvalid(t *testing.T) {
 	t.Parallel()
 
@@ -0,0 +1,172 @@
+// Package covenant builds OP_PUSH_TX covenants: locking scripts that receive the
+// BIP-143 signature hash preimage of the spending transaction in the unlocking
+// script, prove it is genuine, and then inspect its fields to constrain how the
+// output can be spent.
+//
+// The preimage is proven with the well known CHECKSIG trick. The locking script
+// hashes the preimage and computes the ECDSA signature of that hash for the private
+// key 1 and nonce 1, for which the signature is simply s = hash + Gx (mod n), encodes
+// it as DER and checks it against the generator point with OP_CHECKSIGVERIFY. The
+// check only passes if the preimage is the one the interpreter computes for the
+// input, so the fields extracted from it can be trusted.
+//
+// The scripts rely on post-Genesis rules (big number arithmetic, OP_SPLIT, OP_CAT)
+// and on SIGHASH_FORKID signature hashes.
+package covenant
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	ec "github.com/bsv-blockchain/go-sdk/primitives/ec"
+	crypto "github.com/bsv-blockchain/go-sdk/primitives/hash"
+	"github.com/bsv-blockchain/go-sdk/script"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+	sighash "github.com/bsv-blockchain/go-sdk/transaction/sighash"
+)
+
+var (
+	ErrForkIDRequired = errors.New("covenant preimages require a SIGHASH_FORKID flag")
+	// ErrUnsignablePreimage is returned when the signature computed by the locking
+	// script would not be 32 bytes long, which happens for roughly 1 in 256
+	// preimages. Changing the transaction, for example its lock time or the
+	// sequence number of another input, and trying again resolves it.
+	ErrUnsignablePreimage = errors.New("preimage signature is not 32 bytes, modify the transaction and retry")
+	ErrInvalidPreimage    = errors.New("invalid sighash preimage")
+)
+
+var (
+	curveOrder     = ec.S256().N
+	halfCurveOrder = new(big.Int).Rsh(ec.S256().N, 1)
+	// generatorX is r for the nonce 1.
+	generatorX = ec.S256().Gx
+	// minS is the smallest s whose big-endian encoding is 32 bytes.
+	minS = new(big.Int).Lsh(big.NewInt(1), 248)
+)
+
+// generatorPubKey is the public key of the private key 1.
+func generatorPubKey() []byte {
+	_, pub := ec.PrivateKeyFromBytes([]byte{1})
+	return pub.Compressed()
+}
+
+// CheckPreimage returns a script fragment that consumes the preimage on top of the
+// stack and fails unless it is the preimage of the spending input for sigHashFlag.
+// Prefix it with OP_DUP to keep the preimage for field checks.
+func CheckPreimage(sigHashFlag sighash.Flag) (*script.Script, error) {
+	if !sigHashFlag.Has(sighash.ForkID) {
+		return nil, ErrForkIDRequired
+	}
+	s := &script.Script{}
+
+	// e: the double SHA-256 of the preimage, read as a big-endian number
+	_ = s.AppendOpcodes(script.OpHASH256)
+	appendReverse(s, 32)
+	if err := s.AppendPushData([]byte{0x00}); err != nil {
+		return nil, err
+	}
+	_ = s.AppendOpcodes(script.OpCAT, script.OpBIN2NUM)
+
+	// s = e + r (mod n), replaced by n - s when above n/2 to keep it low
+	if err := appendNumber(s, generatorX); err != nil {
+		return nil, err
+	}
+	_ = s.AppendOpcodes(script.OpADD)
+	if err := appendNumber(s, curveOrder); err != nil {
+		return nil, err
+	}
+	_ = s.AppendOpcodes(script.OpMOD, script.OpDUP)
+	if err := appendNumber(s, halfCurveOrder); err != nil {
+		return nil, err
+	}
+	_ = s.AppendOpcodes(script.OpGREATERTHAN, script.OpIF)
+	if err := appendNumber(s, curveOrder); err != nil {
+		return nil, err
+	}
+	_ = s.AppendOpcodes(script.OpSWAP, script.OpSUB, script.OpENDIF)
+
+	// DER encode (r, s) with s as 32 big-endian bytes, then append the sighash byte
+	if err := appendInt(s, 32); err != nil {
+		return nil, err
+	}
+	_ = s.AppendOpcodes(script.OpNUM2BIN)
+	appendReverse(s, 32)
+	prefix := append([]byte{0x30, 0x44, 0x02, 0x20}, generatorX.FillBytes(make([]byte, 32))...)
+	prefix = append(prefix, 0x02, 0x20)
+	if err := s.AppendPushData(prefix); err != nil {
+		return nil, err
+	}
+	_ = s.AppendOpcodes(script.OpSWAP, script.OpCAT)
+	if err := s.AppendPushData([]byte{byte(sigHashFlag)}); err != nil {
+		return nil, err
+	}
+	_ = s.AppendOpcodes(script.OpCAT)
+
+	if err := s.AppendPushData(generatorPubKey()); err != nil {
+		return nil, err
+	}
+	_ = s.AppendOpcodes(script.OpCHECKSIGVERIFY)
+	return s, nil
+}
+
+// Preimage returns the preimage to push when spending a covenant input. It fails
+// with ErrUnsignablePreimage when the transaction has to be modified first.
+func Preimage(tx *transaction.Transaction, inputIndex uint32, sigHashFlag sighash.Flag) ([]byte, error) {
+	if !sigHashFlag.Has(sighash.ForkID) {
+		return nil, ErrForkIDRequired
+	}
+	preimage, err := tx.InputPreimage(inputIndex, sigHashFlag)
+	if err != nil {
+		return nil, err
+	}
+	e := new(big.Int).SetBytes(crypto.Sha256d(preimage))
+	s := e.Add(e, generatorX)
+	s.Mod(s, curveOrder)
+	if s.Cmp(halfCurveOrder) > 0 {
+		s.Sub(curveOrder, s)
+	}
+	if s.Cmp(minS) < 0 {
+		return nil, ErrUnsignablePreimage
+	}
+	return preimage, nil
+}
+
+// appendReverse reverses the byte order of the size byte item on top of the stack.
+func appendReverse(s *script.Script, size int) {
+	for range size - 1 {
+		_ = s.AppendOpcodes(script.Op1, script.OpSPLIT)
+	}
+	for range size - 1 {
+		_ = s.AppendOpcodes(script.OpSWAP, script.OpCAT)
+	}
+}
+
+// appendInt pushes a small non-negative number, using OP_0 to OP_16 when possible.
+func appendInt(s *script.Script, n int) error {
+	switch {
+	case n == 0:
+		return s.AppendOpcodes(script.Op0)
+	case n <= 16:
+		return s.AppendOpcodes(script.Op1 + byte(n-1))
+	default:
+		return appendNumber(s, big.NewInt(int64(n)))
+	}
+}
+
+// appendNumber pushes a positive number as a minimally encoded script number.
+func appendNumber(s *script.Script, n *big.Int) error {
+	if n.Sign() <= 0 {
+		return fmt.Errorf("cannot push non-positive number %s", n)
+	}
+	be := n.Bytes()
+	le := make([]byte, len(be), len(be)+1)
+	for i, b := range be {
+		le[len(be)-1-i] = b
+	}
+	// keep the number positive when the most significant byte has its top bit set
+	if le[len(le)-1]&0x80 != 0 {
+		le = append(le, 0x00)
+	}
+	return s.AppendPushData(le)
+}
@@ -0,0 +1,116 @@
+package covenant_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"testing"
+
+	"github.com/bsv-blockchain/go-sdk/script"
+	"github.com/bsv-blockchain/go-sdk/script/covenant"
+	"github.com/bsv-blockchain/go-sdk/script/interpreter"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+	sighash "github.com/bsv-blockchain/go-sdk/transaction/sighash"
+	"github.com/stretchr/testify/require"
+)
+
+// lockRequiringLockTime locks 1000 satoshis so they can only be spent by a transaction with the given nLockTime.
+func lockRequiringLockTime(t *testing.T, lockTime uint32) *script.Script {
+	check, err := covenant.CheckPreimage(sighash.AllForkID)
+	require.NoError(t, err)
+	value, err := covenant.FieldValue.ExtractNumber()
+	require.NoError(t, err)
+	lockTimeField, err := covenant.FieldLockTime.Extract()
+	require.NoError(t, err)
+
+	s := &script.Script{script.OpDUP}
+	*s = append(*s, *check...)
+	require.NoError(t, s.AppendOpcodes(script.OpDUP))
+	*s = append(*s, *value...)
+	require.NoError(t, s.AppendPushData([]byte{0xe8, 0x03})) // 1000
+	require.NoError(t, s.AppendOpcodes(script.OpNUMEQUALVERIFY))
+	*s = append(*s, *lockTimeField...)
+	require.NoError(t, s.AppendPushData(binary.LittleEndian.AppendUint32(nil, lockTime)))
+	require.NoError(t, s.AppendOpcodes(script.OpEQUAL))
+	return s
+}
+
+// spend builds a transaction spending the covenant output, bumping the sequence until the preimage is signable.
+func spend(t *testing.T, lockingScript *script.Script, lockTime uint32) (*transaction.Transaction, error) {
+	sourceTx := transaction.NewTransaction()
+	sourceTx.AddOutput(&transaction.TransactionOutput{Satoshis: 1000, LockingScript: lockingScript})
+
+	for sequence := uint32(0); ; sequence++ {
+		tx := transaction.NewTransaction()
+		tx.LockTime = lockTime
+		tx.AddInputFromTx(sourceTx, 0, covenant.Unlock(nil))
+		tx.Inputs[0].SequenceNumber = sequence
+		tx.AddOutput(&transaction.TransactionOutput{Satoshis: 900, LockingScript: &script.Script{script.OpTRUE}})
+		err := tx.Sign()
+		if errors.Is(err, covenant.ErrUnsignablePreimage) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		return tx, nil
+	}
+}
+
+func execute(tx *transaction.Transaction) error {
+	return interpreter.NewEngine().Execute(
+		interpreter.WithTx(tx, 0, tx.Inputs[0].SourceTxOutput()),
+		interpreter.WithForkID(),
+		interpreter.WithAfterGenesis(),
+	)
+}
+
+func TestCovenant(t *testing.T) {
+	lockingScript := lockRequiringLockTime(t, 500)
+
+	t.Run("accepts the required spend", func(t *testing.T) {
+		tx, err := spend(t, lockingScript, 500)
+		require.NoError(t, err)
+		require.NoError(t, execute(tx))
+
+		preimage := *tx.Inputs[0].UnlockingScript
+		chunks, err := script.NewFromBytes(preimage).Chunks()
+		require.NoError(t, err)
+		parsed, err := covenant.ParsePreimage(chunks[0].Data)
+		require.NoError(t, err)
+		require.Equal(t, uint32(500), parsed.LockTime)
+		require.Equal(t, uint64(1000), parsed.Value)
+		require.Equal(t, sighash.AllForkID, parsed.SigHashFlag)
+		require.Equal(t, *tx.Inputs[0].SourceTXID, parsed.Outpoint.Txid)
+		require.Equal(t, lockingScript.Bytes(), parsed.ScriptCode.Bytes())
+	})
+
+	t.Run("rejects other spends", func(t *testing.T) {
+		tx, err := spend(t, lockingScript, 501)
+		require.NoError(t, err)
+		require.Error(t, execute(tx))
+	})
+
+	t.Run("rejects forged preimages", func(t *testing.T) {
+		tx, err := spend(t, lockingScript, 500)
+		require.NoError(t, err)
+		tx.LockTime = 400
+		require.Error(t, execute(tx), "the preimage no longer matches the transaction")
+	})
+}
+
+func TestFieldRead(t *testing.T) {
+	preimage := bytes.Repeat([]byte{0}, 200)
+	preimage[0] = 2
+	value, err := covenant.FieldVersion.Read(preimage)
+	require.NoError(t, err)
+	require.Equal(t, []byte{2, 0, 0, 0}, value)
+
+	_, err = covenant.FieldValue.Read(preimage[:50])
+	require.ErrorIs(t, err, covenant.ErrInvalidPreimage)
+	_, err = covenant.ParsePreimage(preimage[:50])
+	require.ErrorIs(t, err, covenant.ErrInvalidPreimage)
+
+	_, err = covenant.CheckPreimage(sighash.All)
+	require.ErrorIs(t, err, covenant.ErrForkIDRequired)
+}
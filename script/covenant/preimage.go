@@ -0,0 +1,155 @@
+package covenant
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	"github.com/bsv-blockchain/go-sdk/chainhash"
+	"github.com/bsv-blockchain/go-sdk/script"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+	sighash "github.com/bsv-blockchain/go-sdk/transaction/sighash"
+	"github.com/bsv-blockchain/go-sdk/util"
+)
+
+// Field is a fixed size field of a BIP-143 preimage. Fields before the script code
+// are located from the start of the preimage, the others from its end.
+type Field struct {
+	Name    string
+	Offset  int
+	Length  int
+	FromEnd bool
+}
+
+// The fixed size fields of a preimage. The script code between Outpoint and Value
+// has a variable length.
+var (
+	FieldVersion      = Field{Name: "version", Offset: 0, Length: 4}
+	FieldHashPrevouts = Field{Name: "hashPrevouts", Offset: 4, Length: 32}
+	FieldHashSequence = Field{Name: "hashSequence", Offset: 36, Length: 32}
+	FieldOutpoint     = Field{Name: "outpoint", Offset: 68, Length: 36}
+	FieldValue        = Field{Name: "value", Offset: 52, Length: 8, FromEnd: true}
+	FieldSequence     = Field{Name: "nSequence", Offset: 44, Length: 4, FromEnd: true}
+	FieldHashOutputs  = Field{Name: "hashOutputs", Offset: 40, Length: 32, FromEnd: true}
+	FieldLockTime     = Field{Name: "nLockTime", Offset: 8, Length: 4, FromEnd: true}
+	FieldSigHashType  = Field{Name: "sighashType", Offset: 4, Length: 4, FromEnd: true}
+)
+
+// minPreimageLength is the length of a preimage with an empty script code.
+const minPreimageLength = 4 + 32 + 32 + 36 + 1 + 8 + 4 + 32 + 4 + 4
+
+// Extract returns a script fragment replacing the preimage on top of the stack
+// with the raw bytes of the field.
+func (f Field) Extract() (*script.Script, error) {
+	s := &script.Script{}
+	if f.FromEnd {
+		if err := s.AppendOpcodes(script.OpSIZE); err != nil {
+			return nil, err
+		}
+		if err := appendInt(s, f.Offset); err != nil {
+			return nil, err
+		}
+		_ = s.AppendOpcodes(script.OpSUB, script.OpSPLIT, script.OpNIP)
+	} else if f.Offset > 0 {
+		if err := appendInt(s, f.Offset); err != nil {
+			return nil, err
+		}
+		_ = s.AppendOpcodes(script.OpSPLIT, script.OpNIP)
+	}
+	if f.FromEnd && f.Offset == f.Length {
+		return s, nil
+	}
+	if err := appendInt(s, f.Length); err != nil {
+		return nil, err
+	}
+	_ = s.AppendOpcodes(script.OpSPLIT, script.OpDROP)
+	return s, nil
+}
+
+// ExtractNumber is like Extract, but converts the little-endian unsigned field to a
+// script number. It suits Version, Value, Sequence, LockTime and SigHashType.
+func (f Field) ExtractNumber() (*script.Script, error) {
+	s, err := f.Extract()
+	if err != nil {
+		return nil, err
+	}
+	if err := s.AppendPushData([]byte{0x00}); err != nil {
+		return nil, err
+	}
+	_ = s.AppendOpcodes(script.OpCAT, script.OpBIN2NUM)
+	return s, nil
+}
+
+// Read returns the bytes of the field in preimage.
+func (f Field) Read(preimage []byte) ([]byte, error) {
+	if len(preimage) < minPreimageLength {
+		return nil, fmt.Errorf("%w: %d bytes is too short", ErrInvalidPreimage, len(preimage))
+	}
+	start := f.Offset
+	if f.FromEnd {
+		start = len(preimage) - f.Offset
+	}
+	return preimage[start : start+f.Length], nil
+}
+
+// ParsedPreimage holds the decoded fields of a BIP-143 preimage.
+type ParsedPreimage struct {
+	Version      uint32
+	HashPrevouts chainhash.Hash
+	HashSequence chainhash.Hash
+	Outpoint     transaction.Outpoint
+	ScriptCode   *script.Script
+	Value        uint64
+	Sequence     uint32
+	HashOutputs  chainhash.Hash
+	LockTime     uint32
+	SigHashFlag  sighash.Flag
+}
+
+// ParsePreimage decodes a BIP-143 preimage, as returned by Preimage.
+func ParsePreimage(preimage []byte) (*ParsedPreimage, error) {
+	if len(preimage) < minPreimageLength {
+		return nil, fmt.Errorf("%w: %d bytes is too short", ErrInvalidPreimage, len(preimage))
+	}
+	p := &ParsedPreimage{}
+	read := func(f Field) []byte {
+		b, _ := f.Read(preimage)
+		return b
+	}
+	p.Version = binary.LittleEndian.Uint32(read(FieldVersion))
+	copy(p.HashPrevouts[:], read(FieldHashPrevouts))
+	copy(p.HashSequence[:], read(FieldHashSequence))
+	outpoint := read(FieldOutpoint)
+	copy(p.Outpoint.Txid[:], outpoint[:32])
+	p.Outpoint.Index = binary.LittleEndian.Uint32(outpoint[32:])
+
+	scriptCode := preimage[FieldOutpoint.Offset+FieldOutpoint.Length : len(preimage)-FieldValue.Offset]
+	if len(scriptCode) < varIntSize(scriptCode[0]) {
+		return nil, fmt.Errorf("%w: truncated script code length", ErrInvalidPreimage)
+	}
+	length, size := util.NewVarIntFromBytes(scriptCode)
+	if uint64(len(scriptCode)-size) != uint64(length) {
+		return nil, fmt.Errorf("%w: script code length mismatch", ErrInvalidPreimage)
+	}
+	p.ScriptCode = script.NewFromBytes(scriptCode[size:])
+
+	p.Value = binary.LittleEndian.Uint64(read(FieldValue))
+	p.Sequence = binary.LittleEndian.Uint32(read(FieldSequence))
+	copy(p.HashOutputs[:], read(FieldHashOutputs))
+	p.LockTime = binary.LittleEndian.Uint32(read(FieldLockTime))
+	p.SigHashFlag = sighash.Flag(binary.LittleEndian.Uint32(read(FieldSigHashType)))
+	return p, nil
+}
+
+// varIntSize returns the encoded size of a varint starting with marker.
+func varIntSize(marker byte) int {
+	switch marker {
+	case 0xff:
+		return 9
+	case 0xfe:
+		return 5
+	case 0xfd:
+		return 3
+	default:
+		return 1
+	}
+}
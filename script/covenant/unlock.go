@@ -0,0 +1,79 @@
+package covenant
+
+import (
+	"github.com/bsv-blockchain/go-sdk/script"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+	sighash "github.com/bsv-blockchain/go-sdk/transaction/sighash"
+	"github.com/bsv-blockchain/go-sdk/util"
+)
+
+// Unlock returns a template that unlocks covenant inputs by pushing their preimage.
+// sigHashFlag must match the flag the locking script checks, and defaults to
+// SIGHASH_ALL|SIGHASH_FORKID.
+func Unlock(sigHashFlag *sighash.Flag) *PushTx {
+	if sigHashFlag == nil {
+		shf := sighash.AllForkID
+		sigHashFlag = &shf
+	}
+	return &PushTx{SigHashFlag: sigHashFlag}
+}
+
+// PushTx is an unlocking script template pushing the sighash preimage of the input.
+type PushTx struct {
+	SigHashFlag *sighash.Flag
+	// Extra is pushed before the preimage, for locking scripts expecting more data.
+	Extra [][]byte
+}
+
+// Sign builds the unlocking script. It fails with ErrUnsignablePreimage when the
+// transaction must be modified before the input can be unlocked.
+func (p *PushTx) Sign(tx *transaction.Transaction, inputIndex uint32) (*script.Script, error) {
+	if tx.InputIdx(int(inputIndex)) == nil {
+		return nil, transaction.ErrInputNoExist
+	}
+	if tx.Inputs[inputIndex].SourceTxOutput() == nil {
+		return nil, transaction.ErrEmptyPreviousTx
+	}
+	preimage, err := Preimage(tx, inputIndex, *p.SigHashFlag)
+	if err != nil {
+		return nil, err
+	}
+	s := &script.Script{}
+	for _, data := range p.Extra {
+		if err := s.AppendPushData(data); err != nil {
+			return nil, err
+		}
+	}
+	if err := s.AppendPushData(preimage); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// EstimateLength returns the length of the unlocking script.
+func (p *PushTx) EstimateLength(tx *transaction.Transaction, inputIndex uint32) uint32 {
+	length := uint64(minPreimageLength - 1)
+	if input := tx.InputIdx(int(inputIndex)); input != nil && input.SourceTxScript() != nil {
+		scriptLen := uint64(len(*input.SourceTxScript()))
+		length += uint64(util.VarInt(scriptLen).Length()) + scriptLen
+	}
+	total := pushLength(length)
+	for _, data := range p.Extra {
+		total += pushLength(uint64(len(data)))
+	}
+	return uint32(total)
+}
+
+// pushLength is the size of a data push of n bytes, including its opcode and length prefix.
+func pushLength(n uint64) uint64 {
+	switch {
+	case n < uint64(script.OpPUSHDATA1):
+		return 1 + n
+	case n <= 0xff:
+		return 2 + n
+	case n <= 0xffff:
+		return 3 + n
+	default:
+		return 5 + n
+	}
+}
@@ -14,6 +14,7 @@ var (
 var (
 	ErrInvalidAddressLength = errors.New("invalid address length")
 	ErrUnsupportedAddress   = errors.New("address not supported")
+	ErrWrongNetwork         = errors.New("address is for a different network")
 )
 
 // Sentinel errors raised by inscriptions.
@@ -0,0 +1,77 @@
+package interpreter
+
+import "sync"
+
+const (
+	// arenaChunkSize is the size of the chunks stack elements are carved from.
+	arenaChunkSize = 16 * 1024
+	// arenaMaxElementSize is the largest element served from a chunk. Larger
+	// elements, which post-Genesis scripts can produce, get their own allocation.
+	arenaMaxElementSize = arenaChunkSize / 8
+	// arenaMaxChunks is the number of chunks an arena keeps when recycled.
+	arenaMaxChunks = 16
+)
+
+// arenaPool recycles arenas between executions.
+var arenaPool = sync.Pool{
+	New: func() any { return &arena{} },
+}
+
+// arena hands out the storage of the stack elements created during an execution,
+// such as the results of numeric and splicing opcodes, from a few large chunks
+// rather than one allocation per element. Everything handed out is reclaimed at
+// once when the execution finishes and the arena is released.
+type arena struct {
+	chunks [][]byte
+	cur    int // index of the chunk being filled
+	off    int // offset of the free space in the current chunk
+
+	// stacks keeps the backing arrays of the data and alt stacks between executions.
+	stacks [2][][]byte
+}
+
+func getArena() *arena {
+	return arenaPool.Get().(*arena)
+}
+
+// alloc returns a zeroed slice of n bytes. The capacity of the slice is n, so
+// appending to it never overwrites neighbouring elements.
+func (a *arena) alloc(n int) []byte {
+	if n > arenaMaxElementSize {
+		return make([]byte, n)
+	}
+	if len(a.chunks) == 0 || a.off+n > arenaChunkSize {
+		a.next()
+	}
+	b := a.chunks[a.cur][a.off : a.off+n : a.off+n]
+	a.off += n
+	clear(b)
+	return b
+}
+
+// next moves to a fresh chunk, reusing one left over from a previous execution if any.
+func (a *arena) next() {
+	if len(a.chunks) > 0 {
+		a.cur++
+	}
+	if a.cur == len(a.chunks) {
+		a.chunks = append(a.chunks, make([]byte, arenaChunkSize))
+	}
+	a.off = 0
+}
+
+// release rewinds the arena and returns it to the pool, keeping the backing
+// arrays of the given stacks for the next execution. None of the slices it
+// handed out may be used afterwards.
+func (a *arena) release(dstack, astack [][]byte) {
+	for i, stk := range [][][]byte{dstack, astack} {
+		clear(stk[:cap(stk)])
+		a.stacks[i] = stk[:0]
+	}
+	if len(a.chunks) > arenaMaxChunks {
+		clear(a.chunks[arenaMaxChunks:])
+		a.chunks = a.chunks[:arenaMaxChunks]
+	}
+	a.cur, a.off = 0, 0
+	arenaPool.Put(a)
+}
@@ -0,0 +1,50 @@
+package interpreter
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/bsv-blockchain/go-sdk/script"
+	"github.com/stretchr/testify/require"
+)
+
+func TestArenaAlloc(t *testing.T) {
+	a := &arena{}
+
+	b := a.alloc(4)
+	copy(b, []byte{1, 2, 3, 4})
+	c := a.alloc(4)
+	require.Len(t, c, 4)
+	require.Equal(t, 4, cap(b), "appending must not overwrite the next element")
+	c = append(b, 5)
+	require.Equal(t, []byte{1, 2, 3, 4}, b)
+	require.Equal(t, []byte{1, 2, 3, 4, 5}, c)
+
+	for range arenaChunkSize / arenaMaxElementSize {
+		a.alloc(arenaMaxElementSize)
+	}
+	require.Len(t, a.chunks, 2, "a full chunk rolls over to a new one")
+
+	large := a.alloc(arenaMaxElementSize + 1)
+	require.Len(t, large, arenaMaxElementSize+1)
+	require.Len(t, a.chunks, 2, "large elements are allocated separately")
+
+	a.release(nil, nil)
+	require.Equal(t, 0, a.cur)
+	require.Equal(t, make([]byte, 4), a.alloc(4), "recycled memory is zeroed")
+}
+
+func TestArenaRecycledAcrossExecutions(t *testing.T) {
+	// <x> OP_DUP OP_CAT <xx> OP_EQUAL, run repeatedly so the arenas get reused.
+	for i := range 100 {
+		x := bytes.Repeat([]byte{byte(i)}, i+1)
+		unlocking := &script.Script{}
+		require.NoError(t, unlocking.AppendPushData(x))
+		locking := &script.Script{}
+		require.NoError(t, locking.AppendOpcodes(script.OpDUP, script.OpCAT))
+		require.NoError(t, locking.AppendPushData(append(x, x...)))
+		require.NoError(t, locking.AppendOpcodes(script.OpEQUAL))
+
+		require.NoError(t, NewEngine().Execute(WithScripts(locking, unlocking), WithAfterGenesis()))
+	}
+}
@@ -0,0 +1,260 @@
+// Package bench holds reference scripts which are expensive to execute, and a
+// harness running them as go test benchmarks, so interpreter changes can be
+// measured against a fixed workload and performance regressions caught:
+//
+//	func BenchmarkEngine(b *testing.B) {
+//		bench.RunAll(b, interpreter.NewEngine())
+//	}
+//
+// The scripts are deterministic, so results are comparable across runs with
+// benchstat. Every case executes with the flags of transactions after the
+// Genesis upgrade, which lift the limits on numbers, opcodes and keys.
+package bench
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"testing"
+
+	"github.com/bsv-blockchain/go-sdk/chainhash"
+	ec "github.com/bsv-blockchain/go-sdk/primitives/ec"
+	crypto "github.com/bsv-blockchain/go-sdk/primitives/hash"
+	"github.com/bsv-blockchain/go-sdk/script"
+	"github.com/bsv-blockchain/go-sdk/script/interpreter"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+	sighash "github.com/bsv-blockchain/go-sdk/transaction/sighash"
+)
+
+const (
+	// MulChainLength is the number of OP_MULs in the mul chain case, each
+	// growing the product by MulOperandSize bytes.
+	MulChainLength = 256
+	MulOperandSize = 64
+	// HashLoopLength is the number of OP_HASH256s in the hash loop case.
+	HashLoopLength = 10000
+	// MultisigKeys is the number of keys, all signing, in the multisig cases.
+	MultisigKeys = 100
+)
+
+// Case is a reference script: a transaction whose input 0 spends a locking
+// script with a valid unlocking script.
+type Case struct {
+	Name string
+	// Tx has the output spent by input 0 attached as its source output.
+	Tx *transaction.Transaction
+}
+
+// Options returns the execution options running the case.
+func (c *Case) Options() []interpreter.ExecutionOptionFunc {
+	return []interpreter.ExecutionOptionFunc{
+		interpreter.WithTx(c.Tx, 0, c.Tx.Inputs[0].SourceTxOutput()),
+		interpreter.WithForkID(),
+		interpreter.WithAfterGenesis(),
+	}
+}
+
+// Cases returns the reference scripts:
+//
+//   - "p2pkh", a standard P2PKH spend as a baseline;
+//   - "mul chain", MulChainLength OP_MULs of MulOperandSize byte numbers;
+//   - "hash loop", HashLoopLength chained OP_HASH256s;
+//   - "checkmultisig", a MultisigKeys of MultisigKeys OP_CHECKMULTISIG;
+//   - "checkmultisig worst order", a 1 of MultisigKeys OP_CHECKMULTISIG signed
+//     by the last key, so every key is tried.
+func Cases() ([]*Case, error) {
+	builders := []struct {
+		name  string
+		build func() (*script.Script, func(*transaction.Transaction) (*script.Script, error), error)
+	}{
+		{"p2pkh", p2pkh},
+		{"mul chain", mulChain},
+		{"hash loop", hashLoop},
+		{"checkmultisig", func() (*script.Script, func(*transaction.Transaction) (*script.Script, error), error) {
+			return checkMultisig(MultisigKeys, false)
+		}},
+		{"checkmultisig worst order", func() (*script.Script, func(*transaction.Transaction) (*script.Script, error), error) {
+			return checkMultisig(1, true)
+		}},
+	}
+
+	cases := make([]*Case, 0, len(builders))
+	for _, builder := range builders {
+		locking, unlock, err := builder.build()
+		if err != nil {
+			return nil, fmt.Errorf("building %s: %w", builder.name, err)
+		}
+		tx := spendingTx(locking)
+		if tx.Inputs[0].UnlockingScript, err = unlock(tx); err != nil {
+			return nil, fmt.Errorf("unlocking %s: %w", builder.name, err)
+		}
+		cases = append(cases, &Case{Name: builder.name, Tx: tx})
+	}
+	return cases, nil
+}
+
+// Run benchmarks engine executing c. It fails b if the case does not verify.
+func Run(b *testing.B, engine interpreter.Engine, c *Case) {
+	b.Helper()
+	opts := c.Options()
+	if err := engine.Execute(opts...); err != nil {
+		b.Fatalf("%s: %v", c.Name, err)
+	}
+	b.ReportAllocs()
+	for b.Loop() {
+		if err := engine.Execute(opts...); err != nil {
+			b.Fatalf("%s: %v", c.Name, err)
+		}
+	}
+}
+
+// RunAll runs every reference case as a sub-benchmark of b.
+func RunAll(b *testing.B, engine interpreter.Engine) {
+	b.Helper()
+	cases, err := Cases()
+	if err != nil {
+		b.Fatal(err)
+	}
+	for _, c := range cases {
+		b.Run(c.Name, func(b *testing.B) {
+			Run(b, engine, c)
+		})
+	}
+}
+
+// key returns the i'th fixed private key of the cases.
+func key(i int) *ec.PrivateKey {
+	seed := sha256.Sum256([]byte(fmt.Sprintf("interpreter bench key %d", i)))
+	priv, _ := ec.PrivateKeyFromBytes(seed[:])
+	return priv
+}
+
+// signature signs input 0 of tx with priv, as pushed by an unlocking script.
+func signature(tx *transaction.Transaction, priv *ec.PrivateKey) ([]byte, error) {
+	hash, err := tx.CalcInputSignatureHash(0, sighash.AllForkID)
+	if err != nil {
+		return nil, err
+	}
+	sig, err := priv.Sign(hash)
+	if err != nil {
+		return nil, err
+	}
+	return append(sig.Serialize(), byte(sighash.AllForkID)), nil
+}
+
+func p2pkh() (*script.Script, func(*transaction.Transaction) (*script.Script, error), error) {
+	priv := key(0)
+	locking := &script.Script{script.OpDUP, script.OpHASH160}
+	if err := locking.AppendPushData(crypto.Hash160(priv.PubKey().Compressed())); err != nil {
+		return nil, nil, err
+	}
+	*locking = append(*locking, script.OpEQUALVERIFY, script.OpCHECKSIG)
+	return locking, func(tx *transaction.Transaction) (*script.Script, error) {
+		sig, err := signature(tx, priv)
+		if err != nil {
+			return nil, err
+		}
+		unlocking := &script.Script{}
+		if err := unlocking.AppendPushDataArray([][]byte{sig, priv.PubKey().Compressed()}); err != nil {
+			return nil, err
+		}
+		return unlocking, nil
+	}, nil
+}
+
+// operand returns a positive, minimally encoded script number of size bytes.
+func operand(seed string, size int) []byte {
+	n := make([]byte, 0, size)
+	for block := 0; len(n) < size; block++ {
+		digest := sha256.Sum256([]byte(fmt.Sprintf("%s %d", seed, block)))
+		n = append(n, digest[:]...)
+	}
+	n = n[:size]
+	// Clear the sign bit and keep the top byte non-zero.
+	n[size-1] = n[size-1]&0x7f | 0x01
+	return n
+}
+
+func mulChain() (*script.Script, func(*transaction.Transaction) (*script.Script, error), error) {
+	locking := &script.Script{}
+	for i := range MulChainLength {
+		if err := locking.AppendPushData(operand(fmt.Sprintf("mul %d", i), MulOperandSize)); err != nil {
+			return nil, nil, err
+		}
+		*locking = append(*locking, script.OpMUL)
+	}
+	// The product of positive numbers is positive.
+	*locking = append(*locking, script.Op0, script.OpGREATERTHAN)
+	return locking, func(*transaction.Transaction) (*script.Script, error) {
+		unlocking := &script.Script{}
+		return unlocking, unlocking.AppendPushData(operand("mul start", MulOperandSize))
+	}, nil
+}
+
+func hashLoop() (*script.Script, func(*transaction.Transaction) (*script.Script, error), error) {
+	preimage := []byte("interpreter bench hash loop")
+	digest := preimage
+	locking := &script.Script{}
+	for range HashLoopLength {
+		digest = crypto.Sha256d(digest)
+		*locking = append(*locking, script.OpHASH256)
+	}
+	if err := locking.AppendPushData(digest); err != nil {
+		return nil, nil, err
+	}
+	*locking = append(*locking, script.OpEQUAL)
+	return locking, func(*transaction.Transaction) (*script.Script, error) {
+		unlocking := &script.Script{}
+		return unlocking, unlocking.AppendPushData(preimage)
+	}, nil
+}
+
+// checkMultisig locks to an m of MultisigKeys OP_CHECKMULTISIG, signed by the
+// first m keys, or by the last m keys when last is set.
+func checkMultisig(m int, last bool) (*script.Script, func(*transaction.Transaction) (*script.Script, error), error) {
+	keys := make([]*ec.PrivateKey, MultisigKeys)
+	locking := smallInt(m)
+	for i := range keys {
+		keys[i] = key(i)
+		if err := locking.AppendPushData(keys[i].PubKey().Compressed()); err != nil {
+			return nil, nil, err
+		}
+	}
+	*locking = append(*locking, *smallInt(MultisigKeys)...)
+	*locking = append(*locking, script.OpCHECKMULTISIG)
+
+	signers := keys[:m]
+	if last {
+		signers = keys[len(keys)-m:]
+	}
+	return locking, func(tx *transaction.Transaction) (*script.Script, error) {
+		unlocking := &script.Script{script.Op0}
+		for _, priv := range signers {
+			sig, err := signature(tx, priv)
+			if err != nil {
+				return nil, err
+			}
+			if err := unlocking.AppendPushData(sig); err != nil {
+				return nil, err
+			}
+		}
+		return unlocking, nil
+	}, nil
+}
+
+// smallInt returns a script pushing n, at most 127, minimally.
+func smallInt(n int) *script.Script {
+	if n >= 1 && n <= 16 {
+		return &script.Script{script.Op1 + byte(n) - 1}
+	}
+	return &script.Script{script.OpDATA1, byte(n)}
+}
+
+func spendingTx(locking *script.Script) *transaction.Transaction {
+	tx := transaction.NewTransaction()
+	tx.AddInputWithOutput(&transaction.TransactionInput{
+		SourceTXID:     &chainhash.Hash{1},
+		SequenceNumber: transaction.DefaultSequenceNumber,
+	}, &transaction.TransactionOutput{Satoshis: 1000, LockingScript: locking})
+	tx.AddOutput(&transaction.TransactionOutput{Satoshis: 900, LockingScript: &script.Script{script.OpFALSE, script.OpRETURN}})
+	return tx
+}
@@ -0,0 +1,32 @@
+package bench_test
+
+import (
+	"testing"
+
+	"github.com/bsv-blockchain/go-sdk/script/interpreter"
+	"github.com/bsv-blockchain/go-sdk/script/interpreter/bench"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCasesVerify(t *testing.T) {
+	cases, err := bench.Cases()
+	require.NoError(t, err)
+	engine := interpreter.NewEngine()
+	for _, c := range cases {
+		require.NoError(t, engine.Execute(c.Options()...), c.Name)
+
+		// The cases must not pass vacuously.
+		unlocking := c.Tx.Inputs[0].UnlockingScript
+		c.Tx.Inputs[0].UnlockingScript = nil
+		require.Error(t, engine.Execute(c.Options()...), c.Name)
+		c.Tx.Inputs[0].UnlockingScript = unlocking
+	}
+}
+
+func BenchmarkReferenceScripts(b *testing.B) {
+	bench.RunAll(b, interpreter.NewEngine())
+}
+
+func BenchmarkReferenceScriptsWithPubKeyCache(b *testing.B) {
+	bench.RunAll(b, interpreter.NewEngine(interpreter.WithPubKeyCache(interpreter.NewPubKeyCache(0))))
+}
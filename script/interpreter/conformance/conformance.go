@@ -0,0 +1,378 @@
+// Package conformance runs the reference script and transaction test vectors
+// (script_tests.json, tx_valid.json and tx_invalid.json, in the format shared by
+// bitcoin-sv and bchd) through a script engine. Forks of the interpreter can use
+// it to check that their modifications remain consensus compatible:
+//
+//	f, _ := os.Open("script_tests.json")
+//	tests, err := conformance.LoadScriptTests(f)
+//	if err != nil {
+//		return err
+//	}
+//	report := conformance.RunScriptTests(myEngine, tests)
+//	for _, failure := range report.Failures {
+//		log.Printf("%s: %v", failure.Name, failure.Err)
+//	}
+package conformance
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/bsv-blockchain/go-sdk/chainhash"
+	"github.com/bsv-blockchain/go-sdk/script"
+	"github.com/bsv-blockchain/go-sdk/script/interpreter"
+	"github.com/bsv-blockchain/go-sdk/script/interpreter/errs"
+	"github.com/bsv-blockchain/go-sdk/script/interpreter/scriptflag"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+)
+
+// ErrNonConformant is returned when an engine's result differs from the one
+// expected by a test vector.
+var ErrNonConformant = errors.New("result does not match the test vector")
+
+// ErrMalformedVector is returned when a test vector file cannot be parsed.
+var ErrMalformedVector = errors.New("malformed test vector")
+
+// ScriptTest is a vector from script_tests.json: an unlocking script spending a
+// locking script under a set of flags, with the expected result.
+type ScriptTest struct {
+	// Index is the position of the vector in its file.
+	Index           int
+	Name            string
+	UnlockingScript *script.Script
+	LockingScript   *script.Script
+	Satoshis        uint64
+	Flags           scriptflag.Flag
+	// Expected is the reference result name, such as "OK" or "EVAL_FALSE".
+	Expected string
+	// ExpectedErrors are the error codes matching Expected; any of them conforms.
+	ExpectedErrors []errs.ErrorCode
+}
+
+// TxTest is a vector from tx_valid.json or tx_invalid.json: a transaction whose
+// inputs carry the outputs they spend, and whether it is expected to verify.
+type TxTest struct {
+	// Index is the position of the vector in its file.
+	Index int
+	// Name is the comment preceding the vector in its file.
+	Name  string
+	Tx    *transaction.Transaction
+	Flags scriptflag.Flag
+	Valid bool
+}
+
+// LoadScriptTests reads the vectors of a script_tests.json file. Comment lines
+// are skipped. Each vector has the form:
+//
+//	[[amount]?, unlocking script, locking script, flags, expected result, comment?]
+func LoadScriptTests(r io.Reader) ([]*ScriptTest, error) {
+	var vectors [][]any
+	if err := json.NewDecoder(r).Decode(&vectors); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrMalformedVector, err)
+	}
+
+	var tests []*ScriptTest
+	for i, vector := range vectors {
+		if len(vector) == 1 {
+			continue
+		}
+		test, err := parseScriptTest(i, vector)
+		if err != nil {
+			return nil, fmt.Errorf("%w: vector %d: %w", ErrMalformedVector, i, err)
+		}
+		tests = append(tests, test)
+	}
+	return tests, nil
+}
+
+func parseScriptTest(index int, vector []any) (*ScriptTest, error) {
+	test := &ScriptTest{Index: index}
+	if amount, ok := vector[0].([]any); ok {
+		if len(amount) == 0 {
+			return nil, errors.New("empty amount")
+		}
+		f, ok := amount[len(amount)-1].(float64)
+		if !ok {
+			return nil, errors.New("amount is not a number")
+		}
+		test.Satoshis = uint64(f * 1e8)
+		vector = vector[1:]
+	}
+	if len(vector) < 4 || len(vector) > 5 {
+		return nil, fmt.Errorf("invalid length %d", len(vector))
+	}
+
+	fields := make([]string, len(vector))
+	for i, v := range vector {
+		s, ok := v.(string)
+		if !ok {
+			return nil, fmt.Errorf("field %d is not a string", i)
+		}
+		fields[i] = s
+	}
+	if len(fields) == 5 {
+		test.Name = fields[4]
+	} else {
+		test.Name = fmt.Sprintf("[%s, %s, %s]", fields[0], fields[1], fields[2])
+	}
+
+	var err error
+	if test.UnlockingScript, err = ParseShortForm(fields[0]); err != nil {
+		return nil, fmt.Errorf("unlocking script: %w", err)
+	}
+	if test.LockingScript, err = ParseShortForm(fields[1]); err != nil {
+		return nil, fmt.Errorf("locking script: %w", err)
+	}
+	if test.Flags, err = ParseFlags(fields[2]); err != nil {
+		return nil, err
+	}
+	test.Expected = fields[3]
+	if test.ExpectedErrors, err = ExpectedErrors(fields[3]); err != nil {
+		return nil, err
+	}
+	return test, nil
+}
+
+// Run executes the vector with e, returning an error wrapping ErrNonConformant if
+// the result is not the expected one.
+func (t *ScriptTest) Run(e interpreter.Engine) error {
+	tx := spendingTx(t.UnlockingScript, t.LockingScript, t.Satoshis)
+	err := e.Execute(
+		interpreter.WithTx(tx, 0, &transaction.TransactionOutput{LockingScript: t.LockingScript, Satoshis: t.Satoshis}),
+		interpreter.WithFlags(t.Flags),
+	)
+	if t.Expected == "OK" {
+		if err != nil {
+			return fmt.Errorf("%w: want OK, got %w", ErrNonConformant, err)
+		}
+		return nil
+	}
+	for _, code := range t.ExpectedErrors {
+		if errs.IsErrorCode(err, code) {
+			return nil
+		}
+	}
+	if err == nil {
+		return fmt.Errorf("%w: want %s, got OK", ErrNonConformant, t.Expected)
+	}
+	return fmt.Errorf("%w: want %s %v, got %w", ErrNonConformant, t.Expected, t.ExpectedErrors, err)
+}
+
+// spendingTx builds a transaction spending the output of a coinbase-like
+// transaction locked by lockingScript, as the reference tests do.
+func spendingTx(unlockingScript, lockingScript *script.Script, satoshis uint64) *transaction.Transaction {
+	coinbaseTx := transaction.NewTransaction()
+	coinbaseTx.AddInput(&transaction.TransactionInput{
+		SourceTXID:       &chainhash.Hash{},
+		SourceTxOutIndex: ^uint32(0),
+		UnlockingScript:  script.NewFromBytes([]byte{script.Op0, script.Op0}),
+		SequenceNumber:   0xffffffff,
+	})
+	coinbaseTx.AddOutput(&transaction.TransactionOutput{
+		Satoshis:      satoshis,
+		LockingScript: lockingScript,
+	})
+
+	tx := &transaction.Transaction{
+		Version: 1,
+		Inputs: []*transaction.TransactionInput{{
+			SourceTXID:       coinbaseTx.TxID(),
+			SourceTxOutIndex: 0,
+			UnlockingScript:  unlockingScript,
+			SequenceNumber:   0xffffffff,
+		}},
+		Outputs: []*transaction.TransactionOutput{{
+			Satoshis:      satoshis,
+			LockingScript: script.NewFromBytes([]byte{}),
+		}},
+	}
+	tx.Inputs[0].SetSourceTxOutput(&transaction.TransactionOutput{LockingScript: lockingScript})
+	return tx
+}
+
+// LoadTxTests reads the vectors of a tx_valid.json (valid true) or
+// tx_invalid.json (valid false) file. Comment lines name the vectors following
+// them. Each vector has the form:
+//
+//	[[[previous txid, previous index, previous locking script, amount?]...], transaction hex, flags]
+func LoadTxTests(r io.Reader, valid bool) ([]*TxTest, error) {
+	var vectors [][]any
+	if err := json.NewDecoder(r).Decode(&vectors); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrMalformedVector, err)
+	}
+
+	var tests []*TxTest
+	var comments []string
+	for i, vector := range vectors {
+		if len(vector) == 0 {
+			continue
+		}
+		if comment, ok := vector[0].(string); ok {
+			comments = append(comments, strings.TrimSpace(comment))
+			continue
+		}
+		test, err := parseTxTest(i, vector)
+		if err != nil {
+			return nil, fmt.Errorf("%w: vector %d: %w", ErrMalformedVector, i, err)
+		}
+		test.Valid = valid
+		test.Name = strings.Join(comments, " ")
+		if test.Name == "" {
+			test.Name = fmt.Sprintf("vector %d", i)
+		}
+		comments = nil
+		tests = append(tests, test)
+	}
+	return tests, nil
+}
+
+type outpoint struct {
+	txid  string
+	index uint32
+}
+
+func parseTxTest(index int, vector []any) (*TxTest, error) {
+	if len(vector) != 3 {
+		return nil, fmt.Errorf("invalid length %d", len(vector))
+	}
+	inputs, ok := vector[0].([]any)
+	if !ok {
+		return nil, errors.New("inputs are not an array")
+	}
+	txHex, ok := vector[1].(string)
+	if !ok {
+		return nil, errors.New("transaction is not a string")
+	}
+	flagStr, ok := vector[2].(string)
+	if !ok {
+		return nil, errors.New("flags are not a string")
+	}
+
+	b, err := hex.DecodeString(txHex)
+	if err != nil {
+		return nil, fmt.Errorf("transaction is not hex: %w", err)
+	}
+	tx, err := transaction.NewTransactionFromBytes(b)
+	if err != nil {
+		return nil, fmt.Errorf("invalid transaction: %w", err)
+	}
+	flags, err := ParseFlags(flagStr)
+	if err != nil {
+		return nil, err
+	}
+
+	prevOuts := make(map[outpoint]*transaction.TransactionOutput, len(inputs))
+	for j, in := range inputs {
+		input, ok := in.([]any)
+		if !ok || len(input) < 3 || len(input) > 4 {
+			return nil, fmt.Errorf("input %d is not a 3 or 4 element array", j)
+		}
+		txid, ok := input[0].(string)
+		if !ok {
+			return nil, fmt.Errorf("input %d txid is not a string", j)
+		}
+		idx, ok := input[1].(float64)
+		if !ok {
+			return nil, fmt.Errorf("input %d index is not a number", j)
+		}
+		lockingStr, ok := input[2].(string)
+		if !ok {
+			return nil, fmt.Errorf("input %d locking script is not a string", j)
+		}
+		lockingScript, err := ParseShortForm(lockingStr)
+		if err != nil {
+			return nil, fmt.Errorf("input %d locking script: %w", j, err)
+		}
+		var satoshis float64
+		if len(input) == 4 {
+			if satoshis, ok = input[3].(float64); !ok {
+				return nil, fmt.Errorf("input %d amount is not a number", j)
+			}
+		}
+		// The vectors use -1 for the max index; converting through int32 keeps
+		// that platform independent.
+		prevOuts[outpoint{txid: txid, index: uint32(int32(idx))}] = &transaction.TransactionOutput{
+			Satoshis:      uint64(satoshis),
+			LockingScript: lockingScript,
+		}
+	}
+
+	for k, in := range tx.Inputs {
+		prevOut, ok := prevOuts[outpoint{txid: in.SourceTXID.String(), index: in.SourceTxOutIndex}]
+		if !ok {
+			return nil, fmt.Errorf("no previous output for input %d", k)
+		}
+		in.SetSourceTxOutput(prevOut)
+	}
+	return &TxTest{Index: index, Tx: tx, Flags: flags}, nil
+}
+
+// Run verifies every input of the transaction with e, returning an error wrapping
+// ErrNonConformant if a valid transaction fails or an invalid one verifies.
+func (t *TxTest) Run(e interpreter.Engine) error {
+	for k, in := range t.Tx.Inputs {
+		err := e.Execute(
+			interpreter.WithTx(t.Tx, k, in.SourceTxOutput()),
+			interpreter.WithFlags(t.Flags),
+		)
+		switch {
+		case err != nil && t.Valid:
+			return fmt.Errorf("%w: input %d failed: %w", ErrNonConformant, k, err)
+		case err != nil:
+			// Invalid transactions may have valid inputs; one failure is enough.
+			return nil
+		}
+	}
+	if !t.Valid {
+		return fmt.Errorf("%w: invalid transaction verified", ErrNonConformant)
+	}
+	return nil
+}
+
+// Failure is a vector an engine did not conform to.
+type Failure struct {
+	Index int
+	Name  string
+	Err   error
+}
+
+// Report summarizes a run of vectors.
+type Report struct {
+	Passed   int
+	Failures []Failure
+}
+
+// OK reports whether every vector passed.
+func (r *Report) OK() bool {
+	return len(r.Failures) == 0
+}
+
+// RunScriptTests runs the script vectors with e.
+func RunScriptTests(e interpreter.Engine, tests []*ScriptTest) *Report {
+	report := &Report{}
+	for _, t := range tests {
+		report.add(t.Index, t.Name, t.Run(e))
+	}
+	return report
+}
+
+// RunTxTests runs the transaction vectors with e.
+func RunTxTests(e interpreter.Engine, tests []*TxTest) *Report {
+	report := &Report{}
+	for _, t := range tests {
+		report.add(t.Index, t.Name, t.Run(e))
+	}
+	return report
+}
+
+func (r *Report) add(index int, name string, err error) {
+	if err != nil {
+		r.Failures = append(r.Failures, Failure{Index: index, Name: name, Err: err})
+		return
+	}
+	r.Passed++
+}
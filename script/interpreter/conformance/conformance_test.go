@@ -0,0 +1,103 @@
+package conformance
+
+import (
+	"context"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/bsv-blockchain/go-sdk/script"
+	"github.com/bsv-blockchain/go-sdk/script/interpreter"
+	"github.com/stretchr/testify/require"
+)
+
+func loadFile[T any](t *testing.T, path string, load func(*os.File) ([]T, error)) []T {
+	t.Helper()
+	f, err := os.Open(path)
+	require.NoError(t, err)
+	defer f.Close()
+	tests, err := load(f)
+	require.NoError(t, err)
+	require.NotEmpty(t, tests)
+	return tests
+}
+
+func requireConformant(t *testing.T, report *Report) {
+	t.Helper()
+	for _, failure := range report.Failures {
+		t.Errorf("vector %d (%s): %v", failure.Index, failure.Name, failure.Err)
+	}
+	require.True(t, report.OK())
+}
+
+func TestScriptTests(t *testing.T) {
+	tests := loadFile(t, "../data/script_tests.json", func(f *os.File) ([]*ScriptTest, error) {
+		return LoadScriptTests(f)
+	})
+	report := RunScriptTests(interpreter.NewEngine(), tests)
+	requireConformant(t, report)
+	require.Equal(t, len(tests), report.Passed)
+}
+
+func TestTxTests(t *testing.T) {
+	for path, valid := range map[string]bool{
+		"../data/tx_valid.json":   true,
+		"../data/tx_invalid.json": false,
+	} {
+		t.Run(path, func(t *testing.T) {
+			tests := loadFile(t, path, func(f *os.File) ([]*TxTest, error) {
+				return LoadTxTests(f, valid)
+			})
+			requireConformant(t, RunTxTests(interpreter.NewEngine(), tests))
+		})
+	}
+}
+
+// brokenEngine accepts every script, as a faulty fork might.
+type brokenEngine struct{}
+
+func (brokenEngine) Execute(...interpreter.ExecutionOptionFunc) error { return nil }
+
+func (brokenEngine) ExecuteContext(context.Context, ...interpreter.ExecutionOptionFunc) error {
+	return nil
+}
+
+func TestReportsNonConformance(t *testing.T) {
+	tests, err := LoadScriptTests(strings.NewReader(`[
+		["comment"],
+		["1", "0 EQUAL", "P2SH", "EVAL_FALSE", "one is not zero"],
+		["1", "1 EQUAL", "P2SH", "OK"]
+	]`))
+	require.NoError(t, err)
+	require.Len(t, tests, 2)
+	require.Equal(t, "one is not zero", tests[0].Name)
+
+	report := RunScriptTests(brokenEngine{}, tests)
+	require.Equal(t, 1, report.Passed)
+	require.Len(t, report.Failures, 1)
+	require.Equal(t, 1, report.Failures[0].Index)
+	require.ErrorIs(t, report.Failures[0].Err, ErrNonConformant)
+}
+
+func TestLoadRejectsMalformedVectors(t *testing.T) {
+	_, err := LoadScriptTests(strings.NewReader(`[["1", "NOTANOPCODE", "", "OK"]]`))
+	require.ErrorIs(t, err, ErrMalformedVector)
+	_, err = LoadScriptTests(strings.NewReader(`[["1", "1", "BOGUSFLAG", "OK"]]`))
+	require.ErrorIs(t, err, ErrMalformedVector)
+	_, err = LoadTxTests(strings.NewReader(`[[[], "zz", ""]]`), true)
+	require.ErrorIs(t, err, ErrMalformedVector)
+}
+
+func TestParseShortForm(t *testing.T) {
+	s, err := ParseShortForm("0 -1 16 17 'ab' 0x01 0x02 DUP OP_HASH160 TRUE CHECKLOCKTIMEVERIFY")
+	require.NoError(t, err)
+	require.Equal(t, []byte{
+		script.Op0, script.Op1NEGATE, script.Op16, script.OpDATA1, 17, script.OpDATA2, 'a', 'b',
+		0x01, 0x02, script.OpDUP, script.OpHASH160, script.OpTRUE, script.OpCHECKLOCKTIMEVERIFY,
+	}, []byte(*s))
+
+	_, err = ParseShortForm("2")
+	require.NoError(t, err)
+	_, err = ParseShortForm("OP_UNKNOWN186")
+	require.Error(t, err)
+}
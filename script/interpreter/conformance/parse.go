@@ -0,0 +1,228 @@
+package conformance
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/bsv-blockchain/go-sdk/script"
+	"github.com/bsv-blockchain/go-sdk/script/interpreter"
+	"github.com/bsv-blockchain/go-sdk/script/interpreter/errs"
+	"github.com/bsv-blockchain/go-sdk/script/interpreter/scriptflag"
+)
+
+var (
+	shortFormOnce sync.Once
+	shortFormOps  map[string]byte
+)
+
+// shortFormOpcodes returns the opcode names accepted in short form scripts. Every
+// opcode may be written as OP_NAME, and all but the small integer pushes as NAME.
+func shortFormOpcodes() map[string]byte {
+	shortFormOnce.Do(func() {
+		names := make(map[string]byte, len(script.OpCodeStrings)+2)
+		for name, op := range script.OpCodeStrings {
+			names[name] = op
+		}
+		names["OP_CHECKLOCKTIMEVERIFY"] = script.OpCHECKLOCKTIMEVERIFY
+		names["OP_CHECKSEQUENCEVERIFY"] = script.OpCHECKSEQUENCEVERIFY
+
+		shortFormOps = make(map[string]byte, 2*len(names))
+		for name, op := range names {
+			if strings.Contains(name, "OP_UNKNOWN") {
+				continue
+			}
+			shortFormOps[name] = op
+
+			// OP_0 to OP_16 can't have the OP_ prefix stripped or they would
+			// conflict with the plain numbers, but their aliases OP_FALSE and
+			// OP_TRUE can.
+			if name == "OP_FALSE" || name == "OP_TRUE" ||
+				(op != script.Op0 && (op < script.Op1 || op > script.Op16)) {
+				shortFormOps[strings.TrimPrefix(name, "OP_")] = op
+			}
+		}
+	})
+	return shortFormOps
+}
+
+// ParseShortForm parses a script in the short form used by the reference test
+// vectors:
+//   - opcodes are written as OP_NAME or NAME
+//   - plain numbers are pushed as script numbers
+//   - hex numbers beginning with 0x are inserted into the script as-is
+//   - single quoted strings are pushed as data
+func ParseShortForm(s string) (*script.Script, error) {
+	ops := shortFormOpcodes()
+
+	var scr script.Script
+	for _, tok := range strings.Fields(s) {
+		if num, err := strconv.ParseInt(tok, 10, 64); err == nil {
+			switch {
+			case num == 0:
+				_ = scr.AppendOpcodes(script.Op0)
+			case num == -1 || (1 <= num && num <= 16):
+				_ = scr.AppendOpcodes((script.Op1 - 1) + byte(num))
+			default:
+				n := &interpreter.ScriptNumber{Val: big.NewInt(num)}
+				_ = scr.AppendPushData(n.Bytes())
+			}
+			continue
+		}
+		if strings.HasPrefix(tok, "0x") {
+			b, err := hex.DecodeString(tok[2:])
+			if err != nil {
+				return nil, fmt.Errorf("bad hex token %q: %w", tok, err)
+			}
+			// The vectors intentionally build oversized and malformed scripts, so
+			// the bytes are appended without going through the script builder.
+			scr = append(scr, b...)
+			continue
+		}
+		if len(tok) >= 2 && tok[0] == '\'' && tok[len(tok)-1] == '\'' {
+			_ = scr.AppendPushData([]byte(tok[1 : len(tok)-1]))
+			continue
+		}
+		op, ok := ops[tok]
+		if !ok {
+			return nil, fmt.Errorf("bad token %q", tok)
+		}
+		scr = append(scr, op)
+	}
+	return &scr, nil
+}
+
+// ParseFlags parses a comma separated list of reference test flags, such as
+// "P2SH,STRICTENC", into script flags.
+func ParseFlags(s string) (scriptflag.Flag, error) {
+	var flags scriptflag.Flag
+	for _, flag := range strings.Split(s, ",") {
+		switch flag {
+		case "", "NONE":
+			// Nothing.
+		case "CHECKLOCKTIMEVERIFY":
+			flags |= scriptflag.VerifyCheckLockTimeVerify
+		case "CHECKSEQUENCEVERIFY":
+			flags |= scriptflag.VerifyCheckSequenceVerify
+		case "CLEANSTACK":
+			flags |= scriptflag.VerifyCleanStack
+		case "DERSIG":
+			flags |= scriptflag.VerifyDERSignatures
+		case "DISCOURAGE_UPGRADABLE_NOPS":
+			flags |= scriptflag.DiscourageUpgradableNops
+		case "LOW_S":
+			flags |= scriptflag.VerifyLowS
+		case "MINIMALDATA":
+			flags |= scriptflag.VerifyMinimalData
+		case "NULLDUMMY":
+			flags |= scriptflag.StrictMultiSig
+		case "NULLFAIL":
+			flags |= scriptflag.VerifyNullFail
+		case "P2SH":
+			flags |= scriptflag.Bip16
+		case "SIGPUSHONLY":
+			flags |= scriptflag.VerifySigPushOnly
+		case "STRICTENC":
+			flags |= scriptflag.VerifyStrictEncoding
+		case "UTXO_AFTER_GENESIS":
+			flags |= scriptflag.UTXOAfterGenesis
+		case "MINIMALIF":
+			flags |= scriptflag.VerifyMinimalIf
+		case "SIGHASH_FORKID":
+			flags |= scriptflag.EnableSighashForkID
+		default:
+			return flags, fmt.Errorf("invalid flag: %s", flag)
+		}
+	}
+	return flags, nil
+}
+
+// ErrUnknownResult is returned by ExpectedErrors for result names it does not map.
+var ErrUnknownResult = errors.New("unrecognized expected result")
+
+// ExpectedErrors returns the interpreter error codes matching a reference test
+// result name. The interpreter's errors are finer grained than the reference
+// results, so a result may map to several codes. "OK" maps to no codes.
+func ExpectedErrors(result string) ([]errs.ErrorCode, error) {
+	switch result {
+	case "OK":
+		return nil, nil
+	case "INVALID_NUMBER_RANGE", "SPLIT_RANGE", "NUMBER_SIZE":
+		return []errs.ErrorCode{errs.ErrNumberTooBig, errs.ErrNumberTooSmall}, nil
+	case "OPERAND_SIZE":
+		return []errs.ErrorCode{errs.ErrInvalidInputLength}, nil
+	case "PUBKEYTYPE":
+		return []errs.ErrorCode{errs.ErrPubKeyType}, nil
+	case "SIG_DER":
+		return []errs.ErrorCode{errs.ErrSigTooShort, errs.ErrSigTooLong,
+			errs.ErrSigInvalidSeqID, errs.ErrSigInvalidDataLen, errs.ErrSigMissingSTypeID,
+			errs.ErrSigMissingSLen, errs.ErrSigInvalidSLen,
+			errs.ErrSigInvalidRIntID, errs.ErrSigZeroRLen, errs.ErrSigNegativeR,
+			errs.ErrSigTooMuchRPadding, errs.ErrSigInvalidSIntID,
+			errs.ErrSigZeroSLen, errs.ErrSigNegativeS, errs.ErrSigTooMuchSPadding,
+			errs.ErrInvalidSigHashType}, nil
+	case "EVAL_FALSE":
+		return []errs.ErrorCode{errs.ErrEvalFalse, errs.ErrEmptyStack}, nil
+	case "EQUALVERIFY":
+		return []errs.ErrorCode{errs.ErrEqualVerify}, nil
+	case "NULLFAIL":
+		return []errs.ErrorCode{errs.ErrNullFail}, nil
+	case "SIG_HIGH_S":
+		return []errs.ErrorCode{errs.ErrSigHighS}, nil
+	case "SIG_HASHTYPE":
+		return []errs.ErrorCode{errs.ErrInvalidSigHashType}, nil
+	case "SIG_NULLDUMMY":
+		return []errs.ErrorCode{errs.ErrSigNullDummy}, nil
+	case "SIG_PUSHONLY":
+		return []errs.ErrorCode{errs.ErrNotPushOnly}, nil
+	case "CLEANSTACK":
+		return []errs.ErrorCode{errs.ErrCleanStack}, nil
+	case "BAD_OPCODE":
+		return []errs.ErrorCode{errs.ErrReservedOpcode, errs.ErrMalformedPush}, nil
+	case "UNBALANCED_CONDITIONAL":
+		return []errs.ErrorCode{errs.ErrUnbalancedConditional, errs.ErrInvalidStackOperation}, nil
+	case "OP_RETURN":
+		return []errs.ErrorCode{errs.ErrEarlyReturn}, nil
+	case "VERIFY":
+		return []errs.ErrorCode{errs.ErrVerify}, nil
+	case "INVALID_STACK_OPERATION", "INVALID_ALTSTACK_OPERATION":
+		return []errs.ErrorCode{errs.ErrInvalidStackOperation}, nil
+	case "DISABLED_OPCODE":
+		return []errs.ErrorCode{errs.ErrDisabledOpcode}, nil
+	case "DISCOURAGE_UPGRADABLE_NOPS":
+		return []errs.ErrorCode{errs.ErrDiscourageUpgradableNOPs}, nil
+	case "SCRIPTNUM_OVERFLOW":
+		return []errs.ErrorCode{errs.ErrNumberTooBig}, nil
+	case "PUSH_SIZE", "ELEMENT_SIZE":
+		return []errs.ErrorCode{errs.ErrElementTooBig}, nil
+	case "OP_COUNT":
+		return []errs.ErrorCode{errs.ErrTooManyOperations}, nil
+	case "STACK_SIZE":
+		return []errs.ErrorCode{errs.ErrStackOverflow}, nil
+	case "SCRIPT_SIZE":
+		return []errs.ErrorCode{errs.ErrScriptTooBig}, nil
+	case "PUBKEY_COUNT":
+		return []errs.ErrorCode{errs.ErrInvalidPubKeyCount}, nil
+	case "SIG_COUNT":
+		return []errs.ErrorCode{errs.ErrInvalidSignatureCount}, nil
+	case "MINIMALDATA", "SCRIPTNUM_MINENCODE":
+		return []errs.ErrorCode{errs.ErrMinimalData}, nil
+	case "MINIMALIF":
+		return []errs.ErrorCode{errs.ErrMinimalIf}, nil
+	case "NEGATIVE_LOCKTIME":
+		return []errs.ErrorCode{errs.ErrNegativeLockTime}, nil
+	case "UNSATISFIED_LOCKTIME":
+		return []errs.ErrorCode{errs.ErrUnsatisfiedLockTime}, nil
+	case "DIV_BY_ZERO", "MOD_BY_ZERO":
+		return []errs.ErrorCode{errs.ErrDivideByZero}, nil
+	case "CHECKSIGVERIFY":
+		return []errs.ErrorCode{errs.ErrCheckSigVerify}, nil
+	case "ILLEGAL_FORKID":
+		return []errs.ErrorCode{errs.ErrIllegalForkID}, nil
+	}
+	return nil, fmt.Errorf("%w: %s", ErrUnknownResult, result)
+}
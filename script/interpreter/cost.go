@@ -0,0 +1,110 @@
+package interpreter
+
+import (
+	"github.com/bsv-blockchain/go-sdk/script"
+	"github.com/bsv-blockchain/go-sdk/script/interpreter/errs"
+)
+
+// CostWeights assigns costs to executed opcodes. Every executed opcode costs Base,
+// and the expensive classes of opcodes cost more on top of it.
+type CostWeights struct {
+	// Base is charged for every executed opcode, including data pushes.
+	Base uint64
+	// Hash is charged for OP_RIPEMD160, OP_SHA1, OP_SHA256, OP_HASH160 and OP_HASH256,
+	// plus HashByte for every byte hashed.
+	Hash     uint64
+	HashByte uint64
+	// SigCheck is charged for every signature verification OP_CHECKSIG and
+	// OP_CHECKSIGVERIFY may perform, and for every public key of OP_CHECKMULTISIG
+	// and OP_CHECKMULTISIGVERIFY.
+	SigCheck uint64
+	// Arithmetic is charged for the numeric opcodes from OP_1ADD to OP_WITHIN,
+	// plus ArithmeticByte for every byte of their operands, as post-Genesis numbers
+	// can be arbitrarily large.
+	Arithmetic     uint64
+	ArithmeticByte uint64
+}
+
+// DefaultCostWeights are rough relative costs of the opcode classes, with a
+// signature check worth about a thousand simple opcodes.
+var DefaultCostWeights = CostWeights{
+	Base:           1,
+	Hash:           50,
+	HashByte:       1,
+	SigCheck:       1000,
+	Arithmetic:     5,
+	ArithmeticByte: 1,
+}
+
+// CostMeter accumulates the cost of an execution. Attach it with WithCostMeter.
+// A meter is not safe for concurrent executions; use one per execution.
+type CostMeter struct {
+	// Budget is the maximum cost of the execution, unlimited when zero.
+	Budget uint64
+	// Weights are the opcode costs, DefaultCostWeights when nil.
+	Weights *CostWeights
+
+	cost uint64
+}
+
+// NewCostMeter creates a CostMeter with the given budget and the default weights.
+func NewCostMeter(budget uint64) *CostMeter {
+	return &CostMeter{Budget: budget}
+}
+
+// Cost returns the cost accumulated so far.
+func (m *CostMeter) Cost() uint64 {
+	return m.cost
+}
+
+// Reset clears the accumulated cost so the meter can be reused.
+func (m *CostMeter) Reset() {
+	m.cost = 0
+}
+
+func (m *CostMeter) charge(pop *ParsedOpcode, t *thread) error {
+	weights := m.Weights
+	if weights == nil {
+		weights = &DefaultCostWeights
+	}
+
+	cost := weights.Base
+	switch op := pop.op.val; {
+	case op >= script.OpRIPEMD160 && op <= script.OpHASH256:
+		cost += weights.Hash + weights.HashByte*operandBytes(t, 1)
+	case op == script.OpCHECKSIG || op == script.OpCHECKSIGVERIFY:
+		cost += weights.SigCheck
+	case op == script.OpCHECKMULTISIG || op == script.OpCHECKMULTISIGVERIFY:
+		keys := uint64(1)
+		if n, err := t.dstack.PeekInt(0); err == nil && n.Int64() > 0 {
+			keys = uint64(n.Int64())
+		}
+		cost += weights.SigCheck * keys
+	case op >= script.Op1ADD && op <= script.OpWITHIN:
+		operands := int32(2)
+		if op <= script.Op0NOTEQUAL {
+			operands = 1
+		} else if op == script.OpWITHIN {
+			operands = 3
+		}
+		cost += weights.Arithmetic + weights.ArithmeticByte*operandBytes(t, operands)
+	}
+
+	m.cost += cost
+	if m.Budget > 0 && m.cost > m.Budget {
+		return errs.NewError(errs.ErrCostBudgetExceeded,
+			"execution cost %d exceeds budget of %d at %s", m.cost, m.Budget, pop.Name())
+	}
+	return nil
+}
+
+// operandBytes returns the total size of the top n stack items, ignoring missing ones.
+func operandBytes(t *thread, n int32) uint64 {
+	var size uint64
+	for i := int32(0); i < n && i < t.dstack.Depth(); i++ {
+		if b, err := t.dstack.PeekByteArray(i); err == nil {
+			size += uint64(len(b))
+		}
+	}
+	return size
+}
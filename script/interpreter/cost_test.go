@@ -0,0 +1,56 @@
+package interpreter_test
+
+import (
+	"testing"
+
+	"github.com/bsv-blockchain/go-sdk/script"
+	"github.com/bsv-blockchain/go-sdk/script/interpreter"
+	"github.com/bsv-blockchain/go-sdk/script/interpreter/errs"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCostMeter(t *testing.T) {
+	unlocking, err := script.NewFromASM("OP_1 OP_2")
+	require.NoError(t, err)
+	// six executed opcodes at base cost, plus an addition of two 1 byte operands and a hash of 1 byte
+	locking, err := script.NewFromASM("OP_ADD OP_SHA256 OP_DROP OP_TRUE")
+	require.NoError(t, err)
+	expected := 6*interpreter.DefaultCostWeights.Base +
+		interpreter.DefaultCostWeights.Arithmetic + 2*interpreter.DefaultCostWeights.ArithmeticByte +
+		interpreter.DefaultCostWeights.Hash + interpreter.DefaultCostWeights.HashByte
+
+	t.Run("accumulates cost", func(t *testing.T) {
+		meter := interpreter.NewCostMeter(0)
+		err := interpreter.NewEngine().Execute(
+			interpreter.WithScripts(locking, unlocking),
+			interpreter.WithAfterGenesis(),
+			interpreter.WithCostMeter(meter),
+		)
+		require.NoError(t, err)
+		require.Equal(t, expected, meter.Cost())
+	})
+
+	t.Run("aborts over budget", func(t *testing.T) {
+		meter := interpreter.NewCostMeter(expected - 1)
+		err := interpreter.NewEngine().Execute(
+			interpreter.WithScripts(locking, unlocking),
+			interpreter.WithAfterGenesis(),
+			interpreter.WithCostMeter(meter),
+		)
+		require.True(t, errs.IsErrorCode(err, errs.ErrCostBudgetExceeded), "got %v", err)
+		require.Greater(t, meter.Cost(), meter.Budget)
+	})
+
+	t.Run("skips unexecuted branches", func(t *testing.T) {
+		branch, err := script.NewFromASM("OP_0 OP_IF OP_SHA256 OP_SHA256 OP_ENDIF OP_TRUE")
+		require.NoError(t, err)
+		meter := &interpreter.CostMeter{Weights: &interpreter.CostWeights{Base: 1, Hash: 100}}
+		err = interpreter.NewEngine().Execute(
+			interpreter.WithScripts(branch, &script.Script{}),
+			interpreter.WithAfterGenesis(),
+			interpreter.WithCostMeter(meter),
+		)
+		require.NoError(t, err)
+		require.Equal(t, uint64(4), meter.Cost())
+	})
+}
@@ -0,0 +1,51 @@
+package interpreter
+
+import (
+	ec "github.com/bsv-blockchain/go-sdk/primitives/ec"
+)
+
+// SignatureDivergence describes a signature check on which the verifier injected
+// with InjectExternalVerifySignatureFn and the built-in verifier disagree. The
+// external result is the one the script execution continues with.
+type SignatureDivergence struct {
+	// InputIdx is the index of the transaction input being verified.
+	InputIdx int
+	// Payload is the signature hash.
+	Payload []byte
+	// Signature is the DER encoded signature passed to the external verifier.
+	Signature []byte
+	PublicKey []byte
+	External  bool
+	BuiltIn   bool
+}
+
+// WithSignatureDivergence runs the built-in verifier alongside the external one for
+// every OP_CHECKSIG and OP_CHECKSIGVERIFY, calling report when their results
+// differ. It has no effect unless an external verifier is injected, and doubles
+// the cost of signature checks, so it is meant for testing and fuzzing alternative
+// verifiers rather than for production use.
+func WithSignatureDivergence(report func(SignatureDivergence)) ExecutionOptionFunc {
+	return func(p *execOpts) {
+		p.signatureDivergence = report
+	}
+}
+
+// verifySignature is the built-in signature verifier. The signature must be strict
+// DER when strict is set; otherwise the lax encoding rules apply. Malformed keys
+// and signatures do not verify.
+func verifySignature(hash, sigBytes, pkBytes []byte, strict bool) bool {
+	pubKey, err := ec.ParsePubKey(pkBytes)
+	if err != nil {
+		return false
+	}
+	var signature *ec.Signature
+	if strict {
+		signature, err = ec.ParseDERSignature(sigBytes)
+	} else {
+		signature, err = ec.ParseSignature(sigBytes)
+	}
+	if err != nil {
+		return false
+	}
+	return signature.Verify(hash, pubKey)
+}
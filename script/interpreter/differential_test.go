@@ -0,0 +1,59 @@
+package interpreter_test
+
+import (
+	"testing"
+
+	ec "github.com/bsv-blockchain/go-sdk/primitives/ec"
+	"github.com/bsv-blockchain/go-sdk/script/interpreter"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSignatureDivergence(t *testing.T) {
+	tx, err := transaction.NewTransactionFromHex("0200000003a9bc457fdc6a54d99300fb137b23714d860c350a9d19ff0f571e694a419ff3a0010000006b48304502210086c83beb2b2663e4709a583d261d75be538aedcafa7766bd983e5c8db2f8b2fc02201a88b178624ab0ad1748b37c875f885930166237c88f5af78ee4e61d337f935f412103e8be830d98bb3b007a0343ee5c36daa48796ae8bb57946b1e87378ad6e8a090dfeffffff0092bb9a47e27bf64fc98f557c530c04d9ac25e2f2a8b600e92a0b1ae7c89c20010000006b483045022100f06b3db1c0a11af348401f9cebe10ae2659d6e766a9dcd9e3a04690ba10a160f02203f7fbd7dfcfc70863aface1a306fcc91bbadf6bc884c21a55ef0d32bd6b088c8412103e8be830d98bb3b007a0343ee5c36daa48796ae8bb57946b1e87378ad6e8a090dfeffffff9d0d4554fa692420a0830ca614b6c60f1bf8eaaa21afca4aa8c99fb052d9f398000000006b483045022100d920f2290548e92a6235f8b2513b7f693a64a0d3fa699f81a034f4b4608ff82f0220767d7d98025aff3c7bd5f2a66aab6a824f5990392e6489aae1e1ae3472d8dffb412103e8be830d98bb3b007a0343ee5c36daa48796ae8bb57946b1e87378ad6e8a090dfeffffff02807c814a000000001976a9143a6bf34ebfcf30e8541bbb33a7882845e5a29cb488ac76b0e60e000000001976a914bd492b67f90cb85918494767ebb23102c4f06b7088ac67000000")
+	require.NoError(t, err)
+	prevTx, err := transaction.NewTransactionFromHex("0200000001424408c9d997772e56112c731b6dc6f050cb3847c5570cea12f30bfbc7df0a010000000049483045022100fe759b2cd7f25bce4fcda4c8366891b0d9289dc5bac1cf216909c89dc324437a02204aa590b6e82764971df4fe741adf41ece4cde607cb6443edceba831060213d3641feffffff02408c380c010000001976a914f761fc0927a43f4fab5740ef39f05b1fb7786f5288ac0065cd1d000000001976a914805096c5167877a5799977d46fb9dee5891dc3cb88ac66000000")
+	require.NoError(t, err)
+	prevOutput := prevTx.OutputIdx(int(tx.InputIdx(0).SourceTxOutIndex))
+
+	execute := func(verify func(payload, signature, publicKey []byte) bool) ([]interpreter.SignatureDivergence, error) {
+		interpreter.InjectExternalVerifySignatureFn(verify)
+		t.Cleanup(func() { interpreter.InjectExternalVerifySignatureFn(nil) })
+		var divergences []interpreter.SignatureDivergence
+		err := interpreter.NewEngine().Execute(
+			interpreter.WithTx(tx, 0, prevOutput),
+			interpreter.WithForkID(),
+			interpreter.WithAfterGenesis(),
+			interpreter.WithSignatureDivergence(func(d interpreter.SignatureDivergence) {
+				divergences = append(divergences, d)
+			}),
+		)
+		return divergences, err
+	}
+
+	t.Run("agreeing verifiers", func(t *testing.T) {
+		divergences, err := execute(func(payload, signature, publicKey []byte) bool {
+			sig, err := ec.ParseDERSignature(signature)
+			if err != nil {
+				return false
+			}
+			pubKey, err := ec.ParsePubKey(publicKey)
+			if err != nil {
+				return false
+			}
+			return sig.Verify(payload, pubKey)
+		})
+		require.NoError(t, err)
+		require.Empty(t, divergences)
+	})
+
+	t.Run("diverging verifier", func(t *testing.T) {
+		divergences, err := execute(func(_, _, _ []byte) bool { return false })
+		require.Error(t, err, "the external result decides the execution")
+		require.Len(t, divergences, 1)
+		require.False(t, divergences[0].External)
+		require.True(t, divergences[0].BuiltIn)
+		require.Equal(t, 0, divergences[0].InputIdx)
+		require.Len(t, divergences[0].Payload, 32)
+	})
+}
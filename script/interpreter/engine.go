@@ -5,23 +5,46 @@
 
 package interpreter
 
+import (
+	"context"
+
+	"github.com/bsv-blockchain/go-sdk/transaction"
+)
+
 // Engine is the virtual machine that executes scripts.
 type Engine interface {
 	Execute(opts ...ExecutionOptionFunc) error
+	ExecuteContext(ctx context.Context, opts ...ExecutionOptionFunc) error
 }
 
-type engine struct{}
+type engine struct {
+	// defaults are the options applied before those of each execution.
+	defaults execOpts
+}
 
-// NewEngine returns a new script engine for the provided locking script
-// (of a previous transaction out), transaction, and input index.  The
-// flags modify the behavior of the script engine according to the
-// description provided by each flag.
-func NewEngine() Engine {
-	return &engine{}
+// NewEngine returns a new script engine. The options, typically flags, are
+// applied to every execution before the execution's own options, so an engine
+// can be configured once and reused.
+//
+// An engine holds no per-execution state and is safe to use from multiple
+// goroutines. Options carrying state, such as WithCostMeter, WithDebugger and
+// WithState, belong to a single execution and should be passed to Execute
+// rather than to NewEngine. Executions given the same transaction input with
+// WithTx may run concurrently once the input has its source output set, as
+// the first execution of an input without one sets it.
+func NewEngine(defaults ...ExecutionOptionFunc) Engine {
+	e := &engine{}
+	for _, o := range defaults {
+		o(&e.defaults)
+	}
+	return e
 }
 
 // Execute will execute all scripts in the script engine and return either nil
-// for successful validation or an error if one occurred.
+// for successful validation or an error if one occurred. Failures while executing
+// the scripts are returned as an *ExecutionError locating the failing opcode,
+// which wraps the errs.Error of the failure: match it with errs.IsErrorCode or
+// errors.As rather than a type assertion.
 //
 // Execute with tx example:
 //
@@ -43,20 +66,66 @@ func NewEngine() Engine {
 //	    // handle err
 //	}
 func (e *engine) Execute(oo ...ExecutionOptionFunc) error {
-	opts := &execOpts{}
+	return e.ExecuteContext(context.Background(), oo...)
+}
+
+// ExecuteContext is like Execute, but stops between opcodes once ctx is done,
+// returning an error wrapping the cause. This lets servers validating untrusted
+// scripts bound executions by wall-clock time rather than only by op count.
+func (e *engine) ExecuteContext(ctx context.Context, oo ...ExecutionOptionFunc) error {
+	opts := e.defaults
 	for _, o := range oo {
-		o(opts)
+		o(&opts)
+	}
+
+	if opts.telemetry != nil {
+		return opts.telemetry.observe(ctx, func(ctx context.Context) (int, error) {
+			return execute(ctx, &opts)
+		})
 	}
+	_, err := execute(ctx, &opts)
+	return err
+}
+
+// ExecuteContext executes the scripts with a new engine, see Engine.ExecuteContext.
+func ExecuteContext(ctx context.Context, opts ...ExecutionOptionFunc) error {
+	return NewEngine().ExecuteContext(ctx, opts...)
+}
 
+// execute runs the execution described by opts, returning the number of opcodes
+// stepped through.
+func execute(ctx context.Context, opts *execOpts) (int, error) {
 	t, err := createThread(opts)
 	if err != nil {
-		return err
+		return 0, err
 	}
+	defer t.release()
 
-	if err := t.execute(); err != nil {
+	if err := t.execute(ctx); err != nil {
 		t.afterError(err)
-		return err
+		return t.opCount, t.executionError(err)
 	}
 
-	return nil
+	return t.opCount, nil
+}
+
+// standardEngine executes with the flags of transactions after the Genesis
+// upgrade.
+var standardEngine = NewEngine(WithForkID(), WithAfterGenesis())
+
+// VerifyInput executes the unlocking script of input inputIndex of tx against
+// the output it spends, with the flags of transactions after the Genesis
+// upgrade. The source output must be attached to the input.
+//
+// It is a transaction.InputVerifier, checking each input signed by
+// Transaction.SignAll when passed to transaction.VerifyAfterSign.
+func VerifyInput(ctx context.Context, tx *transaction.Transaction, inputIndex uint32) error {
+	if int(inputIndex) >= len(tx.Inputs) {
+		return transaction.ErrInputNoExist
+	}
+	sourceOutput := tx.Inputs[inputIndex].SourceTxOutput()
+	if sourceOutput == nil {
+		return transaction.ErrEmptyPreviousTx
+	}
+	return standardEngine.ExecuteContext(ctx, WithTx(tx, int(inputIndex), sourceOutput))
 }
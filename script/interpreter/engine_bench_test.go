@@ -0,0 +1,33 @@
+package interpreter
+
+import (
+	"testing"
+
+	"github.com/bsv-blockchain/go-sdk/script"
+	"github.com/stretchr/testify/require"
+)
+
+func BenchmarkExecuteMultisig(b *testing.B) {
+	tx, prevOutput := multisigSpend(b)
+	e := NewEngine(WithForkID(), WithAfterGenesis())
+	b.ReportAllocs()
+	for b.Loop() {
+		if err := e.Execute(WithTx(tx, 0, prevOutput)); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkExecuteArithmetic(b *testing.B) {
+	unlocking, err := script.NewFromASM("OP_1 OP_2 OP_3")
+	require.NoError(b, err)
+	locking, err := script.NewFromASM("OP_ADD OP_ADD OP_6 OP_EQUAL")
+	require.NoError(b, err)
+	e := NewEngine(WithAfterGenesis())
+	b.ReportAllocs()
+	for b.Loop() {
+		if err := e.Execute(WithScripts(locking, unlocking)); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
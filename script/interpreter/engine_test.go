@@ -5,7 +5,9 @@
 package interpreter
 
 import (
+	"context"
 	"errors"
+	"fmt"
 	"testing"
 
 	"github.com/bsv-blockchain/go-sdk/script"
@@ -14,6 +16,7 @@ import (
 	"github.com/bsv-blockchain/go-sdk/transaction"
 	sighash "github.com/bsv-blockchain/go-sdk/transaction/sighash"
 	"github.com/stretchr/testify/require"
+	"golang.org/x/sync/errgroup"
 )
 
 // TestBadPC sets the pc to a deliberately bad result then confirms that Step()
@@ -985,3 +988,100 @@ func TestExecute(t *testing.T) {
 		})
 	}
 }
+
+// stepHook calls fn after every step.
+type stepHook struct {
+	nopDebugger
+	fn func()
+}
+
+func (s *stepHook) AfterStep(*State) {
+	s.fn()
+}
+
+func TestExecuteContext(t *testing.T) {
+	lockingScript := &script.Script{script.OpNOP, script.OpNOP, script.OpNOP, script.OpNOP, script.OpNOP}
+	unlockingScript := &script.Script{script.OpTRUE}
+
+	require.NoError(t, ExecuteContext(t.Context(), WithScripts(lockingScript, unlockingScript), WithAfterGenesis()))
+
+	ctx, cancel := context.WithCancel(t.Context())
+	cancel()
+	err := NewEngine().ExecuteContext(ctx, WithScripts(lockingScript, unlockingScript), WithAfterGenesis())
+	require.ErrorIs(t, err, context.Canceled)
+	require.EqualError(t, err, "script execution stopped after 0 opcodes: context canceled")
+
+	// Cancelling mid-execution stops before the next opcode
+	cause := errors.New("validation timed out")
+	ctx, cancelCause := context.WithCancelCause(t.Context())
+	steps := 0
+	hook := &stepHook{fn: func() {
+		if steps++; steps == 3 {
+			cancelCause(cause)
+		}
+	}}
+	err = NewEngine().ExecuteContext(ctx, WithScripts(lockingScript, unlockingScript), WithAfterGenesis(), WithDebugger(hook))
+	require.ErrorIs(t, err, cause)
+	require.EqualError(t, err, "script execution stopped after 3 opcodes: validation timed out")
+	require.Equal(t, 3, steps)
+}
+
+func TestEngineReuse(t *testing.T) {
+	tx, prevOutput := multisigSpend(t)
+	e := NewEngine(WithForkID(), WithAfterGenesis())
+
+	// The defaults apply to every execution. The first execution sets the
+	// source output of the input, which the concurrent ones below only read.
+	require.NoError(t, e.Execute(WithTx(tx, 0, prevOutput)))
+	err := NewEngine(WithFlags(scriptflag.VerifyCleanStack)).Execute(WithTx(tx, 0, prevOutput))
+	require.True(t, errs.IsErrorCode(err, errs.ErrInvalidFlags), "got %v", err)
+
+	valid := &script.Script{script.OpTRUE}
+	invalid := &script.Script{script.OpFALSE}
+	lockingScript := &script.Script{script.OpDUP, script.OpDROP}
+	var g errgroup.Group
+	for i := range 64 {
+		g.Go(func() error {
+			switch i % 3 {
+			case 0:
+				return e.Execute(WithTx(tx, 0, prevOutput))
+			case 1:
+				return e.Execute(WithScripts(lockingScript, valid))
+			default:
+				if err := e.Execute(WithScripts(lockingScript, invalid)); !errs.IsErrorCode(err, errs.ErrEvalFalse) {
+					return fmt.Errorf("want ErrEvalFalse, got %v", err)
+				}
+				return nil
+			}
+		})
+	}
+	require.NoError(t, g.Wait())
+}
+
+func TestExecuteSetsSourceOutput(t *testing.T) {
+	tx, prevOutput := multisigSpend(t)
+	tx.Inputs[0].SetSourceTxOutput(nil)
+
+	require.NoError(t, NewEngine(WithForkID(), WithAfterGenesis()).Execute(WithTx(tx, 0, prevOutput)))
+	require.NotNil(t, tx.Inputs[0].SourceTxOutput())
+	require.Equal(t, prevOutput.Satoshis, tx.Inputs[0].SourceTxOutput().Satoshis)
+	require.True(t, prevOutput.LockingScript.Equals(tx.Inputs[0].SourceTxOutput().LockingScript))
+
+	// A different previous output is used without replacing that of the input.
+	other := &transaction.TransactionOutput{Satoshis: prevOutput.Satoshis + 1, LockingScript: prevOutput.LockingScript}
+	require.Error(t, NewEngine(WithForkID(), WithAfterGenesis()).Execute(WithTx(tx, 0, other)))
+	require.Equal(t, prevOutput.Satoshis, tx.Inputs[0].SourceTxOutput().Satoshis)
+
+	// Once set, the input is only read, whichever previous output is given.
+	var g errgroup.Group
+	for i := range 16 {
+		g.Go(func() error {
+			err := NewEngine(WithForkID(), WithAfterGenesis()).Execute(WithTx(tx, 0, []*transaction.TransactionOutput{prevOutput, other}[i%2]))
+			if (err == nil) != (i%2 == 0) {
+				return fmt.Errorf("execution %d: unexpected result %v", i, err)
+			}
+			return nil
+		})
+	}
+	require.NoError(t, g.Wait())
+}
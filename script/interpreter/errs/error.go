@@ -325,6 +325,10 @@ const (
 	// set, but the ScriptEnableSighashForkID flag is not set.
 	ErrIllegalForkID
 
+	// ErrCostBudgetExceeded is returned when a cost meter is attached to the
+	// execution and the accumulated cost of the executed opcodes exceeds its budget.
+	ErrCostBudgetExceeded
+
 	// numErrorCodes is the maximum error code number used in tests.  This
 	// entry MUST be the last entry in the enum.
 	numErrorCodes
@@ -394,6 +398,7 @@ var errorCodeStrings = map[ErrorCode]string{
 	ErrNegativeLockTime:         "ErrNegativeLockTime",
 	ErrUnsatisfiedLockTime:      "ErrUnsatisfiedLockTime",
 	ErrIllegalForkID:            "ErrIllegalForkID",
+	ErrCostBudgetExceeded:       "ErrCostBudgetExceeded",
 }
 
 // String returns the ErrorCode as a human-readable name.
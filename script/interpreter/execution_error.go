@@ -0,0 +1,100 @@
+package interpreter
+
+import (
+	"fmt"
+)
+
+// Script indexes of an ExecutionError.
+const (
+	ScriptUnlocking = 0
+	ScriptLocking   = 1
+	// ScriptRedeem is the redeem script of a pay-to-script-hash spend.
+	ScriptRedeem = 2
+)
+
+// ExecutionError is returned by Execute when a script fails while executing,
+// locating the failure so it can be shown to users. It wraps the underlying error,
+// typically an errs.Error, so errs.IsErrorCode and errors.As still apply, and its
+// message is that of the underlying error. Get it with errors.As:
+//
+//	var execErr *interpreter.ExecutionError
+//	if errors.As(err, &execErr) {
+//		log.Print(execErr.Detail())
+//	}
+//
+// As the errors of Execute are no longer errs.Error values themselves, type
+// assertions such as err.(errs.Error) fail on them. Use errors.As instead:
+//
+//	var scriptErr errs.Error
+//	if errors.As(err, &scriptErr) {
+//		log.Print(scriptErr.ErrorCode)
+//	}
+type ExecutionError struct {
+	Err error
+	// ScriptIdx is the script the failure occurred in: ScriptUnlocking,
+	// ScriptLocking or ScriptRedeem.
+	ScriptIdx int
+	// OpcodeIdx is the index of the failing opcode within its script, or -1 if
+	// the execution stopped before executing any opcode. Failures detected at the
+	// end of a script, such as a false result, point at its last opcode.
+	OpcodeIdx int
+	// Opcode is the name of the failing opcode.
+	Opcode string
+	// StackDepth and AltStackDepth are the depths of the stacks at the failure.
+	StackDepth    int
+	AltStackDepth int
+	// Remaining is the disassembly of the script from the failing opcode on.
+	Remaining string
+}
+
+// Error implements error.
+func (e *ExecutionError) Error() string {
+	return e.Err.Error()
+}
+
+// Unwrap returns the underlying error, the errs.Error of script failures.
+func (e *ExecutionError) Unwrap() error {
+	return e.Err
+}
+
+// Detail describes the error along with where it occurred.
+func (e *ExecutionError) Detail() string {
+	if e.OpcodeIdx < 0 {
+		return e.Err.Error()
+	}
+	return fmt.Sprintf("%v: at %s script opcode %d (%s), stack depth %d, alt stack depth %d, remaining script: %s",
+		e.Err, scriptName(e.ScriptIdx), e.OpcodeIdx, e.Opcode, e.StackDepth, e.AltStackDepth, e.Remaining)
+}
+
+func scriptName(idx int) string {
+	switch idx {
+	case ScriptUnlocking:
+		return "unlocking"
+	case ScriptLocking:
+		return "locking"
+	case ScriptRedeem:
+		return "redeem"
+	}
+	return fmt.Sprintf("#%d", idx)
+}
+
+// executionError wraps err with the position of the last opcode stepped through.
+func (t *thread) executionError(err error) *ExecutionError {
+	e := &ExecutionError{
+		Err:           err,
+		ScriptIdx:     t.opScriptIdx,
+		OpcodeIdx:     -1,
+		StackDepth:    int(t.dstack.Depth()),
+		AltStackDepth: int(t.astack.Depth()),
+	}
+	if t.opCount == 0 || t.opScriptIdx >= len(t.scripts) || t.opScriptOff >= len(t.scripts[t.opScriptIdx]) {
+		return e
+	}
+	e.OpcodeIdx = t.opScriptOff
+	remaining := t.scripts[t.opScriptIdx][t.opScriptOff:]
+	e.Opcode = remaining[0].Name()
+	if s, err := t.scriptParser.Unparse(remaining); err == nil {
+		e.Remaining = s.ToASM()
+	}
+	return e
+}
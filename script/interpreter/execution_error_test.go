@@ -0,0 +1,66 @@
+package interpreter_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/bsv-blockchain/go-sdk/script"
+	"github.com/bsv-blockchain/go-sdk/script/interpreter"
+	"github.com/bsv-blockchain/go-sdk/script/interpreter/errs"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExecutionError(t *testing.T) {
+	execute := func(t *testing.T, ctx context.Context, locking, unlocking string) *interpreter.ExecutionError {
+		t.Helper()
+		l, err := script.NewFromASM(locking)
+		require.NoError(t, err)
+		u, err := script.NewFromASM(unlocking)
+		require.NoError(t, err)
+		err = interpreter.NewEngine().ExecuteContext(ctx, interpreter.WithScripts(l, u), interpreter.WithAfterGenesis())
+		var execErr *interpreter.ExecutionError
+		require.ErrorAs(t, err, &execErr)
+		return execErr
+	}
+
+	t.Run("failing opcode", func(t *testing.T) {
+		err := execute(t, t.Context(), "OP_2 OP_EQUALVERIFY OP_1 OP_DROP", "OP_1 OP_3")
+		require.True(t, errs.IsErrorCode(err, errs.ErrEqualVerify))
+		var scriptErr errs.Error
+		require.ErrorAs(t, err, &scriptErr)
+		require.Equal(t, errs.ErrEqualVerify, scriptErr.ErrorCode)
+		require.Equal(t, scriptErr, errors.Unwrap(err))
+		require.Equal(t, interpreter.ScriptLocking, err.ScriptIdx)
+		require.Equal(t, 1, err.OpcodeIdx)
+		require.Equal(t, "OP_EQUALVERIFY", err.Opcode)
+		require.Equal(t, 1, err.StackDepth)
+		require.Equal(t, "OP_EQUALVERIFY OP_TRUE OP_DROP", err.Remaining)
+		require.Equal(t, "OP_EQUALVERIFY failed: at locking script opcode 1 (OP_EQUALVERIFY), "+
+			"stack depth 1, alt stack depth 0, remaining script: OP_EQUALVERIFY OP_TRUE OP_DROP", err.Detail())
+	})
+
+	t.Run("failure in the unlocking script", func(t *testing.T) {
+		err := execute(t, t.Context(), "OP_1", "OP_1 OP_TOALTSTACK OP_VERIFY")
+		require.True(t, errs.IsErrorCode(err, errs.ErrInvalidStackOperation), "got %v", err.Err)
+		require.Equal(t, interpreter.ScriptUnlocking, err.ScriptIdx)
+		require.Equal(t, "OP_VERIFY", err.Opcode)
+		require.Equal(t, 1, err.AltStackDepth)
+	})
+
+	t.Run("false result", func(t *testing.T) {
+		err := execute(t, t.Context(), "OP_DROP OP_0", "OP_1")
+		require.True(t, errs.IsErrorCode(err, errs.ErrEvalFalse), "got %v", err.Err)
+		require.Equal(t, "OP_0", err.Opcode)
+		require.Equal(t, 1, err.OpcodeIdx)
+	})
+
+	t.Run("stopped before any opcode", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(t.Context())
+		cancel()
+		err := execute(t, ctx, "OP_1", "OP_1")
+		require.True(t, errors.Is(err, context.Canceled))
+		require.Equal(t, -1, err.OpcodeIdx)
+		require.Equal(t, err.Error(), err.Detail())
+	})
+}
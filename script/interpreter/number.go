@@ -3,6 +3,7 @@ package interpreter
 import (
 	"math"
 	"math/big"
+	"sync"
 
 	"github.com/bsv-blockchain/go-sdk/script/interpreter/errs"
 )
@@ -41,6 +42,20 @@ type ScriptNumber struct {
 var Zero = big.NewInt(0)
 var One = big.NewInt(1)
 
+// scratchPool holds big.Int scratch values for comparisons and encoding, so that
+// arithmetic heavy scripts do not allocate a new big.Int per opcode.
+var scratchPool = sync.Pool{
+	New: func() any { return new(big.Int) },
+}
+
+func getScratch() *big.Int {
+	return scratchPool.Get().(*big.Int)
+}
+
+func putScratch(i *big.Int) {
+	scratchPool.Put(i)
+}
+
 // MakeScriptNumber interprets the passed serialized bytes as an encoded integer
 // and returns the result as a Number.
 //
@@ -95,34 +110,25 @@ func MakeScriptNumber(bb []byte, scriptNumLen int, requireMinimal, afterGenesis
 		}, nil
 	}
 
-	// Decode from little endian.
-	//
-	// The following is the equivalent of:
-	//    var v int64
-	//    for i, b := range bb {
-	//        v |= int64(b) << uint8(8*i)
-	//    }
-	v := new(big.Int)
-	for i, b := range bb {
-		v.Or(v, new(big.Int).Lsh(new(big.Int).SetBytes([]byte{b}), uint(8*i)))
+	// Decode from little endian by reversing into a big-endian buffer, which
+	// avoids a big.Int per byte. Numbers up to 32 bytes reverse on the stack.
+	var buf [32]byte
+	be := buf[:0]
+	if len(bb) > len(buf) {
+		be = make([]byte, 0, len(bb))
+	}
+	for i := len(bb) - 1; i >= 0; i-- {
+		be = append(be, bb[i])
 	}
 
 	// When the most significant byte of the input bytes has the sign bit
 	// set, the result is negative.  So, remove the sign bit from the result
 	// and make it negative.
-	//
-	// The following is the equivalent of:
-	//    if bb[len(bb)-1]&0x80 != 0 {
-	//        v &= ^(int64(0x80) << uint8(8*(len(bb)-1)))
-	//        return -v, nil
-	//    }
-	if bb[len(bb)-1]&0x80 != 0 {
-		// The maximum length of bb has already been determined to be 4
-		// above, so uint8 is enough to cover the max possible shift
-		// value of 24.
-		shift := big.NewInt(int64(0x80))
-		shift.Not(shift.Lsh(shift, uint(8*(len(bb)-1))))
-		v.And(v, shift).Neg(v)
+	negative := be[0]&0x80 != 0
+	be[0] &^= 0x80
+	v := new(big.Int).SetBytes(be)
+	if negative {
+		v.Neg(v)
 	}
 	return &ScriptNumber{
 		Val:          v,
@@ -132,37 +138,37 @@ func MakeScriptNumber(bb []byte, scriptNumLen int, requireMinimal, afterGenesis
 
 // Add adds the receiver and the number, sets the result over the receiver and returns.
 func (n *ScriptNumber) Add(o *ScriptNumber) *ScriptNumber {
-	*n.Val = *new(big.Int).Add(n.Val, o.Val)
+	n.Val.Add(n.Val, o.Val)
 	return n
 }
 
 // Sub subtracts the number from the receiver, sets the result over the receiver and returns.
 func (n *ScriptNumber) Sub(o *ScriptNumber) *ScriptNumber {
-	*n.Val = *new(big.Int).Sub(n.Val, o.Val)
+	n.Val.Sub(n.Val, o.Val)
 	return n
 }
 
 // Mul multiplies the receiver by the number, sets the result over the receiver and returns.
 func (n *ScriptNumber) Mul(o *ScriptNumber) *ScriptNumber {
-	*n.Val = *new(big.Int).Mul(n.Val, o.Val)
+	n.Val.Mul(n.Val, o.Val)
 	return n
 }
 
 // Div divides the receiver by the number, sets the result over the receiver and returns.
 func (n *ScriptNumber) Div(o *ScriptNumber) *ScriptNumber {
-	*n.Val = *new(big.Int).Quo(n.Val, o.Val)
+	n.Val.Quo(n.Val, o.Val)
 	return n
 }
 
 // Mod divides the receiver by the number, sets the remainder over the receiver and returns.
 func (n *ScriptNumber) Mod(o *ScriptNumber) *ScriptNumber {
-	*n.Val = *new(big.Int).Rem(n.Val, o.Val)
+	n.Val.Rem(n.Val, o.Val)
 	return n
 }
 
 // LessThanInt returns true if the receiver is smaller than the integer passed.
 func (n *ScriptNumber) LessThanInt(i int64) bool {
-	return n.LessThan(&ScriptNumber{Val: big.NewInt(i)})
+	return n.cmpInt(i) < 0
 }
 
 // LessThan returns true if the receiver is smaller than the number passed.
@@ -177,7 +183,7 @@ func (n *ScriptNumber) LessThanOrEqual(o *ScriptNumber) bool {
 
 // GreaterThanInt returns true if the receiver is larger than the integer passed.
 func (n *ScriptNumber) GreaterThanInt(i int64) bool {
-	return n.GreaterThan(&ScriptNumber{Val: big.NewInt(i)})
+	return n.cmpInt(i) > 0
 }
 
 // GreaterThan returns true if the receiver is larger than the number passed.
@@ -192,7 +198,14 @@ func (n *ScriptNumber) GreaterThanOrEqual(o *ScriptNumber) bool {
 
 // EqualInt returns true if the receiver is equal to the integer passed.
 func (n *ScriptNumber) EqualInt(i int64) bool {
-	return n.Equal(&ScriptNumber{Val: big.NewInt(i)})
+	return n.cmpInt(i) == 0
+}
+
+// cmpInt compares the receiver with the integer passed using a pooled scratch value.
+func (n *ScriptNumber) cmpInt(i int64) int {
+	scratch := getScratch()
+	defer putScratch(scratch)
+	return n.Val.Cmp(scratch.SetInt64(i))
 }
 
 // Equal returns true if the receiver is equal to the number passed.
@@ -202,30 +215,30 @@ func (n *ScriptNumber) Equal(o *ScriptNumber) bool {
 
 // IsZero return strue if hte receiver equals zero.
 func (n *ScriptNumber) IsZero() bool {
-	return n.Val.Cmp(Zero) == 0
+	return n.Val.Sign() == 0
 }
 
 // Incr increment the receiver by one.
 func (n *ScriptNumber) Incr() *ScriptNumber {
-	*n.Val = *new(big.Int).Add(n.Val, One)
+	n.Val.Add(n.Val, One)
 	return n
 }
 
 // Decr decrement the receiver by one.
 func (n *ScriptNumber) Decr() *ScriptNumber {
-	*n.Val = *new(big.Int).Sub(n.Val, One)
+	n.Val.Sub(n.Val, One)
 	return n
 }
 
 // Neg sets the receiver to the negative of the receiver.
 func (n *ScriptNumber) Neg() *ScriptNumber {
-	*n.Val = *new(big.Int).Neg(n.Val)
+	n.Val.Neg(n.Val)
 	return n
 }
 
 // Abs sets the receiver to the absolute value of hte receiver.
 func (n *ScriptNumber) Abs() *ScriptNumber {
-	*n.Val = *new(big.Int).Abs(n.Val)
+	n.Val.Abs(n.Val)
 	return n
 }
 
@@ -283,7 +296,7 @@ func (n *ScriptNumber) Int64() int64 {
 
 // Set the value of the receiver.
 func (n *ScriptNumber) Set(i int64) *ScriptNumber {
-	*n.Val = *new(big.Int).SetInt64(i)
+	n.Val.SetInt64(i)
 	return n
 }
 
@@ -304,45 +317,38 @@ func (n *ScriptNumber) Set(i int64) *ScriptNumber {
 //	 32768 -> [0x00 0x80 0x00]
 //	-32768 -> [0x00 0x80 0x80]
 func (n *ScriptNumber) Bytes() []byte {
+	b := make([]byte, n.encodedLen())
+	n.encode(b)
+	return b
+}
+
+// encodedLen returns the length of the serialized number. The magnitude takes
+// BitLen/8 bytes rounded up, plus a byte for the sign bit when the most
+// significant byte already has its high bit set, which is BitLen/8+1 either way.
+func (n *ScriptNumber) encodedLen() int {
 	// Zero encodes as an empty byte slice.
-	if n.IsZero() {
-		return []byte{}
+	if n.Val.Sign() == 0 {
+		return 0
 	}
+	return n.Val.BitLen()/8 + 1
+}
 
-	// Take the absolute value and keep track of whether it was originally
-	// negative.
-	isNegative := n.Val.Cmp(Zero) == -1
-	if isNegative {
-		n.Neg()
+// encode serializes the number into b, which must be encodedLen bytes long.
+func (n *ScriptNumber) encode(b []byte) {
+	if len(b) == 0 {
+		return
 	}
 
-	var bb []byte
-	if !n.AfterGenesis {
-		v := n.Val.Int64()
-		if v > math.MaxInt32 {
-			bb = big.NewInt(int64(math.MaxInt32)).Bytes()
-		} else if v < math.MinInt32 {
-			bb = big.NewInt(int64(math.MinInt32)).Bytes()
-		}
-	}
-	if bb == nil {
-		bb = n.Val.Bytes()
-	}
+	// Take the absolute value in a scratch value, leaving the receiver untouched.
+	abs := getScratch()
+	defer putScratch(abs)
+	abs.Abs(n.Val)
 
-	// Encode to little endian.  The maximum number of encoded bytes is len(bb)+1
-	// (8 bytes for max int64 plus a potential byte for sign extension).
-	//
-	// The following is the equivalent of:
-	//    result := make([]byte, 0, len(bb)+1)
-	//    for n > 0 {
-	//        result = append(result, byte(n&0xff))
-	//        n >>= 8
-	//    }
-	result := make([]byte, 0, len(bb)+1)
-	cpy := new(big.Int).SetBytes(n.Val.Bytes())
-	for cpy.Cmp(Zero) == 1 {
-		result = append(result, byte(cpy.Int64()&0xff))
-		cpy.Rsh(cpy, 8)
+	// Encode the magnitude to little endian.
+	size := (abs.BitLen() + 7) / 8
+	abs.FillBytes(b[:size])
+	for i, j := 0, size-1; i < j; i, j = i+1, j-1 {
+		b[i], b[j] = b[j], b[i]
 	}
 
 	// When the most significant byte already has the high bit set, an
@@ -352,17 +358,12 @@ func (n *ScriptNumber) Bytes() []byte {
 	//
 	// Otherwise, when the most significant byte does not already have the
 	// high bit set, use it to indicate the value is negative, if needed.
-	if result[len(result)-1]&0x80 != 0 {
-		extraByte := byte(0x00)
-		if isNegative {
-			extraByte = 0x80
-		}
-		result = append(result, extraByte)
-	} else if isNegative {
-		result[len(result)-1] |= 0x80
+	if len(b) > size {
+		b[size] = 0x00
+	}
+	if n.Val.Sign() < 0 {
+		b[len(b)-1] |= 0x80
 	}
-
-	return result
 }
 
 func MinimallyEncode(data []byte) []byte {
@@ -0,0 +1,102 @@
+package interpreter_test
+
+import (
+	"bytes"
+	"math/big"
+	"testing"
+
+	"github.com/bsv-blockchain/go-sdk/script/interpreter"
+)
+
+// referenceDecode decodes a script number byte by byte: little endian magnitude
+// with the sign in the high bit of the last byte.
+func referenceDecode(b []byte) *big.Int {
+	v := new(big.Int)
+	for i := len(b) - 1; i >= 0; i-- {
+		c := b[i]
+		if i == len(b)-1 {
+			c &= 0x7f
+		}
+		v.Lsh(v, 8)
+		v.Or(v, big.NewInt(int64(c)))
+	}
+	if len(b) > 0 && b[len(b)-1]&0x80 != 0 {
+		v.Neg(v)
+	}
+	return v
+}
+
+// referenceEncode encodes v as a minimal script number.
+func referenceEncode(v *big.Int) []byte {
+	if v.Sign() == 0 {
+		return []byte{}
+	}
+	be := new(big.Int).Abs(v).Bytes()
+	le := make([]byte, len(be))
+	for i, c := range be {
+		le[len(be)-1-i] = c
+	}
+	if le[len(le)-1]&0x80 != 0 {
+		le = append(le, 0x00)
+	}
+	if v.Sign() < 0 {
+		le[len(le)-1] |= 0x80
+	}
+	return le
+}
+
+func FuzzMinimallyEncode(f *testing.F) {
+	for _, seed := range [][]byte{
+		{}, {0x00}, {0x80}, {0x01}, {0x7f, 0x00}, {0xff, 0x00}, {0xff, 0x80},
+		{0x00, 0x00, 0x80}, {0x01, 0x00, 0x00}, {0x80, 0x00, 0x00, 0x80},
+	} {
+		f.Add(seed)
+	}
+	f.Fuzz(func(t *testing.T, data []byte) {
+		want := referenceEncode(referenceDecode(data))
+		got := interpreter.MinimallyEncode(bytes.Clone(data))
+		if !bytes.Equal(got, want) {
+			t.Fatalf("MinimallyEncode(%x) = %x, want %x", data, got, want)
+		}
+		if err := interpreter.CheckMinimalDataEncoding(got); err != nil {
+			t.Fatalf("MinimallyEncode(%x) = %x is not minimal: %v", data, got, err)
+		}
+	})
+}
+
+func FuzzMakeScriptNumber(f *testing.F) {
+	for _, seed := range [][]byte{
+		{}, {0x80}, {0x01}, {0x81}, {0x7f, 0x00}, {0xff, 0x00}, {0xff, 0xff, 0xff, 0x7f},
+		{0xff, 0xff, 0xff, 0xff, 0x00}, {0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08, 0x09},
+	} {
+		f.Add(seed, false)
+		f.Add(seed, true)
+	}
+	f.Fuzz(func(t *testing.T, data []byte, requireMinimal bool) {
+		want := referenceDecode(data)
+		minimal := bytes.Equal(data, referenceEncode(want))
+
+		n, err := interpreter.MakeScriptNumber(data, len(data), requireMinimal, true)
+		switch {
+		case requireMinimal && !minimal:
+			if err == nil {
+				t.Fatalf("MakeScriptNumber(%x) accepted a non-minimal encoding", data)
+			}
+			return
+		case err != nil:
+			t.Fatalf("MakeScriptNumber(%x): %v", data, err)
+		}
+		if n.Val.Cmp(want) != 0 {
+			t.Fatalf("MakeScriptNumber(%x) = %s, want %s", data, n.Val, want)
+		}
+		if got := n.Bytes(); !bytes.Equal(got, referenceEncode(want)) {
+			t.Fatalf("MakeScriptNumber(%x).Bytes() = %x, want %x", data, got, referenceEncode(want))
+		}
+
+		if len(data) > 0 {
+			if _, err := interpreter.MakeScriptNumber(data, len(data)-1, requireMinimal, true); err == nil {
+				t.Fatalf("MakeScriptNumber(%x) accepted more than %d bytes", data, len(data)-1)
+			}
+		}
+	})
+}
@@ -88,6 +88,30 @@ func TestScriptNumBytes(t *testing.T) {
 			t.Errorf("Bytes: did not get expected bytes for %d - got %x, want %x", test.num, n.Bytes(), test.serialized)
 			continue
 		}
+		if n.Val.Int64() != test.num {
+			t.Errorf("Bytes: modified the receiver for %d - got %d", test.num, n.Val.Int64())
+		}
+	}
+}
+
+// TestScriptNumRoundTrip ensures that big numbers, including ones larger than
+// the decoding stack buffer, survive encoding and decoding.
+func TestScriptNumRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	for _, bits := range []uint{63, 64, 255, 256, 257, 1024} {
+		for _, sign := range []int64{1, -1} {
+			want := new(big.Int).Lsh(big.NewInt(sign), bits)
+			want.Sub(want, big.NewInt(sign))
+			n, err := MakeScriptNumber((&ScriptNumber{Val: want, AfterGenesis: true}).Bytes(), 1024, true, true)
+			if err != nil {
+				t.Errorf("MakeScriptNumber: unexpected error for %d bits: %v", bits, err)
+				continue
+			}
+			if n.Val.Cmp(want) != 0 {
+				t.Errorf("MakeScriptNumber: did not round trip %d bits - got %s, want %s", bits, n.Val, want)
+			}
+		}
 	}
 }
 
@@ -349,3 +373,17 @@ func TestDisasmString(t *testing.T) {
 		t.Error(err)
 	}
 }
+
+func BenchmarkScriptNumArithmetic(b *testing.B) {
+	encoded := (&ScriptNumber{Val: new(big.Int).Lsh(One, 200), AfterGenesis: true}).Bytes()
+	b.ReportAllocs()
+	for b.Loop() {
+		n, _ := MakeScriptNumber(encoded, 750000, true, true)
+		o, _ := MakeScriptNumber(encoded, 750000, true, true)
+		n.Mul(o).Add(o).Sub(o).Mod(o)
+		if n.LessThanInt(0) || n.EqualInt(1) {
+			b.Fatal("unexpected result")
+		}
+		_ = n.Bytes()
+	}
+}
@@ -3,6 +3,7 @@ package interpreter
 import (
 	"bytes"
 	"encoding/binary"
+	"iter"
 
 	"github.com/bsv-blockchain/go-sdk/script"
 	"github.com/bsv-blockchain/go-sdk/script/interpreter/errs"
@@ -22,7 +23,8 @@ type DefaultOpcodeParser struct {
 	ErrorOnCheckSig bool
 }
 
-// ParsedOpcode is a parsed opcode.
+// ParsedOpcode is a parsed opcode. Its Data references the parsed script
+// rather than a copy of it, and must not be modified in place.
 type ParsedOpcode struct {
 	op   opcode
 	Data []byte
@@ -240,65 +242,100 @@ func (p *DefaultOpcodeParser) Parse(s *script.Script) (ParsedScript, error) {
 	conditionalBlock := 0
 
 	for i := 0; i < len(scr); {
-		instruction := scr[i]
-
-		parsedOp := ParsedOpcode{op: opcodeArray[instruction]}
-		if p.ErrorOnCheckSig && parsedOp.RequiresTx() {
-			return nil, errs.NewError(errs.ErrInvalidParams, "tx and previous output must be supplied for checksig")
+		parsedOp, newPos, err := p.parseOpcode(scr, i, &conditionalBlock)
+		if err != nil {
+			return nil, err
 		}
+		i = newPos
 
-		// Track conditionals and check for OP_RETURN
-		if isOpReturnOutsideConditional := updateConditionalDepth(parsedOp.op.val, &conditionalBlock); isOpReturnOutsideConditional {
-			// OP_RETURN outside conditionals - extract remaining data and return
-			if i+1 < len(scr) {
-				parsedOp.Data = scr[i+1:]
-				parsedOp.op.length = 1 + len(parsedOp.Data)
+		parsedOps = append(parsedOps, parsedOp)
+	}
+	return parsedOps, nil
+}
+
+// Opcodes parses the script lazily, yielding its opcodes one at a time without
+// building a ParsedScript, which suits workloads that only classify or inspect
+// scripts. Like Parse, the data of the yielded opcodes are views of the script
+// buffer rather than copies. Their capacity is limited to their length, so
+// appending to them copies instead of overwriting the script, but they must
+// not be modified in place.
+//
+// Iteration stops after yielding the first error.
+func (p *DefaultOpcodeParser) Opcodes(s *script.Script) iter.Seq2[ParsedOpcode, error] {
+	return func(yield func(ParsedOpcode, error) bool) {
+		scr := *s
+		conditionalBlock := 0
+		for i := 0; i < len(scr); {
+			parsedOp, newPos, err := p.parseOpcode(scr, i, &conditionalBlock)
+			if err != nil {
+				yield(ParsedOpcode{}, err)
+				return
 			}
-			parsedOps = append(parsedOps, parsedOp)
-			return parsedOps, nil
+			if !yield(parsedOp, nil) {
+				return
+			}
+			i = newPos
 		}
+	}
+}
 
-		// Extract data for this opcode
-		switch parsedOp.op.val {
-		case script.OpPUSHDATA1:
-			if len(scr) >= i+2 {
-				dataLen := int(scr[i+1])
-				if len(scr) >= i+2+dataLen {
-					parsedOp.Data = scr[i+2 : i+2+dataLen]
-				}
-			}
-		case script.OpPUSHDATA2:
-			if len(scr) >= i+3 {
-				dataLen := int(binary.LittleEndian.Uint16(scr[i+1:]))
-				if len(scr) >= i+3+dataLen {
-					parsedOp.Data = scr[i+3 : i+3+dataLen]
-				}
+// parseOpcode parses the opcode at position i of the script and returns it
+// along with the position of the next opcode.
+func (p *DefaultOpcodeParser) parseOpcode(scr []byte, i int, conditionalBlock *int) (ParsedOpcode, int, error) {
+	instruction := scr[i]
+
+	parsedOp := ParsedOpcode{op: opcodeArray[instruction]}
+	if p.ErrorOnCheckSig && parsedOp.RequiresTx() {
+		return ParsedOpcode{}, 0, errs.NewError(errs.ErrInvalidParams, "tx and previous output must be supplied for checksig")
+	}
+
+	// Track conditionals and check for OP_RETURN
+	if isOpReturnOutsideConditional := updateConditionalDepth(parsedOp.op.val, conditionalBlock); isOpReturnOutsideConditional {
+		// OP_RETURN outside conditionals - extract remaining data and stop
+		if i+1 < len(scr) {
+			parsedOp.Data = scr[i+1:]
+			parsedOp.op.length = 1 + len(parsedOp.Data)
+		}
+		return parsedOp, len(scr), nil
+	}
+
+	// Extract data for this opcode, as a view of the script whose capacity
+	// ends with the data so that appends never write into the script.
+	switch parsedOp.op.val {
+	case script.OpPUSHDATA1:
+		if len(scr) >= i+2 {
+			dataLen := int(scr[i+1])
+			if len(scr) >= i+2+dataLen {
+				parsedOp.Data = scr[i+2 : i+2+dataLen : i+2+dataLen]
 			}
-		case script.OpPUSHDATA4:
-			if len(scr) >= i+5 {
-				dataLen := int(binary.LittleEndian.Uint32(scr[i+1:]))
-				if len(scr) >= i+5+dataLen {
-					parsedOp.Data = scr[i+5 : i+5+dataLen]
-				}
+		}
+	case script.OpPUSHDATA2:
+		if len(scr) >= i+3 {
+			dataLen := int(binary.LittleEndian.Uint16(scr[i+1:]))
+			if len(scr) >= i+3+dataLen {
+				parsedOp.Data = scr[i+3 : i+3+dataLen : i+3+dataLen]
 			}
-		default:
-			// Fixed length opcodes
-			if parsedOp.op.length > 1 && len(scr[i:]) >= parsedOp.op.length {
-				parsedOp.Data = scr[i+1 : i+parsedOp.op.length]
+		}
+	case script.OpPUSHDATA4:
+		if len(scr) >= i+5 {
+			dataLen := int(binary.LittleEndian.Uint32(scr[i+1:]))
+			if len(scr) >= i+5+dataLen {
+				parsedOp.Data = scr[i+5 : i+5+dataLen : i+5+dataLen]
 			}
 		}
-
-		// Advance position using the same logic as first pass
-		newPos, err := advancePosition(scr, i, instruction)
-		if err != nil {
-			// This shouldn't happen since first pass validated
-			return nil, err
+	default:
+		// Fixed length opcodes
+		if parsedOp.op.length > 1 && len(scr[i:]) >= parsedOp.op.length {
+			parsedOp.Data = scr[i+1 : i+parsedOp.op.length : i+parsedOp.op.length]
 		}
-		i = newPos
+	}
 
-		parsedOps = append(parsedOps, parsedOp)
+	// Advance position using the same logic as the counting pass
+	newPos, err := advancePosition(scr, i, instruction)
+	if err != nil {
+		return ParsedOpcode{}, 0, err
 	}
-	return parsedOps, nil
+	return parsedOp, newPos, nil
 }
 
 // Unparse reverses the action of Parse and returns the
@@ -327,27 +364,52 @@ func (p ParsedScript) IsPushOnly() bool {
 }
 
 // removeOpcodeByData will return the script minus any opcodes that would push
-// the passed data to the stack.
+// the passed data to the stack. The script itself is returned when there is
+// nothing to remove.
 func (p ParsedScript) removeOpcodeByData(data []byte) ParsedScript {
-	retScript := make(ParsedScript, 0, len(p))
-	for _, pop := range p {
-		if !pop.canonicalPush() || !bytes.Contains(pop.Data, data) {
-			retScript = append(retScript, pop)
+	return p.removeFunc(func(pop *ParsedOpcode) bool {
+		return pop.canonicalPush() && bytes.Contains(pop.Data, data)
+	})
+}
+
+// removeOpcode will return the script minus any occurrence of the opcode. The
+// script itself is returned when there is nothing to remove.
+func (p ParsedScript) removeOpcode(opcode byte) ParsedScript {
+	return p.removeFunc(func(pop *ParsedOpcode) bool {
+		return pop.op.val == opcode
+	})
+}
+
+// removeFunc returns the script minus the opcodes for which remove returns
+// true, only copying the script when there is something to remove.
+func (p ParsedScript) removeFunc(remove func(*ParsedOpcode) bool) ParsedScript {
+	for i := range p {
+		if !remove(&p[i]) {
+			continue
 		}
+		retScript := make(ParsedScript, i, len(p))
+		copy(retScript, p[:i])
+		for j := i + 1; j < len(p); j++ {
+			if !remove(&p[j]) {
+				retScript = append(retScript, p[j])
+			}
+		}
+		return retScript
 	}
 
-	return retScript
+	return p
 }
 
-func (p ParsedScript) removeOpcode(opcode byte) ParsedScript {
-	retScript := make(ParsedScript, 0, len(p))
-	for _, pop := range p {
-		if pop.op.val != opcode {
-			retScript = append(retScript, pop)
+// codeSeparators returns the indexes of the OP_CODESEPARATOR opcodes in the script.
+func (p ParsedScript) codeSeparators() []int {
+	var seps []int
+	for i := range p {
+		if p[i].op.val == script.OpCODESEPARATOR {
+			seps = append(seps, i)
 		}
 	}
 
-	return retScript
+	return seps
 }
 
 // canonicalPush returns true if the object is either not a push instruction
@@ -257,3 +257,66 @@ func TestOpShift(t *testing.T) {
 		})
 	}
 }
+
+func TestRemoveOpcode(t *testing.T) {
+	s, err := script.NewFromASM("OP_1 OP_CODESEPARATOR 0102 OP_DROP OP_CODESEPARATOR OP_TRUE")
+	require.NoError(t, err)
+	p, err := (&DefaultOpcodeParser{}).Parse(s)
+	require.NoError(t, err)
+	require.Equal(t, []int{1, 4}, p.codeSeparators())
+
+	removed := p.removeOpcode(script.OpCODESEPARATOR)
+	require.Len(t, removed, 4)
+	require.Empty(t, removed.codeSeparators())
+	require.Len(t, p, 6, "the original script is left untouched")
+
+	removed = removed.removeOpcodeByData([]byte{0x01, 0x02})
+	require.Len(t, removed, 3)
+
+	unchanged := removed.removeOpcode(script.OpCODESEPARATOR)
+	require.Same(t, &removed[0], &unchanged[0], "nothing to remove does not copy the script")
+}
+
+func TestOpcodes(t *testing.T) {
+	s, err := script.NewFromASM("OP_DUP OP_HASH160 000102030405060708090a0b0c0d0e0f10111213 OP_EQUALVERIFY OP_CHECKSIG")
+	require.NoError(t, err)
+	parser := &DefaultOpcodeParser{}
+	parsed, err := parser.Parse(s)
+	require.NoError(t, err)
+
+	var ops []ParsedOpcode
+	for op, err := range parser.Opcodes(s) {
+		require.NoError(t, err)
+		ops = append(ops, op)
+	}
+	require.Len(t, ops, len(parsed))
+	for i := range ops {
+		require.Equal(t, parsed[i].Value(), ops[i].Value())
+		require.Equal(t, parsed[i].Data, ops[i].Data)
+	}
+
+	data := ops[2].Data
+	require.Same(t, &(*s)[3], &data[0], "push data is a view of the script")
+	require.Equal(t, len(data), cap(data))
+	_ = append(data, 0xff)
+	require.Equal(t, script.OpEQUALVERIFY, (*s)[23], "appending to push data copies it")
+
+	t.Run("stops early", func(t *testing.T) {
+		count := 0
+		for range parser.Opcodes(s) {
+			count++
+			break
+		}
+		require.Equal(t, 1, count)
+	})
+
+	t.Run("malformed", func(t *testing.T) {
+		var errs []error
+		for _, err := range parser.Opcodes(&script.Script{script.OpTRUE, script.OpDATA2, 0x01}) {
+			errs = append(errs, err)
+		}
+		require.Len(t, errs, 2)
+		require.NoError(t, errs[0])
+		require.Error(t, errs[1])
+	})
+}
@@ -966,11 +966,12 @@ func opcodeCat(op *ParsedOpcode, t *thread) error {
 		return err
 	}
 
-	c := bytes.Join([][]byte{a, b}, nil)
-	if len(c) > t.cfg.MaxScriptElementSize() {
+	if len(a)+len(b) > t.cfg.MaxScriptElementSize() {
 		return errs.NewError(errs.ErrElementTooBig,
-			"concatenated size %d exceeds max allowed size %d", len(c), t.cfg.MaxScriptElementSize())
+			"concatenated size %d exceeds max allowed size %d", len(a)+len(b), t.cfg.MaxScriptElementSize())
 	}
+	c := t.dstack.alloc(len(a) + len(b))
+	copy(c[copy(c, a):], b)
 
 	t.dstack.PushByteArray(c)
 	return nil
@@ -998,7 +999,9 @@ func opcodeSplit(op *ParsedOpcode, t *thread) error {
 		return errs.NewError(errs.ErrNumberTooSmall, "n is negative")
 	}
 
-	a := c[:n.Int()]
+	// Both halves are views of the operand; the first is capped so that
+	// appending to it copies rather than overwriting the second.
+	a := c[:n.Int():n.Int()]
 	b := c[n.Int():]
 	t.dstack.PushByteArray(a)
 	t.dstack.PushByteArray(b)
@@ -1069,7 +1072,7 @@ func opcodeBin2num(op *ParsedOpcode, t *thread) error {
 		return err
 	}
 
-	b := make([]byte, len(a))
+	b := t.dstack.alloc(len(a))
 	// Copy the bytes so that we don't corrupt the original stack value
 	copy(b, a)
 	b = MinimallyEncode(b)
@@ -1109,7 +1112,7 @@ func opcodeInvert(op *ParsedOpcode, t *thread) error {
 
 	// We need to invert without modifying the bytes in place.
 	// If we modify in place then these changes are reflected elsewhere in the stack.
-	baInverted := make([]byte, len(ba))
+	baInverted := t.dstack.alloc(len(ba))
 	for i := range ba {
 		baInverted[i] = ba[i] ^ 0xFF
 	}
@@ -1137,7 +1140,7 @@ func opcodeAnd(op *ParsedOpcode, t *thread) error { //nolint:dupl // to keep fun
 		return errs.NewError(errs.ErrInvalidInputLength, "byte arrays are not the same length")
 	}
 
-	c := make([]byte, len(a))
+	c := t.dstack.alloc(len(a))
 	for i := range a {
 		c[i] = a[i] & b[i]
 	}
@@ -1164,7 +1167,7 @@ func opcodeOr(op *ParsedOpcode, t *thread) error { //nolint:dupl // to keep func
 		return errs.NewError(errs.ErrInvalidInputLength, "byte arrays are not the same length")
 	}
 
-	c := make([]byte, len(a))
+	c := t.dstack.alloc(len(a))
 	for i := range a {
 		c[i] = a[i] | b[i]
 	}
@@ -1191,7 +1194,7 @@ func opcodeXor(op *ParsedOpcode, t *thread) error { //nolint:dupl // to keep fun
 		return errs.NewError(errs.ErrInvalidInputLength, "byte arrays are not the same length")
 	}
 
-	c := make([]byte, len(a))
+	c := t.dstack.alloc(len(a))
 	for i := range a {
 		c[i] = a[i] ^ b[i]
 	}
@@ -1464,7 +1467,7 @@ func opcodeLShift(op *ParsedOpcode, t *thread) error {
 	mask := []byte{0xFF, 0x7F, 0x3F, 0x1F, 0x0F, 0x07, 0x03, 0x01}[bitShift]
 	overflowMask := ^mask
 
-	result := make([]byte, len(x))
+	result := t.dstack.alloc(len(x))
 	for idx := len(x); idx > 0; idx-- {
 		i := idx - 1
 		if byteShift <= i {
@@ -1505,7 +1508,7 @@ func opcodeRShift(op *ParsedOpcode, t *thread) error {
 	bitShift := n % 8
 	mask := []byte{0xFF, 0xFE, 0xFC, 0xF8, 0xF0, 0xE0, 0xC0, 0x80}[bitShift]
 	overflowMask := ^mask
-	result := make([]byte, len(x))
+	result := t.dstack.alloc(len(x))
 	for i, b := range x {
 		k := i + byteShift
 		if k < len(x) {
@@ -1998,20 +2001,13 @@ func opcodeCheckSig(op *ParsedOpcode, t *thread) error {
 		return err
 	}
 
-	// Get script starting from the most recent script.OpCODESEPARATOR.
-	subScript := t.subScript()
-
 	// Generate the signature hash based on the signature hash type.
 	var hash []byte
 
-	// Remove the signature since there is no way for a signature
-	// to sign itself.
-	if !t.hasFlag(scriptflag.EnableSighashForkID) || !shf.Has(sighash.ForkID) {
-		subScript = subScript.removeOpcodeByData(fullSigBytes)
-		subScript = subScript.removeOpcode(script.OpCODESEPARATOR)
-	}
-
-	up, err := t.scriptParser.Unparse(subScript)
+	// Get script starting from the most recent script.OpCODESEPARATOR,
+	// removing the signature since there is no way for a signature to
+	// sign itself.
+	up, err := t.signatureSubScript(fullSigBytes, !t.hasFlag(scriptflag.EnableSighashForkID) || !shf.Has(sighash.ForkID))
 	if err != nil {
 		return err
 	}
@@ -2049,9 +2045,22 @@ func opcodeCheckSig(op *ParsedOpcode, t *thread) error {
 			}
 		}
 		ok = externalVerifySignatureFn(hash, sigBytesDer, pkBytes)
+		if t.signatureDivergence != nil {
+			strict := t.hasAny(scriptflag.VerifyStrictEncoding, scriptflag.VerifyDERSignatures)
+			if builtIn := verifySignature(hash, sigBytes, pkBytes, strict); builtIn != ok {
+				t.signatureDivergence(SignatureDivergence{
+					InputIdx:  t.inputIdx,
+					Payload:   hash,
+					Signature: sigBytesDer,
+					PublicKey: pkBytes,
+					External:  ok,
+					BuiltIn:   builtIn,
+				})
+			}
+		}
 	} else {
 		var pubKey *ec.PublicKey
-		pubKey, err = ec.ParsePubKey(pkBytes)
+		pubKey, err = t.parsePubKey(pkBytes)
 		if err != nil {
 			t.dstack.PushBool(false)
 			return nil //nolint:nilerr // only need a false push in this case
@@ -2198,9 +2207,15 @@ func opcodeCheckMultiSig(op *ParsedOpcode, t *thread) error {
 
 	for _, sigInfo := range signatures {
 		scr = scr.removeOpcodeByData(sigInfo.signature)
+	}
+	if t.subScriptHasCodeSeparator() {
 		scr = scr.removeOpcode(script.OpCODESEPARATOR)
 	}
 
+	// The script to sign is the same for every signature, so it is only
+	// serialized once, when the first signature is checked.
+	var up *script.Script
+
 	success := true
 	numPubKeys++
 	pubKeyIdx := -1
@@ -2270,15 +2285,16 @@ func opcodeCheckMultiSig(op *ParsedOpcode, t *thread) error {
 		}
 
 		// Parse the pubkey.
-		parsedPubKey, err := ec.ParsePubKey(pubKey)
+		parsedPubKey, err := t.parsePubKey(pubKey)
 		if err != nil {
 			continue
 		}
 
-		up, err := t.scriptParser.Unparse(scr)
-		if err != nil {
-			t.dstack.PushBool(false)
-			return nil //nolint:nilerr // only need a false push in this case
+		if up == nil {
+			if up, err = t.scriptParser.Unparse(scr); err != nil {
+				t.dstack.PushBool(false)
+				return nil //nolint:nilerr // only need a false push in this case
+			}
 		}
 
 		// Generate the signature hash based on the signature hash type.
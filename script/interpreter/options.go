@@ -10,6 +10,10 @@ import (
 type ExecutionOptionFunc func(p *execOpts)
 
 // WithTx configure the execution to run again a tx.
+//
+// When the input at inputIdx has neither a source transaction nor a source
+// output, the execution sets prevOutput as its source output, writing to the
+// input of tx. Set it beforehand to execute the input from several goroutines.
 func WithTx(tx *transaction.Transaction, inputIdx int, prevOutput *transaction.TransactionOutput) ExecutionOptionFunc {
 	return func(p *execOpts) {
 		p.tx = tx
@@ -47,7 +51,8 @@ func WithP2SH() ExecutionOptionFunc {
 	}
 }
 
-// WithFlags configure the execution with the provided flags.
+// WithFlags configure the execution with the provided flags, such as one of the
+// scriptflag presets.
 func WithFlags(flags scriptflag.Flag) ExecutionOptionFunc {
 	return func(p *execOpts) {
 		p.flags.AddFlag(flags)
@@ -63,6 +68,15 @@ func WithDebugger(debugger Debugger) ExecutionOptionFunc {
 	}
 }
 
+// WithCostMeter accumulates the cost of the executed opcodes in meter, aborting the
+// execution with ErrCostBudgetExceeded once its budget is exceeded. The accumulated
+// cost can be read from the meter after execution, whether or not it succeeded.
+func WithCostMeter(meter *CostMeter) ExecutionOptionFunc {
+	return func(p *execOpts) {
+		p.costMeter = meter
+	}
+}
+
 // WithState inject the provided state into the execution thread. This assumes
 // that the state is correct for the scripts provided.
 //
@@ -0,0 +1,120 @@
+package interpreter
+
+import (
+	"container/list"
+	"sync"
+
+	ec "github.com/bsv-blockchain/go-sdk/primitives/ec"
+)
+
+// DefaultPubKeyCacheSize is the number of keys a PubKeyCache holds when created
+// with a non-positive size.
+const DefaultPubKeyCacheSize = 4096
+
+// PubKeyCache is an LRU cache of parsed public keys, keyed by their 33 or 65 byte
+// encoding. Parsing a compressed key takes a square root, so sharing a cache across
+// executions with WithPubKeyCache pays off when the same keys sign many
+// transactions, such as multisig or exchange wallets. It is safe for concurrent
+// use.
+//
+// Keys are always cached for the duration of a single execution, with or without
+// a PubKeyCache, so multisig scripts checking a key against several signatures
+// parse it once.
+type PubKeyCache struct {
+	items   map[string]*list.Element
+	list    *list.List
+	maxSize int
+	mu      sync.Mutex
+}
+
+type pubKeyEntry struct {
+	encoded string
+	key     *ec.PublicKey
+}
+
+// NewPubKeyCache creates a PubKeyCache holding up to size keys.
+func NewPubKeyCache(size int) *PubKeyCache {
+	if size <= 0 {
+		size = DefaultPubKeyCacheSize
+	}
+	return &PubKeyCache{
+		items:   make(map[string]*list.Element),
+		list:    list.New(),
+		maxSize: size,
+	}
+}
+
+// Len returns the number of cached keys.
+func (c *PubKeyCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.items)
+}
+
+func (c *PubKeyCache) get(encoded []byte) (*ec.PublicKey, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if elem, ok := c.items[string(encoded)]; ok {
+		c.list.MoveToFront(elem)
+		return elem.Value.(*pubKeyEntry).key, true
+	}
+	return nil, false
+}
+
+func (c *PubKeyCache) set(encoded []byte, key *ec.PublicKey) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if elem, ok := c.items[string(encoded)]; ok {
+		c.list.MoveToFront(elem)
+		return
+	}
+
+	entry := &pubKeyEntry{encoded: string(encoded), key: key}
+	c.items[entry.encoded] = c.list.PushFront(entry)
+
+	if len(c.items) > c.maxSize {
+		oldest := c.list.Back()
+		delete(c.items, oldest.Value.(*pubKeyEntry).encoded)
+		c.list.Remove(oldest)
+	}
+}
+
+// WithPubKeyCache shares parsed public keys across executions through cache.
+func WithPubKeyCache(cache *PubKeyCache) ExecutionOptionFunc {
+	return func(p *execOpts) {
+		p.pubKeyCache = cache
+	}
+}
+
+// parsePubKey parses a public key, reusing the keys already parsed during this
+// execution or held by the shared cache. Only valid keys are cached.
+func (t *thread) parsePubKey(encoded []byte) (*ec.PublicKey, error) {
+	if key, ok := t.pubKeys[string(encoded)]; ok {
+		return key, nil
+	}
+	if t.pubKeyCache != nil {
+		if key, ok := t.pubKeyCache.get(encoded); ok {
+			t.cachePubKey(encoded, key)
+			return key, nil
+		}
+	}
+
+	key, err := ec.ParsePubKey(encoded)
+	if err != nil {
+		return nil, err
+	}
+	t.cachePubKey(encoded, key)
+	if t.pubKeyCache != nil {
+		t.pubKeyCache.set(encoded, key)
+	}
+	return key, nil
+}
+
+func (t *thread) cachePubKey(encoded []byte, key *ec.PublicKey) {
+	if t.pubKeys == nil {
+		t.pubKeys = make(map[string]*ec.PublicKey)
+	}
+	t.pubKeys[string(encoded)] = key
+}
@@ -0,0 +1,103 @@
+package interpreter
+
+import (
+	"testing"
+
+	"github.com/bsv-blockchain/go-sdk/chainhash"
+	ec "github.com/bsv-blockchain/go-sdk/primitives/ec"
+	"github.com/bsv-blockchain/go-sdk/script"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+	sighash "github.com/bsv-blockchain/go-sdk/transaction/sighash"
+	"github.com/stretchr/testify/require"
+)
+
+// multisigSpend builds a transaction spending a 2-of-3 multisig output, signed by
+// the first two keys.
+func multisigSpend(t testing.TB) (*transaction.Transaction, *transaction.TransactionOutput) {
+	t.Helper()
+	keys := make([]*ec.PrivateKey, 3)
+	locking := &script.Script{}
+	require.NoError(t, locking.AppendOpcodes(script.Op2))
+	for i := range keys {
+		var err error
+		keys[i], err = ec.NewPrivateKey()
+		require.NoError(t, err)
+		require.NoError(t, locking.AppendPushData(keys[i].PubKey().Compressed()))
+	}
+	require.NoError(t, locking.AppendOpcodes(script.Op3, script.OpCHECKMULTISIG))
+
+	prevOutput := &transaction.TransactionOutput{Satoshis: 1000, LockingScript: locking}
+	tx := transaction.NewTransaction()
+	tx.AddInput(&transaction.TransactionInput{SourceTXID: &chainhash.Hash{1}, SequenceNumber: 0xffffffff})
+	tx.Inputs[0].SetSourceTxOutput(prevOutput)
+	tx.AddOutput(&transaction.TransactionOutput{Satoshis: 900, LockingScript: &script.Script{}})
+
+	flag := sighash.AllForkID
+	hash, err := tx.CalcInputSignatureHash(0, flag)
+	require.NoError(t, err)
+	unlocking := &script.Script{}
+	require.NoError(t, unlocking.AppendOpcodes(script.Op0))
+	for _, key := range keys[:2] {
+		sig, err := key.Sign(hash)
+		require.NoError(t, err)
+		require.NoError(t, unlocking.AppendPushData(append(sig.Serialize(), byte(flag))))
+	}
+	tx.Inputs[0].UnlockingScript = unlocking
+	return tx, prevOutput
+}
+
+func TestPubKeyCache(t *testing.T) {
+	tx, prevOutput := multisigSpend(t)
+	cache := NewPubKeyCache(0)
+
+	for range 2 {
+		err := NewEngine().Execute(
+			WithTx(tx, 0, prevOutput),
+			WithForkID(),
+			WithAfterGenesis(),
+			WithPubKeyCache(cache),
+		)
+		require.NoError(t, err)
+	}
+	require.Equal(t, 3, cache.Len())
+
+	t.Run("evicts the least recently used key", func(t *testing.T) {
+		cache := NewPubKeyCache(2)
+		encoded := make([][]byte, 3)
+		for i := range encoded {
+			key, err := ec.NewPrivateKey()
+			require.NoError(t, err)
+			encoded[i] = key.PubKey().Compressed()
+		}
+		th := &thread{pubKeyCache: cache}
+		for _, e := range encoded[:2] {
+			_, err := th.parsePubKey(e)
+			require.NoError(t, err)
+		}
+		_, ok := cache.get(encoded[0])
+		require.True(t, ok)
+		_, err := th.parsePubKey(encoded[2])
+		require.NoError(t, err)
+
+		require.Equal(t, 2, cache.Len())
+		_, ok = cache.get(encoded[1])
+		require.False(t, ok)
+		_, ok = cache.get(encoded[0])
+		require.True(t, ok)
+	})
+
+	t.Run("reuses keys within an execution", func(t *testing.T) {
+		key, err := ec.NewPrivateKey()
+		require.NoError(t, err)
+		th := &thread{}
+		first, err := th.parsePubKey(key.PubKey().Compressed())
+		require.NoError(t, err)
+		second, err := th.parsePubKey(key.PubKey().Compressed())
+		require.NoError(t, err)
+		require.Same(t, first, second)
+
+		_, err = th.parsePubKey([]byte{0x02, 0x01})
+		require.Error(t, err)
+		require.Len(t, th.pubKeys, 1)
+	})
+}
@@ -0,0 +1,153 @@
+package interpreter
+
+import (
+	"context"
+	"strings"
+
+	"github.com/bsv-blockchain/go-sdk/script"
+	"github.com/bsv-blockchain/go-sdk/script/interpreter/errs"
+)
+
+// REPL evaluates a script a line at a time, reporting the state of the stacks
+// after every opcode, for learning and prototyping scripts. Each line is
+// appended to the script built so far, which is then executed as a locking
+// script with an empty unlocking script, so lines can open conditionals that
+// later lines close. A line failing to execute is discarded, leaving the REPL
+// as it was.
+//
+// Opcodes that need a transaction, such as OP_CHECKSIG, fail as there is none.
+// A REPL is not safe for concurrent use.
+type REPL struct {
+	engine Engine
+	script script.Script
+	// ops is the number of opcodes of the script.
+	ops int
+	// lines holds where each evaluated line starts.
+	lines []replLine
+	state *State
+}
+
+type replLine struct {
+	size, ops int
+}
+
+// NewREPL creates a REPL executing scripts with the options, typically flags
+// such as WithAfterGenesis.
+func NewREPL(opts ...ExecutionOptionFunc) *REPL {
+	return &REPL{engine: NewEngine(opts...)}
+}
+
+// replDebugger records the state after every opcode.
+type replDebugger struct {
+	nopDebugger
+	states []*State
+}
+
+func (d *replDebugger) AfterExecuteOpcode(s *State) {
+	d.states = append(d.states, s)
+}
+
+// Eval evaluates a line of script in ASM, returning the states after each of its
+// opcodes. Opcodes not executed because they are within a false conditional
+// branch have states too, with an unchanged stack. The script failing on an
+// opcode of the line is reported with an *ExecutionError and the line is
+// discarded. The checks applied once a script ends, such as requiring a true
+// result and balanced conditionals, are not errors as more lines may follow.
+func (r *REPL) Eval(ctx context.Context, line string) ([]*State, error) {
+	line = strings.Join(strings.Fields(line), " ")
+	if line == "" {
+		return nil, nil
+	}
+	parsed, err := script.NewFromASM(line)
+	if err != nil {
+		return nil, err
+	}
+
+	prev := replLine{size: len(r.script), ops: r.ops}
+	scr := append(r.script[:prev.size:prev.size], *parsed...)
+	executed, err := r.execute(ctx, scr)
+	if err != nil {
+		return nil, err
+	}
+	r.lines = append(r.lines, prev)
+
+	// The earlier lines are executed again, so only the states of the opcodes of
+	// this line are returned.
+	var states []*State
+	for _, s := range executed {
+		if s.ScriptIdx == ScriptLocking && s.OpcodeIdx >= prev.ops {
+			states = append(states, s)
+		}
+	}
+	return states, nil
+}
+
+// execute executes scr, making it the script of the REPL if it succeeds, and
+// returns the states after each of its opcodes.
+func (r *REPL) execute(ctx context.Context, scr script.Script) ([]*State, error) {
+	dbg := &replDebugger{}
+	err := r.engine.ExecuteContext(ctx,
+		WithScripts(&scr, &script.Script{}),
+		WithDebugger(dbg),
+	)
+	if err != nil && !isScriptEndError(err) {
+		return nil, err
+	}
+
+	r.script = scr
+	if n := len(dbg.states); n > 0 {
+		r.state = dbg.states[n-1]
+		r.ops = len(r.state.Scripts[ScriptLocking])
+	}
+	return dbg.states, nil
+}
+
+// isScriptEndError reports whether err is from the checks applied once a script
+// ends rather than from executing an opcode.
+func isScriptEndError(err error) bool {
+	for _, code := range []errs.ErrorCode{errs.ErrUnbalancedConditional, errs.ErrEvalFalse,
+		errs.ErrEmptyStack, errs.ErrCleanStack} {
+		if errs.IsErrorCode(err, code) {
+			return true
+		}
+	}
+	return false
+}
+
+// Undo discards the last line evaluated, returning false if there is none.
+func (r *REPL) Undo(ctx context.Context) (bool, error) {
+	n := len(r.lines)
+	if n == 0 {
+		return false, nil
+	}
+	lines := r.lines[:n-1]
+	scr := r.script[:r.lines[n-1].size]
+	r.Reset()
+	if len(scr) == 0 {
+		return true, nil
+	}
+
+	// Replay the script that remains to recover the state before the line.
+	if _, err := r.execute(ctx, scr); err != nil {
+		return true, err
+	}
+	r.lines = lines
+	return true, nil
+}
+
+// Reset discards every line evaluated.
+func (r *REPL) Reset() {
+	r.script, r.ops, r.lines, r.state = nil, 0, nil, nil
+}
+
+// Script returns the script built from the lines evaluated so far.
+func (r *REPL) Script() *script.Script {
+	scr := make(script.Script, len(r.script))
+	copy(scr, r.script)
+	return &scr
+}
+
+// State returns the state after the last opcode executed, or nil if none has.
+func (r *REPL) State() *State {
+	return r.state
+}
@@ -0,0 +1,85 @@
+package interpreter
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/bsv-blockchain/go-sdk/script/interpreter/errs"
+	"github.com/stretchr/testify/require"
+)
+
+func TestREPL(t *testing.T) {
+	ctx := t.Context()
+	r := NewREPL(WithAfterGenesis(), WithForkID())
+
+	states, err := r.Eval(ctx, "OP_2  OP_3")
+	require.NoError(t, err)
+	require.Len(t, states, 2)
+	require.Equal(t, "OP_2", states[0].Opcode().Name())
+	require.Equal(t, [][]byte{{2}}, states[0].DataStack)
+	require.Equal(t, [][]byte{{2}, {3}}, states[1].DataStack)
+
+	// Only the states of the opcodes of the line are returned.
+	states, err = r.Eval(ctx, "OP_ADD OP_DUP OP_TOALTSTACK")
+	require.NoError(t, err)
+	require.Len(t, states, 3)
+	require.Equal(t, "OP_ADD", states[0].Opcode().Name())
+	require.Equal(t, [][]byte{{5}}, states[0].DataStack)
+	require.Equal(t, [][]byte{{5}}, states[2].AltStack)
+	require.Equal(t, states[2], r.State())
+
+	t.Run("failing line is discarded", func(t *testing.T) {
+		before := r.Script()
+		_, err := r.Eval(ctx, "OP_DROP OP_DROP")
+		var execErr *ExecutionError
+		require.True(t, errors.As(err, &execErr))
+		require.True(t, errs.IsErrorCode(err, errs.ErrInvalidStackOperation))
+		require.Equal(t, "OP_DROP", execErr.Opcode)
+		require.Equal(t, before, r.Script())
+		require.Equal(t, [][]byte{{5}}, r.State().DataStack)
+
+		_, err = r.Eval(ctx, "OP_NOTANOPCODE")
+		require.Error(t, err)
+	})
+
+	t.Run("conditionals span lines", func(t *testing.T) {
+		states, err := r.Eval(ctx, "OP_0 OP_IF")
+		require.NoError(t, err)
+		require.Len(t, states, 2)
+		require.Len(t, r.State().CondStack, 1)
+
+		states, err = r.Eval(ctx, "OP_7 OP_ELSE OP_8 OP_ENDIF")
+		require.NoError(t, err)
+		require.Len(t, states, 4)
+		require.Equal(t, [][]byte{{5}}, states[0].DataStack)
+		require.Equal(t, [][]byte{{5}, {8}}, r.State().DataStack)
+		require.Empty(t, r.State().CondStack)
+	})
+
+	t.Run("undo", func(t *testing.T) {
+		ok, err := r.Undo(ctx)
+		require.NoError(t, err)
+		require.True(t, ok)
+		require.Equal(t, [][]byte{{5}}, r.State().DataStack)
+		require.Len(t, r.State().CondStack, 1)
+
+		states, err := r.Eval(ctx, "OP_ENDIF OP_9")
+		require.NoError(t, err)
+		require.Len(t, states, 2)
+		require.Equal(t, [][]byte{{5}, {9}}, r.State().DataStack)
+	})
+
+	t.Run("reset", func(t *testing.T) {
+		r.Reset()
+		require.Nil(t, r.State())
+		require.Empty(t, *r.Script())
+
+		ok, err := r.Undo(ctx)
+		require.NoError(t, err)
+		require.False(t, ok)
+
+		states, err := r.Eval(ctx, "")
+		require.NoError(t, err)
+		require.Empty(t, states)
+	})
+}
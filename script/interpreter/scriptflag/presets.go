@@ -0,0 +1,91 @@
+package scriptflag
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Named presets of flags. Combine or adjust them with a Builder rather than by
+// ORing individual flags, so the result is validated.
+const (
+	// Consensus are the flags every node enforces on the transactions in a block,
+	// the mandatory script verification flags of the node software.
+	Consensus = Bip16 | VerifyStrictEncoding | EnableSighashForkID | VerifyLowS | VerifyNullFail
+
+	// StandardPolicy are the flags nodes enforce before relaying or mining a
+	// transaction. They are stricter than Consensus, so a script failing them
+	// may still be valid in a block.
+	StandardPolicy = Consensus | VerifyDERSignatures | VerifyMinimalData | StrictMultiSig |
+		DiscourageUpgradableNops | VerifyCleanStack | VerifyCheckLockTimeVerify | VerifyCheckSequenceVerify
+
+	// GenesisDefault are the flags for spending an output created after the
+	// Genesis upgrade with a fork ID signature, the usual case for new
+	// transactions.
+	GenesisDefault = UTXOAfterGenesis | EnableSighashForkID
+)
+
+// all is every defined flag.
+const all = VerifyMinimalIf<<1 - 1
+
+// ErrIncompatibleFlags is returned when validating a combination of flags the
+// engine cannot execute with.
+var ErrIncompatibleFlags = errors.New("incompatible script flags")
+
+// Validate returns an error wrapping ErrIncompatibleFlags if the flags cannot be
+// used together.
+func (s Flag) Validate() error {
+	if unknown := s &^ all; unknown != 0 {
+		return fmt.Errorf("%w: unknown flags %#x", ErrIncompatibleFlags, uint32(unknown))
+	}
+	// Evaluating a P2SH script without Bip16 leaves its inputs on the stack, so
+	// enforcing a clean stack would make P2SH a hard fork.
+	if s.HasFlag(VerifyCleanStack) && !s.HasFlag(Bip16) {
+		return fmt.Errorf("%w: VerifyCleanStack requires Bip16", ErrIncompatibleFlags)
+	}
+	return nil
+}
+
+// RemoveFlag removes the passed flag from Flags.
+func (s *Flag) RemoveFlag(flag Flag) {
+	*s &^= flag
+}
+
+// Builder combines flags, validating the result:
+//
+//	flags, err := scriptflag.NewBuilder(scriptflag.StandardPolicy).
+//		Without(scriptflag.DiscourageUpgradableNops).
+//		With(scriptflag.VerifyMinimalIf).
+//		Build()
+type Builder struct {
+	flags Flag
+}
+
+// NewBuilder creates a Builder starting from the given flags, typically a preset.
+func NewBuilder(base Flag) *Builder {
+	return &Builder{flags: base}
+}
+
+// With adds the flags.
+func (b *Builder) With(flags ...Flag) *Builder {
+	for _, f := range flags {
+		b.flags.AddFlag(f)
+	}
+	return b
+}
+
+// Without removes the flags.
+func (b *Builder) Without(flags ...Flag) *Builder {
+	for _, f := range flags {
+		b.flags.RemoveFlag(f)
+	}
+	return b
+}
+
+// Build returns the combined flags, or an error wrapping ErrIncompatibleFlags if
+// they cannot be used together.
+func (b *Builder) Build() (Flag, error) {
+	if err := b.flags.Validate(); err != nil {
+		return 0, err
+	}
+	return b.flags, nil
+}
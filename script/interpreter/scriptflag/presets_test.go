@@ -0,0 +1,38 @@
+package scriptflag
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPresets(t *testing.T) {
+	for name, preset := range map[string]Flag{
+		"Consensus":      Consensus,
+		"StandardPolicy": StandardPolicy,
+		"GenesisDefault": GenesisDefault,
+	} {
+		require.NoError(t, preset.Validate(), name)
+	}
+	require.True(t, StandardPolicy.HasFlag(Consensus))
+}
+
+func TestBuilder(t *testing.T) {
+	flags, err := NewBuilder(StandardPolicy).
+		Without(DiscourageUpgradableNops).
+		With(VerifyMinimalIf).
+		Build()
+	require.NoError(t, err)
+	require.False(t, flags.HasFlag(DiscourageUpgradableNops))
+	require.True(t, flags.HasFlag(VerifyMinimalIf|VerifyCleanStack))
+
+	_, err = NewBuilder(StandardPolicy).Without(Bip16).Build()
+	require.ErrorIs(t, err, ErrIncompatibleFlags)
+
+	flags, err = NewBuilder(StandardPolicy).Without(Bip16, VerifyCleanStack).Build()
+	require.NoError(t, err)
+	require.False(t, flags.HasAny(Bip16, VerifyCleanStack))
+
+	_, err = NewBuilder(0).With(VerifyMinimalIf << 1).Build()
+	require.ErrorIs(t, err, ErrIncompatibleFlags)
+}
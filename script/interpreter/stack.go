@@ -24,20 +24,16 @@ func asBool(t []byte) bool {
 	return false
 }
 
-// fromBool converts a boolean into the appropriate byte array.
-func fromBool(v bool) []byte {
-	if v {
-		return []byte{1}
-	}
-	return nil
-}
-
 // stack represents a stack of immutable objects to be used with bitcoin
 // scripts.  Objects may be shared, therefore in usage if a value is to be
 // changed it *must* be deep-copied first to avoid changing other values on the
 // stack.
+//
+// New elements are allocated from the arena of the execution when there is one,
+// and are only valid until the execution finishes.
 type stack struct {
 	stk               [][]byte
+	arena             *arena
 	maxNumLength      int
 	afterGenesis      bool
 	verifyMinimalData bool
@@ -55,6 +51,14 @@ func newStack(cfg config, verifyMinimalData bool) stack {
 	}
 }
 
+// alloc returns a zeroed byte slice of size n for a new stack element.
+func (s *stack) alloc(n int) []byte {
+	if s.arena == nil {
+		return make([]byte, n)
+	}
+	return s.arena.alloc(n)
+}
+
 // Depth returns the number of items on the stack.
 func (s *stack) Depth() int32 {
 	return int32(len(s.stk))
@@ -74,7 +78,9 @@ func (s *stack) PushByteArray(so []byte) {
 //
 // Stack transformation: [... x1 x2] -> [... x1 x2 int]
 func (s *stack) PushInt(n *ScriptNumber) {
-	s.PushByteArray(n.Bytes())
+	b := s.alloc(n.encodedLen())
+	n.encode(b)
+	s.PushByteArray(b)
 }
 
 // PushBool converts the provided boolean to a suitable byte array then pushes
@@ -82,7 +88,13 @@ func (s *stack) PushInt(n *ScriptNumber) {
 //
 // Stack transformation: [... x1 x2] -> [... x1 x2 bool]
 func (s *stack) PushBool(val bool) {
-	s.PushByteArray(fromBool(val))
+	if !val {
+		s.PushByteArray(nil)
+		return
+	}
+	b := s.alloc(1)
+	b[0] = 1
+	s.PushByteArray(b)
 }
 
 // PopByteArray pops the value off the top of the stack and returns it.
@@ -175,9 +187,8 @@ func (s *stack) nipN(idx int32) ([]byte, error) {
 	case 0:
 		s.stk = s.stk[:sz-1]
 	case sz - 1:
-		s1 := make([][]byte, sz-1)
-		copy(s1, s.stk[1:])
-		s.stk = s1
+		copy(s.stk, s.stk[1:])
+		s.stk = s.stk[:sz-1]
 	default:
 		s1 := s.stk[sz-idx : sz]
 		s.stk = s.stk[:sz-idx-1]
@@ -41,8 +41,12 @@ type StateHandler interface {
 
 type nopStateHandler struct{}
 
+// nopState is the state reported by nopStateHandler. It is only ever passed to
+// nopDebugger, which ignores it, so a single instance serves every execution.
+var nopState = &State{}
+
 func (n *nopStateHandler) State() *State {
-	return &State{}
+	return nopState
 }
 func (n *nopStateHandler) SetState(state *State) {}
 
@@ -125,6 +129,11 @@ func (t *thread) SetState(state *State) {
 	t.savedFirstStack = state.SavedFirstStack
 
 	t.scripts = state.Scripts
+	t.codeSeps = make([][]int, len(t.scripts))
+	for i, script := range t.scripts {
+		t.codeSeps[i] = script.codeSeparators()
+	}
+	t.subScriptCache.script = nil
 	t.scriptIdx = state.ScriptIdx
 	t.scriptOff = state.OpcodeIdx
 	t.lastCodeSep = state.LastCodeSeparatorIdx
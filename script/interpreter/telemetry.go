@@ -0,0 +1,98 @@
+//go:build !embedded
+
+package interpreter
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/bsv-blockchain/go-sdk/script/interpreter/errs"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const instrumentationName = "github.com/bsv-blockchain/go-sdk/script/interpreter"
+
+// Telemetry reports script executions to OpenTelemetry: a span per execution, and
+// the execution duration and executed opcode count as metrics. Create it once with
+// NewTelemetry and pass it to executions with WithTelemetry; executions without it
+// are not instrumented at all.
+type Telemetry struct {
+	tracer     trace.Tracer
+	duration   metric.Float64Histogram
+	operations metric.Int64Histogram
+}
+
+// NewTelemetry creates a Telemetry using the given providers. Either may be nil to
+// disable that signal.
+func NewTelemetry(tp trace.TracerProvider, mp metric.MeterProvider) *Telemetry {
+	t := &Telemetry{}
+	if tp != nil {
+		t.tracer = tp.Tracer(instrumentationName)
+	}
+	if mp != nil {
+		meter := mp.Meter(instrumentationName)
+		var err error
+		if t.duration, err = meter.Float64Histogram("bsv.interpreter.execution.duration",
+			metric.WithDescription("Duration of script executions"),
+			metric.WithUnit("s")); err != nil {
+			otel.Handle(err)
+		}
+		if t.operations, err = meter.Int64Histogram("bsv.interpreter.execution.operations",
+			metric.WithDescription("Number of opcodes executed per script execution"),
+			metric.WithUnit("{operation}")); err != nil {
+			otel.Handle(err)
+		}
+	}
+	return t
+}
+
+// WithTelemetry reports the execution with the given Telemetry.
+func WithTelemetry(t *Telemetry) ExecutionOptionFunc {
+	return func(p *execOpts) {
+		p.telemetry = t
+	}
+}
+
+// observe runs the execution, recording its duration, opcode count and outcome.
+func (t *Telemetry) observe(ctx context.Context, run func(context.Context) (int, error)) error {
+	var span trace.Span
+	if t.tracer != nil {
+		ctx, span = t.tracer.Start(ctx, "interpreter.Execute")
+		defer span.End()
+	}
+
+	start := time.Now()
+	ops, err := run(ctx)
+	elapsed := time.Since(start)
+
+	var attrs []attribute.KeyValue
+	if err != nil {
+		errType := "error"
+		var scriptErr errs.Error
+		if errors.As(err, &scriptErr) {
+			errType = scriptErr.ErrorCode.String()
+		}
+		attrs = append(attrs, attribute.String("error.type", errType))
+	}
+
+	if span != nil && span.IsRecording() {
+		span.SetAttributes(attribute.Int("bsv.interpreter.operations", ops))
+		if err != nil {
+			span.SetAttributes(attrs...)
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+	}
+	if t.duration != nil {
+		t.duration.Record(ctx, elapsed.Seconds(), metric.WithAttributes(attrs...))
+	}
+	if t.operations != nil {
+		t.operations.Record(ctx, int64(ops), metric.WithAttributes(attrs...))
+	}
+	return err
+}
@@ -0,0 +1,23 @@
+//go:build embedded
+
+package interpreter
+
+import "context"
+
+// Telemetry is not available in embedded builds, which leave out OpenTelemetry.
+// WithTelemetry is kept so that callers compile unchanged, but executions are
+// never instrumented.
+type Telemetry struct{}
+
+// WithTelemetry has no effect in embedded builds.
+func WithTelemetry(t *Telemetry) ExecutionOptionFunc {
+	return func(p *execOpts) {
+		p.telemetry = t
+	}
+}
+
+// observe runs the execution without recording it.
+func (t *Telemetry) observe(ctx context.Context, run func(context.Context) (int, error)) error {
+	_, err := run(ctx)
+	return err
+}
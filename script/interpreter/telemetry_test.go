@@ -0,0 +1,62 @@
+//go:build !embedded
+
+package interpreter
+
+import (
+	"testing"
+
+	"github.com/bsv-blockchain/go-sdk/script"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/codes"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestTelemetry(t *testing.T) {
+	spans := tracetest.NewSpanRecorder()
+	reader := sdkmetric.NewManualReader()
+	telemetry := NewTelemetry(
+		sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(spans)),
+		sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader)),
+	)
+
+	lockingScript := &script.Script{script.Op2, script.OpADD, script.Op3, script.OpEQUAL}
+	require.NoError(t, NewEngine().Execute(
+		WithScripts(lockingScript, &script.Script{script.Op1}),
+		WithAfterGenesis(),
+		WithTelemetry(telemetry),
+	))
+	require.Error(t, NewEngine().Execute(
+		WithScripts(lockingScript, &script.Script{script.Op2}),
+		WithAfterGenesis(),
+		WithTelemetry(telemetry),
+	))
+
+	ended := spans.Ended()
+	require.Len(t, ended, 2)
+	require.Equal(t, "interpreter.Execute", ended[0].Name())
+	require.Equal(t, codes.Unset, ended[0].Status().Code)
+	require.Equal(t, codes.Error, ended[1].Status().Code)
+
+	var rm metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(t.Context(), &rm))
+	require.Len(t, rm.ScopeMetrics, 1)
+	metrics := map[string]metricdata.Aggregation{}
+	for _, m := range rm.ScopeMetrics[0].Metrics {
+		metrics[m.Name] = m.Data
+	}
+
+	ops := metrics["bsv.interpreter.execution.operations"].(metricdata.Histogram[int64]).DataPoints
+	require.Len(t, ops, 2, "one data point per outcome")
+	for _, dp := range ops {
+		require.Equal(t, uint64(1), dp.Count)
+		require.Equal(t, int64(5), dp.Sum)
+		if errType, ok := dp.Attributes.Value("error.type"); ok {
+			require.Equal(t, "ErrEvalFalse", errType.AsString())
+		}
+	}
+	duration := metrics["bsv.interpreter.execution.duration"].(metricdata.Histogram[float64]).DataPoints
+	require.Len(t, duration, 2)
+}
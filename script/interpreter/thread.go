@@ -1,7 +1,10 @@
 package interpreter
 
 import (
-	"math/big"
+	"context"
+	"fmt"
+	"slices"
+	"sync"
 
 	ec "github.com/bsv-blockchain/go-sdk/primitives/ec"
 	script "github.com/bsv-blockchain/go-sdk/script"
@@ -11,21 +14,25 @@ import (
 	sighash "github.com/bsv-blockchain/go-sdk/transaction/sighash"
 )
 
-// halfOrder is used to tame ECDSA malleability (see BIP0062).
-var halfOrder = new(big.Int).Rsh(ec.S256().N, 1)
-
 type thread struct {
 	dstack stack // data stack
 	astack stack // alt stack
 
 	elseStack boolStack
 
+	// arena backs the stack elements, recycled when the execution finishes
+	// unless a debugger may have kept references to them.
+	arena        *arena
+	recycleArena bool
+	pooled       bool // returned to threadPool on release
+
 	cfg config
 
 	debug Debugger
 	state StateHandler
 
 	scripts         []ParsedScript
+	codeSeps        [][]int // OP_CODESEPARATOR indexes of each script
 	condStack       []int
 	savedFirstStack [][]byte // stack from first script for bip16 scripts
 
@@ -34,11 +41,30 @@ type thread struct {
 	scriptOff    int
 	lastCodeSep  int
 
+	// subScriptCache is the serialized subscript of the last signature check,
+	// reused while the script and the last OP_CODESEPARATOR stay the same.
+	subScriptCache struct {
+		scriptIdx   int
+		lastCodeSep int
+		script      *script.Script
+	}
+
 	tx         *transaction.Transaction
 	inputIdx   int
 	prevOutput *transaction.TransactionOutput
 
-	numOps int
+	numOps  int
+	opCount int // opcodes stepped through across all scripts
+	// opScriptIdx and opScriptOff locate the last opcode stepped through.
+	opScriptIdx int
+	opScriptOff int
+	costMeter   *CostMeter
+
+	signatureDivergence func(SignatureDivergence)
+
+	// pubKeys holds the public keys parsed during this execution.
+	pubKeys     map[string]*ec.PublicKey
+	pubKeyCache *PubKeyCache
 
 	flags scriptflag.Flag
 	bip16 bool // treat execution as pay-to-script-hash
@@ -47,13 +73,29 @@ type thread struct {
 	earlyReturnAfterGenesis bool
 }
 
-func createThread(opts *execOpts) (*thread, error) {
-	th := &thread{
-		scriptParser: &DefaultOpcodeParser{
-			ErrorOnCheckSig: opts.tx == nil || opts.previousTxOut == nil,
-		},
-		cfg: &beforeGenesisConfig{},
+// threadPool recycles threads, and the slices they grow, between executions.
+var threadPool = sync.Pool{
+	New: func() any { return &thread{} },
+}
+
+var (
+	txParser     = &DefaultOpcodeParser{}
+	scriptParser = &DefaultOpcodeParser{ErrorOnCheckSig: true}
+)
+
+func createThread(opts *execOpts) (_ *thread, err error) {
+	th := threadPool.Get().(*thread)
+	th.reset()
+	defer func() {
+		if err != nil {
+			th.discard()
+		}
+	}()
+	th.scriptParser = txParser
+	if opts.tx == nil || opts.previousTxOut == nil {
+		th.scriptParser = scriptParser
 	}
+	th.cfg = &beforeGenesisConfig{}
 
 	if err := th.apply(opts); err != nil {
 		return nil, err
@@ -78,6 +120,11 @@ type execOpts struct {
 	flags           scriptflag.Flag
 	debugger        Debugger
 	state           *State
+	costMeter       *CostMeter
+	telemetry       *Telemetry
+	pubKeyCache     *PubKeyCache
+
+	signatureDivergence func(SignatureDivergence)
 }
 
 func (o execOpts) validate() error {
@@ -201,6 +248,12 @@ func (t *thread) executeOpcode(pop ParsedOpcode) error {
 		return nil
 	}
 
+	if t.costMeter != nil && (exec || pop.IsConditional()) {
+		if err := t.costMeter.charge(&pop, t); err != nil {
+			return err
+		}
+	}
+
 	return pop.op.exec(&pop, t)
 }
 
@@ -261,6 +314,9 @@ func (t *thread) apply(opts *execOpts) error {
 	t.flags = opts.flags
 	t.inputIdx = opts.inputIdx
 	t.prevOutput = opts.previousTxOut
+	t.costMeter = opts.costMeter
+	t.signatureDivergence = opts.signatureDivergence
+	t.pubKeyCache = opts.pubKeyCache
 
 	// The clean stack flag (ScriptVerifyCleanStack) is not allowed without
 	// the pay-to-script-hash (P2SH) evaluation (ScriptBip16).
@@ -292,8 +348,8 @@ func (t *thread) apply(opts *execOpts) error {
 		return errs.NewError(errs.ErrEvalFalse, "false stack entry at end of script execution")
 	}
 
-	if t.hasFlag(scriptflag.VerifyCleanStack) && !t.hasFlag(scriptflag.Bip16) {
-		return errs.NewError(errs.ErrInvalidFlags, "invalid scriptflag combination")
+	if err := t.flags.Validate(); err != nil {
+		return errs.NewError(errs.ErrInvalidFlags, "invalid scriptflag combination: %v", err)
 	}
 
 	if len(*uscript) > t.cfg.MaxScriptSize() {
@@ -317,7 +373,8 @@ func (t *thread) apply(opts *execOpts) error {
 	// allows multiple scripts to be executed in sequence.  For example,
 	// with a pay-to-script-hash transaction, there will be ultimately be
 	// a third script to execute.
-	t.scripts = make([]ParsedScript, 2)
+	t.scripts = append(t.scripts[:0], nil, nil)
+	t.codeSeps = append(t.codeSeps[:0], nil, nil)
 	for i, script := range []*script.Script{uscript, lscript} {
 		pscript, err := t.scriptParser.Parse(script)
 		if err != nil {
@@ -325,6 +382,7 @@ func (t *thread) apply(opts *execOpts) error {
 		}
 
 		t.scripts[i] = pscript
+		t.codeSeps[i] = pscript.codeSeparators()
 	}
 
 	// The signature script must only contain data pushes when the
@@ -352,17 +410,41 @@ func (t *thread) apply(opts *execOpts) error {
 	t.astack = newStack(t.cfg, t.hasFlag(scriptflag.VerifyMinimalData))
 
 	if t.tx != nil {
-		t.tx.Inputs[t.inputIdx].SetSourceTxOutput(&transaction.TransactionOutput{
+		prevOutput := &transaction.TransactionOutput{
 			LockingScript: t.prevOutput.LockingScript,
 			Satoshis:      t.prevOutput.Satoshis,
-		})
+		}
+		switch input := t.tx.Inputs[t.inputIdx]; {
+		case input.SourceTransaction != nil:
+			// The source transaction provides the previous output.
+		case input.SourceTxOutput() == nil:
+			// Callers rely on the input of their transaction having its previous
+			// output set once executed.
+			input.SetSourceTxOutput(prevOutput)
+		case !sameOutput(input.SourceTxOutput(), prevOutput):
+			// Set a different previous output on a copy of the input, so that
+			// executions of the same transaction can still run concurrently.
+			tx := *t.tx
+			tx.Inputs = slices.Clone(tx.Inputs)
+			inputCopy := *input
+			inputCopy.SetSourceTxOutput(prevOutput)
+			tx.Inputs[t.inputIdx] = &inputCopy
+			t.tx = &tx
+		}
 	}
 
 	t.state = t
+	t.arena = &arena{}
 	if opts.debugger == nil {
 		opts.debugger = &nopDebugger{}
 		t.state = &nopStateHandler{}
+		t.arena = getArena()
+		t.recycleArena = true
 	}
+	t.dstack.arena = t.arena
+	t.dstack.stk = t.arena.stacks[0]
+	t.astack.arena = t.arena
+	t.astack.stk = t.arena.stacks[1]
 	t.debug = opts.debugger
 	t.dstack.debug = t.debug
 	t.dstack.sh = t.state
@@ -372,15 +454,27 @@ func (t *thread) apply(opts *execOpts) error {
 	if opts.state != nil {
 		t.SetState(opts.state)
 	}
+	// Threads seen by a debugger or sharing slices with a state may be referenced
+	// after the execution, so only the others are recycled.
+	t.pooled = t.recycleArena && opts.state == nil
 
 	return nil
 }
 
-func (t *thread) execute() error {
+func (t *thread) execute(ctx context.Context) error {
+	// Background and TODO contexts are never done, so skip checking them.
+	done := ctx.Done()
 	if err := func() error {
 		defer t.afterExecute()
 		t.beforeExecute()
 		for {
+			if done != nil {
+				select {
+				case <-done:
+					return fmt.Errorf("script execution stopped after %d opcodes: %w", t.opCount, context.Cause(ctx))
+				default:
+				}
+			}
 			t.beforeStep()
 
 			done, err := t.Step()
@@ -413,6 +507,8 @@ func (t *thread) Step() (bool, error) {
 	}
 
 	opcode := t.scripts[t.scriptIdx][t.scriptOff]
+	t.opCount++
+	t.opScriptIdx, t.opScriptOff = t.scriptIdx, t.scriptOff
 
 	t.beforeExecuteOpcode()
 	// Execute the opcode while taking into account several things such as
@@ -473,6 +569,7 @@ func (t *thread) Step() (bool, error) {
 			}
 
 			t.scripts = append(t.scripts, pops)
+			t.codeSeps = append(t.codeSeps, pops.codeSeparators())
 
 			// Set stack to be the stack from first script minus the
 			// script itself
@@ -493,6 +590,55 @@ func (t *thread) Step() (bool, error) {
 	return false, nil
 }
 
+// release hands the arena back for reuse by other executions. The stacks must
+// not be used afterwards.
+func (t *thread) release() {
+	if t.arena == nil || !t.recycleArena {
+		return
+	}
+	t.arena.release(t.dstack.stk, t.astack.stk)
+	t.arena = nil
+	t.dstack = stack{}
+	t.astack = stack{}
+	t.savedFirstStack = nil
+	if t.pooled {
+		threadPool.Put(t)
+	}
+}
+
+// discard recycles a thread whose options were rejected, along with its arena
+// if it got one. Nothing can reference a thread that never ran.
+func (t *thread) discard() {
+	if t.arena != nil {
+		t.arena.release(t.dstack.stk, t.astack.stk)
+	}
+	t.reset()
+	threadPool.Put(t)
+}
+
+// sameOutput reports whether a and b lock the same satoshis with the same script.
+func sameOutput(a, b *transaction.TransactionOutput) bool {
+	if a.Satoshis != b.Satoshis || (a.LockingScript == nil) != (b.LockingScript == nil) {
+		return false
+	}
+	return a.LockingScript == nil || a.LockingScript.Equals(b.LockingScript)
+}
+
+// reset clears the thread for a new execution, keeping the capacity of the
+// slices and maps it grew.
+func (t *thread) reset() {
+	scripts, codeSeps, condStack, pubKeys := t.scripts, t.codeSeps, t.condStack, t.pubKeys
+	clear(scripts)
+	clear(codeSeps)
+	clear(pubKeys)
+	*t = thread{
+		scripts:   scripts[:0],
+		codeSeps:  codeSeps[:0],
+		condStack: condStack[:0],
+		pubKeys:   pubKeys,
+	}
+}
+
 // GetStack returns the contents of the primary stack as an array. where the
 // last item in the array is the top of the stack.
 func (t *thread) GetStack() [][]byte {
@@ -514,6 +660,44 @@ func (t *thread) subScript() ParsedScript {
 	return t.scripts[t.scriptIdx][skip:]
 }
 
+// subScriptHasCodeSeparator returns true if the script since the last
+// OP_CODESEPARATOR contains any further OP_CODESEPARATOR.
+func (t *thread) subScriptHasCodeSeparator() bool {
+	seps := t.codeSeps[t.scriptIdx]
+	if len(seps) == 0 {
+		return false
+	}
+	skip := 0
+	if t.lastCodeSep > 0 {
+		skip = t.lastCodeSep + 1
+	}
+	return seps[len(seps)-1] >= skip
+}
+
+// signatureSubScript returns the subscript to be signed with the signature
+// sig, minus the signature itself and any OP_CODESEPARATOR when removeSig is
+// true. Subscripts that only depend on the current script and OP_CODESEPARATOR
+// are serialized once and reused by later signature checks.
+func (t *thread) signatureSubScript(sig []byte, removeSig bool) (*script.Script, error) {
+	if removeSig {
+		sub := t.subScript().removeOpcodeByData(sig)
+		if t.subScriptHasCodeSeparator() {
+			sub = sub.removeOpcode(script.OpCODESEPARATOR)
+		}
+		return t.scriptParser.Unparse(sub)
+	}
+
+	cache := &t.subScriptCache
+	if cache.script == nil || cache.scriptIdx != t.scriptIdx || cache.lastCodeSep != t.lastCodeSep {
+		up, err := t.scriptParser.Unparse(t.subScript())
+		if err != nil {
+			return nil, err
+		}
+		cache.scriptIdx, cache.lastCodeSep, cache.script = t.scriptIdx, t.lastCodeSep, up
+	}
+	return cache.script, nil
+}
+
 // checkHashTypeEncoding returns whether the passed hashtype adheres to
 // the strict encoding requirements if enabled.
 func (t *thread) checkHashTypeEncoding(shf sighash.Flag) error {
@@ -576,174 +760,7 @@ func (t *thread) checkSignatureEncoding(sig []byte) error {
 		return nil
 	}
 
-	// The format of a DER encoded signature is as follows:
-	//
-	// 0x30 <total length> 0x02 <length of R> <R> 0x02 <length of S> <S>
-	//   - 0x30 is the ASN.1 identifier for a sequence
-	//   - Total length is 1 byte and specifies length of all remaining data
-	//   - 0x02 is the ASN.1 identifier that specifies an integer follows
-	//   - Length of R is 1 byte and specifies how many bytes R occupies
-	//   - R is the arbitrary length big-endian encoded number which
-	//     represents the R value of the signature.  DER encoding dictates
-	//     that the value must be encoded using the minimum possible number
-	//     of bytes.  This implies the first byte can only be null if the
-	//     highest bit of the next byte is set in order to prevent it from
-	//     being interpreted as a negative number.
-	//   - 0x02 is once again the ASN.1 integer identifier
-	//   - Length of S is 1 byte and specifies how many bytes S occupies
-	//   - S is the arbitrary length big-endian encoded number which
-	//     represents the S value of the signature.  The encoding rules are
-	//     identical as those for R.
-	const (
-		asn1SequenceID = 0x30
-		asn1IntegerID  = 0x02
-
-		// minSigLen is the minimum length of a DER encoded signature and is
-		// when both R and S are 1 byte each.
-		//
-		// 0x30 + <1-byte> + 0x02 + 0x01 + <byte> + 0x2 + 0x01 + <byte>
-		minSigLen = 8
-
-		// maxSigLen is the maximum length of a DER encoded signature and is
-		// when both R and S are 33 bytes each.  It is 33 bytes because a
-		// 256-bit integer requires 32 bytes and an additional leading null byte
-		// might be required if the high bit is set in the value.
-		//
-		// 0x30 + <1-byte> + 0x02 + 0x21 + <33 bytes> + 0x2 + 0x21 + <33 bytes>
-		maxSigLen = 72
-
-		// sequenceOffset is the byte offset within the signature of the
-		// expected ASN.1 sequence identifier.
-		sequenceOffset = 0
-
-		// dataLenOffset is the byte offset within the signature of the expected
-		// total length of all remaining data in the signature.
-		dataLenOffset = 1
-
-		// rTypeOffset is the byte offset within the signature of the ASN.1
-		// identifier for R and is expected to indicate an ASN.1 integer.
-		rTypeOffset = 2
-
-		// rLenOffset is the byte offset within the signature of the length of
-		// R.
-		rLenOffset = 3
-
-		// rOffset is the byte offset within the signature of R.
-		rOffset = 4
-	)
-
-	// The signature must adhere to the minimum and maximum allowed length.
-	sigLen := len(sig)
-	if sigLen < minSigLen {
-		return errs.NewError(errs.ErrSigTooShort, "malformed signature: too short: %d < %d", sigLen, minSigLen)
-	}
-	if sigLen > maxSigLen {
-		return errs.NewError(errs.ErrSigTooLong, "malformed signature: too long: %d > %d", sigLen, maxSigLen)
-	}
-
-	// The signature must start with the ASN.1 sequence identifier.
-	if sig[sequenceOffset] != asn1SequenceID {
-		return errs.NewError(errs.ErrSigInvalidSeqID, "malformed signature: format has wrong type: %#x", sig[sequenceOffset])
-	}
-
-	// The signature must indicate the correct amount of data for all elements
-	// related to R and S.
-	if int(sig[dataLenOffset]) != sigLen-2 {
-		return errs.NewError(errs.ErrSigInvalidDataLen,
-			"malformed signature: bad length: %d != %d",
-			sig[dataLenOffset], sigLen-2,
-		)
-	}
-
-	// Calculate the offsets of the elements related to S and ensure S is inside
-	// the signature.
-	//
-	// rLen specifies the length of the big-endian encoded number which
-	// represents the R value of the signature.
-	//
-	// sTypeOffset is the offset of the ASN.1 identifier for S and, like its R
-	// counterpart, is expected to indicate an ASN.1 integer.
-	//
-	// sLenOffset and sOffset are the byte offsets within the signature of the
-	// length of S and S itself, respectively.
-	rLen := int(sig[rLenOffset])
-	sTypeOffset := rOffset + rLen
-	sLenOffset := sTypeOffset + 1
-	if sTypeOffset >= sigLen {
-		return errs.NewError(errs.ErrSigMissingSTypeID, "malformed signature: S type indicator missing")
-	}
-	if sLenOffset >= sigLen {
-		return errs.NewError(errs.ErrSigMissingSLen, "malformed signature: S length missing")
-	}
-
-	// The lengths of R and S must match the overall length of the signature.
-	//
-	// sLen specifies the length of the big-endian encoded number which
-	// represents the S value of the signature.
-	sOffset := sLenOffset + 1
-	sLen := int(sig[sLenOffset])
-	if sOffset+sLen != sigLen {
-		return errs.NewError(errs.ErrSigInvalidSLen, "malformed signature: invalid S length")
-	}
-
-	// R elements must be ASN.1 integers.
-	if sig[rTypeOffset] != asn1IntegerID {
-		return errs.NewError(errs.ErrSigInvalidRIntID,
-			"malformed signature: R integer marker: %#x != %#x", sig[rTypeOffset], asn1IntegerID)
-	}
-
-	// Zero-length integers are not allowed for R.
-	if rLen == 0 {
-		return errs.NewError(errs.ErrSigZeroRLen, "malformed signature: R length is zero")
-	}
-
-	// R must not be negative.
-	if sig[rOffset]&0x80 != 0 {
-		return errs.NewError(errs.ErrSigNegativeR, "malformed signature: R is negative")
-	}
-
-	// Null bytes at the start of R are not allowed, unless R would otherwise be
-	// interpreted as a negative number.
-	if rLen > 1 && sig[rOffset] == 0x00 && sig[rOffset+1]&0x80 == 0 {
-		return errs.NewError(errs.ErrSigTooMuchRPadding, "malformed signature: R value has too much padding")
-	}
-
-	// S elements must be ASN.1 integers.
-	if sig[sTypeOffset] != asn1IntegerID {
-		return errs.NewError(errs.ErrSigInvalidSIntID,
-			"malformed signature: S integer marker: %#x != %#x", sig[sTypeOffset], asn1IntegerID)
-	}
-
-	// Zero-length integers are not allowed for S.
-	if sLen == 0 {
-		return errs.NewError(errs.ErrSigZeroSLen, "malformed signature: S length is zero")
-	}
-
-	// S must not be negative.
-	if sig[sOffset]&0x80 != 0 {
-		return errs.NewError(errs.ErrSigNegativeS, "malformed signature: S is negative")
-	}
-
-	// Null bytes at the start of S are not allowed, unless S would otherwise be
-	// interpreted as a negative number.
-	if sLen > 1 && sig[sOffset] == 0x00 && sig[sOffset+1]&0x80 == 0 {
-		return errs.NewError(errs.ErrSigTooMuchSPadding, "malformed signature: S value has too much padding")
-	}
-
-	// Verify the S value is <= half the order of the curve.  This check is done
-	// because when it is higher, the complement modulo the order can be used
-	// instead which is a shorter encoding by 1 byte.  Further, without
-	// enforcing this, it is possible to replace a signature in a valid
-	// transaction with the complement while still being a valid signature that
-	// verifies.  This would result in changing the transaction hash and thus is
-	// a source of malleability.
-	if t.hasFlag(scriptflag.VerifyLowS) {
-		sValue := new(big.Int).SetBytes(sig[sOffset : sOffset+sLen])
-		if sValue.Cmp(halfOrder) > 0 {
-			return errs.NewError(errs.ErrSigHighS, "signature is not canonical due to unnecessarily high S value")
-		}
-	}
-	return nil
+	return ec.ValidateDEREncoding(sig, t.hasFlag(scriptflag.VerifyLowS))
 }
 
 // getStack returns the contents of stack as a byte array bottom up
@@ -21,6 +21,7 @@ const (
 	ScriptTypeMultiSig              = "multisig"
 	ScriptTypeNullData              = "nulldata"
 	ScriptTypePubKeyHashInscription = "pubkeyhashinscription"
+	ScriptTypeScriptHash            = "scripthash"
 )
 
 // Script type
@@ -247,6 +248,27 @@ func (s *Script) IsMultiSigOut() bool {
 	return IsSmallIntOp(parts[len(parts)-2].Op) && parts[len(parts)-1].Op == OpCHECKMULTISIG
 }
 
+// Type classifies the script as one of the ScriptType constants, returning
+// ScriptTypeNonStandard when it matches none of the standard templates.
+func (s *Script) Type() string {
+	switch {
+	case s == nil || len(*s) == 0:
+		return ScriptTypeEmpty
+	case s.IsP2PKH():
+		return ScriptTypePubKeyHash
+	case s.IsP2SH():
+		return ScriptTypeScriptHash
+	case s.IsData():
+		return ScriptTypeNullData
+	case s.IsP2PK():
+		return ScriptTypePubKey
+	case s.IsMultiSigOut():
+		return ScriptTypeMultiSig
+	default:
+		return ScriptTypeNonStandard
+	}
+}
+
 func IsSmallIntOp(opcode byte) bool {
 	return opcode == OpZERO || (opcode >= OpONE && opcode <= Op16)
 }
@@ -118,6 +118,30 @@ func TestScript_IsData(t *testing.T) {
 	require.True(t, scriptPub.IsData())
 }
 
+func TestScript_Type(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		hex    string
+		expect string
+	}{
+		"empty":       {"", script.ScriptTypeEmpty},
+		"p2pkh":       {"76a91403ececf2d12a7f614aef4c82ecf13c303bd9975d88ac", script.ScriptTypePubKeyHash},
+		"p2pk":        {"2102f0d97c290e79bf2a8660c406aa56b6f189ff79f2245cc5aff82808b58131b4d5ac", script.ScriptTypePubKey},
+		"p2sh":        {"a9149de5aeaff9c48431ba4dd6e8af73d51f38e451cb87", script.ScriptTypeScriptHash},
+		"data":        {"006a0401020304", script.ScriptTypeNullData},
+		"multisig":    {"5201110122013353ae", script.ScriptTypeMultiSig},
+		"nonstandard": {"51", script.ScriptTypeNonStandard},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			s, err := script.NewFromHex(test.hex)
+			require.NoError(t, err)
+			require.Equal(t, test.expect, s.Type())
+		})
+	}
+}
+
 func TestScript_IsMultisigOut(t *testing.T) {
 	t.Parallel()
 
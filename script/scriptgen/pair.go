@@ -0,0 +1,262 @@
+package scriptgen
+
+import (
+	"context"
+	"crypto/sha1" //nolint:gosec // OP_SHA1 is part of the script language
+	"fmt"
+	"slices"
+
+	"github.com/bsv-blockchain/go-sdk/chainhash"
+	ec "github.com/bsv-blockchain/go-sdk/primitives/ec"
+	crypto "github.com/bsv-blockchain/go-sdk/primitives/hash"
+	"github.com/bsv-blockchain/go-sdk/script"
+	"github.com/bsv-blockchain/go-sdk/script/interpreter"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+	"github.com/bsv-blockchain/go-sdk/transaction/template/p2pkh"
+)
+
+// Kind is the shape of the locking script of a Pair.
+type Kind int
+
+const (
+	// KindPushEquality locks to a sequence of data items, checked one by one
+	// with OP_EQUALVERIFY against a push-only unlocking script.
+	KindPushEquality Kind = iota
+	// KindHashLock locks to the digest of a preimage under one of the script
+	// hash opcodes.
+	KindHashLock
+	// KindP2PK locks to a public key checked with OP_CHECKSIG.
+	KindP2PK
+	// KindP2PKH is the P2PKH template.
+	KindP2PKH
+	// KindMultisig is a bare m-of-n OP_CHECKMULTISIG.
+	KindMultisig
+)
+
+// Kinds lists every Kind, in the order Pair picks from.
+var Kinds = []Kind{KindPushEquality, KindHashLock, KindP2PK, KindP2PKH, KindMultisig}
+
+func (k Kind) String() string {
+	switch k {
+	case KindPushEquality:
+		return "push equality"
+	case KindHashLock:
+		return "hash lock"
+	case KindP2PK:
+		return "p2pk"
+	case KindP2PKH:
+		return "p2pkh"
+	case KindMultisig:
+		return "multisig"
+	default:
+		return fmt.Sprintf("Kind(%d)", int(k))
+	}
+}
+
+// Pair is a locking script with an unlocking script satisfying it, in the
+// context of a transaction spending it.
+type Pair struct {
+	Kind      Kind
+	Locking   *script.Script
+	Unlocking *script.Script
+	// Tx spends an output locked by Locking at input 0, which has the source
+	// output attached and Unlocking as its unlocking script.
+	Tx *transaction.Transaction
+}
+
+// Verify executes the pair with interpreter.VerifyInput.
+func (p *Pair) Verify(ctx context.Context) error {
+	return interpreter.VerifyInput(ctx, p.Tx, 0)
+}
+
+// Pair returns a pair of a random Kind.
+func (g *Generator) Pair() (*Pair, error) {
+	return g.PairOf(Kinds[g.rnd.IntN(len(Kinds))])
+}
+
+// PairOf returns a random pair of the given kind. The unlocking script is push
+// only and uses minimal pushes, so the pair is valid under the standardness
+// rules as well as consensus.
+func (g *Generator) PairOf(kind Kind) (*Pair, error) {
+	var (
+		pair *Pair
+		err  error
+	)
+	switch kind {
+	case KindPushEquality:
+		pair, err = g.pushEquality()
+	case KindHashLock:
+		pair, err = g.hashLock()
+	case KindP2PK:
+		pair, err = g.p2pk()
+	case KindP2PKH:
+		pair, err = g.p2pkh()
+	case KindMultisig:
+		pair, err = g.multisig()
+	default:
+		return nil, fmt.Errorf("unknown pair kind %d", int(kind))
+	}
+	if err != nil {
+		return nil, fmt.Errorf("generating %s pair: %w", kind, err)
+	}
+	pair.Kind = kind
+	pair.Tx.Inputs[0].UnlockingScript = pair.Unlocking
+	return pair, nil
+}
+
+func (g *Generator) pushEquality() (*Pair, error) {
+	unlocking, stack, err := g.PushOnly(8)
+	if err != nil {
+		return nil, err
+	}
+	locking := &script.Script{}
+	for i := len(stack) - 1; i >= 0; i-- {
+		if err := AppendMinimalPush(locking, stack[i]); err != nil {
+			return nil, err
+		}
+		*locking = append(*locking, script.OpEQUALVERIFY)
+	}
+	*locking = append(*locking, script.OpTRUE)
+	return &Pair{Locking: locking, Unlocking: unlocking, Tx: g.spendingTx(locking)}, nil
+}
+
+func (g *Generator) hashLock() (*Pair, error) {
+	preimage := g.PushData()
+	hashes := []struct {
+		op     byte
+		digest func([]byte) []byte
+	}{
+		{script.OpSHA256, crypto.Sha256},
+		{script.OpHASH256, crypto.Sha256d},
+		{script.OpHASH160, crypto.Hash160},
+		{script.OpRIPEMD160, crypto.Ripemd160},
+		{script.OpSHA1, func(b []byte) []byte {
+			digest := sha1.Sum(b) //nolint:gosec // OP_SHA1 is part of the script language
+			return digest[:]
+		}},
+	}
+	h := hashes[g.rnd.IntN(len(hashes))]
+
+	locking := &script.Script{h.op}
+	if err := locking.AppendPushData(h.digest(preimage)); err != nil {
+		return nil, err
+	}
+	*locking = append(*locking, script.OpEQUAL)
+	unlocking := &script.Script{}
+	if err := AppendMinimalPush(unlocking, preimage); err != nil {
+		return nil, err
+	}
+	return &Pair{Locking: locking, Unlocking: unlocking, Tx: g.spendingTx(locking)}, nil
+}
+
+func (g *Generator) p2pk() (*Pair, error) {
+	key := g.PrivateKey()
+	locking := &script.Script{}
+	if err := locking.AppendPushData(key.PubKey().Compressed()); err != nil {
+		return nil, err
+	}
+	*locking = append(*locking, script.OpCHECKSIG)
+
+	tx := g.spendingTx(locking)
+	sig, err := g.signature(tx, key)
+	if err != nil {
+		return nil, err
+	}
+	unlocking := &script.Script{}
+	if err := unlocking.AppendPushData(sig); err != nil {
+		return nil, err
+	}
+	return &Pair{Locking: locking, Unlocking: unlocking, Tx: tx}, nil
+}
+
+func (g *Generator) p2pkh() (*Pair, error) {
+	key := g.PrivateKey()
+	address, err := script.NewAddressFromPublicKey(key.PubKey(), true)
+	if err != nil {
+		return nil, err
+	}
+	locking, err := p2pkh.Lock(address)
+	if err != nil {
+		return nil, err
+	}
+
+	tx := g.spendingTx(locking)
+	flag := g.SighashFlag()
+	unlocker, err := p2pkh.Unlock(key, &flag)
+	if err != nil {
+		return nil, err
+	}
+	unlocking, err := unlocker.Sign(tx, 0)
+	if err != nil {
+		return nil, err
+	}
+	return &Pair{Locking: locking, Unlocking: unlocking, Tx: tx}, nil
+}
+
+func (g *Generator) multisig() (*Pair, error) {
+	n := 1 + g.rnd.IntN(5)
+	m := 1 + g.rnd.IntN(n)
+	keys := make([]*ec.PrivateKey, n)
+	locking := &script.Script{script.Op1 + byte(m) - 1}
+	for i := range keys {
+		keys[i] = g.PrivateKey()
+		if err := locking.AppendPushData(keys[i].PubKey().Compressed()); err != nil {
+			return nil, err
+		}
+	}
+	*locking = append(*locking, script.Op1+byte(n)-1, script.OpCHECKMULTISIG)
+
+	tx := g.spendingTx(locking)
+	// Signatures must be in the order of their keys; pick m of the n keys.
+	signers := g.rnd.Perm(n)[:m]
+	slices.Sort(signers)
+	unlocking := &script.Script{script.Op0}
+	for _, i := range signers {
+		sig, err := g.signature(tx, keys[i])
+		if err != nil {
+			return nil, err
+		}
+		if err := unlocking.AppendPushData(sig); err != nil {
+			return nil, err
+		}
+	}
+	return &Pair{Locking: locking, Unlocking: unlocking, Tx: tx}, nil
+}
+
+// signature signs input 0 of tx with key under a random sighash flag, returning
+// the signature as pushed by an unlocking script.
+func (g *Generator) signature(tx *transaction.Transaction, key *ec.PrivateKey) ([]byte, error) {
+	flag := g.SighashFlag()
+	sh, err := tx.CalcInputSignatureHash(0, flag)
+	if err != nil {
+		return nil, err
+	}
+	sig, err := key.Sign(sh)
+	if err != nil {
+		return nil, err
+	}
+	return append(sig.Serialize(), byte(flag)), nil
+}
+
+// spendingTx returns a transaction spending an output locked by locking at
+// input 0, paying to random data outputs.
+func (g *Generator) spendingTx(locking *script.Script) *transaction.Transaction {
+	var sourceTxid chainhash.Hash
+	copy(sourceTxid[:], g.Bytes(chainhash.HashSize, chainhash.HashSize))
+
+	tx := transaction.NewTransaction()
+	tx.AddInputWithOutput(&transaction.TransactionInput{
+		SourceTXID:       &sourceTxid,
+		SourceTxOutIndex: uint32(g.rnd.IntN(4)),
+		SequenceNumber:   transaction.DefaultSequenceNumber,
+	}, &transaction.TransactionOutput{
+		Satoshis:      1 + g.rnd.Uint64N(100_000_000),
+		LockingScript: locking,
+	})
+	for range 1 + g.rnd.IntN(3) {
+		output := &script.Script{script.OpFALSE, script.OpRETURN}
+		_ = output.AppendPushData(g.Bytes(0, 40))
+		tx.AddOutput(&transaction.TransactionOutput{Satoshis: g.rnd.Uint64N(1000), LockingScript: output})
+	}
+	return tx
+}
@@ -0,0 +1,81 @@
+package scriptgen_test
+
+import (
+	"testing"
+
+	"github.com/bsv-blockchain/go-sdk/script"
+	"github.com/bsv-blockchain/go-sdk/script/interpreter"
+	"github.com/bsv-blockchain/go-sdk/script/interpreter/scriptflag"
+	"github.com/bsv-blockchain/go-sdk/script/scriptgen"
+	"github.com/stretchr/testify/require"
+)
+
+// strictFlags are the standardness rules generated pairs must satisfy on top
+// of consensus.
+const strictFlags = scriptflag.VerifyMinimalData | scriptflag.VerifySigPushOnly | scriptflag.VerifyCleanStack | scriptflag.Bip16 |
+	scriptflag.VerifyStrictEncoding | scriptflag.VerifyDERSignatures | scriptflag.VerifyLowS |
+	scriptflag.VerifyNullFail | scriptflag.StrictMultiSig
+
+func TestPairsVerify(t *testing.T) {
+	ctx := t.Context()
+	engine := interpreter.NewEngine()
+	for seed := range uint64(20) {
+		g := scriptgen.New(seed)
+		for range 50 {
+			pair, err := g.Pair()
+			require.NoError(t, err)
+			require.NoError(t, pair.Verify(ctx), "seed %d: %s", seed, pair.Kind)
+			err = engine.ExecuteContext(ctx,
+				interpreter.WithTx(pair.Tx, 0, pair.Tx.Inputs[0].SourceTxOutput()),
+				interpreter.WithForkID(),
+				interpreter.WithAfterGenesis(),
+				interpreter.WithFlags(strictFlags),
+			)
+			require.NoError(t, err, "seed %d: %s", seed, pair.Kind)
+		}
+	}
+}
+
+func TestPairsOfEveryKind(t *testing.T) {
+	g := scriptgen.New(1)
+	for _, kind := range scriptgen.Kinds {
+		pair, err := g.PairOf(kind)
+		require.NoError(t, err)
+		require.Equal(t, kind, pair.Kind)
+		require.NoError(t, pair.Verify(t.Context()), kind.String())
+
+		// Changing the unlocking script breaks the pair.
+		pair.Tx.Inputs[0].UnlockingScript = &script.Script{script.Op0}
+		require.Error(t, pair.Verify(t.Context()), kind.String())
+	}
+
+	_, err := g.PairOf(scriptgen.Kind(-1))
+	require.Error(t, err)
+}
+
+func TestDeterministic(t *testing.T) {
+	a, b := scriptgen.New(42), scriptgen.New(42)
+	for range 20 {
+		pairA, err := a.Pair()
+		require.NoError(t, err)
+		pairB, err := b.Pair()
+		require.NoError(t, err)
+		require.Equal(t, pairA.Tx.Bytes(), pairB.Tx.Bytes())
+		require.Equal(t, pairA.Locking, pairB.Locking)
+	}
+}
+
+func TestPushOnly(t *testing.T) {
+	g := scriptgen.New(7)
+	for range 100 {
+		unlocking, stack, err := g.PushOnly(10)
+		require.NoError(t, err)
+		chunks, err := unlocking.Chunks()
+		require.NoError(t, err)
+		require.Len(t, chunks, len(stack))
+		for _, chunk := range chunks {
+			require.LessOrEqual(t, chunk.Op, script.Op16)
+			require.NotEqual(t, script.OpRESERVED, chunk.Op)
+		}
+	}
+}
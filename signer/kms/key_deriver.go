@@ -0,0 +1,185 @@
+package kms
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	ec "github.com/bsv-blockchain/go-sdk/primitives/ec"
+	"github.com/bsv-blockchain/go-sdk/wallet"
+)
+
+// requestTimeout bounds each KMS call made by KeyDeriver, whose methods take no context.
+const requestTimeout = 30 * time.Second
+
+// KeyDeriver implements wallet.KeyDeriverInterface with a KMS held root key, so a
+// ProtoWallet can encrypt, decrypt, compute HMACs and derive public keys without the
+// root key leaving the KMS. Every derivation costs one ECDH call to the KMS.
+//
+// Deriving private keys is not possible, so DerivePrivateKey, and with it creating
+// signatures and revealing counterparty key linkage, fails with ErrPrivateKeyUnavailable.
+type KeyDeriver struct {
+	client      KeyAgreementClient
+	keyID       string
+	identityKey *ec.PublicKey
+}
+
+var _ wallet.KeyDeriverInterface = (*KeyDeriver)(nil)
+
+// NewKeyDeriver creates a KeyDeriver for the given KMS key, fetching its public key.
+func NewKeyDeriver(ctx context.Context, client KeyAgreementClient, keyID string) (*KeyDeriver, error) {
+	signer, err := NewSigner(ctx, client, keyID)
+	if err != nil {
+		return nil, err
+	}
+	return &KeyDeriver{client: client, keyID: keyID, identityKey: signer.PublicKey()}, nil
+}
+
+// IdentityKey returns the public key of the KMS key.
+func (kd *KeyDeriver) IdentityKey() *ec.PublicKey {
+	return kd.identityKey
+}
+
+// DerivePrivateKey always fails with ErrPrivateKeyUnavailable.
+func (kd *KeyDeriver) DerivePrivateKey(wallet.Protocol, string, wallet.Counterparty) (*ec.PrivateKey, error) {
+	return nil, ErrPrivateKeyUnavailable
+}
+
+// DerivePublicKey derives a BRC-42 child public key, of the root key if forSelf is set
+// and of the counterparty otherwise.
+func (kd *KeyDeriver) DerivePublicKey(protocol wallet.Protocol, keyID string, counterparty wallet.Counterparty, forSelf bool) (*ec.PublicKey, error) {
+	counterpartyKey, h, err := kd.derive(protocol, keyID, counterparty)
+	if err != nil {
+		return nil, err
+	}
+	if forSelf {
+		return addScalarBase(kd.identityKey, h), nil
+	}
+	return addScalarBase(counterpartyKey, h), nil
+}
+
+// DeriveSymmetricKey derives the symmetric key shared with the counterparty. It is the
+// x coordinate of (d+h)·(C+h·G), with d the root key, C the counterparty key and h the
+// BRC-42 offset, which expands to S + h·(C+D+h·G) with S = d·C the shared secret and
+// D = d·G the identity key, so it only needs the KMS for S.
+func (kd *KeyDeriver) DeriveSymmetricKey(protocol wallet.Protocol, keyID string, counterparty wallet.Counterparty) (*ec.SymmetricKey, error) {
+	if counterparty.Type == wallet.CounterpartyTypeAnyone {
+		_, anyonePubKey := wallet.AnyoneKey()
+		counterparty = wallet.Counterparty{Type: wallet.CounterpartyTypeOther, Counterparty: anyonePubKey}
+	}
+
+	counterpartyKey, sharedSecret, err := kd.sharedSecret(counterparty)
+	if err != nil {
+		return nil, err
+	}
+	invoiceNumber, err := wallet.ComputeInvoiceNumber(protocol, keyID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compute invoice number: %w", err)
+	}
+	h := offset(sharedSecret, invoiceNumber)
+
+	curve := ec.S256()
+	x, y := curve.Add(counterpartyKey.X, counterpartyKey.Y, kd.identityKey.X, kd.identityKey.Y)
+	hx, hy := curve.ScalarBaseMult(h.Bytes())
+	x, y = curve.Add(x, y, hx, hy)
+	x, y = curve.ScalarMult(x, y, h.Bytes())
+	x, _ = curve.Add(x, y, sharedSecret.X, sharedSecret.Y)
+	return ec.NewSymmetricKey(x.Bytes()), nil
+}
+
+// RevealSpecificSecret returns the HMAC of the invoice number keyed with the shared
+// secret with the counterparty, as wallet.KeyDeriver does.
+func (kd *KeyDeriver) RevealSpecificSecret(counterparty wallet.Counterparty, protocol wallet.Protocol, keyID string) ([]byte, error) {
+	_, sharedSecret, err := kd.sharedSecret(counterparty)
+	if err != nil {
+		return nil, err
+	}
+	invoiceNumber, err := wallet.ComputeInvoiceNumber(protocol, keyID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compute invoice number: %w", err)
+	}
+	mac := hmac.New(sha256.New, sharedSecret.Compressed())
+	mac.Write([]byte(invoiceNumber))
+	return mac.Sum(nil), nil
+}
+
+// RevealCounterpartySecret returns the shared secret with the counterparty, which
+// must not be the root key itself.
+func (kd *KeyDeriver) RevealCounterpartySecret(counterparty wallet.Counterparty) (*ec.PublicKey, error) {
+	if counterparty.Type == wallet.CounterpartyTypeSelf {
+		return nil, errors.New("counterparty secrets cannot be revealed for counterparty=self")
+	}
+	counterpartyKey, sharedSecret, err := kd.sharedSecret(counterparty)
+	if err != nil {
+		return nil, err
+	}
+	if bytes.Equal(counterpartyKey.Compressed(), kd.identityKey.Compressed()) {
+		return nil, errors.New("counterparty secrets cannot be revealed if counterparty key is self")
+	}
+	return sharedSecret, nil
+}
+
+// derive returns the counterparty key and the BRC-42 offset for the invoice number.
+func (kd *KeyDeriver) derive(protocol wallet.Protocol, keyID string, counterparty wallet.Counterparty) (*ec.PublicKey, *big.Int, error) {
+	invoiceNumber, err := wallet.ComputeInvoiceNumber(protocol, keyID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to compute invoice number: %w", err)
+	}
+	counterpartyKey, sharedSecret, err := kd.sharedSecret(counterparty)
+	if err != nil {
+		return nil, nil, err
+	}
+	return counterpartyKey, offset(sharedSecret, invoiceNumber), nil
+}
+
+// sharedSecret resolves the counterparty and computes the shared secret with it in the KMS.
+func (kd *KeyDeriver) sharedSecret(counterparty wallet.Counterparty) (*ec.PublicKey, *ec.PublicKey, error) {
+	var counterpartyKey *ec.PublicKey
+	switch counterparty.Type {
+	case wallet.CounterpartyTypeSelf:
+		counterpartyKey = kd.identityKey
+	case wallet.CounterpartyTypeOther:
+		if counterparty.Counterparty == nil {
+			return nil, nil, errors.New("counterparty public key required for other")
+		}
+		counterpartyKey = counterparty.Counterparty
+	case wallet.CounterpartyTypeAnyone:
+		_, counterpartyKey = wallet.AnyoneKey()
+	default:
+		return nil, nil, errors.New("invalid counterparty, must be self, other, or anyone")
+	}
+	if !counterpartyKey.Validate() {
+		return nil, nil, errors.New("public key is not on the curve")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	defer cancel()
+	sharedSecret, err := kd.client.DeriveSharedSecret(ctx, kd.keyID, counterpartyKey)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to derive shared secret: %w", err)
+	}
+	if sharedSecret == nil || !sharedSecret.Validate() {
+		return nil, nil, errors.New("KMS returned an invalid shared secret")
+	}
+	return counterpartyKey, sharedSecret, nil
+}
+
+// offset returns the BRC-42 offset HMAC(sharedSecret, invoiceNumber) as a scalar.
+func offset(sharedSecret *ec.PublicKey, invoiceNumber string) *big.Int {
+	mac := hmac.New(sha256.New, sharedSecret.Compressed())
+	mac.Write([]byte(invoiceNumber))
+	return new(big.Int).SetBytes(mac.Sum(nil))
+}
+
+// addScalarBase returns p + h·G.
+func addScalarBase(p *ec.PublicKey, h *big.Int) *ec.PublicKey {
+	curve := ec.S256()
+	x, y := curve.ScalarBaseMult(h.Bytes())
+	x, y = curve.Add(x, y, p.X, p.Y)
+	return &ec.PublicKey{Curve: curve, X: x, Y: y}
+}
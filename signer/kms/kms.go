@@ -0,0 +1,132 @@
+// Package kms signs and derives keys with a secp256k1 root key held in a cloud KMS
+// or HSM, so that the root key never leaves the device.
+//
+// The package talks to the device through the small Client and KeyAgreementClient
+// interfaces rather than a vendor SDK. Adapting AWS KMS (GetPublicKey, and Sign with
+// the DIGEST message type) or Google Cloud KMS (GetPublicKey and AsymmetricSign) to
+// Client takes a few lines.
+package kms
+
+import (
+	"context"
+	"encoding/asn1"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+
+	ec "github.com/bsv-blockchain/go-sdk/primitives/ec"
+)
+
+var (
+	// ErrPrivateKeyUnavailable is returned for operations that need a derived private
+	// key, which cannot be computed without exporting the root key.
+	ErrPrivateKeyUnavailable = errors.New("private keys cannot be derived from a KMS held root key")
+	// ErrUnsupportedKey is returned when the KMS key is not a secp256k1 key.
+	ErrUnsupportedKey = errors.New("KMS key is not a secp256k1 public key")
+)
+
+// Client is the part of a KMS API needed to sign with a key.
+type Client interface {
+	// GetPublicKey returns the public key of the KMS key as a DER or PEM encoded
+	// SubjectPublicKeyInfo.
+	GetPublicKey(ctx context.Context, keyID string) ([]byte, error)
+	// SignDigest signs a 32 byte SHA-256 digest with the KMS key and returns the
+	// DER encoded ECDSA signature.
+	SignDigest(ctx context.Context, keyID string, digest []byte) ([]byte, error)
+}
+
+// KeyAgreementClient is a Client that can also perform ECDH with the KMS key,
+// which BRC-42 key derivation requires.
+type KeyAgreementClient interface {
+	Client
+	// DeriveSharedSecret returns the shared secret point between the KMS key and
+	// the given public key. The full point is needed: the BRC-42 HMAC covers its
+	// compressed encoding, so APIs returning only the x coordinate, such as the
+	// AWS KMS DeriveSharedSecret operation, are not sufficient.
+	DeriveSharedSecret(ctx context.Context, keyID string, publicKey *ec.PublicKey) (*ec.PublicKey, error)
+}
+
+// Signer produces ECDSA signatures with a KMS held key.
+type Signer struct {
+	client    Client
+	keyID     string
+	publicKey *ec.PublicKey
+}
+
+// NewSigner creates a Signer for the given KMS key, fetching its public key.
+func NewSigner(ctx context.Context, client Client, keyID string) (*Signer, error) {
+	der, err := client.GetPublicKey(ctx, keyID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get KMS public key: %w", err)
+	}
+	publicKey, err := ParsePublicKey(der)
+	if err != nil {
+		return nil, err
+	}
+	return &Signer{client: client, keyID: keyID, publicKey: publicKey}, nil
+}
+
+// PublicKey returns the public key of the KMS key.
+func (s *Signer) PublicKey() *ec.PublicKey {
+	return s.publicKey
+}
+
+// Sign signs a 32 byte digest. The signature is normalised to low S, as KMS
+// services do not guarantee it, and checked against the public key.
+func (s *Signer) Sign(ctx context.Context, digest []byte) (*ec.Signature, error) {
+	if len(digest) != 32 {
+		return nil, fmt.Errorf("digest must be 32 bytes, got %d", len(digest))
+	}
+	der, err := s.client.SignDigest(ctx, s.keyID, digest)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign with KMS key: %w", err)
+	}
+	sig, err := ec.ParseSignature(der)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse KMS signature: %w", err)
+	}
+	if n := ec.S256().N; sig.S.Cmp(halfOrder) > 0 {
+		sig.S.Sub(n, sig.S)
+	}
+	if !sig.Verify(digest, s.publicKey) {
+		return nil, errors.New("KMS signature does not verify against the KMS public key")
+	}
+	return sig, nil
+}
+
+var halfOrder = new(big.Int).Rsh(ec.S256().N, 1)
+
+var (
+	oidPublicKeyECDSA = asn1.ObjectIdentifier{1, 2, 840, 10045, 2, 1}
+	oidSecp256k1      = asn1.ObjectIdentifier{1, 3, 132, 0, 10}
+)
+
+type subjectPublicKeyInfo struct {
+	Algorithm struct {
+		Algorithm  asn1.ObjectIdentifier
+		Parameters asn1.ObjectIdentifier
+	}
+	PublicKey asn1.BitString
+}
+
+// ParsePublicKey parses a DER or PEM encoded SubjectPublicKeyInfo holding a
+// secp256k1 public key, the format returned by KMS GetPublicKey APIs. The standard
+// library cannot parse it since it does not support secp256k1.
+func ParsePublicKey(b []byte) (*ec.PublicKey, error) {
+	if block, _ := pem.Decode(b); block != nil {
+		b = block.Bytes
+	}
+	var spki subjectPublicKeyInfo
+	rest, err := asn1.Unmarshal(b, &spki)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse KMS public key: %w", err)
+	}
+	if len(rest) != 0 {
+		return nil, errors.New("failed to parse KMS public key: trailing data")
+	}
+	if !spki.Algorithm.Algorithm.Equal(oidPublicKeyECDSA) || !spki.Algorithm.Parameters.Equal(oidSecp256k1) {
+		return nil, ErrUnsupportedKey
+	}
+	return ec.ParsePubKey(spki.PublicKey.RightAlign())
+}
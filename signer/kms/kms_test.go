@@ -0,0 +1,197 @@
+package kms
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/asn1"
+	"encoding/pem"
+	"math/big"
+	"testing"
+
+	ec "github.com/bsv-blockchain/go-sdk/primitives/ec"
+	"github.com/bsv-blockchain/go-sdk/wallet"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeKMS is an in-memory KeyAgreementClient.
+type fakeKMS struct {
+	keys   map[string]*ec.PrivateKey
+	highS  bool
+	usePEM bool
+}
+
+func newFakeKMS(t *testing.T) (*fakeKMS, *ec.PrivateKey) {
+	key, err := ec.NewPrivateKey()
+	require.NoError(t, err)
+	return &fakeKMS{keys: map[string]*ec.PrivateKey{"root": key}}, key
+}
+
+func marshalSPKI(pub *ec.PublicKey, curve asn1.ObjectIdentifier) ([]byte, error) {
+	var spki subjectPublicKeyInfo
+	spki.Algorithm.Algorithm = oidPublicKeyECDSA
+	spki.Algorithm.Parameters = curve
+	spki.PublicKey = asn1.BitString{Bytes: pub.Uncompressed(), BitLength: 8 * 65}
+	return asn1.Marshal(spki)
+}
+
+func (f *fakeKMS) GetPublicKey(_ context.Context, keyID string) ([]byte, error) {
+	der, err := marshalSPKI(f.keys[keyID].PubKey(), oidSecp256k1)
+	if err != nil || !f.usePEM {
+		return der, err
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}), nil
+}
+
+func (f *fakeKMS) SignDigest(_ context.Context, keyID string, digest []byte) ([]byte, error) {
+	sig, err := f.keys[keyID].Sign(digest)
+	if err != nil {
+		return nil, err
+	}
+	if f.highS {
+		sig.S.Sub(ec.S256().N, sig.S)
+	}
+	return sig.ToDER()
+}
+
+func (f *fakeKMS) DeriveSharedSecret(_ context.Context, keyID string, publicKey *ec.PublicKey) (*ec.PublicKey, error) {
+	return f.keys[keyID].DeriveSharedSecret(publicKey)
+}
+
+func TestSigner(t *testing.T) {
+	ctx := t.Context()
+	client, key := newFakeKMS(t)
+	digest := sha256.Sum256([]byte("message"))
+
+	for _, tc := range []struct {
+		name   string
+		highS  bool
+		usePEM bool
+	}{
+		{name: "DER public key, low S"},
+		{name: "PEM public key, high S", highS: true, usePEM: true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			client.highS, client.usePEM = tc.highS, tc.usePEM
+			signer, err := NewSigner(ctx, client, "root")
+			require.NoError(t, err)
+			require.True(t, key.PubKey().IsEqual(signer.PublicKey()))
+
+			sig, err := signer.Sign(ctx, digest[:])
+			require.NoError(t, err)
+			require.True(t, sig.Verify(digest[:], key.PubKey()))
+			require.LessOrEqual(t, sig.S.Cmp(halfOrder), 0, "signature is low S")
+		})
+	}
+
+	signer, err := NewSigner(ctx, client, "root")
+	require.NoError(t, err)
+	_, err = signer.Sign(ctx, digest[:31])
+	require.Error(t, err)
+}
+
+func TestParsePublicKeyRejectsOtherCurves(t *testing.T) {
+	key, err := ec.NewPrivateKey()
+	require.NoError(t, err)
+
+	der, err := marshalSPKI(key.PubKey(), oidSecp256k1)
+	require.NoError(t, err)
+	pub, err := ParsePublicKey(der)
+	require.NoError(t, err)
+	require.True(t, key.PubKey().IsEqual(pub))
+
+	der, err = marshalSPKI(key.PubKey(), asn1.ObjectIdentifier{1, 2, 840, 10045, 3, 1, 7})
+	require.NoError(t, err)
+	_, err = ParsePublicKey(der)
+	require.ErrorIs(t, err, ErrUnsupportedKey)
+
+	_, err = ParsePublicKey([]byte("not a key"))
+	require.Error(t, err)
+}
+
+func TestKeyDeriverMatchesWallet(t *testing.T) {
+	client, key := newFakeKMS(t)
+	kd, err := NewKeyDeriver(t.Context(), client, "root")
+	require.NoError(t, err)
+	local := wallet.NewKeyDeriver(key)
+
+	other, err := ec.NewPrivateKey()
+	require.NoError(t, err)
+	protocol := wallet.Protocol{SecurityLevel: wallet.SecurityLevelEveryAppAndCounterparty, Protocol: "kms key deriver"}
+
+	require.True(t, local.IdentityKey().IsEqual(kd.IdentityKey()))
+
+	for _, counterparty := range []wallet.Counterparty{
+		{Type: wallet.CounterpartyTypeSelf},
+		{Type: wallet.CounterpartyTypeAnyone},
+		{Type: wallet.CounterpartyTypeOther, Counterparty: other.PubKey()},
+	} {
+		for _, forSelf := range []bool{false, true} {
+			want, err := local.DerivePublicKey(protocol, "1", counterparty, forSelf)
+			require.NoError(t, err)
+			got, err := kd.DerivePublicKey(protocol, "1", counterparty, forSelf)
+			require.NoError(t, err)
+			require.True(t, want.IsEqual(got), "counterparty %v forSelf %v", counterparty.Type, forSelf)
+		}
+
+		wantSym, err := local.DeriveSymmetricKey(protocol, "1", counterparty)
+		require.NoError(t, err)
+		gotSym, err := kd.DeriveSymmetricKey(protocol, "1", counterparty)
+		require.NoError(t, err)
+		require.Equal(t, wantSym.ToBytes(), gotSym.ToBytes(), "counterparty %v", counterparty.Type)
+
+		wantSecret, err := local.RevealSpecificSecret(counterparty, protocol, "1")
+		require.NoError(t, err)
+		gotSecret, err := kd.RevealSpecificSecret(counterparty, protocol, "1")
+		require.NoError(t, err)
+		require.Equal(t, wantSecret, gotSecret)
+	}
+
+	counterparty := wallet.Counterparty{Type: wallet.CounterpartyTypeOther, Counterparty: other.PubKey()}
+	want, err := local.RevealCounterpartySecret(counterparty)
+	require.NoError(t, err)
+	got, err := kd.RevealCounterpartySecret(counterparty)
+	require.NoError(t, err)
+	require.True(t, want.IsEqual(got))
+
+	_, err = kd.RevealCounterpartySecret(wallet.Counterparty{Type: wallet.CounterpartyTypeSelf})
+	require.Error(t, err)
+	_, err = kd.RevealCounterpartySecret(wallet.Counterparty{Type: wallet.CounterpartyTypeOther, Counterparty: key.PubKey()})
+	require.Error(t, err)
+
+	_, err = kd.DerivePrivateKey(protocol, "1", counterparty)
+	require.ErrorIs(t, err, ErrPrivateKeyUnavailable)
+
+	bad := wallet.Counterparty{Type: wallet.CounterpartyTypeOther, Counterparty: &ec.PublicKey{Curve: ec.S256(), X: big.NewInt(1), Y: big.NewInt(1)}}
+	_, err = kd.DerivePublicKey(protocol, "1", bad, false)
+	require.Error(t, err)
+}
+
+func TestProtoWalletWithKMSKeyDeriver(t *testing.T) {
+	ctx := t.Context()
+	client, key := newFakeKMS(t)
+	kd, err := NewKeyDeriver(ctx, client, "root")
+	require.NoError(t, err)
+	kmsWallet, err := wallet.NewProtoWallet(wallet.ProtoWalletArgs{Type: wallet.ProtoWalletArgsTypeKeyDeriver, KeyDeriver: kd})
+	require.NoError(t, err)
+
+	other, err := ec.NewPrivateKey()
+	require.NoError(t, err)
+	otherWallet, err := wallet.NewProtoWallet(wallet.ProtoWalletArgs{Type: wallet.ProtoWalletArgsTypePrivateKey, PrivateKey: other})
+	require.NoError(t, err)
+
+	args := wallet.EncryptionArgs{
+		ProtocolID:   wallet.Protocol{SecurityLevel: wallet.SecurityLevelEveryAppAndCounterparty, Protocol: "kms key deriver"},
+		KeyID:        "1",
+		Counterparty: wallet.Counterparty{Type: wallet.CounterpartyTypeOther, Counterparty: other.PubKey()},
+	}
+	encrypted, err := kmsWallet.Encrypt(ctx, wallet.EncryptArgs{EncryptionArgs: args, Plaintext: []byte("hello")}, "")
+	require.NoError(t, err)
+
+	args.Counterparty.Counterparty = key.PubKey()
+	decrypted, err := otherWallet.Decrypt(ctx, wallet.DecryptArgs{EncryptionArgs: args, Ciphertext: encrypted.Ciphertext}, "")
+	require.NoError(t, err)
+	require.Equal(t, []byte("hello"), []byte(decrypted.Plaintext))
+
+	_, err = kmsWallet.CreateSignature(ctx, wallet.CreateSignatureArgs{EncryptionArgs: args, Data: []byte("data")}, "")
+	require.ErrorIs(t, err, ErrPrivateKeyUnavailable)
+}
@@ -0,0 +1,154 @@
+package spv
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/bsv-blockchain/go-sdk/script/interpreter"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+	"github.com/bsv-blockchain/go-sdk/transaction/chaintracker"
+)
+
+// Level selects how thoroughly VerifyBeef checks a BEEF, trading speed for security.
+type Level int
+
+const (
+	// LevelStructure checks that every transaction is either proven by a BUMP or
+	// spends only transactions of the BEEF, and that the BUMPs are consistent.
+	LevelStructure Level = iota
+	// LevelScripts also runs the scripts of every transaction not proven by a BUMP.
+	LevelScripts
+	// LevelScriptsAndProofs also checks the merkle roots of the BUMPs with the
+	// chain tracker.
+	LevelScriptsAndProofs
+)
+
+func (l Level) String() string {
+	switch l {
+	case LevelStructure:
+		return "structure"
+	case LevelScripts:
+		return "scripts"
+	case LevelScriptsAndProofs:
+		return "scripts+proofs"
+	default:
+		return fmt.Sprintf("Level(%d)", int(l))
+	}
+}
+
+// BeefReport describes the outcome of VerifyBeef. Txids are sorted.
+type BeefReport struct {
+	Level Level
+	// Valid is true when no problem was found at the requested level.
+	Valid bool
+	// ProvenByBump lists the transactions with a merkle path in the BEEF,
+	// including those given only by txid.
+	ProvenByBump []string
+	// ProvenByParents lists the transactions whose validity rests on their
+	// ancestors in the BEEF being proven.
+	ProvenByParents []string
+	// TxidOnly lists the transactions given only by txid. Unless a BUMP proves
+	// them, the transactions spending them are not valid.
+	TxidOnly []string
+	// Problems explains why the BEEF is not valid.
+	Problems []string
+}
+
+func (r *BeefReport) addProblem(format string, args ...any) {
+	r.Problems = append(r.Problems, fmt.Sprintf(format, args...))
+}
+
+// VerifyBeef verifies the transactions of a BEEF at the given level and reports
+// how each was proven. Problems with the BEEF are reported rather than returned;
+// the error is only set when the verification itself fails, for example when the
+// chain tracker cannot be reached.
+//
+// It lives in this package rather than on transaction.Beef because running
+// scripts needs the interpreter, which depends on the transaction package.
+func VerifyBeef(ctx context.Context, beef *transaction.Beef, chainTracker chaintracker.ChainTracker, level Level) (*BeefReport, error) {
+	if beef == nil {
+		return nil, errors.New("beef is nil")
+	}
+	if level >= LevelScriptsAndProofs && chainTracker == nil {
+		return nil, fmt.Errorf("a chain tracker is required to verify at level %s", level)
+	}
+	report := &BeefReport{Level: level}
+
+	vr := beef.ValidateTransactions()
+	report.TxidOnly = vr.TxidOnly
+	for _, txid := range vr.Valid {
+		if beef.FindBump(txid) != nil {
+			report.ProvenByBump = append(report.ProvenByBump, txid)
+		} else {
+			report.ProvenByParents = append(report.ProvenByParents, txid)
+		}
+	}
+	for _, txid := range vr.NotValid {
+		report.addProblem("transaction %s is not proven by a BUMP or its inputs", txid)
+	}
+	for _, txid := range vr.WithMissingInputs {
+		report.addProblem("transaction %s spends outputs missing from the BEEF", txid)
+	}
+	if !beef.IsValid(true) && len(report.Problems) == 0 {
+		report.addProblem("BUMPs are inconsistent with each other or with the transactions")
+	}
+
+	if level >= LevelScripts {
+		for _, txid := range report.ProvenByParents {
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
+			verifyScripts(ctx, beef, txid, report)
+		}
+	}
+
+	if level >= LevelScriptsAndProofs {
+		for _, bump := range beef.BUMPs {
+			root, err := bump.ComputeRoot(nil)
+			if err != nil {
+				report.addProblem("BUMP for block %d: %v", bump.BlockHeight, err)
+				continue
+			}
+			ok, err := chainTracker.IsValidRootForHeight(ctx, root, bump.BlockHeight)
+			if err != nil {
+				return nil, fmt.Errorf("failed to check merkle root for block %d: %w", bump.BlockHeight, err)
+			}
+			if !ok {
+				report.addProblem("merkle root %s is not valid for block %d", root, bump.BlockHeight)
+			}
+		}
+	}
+
+	for _, txids := range [][]string{report.ProvenByBump, report.ProvenByParents, report.TxidOnly} {
+		slices.Sort(txids)
+	}
+	report.Valid = len(report.Problems) == 0
+	return report, nil
+}
+
+// verifyScripts runs the unlocking scripts of a transaction against the outputs
+// they spend, reporting failures.
+func verifyScripts(ctx context.Context, beef *transaction.Beef, txid string, report *BeefReport) {
+	tx := beef.FindTransaction(txid)
+	if tx == nil {
+		report.addProblem("transaction %s is not in the BEEF", txid)
+		return
+	}
+	for vin, input := range tx.Inputs {
+		outpoint := input.SourceOutpoint()
+		sourceOutput := beef.FindOutput(&outpoint)
+		if sourceOutput == nil {
+			report.addProblem("input %d of transaction %s spends %s, which is not in the BEEF", vin, txid, outpoint)
+			continue
+		}
+		if err := interpreter.NewEngine().ExecuteContext(ctx,
+			interpreter.WithTx(tx, vin, sourceOutput),
+			interpreter.WithForkID(),
+			interpreter.WithAfterGenesis(),
+		); err != nil {
+			report.addProblem("input %d of transaction %s: %v", vin, txid, err)
+		}
+	}
+}
@@ -0,0 +1,80 @@
+package spv
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/bsv-blockchain/go-sdk/chainhash"
+	"github.com/bsv-blockchain/go-sdk/script"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+	"github.com/stretchr/testify/require"
+)
+
+type rootTracker struct {
+	valid bool
+	err   error
+}
+
+func (r rootTracker) IsValidRootForHeight(context.Context, *chainhash.Hash, uint32) (bool, error) {
+	return r.valid, r.err
+}
+
+func (r rootTracker) CurrentHeight(context.Context) (uint32, error) {
+	return 800000, nil
+}
+
+func TestVerifyBeef(t *testing.T) {
+	ctx := t.Context()
+	beef, err := transaction.NewBeefFromHex(BRC62Hex)
+	require.NoError(t, err)
+	subject, err := transaction.NewTransactionFromBEEFHex(BRC62Hex)
+	require.NoError(t, err)
+	child := subject.TxID().String()
+	parent := subject.Inputs[0].SourceTXID.String()
+
+	for _, level := range []Level{LevelStructure, LevelScripts, LevelScriptsAndProofs} {
+		report, err := VerifyBeef(ctx, beef, &GullibleHeadersClient{}, level)
+		require.NoError(t, err)
+		require.Equal(t, &BeefReport{
+			Level:           level,
+			Valid:           true,
+			ProvenByBump:    []string{parent},
+			ProvenByParents: []string{child},
+			TxidOnly:        []string{},
+		}, report, level.String())
+	}
+
+	report, err := VerifyBeef(ctx, beef, rootTracker{valid: false}, LevelScriptsAndProofs)
+	require.NoError(t, err)
+	require.False(t, report.Valid)
+	require.Len(t, report.Problems, 1)
+	require.Contains(t, report.Problems[0], "is not valid for block")
+
+	_, err = VerifyBeef(ctx, beef, rootTracker{err: errors.New("unreachable")}, LevelScriptsAndProofs)
+	require.ErrorContains(t, err, "unreachable")
+
+	_, err = VerifyBeef(ctx, beef, nil, LevelScriptsAndProofs)
+	require.Error(t, err)
+}
+
+func TestVerifyBeefInvalidScript(t *testing.T) {
+	ctx := t.Context()
+	subject, err := transaction.NewTransactionFromBEEFHex(BRC62Hex)
+	require.NoError(t, err)
+	subject.Inputs[0].UnlockingScript = &script.Script{script.OpTRUE}
+	b, err := subject.BEEF()
+	require.NoError(t, err)
+	beef, err := transaction.NewBeefFromBytes(b)
+	require.NoError(t, err)
+
+	report, err := VerifyBeef(ctx, beef, nil, LevelStructure)
+	require.NoError(t, err)
+	require.True(t, report.Valid)
+
+	report, err = VerifyBeef(ctx, beef, nil, LevelScripts)
+	require.NoError(t, err)
+	require.False(t, report.Valid)
+	require.Len(t, report.Problems, 1)
+	require.Contains(t, report.Problems[0], "input 0 of transaction "+subject.TxID().String())
+}
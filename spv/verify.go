@@ -45,7 +45,6 @@ func Verify(ctx context.Context, t *transaction.Transaction,
 			if err := clone.Fee(feeModel, transaction.ChangeDistributionEqual); err != nil {
 				return false, err
 			}
-			tx.TotalOutputSatoshis()
 			if txFee, err := tx.GetFee(); err != nil {
 				return false, err
 			} else if cloneFee, err := clone.GetFee(); err != nil {
@@ -55,13 +54,11 @@ func Verify(ctx context.Context, t *transaction.Transaction,
 			}
 		}
 
-		inputTotal := uint64(0)
-		for vin, input := range tx.Inputs {
-			sourceOutput := input.SourceTxOutput()
+		for vin, sourceOutput := range tx.SourceOutputs() {
 			if sourceOutput == nil {
 				return false, fmt.Errorf("input %d has no source transaction", vin)
 			}
-			inputTotal += sourceOutput.Satoshis
+			input := tx.Inputs[vin]
 
 			if input.SourceTransaction != nil {
 				if _, ok := verifiedTxids[input.SourceTransaction.TxID().String()]; !ok {
@@ -69,7 +66,7 @@ func Verify(ctx context.Context, t *transaction.Transaction,
 				}
 			}
 
-			if err := interpreter.NewEngine().Execute(
+			if err := interpreter.NewEngine().ExecuteContext(ctx,
 				interpreter.WithTx(tx, vin, sourceOutput),
 				interpreter.WithForkID(),
 				interpreter.WithAfterGenesis(),
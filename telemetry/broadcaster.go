@@ -0,0 +1,50 @@
+package telemetry
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/bsv-blockchain/go-sdk/transaction"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type broadcaster struct {
+	inner transaction.Broadcaster
+	in    *instruments
+	attrs []attribute.KeyValue
+}
+
+// WrapBroadcaster instruments b. Failed broadcasts record the failure code as
+// their error type.
+func WrapBroadcaster(b transaction.Broadcaster, tp trace.TracerProvider, mp metric.MeterProvider) transaction.Broadcaster {
+	if tp == nil && mp == nil {
+		return b
+	}
+	return &broadcaster{
+		inner: b,
+		in: newInstruments(tp, mp, "bsv.broadcaster.duration",
+			"Duration of transaction broadcasts"),
+		attrs: []attribute.KeyValue{attribute.String("bsv.broadcaster", fmt.Sprintf("%T", b))},
+	}
+}
+
+func (b *broadcaster) Broadcast(tx *transaction.Transaction) (*transaction.BroadcastSuccess, *transaction.BroadcastFailure) {
+	return b.BroadcastCtx(context.Background(), tx)
+}
+
+func (b *broadcaster) BroadcastCtx(ctx context.Context, tx *transaction.Transaction) (*transaction.BroadcastSuccess, *transaction.BroadcastFailure) {
+	var spanAttrs []attribute.KeyValue
+	if tx != nil {
+		spanAttrs = append(spanAttrs, attribute.String("bsv.tx.id", tx.TxID().String()))
+	}
+	c := b.in.start(ctx, "Broadcast", b.attrs, spanAttrs...)
+	success, failure := b.inner.BroadcastCtx(c.ctx, tx)
+	if failure != nil {
+		c.end(failure.Code, failure.Description)
+	} else {
+		c.end("", "")
+	}
+	return success, failure
+}
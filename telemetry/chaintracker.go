@@ -0,0 +1,47 @@
+package telemetry
+
+import (
+	"context"
+
+	"github.com/bsv-blockchain/go-sdk/chainhash"
+	"github.com/bsv-blockchain/go-sdk/transaction/chaintracker"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type chainTracker struct {
+	inner chaintracker.ChainTracker
+	in    *instruments
+}
+
+// WrapChainTracker instruments ct.
+func WrapChainTracker(ct chaintracker.ChainTracker, tp trace.TracerProvider, mp metric.MeterProvider) chaintracker.ChainTracker {
+	if tp == nil && mp == nil {
+		return ct
+	}
+	return &chainTracker{
+		inner: ct,
+		in: newInstruments(tp, mp, "bsv.chaintracker.duration",
+			"Duration of chain tracker queries"),
+	}
+}
+
+func methodAttrs(method string) []attribute.KeyValue {
+	return []attribute.KeyValue{attribute.String("bsv.method", method)}
+}
+
+func (t *chainTracker) IsValidRootForHeight(ctx context.Context, root *chainhash.Hash, height uint32) (bool, error) {
+	c := t.in.start(ctx, "ChainTracker.IsValidRootForHeight", methodAttrs("IsValidRootForHeight"),
+		attribute.Int64("bsv.block.height", int64(height)))
+	valid, err := t.inner.IsValidRootForHeight(c.ctx, root, height)
+	c.endErr(err)
+	return valid, err
+}
+
+func (t *chainTracker) CurrentHeight(ctx context.Context) (uint32, error) {
+	c := t.in.start(ctx, "ChainTracker.CurrentHeight", methodAttrs("CurrentHeight"))
+	height, err := t.inner.CurrentHeight(c.ctx)
+	c.endErr(err)
+	return height, err
+}
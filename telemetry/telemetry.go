@@ -0,0 +1,103 @@
+// Package telemetry instruments SDK components with OpenTelemetry. The Wrap
+// functions return a component that reports a span per call and the call duration
+// as a metric to the given providers. When both providers are nil they return the
+// component itself, so code that does not inject providers pays nothing.
+//
+// Script execution is instrumented from within the interpreter, see
+// interpreter.WithTelemetry.
+package telemetry
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const instrumentationName = "github.com/bsv-blockchain/go-sdk/telemetry"
+
+// errorTypeKey is the attribute recording why a call failed.
+const errorTypeKey = attribute.Key("error.type")
+
+// instruments reports the calls of one kind of component.
+type instruments struct {
+	tracer   trace.Tracer
+	duration metric.Float64Histogram
+}
+
+func newInstruments(tp trace.TracerProvider, mp metric.MeterProvider, metricName, description string) *instruments {
+	in := &instruments{}
+	if tp != nil {
+		in.tracer = tp.Tracer(instrumentationName)
+	}
+	if mp != nil {
+		var err error
+		in.duration, err = mp.Meter(instrumentationName).Float64Histogram(metricName,
+			metric.WithDescription(description),
+			metric.WithUnit("s"))
+		if err != nil {
+			otel.Handle(err)
+		}
+	}
+	return in
+}
+
+// call tracks a single call from start to end.
+type call struct {
+	in    *instruments
+	ctx   context.Context
+	span  trace.Span
+	start time.Time
+	// metricAttrs are recorded on both the span and the duration metric, so
+	// they must be of low cardinality.
+	metricAttrs []attribute.KeyValue
+}
+
+// start begins a call. spanAttrs are recorded on the span only.
+func (in *instruments) start(ctx context.Context, name string, metricAttrs []attribute.KeyValue, spanAttrs ...attribute.KeyValue) *call {
+	c := &call{in: in, ctx: ctx, metricAttrs: metricAttrs}
+	if in.tracer != nil {
+		c.ctx, c.span = in.tracer.Start(ctx, name,
+			trace.WithSpanKind(trace.SpanKindClient),
+			trace.WithAttributes(metricAttrs...),
+			trace.WithAttributes(spanAttrs...))
+	}
+	c.start = time.Now()
+	return c
+}
+
+// end completes the call. errType is empty for successful calls.
+func (c *call) end(errType, errMsg string) {
+	elapsed := time.Since(c.start)
+	attrs := c.metricAttrs
+	if errType != "" {
+		attrs = append(attrs[:len(attrs):len(attrs)], errorTypeKey.String(errType))
+	}
+	if c.span != nil {
+		if errType != "" {
+			c.span.SetAttributes(errorTypeKey.String(errType))
+			c.span.SetStatus(codes.Error, errMsg)
+		}
+		c.span.End()
+	}
+	if c.in.duration != nil {
+		c.in.duration.Record(c.ctx, elapsed.Seconds(), metric.WithAttributes(attrs...))
+	}
+}
+
+// endErr completes the call with the outcome of a Go error.
+func (c *call) endErr(err error) {
+	if err == nil {
+		c.end("", "")
+		return
+	}
+	if c.span != nil {
+		c.span.RecordError(err)
+	}
+	c.end(fmt.Sprintf("%T", err), err.Error())
+}
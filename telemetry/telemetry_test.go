@@ -0,0 +1,157 @@
+package telemetry
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/bsv-blockchain/go-sdk/chainhash"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+	"github.com/bsv-blockchain/go-sdk/wallet"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func newProviders() (*sdktrace.TracerProvider, *tracetest.SpanRecorder, *sdkmetric.MeterProvider, *sdkmetric.ManualReader) {
+	spans := tracetest.NewSpanRecorder()
+	reader := sdkmetric.NewManualReader()
+	return sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(spans)), spans,
+		sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader)), reader
+}
+
+// histogramCounts returns the number of recordings of the named histogram by
+// error type, with "" for successful calls.
+func histogramCounts(t *testing.T, reader *sdkmetric.ManualReader, name string) map[string]uint64 {
+	t.Helper()
+	var rm metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(t.Context(), &rm))
+	counts := map[string]uint64{}
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			if m.Name != name {
+				continue
+			}
+			for _, dp := range m.Data.(metricdata.Histogram[float64]).DataPoints {
+				errType, _ := dp.Attributes.Value(errorTypeKey)
+				counts[errType.AsString()] += dp.Count
+			}
+		}
+	}
+	return counts
+}
+
+func spanAttr(attrs []attribute.KeyValue, key attribute.Key) string {
+	for _, kv := range attrs {
+		if kv.Key == key {
+			return kv.Value.Emit()
+		}
+	}
+	return ""
+}
+
+type fakeBroadcaster struct {
+	failure *transaction.BroadcastFailure
+}
+
+func (b *fakeBroadcaster) Broadcast(tx *transaction.Transaction) (*transaction.BroadcastSuccess, *transaction.BroadcastFailure) {
+	return b.BroadcastCtx(context.Background(), tx)
+}
+
+func (b *fakeBroadcaster) BroadcastCtx(_ context.Context, tx *transaction.Transaction) (*transaction.BroadcastSuccess, *transaction.BroadcastFailure) {
+	if b.failure != nil {
+		return nil, b.failure
+	}
+	return &transaction.BroadcastSuccess{Txid: tx.TxID().String()}, nil
+}
+
+func TestWrapWithoutProviders(t *testing.T) {
+	b := &fakeBroadcaster{}
+	require.Same(t, b, WrapBroadcaster(b, nil, nil))
+	var w wallet.Interface = &fakeWallet{}
+	require.Equal(t, w, WrapWallet(w, nil, nil))
+}
+
+func TestWrapBroadcaster(t *testing.T) {
+	tp, spans, mp, reader := newProviders()
+	inner := &fakeBroadcaster{}
+	b := WrapBroadcaster(inner, tp, mp)
+	tx := transaction.NewTransaction()
+
+	success, failure := b.Broadcast(tx)
+	require.Nil(t, failure)
+	require.Equal(t, tx.TxID().String(), success.Txid)
+
+	inner.failure = &transaction.BroadcastFailure{Code: "465", Description: "fee too low"}
+	_, failure = b.Broadcast(tx)
+	require.Equal(t, inner.failure, failure)
+
+	ended := spans.Ended()
+	require.Len(t, ended, 2)
+	require.Equal(t, "Broadcast", ended[0].Name())
+	require.Equal(t, tx.TxID().String(), spanAttr(ended[0].Attributes(), "bsv.tx.id"))
+	require.Equal(t, "*telemetry.fakeBroadcaster", spanAttr(ended[0].Attributes(), "bsv.broadcaster"))
+	require.Equal(t, codes.Unset, ended[0].Status().Code)
+	require.Equal(t, codes.Error, ended[1].Status().Code)
+	require.Equal(t, "fee too low", ended[1].Status().Description)
+	require.Equal(t, "465", spanAttr(ended[1].Attributes(), errorTypeKey))
+
+	require.Equal(t, map[string]uint64{"": 1, "465": 1}, histogramCounts(t, reader, "bsv.broadcaster.duration"))
+}
+
+type fakeChainTracker struct{}
+
+func (fakeChainTracker) IsValidRootForHeight(context.Context, *chainhash.Hash, uint32) (bool, error) {
+	return true, nil
+}
+
+func (fakeChainTracker) CurrentHeight(context.Context) (uint32, error) {
+	return 0, errors.New("unavailable")
+}
+
+func TestWrapChainTracker(t *testing.T) {
+	tp, spans, mp, reader := newProviders()
+	ct := WrapChainTracker(fakeChainTracker{}, tp, mp)
+
+	valid, err := ct.IsValidRootForHeight(t.Context(), &chainhash.Hash{}, 800000)
+	require.NoError(t, err)
+	require.True(t, valid)
+	_, err = ct.CurrentHeight(t.Context())
+	require.EqualError(t, err, "unavailable")
+
+	ended := spans.Ended()
+	require.Len(t, ended, 2)
+	require.Equal(t, "ChainTracker.IsValidRootForHeight", ended[0].Name())
+	require.Equal(t, "800000", spanAttr(ended[0].Attributes(), "bsv.block.height"))
+	require.Equal(t, codes.Error, ended[1].Status().Code)
+	require.Len(t, ended[1].Events(), 1, "the error is recorded")
+
+	require.Equal(t, map[string]uint64{"": 1, "*errors.errorString": 1}, histogramCounts(t, reader, "bsv.chaintracker.duration"))
+}
+
+type fakeWallet struct {
+	wallet.Interface
+}
+
+func (fakeWallet) GetHeight(context.Context, any, string) (*wallet.GetHeightResult, error) {
+	return &wallet.GetHeightResult{Height: 100}, nil
+}
+
+func TestWrapWallet(t *testing.T) {
+	tp, spans, mp, reader := newProviders()
+	w := WrapWallet(fakeWallet{}, tp, mp)
+
+	height, err := w.GetHeight(t.Context(), nil, "app.com")
+	require.NoError(t, err)
+	require.Equal(t, uint32(100), height.Height)
+
+	ended := spans.Ended()
+	require.Len(t, ended, 1)
+	require.Equal(t, "wallet.getHeight", ended[0].Name())
+	require.Equal(t, "app.com", spanAttr(ended[0].Attributes(), "bsv.wallet.originator"))
+	require.Equal(t, map[string]uint64{"": 1}, histogramCounts(t, reader, "bsv.wallet.duration"))
+}
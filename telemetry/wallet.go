@@ -0,0 +1,149 @@
+package telemetry
+
+import (
+	"context"
+
+	"github.com/bsv-blockchain/go-sdk/wallet"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type walletClient struct {
+	inner wallet.Interface
+	in    *instruments
+}
+
+// WrapWallet instruments w, typically a wallet substrate client. Methods are named
+// as in BRC-100, e.g. "createAction", and spans record the originator of the call.
+func WrapWallet(w wallet.Interface, tp trace.TracerProvider, mp metric.MeterProvider) wallet.Interface {
+	if tp == nil && mp == nil {
+		return w
+	}
+	return &walletClient{
+		inner: w,
+		in: newInstruments(tp, mp, "bsv.wallet.duration",
+			"Duration of wallet calls"),
+	}
+}
+
+func walletCall[A, R any](ctx context.Context, w *walletClient, method string, args A, originator string,
+	fn func(context.Context, A, string) (R, error)) (R, error) {
+	c := w.in.start(ctx, "wallet."+method, methodAttrs(method),
+		attribute.String("bsv.wallet.originator", originator))
+	result, err := fn(c.ctx, args, originator)
+	c.endErr(err)
+	return result, err
+}
+
+func (w *walletClient) GetPublicKey(ctx context.Context, args wallet.GetPublicKeyArgs, originator string) (*wallet.GetPublicKeyResult, error) {
+	return walletCall(ctx, w, "getPublicKey", args, originator, w.inner.GetPublicKey)
+}
+
+func (w *walletClient) Encrypt(ctx context.Context, args wallet.EncryptArgs, originator string) (*wallet.EncryptResult, error) {
+	return walletCall(ctx, w, "encrypt", args, originator, w.inner.Encrypt)
+}
+
+func (w *walletClient) Decrypt(ctx context.Context, args wallet.DecryptArgs, originator string) (*wallet.DecryptResult, error) {
+	return walletCall(ctx, w, "decrypt", args, originator, w.inner.Decrypt)
+}
+
+func (w *walletClient) CreateHMAC(ctx context.Context, args wallet.CreateHMACArgs, originator string) (*wallet.CreateHMACResult, error) {
+	return walletCall(ctx, w, "createHMAC", args, originator, w.inner.CreateHMAC)
+}
+
+func (w *walletClient) VerifyHMAC(ctx context.Context, args wallet.VerifyHMACArgs, originator string) (*wallet.VerifyHMACResult, error) {
+	return walletCall(ctx, w, "verifyHMAC", args, originator, w.inner.VerifyHMAC)
+}
+
+func (w *walletClient) CreateSignature(ctx context.Context, args wallet.CreateSignatureArgs, originator string) (*wallet.CreateSignatureResult, error) {
+	return walletCall(ctx, w, "createSignature", args, originator, w.inner.CreateSignature)
+}
+
+func (w *walletClient) VerifySignature(ctx context.Context, args wallet.VerifySignatureArgs, originator string) (*wallet.VerifySignatureResult, error) {
+	return walletCall(ctx, w, "verifySignature", args, originator, w.inner.VerifySignature)
+}
+
+func (w *walletClient) AcquireCertificate(ctx context.Context, args wallet.AcquireCertificateArgs, originator string) (*wallet.Certificate, error) {
+	return walletCall(ctx, w, "acquireCertificate", args, originator, w.inner.AcquireCertificate)
+}
+
+func (w *walletClient) ListCertificates(ctx context.Context, args wallet.ListCertificatesArgs, originator string) (*wallet.ListCertificatesResult, error) {
+	return walletCall(ctx, w, "listCertificates", args, originator, w.inner.ListCertificates)
+}
+
+func (w *walletClient) ProveCertificate(ctx context.Context, args wallet.ProveCertificateArgs, originator string) (*wallet.ProveCertificateResult, error) {
+	return walletCall(ctx, w, "proveCertificate", args, originator, w.inner.ProveCertificate)
+}
+
+func (w *walletClient) RelinquishCertificate(ctx context.Context, args wallet.RelinquishCertificateArgs, originator string) (*wallet.RelinquishCertificateResult, error) {
+	return walletCall(ctx, w, "relinquishCertificate", args, originator, w.inner.RelinquishCertificate)
+}
+
+func (w *walletClient) CreateAction(ctx context.Context, args wallet.CreateActionArgs, originator string) (*wallet.CreateActionResult, error) {
+	return walletCall(ctx, w, "createAction", args, originator, w.inner.CreateAction)
+}
+
+func (w *walletClient) SignAction(ctx context.Context, args wallet.SignActionArgs, originator string) (*wallet.SignActionResult, error) {
+	return walletCall(ctx, w, "signAction", args, originator, w.inner.SignAction)
+}
+
+func (w *walletClient) AbortAction(ctx context.Context, args wallet.AbortActionArgs, originator string) (*wallet.AbortActionResult, error) {
+	return walletCall(ctx, w, "abortAction", args, originator, w.inner.AbortAction)
+}
+
+func (w *walletClient) ListActions(ctx context.Context, args wallet.ListActionsArgs, originator string) (*wallet.ListActionsResult, error) {
+	return walletCall(ctx, w, "listActions", args, originator, w.inner.ListActions)
+}
+
+func (w *walletClient) InternalizeAction(ctx context.Context, args wallet.InternalizeActionArgs, originator string) (*wallet.InternalizeActionResult, error) {
+	return walletCall(ctx, w, "internalizeAction", args, originator, w.inner.InternalizeAction)
+}
+
+func (w *walletClient) ListOutputs(ctx context.Context, args wallet.ListOutputsArgs, originator string) (*wallet.ListOutputsResult, error) {
+	return walletCall(ctx, w, "listOutputs", args, originator, w.inner.ListOutputs)
+}
+
+func (w *walletClient) RelinquishOutput(ctx context.Context, args wallet.RelinquishOutputArgs, originator string) (*wallet.RelinquishOutputResult, error) {
+	return walletCall(ctx, w, "relinquishOutput", args, originator, w.inner.RelinquishOutput)
+}
+
+func (w *walletClient) RevealCounterpartyKeyLinkage(ctx context.Context, args wallet.RevealCounterpartyKeyLinkageArgs, originator string) (*wallet.RevealCounterpartyKeyLinkageResult, error) {
+	return walletCall(ctx, w, "revealCounterpartyKeyLinkage", args, originator, w.inner.RevealCounterpartyKeyLinkage)
+}
+
+func (w *walletClient) RevealSpecificKeyLinkage(ctx context.Context, args wallet.RevealSpecificKeyLinkageArgs, originator string) (*wallet.RevealSpecificKeyLinkageResult, error) {
+	return walletCall(ctx, w, "revealSpecificKeyLinkage", args, originator, w.inner.RevealSpecificKeyLinkage)
+}
+
+func (w *walletClient) DiscoverByIdentityKey(ctx context.Context, args wallet.DiscoverByIdentityKeyArgs, originator string) (*wallet.DiscoverCertificatesResult, error) {
+	return walletCall(ctx, w, "discoverByIdentityKey", args, originator, w.inner.DiscoverByIdentityKey)
+}
+
+func (w *walletClient) DiscoverByAttributes(ctx context.Context, args wallet.DiscoverByAttributesArgs, originator string) (*wallet.DiscoverCertificatesResult, error) {
+	return walletCall(ctx, w, "discoverByAttributes", args, originator, w.inner.DiscoverByAttributes)
+}
+
+func (w *walletClient) IsAuthenticated(ctx context.Context, args any, originator string) (*wallet.AuthenticatedResult, error) {
+	return walletCall(ctx, w, "isAuthenticated", args, originator, w.inner.IsAuthenticated)
+}
+
+func (w *walletClient) WaitForAuthentication(ctx context.Context, args any, originator string) (*wallet.AuthenticatedResult, error) {
+	return walletCall(ctx, w, "waitForAuthentication", args, originator, w.inner.WaitForAuthentication)
+}
+
+func (w *walletClient) GetHeight(ctx context.Context, args any, originator string) (*wallet.GetHeightResult, error) {
+	return walletCall(ctx, w, "getHeight", args, originator, w.inner.GetHeight)
+}
+
+func (w *walletClient) GetHeaderForHeight(ctx context.Context, args wallet.GetHeaderArgs, originator string) (*wallet.GetHeaderResult, error) {
+	return walletCall(ctx, w, "getHeaderForHeight", args, originator, w.inner.GetHeaderForHeight)
+}
+
+func (w *walletClient) GetNetwork(ctx context.Context, args any, originator string) (*wallet.GetNetworkResult, error) {
+	return walletCall(ctx, w, "getNetwork", args, originator, w.inner.GetNetwork)
+}
+
+func (w *walletClient) GetVersion(ctx context.Context, args any, originator string) (*wallet.GetVersionResult, error) {
+	return walletCall(ctx, w, "getVersion", args, originator, w.inner.GetVersion)
+}
@@ -0,0 +1,315 @@
+// Package simchain simulates a blockchain in memory for integration tests of
+// wallet and SPV code. A Chain accepts transactions as a Broadcaster, mines them
+// into blocks on demand, serves source transactions with their merkle proofs as
+// a UtxoProvider and checks merkle roots as a ChainTracker, deterministically
+// and without a regtest node.
+package simchain
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+
+	"github.com/bsv-blockchain/go-sdk/block"
+	"github.com/bsv-blockchain/go-sdk/chainhash"
+	"github.com/bsv-blockchain/go-sdk/script"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+	"github.com/bsv-blockchain/go-sdk/transaction/merkle"
+)
+
+var (
+	ErrUnknownTransaction = errors.New("transaction is not known to the chain")
+	ErrNotMined           = errors.New("transaction is not mined")
+	ErrUnknownBlock       = errors.New("block is not in the chain")
+	ErrReorgTooDeep       = errors.New("reorg would remove the genesis block")
+)
+
+const (
+	// GenesisTimestamp is the timestamp of the genesis block. Every following
+	// block is BlockInterval seconds later.
+	GenesisTimestamp = 1231006505
+	BlockInterval    = 600
+
+	// regtestBits is the minimum difficulty target of regtest, which is cheap
+	// enough to mine headers with valid proof of work.
+	regtestBits = 0x207fffff
+)
+
+// Block is a block of the simulated chain.
+type Block struct {
+	Height uint32
+	Header block.Header
+	// Txids lists the transactions of the block in order, coinbase first.
+	Txids []chainhash.Hash
+}
+
+// Hash returns the block hash.
+func (b *Block) Hash() chainhash.Hash {
+	return b.Header.Hash()
+}
+
+// Option configures a Chain.
+type Option func(*Chain)
+
+// WithAutoMine mines a block after every accepted transaction, so broadcast
+// transactions are confirmed immediately.
+func WithAutoMine() Option {
+	return func(c *Chain) {
+		c.autoMine = true
+	}
+}
+
+// WithoutScriptVerification accepts transactions without executing their
+// unlocking scripts, for tests using placeholder scripts.
+func WithoutScriptVerification() Option {
+	return func(c *Chain) {
+		c.verifyScripts = false
+	}
+}
+
+type txEntry struct {
+	raw []byte
+	// block is nil while the transaction is in the mempool.
+	block *Block
+	index int
+}
+
+// Chain is a simulated blockchain with a mempool. It is safe for concurrent use.
+type Chain struct {
+	autoMine      bool
+	verifyScripts bool
+
+	mu      sync.Mutex
+	blocks  []*Block
+	mempool []chainhash.Hash
+	txs     map[chainhash.Hash]*txEntry
+	utxos   map[transaction.Outpoint]*transaction.TransactionOutput
+	spentBy map[transaction.Outpoint]chainhash.Hash
+	// coinbases counts the coinbase transactions created, keeping their txids
+	// unique across reorgs and faucet payments.
+	coinbases uint64
+}
+
+// New creates a chain holding only its genesis block.
+func New(opts ...Option) *Chain {
+	c := &Chain{
+		verifyScripts: true,
+		txs:           make(map[chainhash.Hash]*txEntry),
+		utxos:         make(map[transaction.Outpoint]*transaction.TransactionOutput),
+		spentBy:       make(map[transaction.Outpoint]chainhash.Hash),
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	c.mine()
+	return c
+}
+
+// Fund adds a coinbase-like transaction paying satoshis to lockingScript to the
+// mempool, as a faucet for tests. Its output can be spent right away, but it
+// only has a merkle proof, and transactions spending it only have valid BEEF,
+// once it is mined.
+func (c *Chain) Fund(lockingScript *script.Script, satoshis uint64) *transaction.Transaction {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	tx := c.coinbase([]byte("simchain faucet"), &transaction.TransactionOutput{
+		Satoshis:      satoshis,
+		LockingScript: lockingScript,
+	})
+	c.addToMempool(tx)
+	if c.autoMine {
+		c.mine()
+	}
+	return tx
+}
+
+// Mine mines the transactions in the mempool into a new block and returns it.
+func (c *Chain) Mine() *Block {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.mine()
+}
+
+// Reorg removes the depth blocks at the tip of the chain, returning their
+// transactions, other than the coinbases, to the mempool. Blocks mined
+// afterwards have different coinbases, so their hashes and merkle roots differ
+// from the removed blocks even when they hold the same transactions.
+func (c *Chain) Reorg(depth int) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if depth < 0 || depth >= len(c.blocks) {
+		return fmt.Errorf("%w: depth %d with %d blocks", ErrReorgTooDeep, depth, len(c.blocks))
+	}
+	var returned []chainhash.Hash
+	for _, b := range c.blocks[len(c.blocks)-depth:] {
+		delete(c.txs, b.Txids[0])
+		for _, txid := range b.Txids[1:] {
+			c.txs[txid].block = nil
+			returned = append(returned, txid)
+		}
+	}
+	c.blocks = c.blocks[:len(c.blocks)-depth]
+	c.mempool = append(returned, c.mempool...)
+	return nil
+}
+
+// Tip returns the block at the tip of the chain.
+func (c *Chain) Tip() *Block {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.blocks[len(c.blocks)-1]
+}
+
+// Block returns the block at height.
+func (c *Chain) Block(height uint32) (*Block, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if int(height) >= len(c.blocks) {
+		return nil, fmt.Errorf("%w: height %d", ErrUnknownBlock, height)
+	}
+	return c.blocks[height], nil
+}
+
+// Mempool returns the txids of the unmined transactions, in the order they were
+// accepted.
+func (c *Chain) Mempool() []chainhash.Hash {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return slices.Clone(c.mempool)
+}
+
+// MerklePath returns the merkle proof of the mined transaction with txid.
+func (c *Chain) MerklePath(txid *chainhash.Hash) (*transaction.MerklePath, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.txs[*txid]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownTransaction, txid)
+	}
+	if entry.block == nil {
+		return nil, fmt.Errorf("%w: %s", ErrNotMined, txid)
+	}
+	return merklePath(entry)
+}
+
+// Unspent returns the unspent outputs locked by lockingScript, including those
+// of mempool transactions, ordered by txid and output index.
+func (c *Chain) Unspent(lockingScript *script.Script) []*transaction.UTXO {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var utxos []*transaction.UTXO
+	for outpoint, output := range c.utxos {
+		if output.LockingScript.Equals(lockingScript) {
+			txid := outpoint.Txid
+			utxos = append(utxos, &transaction.UTXO{
+				TxID:          &txid,
+				Vout:          outpoint.Index,
+				LockingScript: output.LockingScript,
+				Satoshis:      output.Satoshis,
+			})
+		}
+	}
+	slices.SortFunc(utxos, func(a, b *transaction.UTXO) int {
+		if c := bytes.Compare(a.TxID[:], b.TxID[:]); c != 0 {
+			return c
+		}
+		return int(a.Vout) - int(b.Vout)
+	})
+	return utxos
+}
+
+// coinbase creates a coinbase transaction with outputs, whose unlocking script
+// holds tag and a counter making its txid unique.
+func (c *Chain) coinbase(tag []byte, outputs ...*transaction.TransactionOutput) *transaction.Transaction {
+	c.coinbases++
+	unlockingScript := &script.Script{}
+	_ = unlockingScript.AppendPushData(binary.LittleEndian.AppendUint64(nil, c.coinbases))
+	_ = unlockingScript.AppendPushData(tag)
+
+	tx := transaction.NewTransaction()
+	tx.AddInput(&transaction.TransactionInput{
+		SourceTXID:       &chainhash.Hash{},
+		SourceTxOutIndex: transaction.DefaultSequenceNumber,
+		UnlockingScript:  unlockingScript,
+		SequenceNumber:   transaction.DefaultSequenceNumber,
+	})
+	for _, output := range outputs {
+		tx.AddOutput(output)
+	}
+	return tx
+}
+
+// addToMempool records tx as unmined, spending its inputs and adding its
+// outputs to the UTXO set.
+func (c *Chain) addToMempool(tx *transaction.Transaction) {
+	txid := *tx.TxID()
+	if !tx.IsCoinbase() {
+		for _, in := range tx.Inputs {
+			outpoint := transaction.Outpoint{Txid: *in.SourceTXID, Index: in.SourceTxOutIndex}
+			delete(c.utxos, outpoint)
+			c.spentBy[outpoint] = txid
+		}
+	}
+	for vout, output := range tx.Outputs {
+		c.utxos[transaction.Outpoint{Txid: txid, Index: uint32(vout)}] = output
+	}
+	c.txs[txid] = &txEntry{raw: tx.Bytes()}
+	c.mempool = append(c.mempool, txid)
+}
+
+func (c *Chain) mine() *Block {
+	height := uint32(len(c.blocks))
+	// The coinbase pays nothing, so it adds no outputs to track.
+	coinbase := c.coinbase(binary.LittleEndian.AppendUint32(nil, height), &transaction.TransactionOutput{
+		LockingScript: &script.Script{script.OpFALSE, script.OpRETURN},
+	})
+	txids := append([]chainhash.Hash{*coinbase.TxID()}, c.mempool...)
+	c.txs[txids[0]] = &txEntry{raw: coinbase.Bytes()}
+
+	builder := merkle.NewBuilder(len(txids))
+	for _, txid := range txids {
+		builder.Add(txid)
+	}
+	root, _ := builder.Root()
+
+	b := &Block{
+		Height: height,
+		Header: block.Header{
+			Version:    1,
+			MerkleRoot: *root,
+			Timestamp:  GenesisTimestamp + height*BlockInterval,
+			Bits:       regtestBits,
+		},
+		Txids: txids,
+	}
+	if height > 0 {
+		b.Header.PrevBlock = c.blocks[height-1].Hash()
+	}
+	for b.Header.CheckProofOfWork() != nil {
+		b.Header.Nonce++
+	}
+
+	for i, txid := range txids {
+		entry := c.txs[txid]
+		entry.block = b
+		entry.index = i
+	}
+	c.blocks = append(c.blocks, b)
+	c.mempool = nil
+	return b
+}
+
+func merklePath(entry *txEntry) (*transaction.MerklePath, error) {
+	builder := merkle.NewBuilder(len(entry.block.Txids))
+	for _, txid := range entry.block.Txids {
+		builder.Add(txid)
+	}
+	return builder.MerklePath(entry.block.Height, entry.index)
+}
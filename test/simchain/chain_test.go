@@ -0,0 +1,167 @@
+package simchain_test
+
+import (
+	"testing"
+
+	"github.com/bsv-blockchain/go-sdk/chainhash"
+	ec "github.com/bsv-blockchain/go-sdk/primitives/ec"
+	"github.com/bsv-blockchain/go-sdk/script"
+	"github.com/bsv-blockchain/go-sdk/spv"
+	"github.com/bsv-blockchain/go-sdk/test/simchain"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+	"github.com/bsv-blockchain/go-sdk/transaction/template/p2pkh"
+	"github.com/stretchr/testify/require"
+)
+
+func p2pkhKey(t *testing.T) (*ec.PrivateKey, *script.Script) {
+	t.Helper()
+	key, err := ec.NewPrivateKey()
+	require.NoError(t, err)
+	address, err := script.NewAddressFromPublicKey(key.PubKey(), true)
+	require.NoError(t, err)
+	lockingScript, err := p2pkh.Lock(address)
+	require.NoError(t, err)
+	return key, lockingScript
+}
+
+// spend builds a signed transaction paying satoshis of the outpoint to
+// lockingScript, resolving its source from chain.
+func spend(t *testing.T, chain *simchain.Chain, key *ec.PrivateKey, outpoint *transaction.Outpoint, lockingScript *script.Script, satoshis uint64) *transaction.Transaction {
+	t.Helper()
+	unlocker, err := p2pkh.Unlock(key, nil)
+	require.NoError(t, err)
+	tx := transaction.NewTransaction()
+	tx.AddInput(&transaction.TransactionInput{
+		SourceTXID:              &outpoint.Txid,
+		SourceTxOutIndex:        outpoint.Index,
+		SequenceNumber:          transaction.DefaultSequenceNumber,
+		UnlockingScriptTemplate: unlocker,
+	})
+	tx.AddOutput(&transaction.TransactionOutput{Satoshis: satoshis, LockingScript: lockingScript})
+	require.NoError(t, tx.HydrateInputs(t.Context(), chain))
+	require.NoError(t, tx.Sign())
+	return tx
+}
+
+func TestChain(t *testing.T) {
+	ctx := t.Context()
+	chain := simchain.New()
+	genesis := chain.Tip()
+	require.Equal(t, uint32(0), genesis.Height)
+	require.NoError(t, genesis.Header.CheckProofOfWork())
+
+	aliceKey, aliceScript := p2pkhKey(t)
+	bobKey, bobScript := p2pkhKey(t)
+	funding := chain.Fund(aliceScript, 10000)
+	require.Equal(t, []chainhash.Hash{*funding.TxID()}, chain.Mempool())
+	mined := chain.Mine()
+	require.Equal(t, uint32(1), mined.Height)
+	require.Equal(t, genesis.Hash(), mined.Header.PrevBlock)
+	require.Equal(t, *funding.TxID(), mined.Txids[1])
+
+	utxos := chain.Unspent(aliceScript)
+	require.Len(t, utxos, 1)
+	require.Equal(t, uint64(10000), utxos[0].Satoshis)
+
+	payment := spend(t, chain, aliceKey, &transaction.Outpoint{Txid: *funding.TxID()}, bobScript, 9000)
+	success, failure := chain.BroadcastCtx(ctx, payment)
+	require.Nil(t, failure)
+	require.Equal(t, payment.TxID().String(), success.Txid)
+	require.Empty(t, chain.Unspent(aliceScript))
+
+	t.Run("unmined transactions carry their ancestry", func(t *testing.T) {
+		tx, err := chain.SourceTransaction(ctx, payment.TxID())
+		require.NoError(t, err)
+		require.Nil(t, tx.MerklePath)
+		require.NotNil(t, tx.Inputs[0].SourceTransaction.MerklePath)
+		_, err = chain.MerklePath(payment.TxID())
+		require.ErrorIs(t, err, simchain.ErrNotMined)
+	})
+
+	t.Run("rejects invalid transactions", func(t *testing.T) {
+		doubleSpend := spend(t, chain, aliceKey, &transaction.Outpoint{Txid: *funding.TxID()}, aliceScript, 8000)
+		_, failure := chain.Broadcast(doubleSpend)
+		require.NotNil(t, failure)
+		require.Equal(t, "409", failure.Code)
+
+		tx := spend(t, chain, bobKey, &transaction.Outpoint{Txid: *payment.TxID()}, bobScript, 9001)
+		_, failure = chain.Broadcast(tx)
+		require.NotNil(t, failure)
+		require.Equal(t, "400", failure.Code)
+
+		wrongKey := spend(t, chain, aliceKey, &transaction.Outpoint{Txid: *payment.TxID()}, bobScript, 8000)
+		_, failure = chain.Broadcast(wrongKey)
+		require.NotNil(t, failure)
+		require.Equal(t, "400", failure.Code)
+	})
+
+	chain.Mine()
+	height, err := chain.CurrentHeight(ctx)
+	require.NoError(t, err)
+	require.Equal(t, uint32(2), height)
+	require.Empty(t, chain.Mempool())
+
+	tx, err := chain.SourceTransaction(ctx, payment.TxID())
+	require.NoError(t, err)
+	require.NotNil(t, tx.MerklePath)
+	valid, err := tx.MerklePath.Verify(ctx, payment.TxID(), chain)
+	require.NoError(t, err)
+	require.True(t, valid)
+
+	next := spend(t, chain, bobKey, &transaction.Outpoint{Txid: *payment.TxID()}, aliceScript, 8000)
+	_, failure = chain.Broadcast(next)
+	require.Nil(t, failure)
+	next, err = chain.SourceTransaction(ctx, next.TxID())
+	require.NoError(t, err)
+	beef, err := next.AtomicBEEF(false)
+	require.NoError(t, err)
+	fromBEEF, err := transaction.NewTransactionFromBEEF(beef)
+	require.NoError(t, err)
+	verified, err := spv.Verify(ctx, fromBEEF, chain, nil)
+	require.NoError(t, err)
+	require.True(t, verified)
+
+	t.Run("reorgs invalidate merkle roots", func(t *testing.T) {
+		root := chain.Tip().Header.MerkleRoot
+		require.NoError(t, chain.Reorg(1))
+		require.Equal(t, []chainhash.Hash{*payment.TxID(), *next.TxID()}, chain.Mempool())
+
+		reorged := chain.Mine()
+		require.Equal(t, uint32(2), reorged.Height)
+		require.NotEqual(t, root, reorged.Header.MerkleRoot)
+		valid, err := tx.MerklePath.Verify(ctx, payment.TxID(), chain)
+		require.NoError(t, err)
+		require.False(t, valid)
+
+		proof, err := chain.MerklePath(payment.TxID())
+		require.NoError(t, err)
+		valid, err = proof.Verify(ctx, payment.TxID(), chain)
+		require.NoError(t, err)
+		require.True(t, valid)
+
+		require.ErrorIs(t, chain.Reorg(3), simchain.ErrReorgTooDeep)
+	})
+}
+
+func TestAutoMine(t *testing.T) {
+	chain := simchain.New(simchain.WithAutoMine(), simchain.WithoutScriptVerification())
+	_, lockingScript := p2pkhKey(t)
+	funding := chain.Fund(lockingScript, 1000)
+	require.Equal(t, uint32(1), chain.Tip().Height)
+
+	tx := transaction.NewTransaction()
+	tx.AddInputWithOutput(&transaction.TransactionInput{
+		SourceTXID:      funding.TxID(),
+		UnlockingScript: &script.Script{script.OpTRUE},
+	}, funding.Outputs[0])
+	tx.AddOutput(&transaction.TransactionOutput{Satoshis: 900, LockingScript: lockingScript})
+	_, failure := chain.Broadcast(tx)
+	require.Nil(t, failure)
+	require.Equal(t, uint32(2), chain.Tip().Height)
+	_, err := chain.MerklePath(tx.TxID())
+	require.NoError(t, err)
+
+	success, failure := chain.Broadcast(tx)
+	require.Nil(t, failure)
+	require.Equal(t, "already known", success.Message)
+}
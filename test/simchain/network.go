@@ -0,0 +1,147 @@
+package simchain
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/bsv-blockchain/go-sdk/chainhash"
+	"github.com/bsv-blockchain/go-sdk/script/interpreter"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+	"github.com/bsv-blockchain/go-sdk/transaction/chaintracker"
+)
+
+var (
+	_ transaction.Broadcaster   = (*Chain)(nil)
+	_ transaction.UtxoProvider  = (*Chain)(nil)
+	_ chaintracker.ChainTracker = (*Chain)(nil)
+)
+
+func (c *Chain) Broadcast(tx *transaction.Transaction) (*transaction.BroadcastSuccess, *transaction.BroadcastFailure) {
+	return c.BroadcastCtx(context.Background(), tx)
+}
+
+// BroadcastCtx accepts tx into the mempool if every input spends an unspent
+// output, its outputs do not exceed its inputs and, unless disabled, its
+// unlocking scripts are valid. Rejections carry code "400", and code "409" for
+// double spends. Broadcasting a known transaction again succeeds.
+func (c *Chain) BroadcastCtx(ctx context.Context, tx *transaction.Transaction) (*transaction.BroadcastSuccess, *transaction.BroadcastFailure) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	txid := tx.TxID()
+	if _, ok := c.txs[*txid]; ok {
+		return &transaction.BroadcastSuccess{Txid: txid.String(), Message: "already known"}, nil
+	}
+	if failure := c.validate(ctx, tx); failure != nil {
+		return nil, failure
+	}
+
+	c.addToMempool(tx)
+	if c.autoMine {
+		c.mine()
+	}
+	return &transaction.BroadcastSuccess{Txid: txid.String(), Message: "accepted"}, nil
+}
+
+func (c *Chain) validate(ctx context.Context, tx *transaction.Transaction) *transaction.BroadcastFailure {
+	if len(tx.Inputs) == 0 || len(tx.Outputs) == 0 {
+		return rejected("400", "transaction has no inputs or no outputs")
+	}
+	if tx.IsCoinbase() {
+		return rejected("400", "coinbase transactions cannot be broadcast, use Fund")
+	}
+
+	// Scripts are checked against a copy carrying the source outputs from the
+	// UTXO set, not against whatever sources the caller attached.
+	checked := tx.ShallowClone()
+	spending := make(map[transaction.Outpoint]struct{}, len(tx.Inputs))
+	for vin, in := range checked.Inputs {
+		outpoint := transaction.Outpoint{Txid: *in.SourceTXID, Index: in.SourceTxOutIndex}
+		if _, ok := spending[outpoint]; ok {
+			return rejected("400", "input %d spends %s twice", vin, outpoint)
+		}
+		spending[outpoint] = struct{}{}
+		if spender, ok := c.spentBy[outpoint]; ok {
+			return rejected("409", "input %d spends %s, already spent by %s", vin, outpoint, spender)
+		}
+		output, ok := c.utxos[outpoint]
+		if !ok {
+			return rejected("400", "input %d spends unknown output %s", vin, outpoint)
+		}
+		in.SetSourceTxOutput(output)
+	}
+	if _, err := checked.GetFee(); err != nil {
+		inputSatoshis, _ := checked.TotalInputSatoshis()
+		return rejected("400", "outputs of %d satoshis exceed inputs of %d satoshis", checked.TotalOutputSatoshis(), inputSatoshis)
+	}
+
+	if c.verifyScripts {
+		for vin := range checked.Inputs {
+			if err := interpreter.VerifyInput(ctx, checked, uint32(vin)); err != nil {
+				return rejected("400", "input %d script verification failed: %s", vin, err)
+			}
+		}
+	}
+	return nil
+}
+
+func rejected(code, format string, args ...any) *transaction.BroadcastFailure {
+	return &transaction.BroadcastFailure{Code: code, Description: fmt.Sprintf(format, args...)}
+}
+
+// SourceTransaction returns the transaction with txid. A mined transaction has
+// its MerklePath set, and the inputs of an unmined one have their
+// SourceTransaction set back to mined ancestors, so that BEEF can be built from
+// the result.
+func (c *Chain) SourceTransaction(_ context.Context, txid *chainhash.Hash) (*transaction.Transaction, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.transaction(txid, make(map[chainhash.Hash]*transaction.Transaction))
+}
+
+func (c *Chain) transaction(txid *chainhash.Hash, loaded map[chainhash.Hash]*transaction.Transaction) (*transaction.Transaction, error) {
+	if tx, ok := loaded[*txid]; ok {
+		return tx, nil
+	}
+	entry, ok := c.txs[*txid]
+	if !ok {
+		return nil, fmt.Errorf("%w: transaction %s", transaction.ErrSourceNotFound, txid)
+	}
+	tx, err := transaction.NewTransactionFromBytes(entry.raw)
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case entry.block != nil:
+		if tx.MerklePath, err = merklePath(entry); err != nil {
+			return nil, err
+		}
+	case !tx.IsCoinbase():
+		for _, in := range tx.Inputs {
+			if in.SourceTransaction, err = c.transaction(in.SourceTXID, loaded); err != nil {
+				return nil, err
+			}
+		}
+	}
+	loaded[*txid] = tx
+	return tx, nil
+}
+
+// IsValidRootForHeight reports whether root is the merkle root of the block at
+// height.
+func (c *Chain) IsValidRootForHeight(_ context.Context, root *chainhash.Hash, height uint32) (bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if int(height) >= len(c.blocks) {
+		return false, nil
+	}
+	return c.blocks[height].Header.MerkleRoot.IsEqual(root), nil
+}
+
+// CurrentHeight returns the height of the tip of the chain.
+func (c *Chain) CurrentHeight(context.Context) (uint32, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return uint32(len(c.blocks) - 1), nil
+}
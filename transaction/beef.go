@@ -97,6 +97,9 @@ func readBeefTx(reader *bytes.Reader, BUMPs []*MerklePath) (*map[chainhash.Hash]
 				if err != nil {
 					return nil, err
 				}
+				if int(bumpIndex) >= len(BUMPs) {
+					return nil, fmt.Errorf("invalid bump index %d for %d bumps", bumpIndex, len(BUMPs))
+				}
 				beefTx.BumpIndex = int(bumpIndex)
 			}
 			// read the transaction data
@@ -316,12 +319,13 @@ func readBUMPs(reader *bytes.Reader) ([]*MerklePath, error) {
 		return nil, err
 	}
 
-	BUMPs := make([]*MerklePath, numberOfBUMPs)
-	for i := 0; i < int(numberOfBUMPs); i++ {
-		BUMPs[i], err = NewMerklePathFromReader(reader)
+	BUMPs := make([]*MerklePath, 0, preallocLen(uint64(numberOfBUMPs)))
+	for i := uint64(0); i < uint64(numberOfBUMPs); i++ {
+		bump, err := NewMerklePathFromReader(reader)
 		if err != nil {
 			return nil, err
 		}
+		BUMPs = append(BUMPs, bump)
 	}
 	return BUMPs, nil
 }
@@ -361,6 +365,9 @@ func readAllTransactions(reader *bytes.Reader, BUMPs []*MerklePath) (map[string]
 			if err != nil {
 				return nil, nil, err
 			}
+			if int(pathIndex) >= len(BUMPs) {
+				return nil, nil, fmt.Errorf("invalid bump index %d for %d bumps", pathIndex, len(BUMPs))
+			}
 			tx.MerklePath = BUMPs[int(pathIndex)]
 		}
 		for _, input := range tx.Inputs {
@@ -512,6 +519,16 @@ func (b *Beef) FindTransaction(txid string) *Transaction {
 	return b.FindTransactionByHash(idHash)
 }
 
+// FindOutput returns the output referenced by the outpoint, or nil if the BEEF
+// does not contain the full transaction or the output does not exist.
+func (b *Beef) FindOutput(outpoint *Outpoint) *TransactionOutput {
+	tx := b.FindTransactionByHash(&outpoint.Txid)
+	if tx == nil || int(outpoint.Index) >= len(tx.Outputs) {
+		return nil
+	}
+	return tx.Outputs[outpoint.Index]
+}
+
 func (b *Beef) FindTransactionForSigningByHash(txid *chainhash.Hash) *Transaction {
 	beefTx := b.findTxid(txid)
 	if beefTx == nil {
@@ -6,10 +6,11 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
-	"log"
+	"log/slog"
 	"net/http"
 	"time"
 
+	"github.com/bsv-blockchain/go-sdk/internal/logging"
 	"github.com/bsv-blockchain/go-sdk/transaction"
 	"github.com/bsv-blockchain/go-sdk/util"
 )
@@ -43,7 +44,10 @@ type Arc struct {
 	WaitForStatus           string
 	WaitFor                 ArcStatus
 	Client                  util.HTTPClient // Added for testing
-	Verbose                 bool
+	// Verbose logs every ARC response at info level rather than debug level.
+	Verbose bool
+	// Logger receives the diagnostics of the broadcaster, slog.Default when nil.
+	Logger *slog.Logger
 }
 
 type ArcResponse struct {
@@ -155,9 +159,11 @@ func (a *Arc) BroadcastCtx(ctx context.Context, t *transaction.Transaction) (*tr
 	}
 
 	response := &ArcResponse{}
+	level := slog.LevelDebug
 	if a.Verbose {
-		log.Println("msg", string(msg))
+		level = slog.LevelInfo
 	}
+	logging.OrDefault(a.Logger).Log(ctx, level, "ARC broadcast response", "status", resp.StatusCode, "body", string(msg))
 	err = json.Unmarshal(msg, &response)
 	if err != nil {
 		return nil, &transaction.BroadcastFailure{
@@ -186,7 +192,11 @@ func (a *Arc) BroadcastCtx(ctx context.Context, t *transaction.Transaction) (*tr
 }
 
 func (a *Arc) Status(txid string) (*ArcResponse, error) {
-	ctx := context.Background()
+	return a.StatusCtx(context.Background(), txid)
+}
+
+// StatusCtx queries the status of a transaction, honouring ctx for cancellation.
+func (a *Arc) StatusCtx(ctx context.Context, txid string) (*ArcResponse, error) {
 	req, err := http.NewRequestWithContext(
 		ctx,
 		"GET",
@@ -3,7 +3,9 @@
 package broadcaster
 
 import (
+	"bytes"
 	"io"
+	"log/slog"
 	"net/http"
 	"strings"
 	"testing"
@@ -76,3 +78,24 @@ func TestArcBroadcast(t *testing.T) {
 	require.Equal(t, tx.TxID().String(), success.Txid, "Txid mismatch")
 	require.Equal(t, "Broadcast Success", success.Message, "Message mismatch")
 }
+
+// TestArcLogger tests that ARC responses are logged at debug level, or at info level when verbose.
+func TestArcLogger(t *testing.T) {
+	var logs bytes.Buffer
+	a := &Arc{
+		ApiUrl: "https://arc.gorillapool.io",
+		Client: &MockArcFailureClient{},
+		Logger: slog.New(slog.NewTextHandler(&logs, nil)),
+	}
+	tx := transaction.NewTransaction()
+
+	_, failure := a.Broadcast(tx)
+	require.NotNil(t, failure)
+	require.Empty(t, logs.String(), "debug logs are below the default level")
+
+	a.Verbose = true
+	_, failure = a.Broadcast(tx)
+	require.NotNil(t, failure)
+	require.Contains(t, logs.String(), "level=INFO msg=\"ARC broadcast response\" status=500")
+	require.Contains(t, logs.String(), "Internal Server Error")
+}
@@ -4,10 +4,12 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"log/slog"
 	"net/http"
 	"strconv"
 	"strings"
 
+	"github.com/bsv-blockchain/go-sdk/internal/logging"
 	"github.com/bsv-blockchain/go-sdk/transaction"
 	"github.com/bsv-blockchain/go-sdk/util"
 )
@@ -21,6 +23,8 @@ type TAALResponse struct {
 type TAALBroadcast struct {
 	ApiKey string
 	Client util.HTTPClient
+	// Logger receives the diagnostics of the broadcaster, slog.Default when nil.
+	Logger *slog.Logger
 }
 
 func (b *TAALBroadcast) Broadcast(t *transaction.Transaction) (*transaction.BroadcastSuccess, *transaction.BroadcastFailure) {
@@ -59,6 +63,7 @@ func (b *TAALBroadcast) BroadcastCtx(ctx context.Context, t *transaction.Transac
 		defer resp.Body.Close()
 		var taalResp TAALResponse
 		if err := json.NewDecoder(resp.Body).Decode(&taalResp); err != nil {
+			logging.OrDefault(b.Logger).DebugContext(ctx, "failed to decode TAAL response", "status", resp.StatusCode, "error", err)
 			return nil, &transaction.BroadcastFailure{
 				Code:        strconv.Itoa(resp.StatusCode),
 				Description: "unknown error",
@@ -69,6 +74,9 @@ func (b *TAALBroadcast) BroadcastCtx(ctx context.Context, t *transaction.Transac
 				Description: taalResp.Err,
 			}
 		} else {
+			if resp.StatusCode != 200 {
+				logging.OrDefault(b.Logger).DebugContext(ctx, "TAAL already knows the transaction", "txid", t.TxID().String())
+			}
 			return &transaction.BroadcastSuccess{
 				Txid: t.TxID().String(),
 			}, nil
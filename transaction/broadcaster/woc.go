@@ -6,9 +6,12 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"log/slog"
 	"net/http"
 
+	"github.com/bsv-blockchain/go-sdk/internal/logging"
 	"github.com/bsv-blockchain/go-sdk/transaction"
+	chaincfg "github.com/bsv-blockchain/go-sdk/transaction/chaincfg"
 	"github.com/bsv-blockchain/go-sdk/util"
 )
 
@@ -17,12 +20,31 @@ type WOCNetwork string
 var (
 	WOCMainnet WOCNetwork = "main"
 	WOCTestnet WOCNetwork = "test"
+	WOCSTN     WOCNetwork = "stn"
 )
 
+// WOCNetworkFromParams returns the WhatsOnChain network serving the given chain parameters.
+func WOCNetworkFromParams(params *chaincfg.Params) (WOCNetwork, error) {
+	switch params.Name {
+	case chaincfg.NetworkMain:
+		return WOCMainnet, nil
+	case chaincfg.NetworkTestNet3, chaincfg.NetworkTest:
+		// TestNet has long stood for the public test network too, as both
+		// share address and key encodings.
+		return WOCTestnet, nil
+	case chaincfg.NetworkSTN:
+		return WOCSTN, nil
+	default:
+		return "", fmt.Errorf("whatsonchain does not support network %s", params.Name)
+	}
+}
+
 type WhatsOnChain struct {
 	Network WOCNetwork
 	ApiKey  string
 	Client  util.HTTPClient
+	// Logger receives the diagnostics of the broadcaster, slog.Default when nil.
+	Logger *slog.Logger
 }
 
 func (b *WhatsOnChain) Broadcast(t *transaction.Transaction) (
@@ -83,6 +105,8 @@ func (b *WhatsOnChain) BroadcastCtx(ctx context.Context, t *transaction.Transact
 			defer resp.Body.Close()
 			if resp.StatusCode != 200 {
 				if body, err := io.ReadAll(resp.Body); err != nil {
+					logging.OrDefault(b.Logger).DebugContext(ctx, "failed to read WhatsOnChain error response",
+						"status", resp.StatusCode, "error", err)
 					return nil, &transaction.BroadcastFailure{
 						Code:        fmt.Sprintf("%d", resp.StatusCode),
 						Description: "unknown error",
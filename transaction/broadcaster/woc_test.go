@@ -8,6 +8,7 @@ import (
 	"testing"
 
 	"github.com/bsv-blockchain/go-sdk/transaction"
+	chaincfg "github.com/bsv-blockchain/go-sdk/transaction/chaincfg"
 	"github.com/stretchr/testify/require"
 )
 
@@ -270,3 +271,24 @@ func TestWhatsOnChainBroadcastTestnet(t *testing.T) {
 	require.NotNil(t, success)
 	require.Nil(t, failure)
 }
+
+func TestWOCNetworkFromParams(t *testing.T) {
+	network, err := WOCNetworkFromParams(&chaincfg.MainNet)
+	require.NoError(t, err)
+	require.Equal(t, WOCMainnet, network)
+
+	network, err = WOCNetworkFromParams(&chaincfg.TestNet)
+	require.NoError(t, err)
+	require.Equal(t, WOCTestnet, network)
+
+	network, err = WOCNetworkFromParams(&chaincfg.TestNet3)
+	require.NoError(t, err)
+	require.Equal(t, WOCTestnet, network)
+
+	network, err = WOCNetworkFromParams(&chaincfg.STN)
+	require.NoError(t, err)
+	require.Equal(t, WOCSTN, network)
+
+	_, err = WOCNetworkFromParams(&chaincfg.Params{Name: "bogus"})
+	require.Error(t, err)
+}
@@ -7,7 +7,11 @@ import (
 // Constants for network names.
 const (
 	NetworkMain = "mainnet"
+	// NetworkTest names TestNet, the regression test network.
 	NetworkTest = "regtest"
+	// NetworkTestNet3 names TestNet3, the public test network.
+	NetworkTestNet3 = "testnet"
+	NetworkSTN      = "stn"
 )
 
 var (
@@ -16,6 +20,16 @@ var (
 	// private extended key is not registered.
 	ErrUnknownHDKeyID = errors.New("unknown hd private extended key bytes")
 
+	// ErrDuplicateNet describes an error where the parameters for a Bitcoin
+	// network could not be set due to the network already being a standard
+	// network or previously-registered into this package.
+	ErrDuplicateNet = errors.New("duplicate Bitcoin network")
+
+	// ErrUnknownNet describes an error where no network parameters are
+	// registered under the requested name.
+	ErrUnknownNet = errors.New("unknown Bitcoin network")
+
+	registeredNets    = make(map[string]*Params)
 	scriptHashAddrIDs = make(map[byte]struct{})
 	pubKeyHashAddrIDs = make(map[byte]struct{})
 	hdPrivToPubKeyIDs = make(map[[4]byte][]byte)
@@ -28,6 +42,9 @@ type Params struct {
 	// Name defines a human-readable identifier for the network.
 	Name string
 
+	// DefaultPort defines the default peer-to-peer port for the network.
+	DefaultPort string
+
 	// Address encoding magics
 	LegacyPubKeyHashAddrID byte // First byte of a P2PKH address
 	LegacyScriptHashAddrID byte // First byte of a P2SH address
@@ -40,10 +57,12 @@ type Params struct {
 
 // MainNet defines the network parameters for the main Bitcoin network.
 var MainNet = Params{
-	Name: NetworkMain,
+	Name:        NetworkMain,
+	DefaultPort: "8333",
 
 	// Address encoding magics
 	LegacyPubKeyHashAddrID: 0x00, // starts with 1
+	LegacyScriptHashAddrID: 0x05, // starts with 3
 	PrivateKeyID:           0x80, // starts with 5 (uncompressed) or K (compressed)
 
 	// BIP32 hierarchical deterministic extended key magics
@@ -55,10 +74,44 @@ var MainNet = Params{
 // Bitcoin network.  Not to be confused with the test Bitcoin network (version
 // 3), this network is sometimes simply called "testnet".
 var TestNet = Params{
-	Name: NetworkTest,
+	Name:        NetworkTest,
+	DefaultPort: "18444",
+
+	// Address encoding magics
+	LegacyPubKeyHashAddrID: 0x6f, // starts with m or n
+	LegacyScriptHashAddrID: 0xc4, // starts with 2
+	PrivateKeyID:           0xef, // starts with 9 (uncompressed) or c (compressed)
+
+	// BIP32 hierarchical deterministic extended key magics
+	HDPrivateKeyID: [4]byte{0x04, 0x35, 0x83, 0x94}, // starts with tprv
+	HDPublicKeyID:  [4]byte{0x04, 0x35, 0x87, 0xcf}, // starts with tpub
+}
+
+// STN defines the network parameters for the scaling test network. It
+// shares address and key encodings with TestNet.
+var STN = Params{
+	Name:        NetworkSTN,
+	DefaultPort: "9333",
+
+	// Address encoding magics
+	LegacyPubKeyHashAddrID: 0x6f, // starts with m or n
+	LegacyScriptHashAddrID: 0xc4, // starts with 2
+	PrivateKeyID:           0xef, // starts with 9 (uncompressed) or c (compressed)
+
+	// BIP32 hierarchical deterministic extended key magics
+	HDPrivateKeyID: [4]byte{0x04, 0x35, 0x83, 0x94}, // starts with tprv
+	HDPublicKeyID:  [4]byte{0x04, 0x35, 0x87, 0xcf}, // starts with tpub
+}
+
+// TestNet3 defines the network parameters for the public test Bitcoin network
+// (version 3). It shares address and key encodings with TestNet.
+var TestNet3 = Params{
+	Name:        NetworkTestNet3,
+	DefaultPort: "18333",
 
 	// Address encoding magics
 	LegacyPubKeyHashAddrID: 0x6f, // starts with m or n
+	LegacyScriptHashAddrID: 0xc4, // starts with 2
 	PrivateKeyID:           0xef, // starts with 9 (uncompressed) or c (compressed)
 
 	// BIP32 hierarchical deterministic extended key magics
@@ -66,6 +119,36 @@ var TestNet = Params{
 	HDPublicKeyID:  [4]byte{0x04, 0x35, 0x87, 0xcf}, // starts with tpub
 }
 
+// IsMainNet returns true if the parameters encode addresses and keys for the
+// main network.
+func (p *Params) IsMainNet() bool {
+	return p.LegacyPubKeyHashAddrID == MainNet.LegacyPubKeyHashAddrID &&
+		p.PrivateKeyID == MainNet.PrivateKeyID
+}
+
+// IsPubKeyHashAddrID returns whether the id is an identifier known to prefix a
+// pay-to-pubkey-hash address on any registered network.
+func IsPubKeyHashAddrID(id byte) bool {
+	_, ok := pubKeyHashAddrIDs[id]
+	return ok
+}
+
+// IsScriptHashAddrID returns whether the id is an identifier known to prefix a
+// pay-to-script-hash address on any registered network.
+func IsScriptHashAddrID(id byte) bool {
+	_, ok := scriptHashAddrIDs[id]
+	return ok
+}
+
+// ParamsForName returns the registered network parameters with the given name.
+func ParamsForName(name string) (*Params, error) {
+	params, ok := registeredNets[name]
+	if !ok {
+		return nil, ErrUnknownNet
+	}
+	return params, nil
+}
+
 // HDPrivateKeyToPublicKeyID accepts a private hierarchical deterministic
 // extended key id and returns the associated public key id.  When the provided
 // id is not registered, the ErrUnknownHDKeyID error will be returned.
@@ -94,6 +177,10 @@ func HDPrivateKeyToPublicKeyID(id []byte) ([]byte, error) {
 // parameters based on inputs and work regardless of the network being standard
 // or not.
 func Register(params *Params) error {
+	if _, ok := registeredNets[params.Name]; ok {
+		return ErrDuplicateNet
+	}
+	registeredNets[params.Name] = params
 	scriptHashAddrIDs[params.LegacyScriptHashAddrID] = struct{}{}
 	pubKeyHashAddrIDs[params.LegacyPubKeyHashAddrID] = struct{}{}
 	hdPrivToPubKeyIDs[params.HDPrivateKeyID] = params.HDPublicKeyID[:]
@@ -112,4 +199,6 @@ func init() {
 	// Register all default networks when the package is initialized.
 	mustRegister(&MainNet)
 	mustRegister(&TestNet)
+	mustRegister(&STN)
+	mustRegister(&TestNet3)
 }
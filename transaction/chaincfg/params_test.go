@@ -0,0 +1,31 @@
+package transaction
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParamsForName(t *testing.T) {
+	for _, params := range []*Params{&MainNet, &TestNet, &STN, &TestNet3} {
+		got, err := ParamsForName(params.Name)
+		require.NoError(t, err)
+		require.Same(t, params, got)
+		require.True(t, IsPubKeyHashAddrID(params.LegacyPubKeyHashAddrID))
+		require.True(t, IsScriptHashAddrID(params.LegacyScriptHashAddrID))
+		require.NotEmpty(t, params.DefaultPort)
+	}
+
+	// TestNet keeps the name it has always had.
+	require.Equal(t, "regtest", TestNet.Name)
+	require.Equal(t, "testnet", TestNet3.Name)
+
+	_, err := ParamsForName("bogus")
+	require.ErrorIs(t, err, ErrUnknownNet)
+
+	require.ErrorIs(t, Register(&MainNet), ErrDuplicateNet)
+
+	require.True(t, MainNet.IsMainNet())
+	require.False(t, TestNet.IsMainNet())
+	require.False(t, TestNet3.IsMainNet())
+}
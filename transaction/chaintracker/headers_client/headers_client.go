@@ -8,7 +8,9 @@ import (
 	"io"
 	"net/http"
 
+	"github.com/bsv-blockchain/go-sdk/block"
 	"github.com/bsv-blockchain/go-sdk/chainhash"
+	"github.com/bsv-blockchain/go-sdk/util"
 )
 
 type Header struct {
@@ -22,6 +24,19 @@ type Header struct {
 	PreviousBlock chainhash.Hash `json:"prevBlockHash"`
 }
 
+// BlockHeader converts the header into a block.Header, which can compute the
+// block hash and check proof of work.
+func (h *Header) BlockHeader() *block.Header {
+	return &block.Header{
+		Version:    h.Version,
+		PrevBlock:  h.PreviousBlock,
+		MerkleRoot: h.MerkleRoot,
+		Timestamp:  h.Timestamp,
+		Bits:       h.Bits,
+		Nonce:      h.Nonce,
+	}
+}
+
 type State struct {
 	Header Header `json:"header"`
 	State  string `json:"state"`
@@ -37,17 +52,38 @@ type Client struct {
 	Ctx        context.Context
 	Url        string
 	ApiKey     string
-	httpClient *http.Client
+	httpClient util.HTTPClient
 }
 
-func (c *Client) getHTTPClient() *http.Client {
+// Option configures a Client created with New.
+type Option func(*Client)
+
+// WithHTTPClient sets the client used for requests, for example to route them
+// through a proxy, use custom TLS settings or rate limit them with a
+// ratelimit.Client.
+func WithHTTPClient(client util.HTTPClient) Option {
+	return func(c *Client) {
+		c.httpClient = client
+	}
+}
+
+// New creates a Client for the block headers service at url.
+func New(url, apiKey string, opts ...Option) *Client {
+	c := &Client{Url: url, ApiKey: apiKey}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+func (c *Client) getHTTPClient() util.HTTPClient {
 	if c.httpClient != nil {
 		return c.httpClient
 	}
 	return &http.Client{}
 }
 
-func (c Client) IsValidRootForHeight(ctx context.Context, root *chainhash.Hash, height uint32) (bool, error) {
+func (c *Client) IsValidRootForHeight(ctx context.Context, root *chainhash.Hash, height uint32) (bool, error) {
 	type requestBody struct {
 		MerkleRoot  string `json:"merkleRoot"`
 		BlockHeight uint32 `json:"blockHeight"`
@@ -67,8 +103,7 @@ func (c Client) IsValidRootForHeight(ctx context.Context, root *chainhash.Hash,
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("Authorization", "Bearer "+c.ApiKey)
 
-	client := &http.Client{}
-	resp, err := client.Do(req)
+	resp, err := c.getHTTPClient().Do(req)
 	if err != nil {
 		return false, fmt.Errorf("error sending request: %v", err)
 	}
@@ -93,14 +128,13 @@ func (c Client) IsValidRootForHeight(ctx context.Context, root *chainhash.Hash,
 
 func (c *Client) BlockByHeight(ctx context.Context, height uint32) (*Header, error) {
 	headers := []Header{}
-	client := &http.Client{}
 	url := fmt.Sprintf("%s/api/v1/chain/header/byHeight?height=%d", c.Url, height)
-	req, err := http.NewRequest("GET", url, nil)
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
 	if err != nil {
 		return nil, err
 	}
 	req.Header.Set("Authorization", "Bearer "+c.ApiKey)
-	if res, err := client.Do(req); err != nil {
+	if res, err := c.getHTTPClient().Do(req); err != nil {
 		return nil, err
 	} else {
 		defer res.Body.Close()
@@ -127,13 +161,12 @@ func (c *Client) BlockByHeight(ctx context.Context, height uint32) (*Header, err
 
 func (c *Client) GetBlockState(ctx context.Context, hash string) (*State, error) {
 	headerState := &State{}
-	client := &http.Client{}
-	req, err := http.NewRequest("GET", fmt.Sprintf("%s/api/v1/chain/header/state/%s", c.Url, hash), nil)
+	req, err := http.NewRequestWithContext(ctx, "GET", fmt.Sprintf("%s/api/v1/chain/header/state/%s", c.Url, hash), nil)
 	if err != nil {
 		return nil, err
 	}
 	req.Header.Set("Authorization", "Bearer "+c.ApiKey)
-	if res, err := client.Do(req); err != nil {
+	if res, err := c.getHTTPClient().Do(req); err != nil {
 		return nil, err
 	} else {
 		defer res.Body.Close()
@@ -146,13 +179,12 @@ func (c *Client) GetBlockState(ctx context.Context, hash string) (*State, error)
 
 func (c *Client) GetChaintip(ctx context.Context) (*State, error) {
 	headerState := &State{}
-	client := &http.Client{}
-	req, err := http.NewRequest("GET", fmt.Sprintf("%s/api/v1/chain/tip/longest", c.Url), nil)
+	req, err := http.NewRequestWithContext(ctx, "GET", fmt.Sprintf("%s/api/v1/chain/tip/longest", c.Url), nil)
 	if err != nil {
 		return nil, err
 	}
 	req.Header.Set("Authorization", "Bearer "+c.ApiKey)
-	if res, err := client.Do(req); err != nil {
+	if res, err := c.getHTTPClient().Do(req); err != nil {
 		return nil, err
 	} else {
 		defer res.Body.Close()
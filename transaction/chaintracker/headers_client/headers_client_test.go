@@ -448,3 +448,39 @@ func TestWebhookWithMultipleErrorCounts(t *testing.T) {
 		})
 	}
 }
+
+func TestHeaderBlockHeader(t *testing.T) {
+	merkleRoot, err := chainhash.NewHashFromHex("4a5e1e4baab89f3a32518a88c31bc87f618f76673e2cc77ab2127b7afdeda33b")
+	require.NoError(t, err)
+	header := Header{
+		Version:    1,
+		MerkleRoot: *merkleRoot,
+		Timestamp:  1231006505,
+		Bits:       0x1d00ffff,
+		Nonce:      2083236893,
+	}
+
+	blockHeader := header.BlockHeader()
+	require.NoError(t, blockHeader.CheckProofOfWork())
+	hash := blockHeader.Hash()
+	require.Equal(t, "000000000019d6689c085ae165831e934ff763ae46a2a6c172b3f1b60a8ce26f", hash.String())
+}
+
+func TestCurrentHeightHonoursContext(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/api/v1/chain/tip/longest", r.URL.Path)
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"state":"LONGEST_CHAIN","height":800000}`))
+	}))
+	defer ts.Close()
+
+	client := New(ts.URL, "test-api-key", WithHTTPClient(ts.Client()))
+	height, err := client.CurrentHeight(t.Context())
+	require.NoError(t, err)
+	require.Equal(t, uint32(800000), height)
+
+	ctx, cancel := context.WithCancel(t.Context())
+	cancel()
+	_, err = client.CurrentHeight(ctx)
+	require.ErrorIs(t, err, context.Canceled)
+}
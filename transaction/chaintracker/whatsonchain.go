@@ -1,3 +1,5 @@
+//go:build !embedded
+
 package chaintracker
 
 import (
@@ -7,6 +9,8 @@ import (
 	"net/http"
 
 	"github.com/bsv-blockchain/go-sdk/chainhash"
+	chaincfg "github.com/bsv-blockchain/go-sdk/transaction/chaincfg"
+	"github.com/bsv-blockchain/go-sdk/util"
 )
 
 type Network string
@@ -25,26 +29,66 @@ type BlockHeader struct {
 var (
 	MainNet Network = "main"
 	TestNet Network = "test"
+	STN     Network = "stn"
 )
 
+// NetworkFromParams returns the WhatsOnChain network serving the given chain parameters.
+func NetworkFromParams(params *chaincfg.Params) (Network, error) {
+	switch params.Name {
+	case chaincfg.NetworkMain:
+		return MainNet, nil
+	case chaincfg.NetworkTestNet3, chaincfg.NetworkTest:
+		// TestNet has long stood for the public test network too, as both
+		// share address and key encodings.
+		return TestNet, nil
+	case chaincfg.NetworkSTN:
+		return STN, nil
+	default:
+		return "", fmt.Errorf("whatsonchain does not support network %s", params.Name)
+	}
+}
+
 type WhatsOnChain struct {
 	Network Network
 	ApiKey  string
 	baseURL string
-	client  *http.Client
+	client  util.HTTPClient
 }
 
 type ChainInfo struct {
 	Blocks uint32 `json:"blocks"`
 }
 
-func NewWhatsOnChain(network Network, apiKey string) *WhatsOnChain {
-	return &WhatsOnChain{
+// WhatsOnChainOption configures a WhatsOnChain chain tracker.
+type WhatsOnChainOption func(*WhatsOnChain)
+
+// WithHTTPClient sets the client used for requests, for example to route them
+// through a proxy, use custom TLS settings or rate limit them with a
+// ratelimit.Client. http.DefaultClient is used by default.
+func WithHTTPClient(client util.HTTPClient) WhatsOnChainOption {
+	return func(w *WhatsOnChain) {
+		w.client = client
+	}
+}
+
+func NewWhatsOnChain(network Network, apiKey string, opts ...WhatsOnChainOption) *WhatsOnChain {
+	w := &WhatsOnChain{
 		Network: network,
 		ApiKey:  apiKey,
 		baseURL: fmt.Sprintf("https://api.whatsonchain.com/v1/bsv/%s", network),
 		client:  http.DefaultClient,
 	}
+	for _, opt := range opts {
+		opt(w)
+	}
+	return w
+}
+
+func (w *WhatsOnChain) httpClient() util.HTTPClient {
+	if w.client == nil {
+		return http.DefaultClient
+	}
+	return w.client
 }
 
 // Assuming BlockHeader is defined elsewhere
@@ -57,7 +101,7 @@ func (w *WhatsOnChain) GetBlockHeader(ctx context.Context, height uint32) (heade
 
 	req.Header.Set("Authorization", w.ApiKey)
 
-	resp, err := http.DefaultClient.Do(req)
+	resp, err := w.httpClient().Do(req)
 	if err != nil {
 		return nil, err
 	}
@@ -81,6 +125,9 @@ func (w *WhatsOnChain) GetBlockHeader(ctx context.Context, height uint32) (heade
 func (w *WhatsOnChain) IsValidRootForHeight(ctx context.Context, root *chainhash.Hash, height uint32) (bool, error) {
 	if header, err := w.GetBlockHeader(ctx, height); err != nil {
 		return false, err
+	} else if header == nil {
+		// No block at this height yet
+		return false, nil
 	} else {
 		return header.MerkleRoot.IsEqual(root), nil
 	}
@@ -96,7 +143,7 @@ func (w *WhatsOnChain) CurrentHeight(ctx context.Context) (height uint32, err er
 
 	req.Header.Set("Authorization", w.ApiKey)
 
-	resp, err := http.DefaultClient.Do(req)
+	resp, err := w.httpClient().Do(req)
 	if err != nil {
 		return
 	}
@@ -1,3 +1,5 @@
+//go:build !embedded
+
 // whatsonchain_test.go
 
 package chaintracker
@@ -210,3 +212,25 @@ func TestWhatsOnChainCurrentHeight(t *testing.T) {
 		t.Fatalf("expected height %d, got %d", expectedBlocks, height)
 	}
 }
+
+// roundTripFunc routes requests to a function instead of the network.
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) Do(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func TestWhatsOnChainWithHTTPClient(t *testing.T) {
+	var requested []string
+	client := roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		requested = append(requested, req.URL.String())
+		return &http.Response{StatusCode: http.StatusNotFound, Body: http.NoBody}, nil
+	})
+	woc := NewWhatsOnChain(MainNet, "testapikey", WithHTTPClient(client))
+
+	// A height without a block yet is not a valid root rather than a panic
+	valid, err := woc.IsValidRootForHeight(t.Context(), &chainhash.Hash{}, 100)
+	require.NoError(t, err)
+	require.False(t, valid)
+	require.Equal(t, []string{"https://api.whatsonchain.com/v1/bsv/main/block/100/header"}, requested)
+}
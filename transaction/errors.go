@@ -49,6 +49,9 @@ var (
 	ErrFeeTypeNotFound  = errors.New("feetype not found")
 	ErrFeeQuoteNotInit  = errors.New("feeQuote has not been initialized, call NewFeeQuote()")
 	ErrUnknownFeeType   = errors.New("unknown fee type")
+
+	// ErrNoUnlockingScript an input has neither an unlocking script nor a template to estimate one.
+	ErrNoUnlockingScript = errors.New("inputs must have an unlocking script or an unlocker")
 )
 
 // Sentinel errors reported by Fund.
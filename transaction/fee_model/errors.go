@@ -1,7 +1,7 @@
 package feemodel
 
-import "errors"
+import "github.com/bsv-blockchain/go-sdk/transaction"
 
 var (
-	ErrNoUnlockingScript = errors.New("inputs must have an unlocking script or an unlocker")
+	ErrNoUnlockingScript = transaction.ErrNoUnlockingScript
 )
@@ -3,6 +3,8 @@ package transaction
 import (
 	"slices"
 
+	"github.com/bsv-blockchain/go-sdk/primitives/amount"
+	"github.com/bsv-blockchain/go-sdk/util"
 	"github.com/pkg/errors"
 )
 
@@ -17,28 +19,56 @@ type FeeModel interface {
 	ComputeFee(tx *Transaction) (uint64, error)
 }
 
+// EstimateSize returns the serialized size of the transaction in bytes once all
+// inputs are signed. Inputs that already carry an unlocking script are counted as-is;
+// unsigned inputs are sized using their UnlockingScriptTemplate's EstimateLength.
+func (tx *Transaction) EstimateSize() (int, error) {
+	size := 4 // version
+	size += util.VarInt(len(tx.Inputs)).Length()
+	for vin, i := range tx.Inputs {
+		size += 40 // prev txid, output index and sequence
+		var scriptLen int
+		if i.UnlockingScript != nil && len(*i.UnlockingScript) > 0 {
+			scriptLen = len(*i.UnlockingScript)
+		} else if i.UnlockingScriptTemplate != nil {
+			scriptLen = int(i.UnlockingScriptTemplate.EstimateLength(tx, uint32(vin)))
+		} else {
+			return 0, ErrNoUnlockingScript
+		}
+		size += util.VarInt(scriptLen).Length() + scriptLen
+	}
+	size += util.VarInt(len(tx.Outputs)).Length()
+	for _, o := range tx.Outputs {
+		scriptLen := 0
+		if o.LockingScript != nil {
+			scriptLen = len(*o.LockingScript)
+		}
+		size += 8 + util.VarInt(scriptLen).Length() + scriptLen
+	}
+	size += 4 // lock time
+	return size, nil
+}
+
+// EstimateFee returns the fee the given model would charge for the transaction,
+// allowing fees to be computed before any input is signed.
+func (tx *Transaction) EstimateFee(f FeeModel) (uint64, error) {
+	return f.ComputeFee(tx)
+}
+
 // Fee computes the fee for the transaction.
 func (tx *Transaction) Fee(f FeeModel, changeDistribution ChangeDistribution) error {
 	fee, err := f.ComputeFee(tx)
 	if err != nil {
 		return err
 	}
-	satsIn := uint64(0)
-	for _, i := range tx.Inputs {
-		sourceSats := i.SourceTxSatoshis()
-		if sourceSats == nil {
-			return ErrEmptyPreviousTx
-		}
-		satsIn += *sourceSats
+	satsIn, err := tx.TotalInputSatoshis()
+	if err != nil {
+		return err
 	}
-	satsOut := uint64(0)
+	satsOut := tx.TotalOutputSatoshis() - tx.TotalChangeSatoshis()
 	changeOuts := uint64(0)
-	for _, o := range tx.Outputs {
-		if !o.Change {
-			satsOut += o.Satoshis
-		} else {
-			changeOuts++
-		}
+	for range tx.ChangeOutputs() {
+		changeOuts++
 	}
 	if satsIn < satsOut+fee {
 		return ErrInsufficientInputs
@@ -56,20 +86,29 @@ func (tx *Transaction) Fee(f FeeModel, changeDistribution ChangeDistribution) er
 			return errors.New("not-implemented")
 		case ChangeDistributionEqual:
 			changePerOutput := change / changeOuts
-			for _, o := range tx.Outputs {
-				if o.Change {
-					o.Satoshis = changePerOutput
-				}
+			for _, o := range tx.ChangeOutputs() {
+				o.Satoshis = changePerOutput
 			}
 		}
 	}
 	return nil
 }
 
-func (tx *Transaction) GetFee() (total uint64, err error) {
-	if totalIn, err := tx.TotalInputSatoshis(); err != nil {
+// GetFee returns the fee paid by the transaction, the difference between its
+// input and output satoshis. Every input must have its source output attached,
+// and outputs exceeding the inputs fail with ErrInsufficientInputs. Totals that
+// would wrap around fail with amount.ErrOverflow.
+func (tx *Transaction) GetFee() (uint64, error) {
+	totalIn, err := tx.TotalInputSatoshis()
+	if err != nil {
 		return 0, err
-	} else {
-		return totalIn - tx.TotalOutputSatoshis(), nil
 	}
+	totalOut, err := tx.outputAmount()
+	if err != nil {
+		return 0, err
+	}
+	if amount.Satoshis(totalIn) < totalOut {
+		return 0, ErrInsufficientInputs
+	}
+	return totalIn - uint64(totalOut), nil
 }
@@ -39,6 +39,15 @@ type TransactionInput struct {
 	UnlockingScriptTemplate UnlockingScriptTemplate
 }
 
+// SourceOutpoint returns the outpoint of the output spent by the input.
+func (i *TransactionInput) SourceOutpoint() Outpoint {
+	o := Outpoint{Index: i.SourceTxOutIndex}
+	if i.SourceTXID != nil {
+		o.Txid = *i.SourceTXID
+	}
+	return o
+}
+
 func (i *TransactionInput) SourceTxOutput() *TransactionOutput {
 	if i.SourceTransaction != nil {
 		return i.SourceTransaction.Outputs[i.SourceTxOutIndex]
@@ -98,8 +107,7 @@ func (i *TransactionInput) readFrom(r io.Reader, extended bool) (int64, error) {
 		return bytesRead, err
 	}
 
-	scriptBytes := make([]byte, l)
-	n, err = io.ReadFull(r, scriptBytes)
+	scriptBytes, n, err := readBytes(r, uint64(l))
 	bytesRead += int64(n)
 	if err != nil {
 		return bytesRead, errors.Wrapf(err, "script(%d): got %d bytes", l, n)
@@ -135,8 +143,7 @@ func (i *TransactionInput) readFrom(r io.Reader, extended bool) (int64, error) {
 			return bytesRead, err
 		}
 
-		scriptBytes := make([]byte, scriptLen)
-		n, err := io.ReadFull(r, scriptBytes)
+		scriptBytes, n, err := readBytes(r, uint64(scriptLen))
 		bytesRead += int64(n)
 		if err != nil {
 			return bytesRead, errors.Wrapf(err, "script(%d): got %d bytes", scriptLen.Length(), n)
@@ -0,0 +1,264 @@
+package transaction
+
+import (
+	"bytes"
+	"encoding/binary"
+
+	"github.com/bsv-blockchain/go-sdk/chainhash"
+	crypto "github.com/bsv-blockchain/go-sdk/primitives/hash"
+	"github.com/bsv-blockchain/go-sdk/script"
+	"github.com/bsv-blockchain/go-sdk/util"
+	"github.com/pkg/errors"
+)
+
+// ErrLazyExtendedFormat is returned when the lazy parser is given an extended format transaction.
+var ErrLazyExtendedFormat = errors.New("lazy parsing does not support extended format transactions")
+
+// lazyScriptRef records where an input or output starts and where its script sits
+// within the raw transaction buffer.
+type lazyScriptRef struct {
+	start       int
+	scriptStart int
+	scriptLen   int
+}
+
+// LazyTransaction is a view over a serialized transaction that only records the
+// offsets of its inputs and outputs. Scripts are not copied or parsed until they
+// are accessed, which keeps allocations low when scanning large numbers of
+// transactions where only txids and output values are needed.
+//
+// The LazyTransaction references the buffer it was created from, which must not
+// be modified while the LazyTransaction is in use.
+type LazyTransaction struct {
+	Version  uint32
+	LockTime uint32
+
+	raw     []byte
+	inputs  []lazyScriptRef
+	outputs []lazyScriptRef
+	txid    *chainhash.Hash
+}
+
+// NewTransactionFromBytesLazy creates a LazyTransaction from a byte slice that contains
+// exactly 1 transaction.
+func NewTransactionFromBytesLazy(b []byte) (*LazyTransaction, error) {
+	tx, used, err := NewLazyTransactionFromStream(b)
+	if err != nil {
+		return nil, err
+	}
+
+	if used != len(b) {
+		return nil, ErrNLockTimeLength
+	}
+
+	return tx, nil
+}
+
+// NewLazyTransactionFromStream creates a LazyTransaction from the start of a byte slice
+// that may contain many transactions one after another, returning the bytes used.
+func NewLazyTransactionFromStream(b []byte) (*LazyTransaction, int, error) {
+	p := lazyParser{buf: b}
+	tx := &LazyTransaction{}
+
+	tx.Version = p.uint32()
+	inputCount := p.varInt()
+	if p.err == nil && inputCount == 0 && p.pos+4 < len(b) && b[p.pos] == 0 &&
+		bytes.Equal(b[p.pos+1:p.pos+5], []byte{0, 0, 0, 0xEF}) {
+		return nil, 0, ErrLazyExtendedFormat
+	}
+
+	if p.err == nil {
+		tx.inputs = make([]lazyScriptRef, 0, min(inputCount, uint64(len(b))/41))
+	}
+	for i := uint64(0); i < inputCount && p.err == nil; i++ {
+		ref := lazyScriptRef{start: p.pos}
+		p.skip(36) // previous txid and output index
+		ref.scriptLen = int(p.varInt())
+		ref.scriptStart = p.pos
+		p.skip(ref.scriptLen)
+		p.skip(4) // sequence
+		tx.inputs = append(tx.inputs, ref)
+	}
+
+	outputCount := p.varInt()
+	if p.err == nil {
+		tx.outputs = make([]lazyScriptRef, 0, min(outputCount, uint64(len(b))/9))
+	}
+	for i := uint64(0); i < outputCount && p.err == nil; i++ {
+		ref := lazyScriptRef{start: p.pos}
+		p.skip(8) // satoshis
+		ref.scriptLen = int(p.varInt())
+		ref.scriptStart = p.pos
+		p.skip(ref.scriptLen)
+		tx.outputs = append(tx.outputs, ref)
+	}
+
+	tx.LockTime = p.uint32()
+	if p.err != nil {
+		return nil, p.pos, p.err
+	}
+
+	tx.raw = b[:p.pos]
+	return tx, p.pos, nil
+}
+
+// Bytes returns the serialized transaction. The returned slice aliases the buffer
+// the LazyTransaction was created from.
+func (tx *LazyTransaction) Bytes() []byte {
+	return tx.raw
+}
+
+// Size returns the size of the serialized transaction in bytes.
+func (tx *LazyTransaction) Size() int {
+	return len(tx.raw)
+}
+
+// TxID returns the transaction ID, computing and caching it on first use.
+func (tx *LazyTransaction) TxID() *chainhash.Hash {
+	if tx.txid == nil {
+		tx.txid, _ = chainhash.NewHash(crypto.Sha256d(tx.raw))
+	}
+	return tx.txid
+}
+
+// InputCount returns the number of inputs in the transaction.
+func (tx *LazyTransaction) InputCount() int {
+	return len(tx.inputs)
+}
+
+// OutputCount returns the number of outputs in the transaction.
+func (tx *LazyTransaction) OutputCount() int {
+	return len(tx.outputs)
+}
+
+// IsCoinbase determines if this transaction is a coinbase by checking if the
+// single input spends the null outpoint.
+func (tx *LazyTransaction) IsCoinbase() bool {
+	if len(tx.inputs) != 1 {
+		return false
+	}
+	start := tx.inputs[0].start
+	for _, b := range tx.raw[start : start+32] {
+		if b != 0 {
+			return false
+		}
+	}
+	return binary.LittleEndian.Uint32(tx.raw[start+32:start+36]) == 0xffffffff
+}
+
+// Input parses and returns the input at the given index.
+func (tx *LazyTransaction) Input(index int) (*TransactionInput, error) {
+	if index < 0 || index >= len(tx.inputs) {
+		return nil, ErrInputNoExist
+	}
+	ref := tx.inputs[index]
+	sourceTXID, err := chainhash.NewHash(tx.raw[ref.start : ref.start+32])
+	if err != nil {
+		return nil, err
+	}
+	scriptEnd := ref.scriptStart + ref.scriptLen
+	return &TransactionInput{
+		SourceTXID:       sourceTXID,
+		SourceTxOutIndex: binary.LittleEndian.Uint32(tx.raw[ref.start+32 : ref.start+36]),
+		UnlockingScript:  script.NewFromBytes(tx.raw[ref.scriptStart:scriptEnd]),
+		SequenceNumber:   binary.LittleEndian.Uint32(tx.raw[scriptEnd : scriptEnd+4]),
+	}, nil
+}
+
+// Output parses and returns the output at the given index.
+func (tx *LazyTransaction) Output(index int) (*TransactionOutput, error) {
+	if index < 0 || index >= len(tx.outputs) {
+		return nil, ErrOutputNoExist
+	}
+	ref := tx.outputs[index]
+	return &TransactionOutput{
+		Satoshis:      binary.LittleEndian.Uint64(tx.raw[ref.start : ref.start+8]),
+		LockingScript: script.NewFromBytes(tx.raw[ref.scriptStart : ref.scriptStart+ref.scriptLen]),
+	}, nil
+}
+
+// OutputSatoshis returns the value of the output at the given index without
+// touching its locking script.
+func (tx *LazyTransaction) OutputSatoshis(index int) (uint64, error) {
+	if index < 0 || index >= len(tx.outputs) {
+		return 0, ErrOutputNoExist
+	}
+	start := tx.outputs[index].start
+	return binary.LittleEndian.Uint64(tx.raw[start : start+8]), nil
+}
+
+// OutputLockingScriptBytes returns the locking script of the output at the given
+// index. The returned slice aliases the underlying buffer and must not be modified.
+func (tx *LazyTransaction) OutputLockingScriptBytes(index int) ([]byte, error) {
+	if index < 0 || index >= len(tx.outputs) {
+		return nil, ErrOutputNoExist
+	}
+	ref := tx.outputs[index]
+	return tx.raw[ref.scriptStart : ref.scriptStart+ref.scriptLen : ref.scriptStart+ref.scriptLen], nil
+}
+
+// TotalOutputSatoshis returns the total satoshis output by the transaction.
+func (tx *LazyTransaction) TotalOutputSatoshis() (total uint64) {
+	for _, ref := range tx.outputs {
+		total += binary.LittleEndian.Uint64(tx.raw[ref.start : ref.start+8])
+	}
+	return total
+}
+
+// Transaction fully parses the LazyTransaction into a Transaction.
+func (tx *LazyTransaction) Transaction() (*Transaction, error) {
+	return NewTransactionFromBytes(tx.raw)
+}
+
+// lazyParser walks a transaction buffer, holding the first error encountered.
+type lazyParser struct {
+	buf []byte
+	pos int
+	err error
+}
+
+func (p *lazyParser) skip(n int) {
+	if p.err != nil {
+		return
+	}
+	if n < 0 || len(p.buf)-p.pos < n {
+		p.err = errors.Wrapf(ErrTxTooShort, "need %d bytes at offset %d", n, p.pos)
+		return
+	}
+	p.pos += n
+}
+
+func (p *lazyParser) uint32() uint32 {
+	start := p.pos
+	p.skip(4)
+	if p.err != nil {
+		return 0
+	}
+	return binary.LittleEndian.Uint32(p.buf[start:p.pos])
+}
+
+func (p *lazyParser) varInt() uint64 {
+	if p.err != nil {
+		return 0
+	}
+	if p.pos >= len(p.buf) {
+		p.err = errors.Wrapf(ErrTxTooShort, "need varint at offset %d", p.pos)
+		return 0
+	}
+	size := 1
+	switch p.buf[p.pos] {
+	case 0xff:
+		size = 9
+	case 0xfe:
+		size = 5
+	case 0xfd:
+		size = 3
+	}
+	if len(p.buf)-p.pos < size {
+		p.err = errors.Wrapf(ErrTxTooShort, "need %d byte varint at offset %d", size, p.pos)
+		return 0
+	}
+	v, _ := util.NewVarIntFromBytes(p.buf[p.pos:])
+	p.pos += size
+	return uint64(v)
+}
@@ -0,0 +1,114 @@
+package transaction_test
+
+import (
+	"testing"
+
+	"github.com/bsv-blockchain/go-sdk/transaction"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewTransactionFromBytesLazy(t *testing.T) {
+	beefTx, err := transaction.NewTransactionFromBEEFHex(BRC62Hex)
+	require.NoError(t, err)
+
+	for _, tx := range []*transaction.Transaction{beefTx, beefTx.Inputs[0].SourceTransaction} {
+		raw := tx.Bytes()
+		lazy, err := transaction.NewTransactionFromBytesLazy(raw)
+		require.NoError(t, err)
+
+		require.Equal(t, tx.TxID(), lazy.TxID())
+		require.Equal(t, tx.Version, lazy.Version)
+		require.Equal(t, tx.LockTime, lazy.LockTime)
+		require.Equal(t, len(raw), lazy.Size())
+		require.Equal(t, tx.InputCount(), lazy.InputCount())
+		require.Equal(t, tx.OutputCount(), lazy.OutputCount())
+		require.Equal(t, tx.TotalOutputSatoshis(), lazy.TotalOutputSatoshis())
+		require.Equal(t, tx.IsCoinbase(), lazy.IsCoinbase())
+
+		for i, expected := range tx.Inputs {
+			input, err := lazy.Input(i)
+			require.NoError(t, err)
+			require.Equal(t, expected.SourceTXID, input.SourceTXID)
+			require.Equal(t, expected.SourceTxOutIndex, input.SourceTxOutIndex)
+			require.Equal(t, expected.UnlockingScript, input.UnlockingScript)
+			require.Equal(t, expected.SequenceNumber, input.SequenceNumber)
+		}
+
+		for i, expected := range tx.Outputs {
+			output, err := lazy.Output(i)
+			require.NoError(t, err)
+			require.Equal(t, expected.Satoshis, output.Satoshis)
+			require.Equal(t, expected.LockingScript, output.LockingScript)
+
+			sats, err := lazy.OutputSatoshis(i)
+			require.NoError(t, err)
+			require.Equal(t, expected.Satoshis, sats)
+
+			lockingScript, err := lazy.OutputLockingScriptBytes(i)
+			require.NoError(t, err)
+			require.Equal(t, expected.LockingScript.Bytes(), lockingScript)
+		}
+
+		_, err = lazy.Input(tx.InputCount())
+		require.ErrorIs(t, err, transaction.ErrInputNoExist)
+		_, err = lazy.Output(tx.OutputCount())
+		require.ErrorIs(t, err, transaction.ErrOutputNoExist)
+
+		full, err := lazy.Transaction()
+		require.NoError(t, err)
+		require.Equal(t, tx.Hex(), full.Hex())
+	}
+}
+
+func TestNewLazyTransactionFromStream(t *testing.T) {
+	beefTx, err := transaction.NewTransactionFromBEEFHex(BRC62Hex)
+	require.NoError(t, err)
+	first := beefTx.Inputs[0].SourceTransaction.Bytes()
+	second := beefTx.Bytes()
+	stream := append(append([]byte{}, first...), second...)
+
+	lazy, used, err := transaction.NewLazyTransactionFromStream(stream)
+	require.NoError(t, err)
+	require.Equal(t, len(first), used)
+	require.Equal(t, first, lazy.Bytes())
+
+	lazy, used, err = transaction.NewLazyTransactionFromStream(stream[used:])
+	require.NoError(t, err)
+	require.Equal(t, len(second), used)
+	require.Equal(t, beefTx.TxID(), lazy.TxID())
+
+	t.Run("truncated", func(t *testing.T) {
+		for _, n := range []int{0, 3, 5, 40, len(second) - 1} {
+			_, err := transaction.NewTransactionFromBytesLazy(second[:n])
+			require.ErrorIs(t, err, transaction.ErrTxTooShort)
+		}
+	})
+
+	t.Run("extended format", func(t *testing.T) {
+		ef, err := beefTx.EF()
+		require.NoError(t, err)
+		_, err = transaction.NewTransactionFromBytesLazy(ef)
+		require.ErrorIs(t, err, transaction.ErrLazyExtendedFormat)
+	})
+}
+
+func BenchmarkNewTransactionFromBytesLazy(b *testing.B) {
+	tx, err := transaction.NewTransactionFromBEEFHex(BRC62Hex)
+	require.NoError(b, err)
+	raw := tx.Bytes()
+
+	b.Run("lazy", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			lazy, _ := transaction.NewTransactionFromBytesLazy(raw)
+			_, _ = lazy.OutputSatoshis(0)
+		}
+	})
+	b.Run("full", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			full, _ := transaction.NewTransactionFromBytes(raw)
+			_ = full.Outputs[0].Satoshis
+		}
+	})
+}
@@ -0,0 +1,169 @@
+// Package merkle builds block merkle trees from transaction ids so that merkle
+// roots, branches and BUMPs (BRC-74 merkle paths) can be produced, not just verified.
+package merkle
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/bsv-blockchain/go-sdk/chainhash"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+)
+
+var (
+	ErrEmptyTree    = errors.New("merkle tree has no leaves")
+	ErrIndexInvalid = errors.New("leaf index out of range")
+)
+
+// Builder accumulates txids in block order and computes the merkle tree over them.
+// Txids can be streamed in with Add; the tree is computed on demand and cached
+// until more leaves are added.
+type Builder struct {
+	leaves []chainhash.Hash
+	levels [][]chainhash.Hash
+}
+
+// NewBuilder creates an empty Builder. sizeHint preallocates room for that many txids.
+func NewBuilder(sizeHint int) *Builder {
+	return &Builder{leaves: make([]chainhash.Hash, 0, sizeHint)}
+}
+
+// Add appends the next txid in block order.
+func (b *Builder) Add(txid chainhash.Hash) {
+	b.leaves = append(b.leaves, txid)
+	b.levels = nil
+}
+
+// Len returns the number of txids added.
+func (b *Builder) Len() int {
+	return len(b.leaves)
+}
+
+// Root returns the merkle root of the txids added so far.
+func (b *Builder) Root() (*chainhash.Hash, error) {
+	if len(b.leaves) == 0 {
+		return nil, ErrEmptyTree
+	}
+	levels := b.build()
+	root := levels[len(levels)-1][0]
+	return &root, nil
+}
+
+// Branch returns the sibling hashes needed to compute the merkle root from the
+// txid at index, ordered from the leaf level upward. Where a node has no sibling
+// the node itself is returned, matching the duplicated hash used in the tree.
+func (b *Builder) Branch(index int) ([]chainhash.Hash, error) {
+	if index < 0 || index >= len(b.leaves) {
+		return nil, fmt.Errorf("%w: %d", ErrIndexInvalid, index)
+	}
+	levels := b.build()
+	branch := make([]chainhash.Hash, 0, len(levels)-1)
+	offset := index
+	for _, level := range levels[:len(levels)-1] {
+		sibling := offset ^ 1
+		if sibling >= len(level) {
+			sibling = offset
+		}
+		branch = append(branch, level[sibling])
+		offset >>= 1
+	}
+	return branch, nil
+}
+
+// MerklePath returns a BUMP proving inclusion of the txids at the given indices in
+// a block at blockHeight. Multiple indices produce a single compound path.
+func (b *Builder) MerklePath(blockHeight uint32, indices ...int) (*transaction.MerklePath, error) {
+	if len(b.leaves) == 0 {
+		return nil, ErrEmptyTree
+	}
+	if len(indices) == 0 {
+		return nil, fmt.Errorf("%w: no indices requested", ErrIndexInvalid)
+	}
+	levels := b.build()
+
+	// Offsets at the current level whose hashes can be computed from the path
+	tracked := make(map[uint64]struct{}, len(indices))
+	leafLevel := make([]*transaction.PathElement, 0, len(indices)*2)
+	isTxid := true
+	for _, index := range indices {
+		if index < 0 || index >= len(b.leaves) {
+			return nil, fmt.Errorf("%w: %d", ErrIndexInvalid, index)
+		}
+		if _, ok := tracked[uint64(index)]; ok {
+			continue
+		}
+		tracked[uint64(index)] = struct{}{}
+		hash := levels[0][index]
+		leafLevel = append(leafLevel, &transaction.PathElement{
+			Offset: uint64(index),
+			Hash:   &hash,
+			Txid:   &isTxid,
+		})
+	}
+
+	if len(levels) == 1 {
+		return transaction.NewMerklePath(blockHeight, [][]*transaction.PathElement{leafLevel}), nil
+	}
+
+	isDuplicate := true
+	path := make([][]*transaction.PathElement, len(levels)-1)
+	path[0] = leafLevel
+	for h, level := range levels[:len(levels)-1] {
+		parents := make(map[uint64]struct{}, len(tracked))
+		for offset := range tracked {
+			parents[offset>>1] = struct{}{}
+			sibling := offset ^ 1
+			if _, ok := tracked[sibling]; ok {
+				continue
+			}
+			if sibling >= uint64(len(level)) {
+				path[h] = append(path[h], &transaction.PathElement{
+					Offset:    sibling,
+					Duplicate: &isDuplicate,
+				})
+				continue
+			}
+			hash := level[sibling]
+			path[h] = append(path[h], &transaction.PathElement{
+				Offset: sibling,
+				Hash:   &hash,
+			})
+		}
+		slices.SortFunc(path[h], func(a, b *transaction.PathElement) int {
+			return int(a.Offset) - int(b.Offset)
+		})
+		tracked = parents
+	}
+
+	return transaction.NewMerklePath(blockHeight, path), nil
+}
+
+// build computes every level of the tree, from the leaves up to the root.
+func (b *Builder) build() [][]chainhash.Hash {
+	if b.levels != nil {
+		return b.levels
+	}
+	levels := [][]chainhash.Hash{b.leaves}
+	for level := b.leaves; len(level) > 1; {
+		next := make([]chainhash.Hash, (len(level)+1)/2)
+		for i := range next {
+			left := &level[i*2]
+			right := left
+			if i*2+1 < len(level) {
+				right = &level[i*2+1]
+			}
+			next[i] = *transaction.MerkleTreeParent(left, right)
+		}
+		levels = append(levels, next)
+		level = next
+	}
+	b.levels = levels
+	return levels
+}
+
+// Root computes the merkle root of txids without retaining the tree.
+func Root(txids []chainhash.Hash) (*chainhash.Hash, error) {
+	b := &Builder{leaves: txids}
+	return b.Root()
+}
@@ -0,0 +1,136 @@
+package merkle_test
+
+import (
+	"testing"
+
+	"github.com/bsv-blockchain/go-sdk/chainhash"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+	"github.com/bsv-blockchain/go-sdk/transaction/merkle"
+	"github.com/stretchr/testify/require"
+)
+
+func testTxids(n int) []chainhash.Hash {
+	txids := make([]chainhash.Hash, n)
+	for i := range txids {
+		txids[i] = chainhash.DoubleHashH([]byte{byte(i), byte(i >> 8)})
+	}
+	return txids
+}
+
+// naiveRoot computes the merkle root level by level, duplicating odd nodes.
+func naiveRoot(txids []chainhash.Hash) chainhash.Hash {
+	level := txids
+	for len(level) > 1 {
+		if len(level)%2 == 1 {
+			level = append(level, level[len(level)-1])
+		}
+		next := make([]chainhash.Hash, len(level)/2)
+		for i := range next {
+			next[i] = *transaction.MerkleTreeParent(&level[2*i], &level[2*i+1])
+		}
+		level = next
+	}
+	return level[0]
+}
+
+func TestBuilderRoot(t *testing.T) {
+	b := merkle.NewBuilder(0)
+	_, err := b.Root()
+	require.ErrorIs(t, err, merkle.ErrEmptyTree)
+
+	for _, n := range []int{1, 2, 3, 5, 8, 13} {
+		txids := testTxids(n)
+		b := merkle.NewBuilder(n)
+		for _, txid := range txids {
+			b.Add(txid)
+		}
+		require.Equal(t, n, b.Len())
+
+		root, err := b.Root()
+		require.NoError(t, err)
+		require.Equal(t, naiveRoot(txids), *root)
+
+		root, err = merkle.Root(txids)
+		require.NoError(t, err)
+		require.Equal(t, naiveRoot(txids), *root)
+	}
+}
+
+func TestBuilderBranch(t *testing.T) {
+	txids := testTxids(7)
+	b := merkle.NewBuilder(len(txids))
+	for _, txid := range txids {
+		b.Add(txid)
+	}
+	root, err := b.Root()
+	require.NoError(t, err)
+
+	for i, txid := range txids {
+		branch, err := b.Branch(i)
+		require.NoError(t, err)
+		require.Len(t, branch, 3)
+
+		working := txid
+		offset := i
+		for _, sibling := range branch {
+			if offset%2 == 0 {
+				working = *transaction.MerkleTreeParent(&working, &sibling)
+			} else {
+				working = *transaction.MerkleTreeParent(&sibling, &working)
+			}
+			offset >>= 1
+		}
+		require.Equal(t, *root, working)
+	}
+
+	_, err = b.Branch(7)
+	require.ErrorIs(t, err, merkle.ErrIndexInvalid)
+}
+
+func TestBuilderMerklePath(t *testing.T) {
+	for _, n := range []int{1, 2, 3, 6, 11} {
+		txids := testTxids(n)
+		b := merkle.NewBuilder(n)
+		for _, txid := range txids {
+			b.Add(txid)
+		}
+		root, err := b.Root()
+		require.NoError(t, err)
+
+		for i := range txids {
+			mp, err := b.MerklePath(100, i)
+			require.NoError(t, err)
+			require.Equal(t, uint32(100), mp.BlockHeight)
+
+			computed, err := mp.ComputeRoot(&txids[i])
+			require.NoError(t, err)
+			require.Equal(t, *root, *computed, "n=%d index=%d", n, i)
+
+			// Round trip through the binary BUMP encoding
+			parsed, err := transaction.NewMerklePathFromBinary(mp.Bytes())
+			require.NoError(t, err)
+			computed, err = parsed.ComputeRoot(&txids[i])
+			require.NoError(t, err)
+			require.Equal(t, *root, *computed)
+		}
+
+		indices := make([]int, 0, n)
+		for i := 0; i < n; i += 2 {
+			indices = append(indices, i)
+		}
+		compound, err := b.MerklePath(100, indices...)
+		require.NoError(t, err)
+		for _, i := range indices {
+			computed, err := compound.ComputeRoot(&txids[i])
+			require.NoError(t, err)
+			require.Equal(t, *root, *computed)
+		}
+	}
+
+	b := merkle.NewBuilder(0)
+	b.Add(testTxids(1)[0])
+	_, err := b.MerklePath(1, 1)
+	require.ErrorIs(t, err, merkle.ErrIndexInvalid)
+	_, err = b.MerklePath(1)
+	require.ErrorIs(t, err, merkle.ErrIndexInvalid)
+}
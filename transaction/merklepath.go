@@ -2,9 +2,11 @@ package transaction
 
 import (
 	"bytes"
+	"cmp"
 	"context"
 	"encoding/binary"
 	"encoding/hex"
+	"encoding/json"
 	"fmt"
 	"io"
 	"slices"
@@ -28,6 +30,20 @@ type MerklePath struct {
 	Path        [][]*PathElement `json:"path"`
 }
 
+// pathElementJSON is the BRC-74 JSON form of a leaf, where flags are only
+// present when set.
+type pathElementJSON struct {
+	Offset    uint64          `json:"offset"`
+	Hash      *chainhash.Hash `json:"hash,omitempty"`
+	Txid      bool            `json:"txid,omitempty"`
+	Duplicate bool            `json:"duplicate,omitempty"`
+}
+
+type merklePathJSON struct {
+	BlockHeight uint32              `json:"blockHeight"`
+	Path        [][]pathElementJSON `json:"path"`
+}
+
 type IndexedPath []map[uint64]*PathElement
 
 func (ip IndexedPath) GetOffsetLeaf(layer int, offset uint64) *PathElement {
@@ -108,7 +124,7 @@ func NewMerklePathFromReader(reader io.Reader) (*MerklePath, error) {
 			return nil, err
 		}
 
-		bump.Path[lv] = make([]*PathElement, nLeavesAtThisHeight)
+		bump.Path[lv] = make([]*PathElement, 0, preallocLen(uint64(nLeavesAtThisHeight)))
 		for lf := uint64(0); lf < uint64(nLeavesAtThisHeight); lf++ {
 			// For each leaf we parse the offset, hash, txid and duplicate.
 			var offset util.VarInt
@@ -132,7 +148,7 @@ func NewMerklePathFromReader(reader io.Reader) (*MerklePath, error) {
 				l.Duplicate = &dup
 			} else {
 				hash := make([]byte, 32)
-				if _, err = reader.Read(hash); err != nil {
+				if _, err = io.ReadFull(reader, hash); err != nil {
 					return nil, err
 				} else if l.Hash, err = chainhash.NewHash(hash); err != nil {
 					return nil, err
@@ -141,7 +157,7 @@ func NewMerklePathFromReader(reader io.Reader) (*MerklePath, error) {
 			if txid {
 				l.Txid = &txid
 			}
-			bump.Path[lv][lf] = &l
+			bump.Path[lv] = append(bump.Path[lv], &l)
 		}
 	}
 
@@ -181,6 +197,74 @@ func (mp *MerklePath) Bytes() []byte {
 	return bytes
 }
 
+// MarshalJSON encodes the MerklePath in the JSON format of BRC-74, with the leaves
+// of each level sorted by offset, so that equal paths have the same encoding.
+func (mp MerklePath) MarshalJSON() ([]byte, error) {
+	mpj := merklePathJSON{
+		BlockHeight: mp.BlockHeight,
+		Path:        make([][]pathElementJSON, len(mp.Path)),
+	}
+	for h, level := range mp.Path {
+		mpj.Path[h] = make([]pathElementJSON, 0, len(level))
+		for _, leaf := range level {
+			lj := pathElementJSON{
+				Offset:    leaf.Offset,
+				Txid:      leaf.Txid != nil && *leaf.Txid,
+				Duplicate: leaf.Duplicate != nil && *leaf.Duplicate,
+			}
+			if !lj.Duplicate {
+				if leaf.Hash == nil {
+					return nil, fmt.Errorf("leaf at offset %d of level %d has no hash", leaf.Offset, h)
+				}
+				lj.Hash = leaf.Hash
+			}
+			mpj.Path[h] = append(mpj.Path[h], lj)
+		}
+		slices.SortFunc(mpj.Path[h], func(a, b pathElementJSON) int {
+			return cmp.Compare(a.Offset, b.Offset)
+		})
+	}
+	return json.Marshal(mpj)
+}
+
+// UnmarshalJSON decodes a MerklePath from the JSON format of BRC-74.
+func (mp *MerklePath) UnmarshalJSON(data []byte) error {
+	var mpj merklePathJSON
+	if err := json.Unmarshal(data, &mpj); err != nil {
+		return err
+	}
+	if len(mpj.Path) == 0 || len(mpj.Path) > 64 {
+		return fmt.Errorf("invalid BUMP tree height %d", len(mpj.Path))
+	}
+	path := make([][]*PathElement, len(mpj.Path))
+	for h, level := range mpj.Path {
+		path[h] = make([]*PathElement, 0, len(level))
+		for _, lj := range level {
+			leaf := &PathElement{Offset: lj.Offset}
+			switch {
+			case lj.Duplicate && lj.Hash != nil:
+				return fmt.Errorf("duplicate leaf at offset %d of level %d has a hash", lj.Offset, h)
+			case lj.Duplicate:
+				leaf.Duplicate = &lj.Duplicate
+			case lj.Hash == nil:
+				return fmt.Errorf("leaf at offset %d of level %d has no hash", lj.Offset, h)
+			default:
+				leaf.Hash = lj.Hash
+			}
+			if lj.Txid {
+				leaf.Txid = &lj.Txid
+			}
+			path[h] = append(path[h], leaf)
+		}
+		slices.SortFunc(path[h], func(a, b *PathElement) int {
+			return cmp.Compare(a.Offset, b.Offset)
+		})
+	}
+	mp.BlockHeight = mpj.BlockHeight
+	mp.Path = path
+	return nil
+}
+
 // Hex converts the MerklePath to a hexadecimal string representation
 func (mp *MerklePath) Hex() string {
 	return hex.EncodeToString(mp.Bytes())
@@ -280,6 +364,48 @@ func (mp *MerklePath) Verify(ctx context.Context, txid *chainhash.Hash, ct chain
 	return ct.IsValidRootForHeight(ctx, root, mp.BlockHeight)
 }
 
+// Compress removes the leaves that are not needed to compute the root for the
+// txids of the path: nodes that can be computed from the levels below, and nodes
+// that are not the sibling of any node on the way from a txid to the root. Paths
+// combined for several transactions of the same block shrink the most. When no
+// leaf is flagged as a txid, every hash of the lowest level is treated as one.
+func (mp *MerklePath) Compress() {
+	if len(mp.Path) == 0 {
+		return
+	}
+	onPath := make(map[uint64]struct{})
+	for _, leaf := range mp.Path[0] {
+		if leaf.Txid != nil && *leaf.Txid {
+			onPath[leaf.Offset] = struct{}{}
+		}
+	}
+	if len(onPath) == 0 {
+		for _, leaf := range mp.Path[0] {
+			if leaf.Hash != nil && (leaf.Duplicate == nil || !*leaf.Duplicate) {
+				onPath[leaf.Offset] = struct{}{}
+			}
+		}
+	}
+
+	for h, level := range mp.Path {
+		kept := make([]*PathElement, 0, len(level))
+		for _, leaf := range level {
+			_, self := onPath[leaf.Offset]
+			_, sibling := onPath[leaf.Offset^1]
+			if (self && h == 0) || (!self && sibling) {
+				kept = append(kept, leaf)
+			}
+		}
+		mp.Path[h] = kept
+
+		parents := make(map[uint64]struct{}, len(onPath))
+		for offset := range onPath {
+			parents[offset>>1] = struct{}{}
+		}
+		onPath = parents
+	}
+}
+
 func (m *MerklePath) Combine(other *MerklePath) (err error) {
 	if m.BlockHeight != other.BlockHeight {
 		return errors.New("cannot combine MerklePaths with different block heights")
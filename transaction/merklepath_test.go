@@ -296,3 +296,107 @@ func TestMerklePathCombine(t *testing.T) {
 		}
 	})
 }
+
+func TestMerklePathJSON(t *testing.T) {
+	t.Parallel()
+
+	t.Run("round trips with hex", func(t *testing.T) {
+		bumps := []string{BRC74Hex}
+		for _, valid := range testdata.ValidBumps {
+			bumps = append(bumps, valid.Bump)
+		}
+		for _, bump := range bumps {
+			mp, err := NewMerklePathFromHex(bump)
+			require.NoError(t, err)
+			b, err := json.Marshal(mp)
+			require.NoError(t, err)
+
+			var decoded MerklePath
+			require.NoError(t, json.Unmarshal(b, &decoded))
+			require.Equal(t, bump, decoded.Hex())
+		}
+	})
+
+	t.Run("sorts leaves by offset", func(t *testing.T) {
+		dup := true
+		mp := MerklePath{BlockHeight: 1, Path: [][]*PathElement{{
+			{Offset: 3, Duplicate: &dup},
+			{Offset: 2, Hash: hexToChainhash(BRC74TXID1), Txid: &dup},
+		}}}
+		b, err := json.Marshal(mp)
+		require.NoError(t, err)
+		require.JSONEq(t, `{"blockHeight":1,"path":[[{"offset":2,"hash":"`+BRC74TXID1+`","txid":true},{"offset":3,"duplicate":true}]]}`, string(b))
+	})
+
+	t.Run("rejects invalid leaves", func(t *testing.T) {
+		for _, invalid := range []string{
+			`{"blockHeight":1,"path":[]}`,
+			`{"blockHeight":1,"path":[[{"offset":0}]]}`,
+			`{"blockHeight":1,"path":[[{"offset":0,"hash":"` + BRC74TXID1 + `","duplicate":true}]]}`,
+		} {
+			var mp MerklePath
+			require.Error(t, json.Unmarshal([]byte(invalid), &mp), invalid)
+		}
+	})
+}
+
+func TestMerklePathCompress(t *testing.T) {
+	t.Parallel()
+
+	t.Run("drops nodes computable from lower levels", func(t *testing.T) {
+		mp, err := NewMerklePathFromHex(BRC74Hex)
+		require.NoError(t, err)
+		mp.Path[1] = BRC74JSON.Path[1]
+
+		mp.Compress()
+		out, err := json.Marshal(mp)
+		require.NoError(t, err)
+		require.JSONEq(t, BRC74JSONTrimmed, string(out))
+		for _, txid := range []string{BRC74TXID2, BRC74TXID3} {
+			root, err := mp.ComputeRootHex(&txid)
+			require.NoError(t, err)
+			require.Equal(t, BRC74Root, root)
+		}
+	})
+
+	t.Run("keeps only the branches of the txids", func(t *testing.T) {
+		// Build the complete tree of a block with five transactions
+		var leaves []*chainhash.Hash
+		for i := range 5 {
+			leaves = append(leaves, &chainhash.Hash{byte(i + 1)})
+		}
+		dup := true
+		mp := &MerklePath{BlockHeight: 100}
+		for len(leaves) > 1 {
+			level := make([]*PathElement, 0, len(leaves)+1)
+			var parents []*chainhash.Hash
+			for i := 0; i < len(leaves); i += 2 {
+				level = append(level, &PathElement{Offset: uint64(i), Hash: leaves[i]})
+				if i+1 < len(leaves) {
+					level = append(level, &PathElement{Offset: uint64(i + 1), Hash: leaves[i+1]})
+					parents = append(parents, MerkleTreeParent(leaves[i], leaves[i+1]))
+				} else {
+					level = append(level, &PathElement{Offset: uint64(i + 1), Duplicate: &dup})
+					parents = append(parents, MerkleTreeParent(leaves[i], leaves[i]))
+				}
+			}
+			mp.Path = append(mp.Path, level)
+			leaves = parents
+		}
+		root := leaves[0]
+		mp.Path[0][1].Txid = &dup
+		mp.Path[0][4].Txid = &dup
+		full := len(mp.Bytes())
+
+		mp.Compress()
+		require.Less(t, len(mp.Bytes()), full)
+		require.Len(t, mp.Path[0], 4)
+		require.Len(t, mp.Path[1], 2)
+		require.Empty(t, mp.Path[2])
+		for _, txid := range []*chainhash.Hash{mp.Path[0][1].Hash, mp.Path[0][3].Hash} {
+			computed, err := mp.ComputeRoot(txid)
+			require.NoError(t, err)
+			require.Equal(t, root, computed)
+		}
+	})
+}
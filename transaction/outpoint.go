@@ -27,6 +27,16 @@ func (o *Outpoint) TxBytes() []byte {
 	return binary.LittleEndian.AppendUint32(o.Txid.CloneBytes(), o.Index)
 }
 
+// NewOutpointFromInputBytes creates a new Outpoint from the 36 bytes returned by TxBytes,
+// as found in transaction inputs (little-endian). Use NewOutpointFromBytes for the
+// big-endian format returned by Bytes
+func NewOutpointFromInputBytes(b [36]byte) *Outpoint {
+	return &Outpoint{
+		Txid:  chainhash.Hash(b[:32]),
+		Index: binary.LittleEndian.Uint32(b[32:]),
+	}
+}
+
 // NewOutpointFromBytes creates a new Outpoint from a 36-byte array in standard byte format (big-endian)
 func NewOutpointFromBytes(b [36]byte) (o *Outpoint) {
 	o = &Outpoint{
@@ -42,6 +52,20 @@ func (o *Outpoint) Bytes() []byte {
 	return binary.BigEndian.AppendUint32(util.ReverseBytes(o.Txid.CloneBytes()), o.Index)
 }
 
+// MarshalBinary implements the encoding.BinaryMarshaler interface using the format of Bytes
+func (o Outpoint) MarshalBinary() ([]byte, error) {
+	return o.Bytes(), nil
+}
+
+// UnmarshalBinary implements the encoding.BinaryUnmarshaler interface
+func (o *Outpoint) UnmarshalBinary(data []byte) error {
+	if len(data) != 36 {
+		return fmt.Errorf("invalid outpoint length of %d, want 36", len(data))
+	}
+	*o = *NewOutpointFromBytes([36]byte(data))
+	return nil
+}
+
 // OutpointFromString creates a new Outpoint from a string in the format "txid.outputIndex"
 func OutpointFromString(s string) (*Outpoint, error) {
 	if len(s) < 66 {
@@ -96,13 +120,20 @@ func (o Outpoint) Value() (driver.Value, error) {
 	return o.Bytes(), nil
 }
 
-// Scan implements the sql.Scanner interface for database retrieval
+// Scan implements the sql.Scanner interface for database retrieval. It accepts the
+// 36 bytes written by Value, and strings in the format "txid.outputIndex"
 func (o *Outpoint) Scan(value any) error {
-	if b, ok := value.([]byte); !ok || len(b) != 36 {
-		return fmt.Errorf("invalid-outpoint")
-	} else {
-		op := NewOutpointFromBytes([36]byte(b))
-		*o = *op
-		return nil
+	switch v := value.(type) {
+	case []byte:
+		if len(v) == 36 {
+			*o = *NewOutpointFromBytes([36]byte(v))
+			return nil
+		}
+	case string:
+		if op, err := OutpointFromString(v); err == nil {
+			*o = *op
+			return nil
+		}
 	}
+	return fmt.Errorf("invalid-outpoint")
 }
@@ -0,0 +1,52 @@
+package transaction_test
+
+import (
+	"testing"
+
+	"github.com/bsv-blockchain/go-sdk/chainhash"
+	"github.com/bsv-blockchain/go-sdk/script"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOutpointEncoding(t *testing.T) {
+	op, err := transaction.OutpointFromString("3c8edde27cb9a9132c22038dac4391496be9db16fd21351565cc1006966fdad5.114")
+	require.NoError(t, err)
+
+	require.Equal(t, op, transaction.NewOutpointFromInputBytes([36]byte(op.TxBytes())))
+	require.Equal(t, op, transaction.NewOutpointFromBytes([36]byte(op.Bytes())))
+
+	b, err := op.MarshalBinary()
+	require.NoError(t, err)
+	var decoded transaction.Outpoint
+	require.NoError(t, decoded.UnmarshalBinary(b))
+	require.Equal(t, *op, decoded)
+	require.Error(t, decoded.UnmarshalBinary(b[:35]))
+
+	value, err := op.Value()
+	require.NoError(t, err)
+	for _, src := range []any{value, op.String()} {
+		var scanned transaction.Outpoint
+		require.NoError(t, scanned.Scan(src))
+		require.Equal(t, *op, scanned)
+	}
+	require.EqualError(t, decoded.Scan("not an outpoint"), "invalid-outpoint")
+	require.EqualError(t, decoded.Scan(int64(1)), "invalid-outpoint")
+}
+
+func TestInputSourceOutpoint(t *testing.T) {
+	source := transaction.NewTransaction()
+	source.AddOutput(&transaction.TransactionOutput{Satoshis: 100, LockingScript: &script.Script{script.OpTRUE}})
+	source.AddOutput(&transaction.TransactionOutput{Satoshis: 200, LockingScript: &script.Script{script.OpTRUE}})
+
+	tx := transaction.NewTransaction()
+	tx.AddInputFromTx(source, 1, nil)
+	op := tx.Inputs[0].SourceOutpoint()
+	require.Equal(t, transaction.Outpoint{Txid: *source.TxID(), Index: 1}, op)
+
+	beef, err := transaction.NewBeefFromTransaction(tx)
+	require.NoError(t, err)
+	require.Equal(t, uint64(200), beef.FindOutput(&op).Satoshis)
+	require.Nil(t, beef.FindOutput(&transaction.Outpoint{Txid: op.Txid, Index: 2}))
+	require.Nil(t, beef.FindOutput(&transaction.Outpoint{Txid: chainhash.Hash{1}}))
+}
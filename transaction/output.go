@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"io"
 
+	"github.com/bsv-blockchain/go-sdk/primitives/amount"
 	script "github.com/bsv-blockchain/go-sdk/script"
 	"github.com/bsv-blockchain/go-sdk/util"
 	"github.com/pkg/errors"
@@ -30,6 +31,11 @@ type TransactionOutput struct {
 	Change        bool           `json:"change"`
 }
 
+// Amount returns the satoshis of the output as an amount.
+func (o *TransactionOutput) Amount() amount.Satoshis {
+	return amount.Satoshis(o.Satoshis)
+}
+
 // ReadFrom reads from the `io.Reader` into the `transaction.TransactionOutput`.
 func (o *TransactionOutput) ReadFrom(r io.Reader) (int64, error) {
 	*o = TransactionOutput{}
@@ -49,8 +55,7 @@ func (o *TransactionOutput) ReadFrom(r io.Reader) (int64, error) {
 		return bytesRead, err
 	}
 
-	scriptBytes := make([]byte, l)
-	n, err = io.ReadFull(r, scriptBytes)
+	scriptBytes, n, err := readBytes(r, uint64(l))
 	bytesRead += int64(n)
 	if err != nil {
 		return bytesRead, errors.Wrapf(err, "lockingScript(%d): got %d bytes", l, n)
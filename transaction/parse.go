@@ -0,0 +1,150 @@
+package transaction
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+)
+
+// Format is the encoding of a transaction recognised by Parse.
+type Format int
+
+const (
+	FormatUnknown Format = iota
+	// FormatRaw is the standard transaction serialization.
+	FormatRaw
+	// FormatEF is the extended format of BRC-30.
+	FormatEF
+	// FormatBEEF is BEEF, version 1 (BRC-64) or 2 (BRC-96).
+	FormatBEEF
+	// FormatAtomicBEEF is Atomic BEEF (BRC-95).
+	FormatAtomicBEEF
+)
+
+func (f Format) String() string {
+	switch f {
+	case FormatRaw:
+		return "raw"
+	case FormatEF:
+		return "EF"
+	case FormatBEEF:
+		return "BEEF"
+	case FormatAtomicBEEF:
+		return "AtomicBEEF"
+	default:
+		return "unknown"
+	}
+}
+
+// ErrUnknownFormat is returned by Parse when the data is not a transaction in any
+// of the supported formats.
+var ErrUnknownFormat = errors.New("data is not a transaction in a supported format")
+
+// efMarker follows the version of a transaction in extended format.
+var efMarker = []byte{0x00, 0x00, 0x00, 0x00, 0x00, 0xEF}
+
+// Parse decodes a transaction given in any of the supported formats, either as
+// binary or as hex, and reports the format it was found in. For BEEF the subject
+// transaction is returned, with its ancestors attached as source transactions.
+// Lengths and counts declared by the data are checked against what it holds
+// before they are allocated, so Parse can be given untrusted input.
+func Parse[T ~string | ~[]byte](data T) (*Transaction, Format, error) {
+	b := []byte(data)
+	if text := bytes.TrimSpace(b); isHex(text) {
+		decoded := make([]byte, hex.DecodedLen(len(text)))
+		if _, err := hex.Decode(decoded, text); err == nil {
+			b = decoded
+		}
+	}
+	if len(b) < 4 {
+		return nil, FormatUnknown, ErrUnknownFormat
+	}
+
+	format := detectFormat(b)
+	tx, err := parseFormat(b, format)
+	if err != nil {
+		return nil, format, fmt.Errorf("failed to parse %s transaction: %w", format, err)
+	}
+	return tx, format, nil
+}
+
+func parseFormat(b []byte, format Format) (*Transaction, error) {
+	switch format {
+	case FormatAtomicBEEF:
+		beef, txid, err := NewBeefFromAtomicBytes(b)
+		if err != nil {
+			return nil, err
+		}
+		tx := beef.FindAtomicTransactionByHash(txid)
+		if tx == nil {
+			return nil, fmt.Errorf("atomic BEEF does not contain its subject transaction %s", txid)
+		}
+		return tx, nil
+	case FormatBEEF:
+		beef, err := NewBeefFromBytes(b)
+		if err != nil {
+			return nil, err
+		}
+		return beefSubject(beef)
+	default:
+		return NewTransactionFromBytes(b)
+	}
+}
+
+func detectFormat(b []byte) Format {
+	switch binary.LittleEndian.Uint32(b) {
+	case ATOMIC_BEEF:
+		return FormatAtomicBEEF
+	case BEEF_V1, BEEF_V2:
+		return FormatBEEF
+	}
+	if len(b) >= 10 && bytes.Equal(b[4:10], efMarker) {
+		return FormatEF
+	}
+	return FormatRaw
+}
+
+// beefSubject returns the only transaction of the BEEF that no other transaction
+// in it spends.
+func beefSubject(beef *Beef) (*Transaction, error) {
+	spent := make(map[string]struct{})
+	for _, btx := range beef.Transactions {
+		if btx.Transaction == nil {
+			continue
+		}
+		for _, in := range btx.Transaction.Inputs {
+			spent[in.SourceTXID.String()] = struct{}{}
+		}
+	}
+	var subject *Transaction
+	for _, btx := range beef.Transactions {
+		if btx.DataFormat == TxIDOnly || btx.Transaction == nil {
+			continue
+		}
+		if _, ok := spent[btx.Transaction.TxID().String()]; ok {
+			continue
+		}
+		if subject != nil {
+			return nil, errors.New("BEEF contains more than one unspent transaction")
+		}
+		subject = btx.Transaction
+	}
+	if subject == nil {
+		return nil, errors.New("BEEF contains no transactions")
+	}
+	return subject, nil
+}
+
+func isHex(b []byte) bool {
+	if len(b) == 0 || len(b)%2 != 0 {
+		return false
+	}
+	for _, c := range b {
+		if !('0' <= c && c <= '9' || 'a' <= c && c <= 'f' || 'A' <= c && c <= 'F') {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,88 @@
+package transaction_test
+
+import (
+	"bytes"
+	"encoding/hex"
+	"io"
+	"testing"
+
+	"github.com/bsv-blockchain/go-sdk/transaction"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	subject, err := transaction.NewTransactionFromBEEFHex(BRC62Hex)
+	require.NoError(t, err)
+
+	ef, err := subject.EF()
+	require.NoError(t, err)
+	atomic, err := subject.AtomicBEEF(false)
+	require.NoError(t, err)
+	beef, err := transaction.NewBeefFromTransaction(subject)
+	require.NoError(t, err)
+	beefV2, err := beef.Bytes()
+	require.NoError(t, err)
+	beefV1, err := hex.DecodeString(BRC62Hex)
+	require.NoError(t, err)
+
+	tests := map[string]struct {
+		data   []byte
+		format transaction.Format
+	}{
+		"raw":         {subject.Bytes(), transaction.FormatRaw},
+		"EF":          {ef, transaction.FormatEF},
+		"BEEF V1":     {beefV1, transaction.FormatBEEF},
+		"BEEF V2":     {beefV2, transaction.FormatBEEF},
+		"Atomic BEEF": {atomic, transaction.FormatAtomicBEEF},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			tx, format, err := transaction.Parse(test.data)
+			require.NoError(t, err)
+			require.Equal(t, test.format, format)
+			require.Equal(t, subject.TxID(), tx.TxID())
+
+			tx, format, err = transaction.Parse(" " + hex.EncodeToString(test.data) + "\n")
+			require.NoError(t, err)
+			require.Equal(t, test.format, format)
+			require.Equal(t, subject.TxID(), tx.TxID())
+
+			if test.format != transaction.FormatRaw {
+				require.NotNil(t, tx.Inputs[0].SourceTxOutput())
+			}
+		})
+	}
+
+	_, format, err := transaction.Parse("abc")
+	require.ErrorIs(t, err, transaction.ErrUnknownFormat)
+	require.Equal(t, transaction.FormatUnknown, format)
+
+	_, format, err = transaction.Parse(beefV1[:40])
+	require.Error(t, err)
+	require.Equal(t, transaction.FormatBEEF, format)
+	require.Contains(t, err.Error(), "failed to parse BEEF transaction")
+}
+
+func TestParseHugeLengths(t *testing.T) {
+	subject, err := transaction.NewTransactionFromBEEFHex(BRC62Hex)
+	require.NoError(t, err)
+	raw := subject.Bytes()
+
+	// Declare an unlocking script of 2^62 bytes in place of the first one: the
+	// version, input count, txid and output index take 41 bytes.
+	huge := append([]byte{}, raw[:41]...)
+	huge = append(huge, 0xff, 0, 0, 0, 0, 0, 0, 0, 0x40)
+	huge = append(huge, raw[42:]...)
+
+	_, _, err = transaction.Parse(huge)
+	require.Error(t, err)
+
+	// Readers without a known length fail once the data runs out.
+	_, err = new(transaction.Transaction).ReadFrom(io.MultiReader(bytes.NewReader(huge)))
+	require.ErrorIs(t, err, io.ErrUnexpectedEOF)
+
+	// A BEEF declaring 2^62 BUMPs.
+	beef := []byte{0x01, 0x00, 0xbe, 0xef, 0xff, 0, 0, 0, 0, 0, 0, 0, 0x40, 0x00}
+	_, _, err = transaction.Parse(beef)
+	require.Error(t, err)
+}
@@ -0,0 +1,129 @@
+// Package policy checks transactions against the standardness rules miners apply
+// on top of consensus before relaying or mining them: dust outputs, transaction
+// and script sizes, data carrier sizes and non-standard scripts. A transaction
+// failing them is valid but likely to be rejected when broadcast, so checking it
+// beforehand gives a clearer error than the one returned by the broadcaster.
+package policy
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/bsv-blockchain/go-sdk/script"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+)
+
+var (
+	ErrDust                 = errors.New("output is dust")
+	ErrTxTooLarge           = errors.New("transaction is too large")
+	ErrScriptTooLarge       = errors.New("script is too large")
+	ErrDataCarrierTooLarge  = errors.New("data carrier outputs are too large")
+	ErrNonStandardOutput    = errors.New("non-standard output script")
+	ErrNonPushOnlyUnlocking = errors.New("unlocking script is not push only")
+)
+
+// Rules are the standardness rules to check transactions against. Zero limits
+// are not enforced.
+type Rules struct {
+	// DustLimit is the smallest number of satoshis an output may hold. Data
+	// carrier outputs are exempt.
+	DustLimit uint64
+	// MaxTxSize is the largest serialized transaction size, in bytes.
+	MaxTxSize int
+	// MaxScriptSize is the largest locking or unlocking script size, in bytes.
+	MaxScriptSize int
+	// MaxDataCarrierSize is the largest total size of the data carrier
+	// (OP_RETURN) output scripts of a transaction, in bytes.
+	MaxDataCarrierSize int
+	// AcceptNonStandardOutputs accepts locking scripts matching none of the
+	// standard templates. P2SH outputs, invalid since the Genesis upgrade, are
+	// never accepted.
+	AcceptNonStandardOutputs bool
+	// AcceptNonPushOnlyUnlocking accepts unlocking scripts with opcodes other
+	// than data pushes.
+	AcceptNonPushOnlyUnlocking bool
+}
+
+// DefaultRules returns the rules of the default SV Node policy, which most
+// miners run: a 1 satoshi dust limit, 10MB transactions, 500KB scripts,
+// unlimited data carrier outputs, non-standard outputs accepted and push only
+// unlocking scripts.
+func DefaultRules() Rules {
+	return Rules{
+		DustLimit:                1,
+		MaxTxSize:                10_000_000,
+		MaxScriptSize:            500_000,
+		AcceptNonStandardOutputs: true,
+	}
+}
+
+// CheckStandard checks tx against rules, returning nil when it is standard or
+// the errors for all the rules it breaks joined together. Each wraps one of the
+// Err values of this package, which errors.Is matches.
+func CheckStandard(tx *transaction.Transaction, rules Rules) error {
+	var errs []error
+	if rules.MaxTxSize > 0 {
+		if size := tx.Size(); size > rules.MaxTxSize {
+			errs = append(errs, fmt.Errorf("%w: %d bytes, limit %d", ErrTxTooLarge, size, rules.MaxTxSize))
+		}
+	}
+
+	for i, input := range tx.Inputs {
+		if input.UnlockingScript == nil {
+			continue
+		}
+		if rules.MaxScriptSize > 0 && len(*input.UnlockingScript) > rules.MaxScriptSize {
+			errs = append(errs, fmt.Errorf("%w: input %d unlocking script is %d bytes, limit %d",
+				ErrScriptTooLarge, i, len(*input.UnlockingScript), rules.MaxScriptSize))
+		}
+		if !rules.AcceptNonPushOnlyUnlocking && !isPushOnly(input.UnlockingScript) {
+			errs = append(errs, fmt.Errorf("%w: input %d", ErrNonPushOnlyUnlocking, i))
+		}
+	}
+
+	dataSize := 0
+	for i, output := range tx.Outputs {
+		lockingScript := output.LockingScript
+		if lockingScript == nil {
+			lockingScript = &script.Script{}
+		}
+		if rules.MaxScriptSize > 0 && len(*lockingScript) > rules.MaxScriptSize {
+			errs = append(errs, fmt.Errorf("%w: output %d locking script is %d bytes, limit %d",
+				ErrScriptTooLarge, i, len(*lockingScript), rules.MaxScriptSize))
+		}
+
+		switch scriptType := lockingScript.Type(); scriptType {
+		case script.ScriptTypeNullData:
+			dataSize += len(*lockingScript)
+			continue
+		case script.ScriptTypeScriptHash:
+			errs = append(errs, fmt.Errorf("%w: output %d is P2SH", ErrNonStandardOutput, i))
+		case script.ScriptTypeNonStandard:
+			if !rules.AcceptNonStandardOutputs {
+				errs = append(errs, fmt.Errorf("%w: output %d", ErrNonStandardOutput, i))
+			}
+		}
+		if output.Satoshis < rules.DustLimit {
+			errs = append(errs, fmt.Errorf("%w: output %d holds %d satoshis, limit %d",
+				ErrDust, i, output.Satoshis, rules.DustLimit))
+		}
+	}
+	if rules.MaxDataCarrierSize > 0 && dataSize > rules.MaxDataCarrierSize {
+		errs = append(errs, fmt.Errorf("%w: %d bytes, limit %d", ErrDataCarrierTooLarge, dataSize, rules.MaxDataCarrierSize))
+	}
+
+	return errors.Join(errs...)
+}
+
+func isPushOnly(s *script.Script) bool {
+	chunks, err := script.DecodeScript(*s)
+	if err != nil {
+		return false
+	}
+	for _, chunk := range chunks {
+		if chunk.Op > script.Op16 {
+			return false
+		}
+	}
+	return true
+}
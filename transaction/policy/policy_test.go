@@ -0,0 +1,98 @@
+package policy_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/bsv-blockchain/go-sdk/script"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+	"github.com/bsv-blockchain/go-sdk/transaction/policy"
+	"github.com/stretchr/testify/require"
+)
+
+func p2pkh(t *testing.T) *script.Script {
+	t.Helper()
+	s, err := script.NewFromHex("76a9146bfd5c7fbe21529d45803dbcf0c87dd3c71efbc288ac")
+	require.NoError(t, err)
+	return s
+}
+
+func standardTx(t *testing.T) *transaction.Transaction {
+	t.Helper()
+	tx := transaction.NewTransaction()
+	unlocking := &script.Script{}
+	require.NoError(t, unlocking.AppendPushData(bytes.Repeat([]byte{1}, 72)))
+	require.NoError(t, unlocking.AppendPushData(bytes.Repeat([]byte{2}, 33)))
+	tx.AddInput(&transaction.TransactionInput{SourceTXID: tx.TxID(), UnlockingScript: unlocking})
+	tx.AddOutput(&transaction.TransactionOutput{Satoshis: 1, LockingScript: p2pkh(t)})
+	require.NoError(t, tx.AddOpReturnOutput([]byte("hello")))
+	return tx
+}
+
+func TestCheckStandard(t *testing.T) {
+	rules := policy.DefaultRules()
+	require.NoError(t, policy.CheckStandard(standardTx(t), rules))
+
+	t.Run("dust", func(t *testing.T) {
+		tx := standardTx(t)
+		tx.Outputs[0].Satoshis = 0
+		require.ErrorIs(t, policy.CheckStandard(tx, rules), policy.ErrDust)
+
+		strict := rules
+		strict.DustLimit = 546
+		tx.Outputs[0].Satoshis = 545
+		err := policy.CheckStandard(tx, strict)
+		require.ErrorIs(t, err, policy.ErrDust)
+		require.ErrorContains(t, err, "output 0 holds 545 satoshis, limit 546")
+	})
+
+	t.Run("sizes", func(t *testing.T) {
+		tx := standardTx(t)
+		small := rules
+		small.MaxTxSize = tx.Size() - 1
+		require.ErrorIs(t, policy.CheckStandard(tx, small), policy.ErrTxTooLarge)
+
+		small = rules
+		small.MaxScriptSize = 100
+		err := policy.CheckStandard(tx, small)
+		require.ErrorIs(t, err, policy.ErrScriptTooLarge)
+		require.ErrorContains(t, err, "input 0")
+
+		small = rules
+		small.MaxDataCarrierSize = 5
+		require.ErrorIs(t, policy.CheckStandard(tx, small), policy.ErrDataCarrierTooLarge)
+		small.MaxDataCarrierSize = 100
+		require.NoError(t, policy.CheckStandard(tx, small))
+	})
+
+	t.Run("non-standard scripts", func(t *testing.T) {
+		tx := standardTx(t)
+		tx.AddOutput(&transaction.TransactionOutput{Satoshis: 1, LockingScript: &script.Script{script.OpTRUE}})
+		require.NoError(t, policy.CheckStandard(tx, rules))
+		strict := rules
+		strict.AcceptNonStandardOutputs = false
+		require.ErrorIs(t, policy.CheckStandard(tx, strict), policy.ErrNonStandardOutput)
+
+		p2sh, err := script.NewFromHex("a9146bfd5c7fbe21529d45803dbcf0c87dd3c71efbc287")
+		require.NoError(t, err)
+		tx = standardTx(t)
+		tx.AddOutput(&transaction.TransactionOutput{Satoshis: 1, LockingScript: p2sh})
+		require.ErrorIs(t, policy.CheckStandard(tx, rules), policy.ErrNonStandardOutput)
+
+		tx = standardTx(t)
+		*tx.Inputs[0].UnlockingScript = append(*tx.Inputs[0].UnlockingScript, script.OpDUP)
+		require.ErrorIs(t, policy.CheckStandard(tx, rules), policy.ErrNonPushOnlyUnlocking)
+		lax := rules
+		lax.AcceptNonPushOnlyUnlocking = true
+		require.NoError(t, policy.CheckStandard(tx, lax))
+	})
+
+	t.Run("all violations reported", func(t *testing.T) {
+		tx := standardTx(t)
+		tx.Outputs[0].Satoshis = 0
+		*tx.Inputs[0].UnlockingScript = append(*tx.Inputs[0].UnlockingScript, script.OpDUP)
+		err := policy.CheckStandard(tx, rules)
+		require.ErrorIs(t, err, policy.ErrDust)
+		require.ErrorIs(t, err, policy.ErrNonPushOnlyUnlocking)
+	})
+}
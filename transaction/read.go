@@ -0,0 +1,47 @@
+package transaction
+
+import (
+	"bytes"
+	"io"
+	"math"
+)
+
+// maxPrealloc bounds the memory allocated up front for a length or count declared
+// by the data being read, so that data declaring more than it holds fails once it
+// runs out rather than exhausting memory first.
+const maxPrealloc = 1 << 16
+
+// readBytes reads n bytes from r and returns them with the number of bytes read.
+// When r reports its remaining length, as bytes.Reader does, n is checked against
+// it before allocating, and a length beyond it consumes what remains as a short
+// read would. Otherwise lengths beyond maxPrealloc are read into a
+// buffer growing with the bytes actually read.
+func readBytes(r io.Reader, n uint64) ([]byte, int, error) {
+	known := false
+	if lr, ok := r.(interface{ Len() int }); ok {
+		if remaining := lr.Len(); n > uint64(remaining) {
+			read, _ := io.ReadFull(r, make([]byte, remaining))
+			return nil, read, io.ErrUnexpectedEOF
+		}
+		known = true
+	}
+	if known || n <= maxPrealloc {
+		b := make([]byte, n)
+		read, err := io.ReadFull(r, b)
+		return b, read, err
+	}
+	if n > math.MaxInt64 {
+		return nil, 0, io.ErrUnexpectedEOF
+	}
+	var buf bytes.Buffer
+	read, err := io.CopyN(&buf, r, int64(n))
+	if err == io.EOF {
+		err = io.ErrUnexpectedEOF
+	}
+	return buf.Bytes(), int(read), err
+}
+
+// preallocLen returns the capacity to allocate for a declared count of n elements.
+func preallocLen(n uint64) int {
+	return int(min(n, maxPrealloc))
+}
@@ -0,0 +1,168 @@
+package transaction
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+	"strings"
+	"sync"
+
+	"github.com/bsv-blockchain/go-sdk/script"
+)
+
+// InputVerifier checks the unlocking script of input inputIndex of tx against
+// the output it spends. interpreter.VerifyInput is the standard one.
+type InputVerifier func(ctx context.Context, tx *Transaction, inputIndex uint32) error
+
+// SignOption configures SignAll.
+type SignOption func(*signOptions)
+
+type signOptions struct {
+	concurrency int
+	verify      InputVerifier
+}
+
+// WithSignConcurrency sets how many inputs SignAll signs at once, defaulting
+// to GOMAXPROCS. Pass 1 for templates which are not safe to call concurrently,
+// such as ones sharing a signer that serializes requests poorly.
+func WithSignConcurrency(n int) SignOption {
+	return func(o *signOptions) {
+		o.concurrency = n
+	}
+}
+
+// VerifyAfterSign makes SignAll check every signed input with verify, such as
+// interpreter.VerifyInput, once all inputs are signed.
+func VerifyAfterSign(verify InputVerifier) SignOption {
+	return func(o *signOptions) {
+		o.verify = verify
+	}
+}
+
+// InputError is the failure to sign or verify an input.
+type InputError struct {
+	Index uint32
+	Err   error
+}
+
+func (e *InputError) Error() string {
+	return fmt.Sprintf("input %d: %v", e.Index, e.Err)
+}
+
+func (e *InputError) Unwrap() error {
+	return e.Err
+}
+
+// SignError lists the inputs SignAll failed to sign or verify, in input order.
+type SignError struct {
+	Inputs []*InputError
+}
+
+func (e *SignError) Error() string {
+	msgs := make([]string, len(e.Inputs))
+	for i, in := range e.Inputs {
+		msgs[i] = in.Error()
+	}
+	return fmt.Sprintf("failed to sign %d inputs: %s", len(e.Inputs), strings.Join(msgs, "; "))
+}
+
+// Unwrap returns the input errors, so errors.Is and errors.As match any of them.
+func (e *SignError) Unwrap() []error {
+	errs := make([]error, len(e.Inputs))
+	for i, in := range e.Inputs {
+		errs[i] = in
+	}
+	return errs
+}
+
+// SignAll signs every input with an UnlockingScriptTemplate, like Sign, but
+// calls the templates concurrently and carries on past failing inputs. The
+// templates only read the transaction, which is left as it was until they all
+// return, so they must not modify it. The unlocking scripts of the inputs which
+// signed are then set, and checked when VerifyAfterSign is given.
+//
+// The error is a *SignError listing every input which failed, including those
+// not signed because ctx was done.
+func (tx *Transaction) SignAll(ctx context.Context, opts ...SignOption) error {
+	if err := tx.checkFeeComputed(); err != nil {
+		return err
+	}
+	o := signOptions{concurrency: runtime.GOMAXPROCS(0)}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	if o.concurrency < 1 {
+		o.concurrency = 1
+	}
+
+	var toSign []uint32
+	for vin, in := range tx.Inputs {
+		if in.UnlockingScriptTemplate != nil {
+			toSign = append(toSign, uint32(vin))
+		}
+	}
+	unlocks := make([]*script.Script, len(tx.Inputs))
+	errs := make([]error, len(tx.Inputs))
+	forEachInput(ctx, toSign, o.concurrency, errs, func(vin uint32) error {
+		unlock, err := tx.Inputs[vin].UnlockingScriptTemplate.Sign(tx, vin)
+		unlocks[vin] = unlock
+		return err
+	})
+
+	var signed []uint32
+	for _, vin := range toSign {
+		if errs[vin] == nil {
+			tx.Inputs[vin].UnlockingScript = unlocks[vin]
+			signed = append(signed, vin)
+		}
+	}
+	if o.verify != nil {
+		forEachInput(ctx, signed, o.concurrency, errs, func(vin uint32) error {
+			if err := o.verify(ctx, tx, vin); err != nil {
+				return fmt.Errorf("verification failed: %w", err)
+			}
+			return nil
+		})
+	}
+
+	var failed []*InputError
+	for vin, err := range errs {
+		if err != nil {
+			failed = append(failed, &InputError{Index: uint32(vin), Err: err})
+		}
+	}
+	if len(failed) > 0 {
+		return &SignError{Inputs: failed}
+	}
+	return nil
+}
+
+// forEachInput calls f for the inputs with up to concurrency calls at once,
+// recording their errors by input index. Inputs not reached before ctx is done
+// get the context's error.
+func forEachInput(ctx context.Context, inputs []uint32, concurrency int, errs []error, f func(vin uint32) error) {
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	for _, vin := range inputs {
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			errs[vin] = ctx.Err()
+			continue
+		}
+		if err := ctx.Err(); err != nil {
+			<-sem
+			errs[vin] = err
+			continue
+		}
+		wg.Add(1)
+		go func() {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			errs[vin] = f(vin)
+		}()
+	}
+	wg.Wait()
+}
@@ -0,0 +1,127 @@
+package transaction_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	ec "github.com/bsv-blockchain/go-sdk/primitives/ec"
+	"github.com/bsv-blockchain/go-sdk/script"
+	"github.com/bsv-blockchain/go-sdk/script/interpreter"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+	"github.com/bsv-blockchain/go-sdk/transaction/template/p2pkh"
+	"github.com/stretchr/testify/require"
+)
+
+// failingTemplate fails to sign, or signs with a script which does not unlock
+// the input when script is set.
+type failingTemplate struct {
+	script *script.Script
+}
+
+func (f failingTemplate) Sign(*transaction.Transaction, uint32) (*script.Script, error) {
+	if f.script != nil {
+		return f.script, nil
+	}
+	return nil, errors.New("signer unavailable")
+}
+
+func (f failingTemplate) EstimateLength(*transaction.Transaction, uint32) uint32 {
+	return 107
+}
+
+func signAllTx(t *testing.T, inputs int) *transaction.Transaction {
+	t.Helper()
+	key, err := ec.NewPrivateKey()
+	require.NoError(t, err)
+	address, err := script.NewAddressFromPublicKey(key.PubKey(), true)
+	require.NoError(t, err)
+	lockingScript, err := p2pkh.Lock(address)
+	require.NoError(t, err)
+	unlocker, err := p2pkh.Unlock(key, nil)
+	require.NoError(t, err)
+
+	source := transaction.NewTransaction()
+	for range inputs {
+		source.AddOutput(&transaction.TransactionOutput{Satoshis: 1000, LockingScript: lockingScript})
+	}
+	tx := transaction.NewTransaction()
+	for vout := range inputs {
+		tx.AddInputFromTx(source, uint32(vout), unlocker)
+	}
+	tx.AddOutput(&transaction.TransactionOutput{Satoshis: uint64(inputs) * 900, LockingScript: lockingScript})
+	return tx
+}
+
+func TestSignAll(t *testing.T) {
+	ctx := t.Context()
+
+	t.Run("signs every input", func(t *testing.T) {
+		tx := signAllTx(t, 16)
+		require.NoError(t, tx.SignAll(ctx, transaction.VerifyAfterSign(interpreter.VerifyInput)))
+		for vin := range tx.Inputs {
+			require.NoError(t, interpreter.VerifyInput(ctx, tx, uint32(vin)))
+		}
+
+		// The same scripts as signing one input after another.
+		signedAll := tx.Bytes()
+		for _, in := range tx.Inputs {
+			in.UnlockingScript = nil
+		}
+		require.NoError(t, tx.Sign())
+		require.Equal(t, tx.Bytes(), signedAll)
+
+		sequential := signAllTx(t, 2)
+		require.NoError(t, sequential.SignAll(ctx, transaction.WithSignConcurrency(1)))
+		for vin := range sequential.Inputs {
+			require.NoError(t, interpreter.VerifyInput(ctx, sequential, uint32(vin)))
+		}
+	})
+
+	t.Run("reports each failing input", func(t *testing.T) {
+		tx := signAllTx(t, 4)
+		tx.Inputs[1].UnlockingScriptTemplate = failingTemplate{}
+		tx.Inputs[3].UnlockingScriptTemplate = failingTemplate{script: &script.Script{script.OpTRUE}}
+
+		err := tx.SignAll(ctx, transaction.VerifyAfterSign(interpreter.VerifyInput))
+		var signErr *transaction.SignError
+		require.ErrorAs(t, err, &signErr)
+		require.Len(t, signErr.Inputs, 2)
+		require.Equal(t, uint32(1), signErr.Inputs[0].Index)
+		require.ErrorContains(t, signErr.Inputs[0], "signer unavailable")
+		require.Equal(t, uint32(3), signErr.Inputs[1].Index)
+		require.ErrorContains(t, signErr.Inputs[1], "verification failed")
+		var execErr *interpreter.ExecutionError
+		require.ErrorAs(t, err, &execErr)
+
+		// Inputs which signed keep their scripts.
+		require.NotNil(t, tx.Inputs[0].UnlockingScript)
+		require.Nil(t, tx.Inputs[1].UnlockingScript)
+		require.NoError(t, interpreter.VerifyInput(ctx, tx, 2))
+	})
+
+	t.Run("canceled", func(t *testing.T) {
+		tx := signAllTx(t, 3)
+		canceled, cancel := context.WithCancel(ctx)
+		cancel()
+		err := tx.SignAll(canceled)
+		require.ErrorIs(t, err, context.Canceled)
+		var signErr *transaction.SignError
+		require.ErrorAs(t, err, &signErr)
+		require.Len(t, signErr.Inputs, 3)
+	})
+
+	t.Run("fee not computed", func(t *testing.T) {
+		tx := signAllTx(t, 1)
+		tx.AddOutput(&transaction.TransactionOutput{LockingScript: &script.Script{script.OpTRUE}, Change: true})
+		require.Error(t, tx.SignAll(ctx))
+		require.Nil(t, tx.Inputs[0].UnlockingScript)
+	})
+}
+
+func TestVerifyInput(t *testing.T) {
+	tx := signAllTx(t, 1)
+	require.ErrorIs(t, interpreter.VerifyInput(t.Context(), tx, 1), transaction.ErrInputNoExist)
+	tx.AddInput(&transaction.TransactionInput{SourceTXID: tx.TxID()})
+	require.ErrorIs(t, interpreter.VerifyInput(t.Context(), tx, 1), transaction.ErrEmptyPreviousTx)
+}
@@ -33,8 +33,7 @@ func (tx *Transaction) sigStrat(shf sighash.Flag) sigHashFunc {
 //
 // see https://github.com/bitcoin-sv/bitcoin-sv/blob/master/doc/abc/replay-protected-sighash.md#digest-algorithm
 func (tx *Transaction) CalcInputSignatureHash(inputNumber uint32, sigHashFlag sighash.Flag) ([]byte, error) {
-	sigHashFn := tx.sigStrat(sigHashFlag)
-	buf, err := sigHashFn(inputNumber, sigHashFlag)
+	buf, err := tx.InputPreimage(inputNumber, sigHashFlag)
 	if err != nil {
 		return nil, err
 	}
@@ -55,6 +54,17 @@ func (tx *Transaction) CalcInputSignatureHash(inputNumber uint32, sigHashFlag si
 	return crypto.Sha256d(buf), nil
 }
 
+// InputPreimage returns the exact bytes that are double hashed (SHA256d) to produce the
+// signature hash of the input, using the BIP-143 style serialization when the flag has
+// SIGHASH_FORKID and the legacy serialization otherwise. External signers can hash and
+// sign the result themselves, and tools can inspect what a signature commits to.
+//
+// For legacy SIGHASH_SINGLE signatures of inputs without a matching output, the
+// preimage is the consensus "hash of one" and is used as the signature hash as is.
+func (tx *Transaction) InputPreimage(inputNumber uint32, sigHashFlag sighash.Flag) ([]byte, error) {
+	return tx.sigStrat(sigHashFlag)(inputNumber, sigHashFlag)
+}
+
 // CalcInputPreimage serializes the transaction based on the input index and the SIGHASH flag
 // and returns the preimage before double hashing (SHA256d).
 //
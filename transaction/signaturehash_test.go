@@ -4,6 +4,7 @@ import (
 	"encoding/hex"
 	"testing"
 
+	crypto "github.com/bsv-blockchain/go-sdk/primitives/hash"
 	script "github.com/bsv-blockchain/go-sdk/script"
 	"github.com/bsv-blockchain/go-sdk/transaction"
 	sighash "github.com/bsv-blockchain/go-sdk/transaction/sighash"
@@ -225,3 +226,42 @@ func TestTx_CalcInputPreimageLegacy(t *testing.T) {
 		})
 	}
 }
+
+func TestTx_InputPreimage(t *testing.T) {
+	t.Parallel()
+
+	tx, err := transaction.NewTransactionFromHex("01000000027e2705da59f7112c7337d79840b56fff582b8f3a0e9df8eb19e282377bebb1bc0100000000ffffffffdebe6fe5ad8e9220a10fcf6340f7fca660d87aeedf0f74a142fba6de1f68d8490000000000ffffffff0300e1f505000000001976a9142987362cf0d21193ce7e7055824baac1ee245d0d88ac00e1f505000000001976a9143ca26faa390248b7a7ac45be53b0e4004ad7952688ac34657fe2000000001976a914eb0bd5edba389198e73f8efabddfc61666969ff788ac00000000")
+	require.NoError(t, err)
+	prevScript, err := script.NewFromHex("76a914eb0bd5edba389198e73f8efabddfc61666969ff788ac")
+	require.NoError(t, err)
+	for _, input := range tx.Inputs {
+		input.SetSourceTxOutput(&transaction.TransactionOutput{LockingScript: prevScript, Satoshis: 2000000000})
+	}
+
+	flags := []sighash.Flag{
+		sighash.AllForkID, sighash.NoneForkID, sighash.SingleForkID, sighash.AllForkID | sighash.AnyOneCanPay,
+		sighash.All, sighash.None, sighash.Single, sighash.All | sighash.AnyOneCanPay,
+	}
+	for _, flag := range flags {
+		for index := range tx.Inputs {
+			preimage, err := tx.InputPreimage(uint32(index), flag)
+			require.NoError(t, err)
+			hash, err := tx.CalcInputSignatureHash(uint32(index), flag)
+			require.NoError(t, err)
+			require.Equal(t, crypto.Sha256d(preimage), hash, "flag %s index %d", flag, index)
+
+			if flag.Has(sighash.ForkID) {
+				expected, err := tx.CalcInputPreimage(uint32(index), flag)
+				require.NoError(t, err)
+				require.Equal(t, expected, preimage)
+			} else {
+				expected, err := tx.CalcInputPreimageLegacy(uint32(index), flag)
+				require.NoError(t, err)
+				require.Equal(t, expected, preimage)
+			}
+		}
+	}
+
+	_, err = tx.InputPreimage(5, sighash.AllForkID)
+	require.ErrorIs(t, err, transaction.ErrInputNoExist)
+}
@@ -192,7 +192,7 @@ func (tt *Transactions) ReadFrom(r io.Reader) (int64, error) {
 		return bytesRead, err
 	}
 
-	*tt = make([]*Transaction, txCount)
+	*tt = make([]*Transaction, 0, preallocLen(uint64(txCount)))
 
 	for i := uint64(0); i < uint64(txCount); i++ {
 		tx := new(Transaction)
@@ -202,7 +202,7 @@ func (tt *Transactions) ReadFrom(r io.Reader) (int64, error) {
 			return bytesRead, err
 		}
 
-		(*tt)[i] = tx
+		*tt = append(*tt, tx)
 	}
 
 	return bytesRead, nil
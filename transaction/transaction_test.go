@@ -3,9 +3,11 @@ package transaction_test
 import (
 	"encoding/binary"
 	"encoding/hex"
+	"math"
 	"testing"
 
 	"github.com/bsv-blockchain/go-sdk/chainhash"
+	"github.com/bsv-blockchain/go-sdk/primitives/amount"
 	ec "github.com/bsv-blockchain/go-sdk/primitives/ec"
 	"github.com/bsv-blockchain/go-sdk/script"
 	"github.com/bsv-blockchain/go-sdk/transaction"
@@ -220,6 +222,49 @@ func TestTransactionGetFee(t *testing.T) {
 	require.Equal(t, expectedFee, fee)
 }
 
+func TestTransactionTotals(t *testing.T) {
+	source := transaction.NewTransaction()
+	source.AddOutput(&transaction.TransactionOutput{Satoshis: 700, LockingScript: &script.Script{script.OpTRUE}})
+	source.AddOutput(&transaction.TransactionOutput{Satoshis: 300, LockingScript: &script.Script{script.OpTRUE}})
+
+	tx := transaction.NewTransaction()
+	tx.AddInputFromTx(source, 0, nil)
+	tx.AddInputFromTx(source, 1, nil)
+	tx.AddOutput(&transaction.TransactionOutput{Satoshis: 600, LockingScript: &script.Script{script.OpTRUE}})
+	tx.AddOutput(&transaction.TransactionOutput{Satoshis: 350, LockingScript: &script.Script{script.OpTRUE}, Change: true})
+
+	fee, err := tx.GetFee()
+	require.NoError(t, err)
+	require.Equal(t, uint64(50), fee)
+	require.Equal(t, uint64(350), tx.TotalChangeSatoshis())
+
+	var spent []uint64
+	for i, out := range tx.SourceOutputs() {
+		require.Equal(t, len(spent), i)
+		spent = append(spent, out.Satoshis)
+	}
+	require.Equal(t, []uint64{700, 300}, spent)
+
+	var change []int
+	for i := range tx.ChangeOutputs() {
+		change = append(change, i)
+	}
+	require.Equal(t, []int{1}, change)
+
+	tx.Outputs[1].Satoshis = 500
+	_, err = tx.GetFee()
+	require.ErrorIs(t, err, transaction.ErrInsufficientInputs)
+
+	tx.AddInput(&transaction.TransactionInput{SourceTXID: source.TxID(), SourceTxOutIndex: 0})
+	_, err = tx.GetFee()
+	require.ErrorIs(t, err, transaction.ErrEmptyPreviousTx)
+	for i, out := range tx.SourceOutputs() {
+		if i == 2 {
+			require.Nil(t, out)
+		}
+	}
+}
+
 func TestTransactionFee(t *testing.T) {
 	// Example WIF and associated address
 	privKeyWIF := "KznvCNc6Yf4iztSThoMH6oHWzH9EgjfodKxmeuUGPq5DEX5maspS"
@@ -295,6 +340,51 @@ func TestTransactionFee(t *testing.T) {
 	t.Logf("Computed fee: %d satoshis", fee)
 }
 
+func TestEstimateSize(t *testing.T) {
+	privKey, err := ec.PrivateKeyFromWif("KznvCNc6Yf4iztSThoMH6oHWzH9EgjfodKxmeuUGPq5DEX5maspS")
+	require.NoError(t, err)
+	address, err := script.NewAddressFromPublicKey(privKey.PubKey(), true)
+	require.NoError(t, err)
+
+	sourceTx, err := transaction.NewTransactionFromHex("0100000001b1e5bf6e0649f299bb2b20964090b5b0a02e96db182eecedb0a9e4e7af03e06e000000006b483045022100ca75f7f664fa3086a3430b0f5d4a531d26e8d2ef3a72f086e890c5618d858fed022006e9a3c9f08e1743b033a55c27fb9d6c6cf1a1f6e0c40090e229d4ff8e5ecb31412102798913bc057b344de675dac34faafe3dc2f312c758cd9068209f810877306d66ffffffff01b0f9d804000000001976a9144bd8c375bdac70fb6eb7261d6e6c70450787e6af88ac00000000")
+	require.NoError(t, err)
+
+	unlocker, err := p2pkh.Unlock(privKey, nil)
+	require.NoError(t, err)
+	lockScript, err := p2pkh.Lock(address)
+	require.NoError(t, err)
+
+	tx := transaction.NewTransaction()
+	tx.AddInputFromTx(sourceTx, 0, unlocker)
+	tx.AddOutput(&transaction.TransactionOutput{
+		LockingScript: lockScript,
+		Satoshis:      1000000,
+	})
+
+	estimated, err := tx.EstimateSize()
+	require.NoError(t, err)
+
+	feeModel := &feemodel.SatoshisPerKilobyte{Satoshis: 1000}
+	fee, err := tx.EstimateFee(feeModel)
+	require.NoError(t, err)
+	require.Equal(t, uint64(1000), fee)
+
+	require.NoError(t, tx.Sign())
+	// DER signature lengths vary by a byte or two, so the estimate is approximate
+	require.InDelta(t, tx.Size(), estimated, 2)
+
+	signedSize, err := tx.EstimateSize()
+	require.NoError(t, err)
+	require.Equal(t, tx.Size(), signedSize)
+
+	t.Run("no unlocker", func(t *testing.T) {
+		tx := transaction.NewTransaction()
+		tx.AddInput(&transaction.TransactionInput{SourceTXID: sourceTx.TxID()})
+		_, err := tx.EstimateSize()
+		require.ErrorIs(t, err, transaction.ErrNoUnlockingScript)
+	})
+}
+
 func TestAtomicBEEF(t *testing.T) {
 	// First decode the BEEF data to get a transaction
 	beefBytes, err := hex.DecodeString(BRC62Hex)
@@ -326,3 +416,31 @@ func TestAtomicBEEF(t *testing.T) {
 	require.NoError(t, err)
 	require.NotNil(t, atomicBeefPartial)
 }
+
+func TestTransactionAmounts(t *testing.T) {
+	source := transaction.NewTransaction()
+	source.AddOutput(&transaction.TransactionOutput{Satoshis: 1000, LockingScript: &script.Script{script.OpTRUE}})
+	source.AddOutput(&transaction.TransactionOutput{Satoshis: math.MaxUint64, LockingScript: &script.Script{script.OpTRUE}})
+
+	tx := transaction.NewTransaction()
+	tx.AddInputFromTx(source, 0, nil)
+	tx.AddOutput(&transaction.TransactionOutput{Satoshis: 990, LockingScript: &script.Script{script.OpTRUE}})
+
+	in, err := tx.TotalInputSatoshis()
+	require.NoError(t, err)
+	require.Equal(t, uint64(1000), in)
+	require.Equal(t, amount.Satoshis(990), tx.Outputs[0].Amount())
+	fee, err := tx.GetFee()
+	require.NoError(t, err)
+	require.Equal(t, uint64(10), fee)
+
+	// Totals that would wrap around fail instead.
+	tx.AddOutput(&transaction.TransactionOutput{Satoshis: math.MaxUint64, LockingScript: &script.Script{script.OpTRUE}})
+	_, err = tx.GetFee()
+	require.ErrorIs(t, err, amount.ErrOverflow)
+	tx.AddInputFromTx(source, 1, nil)
+	_, err = tx.TotalInputSatoshis()
+	require.ErrorIs(t, err, amount.ErrOverflow)
+	_, err = tx.GetFee()
+	require.ErrorIs(t, err, amount.ErrOverflow)
+}
@@ -2,24 +2,41 @@ package transaction
 
 import (
 	"encoding/binary"
+	"iter"
 
 	"github.com/bsv-blockchain/go-sdk/chainhash"
+	"github.com/bsv-blockchain/go-sdk/primitives/amount"
 	crypto "github.com/bsv-blockchain/go-sdk/primitives/hash"
 	script "github.com/bsv-blockchain/go-sdk/script"
 )
 
-// TotalInputSatoshis returns the total Satoshis inputted to the transaction.
-func (tx *Transaction) TotalInputSatoshis() (total uint64, err error) {
+// TotalInputSatoshis returns the total Satoshis inputted to the transaction,
+// failing with amount.ErrOverflow rather than wrapping around.
+func (tx *Transaction) TotalInputSatoshis() (uint64, error) {
+	var total amount.Satoshis
 	for _, in := range tx.Inputs {
-		prevSats := uint64(0)
-		if in.SourceTxSatoshis() == nil {
+		sats := in.SourceTxSatoshis()
+		if sats == nil {
 			return 0, ErrEmptyPreviousTx
-		} else {
-			prevSats = *in.SourceTxSatoshis()
 		}
-		total += prevSats
+		var err error
+		if total, err = total.Add(amount.Satoshis(*sats)); err != nil {
+			return 0, err
+		}
+	}
+	return uint64(total), nil
+}
+
+// SourceOutputs iterates over the inputs of the transaction, yielding the index of
+// each input with the output it spends, or nil when the source output is not attached.
+func (tx *Transaction) SourceOutputs() iter.Seq2[int, *TransactionOutput] {
+	return func(yield func(int, *TransactionOutput) bool) {
+		for i, in := range tx.Inputs {
+			if !yield(i, in.SourceTxOutput()) {
+				return
+			}
+		}
 	}
-	return
 }
 
 func (tx *Transaction) AddInput(input *TransactionInput) {
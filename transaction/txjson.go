@@ -8,25 +8,35 @@ import (
 	"github.com/pkg/errors"
 )
 
+// The JSON shapes follow the TypeScript SDK: scripts are hex encoded, amounts are
+// satoshis and inputs reference their source by sourceTXID and sourceOutputIndex.
+// The txid and vout input fields written by earlier versions of this library are
+// still produced and accepted.
+
 type txJSON struct {
-	TxID     string               `json:"txid"`
-	Hex      string               `json:"hex"`
-	Inputs   []*TransactionInput  `json:"inputs"`
-	Outputs  []*TransactionOutput `json:"outputs"`
-	Version  uint32               `json:"version"`
-	LockTime uint32               `json:"lockTime"`
+	TxID       string               `json:"txid"`
+	Hex        string               `json:"hex"`
+	Inputs     []*TransactionInput  `json:"inputs"`
+	Outputs    []*TransactionOutput `json:"outputs"`
+	Version    uint32               `json:"version"`
+	LockTime   uint32               `json:"lockTime"`
+	MerklePath *MerklePath          `json:"merklePath,omitempty"`
 }
 
 type inputJSON struct {
-	UnlockingScript string `json:"unlockingScript"`
-	TxID            string `json:"txid"`
-	Vout            uint32 `json:"vout"`
-	Sequence        uint32 `json:"sequence"`
+	SourceTXID        string       `json:"sourceTXID,omitempty"`
+	SourceOutputIndex *uint32      `json:"sourceOutputIndex,omitempty"`
+	SourceTransaction *Transaction `json:"sourceTransaction,omitempty"`
+	UnlockingScript   string       `json:"unlockingScript,omitempty"`
+	TxID              string       `json:"txid,omitempty"`
+	Vout              *uint32      `json:"vout,omitempty"`
+	Sequence          uint32       `json:"sequence"`
 }
 
 type outputJSON struct {
 	Satoshis      uint64 `json:"satoshis"`
 	LockingScript string `json:"lockingScript"`
+	Change        bool   `json:"change,omitempty"`
 }
 
 // MarshalJSON will serialize a transaction to json.
@@ -35,16 +45,19 @@ func (tx *Transaction) MarshalJSON() ([]byte, error) {
 		return nil, errors.Wrap(ErrTxNil, "cannot marshal tx")
 	}
 	return json.Marshal(txJSON{
-		TxID:     tx.TxID().String(),
-		Hex:      tx.String(),
-		Inputs:   tx.Inputs,
-		Outputs:  tx.Outputs,
-		LockTime: tx.LockTime,
-		Version:  tx.Version,
+		TxID:       tx.TxID().String(),
+		Hex:        tx.String(),
+		Inputs:     tx.Inputs,
+		Outputs:    tx.Outputs,
+		LockTime:   tx.LockTime,
+		Version:    tx.Version,
+		MerklePath: tx.MerklePath,
 	})
 }
 
-// UnmarshalJSON will unmarshall a transaction that has been marshaled with this library.
+// UnmarshalJSON will unmarshall a transaction marshaled with this library or
+// the TypeScript SDK. When hex is present it takes precedence over the inputs and
+// outputs, which then only contribute their source transactions and change flags.
 func (tx *Transaction) UnmarshalJSON(b []byte) error {
 	var txj txJSON
 	if err := json.Unmarshal(b, &txj); err != nil {
@@ -56,23 +69,47 @@ func (tx *Transaction) UnmarshalJSON(b []byte) error {
 		if err != nil {
 			return err
 		}
+		if len(txj.Inputs) == len(t.Inputs) {
+			for i, in := range txj.Inputs {
+				t.Inputs[i].SourceTransaction = in.SourceTransaction
+			}
+		}
+		if len(txj.Outputs) == len(t.Outputs) {
+			for i, out := range txj.Outputs {
+				t.Outputs[i].Change = out.Change
+			}
+		}
+		t.MerklePath = txj.MerklePath
 		*tx = *t
 		return nil
 	}
+	tx.Inputs = txj.Inputs
+	tx.Outputs = txj.Outputs
 	tx.LockTime = txj.LockTime
 	tx.Version = txj.Version
+	tx.MerklePath = txj.MerklePath
 	return nil
 }
 
 // MarshalJSON will convert an input to json, expanding upon the
 // input struct to add additional fields.
 func (i *TransactionInput) MarshalJSON() ([]byte, error) {
-	return json.Marshal(&inputJSON{
-		TxID:            i.SourceTXID.String(),
-		Vout:            i.SourceTxOutIndex,
-		UnlockingScript: i.UnlockingScript.String(),
-		Sequence:        i.SequenceNumber,
-	})
+	ij := &inputJSON{
+		SourceOutputIndex: &i.SourceTxOutIndex,
+		SourceTransaction: i.SourceTransaction,
+		Vout:              &i.SourceTxOutIndex,
+		Sequence:          i.SequenceNumber,
+	}
+	if i.SourceTXID != nil {
+		ij.SourceTXID = i.SourceTXID.String()
+	} else if i.SourceTransaction != nil {
+		ij.SourceTXID = i.SourceTransaction.TxID().String()
+	}
+	ij.TxID = ij.SourceTXID
+	if i.UnlockingScript != nil {
+		ij.UnlockingScript = i.UnlockingScript.String()
+	}
+	return json.Marshal(ij)
 }
 
 // UnmarshalJSON will convert a JSON input to an input.
@@ -81,27 +118,50 @@ func (i *TransactionInput) UnmarshalJSON(b []byte) error {
 	if err := json.Unmarshal(b, &ij); err != nil {
 		return err
 	}
-	ptxID, err := chainhash.NewHashFromHex(ij.TxID)
-	if err != nil {
-		return err
+	txid := ij.SourceTXID
+	if txid == "" {
+		txid = ij.TxID
 	}
-	s, err := script.NewFromHex(ij.UnlockingScript)
-	if err != nil {
-		return err
+	switch {
+	case txid != "":
+		ptxID, err := chainhash.NewHashFromHex(txid)
+		if err != nil {
+			return err
+		}
+		i.SourceTXID = ptxID
+	case ij.SourceTransaction != nil:
+		i.SourceTXID = ij.SourceTransaction.TxID()
+	default:
+		return errors.New("input is missing sourceTXID")
 	}
-	i.UnlockingScript = s
-	i.SourceTXID = ptxID
-	i.SourceTxOutIndex = ij.Vout
+	switch {
+	case ij.SourceOutputIndex != nil:
+		i.SourceTxOutIndex = *ij.SourceOutputIndex
+	case ij.Vout != nil:
+		i.SourceTxOutIndex = *ij.Vout
+	}
+	if ij.UnlockingScript != "" {
+		s, err := script.NewFromHex(ij.UnlockingScript)
+		if err != nil {
+			return err
+		}
+		i.UnlockingScript = s
+	}
+	i.SourceTransaction = ij.SourceTransaction
 	i.SequenceNumber = ij.Sequence
 	return nil
 }
 
 // MarshalJSON will serialize an output to json.
 func (o *TransactionOutput) MarshalJSON() ([]byte, error) {
-	return json.Marshal(&outputJSON{
-		Satoshis:      o.Satoshis,
-		LockingScript: o.LockingScriptHex(),
-	})
+	oj := &outputJSON{
+		Satoshis: o.Satoshis,
+		Change:   o.Change,
+	}
+	if o.LockingScript != nil {
+		oj.LockingScript = o.LockingScriptHex()
+	}
+	return json.Marshal(oj)
 }
 
 // UnmarshalJSON will convert a json serialized output to a bt Output.
@@ -116,5 +176,6 @@ func (o *TransactionOutput) UnmarshalJSON(b []byte) error {
 	}
 	o.Satoshis = oj.Satoshis
 	o.LockingScript = s
+	o.Change = oj.Change
 	return nil
 }
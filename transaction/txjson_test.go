@@ -105,6 +105,8 @@ func TestTx_MarshallJSON(t *testing.T) {
 	"hex": "0100000001abad53d72f342dd3f338e5e3346b492440f8ea821f8b8800e318f461cc5ea5a2010000006a4730440220042edc1302c5463e8397120a56b28ea381c8f7f6d9bdc1fee5ebca00c84a76e2022077069bbdb7ed701c4977b7db0aba80d41d4e693112256660bb5d674599e390cf41210294639d6e4249ea381c2e077e95c78fc97afe47a52eb24e1b1595cd3fdd0afdf8ffffffff02000000000000000008006a0548656c6c6f7f030000000000001976a914b85524abf8202a961b847a3bd0bc89d3d4d41cc588ac00000000",
 	"inputs": [
 		{
+			"sourceTXID": "a2a55ecc61f418e300888b1f82eaf84024496b34e3e538f3d32d342fd753adab",
+			"sourceOutputIndex": 1,
 			"unlockingScript": "4730440220042edc1302c5463e8397120a56b28ea381c8f7f6d9bdc1fee5ebca00c84a76e2022077069bbdb7ed701c4977b7db0aba80d41d4e693112256660bb5d674599e390cf41210294639d6e4249ea381c2e077e95c78fc97afe47a52eb24e1b1595cd3fdd0afdf8",
 			"txid": "a2a55ecc61f418e300888b1f82eaf84024496b34e3e538f3d32d342fd753adab",
 			"vout": 1,
@@ -166,18 +168,24 @@ func TestTx_MarshallJSON(t *testing.T) {
 	"hex": "0100000003d5da6f960610cc65153521fd16dbe96b499143ac8d03222c13a9b97ce2dd8e3c000000006b48304502210081214df575da1e9378f1d5a29dfd6811e93466a7222fb010b7c50dd2d44d7f2e0220399bb396336d2e294049e7db009926b1b30018ac834ee0cbca20b9d99f488038412102798913bc057b344de675dac34faafe3dc2f312c758cd9068209f810877306d66ffffffffd5da6f960610cc65153521fd16dbe96b499143ac8d03222c13a9b97ce2dd8e3c0200000069463043021f7059426d6aeb7d74275e52819a309b2bf903bd18b2b4d942d0e8e037681df702203f851f8a45aabfefdca5822f457609600f5d12a173adc09c6e7e2d4fdff7620a412102798913bc057b344de675dac34faafe3dc2f312c758cd9068209f810877306d66ffffffffd5da6f960610cc65153521fd16dbe96b499143ac8d03222c13a9b97ce2dd8e3c720000006b483045022100e7b3837f2818fe00a05293e0f90e9005d59b0c5c8890f22bd31c36190a9b55e9022027de4b77b78139ea21b9fd30876a447bbf29662bd19d7914028c607bccd772e4412102798913bc057b344de675dac34faafe3dc2f312c758cd9068209f810877306d66ffffffff01e8030000000000001976a914eb0bd5edba389198e73f8efabddfc61666969ff788ac00000000",
 	"inputs": [
 		{
+			"sourceTXID": "3c8edde27cb9a9132c22038dac4391496be9db16fd21351565cc1006966fdad5",
+			"sourceOutputIndex": 0,
 			"unlockingScript": "48304502210081214df575da1e9378f1d5a29dfd6811e93466a7222fb010b7c50dd2d44d7f2e0220399bb396336d2e294049e7db009926b1b30018ac834ee0cbca20b9d99f488038412102798913bc057b344de675dac34faafe3dc2f312c758cd9068209f810877306d66",
 			"txid": "3c8edde27cb9a9132c22038dac4391496be9db16fd21351565cc1006966fdad5",
 			"vout": 0,
 			"sequence": 4294967295
 		},
 		{
+			"sourceTXID": "3c8edde27cb9a9132c22038dac4391496be9db16fd21351565cc1006966fdad5",
+			"sourceOutputIndex": 2,
 			"unlockingScript": "463043021f7059426d6aeb7d74275e52819a309b2bf903bd18b2b4d942d0e8e037681df702203f851f8a45aabfefdca5822f457609600f5d12a173adc09c6e7e2d4fdff7620a412102798913bc057b344de675dac34faafe3dc2f312c758cd9068209f810877306d66",
 			"txid": "3c8edde27cb9a9132c22038dac4391496be9db16fd21351565cc1006966fdad5",
 			"vout": 2,
 			"sequence": 4294967295
 		},
 		{
+			"sourceTXID": "3c8edde27cb9a9132c22038dac4391496be9db16fd21351565cc1006966fdad5",
+			"sourceOutputIndex": 114,
 			"unlockingScript": "483045022100e7b3837f2818fe00a05293e0f90e9005d59b0c5c8890f22bd31c36190a9b55e9022027de4b77b78139ea21b9fd30876a447bbf29662bd19d7914028c607bccd772e4412102798913bc057b344de675dac34faafe3dc2f312c758cd9068209f810877306d66",
 			"txid": "3c8edde27cb9a9132c22038dac4391496be9db16fd21351565cc1006966fdad5",
 			"vout": 114,
@@ -267,3 +275,78 @@ func TestTx_ToJson(t *testing.T) {
 	_, err := json.MarshalIndent(tx, "", "\t")
 	require.NoError(t, err)
 }
+
+func TestTx_UnmarshalJSON_TSShape(t *testing.T) {
+	source, err := transaction.NewTransactionFromHex("0100000001abad53d72f342dd3f338e5e3346b492440f8ea821f8b8800e318f461cc5ea5a2010000006a4730440220042edc1302c5463e8397120a56b28ea381c8f7f6d9bdc1fee5ebca00c84a76e2022077069bbdb7ed701c4977b7db0aba80d41d4e693112256660bb5d674599e390cf41210294639d6e4249ea381c2e077e95c78fc97afe47a52eb24e1b1595cd3fdd0afdf8ffffffff02000000000000000008006a0548656c6c6f7f030000000000001976a914b85524abf8202a961b847a3bd0bc89d3d4d41cc588ac00000000")
+	require.NoError(t, err)
+
+	// An unsigned transaction as produced by the TypeScript SDK, without hex
+	var tx transaction.Transaction
+	require.NoError(t, json.Unmarshal([]byte(`{
+		"version": 2,
+		"lockTime": 10,
+		"inputs": [
+			{
+				"sourceTXID": "aec245f27b7640c8b1865045107731bfb848115c573f7da38166074b1c9e475d",
+				"sourceOutputIndex": 1,
+				"sequence": 4294967295
+			}
+		],
+		"outputs": [
+			{"satoshis": 500, "lockingScript": "76a914b85524abf8202a961b847a3bd0bc89d3d4d41cc588ac"},
+			{"satoshis": 390, "lockingScript": "76a914b85524abf8202a961b847a3bd0bc89d3d4d41cc588ac", "change": true}
+		]
+	}`), &tx))
+
+	require.Equal(t, uint32(2), tx.Version)
+	require.Equal(t, uint32(10), tx.LockTime)
+	require.Len(t, tx.Inputs, 1)
+	require.Equal(t, source.TxID(), tx.Inputs[0].SourceTXID)
+	require.Equal(t, uint32(1), tx.Inputs[0].SourceTxOutIndex)
+	require.Nil(t, tx.Inputs[0].UnlockingScript)
+	require.Len(t, tx.Outputs, 2)
+	require.Equal(t, uint64(500), tx.Outputs[0].Satoshis)
+	require.False(t, tx.Outputs[0].Change)
+	require.True(t, tx.Outputs[1].Change)
+
+	var in transaction.TransactionInput
+	require.EqualError(t, json.Unmarshal([]byte(`{"sourceOutputIndex": 0}`), &in), "input is missing sourceTXID")
+}
+
+func TestTx_JSON_SourceTransactionsAndMerklePath(t *testing.T) {
+	source, err := transaction.NewTransactionFromHex("0100000001abad53d72f342dd3f338e5e3346b492440f8ea821f8b8800e318f461cc5ea5a2010000006a4730440220042edc1302c5463e8397120a56b28ea381c8f7f6d9bdc1fee5ebca00c84a76e2022077069bbdb7ed701c4977b7db0aba80d41d4e693112256660bb5d674599e390cf41210294639d6e4249ea381c2e077e95c78fc97afe47a52eb24e1b1595cd3fdd0afdf8ffffffff02000000000000000008006a0548656c6c6f7f030000000000001976a914b85524abf8202a961b847a3bd0bc89d3d4d41cc588ac00000000")
+	require.NoError(t, err)
+	isTxid := true
+	source.MerklePath = transaction.NewMerklePath(813706, [][]*transaction.PathElement{{
+		{Offset: 0, Hash: source.TxID(), Txid: &isTxid},
+	}})
+
+	tx := transaction.NewTransaction()
+	tx.AddInputFromTx(source, 1, nil)
+	tx.AddOutput(&transaction.TransactionOutput{Satoshis: 800, LockingScript: source.Outputs[1].LockingScript, Change: true})
+
+	bb, err := json.Marshal(tx)
+	require.NoError(t, err)
+
+	var fields map[string]any
+	require.NoError(t, json.Unmarshal(bb, &fields))
+	input := fields["inputs"].([]any)[0].(map[string]any)
+	require.Equal(t, source.TxID().String(), input["sourceTXID"])
+	require.Equal(t, map[string]any{
+		"blockHeight": float64(813706),
+		"path": []any{[]any{map[string]any{
+			"offset": float64(0),
+			"hash":   source.TxID().String(),
+			"txid":   true,
+		}}},
+	}, input["sourceTransaction"].(map[string]any)["merklePath"])
+
+	var decoded transaction.Transaction
+	require.NoError(t, json.Unmarshal(bb, &decoded))
+	require.Equal(t, tx.TxID(), decoded.TxID())
+	require.True(t, decoded.Outputs[0].Change)
+	require.NotNil(t, decoded.Inputs[0].SourceTransaction)
+	require.Equal(t, source.TxID(), decoded.Inputs[0].SourceTransaction.TxID())
+	require.Equal(t, source.MerklePath, decoded.Inputs[0].SourceTransaction.MerklePath)
+	require.Equal(t, uint64(895), decoded.Inputs[0].SourceTxOutput().Satoshis)
+}
@@ -4,7 +4,9 @@ import (
 	"encoding/binary"
 	"encoding/hex"
 	"fmt"
+	"iter"
 
+	"github.com/bsv-blockchain/go-sdk/primitives/amount"
 	crypto "github.com/bsv-blockchain/go-sdk/primitives/hash"
 	script "github.com/bsv-blockchain/go-sdk/script"
 	"github.com/bsv-blockchain/go-sdk/util"
@@ -42,6 +44,39 @@ func (tx *Transaction) TotalOutputSatoshis() (total uint64) {
 	return
 }
 
+// outputAmount returns the total amount outputted from the transaction, failing
+// with amount.ErrOverflow where TotalOutputSatoshis would wrap around.
+func (tx *Transaction) outputAmount() (amount.Satoshis, error) {
+	var total amount.Satoshis
+	for _, o := range tx.Outputs {
+		var err error
+		if total, err = total.Add(o.Amount()); err != nil {
+			return 0, err
+		}
+	}
+	return total, nil
+}
+
+// TotalChangeSatoshis returns the total Satoshis of the change outputs.
+func (tx *Transaction) TotalChangeSatoshis() (total uint64) {
+	for _, o := range tx.ChangeOutputs() {
+		total += o.Satoshis
+	}
+	return
+}
+
+// ChangeOutputs iterates over the change outputs of the transaction, yielding
+// each with its output index.
+func (tx *Transaction) ChangeOutputs() iter.Seq2[int, *TransactionOutput] {
+	return func(yield func(int, *TransactionOutput) bool) {
+		for i, o := range tx.Outputs {
+			if o.Change && !yield(i, o) {
+				return
+			}
+		}
+	}
+}
+
 // AddHashPuzzleOutput makes an output to a hash puzzle + PKH with a value.
 func (tx *Transaction) AddHashPuzzleOutput(secret, publicKeyHash string, satoshis uint64) error {
 	publicKeyHashBytes, err := hex.DecodeString(publicKeyHash)
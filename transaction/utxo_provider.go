@@ -0,0 +1,96 @@
+package transaction
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/bsv-blockchain/go-sdk/chainhash"
+	"github.com/pkg/errors"
+)
+
+// ErrSourceNotFound is returned by UtxoProviders which do not know a source
+// transaction or output.
+var ErrSourceNotFound = errors.New("source not found")
+
+// UtxoProvider resolves the transactions spent by inputs built from bare
+// outpoints, from an explorer, a node or a store of BEEF.
+type UtxoProvider interface {
+	// SourceTransaction returns the transaction with txid, or an error wrapping
+	// ErrSourceNotFound when the provider does not know it.
+	SourceTransaction(ctx context.Context, txid *chainhash.Hash) (*Transaction, error)
+}
+
+// SourceOutputProvider is implemented by UtxoProviders which can resolve single
+// outputs more cheaply than whole transactions, such as UTXO indexes.
+// HydrateInputs prefers SourceOutput when the provider implements it.
+type SourceOutputProvider interface {
+	// SourceOutput returns the output at outpoint, or an error wrapping
+	// ErrSourceNotFound when the provider does not know it.
+	SourceOutput(ctx context.Context, outpoint *Outpoint) (*TransactionOutput, error)
+}
+
+// UtxoProviderFunc adapts a function to a UtxoProvider.
+type UtxoProviderFunc func(ctx context.Context, txid *chainhash.Hash) (*Transaction, error)
+
+func (f UtxoProviderFunc) SourceTransaction(ctx context.Context, txid *chainhash.Hash) (*Transaction, error) {
+	return f(ctx, txid)
+}
+
+// SourceTransaction returns the transaction with txid held by the BEEF, making a
+// Beef a UtxoProvider. Transactions the BEEF only has the txid of are not found.
+func (b *Beef) SourceTransaction(_ context.Context, txid *chainhash.Hash) (*Transaction, error) {
+	if beefTx := b.findTxid(txid); beefTx == nil || beefTx.DataFormat == TxIDOnly {
+		return nil, fmt.Errorf("%w: transaction %s is not in the BEEF", ErrSourceNotFound, txid)
+	}
+	return b.FindTransactionForSigningByHash(txid), nil
+}
+
+// HydrateInputs fetches the source of every input missing it from provider, so
+// the transaction built from bare outpoints can be signed and its fee computed.
+// Inputs which already have their source output are left alone. Source
+// transactions are fetched once per txid and checked to hash to it, and are
+// attached as SourceTransaction; outputs from a SourceOutputProvider are
+// attached with SetSourceTxOutput.
+//
+// The error is an *InputError for the first input which could not be hydrated.
+func (tx *Transaction) HydrateInputs(ctx context.Context, provider UtxoProvider) error {
+	outputs, _ := provider.(SourceOutputProvider)
+	fetched := make(map[chainhash.Hash]*Transaction)
+	for vin, in := range tx.Inputs {
+		if in.SourceTxOutput() != nil {
+			continue
+		}
+		if in.SourceTXID == nil {
+			return &InputError{Index: uint32(vin), Err: ErrEmptyPreviousTxID}
+		}
+
+		if outputs != nil {
+			output, err := outputs.SourceOutput(ctx, &Outpoint{Txid: *in.SourceTXID, Index: in.SourceTxOutIndex})
+			if err != nil {
+				return &InputError{Index: uint32(vin), Err: err}
+			}
+			in.SetSourceTxOutput(output)
+			continue
+		}
+
+		source, ok := fetched[*in.SourceTXID]
+		if !ok {
+			var err error
+			if source, err = provider.SourceTransaction(ctx, in.SourceTXID); err != nil {
+				return &InputError{Index: uint32(vin), Err: err}
+			}
+			if source == nil {
+				return &InputError{Index: uint32(vin), Err: fmt.Errorf("%w: transaction %s", ErrSourceNotFound, in.SourceTXID)}
+			}
+			if txid := source.TxID(); !txid.IsEqual(in.SourceTXID) {
+				return &InputError{Index: uint32(vin), Err: fmt.Errorf("provider returned transaction %s for %s", txid, in.SourceTXID)}
+			}
+			fetched[*in.SourceTXID] = source
+		}
+		if int(in.SourceTxOutIndex) >= len(source.Outputs) {
+			return &InputError{Index: uint32(vin), Err: fmt.Errorf("%w: %s has %d outputs", ErrOutputNoExist, in.SourceOutpoint(), len(source.Outputs))}
+		}
+		in.SourceTransaction = source
+	}
+	return nil
+}
@@ -0,0 +1,129 @@
+package transaction_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/bsv-blockchain/go-sdk/chainhash"
+	"github.com/bsv-blockchain/go-sdk/script"
+	"github.com/bsv-blockchain/go-sdk/script/interpreter"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+	"github.com/stretchr/testify/require"
+)
+
+// outputProvider resolves single outputs from a map.
+type outputProvider map[transaction.Outpoint]*transaction.TransactionOutput
+
+func (p outputProvider) SourceTransaction(context.Context, *chainhash.Hash) (*transaction.Transaction, error) {
+	return nil, errors.New("not supported")
+}
+
+func (p outputProvider) SourceOutput(_ context.Context, outpoint *transaction.Outpoint) (*transaction.TransactionOutput, error) {
+	if out, ok := p[*outpoint]; ok {
+		return out, nil
+	}
+	return nil, transaction.ErrSourceNotFound
+}
+
+// bareInputs returns a copy of tx whose inputs only have their outpoints and
+// templates.
+func bareInputs(tx *transaction.Transaction) *transaction.Transaction {
+	bare := transaction.NewTransaction()
+	for _, in := range tx.Inputs {
+		bare.AddInput(&transaction.TransactionInput{
+			SourceTXID:              in.SourceTXID,
+			SourceTxOutIndex:        in.SourceTxOutIndex,
+			SequenceNumber:          in.SequenceNumber,
+			UnlockingScriptTemplate: in.UnlockingScriptTemplate,
+		})
+	}
+	bare.Outputs = tx.Outputs
+	return bare
+}
+
+func TestHydrateInputs(t *testing.T) {
+	ctx := t.Context()
+	signed := signAllTx(t, 3)
+	source := signed.Inputs[0].SourceTransaction
+
+	t.Run("source transactions", func(t *testing.T) {
+		tx := bareInputs(signed)
+		var calls int
+		provider := transaction.UtxoProviderFunc(func(_ context.Context, txid *chainhash.Hash) (*transaction.Transaction, error) {
+			calls++
+			require.True(t, txid.IsEqual(source.TxID()))
+			return source, nil
+		})
+		require.NoError(t, tx.HydrateInputs(ctx, provider))
+		require.Equal(t, 1, calls)
+		for _, in := range tx.Inputs {
+			require.Same(t, source, in.SourceTransaction)
+		}
+
+		fee, err := tx.GetFee()
+		require.NoError(t, err)
+		require.Equal(t, uint64(300), fee)
+		require.NoError(t, tx.SignAll(ctx, transaction.VerifyAfterSign(interpreter.VerifyInput)))
+
+		// Hydrated inputs are not fetched again.
+		require.NoError(t, tx.HydrateInputs(ctx, provider))
+		require.Equal(t, 1, calls)
+	})
+
+	t.Run("source outputs", func(t *testing.T) {
+		tx := bareInputs(signed)
+		provider := outputProvider{}
+		for vout, out := range source.Outputs {
+			provider[transaction.Outpoint{Txid: *source.TxID(), Index: uint32(vout)}] = out
+		}
+		require.NoError(t, tx.HydrateInputs(ctx, provider))
+		for _, in := range tx.Inputs {
+			require.Nil(t, in.SourceTransaction)
+			require.NotNil(t, in.SourceTxOutput())
+		}
+		require.NoError(t, tx.SignAll(ctx, transaction.VerifyAfterSign(interpreter.VerifyInput)))
+	})
+
+	t.Run("beef", func(t *testing.T) {
+		beef, err := transaction.NewBeefFromTransaction(signed)
+		require.NoError(t, err)
+		tx := bareInputs(signed)
+		require.NoError(t, tx.HydrateInputs(ctx, beef))
+		require.True(t, tx.Inputs[2].SourceTransaction.TxID().IsEqual(source.TxID()))
+
+		_, err = beef.SourceTransaction(ctx, &chainhash.Hash{1})
+		require.ErrorIs(t, err, transaction.ErrSourceNotFound)
+	})
+
+	t.Run("failures", func(t *testing.T) {
+		tx := bareInputs(signed)
+		notFound := transaction.UtxoProviderFunc(func(context.Context, *chainhash.Hash) (*transaction.Transaction, error) {
+			return nil, transaction.ErrSourceNotFound
+		})
+		err := tx.HydrateInputs(ctx, notFound)
+		var inputErr *transaction.InputError
+		require.ErrorAs(t, err, &inputErr)
+		require.Equal(t, uint32(0), inputErr.Index)
+		require.ErrorIs(t, err, transaction.ErrSourceNotFound)
+
+		other := transaction.NewTransaction()
+		other.AddOutput(&transaction.TransactionOutput{Satoshis: 1, LockingScript: &script.Script{script.OpTRUE}})
+		wrong := transaction.UtxoProviderFunc(func(context.Context, *chainhash.Hash) (*transaction.Transaction, error) {
+			return other, nil
+		})
+		require.ErrorContains(t, tx.HydrateInputs(ctx, wrong), "provider returned transaction")
+
+		tx = bareInputs(signed)
+		tx.Inputs[1].SourceTxOutIndex = 7
+		err = tx.HydrateInputs(ctx, transaction.UtxoProviderFunc(func(context.Context, *chainhash.Hash) (*transaction.Transaction, error) {
+			return source, nil
+		}))
+		require.ErrorAs(t, err, &inputErr)
+		require.Equal(t, uint32(1), inputErr.Index)
+		require.ErrorIs(t, err, transaction.ErrOutputNoExist)
+
+		tx.Inputs[1].SourceTXID = nil
+		require.ErrorIs(t, tx.HydrateInputs(ctx, notFound), transaction.ErrEmptyPreviousTxID)
+	})
+}
@@ -0,0 +1,76 @@
+// Package utxoprovider provides transaction.UtxoProviders fetching the source
+// transactions of inputs from explorers. Nodes are served by
+// node/rpcclient.Client and BEEF by transaction.Beef, which are UtxoProviders
+// themselves.
+package utxoprovider
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/bsv-blockchain/go-sdk/chainhash"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+	"github.com/bsv-blockchain/go-sdk/transaction/chaintracker"
+	"github.com/bsv-blockchain/go-sdk/util"
+)
+
+var _ transaction.UtxoProvider = (*WhatsOnChain)(nil)
+
+// WhatsOnChain fetches source transactions from the WhatsOnChain API.
+type WhatsOnChain struct {
+	Network chaintracker.Network
+	ApiKey  string
+	Client  util.HTTPClient
+	// BaseURL overrides the API root, https://api.whatsonchain.com/v1/bsv/<network>
+	// by default.
+	BaseURL string
+}
+
+// NewWhatsOnChain creates a WhatsOnChain provider for network.
+func NewWhatsOnChain(network chaintracker.Network, apiKey string) *WhatsOnChain {
+	return &WhatsOnChain{Network: network, ApiKey: apiKey}
+}
+
+// SourceTransaction fetches the raw transaction with txid.
+func (w *WhatsOnChain) SourceTransaction(ctx context.Context, txid *chainhash.Hash) (*transaction.Transaction, error) {
+	baseURL := w.BaseURL
+	if baseURL == "" {
+		baseURL = fmt.Sprintf("https://api.whatsonchain.com/v1/bsv/%s", w.Network)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("%s/tx/%s/hex", baseURL, txid), nil)
+	if err != nil {
+		return nil, err
+	}
+	if w.ApiKey != "" {
+		req.Header.Set("Authorization", w.ApiKey)
+	}
+
+	client := w.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound:
+		return nil, fmt.Errorf("%w: transaction %s", transaction.ErrSourceNotFound, txid)
+	default:
+		return nil, &util.HTTPError{
+			StatusCode: resp.StatusCode,
+			Err:        fmt.Errorf("failed to fetch transaction %s: %s", txid, strings.TrimSpace(string(body))),
+		}
+	}
+	return transaction.NewTransactionFromHex(strings.TrimSpace(string(body)))
+}
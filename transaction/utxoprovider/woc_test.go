@@ -0,0 +1,52 @@
+package utxoprovider
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/bsv-blockchain/go-sdk/chainhash"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+	"github.com/bsv-blockchain/go-sdk/util"
+	"github.com/stretchr/testify/require"
+)
+
+const testTxHex = "0100000001b1e5bf6e0649f299bb2b20964090b5b0a02e96db182eecedb0a9e4e7af03e06e000000006b483045022100ca75f7f664fa3086a3430b0f5d4a531d26e8d2ef3a72f086e890c5618d858fed022006e9a3c9f08e1743b033a55c27fb9d6c6cf1a1f6e0c40090e229d4ff8e5ecb31412102798913bc057b344de675dac34faafe3dc2f312c758cd9068209f810877306d66ffffffff01b0f9d804000000001976a9144bd8c375bdac70fb6eb7261d6e6c70450787e6af88ac00000000"
+
+func TestWhatsOnChain(t *testing.T) {
+	tx, err := transaction.NewTransactionFromHex(testTxHex)
+	require.NoError(t, err)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/tx/" + tx.TxID().String() + "/hex":
+			if r.Header.Get("Authorization") != "key" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			_, _ = w.Write([]byte(testTxHex + "\n"))
+		case "/tx/" + chainhash.Hash{}.String() + "/hex":
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write([]byte("rate limited"))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+	woc := NewWhatsOnChain("main", "key")
+	woc.BaseURL = server.URL
+	ctx := t.Context()
+
+	fetched, err := woc.SourceTransaction(ctx, tx.TxID())
+	require.NoError(t, err)
+	require.Equal(t, testTxHex, fetched.Hex())
+
+	_, err = woc.SourceTransaction(ctx, &chainhash.Hash{1})
+	require.ErrorIs(t, err, transaction.ErrSourceNotFound)
+
+	_, err = woc.SourceTransaction(ctx, &chainhash.Hash{})
+	var httpErr *util.HTTPError
+	require.ErrorAs(t, err, &httpErr)
+	require.Equal(t, http.StatusInternalServerError, httpErr.StatusCode)
+	require.ErrorContains(t, err, "rate limited")
+}
@@ -0,0 +1,71 @@
+package ratelimit
+
+import (
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// Backoff computes jittered exponential delays between retries.
+type Backoff struct {
+	// Initial is the delay before the first retry.
+	Initial time.Duration
+	// Max caps the delay between retries.
+	Max time.Duration
+	// Multiplier grows the delay after each retry, 2 when zero.
+	Multiplier float64
+	// Jitter is the fraction of the delay that is randomised, from 0 for fixed
+	// delays to 1 for delays anywhere between zero and the computed delay.
+	Jitter float64
+}
+
+// DefaultBackoff starts at half a second and doubles up to 30 seconds, with half
+// of each delay randomised.
+var DefaultBackoff = Backoff{
+	Initial:    500 * time.Millisecond,
+	Max:        30 * time.Second,
+	Multiplier: 2,
+	Jitter:     0.5,
+}
+
+// Delay returns the delay before the given retry, counting from zero.
+func (b Backoff) Delay(retry int) time.Duration {
+	multiplier := b.Multiplier
+	if multiplier == 0 {
+		multiplier = 2
+	}
+	delay := float64(b.Initial)
+	for i := 0; i < retry && (b.Max <= 0 || delay < float64(b.Max)); i++ {
+		delay *= multiplier
+	}
+	if b.Max > 0 && delay > float64(b.Max) {
+		delay = float64(b.Max)
+	}
+	if jitter := min(max(b.Jitter, 0), 1); jitter > 0 {
+		delay -= delay * jitter * rand.Float64()
+	}
+	return time.Duration(delay)
+}
+
+// RetryAfter returns the delay requested by the Retry-After header of resp, given
+// either in seconds or as an HTTP date.
+func RetryAfter(resp *http.Response, now time.Time) (time.Duration, bool) {
+	if resp == nil {
+		return 0, false
+	}
+	value := resp.Header.Get("Retry-After")
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+	if at, err := http.ParseTime(value); err == nil {
+		return max(at.Sub(now), 0), true
+	}
+	return 0, false
+}
@@ -0,0 +1,119 @@
+package ratelimit
+
+import (
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/bsv-blockchain/go-sdk/util"
+)
+
+// Client wraps an HTTP client, waiting for its Limiter before each request and
+// retrying requests the server throttled (429 Too Many Requests and 503 Service
+// Unavailable) after the delay of the Retry-After header or of its Backoff.
+// Requests whose body cannot be replayed are not retried.
+type Client struct {
+	inner      util.HTTPClient
+	limiter    *Limiter
+	backoff    Backoff
+	maxRetries int
+	maxWait    time.Duration
+	now        func() time.Time
+}
+
+var _ util.HTTPClient = (*Client)(nil)
+
+// Option configures a Client.
+type Option func(*Client)
+
+// WithLimiter limits the request rate. Limiters may be shared by clients calling
+// the same service. Requests are not rate limited by default.
+func WithLimiter(l *Limiter) Option {
+	return func(c *Client) {
+		c.limiter = l
+	}
+}
+
+// WithBackoff sets the delays between retries, DefaultBackoff by default.
+func WithBackoff(b Backoff) Option {
+	return func(c *Client) {
+		c.backoff = b
+	}
+}
+
+// WithMaxRetries sets how many times a throttled request is retried, 3 by default.
+// Zero disables retries.
+func WithMaxRetries(n int) Option {
+	return func(c *Client) {
+		c.maxRetries = max(n, 0)
+	}
+}
+
+// WithMaxRetryAfter caps the delay honoured from Retry-After headers, one minute
+// by default. Throttled responses asking for longer are returned to the caller.
+func WithMaxRetryAfter(d time.Duration) Option {
+	return func(c *Client) {
+		c.maxWait = d
+	}
+}
+
+// NewClient wraps inner, or http.DefaultClient if inner is nil.
+func NewClient(inner util.HTTPClient, opts ...Option) *Client {
+	if inner == nil {
+		inner = http.DefaultClient
+	}
+	c := &Client{
+		inner:      inner,
+		backoff:    DefaultBackoff,
+		maxRetries: 3,
+		maxWait:    time.Minute,
+		now:        time.Now,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// Do sends the request, honouring the context of req while waiting.
+func (c *Client) Do(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+	for retry := 0; ; retry++ {
+		if err := c.limiter.Wait(ctx); err != nil {
+			return nil, err
+		}
+		resp, err := c.inner.Do(req)
+		if err != nil || !throttled(resp) || retry >= c.maxRetries {
+			return resp, err
+		}
+
+		delay, ok := RetryAfter(resp, c.now())
+		if !ok {
+			delay = c.backoff.Delay(retry)
+		} else if delay > c.maxWait {
+			return resp, nil
+		}
+		if req.Body != nil && req.Body != http.NoBody {
+			if req.GetBody == nil {
+				return resp, nil
+			}
+			body, err := req.GetBody()
+			if err != nil {
+				return resp, nil
+			}
+			req = req.Clone(ctx)
+			req.Body = body
+		}
+		// Drain the body so the connection can be reused.
+		_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+		resp.Body.Close()
+
+		if err := sleep(ctx, delay); err != nil {
+			return nil, err
+		}
+	}
+}
+
+func throttled(resp *http.Response) bool {
+	return resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode == http.StatusServiceUnavailable
+}
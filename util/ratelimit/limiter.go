@@ -0,0 +1,107 @@
+// Package ratelimit provides client-side rate limiting and retry backoff for the
+// SDK's API clients. Wrap the HTTP client of a broadcaster, chain tracker or
+// overlay client with NewClient to limit its request rate and retry throttled
+// requests:
+//
+//	arc := &broadcaster.Arc{
+//		ApiUrl: "https://arc.taal.com/v1",
+//		Client: ratelimit.NewClient(http.DefaultClient,
+//			ratelimit.WithLimiter(ratelimit.NewLimiter(3, 3))),
+//	}
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Limiter is a token bucket allowing bursts of up to burst requests, refilled at
+// rate requests per second. A nil Limiter allows everything.
+type Limiter struct {
+	mu     sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+	now    func() time.Time
+}
+
+// NewLimiter creates a Limiter allowing rate requests per second with bursts of up
+// to burst requests. The bucket starts full.
+func NewLimiter(rate float64, burst int) *Limiter {
+	if burst < 1 {
+		burst = 1
+	}
+	l := &Limiter{rate: rate, burst: float64(burst), tokens: float64(burst), now: time.Now}
+	l.last = l.now()
+	return l
+}
+
+// reserve takes a token and returns how long to wait before using it.
+func (l *Limiter) reserve() time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	now := l.now()
+	if elapsed := now.Sub(l.last); elapsed > 0 {
+		l.tokens = min(l.burst, l.tokens+elapsed.Seconds()*l.rate)
+		l.last = now
+	}
+	l.tokens--
+	if l.tokens >= 0 {
+		return 0
+	}
+	if l.rate <= 0 {
+		// Without refills the debt is never repaid, so wait indefinitely.
+		return time.Duration(1<<63 - 1)
+	}
+	return time.Duration(-l.tokens / l.rate * float64(time.Second))
+}
+
+// cancel returns a token taken by reserve that was not used.
+func (l *Limiter) cancel() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.tokens = min(l.burst, l.tokens+1)
+}
+
+// Allow reports whether a request may be made now, taking a token if so.
+func (l *Limiter) Allow() bool {
+	if l == nil {
+		return true
+	}
+	if l.reserve() > 0 {
+		l.cancel()
+		return false
+	}
+	return true
+}
+
+// Wait blocks until a request may be made or ctx is done, in which case it returns
+// the context's error.
+func (l *Limiter) Wait(ctx context.Context) error {
+	if l == nil || ctx.Err() != nil {
+		return ctx.Err()
+	}
+	delay := l.reserve()
+	if delay == 0 {
+		return nil
+	}
+	if err := sleep(ctx, delay); err != nil {
+		l.cancel()
+		return err
+	}
+	return nil
+}
+
+// sleep waits for d or until ctx is done.
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,160 @@
+package ratelimit
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLimiterAllow(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	l := NewLimiter(2, 3)
+	l.now = func() time.Time { return now }
+	l.last = now
+
+	for range 3 {
+		require.True(t, l.Allow())
+	}
+	require.False(t, l.Allow(), "the burst is spent")
+
+	now = now.Add(500 * time.Millisecond)
+	require.True(t, l.Allow(), "one token refilled")
+	require.False(t, l.Allow())
+
+	now = now.Add(time.Hour)
+	for range 3 {
+		require.True(t, l.Allow())
+	}
+	require.False(t, l.Allow(), "refills are capped at the burst")
+
+	var nilLimiter *Limiter
+	require.True(t, nilLimiter.Allow())
+}
+
+func TestLimiterWait(t *testing.T) {
+	l := NewLimiter(100, 1)
+	require.NoError(t, l.Wait(t.Context()))
+
+	start := time.Now()
+	require.NoError(t, l.Wait(t.Context()))
+	require.GreaterOrEqual(t, time.Since(start), 5*time.Millisecond)
+
+	ctx, cancel := context.WithCancel(t.Context())
+	cancel()
+	require.ErrorIs(t, NewLimiter(0, 1).Wait(ctx), context.Canceled)
+	blocked := NewLimiter(0, 1)
+	require.NoError(t, blocked.Wait(t.Context()))
+	ctx, cancel = context.WithTimeout(t.Context(), 10*time.Millisecond)
+	defer cancel()
+	require.ErrorIs(t, blocked.Wait(ctx), context.DeadlineExceeded)
+}
+
+func TestBackoffDelay(t *testing.T) {
+	b := Backoff{Initial: 100 * time.Millisecond, Max: time.Second}
+	require.Equal(t, 100*time.Millisecond, b.Delay(0))
+	require.Equal(t, 200*time.Millisecond, b.Delay(1))
+	require.Equal(t, 800*time.Millisecond, b.Delay(3))
+	require.Equal(t, time.Second, b.Delay(4))
+	require.Equal(t, time.Second, b.Delay(1000))
+
+	b.Jitter = 0.5
+	for range 100 {
+		d := b.Delay(2)
+		require.GreaterOrEqual(t, d, 200*time.Millisecond)
+		require.LessOrEqual(t, d, 400*time.Millisecond)
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	resp := &http.Response{Header: http.Header{}}
+
+	_, ok := RetryAfter(resp, now)
+	require.False(t, ok)
+
+	resp.Header.Set("Retry-After", "7")
+	d, ok := RetryAfter(resp, now)
+	require.True(t, ok)
+	require.Equal(t, 7*time.Second, d)
+
+	resp.Header.Set("Retry-After", now.Add(90*time.Second).Format(http.TimeFormat))
+	d, ok = RetryAfter(resp, now)
+	require.True(t, ok)
+	require.Equal(t, 90*time.Second, d)
+
+	resp.Header.Set("Retry-After", "soon")
+	_, ok = RetryAfter(resp, now)
+	require.False(t, ok)
+}
+
+func TestClientRetriesThrottledRequests(t *testing.T) {
+	var bodies []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, string(body))
+		switch len(bodies) {
+		case 1:
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+		case 2:
+			w.WriteHeader(http.StatusServiceUnavailable)
+		default:
+			w.WriteHeader(http.StatusOK)
+		}
+	}))
+	defer ts.Close()
+
+	client := NewClient(ts.Client(), WithBackoff(Backoff{Initial: time.Millisecond}))
+	req, err := http.NewRequestWithContext(t.Context(), http.MethodPost, ts.URL, strings.NewReader("tx"))
+	require.NoError(t, err)
+	resp, err := client.Do(req)
+	require.NoError(t, err)
+	resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.Equal(t, []string{"tx", "tx", "tx"}, bodies, "the body is replayed on every retry")
+}
+
+func TestClientGivesUp(t *testing.T) {
+	requests := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.URL.Path == "/later" {
+			w.Header().Set("Retry-After", "3600")
+		}
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer ts.Close()
+
+	client := NewClient(ts.Client(), WithMaxRetries(2), WithBackoff(Backoff{Initial: time.Millisecond}))
+	req, err := http.NewRequestWithContext(t.Context(), http.MethodGet, ts.URL, nil)
+	require.NoError(t, err)
+	resp, err := client.Do(req)
+	require.NoError(t, err)
+	resp.Body.Close()
+	require.Equal(t, http.StatusTooManyRequests, resp.StatusCode)
+	require.Equal(t, 3, requests)
+
+	// Retry-After delays beyond the limit are not waited for
+	requests = 0
+	req, err = http.NewRequestWithContext(t.Context(), http.MethodGet, ts.URL+"/later", nil)
+	require.NoError(t, err)
+	resp, err = client.Do(req)
+	require.NoError(t, err)
+	resp.Body.Close()
+	require.Equal(t, 1, requests)
+
+	// Waiting for a retry stops with the context
+	ctx, cancel := context.WithTimeout(t.Context(), 10*time.Millisecond)
+	defer cancel()
+	client = NewClient(ts.Client(), WithBackoff(Backoff{Initial: time.Hour}))
+	req, err = http.NewRequestWithContext(ctx, http.MethodGet, ts.URL, nil)
+	require.NoError(t, err)
+	_, err = client.Do(req)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+}
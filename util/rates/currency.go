@@ -0,0 +1,107 @@
+package rates
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/bsv-blockchain/go-sdk/primitives/amount"
+)
+
+// ErrInvalidCurrency is returned for currency codes which are not three letters.
+var ErrInvalidCurrency = errors.New("invalid currency code")
+
+// Currency is an ISO 4217 currency code, such as USD.
+type Currency string
+
+const (
+	USD Currency = "USD"
+	EUR Currency = "EUR"
+	GBP Currency = "GBP"
+	JPY Currency = "JPY"
+)
+
+// ParseCurrency parses a three letter currency code, in any case.
+func ParseCurrency(s string) (Currency, error) {
+	code := strings.ToUpper(strings.TrimSpace(s))
+	if len(code) != 3 {
+		return "", fmt.Errorf("%w: %q", ErrInvalidCurrency, s)
+	}
+	for _, c := range code {
+		if c < 'A' || c > 'Z' {
+			return "", fmt.Errorf("%w: %q", ErrInvalidCurrency, s)
+		}
+	}
+	return Currency(code), nil
+}
+
+// minorUnits lists the currencies whose minor unit is not a hundredth.
+var minorUnits = map[Currency]int{
+	"BIF": 0, "CLP": 0, "ISK": 0, "JPY": 0, "KRW": 0, "PYG": 0, "UGX": 0, "VND": 0, "XAF": 0, "XOF": 0,
+	"BHD": 3, "IQD": 3, "JOD": 3, "KWD": 3, "LYD": 3, "OMR": 3, "TND": 3,
+}
+
+// Decimals returns the number of decimals of the minor unit of the currency,
+// 2 for most currencies.
+func (c Currency) Decimals() int {
+	if d, ok := minorUnits[c]; ok {
+		return d
+	}
+	return 2
+}
+
+// Fiat is an amount of a fiat currency, in its minor unit, such as cents.
+type Fiat struct {
+	Minor    int64
+	Currency Currency
+}
+
+// String formats the amount with the decimals of its currency, as 12.34 USD.
+func (f Fiat) String() string {
+	decimals := f.Currency.Decimals()
+	if decimals == 0 {
+		return fmt.Sprintf("%d %s", f.Minor, f.Currency)
+	}
+	sign := ""
+	minor := f.Minor
+	if minor < 0 {
+		sign, minor = "-", -minor
+	}
+	scale := int64(math.Pow10(decimals))
+	return fmt.Sprintf("%s%d.%0*d %s", sign, minor/scale, decimals, minor%scale, f.Currency)
+}
+
+// Float returns the amount in major units, such as dollars.
+func (f Fiat) Float() float64 {
+	return float64(f.Minor) / math.Pow10(f.Currency.Decimals())
+}
+
+// Convert returns the value of satoshis at the rate, rounded to the nearest
+// minor unit.
+func (r *Rate) Convert(satoshis amount.Satoshis) Fiat {
+	major := float64(satoshis) / float64(amount.SatoshisPerBSV) * r.Price
+	return Fiat{Minor: int64(math.Round(major * math.Pow10(r.Currency.Decimals()))), Currency: r.Currency}
+}
+
+// Satoshis returns the satoshis worth fiat at the rate, rounded down, so that
+// spending limits set in fiat are never exceeded.
+func (r *Rate) Satoshis(fiat Fiat) (amount.Satoshis, error) {
+	if fiat.Currency != r.Currency {
+		return 0, fmt.Errorf("rate is for %s, not %s", r.Currency, fiat.Currency)
+	}
+	if fiat.Minor < 0 {
+		return 0, fmt.Errorf("%w: %s", amount.ErrNegative, fiat)
+	}
+	satoshis := math.Floor(fiat.Float() / r.Price * float64(amount.SatoshisPerBSV))
+	if satoshis >= math.MaxUint64 {
+		return 0, amount.ErrOverflow
+	}
+	return amount.Satoshis(satoshis), nil
+}
+
+// String formats the rate as 45.12 USD/BSV.
+func (r *Rate) String() string {
+	return strconv.FormatFloat(r.Price, 'f', -1, 64) + " " + string(r.Currency) + "/BSV"
+}
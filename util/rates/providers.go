@@ -0,0 +1,160 @@
+package rates
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/bsv-blockchain/go-sdk/util"
+)
+
+// ProviderFunc adapts a function to a Provider.
+type ProviderFunc func(ctx context.Context, currency Currency, at time.Time) (*Rate, error)
+
+// Rate calls f.
+func (f ProviderFunc) Rate(ctx context.Context, currency Currency, at time.Time) (*Rate, error) {
+	return f(ctx, currency, at)
+}
+
+var (
+	_ Provider = (*WhatsOnChain)(nil)
+	_ Provider = (*CoinGecko)(nil)
+)
+
+// WhatsOnChain fetches USD rates from the WhatsOnChain API.
+type WhatsOnChain struct {
+	ApiKey string
+	Client util.HTTPClient
+	// BaseURL overrides the API root, https://api.whatsonchain.com/v1/bsv/main
+	// by default.
+	BaseURL string
+}
+
+type wocRate struct {
+	Rate float64 `json:"rate"`
+	Time int64   `json:"time"`
+}
+
+// Rate fetches the current rate, or the last rate of the day up to at.
+func (w *WhatsOnChain) Rate(ctx context.Context, currency Currency, at time.Time) (*Rate, error) {
+	if currency != USD {
+		return nil, fmt.Errorf("%w: WhatsOnChain only quotes USD", ErrUnsupportedCurrency)
+	}
+	baseURL := w.BaseURL
+	if baseURL == "" {
+		baseURL = "https://api.whatsonchain.com/v1/bsv/main"
+	}
+
+	if at.IsZero() {
+		var current wocRate
+		if err := getJSON(ctx, w.Client, baseURL+"/exchangerate", "Authorization", w.ApiKey, &current); err != nil {
+			return nil, err
+		}
+		return newRate("whatsonchain", USD, current.Rate, time.Unix(current.Time, 0))
+	}
+
+	var history []wocRate
+	query := fmt.Sprintf("%s/exchangerate/historical?from=%d&to=%d", baseURL, at.Add(-24*time.Hour).Unix(), at.Unix())
+	if err := getJSON(ctx, w.Client, query, "Authorization", w.ApiKey, &history); err != nil {
+		return nil, err
+	}
+	var last *wocRate
+	for i, r := range history {
+		if r.Time <= at.Unix() && (last == nil || r.Time > last.Time) {
+			last = &history[i]
+		}
+	}
+	if last == nil {
+		return nil, fmt.Errorf("%w: no USD rate at %s", ErrNoRate, at.Format(time.RFC3339))
+	}
+	return newRate("whatsonchain", USD, last.Rate, time.Unix(last.Time, 0))
+}
+
+// CoinGecko fetches rates in any currency it quotes from the CoinGecko API.
+// Historical rates are daily.
+type CoinGecko struct {
+	// ApiKey is sent as the x-cg-demo-api-key header.
+	ApiKey string
+	Client util.HTTPClient
+	// BaseURL overrides the API root, https://api.coingecko.com/api/v3 by
+	// default.
+	BaseURL string
+}
+
+const coinGeckoID = "bitcoin-cash-sv"
+
+// Rate fetches the current rate, or the rate of the day of at.
+func (g *CoinGecko) Rate(ctx context.Context, currency Currency, at time.Time) (*Rate, error) {
+	baseURL := g.BaseURL
+	if baseURL == "" {
+		baseURL = "https://api.coingecko.com/api/v3"
+	}
+	vs := strings.ToLower(string(currency))
+
+	var prices map[string]float64
+	quotedAt := at
+	if at.IsZero() {
+		var response map[string]map[string]float64
+		query := fmt.Sprintf("%s/simple/price?ids=%s&vs_currencies=%s", baseURL, coinGeckoID, url.QueryEscape(vs))
+		if err := getJSON(ctx, g.Client, query, "x-cg-demo-api-key", g.ApiKey, &response); err != nil {
+			return nil, err
+		}
+		prices = response[coinGeckoID]
+		quotedAt = time.Now()
+	} else {
+		var response struct {
+			MarketData struct {
+				CurrentPrice map[string]float64 `json:"current_price"`
+			} `json:"market_data"`
+		}
+		query := fmt.Sprintf("%s/coins/%s/history?date=%s&localization=false", baseURL, coinGeckoID, at.UTC().Format("02-01-2006"))
+		if err := getJSON(ctx, g.Client, query, "x-cg-demo-api-key", g.ApiKey, &response); err != nil {
+			return nil, err
+		}
+		prices = response.MarketData.CurrentPrice
+	}
+
+	price, ok := prices[vs]
+	if !ok {
+		return nil, fmt.Errorf("%w: CoinGecko does not quote %s", ErrUnsupportedCurrency, currency)
+	}
+	return newRate("coingecko", currency, price, quotedAt)
+}
+
+func getJSON(ctx context.Context, client util.HTTPClient, url, keyHeader, apiKey string, v any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	if apiKey != "" {
+		req.Header.Set(keyHeader, apiKey)
+	}
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return &util.HTTPError{
+			StatusCode: resp.StatusCode,
+			Err:        fmt.Errorf("failed to fetch exchange rate: %s", strings.TrimSpace(string(body))),
+		}
+	}
+	if err = json.Unmarshal(body, v); err != nil {
+		return fmt.Errorf("failed to decode exchange rate: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,200 @@
+// Package rates converts satoshis to fiat currencies at current or historical
+// exchange rates, for showing the value of actions in their descriptions and
+// enforcing spending limits set in fiat.
+//
+// Rates are fetched from pluggable Providers, such as WhatsOnChain and
+// CoinGecko, tried in order until one quotes the currency, and cached by the
+// Client:
+//
+//	c := rates.New([]rates.Provider{&rates.CoinGecko{}, &rates.WhatsOnChain{}})
+//	value, err := c.Convert(ctx, 150_000, rates.USD)
+//	description := fmt.Sprintf("Pay for coffee (%s)", value)
+//
+// The spending limits of wallet/permissions may be set in fiat, converted with
+// a Client at the current rate for every action:
+//
+//	config.Default.PerDayFiat = rates.Fiat{Minor: 5000, Currency: rates.USD}
+//	m := permissions.NewManager(w, config, permissions.WithRates(c))
+package rates
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/bsv-blockchain/go-sdk/primitives/amount"
+)
+
+var (
+	// ErrUnsupportedCurrency is returned by providers which do not quote a
+	// currency. The Client then tries the next provider.
+	ErrUnsupportedCurrency = errors.New("currency not supported")
+	ErrNoRate              = errors.New("no exchange rate available")
+	ErrInvalidRate         = errors.New("invalid exchange rate")
+)
+
+// Rate is the price of one BSV in a currency.
+type Rate struct {
+	Currency Currency
+	Price    float64
+	// At is when the rate was quoted.
+	At time.Time
+	// Provider names the provider of the rate.
+	Provider string
+}
+
+func newRate(provider string, currency Currency, price float64, at time.Time) (*Rate, error) {
+	if price <= 0 || math.IsInf(price, 0) || math.IsNaN(price) {
+		return nil, fmt.Errorf("%w: %s quoted %v %s", ErrInvalidRate, provider, price, currency)
+	}
+	return &Rate{Currency: currency, Price: price, At: at, Provider: provider}, nil
+}
+
+// Provider fetches exchange rates.
+type Provider interface {
+	// Rate returns the rate of currency at the time at, or the current rate
+	// when at is zero. Providers return ErrUnsupportedCurrency for currencies
+	// they do not quote.
+	Rate(ctx context.Context, currency Currency, at time.Time) (*Rate, error)
+}
+
+// DefaultTTL is how long a Client caches current rates by default.
+const DefaultTTL = 5 * time.Minute
+
+// DefaultResolution is the resolution of the historical rates of a Client by
+// default.
+const DefaultResolution = time.Hour
+
+// maxHistorical bounds the historical rates cached by a Client.
+const maxHistorical = 4096
+
+// Option configures a Client.
+type Option func(*Client)
+
+// WithTTL sets how long current rates are cached, DefaultTTL by default.
+func WithTTL(ttl time.Duration) Option {
+	return func(c *Client) {
+		c.ttl = ttl
+	}
+}
+
+// WithResolution sets the resolution of historical rates, DefaultResolution by
+// default: times are truncated to it before rates are fetched and cached, so
+// that rates at times close to each other are fetched once.
+func WithResolution(resolution time.Duration) Option {
+	return func(c *Client) {
+		c.resolution = resolution
+	}
+}
+
+type historicalKey struct {
+	currency Currency
+	at       int64
+}
+
+// Client fetches rates from its providers and caches them. Historical rates
+// never change, so they are cached without expiry.
+type Client struct {
+	providers  []Provider
+	ttl        time.Duration
+	resolution time.Duration
+	now        func() time.Time
+
+	mu         sync.Mutex
+	current    map[Currency]*Rate
+	fetched    map[Currency]time.Time
+	historical map[historicalKey]*Rate
+}
+
+// ensure that Client is implementing Provider
+var _ Provider = (*Client)(nil)
+
+// New creates a Client fetching rates from providers, tried in order.
+func New(providers []Provider, opts ...Option) *Client {
+	c := &Client{
+		providers:  providers,
+		ttl:        DefaultTTL,
+		resolution: DefaultResolution,
+		now:        time.Now,
+		current:    make(map[Currency]*Rate),
+		fetched:    make(map[Currency]time.Time),
+		historical: make(map[historicalKey]*Rate),
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// Rate returns the rate of currency at the time at, or the current rate when
+// at is zero, from the cache or else from the first provider quoting it.
+// Currency codes are normalized to upper case.
+func (c *Client) Rate(ctx context.Context, currency Currency, at time.Time) (*Rate, error) {
+	currency, err := ParseCurrency(string(currency))
+	if err != nil {
+		return nil, err
+	}
+	if !at.IsZero() && c.resolution > 0 {
+		at = at.Truncate(c.resolution)
+	}
+	key := historicalKey{currency: currency, at: at.UnixNano()}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	now := c.now()
+	if at.IsZero() {
+		if rate, ok := c.current[currency]; ok && now.Before(c.fetched[currency].Add(c.ttl)) {
+			return rate, nil
+		}
+	} else if rate, ok := c.historical[key]; ok {
+		return rate, nil
+	}
+
+	var errs []error
+	for _, provider := range c.providers {
+		rate, err := provider.Rate(ctx, currency, at)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if at.IsZero() {
+			c.current[currency] = rate
+			c.fetched[currency] = now
+		} else {
+			if len(c.historical) >= maxHistorical {
+				clear(c.historical)
+			}
+			c.historical[key] = rate
+		}
+		return rate, nil
+	}
+	return nil, fmt.Errorf("%w for %s: %w", ErrNoRate, currency, errors.Join(errs...))
+}
+
+// Convert returns the current value of satoshis in currency.
+func (c *Client) Convert(ctx context.Context, satoshis amount.Satoshis, currency Currency) (Fiat, error) {
+	return c.ConvertAt(ctx, satoshis, currency, time.Time{})
+}
+
+// ConvertAt returns the value of satoshis in currency at the time at.
+func (c *Client) ConvertAt(ctx context.Context, satoshis amount.Satoshis, currency Currency, at time.Time) (Fiat, error) {
+	rate, err := c.Rate(ctx, currency, at)
+	if err != nil {
+		return Fiat{}, err
+	}
+	return rate.Convert(satoshis), nil
+}
+
+// Satoshis returns the satoshis currently worth fiat, rounded down, for
+// spending limits set in fiat.
+func (c *Client) Satoshis(ctx context.Context, fiat Fiat) (amount.Satoshis, error) {
+	rate, err := c.Rate(ctx, fiat.Currency, time.Time{})
+	if err != nil {
+		return 0, err
+	}
+	fiat.Currency = rate.Currency
+	return rate.Satoshis(fiat)
+}
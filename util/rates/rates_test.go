@@ -0,0 +1,141 @@
+package rates
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/bsv-blockchain/go-sdk/primitives/amount"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCurrency(t *testing.T) {
+	c, err := ParseCurrency(" usd")
+	require.NoError(t, err)
+	require.Equal(t, USD, c)
+	for _, s := range []string{"", "US", "USDT", "U$D"} {
+		_, err = ParseCurrency(s)
+		require.ErrorIs(t, err, ErrInvalidCurrency, s)
+	}
+
+	require.Equal(t, "12.34 USD", Fiat{Minor: 1234, Currency: USD}.String())
+	require.Equal(t, "-0.05 EUR", Fiat{Minor: -5, Currency: EUR}.String())
+	require.Equal(t, "1234 JPY", Fiat{Minor: 1234, Currency: JPY}.String())
+	require.Equal(t, "1.234 KWD", Fiat{Minor: 1234, Currency: "KWD"}.String())
+}
+
+func TestRateConversion(t *testing.T) {
+	rate := &Rate{Currency: USD, Price: 45.5}
+	require.Equal(t, Fiat{Minor: 4550, Currency: USD}, rate.Convert(amount.SatoshisPerBSV))
+	require.Equal(t, Fiat{Minor: 7, Currency: USD}, rate.Convert(150_000))
+	require.Equal(t, "45.5 USD/BSV", rate.String())
+
+	satoshis, err := rate.Satoshis(Fiat{Minor: 4550, Currency: USD})
+	require.NoError(t, err)
+	require.Equal(t, amount.SatoshisPerBSV, satoshis)
+	satoshis, err = rate.Satoshis(Fiat{Minor: 1, Currency: USD})
+	require.NoError(t, err)
+	require.Equal(t, amount.Satoshis(21978), satoshis)
+
+	_, err = rate.Satoshis(Fiat{Minor: 1, Currency: EUR})
+	require.Error(t, err)
+	_, err = rate.Satoshis(Fiat{Minor: -1, Currency: USD})
+	require.ErrorIs(t, err, amount.ErrNegative)
+}
+
+func TestProviders(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/woc/exchangerate":
+			_, _ = w.Write([]byte(`{"currency":"USD","rate":45.5,"time":1760745600}`))
+		case "/woc/exchangerate/historical":
+			require.Equal(t, "1760659200", r.URL.Query().Get("from"))
+			_, _ = w.Write([]byte(`[{"rate":40,"time":1760700000},{"rate":41,"time":1760740000},{"rate":99,"time":1760800000}]`))
+		case "/cg/simple/price":
+			require.Equal(t, "eur", r.URL.Query().Get("vs_currencies"))
+			require.Equal(t, "key", r.Header.Get("x-cg-demo-api-key"))
+			_, _ = w.Write([]byte(`{"bitcoin-cash-sv":{"eur":38.25}}`))
+		case "/cg/coins/bitcoin-cash-sv/history":
+			require.Equal(t, "18-10-2025", r.URL.Query().Get("date"))
+			_, _ = w.Write([]byte(`{"market_data":{"current_price":{"usd":44,"eur":37}}}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+	ctx := t.Context()
+	at := time.Unix(1760745600, 0)
+
+	woc := &WhatsOnChain{BaseURL: srv.URL + "/woc"}
+	rate, err := woc.Rate(ctx, USD, time.Time{})
+	require.NoError(t, err)
+	require.Equal(t, 45.5, rate.Price)
+	rate, err = woc.Rate(ctx, USD, at)
+	require.NoError(t, err)
+	require.Equal(t, 41.0, rate.Price)
+	_, err = woc.Rate(ctx, EUR, at)
+	require.ErrorIs(t, err, ErrUnsupportedCurrency)
+
+	cg := &CoinGecko{ApiKey: "key", BaseURL: srv.URL + "/cg"}
+	rate, err = cg.Rate(ctx, EUR, time.Time{})
+	require.NoError(t, err)
+	require.Equal(t, 38.25, rate.Price)
+	rate, err = cg.Rate(ctx, USD, at)
+	require.NoError(t, err)
+	require.Equal(t, 44.0, rate.Price)
+	_, err = cg.Rate(ctx, GBP, at)
+	require.ErrorIs(t, err, ErrUnsupportedCurrency)
+}
+
+func TestClient(t *testing.T) {
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	calls := 0
+	usdOnly := ProviderFunc(func(_ context.Context, currency Currency, at time.Time) (*Rate, error) {
+		calls++
+		if currency != USD {
+			return nil, ErrUnsupportedCurrency
+		}
+		return newRate("usd", currency, float64(40+calls), at)
+	})
+	down := ProviderFunc(func(context.Context, Currency, time.Time) (*Rate, error) {
+		return nil, errors.New("down")
+	})
+	c := New([]Provider{down, usdOnly}, WithTTL(time.Minute))
+	c.now = func() time.Time { return now }
+	ctx := t.Context()
+
+	value, err := c.Convert(ctx, amount.SatoshisPerBSV, USD)
+	require.NoError(t, err)
+	require.Equal(t, "41.00 USD", value.String())
+	value, err = c.Convert(ctx, amount.SatoshisPerBSV, USD)
+	require.NoError(t, err)
+	require.Equal(t, "41.00 USD", value.String())
+	require.Equal(t, 1, calls)
+
+	now = now.Add(time.Minute)
+	limit, err := c.Satoshis(ctx, Fiat{Minor: 4200, Currency: USD})
+	require.NoError(t, err)
+	require.Equal(t, amount.SatoshisPerBSV, limit)
+	require.Equal(t, 2, calls)
+
+	// Historical rates are cached at the resolution.
+	at := time.Date(2025, 1, 1, 10, 15, 0, 0, time.UTC)
+	rate, err := c.Rate(ctx, USD, at)
+	require.NoError(t, err)
+	require.Equal(t, time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC), rate.At)
+	_, err = c.ConvertAt(ctx, 1, USD, at.Add(30*time.Minute))
+	require.NoError(t, err)
+	require.Equal(t, 3, calls)
+
+	_, err = c.Convert(ctx, 1, EUR)
+	require.ErrorIs(t, err, ErrNoRate)
+	require.ErrorIs(t, err, ErrUnsupportedCurrency)
+	value, err = c.Convert(ctx, amount.SatoshisPerBSV, "usd")
+	require.NoError(t, err)
+	require.Equal(t, USD, value.Currency)
+	_, err = c.Convert(ctx, 1, "US$")
+	require.ErrorIs(t, err, ErrInvalidCurrency)
+}
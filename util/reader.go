@@ -34,12 +34,13 @@ func (r *Reader) ReadByte() (byte, error) {
 }
 
 func (r *Reader) ReadBytes(n int) ([]byte, error) {
-	if r.Pos+n > len(r.Data) {
-		return nil, errors.New("read past end of data")
-	}
 	if n < 0 {
 		return nil, fmt.Errorf("invalid read length: %d", n)
 	}
+	// Compare against the remaining length, as r.Pos+n can overflow.
+	if n > len(r.Data)-r.Pos {
+		return nil, errors.New("read past end of data")
+	}
 	b := r.Data[r.Pos : r.Pos+n]
 	r.Pos += n
 	return b, nil
@@ -213,6 +214,9 @@ func (r *Reader) ReadTxidSlice() ([]chainhash.Hash, error) {
 	if count == math.MaxUint64 {
 		return nil, nil
 	}
+	if count > uint64(len(r.Data)-r.Pos)/chainhash.HashSize {
+		return nil, fmt.Errorf("slice txid count %d exceeds remaining data", count)
+	}
 
 	txIDs := make([]chainhash.Hash, 0, count)
 	for i := uint64(0); i < count; i++ {
@@ -241,6 +245,9 @@ func (r *Reader) ReadStringSlice() ([]string, error) {
 	if count >= math.MaxInt {
 		return nil, fmt.Errorf("slice count %d exceeds maximum int size", count)
 	}
+	if count > uint64(len(r.Data)-r.Pos) {
+		return nil, fmt.Errorf("slice string count %d exceeds remaining data", count)
+	}
 
 	slice := make([]string, 0, count)
 	for i := uint64(0); i < count; i++ {
@@ -399,6 +406,11 @@ func Uint32Ptr(ui uint32) *uint32 {
 	return &ui
 }
 
+// Uint64Ptr is a helper function to create a pointer to a uint64 value
+func Uint64Ptr(ui uint64) *uint64 {
+	return &ui
+}
+
 func (r *ReaderHoldError) ReadTxidSlice() []chainhash.Hash {
 	if r.Err != nil {
 		return nil
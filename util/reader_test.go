@@ -1,6 +1,7 @@
 package util_test
 
 import (
+	"math"
 	"testing"
 
 	"github.com/bsv-blockchain/go-sdk/util"
@@ -105,6 +106,31 @@ func TestReaderErrors(t *testing.T) {
 			},
 			wantErr: "read past end of data",
 		},
+		{
+			name: "readBytes overflowing length",
+			data: []byte{0x01, 0x02},
+			readFn: func(r *util.Reader) (any, error) {
+				_, _ = r.ReadByte()
+				return r.ReadBytes(math.MaxInt)
+			},
+			wantErr: "read past end of data",
+		},
+		{
+			name: "readStringSlice huge count",
+			data: []byte{0xfe, 0xff, 0xff, 0xff, 0x0f, 0x01},
+			readFn: func(r *util.Reader) (any, error) {
+				return r.ReadStringSlice()
+			},
+			wantErr: "slice string count 268435455 exceeds remaining data",
+		},
+		{
+			name: "readTxidSlice huge count",
+			data: []byte{0x02, 0x01},
+			readFn: func(r *util.Reader) (any, error) {
+				return r.ReadTxidSlice()
+			},
+			wantErr: "slice txid count 2 exceeds remaining data",
+		},
 	}
 
 	for _, tt := range tests {
@@ -0,0 +1,215 @@
+// Package actions provides high level helpers building common wallet actions,
+// such as paying several recipients at once.
+package actions
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/bsv-blockchain/go-sdk/chainhash"
+	"github.com/bsv-blockchain/go-sdk/script"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+	"github.com/bsv-blockchain/go-sdk/transaction/template/p2pkh"
+	"github.com/bsv-blockchain/go-sdk/wallet"
+)
+
+// BRC-100 requires descriptions of 5 to 50 bytes.
+const (
+	minDescriptionLength = 5
+	maxDescriptionLength = 50
+)
+
+// DefaultDescription describes actions created by Send without WithDescription.
+const DefaultDescription = "Send payment"
+
+// ErrNoRecipients is returned by Send when there is nobody to pay.
+var ErrNoRecipients = errors.New("no recipients")
+
+// Recipient is paid by Send. Exactly one of Address and LockingScript must be set.
+type Recipient struct {
+	// Address is a P2PKH address to pay.
+	Address string
+	// LockingScript is the script to pay, for any other kind of output.
+	LockingScript *script.Script
+	Satoshis      uint64
+	// Description describes the output. It defaults to "Payment to <address>" for
+	// addresses and "Payment" for scripts.
+	Description string
+}
+
+// SendResult is the outcome of Send.
+type SendResult struct {
+	Txid chainhash.Hash
+	// BEEF is the Atomic BEEF of the transaction, ready to be broadcast or handed
+	// to the recipients.
+	BEEF []byte
+	// Tx is the transaction parsed from BEEF, with its ancestors attached.
+	Tx *transaction.Transaction
+}
+
+type sendOptions struct {
+	description    string
+	labels         []string
+	originator     string
+	changeStrategy *wallet.ChangeStrategy
+	noSend         bool
+}
+
+// SendOption configures Send.
+type SendOption func(*sendOptions)
+
+// WithDescription sets the description of the action.
+func WithDescription(description string) SendOption {
+	return func(o *sendOptions) {
+		o.description = description
+	}
+}
+
+// WithLabels labels the action.
+func WithLabels(labels ...string) SendOption {
+	return func(o *sendOptions) {
+		o.labels = append(o.labels, labels...)
+	}
+}
+
+// WithOriginator sets the originator passed to the wallet.
+func WithOriginator(originator string) SendOption {
+	return func(o *sendOptions) {
+		o.originator = originator
+	}
+}
+
+// WithChangeStrategy controls the change outputs the wallet creates.
+func WithChangeStrategy(strategy *wallet.ChangeStrategy) SendOption {
+	return func(o *sendOptions) {
+		o.changeStrategy = strategy
+	}
+}
+
+// WithNoSend asks the wallet not to broadcast the transaction, leaving it to the
+// caller, for example to deliver the BEEF to the recipients directly.
+func WithNoSend() SendOption {
+	return func(o *sendOptions) {
+		o.noSend = true
+	}
+}
+
+// Send pays the recipients from the wallet in a single action. The wallet funds
+// the action and adds change. The action is signed and, unless WithNoSend is
+// given, broadcast by the wallet.
+func Send(ctx context.Context, w wallet.Interface, recipients []Recipient, opts ...SendOption) (*SendResult, error) {
+	args, originator, err := NewSendArgs(recipients, opts...)
+	if err != nil {
+		return nil, err
+	}
+	result, err := w.CreateAction(ctx, *args, originator)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create action: %w", err)
+	}
+	if result.SignableTransaction != nil {
+		return nil, errors.New("wallet returned a transaction to sign instead of signing it")
+	}
+	if len(result.Tx) == 0 {
+		return nil, errors.New("wallet did not return the transaction")
+	}
+	tx, err := transaction.NewTransactionFromBEEF(result.Tx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse transaction returned by the wallet: %w", err)
+	}
+	if tx == nil {
+		return nil, errors.New("wallet returned a BEEF without the transaction")
+	}
+	return &SendResult{
+		Txid: *tx.TxID(),
+		BEEF: result.Tx,
+		Tx:   tx,
+	}, nil
+}
+
+// NewSendArgs builds the CreateActionArgs used by Send, along with the
+// originator, for callers that create the action themselves.
+func NewSendArgs(recipients []Recipient, opts ...SendOption) (*wallet.CreateActionArgs, string, error) {
+	o := sendOptions{description: DefaultDescription}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	if len(recipients) == 0 {
+		return nil, "", ErrNoRecipients
+	}
+	if err := validateDescription(o.description); err != nil {
+		return nil, "", fmt.Errorf("invalid action description: %w", err)
+	}
+	if o.changeStrategy != nil {
+		if err := o.changeStrategy.Validate(); err != nil {
+			return nil, "", err
+		}
+	}
+
+	outputs := make([]wallet.CreateActionOutput, 0, len(recipients))
+	for i, r := range recipients {
+		output, err := r.output()
+		if err != nil {
+			return nil, "", fmt.Errorf("invalid recipient %d: %w", i, err)
+		}
+		outputs = append(outputs, output)
+	}
+
+	args := &wallet.CreateActionArgs{
+		Description: o.description,
+		Outputs:     outputs,
+		Labels:      o.labels,
+	}
+	if o.changeStrategy != nil || o.noSend {
+		args.Options = &wallet.CreateActionOptions{ChangeStrategy: o.changeStrategy}
+		if o.noSend {
+			noSend := true
+			args.Options.NoSend = &noSend
+		}
+	}
+	return args, o.originator, nil
+}
+
+func (r Recipient) output() (wallet.CreateActionOutput, error) {
+	if r.Satoshis == 0 {
+		return wallet.CreateActionOutput{}, errors.New("amount must be greater than zero")
+	}
+	lockingScript := r.LockingScript
+	description := r.Description
+	switch {
+	case r.Address != "" && lockingScript != nil:
+		return wallet.CreateActionOutput{}, errors.New("both address and locking script are set")
+	case r.Address != "":
+		address, err := script.NewAddressFromString(r.Address)
+		if err != nil {
+			return wallet.CreateActionOutput{}, err
+		}
+		if lockingScript, err = p2pkh.Lock(address); err != nil {
+			return wallet.CreateActionOutput{}, err
+		}
+		if description == "" {
+			description = "Payment to " + r.Address
+		}
+	case lockingScript != nil && len(*lockingScript) > 0:
+		if description == "" {
+			description = "Payment"
+		}
+	default:
+		return wallet.CreateActionOutput{}, errors.New("address or locking script is required")
+	}
+	if err := validateDescription(description); err != nil {
+		return wallet.CreateActionOutput{}, fmt.Errorf("invalid output description: %w", err)
+	}
+	return wallet.CreateActionOutput{
+		LockingScript:     *lockingScript,
+		Satoshis:          r.Satoshis,
+		OutputDescription: description,
+	}, nil
+}
+
+func validateDescription(description string) error {
+	if len(description) < minDescriptionLength || len(description) > maxDescriptionLength {
+		return fmt.Errorf("%q must be %d to %d bytes long", description, minDescriptionLength, maxDescriptionLength)
+	}
+	return nil
+}
@@ -0,0 +1,118 @@
+package actions
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/bsv-blockchain/go-sdk/script"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+	"github.com/bsv-blockchain/go-sdk/transaction/template/p2pkh"
+	"github.com/bsv-blockchain/go-sdk/wallet"
+	"github.com/stretchr/testify/require"
+)
+
+const testAddress = "1AdZmoAQUw4XCsCihukoHMvNWXcsd8jDN6"
+
+type fakeWallet struct {
+	wallet.Interface
+	args       wallet.CreateActionArgs
+	originator string
+	err        error
+	// missingTx makes the returned Atomic BEEF name a transaction it lacks.
+	missingTx bool
+}
+
+func (f *fakeWallet) CreateAction(_ context.Context, args wallet.CreateActionArgs, originator string) (*wallet.CreateActionResult, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	f.args, f.originator = args, originator
+
+	tx := transaction.NewTransaction()
+	for _, o := range args.Outputs {
+		lockingScript := script.Script(o.LockingScript)
+		tx.AddOutput(&transaction.TransactionOutput{Satoshis: o.Satoshis, LockingScript: &lockingScript})
+	}
+	isTxid := true
+	tx.MerklePath = transaction.NewMerklePath(100, [][]*transaction.PathElement{{{Offset: 0, Hash: tx.TxID(), Txid: &isTxid}}})
+	beef, err := tx.AtomicBEEF(false)
+	if err != nil {
+		return nil, err
+	}
+	if f.missingTx {
+		copy(beef[4:36], make([]byte, 32))
+	}
+	return &wallet.CreateActionResult{Txid: *tx.TxID(), Tx: beef}, nil
+}
+
+func TestSend(t *testing.T) {
+	w := &fakeWallet{}
+	opReturn := &script.Script{script.OpFALSE, script.OpRETURN}
+	strategy := &wallet.ChangeStrategy{Type: wallet.ChangeStrategySplit, Count: 2}
+
+	result, err := Send(t.Context(), w, []Recipient{
+		{Address: testAddress, Satoshis: 1000},
+		{LockingScript: opReturn, Satoshis: 1, Description: "Data output"},
+	}, WithDescription("Pay friends"), WithLabels("payments"), WithOriginator("app.com"), WithChangeStrategy(strategy), WithNoSend())
+	require.NoError(t, err)
+
+	address, err := script.NewAddressFromString(testAddress)
+	require.NoError(t, err)
+	lockingScript, err := p2pkh.Lock(address)
+	require.NoError(t, err)
+	noSend := true
+	require.Equal(t, wallet.CreateActionArgs{
+		Description: "Pay friends",
+		Outputs: []wallet.CreateActionOutput{
+			{LockingScript: *lockingScript, Satoshis: 1000, OutputDescription: "Payment to " + testAddress},
+			{LockingScript: *opReturn, Satoshis: 1, OutputDescription: "Data output"},
+		},
+		Labels:  []string{"payments"},
+		Options: &wallet.CreateActionOptions{ChangeStrategy: strategy, NoSend: &noSend},
+	}, w.args)
+	require.Equal(t, "app.com", w.originator)
+
+	require.Equal(t, *result.Tx.TxID(), result.Txid)
+	require.Len(t, result.Tx.Outputs, 2)
+	require.NotEmpty(t, result.BEEF)
+}
+
+func TestSendDefaults(t *testing.T) {
+	w := &fakeWallet{}
+	_, err := Send(t.Context(), w, []Recipient{{LockingScript: &script.Script{script.OpTRUE}, Satoshis: 5}})
+	require.NoError(t, err)
+	require.Equal(t, DefaultDescription, w.args.Description)
+	require.Equal(t, "Payment", w.args.Outputs[0].OutputDescription)
+	require.Nil(t, w.args.Options)
+}
+
+func TestSendErrors(t *testing.T) {
+	ctx := t.Context()
+	w := &fakeWallet{}
+
+	_, err := Send(ctx, w, nil)
+	require.ErrorIs(t, err, ErrNoRecipients)
+
+	for name, r := range map[string]Recipient{
+		"zero amount":      {Address: testAddress},
+		"no destination":   {Satoshis: 1},
+		"two destinations": {Address: testAddress, LockingScript: &script.Script{script.OpTRUE}, Satoshis: 1},
+		"bad address":      {Address: "not an address", Satoshis: 1},
+		"bad description":  {Address: testAddress, Satoshis: 1, Description: "pay"},
+	} {
+		_, err := Send(ctx, w, []Recipient{r})
+		require.ErrorContains(t, err, "invalid recipient 0", name)
+	}
+
+	_, err = Send(ctx, w, []Recipient{{Address: testAddress, Satoshis: 1}}, WithDescription("hi"))
+	require.ErrorContains(t, err, "invalid action description")
+
+	w.missingTx = true
+	_, err = Send(ctx, w, []Recipient{{Address: testAddress, Satoshis: 1}})
+	require.ErrorContains(t, err, "without the transaction")
+
+	w.err = errors.New("insufficient funds")
+	_, err = Send(ctx, w, []Recipient{{Address: testAddress, Satoshis: 1}})
+	require.ErrorIs(t, err, w.err)
+}
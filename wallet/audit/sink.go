@@ -0,0 +1,97 @@
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+// Status is the outcome of an audited call.
+type Status string
+
+const (
+	StatusOK    Status = "ok"
+	StatusError Status = "error"
+)
+
+// Record describes one wallet call.
+type Record struct {
+	Time       time.Time `json:"time"`
+	Method     string    `json:"method"`
+	Originator string    `json:"originator"`
+	// ArgsDigest is the hex HMAC-SHA256 of the JSON encoded arguments, keyed
+	// with the digest key of the Wallet. It identifies the request without
+	// recording its content, such as plaintexts to encrypt.
+	ArgsDigest string        `json:"argsDigest"`
+	Status     Status        `json:"status"`
+	Error      string        `json:"error,omitempty"`
+	Latency    time.Duration `json:"latency"`
+}
+
+// Sink receives audit records. Implementations must be safe for concurrent use,
+// and should not block for long since they are called inline with wallet calls.
+type Sink interface {
+	Record(ctx context.Context, r Record)
+}
+
+// SinkFunc adapts a function to the Sink interface, for example to add records as
+// events to the OpenTelemetry span in the context.
+type SinkFunc func(ctx context.Context, r Record)
+
+// Record calls f(ctx, r).
+func (f SinkFunc) Record(ctx context.Context, r Record) {
+	f(ctx, r)
+}
+
+// WriterSink writes records to an io.Writer as JSON lines.
+type WriterSink struct {
+	mu  sync.Mutex
+	enc *json.Encoder
+}
+
+// NewWriterSink creates a WriterSink writing to w.
+func NewWriterSink(w io.Writer) *WriterSink {
+	return &WriterSink{enc: json.NewEncoder(w)}
+}
+
+// Record writes r as a line of JSON. Write errors are dropped so that auditing
+// never fails a wallet call.
+func (s *WriterSink) Record(_ context.Context, r Record) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_ = s.enc.Encode(r)
+}
+
+// SlogSink logs records with a slog.Logger, at info level for successful calls
+// and at warn level for failed ones.
+type SlogSink struct {
+	Logger *slog.Logger
+}
+
+// NewSlogSink creates a SlogSink logging with l, or with slog.Default if l is nil.
+func NewSlogSink(l *slog.Logger) *SlogSink {
+	if l == nil {
+		l = slog.Default()
+	}
+	return &SlogSink{Logger: l}
+}
+
+// Record logs r.
+func (s *SlogSink) Record(ctx context.Context, r Record) {
+	level := slog.LevelInfo
+	attrs := []slog.Attr{
+		slog.String("method", r.Method),
+		slog.String("originator", r.Originator),
+		slog.String("argsDigest", r.ArgsDigest),
+		slog.String("status", string(r.Status)),
+		slog.Duration("latency", r.Latency),
+	}
+	if r.Status == StatusError {
+		level = slog.LevelWarn
+		attrs = append(attrs, slog.String("error", r.Error))
+	}
+	s.Logger.LogAttrs(ctx, level, "wallet call", attrs...)
+}
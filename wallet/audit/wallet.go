@@ -0,0 +1,229 @@
+// Package audit provides a wallet.Interface decorator that records every call made
+// to the wrapped wallet, for audit trails that do not depend on how the wallet is
+// implemented.
+package audit
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"time"
+
+	"github.com/bsv-blockchain/go-sdk/wallet"
+)
+
+// Wallet wraps a wallet.Interface and sends a Record to its Sink for every call.
+// Methods are named as in BRC-100, e.g. "createAction".
+type Wallet struct {
+	inner     wallet.Interface
+	sink      Sink
+	digestKey []byte
+	now       func() time.Time
+}
+
+var _ wallet.Interface = (*Wallet)(nil)
+
+// Option configures a Wallet.
+type Option func(*Wallet)
+
+// WithDigestKey sets the key of the HMAC-SHA256 digests of the arguments in
+// records. By default a random key is generated for every Wallet, so digests
+// can only be compared between records of the same Wallet; a fixed key makes
+// them comparable across restarts. The key must be kept secret, as anyone
+// holding it can confirm guesses of the arguments of a call.
+func WithDigestKey(key []byte) Option {
+	return func(w *Wallet) {
+		w.digestKey = key
+	}
+}
+
+// New creates a Wallet recording the calls made to w with sink.
+func New(w wallet.Interface, sink Sink, opts ...Option) *Wallet {
+	aw := &Wallet{inner: w, sink: sink, now: time.Now}
+	for _, opt := range opts {
+		opt(aw)
+	}
+	if aw.digestKey == nil {
+		aw.digestKey = make([]byte, sha256.Size)
+		_, _ = rand.Read(aw.digestKey)
+	}
+	return aw
+}
+
+// call forwards a call to fn and records its outcome.
+func call[A, R any](ctx context.Context, w *Wallet, method string, args A, originator string,
+	fn func(context.Context, A, string) (R, error)) (R, error) {
+	start := w.now()
+	result, err := fn(ctx, args, originator)
+	record := Record{
+		Time:       start,
+		Method:     method,
+		Originator: originator,
+		ArgsDigest: w.argsDigest(args),
+		Status:     StatusOK,
+		Latency:    w.now().Sub(start),
+	}
+	if err != nil {
+		record.Status = StatusError
+		record.Error = err.Error()
+	}
+	w.sink.Record(ctx, record)
+	return result, err
+}
+
+// argsDigest returns the hex HMAC-SHA256 of the JSON encoding of args with the
+// digest key, or an empty string if args cannot be encoded. Unlike a plain hash,
+// it cannot be reversed by hashing guesses of low entropy arguments, such as
+// short plaintexts to encrypt, without the key.
+func (w *Wallet) argsDigest(args any) string {
+	data, err := json.Marshal(args)
+	if err != nil {
+		return ""
+	}
+	mac := hmac.New(sha256.New, w.digestKey)
+	mac.Write(data)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// GetPublicKey records and forwards the call.
+func (w *Wallet) GetPublicKey(ctx context.Context, args wallet.GetPublicKeyArgs, originator string) (*wallet.GetPublicKeyResult, error) {
+	return call(ctx, w, "getPublicKey", args, originator, w.inner.GetPublicKey)
+}
+
+// Encrypt records and forwards the call.
+func (w *Wallet) Encrypt(ctx context.Context, args wallet.EncryptArgs, originator string) (*wallet.EncryptResult, error) {
+	return call(ctx, w, "encrypt", args, originator, w.inner.Encrypt)
+}
+
+// Decrypt records and forwards the call.
+func (w *Wallet) Decrypt(ctx context.Context, args wallet.DecryptArgs, originator string) (*wallet.DecryptResult, error) {
+	return call(ctx, w, "decrypt", args, originator, w.inner.Decrypt)
+}
+
+// CreateHMAC records and forwards the call.
+func (w *Wallet) CreateHMAC(ctx context.Context, args wallet.CreateHMACArgs, originator string) (*wallet.CreateHMACResult, error) {
+	return call(ctx, w, "createHMAC", args, originator, w.inner.CreateHMAC)
+}
+
+// VerifyHMAC records and forwards the call.
+func (w *Wallet) VerifyHMAC(ctx context.Context, args wallet.VerifyHMACArgs, originator string) (*wallet.VerifyHMACResult, error) {
+	return call(ctx, w, "verifyHMAC", args, originator, w.inner.VerifyHMAC)
+}
+
+// CreateSignature records and forwards the call.
+func (w *Wallet) CreateSignature(ctx context.Context, args wallet.CreateSignatureArgs, originator string) (*wallet.CreateSignatureResult, error) {
+	return call(ctx, w, "createSignature", args, originator, w.inner.CreateSignature)
+}
+
+// VerifySignature records and forwards the call.
+func (w *Wallet) VerifySignature(ctx context.Context, args wallet.VerifySignatureArgs, originator string) (*wallet.VerifySignatureResult, error) {
+	return call(ctx, w, "verifySignature", args, originator, w.inner.VerifySignature)
+}
+
+// AcquireCertificate records and forwards the call.
+func (w *Wallet) AcquireCertificate(ctx context.Context, args wallet.AcquireCertificateArgs, originator string) (*wallet.Certificate, error) {
+	return call(ctx, w, "acquireCertificate", args, originator, w.inner.AcquireCertificate)
+}
+
+// ListCertificates records and forwards the call.
+func (w *Wallet) ListCertificates(ctx context.Context, args wallet.ListCertificatesArgs, originator string) (*wallet.ListCertificatesResult, error) {
+	return call(ctx, w, "listCertificates", args, originator, w.inner.ListCertificates)
+}
+
+// ProveCertificate records and forwards the call.
+func (w *Wallet) ProveCertificate(ctx context.Context, args wallet.ProveCertificateArgs, originator string) (*wallet.ProveCertificateResult, error) {
+	return call(ctx, w, "proveCertificate", args, originator, w.inner.ProveCertificate)
+}
+
+// RelinquishCertificate records and forwards the call.
+func (w *Wallet) RelinquishCertificate(ctx context.Context, args wallet.RelinquishCertificateArgs, originator string) (*wallet.RelinquishCertificateResult, error) {
+	return call(ctx, w, "relinquishCertificate", args, originator, w.inner.RelinquishCertificate)
+}
+
+// CreateAction records and forwards the call.
+func (w *Wallet) CreateAction(ctx context.Context, args wallet.CreateActionArgs, originator string) (*wallet.CreateActionResult, error) {
+	return call(ctx, w, "createAction", args, originator, w.inner.CreateAction)
+}
+
+// SignAction records and forwards the call.
+func (w *Wallet) SignAction(ctx context.Context, args wallet.SignActionArgs, originator string) (*wallet.SignActionResult, error) {
+	return call(ctx, w, "signAction", args, originator, w.inner.SignAction)
+}
+
+// AbortAction records and forwards the call.
+func (w *Wallet) AbortAction(ctx context.Context, args wallet.AbortActionArgs, originator string) (*wallet.AbortActionResult, error) {
+	return call(ctx, w, "abortAction", args, originator, w.inner.AbortAction)
+}
+
+// ListActions records and forwards the call.
+func (w *Wallet) ListActions(ctx context.Context, args wallet.ListActionsArgs, originator string) (*wallet.ListActionsResult, error) {
+	return call(ctx, w, "listActions", args, originator, w.inner.ListActions)
+}
+
+// InternalizeAction records and forwards the call.
+func (w *Wallet) InternalizeAction(ctx context.Context, args wallet.InternalizeActionArgs, originator string) (*wallet.InternalizeActionResult, error) {
+	return call(ctx, w, "internalizeAction", args, originator, w.inner.InternalizeAction)
+}
+
+// ListOutputs records and forwards the call.
+func (w *Wallet) ListOutputs(ctx context.Context, args wallet.ListOutputsArgs, originator string) (*wallet.ListOutputsResult, error) {
+	return call(ctx, w, "listOutputs", args, originator, w.inner.ListOutputs)
+}
+
+// RelinquishOutput records and forwards the call.
+func (w *Wallet) RelinquishOutput(ctx context.Context, args wallet.RelinquishOutputArgs, originator string) (*wallet.RelinquishOutputResult, error) {
+	return call(ctx, w, "relinquishOutput", args, originator, w.inner.RelinquishOutput)
+}
+
+// RevealCounterpartyKeyLinkage records and forwards the call.
+func (w *Wallet) RevealCounterpartyKeyLinkage(ctx context.Context, args wallet.RevealCounterpartyKeyLinkageArgs, originator string) (*wallet.RevealCounterpartyKeyLinkageResult, error) {
+	return call(ctx, w, "revealCounterpartyKeyLinkage", args, originator, w.inner.RevealCounterpartyKeyLinkage)
+}
+
+// RevealSpecificKeyLinkage records and forwards the call.
+func (w *Wallet) RevealSpecificKeyLinkage(ctx context.Context, args wallet.RevealSpecificKeyLinkageArgs, originator string) (*wallet.RevealSpecificKeyLinkageResult, error) {
+	return call(ctx, w, "revealSpecificKeyLinkage", args, originator, w.inner.RevealSpecificKeyLinkage)
+}
+
+// DiscoverByIdentityKey records and forwards the call.
+func (w *Wallet) DiscoverByIdentityKey(ctx context.Context, args wallet.DiscoverByIdentityKeyArgs, originator string) (*wallet.DiscoverCertificatesResult, error) {
+	return call(ctx, w, "discoverByIdentityKey", args, originator, w.inner.DiscoverByIdentityKey)
+}
+
+// DiscoverByAttributes records and forwards the call.
+func (w *Wallet) DiscoverByAttributes(ctx context.Context, args wallet.DiscoverByAttributesArgs, originator string) (*wallet.DiscoverCertificatesResult, error) {
+	return call(ctx, w, "discoverByAttributes", args, originator, w.inner.DiscoverByAttributes)
+}
+
+// IsAuthenticated records and forwards the call.
+func (w *Wallet) IsAuthenticated(ctx context.Context, args any, originator string) (*wallet.AuthenticatedResult, error) {
+	return call(ctx, w, "isAuthenticated", args, originator, w.inner.IsAuthenticated)
+}
+
+// WaitForAuthentication records and forwards the call.
+func (w *Wallet) WaitForAuthentication(ctx context.Context, args any, originator string) (*wallet.AuthenticatedResult, error) {
+	return call(ctx, w, "waitForAuthentication", args, originator, w.inner.WaitForAuthentication)
+}
+
+// GetHeight records and forwards the call.
+func (w *Wallet) GetHeight(ctx context.Context, args any, originator string) (*wallet.GetHeightResult, error) {
+	return call(ctx, w, "getHeight", args, originator, w.inner.GetHeight)
+}
+
+// GetHeaderForHeight records and forwards the call.
+func (w *Wallet) GetHeaderForHeight(ctx context.Context, args wallet.GetHeaderArgs, originator string) (*wallet.GetHeaderResult, error) {
+	return call(ctx, w, "getHeaderForHeight", args, originator, w.inner.GetHeaderForHeight)
+}
+
+// GetNetwork records and forwards the call.
+func (w *Wallet) GetNetwork(ctx context.Context, args any, originator string) (*wallet.GetNetworkResult, error) {
+	return call(ctx, w, "getNetwork", args, originator, w.inner.GetNetwork)
+}
+
+// GetVersion records and forwards the call.
+func (w *Wallet) GetVersion(ctx context.Context, args any, originator string) (*wallet.GetVersionResult, error) {
+	return call(ctx, w, "getVersion", args, originator, w.inner.GetVersion)
+}
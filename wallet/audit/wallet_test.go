@@ -0,0 +1,133 @@
+package audit
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/bsv-blockchain/go-sdk/wallet"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeWallet struct {
+	wallet.Interface
+}
+
+func (fakeWallet) GetHeight(context.Context, any, string) (*wallet.GetHeightResult, error) {
+	return &wallet.GetHeightResult{Height: 100}, nil
+}
+
+func (fakeWallet) Encrypt(context.Context, wallet.EncryptArgs, string) (*wallet.EncryptResult, error) {
+	return nil, errors.New("key not found")
+}
+
+type recorder []Record
+
+func (r *recorder) Record(_ context.Context, rec Record) {
+	*r = append(*r, rec)
+}
+
+func newTestWallet(sink Sink) *Wallet {
+	w := New(fakeWallet{}, sink)
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	w.now = func() time.Time {
+		now = now.Add(5 * time.Millisecond)
+		return now
+	}
+	return w
+}
+
+func TestWalletRecordsCalls(t *testing.T) {
+	ctx := t.Context()
+	var records recorder
+	w := newTestWallet(&records)
+
+	height, err := w.GetHeight(ctx, nil, "app.com")
+	require.NoError(t, err)
+	require.Equal(t, uint32(100), height.Height)
+
+	args := wallet.EncryptArgs{Plaintext: []byte("secret message")}
+	_, err = w.Encrypt(ctx, args, "other.com")
+	require.EqualError(t, err, "key not found")
+
+	require.Len(t, records, 2)
+	require.Equal(t, Record{
+		Time:       time.Date(2025, 1, 1, 0, 0, 0, 5e6, time.UTC),
+		Method:     "getHeight",
+		Originator: "app.com",
+		ArgsDigest: w.argsDigest(nil),
+		Status:     StatusOK,
+		Latency:    5 * time.Millisecond,
+	}, records[0])
+
+	require.Equal(t, "encrypt", records[1].Method)
+	require.Equal(t, "other.com", records[1].Originator)
+	require.Equal(t, StatusError, records[1].Status)
+	require.Equal(t, "key not found", records[1].Error)
+	require.Len(t, records[1].ArgsDigest, 64)
+	require.Equal(t, w.argsDigest(args), records[1].ArgsDigest)
+
+	args.Plaintext = []byte("another message")
+	require.NotEqual(t, w.argsDigest(args), records[1].ArgsDigest)
+}
+
+func TestWalletArgsDigestKey(t *testing.T) {
+	args := wallet.EncryptArgs{Plaintext: []byte("yes")}
+	data, err := json.Marshal(args)
+	require.NoError(t, err)
+	unkeyed := sha256.Sum256(data)
+
+	// Digests cannot be recomputed without the key.
+	w := New(fakeWallet{}, &recorder{})
+	require.NotEqual(t, hex.EncodeToString(unkeyed[:]), w.argsDigest(args))
+	require.NotEqual(t, w.argsDigest(args), New(fakeWallet{}, &recorder{}).argsDigest(args))
+
+	key := []byte("audit key")
+	keyed := New(fakeWallet{}, &recorder{}, WithDigestKey(key))
+	mac := hmac.New(sha256.New, key)
+	mac.Write(data)
+	require.Equal(t, hex.EncodeToString(mac.Sum(nil)), keyed.argsDigest(args))
+	require.Equal(t, keyed.argsDigest(args), New(fakeWallet{}, &recorder{}, WithDigestKey(key)).argsDigest(args))
+}
+
+func TestWriterSink(t *testing.T) {
+	var buf bytes.Buffer
+	w := newTestWallet(NewWriterSink(&buf))
+
+	_, _ = w.Encrypt(t.Context(), wallet.EncryptArgs{Plaintext: []byte("secret message")}, "app.com")
+	_, _ = w.GetHeight(t.Context(), nil, "app.com")
+	require.NotContains(t, buf.String(), "secret message")
+
+	dec := json.NewDecoder(&buf)
+	var first, second Record
+	require.NoError(t, dec.Decode(&first))
+	require.NoError(t, dec.Decode(&second))
+	require.Equal(t, "encrypt", first.Method)
+	require.Equal(t, StatusError, first.Status)
+	require.Equal(t, "getHeight", second.Method)
+	require.Equal(t, 5*time.Millisecond, second.Latency)
+}
+
+func TestSlogSink(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, nil))
+	w := newTestWallet(NewSlogSink(logger))
+
+	_, _ = w.Encrypt(t.Context(), wallet.EncryptArgs{}, "app.com")
+
+	var entry map[string]any
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &entry))
+	require.Equal(t, "WARN", entry["level"])
+	require.Equal(t, "wallet call", entry["msg"])
+	require.Equal(t, "encrypt", entry["method"])
+	require.Equal(t, "app.com", entry["originator"])
+	require.Equal(t, "error", entry["status"])
+	require.Equal(t, "key not found", entry["error"])
+}
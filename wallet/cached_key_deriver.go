@@ -8,11 +8,11 @@ import (
 	ec "github.com/bsv-blockchain/go-sdk/primitives/ec"
 )
 
-// CachedKeyDeriver is a wrapper around KeyDeriver that caches derived keys
+// CachedKeyDeriver is a wrapper around a KeyDeriverInterface that caches derived keys
 // to improve performance for repeated derivations with the same parameters.
 // It uses an LRU cache with configurable size.
 type CachedKeyDeriver struct {
-	keyDeriver   keyDeriverInterface
+	keyDeriver   KeyDeriverInterface
 	cache        *lruCache
 	maxCacheSize int
 }
@@ -38,16 +38,25 @@ type lruCache struct {
 
 const defaultMaxCacheSize = 1000
 
+var _ KeyDeriverInterface = (*CachedKeyDeriver)(nil)
+
 // NewCachedKeyDeriver creates a new CachedKeyDeriver instance.
 // rootKey is the root private key or 'anyone' key.
 // maxCacheSize specifies the maximum number of items to cache (default 1000 if <= 0).
 func NewCachedKeyDeriver(rootKey *ec.PrivateKey, maxCacheSize int) *CachedKeyDeriver {
+	return NewCachedKeyDeriverFrom(NewKeyDeriver(rootKey), maxCacheSize)
+}
+
+// NewCachedKeyDeriverFrom creates a CachedKeyDeriver decorating an existing key deriver,
+// such as an HSM or KMS backed one.
+// maxCacheSize specifies the maximum number of items to cache (default 1000 if <= 0).
+func NewCachedKeyDeriverFrom(keyDeriver KeyDeriverInterface, maxCacheSize int) *CachedKeyDeriver {
 	if maxCacheSize <= 0 {
 		maxCacheSize = defaultMaxCacheSize
 	}
 
 	return &CachedKeyDeriver{
-		keyDeriver: NewKeyDeriver(rootKey),
+		keyDeriver: keyDeriver,
 		cache: &lruCache{
 			items: make(map[cacheKey]*cacheValue),
 			list:  list.New(),
@@ -153,6 +162,63 @@ func (c *CachedKeyDeriver) RevealSpecificSecret(counterparty Counterparty, proto
 	return secret, nil
 }
 
+// IdentityKey returns the identity key of the underlying key deriver.
+func (c *CachedKeyDeriver) IdentityKey() *ec.PublicKey {
+	key := cacheKey{method: "identityKey"}
+
+	if val, ok := c.cacheGet(key); ok {
+		if pubKey, ok := val.(*ec.PublicKey); ok {
+			return pubKey
+		}
+	}
+
+	pubKey := c.keyDeriver.IdentityKey()
+	c.cacheSet(key, pubKey)
+	return pubKey
+}
+
+// RevealCounterpartySecret reveals the shared secret with the counterparty with caching.
+func (c *CachedKeyDeriver) RevealCounterpartySecret(counterparty Counterparty) (*ec.PublicKey, error) {
+	key := cacheKey{
+		method:       "revealCounterpartySecret",
+		counterparty: counterparty,
+	}
+
+	if val, ok := c.cacheGet(key); ok {
+		if secret, ok := val.(*ec.PublicKey); ok {
+			return secret, nil
+		}
+	}
+
+	secret, err := c.keyDeriver.RevealCounterpartySecret(counterparty)
+	if err != nil {
+		return nil, fmt.Errorf("failed to reveal counterparty secret: %w", err)
+	}
+
+	c.cacheSet(key, secret)
+	return secret, nil
+}
+
+func (c *CachedKeyDeriver) rootPrivateKey() *ec.PrivateKey {
+	if holder, ok := c.keyDeriver.(rootKeyHolder); ok {
+		return holder.rootPrivateKey()
+	}
+	return nil
+}
+
+// Wipe drops all cached keys and wipes the underlying key deriver if it supports it.
+// Keys previously returned to callers are left untouched.
+func (c *CachedKeyDeriver) Wipe() {
+	c.cache.mu.Lock()
+	clear(c.cache.items)
+	c.cache.list.Init()
+	c.cache.mu.Unlock()
+
+	if w, ok := c.keyDeriver.(interface{ Wipe() }); ok {
+		w.Wipe()
+	}
+}
+
 // cacheGet retrieves a value from cache and updates its LRU position.
 func (c *CachedKeyDeriver) cacheGet(key cacheKey) (any, bool) {
 	c.cache.mu.Lock()
@@ -12,16 +12,23 @@ import (
 )
 
 type MockKeyDeriver struct {
-	publicKeyCallCount        int
-	privateKeyCallCount       int
-	symmetricKeyCallCount     int
-	specificSecretCallCount   int
-	publicKeySleepTime        time.Duration
-	publicKeyToReturn         *ec.PublicKey
-	privateKeyToReturn        *ec.PrivateKey
-	symmetricKeyToReturn      *ec.SymmetricKey
-	specificSecretToReturn    []byte
-	symmetricKeyErrorToReturn error
+	publicKeyCallCount          int
+	privateKeyCallCount         int
+	symmetricKeyCallCount       int
+	specificSecretCallCount     int
+	publicKeySleepTime          time.Duration
+	publicKeyToReturn           *ec.PublicKey
+	privateKeyToReturn          *ec.PrivateKey
+	symmetricKeyToReturn        *ec.SymmetricKey
+	specificSecretToReturn      []byte
+	symmetricKeyErrorToReturn   error
+	counterpartySecretCallCount int
+	counterpartySecretToReturn  *ec.PublicKey
+	identityKeyToReturn         *ec.PublicKey
+}
+
+func (m *MockKeyDeriver) IdentityKey() *ec.PublicKey {
+	return m.identityKeyToReturn
 }
 
 func (m *MockKeyDeriver) DerivePublicKey(protocolID Protocol, keyID string, counterparty Counterparty, forSelf bool) (*ec.PublicKey, error) {
@@ -46,6 +53,11 @@ func (m *MockKeyDeriver) RevealSpecificSecret(counterparty Counterparty, protoco
 	return m.specificSecretToReturn, nil
 }
 
+func (m *MockKeyDeriver) RevealCounterpartySecret(counterparty Counterparty) (*ec.PublicKey, error) {
+	m.counterpartySecretCallCount++
+	return m.counterpartySecretToReturn, nil
+}
+
 func TestDerivePublicKey(t *testing.T) {
 	// Create keys and cached key deriver
 	rootKey, _ := ec.PrivateKeyFromBytes([]byte{1})
@@ -401,3 +413,68 @@ func TestPerformanceConsiderations(t *testing.T) {
 		assert.Less(t, secondCallDuration.Milliseconds(), int64(10)) // Should be much faster
 	})
 }
+
+func TestRevealCounterpartySecretCaching(t *testing.T) {
+	secret := &ec.PublicKey{Curve: ec.S256(), X: big.NewInt(1), Y: big.NewInt(2)}
+	mockKeyDeriver := &MockKeyDeriver{counterpartySecretToReturn: secret}
+	cachedKeyDeriver := NewCachedKeyDeriverFrom(mockKeyDeriver, 0)
+	counterparty := Counterparty{Type: CounterpartyTypeAnyone}
+
+	for range 2 {
+		got, err := cachedKeyDeriver.RevealCounterpartySecret(counterparty)
+		assert.NoError(t, err)
+		assert.Same(t, secret, got)
+	}
+	assert.Equal(t, 1, mockKeyDeriver.counterpartySecretCallCount)
+}
+
+func TestProtoWalletWithCustomKeyDeriver(t *testing.T) {
+	userKey, err := ec.NewPrivateKey()
+	assert.NoError(t, err)
+	counterpartyKey, err := ec.NewPrivateKey()
+	assert.NoError(t, err)
+
+	cached, err := NewProtoWallet(ProtoWalletArgs{
+		Type:       ProtoWalletArgsTypeKeyDeriver,
+		KeyDeriver: NewCachedKeyDeriverFrom(NewKeyDeriver(userKey), 0),
+	})
+	assert.NoError(t, err)
+	counterpartyWallet, err := NewProtoWallet(ProtoWalletArgs{Type: ProtoWalletArgsTypePrivateKey, PrivateKey: counterpartyKey})
+	assert.NoError(t, err)
+
+	ctx := t.Context()
+	identity, err := cached.GetPublicKey(ctx, GetPublicKeyArgs{IdentityKey: true}, "")
+	assert.NoError(t, err)
+	assert.True(t, userKey.PubKey().IsEqual(identity.PublicKey))
+
+	args := EncryptionArgs{
+		ProtocolID:   Protocol{SecurityLevel: SecurityLevelEveryAppAndCounterparty, Protocol: "custom key deriver"},
+		KeyID:        "1",
+		Counterparty: Counterparty{Type: CounterpartyTypeOther, Counterparty: counterpartyKey.PubKey()},
+	}
+	encrypted, err := cached.Encrypt(ctx, EncryptArgs{EncryptionArgs: args, Plaintext: []byte("hello")}, "")
+	assert.NoError(t, err)
+
+	args.Counterparty.Counterparty = userKey.PubKey()
+	decrypted, err := counterpartyWallet.Decrypt(ctx, DecryptArgs{EncryptionArgs: args, Ciphertext: encrypted.Ciphertext}, "")
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("hello"), []byte(decrypted.Plaintext))
+
+	_, err = cached.RevealCounterpartyKeyLinkage(ctx, RevealCounterpartyKeyLinkageArgs{
+		Counterparty: counterpartyKey.PubKey(),
+		Verifier:     counterpartyKey.PubKey(),
+	}, "")
+	assert.NoError(t, err, "decorated in-memory key derivers can still prove linkage")
+
+	// A backend that does not hold its root key in memory cannot prove linkage
+	external, err := NewProtoWallet(ProtoWalletArgs{
+		Type:       ProtoWalletArgsTypeKeyDeriver,
+		KeyDeriver: &MockKeyDeriver{identityKeyToReturn: userKey.PubKey()},
+	})
+	assert.NoError(t, err)
+	_, err = external.RevealCounterpartyKeyLinkage(ctx, RevealCounterpartyKeyLinkageArgs{
+		Counterparty: counterpartyKey.PubKey(),
+		Verifier:     counterpartyKey.PubKey(),
+	}, "")
+	assert.ErrorContains(t, err, "does not support counterparty key linkage revelation")
+}
@@ -0,0 +1,163 @@
+// Package certtypes is a registry of certificate types, declaring the fields each
+// known type carries and how their plaintext values are validated.
+//
+// Types are identified by their base64 encoded 32 byte type ID, the same form used
+// by identity.KnownIdentityTypes and certificates.Certificate.
+package certtypes
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"sync"
+)
+
+// Type IDs of the well known identity certificates.
+const (
+	IdentiCert  = "z40BOInXkI8m7f/wBrv4MJ09bZfzZbTj2fJqCtONqCY="
+	DiscordCert = "2TgqRC35B1zehGmB21xveZNc7i5iqHc0uxMb+1NMPW4="
+	PhoneCert   = "mffUklUzxbHr65xLohn0hRL0Tq2GjW1GYF/OPfzqJ6A="
+	XCert       = "vdDWvftf1H+5+ZprUw123kjHlywH+v20aPQTuXgMpNc="
+	Registrant  = "YoPsbfR6YQczjzPdHCoGC7nJsOdPQR50+SYqcWpJ0y0="
+	EmailCert   = "exOl3KM0dIJ04EW5pZgbZmPag6MdJXd3/a1enmUU/BA="
+	Anyone      = "mfkOMfLDQmrr3SBxBQ5WeE+6Hy3VJRFq6w4A5Ljtlis="
+	Self        = "Hkge6X5JRxt1cWXtHLCrSTg6dCVTxjQJJ48iOYd7n3g="
+	CoolCert    = "AGfk/WrT1eBDXpz3mcw386Zww2HmqcIn3uY6x4Af1eo="
+)
+
+var (
+	ErrMissingField    = errors.New("missing certificate field")
+	ErrUnexpectedField = errors.New("unexpected certificate field")
+	ErrInvalidField    = errors.New("invalid certificate field")
+	ErrInvalidSchema   = errors.New("invalid certificate schema")
+)
+
+// Field declares a field of a certificate type.
+type Field struct {
+	Name     string
+	Required bool
+	// Validate checks a plaintext value of the field. It may be nil.
+	Validate func(value string) error
+}
+
+// Schema declares the fields of a certificate type.
+type Schema struct {
+	// Type is the base64 encoded type ID.
+	Type string
+	// Name is a human readable name of the type.
+	Name   string
+	Fields []Field
+	// Strict rejects fields that are not declared. Otherwise undeclared fields
+	// are accepted without validation.
+	Strict bool
+}
+
+// Field returns the declared field with the given name.
+func (s *Schema) Field(name string) (Field, bool) {
+	i := slices.IndexFunc(s.Fields, func(f Field) bool { return f.Name == name })
+	if i < 0 {
+		return Field{}, false
+	}
+	return s.Fields[i], true
+}
+
+// ValidateFields checks plaintext field values against the schema.
+func (s *Schema) ValidateFields(fields map[string]string) error {
+	for _, f := range s.Fields {
+		value, ok := fields[f.Name]
+		if !ok {
+			if f.Required {
+				return fmt.Errorf("%w: %s certificate requires %q", ErrMissingField, s.Name, f.Name)
+			}
+			continue
+		}
+		if f.Validate != nil {
+			if err := f.Validate(value); err != nil {
+				return fmt.Errorf("%w: %s certificate field %q: %w", ErrInvalidField, s.Name, f.Name, err)
+			}
+		}
+	}
+	if s.Strict {
+		for _, name := range slices.Sorted(maps.Keys(fields)) {
+			if _, ok := s.Field(name); !ok {
+				return fmt.Errorf("%w: %s certificate does not declare %q", ErrUnexpectedField, s.Name, name)
+			}
+		}
+	}
+	return nil
+}
+
+// Registry holds the schemas of certificate types. It is safe for concurrent use.
+type Registry struct {
+	mu      sync.RWMutex
+	schemas map[string]*Schema
+}
+
+// NewRegistry creates an empty registry.
+func NewRegistry() *Registry {
+	return &Registry{schemas: make(map[string]*Schema)}
+}
+
+// Register adds a schema, replacing any schema registered for the same type.
+func (r *Registry) Register(schema Schema) error {
+	if schema.Type == "" {
+		return fmt.Errorf("%w: type is required", ErrInvalidSchema)
+	}
+	seen := make(map[string]struct{}, len(schema.Fields))
+	for _, f := range schema.Fields {
+		if f.Name == "" || len(f.Name) > 50 {
+			return fmt.Errorf("%w: field names must be 1 to 50 bytes long: %q", ErrInvalidSchema, f.Name)
+		}
+		if _, ok := seen[f.Name]; ok {
+			return fmt.Errorf("%w: duplicate field %q", ErrInvalidSchema, f.Name)
+		}
+		seen[f.Name] = struct{}{}
+	}
+	if schema.Name == "" {
+		schema.Name = schema.Type
+	}
+	schema.Fields = slices.Clone(schema.Fields)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.schemas[schema.Type] = &schema
+	return nil
+}
+
+// Lookup returns the schema of a certificate type.
+func (r *Registry) Lookup(certType string) (*Schema, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	s, ok := r.schemas[certType]
+	return s, ok
+}
+
+// ValidateFields checks plaintext field values against the schema of the
+// certificate type. Types without a schema are not validated.
+func (r *Registry) ValidateFields(certType string, fields map[string]string) error {
+	s, ok := r.Lookup(certType)
+	if !ok {
+		return nil
+	}
+	return s.ValidateFields(fields)
+}
+
+// Default is the registry used by the package level functions. It holds the
+// schemas of the well known identity certificates.
+var Default = newDefaultRegistry()
+
+// Register adds a schema to the Default registry.
+func Register(schema Schema) error {
+	return Default.Register(schema)
+}
+
+// Lookup returns the schema of a certificate type from the Default registry.
+func Lookup(certType string) (*Schema, bool) {
+	return Default.Lookup(certType)
+}
+
+// ValidateFields checks plaintext field values against the Default registry.
+func ValidateFields(certType string, fields map[string]string) error {
+	return Default.ValidateFields(certType, fields)
+}
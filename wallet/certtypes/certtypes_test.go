@@ -0,0 +1,59 @@
+package certtypes
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateFieldsKnownTypes(t *testing.T) {
+	tests := []struct {
+		name     string
+		certType string
+		fields   map[string]string
+		err      error
+	}{
+		{"email", EmailCert, map[string]string{"email": "alice@example.com"}, nil},
+		{"email missing", EmailCert, map[string]string{}, ErrMissingField},
+		{"email invalid", EmailCert, map[string]string{"email": "Alice <alice@example.com>"}, ErrInvalidField},
+		{"phone", PhoneCert, map[string]string{"phoneNumber": "+1 (555) 123-4567"}, nil},
+		{"phone invalid", PhoneCert, map[string]string{"phoneNumber": "call me"}, ErrInvalidField},
+		{"x with optional field omitted", XCert, map[string]string{"userName": "alice"}, nil},
+		{"x with extra field", XCert, map[string]string{"userName": "alice", "followers": "10"}, nil},
+		{"identicert empty name", IdentiCert, map[string]string{"firstName": "", "lastName": "Smith"}, ErrInvalidField},
+		{"cool", CoolCert, map[string]string{"cool": "true"}, nil},
+		{"cool invalid", CoolCert, map[string]string{"cool": "very"}, ErrInvalidField},
+		{"cool is strict", CoolCert, map[string]string{"cool": "true", "extra": "x"}, ErrUnexpectedField},
+		{"unknown type", "bm90IGEga25vd24gdHlwZQ==", map[string]string{"anything": ""}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateFields(tt.certType, tt.fields)
+			if tt.err == nil {
+				require.NoError(t, err)
+			} else {
+				require.ErrorIs(t, err, tt.err)
+			}
+		})
+	}
+}
+
+func TestRegistryRegister(t *testing.T) {
+	r := NewRegistry()
+	require.ErrorIs(t, r.Register(Schema{Name: "no type"}), ErrInvalidSchema)
+	require.ErrorIs(t, r.Register(Schema{Type: "dA==", Fields: []Field{{Name: "a"}, {Name: "a"}}}), ErrInvalidSchema)
+
+	require.NoError(t, r.Register(Schema{
+		Type:   "dA==",
+		Fields: []Field{{Name: "level", Required: true, Validate: OneOf("gold", "silver")}},
+		Strict: true,
+	}))
+	s, ok := r.Lookup("dA==")
+	require.True(t, ok)
+	require.Equal(t, "dA==", s.Name)
+	require.NoError(t, r.ValidateFields("dA==", map[string]string{"level": "gold"}))
+	require.ErrorIs(t, r.ValidateFields("dA==", map[string]string{"level": "bronze"}), ErrInvalidField)
+
+	_, ok = r.Lookup(EmailCert)
+	require.False(t, ok, "new registries start empty")
+}
@@ -0,0 +1,116 @@
+package certtypes
+
+import (
+	"errors"
+	"fmt"
+	"net/mail"
+	"regexp"
+)
+
+// phoneNumberPattern accepts international numbers, optionally with separators.
+var phoneNumberPattern = regexp.MustCompile(`^\+?[0-9][0-9 ().-]{5,}[0-9]$`)
+
+// NotEmpty rejects empty values.
+func NotEmpty(value string) error {
+	if value == "" {
+		return errors.New("value is empty")
+	}
+	return nil
+}
+
+// EmailAddress accepts a bare email address.
+func EmailAddress(value string) error {
+	addr, err := mail.ParseAddress(value)
+	if err != nil {
+		return err
+	}
+	if addr.Address != value {
+		return fmt.Errorf("%q is not a bare email address", value)
+	}
+	return nil
+}
+
+// PhoneNumber accepts phone numbers made of digits, an optional leading + and
+// the usual separators.
+func PhoneNumber(value string) error {
+	if !phoneNumberPattern.MatchString(value) {
+		return fmt.Errorf("%q is not a phone number", value)
+	}
+	return nil
+}
+
+// OneOf accepts only the given values.
+func OneOf(values ...string) func(string) error {
+	return func(value string) error {
+		for _, v := range values {
+			if value == v {
+				return nil
+			}
+		}
+		return fmt.Errorf("%q is not one of %q", value, values)
+	}
+}
+
+// knownSchemas are the fields of the well known identity certificates, as read
+// by identity.Client. Anyone and Self are not issued, so they have no schema.
+var knownSchemas = []Schema{
+	{
+		Type: IdentiCert,
+		Name: "IdentiCert",
+		Fields: []Field{
+			{Name: "firstName", Required: true, Validate: NotEmpty},
+			{Name: "lastName", Required: true, Validate: NotEmpty},
+			{Name: "profilePhoto"},
+		},
+	},
+	{
+		Type: DiscordCert,
+		Name: "DiscordCert",
+		Fields: []Field{
+			{Name: "userName", Required: true, Validate: NotEmpty},
+			{Name: "profilePhoto"},
+		},
+	},
+	{
+		Type: XCert,
+		Name: "XCert",
+		Fields: []Field{
+			{Name: "userName", Required: true, Validate: NotEmpty},
+			{Name: "profilePhoto"},
+		},
+	},
+	{
+		Type:   EmailCert,
+		Name:   "EmailCert",
+		Fields: []Field{{Name: "email", Required: true, Validate: EmailAddress}},
+	},
+	{
+		Type:   PhoneCert,
+		Name:   "PhoneCert",
+		Fields: []Field{{Name: "phoneNumber", Required: true, Validate: PhoneNumber}},
+	},
+	{
+		Type: Registrant,
+		Name: "Registrant",
+		Fields: []Field{
+			{Name: "name", Required: true, Validate: NotEmpty},
+			{Name: "icon"},
+		},
+	},
+	{
+		Type:   CoolCert,
+		Name:   "CoolCert",
+		Fields: []Field{{Name: "cool", Required: true, Validate: OneOf("true", "false")}},
+		Strict: true,
+	},
+}
+
+func newDefaultRegistry() *Registry {
+	r := NewRegistry()
+	for _, s := range knownSchemas {
+		if err := r.Register(s); err != nil {
+			panic(err)
+		}
+	}
+	return r
+}
@@ -0,0 +1,234 @@
+package wallet
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/bsv-blockchain/go-sdk/script"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+)
+
+// ChangeStrategyType selects how a wallet shapes the change outputs of a new action.
+type ChangeStrategyType string
+
+const (
+	// ChangeStrategySingle returns all change in a single output. This is the default.
+	ChangeStrategySingle ChangeStrategyType = "single"
+	// ChangeStrategySplit divides change evenly across Count outputs.
+	ChangeStrategySplit ChangeStrategyType = "split"
+	// ChangeStrategyDenominations breaks change into outputs of fixed Denominations.
+	ChangeStrategyDenominations ChangeStrategyType = "denominations"
+)
+
+// MaxChangeOutputs is the largest number of change outputs a strategy may produce.
+// Strategies can arrive over the wallet wire protocol, so this bounds the work
+// and memory a caller can demand.
+const MaxChangeOutputs = 1000
+
+// ErrInvalidChangeStrategy is returned when a ChangeStrategy is malformed.
+var ErrInvalidChangeStrategy = errors.New("invalid change strategy")
+
+// ChangeStrategy lets privacy-conscious applications control the number and amounts
+// of change outputs a wallet creates when funding an action. Amounts computed from a
+// strategy are deterministic; output order is still governed by RandomizeOutputs.
+// Wallets shape the change of the transactions they fund with Apply.
+type ChangeStrategy struct {
+	Type ChangeStrategyType `json:"type"`
+	// Count is the number of change outputs for ChangeStrategySplit.
+	Count uint32 `json:"count,omitempty"`
+	// Denominations are the output amounts, in satoshis, for ChangeStrategyDenominations.
+	Denominations []uint64 `json:"denominations,omitempty"`
+}
+
+// Validate checks that the strategy is well formed.
+func (c *ChangeStrategy) Validate() error {
+	switch c.Type {
+	case ChangeStrategySingle, "":
+		return nil
+	case ChangeStrategySplit:
+		if c.Count == 0 {
+			return fmt.Errorf("%w: split count must be greater than zero", ErrInvalidChangeStrategy)
+		}
+		if c.Count > MaxChangeOutputs {
+			return fmt.Errorf("%w: split count %d exceeds %d", ErrInvalidChangeStrategy, c.Count, MaxChangeOutputs)
+		}
+		return nil
+	case ChangeStrategyDenominations:
+		if len(c.Denominations) == 0 {
+			return fmt.Errorf("%w: at least one denomination is required", ErrInvalidChangeStrategy)
+		}
+		if len(c.Denominations) > MaxChangeOutputs {
+			return fmt.Errorf("%w: more than %d denominations", ErrInvalidChangeStrategy, MaxChangeOutputs)
+		}
+		if slices.Contains(c.Denominations, 0) {
+			return fmt.Errorf("%w: denominations must be greater than zero", ErrInvalidChangeStrategy)
+		}
+		return nil
+	default:
+		return fmt.Errorf("%w: unknown type %q", ErrInvalidChangeStrategy, c.Type)
+	}
+}
+
+// Amounts distributes total satoshis of change into output amounts according to the
+// strategy. No returned amount is below minOutput; when the change cannot be split
+// that finely, fewer outputs are produced. Denominations breaking the change into
+// more than MaxChangeOutputs outputs are rejected. A nil strategy behaves as
+// ChangeStrategySingle.
+func (c *ChangeStrategy) Amounts(total, minOutput uint64) ([]uint64, error) {
+	if total == 0 {
+		return nil, nil
+	}
+	if minOutput == 0 {
+		minOutput = 1
+	}
+	if c == nil {
+		return []uint64{total}, nil
+	}
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+
+	switch c.Type {
+	case ChangeStrategySplit:
+		count := uint64(c.Count)
+		if maxCount := total / minOutput; maxCount < count {
+			count = max(maxCount, 1)
+		}
+		amounts := make([]uint64, count)
+		share, remainder := total/count, total%count
+		for i := range amounts {
+			amounts[i] = share
+			if uint64(i) < remainder {
+				amounts[i]++
+			}
+		}
+		return amounts, nil
+
+	case ChangeStrategyDenominations:
+		denominations := slices.Clone(c.Denominations)
+		slices.SortFunc(denominations, func(a, b uint64) int { return cmp.Compare(b, a) })
+
+		var amounts []uint64
+		remaining := total
+		for _, d := range denominations {
+			if d < minOutput {
+				continue
+			}
+			n := remaining / d
+			if n > uint64(MaxChangeOutputs-len(amounts)) {
+				return nil, fmt.Errorf("%w: denominations break %d satoshis into more than %d outputs", ErrInvalidChangeStrategy, total, MaxChangeOutputs)
+			}
+			for range n {
+				amounts = append(amounts, d)
+			}
+			remaining -= n * d
+		}
+		if remaining > 0 {
+			if remaining >= minOutput || len(amounts) == 0 {
+				if len(amounts) == MaxChangeOutputs {
+					return nil, fmt.Errorf("%w: denominations break %d satoshis into more than %d outputs", ErrInvalidChangeStrategy, total, MaxChangeOutputs)
+				}
+				amounts = append(amounts, remaining)
+			} else {
+				// Fold sub-minimum leftovers into the smallest output
+				amounts[len(amounts)-1] += remaining
+			}
+		}
+		return amounts, nil
+
+	default:
+		return []uint64{total}, nil
+	}
+}
+
+// maxChangeRounds bounds the rounds of Apply growing the change outputs.
+const maxChangeRounds = 8
+
+// Apply replaces the change outputs of tx with outputs shaped by the strategy,
+// sharing the change left after the fee computed by f. The locking scripts of the
+// existing change outputs are reused, and lockingScript is called for each
+// further output. When there is no change left, tx keeps no change outputs and
+// the remainder goes to the miners. A nil strategy behaves as ChangeStrategySingle.
+func (c *ChangeStrategy) Apply(tx *transaction.Transaction, f transaction.FeeModel, minOutput uint64, lockingScript func() (*script.Script, error)) error {
+	if c != nil {
+		if err := c.Validate(); err != nil {
+			return err
+		}
+	}
+	var scripts []*script.Script
+	for _, o := range tx.ChangeOutputs() {
+		scripts = append(scripts, o.LockingScript)
+	}
+	tx.Outputs = slices.DeleteFunc(tx.Outputs, func(o *transaction.TransactionOutput) bool { return o.Change })
+	base := len(tx.Outputs)
+
+	// setChange sizes tx to count change outputs and returns the change left
+	// after the fee.
+	setChange := func(count int) (uint64, error) {
+		for len(scripts) < count {
+			if lockingScript == nil {
+				return 0, fmt.Errorf("%w: no locking script for change output %d", ErrInvalidChangeStrategy, len(scripts))
+			}
+			s, err := lockingScript()
+			if err != nil {
+				return 0, err
+			}
+			scripts = append(scripts, s)
+		}
+		tx.Outputs = tx.Outputs[:base]
+		for i := range count {
+			tx.AddOutput(&transaction.TransactionOutput{LockingScript: scripts[i], Change: true})
+		}
+		fee, err := f.ComputeFee(tx)
+		if err != nil {
+			return 0, err
+		}
+		// The change outputs are still empty, so the fee paid is the change
+		// available before the fee.
+		available, err := tx.GetFee()
+		if err != nil {
+			return 0, err
+		}
+		if available < fee {
+			return 0, transaction.ErrInsufficientInputs
+		}
+		return available - fee, nil
+	}
+
+	count := 1
+	for range maxChangeRounds {
+		change, err := setChange(count)
+		if err != nil {
+			return err
+		}
+		amounts, err := c.Amounts(change, minOutput)
+		if err != nil {
+			return err
+		}
+		if len(amounts) > count {
+			// More outputs raise the fee, so the change is shared again.
+			count = len(amounts)
+			continue
+		}
+		if len(amounts) < count {
+			// Fewer outputs lower the fee, and the saving goes to the last output.
+			if change, err = setChange(len(amounts)); err != nil {
+				return err
+			}
+			if len(amounts) > 0 {
+				var shared uint64
+				for _, a := range amounts {
+					shared += a
+				}
+				amounts[len(amounts)-1] += change - shared
+			}
+		}
+		for i, o := range tx.Outputs[base:] {
+			o.Satoshis = amounts[i]
+		}
+		return nil
+	}
+	return fmt.Errorf("%w: change outputs did not settle after %d rounds", ErrInvalidChangeStrategy, maxChangeRounds)
+}
@@ -0,0 +1,168 @@
+package wallet
+
+import (
+	"testing"
+
+	"github.com/bsv-blockchain/go-sdk/chainhash"
+	"github.com/bsv-blockchain/go-sdk/script"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+	feemodel "github.com/bsv-blockchain/go-sdk/transaction/fee_model"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChangeStrategyAmounts(t *testing.T) {
+	tests := []struct {
+		name      string
+		strategy  *ChangeStrategy
+		total     uint64
+		minOutput uint64
+		expected  []uint64
+	}{
+		{
+			name:     "nil strategy is single output",
+			total:    5000,
+			expected: []uint64{5000},
+		},
+		{
+			name:     "single",
+			strategy: &ChangeStrategy{Type: ChangeStrategySingle},
+			total:    5000,
+			expected: []uint64{5000},
+		},
+		{
+			name:     "split evenly with remainder",
+			strategy: &ChangeStrategy{Type: ChangeStrategySplit, Count: 3},
+			total:    1000,
+			expected: []uint64{334, 333, 333},
+		},
+		{
+			name:      "split reduced by minimum output",
+			strategy:  &ChangeStrategy{Type: ChangeStrategySplit, Count: 10},
+			total:     1000,
+			minOutput: 400,
+			expected:  []uint64{500, 500},
+		},
+		{
+			name:     "denominations with leftover output",
+			strategy: &ChangeStrategy{Type: ChangeStrategyDenominations, Denominations: []uint64{100, 1000}},
+			total:    2345,
+			expected: []uint64{1000, 1000, 100, 100, 100, 45},
+		},
+		{
+			name:      "denominations fold leftover below minimum",
+			strategy:  &ChangeStrategy{Type: ChangeStrategyDenominations, Denominations: []uint64{1000}},
+			total:     2010,
+			minOutput: 50,
+			expected:  []uint64{1000, 1010},
+		},
+		{
+			name:     "zero change",
+			strategy: &ChangeStrategy{Type: ChangeStrategySplit, Count: 2},
+			total:    0,
+			expected: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			amounts, err := tt.strategy.Amounts(tt.total, tt.minOutput)
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, amounts)
+		})
+	}
+}
+
+func TestChangeStrategyValidate(t *testing.T) {
+	require.ErrorIs(t, (&ChangeStrategy{Type: ChangeStrategySplit}).Validate(), ErrInvalidChangeStrategy)
+	require.ErrorIs(t, (&ChangeStrategy{Type: ChangeStrategyDenominations}).Validate(), ErrInvalidChangeStrategy)
+	require.ErrorIs(t, (&ChangeStrategy{Type: ChangeStrategyDenominations, Denominations: []uint64{0}}).Validate(), ErrInvalidChangeStrategy)
+	require.ErrorIs(t, (&ChangeStrategy{Type: "bogus"}).Validate(), ErrInvalidChangeStrategy)
+	require.NoError(t, (&ChangeStrategy{}).Validate())
+
+	require.ErrorIs(t, (&ChangeStrategy{Type: ChangeStrategySplit, Count: MaxChangeOutputs + 1}).Validate(), ErrInvalidChangeStrategy)
+	require.NoError(t, (&ChangeStrategy{Type: ChangeStrategySplit, Count: MaxChangeOutputs}).Validate())
+	tooMany := make([]uint64, MaxChangeOutputs+1)
+	for i := range tooMany {
+		tooMany[i] = uint64(i + 1)
+	}
+	require.ErrorIs(t, (&ChangeStrategy{Type: ChangeStrategyDenominations, Denominations: tooMany}).Validate(), ErrInvalidChangeStrategy)
+}
+
+func TestChangeStrategyMaxOutputs(t *testing.T) {
+	// One satoshi denominations would otherwise break the change into 21e14 outputs.
+	strategy := &ChangeStrategy{Type: ChangeStrategyDenominations, Denominations: []uint64{1}}
+	_, err := strategy.Amounts(2_100_000_000_000_000, 1)
+	require.ErrorIs(t, err, ErrInvalidChangeStrategy)
+
+	amounts, err := strategy.Amounts(MaxChangeOutputs, 1)
+	require.NoError(t, err)
+	require.Len(t, amounts, MaxChangeOutputs)
+
+	// A leftover output past the limit is rejected too.
+	strategy.Denominations = []uint64{2}
+	_, err = strategy.Amounts(2*MaxChangeOutputs+1, 1)
+	require.ErrorIs(t, err, ErrInvalidChangeStrategy)
+}
+
+func TestChangeStrategyApply(t *testing.T) {
+	fees := &feemodel.SatoshisPerKilobyte{Satoshis: 1000}
+	newTx := func(input uint64) *transaction.Transaction {
+		tx := transaction.NewTransaction()
+		in := &transaction.TransactionInput{
+			SourceTXID:      &chainhash.Hash{1},
+			UnlockingScript: &script.Script{script.OpTRUE},
+			SequenceNumber:  0xffffffff,
+		}
+		in.SetSourceTxOutput(&transaction.TransactionOutput{Satoshis: input, LockingScript: &script.Script{script.OpTRUE}})
+		tx.AddInput(in)
+		tx.AddOutput(&transaction.TransactionOutput{Satoshis: 1000, LockingScript: &script.Script{script.OpTRUE}})
+		tx.AddOutput(&transaction.TransactionOutput{LockingScript: &script.Script{script.OpFALSE}, Change: true})
+		return tx
+	}
+	scripts := 0
+	lockingScript := func() (*script.Script, error) {
+		scripts++
+		return &script.Script{script.OpFALSE, script.Op1}, nil
+	}
+	requireSettled := func(t *testing.T, tx *transaction.Transaction) {
+		fee, err := fees.ComputeFee(tx)
+		require.NoError(t, err)
+		paid, err := tx.GetFee()
+		require.NoError(t, err)
+		require.Equal(t, fee, paid)
+	}
+
+	tx := newTx(100_000)
+	require.NoError(t, (&ChangeStrategy{Type: ChangeStrategySplit, Count: 3}).Apply(tx, fees, 1, lockingScript))
+	require.Len(t, tx.Outputs, 4)
+	require.Equal(t, 2, scripts)
+	require.Equal(t, script.Script{script.OpFALSE}, *tx.Outputs[1].LockingScript, "existing change scripts are reused")
+	for _, o := range tx.Outputs[1:] {
+		require.True(t, o.Change)
+		require.InDelta(t, tx.Outputs[1].Satoshis, o.Satoshis, 1)
+	}
+	requireSettled(t, tx)
+
+	tx = newTx(4_500)
+	require.NoError(t, (&ChangeStrategy{Type: ChangeStrategyDenominations, Denominations: []uint64{1000}}).Apply(tx, fees, 100, lockingScript))
+	var amounts []uint64
+	for _, o := range tx.Outputs[1:] {
+		amounts = append(amounts, o.Satoshis)
+	}
+	require.Equal(t, []uint64{1000, 1000, 500}, amounts)
+	requireSettled(t, tx)
+
+	tx = newTx(50_000)
+	var single *ChangeStrategy
+	require.NoError(t, single.Apply(tx, fees, 1, nil))
+	require.Len(t, tx.Outputs, 2)
+	requireSettled(t, tx)
+
+	// No change is left, so the change outputs are dropped.
+	tx = newTx(2_000)
+	require.NoError(t, single.Apply(tx, fees, 1, nil))
+	require.Len(t, tx.Outputs, 1)
+
+	require.ErrorIs(t, single.Apply(newTx(1_999), fees, 1, nil), transaction.ErrInsufficientInputs)
+	require.ErrorIs(t, (&ChangeStrategy{Type: ChangeStrategySplit, Count: 2}).Apply(newTx(100_000), fees, 1, nil), ErrInvalidChangeStrategy)
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/bsv-blockchain/go-sdk/chainhash"
 	ec "github.com/bsv-blockchain/go-sdk/primitives/ec"
 	"github.com/bsv-blockchain/go-sdk/transaction"
+	"github.com/bsv-blockchain/go-sdk/wallet/certtypes"
 )
 
 type PublicKeyGetter interface {
@@ -124,7 +125,7 @@ type Certificate struct {
 
 // CreateActionInput represents an input to be spent in a transaction
 type CreateActionInput struct {
-	Outpoint              transaction.Outpoint `json:"outpoint"` // Format: "txid:outputIndex"
+	Outpoint              transaction.Outpoint `json:"outpoint"` // Format: "txid.index"
 	InputDescription      string               `json:"inputDescription"`
 	UnlockingScript       []byte               `json:"unlockingScript,omitempty"`
 	UnlockingScriptLength uint32               `json:"unlockingScriptLength,omitempty"`
@@ -160,6 +161,7 @@ type CreateActionOptions struct {
 	NoSendChange           []transaction.Outpoint
 	SendWith               []chainhash.Hash
 	RandomizeOutputs       *bool
+	ChangeStrategy         *ChangeStrategy
 }
 
 // CreateActionArgs contains all data needed to create a new transaction
@@ -315,12 +317,19 @@ type ListActionsArgs struct {
 	Limit                            *uint32   `json:"limit,omitempty"` // Default 10, max 10000
 	Offset                           *uint32   `json:"offset,omitempty"`
 	SeekPermission                   *bool     `json:"seekPermission,omitempty"` // Default true
+	// Cursor continues the listing from the NextCursor of a previous result, in
+	// place of Offset. Cursors are opaque and only meaningful to the wallet that
+	// issued them.
+	Cursor string `json:"cursor,omitempty"`
 }
 
 // ListActionsResult contains a paginated list of wallet transactions matching the query.
 type ListActionsResult struct {
 	TotalActions uint32   `json:"totalActions"`
 	Actions      []Action `json:"actions"`
+	// NextCursor fetches the next page when passed as Cursor. It is empty on the
+	// last page and from wallets that only support offset pagination.
+	NextCursor string `json:"nextCursor,omitempty"`
 }
 
 // OutputInclude specifies what additional data to include with output listings.
@@ -342,6 +351,26 @@ func OutputIncludeFromString(s string) (OutputInclude, error) {
 	return "", fmt.Errorf("invalid output include option: %s", s)
 }
 
+// OutputSortOrder specifies the order of output listings.
+type OutputSortOrder string
+
+const (
+	OutputSortSatoshisAscending  OutputSortOrder = "satoshis ascending"
+	OutputSortSatoshisDescending OutputSortOrder = "satoshis descending"
+)
+
+// OutputSortOrderFromString converts a string to an OutputSortOrder with validation.
+// Valid values are "satoshis ascending" and "satoshis descending"; empty keeps
+// the storage order.
+func OutputSortOrderFromString(s string) (OutputSortOrder, error) {
+	so := OutputSortOrder(s)
+	switch so {
+	case "", OutputSortSatoshisAscending, OutputSortSatoshisDescending:
+		return so, nil
+	}
+	return "", fmt.Errorf("invalid output sort order: %s", s)
+}
+
 // ListOutputsArgs defines filtering and options for listing wallet outputs.
 type ListOutputsArgs struct {
 	Basket                    string        `json:"basket"`
@@ -354,6 +383,17 @@ type ListOutputsArgs struct {
 	Limit                     *uint32       `json:"limit,omitempty"` // Default 10, max 10000
 	Offset                    *uint32       `json:"offset,omitempty"`
 	SeekPermission            *bool         `json:"seekPermission,omitempty"` // Default true
+	// MinSatoshis and MaxSatoshis restrict the listing to outputs in the range, inclusive.
+	MinSatoshis *uint64 `json:"minSatoshis,omitempty"`
+	MaxSatoshis *uint64 `json:"maxSatoshis,omitempty"`
+	// ScriptTypes restricts the listing to outputs whose locking script is
+	// classified by script.Script.Type as any of the given types.
+	ScriptTypes []string        `json:"scriptTypes,omitempty"`
+	SortOrder   OutputSortOrder `json:"sortOrder,omitempty"`
+	// Cursor continues the listing from the NextCursor of a previous result, in
+	// place of Offset. Cursors are opaque and only meaningful to the wallet that
+	// issued them.
+	Cursor string `json:"cursor,omitempty"`
 }
 
 // Output represents a wallet UTXO with its metadata
@@ -372,6 +412,9 @@ type ListOutputsResult struct {
 	TotalOutputs uint32   `json:"totalOutputs"`
 	BEEF         []byte   `json:"BEEF,omitempty"`
 	Outputs      []Output `json:"outputs"`
+	// NextCursor fetches the next page when passed as Cursor. It is empty on the
+	// last page and from wallets that only support offset pagination.
+	NextCursor string `json:"nextCursor,omitempty"`
 }
 
 // AbortActionArgs identifies a transaction to abort using its reference string.
@@ -544,6 +587,16 @@ type AcquireCertificateArgs struct {
 	PrivilegedReason    string                `json:"privilegedReason,omitempty"`
 }
 
+// ValidateFields checks the fields of an issuance request against the schema of
+// the certificate type in certtypes.Default. Fields of direct acquisitions are
+// encrypted, so they are not checked.
+func (a *AcquireCertificateArgs) ValidateFields() error {
+	if a.AcquisitionProtocol != AcquisitionProtocolIssuance {
+		return nil
+	}
+	return certtypes.ValidateFields(string(StringBase64FromArray(a.Type)), a.Fields)
+}
+
 // ListCertificatesArgs contains parameters for listing certificates with filtering and pagination.
 type ListCertificatesArgs struct {
 	Certifiers       []*ec.PublicKey   `json:"certifiers"`
@@ -0,0 +1,252 @@
+// Package internalize validates InternalizeAction calls before they reach the
+// storage of a wallet. The pipeline verifies the BEEF of the transaction with
+// SPV, checks that each wallet payment output really pays the key the wallet
+// derives from its BRC-29 remittance, and applies a policy to each basket
+// insertion. Rejections are *Error values naming the stage and output which
+// failed, and wrapping one of the sentinel errors of the package.
+package internalize
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/bsv-blockchain/go-sdk/payments/brc29"
+	"github.com/bsv-blockchain/go-sdk/spv"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+	"github.com/bsv-blockchain/go-sdk/transaction/chaintracker"
+	"github.com/bsv-blockchain/go-sdk/wallet"
+)
+
+// Stage is a step of the validation pipeline.
+type Stage string
+
+const (
+	// StageBeef parses the BEEF and finds the transaction to internalize.
+	StageBeef Stage = "beef"
+	// StageSPV verifies the BEEF at the configured spv.Level.
+	StageSPV Stage = "spv"
+	// StageOutputs checks the output indexes and protocols.
+	StageOutputs Stage = "outputs"
+	// StagePayment checks the key of wallet payment outputs.
+	StagePayment Stage = "payment"
+	// StageBasket applies the BasketPolicy to basket insertions.
+	StageBasket Stage = "basket"
+)
+
+var (
+	ErrInvalidBeef        = errors.New("invalid BEEF")
+	ErrSPV                = errors.New("BEEF failed SPV verification")
+	ErrInvalidOutput      = errors.New("invalid output")
+	ErrInvalidRemittance  = errors.New("invalid remittance")
+	ErrPaymentMismatch    = errors.New("output does not pay the key derived from its remittance")
+	ErrBasketNotPermitted = errors.New("basket insertion not permitted")
+)
+
+// Error is the rejection of an internalization by a stage of the pipeline.
+type Error struct {
+	Stage Stage
+	// OutputIndex is the index of the rejected output in the transaction, or
+	// -1 when the rejection is not about an output.
+	OutputIndex int
+	// Problems lists the problems found by SPV verification.
+	Problems []string
+	Err      error
+}
+
+func (e *Error) Error() string {
+	msg := fmt.Sprintf("internalize %s", e.Stage)
+	if e.OutputIndex >= 0 {
+		msg += fmt.Sprintf(" output %d", e.OutputIndex)
+	}
+	msg += ": " + e.Err.Error()
+	if len(e.Problems) > 0 {
+		msg += ": " + strings.Join(e.Problems, "; ")
+	}
+	return msg
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// BasketPolicy decides whether the originator may insert an output of tx into
+// a basket, returning an error to refuse it.
+type BasketPolicy func(ctx context.Context, originator string, tx *transaction.Transaction, outputIndex uint32, insertion *wallet.BasketInsertion) error
+
+// Limits of basket names and tags, as enforced by BRC-100 wallets.
+const (
+	MaxBasketLength = 300
+	MaxTagLength    = 300
+)
+
+// DefaultBasketPolicy refuses basket insertions with names or tags wallets
+// would reject: empty or longer than MaxBasketLength or MaxTagLength bytes,
+// and the default basket and admin baskets, which are reserved for the wallet.
+func DefaultBasketPolicy(_ context.Context, _ string, _ *transaction.Transaction, _ uint32, insertion *wallet.BasketInsertion) error {
+	basket := insertion.Basket
+	switch {
+	case basket == "":
+		return errors.New("basket is required")
+	case len(basket) > MaxBasketLength:
+		return fmt.Errorf("basket is longer than %d bytes", MaxBasketLength)
+	case strings.TrimSpace(basket) != basket:
+		return fmt.Errorf("basket %q has leading or trailing spaces", basket)
+	case basket == "default":
+		return errors.New("the default basket is reserved for change")
+	case strings.HasPrefix(basket, "admin"):
+		return fmt.Errorf("basket %q is reserved for the wallet", basket)
+	}
+	for _, tag := range insertion.Tags {
+		if tag == "" || len(tag) > MaxTagLength {
+			return fmt.Errorf("tags must be between 1 and %d bytes", MaxTagLength)
+		}
+	}
+	return nil
+}
+
+// Config configures a Validator.
+type Config struct {
+	// Level is how thoroughly the BEEF is verified. LevelScriptsAndProofs,
+	// which checks the merkle roots, requires ChainTracker.
+	Level        spv.Level
+	ChainTracker chaintracker.ChainTracker
+	// BasketPolicy is DefaultBasketPolicy when nil.
+	BasketPolicy BasketPolicy
+}
+
+// Validator runs the validation pipeline for the wallet whose keys derive the
+// payment keys.
+type Validator struct {
+	keys   wallet.KeyOperations
+	config Config
+}
+
+// NewValidator creates a Validator deriving payment keys with keys.
+func NewValidator(keys wallet.KeyOperations, config Config) (*Validator, error) {
+	if keys == nil {
+		return nil, errors.New("key operations are required")
+	}
+	if config.Level >= spv.LevelScriptsAndProofs && config.ChainTracker == nil {
+		return nil, fmt.Errorf("a chain tracker is required to verify at level %s", config.Level)
+	}
+	if config.BasketPolicy == nil {
+		config.BasketPolicy = DefaultBasketPolicy
+	}
+	return &Validator{keys: keys, config: config}, nil
+}
+
+// Validate runs the pipeline on args and returns the transaction they
+// internalize, or the *Error of the first stage rejecting them.
+func (v *Validator) Validate(ctx context.Context, args wallet.InternalizeActionArgs, originator string) (*transaction.Transaction, error) {
+	beef, tx, err := parseBeef(args.Tx)
+	if err != nil {
+		return nil, &Error{Stage: StageBeef, OutputIndex: -1, Err: fmt.Errorf("%w: %w", ErrInvalidBeef, err)}
+	}
+
+	report, err := spv.VerifyBeef(ctx, beef, v.config.ChainTracker, v.config.Level)
+	if err != nil {
+		return nil, fmt.Errorf("failed to verify BEEF: %w", err)
+	}
+	if !report.Valid {
+		return nil, &Error{Stage: StageSPV, OutputIndex: -1, Problems: report.Problems, Err: ErrSPV}
+	}
+
+	if len(args.Outputs) == 0 {
+		return nil, &Error{Stage: StageOutputs, OutputIndex: -1, Err: fmt.Errorf("%w: no outputs to internalize", ErrInvalidOutput)}
+	}
+	seen := make(map[uint32]bool, len(args.Outputs))
+	for _, output := range args.Outputs {
+		index := int(output.OutputIndex)
+		if index >= len(tx.Outputs) {
+			return nil, &Error{Stage: StageOutputs, OutputIndex: index, Err: fmt.Errorf("%w: transaction has %d outputs", ErrInvalidOutput, len(tx.Outputs))}
+		}
+		if seen[output.OutputIndex] {
+			return nil, &Error{Stage: StageOutputs, OutputIndex: index, Err: fmt.Errorf("%w: internalized twice", ErrInvalidOutput)}
+		}
+		seen[output.OutputIndex] = true
+
+		switch output.Protocol {
+		case wallet.InternalizeProtocolWalletPayment:
+			if err = v.checkPayment(ctx, tx, output, originator); err != nil {
+				return nil, &Error{Stage: StagePayment, OutputIndex: index, Err: err}
+			}
+		case wallet.InternalizeProtocolBasketInsertion:
+			if output.InsertionRemittance == nil {
+				return nil, &Error{Stage: StageBasket, OutputIndex: index, Err: fmt.Errorf("%w: missing insertion remittance", ErrInvalidRemittance)}
+			}
+			if err = v.config.BasketPolicy(ctx, originator, tx, output.OutputIndex, output.InsertionRemittance); err != nil {
+				return nil, &Error{Stage: StageBasket, OutputIndex: index, Err: fmt.Errorf("%w: %w", ErrBasketNotPermitted, err)}
+			}
+		default:
+			return nil, &Error{Stage: StageOutputs, OutputIndex: index, Err: fmt.Errorf("%w: unknown protocol %q", ErrInvalidOutput, output.Protocol)}
+		}
+	}
+	return tx, nil
+}
+
+// checkPayment checks that the output pays the key derived from its BRC-29
+// remittance.
+func (v *Validator) checkPayment(ctx context.Context, tx *transaction.Transaction, output wallet.InternalizeOutput, originator string) error {
+	if output.PaymentRemittance == nil {
+		return fmt.Errorf("%w: missing payment remittance", ErrInvalidRemittance)
+	}
+	expected, err := brc29.ReceiverLockingScript(ctx, v.keys, output.PaymentRemittance, originator)
+	if errors.Is(err, brc29.ErrInvalidRemittance) {
+		return fmt.Errorf("%w: %w", ErrInvalidRemittance, err)
+	} else if err != nil {
+		return err
+	}
+	lockingScript := tx.Outputs[output.OutputIndex].LockingScript
+	if lockingScript == nil || !bytes.Equal(lockingScript.Bytes(), expected.Bytes()) {
+		return ErrPaymentMismatch
+	}
+	return nil
+}
+
+// parseBeef returns the BEEF of data and the transaction it internalizes: the
+// subject of an Atomic BEEF, or else the last transaction of the BEEF.
+func parseBeef(data []byte) (*transaction.Beef, *transaction.Transaction, error) {
+	beef, tx, _, err := transaction.ParseBeef(data)
+	if err != nil {
+		return nil, nil, err
+	}
+	if tx == nil {
+		if tx, err = transaction.NewTransactionFromBEEF(data); err != nil {
+			return nil, nil, err
+		}
+	}
+	if tx == nil {
+		return nil, nil, errors.New("no transaction to internalize")
+	}
+	return beef, tx, nil
+}
+
+// Wallet validates the InternalizeAction calls of the wallet it wraps before
+// forwarding them. Other calls are forwarded unchanged.
+type Wallet struct {
+	wallet.Interface
+	validator *Validator
+}
+
+var _ wallet.Interface = (*Wallet)(nil)
+
+// NewWallet wraps w, whose keys derive the payment keys.
+func NewWallet(w wallet.Interface, config Config) (*Wallet, error) {
+	validator, err := NewValidator(w, config)
+	if err != nil {
+		return nil, err
+	}
+	return &Wallet{Interface: w, validator: validator}, nil
+}
+
+// InternalizeAction forwards args to the wrapped wallet once the pipeline
+// accepts them.
+func (w *Wallet) InternalizeAction(ctx context.Context, args wallet.InternalizeActionArgs, originator string) (*wallet.InternalizeActionResult, error) {
+	if _, err := w.validator.Validate(ctx, args, originator); err != nil {
+		return nil, err
+	}
+	return w.Interface.InternalizeAction(ctx, args, originator)
+}
@@ -0,0 +1,183 @@
+package internalize
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/bsv-blockchain/go-sdk/chainhash"
+	"github.com/bsv-blockchain/go-sdk/payments/brc29"
+	"github.com/bsv-blockchain/go-sdk/script"
+	"github.com/bsv-blockchain/go-sdk/spv"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+	"github.com/bsv-blockchain/go-sdk/wallet"
+	"github.com/stretchr/testify/require"
+)
+
+// payment returns the Atomic BEEF of a transaction whose first output pays
+// recipient with BRC-29 and whose second output is a token, and the payment
+// remittance. When proven is false, the source of its input is unproven.
+func payment(t *testing.T, recipient *wallet.TestWallet, proven bool) ([]byte, *wallet.Payment) {
+	t.Helper()
+	identity, err := recipient.GetPublicKey(t.Context(), wallet.GetPublicKeyArgs{IdentityKey: true}, "")
+	require.NoError(t, err)
+	p, err := brc29.NewPayment(t.Context(), wallet.NewTestWalletForRandomKey(t), identity.PublicKey, "")
+	require.NoError(t, err)
+
+	source := transaction.NewTransaction()
+	source.AddInput(&transaction.TransactionInput{
+		SourceTXID:      &chainhash.Hash{1},
+		UnlockingScript: &script.Script{script.OpTRUE},
+		SequenceNumber:  0xffffffff,
+	})
+	source.AddOutput(&transaction.TransactionOutput{Satoshis: 2000, LockingScript: &script.Script{script.OpTRUE}})
+	if proven {
+		isTxid := true
+		source.MerklePath = &transaction.MerklePath{BlockHeight: 100, Path: [][]*transaction.PathElement{{
+			{Offset: 0, Hash: source.TxID(), Txid: &isTxid},
+		}}}
+	}
+
+	tx := transaction.NewTransaction()
+	tx.AddInput(&transaction.TransactionInput{
+		SourceTXID:        source.TxID(),
+		SourceTransaction: source,
+		UnlockingScript:   &script.Script{script.OpTRUE},
+		SequenceNumber:    0xffffffff,
+	})
+	tx.AddOutput(&transaction.TransactionOutput{Satoshis: 1000, LockingScript: p.LockingScript})
+	tx.AddOutput(&transaction.TransactionOutput{Satoshis: 1, LockingScript: &script.Script{script.OpTRUE}})
+	beef, err := tx.AtomicBEEF(true)
+	require.NoError(t, err)
+	return beef, &p.Remittance
+}
+
+func paymentArgs(beef []byte, remittance *wallet.Payment) wallet.InternalizeActionArgs {
+	return brc29.InternalizeArgs(beef, 0, remittance, "payment")
+}
+
+func requireRejected(t *testing.T, err error, stage Stage, outputIndex int, target error) {
+	t.Helper()
+	var rejection *Error
+	require.ErrorAs(t, err, &rejection)
+	require.Equal(t, stage, rejection.Stage)
+	require.Equal(t, outputIndex, rejection.OutputIndex)
+	require.ErrorIs(t, err, target)
+}
+
+func TestValidator(t *testing.T) {
+	ctx := t.Context()
+	recipient := wallet.NewTestWalletForRandomKey(t)
+	v, err := NewValidator(recipient, Config{Level: spv.LevelStructure})
+	require.NoError(t, err)
+	beef, remittance := payment(t, recipient, true)
+
+	t.Run("accepts payments and insertions", func(t *testing.T) {
+		args := paymentArgs(beef, remittance)
+		args.Outputs = append(args.Outputs, wallet.InternalizeOutput{
+			OutputIndex:         1,
+			Protocol:            wallet.InternalizeProtocolBasketInsertion,
+			InsertionRemittance: &wallet.BasketInsertion{Basket: "tokens", Tags: []string{"ticket"}},
+		})
+		tx, err := v.Validate(ctx, args, "app.com")
+		require.NoError(t, err)
+		require.Len(t, tx.Outputs, 2)
+	})
+
+	t.Run("rejects invalid BEEF", func(t *testing.T) {
+		_, err := v.Validate(ctx, paymentArgs([]byte{1, 2, 3}, remittance), "")
+		requireRejected(t, err, StageBeef, -1, ErrInvalidBeef)
+	})
+
+	t.Run("rejects unproven BEEF", func(t *testing.T) {
+		unproven, remittance := payment(t, recipient, false)
+		_, err := v.Validate(ctx, paymentArgs(unproven, remittance), "")
+		requireRejected(t, err, StageSPV, -1, ErrSPV)
+		require.NotEmpty(t, err.(*Error).Problems)
+	})
+
+	t.Run("rejects payments to other keys", func(t *testing.T) {
+		other := wallet.NewTestWalletForRandomKey(t)
+		otherBeef, otherRemittance := payment(t, other, true)
+		_, err := v.Validate(ctx, paymentArgs(otherBeef, otherRemittance), "")
+		requireRejected(t, err, StagePayment, 0, ErrPaymentMismatch)
+
+		args := paymentArgs(beef, remittance)
+		args.Outputs[0].OutputIndex = 1
+		_, err = v.Validate(ctx, args, "")
+		requireRejected(t, err, StagePayment, 1, ErrPaymentMismatch)
+
+		_, err = v.Validate(ctx, paymentArgs(beef, &wallet.Payment{SenderIdentityKey: remittance.SenderIdentityKey}), "")
+		requireRejected(t, err, StagePayment, 0, ErrInvalidRemittance)
+	})
+
+	t.Run("rejects invalid outputs", func(t *testing.T) {
+		args := paymentArgs(beef, remittance)
+		args.Outputs[0].OutputIndex = 2
+		_, err := v.Validate(ctx, args, "")
+		requireRejected(t, err, StageOutputs, 2, ErrInvalidOutput)
+
+		args = paymentArgs(beef, remittance)
+		args.Outputs = append(args.Outputs, args.Outputs[0])
+		_, err = v.Validate(ctx, args, "")
+		requireRejected(t, err, StageOutputs, 0, ErrInvalidOutput)
+
+		args.Outputs = nil
+		_, err = v.Validate(ctx, args, "")
+		requireRejected(t, err, StageOutputs, -1, ErrInvalidOutput)
+	})
+
+	t.Run("applies the basket policy", func(t *testing.T) {
+		for _, basket := range []string{"", "default", "admin tokens", " tokens"} {
+			_, err := v.Validate(ctx, wallet.InternalizeActionArgs{Tx: beef, Outputs: []wallet.InternalizeOutput{{
+				OutputIndex:         1,
+				Protocol:            wallet.InternalizeProtocolBasketInsertion,
+				InsertionRemittance: &wallet.BasketInsertion{Basket: basket},
+			}}}, "")
+			requireRejected(t, err, StageBasket, 1, ErrBasketNotPermitted)
+		}
+
+		strict, err := NewValidator(recipient, Config{BasketPolicy: func(_ context.Context, originator string, _ *transaction.Transaction, _ uint32, _ *wallet.BasketInsertion) error {
+			if originator != "tokens.app" {
+				return errors.New("only tokens.app may insert")
+			}
+			return nil
+		}})
+		require.NoError(t, err)
+		args := wallet.InternalizeActionArgs{Tx: beef, Outputs: []wallet.InternalizeOutput{{
+			OutputIndex:         1,
+			Protocol:            wallet.InternalizeProtocolBasketInsertion,
+			InsertionRemittance: &wallet.BasketInsertion{Basket: "tokens"},
+		}}}
+		_, err = strict.Validate(ctx, args, "other.app")
+		requireRejected(t, err, StageBasket, 1, ErrBasketNotPermitted)
+		_, err = strict.Validate(ctx, args, "tokens.app")
+		require.NoError(t, err)
+	})
+}
+
+func TestWallet(t *testing.T) {
+	recipient := wallet.NewTestWalletForRandomKey(t)
+	internalized := 0
+	recipient.OnInternalizeAction().Do(func(context.Context, wallet.InternalizeActionArgs, string) (*wallet.InternalizeActionResult, error) {
+		internalized++
+		return &wallet.InternalizeActionResult{Accepted: true}, nil
+	})
+	w, err := NewWallet(recipient, Config{})
+	require.NoError(t, err)
+
+	beef, remittance := payment(t, recipient, true)
+	result, err := w.InternalizeAction(t.Context(), paymentArgs(beef, remittance), "")
+	require.NoError(t, err)
+	require.True(t, result.Accepted)
+
+	other, otherRemittance := payment(t, wallet.NewTestWalletForRandomKey(t), true)
+	_, err = w.InternalizeAction(t.Context(), paymentArgs(other, otherRemittance), "")
+	require.ErrorIs(t, err, ErrPaymentMismatch)
+	require.Equal(t, 1, internalized)
+
+	_, err = NewWallet(recipient, Config{Level: spv.LevelScriptsAndProofs})
+	require.Error(t, err)
+	_, err = NewValidator(nil, Config{})
+	require.Error(t, err)
+}
@@ -10,29 +10,62 @@ import (
 	"strings"
 
 	ec "github.com/bsv-blockchain/go-sdk/primitives/ec"
+	"github.com/bsv-blockchain/go-sdk/primitives/secure"
 )
 
-type keyDeriverInterface interface {
+// KeyDeriverInterface is the key derivation backend used by ProtoWallet. KeyDeriver
+// implements it with an in-memory root key; alternate implementations, such as ones
+// backed by an HSM or a remote KMS, can be plugged in with ProtoWalletArgsTypeKeyDeriver.
+// Implementations must be safe for concurrent use.
+type KeyDeriverInterface interface {
+	// IdentityKey returns the public key of the root key.
+	IdentityKey() *ec.PublicKey
 	DerivePrivateKey(protocol Protocol, keyID string, counterparty Counterparty) (*ec.PrivateKey, error)
 	DerivePublicKey(protocol Protocol, keyID string, counterparty Counterparty, forSelf bool) (*ec.PublicKey, error)
 	DeriveSymmetricKey(protocol Protocol, keyID string, counterparty Counterparty) (*ec.SymmetricKey, error)
 	RevealSpecificSecret(counterparty Counterparty, protocol Protocol, keyID string) ([]byte, error)
+	RevealCounterpartySecret(counterparty Counterparty) (*ec.PublicKey, error)
 }
 
+// rootKeyHolder is implemented by key derivers holding their root key in memory.
+// Revealing counterparty key linkage needs the root key to prove the linkage, so it
+// is only supported by such derivers.
+type rootKeyHolder interface {
+	rootPrivateKey() *ec.PrivateKey
+}
+
+var _ KeyDeriverInterface = (*KeyDeriver)(nil)
+
 // KeyDeriver is responsible for deriving various types of keys using a root private key.
 // It supports deriving public and private keys, symmetric keys, and revealing key linkages.
 type KeyDeriver struct {
 	rootKey *ec.PrivateKey
+	// secrets optionally caches the shared secrets with counterparties.
+	secrets *sharedSecretCache
 }
 
 func (kd *KeyDeriver) IdentityKey() *ec.PublicKey {
 	return kd.rootKey.PubKey()
 }
 
+func (kd *KeyDeriver) rootPrivateKey() *ec.PrivateKey {
+	return kd.rootKey
+}
+
 func (kd *KeyDeriver) IdentityKeyHex() string {
 	return kd.IdentityKey().ToDERHex()
 }
 
+// Wipe zeroes the root private key, which is shared with the key the KeyDeriver was
+// created from, and drops any cached shared secrets. The KeyDeriver cannot be used
+// afterwards.
+func (kd *KeyDeriver) Wipe() {
+	secure.WipeInt(kd.rootKey.D)
+	if kd.secrets != nil {
+		kd.secrets.clear()
+	}
+}
+
 // NewKeyDeriver creates a new KeyDeriver instance with a root private key.
 // The root key can be either a specific private key or the special 'anyone' key.
 func NewKeyDeriver(privateKey *ec.PrivateKey) *KeyDeriver {
@@ -91,19 +124,15 @@ func (kd *KeyDeriver) DerivePublicKey(protocol Protocol, keyID string, counterpa
 		return nil, fmt.Errorf("failed to compute invoice number: %w", err)
 	}
 
-	if forSelf {
-		privKey, err := kd.rootKey.DeriveChild(counterpartyKey, invoiceNumber)
-		if err != nil {
-			return nil, fmt.Errorf("failed to derive child private key: %w", err)
-		}
-		return privKey.PubKey(), nil
+	sharedSecret, err := kd.sharedSecret(counterpartyKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to derive shared secret: %w", err)
 	}
 
-	pubKey, err := counterpartyKey.DeriveChild(kd.rootKey, invoiceNumber)
-	if err != nil {
-		return nil, fmt.Errorf("failed to derive child public key: %w", err)
+	if forSelf {
+		return kd.childPrivateKey(sharedSecret, invoiceNumber).PubKey(), nil
 	}
-	return pubKey, nil
+	return childPublicKey(counterpartyKey, sharedSecret, invoiceNumber), nil
 }
 
 // DerivePrivateKey creates a private key based on protocol ID, key ID, and counterparty.
@@ -118,11 +147,11 @@ func (kd *KeyDeriver) DerivePrivateKey(protocol Protocol, keyID string, counterp
 		return nil, err
 	}
 
-	k, err := kd.rootKey.DeriveChild(counterpartyKey, invoiceNumber)
+	sharedSecret, err := kd.sharedSecret(counterpartyKey)
 	if err != nil {
 		return nil, fmt.Errorf("failed to derive child key: %w", err)
 	}
-	return k, nil
+	return kd.childPrivateKey(sharedSecret, invoiceNumber), nil
 }
 
 // normalizeCounterparty converts the counterparty parameter into a standard public key format.
@@ -152,7 +181,7 @@ func (kd *KeyDeriver) RevealSpecificSecret(counterparty Counterparty, protocol P
 		return nil, fmt.Errorf("failed to normalize counterparty: %w", err)
 	}
 
-	sharedSecret, err := kd.rootKey.DeriveSharedSecret(counterpartyKey)
+	sharedSecret, err := kd.sharedSecret(counterpartyKey)
 	if err != nil {
 		return nil, fmt.Errorf("failed to derive shared secret: %w", err)
 	}
@@ -196,7 +225,7 @@ func (kd *KeyDeriver) RevealCounterpartySecret(counterparty Counterparty) (*ec.P
 		return nil, errors.New("counterparty secrets cannot be revealed if counterparty key is self")
 	}
 
-	sharedSecret, err := kd.rootKey.DeriveSharedSecret(counterpartyKey)
+	sharedSecret, err := kd.sharedSecret(counterpartyKey)
 	if err != nil {
 		return nil, fmt.Errorf("failed to derive shared secret: %w", err)
 	}
@@ -209,6 +238,13 @@ var regexOnlyLettersNumbersSpaces = regexp.MustCompile(`^[a-z0-9 ]+$`)
 // computeInvoiceNumber generates a unique identifier string based on the protocol and key ID.
 // This string is used as part of the key derivation process to ensure unique keys for different contexts.
 func (kd *KeyDeriver) computeInvoiceNumber(protocol Protocol, keyID string) (string, error) {
+	return ComputeInvoiceNumber(protocol, keyID)
+}
+
+// ComputeInvoiceNumber validates the protocol and key ID and returns the BRC-43 invoice
+// number used for BRC-42 key derivation. It is exported for KeyDeriverInterface
+// implementations that perform the derivation themselves.
+func ComputeInvoiceNumber(protocol Protocol, keyID string) (string, error) {
 	// Validate protocol security level
 	if protocol.SecurityLevel < 0 || protocol.SecurityLevel > 2 {
 		return "", fmt.Errorf("protocol security level must be 0, 1, or 2")
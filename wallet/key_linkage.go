@@ -0,0 +1,189 @@
+package wallet
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	ec "github.com/bsv-blockchain/go-sdk/primitives/ec"
+	"github.com/bsv-blockchain/go-sdk/primitives/schnorr"
+)
+
+// ErrInvalidKeyLinkage is returned when a key linkage revelation fails verification.
+var ErrInvalidKeyLinkage = errors.New("invalid key linkage revelation")
+
+// KeyLinkageProofTypeNone is the proof type of specific key linkage revelations,
+// which carry no proof beyond the encryption by the prover.
+const KeyLinkageProofTypeNone byte = 0
+
+// counterpartyLinkageProofSize is the size of a serialized Schnorr linkage proof:
+// R (33 bytes) || S' (33 bytes) || z (32 bytes).
+const counterpartyLinkageProofSize = 98
+
+// counterpartyLinkageProtocol encrypts counterparty linkage revelations.
+var counterpartyLinkageProtocol = Protocol{SecurityLevel: SecurityLevelEveryAppAndCounterparty, Protocol: "counterparty linkage revelation"}
+
+// specificLinkageProtocol encrypts specific linkage revelations for a protocol.
+func specificLinkageProtocol(protocol Protocol) Protocol {
+	return Protocol{
+		SecurityLevel: SecurityLevelEveryAppAndCounterparty,
+		Protocol:      fmt.Sprintf("specific linkage revelation %d %s", protocol.SecurityLevel, protocol.Protocol),
+	}
+}
+
+func encodeLinkageProof(proof *schnorr.Proof) []byte {
+	b := make([]byte, 0, counterpartyLinkageProofSize)
+	b = append(b, proof.R.Compressed()...)
+	b = append(b, proof.SPrime.Compressed()...)
+	return append(b, padTo32Bytes(proof.Z.Bytes())...)
+}
+
+func decodeLinkageProof(b []byte) (*schnorr.Proof, error) {
+	if len(b) != counterpartyLinkageProofSize {
+		return nil, fmt.Errorf("proof is %d bytes, expected %d", len(b), counterpartyLinkageProofSize)
+	}
+	r, err := ec.PublicKeyFromBytes(b[:33])
+	if err != nil {
+		return nil, fmt.Errorf("invalid proof point R: %w", err)
+	}
+	sPrime, err := ec.PublicKeyFromBytes(b[33:66])
+	if err != nil {
+		return nil, fmt.Errorf("invalid proof point S': %w", err)
+	}
+	return &schnorr.Proof{R: r, SPrime: sPrime, Z: new(big.Int).SetBytes(b[66:])}, nil
+}
+
+// CounterpartyKeyLinkage is a verified counterparty key linkage revelation.
+type CounterpartyKeyLinkage struct {
+	Prover         *ec.PublicKey
+	Counterparty   *ec.PublicKey
+	RevelationTime time.Time
+	// SharedSecret is the ECDH secret of the prover and the counterparty. It links
+	// every key either of them derives for the other.
+	SharedSecret *ec.PublicKey
+}
+
+// ProverKey returns the public key the prover derives for the counterparty under
+// the protocol and key ID.
+func (l *CounterpartyKeyLinkage) ProverKey(protocol Protocol, keyID string) (*ec.PublicKey, error) {
+	invoiceNumber, err := ComputeInvoiceNumber(protocol, keyID)
+	if err != nil {
+		return nil, err
+	}
+	return childPublicKey(l.Prover, l.SharedSecret, invoiceNumber), nil
+}
+
+// CounterpartyKey returns the public key the counterparty derives for the prover
+// under the protocol and key ID.
+func (l *CounterpartyKeyLinkage) CounterpartyKey(protocol Protocol, keyID string) (*ec.PublicKey, error) {
+	invoiceNumber, err := ComputeInvoiceNumber(protocol, keyID)
+	if err != nil {
+		return nil, err
+	}
+	return childPublicKey(l.Counterparty, l.SharedSecret, invoiceNumber), nil
+}
+
+// VerifyCounterpartyKeyLinkage decrypts a counterparty key linkage revelation with
+// the verifier's wallet and checks its Schnorr proof, which shows the prover knows
+// the private key of Prover and computed the shared secret with Counterparty.
+func VerifyCounterpartyKeyLinkage(ctx context.Context, verifier CipherOperations, r *RevealCounterpartyKeyLinkageResult) (*CounterpartyKeyLinkage, error) {
+	if r == nil || r.Prover == nil || r.Counterparty == nil {
+		return nil, fmt.Errorf("%w: prover and counterparty are required", ErrInvalidKeyLinkage)
+	}
+	revelationTime, err := time.Parse(time.RFC3339Nano, r.RevelationTime)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid revelation time: %w", ErrInvalidKeyLinkage, err)
+	}
+	linkage, proofBytes, err := decryptLinkage(ctx, verifier, r.Prover, counterpartyLinkageProtocol, r.RevelationTime, r.EncryptedLinkage, r.EncryptedLinkageProof)
+	if err != nil {
+		return nil, err
+	}
+	sharedSecret, err := ec.PublicKeyFromBytes(linkage)
+	if err != nil {
+		return nil, fmt.Errorf("%w: linkage is not a point: %w", ErrInvalidKeyLinkage, err)
+	}
+	proof, err := decodeLinkageProof(proofBytes)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidKeyLinkage, err)
+	}
+	if !schnorr.New().VerifyProof(r.Prover, r.Counterparty, sharedSecret, proof) {
+		return nil, fmt.Errorf("%w: proof does not verify", ErrInvalidKeyLinkage)
+	}
+	return &CounterpartyKeyLinkage{
+		Prover:         r.Prover,
+		Counterparty:   r.Counterparty,
+		RevelationTime: revelationTime,
+		SharedSecret:   sharedSecret,
+	}, nil
+}
+
+// SpecificKeyLinkage is a verified specific key linkage revelation.
+type SpecificKeyLinkage struct {
+	Prover       *ec.PublicKey
+	Counterparty *ec.PublicKey
+	ProtocolID   Protocol
+	KeyID        string
+	// Linkage is the BRC-42 HMAC offsetting the keys of the protocol and key ID.
+	Linkage []byte
+	// ProverKey is the public key the prover derives for the counterparty under
+	// the protocol and key ID.
+	ProverKey *ec.PublicKey
+}
+
+// VerifySpecificKeyLinkage decrypts a specific key linkage revelation with the
+// verifier's wallet and checks its proof type. Specific revelations carry no proof
+// beyond being encrypted by the prover, so the linkage is only as trustworthy as
+// the prover; comparing ProverKey with keys seen elsewhere ties it to them.
+func VerifySpecificKeyLinkage(ctx context.Context, verifier CipherOperations, r *RevealSpecificKeyLinkageResult) (*SpecificKeyLinkage, error) {
+	if r == nil || r.Prover == nil || r.Counterparty == nil {
+		return nil, fmt.Errorf("%w: prover and counterparty are required", ErrInvalidKeyLinkage)
+	}
+	if r.ProofType != KeyLinkageProofTypeNone {
+		return nil, fmt.Errorf("%w: unsupported proof type %d", ErrInvalidKeyLinkage, r.ProofType)
+	}
+	linkage, proof, err := decryptLinkage(ctx, verifier, r.Prover, specificLinkageProtocol(r.ProtocolID), r.KeyID, r.EncryptedLinkage, r.EncryptedLinkageProof)
+	if err != nil {
+		return nil, err
+	}
+	if !bytes.Equal(proof, []byte{r.ProofType}) {
+		return nil, fmt.Errorf("%w: encrypted proof does not match proof type %d", ErrInvalidKeyLinkage, r.ProofType)
+	}
+	if len(linkage) != 32 {
+		return nil, fmt.Errorf("%w: linkage is %d bytes, expected 32", ErrInvalidKeyLinkage, len(linkage))
+	}
+	curve := ec.S256()
+	x, y := curve.ScalarBaseMult(linkage)
+	x, y = curve.Add(x, y, r.Prover.X, r.Prover.Y)
+	return &SpecificKeyLinkage{
+		Prover:       r.Prover,
+		Counterparty: r.Counterparty,
+		ProtocolID:   r.ProtocolID,
+		KeyID:        r.KeyID,
+		Linkage:      linkage,
+		ProverKey:    &ec.PublicKey{Curve: curve, X: x, Y: y},
+	}, nil
+}
+
+func decryptLinkage(ctx context.Context, verifier CipherOperations, prover *ec.PublicKey, protocol Protocol, keyID string, encryptedLinkage, encryptedProof []byte) (linkage, proof []byte, err error) {
+	args := DecryptArgs{
+		EncryptionArgs: EncryptionArgs{
+			ProtocolID:   protocol,
+			KeyID:        keyID,
+			Counterparty: Counterparty{Type: CounterpartyTypeOther, Counterparty: prover},
+		},
+	}
+	args.Ciphertext = encryptedLinkage
+	linkageResult, err := verifier.Decrypt(ctx, args, "")
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w: failed to decrypt linkage: %w", ErrInvalidKeyLinkage, err)
+	}
+	args.Ciphertext = encryptedProof
+	proofResult, err := verifier.Decrypt(ctx, args, "")
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w: failed to decrypt proof: %w", ErrInvalidKeyLinkage, err)
+	}
+	return linkageResult.Plaintext, proofResult.Plaintext, nil
+}
@@ -0,0 +1,111 @@
+package wallet
+
+import (
+	"context"
+	"testing"
+
+	ec "github.com/bsv-blockchain/go-sdk/primitives/ec"
+	"github.com/stretchr/testify/require"
+)
+
+func newRevealTestWallet(t *testing.T) (*ProtoWallet, *ec.PublicKey) {
+	t.Helper()
+	key, err := ec.NewPrivateKey()
+	require.NoError(t, err)
+	w, err := NewProtoWallet(ProtoWalletArgs{Type: ProtoWalletArgsTypePrivateKey, PrivateKey: key})
+	require.NoError(t, err)
+	return w, key.PubKey()
+}
+
+func forSelfKey(t *testing.T, w *ProtoWallet, counterparty *ec.PublicKey, protocol Protocol, keyID string) *ec.PublicKey {
+	t.Helper()
+	forSelf := true
+	result, err := w.GetPublicKey(context.Background(), GetPublicKeyArgs{
+		EncryptionArgs: EncryptionArgs{
+			ProtocolID:   protocol,
+			KeyID:        keyID,
+			Counterparty: Counterparty{Type: CounterpartyTypeOther, Counterparty: counterparty},
+		},
+		ForSelf: &forSelf,
+	}, "")
+	require.NoError(t, err)
+	return result.PublicKey
+}
+
+func TestVerifyCounterpartyKeyLinkage(t *testing.T) {
+	ctx := context.Background()
+	prover, proverKey := newRevealTestWallet(t)
+	counterparty, counterpartyKey := newRevealTestWallet(t)
+	verifier, verifierKey := newRevealTestWallet(t)
+
+	revelation, err := prover.RevealCounterpartyKeyLinkage(ctx, RevealCounterpartyKeyLinkageArgs{
+		Counterparty: counterpartyKey,
+		Verifier:     verifierKey,
+	}, "")
+	require.NoError(t, err)
+
+	linkage, err := VerifyCounterpartyKeyLinkage(ctx, verifier, revelation)
+	require.NoError(t, err)
+	require.True(t, linkage.Prover.IsEqual(proverKey))
+	require.False(t, linkage.RevelationTime.IsZero())
+
+	// The shared secret links the keys both parties derive for each other.
+	protocol := Protocol{SecurityLevel: SecurityLevelEveryAppAndCounterparty, Protocol: "linkage test"}
+	proverDerived, err := linkage.ProverKey(protocol, "1")
+	require.NoError(t, err)
+	require.True(t, proverDerived.IsEqual(forSelfKey(t, prover, counterpartyKey, protocol, "1")))
+	counterpartyDerived, err := linkage.CounterpartyKey(protocol, "1")
+	require.NoError(t, err)
+	require.True(t, counterpartyDerived.IsEqual(forSelfKey(t, counterparty, proverKey, protocol, "1")))
+
+	t.Run("another verifier cannot decrypt", func(t *testing.T) {
+		other, _ := newRevealTestWallet(t)
+		_, err := VerifyCounterpartyKeyLinkage(ctx, other, revelation)
+		require.ErrorIs(t, err, ErrInvalidKeyLinkage)
+	})
+
+	t.Run("proof is bound to the counterparty", func(t *testing.T) {
+		_, otherKey := newRevealTestWallet(t)
+		tampered := *revelation
+		tampered.Counterparty = otherKey
+		_, err := VerifyCounterpartyKeyLinkage(ctx, verifier, &tampered)
+		require.ErrorIs(t, err, ErrInvalidKeyLinkage)
+		require.ErrorContains(t, err, "proof does not verify")
+	})
+}
+
+func TestVerifySpecificKeyLinkage(t *testing.T) {
+	ctx := context.Background()
+	prover, proverKey := newRevealTestWallet(t)
+	_, counterpartyKey := newRevealTestWallet(t)
+	verifier, verifierKey := newRevealTestWallet(t)
+	protocol := Protocol{SecurityLevel: SecurityLevelEveryAppAndCounterparty, Protocol: "linkage test"}
+
+	revelation, err := prover.RevealSpecificKeyLinkage(ctx, RevealSpecificKeyLinkageArgs{
+		Counterparty: Counterparty{Type: CounterpartyTypeOther, Counterparty: counterpartyKey},
+		Verifier:     verifierKey,
+		ProtocolID:   protocol,
+		KeyID:        "invoice 7",
+	}, "")
+	require.NoError(t, err)
+
+	linkage, err := VerifySpecificKeyLinkage(ctx, verifier, revelation)
+	require.NoError(t, err)
+	require.True(t, linkage.Prover.IsEqual(proverKey))
+	require.Len(t, linkage.Linkage, 32)
+	require.True(t, linkage.ProverKey.IsEqual(forSelfKey(t, prover, counterpartyKey, protocol, "invoice 7")))
+
+	t.Run("unsupported proof type", func(t *testing.T) {
+		tampered := *revelation
+		tampered.ProofType = 1
+		_, err := VerifySpecificKeyLinkage(ctx, verifier, &tampered)
+		require.ErrorIs(t, err, ErrInvalidKeyLinkage)
+	})
+
+	t.Run("revelation is bound to the key ID", func(t *testing.T) {
+		tampered := *revelation
+		tampered.KeyID = "invoice 8"
+		_, err := VerifySpecificKeyLinkage(ctx, verifier, &tampered)
+		require.ErrorIs(t, err, ErrInvalidKeyLinkage)
+	})
+}
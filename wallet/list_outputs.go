@@ -0,0 +1,94 @@
+package wallet
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/bsv-blockchain/go-sdk/primitives/amount"
+	"github.com/bsv-blockchain/go-sdk/script"
+)
+
+// ErrInvalidOutputFilter is returned when the value, script type or sort filters
+// of ListOutputsArgs are malformed.
+var ErrInvalidOutputFilter = errors.New("invalid output filter")
+
+var knownScriptTypes = []string{
+	script.ScriptTypeEmpty,
+	script.ScriptTypePubKey,
+	script.ScriptTypePubKeyHash,
+	script.ScriptTypeScriptHash,
+	script.ScriptTypeMultiSig,
+	script.ScriptTypeNullData,
+	script.ScriptTypeNonStandard,
+}
+
+// ValidateFilters checks the value range, script types and sort order of the args.
+func (args *ListOutputsArgs) ValidateFilters() error {
+	if args.MinSatoshis != nil && args.MaxSatoshis != nil && *args.MinSatoshis > *args.MaxSatoshis {
+		return fmt.Errorf("%w: minSatoshis %d is greater than maxSatoshis %d", ErrInvalidOutputFilter, *args.MinSatoshis, *args.MaxSatoshis)
+	}
+	for _, t := range args.ScriptTypes {
+		if !slices.Contains(knownScriptTypes, t) {
+			return fmt.Errorf("%w: unknown script type %q", ErrInvalidOutputFilter, t)
+		}
+	}
+	if _, err := OutputSortOrderFromString(string(args.SortOrder)); err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidOutputFilter, err)
+	}
+	return nil
+}
+
+// Matches reports whether an output with the given value and locking script passes
+// the value and script type filters of the args. Basket and tag filters are left
+// to the storage. The locking script is needed even when the listing does not
+// include locking scripts.
+func (args *ListOutputsArgs) Matches(satoshis uint64, lockingScript []byte) bool {
+	if args.MinSatoshis != nil && satoshis < *args.MinSatoshis {
+		return false
+	}
+	if args.MaxSatoshis != nil && satoshis > *args.MaxSatoshis {
+		return false
+	}
+	if len(args.ScriptTypes) > 0 {
+		s := script.Script(lockingScript)
+		if !slices.Contains(args.ScriptTypes, s.Type()) {
+			return false
+		}
+	}
+	return true
+}
+
+// SortOutputs orders outputs in place according to SortOrder. Outputs of equal
+// value keep their storage order, as do all outputs when SortOrder is empty.
+func (args *ListOutputsArgs) SortOutputs(outputs []Output) {
+	switch args.SortOrder {
+	case OutputSortSatoshisAscending:
+		slices.SortStableFunc(outputs, func(a, b Output) int {
+			return cmp.Compare(a.Satoshis, b.Satoshis)
+		})
+	case OutputSortSatoshisDescending:
+		slices.SortStableFunc(outputs, func(a, b Output) int {
+			return cmp.Compare(b.Satoshis, a.Satoshis)
+		})
+	}
+}
+
+// Amount returns the satoshis of the output as an amount.
+func (o Output) Amount() amount.Satoshis {
+	return amount.Satoshis(o.Satoshis)
+}
+
+// TotalAmount returns the sum of the outputs of the page, failing with
+// amount.ErrOverflow rather than wrapping around.
+func (r *ListOutputsResult) TotalAmount() (amount.Satoshis, error) {
+	var total amount.Satoshis
+	for _, o := range r.Outputs {
+		var err error
+		if total, err = total.Add(o.Amount()); err != nil {
+			return 0, err
+		}
+	}
+	return total, nil
+}
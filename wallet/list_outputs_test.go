@@ -0,0 +1,77 @@
+package wallet
+
+import (
+	"encoding/hex"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bsv-blockchain/go-sdk/primitives/amount"
+	"github.com/bsv-blockchain/go-sdk/script"
+)
+
+func mustScriptBytes(t *testing.T, s string) []byte {
+	b, err := hex.DecodeString(s)
+	require.NoError(t, err)
+	return b
+}
+
+func TestListOutputsArgsMatches(t *testing.T) {
+	p2pkh := mustScriptBytes(t, "76a91403ececf2d12a7f614aef4c82ecf13c303bd9975d88ac")
+	p2sh := mustScriptBytes(t, "a9149de5aeaff9c48431ba4dd6e8af73d51f38e451cb87")
+	minSats, maxSats := uint64(10000), uint64(50000)
+
+	args := &ListOutputsArgs{
+		MinSatoshis: &minSats,
+		ScriptTypes: []string{script.ScriptTypePubKeyHash},
+	}
+	require.True(t, args.Matches(10000, p2pkh))
+	require.True(t, args.Matches(1_000_000, p2pkh))
+	require.False(t, args.Matches(9999, p2pkh))
+	require.False(t, args.Matches(20000, p2sh))
+
+	args = &ListOutputsArgs{MinSatoshis: &minSats, MaxSatoshis: &maxSats}
+	require.True(t, args.Matches(50000, p2sh))
+	require.False(t, args.Matches(50001, p2sh))
+
+	require.True(t, (&ListOutputsArgs{}).Matches(1, nil))
+}
+
+func TestListOutputsArgsSortOutputs(t *testing.T) {
+	outputs := func() []Output {
+		return []Output{{Satoshis: 20, Tags: []string{"a"}}, {Satoshis: 10}, {Satoshis: 20, Tags: []string{"b"}}}
+	}
+
+	got := outputs()
+	(&ListOutputsArgs{SortOrder: OutputSortSatoshisAscending}).SortOutputs(got)
+	require.Equal(t, []Output{{Satoshis: 10}, {Satoshis: 20, Tags: []string{"a"}}, {Satoshis: 20, Tags: []string{"b"}}}, got)
+
+	got = outputs()
+	(&ListOutputsArgs{SortOrder: OutputSortSatoshisDescending}).SortOutputs(got)
+	require.Equal(t, []Output{{Satoshis: 20, Tags: []string{"a"}}, {Satoshis: 20, Tags: []string{"b"}}, {Satoshis: 10}}, got)
+
+	got = outputs()
+	(&ListOutputsArgs{}).SortOutputs(got)
+	require.Equal(t, outputs(), got)
+}
+
+func TestListOutputsArgsValidateFilters(t *testing.T) {
+	minSats, maxSats := uint64(10), uint64(5)
+	require.NoError(t, (&ListOutputsArgs{ScriptTypes: []string{script.ScriptTypeMultiSig}}).ValidateFilters())
+	require.ErrorIs(t, (&ListOutputsArgs{MinSatoshis: &minSats, MaxSatoshis: &maxSats}).ValidateFilters(), ErrInvalidOutputFilter)
+	require.ErrorIs(t, (&ListOutputsArgs{ScriptTypes: []string{"p2pkh"}}).ValidateFilters(), ErrInvalidOutputFilter)
+	require.ErrorIs(t, (&ListOutputsArgs{SortOrder: "newest"}).ValidateFilters(), ErrInvalidOutputFilter)
+}
+
+func TestListOutputsResultTotalAmount(t *testing.T) {
+	result := &ListOutputsResult{Outputs: []Output{{Satoshis: 1500}, {Satoshis: 250}}}
+	total, err := result.TotalAmount()
+	require.NoError(t, err)
+	require.Equal(t, amount.Satoshis(1750), total)
+	require.Equal(t, amount.Satoshis(250), result.Outputs[1].Amount())
+
+	result.Outputs = append(result.Outputs, Output{Satoshis: math.MaxUint64})
+	_, err = result.TotalAmount()
+	require.ErrorIs(t, err, amount.ErrOverflow)
+}
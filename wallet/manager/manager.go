@@ -0,0 +1,479 @@
+// Package manager hosts several wallet profiles, each its own identity with a
+// separate root key, behind a single wallet.Interface, like the CWI wallet
+// manager of the TypeScript SDK.
+package manager
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	ec "github.com/bsv-blockchain/go-sdk/primitives/ec"
+	"github.com/bsv-blockchain/go-sdk/wallet"
+)
+
+// DefaultProfileID is the ID of the profile of the root key the Manager is
+// created with, which cannot be deleted.
+const DefaultProfileID = "default"
+
+var (
+	ErrProfileNotFound = errors.New("profile not found")
+	ErrProfileExists   = errors.New("profile with this identity key already exists")
+	ErrDefaultProfile  = errors.New("the default profile cannot be deleted")
+)
+
+// WalletFactory creates the wallet of a profile from its root key. Wallets of
+// all profiles are typically created over the same storage, which keeps their
+// data apart by identity key.
+type WalletFactory func(ctx context.Context, profile Profile, rootKey *ec.PrivateKey) (wallet.Interface, error)
+
+// Profile describes an identity hosted by the Manager.
+type Profile struct {
+	ID          string
+	Name        string
+	IdentityKey *ec.PublicKey
+	CreatedAt   time.Time
+}
+
+type profile struct {
+	Profile
+	rootKey *ec.PrivateKey
+	wallet  wallet.Interface
+}
+
+// Manager is a wallet.Interface forwarding every call to the wallet of one of
+// its profiles: the profile bound to the originator of the call with
+// BindOriginator, or else the active profile chosen with SwitchProfile.
+// Profile wallets are created by the WalletFactory on first use.
+type Manager struct {
+	factory WalletFactory
+
+	mu          sync.Mutex
+	profiles    map[string]*profile
+	order       []string
+	active      string
+	originators map[string]string
+	now         func() time.Time
+}
+
+var _ wallet.Interface = (*Manager)(nil)
+var _ wallet.AdminInterface = (*Manager)(nil)
+
+// NewManager creates a Manager with the default profile of rootKey, which is
+// active.
+func NewManager(factory WalletFactory, rootKey *ec.PrivateKey) (*Manager, error) {
+	if factory == nil {
+		return nil, errors.New("wallet factory is required")
+	}
+	if rootKey == nil {
+		return nil, errors.New("root key is required")
+	}
+	m := &Manager{
+		factory:     factory,
+		profiles:    make(map[string]*profile),
+		active:      DefaultProfileID,
+		originators: make(map[string]string),
+		now:         time.Now,
+	}
+	m.insert(DefaultProfileID, "Default", rootKey)
+	return m, nil
+}
+
+func (m *Manager) insert(id, name string, rootKey *ec.PrivateKey) *profile {
+	p := &profile{
+		Profile: Profile{
+			ID:          id,
+			Name:        name,
+			IdentityKey: rootKey.PubKey(),
+			CreatedAt:   m.now(),
+		},
+		rootKey: rootKey,
+	}
+	m.profiles[id] = p
+	m.order = append(m.order, id)
+	return p
+}
+
+// AddProfile adds a profile with rootKey, or a new random key when rootKey is
+// nil, and returns it. The active profile is unchanged.
+func (m *Manager) AddProfile(name string, rootKey *ec.PrivateKey) (Profile, error) {
+	if rootKey == nil {
+		var err error
+		if rootKey, err = ec.NewPrivateKey(); err != nil {
+			return Profile{}, err
+		}
+	}
+	id := make([]byte, 16)
+	if _, err := rand.Read(id); err != nil {
+		return Profile{}, err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, p := range m.profiles {
+		if p.IdentityKey.IsEqual(rootKey.PubKey()) {
+			return Profile{}, fmt.Errorf("%w: %s", ErrProfileExists, p.ID)
+		}
+	}
+	return m.insert(hex.EncodeToString(id), name, rootKey).Profile, nil
+}
+
+// DeleteProfile removes a profile and its originator bindings. The default
+// profile becomes active if the deleted one was. Data the profile's wallet kept
+// in storage is left there.
+func (m *Manager) DeleteProfile(id string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if id == DefaultProfileID {
+		return ErrDefaultProfile
+	}
+	if _, ok := m.profiles[id]; !ok {
+		return fmt.Errorf("%w: %s", ErrProfileNotFound, id)
+	}
+	delete(m.profiles, id)
+	m.order = slices.DeleteFunc(m.order, func(other string) bool { return other == id })
+	for originator, bound := range m.originators {
+		if bound == id {
+			delete(m.originators, originator)
+		}
+	}
+	if m.active == id {
+		m.active = DefaultProfileID
+	}
+	return nil
+}
+
+// Profiles lists the profiles in the order they were added, the default first.
+func (m *Manager) Profiles() []Profile {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	profiles := make([]Profile, len(m.order))
+	for i, id := range m.order {
+		profiles[i] = m.profiles[id].Profile
+	}
+	return profiles
+}
+
+// ActiveProfile returns the profile serving originators without a binding.
+func (m *Manager) ActiveProfile() Profile {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.profiles[m.active].Profile
+}
+
+// SwitchProfile makes the profile with id active.
+func (m *Manager) SwitchProfile(id string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, ok := m.profiles[id]; !ok {
+		return fmt.Errorf("%w: %s", ErrProfileNotFound, id)
+	}
+	m.active = id
+	return nil
+}
+
+// BindOriginator makes the profile with id serve the calls of originator,
+// whichever profile is active.
+func (m *Manager) BindOriginator(originator, id string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, ok := m.profiles[id]; !ok {
+		return fmt.Errorf("%w: %s", ErrProfileNotFound, id)
+	}
+	m.originators[originator] = id
+	return nil
+}
+
+// UnbindOriginator makes the active profile serve the calls of originator again.
+func (m *Manager) UnbindOriginator(originator string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.originators, originator)
+}
+
+// ProfileFor returns the profile serving the calls of originator.
+func (m *Manager) ProfileFor(originator string) Profile {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.profileFor(originator).Profile
+}
+
+func (m *Manager) profileFor(originator string) *profile {
+	if id, ok := m.originators[originator]; ok {
+		return m.profiles[id]
+	}
+	return m.profiles[m.active]
+}
+
+// walletFor returns the wallet of the profile serving originator, creating it
+// on first use.
+func (m *Manager) walletFor(ctx context.Context, originator string) (wallet.Interface, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	p := m.profileFor(originator)
+	if p.wallet == nil {
+		w, err := m.factory(ctx, p.Profile, p.rootKey)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create wallet for profile %s: %w", p.ID, err)
+		}
+		p.wallet = w
+	}
+	return p.wallet, nil
+}
+
+func (m *Manager) GetPublicKey(ctx context.Context, args wallet.GetPublicKeyArgs, originator string) (*wallet.GetPublicKeyResult, error) {
+	w, err := m.walletFor(ctx, originator)
+	if err != nil {
+		return nil, err
+	}
+	return w.GetPublicKey(ctx, args, originator)
+}
+
+func (m *Manager) Encrypt(ctx context.Context, args wallet.EncryptArgs, originator string) (*wallet.EncryptResult, error) {
+	w, err := m.walletFor(ctx, originator)
+	if err != nil {
+		return nil, err
+	}
+	return w.Encrypt(ctx, args, originator)
+}
+
+func (m *Manager) Decrypt(ctx context.Context, args wallet.DecryptArgs, originator string) (*wallet.DecryptResult, error) {
+	w, err := m.walletFor(ctx, originator)
+	if err != nil {
+		return nil, err
+	}
+	return w.Decrypt(ctx, args, originator)
+}
+
+func (m *Manager) CreateHMAC(ctx context.Context, args wallet.CreateHMACArgs, originator string) (*wallet.CreateHMACResult, error) {
+	w, err := m.walletFor(ctx, originator)
+	if err != nil {
+		return nil, err
+	}
+	return w.CreateHMAC(ctx, args, originator)
+}
+
+func (m *Manager) VerifyHMAC(ctx context.Context, args wallet.VerifyHMACArgs, originator string) (*wallet.VerifyHMACResult, error) {
+	w, err := m.walletFor(ctx, originator)
+	if err != nil {
+		return nil, err
+	}
+	return w.VerifyHMAC(ctx, args, originator)
+}
+
+func (m *Manager) CreateSignature(ctx context.Context, args wallet.CreateSignatureArgs, originator string) (*wallet.CreateSignatureResult, error) {
+	w, err := m.walletFor(ctx, originator)
+	if err != nil {
+		return nil, err
+	}
+	return w.CreateSignature(ctx, args, originator)
+}
+
+func (m *Manager) VerifySignature(ctx context.Context, args wallet.VerifySignatureArgs, originator string) (*wallet.VerifySignatureResult, error) {
+	w, err := m.walletFor(ctx, originator)
+	if err != nil {
+		return nil, err
+	}
+	return w.VerifySignature(ctx, args, originator)
+}
+
+func (m *Manager) AcquireCertificate(ctx context.Context, args wallet.AcquireCertificateArgs, originator string) (*wallet.Certificate, error) {
+	w, err := m.walletFor(ctx, originator)
+	if err != nil {
+		return nil, err
+	}
+	return w.AcquireCertificate(ctx, args, originator)
+}
+
+func (m *Manager) ListCertificates(ctx context.Context, args wallet.ListCertificatesArgs, originator string) (*wallet.ListCertificatesResult, error) {
+	w, err := m.walletFor(ctx, originator)
+	if err != nil {
+		return nil, err
+	}
+	return w.ListCertificates(ctx, args, originator)
+}
+
+func (m *Manager) ProveCertificate(ctx context.Context, args wallet.ProveCertificateArgs, originator string) (*wallet.ProveCertificateResult, error) {
+	w, err := m.walletFor(ctx, originator)
+	if err != nil {
+		return nil, err
+	}
+	return w.ProveCertificate(ctx, args, originator)
+}
+
+func (m *Manager) RelinquishCertificate(ctx context.Context, args wallet.RelinquishCertificateArgs, originator string) (*wallet.RelinquishCertificateResult, error) {
+	w, err := m.walletFor(ctx, originator)
+	if err != nil {
+		return nil, err
+	}
+	return w.RelinquishCertificate(ctx, args, originator)
+}
+
+func (m *Manager) CreateAction(ctx context.Context, args wallet.CreateActionArgs, originator string) (*wallet.CreateActionResult, error) {
+	w, err := m.walletFor(ctx, originator)
+	if err != nil {
+		return nil, err
+	}
+	return w.CreateAction(ctx, args, originator)
+}
+
+func (m *Manager) SignAction(ctx context.Context, args wallet.SignActionArgs, originator string) (*wallet.SignActionResult, error) {
+	w, err := m.walletFor(ctx, originator)
+	if err != nil {
+		return nil, err
+	}
+	return w.SignAction(ctx, args, originator)
+}
+
+func (m *Manager) AbortAction(ctx context.Context, args wallet.AbortActionArgs, originator string) (*wallet.AbortActionResult, error) {
+	w, err := m.walletFor(ctx, originator)
+	if err != nil {
+		return nil, err
+	}
+	return w.AbortAction(ctx, args, originator)
+}
+
+func (m *Manager) ListActions(ctx context.Context, args wallet.ListActionsArgs, originator string) (*wallet.ListActionsResult, error) {
+	w, err := m.walletFor(ctx, originator)
+	if err != nil {
+		return nil, err
+	}
+	return w.ListActions(ctx, args, originator)
+}
+
+func (m *Manager) InternalizeAction(ctx context.Context, args wallet.InternalizeActionArgs, originator string) (*wallet.InternalizeActionResult, error) {
+	w, err := m.walletFor(ctx, originator)
+	if err != nil {
+		return nil, err
+	}
+	return w.InternalizeAction(ctx, args, originator)
+}
+
+func (m *Manager) ListOutputs(ctx context.Context, args wallet.ListOutputsArgs, originator string) (*wallet.ListOutputsResult, error) {
+	w, err := m.walletFor(ctx, originator)
+	if err != nil {
+		return nil, err
+	}
+	return w.ListOutputs(ctx, args, originator)
+}
+
+// RelabelActions forwards to the wallet of the profile if it implements
+// wallet.AdminInterface, and fails with wallet.ErrAdminUnsupported otherwise.
+func (m *Manager) RelabelActions(ctx context.Context, args wallet.RelabelActionsArgs, originator string) (*wallet.RelabelActionsResult, error) {
+	w, err := m.walletFor(ctx, originator)
+	if err != nil {
+		return nil, err
+	}
+	admin, ok := w.(wallet.AdminInterface)
+	if !ok {
+		return nil, wallet.ErrAdminUnsupported
+	}
+	return admin.RelabelActions(ctx, args, originator)
+}
+
+// RetagOutputs forwards to the wallet of the profile if it implements
+// wallet.AdminInterface, and fails with wallet.ErrAdminUnsupported otherwise.
+func (m *Manager) RetagOutputs(ctx context.Context, args wallet.RetagOutputsArgs, originator string) (*wallet.RetagOutputsResult, error) {
+	w, err := m.walletFor(ctx, originator)
+	if err != nil {
+		return nil, err
+	}
+	admin, ok := w.(wallet.AdminInterface)
+	if !ok {
+		return nil, wallet.ErrAdminUnsupported
+	}
+	return admin.RetagOutputs(ctx, args, originator)
+}
+
+func (m *Manager) RelinquishOutput(ctx context.Context, args wallet.RelinquishOutputArgs, originator string) (*wallet.RelinquishOutputResult, error) {
+	w, err := m.walletFor(ctx, originator)
+	if err != nil {
+		return nil, err
+	}
+	return w.RelinquishOutput(ctx, args, originator)
+}
+
+func (m *Manager) RevealCounterpartyKeyLinkage(ctx context.Context, args wallet.RevealCounterpartyKeyLinkageArgs, originator string) (*wallet.RevealCounterpartyKeyLinkageResult, error) {
+	w, err := m.walletFor(ctx, originator)
+	if err != nil {
+		return nil, err
+	}
+	return w.RevealCounterpartyKeyLinkage(ctx, args, originator)
+}
+
+func (m *Manager) RevealSpecificKeyLinkage(ctx context.Context, args wallet.RevealSpecificKeyLinkageArgs, originator string) (*wallet.RevealSpecificKeyLinkageResult, error) {
+	w, err := m.walletFor(ctx, originator)
+	if err != nil {
+		return nil, err
+	}
+	return w.RevealSpecificKeyLinkage(ctx, args, originator)
+}
+
+func (m *Manager) DiscoverByIdentityKey(ctx context.Context, args wallet.DiscoverByIdentityKeyArgs, originator string) (*wallet.DiscoverCertificatesResult, error) {
+	w, err := m.walletFor(ctx, originator)
+	if err != nil {
+		return nil, err
+	}
+	return w.DiscoverByIdentityKey(ctx, args, originator)
+}
+
+func (m *Manager) DiscoverByAttributes(ctx context.Context, args wallet.DiscoverByAttributesArgs, originator string) (*wallet.DiscoverCertificatesResult, error) {
+	w, err := m.walletFor(ctx, originator)
+	if err != nil {
+		return nil, err
+	}
+	return w.DiscoverByAttributes(ctx, args, originator)
+}
+
+func (m *Manager) IsAuthenticated(ctx context.Context, args any, originator string) (*wallet.AuthenticatedResult, error) {
+	w, err := m.walletFor(ctx, originator)
+	if err != nil {
+		return nil, err
+	}
+	return w.IsAuthenticated(ctx, args, originator)
+}
+
+func (m *Manager) WaitForAuthentication(ctx context.Context, args any, originator string) (*wallet.AuthenticatedResult, error) {
+	w, err := m.walletFor(ctx, originator)
+	if err != nil {
+		return nil, err
+	}
+	return w.WaitForAuthentication(ctx, args, originator)
+}
+
+func (m *Manager) GetHeight(ctx context.Context, args any, originator string) (*wallet.GetHeightResult, error) {
+	w, err := m.walletFor(ctx, originator)
+	if err != nil {
+		return nil, err
+	}
+	return w.GetHeight(ctx, args, originator)
+}
+
+func (m *Manager) GetHeaderForHeight(ctx context.Context, args wallet.GetHeaderArgs, originator string) (*wallet.GetHeaderResult, error) {
+	w, err := m.walletFor(ctx, originator)
+	if err != nil {
+		return nil, err
+	}
+	return w.GetHeaderForHeight(ctx, args, originator)
+}
+
+func (m *Manager) GetNetwork(ctx context.Context, args any, originator string) (*wallet.GetNetworkResult, error) {
+	w, err := m.walletFor(ctx, originator)
+	if err != nil {
+		return nil, err
+	}
+	return w.GetNetwork(ctx, args, originator)
+}
+
+func (m *Manager) GetVersion(ctx context.Context, args any, originator string) (*wallet.GetVersionResult, error) {
+	w, err := m.walletFor(ctx, originator)
+	if err != nil {
+		return nil, err
+	}
+	return w.GetVersion(ctx, args, originator)
+}
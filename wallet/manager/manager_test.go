@@ -0,0 +1,125 @@
+package manager
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	ec "github.com/bsv-blockchain/go-sdk/primitives/ec"
+	"github.com/bsv-blockchain/go-sdk/wallet"
+	"github.com/stretchr/testify/require"
+)
+
+func protoWalletFactory(created *int) WalletFactory {
+	return func(_ context.Context, _ Profile, rootKey *ec.PrivateKey) (wallet.Interface, error) {
+		*created++
+		return wallet.NewCompletedProtoWallet(rootKey)
+	}
+}
+
+func identityKey(t *testing.T, m *Manager, originator string) *ec.PublicKey {
+	t.Helper()
+	result, err := m.GetPublicKey(t.Context(), wallet.GetPublicKeyArgs{IdentityKey: true}, originator)
+	require.NoError(t, err)
+	return result.PublicKey
+}
+
+func TestManager(t *testing.T) {
+	rootKey, err := ec.NewPrivateKey()
+	require.NoError(t, err)
+
+	t.Run("switches profiles", func(t *testing.T) {
+		var created int
+		m, err := NewManager(protoWalletFactory(&created), rootKey)
+		require.NoError(t, err)
+		require.Equal(t, DefaultProfileID, m.ActiveProfile().ID)
+		require.True(t, identityKey(t, m, "app.com").IsEqual(rootKey.PubKey()))
+
+		work, err := m.AddProfile("Work", nil)
+		require.NoError(t, err)
+		require.NotEqual(t, DefaultProfileID, work.ID)
+		require.False(t, work.IdentityKey.IsEqual(rootKey.PubKey()))
+		require.Equal(t, DefaultProfileID, m.ActiveProfile().ID)
+
+		require.NoError(t, m.SwitchProfile(work.ID))
+		require.True(t, identityKey(t, m, "app.com").IsEqual(work.IdentityKey))
+		require.NoError(t, m.SwitchProfile(DefaultProfileID))
+		require.True(t, identityKey(t, m, "app.com").IsEqual(rootKey.PubKey()))
+
+		// Wallets are created once per profile.
+		require.Equal(t, 2, created)
+		require.ErrorIs(t, m.SwitchProfile("missing"), ErrProfileNotFound)
+
+		profiles := m.Profiles()
+		require.Len(t, profiles, 2)
+		require.Equal(t, DefaultProfileID, profiles[0].ID)
+		require.Equal(t, "Work", profiles[1].Name)
+	})
+
+	t.Run("binds originators", func(t *testing.T) {
+		var created int
+		m, err := NewManager(protoWalletFactory(&created), rootKey)
+		require.NoError(t, err)
+		workKey, err := ec.NewPrivateKey()
+		require.NoError(t, err)
+		work, err := m.AddProfile("Work", workKey)
+		require.NoError(t, err)
+
+		require.NoError(t, m.BindOriginator("work.app", work.ID))
+		require.Equal(t, work.ID, m.ProfileFor("work.app").ID)
+		require.True(t, identityKey(t, m, "work.app").IsEqual(workKey.PubKey()))
+		require.True(t, identityKey(t, m, "other.app").IsEqual(rootKey.PubKey()))
+
+		m.UnbindOriginator("work.app")
+		require.True(t, identityKey(t, m, "work.app").IsEqual(rootKey.PubKey()))
+		require.ErrorIs(t, m.BindOriginator("work.app", "missing"), ErrProfileNotFound)
+	})
+
+	t.Run("deletes profiles", func(t *testing.T) {
+		var created int
+		m, err := NewManager(protoWalletFactory(&created), rootKey)
+		require.NoError(t, err)
+		work, err := m.AddProfile("Work", nil)
+		require.NoError(t, err)
+		require.NoError(t, m.SwitchProfile(work.ID))
+		require.NoError(t, m.BindOriginator("work.app", work.ID))
+
+		require.ErrorIs(t, m.DeleteProfile(DefaultProfileID), ErrDefaultProfile)
+		require.NoError(t, m.DeleteProfile(work.ID))
+		require.ErrorIs(t, m.DeleteProfile(work.ID), ErrProfileNotFound)
+		require.Equal(t, DefaultProfileID, m.ActiveProfile().ID)
+		require.Equal(t, DefaultProfileID, m.ProfileFor("work.app").ID)
+		require.Len(t, m.Profiles(), 1)
+	})
+
+	t.Run("rejects duplicate identities", func(t *testing.T) {
+		var created int
+		m, err := NewManager(protoWalletFactory(&created), rootKey)
+		require.NoError(t, err)
+		_, err = m.AddProfile("Again", rootKey)
+		require.ErrorIs(t, err, ErrProfileExists)
+	})
+
+	t.Run("admin calls", func(t *testing.T) {
+		m, err := NewManager(protoWalletFactory(new(int)), rootKey)
+		require.NoError(t, err)
+		_, err = m.RelabelActions(t.Context(), wallet.RelabelActionsArgs{Labels: []string{"a"}, AddLabels: []string{"b"}}, "app.com")
+		require.ErrorIs(t, err, wallet.ErrAdminUnsupported)
+		_, err = m.RetagOutputs(t.Context(), wallet.RetagOutputsArgs{Basket: "a", AddTags: []string{"b"}}, "app.com")
+		require.ErrorIs(t, err, wallet.ErrAdminUnsupported)
+	})
+
+	t.Run("factory errors", func(t *testing.T) {
+		m, err := NewManager(func(context.Context, Profile, *ec.PrivateKey) (wallet.Interface, error) {
+			return nil, errors.New("storage unavailable")
+		}, rootKey)
+		require.NoError(t, err)
+		_, err = m.GetPublicKey(t.Context(), wallet.GetPublicKeyArgs{IdentityKey: true}, "app.com")
+		require.ErrorContains(t, err, "storage unavailable")
+
+		_, err = NewManager(nil, rootKey)
+		require.Error(t, err)
+		_, err = NewManager(protoWalletFactory(new(int)), nil)
+		require.Error(t, err)
+	})
+}
@@ -0,0 +1,183 @@
+package wallet
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/bsv-blockchain/go-sdk/script"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+	sighash "github.com/bsv-blockchain/go-sdk/transaction/sighash"
+)
+
+// ErrPreimageMismatch is returned when an input of a SigningBundle does not
+// match the transaction it carries.
+var ErrPreimageMismatch = errors.New("signing bundle input does not match its transaction")
+
+// ErrMissingSubjectTransaction is returned when the BEEF of a signable
+// transaction does not contain the transaction it names.
+var ErrMissingSubjectTransaction = errors.New("bundle BEEF does not contain its subject transaction")
+
+// InputDerivation names the key unlocking a P2PKH input of a signable
+// transaction, derived with the protocol, key ID and counterparty the output
+// was locked with.
+type InputDerivation struct {
+	InputIndex   uint32
+	ProtocolID   Protocol
+	KeyID        string
+	Counterparty Counterparty
+	// SighashFlag defaults to SIGHASH_ALL|SIGHASH_FORKID.
+	SighashFlag sighash.Flag
+}
+
+// SigningBundleInput is an input of a SigningBundle to sign, with its
+// derivation, the output it spends and the preimage its signature commits to.
+type SigningBundleInput struct {
+	InputDerivation
+	SourceSatoshis      uint64
+	SourceLockingScript []byte
+	Preimage            []byte
+}
+
+// SigningBundle carries a SignableTransaction to an offline signer: the online
+// wallet exports it with NewSigningBundle, the signer turns it into the
+// SignActionArgs completing the action with Sign, and the online wallet passes
+// those to SignAction. serializer.SerializeSigningBundle and
+// serializer.SerializeSignActionArgs encode both for the transfer.
+type SigningBundle struct {
+	Reference []byte
+	// Tx is the signable transaction as returned by CreateAction, in BEEF with
+	// the transactions its inputs spend.
+	Tx     []byte
+	Inputs []SigningBundleInput
+}
+
+// NewSigningBundle prepares the inputs of signable named by derivations for
+// offline signing.
+func NewSigningBundle(signable *SignableTransaction, derivations []InputDerivation) (*SigningBundle, error) {
+	if signable == nil {
+		return nil, errors.New("signable transaction is required")
+	}
+	tx, err := parseSignable(signable.Tx)
+	if err != nil {
+		return nil, err
+	}
+
+	bundle := &SigningBundle{Reference: signable.Reference, Tx: signable.Tx}
+	for _, derivation := range derivations {
+		if derivation.SighashFlag == 0 {
+			derivation.SighashFlag = sighash.AllForkID
+		}
+		source, err := sourceOutput(tx, derivation.InputIndex)
+		if err != nil {
+			return nil, err
+		}
+		preimage, err := tx.InputPreimage(derivation.InputIndex, derivation.SighashFlag)
+		if err != nil {
+			return nil, fmt.Errorf("failed to compute preimage of input %d: %w", derivation.InputIndex, err)
+		}
+		bundle.Inputs = append(bundle.Inputs, SigningBundleInput{
+			InputDerivation:     derivation,
+			SourceSatoshis:      source.Satoshis,
+			SourceLockingScript: source.LockingScript.Bytes(),
+			Preimage:            preimage,
+		})
+	}
+	return bundle, nil
+}
+
+// Sign signs the inputs of the bundle with signer, holding the root key, and
+// returns the SignActionArgs completing the action, with P2PKH unlocking
+// scripts. Each input is first checked against the transaction, so a bundle
+// whose preimages or source outputs were tampered with fails with
+// ErrPreimageMismatch rather than producing signatures over something else.
+func (b *SigningBundle) Sign(ctx context.Context, signer KeyOperations, originator string) (*SignActionArgs, error) {
+	tx, err := parseSignable(b.Tx)
+	if err != nil {
+		return nil, err
+	}
+	rawTx := tx.Bytes()
+
+	args := &SignActionArgs{Reference: b.Reference, Spends: make(map[uint32]SignActionSpend, len(b.Inputs))}
+	for _, in := range b.Inputs {
+		source, err := sourceOutput(tx, in.InputIndex)
+		if err != nil {
+			return nil, err
+		}
+		if source.Satoshis != in.SourceSatoshis || !bytes.Equal(source.LockingScript.Bytes(), in.SourceLockingScript) {
+			return nil, fmt.Errorf("%w: input %d spends a different output", ErrPreimageMismatch, in.InputIndex)
+		}
+		preimage, err := tx.InputPreimage(in.InputIndex, in.SighashFlag)
+		if err != nil {
+			return nil, fmt.Errorf("failed to compute preimage of input %d: %w", in.InputIndex, err)
+		}
+		if !bytes.Equal(preimage, in.Preimage) {
+			return nil, fmt.Errorf("%w: input %d preimage", ErrPreimageMismatch, in.InputIndex)
+		}
+
+		signed, err := signer.CreateSignature(ctx, CreateSignatureArgs{
+			EncryptionArgs: EncryptionArgs{
+				ProtocolID:   in.ProtocolID,
+				KeyID:        in.KeyID,
+				Counterparty: in.Counterparty,
+			},
+			SighashContext: &SighashContext{
+				Tx:                  rawTx,
+				InputIndex:          in.InputIndex,
+				SourceSatoshis:      in.SourceSatoshis,
+				SourceLockingScript: in.SourceLockingScript,
+				SighashFlag:         in.SighashFlag,
+			},
+		}, originator)
+		if err != nil {
+			return nil, fmt.Errorf("failed to sign input %d: %w", in.InputIndex, err)
+		}
+		forSelf := true
+		key, err := signer.GetPublicKey(ctx, GetPublicKeyArgs{
+			EncryptionArgs: EncryptionArgs{
+				ProtocolID:   in.ProtocolID,
+				KeyID:        in.KeyID,
+				Counterparty: in.Counterparty,
+			},
+			ForSelf: &forSelf,
+		}, originator)
+		if err != nil {
+			return nil, fmt.Errorf("failed to derive key of input %d: %w", in.InputIndex, err)
+		}
+
+		unlockingScript := &script.Script{}
+		if err := unlockingScript.AppendPushData(append(signed.Signature.Serialize(), byte(in.SighashFlag))); err != nil {
+			return nil, err
+		}
+		if err := unlockingScript.AppendPushData(key.PublicKey.Compressed()); err != nil {
+			return nil, err
+		}
+		args.Spends[in.InputIndex] = SignActionSpend{UnlockingScript: unlockingScript.Bytes()}
+	}
+	return args, nil
+}
+
+func sourceOutput(tx *transaction.Transaction, inputIndex uint32) (*transaction.TransactionOutput, error) {
+	if int(inputIndex) >= len(tx.Inputs) {
+		return nil, fmt.Errorf("%w: input %d", transaction.ErrInputNoExist, inputIndex)
+	}
+	source := tx.Inputs[inputIndex].SourceTxOutput()
+	if source == nil {
+		return nil, fmt.Errorf("%w: input %d", transaction.ErrEmptyPreviousTx, inputIndex)
+	}
+	return source, nil
+}
+
+// parseSignable parses the BEEF of a signable transaction, which may come from
+// outside the process.
+func parseSignable(beef []byte) (*transaction.Transaction, error) {
+	tx, err := transaction.NewTransactionFromBEEF(beef)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse signable transaction: %w", err)
+	}
+	if tx == nil {
+		return nil, ErrMissingSubjectTransaction
+	}
+	return tx, nil
+}
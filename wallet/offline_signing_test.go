@@ -0,0 +1,105 @@
+package wallet_test
+
+import (
+	"bytes"
+	"testing"
+
+	ec "github.com/bsv-blockchain/go-sdk/primitives/ec"
+	"github.com/bsv-blockchain/go-sdk/script"
+	"github.com/bsv-blockchain/go-sdk/script/interpreter"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+	"github.com/bsv-blockchain/go-sdk/transaction/template/p2pkh"
+	"github.com/bsv-blockchain/go-sdk/wallet"
+	"github.com/bsv-blockchain/go-sdk/wallet/serializer"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSigningBundle(t *testing.T) {
+	ctx := t.Context()
+	rootKey, err := ec.NewPrivateKey()
+	require.NoError(t, err)
+	signer, err := wallet.NewCompletedProtoWallet(rootKey)
+	require.NoError(t, err)
+
+	derivation := wallet.InputDerivation{
+		ProtocolID:   wallet.Protocol{SecurityLevel: wallet.SecurityLevelEveryApp, Protocol: "offline signing"},
+		KeyID:        "1",
+		Counterparty: wallet.Counterparty{Type: wallet.CounterpartyTypeSelf},
+	}
+	forSelf := true
+	derived, err := signer.GetPublicKey(ctx, wallet.GetPublicKeyArgs{
+		EncryptionArgs: wallet.EncryptionArgs{
+			ProtocolID:   derivation.ProtocolID,
+			KeyID:        derivation.KeyID,
+			Counterparty: derivation.Counterparty,
+		},
+		ForSelf: &forSelf,
+	}, "")
+	require.NoError(t, err)
+	address, err := script.NewAddressFromPublicKey(derived.PublicKey, true)
+	require.NoError(t, err)
+	lockingScript, err := p2pkh.Lock(address)
+	require.NoError(t, err)
+
+	sourceTx := transaction.NewTransaction()
+	sourceTx.AddOutput(&transaction.TransactionOutput{Satoshis: 1000, LockingScript: lockingScript})
+	tx := transaction.NewTransaction()
+	tx.AddInputFromTx(sourceTx, 0, nil)
+	tx.AddOutput(&transaction.TransactionOutput{Satoshis: 900, LockingScript: &script.Script{script.OpTRUE}})
+	beef, err := tx.AtomicBEEF(true)
+	require.NoError(t, err)
+	signable := &wallet.SignableTransaction{Tx: beef, Reference: []byte("ref")}
+
+	bundle, err := wallet.NewSigningBundle(signable, []wallet.InputDerivation{derivation})
+	require.NoError(t, err)
+	require.Len(t, bundle.Inputs, 1)
+	require.Equal(t, uint64(1000), bundle.Inputs[0].SourceSatoshis)
+
+	// The bundle travels to the offline signer and the spends come back.
+	data, err := serializer.SerializeSigningBundle(bundle)
+	require.NoError(t, err)
+	received, err := serializer.DeserializeSigningBundle(data)
+	require.NoError(t, err)
+	signed, err := received.Sign(ctx, signer, "")
+	require.NoError(t, err)
+	data, err = serializer.SerializeSignActionArgs(signed)
+	require.NoError(t, err)
+	args, err := serializer.DeserializeSignActionArgs(data)
+	require.NoError(t, err)
+	require.Equal(t, signable.Reference, args.Reference)
+
+	require.Len(t, args.Spends, 1)
+	tx.Inputs[0].UnlockingScript = script.NewFromBytes(args.Spends[0].UnlockingScript)
+	require.NoError(t, interpreter.VerifyInput(ctx, tx, 0))
+
+	t.Run("rejects tampered preimages", func(t *testing.T) {
+		tampered := *bundle
+		tampered.Inputs = append([]wallet.SigningBundleInput(nil), bundle.Inputs...)
+		tampered.Inputs[0].Preimage = append([]byte{0}, bundle.Inputs[0].Preimage[1:]...)
+		_, err := tampered.Sign(ctx, signer, "")
+		require.ErrorIs(t, err, wallet.ErrPreimageMismatch)
+
+		tampered.Inputs[0].Preimage = bundle.Inputs[0].Preimage
+		tampered.Inputs[0].SourceSatoshis++
+		_, err = tampered.Sign(ctx, signer, "")
+		require.ErrorIs(t, err, wallet.ErrPreimageMismatch)
+	})
+
+	t.Run("rejects missing inputs", func(t *testing.T) {
+		_, err := wallet.NewSigningBundle(signable, []wallet.InputDerivation{{InputIndex: 1}})
+		require.ErrorIs(t, err, transaction.ErrInputNoExist)
+	})
+
+	t.Run("rejects a BEEF without its subject transaction", func(t *testing.T) {
+		// Name a subject transaction that is not in the bundle.
+		missing := bytes.Clone(beef)
+		copy(missing[4:36], make([]byte, 32))
+		_, err := wallet.NewSigningBundle(&wallet.SignableTransaction{Tx: missing, Reference: []byte("ref")}, []wallet.InputDerivation{derivation})
+		require.ErrorIs(t, err, wallet.ErrMissingSubjectTransaction)
+
+		tampered := *bundle
+		tampered.Tx = missing
+		_, err = tampered.Sign(ctx, signer, "")
+		require.ErrorIs(t, err, wallet.ErrMissingSubjectTransaction)
+	})
+}
@@ -0,0 +1,184 @@
+// Package permissions provides a wallet.Interface wrapper that enforces policy on
+// the calls applications make before forwarding them to the underlying wallet.
+package permissions
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/bsv-blockchain/go-sdk/util/rates"
+	"github.com/bsv-blockchain/go-sdk/wallet"
+)
+
+// Manager wraps a wallet and enforces per-originator and global spending limits on
+// CreateAction and SignAction. Calls it does not restrict are forwarded unchanged.
+//
+// CreateAction counts the spend of an action against the limits before forwarding
+// it. If the action awaits signature, its spend stays reserved until AbortAction
+// releases it, and SignAction checks it against the limits again so that actions
+// created before the limits were lowered cannot be completed beyond them.
+// Reservations do not expire while the action awaits signature.
+type Manager struct {
+	wallet.Interface
+
+	rates *rates.Client
+
+	mu     sync.Mutex
+	config SpendingConfig
+	ledger ledger
+	now    func() time.Time
+}
+
+var _ wallet.Interface = (*Manager)(nil)
+
+// Option configures a Manager.
+type Option func(*Manager)
+
+// WithRates sets the exchange rates limits set in fiat are converted with.
+// Without them, actions subject to a fiat limit fail with ErrNoRates.
+func WithRates(client *rates.Client) Option {
+	return func(m *Manager) {
+		m.rates = client
+	}
+}
+
+// NewManager creates a Manager enforcing config on the given wallet.
+func NewManager(w wallet.Interface, config SpendingConfig, opts ...Option) *Manager {
+	m := &Manager{
+		Interface: w,
+		config:    config,
+		now:       time.Now,
+	}
+	for _, opt := range opts {
+		opt(m)
+	}
+	return m
+}
+
+// SetSpendingConfig replaces the spending limits. Spends already recorded keep
+// counting towards the new limits.
+func (m *Manager) SetSpendingConfig(config SpendingConfig) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.config = config
+}
+
+// limits returns the limits that apply to the actions of originator, with those
+// set in fiat converted at the current rates. The rates may have to be fetched,
+// so it must not be called with the lock held.
+func (m *Manager) limits(ctx context.Context, originator string) (resolvedConfig, error) {
+	m.mu.Lock()
+	config := m.config
+	m.mu.Unlock()
+
+	originatorLimits, err := config.originatorLimits(originator).resolve(ctx, m.rates)
+	if err != nil {
+		return resolvedConfig{}, err
+	}
+	global, err := config.Global.resolve(ctx, m.rates)
+	if err != nil {
+		return resolvedConfig{}, err
+	}
+	return resolvedConfig{originator: originatorLimits, global: global}, nil
+}
+
+// CreateAction checks the action against the spending limits before forwarding it.
+func (m *Manager) CreateAction(ctx context.Context, args wallet.CreateActionArgs, originator string) (*wallet.CreateActionResult, error) {
+	amount := actionSpend(args)
+	limits, err := m.limits(ctx, originator)
+	if err != nil {
+		return nil, err
+	}
+
+	m.mu.Lock()
+	now := m.now()
+	m.ledger.prune(now)
+	if err := m.ledger.check(limits, originator, amount); err != nil {
+		m.mu.Unlock()
+		return nil, err
+	}
+	// Reserve the spend so concurrent actions cannot exceed the limits together.
+	reservation := spend{at: now, originator: originator, amount: amount}
+	m.ledger.insert(reservation)
+	m.mu.Unlock()
+
+	result, err := m.Interface.CreateAction(ctx, args, originator)
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.ledger.remove(reservation)
+	if err != nil {
+		return nil, err
+	}
+	if result != nil && result.SignableTransaction != nil {
+		// Reserve even spends of nothing, so SignAction knows the action.
+		reservation.reference = string(result.SignableTransaction.Reference)
+		m.ledger.insert(reservation)
+	} else if amount > 0 {
+		m.ledger.insert(reservation)
+	}
+	return result, nil
+}
+
+// SignAction checks the spend reserved for the action against the current limits
+// before forwarding it. Actions without a reservation are rejected with
+// ErrUnknownAction.
+func (m *Manager) SignAction(ctx context.Context, args wallet.SignActionArgs, originator string) (*wallet.SignActionResult, error) {
+	reference := string(args.Reference)
+
+	m.mu.Lock()
+	m.ledger.prune(m.now())
+	reserved, ok := m.ledger.find(reference)
+	m.mu.Unlock()
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownAction, reference)
+	}
+	limits, err := m.limits(ctx, reserved.originator)
+	if err != nil {
+		return nil, err
+	}
+
+	m.mu.Lock()
+	// The action may have been signed or aborted meanwhile.
+	reserved, ok = m.ledger.take(reference)
+	if !ok {
+		m.mu.Unlock()
+		return nil, fmt.Errorf("%w: %q", ErrUnknownAction, reference)
+	}
+	err = m.ledger.check(limits, reserved.originator, reserved.amount)
+	m.ledger.insert(reserved)
+	m.mu.Unlock()
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := m.Interface.SignAction(ctx, args, originator)
+	if err != nil {
+		return nil, err
+	}
+
+	// The action is complete, so its spend no longer needs the reference and
+	// counts from now on.
+	m.mu.Lock()
+	if s, found := m.ledger.take(reference); found && s.amount > 0 {
+		s.reference = ""
+		s.at = m.now()
+		m.ledger.insert(s)
+	}
+	m.mu.Unlock()
+	return result, nil
+}
+
+// AbortAction forwards the call and releases the spend reserved for the action.
+func (m *Manager) AbortAction(ctx context.Context, args wallet.AbortActionArgs, originator string) (*wallet.AbortActionResult, error) {
+	result, err := m.Interface.AbortAction(ctx, args, originator)
+	if err != nil {
+		return nil, err
+	}
+	m.mu.Lock()
+	m.ledger.take(string(args.Reference))
+	m.mu.Unlock()
+	return result, nil
+}
@@ -0,0 +1,283 @@
+package permissions
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/bsv-blockchain/go-sdk/script"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+	"github.com/bsv-blockchain/go-sdk/util/rates"
+	"github.com/bsv-blockchain/go-sdk/wallet"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeWallet implements the action methods of wallet.Interface.
+type fakeWallet struct {
+	wallet.Interface
+	signable  bool
+	createErr error
+	created   int
+	signed    int
+	aborted   int
+}
+
+func (f *fakeWallet) CreateAction(_ context.Context, _ wallet.CreateActionArgs, _ string) (*wallet.CreateActionResult, error) {
+	if f.createErr != nil {
+		return nil, f.createErr
+	}
+	f.created++
+	result := &wallet.CreateActionResult{}
+	if f.signable {
+		result.SignableTransaction = &wallet.SignableTransaction{Reference: fmt.Appendf(nil, "ref%d", f.created)}
+	}
+	return result, nil
+}
+
+func (f *fakeWallet) SignAction(_ context.Context, _ wallet.SignActionArgs, _ string) (*wallet.SignActionResult, error) {
+	f.signed++
+	return &wallet.SignActionResult{}, nil
+}
+
+func (f *fakeWallet) AbortAction(_ context.Context, _ wallet.AbortActionArgs, _ string) (*wallet.AbortActionResult, error) {
+	f.aborted++
+	return &wallet.AbortActionResult{Aborted: true}, nil
+}
+
+func pay(satoshis ...uint64) wallet.CreateActionArgs {
+	args := wallet.CreateActionArgs{Description: "payment"}
+	for _, s := range satoshis {
+		args.Outputs = append(args.Outputs, wallet.CreateActionOutput{Satoshis: s})
+	}
+	return args
+}
+
+func newTestManager(w wallet.Interface, config SpendingConfig) (*Manager, *time.Time) {
+	m := NewManager(w, config)
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	m.now = func() time.Time { return now }
+	return m, &now
+}
+
+func requireLimitError(t *testing.T, err error, want SpendingLimitError) {
+	t.Helper()
+	require.ErrorIs(t, err, ErrSpendingLimitExceeded)
+	var limitErr *SpendingLimitError
+	require.True(t, errors.As(err, &limitErr))
+	require.Equal(t, want, *limitErr)
+}
+
+func TestPerActionLimit(t *testing.T) {
+	ctx := t.Context()
+	inner := &fakeWallet{}
+	m, _ := newTestManager(inner, SpendingConfig{
+		Originators: map[string]SpendingLimits{"app.com": {PerAction: 1000}},
+	})
+
+	_, err := m.CreateAction(ctx, pay(600, 400), "app.com")
+	require.NoError(t, err)
+
+	_, err = m.CreateAction(ctx, pay(600, 401), "app.com")
+	requireLimitError(t, err, SpendingLimitError{Originator: "app.com", Kind: LimitPerAction, Limit: 1000, Requested: 1001})
+	require.Contains(t, err.Error(), `originator "app.com" per action spending limit of 1000 satoshis exceeded`)
+
+	// Other originators are unrestricted without a default
+	_, err = m.CreateAction(ctx, pay(5000), "other.com")
+	require.NoError(t, err)
+	require.Equal(t, 2, inner.created)
+}
+
+func TestPerDayLimitRollingWindow(t *testing.T) {
+	ctx := t.Context()
+	m, now := newTestManager(&fakeWallet{}, SpendingConfig{Default: SpendingLimits{PerDay: 1000}})
+
+	_, err := m.CreateAction(ctx, pay(700), "app.com")
+	require.NoError(t, err)
+	*now = now.Add(12 * time.Hour)
+	_, err = m.CreateAction(ctx, pay(300), "app.com")
+	require.NoError(t, err)
+
+	_, err = m.CreateAction(ctx, pay(1), "app.com")
+	requireLimitError(t, err, SpendingLimitError{Originator: "app.com", Kind: LimitPerDay, Limit: 1000, Spent: 1000, Requested: 1})
+
+	// The default applies to each originator separately
+	_, err = m.CreateAction(ctx, pay(1000), "other.com")
+	require.NoError(t, err)
+
+	// Once the first spend leaves the window its amount is available again
+	*now = now.Add(12*time.Hour + time.Second)
+	_, err = m.CreateAction(ctx, pay(700), "app.com")
+	require.NoError(t, err)
+	_, err = m.CreateAction(ctx, pay(1), "app.com")
+	require.ErrorIs(t, err, ErrSpendingLimitExceeded)
+}
+
+func TestGlobalLimit(t *testing.T) {
+	ctx := t.Context()
+	m, _ := newTestManager(&fakeWallet{}, SpendingConfig{Global: SpendingLimits{PerDay: 1500}})
+
+	_, err := m.CreateAction(ctx, pay(1000), "a.com")
+	require.NoError(t, err)
+	_, err = m.CreateAction(ctx, pay(600), "b.com")
+	requireLimitError(t, err, SpendingLimitError{Global: true, Originator: "b.com", Kind: LimitPerDay, Limit: 1500, Spent: 1000, Requested: 600})
+	require.Contains(t, err.Error(), "global per day spending limit")
+}
+
+func TestFiatLimits(t *testing.T) {
+	ctx := t.Context()
+	price := 50.0
+	provider := rates.ProviderFunc(func(_ context.Context, currency rates.Currency, _ time.Time) (*rates.Rate, error) {
+		return &rates.Rate{Currency: currency, Price: price}, nil
+	})
+	config := SpendingConfig{Default: SpendingLimits{
+		PerActionFiat: rates.Fiat{Minor: 100, Currency: rates.USD},
+		PerDay:        3_000_000,
+		PerDayFiat:    rates.Fiat{Minor: 200, Currency: rates.USD},
+	}}
+	m := NewManager(&fakeWallet{}, config, WithRates(rates.New([]rates.Provider{provider}, rates.WithTTL(0))))
+
+	// At 50 USD/BSV, 1.00 USD is worth 2,000,000 satoshis.
+	_, err := m.CreateAction(ctx, pay(2_000_000), "app.com")
+	require.NoError(t, err)
+	_, err = m.CreateAction(ctx, pay(2_000_001), "app.com")
+	requireLimitError(t, err, SpendingLimitError{Originator: "app.com", Kind: LimitPerAction, Limit: 2_000_000, Requested: 2_000_001,
+		LimitFiat: rates.Fiat{Minor: 100, Currency: rates.USD}, RequestedFiat: rates.Fiat{Minor: 100, Currency: rates.USD}})
+	require.Contains(t, err.Error(), "per action spending limit of 1.00 USD (2000000 satoshis) exceeded: 2000001 (1.00 USD) requested")
+
+	// The limit in satoshis is lower than 2.00 USD, so it applies.
+	_, err = m.CreateAction(ctx, pay(1_000_001), "app.com")
+	requireLimitError(t, err, SpendingLimitError{Originator: "app.com", Kind: LimitPerDay, Limit: 3_000_000, Spent: 2_000_000, Requested: 1_000_001})
+
+	// Once the price rises, fiat limits are worth fewer satoshis.
+	price = 100
+	_, err = m.CreateAction(ctx, pay(1_000_001), "app.com")
+	requireLimitError(t, err, SpendingLimitError{Originator: "app.com", Kind: LimitPerAction, Limit: 1_000_000, Requested: 1_000_001,
+		LimitFiat: rates.Fiat{Minor: 100, Currency: rates.USD}, RequestedFiat: rates.Fiat{Minor: 100, Currency: rates.USD}})
+	_, err = m.CreateAction(ctx, pay(1), "app.com")
+	requireLimitError(t, err, SpendingLimitError{Originator: "app.com", Kind: LimitPerDay, Limit: 2_000_000, Spent: 2_000_000, Requested: 1,
+		LimitFiat: rates.Fiat{Minor: 200, Currency: rates.USD}, RequestedFiat: rates.Fiat{Currency: rates.USD}})
+
+	// Fiat limits cannot be enforced without rates.
+	_, err = NewManager(&fakeWallet{}, config).CreateAction(ctx, pay(1), "app.com")
+	require.ErrorIs(t, err, ErrNoRates)
+}
+
+func TestFailedActionsDoNotCount(t *testing.T) {
+	ctx := t.Context()
+	inner := &fakeWallet{createErr: errors.New("insufficient funds")}
+	m, _ := newTestManager(inner, SpendingConfig{Default: SpendingLimits{PerDay: 1000}})
+
+	_, err := m.CreateAction(ctx, pay(1000), "app.com")
+	require.EqualError(t, err, "insufficient funds")
+
+	inner.createErr = nil
+	_, err = m.CreateAction(ctx, pay(1000), "app.com")
+	require.NoError(t, err)
+}
+
+func TestSignableActions(t *testing.T) {
+	ctx := t.Context()
+	inner := &fakeWallet{signable: true}
+	m, _ := newTestManager(inner, SpendingConfig{Default: SpendingLimits{PerDay: 1000}})
+
+	// An aborted action releases its reservation
+	created, err := m.CreateAction(ctx, pay(1000), "app.com")
+	require.NoError(t, err)
+	_, err = m.CreateAction(ctx, pay(1), "app.com")
+	require.ErrorIs(t, err, ErrSpendingLimitExceeded)
+	_, err = m.AbortAction(ctx, wallet.AbortActionArgs{Reference: created.SignableTransaction.Reference}, "app.com")
+	require.NoError(t, err)
+
+	created, err = m.CreateAction(ctx, pay(800), "app.com")
+	require.NoError(t, err)
+	reference := created.SignableTransaction.Reference
+
+	// Lowering the limits stops actions awaiting signature
+	m.SetSpendingConfig(SpendingConfig{Default: SpendingLimits{PerDay: 500}})
+	_, err = m.SignAction(ctx, wallet.SignActionArgs{Reference: reference}, "app.com")
+	requireLimitError(t, err, SpendingLimitError{Originator: "app.com", Kind: LimitPerDay, Limit: 500, Requested: 800})
+	require.Zero(t, inner.signed)
+
+	// The reservation still counts, and signing succeeds within the limits
+	m.SetSpendingConfig(SpendingConfig{Default: SpendingLimits{PerDay: 1000}})
+	_, err = m.CreateAction(ctx, pay(201), "app.com")
+	require.ErrorIs(t, err, ErrSpendingLimitExceeded)
+	_, err = m.SignAction(ctx, wallet.SignActionArgs{Reference: reference}, "app.com")
+	require.NoError(t, err)
+	require.Equal(t, 1, inner.signed)
+
+	// Once signed, the spend can no longer be released by aborting
+	_, err = m.AbortAction(ctx, wallet.AbortActionArgs{Reference: reference}, "app.com")
+	require.NoError(t, err)
+	_, err = m.CreateAction(ctx, pay(201), "app.com")
+	require.ErrorIs(t, err, ErrSpendingLimitExceeded)
+}
+
+func TestPendingReservationsDoNotExpire(t *testing.T) {
+	ctx := t.Context()
+	inner := &fakeWallet{signable: true}
+	m, now := newTestManager(inner, SpendingConfig{Default: SpendingLimits{PerDay: 1000}})
+
+	created, err := m.CreateAction(ctx, pay(800), "app.com")
+	require.NoError(t, err)
+	reference := created.SignableTransaction.Reference
+
+	// The reservation outlives the window while the action awaits signature
+	*now = now.Add(2 * spendingWindow)
+	_, err = m.CreateAction(ctx, pay(201), "app.com")
+	require.ErrorIs(t, err, ErrSpendingLimitExceeded)
+
+	m.SetSpendingConfig(SpendingConfig{Default: SpendingLimits{PerDay: 500}})
+	_, err = m.SignAction(ctx, wallet.SignActionArgs{Reference: reference}, "app.com")
+	require.ErrorIs(t, err, ErrSpendingLimitExceeded)
+	require.Zero(t, inner.signed)
+
+	// Once signed, the spend counts for a window from the signature
+	m.SetSpendingConfig(SpendingConfig{Default: SpendingLimits{PerDay: 1000}})
+	_, err = m.SignAction(ctx, wallet.SignActionArgs{Reference: reference}, "app.com")
+	require.NoError(t, err)
+	*now = now.Add(spendingWindow - time.Second)
+	_, err = m.CreateAction(ctx, pay(201), "app.com")
+	require.ErrorIs(t, err, ErrSpendingLimitExceeded)
+}
+
+func TestSignUnknownAction(t *testing.T) {
+	ctx := t.Context()
+	inner := &fakeWallet{signable: true}
+	m, _ := newTestManager(inner, SpendingConfig{Default: SpendingLimits{PerDay: 1000}})
+
+	_, err := m.SignAction(ctx, wallet.SignActionArgs{Reference: []byte("elsewhere")}, "app.com")
+	require.ErrorIs(t, err, ErrUnknownAction)
+
+	// Actions spending nothing are known too, but only until signed
+	created, err := m.CreateAction(ctx, pay(), "app.com")
+	require.NoError(t, err)
+	args := wallet.SignActionArgs{Reference: created.SignableTransaction.Reference}
+	_, err = m.SignAction(ctx, args, "app.com")
+	require.NoError(t, err)
+	_, err = m.SignAction(ctx, args, "app.com")
+	require.ErrorIs(t, err, ErrUnknownAction)
+	require.Equal(t, 1, inner.signed)
+}
+
+func TestActionSpend(t *testing.T) {
+	source := transaction.NewTransaction()
+	source.AddOutput(&transaction.TransactionOutput{Satoshis: 700, LockingScript: &script.Script{script.OpTRUE}})
+	beef, err := transaction.NewBeefFromTransaction(source)
+	require.NoError(t, err)
+	beefBytes, err := beef.Bytes()
+	require.NoError(t, err)
+
+	// Inputs in the input BEEF are not subtracted, whoever funds them
+	args := pay(1000, 500)
+	args.InputBEEF = beefBytes
+	args.Inputs = []wallet.CreateActionInput{
+		{Outpoint: transaction.Outpoint{Txid: *source.TxID(), Index: 0}},
+	}
+	require.Equal(t, uint64(1500), actionSpend(args))
+
+	require.Zero(t, actionSpend(pay()))
+	require.Equal(t, uint64(1<<64-1), actionSpend(pay(1<<63, 1<<63, 1)), "sums saturate")
+}
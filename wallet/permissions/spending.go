@@ -0,0 +1,322 @@
+package permissions
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"math/bits"
+	"time"
+
+	"github.com/bsv-blockchain/go-sdk/primitives/amount"
+	"github.com/bsv-blockchain/go-sdk/util/rates"
+	"github.com/bsv-blockchain/go-sdk/wallet"
+)
+
+var (
+	// ErrSpendingLimitExceeded is matched by every SpendingLimitError.
+	ErrSpendingLimitExceeded = errors.New("spending limit exceeded")
+	// ErrUnknownAction is returned by SignAction for references of actions
+	// which were not created through the Manager, or were already signed or
+	// aborted, as their spend cannot be checked against the limits.
+	ErrUnknownAction = errors.New("unknown action reference")
+	// ErrNoRates is returned when a limit is set in fiat but the Manager was
+	// not given exchange rates to convert it with.
+	ErrNoRates = errors.New("fiat spending limit without exchange rates")
+)
+
+// spendingWindow is the rolling window PerDay limits apply to.
+const spendingWindow = 24 * time.Hour
+
+// SpendingLimits caps the satoshis spent by actions. Zero values mean no limit.
+//
+// Limits set in fiat are converted to satoshis at the current exchange rate for
+// every check, which needs a Manager created WithRates. When a limit is set both
+// in satoshis and in fiat, the lower of the two applies.
+type SpendingLimits struct {
+	// PerAction caps the satoshis a single action may spend.
+	PerAction uint64
+	// PerDay caps the satoshis spent over any rolling 24 hours.
+	PerDay uint64
+	// PerActionFiat caps the value a single action may spend in a fiat currency.
+	PerActionFiat rates.Fiat
+	// PerDayFiat caps the value spent over any rolling 24 hours in a fiat
+	// currency.
+	PerDayFiat rates.Fiat
+}
+
+// SpendingConfig configures the spending limits enforced by a Manager.
+type SpendingConfig struct {
+	// Global limits apply to the actions of all originators combined.
+	Global SpendingLimits
+	// Originators sets the limits of specific originators.
+	Originators map[string]SpendingLimits
+	// Default limits apply to originators not listed in Originators.
+	Default SpendingLimits
+}
+
+func (c SpendingConfig) originatorLimits(originator string) SpendingLimits {
+	if limits, ok := c.Originators[originator]; ok {
+		return limits
+	}
+	return c.Default
+}
+
+// LimitKind identifies which spending limit was exceeded.
+type LimitKind string
+
+const (
+	LimitPerAction LimitKind = "per action"
+	LimitPerDay    LimitKind = "per day"
+)
+
+// SpendingLimitError reports the spending limit an action would exceed.
+type SpendingLimitError struct {
+	// Global is set when the global limits were exceeded rather than those
+	// of the originator.
+	Global     bool
+	Originator string
+	Kind       LimitKind
+	// Limit is the configured limit in satoshis, or the limit set in fiat
+	// converted to satoshis.
+	Limit uint64
+	// Spent is what was already spent within the window of a PerDay limit.
+	Spent uint64
+	// Requested is what the action would spend.
+	Requested uint64
+	// LimitFiat is the limit as set in fiat, and RequestedFiat the value of
+	// Requested in its currency. Both are zero for limits set in satoshis.
+	LimitFiat     rates.Fiat
+	RequestedFiat rates.Fiat
+}
+
+func (e *SpendingLimitError) Error() string {
+	scope := fmt.Sprintf("originator %q", e.Originator)
+	if e.Global {
+		scope = "global"
+	}
+	limit := fmt.Sprintf("%d satoshis", e.Limit)
+	requested := fmt.Sprintf("%d", e.Requested)
+	if e.LimitFiat.Minor != 0 {
+		limit = fmt.Sprintf("%s (%d satoshis)", e.LimitFiat, e.Limit)
+		requested = fmt.Sprintf("%d (%s)", e.Requested, e.RequestedFiat)
+	}
+	if e.Kind == LimitPerDay {
+		return fmt.Sprintf("%s %s spending limit of %s exceeded: %d already spent, %s requested",
+			scope, e.Kind, limit, e.Spent, requested)
+	}
+	return fmt.Sprintf("%s %s spending limit of %s exceeded: %s requested", scope, e.Kind, limit, requested)
+}
+
+// limit is a spending limit in satoshis. Limits set in fiat carry the rate they
+// were converted at.
+type limit struct {
+	satoshis uint64
+	fiat     rates.Fiat
+	rate     *rates.Rate
+}
+
+// set reports whether there is a limit. Limits set in fiat may convert to 0
+// satoshis, which still forbids spending.
+func (l limit) set() bool {
+	return l.satoshis > 0 || l.rate != nil
+}
+
+// newError returns the SpendingLimitError of exceeding the limit.
+func (l limit) newError(global bool, originator string, kind LimitKind, spent, requested uint64) *SpendingLimitError {
+	err := &SpendingLimitError{Global: global, Originator: originator, Kind: kind,
+		Limit: l.satoshis, Spent: spent, Requested: requested}
+	if l.rate != nil {
+		err.LimitFiat = l.fiat
+		err.RequestedFiat = l.rate.Convert(amount.Satoshis(requested))
+	}
+	return err
+}
+
+// resolvedLimits are SpendingLimits with the limits set in fiat converted.
+type resolvedLimits struct {
+	perAction, perDay limit
+}
+
+// resolvedConfig holds the limits that apply to the actions of an originator.
+type resolvedConfig struct {
+	originator, global resolvedLimits
+}
+
+// resolve converts the limits set in fiat to satoshis at the current rates of r.
+func (l SpendingLimits) resolve(ctx context.Context, r *rates.Client) (resolvedLimits, error) {
+	perAction, err := resolveLimit(ctx, r, l.PerAction, l.PerActionFiat)
+	if err != nil {
+		return resolvedLimits{}, err
+	}
+	perDay, err := resolveLimit(ctx, r, l.PerDay, l.PerDayFiat)
+	if err != nil {
+		return resolvedLimits{}, err
+	}
+	return resolvedLimits{perAction: perAction, perDay: perDay}, nil
+}
+
+// resolveLimit returns the lower of a limit in satoshis and one in fiat, either
+// of which may be unset.
+func resolveLimit(ctx context.Context, r *rates.Client, satoshis uint64, fiat rates.Fiat) (limit, error) {
+	l := limit{satoshis: satoshis}
+	if fiat.Minor == 0 {
+		return l, nil
+	}
+	if r == nil {
+		return limit{}, fmt.Errorf("%w: %s", ErrNoRates, fiat)
+	}
+	rate, err := r.Rate(ctx, fiat.Currency, time.Time{})
+	if err != nil {
+		return limit{}, fmt.Errorf("converting spending limit of %s: %w", fiat, err)
+	}
+	fiat.Currency = rate.Currency
+	converted, err := rate.Satoshis(fiat)
+	if err != nil {
+		return limit{}, fmt.Errorf("converting spending limit of %s: %w", fiat, err)
+	}
+	if satoshis == 0 || converted.Uint64() < satoshis {
+		l = limit{satoshis: converted.Uint64(), fiat: fiat, rate: rate}
+	}
+	return l, nil
+}
+
+// Is makes SpendingLimitError match ErrSpendingLimitExceeded.
+func (e *SpendingLimitError) Is(target error) bool {
+	return target == ErrSpendingLimitExceeded
+}
+
+// spend is an amount counted against the limits. Spends of actions awaiting
+// signature carry the action reference.
+type spend struct {
+	at         time.Time
+	originator string
+	amount     uint64
+	reference  string
+}
+
+// ledger records the spends within the current window.
+type ledger struct {
+	spends []spend
+}
+
+// prune drops the spends that fell out of the window. Reservations of actions
+// awaiting signature are kept until they are signed or aborted, so that
+// SignAction always finds them.
+func (l *ledger) prune(now time.Time) {
+	cutoff := now.Add(-spendingWindow)
+	kept := l.spends[:0]
+	for _, s := range l.spends {
+		if s.reference != "" || s.at.After(cutoff) {
+			kept = append(kept, s)
+		}
+	}
+	clear(l.spends[len(kept):])
+	l.spends = kept
+}
+
+// spent returns the total spent in the window, by everyone if originator is nil.
+func (l *ledger) spent(originator *string) uint64 {
+	var total uint64
+	for _, s := range l.spends {
+		if originator == nil || s.originator == *originator {
+			total = addSatoshis(total, s.amount)
+		}
+	}
+	return total
+}
+
+// check returns a SpendingLimitError if spending amount for originator would exceed the limits.
+func (l *ledger) check(cfg resolvedConfig, originator string, amount uint64) error {
+	type scope struct {
+		originator *string
+		limits     resolvedLimits
+	}
+	for _, sc := range []scope{
+		{originator: &originator, limits: cfg.originator},
+		{limits: cfg.global},
+	} {
+		global := sc.originator == nil
+		if perAction := sc.limits.perAction; perAction.set() && amount > perAction.satoshis {
+			return perAction.newError(global, originator, LimitPerAction, 0, amount)
+		}
+		if perDay := sc.limits.perDay; perDay.set() {
+			spent := l.spent(sc.originator)
+			if addSatoshis(spent, amount) > perDay.satoshis {
+				return perDay.newError(global, originator, LimitPerDay, spent, amount)
+			}
+		}
+	}
+	return nil
+}
+
+// find returns the spend of the action with the given reference.
+func (l *ledger) find(reference string) (spend, bool) {
+	if reference == "" {
+		return spend{}, false
+	}
+	for _, s := range l.spends {
+		if s.reference == reference {
+			return s, true
+		}
+	}
+	return spend{}, false
+}
+
+// take removes and returns the spend of the action with the given reference.
+func (l *ledger) take(reference string) (spend, bool) {
+	if reference == "" {
+		return spend{}, false
+	}
+	for i, s := range l.spends {
+		if s.reference == reference {
+			l.spends = append(l.spends[:i], l.spends[i+1:]...)
+			return s, true
+		}
+	}
+	return spend{}, false
+}
+
+// remove removes a spend, if it is still in the window.
+func (l *ledger) remove(target spend) {
+	for i, s := range l.spends {
+		if s == target {
+			l.spends = append(l.spends[:i], l.spends[i+1:]...)
+			return
+		}
+	}
+}
+
+// insert adds a spend, keeping the ledger ordered by time.
+func (l *ledger) insert(s spend) {
+	i := len(l.spends)
+	for i > 0 && l.spends[i-1].at.After(s.at) {
+		i--
+	}
+	l.spends = append(l.spends, spend{})
+	copy(l.spends[i+1:], l.spends[i:])
+	l.spends[i] = s
+}
+
+// actionSpend returns the satoshis an action spends: the gross value of its
+// outputs. Inputs supplied by the caller are not subtracted, as the wallet cannot
+// tell from the input BEEF whether they are funded by the caller or by the
+// wallet itself. Fees chosen by the wallet are not known beforehand and are not
+// counted.
+func actionSpend(args wallet.CreateActionArgs) uint64 {
+	var outputs uint64
+	for _, output := range args.Outputs {
+		outputs = addSatoshis(outputs, output.Satoshis)
+	}
+	return outputs
+}
+
+// addSatoshis adds two amounts, saturating instead of overflowing so that huge
+// output values cannot wrap around to pass a limit.
+func addSatoshis(a, b uint64) uint64 {
+	sum, carry := bits.Add64(a, b, 0)
+	if carry != 0 {
+		return math.MaxUint64
+	}
+	return sum
+}
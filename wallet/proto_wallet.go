@@ -18,7 +18,7 @@ import (
 // or store any data.
 type ProtoWallet struct {
 	// The underlying key deriver
-	keyDeriver *KeyDeriver
+	keyDeriver KeyDeriverInterface
 }
 
 // ProtoWalletArgsType specifies the type of argument used to create a ProtoWallet.
@@ -35,15 +35,35 @@ const (
 type ProtoWalletArgs struct {
 	Type       ProtoWalletArgsType
 	PrivateKey *ec.PrivateKey
-	KeyDeriver *KeyDeriver
+	KeyDeriver KeyDeriverInterface
+	// SharedSecretCacheSize, when positive, enables an LRU cache of that many
+	// ECDH shared secrets, so that repeated operations with the same counterparty
+	// skip the scalar multiplication. Caching is disabled by default, and only
+	// applies to the built-in KeyDeriver.
+	SharedSecretCacheSize int
 }
 
 // NewProtoWallet creates a new ProtoWallet from a private key or KeyDeriver
 func NewProtoWallet(rootKeyOrKeyDeriver ProtoWalletArgs) (*ProtoWallet, error) {
+	p, err := newProtoWallet(rootKeyOrKeyDeriver)
+	if err != nil {
+		return nil, err
+	}
+	if kd, ok := p.keyDeriver.(*KeyDeriver); ok && rootKeyOrKeyDeriver.SharedSecretCacheSize > 0 {
+		p.keyDeriver = kd.withSharedSecretCache(rootKeyOrKeyDeriver.SharedSecretCacheSize)
+	}
+	return p, nil
+}
+
+func newProtoWallet(rootKeyOrKeyDeriver ProtoWalletArgs) (*ProtoWallet, error) {
 	switch rootKeyOrKeyDeriver.Type {
 	case ProtoWalletArgsTypeKeyDeriver:
+		keyDeriver := rootKeyOrKeyDeriver.KeyDeriver
+		if kd, ok := keyDeriver.(*KeyDeriver); ok && kd == nil {
+			keyDeriver = nil
+		}
 		return &ProtoWallet{
-			keyDeriver: rootKeyOrKeyDeriver.KeyDeriver,
+			keyDeriver: keyDeriver,
 		}, nil
 	case ProtoWalletArgsTypePrivateKey:
 		return &ProtoWallet{
@@ -66,7 +86,7 @@ func (p *ProtoWallet) GetPublicKey(ctx context.Context, args GetPublicKeyArgs, _
 			return nil, errors.New("keyDeriver is undefined")
 		}
 		return &GetPublicKeyResult{
-			PublicKey: p.keyDeriver.rootKey.PubKey(),
+			PublicKey: p.keyDeriver.IdentityKey(),
 		}, nil
 	} else {
 		if args.ProtocolID.Protocol == "" || args.KeyID == "" {
@@ -92,7 +112,7 @@ func (p *ProtoWallet) GetPublicKey(ctx context.Context, args GetPublicKeyArgs, _
 			util.PtrToBool(args.ForSelf),
 		)
 		if err != nil {
-			return nil, fmt.Errorf("failed to derive public key: %v", err)
+			return nil, fmt.Errorf("failed to derive public key: %w", err)
 		}
 		return &GetPublicKeyResult{
 			PublicKey: pubKey,
@@ -126,7 +146,7 @@ func (p *ProtoWallet) Encrypt(
 	// Derive a symmetric key for encryption
 	key, err := p.keyDeriver.DeriveSymmetricKey(protocol, args.KeyID, counterpartyObj)
 	if err != nil {
-		return nil, fmt.Errorf("failed to derive symmetric key: %v", err)
+		return nil, fmt.Errorf("failed to derive symmetric key: %w", err)
 	}
 
 	encrypted, err := key.Encrypt(args.Plaintext)
@@ -161,7 +181,7 @@ func (p *ProtoWallet) Decrypt(
 	// Derive a symmetric key for decryption
 	key, err := p.keyDeriver.DeriveSymmetricKey(args.ProtocolID, args.KeyID, counterparty)
 	if err != nil {
-		return nil, fmt.Errorf("failed to derive symmetric key: %v", err)
+		return nil, fmt.Errorf("failed to derive symmetric key: %w", err)
 	}
 
 	plaintext, err := key.Decrypt(args.Ciphertext)
@@ -186,7 +206,12 @@ func (p *ProtoWallet) CreateSignature(
 
 	// Get hash to sign
 	var dataHash []byte
-	if len(args.HashToDirectlySign) > 0 {
+	if args.SighashContext != nil {
+		var err error
+		if dataHash, err = args.SighashContext.signatureHash(); err != nil {
+			return nil, fmt.Errorf("failed to compute sighash: %w", err)
+		}
+	} else if len(args.HashToDirectlySign) > 0 {
 		dataHash = args.HashToDirectlySign
 	} else {
 		// Handle empty data by hashing it (sha256 of empty is valid)
@@ -208,7 +233,7 @@ func (p *ProtoWallet) CreateSignature(
 		counterpartyObj,
 	)
 	if err != nil {
-		return nil, fmt.Errorf("failed to derive private key: %v", err)
+		return nil, fmt.Errorf("failed to derive private key: %w", err)
 	}
 
 	// Create signature
@@ -260,7 +285,7 @@ func (p *ProtoWallet) VerifySignature(
 		util.PtrToBool(args.ForSelf),
 	)
 	if err != nil {
-		return nil, fmt.Errorf("failed to derive public key: %v", err)
+		return nil, fmt.Errorf("failed to derive public key: %w", err)
 	}
 
 	// Verify signature
@@ -300,7 +325,7 @@ func (p *ProtoWallet) CreateHMAC(
 		counterpartyObj,
 	)
 	if err != nil {
-		return nil, fmt.Errorf("failed to derive symmetric key: %v", err)
+		return nil, fmt.Errorf("failed to derive symmetric key: %w", err)
 	}
 
 	// Create HMAC using the derived key
@@ -340,7 +365,7 @@ func (p *ProtoWallet) VerifyHMAC(
 		counterpartyObj,
 	)
 	if err != nil {
-		return nil, fmt.Errorf("failed to derive symmetric key: %v", err)
+		return nil, fmt.Errorf("failed to derive symmetric key: %w", err)
 	}
 
 	// Create expected HMAC
@@ -0,0 +1,133 @@
+package wallet
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"runtime"
+
+	hash "github.com/bsv-blockchain/go-sdk/primitives/hash"
+	"github.com/bsv-blockchain/go-sdk/util"
+	"golang.org/x/sync/errgroup"
+)
+
+// CreateHMACBatch computes the HMACs of many payloads in one call. Payloads with
+// the same protocol, key ID and counterparty share a single key derivation, and
+// distinct keys are derived in parallel. Results are in the order of args; an
+// invalid payload fails the whole batch.
+func (p *ProtoWallet) CreateHMACBatch(ctx context.Context, args []CreateHMACArgs, originator string) ([]CreateHMACResult, error) {
+	if p.keyDeriver == nil {
+		return nil, errors.New("keyDeriver is undefined")
+	}
+	results := make([]CreateHMACResult, len(args))
+	err := processBatch(ctx, args, func(a CreateHMACArgs) string {
+		return derivationKey(a.EncryptionArgs, false)
+	}, func(indices []int) error {
+		first := args[indices[0]]
+		key, err := p.keyDeriver.DeriveSymmetricKey(first.ProtocolID, first.KeyID, defaultToSelf(first.Counterparty))
+		if err != nil {
+			return fmt.Errorf("payload %d: failed to derive symmetric key: %w", indices[0], err)
+		}
+		for _, i := range indices {
+			mac := hmac.New(sha256.New, key.ToBytes())
+			mac.Write(args[i].Data)
+			copy(results[i].HMAC[:], mac.Sum(nil))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// VerifySignatureBatch verifies many signatures in one call. Signatures with the
+// same protocol, key ID, counterparty and ForSelf share a single key derivation,
+// and are verified in parallel. Results are in the order of args; an invalid
+// payload, such as one without a signature, fails the whole batch.
+func (p *ProtoWallet) VerifySignatureBatch(ctx context.Context, args []VerifySignatureArgs, originator string) ([]VerifySignatureResult, error) {
+	if p.keyDeriver == nil {
+		return nil, errors.New("keyDeriver is undefined")
+	}
+	for i, a := range args {
+		if len(a.Data) == 0 && len(a.HashToDirectlyVerify) == 0 {
+			return nil, fmt.Errorf("payload %d: args.data or args.hashToDirectlyVerify must be valid", i)
+		}
+		if a.Signature == nil {
+			return nil, fmt.Errorf("payload %d: signature is nil", i)
+		}
+	}
+	results := make([]VerifySignatureResult, len(args))
+	err := processBatch(ctx, args, func(a VerifySignatureArgs) string {
+		return derivationKey(a.EncryptionArgs, util.PtrToBool(a.ForSelf))
+	}, func(indices []int) error {
+		first := args[indices[0]]
+		pubKey, err := p.keyDeriver.DerivePublicKey(first.ProtocolID, first.KeyID, defaultToSelf(first.Counterparty), util.PtrToBool(first.ForSelf))
+		if err != nil {
+			return fmt.Errorf("payload %d: failed to derive public key: %w", indices[0], err)
+		}
+		for _, i := range indices {
+			dataHash := args[i].HashToDirectlyVerify
+			if len(dataHash) == 0 {
+				dataHash = hash.Sha256(args[i].Data)
+			}
+			results[i].Valid = args[i].Signature.Verify(dataHash, pubKey)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// processBatch groups the indices of args by key, preserving their order, and
+// runs process for each group on up to GOMAXPROCS goroutines.
+func processBatch[A any](ctx context.Context, args []A, key func(A) string, process func(indices []int) error) error {
+	groups := make(map[string][]int)
+	var order []string
+	for i, a := range args {
+		k := key(a)
+		if _, ok := groups[k]; !ok {
+			order = append(order, k)
+		}
+		groups[k] = append(groups[k], i)
+	}
+
+	g, ctx := errgroup.WithContext(ctx)
+	g.SetLimit(runtime.GOMAXPROCS(0))
+	for _, k := range order {
+		indices := groups[k]
+		g.Go(func() error {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			return process(indices)
+		})
+	}
+	return g.Wait()
+}
+
+// derivationKey identifies the key derived for args, so payloads sharing it can
+// share the derivation.
+func derivationKey(args EncryptionArgs, forSelf bool) string {
+	counterparty := defaultToSelf(args.Counterparty)
+	var counterpartyKey string
+	if counterparty.Type == CounterpartyTypeOther && counterparty.Counterparty != nil {
+		counterpartyKey = string(counterparty.Counterparty.Compressed())
+	}
+	return fmt.Sprintf("%d\x00%s\x00%s\x00%d\x00%s\x00%t",
+		args.ProtocolID.SecurityLevel, args.ProtocolID.Protocol, args.KeyID,
+		counterparty.Type, counterpartyKey, forSelf)
+}
+
+// defaultToSelf returns the counterparty, or self when it is not set, as the
+// HMAC and signature verification methods do.
+func defaultToSelf(counterparty Counterparty) Counterparty {
+	if counterparty.Type == CounterpartyUninitialized {
+		return Counterparty{Type: CounterpartyTypeSelf}
+	}
+	return counterparty
+}
@@ -0,0 +1,114 @@
+package wallet
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func batchTestArgs(t *testing.T, n int) []EncryptionArgs {
+	t.Helper()
+	_, other := newRevealTestWallet(t)
+	args := make([]EncryptionArgs, n)
+	for i := range args {
+		args[i] = EncryptionArgs{
+			ProtocolID: Protocol{SecurityLevel: SecurityLevelEveryAppAndCounterparty, Protocol: "batch test"},
+			KeyID:      fmt.Sprintf("%d", i%3),
+		}
+		if i%2 == 0 {
+			args[i].Counterparty = Counterparty{Type: CounterpartyTypeOther, Counterparty: other}
+		}
+	}
+	return args
+}
+
+func TestProtoWallet_CreateHMACBatch(t *testing.T) {
+	ctx := context.Background()
+	w, _ := newRevealTestWallet(t)
+
+	args := make([]CreateHMACArgs, 0, 20)
+	for i, encryption := range batchTestArgs(t, 20) {
+		args = append(args, CreateHMACArgs{EncryptionArgs: encryption, Data: []byte(fmt.Sprintf("message %d", i))})
+	}
+	results, err := w.CreateHMACBatch(ctx, args, "")
+	require.NoError(t, err)
+	require.Len(t, results, len(args))
+	for i, a := range args {
+		single, err := w.CreateHMAC(ctx, a, "")
+		require.NoError(t, err)
+		require.Equal(t, single.HMAC, results[i].HMAC, "payload %d", i)
+	}
+
+	t.Run("empty batch", func(t *testing.T) {
+		results, err := w.CreateHMACBatch(ctx, nil, "")
+		require.NoError(t, err)
+		require.Empty(t, results)
+	})
+
+	t.Run("canceled context", func(t *testing.T) {
+		canceled, cancel := context.WithCancel(ctx)
+		cancel()
+		_, err := w.CreateHMACBatch(canceled, args, "")
+		require.ErrorIs(t, err, context.Canceled)
+	})
+}
+
+func TestProtoWallet_VerifySignatureBatch(t *testing.T) {
+	ctx := context.Background()
+	signer, signerKey := newRevealTestWallet(t)
+	verifier, _ := newRevealTestWallet(t)
+
+	args := make([]VerifySignatureArgs, 0, 20)
+	for i, encryption := range batchTestArgs(t, 20) {
+		data := []byte(fmt.Sprintf("message %d", i))
+		signed, err := signer.CreateSignature(ctx, CreateSignatureArgs{
+			EncryptionArgs: EncryptionArgs{
+				ProtocolID:   encryption.ProtocolID,
+				KeyID:        encryption.KeyID,
+				Counterparty: Counterparty{Type: CounterpartyTypeAnyone},
+			},
+			Data: data,
+		}, "")
+		require.NoError(t, err)
+		if i == 7 {
+			data = []byte("tampered")
+		}
+		args = append(args, VerifySignatureArgs{
+			EncryptionArgs: EncryptionArgs{
+				ProtocolID:   encryption.ProtocolID,
+				KeyID:        encryption.KeyID,
+				Counterparty: Counterparty{Type: CounterpartyTypeOther, Counterparty: signerKey},
+			},
+			Data:      data,
+			Signature: signed.Signature,
+		})
+	}
+
+	// Anyone-signed payloads verify against the signer's key derived for anyone.
+	anyoneVerifier, err := NewProtoWallet(ProtoWalletArgs{Type: ProtoWalletArgsTypeAnyone})
+	require.NoError(t, err)
+	results, err := anyoneVerifier.VerifySignatureBatch(ctx, args, "")
+	require.NoError(t, err)
+	require.Len(t, results, len(args))
+	for i, a := range args {
+		single, err := anyoneVerifier.VerifySignature(ctx, a, "")
+		require.NoError(t, err)
+		require.Equal(t, single.Valid, results[i].Valid, "payload %d", i)
+		require.Equal(t, i != 7, results[i].Valid, "payload %d", i)
+	}
+
+	t.Run("signature from another key", func(t *testing.T) {
+		results, err := verifier.VerifySignatureBatch(ctx, args[:1], "")
+		require.NoError(t, err)
+		require.False(t, results[0].Valid)
+	})
+
+	t.Run("missing signature", func(t *testing.T) {
+		invalid := append([]VerifySignatureArgs(nil), args...)
+		invalid[3].Signature = nil
+		_, err := anyoneVerifier.VerifySignatureBatch(ctx, invalid, "")
+		require.ErrorContains(t, err, "payload 3")
+	})
+}
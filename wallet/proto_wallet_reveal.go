@@ -25,7 +25,13 @@ func (p *ProtoWallet) RevealCounterpartyKeyLinkage(
 	}
 
 	// Get the identity key (root key)
-	identityKey := p.keyDeriver.rootKey
+	var identityKey *ec.PrivateKey
+	if holder, ok := p.keyDeriver.(rootKeyHolder); ok {
+		identityKey = holder.rootPrivateKey()
+	}
+	if identityKey == nil {
+		return nil, fmt.Errorf("key deriver %T does not support counterparty key linkage revelation", p.keyDeriver)
+	}
 	proverPublicKey := identityKey.PubKey()
 
 	// Get the shared secret (linkage) as a point
@@ -34,7 +40,7 @@ func (p *ProtoWallet) RevealCounterpartyKeyLinkage(
 		Counterparty: args.Counterparty,
 	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to reveal counterparty secret: %v", err)
+		return nil, fmt.Errorf("failed to reveal counterparty secret: %w", err)
 	}
 
 	// Generate Schnorr proof
@@ -46,16 +52,7 @@ func (p *ProtoWallet) RevealCounterpartyKeyLinkage(
 
 	// Serialize the proof components
 	// Format: R compressed (33 bytes) || S' compressed (33 bytes) || z (32 bytes) = 98 bytes total
-	proofBytes := make([]byte, 0, 98)
-
-	// R point compressed
-	proofBytes = append(proofBytes, proof.R.Compressed()...)
-
-	// S' point compressed
-	proofBytes = append(proofBytes, proof.SPrime.Compressed()...)
-
-	// z value (32 bytes)
-	proofBytes = append(proofBytes, padTo32Bytes(proof.Z.Bytes())...)
+	proofBytes := encodeLinkageProof(proof)
 
 	// Create revelation time
 	revelationTime := time.Now().UTC().Format(time.RFC3339Nano)
@@ -67,7 +64,7 @@ func (p *ProtoWallet) RevealCounterpartyKeyLinkage(
 	encryptArgs := EncryptArgs{
 		Plaintext: linkageBytes,
 		EncryptionArgs: EncryptionArgs{
-			ProtocolID:   Protocol{SecurityLevel: 2, Protocol: "counterparty linkage revelation"},
+			ProtocolID:   counterpartyLinkageProtocol,
 			KeyID:        revelationTime,
 			Counterparty: Counterparty{Type: CounterpartyTypeOther, Counterparty: args.Verifier},
 		},
@@ -81,7 +78,7 @@ func (p *ProtoWallet) RevealCounterpartyKeyLinkage(
 	encryptProofArgs := EncryptArgs{
 		Plaintext: proofBytes,
 		EncryptionArgs: EncryptionArgs{
-			ProtocolID:   Protocol{SecurityLevel: 2, Protocol: "counterparty linkage revelation"},
+			ProtocolID:   counterpartyLinkageProtocol,
 			KeyID:        revelationTime,
 			Counterparty: Counterparty{Type: CounterpartyTypeOther, Counterparty: args.Verifier},
 		},
@@ -112,9 +109,8 @@ func (p *ProtoWallet) RevealSpecificKeyLinkage(
 		return nil, fmt.Errorf("verifier public key is required")
 	}
 
-	// Get the identity key (root key)
-	identityKey := p.keyDeriver.rootKey
-	proverPublicKey := identityKey.PubKey()
+	// Get the identity key
+	proverPublicKey := p.keyDeriver.IdentityKey()
 
 	// Validate counterparty
 	counterpartyPubKey, err := getCounterpartyPublicKey(args.Counterparty)
@@ -125,18 +121,15 @@ func (p *ProtoWallet) RevealSpecificKeyLinkage(
 	// Get the specific secret (linkage)
 	linkage, err := p.keyDeriver.RevealSpecificSecret(args.Counterparty, args.ProtocolID, args.KeyID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to reveal specific secret: %v", err)
+		return nil, fmt.Errorf("failed to reveal specific secret: %w", err)
 	}
 
 	// For specific key linkage, we use proof type 0 (no proof)
 	// Just a single byte array [0]
-	proofBytes := []byte{0}
+	proofBytes := []byte{KeyLinkageProofTypeNone}
 
 	// Create the special protocol ID for specific linkage revelation
-	encryptProtocolID := Protocol{
-		SecurityLevel: 2,
-		Protocol:      fmt.Sprintf("specific linkage revelation %d %s", args.ProtocolID.SecurityLevel, args.ProtocolID.Protocol),
-	}
+	encryptProtocolID := specificLinkageProtocol(args.ProtocolID)
 
 	// Encrypt the linkage for the verifier
 	encryptArgs := EncryptArgs{
@@ -174,7 +167,7 @@ func (p *ProtoWallet) RevealSpecificKeyLinkage(
 		Counterparty:          counterpartyPubKey,
 		ProtocolID:            args.ProtocolID,
 		KeyID:                 args.KeyID,
-		ProofType:             0, // No proof for specific linkage
+		ProofType:             KeyLinkageProofTypeNone,
 	}, nil
 }
 
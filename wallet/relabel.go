@@ -0,0 +1,181 @@
+package wallet
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+)
+
+// MaxRelabelLimit is the largest number of actions or outputs changed by a
+// single RelabelActions or RetagOutputs call.
+const MaxRelabelLimit = MaxActionsLimit
+
+// ErrInvalidRelabel is returned when the filter or the changes of
+// RelabelActionsArgs or RetagOutputsArgs are malformed.
+var ErrInvalidRelabel = errors.New("invalid relabel request")
+
+// ErrAdminUnsupported is returned by wallets forwarding AdminInterface calls
+// to a wallet which does not implement it.
+var ErrAdminUnsupported = errors.New("wallet does not support admin calls")
+
+// AdminInterface is implemented by wallets whose storage can change the labels
+// of actions and the tags of outputs in bulk, for migrating metadata schemes.
+// Each call changes at most Limit matching rows, so that storages holding
+// millions of them can apply a migration in bounded transactions, and returns
+// a NextCursor to continue from until it is empty.
+type AdminInterface interface {
+	RelabelActions(ctx context.Context, args RelabelActionsArgs, originator string) (*RelabelActionsResult, error)
+	RetagOutputs(ctx context.Context, args RetagOutputsArgs, originator string) (*RetagOutputsResult, error)
+}
+
+// RelabelActionsArgs adds and removes labels of the actions matching Labels.
+type RelabelActionsArgs struct {
+	// Labels selects the actions to relabel, combined according to
+	// LabelQueryMode as in ListActionsArgs. At least one label is required.
+	Labels         []string  `json:"labels"`
+	LabelQueryMode QueryMode `json:"labelQueryMode,omitempty"` // "any" | "all"
+	AddLabels      []string  `json:"addLabels,omitempty"`
+	RemoveLabels   []string  `json:"removeLabels,omitempty"`
+	Limit          *uint32   `json:"limit,omitempty"` // Default and max 10000
+	// Cursor continues from the NextCursor of a previous result.
+	Cursor string `json:"cursor,omitempty"`
+}
+
+// RelabelActionsResult reports the actions changed by a RelabelActions call.
+type RelabelActionsResult struct {
+	RelabeledActions uint32 `json:"relabeledActions"`
+	// NextCursor continues the relabeling when passed as Cursor. It is empty
+	// once every matching action has been processed.
+	NextCursor string `json:"nextCursor,omitempty"`
+}
+
+// RetagOutputsArgs adds and removes tags of the outputs matching Basket and
+// Tags.
+type RetagOutputsArgs struct {
+	// Basket and Tags select the outputs to retag, Tags being combined
+	// according to TagQueryMode as in ListOutputsArgs. An empty Basket matches
+	// every basket, but at least one of Basket and Tags is required.
+	Basket       string    `json:"basket,omitempty"`
+	Tags         []string  `json:"tags,omitempty"`
+	TagQueryMode QueryMode `json:"tagQueryMode,omitempty"` // "any" | "all"
+	AddTags      []string  `json:"addTags,omitempty"`
+	RemoveTags   []string  `json:"removeTags,omitempty"`
+	Limit        *uint32   `json:"limit,omitempty"` // Default and max 10000
+	// Cursor continues from the NextCursor of a previous result.
+	Cursor string `json:"cursor,omitempty"`
+}
+
+// RetagOutputsResult reports the outputs changed by a RetagOutputs call.
+type RetagOutputsResult struct {
+	RetaggedOutputs uint32 `json:"retaggedOutputs"`
+	// NextCursor continues the retagging when passed as Cursor. It is empty
+	// once every matching output has been processed.
+	NextCursor string `json:"nextCursor,omitempty"`
+}
+
+// Validate checks the filter, the changes and the limit of the args.
+func (args *RelabelActionsArgs) Validate() error {
+	if len(args.Labels) == 0 {
+		return fmt.Errorf("%w: at least one label to match is required", ErrInvalidRelabel)
+	}
+	return validateRelabel(args.LabelQueryMode, args.AddLabels, args.RemoveLabels, args.Limit, "label")
+}
+
+// Matches reports whether an action with the given labels is selected by the
+// args.
+func (args *RelabelActionsArgs) Matches(labels []string) bool {
+	return matchesQuery(labels, args.Labels, args.LabelQueryMode)
+}
+
+// Apply returns the labels of an action once relabeled, and whether they
+// changed. Removals are applied before additions.
+func (args *RelabelActionsArgs) Apply(labels []string) ([]string, bool) {
+	return applyChanges(labels, args.AddLabels, args.RemoveLabels)
+}
+
+// Validate checks the filter, the changes and the limit of the args.
+func (args *RetagOutputsArgs) Validate() error {
+	if args.Basket == "" && len(args.Tags) == 0 {
+		return fmt.Errorf("%w: a basket or at least one tag to match is required", ErrInvalidRelabel)
+	}
+	return validateRelabel(args.TagQueryMode, args.AddTags, args.RemoveTags, args.Limit, "tag")
+}
+
+// Matches reports whether an output in basket with the given tags is selected
+// by the args.
+func (args *RetagOutputsArgs) Matches(basket string, tags []string) bool {
+	if args.Basket != "" && basket != args.Basket {
+		return false
+	}
+	return len(args.Tags) == 0 || matchesQuery(tags, args.Tags, args.TagQueryMode)
+}
+
+// Apply returns the tags of an output once retagged, and whether they changed.
+// Removals are applied before additions.
+func (args *RetagOutputsArgs) Apply(tags []string) ([]string, bool) {
+	return applyChanges(tags, args.AddTags, args.RemoveTags)
+}
+
+func validateRelabel(mode QueryMode, add, remove []string, limit *uint32, kind string) error {
+	if _, err := QueryModeFromString(string(mode)); err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidRelabel, err)
+	}
+	if len(add) == 0 && len(remove) == 0 {
+		return fmt.Errorf("%w: at least one %s to add or remove is required", ErrInvalidRelabel, kind)
+	}
+	for _, v := range slices.Concat(add, remove) {
+		if v == "" {
+			return fmt.Errorf("%w: empty %s", ErrInvalidRelabel, kind)
+		}
+	}
+	for _, v := range add {
+		if slices.Contains(remove, v) {
+			return fmt.Errorf("%w: %s %q is both added and removed", ErrInvalidRelabel, kind, v)
+		}
+	}
+	if limit != nil && (*limit == 0 || *limit > MaxRelabelLimit) {
+		return fmt.Errorf("%w: limit must be between 1 and %d", ErrInvalidRelabel, MaxRelabelLimit)
+	}
+	return nil
+}
+
+// matchesQuery reports whether have contains any of want, or all of them for
+// QueryModeAll.
+func matchesQuery(have, want []string, mode QueryMode) bool {
+	if mode == QueryModeAll {
+		for _, w := range want {
+			if !slices.Contains(have, w) {
+				return false
+			}
+		}
+		return true
+	}
+	for _, w := range want {
+		if slices.Contains(have, w) {
+			return true
+		}
+	}
+	return false
+}
+
+// applyChanges removes then adds values, keeping the order of values and
+// appending new ones in the order of add.
+func applyChanges(values, add, remove []string) ([]string, bool) {
+	result := make([]string, 0, len(values)+len(add))
+	changed := false
+	for _, v := range values {
+		if slices.Contains(remove, v) {
+			changed = true
+			continue
+		}
+		result = append(result, v)
+	}
+	for _, v := range add {
+		if !slices.Contains(result, v) {
+			result = append(result, v)
+			changed = true
+		}
+	}
+	return result, changed
+}
@@ -0,0 +1,60 @@
+package wallet
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRelabelActionsArgs(t *testing.T) {
+	args := &RelabelActionsArgs{
+		Labels:         []string{"payment", "v1"},
+		LabelQueryMode: QueryModeAll,
+		AddLabels:      []string{"v2"},
+		RemoveLabels:   []string{"v1"},
+	}
+	require.NoError(t, args.Validate())
+	require.True(t, args.Matches([]string{"v1", "payment", "other"}))
+	require.False(t, args.Matches([]string{"payment"}))
+
+	labels, changed := args.Apply([]string{"payment", "v1"})
+	require.True(t, changed)
+	require.Equal(t, []string{"payment", "v2"}, labels)
+	_, changed = args.Apply([]string{"payment", "v2"})
+	require.False(t, changed)
+
+	args.LabelQueryMode = QueryModeAny
+	require.True(t, args.Matches([]string{"v1"}))
+	require.False(t, args.Matches(nil))
+}
+
+func TestRetagOutputsArgs(t *testing.T) {
+	args := &RetagOutputsArgs{Basket: "tokens", AddTags: []string{"migrated"}}
+	require.NoError(t, args.Validate())
+	require.True(t, args.Matches("tokens", nil))
+	require.False(t, args.Matches("default", nil))
+
+	args.Tags = []string{"old", "legacy"}
+	require.True(t, args.Matches("tokens", []string{"legacy"}))
+	require.False(t, args.Matches("tokens", []string{"new"}))
+
+	tags, changed := args.Apply([]string{"legacy"})
+	require.True(t, changed)
+	require.Equal(t, []string{"legacy", "migrated"}, tags)
+}
+
+func TestRelabelValidate(t *testing.T) {
+	zero, tooMany := uint32(0), uint32(MaxRelabelLimit+1)
+	for name, err := range map[string]error{
+		"no filter":        (&RelabelActionsArgs{AddLabels: []string{"a"}}).Validate(),
+		"no changes":       (&RelabelActionsArgs{Labels: []string{"a"}}).Validate(),
+		"empty label":      (&RelabelActionsArgs{Labels: []string{"a"}, AddLabels: []string{""}}).Validate(),
+		"add and remove":   (&RelabelActionsArgs{Labels: []string{"a"}, AddLabels: []string{"b"}, RemoveLabels: []string{"b"}}).Validate(),
+		"query mode":       (&RelabelActionsArgs{Labels: []string{"a"}, LabelQueryMode: "some", AddLabels: []string{"b"}}).Validate(),
+		"zero limit":       (&RetagOutputsArgs{Basket: "a", AddTags: []string{"b"}, Limit: &zero}).Validate(),
+		"limit":            (&RetagOutputsArgs{Basket: "a", AddTags: []string{"b"}, Limit: &tooMany}).Validate(),
+		"no output filter": (&RetagOutputsArgs{AddTags: []string{"b"}}).Validate(),
+	} {
+		require.ErrorIs(t, err, ErrInvalidRelabel, name)
+	}
+}
@@ -0,0 +1,95 @@
+package rotation
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/bsv-blockchain/go-sdk/auth/certificates"
+	ec "github.com/bsv-blockchain/go-sdk/primitives/ec"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+	"github.com/bsv-blockchain/go-sdk/wallet"
+)
+
+// ReissueCertificates re-issues the self-signed certificates of the old
+// identity for the new one, and stores them in the new wallet. Each keeps its
+// type, fields and revocation outpoint, and gets a fresh serial number. The old
+// certificates are left in the old wallet.
+func ReissueCertificates(ctx context.Context, oldWallet, newWallet wallet.Interface, originator string) ([]*wallet.Certificate, error) {
+	oldKey, err := identityKey(ctx, oldWallet, originator)
+	if err != nil {
+		return nil, err
+	}
+	self := wallet.Counterparty{Type: wallet.CounterpartyTypeSelf}
+
+	var reissued []*wallet.Certificate
+	limit := uint32(100)
+	for offset := uint32(0); ; {
+		page, err := oldWallet.ListCertificates(ctx, wallet.ListCertificatesArgs{
+			Certifiers: []*ec.PublicKey{oldKey},
+			Limit:      &limit,
+			Offset:     &offset,
+		}, originator)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list certificates: %w", err)
+		}
+
+		for _, listed := range page.Certificates {
+			if listed.Subject == nil || !listed.Subject.IsEqual(oldKey) {
+				continue
+			}
+			old, err := certificates.FromWalletCertificate(&listed.Certificate)
+			if err != nil {
+				return nil, err
+			}
+			keyring := make(map[wallet.CertificateFieldNameUnder50Bytes]wallet.StringBase64, len(listed.Keyring))
+			for name, key := range listed.Keyring {
+				keyring[wallet.CertificateFieldNameUnder50Bytes(name)] = wallet.StringBase64(key)
+			}
+			fields, err := certificates.DecryptFields(ctx, oldWallet, keyring, old.Fields, self, false, "")
+			if err != nil {
+				return nil, fmt.Errorf("failed to decrypt certificate %s: %w", old.SerialNumber, err)
+			}
+			plain := make(map[string]string, len(fields))
+			for name, value := range fields {
+				plain[string(name)] = value
+			}
+
+			issued, err := certificates.IssueCertificateForSubject(ctx, newWallet, self, plain, string(old.Type),
+				func(string) (*transaction.Outpoint, error) { return old.RevocationOutpoint, nil }, "")
+			if err != nil {
+				return nil, fmt.Errorf("failed to re-issue certificate %s: %w", old.SerialNumber, err)
+			}
+			cert, err := issued.ToWalletCertificate()
+			if err != nil {
+				return nil, err
+			}
+			keyringForSubject := make(map[string]string, len(issued.MasterKeyring))
+			for name, key := range issued.MasterKeyring {
+				keyringForSubject[string(name)] = string(key)
+			}
+			acquired, err := newWallet.AcquireCertificate(ctx, wallet.AcquireCertificateArgs{
+				Type:                cert.Type,
+				Certifier:           cert.Certifier,
+				AcquisitionProtocol: wallet.AcquisitionProtocolDirect,
+				Fields:              cert.Fields,
+				SerialNumber:        &cert.SerialNumber,
+				RevocationOutpoint:  cert.RevocationOutpoint,
+				Signature:           cert.Signature,
+				KeyringRevealer:     &wallet.KeyringRevealer{Certifier: true},
+				KeyringForSubject:   keyringForSubject,
+			}, originator)
+			if err != nil {
+				return nil, fmt.Errorf("failed to store re-issued certificate %s: %w", old.SerialNumber, err)
+			}
+			if acquired == nil {
+				acquired = cert
+			}
+			reissued = append(reissued, acquired)
+		}
+
+		offset += uint32(len(page.Certificates))
+		if len(page.Certificates) == 0 || offset >= page.TotalCertificates {
+			return reissued, nil
+		}
+	}
+}
@@ -0,0 +1,64 @@
+// Package rotation rotates the root key of a long-lived identity. Given the
+// wallets of the old and new root keys, it produces a Statement signed by both
+// identity keys binding the new identity to the old one, re-issues the old
+// identity's self-signed certificates for the new identity, and sweeps the old
+// wallet's balance to the new one.
+package rotation
+
+import (
+	"context"
+	"errors"
+
+	"github.com/bsv-blockchain/go-sdk/wallet"
+)
+
+// Options configures Rotate.
+type Options struct {
+	// FeeRate is the fee rate the sweep reserves fees at, in satoshis per
+	// kilobyte, DefaultFeeRate when zero.
+	FeeRate uint64
+	// SkipSweep leaves the balance in the old wallet.
+	SkipSweep bool
+	// Baskets are the baskets swept, DefaultBasket when empty.
+	Baskets []string
+	// SkipCertificates does not re-issue certificates.
+	SkipCertificates bool
+	// Originator is passed to the wallets.
+	Originator string
+}
+
+// Result describes a completed rotation.
+type Result struct {
+	Statement    *Statement
+	Certificates []*wallet.Certificate
+	// Sweep is nil when the sweep was skipped or there was nothing to sweep.
+	Sweep *SweepResult
+}
+
+// Rotate rotates the identity of oldWallet to that of newWallet: it creates the
+// linkage statement, re-issues the self-signed certificates, then sweeps the
+// balance. A failure leaves the steps before it done, so Rotate can be retried
+// with the steps already done skipped.
+func Rotate(ctx context.Context, oldWallet, newWallet wallet.Interface, opts Options) (*Result, error) {
+	statement, err := NewStatement(ctx, oldWallet, newWallet, opts.Originator)
+	if err != nil {
+		return nil, err
+	}
+	result := &Result{Statement: statement}
+
+	if !opts.SkipCertificates {
+		if result.Certificates, err = ReissueCertificates(ctx, oldWallet, newWallet, opts.Originator); err != nil {
+			return result, err
+		}
+	}
+	if !opts.SkipSweep {
+		result.Sweep, err = Sweep(ctx, oldWallet, newWallet, opts.FeeRate, opts.Originator, opts.Baskets...)
+		if errors.Is(err, ErrNothingToSweep) {
+			err = nil
+		}
+		if err != nil {
+			return result, err
+		}
+	}
+	return result, nil
+}
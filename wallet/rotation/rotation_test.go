@@ -0,0 +1,218 @@
+package rotation_test
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"testing"
+
+	"github.com/bsv-blockchain/go-sdk/auth/certificates"
+	"github.com/bsv-blockchain/go-sdk/chainhash"
+	ec "github.com/bsv-blockchain/go-sdk/primitives/ec"
+	"github.com/bsv-blockchain/go-sdk/script"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+	"github.com/bsv-blockchain/go-sdk/wallet"
+	"github.com/bsv-blockchain/go-sdk/wallet/rotation"
+	"github.com/stretchr/testify/require"
+)
+
+func identityKey(t *testing.T, w wallet.KeyOperations) *ec.PublicKey {
+	t.Helper()
+	res, err := w.GetPublicKey(t.Context(), wallet.GetPublicKeyArgs{IdentityKey: true}, "")
+	require.NoError(t, err)
+	return res.PublicKey
+}
+
+// withOutputs makes w list outputs in its default basket, two per page, and
+// create actions paying their outputs plus change.
+func withOutputs(t *testing.T, w *wallet.TestWallet, outputs []wallet.Output) {
+	withBaskets(t, w, map[string][]wallet.Output{rotation.DefaultBasket: outputs})
+}
+
+// withBaskets is withOutputs for outputs in several baskets.
+func withBaskets(t *testing.T, w *wallet.TestWallet, baskets map[string][]wallet.Output) {
+	w.OnListOutputs().Do(func(_ context.Context, args wallet.ListOutputsArgs, _ string) (*wallet.ListOutputsResult, error) {
+		outputs, ok := baskets[args.Basket]
+		require.True(t, ok, "unexpected basket %q", args.Basket)
+		offset := int(*args.Offset)
+		end := min(offset+2, len(outputs))
+		return &wallet.ListOutputsResult{TotalOutputs: uint32(len(outputs)), Outputs: outputs[offset:end]}, nil
+	})
+	w.OnCreateAction().Do(func(_ context.Context, args wallet.CreateActionArgs, _ string) (*wallet.CreateActionResult, error) {
+		tx := transaction.NewTransaction()
+		tx.AddOutput(&transaction.TransactionOutput{Satoshis: 7, LockingScript: &script.Script{script.OpTRUE}})
+		for _, output := range args.Outputs {
+			tx.AddOutput(&transaction.TransactionOutput{Satoshis: output.Satoshis, LockingScript: script.NewFromBytes(output.LockingScript)})
+		}
+		beef, err := tx.AtomicBEEF(true)
+		require.NoError(t, err)
+		return &wallet.CreateActionResult{Txid: *tx.TxID(), Tx: beef}, nil
+	})
+}
+
+func TestSweep(t *testing.T) {
+	ctx := t.Context()
+	oldWallet := wallet.NewTestWalletForRandomKey(t)
+	newWallet := wallet.NewTestWalletForRandomKey(t)
+	withOutputs(t, oldWallet, []wallet.Output{
+		{Satoshis: 1000, Spendable: true},
+		{Satoshis: 500},
+		{Satoshis: 2000, Spendable: true},
+	})
+	var internalized wallet.InternalizeActionArgs
+	newWallet.OnInternalizeAction().Do(func(_ context.Context, args wallet.InternalizeActionArgs, _ string) (*wallet.InternalizeActionResult, error) {
+		internalized = args
+		return &wallet.InternalizeActionResult{Accepted: true}, nil
+	})
+
+	result, err := rotation.Sweep(ctx, oldWallet, newWallet, 0, "")
+	require.NoError(t, err)
+	// Two inputs at 148 bytes and 78 bytes of overhead at 100 sat/kB.
+	require.Equal(t, uint64(3000-38), result.Satoshis)
+	require.Equal(t, uint32(1), result.OutputIndex)
+	require.True(t, result.Remittance.SenderIdentityKey.IsEqual(identityKey(t, oldWallet)))
+
+	require.Len(t, internalized.Outputs, 1)
+	require.Equal(t, uint32(1), internalized.Outputs[0].OutputIndex)
+	require.Equal(t, wallet.InternalizeProtocolWalletPayment, internalized.Outputs[0].Protocol)
+	require.Equal(t, &result.Remittance, internalized.Outputs[0].PaymentRemittance)
+	tx, err := transaction.NewTransactionFromBEEF(internalized.Tx)
+	require.NoError(t, err)
+	require.Equal(t, result.Txid, *tx.TxID())
+
+	t.Run("several baskets", func(t *testing.T) {
+		w := wallet.NewTestWalletForRandomKey(t)
+		withBaskets(t, w, map[string][]wallet.Output{
+			rotation.DefaultBasket: {{Satoshis: 1000, Spendable: true}},
+			"savings":              {{Satoshis: 4000, Spendable: true}, {Satoshis: 300}, {Satoshis: 2000, Spendable: true}},
+		})
+		result, err := rotation.Sweep(ctx, w, newWallet, 0, "", "savings", rotation.DefaultBasket, "savings")
+		require.NoError(t, err)
+		// Three inputs at 148 bytes and 78 bytes of overhead at 100 sat/kB.
+		require.Equal(t, uint64(7000-53), result.Satoshis)
+
+		result, err = rotation.Sweep(ctx, w, newWallet, 0, "", "savings")
+		require.NoError(t, err)
+		require.Equal(t, uint64(6000-38), result.Satoshis)
+	})
+
+	t.Run("nothing to sweep", func(t *testing.T) {
+		empty := wallet.NewTestWalletForRandomKey(t)
+		withOutputs(t, empty, []wallet.Output{{Satoshis: 20, Spendable: true}})
+		_, err := rotation.Sweep(ctx, empty, newWallet, 0, "")
+		require.ErrorIs(t, err, rotation.ErrNothingToSweep)
+	})
+
+	t.Run("BEEF without the sweep transaction", func(t *testing.T) {
+		w := wallet.NewTestWalletForRandomKey(t)
+		withOutputs(t, w, []wallet.Output{{Satoshis: 1000, Spendable: true}})
+		w.OnCreateAction().Do(func(_ context.Context, _ wallet.CreateActionArgs, _ string) (*wallet.CreateActionResult, error) {
+			tx := transaction.NewTransaction()
+			tx.AddOutput(&transaction.TransactionOutput{Satoshis: 7, LockingScript: &script.Script{script.OpTRUE}})
+			beef, err := tx.AtomicBEEF(true)
+			require.NoError(t, err)
+			// Name a subject transaction the BEEF does not contain.
+			copy(beef[4:36], make([]byte, 32))
+			return &wallet.CreateActionResult{Tx: beef}, nil
+		})
+		_, err := rotation.Sweep(ctx, w, newWallet, 0, "")
+		require.ErrorContains(t, err, "without the sweep transaction")
+	})
+}
+
+func randomBase64(t *testing.T) string {
+	b := make([]byte, 32)
+	_, err := rand.Read(b)
+	require.NoError(t, err)
+	return base64.StdEncoding.EncodeToString(b)
+}
+
+// listedCertificate issues a certificate with w as certifier, as the wallet
+// would list it.
+func listedCertificate(t *testing.T, w *wallet.TestWallet, subject wallet.Counterparty, fields map[string]string, revocation *transaction.Outpoint) wallet.CertificateResult {
+	issued, err := certificates.IssueCertificateForSubject(t.Context(), w, subject, fields, randomBase64(t),
+		func(string) (*transaction.Outpoint, error) { return revocation, nil }, "")
+	require.NoError(t, err)
+	cert, err := issued.ToWalletCertificate()
+	require.NoError(t, err)
+	keyring := make(map[string]string)
+	for name, key := range issued.MasterKeyring {
+		keyring[string(name)] = string(key)
+	}
+	return wallet.CertificateResult{Certificate: *cert, Keyring: keyring}
+}
+
+func TestReissueCertificates(t *testing.T) {
+	ctx := t.Context()
+	oldWallet := wallet.NewTestWalletForRandomKey(t)
+	newWallet := wallet.NewTestWalletForRandomKey(t)
+	subject := wallet.NewTestWalletForRandomKey(t)
+
+	fields := map[string]string{"name": "Alice", "email": "alice@example.com"}
+	revocation := &transaction.Outpoint{Txid: chainhash.DoubleHashH([]byte("revocation")), Index: 1}
+	selfSigned := listedCertificate(t, oldWallet, wallet.Counterparty{Type: wallet.CounterpartyTypeSelf}, fields, revocation)
+	issuedToOther := listedCertificate(t, oldWallet, wallet.Counterparty{Type: wallet.CounterpartyTypeOther, Counterparty: identityKey(t, subject)}, fields, revocation)
+	oldWallet.OnListCertificates().Do(func(_ context.Context, args wallet.ListCertificatesArgs, _ string) (*wallet.ListCertificatesResult, error) {
+		require.Len(t, args.Certifiers, 1)
+		require.True(t, args.Certifiers[0].IsEqual(identityKey(t, oldWallet)))
+		return &wallet.ListCertificatesResult{
+			TotalCertificates: 2,
+			Certificates:      []wallet.CertificateResult{issuedToOther, selfSigned}[*args.Offset:],
+		}, nil
+	})
+
+	var acquired wallet.AcquireCertificateArgs
+	newWallet.OnAcquireCertificate().Do(func(_ context.Context, args wallet.AcquireCertificateArgs, _ string) (*wallet.Certificate, error) {
+		acquired = args
+		return &wallet.Certificate{
+			Type:               args.Type,
+			SerialNumber:       *args.SerialNumber,
+			Subject:            args.Certifier,
+			Certifier:          args.Certifier,
+			RevocationOutpoint: args.RevocationOutpoint,
+			Fields:             args.Fields,
+			Signature:          args.Signature,
+		}, nil
+	})
+
+	reissued, err := rotation.ReissueCertificates(ctx, oldWallet, newWallet, "")
+	require.NoError(t, err)
+	require.Len(t, reissued, 1)
+	require.Equal(t, wallet.AcquisitionProtocolDirect, acquired.AcquisitionProtocol)
+
+	cert := reissued[0]
+	require.Equal(t, selfSigned.Type, cert.Type)
+	require.NotEqual(t, selfSigned.SerialNumber, cert.SerialNumber)
+	require.True(t, cert.Certifier.IsEqual(identityKey(t, newWallet)))
+	require.Equal(t, revocation, cert.RevocationOutpoint)
+
+	parsed, err := certificates.FromWalletCertificate(cert)
+	require.NoError(t, err)
+	require.NoError(t, parsed.Verify(ctx))
+	keyring := make(map[wallet.CertificateFieldNameUnder50Bytes]wallet.StringBase64)
+	for name, key := range acquired.KeyringForSubject {
+		keyring[wallet.CertificateFieldNameUnder50Bytes(name)] = wallet.StringBase64(key)
+	}
+	decrypted, err := certificates.DecryptFields(ctx, newWallet, keyring, parsed.Fields, wallet.Counterparty{Type: wallet.CounterpartyTypeSelf}, false, "")
+	require.NoError(t, err)
+	require.Len(t, decrypted, len(fields))
+	for name, value := range fields {
+		require.Equal(t, value, decrypted[wallet.CertificateFieldNameUnder50Bytes(name)])
+	}
+}
+
+func TestRotate(t *testing.T) {
+	ctx := t.Context()
+	oldWallet := wallet.NewTestWalletForRandomKey(t)
+	newWallet := wallet.NewTestWalletForRandomKey(t)
+	withOutputs(t, oldWallet, nil)
+
+	result, err := rotation.Rotate(ctx, oldWallet, newWallet, rotation.Options{})
+	require.NoError(t, err)
+	require.NoError(t, result.Statement.Verify(ctx))
+	require.Empty(t, result.Certificates)
+	require.Nil(t, result.Sweep)
+
+	_, err = rotation.Rotate(ctx, oldWallet, oldWallet, rotation.Options{})
+	require.ErrorIs(t, err, rotation.ErrSameIdentity)
+}
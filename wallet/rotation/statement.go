@@ -0,0 +1,144 @@
+package rotation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	ec "github.com/bsv-blockchain/go-sdk/primitives/ec"
+	"github.com/bsv-blockchain/go-sdk/wallet"
+)
+
+// LinkageProtocol is the protocol of the keys signing linkage statements,
+// derived from each identity key for anyone to verify.
+var LinkageProtocol = wallet.Protocol{
+	SecurityLevel: wallet.SecurityLevelEveryAppAndCounterparty,
+	Protocol:      "identity key rotation",
+}
+
+// LinkageKeyID is the key ID of the keys signing linkage statements.
+const LinkageKeyID = "1"
+
+var (
+	ErrSameIdentity     = errors.New("old and new identity keys are the same")
+	ErrInvalidStatement = errors.New("invalid linkage statement")
+)
+
+// Statement binds an old identity key to the new one replacing it. It is signed
+// by both keys, so that it proves the owner of the old identity authorized the
+// rotation and the owner of the new one accepted it.
+type Statement struct {
+	OldIdentityKey *ec.PublicKey `json:"oldIdentityKey"`
+	NewIdentityKey *ec.PublicKey `json:"newIdentityKey"`
+	// Timestamp is when the rotation took effect, to the second.
+	Timestamp time.Time `json:"timestamp"`
+	// OldSignature and NewSignature are the DER encoded signatures of Message by
+	// the old and new identity keys.
+	OldSignature []byte `json:"oldSignature"`
+	NewSignature []byte `json:"newSignature"`
+}
+
+// Message returns the data signed by both keys.
+func (s *Statement) Message() []byte {
+	return fmt.Appendf(nil, "%s\n%s\n%s\n%s", LinkageProtocol.Protocol,
+		s.OldIdentityKey.ToDERHex(), s.NewIdentityKey.ToDERHex(), s.Timestamp.UTC().Format(time.RFC3339))
+}
+
+// NewStatement creates a linkage statement from the old identity to the new one,
+// signed with both wallets.
+func NewStatement(ctx context.Context, oldWallet, newWallet wallet.KeyOperations, originator string) (*Statement, error) {
+	oldKey, err := identityKey(ctx, oldWallet, originator)
+	if err != nil {
+		return nil, err
+	}
+	newKey, err := identityKey(ctx, newWallet, originator)
+	if err != nil {
+		return nil, err
+	}
+	if oldKey.IsEqual(newKey) {
+		return nil, ErrSameIdentity
+	}
+
+	s := &Statement{
+		OldIdentityKey: oldKey,
+		NewIdentityKey: newKey,
+		Timestamp:      time.Now().UTC().Truncate(time.Second),
+	}
+	if s.OldSignature, err = signStatement(ctx, oldWallet, s.Message(), originator); err != nil {
+		return nil, fmt.Errorf("failed to sign with old identity: %w", err)
+	}
+	if s.NewSignature, err = signStatement(ctx, newWallet, s.Message(), originator); err != nil {
+		return nil, fmt.Errorf("failed to sign with new identity: %w", err)
+	}
+	return s, nil
+}
+
+// Verify checks that both identity keys signed the statement.
+func (s *Statement) Verify(ctx context.Context) error {
+	if s.OldIdentityKey == nil || s.NewIdentityKey == nil || len(s.OldSignature) == 0 || len(s.NewSignature) == 0 {
+		return fmt.Errorf("%w: missing keys or signatures", ErrInvalidStatement)
+	}
+	if s.OldIdentityKey.IsEqual(s.NewIdentityKey) {
+		return fmt.Errorf("%w: %w", ErrInvalidStatement, ErrSameIdentity)
+	}
+	verifier, err := wallet.NewProtoWallet(wallet.ProtoWalletArgs{Type: wallet.ProtoWalletArgsTypeAnyone})
+	if err != nil {
+		return fmt.Errorf("failed to create verifier wallet: %w", err)
+	}
+	for _, signed := range []struct {
+		name      string
+		key       *ec.PublicKey
+		signature []byte
+	}{
+		{"old", s.OldIdentityKey, s.OldSignature},
+		{"new", s.NewIdentityKey, s.NewSignature},
+	} {
+		signature, err := ec.ParseDERSignature(signed.signature)
+		if err != nil {
+			return fmt.Errorf("%w: %s signature: %w", ErrInvalidStatement, signed.name, err)
+		}
+		result, err := verifier.VerifySignature(ctx, wallet.VerifySignatureArgs{
+			EncryptionArgs: wallet.EncryptionArgs{
+				ProtocolID: LinkageProtocol,
+				KeyID:      LinkageKeyID,
+				Counterparty: wallet.Counterparty{
+					Type:         wallet.CounterpartyTypeOther,
+					Counterparty: signed.key,
+				},
+			},
+			Data:      s.Message(),
+			Signature: signature,
+		}, "")
+		if err != nil {
+			return fmt.Errorf("%w: %s signature: %w", ErrInvalidStatement, signed.name, err)
+		}
+		if !result.Valid {
+			return fmt.Errorf("%w: %s signature does not verify", ErrInvalidStatement, signed.name)
+		}
+	}
+	return nil
+}
+
+func signStatement(ctx context.Context, w wallet.KeyOperations, message []byte, originator string) ([]byte, error) {
+	result, err := w.CreateSignature(ctx, wallet.CreateSignatureArgs{
+		EncryptionArgs: wallet.EncryptionArgs{
+			ProtocolID:   LinkageProtocol,
+			KeyID:        LinkageKeyID,
+			Counterparty: wallet.Counterparty{Type: wallet.CounterpartyTypeAnyone},
+		},
+		Data: message,
+	}, originator)
+	if err != nil {
+		return nil, err
+	}
+	return result.Signature.Serialize(), nil
+}
+
+func identityKey(ctx context.Context, w wallet.PublicKeyGetter, originator string) (*ec.PublicKey, error) {
+	result, err := w.GetPublicKey(ctx, wallet.GetPublicKeyArgs{IdentityKey: true}, originator)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get identity key: %w", err)
+	}
+	return result.PublicKey, nil
+}
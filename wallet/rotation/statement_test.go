@@ -0,0 +1,51 @@
+package rotation_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/bsv-blockchain/go-sdk/wallet"
+	"github.com/bsv-blockchain/go-sdk/wallet/rotation"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStatement(t *testing.T) {
+	ctx := t.Context()
+	oldWallet := wallet.NewTestWalletForRandomKey(t)
+	newWallet := wallet.NewTestWalletForRandomKey(t)
+
+	statement, err := rotation.NewStatement(ctx, oldWallet, newWallet, "")
+	require.NoError(t, err)
+	require.True(t, statement.OldIdentityKey.IsEqual(identityKey(t, oldWallet)))
+	require.True(t, statement.NewIdentityKey.IsEqual(identityKey(t, newWallet)))
+	require.NoError(t, statement.Verify(ctx))
+
+	t.Run("json", func(t *testing.T) {
+		data, err := json.Marshal(statement)
+		require.NoError(t, err)
+		var decoded rotation.Statement
+		require.NoError(t, json.Unmarshal(data, &decoded))
+		require.NoError(t, decoded.Verify(ctx))
+		require.Equal(t, statement.Message(), decoded.Message())
+	})
+
+	t.Run("tampered", func(t *testing.T) {
+		swapped := *statement
+		swapped.OldIdentityKey, swapped.NewIdentityKey = statement.NewIdentityKey, statement.OldIdentityKey
+		require.ErrorIs(t, swapped.Verify(ctx), rotation.ErrInvalidStatement)
+
+		other := wallet.NewTestWalletForRandomKey(t)
+		redirected := *statement
+		redirected.NewIdentityKey = identityKey(t, other)
+		require.ErrorIs(t, redirected.Verify(ctx), rotation.ErrInvalidStatement)
+
+		unsigned := *statement
+		unsigned.NewSignature = nil
+		require.ErrorIs(t, unsigned.Verify(ctx), rotation.ErrInvalidStatement)
+	})
+
+	t.Run("same identity", func(t *testing.T) {
+		_, err := rotation.NewStatement(ctx, oldWallet, oldWallet, "")
+		require.ErrorIs(t, err, rotation.ErrSameIdentity)
+	})
+}
@@ -0,0 +1,162 @@
+package rotation
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/bsv-blockchain/go-sdk/chainhash"
+	"github.com/bsv-blockchain/go-sdk/payments/brc29"
+	"github.com/bsv-blockchain/go-sdk/primitives/amount"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+	"github.com/bsv-blockchain/go-sdk/wallet"
+)
+
+// DefaultBasket is the basket swept when none is given, holding the change the
+// wallet funds actions with.
+const DefaultBasket = "default"
+
+// DefaultFeeRate is the fee rate, in satoshis per kilobyte, the sweep reserves
+// fees at when none is given.
+const DefaultFeeRate = 100
+
+// ErrNothingToSweep is returned by Sweep when the old wallet has no balance
+// beyond the fee reserve.
+var ErrNothingToSweep = errors.New("nothing to sweep")
+
+// Estimated serialized sizes for reserving the sweep fee: a P2PKH input, and
+// the transaction overhead with the payment and a change output.
+const (
+	inputSize    = 148
+	overheadSize = 10 + 2*34
+)
+
+// SweepResult describes the sweep transaction.
+type SweepResult struct {
+	Txid        chainhash.Hash
+	OutputIndex uint32
+	Satoshis    uint64
+	// Remittance is the BRC-29 derivation of the key the new identity received
+	// the sweep with.
+	Remittance wallet.Payment
+}
+
+// Sweep moves the spendable balance of the given baskets of the old wallet,
+// DefaultBasket when none is given, to the new identity. The old wallet pays it
+// to a key derived for the new identity with BRC-29, and the payment is
+// internalized into the new wallet.
+//
+// The old wallet funds the payment and its fee itself, so it must fund actions
+// from every basket swept. The swept amount is the balance less a fee reserve
+// estimated at feeRate satoshis per kilobyte, DefaultFeeRate when zero, for
+// spending every output. What the fee does not use stays in the old wallet as
+// change.
+func Sweep(ctx context.Context, oldWallet, newWallet wallet.Interface, feeRate uint64, originator string, baskets ...string) (*SweepResult, error) {
+	if feeRate == 0 {
+		feeRate = DefaultFeeRate
+	}
+	if len(baskets) == 0 {
+		baskets = []string{DefaultBasket}
+	}
+	balance, count, err := spendableBalance(ctx, oldWallet, baskets, originator)
+	if err != nil {
+		return nil, err
+	}
+	reserve := ((inputSize*count+overheadSize)*feeRate + 999) / 1000
+	if balance <= reserve {
+		return nil, fmt.Errorf("%w: balance of %d satoshis does not cover the fee reserve of %d", ErrNothingToSweep, balance, reserve)
+	}
+
+	newKey, err := identityKey(ctx, newWallet, originator)
+	if err != nil {
+		return nil, err
+	}
+	payment, err := brc29.NewPayment(ctx, oldWallet, newKey, originator)
+	if err != nil {
+		return nil, err
+	}
+	satoshis := balance - reserve
+	created, err := oldWallet.CreateAction(ctx, wallet.CreateActionArgs{
+		Description: "Sweep to rotated identity key",
+		Outputs: []wallet.CreateActionOutput{{
+			LockingScript:     payment.LockingScript.Bytes(),
+			Satoshis:          satoshis,
+			OutputDescription: "Rotated identity key",
+		}},
+		Labels: []string{"identity rotation"},
+	}, originator)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create sweep transaction: %w", err)
+	}
+	if created == nil || len(created.Tx) == 0 {
+		return nil, errors.New("wallet did not return the sweep transaction")
+	}
+
+	tx, err := transaction.NewTransactionFromBEEF(created.Tx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse sweep transaction: %w", err)
+	}
+	if tx == nil {
+		return nil, errors.New("wallet returned a BEEF without the sweep transaction")
+	}
+	vout := -1
+	for i, output := range tx.Outputs {
+		if output.Satoshis == satoshis && bytes.Equal(output.LockingScript.Bytes(), payment.LockingScript.Bytes()) {
+			vout = i
+			break
+		}
+	}
+	if vout < 0 {
+		return nil, errors.New("sweep transaction does not pay the new identity")
+	}
+	if _, err := brc29.Internalize(ctx, newWallet, created.Tx, uint32(vout), &payment.Remittance, "Sweep from rotated identity key", originator); err != nil {
+		return nil, err
+	}
+	return &SweepResult{
+		Txid:        *tx.TxID(),
+		OutputIndex: uint32(vout),
+		Satoshis:    satoshis,
+		Remittance:  payment.Remittance,
+	}, nil
+}
+
+// spendableBalance totals the spendable outputs of the baskets.
+func spendableBalance(ctx context.Context, w wallet.Interface, baskets []string, originator string) (uint64, uint64, error) {
+	var total amount.Satoshis
+	var count uint64
+	for _, basket := range slices.Compact(slices.Sorted(slices.Values(baskets))) {
+		limit := uint32(10000)
+		args := wallet.ListOutputsArgs{Basket: basket, Limit: &limit}
+		var listed uint64
+		for {
+			if args.Cursor == "" {
+				offset := uint32(listed)
+				args.Offset = &offset
+			}
+			page, err := w.ListOutputs(ctx, args, originator)
+			if err != nil {
+				return 0, 0, fmt.Errorf("failed to list outputs of basket %q: %w", basket, err)
+			}
+			for _, output := range page.Outputs {
+				if !output.Spendable {
+					continue
+				}
+				if total, err = total.Add(output.Amount()); err != nil {
+					return 0, 0, err
+				}
+				count++
+			}
+			listed += uint64(len(page.Outputs))
+			if page.NextCursor != "" {
+				args.Cursor = page.NextCursor
+				continue
+			}
+			if len(page.Outputs) == 0 || listed >= uint64(page.TotalOutputs) {
+				break
+			}
+		}
+	}
+	return total.Uint64(), count, nil
+}
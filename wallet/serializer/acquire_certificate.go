@@ -101,7 +101,7 @@ func DeserializeAcquireCertificateArgs(data []byte) (*wallet.AcquireCertificateA
 	args.Certifier = parsedCertifier
 
 	// Read fields
-	fieldsLength := r.ReadVarInt()
+	fieldsLength := readVarIntCapped(r, 2)
 	if fieldsLength > 0 {
 		args.Fields = make(map[string]string, fieldsLength)
 	}
@@ -169,7 +169,7 @@ func DeserializeAcquireCertificateArgs(data []byte) (*wallet.AcquireCertificateA
 		}
 
 		// Read keyring for subject
-		keyringEntriesLength := r.ReadVarInt()
+		keyringEntriesLength := readVarIntCapped(r, 2)
 		if keyringEntriesLength > 0 {
 			args.KeyringForSubject = make(map[string]string, keyringEntriesLength)
 		}
@@ -100,7 +100,7 @@ func DeserializeCertificate(data []byte) (cert *wallet.Certificate, err error) {
 	}
 
 	// Read fields
-	fieldsLength := r.ReadVarInt()
+	fieldsLength := readVarIntCapped(r, 2)
 	if fieldsLength > 0 {
 		cert.Fields = make(map[string]string, fieldsLength)
 	}
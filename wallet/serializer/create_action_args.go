@@ -1,6 +1,7 @@
 package serializer
 
 import (
+	"errors"
 	"fmt"
 	"github.com/bsv-blockchain/go-sdk/util"
 	"github.com/bsv-blockchain/go-sdk/wallet"
@@ -126,5 +127,20 @@ func serializeCreateActionOptions(paramWriter *util.Writer, options *wallet.Crea
 	// randomizeOutputs
 	paramWriter.WriteOptionalBool(options.RandomizeOutputs)
 
+	// changeStrategy is not part of the params, so that they stay readable by
+	// wallets predating it; it travels as a frame extension instead
+	if options.ChangeStrategy != nil {
+		return errors.New("change strategy must be sent as a frame extension, see SplitCreateActionExtensions")
+	}
+
 	return nil
 }
+
+func serializeChangeStrategy(paramWriter *util.Writer, strategy *wallet.ChangeStrategy) {
+	paramWriter.WriteString(string(strategy.Type))
+	paramWriter.WriteVarInt(uint64(strategy.Count))
+	paramWriter.WriteVarInt(uint64(len(strategy.Denominations)))
+	for _, d := range strategy.Denominations {
+		paramWriter.WriteVarInt(d)
+	}
+}
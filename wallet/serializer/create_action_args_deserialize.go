@@ -3,6 +3,8 @@ package serializer
 import (
 	"errors"
 	"fmt"
+
+	"github.com/bsv-blockchain/go-sdk/chainhash"
 	"github.com/bsv-blockchain/go-sdk/util"
 	"github.com/bsv-blockchain/go-sdk/wallet"
 )
@@ -57,7 +59,10 @@ func DeserializeCreateActionArgs(data []byte) (*wallet.CreateActionArgs, error)
 
 // deserializeCreateActionInputs deserializes the inputs into a slice of wallet.CreateActionInput
 func deserializeCreateActionInputs(messageReader *util.ReaderHoldError) ([]wallet.CreateActionInput, error) {
-	inputsLen := messageReader.ReadVarInt()
+	inputsLen := readOptionalVarIntCapped(messageReader, chainhash.HashSize+1)
+	if messageReader.Err != nil {
+		return nil, messageReader.Err
+	}
 	if util.IsNegativeOne(inputsLen) {
 		return nil, nil
 	}
@@ -101,7 +106,10 @@ func deserializeCreateActionInputs(messageReader *util.ReaderHoldError) ([]walle
 
 // deserializeCreateActionOutputs deserializes the outputs into a slice of wallet.CreateActionOutput
 func deserializeCreateActionOutputs(messageReader *util.ReaderHoldError) ([]wallet.CreateActionOutput, error) {
-	outputsLen := messageReader.ReadVarInt()
+	outputsLen := readOptionalVarIntCapped(messageReader, 1)
+	if messageReader.Err != nil {
+		return nil, messageReader.Err
+	}
 	if util.IsNegativeOne(outputsLen) {
 		return nil, nil
 	}
@@ -171,3 +179,16 @@ func deserializeCreateActionOptions(messageReader *util.ReaderHoldError) (*walle
 
 	return options, nil
 }
+
+// deserializeChangeStrategy decodes into wallet.ChangeStrategy
+func deserializeChangeStrategy(messageReader *util.ReaderHoldError) *wallet.ChangeStrategy {
+	strategy := &wallet.ChangeStrategy{
+		Type:  wallet.ChangeStrategyType(messageReader.ReadString()),
+		Count: messageReader.ReadVarInt32(),
+	}
+	count := readVarIntCapped(messageReader, 1)
+	for i := uint64(0); i < count && messageReader.Err == nil; i++ {
+		strategy.Denominations = append(strategy.Denominations, messageReader.ReadVarInt())
+	}
+	return strategy
+}
@@ -179,7 +179,23 @@ func TestDeserializeCreateActionArgsErrors(t *testing.T) {
 				w.WriteBytes([]byte{0x01, 0x02})
 				return w.Buf
 			}(),
-			err: "error decoding outpoint: failed to read txid",
+			err: "count exceeds remaining data",
+		},
+		{
+			name: "huge output count",
+			data: func() []byte {
+				w := util.NewWriter()
+				// description (empty)
+				w.WriteVarInt(0)
+				// input BEEF (nil)
+				w.WriteVarInt(util.NegativeOne)
+				// inputs (nil)
+				w.WriteVarInt(util.NegativeOne)
+				// outputs (far more than the data holds)
+				w.WriteVarInt(1 << 60)
+				return w.Buf
+			}(),
+			err: "count exceeds remaining data",
 		},
 		{
 			name: "invalid unlocking script",
@@ -0,0 +1,49 @@
+package serializer
+
+import (
+	"fmt"
+
+	"github.com/bsv-blockchain/go-sdk/util"
+	"github.com/bsv-blockchain/go-sdk/wallet"
+)
+
+// Extension types of CreateAction request frames.
+const (
+	// CreateActionExtensionChangeStrategy carries options.changeStrategy.
+	CreateActionExtensionChangeStrategy uint64 = 1
+)
+
+// SplitCreateActionExtensions moves the fields of args which older wallets do
+// not know into extensions, returning args without them. The options of the
+// caller's args are not modified.
+func SplitCreateActionExtensions(args wallet.CreateActionArgs) (wallet.CreateActionArgs, []Extension) {
+	var extensions []Extension
+	if args.Options != nil && args.Options.ChangeStrategy != nil {
+		w := util.NewWriter()
+		serializeChangeStrategy(w, args.Options.ChangeStrategy)
+		extensions = append(extensions, Extension{Type: CreateActionExtensionChangeStrategy, Value: w.Buf})
+
+		options := *args.Options
+		options.ChangeStrategy = nil
+		args.Options = &options
+	}
+	return args, extensions
+}
+
+// ApplyCreateActionExtensions sets the fields of args carried by extensions,
+// skipping extensions of unknown types.
+func ApplyCreateActionExtensions(args *wallet.CreateActionArgs, extensions []Extension) error {
+	if value, ok := findExtension(extensions, CreateActionExtensionChangeStrategy); ok {
+		r := util.NewReaderHoldError(value)
+		strategy := deserializeChangeStrategy(r)
+		r.CheckComplete()
+		if r.Err != nil {
+			return fmt.Errorf("error decoding change strategy extension: %w", r.Err)
+		}
+		if args.Options == nil {
+			args.Options = &wallet.CreateActionOptions{}
+		}
+		args.Options.ChangeStrategy = strategy
+	}
+	return nil
+}
@@ -76,7 +76,7 @@ func DeserializeCreateActionResult(data []byte) (*wallet.CreateActionResult, err
 	result.NoSendChange = noSendChange
 
 	// Parse sendWithResults
-	result.SendWithResults, err = readTxidSliceWithStatus(&resultReader.Reader)
+	result.SendWithResults, err = readTxidSliceWithStatus(resultReader)
 	if err != nil {
 		return nil, fmt.Errorf("error reading sendWith results: %w", err)
 	}
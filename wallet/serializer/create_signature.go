@@ -4,6 +4,7 @@ import (
 	"fmt"
 
 	ec "github.com/bsv-blockchain/go-sdk/primitives/ec"
+	sighash "github.com/bsv-blockchain/go-sdk/transaction/sighash"
 	"github.com/bsv-blockchain/go-sdk/util"
 	"github.com/bsv-blockchain/go-sdk/wallet"
 )
@@ -25,8 +26,15 @@ func SerializeCreateSignatureArgs(args *wallet.CreateSignatureArgs) ([]byte, err
 	}
 	w.WriteBytes(keyParams)
 
-	// Write data or hash flag and content
-	if args.Data != nil {
+	// Write data, hash or sighash context flag and content
+	if args.SighashContext != nil {
+		w.WriteByte(3)
+		w.WriteIntBytes(args.SighashContext.Tx)
+		w.WriteVarInt(uint64(args.SighashContext.InputIndex))
+		w.WriteVarInt(args.SighashContext.SourceSatoshis)
+		w.WriteIntBytes(args.SighashContext.SourceLockingScript)
+		w.WriteByte(byte(args.SighashContext.SighashFlag))
+	} else if args.Data != nil {
 		w.WriteByte(1)
 		w.WriteVarInt(uint64(len(args.Data)))
 		w.WriteBytes(args.Data)
@@ -66,6 +74,15 @@ func DeserializeCreateSignatureArgs(data []byte) (*wallet.CreateSignatureArgs, e
 	case 2:
 		// Hash provided directly
 		args.HashToDirectlySign = r.ReadBytes(32)
+	case 3:
+		// Transaction input to compute the sighash for
+		args.SighashContext = &wallet.SighashContext{
+			Tx:                  r.ReadIntBytes(),
+			InputIndex:          r.ReadVarInt32(),
+			SourceSatoshis:      r.ReadVarInt(),
+			SourceLockingScript: r.ReadIntBytes(),
+			SighashFlag:         sighash.Flag(r.ReadByte()),
+		}
 	default:
 		return nil, fmt.Errorf("invalid data type flag: %d", dataTypeFlag)
 	}
@@ -38,6 +38,24 @@ func TestCreateSignatureArgs(t *testing.T) {
 			},
 			HashToDirectlySign: make([]byte, 32),
 		},
+	}, {
+		name: "args with sighash context",
+		args: &wallet.CreateSignatureArgs{
+			EncryptionArgs: wallet.EncryptionArgs{
+				ProtocolID: wallet.Protocol{
+					SecurityLevel: wallet.SecurityLevelEveryApp,
+					Protocol:      "test-sighash",
+				},
+				KeyID: "sighash-key",
+			},
+			SighashContext: &wallet.SighashContext{
+				Tx:                  []byte{1, 0, 0, 0, 0, 0, 0, 0, 0, 0},
+				InputIndex:          2,
+				SourceSatoshis:      1000,
+				SourceLockingScript: []byte{0x76, 0xa9},
+				SighashFlag:         0x41,
+			},
+		},
 	}, {
 		name: "minimal args",
 		args: &wallet.CreateSignatureArgs{
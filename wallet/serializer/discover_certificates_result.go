@@ -34,7 +34,7 @@ func DeserializeDiscoverCertificatesResult(data []byte) (*wallet.DiscoverCertifi
 	result := &wallet.DiscoverCertificatesResult{}
 
 	// Read total certificates
-	result.TotalCertificates = uint32(r.ReadVarInt())
+	result.TotalCertificates = uint32(readVarIntCapped(r, 1))
 
 	// Read certificates
 	if result.TotalCertificates > 0 {
@@ -1,6 +1,7 @@
 package serializer
 
 import (
+	"bytes"
 	"fmt"
 
 	"github.com/bsv-blockchain/go-sdk/util"
@@ -9,15 +10,30 @@ import (
 
 // RequestFrame represents a wallet wire protocol request message.
 // It contains the command type, originator information, and serialized arguments
-// for the wallet operation being requested.
+// for the wallet operation being requested. A chunked frame carries its params,
+// and is replied with its result, in chunks (see FrameContinuation). A frame with
+// a version or extensions is versioned (see FrameVersioned).
 type RequestFrame struct {
 	Call       byte
 	Originator string
 	Params     []byte
+	Chunked    bool
+	Version    byte
+	Extensions []Extension
+}
+
+// versioned reports whether the frame is written as a versioned frame.
+func (f *RequestFrame) versioned() bool {
+	return f.Version != 0 || len(f.Extensions) > 0
 }
 
 // WriteRequestFrame writes a call frame with call type, originator and params
 func WriteRequestFrame(requestFrame RequestFrame) []byte {
+	if requestFrame.Chunked || requestFrame.versioned() {
+		var buf bytes.Buffer
+		_ = WriteRequestFrameTo(&buf, requestFrame, DefaultFrameChunkSize)
+		return buf.Bytes()
+	}
 	frameWriter := util.NewWriter()
 
 	// Write call type byte
@@ -38,6 +54,9 @@ func WriteRequestFrame(requestFrame RequestFrame) []byte {
 
 // ReadRequestFrame reads a request frame and returns call type, originator and params
 func ReadRequestFrame(data []byte) (*RequestFrame, error) {
+	if len(data) > 0 && data[0]&(FrameContinuation|FrameVersioned) != 0 {
+		return ReadRequestFrameFrom(bytes.NewReader(data))
+	}
 	frameReader := util.NewReader(data)
 
 	// Read call type byte
@@ -0,0 +1,225 @@
+package serializer
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/bsv-blockchain/go-sdk/util"
+	"github.com/bsv-blockchain/go-sdk/wallet"
+)
+
+// FrameContinuation is set in the call byte of a chunked request frame. The
+// params of a chunked frame follow the originator as a sequence of chunks, each
+// a varint length and as many bytes, ended by an empty chunk; the result frame
+// replied to it carries its result in chunks as well. Chunked frames are
+// self-delimiting, so large Encrypt and Decrypt payloads and BEEF blobs can be
+// streamed through a wire without either side holding the whole frame.
+const FrameContinuation byte = 0x80
+
+// DefaultFrameChunkSize is the default size of the chunks of chunked frames.
+const DefaultFrameChunkSize = 64 * 1024
+
+// ErrChunkedFrame is returned when reading a chunked frame fails.
+var ErrChunkedFrame = errors.New("invalid chunked frame")
+
+// writeChunks writes data to w in chunks of at most chunkSize bytes, followed
+// by the empty chunk.
+func writeChunks(w io.Writer, data []byte, chunkSize int) error {
+	if chunkSize <= 0 {
+		chunkSize = DefaultFrameChunkSize
+	}
+	for len(data) > 0 {
+		n := min(chunkSize, len(data))
+		if _, err := w.Write(util.VarInt(n).Bytes()); err != nil {
+			return err
+		}
+		if _, err := w.Write(data[:n]); err != nil {
+			return err
+		}
+		data = data[n:]
+	}
+	_, err := w.Write(util.VarInt(0).Bytes())
+	return err
+}
+
+// WriteChunksFrom copies r to w in chunks of at most chunkSize bytes, followed
+// by the empty chunk, holding a single chunk in memory.
+func WriteChunksFrom(w io.Writer, r io.Reader, chunkSize int) error {
+	if chunkSize <= 0 {
+		chunkSize = DefaultFrameChunkSize
+	}
+	buf := make([]byte, chunkSize)
+	for {
+		n, err := io.ReadFull(r, buf)
+		if n > 0 {
+			if _, werr := w.Write(util.VarInt(n).Bytes()); werr != nil {
+				return werr
+			}
+			if _, werr := w.Write(buf[:n]); werr != nil {
+				return werr
+			}
+		}
+		if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+			break
+		}
+		if err != nil {
+			return err
+		}
+	}
+	_, err := w.Write(util.VarInt(0).Bytes())
+	return err
+}
+
+// chunkReader reads the data of a sequence of chunks, up to the empty chunk.
+type chunkReader struct {
+	r         io.Reader
+	remaining uint64
+	done      bool
+}
+
+// NewChunkReader returns a reader of the data of the chunks read from r, which
+// reports io.EOF at the empty chunk ending them. It does not read past it.
+func NewChunkReader(r io.Reader) io.Reader {
+	return &chunkReader{r: r}
+}
+
+func (c *chunkReader) Read(p []byte) (int, error) {
+	if c.done {
+		return 0, io.EOF
+	}
+	if c.remaining == 0 {
+		var size util.VarInt
+		if _, err := size.ReadFrom(c.r); err != nil {
+			return 0, fmt.Errorf("%w: error reading chunk length: %w", ErrChunkedFrame, err)
+		}
+		if size == 0 {
+			c.done = true
+			return 0, io.EOF
+		}
+		c.remaining = uint64(size)
+	}
+	if uint64(len(p)) > c.remaining {
+		p = p[:c.remaining]
+	}
+	n, err := c.r.Read(p)
+	c.remaining -= uint64(n)
+	if errors.Is(err, io.EOF) {
+		err = fmt.Errorf("%w: %w", ErrChunkedFrame, io.ErrUnexpectedEOF)
+	}
+	return n, err
+}
+
+// readChunks reads the data of the chunks read from r.
+func readChunks(r io.Reader) ([]byte, error) {
+	var buf bytes.Buffer
+	if _, err := io.Copy(&buf, NewChunkReader(r)); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// WriteRequestFrameTo writes a request frame to w. A chunked frame writes its
+// params in chunks of at most chunkSize bytes, without copying them.
+func WriteRequestFrameTo(w io.Writer, requestFrame RequestFrame, chunkSize int) error {
+	call := requestFrame.Call
+	if requestFrame.Chunked {
+		call |= FrameContinuation
+	}
+	if requestFrame.versioned() {
+		call |= FrameVersioned
+	}
+	originatorBytes := []byte(requestFrame.Originator)
+	header := append([]byte{call, byte(len(originatorBytes))}, originatorBytes...)
+	if requestFrame.versioned() {
+		header = append(header, WriteFrameExtensions(requestFrame.Version, requestFrame.Extensions)...)
+	}
+	if _, err := w.Write(header); err != nil {
+		return err
+	}
+	if requestFrame.Chunked {
+		return writeChunks(w, requestFrame.Params, chunkSize)
+	}
+	_, err := w.Write(requestFrame.Params)
+	return err
+}
+
+// NewRequestFrameReader reads the call, originator and extensions of the request
+// frame read from r, returning them with a reader of its params. The params of a chunked
+// frame end at its empty chunk, the others at the end of r.
+func NewRequestFrameReader(r io.Reader) (*RequestFrame, io.Reader, error) {
+	header := make([]byte, 2)
+	if _, err := io.ReadFull(r, header); err != nil {
+		return nil, nil, fmt.Errorf("error reading request frame header: %w", err)
+	}
+	originatorBytes := make([]byte, header[1])
+	if _, err := io.ReadFull(r, originatorBytes); err != nil {
+		return nil, nil, fmt.Errorf("error reading originator: %w", err)
+	}
+	requestFrame := &RequestFrame{
+		Call:       header[0] &^ (FrameContinuation | FrameVersioned),
+		Originator: string(originatorBytes),
+		Chunked:    header[0]&FrameContinuation != 0,
+	}
+	if header[0]&FrameVersioned != 0 {
+		var err error
+		if requestFrame.Version, requestFrame.Extensions, err = ReadFrameExtensions(r); err != nil {
+			return nil, nil, err
+		}
+	}
+	if requestFrame.Chunked {
+		return requestFrame, NewChunkReader(r), nil
+	}
+	return requestFrame, r, nil
+}
+
+// ReadRequestFrameFrom reads a request frame, chunked or not, from r.
+func ReadRequestFrameFrom(r io.Reader) (*RequestFrame, error) {
+	requestFrame, params, err := NewRequestFrameReader(r)
+	if err != nil {
+		return nil, err
+	}
+	if requestFrame.Params, err = io.ReadAll(params); err != nil {
+		return nil, fmt.Errorf("error reading params: %w", err)
+	}
+	if len(requestFrame.Params) == 0 {
+		requestFrame.Params = nil
+	}
+	return requestFrame, nil
+}
+
+// WriteResultFrameTo writes a result frame to w, with the result in chunks of at
+// most chunkSize bytes when chunked.
+func WriteResultFrameTo(w io.Writer, result []byte, err *wallet.Error, chunked bool, chunkSize int) error {
+	if err != nil {
+		_, werr := w.Write(WriteResultFrame(nil, err))
+		return werr
+	}
+	if _, werr := w.Write([]byte{0}); werr != nil {
+		return werr
+	}
+	if chunked {
+		return writeChunks(w, result, chunkSize)
+	}
+	_, werr := w.Write(result)
+	return werr
+}
+
+// ReadResultFrameFrom reads a result frame from r, returning either the result
+// or the error. A chunked frame is read up to its empty chunk, the others to
+// the end of r.
+func ReadResultFrameFrom(r io.Reader, chunked bool) ([]byte, error) {
+	status := make([]byte, 1)
+	if _, err := io.ReadFull(r, status); err != nil {
+		return nil, fmt.Errorf("error reading error byte: %w", err)
+	}
+	if status[0] == 0 && chunked {
+		return readChunks(r)
+	}
+	rest, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("error reading result frame: %w", err)
+	}
+	return ReadResultFrame(append(status, rest...))
+}
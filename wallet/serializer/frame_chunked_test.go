@@ -0,0 +1,82 @@
+package serializer
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/bsv-blockchain/go-sdk/wallet"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChunkedRequestFrameRoundTrip(t *testing.T) {
+	for _, size := range []int{0, 1, 99, 100, 101, 1000} {
+		params := bytes.Repeat([]byte{0xab}, size)
+		if size == 0 {
+			params = nil
+		}
+		frame := RequestFrame{Call: 11, Originator: "example.com", Params: params, Chunked: true}
+
+		var buf bytes.Buffer
+		require.NoError(t, WriteRequestFrameTo(&buf, frame, 100))
+		require.Equal(t, 11|FrameContinuation, buf.Bytes()[0])
+
+		// The frame is self-delimiting: the bytes following it are not read.
+		buf.WriteString("next")
+		read, err := ReadRequestFrameFrom(&buf)
+		require.NoError(t, err)
+		require.Equal(t, &frame, read)
+		require.Equal(t, "next", buf.String())
+
+		read, err = ReadRequestFrame(WriteRequestFrame(frame))
+		require.NoError(t, err)
+		require.Equal(t, &frame, read)
+	}
+}
+
+func TestRequestFrameFromStream(t *testing.T) {
+	frame := RequestFrame{Call: 12, Originator: "example.com", Params: []byte{1, 2, 3}}
+	read, err := ReadRequestFrameFrom(bytes.NewReader(WriteRequestFrame(frame)))
+	require.NoError(t, err)
+	require.Equal(t, &frame, read)
+}
+
+func TestChunkedResultFrameRoundTrip(t *testing.T) {
+	result := bytes.Repeat([]byte{0xcd}, 250)
+
+	var buf bytes.Buffer
+	require.NoError(t, WriteResultFrameTo(&buf, result, nil, true, 100))
+	read, err := ReadResultFrameFrom(&buf, true)
+	require.NoError(t, err)
+	require.Equal(t, result, read)
+
+	buf.Reset()
+	require.NoError(t, WriteResultFrameTo(&buf, result, nil, false, 100))
+	require.Equal(t, WriteResultFrame(result, nil), buf.Bytes())
+	read, err = ReadResultFrameFrom(&buf, false)
+	require.NoError(t, err)
+	require.Equal(t, result, read)
+
+	walletErr := &wallet.Error{Code: 3, Message: "failed", Stack: "stack"}
+	buf.Reset()
+	require.NoError(t, WriteResultFrameTo(&buf, nil, walletErr, true, 100))
+	_, err = ReadResultFrameFrom(&buf, true)
+	require.Equal(t, walletErr, err)
+}
+
+func TestChunkReaderTruncated(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, writeChunks(&buf, bytes.Repeat([]byte{1}, 50), 20))
+	truncated := buf.Bytes()[:30]
+
+	_, err := io.ReadAll(NewChunkReader(bytes.NewReader(truncated)))
+	require.ErrorIs(t, err, ErrChunkedFrame)
+}
+
+func TestWriteChunksFrom(t *testing.T) {
+	data := bytes.Repeat([]byte{7}, 45)
+	var fromReader, fromBytes bytes.Buffer
+	require.NoError(t, WriteChunksFrom(&fromReader, bytes.NewReader(data), 20))
+	require.NoError(t, writeChunks(&fromBytes, data, 20))
+	require.Equal(t, fromBytes.Bytes(), fromReader.Bytes())
+}
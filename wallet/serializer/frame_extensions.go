@@ -0,0 +1,92 @@
+package serializer
+
+import (
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/bsv-blockchain/go-sdk/util"
+)
+
+// FrameVersioned is set in the call byte of a versioned request frame. Between
+// the originator and the params, a versioned frame carries its version byte and
+// an extension area: the varint length of the area followed by extensions, each
+// a varint type, a varint length and as many bytes of value. The area of any
+// version starts the same way, so readers skip the extensions, and the area,
+// they do not know.
+const FrameVersioned byte = 0x40
+
+// FrameVersion is the version of the versioned request frames written by this
+// package.
+const FrameVersion byte = 1
+
+// ErrFrameExtensions is returned when reading the extension area of a versioned
+// frame fails.
+var ErrFrameExtensions = errors.New("invalid frame extensions")
+
+// Extension is an optional field of a versioned request frame, identified by a
+// type unique to the call. Wallets skip extensions of types they do not know, so
+// newer clients can pass new fields without breaking older wallets.
+type Extension struct {
+	Type  uint64
+	Value []byte
+}
+
+// findExtension returns the value of the extension of type typ, if present.
+func findExtension(extensions []Extension, typ uint64) ([]byte, bool) {
+	for _, extension := range extensions {
+		if extension.Type == typ {
+			return extension.Value, true
+		}
+	}
+	return nil, false
+}
+
+// WriteFrameExtensions writes the version byte and extension area of a
+// versioned frame, with FrameVersion for a zero version.
+func WriteFrameExtensions(version byte, extensions []Extension) []byte {
+	if version == 0 {
+		version = FrameVersion
+	}
+	area := util.NewWriter()
+	for _, extension := range extensions {
+		area.WriteVarInt(extension.Type)
+		area.WriteIntBytes(extension.Value)
+	}
+	w := util.NewWriter()
+	w.WriteByte(version)
+	w.WriteIntBytes(area.Buf)
+	return w.Buf
+}
+
+// ReadFrameExtensions reads the version byte and extension area of a versioned
+// frame from r.
+func ReadFrameExtensions(r io.Reader) (byte, []Extension, error) {
+	version := make([]byte, 1)
+	if _, err := io.ReadFull(r, version); err != nil {
+		return 0, nil, fmt.Errorf("%w: error reading version: %w", ErrFrameExtensions, err)
+	}
+	var size util.VarInt
+	if _, err := size.ReadFrom(r); err != nil {
+		return 0, nil, fmt.Errorf("%w: error reading length: %w", ErrFrameExtensions, err)
+	}
+	area, err := io.ReadAll(io.LimitReader(r, int64(size)))
+	if err != nil {
+		return 0, nil, fmt.Errorf("%w: %w", ErrFrameExtensions, err)
+	}
+	if uint64(len(area)) != uint64(size) {
+		return 0, nil, fmt.Errorf("%w: %w", ErrFrameExtensions, io.ErrUnexpectedEOF)
+	}
+
+	areaReader := util.NewReaderHoldError(area)
+	var extensions []Extension
+	for !areaReader.IsComplete() && areaReader.Err == nil {
+		extension := Extension{Type: areaReader.ReadVarInt()}
+		extension.Value = areaReader.ReadIntBytes()
+		extensions = append(extensions, extension)
+	}
+	if areaReader.Err != nil {
+		return 0, nil, fmt.Errorf("%w: %w", ErrFrameExtensions, areaReader.Err)
+	}
+	return version[0], extensions, nil
+}
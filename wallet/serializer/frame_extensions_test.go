@@ -0,0 +1,96 @@
+package serializer
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/bsv-blockchain/go-sdk/wallet"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVersionedRequestFrameRoundTrip(t *testing.T) {
+	frame := RequestFrame{
+		Call:       1,
+		Originator: "example.com",
+		Params:     []byte{1, 2, 3},
+		Version:    FrameVersion,
+		Extensions: []Extension{{Type: 1, Value: []byte{4, 5}}, {Type: 300, Value: []byte{}}},
+	}
+	data := WriteRequestFrame(frame)
+	require.Equal(t, 1|FrameVersioned, data[0])
+
+	read, err := ReadRequestFrame(data)
+	require.NoError(t, err)
+	require.Equal(t, frame.Params, read.Params)
+	require.Equal(t, frame.Version, read.Version)
+	require.Len(t, read.Extensions, 2)
+	require.Equal(t, []byte{4, 5}, read.Extensions[0].Value)
+	require.Equal(t, uint64(300), read.Extensions[1].Type)
+
+	frame.Chunked = true
+	var buf bytes.Buffer
+	require.NoError(t, WriteRequestFrameTo(&buf, frame, 2))
+	read, err = ReadRequestFrameFrom(&buf)
+	require.NoError(t, err)
+	require.True(t, read.Chunked)
+	require.Equal(t, frame.Params, read.Params)
+	require.Len(t, read.Extensions, 2)
+}
+
+func TestVersionedRequestFrameFromNewerClient(t *testing.T) {
+	// A frame of a future version, with extensions unknown to this package.
+	frame := RequestFrame{
+		Call:       27,
+		Params:     []byte{9},
+		Version:    7,
+		Extensions: []Extension{{Type: 99, Value: bytes.Repeat([]byte{1}, 300)}},
+	}
+	read, err := ReadRequestFrame(WriteRequestFrame(frame))
+	require.NoError(t, err)
+	require.Equal(t, byte(27), read.Call)
+	require.Equal(t, []byte{9}, read.Params)
+	require.Equal(t, byte(7), read.Version)
+
+	args := &wallet.CreateActionArgs{Description: "unchanged"}
+	require.NoError(t, ApplyCreateActionExtensions(args, read.Extensions))
+	require.Equal(t, &wallet.CreateActionArgs{Description: "unchanged"}, args)
+}
+
+func TestVersionedRequestFrameTruncated(t *testing.T) {
+	data := WriteRequestFrame(RequestFrame{Call: 1, Extensions: []Extension{{Type: 1, Value: []byte{1, 2, 3}}}})
+	_, err := ReadRequestFrame(data[:len(data)-1])
+	require.ErrorIs(t, err, ErrFrameExtensions)
+}
+
+func TestCreateActionExtensions(t *testing.T) {
+	strategy := &wallet.ChangeStrategy{Type: wallet.ChangeStrategyDenominations, Denominations: []uint64{100, 1000}}
+	args := wallet.CreateActionArgs{
+		Description: "description",
+		Options:     &wallet.CreateActionOptions{NoSend: new(bool), ChangeStrategy: strategy},
+	}
+
+	// The params have no room for the strategy.
+	_, err := SerializeCreateActionArgs(&args)
+	require.ErrorContains(t, err, "frame extension")
+
+	split, extensions := SplitCreateActionExtensions(args)
+	require.Len(t, extensions, 1)
+	require.Nil(t, split.Options.ChangeStrategy)
+	require.Equal(t, strategy, args.Options.ChangeStrategy)
+
+	// The args without extensions deserialize on wallets predating them.
+	data, err := SerializeCreateActionArgs(&split)
+	require.NoError(t, err)
+	deserialized, err := DeserializeCreateActionArgs(data)
+	require.NoError(t, err)
+	require.Nil(t, deserialized.Options.ChangeStrategy)
+
+	require.NoError(t, ApplyCreateActionExtensions(deserialized, extensions))
+	require.Equal(t, strategy, deserialized.Options.ChangeStrategy)
+
+	_, extensions = SplitCreateActionExtensions(wallet.CreateActionArgs{Description: "description"})
+	require.Empty(t, extensions)
+
+	err = ApplyCreateActionExtensions(&wallet.CreateActionArgs{}, []Extension{{Type: CreateActionExtensionChangeStrategy, Value: []byte{0xff}}})
+	require.Error(t, err)
+}
@@ -66,7 +66,7 @@ func DeserializeIdentityCertificate(r *util.ReaderHoldError) (*wallet.IdentityCe
 	cert.CertifierInfo.Trust = r.ReadByte()
 
 	// Deserialize PubliclyRevealedKeyring
-	keyringLen := r.ReadVarInt()
+	keyringLen := readVarIntCapped(r, 2)
 	if keyringLen > 0 {
 		cert.PubliclyRevealedKeyring = make(map[string]string, keyringLen)
 		for i := uint64(0); i < keyringLen; i++ {
@@ -77,7 +77,7 @@ func DeserializeIdentityCertificate(r *util.ReaderHoldError) (*wallet.IdentityCe
 	}
 
 	// Deserialize DecryptedFields
-	fieldsLen := r.ReadVarInt()
+	fieldsLen := readVarIntCapped(r, 2)
 	if fieldsLen > 0 {
 		cert.DecryptedFields = make(map[string]string, fieldsLen)
 		for i := uint64(0); i < fieldsLen; i++ {
@@ -65,7 +65,7 @@ func DeserializeInternalizeActionArgs(data []byte) (*wallet.InternalizeActionArg
 	}
 
 	// Outputs
-	outputCount := r.ReadVarInt()
+	outputCount := readVarIntCapped(r, 1)
 	args.Outputs = make([]wallet.InternalizeOutput, 0, outputCount)
 	for i := uint64(0); i < outputCount; i++ {
 		output := wallet.InternalizeOutput{
@@ -48,6 +48,11 @@ func SerializeListActionsArgs(args *wallet.ListActionsArgs) ([]byte, error) {
 	w.WriteOptionalUint32(args.Offset)
 	w.WriteOptionalBool(args.SeekPermission)
 
+	// cursor is a trailing extension, omitted entirely when unset
+	if args.Cursor != "" {
+		w.WriteString(args.Cursor)
+	}
+
 	return w.Buf, nil
 }
 
@@ -83,6 +88,11 @@ func DeserializeListActionsArgs(data []byte) (*wallet.ListActionsArgs, error) {
 	args.Offset = r.ReadOptionalUint32()
 	args.SeekPermission = r.ReadOptionalBool()
 
+	// Read cursor, which is only present when set
+	if !r.IsComplete() {
+		args.Cursor = r.ReadString()
+	}
+
 	r.CheckComplete()
 	if r.Err != nil {
 		return nil, fmt.Errorf("error reading list action args: %w", r.Err)
@@ -188,6 +198,11 @@ func SerializeListActionsResult(result *wallet.ListActionsResult) ([]byte, error
 		}
 	}
 
+	// nextCursor is a trailing extension, omitted entirely when unset
+	if result.NextCursor != "" {
+		w.WriteString(result.NextCursor)
+	}
+
 	return w.Buf, nil
 }
 
@@ -196,7 +211,7 @@ func DeserializeListActionsResult(data []byte) (*wallet.ListActionsResult, error
 	result := &wallet.ListActionsResult{}
 
 	// Deserialize totalActions
-	result.TotalActions = r.ReadVarInt32()
+	result.TotalActions = uint32(readVarIntCapped(r, chainhash.HashSize))
 
 	// Deserialize actions
 	result.Actions = make([]wallet.Action, 0, result.TotalActions)
@@ -236,7 +251,7 @@ func DeserializeListActionsResult(data []byte) (*wallet.ListActionsResult, error
 		action.LockTime = r.ReadVarInt32()
 
 		// Deserialize inputs
-		inputCount := r.ReadVarInt()
+		inputCount := readOptionalVarIntCapped(r, chainhash.HashSize+1)
 		if inputCount == math.MaxUint64 {
 			inputCount = 0
 		} else {
@@ -267,7 +282,7 @@ func DeserializeListActionsResult(data []byte) (*wallet.ListActionsResult, error
 		}
 
 		// Deserialize outputs
-		outputCount := r.ReadVarInt()
+		outputCount := readOptionalVarIntCapped(r, 1)
 		if outputCount == math.MaxUint64 {
 			outputCount = 0
 		} else {
@@ -303,6 +318,11 @@ func DeserializeListActionsResult(data []byte) (*wallet.ListActionsResult, error
 		result.Actions = append(result.Actions, action)
 	}
 
+	// Read nextCursor, which is only present when set
+	if !r.IsComplete() {
+		result.NextCursor = r.ReadString()
+	}
+
 	r.CheckComplete()
 	if r.Err != nil {
 		return nil, fmt.Errorf("error reading list action result: %w", r.Err)
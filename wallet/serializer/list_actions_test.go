@@ -47,6 +47,14 @@ func TestListActionArgsSerializeAndDeserialize(t *testing.T) {
 				Labels: []string{},
 			},
 		},
+		{
+			name: "with cursor",
+			args: wallet.ListActionsArgs{
+				Labels: []string{"label1"},
+				Limit:  util.Uint32Ptr(25),
+				Cursor: "eyJpZCI6NDJ9",
+			},
+		},
 		{
 			name: "nil options",
 			args: wallet.ListActionsArgs{
@@ -179,6 +187,14 @@ func TestListActionResultSerializeAndDeserialize(t *testing.T) {
 				Actions:      []wallet.Action{},
 			},
 		},
+		{
+			name: "empty result with next cursor",
+			result: wallet.ListActionsResult{
+				TotalActions: 0,
+				Actions:      []wallet.Action{},
+				NextCursor:   "eyJpZCI6NDJ9",
+			},
+		},
 	}
 
 	for _, tt := range tests {
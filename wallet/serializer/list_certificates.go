@@ -39,7 +39,7 @@ func DeserializeListCertificatesArgs(data []byte) (*wallet.ListCertificatesArgs,
 	args := &wallet.ListCertificatesArgs{}
 
 	// Read certifiers
-	certifiersLength := r.ReadVarInt()
+	certifiersLength := readVarIntCapped(r, 33)
 	args.Certifiers = make([]*ec.PublicKey, 0, certifiersLength)
 	for i := uint64(0); i < certifiersLength; i++ {
 		certifierBytes := r.ReadBytes(33)
@@ -54,7 +54,7 @@ func DeserializeListCertificatesArgs(data []byte) (*wallet.ListCertificatesArgs,
 	}
 
 	// Read types
-	typesLength := r.ReadVarInt()
+	typesLength := readVarIntCapped(r, 32)
 	args.Types = make([]wallet.CertificateType, 0, typesLength)
 	for i := uint64(0); i < typesLength; i++ {
 		var typeArray wallet.CertificateType
@@ -129,7 +129,7 @@ func DeserializeListCertificatesResult(data []byte) (*wallet.ListCertificatesRes
 	result := &wallet.ListCertificatesResult{}
 
 	// Read total certificates
-	result.TotalCertificates = uint32(r.ReadVarInt())
+	result.TotalCertificates = uint32(readVarIntCapped(r, 1))
 
 	// Read certificates
 	if result.TotalCertificates > 0 {
@@ -145,7 +145,7 @@ func DeserializeListCertificatesResult(data []byte) (*wallet.ListCertificatesRes
 
 		// Read keyring if present
 		if r.ReadByte() == 1 {
-			keyringLen := r.ReadVarInt()
+			keyringLen := readVarIntCapped(r, 2)
 			if keyringLen > 0 {
 				certResult.Keyring = make(map[string]string, keyringLen)
 			}
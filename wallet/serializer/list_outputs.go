@@ -3,6 +3,7 @@ package serializer
 import (
 	"fmt"
 
+	"github.com/bsv-blockchain/go-sdk/chainhash"
 	"github.com/bsv-blockchain/go-sdk/util"
 	"github.com/bsv-blockchain/go-sdk/wallet"
 )
@@ -13,6 +14,9 @@ const (
 
 	outputIncludeLockingScriptsCode     uint8 = 1
 	outputIncludeEntireTransactionsCode uint8 = 2
+
+	outputSortSatoshisAscendingCode  uint8 = 1
+	outputSortSatoshisDescendingCode uint8 = 2
 )
 
 func SerializeListOutputsArgs(args *wallet.ListOutputsArgs) ([]byte, error) {
@@ -51,6 +55,23 @@ func SerializeListOutputsArgs(args *wallet.ListOutputsArgs) ([]byte, error) {
 	w.WriteOptionalUint32(args.Offset)
 	w.WriteOptionalBool(args.SeekPermission)
 
+	// Value, script type and sort filters and the cursor are a trailing
+	// extension, omitted entirely when unset
+	if args.MinSatoshis != nil || args.MaxSatoshis != nil || len(args.ScriptTypes) > 0 || args.SortOrder != "" || args.Cursor != "" {
+		writeOptionalUint64(w, args.MinSatoshis)
+		writeOptionalUint64(w, args.MaxSatoshis)
+		w.WriteStringSlice(args.ScriptTypes)
+		switch args.SortOrder {
+		case wallet.OutputSortSatoshisAscending:
+			w.WriteByte(outputSortSatoshisAscendingCode)
+		case wallet.OutputSortSatoshisDescending:
+			w.WriteByte(outputSortSatoshisDescendingCode)
+		default:
+			w.WriteNegativeOneByte()
+		}
+		w.WriteOptionalString(args.Cursor)
+	}
+
 	return w.Buf, nil
 }
 
@@ -85,6 +106,20 @@ func DeserializeListOutputsArgs(data []byte) (*wallet.ListOutputsArgs, error) {
 	args.Offset = r.ReadOptionalUint32()
 	args.SeekPermission = r.ReadOptionalBool()
 
+	// Read the filters, which are only present when set
+	if !r.IsComplete() {
+		args.MinSatoshis = readOptionalUint64(r)
+		args.MaxSatoshis = readOptionalUint64(r)
+		args.ScriptTypes = r.ReadStringSlice()
+		switch r.ReadByte() {
+		case outputSortSatoshisAscendingCode:
+			args.SortOrder = wallet.OutputSortSatoshisAscending
+		case outputSortSatoshisDescendingCode:
+			args.SortOrder = wallet.OutputSortSatoshisDescending
+		}
+		args.Cursor = r.ReadString()
+	}
+
 	r.CheckComplete()
 	if r.Err != nil {
 		return nil, fmt.Errorf("error reading list outputs args: %w", r.Err)
@@ -93,6 +128,24 @@ func DeserializeListOutputsArgs(data []byte) (*wallet.ListOutputsArgs, error) {
 	return args, nil
 }
 
+// writeOptionalUint64 writes the value as a varint, or -1 when nil. Satoshi
+// amounts never reach the -1 sentinel.
+func writeOptionalUint64(w *util.Writer, v *uint64) {
+	if v == nil {
+		w.WriteNegativeOne()
+		return
+	}
+	w.WriteVarInt(*v)
+}
+
+func readOptionalUint64(r *util.ReaderHoldError) *uint64 {
+	v := r.ReadVarInt()
+	if r.Err != nil || util.IsNegativeOne(v) {
+		return nil
+	}
+	return &v
+}
+
 func SerializeListOutputsResult(result *wallet.ListOutputsResult) ([]byte, error) {
 	w := util.NewWriter()
 
@@ -124,6 +177,11 @@ func SerializeListOutputsResult(result *wallet.ListOutputsResult) ([]byte, error
 		w.WriteStringSlice(output.Labels)
 	}
 
+	// nextCursor is a trailing extension, omitted entirely when unset
+	if result.NextCursor != "" {
+		w.WriteString(result.NextCursor)
+	}
+
 	return w.Buf, nil
 }
 
@@ -131,7 +189,7 @@ func DeserializeListOutputsResult(data []byte) (*wallet.ListOutputsResult, error
 	r := util.NewReaderHoldError(data)
 	result := &wallet.ListOutputsResult{}
 
-	result.TotalOutputs = r.ReadVarInt32()
+	result.TotalOutputs = uint32(readVarIntCapped(r, chainhash.HashSize+1))
 
 	// Optional BEEF
 	beefLen := r.ReadVarInt()
@@ -168,6 +226,11 @@ func DeserializeListOutputsResult(data []byte) (*wallet.ListOutputsResult, error
 		result.Outputs = append(result.Outputs, output)
 	}
 
+	// Read nextCursor, which is only present when set
+	if !r.IsComplete() {
+		result.NextCursor = r.ReadString()
+	}
+
 	r.CheckComplete()
 	if r.Err != nil {
 		return nil, fmt.Errorf("error reading list outputs result: %w", r.Err)
@@ -36,6 +36,30 @@ func TestListOutputsArgs(t *testing.T) {
 				Limit:  util.Uint32Ptr(10),
 			},
 		},
+		{
+			name: "value, script type and sort filters",
+			args: &wallet.ListOutputsArgs{
+				Basket:      "default",
+				MinSatoshis: util.Uint64Ptr(10000),
+				ScriptTypes: []string{script.ScriptTypePubKeyHash},
+				SortOrder:   wallet.OutputSortSatoshisDescending,
+			},
+		},
+		{
+			name: "max satoshis only",
+			args: &wallet.ListOutputsArgs{
+				Basket:      "default",
+				MaxSatoshis: util.Uint64Ptr(546),
+			},
+		},
+		{
+			name: "cursor only",
+			args: &wallet.ListOutputsArgs{
+				Basket: "default",
+				Limit:  util.Uint32Ptr(100),
+				Cursor: "opaque-token",
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -90,6 +114,21 @@ func TestListOutputsResult(t *testing.T) {
 		require.Equal(t, result, got)
 	})
 
+	t.Run("with next cursor", func(t *testing.T) {
+		result := &wallet.ListOutputsResult{
+			TotalOutputs: 0,
+			Outputs:      []wallet.Output{},
+			NextCursor:   "opaque-token",
+		}
+
+		data, err := SerializeListOutputsResult(result)
+		require.NoError(t, err)
+
+		got, err := DeserializeListOutputsResult(data)
+		require.NoError(t, err)
+		require.Equal(t, result, got)
+	})
+
 	t.Run("minimal result", func(t *testing.T) {
 		result := &wallet.ListOutputsResult{
 			TotalOutputs: 0,
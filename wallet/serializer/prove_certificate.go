@@ -99,7 +99,7 @@ func DeserializeProveCertificateArgs(data []byte) (args *wallet.ProveCertificate
 	}
 
 	// Read fields
-	fieldsLen := r.ReadVarInt()
+	fieldsLen := readVarIntCapped(r, 2)
 	if fieldsLen > 0 {
 		args.Certificate.Fields = make(map[string]string, fieldsLen)
 	}
@@ -114,7 +114,7 @@ func DeserializeProveCertificateArgs(data []byte) (args *wallet.ProveCertificate
 	}
 
 	// Read fieldsToReveal
-	fieldsToRevealLen := r.ReadVarInt()
+	fieldsToRevealLen := readVarIntCapped(r, 1)
 	args.FieldsToReveal = make([]string, 0, fieldsToRevealLen)
 	for i := uint64(0); i < fieldsToRevealLen; i++ {
 		fieldBytes := r.ReadIntBytes()
@@ -164,7 +164,7 @@ func DeserializeProveCertificateResult(data []byte) (*wallet.ProveCertificateRes
 	result := &wallet.ProveCertificateResult{}
 
 	// Read keyringForVerifier
-	keyringLen := r.ReadVarInt()
+	keyringLen := readVarIntCapped(r, 2)
 	if keyringLen > 0 {
 		result.KeyringForVerifier = make(map[string]string, keyringLen)
 	}
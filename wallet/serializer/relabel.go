@@ -0,0 +1,162 @@
+package serializer
+
+import (
+	"fmt"
+
+	"github.com/bsv-blockchain/go-sdk/util"
+	"github.com/bsv-blockchain/go-sdk/wallet"
+)
+
+func SerializeRelabelActionsArgs(args *wallet.RelabelActionsArgs) ([]byte, error) {
+	w := util.NewWriter()
+
+	// Filter, with the query mode coded as in listActions
+	w.WriteStringSlice(args.Labels)
+	switch args.LabelQueryMode {
+	case wallet.QueryModeAny:
+		w.WriteByte(labelQueryModeAnyCode)
+	case wallet.QueryModeAll:
+		w.WriteByte(labelQueryModeAllCode)
+	case "":
+		w.WriteNegativeOneByte()
+	default:
+		return nil, fmt.Errorf("invalid label query mode: %s", args.LabelQueryMode)
+	}
+
+	// Changes
+	w.WriteStringSlice(args.AddLabels)
+	w.WriteStringSlice(args.RemoveLabels)
+
+	// Pagination
+	w.WriteOptionalUint32(args.Limit)
+	w.WriteOptionalString(args.Cursor)
+
+	return w.Buf, nil
+}
+
+func DeserializeRelabelActionsArgs(data []byte) (*wallet.RelabelActionsArgs, error) {
+	r := util.NewReaderHoldError(data)
+	args := &wallet.RelabelActionsArgs{}
+
+	args.Labels = r.ReadStringSlice()
+	switch mode := r.ReadByte(); mode {
+	case labelQueryModeAnyCode:
+		args.LabelQueryMode = wallet.QueryModeAny
+	case labelQueryModeAllCode:
+		args.LabelQueryMode = wallet.QueryModeAll
+	case util.NegativeOneByte:
+	default:
+		if r.Err == nil {
+			return nil, fmt.Errorf("invalid label query mode byte: %d", mode)
+		}
+	}
+
+	args.AddLabels = r.ReadStringSlice()
+	args.RemoveLabels = r.ReadStringSlice()
+	args.Limit = r.ReadOptionalUint32()
+	args.Cursor = r.ReadString()
+
+	r.CheckComplete()
+	if r.Err != nil {
+		return nil, fmt.Errorf("error reading relabel actions args: %w", r.Err)
+	}
+	return args, nil
+}
+
+func SerializeRelabelActionsResult(result *wallet.RelabelActionsResult) ([]byte, error) {
+	w := util.NewWriter()
+	w.WriteVarInt(uint64(result.RelabeledActions))
+	w.WriteOptionalString(result.NextCursor)
+	return w.Buf, nil
+}
+
+func DeserializeRelabelActionsResult(data []byte) (*wallet.RelabelActionsResult, error) {
+	r := util.NewReaderHoldError(data)
+	result := &wallet.RelabelActionsResult{
+		RelabeledActions: r.ReadVarInt32(),
+		NextCursor:       r.ReadString(),
+	}
+	r.CheckComplete()
+	if r.Err != nil {
+		return nil, fmt.Errorf("error reading relabel actions result: %w", r.Err)
+	}
+	return result, nil
+}
+
+func SerializeRetagOutputsArgs(args *wallet.RetagOutputsArgs) ([]byte, error) {
+	w := util.NewWriter()
+
+	// Filter, with the query mode coded as in listOutputs
+	w.WriteString(args.Basket)
+	w.WriteStringSlice(args.Tags)
+	switch args.TagQueryMode {
+	case wallet.QueryModeAll:
+		w.WriteByte(tagQueryModeAllCode)
+	case wallet.QueryModeAny:
+		w.WriteByte(tagQueryModeAnyCode)
+	case "":
+		w.WriteNegativeOneByte()
+	default:
+		return nil, fmt.Errorf("invalid tag query mode: %s", args.TagQueryMode)
+	}
+
+	// Changes
+	w.WriteStringSlice(args.AddTags)
+	w.WriteStringSlice(args.RemoveTags)
+
+	// Pagination
+	w.WriteOptionalUint32(args.Limit)
+	w.WriteOptionalString(args.Cursor)
+
+	return w.Buf, nil
+}
+
+func DeserializeRetagOutputsArgs(data []byte) (*wallet.RetagOutputsArgs, error) {
+	r := util.NewReaderHoldError(data)
+	args := &wallet.RetagOutputsArgs{}
+
+	args.Basket = r.ReadString()
+	args.Tags = r.ReadStringSlice()
+	switch mode := r.ReadByte(); mode {
+	case tagQueryModeAllCode:
+		args.TagQueryMode = wallet.QueryModeAll
+	case tagQueryModeAnyCode:
+		args.TagQueryMode = wallet.QueryModeAny
+	case util.NegativeOneByte:
+	default:
+		if r.Err == nil {
+			return nil, fmt.Errorf("invalid tag query mode byte: %d", mode)
+		}
+	}
+
+	args.AddTags = r.ReadStringSlice()
+	args.RemoveTags = r.ReadStringSlice()
+	args.Limit = r.ReadOptionalUint32()
+	args.Cursor = r.ReadString()
+
+	r.CheckComplete()
+	if r.Err != nil {
+		return nil, fmt.Errorf("error reading retag outputs args: %w", r.Err)
+	}
+	return args, nil
+}
+
+func SerializeRetagOutputsResult(result *wallet.RetagOutputsResult) ([]byte, error) {
+	w := util.NewWriter()
+	w.WriteVarInt(uint64(result.RetaggedOutputs))
+	w.WriteOptionalString(result.NextCursor)
+	return w.Buf, nil
+}
+
+func DeserializeRetagOutputsResult(data []byte) (*wallet.RetagOutputsResult, error) {
+	r := util.NewReaderHoldError(data)
+	result := &wallet.RetagOutputsResult{
+		RetaggedOutputs: r.ReadVarInt32(),
+		NextCursor:      r.ReadString(),
+	}
+	r.CheckComplete()
+	if r.Err != nil {
+		return nil, fmt.Errorf("error reading retag outputs result: %w", r.Err)
+	}
+	return result, nil
+}
@@ -0,0 +1,114 @@
+package serializer
+
+import (
+	"testing"
+
+	"github.com/bsv-blockchain/go-sdk/util"
+	"github.com/bsv-blockchain/go-sdk/wallet"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRelabelActionsArgs(t *testing.T) {
+	tests := []struct {
+		name string
+		args *wallet.RelabelActionsArgs
+	}{
+		{
+			name: "full args",
+			args: &wallet.RelabelActionsArgs{
+				Labels:         []string{"invoice", "v1"},
+				LabelQueryMode: wallet.QueryModeAll,
+				AddLabels:      []string{"v2"},
+				RemoveLabels:   []string{"v1"},
+				Limit:          util.Uint32Ptr(500),
+				Cursor:         "opaque-token",
+			},
+		},
+		{
+			name: "minimal args",
+			args: &wallet.RelabelActionsArgs{
+				Labels:    []string{"invoice"},
+				AddLabels: []string{"paid"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := SerializeRelabelActionsArgs(tt.args)
+			require.NoError(t, err)
+			got, err := DeserializeRelabelActionsArgs(data)
+			require.NoError(t, err)
+			require.Equal(t, tt.args, got)
+		})
+	}
+
+	_, err := SerializeRelabelActionsArgs(&wallet.RelabelActionsArgs{Labels: []string{"a"}, LabelQueryMode: "some"})
+	require.Error(t, err)
+}
+
+func TestRelabelActionsResult(t *testing.T) {
+	for _, result := range []*wallet.RelabelActionsResult{
+		{RelabeledActions: 10000, NextCursor: "opaque-token"},
+		{RelabeledActions: 3},
+	} {
+		data, err := SerializeRelabelActionsResult(result)
+		require.NoError(t, err)
+		got, err := DeserializeRelabelActionsResult(data)
+		require.NoError(t, err)
+		require.Equal(t, result, got)
+	}
+}
+
+func TestRetagOutputsArgs(t *testing.T) {
+	tests := []struct {
+		name string
+		args *wallet.RetagOutputsArgs
+	}{
+		{
+			name: "full args",
+			args: &wallet.RetagOutputsArgs{
+				Basket:       "tokens",
+				Tags:         []string{"schema:v1", "owner"},
+				TagQueryMode: wallet.QueryModeAny,
+				AddTags:      []string{"schema:v2"},
+				RemoveTags:   []string{"schema:v1"},
+				Limit:        util.Uint32Ptr(10000),
+				Cursor:       "opaque-token",
+			},
+		},
+		{
+			name: "every basket",
+			args: &wallet.RetagOutputsArgs{
+				Tags:       []string{"schema:v1"},
+				RemoveTags: []string{"schema:v1"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := SerializeRetagOutputsArgs(tt.args)
+			require.NoError(t, err)
+			got, err := DeserializeRetagOutputsArgs(data)
+			require.NoError(t, err)
+			require.Equal(t, tt.args, got)
+		})
+	}
+}
+
+func TestRetagOutputsResult(t *testing.T) {
+	for _, result := range []*wallet.RetagOutputsResult{
+		{RetaggedOutputs: 10000, NextCursor: "opaque-token"},
+		{},
+	} {
+		data, err := SerializeRetagOutputsResult(result)
+		require.NoError(t, err)
+		got, err := DeserializeRetagOutputsResult(data)
+		require.NoError(t, err)
+		require.Equal(t, result, got)
+	}
+
+	_, err := DeserializeRetagOutputsResult([]byte{1, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0})
+	require.Error(t, err)
+}
@@ -15,7 +15,43 @@ import (
 	"github.com/bsv-blockchain/go-sdk/wallet"
 )
 
-// encodeOutpoint converts outpoint string "txid.index" to binary format
+// ErrCountExceedsData is returned when the declared count of a collection is
+// more than the remaining data can hold.
+var ErrCountExceedsData = errors.New("count exceeds remaining data")
+
+// readVarIntCapped reads the count of a collection whose elements take at least
+// minSize bytes each, failing when the remaining data cannot hold that many, so
+// a hostile count cannot size an allocation.
+func readVarIntCapped(r *util.ReaderHoldError, minSize uint64) uint64 {
+	return capCount(r, r.ReadVarInt(), minSize)
+}
+
+// readOptionalVarIntCapped is readVarIntCapped for the count of an optional
+// collection, returning the -1 sentinel of an absent collection as read.
+func readOptionalVarIntCapped(r *util.ReaderHoldError, minSize uint64) uint64 {
+	count := r.ReadVarInt()
+	if util.IsNegativeOne(count) {
+		return count
+	}
+	return capCount(r, count, minSize)
+}
+
+// capCount fails r when its remaining data cannot hold count elements of
+// minSize bytes.
+func capCount(r *util.ReaderHoldError, count, minSize uint64) uint64 {
+	if r.Err != nil {
+		return 0
+	}
+	if remaining := uint64(len(r.Reader.Data) - r.Reader.Pos); count > remaining/minSize {
+		r.Err = fmt.Errorf("%w: %d elements of at least %d bytes, %d bytes remaining",
+			ErrCountExceedsData, count, minSize, remaining)
+		return 0
+	}
+	return count
+}
+
+// encodeOutpoint converts an outpoint to the wire format: the txid followed by the
+// output index as a varint
 func encodeOutpoint(outpoint *transaction.Outpoint) []byte {
 	writer := util.NewWriter()
 	writer.WriteBytesReverse(outpoint.Txid[:])
@@ -24,7 +60,9 @@ func encodeOutpoint(outpoint *transaction.Outpoint) []byte {
 }
 
 // Outpoint represents a transaction output reference (txid + output index)
-type Outpoint string
+//
+// Deprecated: use transaction.Outpoint.
+type Outpoint = transaction.Outpoint
 
 // encodeOutpoints serializes a slice of outpoints
 func encodeOutpoints(outpoints []transaction.Outpoint) ([]byte, error) {
@@ -46,15 +84,16 @@ func decodeOutpoints(data []byte) ([]transaction.Outpoint, error) {
 		return nil, nil
 	}
 
-	r := util.NewReader(data)
-	count, err := r.ReadVarInt()
-	if err != nil {
-		return nil, err
+	hr := util.NewReaderHoldError(data)
+	count := readOptionalVarIntCapped(hr, chainhash.HashSize+1)
+	if hr.Err != nil {
+		return nil, hr.Err
 	}
 	if util.IsNegativeOne(count) {
 		return nil, nil
 	}
 
+	r := &hr.Reader
 	outpoints := make([]transaction.Outpoint, 0, count)
 	for i := uint64(0); i < count; i++ {
 		txBytes, err := r.ReadBytesReverse(chainhash.HashSize)
@@ -348,3 +348,86 @@ func newCounterparty(t *testing.T, pubKeyHex string) wallet.Counterparty {
 func newTestSignature(t *testing.T) *ec.Signature {
 	return tu.GetSigFromHex(t, "302502204e45e16932b8af514961a1d3a1a25fdf3f4f7732e9d624c6c61548ab5fb8cd41020101")
 }
+
+func TestDeserializeHugeCounts(t *testing.T) {
+	// Each frame declares a count no remaining data can hold, as a hostile
+	// client could to exhaust the memory of a wallet.
+	hugeCount := func(prefix ...[]byte) []byte {
+		w := util.NewWriter()
+		for _, p := range prefix {
+			w.WriteBytes(p)
+		}
+		w.WriteVarInt(1 << 62)
+		w.WriteBytes([]byte{1, 2, 3})
+		return w.Buf
+	}
+	tests := []struct {
+		name        string
+		deserialize func([]byte) error
+		data        []byte
+	}{{
+		name: "list certificates certifiers",
+		deserialize: func(data []byte) error {
+			_, err := DeserializeListCertificatesArgs(data)
+			return err
+		},
+		data: hugeCount(),
+	}, {
+		name: "list actions result",
+		deserialize: func(data []byte) error {
+			_, err := DeserializeListActionsResult(data)
+			return err
+		},
+		data: hugeCount(),
+	}, {
+		name: "list outputs result",
+		deserialize: func(data []byte) error {
+			_, err := DeserializeListOutputsResult(data)
+			return err
+		},
+		data: hugeCount(),
+	}, {
+		name: "sign action spends",
+		deserialize: func(data []byte) error {
+			_, err := DeserializeSignActionArgs(data)
+			return err
+		},
+		data: hugeCount(),
+	}, {
+		name: "internalize action outputs",
+		deserialize: func(data []byte) error {
+			_, err := DeserializeInternalizeActionArgs(data)
+			return err
+		},
+		data: hugeCount([]byte{0}),
+	}, {
+		name: "prove certificate keyring",
+		deserialize: func(data []byte) error {
+			_, err := DeserializeProveCertificateResult(data)
+			return err
+		},
+		data: hugeCount(),
+	}, {
+		name: "outpoints",
+		deserialize: func(data []byte) error {
+			_, err := decodeOutpoints(data)
+			return err
+		},
+		data: hugeCount(),
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.ErrorIs(t, tt.deserialize(tt.data), ErrCountExceedsData)
+		})
+	}
+}
+
+func TestDeserializeHugeLengths(t *testing.T) {
+	// The transaction length decodes to 2^63-1, so r.Pos+n overflows.
+	data := []byte{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0x7f}
+	require.NotPanics(t, func() {
+		_, err := DeserializeInternalizeActionArgs(data)
+		require.Error(t, err)
+	})
+}
@@ -57,7 +57,7 @@ func DeserializeSignActionArgs(data []byte) (*wallet.SignActionArgs, error) {
 	args := &wallet.SignActionArgs{}
 
 	// Deserialize spends
-	spendCount := r.ReadVarInt()
+	spendCount := readVarIntCapped(r, 2)
 	args.Spends = make(map[uint32]wallet.SignActionSpend)
 	for i := 0; i < int(spendCount); i++ {
 		inputIndex := r.ReadVarInt32()
@@ -32,7 +32,7 @@ func DeserializeSignActionResult(data []byte) (*wallet.SignActionResult, error)
 	result.Tx = r.ReadOptionalBytes(util.BytesOptionWithFlag)
 
 	// SendWithResults
-	results, err := readTxidSliceWithStatus(&r.Reader)
+	results, err := readTxidSliceWithStatus(r)
 	if err != nil {
 		return nil, fmt.Errorf("reading sendWith results: %w", err)
 	}
@@ -0,0 +1,63 @@
+package serializer
+
+import (
+	"testing"
+
+	ec "github.com/bsv-blockchain/go-sdk/primitives/ec"
+	sighash "github.com/bsv-blockchain/go-sdk/transaction/sighash"
+	"github.com/bsv-blockchain/go-sdk/wallet"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSerializeSigningBundle(t *testing.T) {
+	key, err := ec.NewPrivateKey()
+	require.NoError(t, err)
+
+	bundle := &wallet.SigningBundle{
+		Reference: []byte("ref123"),
+		Tx:        []byte{0x01, 0x02, 0x03},
+		Inputs: []wallet.SigningBundleInput{
+			{
+				InputDerivation: wallet.InputDerivation{
+					InputIndex:   0,
+					ProtocolID:   wallet.Protocol{SecurityLevel: wallet.SecurityLevelEveryAppAndCounterparty, Protocol: "3241645161d8"},
+					KeyID:        "prefix suffix",
+					Counterparty: wallet.Counterparty{Type: wallet.CounterpartyTypeOther, Counterparty: key.PubKey()},
+					SighashFlag:  sighash.AllForkID,
+				},
+				SourceSatoshis:      1000,
+				SourceLockingScript: []byte{0x76, 0xa9},
+				Preimage:            []byte{0xde, 0xad, 0xbe, 0xef},
+			},
+			{
+				InputDerivation: wallet.InputDerivation{
+					InputIndex:   2,
+					ProtocolID:   wallet.Protocol{SecurityLevel: wallet.SecurityLevelEveryApp, Protocol: "offline signing"},
+					KeyID:        "1",
+					Counterparty: wallet.Counterparty{Type: wallet.CounterpartyTypeSelf},
+					SighashFlag:  sighash.SingleForkID | sighash.AnyOneCanPay,
+				},
+				SourceSatoshis:      1,
+				SourceLockingScript: []byte{0x51},
+				Preimage:            []byte{0x00},
+			},
+		},
+	}
+
+	data, err := SerializeSigningBundle(bundle)
+	require.NoError(t, err)
+	got, err := DeserializeSigningBundle(data)
+	require.NoError(t, err)
+	require.Equal(t, bundle, got)
+
+	t.Run("rejects unknown version", func(t *testing.T) {
+		invalid := append([]byte{2}, data[1:]...)
+		_, err := DeserializeSigningBundle(invalid)
+		require.Error(t, err)
+	})
+
+	t.Run("rejects trailing data", func(t *testing.T) {
+		_, err := DeserializeSigningBundle(append(data, 0))
+		require.Error(t, err)
+	})
+}
@@ -42,15 +42,16 @@ func writeTxidSliceWithStatus(w *util.Writer, results []wallet.SendWithResult) e
 	return nil
 }
 
-func readTxidSliceWithStatus(r *util.Reader) ([]wallet.SendWithResult, error) {
-	count, err := r.ReadVarInt()
-	if err != nil {
-		return nil, err
+func readTxidSliceWithStatus(hr *util.ReaderHoldError) ([]wallet.SendWithResult, error) {
+	count := readVarIntCapped(hr, chainhash.HashSize+1)
+	if hr.Err != nil {
+		return nil, hr.Err
 	}
 	if count == 0 {
 		return nil, nil
 	}
 
+	r := &hr.Reader
 	results := make([]wallet.SendWithResult, 0, count)
 	for i := uint64(0); i < count; i++ {
 		txidBytes, err := r.ReadBytes(chainhash.HashSize)
@@ -0,0 +1,133 @@
+package wallet
+
+import (
+	"container/list"
+	"math/big"
+	"sync"
+
+	ec "github.com/bsv-blockchain/go-sdk/primitives/ec"
+	hash "github.com/bsv-blockchain/go-sdk/primitives/hash"
+)
+
+// sharedSecretCache is an LRU cache of the ECDH shared secrets between a key
+// deriver's root key and its counterparties. Every BRC-42 derivation for a
+// counterparty starts with the same scalar multiplication, so caching the
+// result saves the bulk of the work for repeated operations with it.
+//
+// A cache belongs to a single key deriver, so entries are keyed by the
+// counterparty alone; the root key half of the pair is implicit.
+type sharedSecretCache struct {
+	items   map[[33]byte]*list.Element
+	list    *list.List
+	maxSize int
+	mu      sync.Mutex
+}
+
+type sharedSecretEntry struct {
+	counterparty [33]byte
+	secret       *ec.PublicKey
+}
+
+func newSharedSecretCache(maxSize int) *sharedSecretCache {
+	if maxSize <= 0 {
+		maxSize = defaultMaxCacheSize
+	}
+	return &sharedSecretCache{
+		items:   make(map[[33]byte]*list.Element),
+		list:    list.New(),
+		maxSize: maxSize,
+	}
+}
+
+func (c *sharedSecretCache) get(counterparty [33]byte) (*ec.PublicKey, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if elem, ok := c.items[counterparty]; ok {
+		c.list.MoveToFront(elem)
+		return elem.Value.(*sharedSecretEntry).secret, true
+	}
+	return nil, false
+}
+
+func (c *sharedSecretCache) set(counterparty [33]byte, secret *ec.PublicKey) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if elem, ok := c.items[counterparty]; ok {
+		elem.Value.(*sharedSecretEntry).secret = secret
+		c.list.MoveToFront(elem)
+		return
+	}
+
+	c.items[counterparty] = c.list.PushFront(&sharedSecretEntry{counterparty: counterparty, secret: secret})
+
+	if len(c.items) > c.maxSize {
+		oldest := c.list.Back()
+		delete(c.items, oldest.Value.(*sharedSecretEntry).counterparty)
+		c.list.Remove(oldest)
+	}
+}
+
+// clear drops all cached shared secrets. They are not zeroed since they may
+// have been returned to callers by RevealCounterpartySecret.
+func (c *sharedSecretCache) clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	clear(c.items)
+	c.list.Init()
+}
+
+// sharedSecret returns the ECDH shared secret between the root key and the
+// counterparty, consulting the shared secret cache when one is configured.
+func (kd *KeyDeriver) sharedSecret(counterparty *ec.PublicKey) (*ec.PublicKey, error) {
+	if kd.secrets == nil {
+		return kd.rootKey.DeriveSharedSecret(counterparty)
+	}
+
+	var key [33]byte
+	copy(key[:], counterparty.Compressed())
+	if secret, ok := kd.secrets.get(key); ok {
+		return secret, nil
+	}
+
+	secret, err := kd.rootKey.DeriveSharedSecret(counterparty)
+	if err != nil {
+		return nil, err
+	}
+	kd.secrets.set(key, secret)
+	return secret, nil
+}
+
+// withSharedSecretCache returns a copy of the key deriver that caches up to
+// maxSize shared secrets.
+func (kd *KeyDeriver) withSharedSecretCache(maxSize int) *KeyDeriver {
+	return &KeyDeriver{
+		rootKey: kd.rootKey,
+		secrets: newSharedSecretCache(maxSize),
+	}
+}
+
+// childPrivateKey derives the BRC-42 child of the root key from the shared
+// secret with the counterparty. It matches ec.PrivateKey.DeriveChild.
+func (kd *KeyDeriver) childPrivateKey(sharedSecret *ec.PublicKey, invoiceNumber string) *ec.PrivateKey {
+	hmac := hash.Sha256HMAC([]byte(invoiceNumber), sharedSecret.Compressed())
+
+	d := new(big.Int).SetBytes(hmac)
+	d.Add(d, kd.rootKey.D)
+	d.Mod(d, ec.S256().N)
+	privKey, _ := ec.PrivateKeyFromBytes(d.Bytes())
+	return privKey
+}
+
+// childPublicKey derives the BRC-42 child of the counterparty key from the
+// shared secret with the root key. It matches ec.PublicKey.DeriveChild.
+func childPublicKey(counterparty, sharedSecret *ec.PublicKey, invoiceNumber string) *ec.PublicKey {
+	hmac := hash.Sha256HMAC([]byte(invoiceNumber), sharedSecret.Compressed())
+
+	curve := ec.S256()
+	x, y := curve.ScalarBaseMult(hmac)
+	x, y = curve.Add(x, y, counterparty.X, counterparty.Y)
+	return &ec.PublicKey{Curve: curve, X: x, Y: y}
+}
@@ -0,0 +1,125 @@
+package wallet
+
+import (
+	"testing"
+
+	ec "github.com/bsv-blockchain/go-sdk/primitives/ec"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSharedSecretCacheMatchesUncached(t *testing.T) {
+	rootKey, err := ec.NewPrivateKey()
+	require.NoError(t, err)
+	counterpartyKey, err := ec.NewPrivateKey()
+	require.NoError(t, err)
+
+	plain := NewKeyDeriver(rootKey)
+	cached := plain.withSharedSecretCache(2)
+
+	protocol := Protocol{SecurityLevel: SecurityLevelEveryAppAndCounterparty, Protocol: "shared secret cache"}
+	for _, counterparty := range []Counterparty{
+		{Type: CounterpartyTypeSelf},
+		{Type: CounterpartyTypeAnyone},
+		{Type: CounterpartyTypeOther, Counterparty: counterpartyKey.PubKey()},
+	} {
+		// Run twice so the second round is served from the cache
+		for range 2 {
+			want, err := plain.DerivePrivateKey(protocol, "1", counterparty)
+			require.NoError(t, err)
+			got, err := cached.DerivePrivateKey(protocol, "1", counterparty)
+			require.NoError(t, err)
+			require.Equal(t, want.Serialize(), got.Serialize())
+
+			for _, forSelf := range []bool{false, true} {
+				want, err := plain.DerivePublicKey(protocol, "1", counterparty, forSelf)
+				require.NoError(t, err)
+				got, err := cached.DerivePublicKey(protocol, "1", counterparty, forSelf)
+				require.NoError(t, err)
+				require.True(t, want.IsEqual(got))
+			}
+
+			wantSym, err := plain.DeriveSymmetricKey(protocol, "1", counterparty)
+			require.NoError(t, err)
+			gotSym, err := cached.DeriveSymmetricKey(protocol, "1", counterparty)
+			require.NoError(t, err)
+			require.Equal(t, wantSym.ToBytes(), gotSym.ToBytes())
+
+			wantSecret, err := plain.RevealSpecificSecret(counterparty, protocol, "1")
+			require.NoError(t, err)
+			gotSecret, err := cached.RevealSpecificSecret(counterparty, protocol, "1")
+			require.NoError(t, err)
+			require.Equal(t, wantSecret, gotSecret)
+		}
+	}
+}
+
+func TestSharedSecretCacheEviction(t *testing.T) {
+	rootKey, err := ec.NewPrivateKey()
+	require.NoError(t, err)
+	kd := NewKeyDeriver(rootKey).withSharedSecretCache(2)
+
+	var counterparties []*ec.PublicKey
+	for range 3 {
+		k, err := ec.NewPrivateKey()
+		require.NoError(t, err)
+		counterparties = append(counterparties, k.PubKey())
+		_, err = kd.sharedSecret(k.PubKey())
+		require.NoError(t, err)
+	}
+
+	require.Equal(t, 2, kd.secrets.list.Len())
+	var first [33]byte
+	copy(first[:], counterparties[0].Compressed())
+	_, ok := kd.secrets.get(first)
+	require.False(t, ok, "least recently used counterparty should be evicted")
+
+	for _, pub := range counterparties[1:] {
+		var key [33]byte
+		copy(key[:], pub.Compressed())
+		secret, ok := kd.secrets.get(key)
+		require.True(t, ok)
+		want, err := rootKey.DeriveSharedSecret(pub)
+		require.NoError(t, err)
+		require.True(t, want.IsEqual(secret))
+	}
+}
+
+func TestProtoWalletSharedSecretCache(t *testing.T) {
+	key, err := ec.NewPrivateKey()
+	require.NoError(t, err)
+
+	w, err := NewProtoWallet(ProtoWalletArgs{Type: ProtoWalletArgsTypePrivateKey, PrivateKey: key, SharedSecretCacheSize: 10})
+	require.NoError(t, err)
+	require.NotNil(t, w.keyDeriver.(*KeyDeriver).secrets)
+
+	kd := NewKeyDeriver(key)
+	w, err = NewProtoWallet(ProtoWalletArgs{Type: ProtoWalletArgsTypeKeyDeriver, KeyDeriver: kd, SharedSecretCacheSize: 10})
+	require.NoError(t, err)
+	require.NotNil(t, w.keyDeriver.(*KeyDeriver).secrets)
+	require.Nil(t, kd.secrets, "the caller's key deriver is left untouched")
+
+	w, err = NewProtoWallet(ProtoWalletArgs{Type: ProtoWalletArgsTypePrivateKey, PrivateKey: key})
+	require.NoError(t, err)
+	require.Nil(t, w.keyDeriver.(*KeyDeriver).secrets)
+}
+
+func TestKeyDeriverWipe(t *testing.T) {
+	rootKey, err := ec.NewPrivateKey()
+	require.NoError(t, err)
+	counterparty, err := ec.NewPrivateKey()
+	require.NoError(t, err)
+
+	kd := NewKeyDeriver(rootKey).withSharedSecretCache(10)
+	_, err = kd.sharedSecret(counterparty.PubKey())
+	require.NoError(t, err)
+
+	cached := NewCachedKeyDeriverFrom(kd, 0)
+	protocol := Protocol{SecurityLevel: SecurityLevelSilent, Protocol: "wipe test"}
+	_, err = cached.DeriveSymmetricKey(protocol, "1", Counterparty{Type: CounterpartyTypeSelf})
+	require.NoError(t, err)
+
+	cached.Wipe()
+	require.Zero(t, rootKey.D.Sign())
+	require.Zero(t, kd.secrets.list.Len())
+	require.Zero(t, cached.cache.list.Len())
+}
@@ -0,0 +1,370 @@
+// Package migrate runs versioned schema migrations for SQL wallet storage.
+//
+// Storage backends embed their migrations with go:embed, as files named
+// <version>_<name>.up.sql with an optional <version>_<name>.down.sql:
+//
+//	//go:embed migrations/*.sql
+//	var migrations embed.FS
+//
+//	ms, err := migrate.Load(migrations, "migrations")
+//	m, err := migrate.New(db, ms)
+//	err = m.Up(ctx)
+//
+// The applied migrations are recorded with a checksum of their up script in a
+// table of the database, schema_migrations by default, so that deployments can
+// be upgraded in place and edits to migrations which already ran are caught by
+// Check before anything else is applied.
+//
+// Each migration runs in its own database transaction with its record. Its
+// script is executed in one statement, so the driver must accept several
+// statements per Exec, as the SQLite and PostgreSQL drivers do.
+package migrate
+
+import (
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	ErrInvalidMigration = errors.New("invalid migration")
+	ErrChecksumMismatch = errors.New("migration changed since it was applied")
+	ErrUnknownMigration = errors.New("database has a migration unknown to this version")
+	ErrMissingMigration = errors.New("migration was skipped")
+	ErrIrreversible     = errors.New("migration has no down script")
+)
+
+// Migration is a versioned schema change.
+type Migration struct {
+	Version uint64
+	Name    string
+	// Up applies the migration.
+	Up string
+	// Down reverts it, and is empty for irreversible migrations.
+	Down string
+}
+
+// Checksum returns the checksum of the up script recorded when the migration is
+// applied.
+func (m Migration) Checksum() string {
+	sum := sha256.Sum256([]byte(m.Up))
+	return hex.EncodeToString(sum[:])
+}
+
+// Applied is the record of an applied migration.
+type Applied struct {
+	Version   uint64
+	Name      string
+	Checksum  string
+	AppliedAt time.Time
+}
+
+var fileName = regexp.MustCompile(`^(\d+)_([^.]+)\.(up|down)\.sql$`)
+
+// Load reads the migrations of dir in fsys, ordered by version. Files not named
+// like migrations are ignored.
+func Load(fsys fs.FS, dir string) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, dir)
+	if err != nil {
+		return nil, err
+	}
+	byVersion := make(map[uint64]*Migration)
+	for _, entry := range entries {
+		match := fileName.FindStringSubmatch(entry.Name())
+		if entry.IsDir() || match == nil {
+			continue
+		}
+		version, err := strconv.ParseUint(match[1], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s: %w", ErrInvalidMigration, entry.Name(), err)
+		}
+		script, err := fs.ReadFile(fsys, path.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+
+		m, ok := byVersion[version]
+		if !ok {
+			m = &Migration{Version: version, Name: match[2]}
+			byVersion[version] = m
+		} else if m.Name != match[2] {
+			return nil, fmt.Errorf("%w: version %d is named both %q and %q", ErrInvalidMigration, version, m.Name, match[2])
+		}
+		if match[3] == "up" {
+			m.Up = string(script)
+		} else {
+			m.Down = string(script)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, m := range byVersion {
+		if strings.TrimSpace(m.Up) == "" {
+			return nil, fmt.Errorf("%w: version %d has no up script", ErrInvalidMigration, m.Version)
+		}
+		migrations = append(migrations, *m)
+	}
+	slices.SortFunc(migrations, func(a, b Migration) int {
+		return compare(a.Version, b.Version)
+	})
+	return migrations, nil
+}
+
+// Option configures a Migrator.
+type Option func(*Migrator)
+
+// WithTable sets the table recording the applied migrations, schema_migrations
+// by default. The name must be a plain SQL identifier.
+func WithTable(name string) Option {
+	return func(m *Migrator) {
+		m.table = name
+	}
+}
+
+// WithDollarPlaceholders makes the Migrator use $1 style placeholders, as
+// PostgreSQL requires, in place of ?.
+func WithDollarPlaceholders() Option {
+	return func(m *Migrator) {
+		m.placeholder = func(n int) string { return "$" + strconv.Itoa(n) }
+	}
+}
+
+// Migrator applies and reverts migrations on a database.
+type Migrator struct {
+	db          *sql.DB
+	migrations  []Migration
+	table       string
+	placeholder func(n int) string
+}
+
+var identifier = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// New returns a Migrator applying migrations, which must have distinct versions,
+// to db.
+func New(db *sql.DB, migrations []Migration, opts ...Option) (*Migrator, error) {
+	m := &Migrator{
+		db:          db,
+		migrations:  slices.Clone(migrations),
+		table:       "schema_migrations",
+		placeholder: func(int) string { return "?" },
+	}
+	for _, opt := range opts {
+		opt(m)
+	}
+	if !identifier.MatchString(m.table) {
+		return nil, fmt.Errorf("invalid migrations table name %q", m.table)
+	}
+	slices.SortFunc(m.migrations, func(a, b Migration) int {
+		return compare(a.Version, b.Version)
+	})
+	for i, mig := range m.migrations {
+		if i > 0 && m.migrations[i-1].Version == mig.Version {
+			return nil, fmt.Errorf("%w: duplicate version %d", ErrInvalidMigration, mig.Version)
+		}
+	}
+	return m, nil
+}
+
+// Applied returns the migrations applied to the database, ordered by version.
+func (m *Migrator) Applied(ctx context.Context) ([]Applied, error) {
+	if err := m.ensureTable(ctx); err != nil {
+		return nil, err
+	}
+	rows, err := m.db.QueryContext(ctx, fmt.Sprintf(
+		"SELECT version, name, checksum, applied_at FROM %s ORDER BY version", m.table))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read applied migrations: %w", err)
+	}
+	defer rows.Close()
+
+	var applied []Applied
+	for rows.Next() {
+		var a Applied
+		var appliedAt string
+		if err := rows.Scan(&a.Version, &a.Name, &a.Checksum, &appliedAt); err != nil {
+			return nil, fmt.Errorf("failed to read applied migrations: %w", err)
+		}
+		if a.AppliedAt, err = time.Parse(time.RFC3339Nano, appliedAt); err != nil {
+			return nil, fmt.Errorf("failed to read applied migrations: %w", err)
+		}
+		applied = append(applied, a)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read applied migrations: %w", err)
+	}
+	return applied, nil
+}
+
+// Version returns the version of the last applied migration, 0 when none is.
+func (m *Migrator) Version(ctx context.Context) (uint64, error) {
+	applied, err := m.Applied(ctx)
+	if err != nil || len(applied) == 0 {
+		return 0, err
+	}
+	return applied[len(applied)-1].Version, nil
+}
+
+// Check verifies the integrity of the applied migrations: each must be known,
+// unchanged since it was applied, and no known migration may be missing before
+// the last applied one.
+func (m *Migrator) Check(ctx context.Context) error {
+	applied, err := m.Applied(ctx)
+	if err != nil {
+		return err
+	}
+	_, err = m.pending(applied)
+	return err
+}
+
+// pending checks the applied migrations and returns the known migrations which
+// are not.
+func (m *Migrator) pending(applied []Applied) ([]Migration, error) {
+	known := make(map[uint64]Migration, len(m.migrations))
+	for _, mig := range m.migrations {
+		known[mig.Version] = mig
+	}
+	done := make(map[uint64]bool, len(applied))
+	var last uint64
+	for _, a := range applied {
+		mig, ok := known[a.Version]
+		if !ok {
+			return nil, fmt.Errorf("%w: %d_%s", ErrUnknownMigration, a.Version, a.Name)
+		}
+		if mig.Checksum() != a.Checksum {
+			return nil, fmt.Errorf("%w: %d_%s", ErrChecksumMismatch, a.Version, a.Name)
+		}
+		done[a.Version] = true
+		last = max(last, a.Version)
+	}
+
+	var pending []Migration
+	for _, mig := range m.migrations {
+		if done[mig.Version] {
+			continue
+		}
+		if mig.Version < last {
+			return nil, fmt.Errorf("%w: %d_%s is older than applied version %d", ErrMissingMigration, mig.Version, mig.Name, last)
+		}
+		pending = append(pending, mig)
+	}
+	return pending, nil
+}
+
+// Up applies the pending migrations in order, after checking the integrity of
+// the applied ones. It stops at the first failing migration, which is rolled
+// back; the migrations before it stay applied.
+func (m *Migrator) Up(ctx context.Context) error {
+	applied, err := m.Applied(ctx)
+	if err != nil {
+		return err
+	}
+	pending, err := m.pending(applied)
+	if err != nil {
+		return err
+	}
+	insert := fmt.Sprintf("INSERT INTO %s (version, name, checksum, applied_at) VALUES (%s, %s, %s, %s)",
+		m.table, m.placeholder(1), m.placeholder(2), m.placeholder(3), m.placeholder(4))
+	for _, mig := range pending {
+		err := m.inTx(ctx, func(tx *sql.Tx) error {
+			if _, err := tx.ExecContext(ctx, mig.Up); err != nil {
+				return err
+			}
+			_, err := tx.ExecContext(ctx, insert,
+				mig.Version, mig.Name, mig.Checksum(), time.Now().UTC().Format(time.RFC3339Nano))
+			return err
+		})
+		if err != nil {
+			return fmt.Errorf("failed to apply migration %d_%s: %w", mig.Version, mig.Name, err)
+		}
+	}
+	return nil
+}
+
+// Down reverts the applied migrations newer than version, newest first, after
+// checking the integrity of the applied ones. Down(ctx, 0) reverts them all.
+// Nothing is reverted when any of them is irreversible.
+func (m *Migrator) Down(ctx context.Context, version uint64) error {
+	applied, err := m.Applied(ctx)
+	if err != nil {
+		return err
+	}
+	if _, err := m.pending(applied); err != nil {
+		return err
+	}
+	known := make(map[uint64]Migration, len(m.migrations))
+	for _, mig := range m.migrations {
+		known[mig.Version] = mig
+	}
+
+	var revert []Migration
+	for _, a := range slices.Backward(applied) {
+		if a.Version <= version {
+			break
+		}
+		mig := known[a.Version]
+		if strings.TrimSpace(mig.Down) == "" {
+			return fmt.Errorf("%w: %d_%s", ErrIrreversible, mig.Version, mig.Name)
+		}
+		revert = append(revert, mig)
+	}
+
+	remove := fmt.Sprintf("DELETE FROM %s WHERE version = %s", m.table, m.placeholder(1))
+	for _, mig := range revert {
+		err := m.inTx(ctx, func(tx *sql.Tx) error {
+			if _, err := tx.ExecContext(ctx, mig.Down); err != nil {
+				return err
+			}
+			_, err := tx.ExecContext(ctx, remove, mig.Version)
+			return err
+		})
+		if err != nil {
+			return fmt.Errorf("failed to revert migration %d_%s: %w", mig.Version, mig.Name, err)
+		}
+	}
+	return nil
+}
+
+func (m *Migrator) ensureTable(ctx context.Context) error {
+	_, err := m.db.ExecContext(ctx, fmt.Sprintf("CREATE TABLE IF NOT EXISTS %s ("+
+		"version BIGINT PRIMARY KEY, "+
+		"name TEXT NOT NULL, "+
+		"checksum TEXT NOT NULL, "+
+		"applied_at TEXT NOT NULL)", m.table))
+	if err != nil {
+		return fmt.Errorf("failed to create migrations table: %w", err)
+	}
+	return nil
+}
+
+func (m *Migrator) inTx(ctx context.Context, f func(tx *sql.Tx) error) error {
+	tx, err := m.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	if err := f(tx); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
+
+func compare(a, b uint64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
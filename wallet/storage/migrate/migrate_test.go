@@ -0,0 +1,298 @@
+package migrate
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"embed"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/require"
+)
+
+//go:embed testdata/*.sql
+var testMigrations embed.FS
+
+// fakeDB is a database understanding the Migrator's bookkeeping statements,
+// which logs the migration scripts it runs and fails those containing FAIL.
+type fakeDB struct {
+	mu      sync.Mutex
+	records map[int64][]driver.Value
+	scripts []string
+	// snapshot is the state at the start of the open transaction.
+	snapshot *fakeDB
+}
+
+type fakeDriver struct {
+	mu  sync.Mutex
+	dbs map[string]*fakeDB
+}
+
+var testDriver = &fakeDriver{dbs: make(map[string]*fakeDB)}
+
+func init() {
+	sql.Register("migratetest", testDriver)
+}
+
+func (d *fakeDriver) Open(name string) (driver.Conn, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	db, ok := d.dbs[name]
+	if !ok {
+		db = &fakeDB{records: make(map[int64][]driver.Value)}
+		d.dbs[name] = db
+	}
+	return &fakeConn{db: db}, nil
+}
+
+type fakeConn struct {
+	db *fakeDB
+}
+
+func (c *fakeConn) Prepare(string) (driver.Stmt, error) {
+	return nil, errors.New("prepared statements not supported")
+}
+
+func (c *fakeConn) Close() error { return nil }
+
+func (c *fakeConn) Begin() (driver.Tx, error) {
+	c.db.mu.Lock()
+	defer c.db.mu.Unlock()
+	c.db.snapshot = &fakeDB{records: maps.Clone(c.db.records), scripts: slices.Clone(c.db.scripts)}
+	return c, nil
+}
+
+func (c *fakeConn) Commit() error {
+	c.db.mu.Lock()
+	defer c.db.mu.Unlock()
+	c.db.snapshot = nil
+	return nil
+}
+
+func (c *fakeConn) Rollback() error {
+	c.db.mu.Lock()
+	defer c.db.mu.Unlock()
+	c.db.records, c.db.scripts = c.db.snapshot.records, c.db.snapshot.scripts
+	c.db.snapshot = nil
+	return nil
+}
+
+func (c *fakeConn) ExecContext(_ context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	c.db.mu.Lock()
+	defer c.db.mu.Unlock()
+	switch {
+	case strings.HasPrefix(query, "CREATE TABLE IF NOT EXISTS"):
+	case strings.HasPrefix(query, "INSERT INTO"):
+		row := make([]driver.Value, len(args))
+		for i, arg := range args {
+			row[i] = arg.Value
+		}
+		c.db.records[row[0].(int64)] = row
+	case strings.HasPrefix(query, "DELETE FROM"):
+		delete(c.db.records, args[0].Value.(int64))
+	case strings.Contains(query, "FAIL"):
+		return nil, errors.New("syntax error")
+	default:
+		c.db.scripts = append(c.db.scripts, query)
+	}
+	return driver.RowsAffected(1), nil
+}
+
+func (c *fakeConn) QueryContext(_ context.Context, query string, _ []driver.NamedValue) (driver.Rows, error) {
+	if !strings.HasPrefix(query, "SELECT version") {
+		return nil, fmt.Errorf("unexpected query %q", query)
+	}
+	c.db.mu.Lock()
+	defer c.db.mu.Unlock()
+	versions := slices.Sorted(maps.Keys(c.db.records))
+	rows := &fakeRows{}
+	for _, version := range versions {
+		rows.rows = append(rows.rows, c.db.records[version])
+	}
+	return rows, nil
+}
+
+type fakeRows struct {
+	rows [][]driver.Value
+}
+
+func (r *fakeRows) Columns() []string {
+	return []string{"version", "name", "checksum", "applied_at"}
+}
+
+func (r *fakeRows) Close() error { return nil }
+
+func (r *fakeRows) Next(dest []driver.Value) error {
+	if len(r.rows) == 0 {
+		return io.EOF
+	}
+	copy(dest, r.rows[0])
+	r.rows = r.rows[1:]
+	return nil
+}
+
+func openTestDB(t *testing.T) (*sql.DB, *fakeDB) {
+	t.Helper()
+	db, err := sql.Open("migratetest", t.Name())
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+	require.NoError(t, db.Ping())
+	return db, testDriver.dbs[t.Name()]
+}
+
+func loadTestMigrations(t *testing.T) []Migration {
+	t.Helper()
+	migrations, err := Load(testMigrations, "testdata")
+	require.NoError(t, err)
+	return migrations
+}
+
+func TestLoad(t *testing.T) {
+	migrations := loadTestMigrations(t)
+	require.Len(t, migrations, 3)
+	require.Equal(t, []uint64{1, 2, 3}, []uint64{migrations[0].Version, migrations[1].Version, migrations[2].Version})
+	require.Equal(t, "create_outputs", migrations[0].Name)
+	require.Contains(t, migrations[0].Up, "CREATE TABLE outputs")
+	require.Equal(t, "DROP TABLE outputs;\n", migrations[0].Down)
+	require.Empty(t, migrations[2].Down)
+
+	t.Run("ignores other files", func(t *testing.T) {
+		migrations, err := Load(fstest.MapFS{
+			"m/10_b.up.sql": {Data: []byte("B")},
+			"m/9_a.up.sql":  {Data: []byte("A")},
+			"m/README.md":   {Data: []byte("docs")},
+		}, "m")
+		require.NoError(t, err)
+		require.Len(t, migrations, 2)
+		require.Equal(t, uint64(9), migrations[0].Version)
+		require.Equal(t, uint64(10), migrations[1].Version)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		_, err := Load(fstest.MapFS{"m/1_a.down.sql": {Data: []byte("A")}}, "m")
+		require.ErrorIs(t, err, ErrInvalidMigration)
+		_, err = Load(fstest.MapFS{
+			"m/1_a.up.sql":   {Data: []byte("A")},
+			"m/1_b.down.sql": {Data: []byte("B")},
+		}, "m")
+		require.ErrorIs(t, err, ErrInvalidMigration)
+	})
+}
+
+func TestMigrator(t *testing.T) {
+	ctx := t.Context()
+
+	t.Run("up and down", func(t *testing.T) {
+		db, fake := openTestDB(t)
+		migrations := loadTestMigrations(t)
+		m, err := New(db, migrations[:2])
+		require.NoError(t, err)
+
+		version, err := m.Version(ctx)
+		require.NoError(t, err)
+		require.Zero(t, version)
+
+		require.NoError(t, m.Up(ctx))
+		require.Equal(t, []string{migrations[0].Up, migrations[1].Up}, fake.scripts)
+		applied, err := m.Applied(ctx)
+		require.NoError(t, err)
+		require.Len(t, applied, 2)
+		require.Equal(t, "add_labels", applied[1].Name)
+		require.Equal(t, migrations[1].Checksum(), applied[1].Checksum)
+		require.False(t, applied[1].AppliedAt.IsZero())
+
+		// Nothing left to apply.
+		require.NoError(t, m.Up(ctx))
+		require.Len(t, fake.scripts, 2)
+
+		// A later release adds a migration.
+		m, err = New(db, migrations)
+		require.NoError(t, err)
+		require.NoError(t, m.Up(ctx))
+		version, err = m.Version(ctx)
+		require.NoError(t, err)
+		require.Equal(t, uint64(3), version)
+
+		require.ErrorIs(t, m.Down(ctx, 1), ErrIrreversible)
+		require.Len(t, fake.scripts, 3)
+
+		m, err = New(db, append(migrations[:2], Migration{Version: 3, Name: "index_satoshis", Up: migrations[2].Up, Down: "DROP INDEX outputs_satoshis;"}))
+		require.NoError(t, err)
+		require.NoError(t, m.Down(ctx, 1))
+		require.Equal(t, []string{"DROP INDEX outputs_satoshis;", migrations[1].Down}, fake.scripts[3:])
+		version, err = m.Version(ctx)
+		require.NoError(t, err)
+		require.Equal(t, uint64(1), version)
+
+		require.NoError(t, m.Down(ctx, 0))
+		version, err = m.Version(ctx)
+		require.NoError(t, err)
+		require.Zero(t, version)
+	})
+
+	t.Run("failing migration", func(t *testing.T) {
+		db, fake := openTestDB(t)
+		migrations := append(loadTestMigrations(t)[:1],
+			Migration{Version: 2, Name: "broken", Up: "FAIL"},
+			Migration{Version: 3, Name: "never", Up: "CREATE TABLE never (id INTEGER);"})
+		m, err := New(db, migrations)
+		require.NoError(t, err)
+
+		err = m.Up(ctx)
+		require.ErrorContains(t, err, "2_broken")
+		require.ErrorContains(t, err, "syntax error")
+		require.Len(t, fake.scripts, 1)
+		version, err := m.Version(ctx)
+		require.NoError(t, err)
+		require.Equal(t, uint64(1), version)
+	})
+
+	t.Run("integrity", func(t *testing.T) {
+		db, _ := openTestDB(t)
+		migrations := loadTestMigrations(t)
+		m, err := New(db, migrations[:2])
+		require.NoError(t, err)
+		require.NoError(t, m.Up(ctx))
+		require.NoError(t, m.Check(ctx))
+
+		edited := slices.Clone(migrations[:2])
+		edited[1].Up = "CREATE TABLE labels (id INTEGER);"
+		m, err = New(db, edited)
+		require.NoError(t, err)
+		require.ErrorIs(t, m.Check(ctx), ErrChecksumMismatch)
+		require.ErrorIs(t, m.Up(ctx), ErrChecksumMismatch)
+
+		// An older release than the database.
+		m, err = New(db, migrations[:1])
+		require.NoError(t, err)
+		require.ErrorIs(t, m.Check(ctx), ErrUnknownMigration)
+
+		// A migration inserted before applied ones.
+		m, err = New(db, append(slices.Clone(migrations[:2]), Migration{Version: 0, Name: "late", Up: "SELECT 1;"}))
+		require.NoError(t, err)
+		require.ErrorIs(t, m.Up(ctx), ErrMissingMigration)
+	})
+
+	t.Run("options", func(t *testing.T) {
+		db, _ := openTestDB(t)
+		_, err := New(db, nil, WithTable("migrations; DROP TABLE outputs"))
+		require.Error(t, err)
+		_, err = New(db, []Migration{{Version: 1, Up: "A"}, {Version: 1, Up: "B"}})
+		require.ErrorIs(t, err, ErrInvalidMigration)
+
+		m, err := New(db, loadTestMigrations(t), WithTable("wallet_migrations"), WithDollarPlaceholders())
+		require.NoError(t, err)
+		require.NoError(t, m.Up(ctx))
+		version, err := m.Version(ctx)
+		require.NoError(t, err)
+		require.Equal(t, uint64(3), version)
+	})
+}
@@ -0,0 +1,6 @@
+CREATE TABLE outputs (
+    txid TEXT NOT NULL,
+    vout INTEGER NOT NULL,
+    satoshis BIGINT NOT NULL,
+    PRIMARY KEY (txid, vout)
+);
@@ -0,0 +1,4 @@
+CREATE TABLE labels (
+    id INTEGER PRIMARY KEY,
+    label TEXT NOT NULL UNIQUE
+);
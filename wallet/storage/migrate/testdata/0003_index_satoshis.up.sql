@@ -0,0 +1 @@
+CREATE INDEX outputs_satoshis ON outputs (satoshis);
@@ -0,0 +1,362 @@
+package substrates
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync/atomic"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+// Content codings of compressed wire frames, in the order of preference.
+const (
+	EncodingZstd = "zstd"
+	EncodingGzip = "gzip"
+)
+
+// DefaultCompressionThreshold is the size of the smallest params or result
+// frame compressed by default. Smaller frames are sent as is, as compressing
+// them saves too little to pay for itself.
+const DefaultCompressionThreshold = 16 * 1024
+
+// DefaultMaxDecompressedSize bounds the size of decompressed frames by default,
+// so that small compressed bodies cannot expand without limit.
+const DefaultMaxDecompressedSize = 128 << 20
+
+var (
+	// ErrUnsupportedEncoding is returned for a content coding other than
+	// EncodingZstd and EncodingGzip.
+	ErrUnsupportedEncoding = errors.New("unsupported content encoding")
+	// ErrDecompressedTooLarge is returned when reading a compressed frame
+	// decompressing to more than the maximum size.
+	ErrDecompressedTooLarge = errors.New("decompressed frame too large")
+)
+
+// compressionEncodings are the supported content codings, in the order of
+// preference, as advertised in Accept-Encoding.
+var compressionEncodings = []string{EncodingZstd, EncodingGzip}
+
+var acceptEncoding = strings.Join(compressionEncodings, ", ")
+
+// negotiateEncoding returns the preferred supported coding listed in the
+// Accept-Encoding header values, or "" when none is.
+func negotiateEncoding(values []string) string {
+	accepted := make(map[string]bool)
+	for _, value := range values {
+		for _, coding := range strings.Split(value, ",") {
+			name, params, _ := strings.Cut(coding, ";")
+			if q, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+				if weight, err := strconv.ParseFloat(q, 64); err == nil && weight == 0 {
+					continue
+				}
+			}
+			accepted[strings.ToLower(strings.TrimSpace(name))] = true
+		}
+	}
+	for _, encoding := range compressionEncodings {
+		if accepted[encoding] {
+			return encoding
+		}
+	}
+	return ""
+}
+
+// compressWriter returns a writer compressing to w with encoding. Closing it
+// flushes the compressed stream without closing w.
+func compressWriter(w io.Writer, encoding string) (io.WriteCloser, error) {
+	switch encoding {
+	case EncodingZstd:
+		return zstd.NewWriter(w)
+	case EncodingGzip:
+		return gzip.NewWriter(w), nil
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedEncoding, encoding)
+	}
+}
+
+// compress returns data compressed with encoding.
+func compress(data []byte, encoding string) ([]byte, error) {
+	var buf bytes.Buffer
+	zw, err := compressWriter(&buf, encoding)
+	if err != nil {
+		return nil, err
+	}
+	if _, err = zw.Write(data); err != nil {
+		return nil, err
+	}
+	if err = zw.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// compressReader returns a reader of r compressed with encoding.
+func compressReader(r io.Reader, encoding string) io.ReadCloser {
+	pr, pw := io.Pipe()
+	go func() {
+		zw, err := compressWriter(pw, encoding)
+		if err == nil {
+			if _, err = io.Copy(zw, r); err == nil {
+				err = zw.Close()
+			}
+		}
+		pw.CloseWithError(err)
+	}()
+	return pr
+}
+
+// minZstdMemory is the least memory allowed to zstd decoders.
+const minZstdMemory = 8 << 20
+
+// decompressReader returns a reader decompressing r, compressed with encoding,
+// which fails with ErrDecompressedTooLarge past maxSize bytes. Closing it closes r.
+func decompressReader(r io.ReadCloser, encoding string, maxSize int64) (io.ReadCloser, error) {
+	switch strings.ToLower(encoding) {
+	case "", "identity":
+		return r, nil
+	case EncodingZstd:
+		// The window of even small frames may exceed a small maxSize, which
+		// decompressedBody enforces anyway.
+		zr, err := zstd.NewReader(r, zstd.WithDecoderMaxMemory(uint64(max(maxSize, minZstdMemory))), zstd.WithDecoderConcurrency(1))
+		if err != nil {
+			return nil, err
+		}
+		return &decompressedBody{r: zr, max: maxSize, close: func() error { zr.Close(); return r.Close() }}, nil
+	case EncodingGzip:
+		zr, err := gzip.NewReader(r)
+		if err != nil {
+			return nil, err
+		}
+		return &decompressedBody{r: zr, max: maxSize, close: r.Close}, nil
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedEncoding, encoding)
+	}
+}
+
+// decompressedBody reads a decompressed stream of at most max bytes.
+type decompressedBody struct {
+	r        io.Reader
+	max      int64
+	read     int64
+	exceeded atomic.Bool
+	close    func() error
+}
+
+func (b *decompressedBody) Read(p []byte) (int, error) {
+	if b.read >= b.max {
+		// Only fail if the stream really goes on past the limit.
+		var probe [1]byte
+		if n, err := b.r.Read(probe[:]); n == 0 {
+			return 0, err
+		}
+		return 0, b.tooLarge(nil)
+	}
+	if int64(len(p)) > b.max-b.read {
+		p = p[:b.max-b.read]
+	}
+	n, err := b.r.Read(p)
+	b.read += int64(n)
+	if errors.Is(err, zstd.ErrDecoderSizeExceeded) || errors.Is(err, zstd.ErrWindowSizeExceeded) {
+		err = b.tooLarge(err)
+	}
+	return n, err
+}
+
+func (b *decompressedBody) tooLarge(err error) error {
+	b.exceeded.Store(true)
+	if err != nil {
+		return fmt.Errorf("%w: more than %d bytes: %w", ErrDecompressedTooLarge, b.max, err)
+	}
+	return fmt.Errorf("%w: more than %d bytes", ErrDecompressedTooLarge, b.max)
+}
+
+func (b *decompressedBody) Close() error {
+	return b.close()
+}
+
+// CompressionOption configures CompressionHandler.
+type CompressionOption func(*compressionOptions)
+
+type compressionOptions struct {
+	maxDecompressedSize int64
+}
+
+// WithMaxDecompressedSize bounds the size of decompressed requests,
+// DefaultMaxDecompressedSize by default.
+func WithMaxDecompressedSize(size int64) CompressionOption {
+	return func(o *compressionOptions) {
+		if size > 0 {
+			o.maxDecompressedSize = size
+		}
+	}
+}
+
+// CompressionHandler serves the wire frames of next compressed. Requests with
+// a Content-Encoding of EncodingZstd or EncodingGzip are decompressed, others
+// refused with 415 Unsupported Media Type. Responses advertise the supported
+// codings in Accept-Encoding, so clients know they may compress their requests,
+// and result frames of at least threshold bytes are compressed with the coding
+// preferred among those the client accepts. A threshold of 0 is
+// DefaultCompressionThreshold. Requests decompressing to more than the maximum
+// size are answered with 413 Request Entity Too Large.
+func CompressionHandler(next http.Handler, threshold int, opts ...CompressionOption) http.Handler {
+	if threshold <= 0 {
+		threshold = DefaultCompressionThreshold
+	}
+	options := compressionOptions{maxDecompressedSize: DefaultMaxDecompressedSize}
+	for _, opt := range opts {
+		opt(&options)
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Accept-Encoding", acceptEncoding)
+		if encoding := r.Header.Get("Content-Encoding"); encoding != "" {
+			body, err := decompressReader(r.Body, encoding, options.maxDecompressedSize)
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusUnsupportedMediaType)
+				return
+			}
+			if limited, ok := body.(*decompressedBody); ok {
+				lw := &limitResponseWriter{ResponseWriter: w, body: limited}
+				defer lw.finish()
+				w = lw
+			}
+			r.Body = body
+			r.Header.Del("Content-Encoding")
+			r.ContentLength = -1
+		}
+
+		encoding := negotiateEncoding(r.Header.Values("Accept-Encoding"))
+		if encoding == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+		cw := &compressResponseWriter{ResponseWriter: w, encoding: encoding, threshold: threshold}
+		defer cw.close()
+		next.ServeHTTP(cw, r)
+	})
+}
+
+// limitResponseWriter answers with 413 Request Entity Too Large instead of the
+// response of the handler once the request body exceeded its maximum size.
+type limitResponseWriter struct {
+	http.ResponseWriter
+	body     *decompressedBody
+	written  bool
+	rejected bool
+}
+
+func (w *limitResponseWriter) reject() bool {
+	if !w.rejected && !w.written && w.body.exceeded.Load() {
+		w.rejected = true
+		http.Error(w.ResponseWriter, ErrDecompressedTooLarge.Error(), http.StatusRequestEntityTooLarge)
+	}
+	return w.rejected
+}
+
+func (w *limitResponseWriter) WriteHeader(status int) {
+	if w.reject() {
+		return
+	}
+	w.written = true
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *limitResponseWriter) Write(p []byte) (int, error) {
+	if w.reject() {
+		return len(p), nil
+	}
+	w.written = true
+	return w.ResponseWriter.Write(p)
+}
+
+// finish rejects the request if the handler wrote no response.
+func (w *limitResponseWriter) finish() {
+	w.reject()
+}
+
+// compressResponseWriter buffers the response until it reaches the threshold,
+// compressing it from then on. Shorter responses are written as is.
+type compressResponseWriter struct {
+	http.ResponseWriter
+	encoding  string
+	threshold int
+	status    int
+	buf       []byte
+	zw        io.WriteCloser
+	bw        *bufio.Writer
+	done      bool
+}
+
+func (w *compressResponseWriter) WriteHeader(status int) {
+	if w.status == 0 {
+		w.status = status
+	}
+}
+
+func (w *compressResponseWriter) Write(p []byte) (int, error) {
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+	if w.zw != nil {
+		return w.zw.Write(p)
+	}
+	if w.done || w.status != http.StatusOK {
+		w.flushPlain()
+		return w.ResponseWriter.Write(p)
+	}
+	w.buf = append(w.buf, p...)
+	if len(w.buf) < w.threshold {
+		return len(p), nil
+	}
+
+	header := w.ResponseWriter.Header()
+	header.Set("Content-Encoding", w.encoding)
+	header.Add("Vary", "Accept-Encoding")
+	header.Del("Content-Length")
+	w.ResponseWriter.WriteHeader(w.status)
+	w.bw = bufio.NewWriter(w.ResponseWriter)
+	zw, err := compressWriter(w.bw, w.encoding)
+	if err != nil {
+		return 0, err
+	}
+	w.zw = zw
+	buf := w.buf
+	w.buf = nil
+	if _, err = zw.Write(buf); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// flushPlain writes the status and buffered bytes of a response left
+// uncompressed.
+func (w *compressResponseWriter) flushPlain() {
+	if w.done {
+		return
+	}
+	w.done = true
+	w.ResponseWriter.WriteHeader(w.status)
+	if len(w.buf) > 0 {
+		_, _ = w.ResponseWriter.Write(w.buf)
+		w.buf = nil
+	}
+}
+
+func (w *compressResponseWriter) close() {
+	if w.zw != nil {
+		_ = w.zw.Close()
+		_ = w.bw.Flush()
+		return
+	}
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+	w.flushPlain()
+}
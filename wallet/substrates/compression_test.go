@@ -0,0 +1,135 @@
+package substrates
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/bsv-blockchain/go-sdk/wallet"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNegotiateEncoding(t *testing.T) {
+	require.Equal(t, EncodingZstd, negotiateEncoding([]string{"gzip, zstd"}))
+	require.Equal(t, EncodingGzip, negotiateEncoding([]string{"zstd;q=0", "GZIP;q=0.5"}))
+	require.Empty(t, negotiateEncoding([]string{"br, deflate"}))
+	require.Empty(t, negotiateEncoding(nil))
+}
+
+func TestCompressRoundTrip(t *testing.T) {
+	data := bytes.Repeat([]byte("wire frame "), 1000)
+	for _, encoding := range compressionEncodings {
+		compressed, err := compress(data, encoding)
+		require.NoError(t, err)
+		require.Less(t, len(compressed), len(data))
+
+		r, err := decompressReader(io.NopCloser(bytes.NewReader(compressed)), encoding, int64(len(data)))
+		require.NoError(t, err)
+		decompressed, err := io.ReadAll(r)
+		require.NoError(t, err)
+		require.Equal(t, data, decompressed)
+		require.NoError(t, r.Close())
+
+		r, err = decompressReader(io.NopCloser(bytes.NewReader(compressed)), encoding, int64(len(data)-1))
+		require.NoError(t, err)
+		_, err = io.ReadAll(r)
+		require.ErrorIs(t, err, ErrDecompressedTooLarge, encoding)
+	}
+	_, err := compress(data, "br")
+	require.ErrorIs(t, err, ErrUnsupportedEncoding)
+}
+
+func TestHTTPWalletWireCompression(t *testing.T) {
+	backend := wallet.NewTestWalletForRandomKey(t)
+	handler := CompressionHandler(newHTTPWireHandler(t, NewWalletWireProcessor(backend)), 1024)
+
+	var mu sync.Mutex
+	var requestEncodings, responseEncodings []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestEncoding := r.Header.Get("Content-Encoding")
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, r)
+		mu.Lock()
+		requestEncodings = append(requestEncodings, requestEncoding)
+		responseEncodings = append(responseEncodings, rec.Header().Get("Content-Encoding"))
+		mu.Unlock()
+		for key, values := range rec.Header() {
+			w.Header()[key] = values
+		}
+		w.WriteHeader(rec.Code)
+		_, _ = w.Write(rec.Body.Bytes())
+	}))
+	t.Cleanup(server.Close)
+
+	encryption := wallet.EncryptionArgs{
+		ProtocolID:   wallet.Protocol{SecurityLevel: wallet.SecurityLevelEveryApp, Protocol: "compressed frames"},
+		KeyID:        "1",
+		Counterparty: wallet.Counterparty{Type: wallet.CounterpartyTypeSelf},
+	}
+	plaintext := bytes.Repeat([]byte("a compressible plaintext "), 10000)
+	ctx := t.Context()
+	transceiver := &WalletWireTransceiver{Wire: NewHTTPWalletWire("", server.URL, server.Client(), WithCompression(1024))}
+
+	// The first request goes out plain, as the wallet has not advertised the
+	// codings it accepts yet, and the large result comes back compressed.
+	encrypted, err := transceiver.Encrypt(ctx, wallet.EncryptArgs{EncryptionArgs: encryption, Plaintext: plaintext}, "example.com")
+	require.NoError(t, err)
+	// The ciphertext does not compress, but its request is above the threshold.
+	decrypted, err := transceiver.Decrypt(ctx, wallet.DecryptArgs{EncryptionArgs: encryption, Ciphertext: encrypted.Ciphertext}, "example.com")
+	require.NoError(t, err)
+	require.True(t, bytes.Equal(plaintext, decrypted.Plaintext))
+
+	// Small frames are sent as is.
+	backend.OnGetHeight().ReturnSuccess(&wallet.GetHeightResult{Height: 850000})
+	height, err := transceiver.GetHeight(ctx, nil, "example.com")
+	require.NoError(t, err)
+	require.Equal(t, uint32(850000), height.Height)
+
+	require.Equal(t, []string{"", EncodingZstd, ""}, requestEncodings)
+	require.Equal(t, []string{EncodingZstd, EncodingZstd, ""}, responseEncodings)
+
+	// Clients without compression are served as before.
+	plain := &WalletWireTransceiver{Wire: NewHTTPWalletWire("", server.URL, server.Client())}
+	encrypted, err = plain.Encrypt(ctx, wallet.EncryptArgs{EncryptionArgs: encryption, Plaintext: plaintext}, "example.com")
+	require.NoError(t, err)
+	require.NotEmpty(t, encrypted.Ciphertext)
+}
+
+func TestCompressionHandlerUnsupportedEncoding(t *testing.T) {
+	handler := CompressionHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Fatal("request with unsupported encoding reached the handler")
+	}), 0)
+
+	req := httptest.NewRequest(http.MethodPost, "/getHeight", strings.NewReader("payload"))
+	req.Header.Set("Content-Encoding", "br")
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusUnsupportedMediaType, rec.Code)
+	require.Equal(t, "zstd, gzip", rec.Header().Get("Accept-Encoding"))
+}
+
+func TestCompressionHandlerDecompressedTooLarge(t *testing.T) {
+	handler := CompressionHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, err := io.ReadAll(r.Body); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		_, _ = w.Write([]byte("ok"))
+	}), 0, WithMaxDecompressedSize(1024))
+
+	for _, encoding := range compressionEncodings {
+		for size, status := range map[int]int{1024: http.StatusOK, 1 << 20: http.StatusRequestEntityTooLarge} {
+			compressed, err := compress(make([]byte, size), encoding)
+			require.NoError(t, err)
+			req := httptest.NewRequest(http.MethodPost, "/getHeight", bytes.NewReader(compressed))
+			req.Header.Set("Content-Encoding", encoding)
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+			require.Equal(t, status, rec.Code, "%s %d", encoding, size)
+		}
+	}
+}
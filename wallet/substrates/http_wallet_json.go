@@ -6,8 +6,11 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"log/slog"
 	"net/http"
+	"time"
 
+	"github.com/bsv-blockchain/go-sdk/internal/logging"
 	"github.com/bsv-blockchain/go-sdk/wallet"
 )
 
@@ -16,10 +19,11 @@ type HTTPWalletJSON struct {
 	baseURL    string
 	httpClient *http.Client
 	originator string
+	logger     *slog.Logger
 }
 
 // NewHTTPWalletJSON creates a new HTTPWalletJSON instance
-func NewHTTPWalletJSON(originator string, baseURL string, httpClient *http.Client) *HTTPWalletJSON {
+func NewHTTPWalletJSON(originator string, baseURL string, httpClient *http.Client, opts ...HTTPWalletOption) *HTTPWalletJSON {
 	if httpClient == nil {
 		httpClient = http.DefaultClient
 	}
@@ -30,6 +34,7 @@ func NewHTTPWalletJSON(originator string, baseURL string, httpClient *http.Clien
 		baseURL:    baseURL,
 		httpClient: httpClient,
 		originator: originator,
+		logger:     logging.OrDefault(applyHTTPWalletOptions(opts).logger),
 	}
 }
 
@@ -53,14 +58,20 @@ func (h *HTTPWalletJSON) api(ctx context.Context, call string, args any) ([]byte
 	}
 
 	// Send request
+	start := time.Now()
 	resp, err := h.httpClient.Do(req)
 	if err != nil {
+		h.logger.DebugContext(ctx, "wallet request failed", "call", call, "error", err)
 		return nil, fmt.Errorf("failed to make HTTP request: %w", err)
 	}
 	defer resp.Body.Close()
+	h.logger.DebugContext(ctx, "wallet request", "call", call, "status", resp.StatusCode, "duration", time.Since(start))
 
 	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
+		body, err := io.ReadAll(resp.Body)
+		if err != nil {
+			h.logger.DebugContext(ctx, "failed to read wallet error response", "call", call, "error", err)
+		}
 		return nil, fmt.Errorf("HTTP request failed with status %d: %s", resp.StatusCode, string(body))
 	}
 
@@ -145,6 +156,28 @@ func (h *HTTPWalletJSON) RelinquishOutput(ctx context.Context, args *wallet.Reli
 	return &result, err
 }
 
+// RelabelActions changes the labels of the actions matching a filter
+func (h *HTTPWalletJSON) RelabelActions(ctx context.Context, args wallet.RelabelActionsArgs) (*wallet.RelabelActionsResult, error) {
+	data, err := h.api(ctx, "relabelActions", args)
+	if err != nil {
+		return nil, err
+	}
+	var result wallet.RelabelActionsResult
+	err = json.Unmarshal(data, &result)
+	return &result, err
+}
+
+// RetagOutputs changes the tags of the outputs matching a filter
+func (h *HTTPWalletJSON) RetagOutputs(ctx context.Context, args wallet.RetagOutputsArgs) (*wallet.RetagOutputsResult, error) {
+	data, err := h.api(ctx, "retagOutputs", args)
+	if err != nil {
+		return nil, err
+	}
+	var result wallet.RetagOutputsResult
+	err = json.Unmarshal(data, &result)
+	return &result, err
+}
+
 // GetPublicKey retrieves a derived or identity public key
 func (h *HTTPWalletJSON) GetPublicKey(ctx context.Context, args wallet.GetPublicKeyArgs) (*wallet.GetPublicKeyResult, error) {
 	data, err := h.api(ctx, "getPublicKey", args)
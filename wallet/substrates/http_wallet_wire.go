@@ -2,36 +2,61 @@ package substrates
 
 import (
 	"bytes"
+	"context"
 	"encoding/binary"
+	"encoding/hex"
 	"fmt"
 	"io"
+	"log/slog"
 	"net/http"
+	"sync/atomic"
+	"time"
+
+	"github.com/bsv-blockchain/go-sdk/internal/logging"
+	"github.com/bsv-blockchain/go-sdk/wallet/serializer"
 )
 
+// ExtensionsHeader is the HTTP header carrying the version and extension area of
+// versioned frames (see serializer.FrameVersioned) in hex.
+const ExtensionsHeader = "X-Wallet-Extensions"
+
 // HTTPWalletWire implements WalletWire interface for HTTP transport
 type HTTPWalletWire struct {
 	baseURL    string
 	httpClient *http.Client
 	originator string
+	logger     *slog.Logger
+
+	// compressionThreshold is the size of the smallest params compressed, 0
+	// when compression is disabled.
+	compressionThreshold int
+	// requestEncoding is the coding of compressed requests, negotiated from
+	// the Accept-Encoding of the wallet's responses.
+	requestEncoding atomic.Value
 }
 
 // NewHTTPWalletWire creates a new HTTPWalletWire instance
-func NewHTTPWalletWire(originator string, baseURL string, httpClient *http.Client) *HTTPWalletWire {
+func NewHTTPWalletWire(originator string, baseURL string, httpClient *http.Client, opts ...HTTPWalletOption) *HTTPWalletWire {
 	if httpClient == nil {
 		httpClient = http.DefaultClient
 	}
 	if baseURL == "" {
 		baseURL = "http://localhost:3301" // Default port matches TS version
 	}
+	options := applyHTTPWalletOptions(opts)
 	return &HTTPWalletWire{
-		baseURL:    baseURL,
-		httpClient: httpClient,
-		originator: originator,
+		baseURL:              baseURL,
+		httpClient:           httpClient,
+		originator:           originator,
+		logger:               logging.OrDefault(options.logger),
+		compressionThreshold: options.compressionThreshold,
 	}
 }
 
+var _ WalletWire = (*HTTPWalletWire)(nil)
+
 // TransmitToWallet sends a binary message to the wallet and returns the response
-func (h *HTTPWalletWire) TransmitToWallet(message []byte) ([]byte, error) {
+func (h *HTTPWalletWire) TransmitToWallet(ctx context.Context, message []byte) ([]byte, error) {
 	// Create reader for the message
 	reader := bytes.NewReader(message)
 
@@ -42,7 +67,7 @@ func (h *HTTPWalletWire) TransmitToWallet(message []byte) ([]byte, error) {
 	}
 
 	// Map call code to endpoint name
-	callName, ok := callCodeToName[Call(callCode)]
+	callName, ok := callCodeToName[Call(callCode&^serializer.FrameVersioned)]
 	if !ok {
 		return nil, fmt.Errorf("invalid call code")
 	}
@@ -65,35 +90,136 @@ func (h *HTTPWalletWire) TransmitToWallet(message []byte) ([]byte, error) {
 		originator = string(originatorBytes)
 	}
 
+	// Read the extensions of a versioned frame
+	requestFrame := &serializer.RequestFrame{Originator: originator}
+	if callCode&serializer.FrameVersioned != 0 {
+		var err error
+		if requestFrame.Version, requestFrame.Extensions, err = serializer.ReadFrameExtensions(reader); err != nil {
+			return nil, err
+		}
+	}
+
 	// Remaining bytes are the payload
 	payload, err := io.ReadAll(reader)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read payload: %w", err)
 	}
 
-	// Create HTTP request
-	req, err := http.NewRequest("POST", h.baseURL+"/"+callName, bytes.NewReader(payload))
+	resp, err := h.send(ctx, callName, requestFrame, bytes.NewReader(payload), len(payload))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	// Read and return response
+	return io.ReadAll(resp.Body)
+}
+
+var _ StreamingWalletWire = (*HTTPWalletWire)(nil)
+
+// StreamToWallet streams the params of the request frame read from message to
+// the wallet as the request body, and returns the response body as the stream
+// of the result frame. The result of a chunked request is chunked on the way,
+// as the wallet replies with a plain result frame.
+func (h *HTTPWalletWire) StreamToWallet(ctx context.Context, message io.Reader) (io.ReadCloser, error) {
+	requestFrame, params, err := serializer.NewRequestFrameReader(message)
+	if err != nil {
+		return nil, err
+	}
+	callName, ok := callCodeToName[Call(requestFrame.Call)]
+	if !ok {
+		return nil, fmt.Errorf("invalid call code")
+	}
+	resp, err := h.send(ctx, callName, requestFrame, params, -1)
+	if err != nil {
+		return nil, err
+	}
+	if !requestFrame.Chunked {
+		return resp.Body, nil
+	}
+
+	pr, pw := io.Pipe()
+	go func() {
+		defer resp.Body.Close()
+		pw.CloseWithError(chunkResultFrame(pw, resp.Body))
+	}()
+	return pr, nil
+}
+
+// chunkResultFrame copies the plain result frame read from r to w as a chunked
+// result frame.
+func chunkResultFrame(w io.Writer, r io.Reader) error {
+	status := make([]byte, 1)
+	if _, err := io.ReadFull(r, status); err != nil {
+		return fmt.Errorf("failed to read result frame: %w", err)
+	}
+	if _, err := w.Write(status); err != nil {
+		return err
+	}
+	if status[0] != 0 {
+		_, err := io.Copy(w, r)
+		return err
+	}
+	return serializer.WriteChunksFrom(w, r, serializer.DefaultFrameChunkSize)
+}
+
+// send posts body, the params of requestFrame of size bytes or -1 when unknown,
+// to the endpoint of the call, returning the response when its status is OK.
+// The version and extensions of a versioned frame are sent in the
+// ExtensionsHeader, which wallets not knowing it ignore. With compression
+// enabled, params of unknown size or above the threshold are compressed once
+// the wallet advertised a coding it accepts, and compressed responses are
+// decompressed.
+func (h *HTTPWalletWire) send(ctx context.Context, callName string, requestFrame *serializer.RequestFrame, body io.Reader, size int) (*http.Response, error) {
+	var encoding string
+	if h.compressionThreshold > 0 && (size < 0 || size >= h.compressionThreshold) {
+		encoding, _ = h.requestEncoding.Load().(string)
+	}
+	if encoding != "" {
+		body = compressReader(body, encoding)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", h.baseURL+"/"+callName, body)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
 	req.Header.Set("Content-Type", "application/octet-stream")
-	if originator != "" {
-		req.Header.Set("Origin", originator)
+	if h.compressionThreshold > 0 {
+		req.Header.Set("Accept-Encoding", acceptEncoding)
+	}
+	if encoding != "" {
+		req.Header.Set("Content-Encoding", encoding)
+	}
+	if requestFrame.Originator != "" {
+		req.Header.Set("Origin", requestFrame.Originator)
+	}
+	if requestFrame.Version != 0 || len(requestFrame.Extensions) > 0 {
+		req.Header.Set(ExtensionsHeader, hex.EncodeToString(serializer.WriteFrameExtensions(requestFrame.Version, requestFrame.Extensions)))
 	}
 
 	// Send request
+	start := time.Now()
 	resp, err := h.httpClient.Do(req)
 	if err != nil {
+		h.logger.DebugContext(ctx, "wallet request failed", "call", callName, "error", err)
 		return nil, err
 	}
-	defer resp.Body.Close()
+	h.logger.DebugContext(ctx, "wallet request", "call", callName, "status", resp.StatusCode, "duration", time.Since(start))
 
+	if h.compressionThreshold > 0 {
+		h.requestEncoding.Store(negotiateEncoding(resp.Header.Values("Accept-Encoding")))
+	}
 	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
 		return nil, fmt.Errorf("HTTP request failed with status: %s", resp.Status)
 	}
-
-	// Read and return response
-	return io.ReadAll(resp.Body)
+	decompressed, err := decompressReader(resp.Body, resp.Header.Get("Content-Encoding"), DefaultMaxDecompressedSize)
+	if err != nil {
+		resp.Body.Close()
+		return nil, err
+	}
+	resp.Body = decompressed
+	return resp, nil
 }
 
 // callCodeToName maps Call codes to endpoint names
@@ -126,4 +252,6 @@ var callCodeToName = map[Call]string{
 	CallGetHeaderForHeight:           "getHeaderForHeight",
 	CallGetNetwork:                   "getNetwork",
 	CallGetVersion:                   "getVersion",
+	CallRelabelActions:               "relabelActions",
+	CallRetagOutputs:                 "retagOutputs",
 }
@@ -1,11 +1,15 @@
 package substrates
 
 import (
-	"github.com/stretchr/testify/require"
+	"bytes"
+	"encoding/hex"
 	"io"
 	"net/http"
 	"net/http/httptest"
 	"testing"
+
+	"github.com/bsv-blockchain/go-sdk/wallet/serializer"
+	"github.com/stretchr/testify/require"
 )
 
 const TestOriginator = "test.com"
@@ -79,7 +83,7 @@ func TestTransmitToWallet(t *testing.T) {
 	}
 
 	wire := NewHTTPWalletWire(TestOriginator, ts.URL, nil)
-	response, err := wire.TransmitToWallet(message)
+	response, err := wire.TransmitToWallet(t.Context(), message)
 	require.NoError(t, err, "TransmitToWallet failed")
 	require.Equal(t, []byte("response"), response, "unexpected response")
 }
@@ -110,7 +114,7 @@ func TestTransmitToWallet_Errors(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			wire := NewHTTPWalletWire(TestOriginator, "http://localhost", &http.Client{})
-			_, err := wire.TransmitToWallet(tt.message)
+			_, err := wire.TransmitToWallet(t.Context(), tt.message)
 			require.Error(t, err, "expected error")
 			require.ErrorContains(t, err, tt.wantErr, "error message mismatch")
 		})
@@ -133,7 +137,38 @@ func TestTransmitToWallet_HTTPErrors(t *testing.T) {
 	}
 
 	wire := NewHTTPWalletWire("", ts.URL, nil)
-	_, err := wire.TransmitToWallet(message)
+	_, err := wire.TransmitToWallet(t.Context(), message)
 	require.Error(t, err, "expected HTTP error")
 	require.EqualError(t, err, "HTTP request failed with status: 500 Internal Server Error", "error message mismatch")
 }
+
+func TestTransmitToWallet_Extensions(t *testing.T) {
+	var header string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		header = r.Header.Get(ExtensionsHeader)
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		require.Equal(t, []byte{0x01}, body)
+		_, err = w.Write([]byte{0})
+		require.NoError(t, err)
+	}))
+	defer server.Close()
+
+	extensions := []serializer.Extension{{Type: 1, Value: []byte{0x02}}}
+	message := serializer.WriteRequestFrame(serializer.RequestFrame{
+		Call:       byte(CallCreateAction),
+		Originator: TestOriginator,
+		Params:     []byte{0x01},
+		Extensions: extensions,
+	})
+	wire := NewHTTPWalletWire(TestOriginator, server.URL, server.Client())
+	_, err := wire.TransmitToWallet(t.Context(), message)
+	require.NoError(t, err)
+
+	data, err := hex.DecodeString(header)
+	require.NoError(t, err)
+	version, read, err := serializer.ReadFrameExtensions(bytes.NewReader(data))
+	require.NoError(t, err)
+	require.Equal(t, serializer.FrameVersion, version)
+	require.Equal(t, extensions, read)
+}
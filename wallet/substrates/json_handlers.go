@@ -0,0 +1,93 @@
+package substrates
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/bsv-blockchain/go-sdk/wallet"
+)
+
+// errInvalidArguments is returned by a jsonHandler for arguments which cannot be
+// unmarshalled.
+var errInvalidArguments = errors.New("invalid arguments")
+
+// jsonHandler calls a wallet method with JSON arguments, for the substrates
+// carrying calls as JSON.
+type jsonHandler func(ctx context.Context, args json.RawMessage, originator string) (any, error)
+
+func handleJSON[A, R any](fn func(context.Context, A, string) (R, error)) jsonHandler {
+	return func(ctx context.Context, raw json.RawMessage, originator string) (any, error) {
+		var args A
+		if len(raw) > 0 {
+			if err := json.Unmarshal(raw, &args); err != nil {
+				return nil, fmt.Errorf("%w: %w", errInvalidArguments, err)
+			}
+		}
+		return fn(ctx, args, originator)
+	}
+}
+
+// unsupportedAdmin fails the admin calls of wallets which do not implement
+// wallet.AdminInterface.
+type unsupportedAdmin struct{}
+
+func (unsupportedAdmin) RelabelActions(context.Context, wallet.RelabelActionsArgs, string) (*wallet.RelabelActionsResult, error) {
+	return nil, wallet.ErrAdminUnsupported
+}
+
+func (unsupportedAdmin) RetagOutputs(context.Context, wallet.RetagOutputsArgs, string) (*wallet.RetagOutputsResult, error) {
+	return nil, wallet.ErrAdminUnsupported
+}
+
+// adminOf returns the admin calls of w, failing with wallet.ErrAdminUnsupported
+// if it does not implement them.
+func adminOf(w wallet.Interface) wallet.AdminInterface {
+	if admin, ok := w.(wallet.AdminInterface); ok {
+		return admin
+	}
+	return unsupportedAdmin{}
+}
+
+// jsonHandlers returns the handlers of the methods of w by call name.
+func jsonHandlers(w wallet.Interface) map[string]jsonHandler {
+	admin := adminOf(w)
+	handlers := map[Call]jsonHandler{
+		CallCreateAction:                 handleJSON(w.CreateAction),
+		CallSignAction:                   handleJSON(w.SignAction),
+		CallAbortAction:                  handleJSON(w.AbortAction),
+		CallListActions:                  handleJSON(w.ListActions),
+		CallInternalizeAction:            handleJSON(w.InternalizeAction),
+		CallListOutputs:                  handleJSON(w.ListOutputs),
+		CallRelinquishOutput:             handleJSON(w.RelinquishOutput),
+		CallGetPublicKey:                 handleJSON(w.GetPublicKey),
+		CallRevealCounterpartyKeyLinkage: handleJSON(w.RevealCounterpartyKeyLinkage),
+		CallRevealSpecificKeyLinkage:     handleJSON(w.RevealSpecificKeyLinkage),
+		CallEncrypt:                      handleJSON(w.Encrypt),
+		CallDecrypt:                      handleJSON(w.Decrypt),
+		CallCreateHMAC:                   handleJSON(w.CreateHMAC),
+		CallVerifyHMAC:                   handleJSON(w.VerifyHMAC),
+		CallCreateSignature:              handleJSON(w.CreateSignature),
+		CallVerifySignature:              handleJSON(w.VerifySignature),
+		CallAcquireCertificate:           handleJSON(w.AcquireCertificate),
+		CallListCertificates:             handleJSON(w.ListCertificates),
+		CallProveCertificate:             handleJSON(w.ProveCertificate),
+		CallRelinquishCertificate:        handleJSON(w.RelinquishCertificate),
+		CallDiscoverByIdentityKey:        handleJSON(w.DiscoverByIdentityKey),
+		CallDiscoverByAttributes:         handleJSON(w.DiscoverByAttributes),
+		CallIsAuthenticated:              handleJSON(w.IsAuthenticated),
+		CallWaitForAuthentication:        handleJSON(w.WaitForAuthentication),
+		CallGetHeight:                    handleJSON(w.GetHeight),
+		CallGetHeaderForHeight:           handleJSON(w.GetHeaderForHeight),
+		CallGetNetwork:                   handleJSON(w.GetNetwork),
+		CallGetVersion:                   handleJSON(w.GetVersion),
+		CallRelabelActions:               handleJSON(admin.RelabelActions),
+		CallRetagOutputs:                 handleJSON(admin.RetagOutputs),
+	}
+	byName := make(map[string]jsonHandler, len(handlers))
+	for call, handler := range handlers {
+		byName[callCodeToName[call]] = handler
+	}
+	return byName
+}
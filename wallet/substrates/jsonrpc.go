@@ -0,0 +1,512 @@
+package substrates
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"math"
+	"net/http"
+	"reflect"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	"github.com/bsv-blockchain/go-sdk/internal/logging"
+	"github.com/bsv-blockchain/go-sdk/wallet"
+)
+
+// JSONRPCVersion is the version of the JSON-RPC protocol spoken by the JSON-RPC
+// substrate.
+const JSONRPCVersion = "2.0"
+
+// Error codes of the JSON-RPC 2.0 specification.
+const (
+	JSONRPCParseError     = -32700
+	JSONRPCInvalidRequest = -32600
+	JSONRPCMethodNotFound = -32601
+	JSONRPCInvalidParams  = -32602
+	JSONRPCInternalError  = -32603
+)
+
+// jsonRPCErrorCode is the code of errors replied for failures of wallet calls
+// which are not *wallet.Error.
+const jsonRPCErrorCode = 1
+
+// JSONRPCRequest is a JSON-RPC 2.0 request. The method is the name of a wallet
+// call, such as "createAction", and the params are its arguments as the JSON
+// object sent by the HTTP JSON substrate. A request without ID is a
+// notification, which is not answered.
+type JSONRPCRequest struct {
+	JSONRPC string          `json:"jsonrpc"`
+	Method  string          `json:"method"`
+	Params  json.RawMessage `json:"params,omitempty"`
+	ID      json.RawMessage `json:"id,omitempty"`
+}
+
+// JSONRPCResponse is a JSON-RPC 2.0 response, carrying either the result of the
+// call or its error.
+type JSONRPCResponse struct {
+	JSONRPC string          `json:"jsonrpc"`
+	Result  json.RawMessage `json:"result,omitempty"`
+	Error   *JSONRPCError   `json:"error,omitempty"`
+	ID      json.RawMessage `json:"id"`
+}
+
+// JSONRPCError is the error of a JSON-RPC 2.0 response. Failed wallet calls
+// reply the code of their *wallet.Error, with its stack in the data, and code 1
+// for other errors; the negative codes are the protocol errors of the
+// specification.
+type JSONRPCError struct {
+	Code    int             `json:"code"`
+	Message string          `json:"message"`
+	Data    json.RawMessage `json:"data,omitempty"`
+}
+
+// Error implements the error interface.
+func (e *JSONRPCError) Error() string {
+	return fmt.Sprintf("JSON-RPC error %d: %s", e.Code, e.Message)
+}
+
+type jsonRPCErrorData struct {
+	Stack string `json:"stack,omitempty"`
+}
+
+// walletError returns the *wallet.Error replied by a failed wallet call, or e
+// itself for protocol errors.
+func (e *JSONRPCError) walletError() error {
+	if e.Code <= 0 || e.Code > math.MaxUint8 {
+		return e
+	}
+	walletErr := &wallet.Error{Code: byte(e.Code), Message: e.Message}
+	var data jsonRPCErrorData
+	if json.Unmarshal(e.Data, &data) == nil {
+		walletErr.Stack = data.Stack
+	}
+	return walletErr
+}
+
+var _ wallet.Interface = (*JSONRPCWallet)(nil)
+var _ wallet.AdminInterface = (*JSONRPCWallet)(nil)
+
+// JSONRPCWallet implements wallet.Interface over JSON-RPC 2.0, posting every call
+// to a single endpoint with the method named after the call. Arguments, results
+// and the Originator header are those of the HTTP JSON substrate, and a
+// *wallet.Error returned by the remote wallet is returned as well.
+type JSONRPCWallet struct {
+	url        string
+	httpClient *http.Client
+	originator string
+	logger     *slog.Logger
+	nextID     atomic.Uint64
+}
+
+// NewJSONRPCWallet creates a JSONRPCWallet calling the endpoint at url. The
+// originator is sent with the calls made without one.
+func NewJSONRPCWallet(originator string, url string, httpClient *http.Client, opts ...HTTPWalletOption) *JSONRPCWallet {
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	if url == "" {
+		url = "http://localhost:3321" // Default port matches the HTTP JSON substrate
+	}
+	return &JSONRPCWallet{
+		url:        url,
+		httpClient: httpClient,
+		originator: originator,
+		logger:     logging.OrDefault(applyHTTPWalletOptions(opts).logger),
+	}
+}
+
+// JSONRPCBatchCall is a wallet call made in a batch. Result points to the result
+// type of the call, such as *wallet.GetHeightResult, and receives the result;
+// Err is set instead when the call fails.
+type JSONRPCBatchCall struct {
+	Call   Call
+	Args   any
+	Result any
+	Err    error
+}
+
+// Batch makes calls in a single JSON-RPC batch request, setting the result or
+// error of each. The returned error reports the failure of the request as a
+// whole.
+func (w *JSONRPCWallet) Batch(ctx context.Context, calls []*JSONRPCBatchCall, originator string) error {
+	if len(calls) == 0 {
+		return nil
+	}
+	requests := make([]*JSONRPCRequest, len(calls))
+	byID := make(map[string]*JSONRPCBatchCall, len(calls))
+	for i, call := range calls {
+		req, err := w.request(call.Call, call.Args)
+		if err != nil {
+			return err
+		}
+		requests[i] = req
+		byID[string(req.ID)] = call
+	}
+	data, err := w.post(ctx, "batch", originator, requests)
+	if err != nil {
+		return err
+	}
+
+	var responses []*JSONRPCResponse
+	if err := json.Unmarshal(data, &responses); err != nil {
+		// The whole batch is rejected with a single response.
+		var res JSONRPCResponse
+		if json.Unmarshal(data, &res) == nil && res.Error != nil {
+			return res.Error
+		}
+		return fmt.Errorf("failed to unmarshal batch response: %w", err)
+	}
+	for _, res := range responses {
+		call, ok := byID[string(res.ID)]
+		if !ok {
+			continue
+		}
+		delete(byID, string(res.ID))
+		if res.Error != nil {
+			call.Err = res.Error.walletError()
+		} else if err := json.Unmarshal(res.Result, call.Result); err != nil {
+			call.Err = fmt.Errorf("failed to unmarshal %s result: %w", callCodeToName[call.Call], err)
+		}
+	}
+	for _, call := range byID {
+		call.Err = fmt.Errorf("no response to %s call", callCodeToName[call.Call])
+	}
+	return nil
+}
+
+// request builds the request of call with a new ID.
+func (w *JSONRPCWallet) request(call Call, args any) (*JSONRPCRequest, error) {
+	method, ok := callCodeToName[call]
+	if !ok {
+		return nil, fmt.Errorf("invalid call code %d", call)
+	}
+	req := &JSONRPCRequest{
+		JSONRPC: JSONRPCVersion,
+		Method:  method,
+		ID:      json.RawMessage(strconv.FormatUint(w.nextID.Add(1), 10)),
+	}
+	if args != nil {
+		params, err := json.Marshal(addressable(args))
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal %s arguments: %w", method, err)
+		}
+		req.Params = params
+	}
+	return req, nil
+}
+
+// addressable returns a pointer to a copy of v when v is not a pointer, so that
+// the MarshalJSON methods with pointer receivers of the argument types apply.
+func addressable(v any) any {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() == reflect.Pointer {
+		return v
+	}
+	ptr := reflect.New(rv.Type())
+	ptr.Elem().Set(rv)
+	return ptr.Interface()
+}
+
+// post sends body to the endpoint and returns the response body.
+func (w *JSONRPCWallet) post(ctx context.Context, method string, originator string, body any) ([]byte, error) {
+	reqBody, err := json.Marshal(body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.url, bytes.NewReader(reqBody))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Content-Type", "application/json")
+	if originator == "" {
+		originator = w.originator
+	}
+	if originator != "" {
+		req.Header.Set("Originator", originator)
+	}
+
+	start := time.Now()
+	resp, err := w.httpClient.Do(req)
+	if err != nil {
+		w.logger.DebugContext(ctx, "wallet request failed", "method", method, "error", err)
+		return nil, fmt.Errorf("failed to make HTTP request: %w", err)
+	}
+	defer resp.Body.Close()
+	w.logger.DebugContext(ctx, "wallet request", "method", method, "status", resp.StatusCode, "duration", time.Since(start))
+
+	if resp.StatusCode != http.StatusOK {
+		body, err := io.ReadAll(resp.Body)
+		if err != nil {
+			w.logger.DebugContext(ctx, "failed to read wallet error response", "method", method, "error", err)
+		}
+		return nil, fmt.Errorf("HTTP request failed with status %d: %s", resp.StatusCode, string(body))
+	}
+	return io.ReadAll(resp.Body)
+}
+
+func jsonRPCCall[R, A any](ctx context.Context, w *JSONRPCWallet, call Call, args A, originator string) (*R, error) {
+	var params any = &args
+	if any(args) == nil {
+		params = nil
+	}
+	req, err := w.request(call, params)
+	if err != nil {
+		return nil, err
+	}
+	data, err := w.post(ctx, req.Method, originator, req)
+	if err != nil {
+		return nil, err
+	}
+	var res JSONRPCResponse
+	if err := json.Unmarshal(data, &res); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+	if res.Error != nil {
+		return nil, res.Error.walletError()
+	}
+	var result R
+	if err := json.Unmarshal(res.Result, &result); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal %s result: %w", req.Method, err)
+	}
+	return &result, nil
+}
+
+func (w *JSONRPCWallet) CreateAction(ctx context.Context, args wallet.CreateActionArgs, originator string) (*wallet.CreateActionResult, error) {
+	return jsonRPCCall[wallet.CreateActionResult](ctx, w, CallCreateAction, args, originator)
+}
+
+func (w *JSONRPCWallet) SignAction(ctx context.Context, args wallet.SignActionArgs, originator string) (*wallet.SignActionResult, error) {
+	return jsonRPCCall[wallet.SignActionResult](ctx, w, CallSignAction, args, originator)
+}
+
+func (w *JSONRPCWallet) AbortAction(ctx context.Context, args wallet.AbortActionArgs, originator string) (*wallet.AbortActionResult, error) {
+	return jsonRPCCall[wallet.AbortActionResult](ctx, w, CallAbortAction, args, originator)
+}
+
+func (w *JSONRPCWallet) ListActions(ctx context.Context, args wallet.ListActionsArgs, originator string) (*wallet.ListActionsResult, error) {
+	return jsonRPCCall[wallet.ListActionsResult](ctx, w, CallListActions, args, originator)
+}
+
+func (w *JSONRPCWallet) InternalizeAction(ctx context.Context, args wallet.InternalizeActionArgs, originator string) (*wallet.InternalizeActionResult, error) {
+	return jsonRPCCall[wallet.InternalizeActionResult](ctx, w, CallInternalizeAction, args, originator)
+}
+
+func (w *JSONRPCWallet) ListOutputs(ctx context.Context, args wallet.ListOutputsArgs, originator string) (*wallet.ListOutputsResult, error) {
+	return jsonRPCCall[wallet.ListOutputsResult](ctx, w, CallListOutputs, args, originator)
+}
+
+func (w *JSONRPCWallet) RelinquishOutput(ctx context.Context, args wallet.RelinquishOutputArgs, originator string) (*wallet.RelinquishOutputResult, error) {
+	return jsonRPCCall[wallet.RelinquishOutputResult](ctx, w, CallRelinquishOutput, args, originator)
+}
+
+func (w *JSONRPCWallet) GetPublicKey(ctx context.Context, args wallet.GetPublicKeyArgs, originator string) (*wallet.GetPublicKeyResult, error) {
+	return jsonRPCCall[wallet.GetPublicKeyResult](ctx, w, CallGetPublicKey, args, originator)
+}
+
+func (w *JSONRPCWallet) RevealCounterpartyKeyLinkage(ctx context.Context, args wallet.RevealCounterpartyKeyLinkageArgs, originator string) (*wallet.RevealCounterpartyKeyLinkageResult, error) {
+	return jsonRPCCall[wallet.RevealCounterpartyKeyLinkageResult](ctx, w, CallRevealCounterpartyKeyLinkage, args, originator)
+}
+
+func (w *JSONRPCWallet) RevealSpecificKeyLinkage(ctx context.Context, args wallet.RevealSpecificKeyLinkageArgs, originator string) (*wallet.RevealSpecificKeyLinkageResult, error) {
+	return jsonRPCCall[wallet.RevealSpecificKeyLinkageResult](ctx, w, CallRevealSpecificKeyLinkage, args, originator)
+}
+
+func (w *JSONRPCWallet) Encrypt(ctx context.Context, args wallet.EncryptArgs, originator string) (*wallet.EncryptResult, error) {
+	return jsonRPCCall[wallet.EncryptResult](ctx, w, CallEncrypt, args, originator)
+}
+
+func (w *JSONRPCWallet) Decrypt(ctx context.Context, args wallet.DecryptArgs, originator string) (*wallet.DecryptResult, error) {
+	return jsonRPCCall[wallet.DecryptResult](ctx, w, CallDecrypt, args, originator)
+}
+
+func (w *JSONRPCWallet) CreateHMAC(ctx context.Context, args wallet.CreateHMACArgs, originator string) (*wallet.CreateHMACResult, error) {
+	return jsonRPCCall[wallet.CreateHMACResult](ctx, w, CallCreateHMAC, args, originator)
+}
+
+func (w *JSONRPCWallet) VerifyHMAC(ctx context.Context, args wallet.VerifyHMACArgs, originator string) (*wallet.VerifyHMACResult, error) {
+	return jsonRPCCall[wallet.VerifyHMACResult](ctx, w, CallVerifyHMAC, args, originator)
+}
+
+func (w *JSONRPCWallet) CreateSignature(ctx context.Context, args wallet.CreateSignatureArgs, originator string) (*wallet.CreateSignatureResult, error) {
+	return jsonRPCCall[wallet.CreateSignatureResult](ctx, w, CallCreateSignature, args, originator)
+}
+
+func (w *JSONRPCWallet) VerifySignature(ctx context.Context, args wallet.VerifySignatureArgs, originator string) (*wallet.VerifySignatureResult, error) {
+	return jsonRPCCall[wallet.VerifySignatureResult](ctx, w, CallVerifySignature, args, originator)
+}
+
+func (w *JSONRPCWallet) AcquireCertificate(ctx context.Context, args wallet.AcquireCertificateArgs, originator string) (*wallet.Certificate, error) {
+	return jsonRPCCall[wallet.Certificate](ctx, w, CallAcquireCertificate, args, originator)
+}
+
+func (w *JSONRPCWallet) ListCertificates(ctx context.Context, args wallet.ListCertificatesArgs, originator string) (*wallet.ListCertificatesResult, error) {
+	return jsonRPCCall[wallet.ListCertificatesResult](ctx, w, CallListCertificates, args, originator)
+}
+
+func (w *JSONRPCWallet) ProveCertificate(ctx context.Context, args wallet.ProveCertificateArgs, originator string) (*wallet.ProveCertificateResult, error) {
+	return jsonRPCCall[wallet.ProveCertificateResult](ctx, w, CallProveCertificate, args, originator)
+}
+
+func (w *JSONRPCWallet) RelinquishCertificate(ctx context.Context, args wallet.RelinquishCertificateArgs, originator string) (*wallet.RelinquishCertificateResult, error) {
+	return jsonRPCCall[wallet.RelinquishCertificateResult](ctx, w, CallRelinquishCertificate, args, originator)
+}
+
+func (w *JSONRPCWallet) DiscoverByIdentityKey(ctx context.Context, args wallet.DiscoverByIdentityKeyArgs, originator string) (*wallet.DiscoverCertificatesResult, error) {
+	return jsonRPCCall[wallet.DiscoverCertificatesResult](ctx, w, CallDiscoverByIdentityKey, args, originator)
+}
+
+func (w *JSONRPCWallet) DiscoverByAttributes(ctx context.Context, args wallet.DiscoverByAttributesArgs, originator string) (*wallet.DiscoverCertificatesResult, error) {
+	return jsonRPCCall[wallet.DiscoverCertificatesResult](ctx, w, CallDiscoverByAttributes, args, originator)
+}
+
+func (w *JSONRPCWallet) IsAuthenticated(ctx context.Context, args any, originator string) (*wallet.AuthenticatedResult, error) {
+	return jsonRPCCall[wallet.AuthenticatedResult](ctx, w, CallIsAuthenticated, args, originator)
+}
+
+func (w *JSONRPCWallet) WaitForAuthentication(ctx context.Context, args any, originator string) (*wallet.AuthenticatedResult, error) {
+	return jsonRPCCall[wallet.AuthenticatedResult](ctx, w, CallWaitForAuthentication, args, originator)
+}
+
+func (w *JSONRPCWallet) GetHeight(ctx context.Context, args any, originator string) (*wallet.GetHeightResult, error) {
+	return jsonRPCCall[wallet.GetHeightResult](ctx, w, CallGetHeight, args, originator)
+}
+
+func (w *JSONRPCWallet) GetHeaderForHeight(ctx context.Context, args wallet.GetHeaderArgs, originator string) (*wallet.GetHeaderResult, error) {
+	return jsonRPCCall[wallet.GetHeaderResult](ctx, w, CallGetHeaderForHeight, args, originator)
+}
+
+func (w *JSONRPCWallet) GetNetwork(ctx context.Context, args any, originator string) (*wallet.GetNetworkResult, error) {
+	return jsonRPCCall[wallet.GetNetworkResult](ctx, w, CallGetNetwork, args, originator)
+}
+
+func (w *JSONRPCWallet) GetVersion(ctx context.Context, args any, originator string) (*wallet.GetVersionResult, error) {
+	return jsonRPCCall[wallet.GetVersionResult](ctx, w, CallGetVersion, args, originator)
+}
+
+func (w *JSONRPCWallet) RelabelActions(ctx context.Context, args wallet.RelabelActionsArgs, originator string) (*wallet.RelabelActionsResult, error) {
+	return jsonRPCCall[wallet.RelabelActionsResult](ctx, w, CallRelabelActions, args, originator)
+}
+
+func (w *JSONRPCWallet) RetagOutputs(ctx context.Context, args wallet.RetagOutputsArgs, originator string) (*wallet.RetagOutputsResult, error) {
+	return jsonRPCCall[wallet.RetagOutputsResult](ctx, w, CallRetagOutputs, args, originator)
+}
+
+var _ http.Handler = (*JSONRPCServer)(nil)
+
+// JSONRPCServer serves a wallet over JSON-RPC 2.0 at a single HTTP endpoint, for
+// JSONRPCWallet and other JSON-RPC clients. It answers single requests and
+// batches, and passes the Originator header of the request to the wallet.
+type JSONRPCServer struct {
+	handlers map[string]jsonHandler
+}
+
+// NewJSONRPCServer creates a JSONRPCServer calling w.
+func NewJSONRPCServer(w wallet.Interface) *JSONRPCServer {
+	return &JSONRPCServer{handlers: jsonHandlers(w)}
+}
+
+// ServeHTTP answers the JSON-RPC request or batch posted in r. Notifications
+// are not answered, and a request made only of notifications is answered with
+// no content.
+func (s *JSONRPCServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, "failed to read request", http.StatusBadRequest)
+		return
+	}
+	ctx := r.Context()
+	originator := r.Header.Get("Originator")
+
+	var res any
+	if body = bytes.TrimSpace(body); len(body) > 0 && body[0] == '[' {
+		var batch []json.RawMessage
+		if err := json.Unmarshal(body, &batch); err != nil {
+			res = jsonRPCErrorResponse(nil, JSONRPCParseError, "parse error")
+		} else if len(batch) == 0 {
+			res = jsonRPCErrorResponse(nil, JSONRPCInvalidRequest, "empty batch")
+		} else {
+			responses := make([]*JSONRPCResponse, 0, len(batch))
+			for _, raw := range batch {
+				if response := s.handle(ctx, raw, originator); response != nil {
+					responses = append(responses, response)
+				}
+			}
+			if len(responses) > 0 {
+				res = responses
+			}
+		}
+	} else if response := s.handle(ctx, body, originator); response != nil {
+		res = response
+	}
+
+	if res == nil {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(res)
+}
+
+// handle answers a single request, returning nil for notifications.
+func (s *JSONRPCServer) handle(ctx context.Context, raw json.RawMessage, originator string) *JSONRPCResponse {
+	if !json.Valid(raw) {
+		return jsonRPCErrorResponse(nil, JSONRPCParseError, "parse error")
+	}
+	var req JSONRPCRequest
+	if err := json.Unmarshal(raw, &req); err != nil || req.JSONRPC != JSONRPCVersion || req.Method == "" {
+		return jsonRPCErrorResponse(req.ID, JSONRPCInvalidRequest, "invalid request")
+	}
+	notification := len(req.ID) == 0
+
+	handler, ok := s.handlers[req.Method]
+	if !ok {
+		if notification {
+			return nil
+		}
+		return jsonRPCErrorResponse(req.ID, JSONRPCMethodNotFound, fmt.Sprintf("unknown method %q", req.Method))
+	}
+	result, err := handler(ctx, req.Params, originator)
+	if notification {
+		return nil
+	}
+	if err != nil {
+		return &JSONRPCResponse{JSONRPC: JSONRPCVersion, Error: jsonRPCCallError(err), ID: req.ID}
+	}
+	data, err := json.Marshal(result)
+	if err != nil {
+		return jsonRPCErrorResponse(req.ID, JSONRPCInternalError, fmt.Sprintf("failed to marshal result: %v", err))
+	}
+	return &JSONRPCResponse{JSONRPC: JSONRPCVersion, Result: data, ID: req.ID}
+}
+
+func jsonRPCErrorResponse(id json.RawMessage, code int, message string) *JSONRPCResponse {
+	return &JSONRPCResponse{JSONRPC: JSONRPCVersion, Error: &JSONRPCError{Code: code, Message: message}, ID: id}
+}
+
+// jsonRPCCallError returns the error replied for a failed wallet call.
+func jsonRPCCallError(err error) *JSONRPCError {
+	if errors.Is(err, errInvalidArguments) {
+		return &JSONRPCError{Code: JSONRPCInvalidParams, Message: err.Error()}
+	}
+	var walletErr *wallet.Error
+	if !errors.As(err, &walletErr) {
+		return &JSONRPCError{Code: jsonRPCErrorCode, Message: err.Error()}
+	}
+	res := &JSONRPCError{Code: int(walletErr.Code), Message: walletErr.Message}
+	if walletErr.Stack != "" {
+		res.Data, _ = json.Marshal(jsonRPCErrorData{Stack: walletErr.Stack})
+	}
+	return res
+}
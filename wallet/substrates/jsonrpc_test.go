@@ -0,0 +1,193 @@
+package substrates
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/bsv-blockchain/go-sdk/wallet"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestJSONRPCWallet(t *testing.T, originator string) (*JSONRPCWallet, *wallet.TestWallet) {
+	backend := wallet.NewTestWalletForRandomKey(t)
+	server := httptest.NewServer(NewJSONRPCServer(backend))
+	t.Cleanup(server.Close)
+	return NewJSONRPCWallet(originator, server.URL, server.Client()), backend
+}
+
+func TestJSONRPCWallet(t *testing.T) {
+	ctx := t.Context()
+	client, backend := newTestJSONRPCWallet(t, "default.example.com")
+
+	encryption := wallet.EncryptionArgs{
+		ProtocolID:   wallet.Protocol{SecurityLevel: wallet.SecurityLevelEveryApp, Protocol: "jsonrpc test"},
+		KeyID:        "1",
+		Counterparty: wallet.Counterparty{Type: wallet.CounterpartyTypeSelf},
+	}
+	identity, err := client.GetPublicKey(ctx, wallet.GetPublicKeyArgs{IdentityKey: true}, "")
+	require.NoError(t, err)
+	expected, err := backend.GetPublicKey(ctx, wallet.GetPublicKeyArgs{IdentityKey: true}, "")
+	require.NoError(t, err)
+	require.True(t, expected.PublicKey.IsEqual(identity.PublicKey))
+
+	encrypted, err := client.Encrypt(ctx, wallet.EncryptArgs{EncryptionArgs: encryption, Plaintext: []byte("hello")}, "")
+	require.NoError(t, err)
+	decrypted, err := client.Decrypt(ctx, wallet.DecryptArgs{EncryptionArgs: encryption, Ciphertext: encrypted.Ciphertext}, "")
+	require.NoError(t, err)
+	require.Equal(t, []byte("hello"), []byte(decrypted.Plaintext))
+
+	signed, err := client.CreateSignature(ctx, wallet.CreateSignatureArgs{EncryptionArgs: encryption, Data: []byte("data")}, "")
+	require.NoError(t, err)
+	verified, err := client.VerifySignature(ctx, wallet.VerifySignatureArgs{EncryptionArgs: encryption, Data: []byte("data"), Signature: signed.Signature}, "")
+	require.NoError(t, err)
+	require.True(t, verified.Valid)
+
+	t.Run("passes the originator", func(t *testing.T) {
+		var originators []string
+		backend.OnGetHeight().Do(func(_ context.Context, _ any, originator string) (*wallet.GetHeightResult, error) {
+			originators = append(originators, originator)
+			return &wallet.GetHeightResult{Height: 850000}, nil
+		})
+		height, err := client.GetHeight(ctx, nil, "")
+		require.NoError(t, err)
+		require.Equal(t, uint32(850000), height.Height)
+		_, err = client.GetHeight(ctx, nil, "example.com")
+		require.NoError(t, err)
+		require.Equal(t, []string{"default.example.com", "example.com"}, originators)
+	})
+
+	t.Run("returns wallet errors", func(t *testing.T) {
+		walletErr := &wallet.Error{Code: 5, Message: "no network", Stack: "stack"}
+		backend.OnGetNetwork().ReturnError(walletErr)
+		_, err := client.GetNetwork(ctx, nil, "")
+		require.Equal(t, walletErr, err)
+
+		backend.OnGetVersion().ReturnError(errors.New("unavailable"))
+		_, err = client.GetVersion(ctx, nil, "")
+		require.Equal(t, &wallet.Error{Code: jsonRPCErrorCode, Message: "unavailable"}, err)
+	})
+
+	t.Run("makes batches", func(t *testing.T) {
+		backend.OnGetHeight().ReturnSuccess(&wallet.GetHeightResult{Height: 850001})
+		backend.OnAbortAction().ReturnError(&wallet.Error{Code: 6, Message: "not found"})
+		var height wallet.GetHeightResult
+		var hmac wallet.CreateHMACResult
+		var aborted wallet.AbortActionResult
+		calls := []*JSONRPCBatchCall{
+			{Call: CallGetHeight, Result: &height},
+			{Call: CallCreateHMAC, Args: wallet.CreateHMACArgs{EncryptionArgs: encryption, Data: []byte("data")}, Result: &hmac},
+			{Call: CallAbortAction, Args: wallet.AbortActionArgs{Reference: []byte("ref")}, Result: &aborted},
+		}
+		require.NoError(t, client.Batch(ctx, calls, ""))
+		require.NoError(t, calls[0].Err)
+		require.Equal(t, uint32(850001), height.Height)
+		require.NoError(t, calls[1].Err)
+		valid, err := client.VerifyHMAC(ctx, wallet.VerifyHMACArgs{EncryptionArgs: encryption, Data: []byte("data"), HMAC: hmac.HMAC}, "")
+		require.NoError(t, err)
+		require.True(t, valid.Valid)
+		require.Equal(t, &wallet.Error{Code: 6, Message: "not found"}, calls[2].Err)
+	})
+}
+
+func postJSONRPC(t *testing.T, server *httptest.Server, body string) (*http.Response, string) {
+	resp, err := server.Client().Post(server.URL, "application/json", strings.NewReader(body))
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	data, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	return resp, string(data)
+}
+
+func TestJSONRPCServer(t *testing.T) {
+	backend := wallet.NewTestWalletForRandomKey(t)
+	backend.OnGetHeight().ReturnSuccess(&wallet.GetHeightResult{Height: 850000})
+	server := httptest.NewServer(NewJSONRPCServer(backend))
+	t.Cleanup(server.Close)
+
+	tests := []struct {
+		name     string
+		request  string
+		status   int
+		response string
+	}{
+		{
+			name:     "request",
+			request:  `{"jsonrpc":"2.0","method":"getHeight","id":1}`,
+			status:   http.StatusOK,
+			response: `{"jsonrpc":"2.0","result":{"height":850000},"id":1}`,
+		},
+		{
+			name:    "notification",
+			request: `{"jsonrpc":"2.0","method":"getHeight"}`,
+			status:  http.StatusNoContent,
+		},
+		{
+			name:     "parse error",
+			request:  `{"jsonrpc":"2.0","method"`,
+			status:   http.StatusOK,
+			response: `{"jsonrpc":"2.0","error":{"code":-32700,"message":"parse error"},"id":null}`,
+		},
+		{
+			name:     "invalid request",
+			request:  `{"jsonrpc":"1.0","method":"getHeight","id":"a"}`,
+			status:   http.StatusOK,
+			response: `{"jsonrpc":"2.0","error":{"code":-32600,"message":"invalid request"},"id":"a"}`,
+		},
+		{
+			name:     "unknown method",
+			request:  `{"jsonrpc":"2.0","method":"mine","id":2}`,
+			status:   http.StatusOK,
+			response: `{"jsonrpc":"2.0","error":{"code":-32601,"message":"unknown method \"mine\""},"id":2}`,
+		},
+		{
+			name:     "empty batch",
+			request:  `[]`,
+			status:   http.StatusOK,
+			response: `{"jsonrpc":"2.0","error":{"code":-32600,"message":"empty batch"},"id":null}`,
+		},
+		{
+			name:    "batch",
+			request: `[{"jsonrpc":"2.0","method":"getHeight","id":1},{"jsonrpc":"2.0","method":"getHeight"},1]`,
+			status:  http.StatusOK,
+			response: `[{"jsonrpc":"2.0","result":{"height":850000},"id":1},` +
+				`{"jsonrpc":"2.0","error":{"code":-32600,"message":"invalid request"},"id":null}]`,
+		},
+		{
+			name:    "batch of notifications",
+			request: `[{"jsonrpc":"2.0","method":"getHeight"}]`,
+			status:  http.StatusNoContent,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, body := postJSONRPC(t, server, tt.request)
+			require.Equal(t, tt.status, resp.StatusCode)
+			if tt.response == "" {
+				require.Empty(t, body)
+			} else {
+				require.JSONEq(t, tt.response, body)
+			}
+		})
+	}
+
+	t.Run("invalid params", func(t *testing.T) {
+		_, body := postJSONRPC(t, server, `{"jsonrpc":"2.0","method":"getHeaderForHeight","params":{"height":"tall"},"id":3}`)
+		var res JSONRPCResponse
+		require.NoError(t, json.Unmarshal([]byte(body), &res))
+		require.Equal(t, JSONRPCInvalidParams, res.Error.Code)
+		require.Equal(t, "3", string(res.ID))
+	})
+
+	t.Run("rejects other methods", func(t *testing.T) {
+		resp, err := server.Client().Get(server.URL)
+		require.NoError(t, err)
+		require.NoError(t, resp.Body.Close())
+		require.Equal(t, http.StatusMethodNotAllowed, resp.StatusCode)
+	})
+}
@@ -0,0 +1,40 @@
+package substrates
+
+import "log/slog"
+
+// HTTPWalletOption configures the HTTP wallet substrates.
+type HTTPWalletOption func(*httpWalletOptions)
+
+type httpWalletOptions struct {
+	logger               *slog.Logger
+	compressionThreshold int
+}
+
+// WithLogger sets the logger receiving the debug logs of the substrate,
+// slog.Default by default.
+func WithLogger(logger *slog.Logger) HTTPWalletOption {
+	return func(o *httpWalletOptions) {
+		o.logger = logger
+	}
+}
+
+// WithCompression enables the compression of wire frames of at least threshold
+// bytes, DefaultCompressionThreshold for 0, by HTTPWalletWire. Results are
+// compressed by wallets supporting it, and requests once the wallet advertised
+// the codings it accepts (see CompressionHandler). Other substrates ignore it.
+func WithCompression(threshold int) HTTPWalletOption {
+	return func(o *httpWalletOptions) {
+		if threshold <= 0 {
+			threshold = DefaultCompressionThreshold
+		}
+		o.compressionThreshold = threshold
+	}
+}
+
+func applyHTTPWalletOptions(opts []HTTPWalletOption) httpWalletOptions {
+	var o httpWalletOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
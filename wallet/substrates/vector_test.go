@@ -5,8 +5,7 @@ import (
 	"encoding/hex"
 	"encoding/json"
 	"fmt"
-	"os"
-	"path/filepath"
+	"io/fs"
 	"reflect"
 	"strings"
 	"testing"
@@ -19,6 +18,7 @@ import (
 	"github.com/bsv-blockchain/go-sdk/wallet"
 	"github.com/bsv-blockchain/go-sdk/wallet/serializer"
 	"github.com/bsv-blockchain/go-sdk/wallet/substrates"
+	"github.com/bsv-blockchain/go-sdk/wallet/substrates/vectors"
 	"github.com/stretchr/testify/require"
 )
 
@@ -667,7 +667,7 @@ func TestVectors(t *testing.T) {
 				t.Skip()
 			}
 			// Read test vector file
-			data, err := os.ReadFile(filepath.Join("testdata", tt.Filename+".json"))
+			data, err := fs.ReadFile(vectors.TS, tt.Filename+".json")
 			if err != nil {
 				t.Fatalf("Failed to read test file: %v", err)
 			}
@@ -0,0 +1,28 @@
+//go:build ignore
+
+// This program generates the vectors in go from the vectors in ts.
+package main
+
+import (
+	"log"
+
+	"github.com/bsv-blockchain/go-sdk/wallet/substrates/vectors"
+)
+
+func main() {
+	ts, err := vectors.Load(vectors.TS)
+	if err != nil {
+		log.Fatal(err)
+	}
+	generated := make([]vectors.Vector, 0, len(ts))
+	for _, v := range ts {
+		g, err := vectors.Generate(v)
+		if err != nil {
+			log.Fatal(err)
+		}
+		generated = append(generated, g)
+	}
+	if err := vectors.Write("go", generated); err != nil {
+		log.Fatal(err)
+	}
+}
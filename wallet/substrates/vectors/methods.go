@@ -0,0 +1,133 @@
+package vectors
+
+import (
+	"encoding/json"
+
+	"github.com/bsv-blockchain/go-sdk/wallet/serializer"
+	"github.com/bsv-blockchain/go-sdk/wallet/substrates"
+)
+
+// codec converts the args or the result of a call between the JSON of a vector,
+// its Go type and its wire params.
+type codec struct {
+	decodeJSON  func(data []byte) (any, error)
+	serialize   func(v any) ([]byte, error)
+	deserialize func(data []byte) (any, error)
+}
+
+// codecOf returns the codec of the Go type T serialized by ser and de.
+func codecOf[T any](ser func(*T) ([]byte, error), de func([]byte) (*T, error)) *codec {
+	return &codec{
+		decodeJSON: func(data []byte) (any, error) {
+			var v T
+			if err := json.Unmarshal(data, &v); err != nil {
+				return nil, err
+			}
+			return &v, nil
+		},
+		serialize: func(v any) ([]byte, error) {
+			return ser(v.(*T))
+		},
+		deserialize: func(data []byte) (any, error) {
+			return de(data)
+		},
+	}
+}
+
+// noArgs is the codec of the args of calls taking none, written as {} in JSON
+// and as empty params on the wire.
+var noArgs = codecOf(
+	func(*struct{}) ([]byte, error) { return nil, nil },
+	func([]byte) (*struct{}, error) { return &struct{}{}, nil },
+)
+
+// method describes the wire call of a wallet method and the codecs of its args
+// and result.
+type method struct {
+	call   substrates.Call
+	args   *codec
+	result *codec
+}
+
+// methods are the wallet methods by the name used in vector names.
+var methods = map[string]method{
+	"createAction": {substrates.CallCreateAction,
+		codecOf(serializer.SerializeCreateActionArgs, serializer.DeserializeCreateActionArgs),
+		codecOf(serializer.SerializeCreateActionResult, serializer.DeserializeCreateActionResult)},
+	"signAction": {substrates.CallSignAction,
+		codecOf(serializer.SerializeSignActionArgs, serializer.DeserializeSignActionArgs),
+		codecOf(serializer.SerializeSignActionResult, serializer.DeserializeSignActionResult)},
+	"abortAction": {substrates.CallAbortAction,
+		codecOf(serializer.SerializeAbortActionArgs, serializer.DeserializeAbortActionArgs),
+		codecOf(serializer.SerializeAbortActionResult, serializer.DeserializeAbortActionResult)},
+	"listActions": {substrates.CallListActions,
+		codecOf(serializer.SerializeListActionsArgs, serializer.DeserializeListActionsArgs),
+		codecOf(serializer.SerializeListActionsResult, serializer.DeserializeListActionsResult)},
+	"internalizeAction": {substrates.CallInternalizeAction,
+		codecOf(serializer.SerializeInternalizeActionArgs, serializer.DeserializeInternalizeActionArgs),
+		codecOf(serializer.SerializeInternalizeActionResult, serializer.DeserializeInternalizeActionResult)},
+	"listOutputs": {substrates.CallListOutputs,
+		codecOf(serializer.SerializeListOutputsArgs, serializer.DeserializeListOutputsArgs),
+		codecOf(serializer.SerializeListOutputsResult, serializer.DeserializeListOutputsResult)},
+	"relinquishOutput": {substrates.CallRelinquishOutput,
+		codecOf(serializer.SerializeRelinquishOutputArgs, serializer.DeserializeRelinquishOutputArgs),
+		codecOf(serializer.SerializeRelinquishOutputResult, serializer.DeserializeRelinquishOutputResult)},
+	"getPublicKey": {substrates.CallGetPublicKey,
+		codecOf(serializer.SerializeGetPublicKeyArgs, serializer.DeserializeGetPublicKeyArgs),
+		codecOf(serializer.SerializeGetPublicKeyResult, serializer.DeserializeGetPublicKeyResult)},
+	"revealCounterpartyKeyLinkage": {substrates.CallRevealCounterpartyKeyLinkage,
+		codecOf(serializer.SerializeRevealCounterpartyKeyLinkageArgs, serializer.DeserializeRevealCounterpartyKeyLinkageArgs),
+		codecOf(serializer.SerializeRevealCounterpartyKeyLinkageResult, serializer.DeserializeRevealCounterpartyKeyLinkageResult)},
+	"revealSpecificKeyLinkage": {substrates.CallRevealSpecificKeyLinkage,
+		codecOf(serializer.SerializeRevealSpecificKeyLinkageArgs, serializer.DeserializeRevealSpecificKeyLinkageArgs),
+		codecOf(serializer.SerializeRevealSpecificKeyLinkageResult, serializer.DeserializeRevealSpecificKeyLinkageResult)},
+	"encrypt": {substrates.CallEncrypt,
+		codecOf(serializer.SerializeEncryptArgs, serializer.DeserializeEncryptArgs),
+		codecOf(serializer.SerializeEncryptResult, serializer.DeserializeEncryptResult)},
+	"decrypt": {substrates.CallDecrypt,
+		codecOf(serializer.SerializeDecryptArgs, serializer.DeserializeDecryptArgs),
+		codecOf(serializer.SerializeDecryptResult, serializer.DeserializeDecryptResult)},
+	"createHmac": {substrates.CallCreateHMAC,
+		codecOf(serializer.SerializeCreateHMACArgs, serializer.DeserializeCreateHMACArgs),
+		codecOf(serializer.SerializeCreateHMACResult, serializer.DeserializeCreateHMACResult)},
+	"verifyHmac": {substrates.CallVerifyHMAC,
+		codecOf(serializer.SerializeVerifyHMACArgs, serializer.DeserializeVerifyHMACArgs),
+		codecOf(serializer.SerializeVerifyHMACResult, serializer.DeserializeVerifyHMACResult)},
+	"createSignature": {substrates.CallCreateSignature,
+		codecOf(serializer.SerializeCreateSignatureArgs, serializer.DeserializeCreateSignatureArgs),
+		codecOf(serializer.SerializeCreateSignatureResult, serializer.DeserializeCreateSignatureResult)},
+	"verifySignature": {substrates.CallVerifySignature,
+		codecOf(serializer.SerializeVerifySignatureArgs, serializer.DeserializeVerifySignatureArgs),
+		codecOf(serializer.SerializeVerifySignatureResult, serializer.DeserializeVerifySignatureResult)},
+	"acquireCertificate": {substrates.CallAcquireCertificate,
+		codecOf(serializer.SerializeAcquireCertificateArgs, serializer.DeserializeAcquireCertificateArgs),
+		codecOf(serializer.SerializeCertificate, serializer.DeserializeCertificate)},
+	"listCertificates": {substrates.CallListCertificates,
+		codecOf(serializer.SerializeListCertificatesArgs, serializer.DeserializeListCertificatesArgs),
+		codecOf(serializer.SerializeListCertificatesResult, serializer.DeserializeListCertificatesResult)},
+	"proveCertificate": {substrates.CallProveCertificate,
+		codecOf(serializer.SerializeProveCertificateArgs, serializer.DeserializeProveCertificateArgs),
+		codecOf(serializer.SerializeProveCertificateResult, serializer.DeserializeProveCertificateResult)},
+	"relinquishCertificate": {substrates.CallRelinquishCertificate,
+		codecOf(serializer.SerializeRelinquishCertificateArgs, serializer.DeserializeRelinquishCertificateArgs),
+		codecOf(serializer.SerializeRelinquishCertificateResult, serializer.DeserializeRelinquishCertificateResult)},
+	"discoverByIdentityKey": {substrates.CallDiscoverByIdentityKey,
+		codecOf(serializer.SerializeDiscoverByIdentityKeyArgs, serializer.DeserializeDiscoverByIdentityKeyArgs),
+		codecOf(serializer.SerializeDiscoverCertificatesResult, serializer.DeserializeDiscoverCertificatesResult)},
+	"discoverByAttributes": {substrates.CallDiscoverByAttributes,
+		codecOf(serializer.SerializeDiscoverByAttributesArgs, serializer.DeserializeDiscoverByAttributesArgs),
+		codecOf(serializer.SerializeDiscoverCertificatesResult, serializer.DeserializeDiscoverCertificatesResult)},
+	"isAuthenticated": {substrates.CallIsAuthenticated, noArgs,
+		codecOf(serializer.SerializeIsAuthenticatedResult, serializer.DeserializeIsAuthenticatedResult)},
+	"waitForAuthentication": {substrates.CallWaitForAuthentication, noArgs,
+		codecOf(serializer.SerializeWaitAuthenticatedResult, serializer.DeserializeWaitAuthenticatedResult)},
+	"getHeight": {substrates.CallGetHeight, noArgs,
+		codecOf(serializer.SerializeGetHeightResult, serializer.DeserializeGetHeightResult)},
+	"getHeaderForHeight": {substrates.CallGetHeaderForHeight,
+		codecOf(serializer.SerializeGetHeaderArgs, serializer.DeserializeGetHeaderArgs),
+		codecOf(serializer.SerializeGetHeaderResult, serializer.DeserializeGetHeaderResult)},
+	"getNetwork": {substrates.CallGetNetwork, noArgs,
+		codecOf(serializer.SerializeGetNetworkResult, serializer.DeserializeGetNetworkResult)},
+	"getVersion": {substrates.CallGetVersion, noArgs,
+		codecOf(serializer.SerializeGetVersionResult, serializer.DeserializeGetVersionResult)},
+}
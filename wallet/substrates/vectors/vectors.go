@@ -0,0 +1,235 @@
+// Package vectors holds the conformance vectors of the wallet wire protocol
+// shared with the TypeScript SDK. Each vector is a JSON file holding the args
+// or the result of a wallet call, as "json", and its wire frame, as the hex
+// "wire". The vectors in ts are produced by the TypeScript SDK; Check verifies
+// the Go serializers agree with them byte for byte. The vectors in go are
+// produced by Generate from the JSON of the TypeScript vectors, for the
+// TypeScript SDK to check the other way round.
+package vectors
+
+import (
+	"bytes"
+	"embed"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"reflect"
+	"strings"
+
+	"github.com/bsv-blockchain/go-sdk/wallet/serializer"
+)
+
+//go:generate go run genvectors.go
+
+//go:embed ts/*.json go/*.json
+var files embed.FS
+
+// TS holds the vectors produced by the TypeScript SDK.
+var TS = mustSub("ts")
+
+// Go holds the vectors produced by Generate.
+var Go = mustSub("go")
+
+func mustSub(dir string) fs.FS {
+	sub, err := fs.Sub(files, dir)
+	if err != nil {
+		panic(err)
+	}
+	return sub
+}
+
+var (
+	// ErrVectorName is returned for a vector not named after a known wallet
+	// method, as <method>-<label>-args or <method>-<label>-result.
+	ErrVectorName = errors.New("invalid vector name")
+	// ErrMismatch is returned when the Go serializers disagree with a vector.
+	ErrMismatch = errors.New("vector mismatch")
+)
+
+// Vector is the JSON and wire encoding of the args or the result of a call.
+type Vector struct {
+	// Name is the file name of the vector, without its .json extension.
+	Name string
+	JSON json.RawMessage
+	Wire []byte
+}
+
+type vectorFile struct {
+	JSON json.RawMessage `json:"json"`
+	Wire string          `json:"wire"`
+}
+
+// MarshalJSON encodes the vector in the format of its file.
+func (v Vector) MarshalJSON() ([]byte, error) {
+	return json.Marshal(vectorFile{JSON: v.JSON, Wire: hex.EncodeToString(v.Wire)})
+}
+
+// UnmarshalJSON decodes a vector file, leaving the name unset.
+func (v *Vector) UnmarshalJSON(data []byte) error {
+	var file vectorFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return err
+	}
+	wire, err := hex.DecodeString(file.Wire)
+	if err != nil {
+		return fmt.Errorf("error decoding wire: %w", err)
+	}
+	if len(file.JSON) == 0 || len(wire) == 0 {
+		return errors.New("both json and wire are required")
+	}
+	v.JSON, v.Wire = file.JSON, wire
+	return nil
+}
+
+// Load reads the vectors of fsys, sorted by name.
+func Load(fsys fs.FS) ([]Vector, error) {
+	names, err := fs.Glob(fsys, "*.json")
+	if err != nil {
+		return nil, err
+	}
+	vectors := make([]Vector, 0, len(names))
+	for _, name := range names {
+		data, err := fs.ReadFile(fsys, name)
+		if err != nil {
+			return nil, err
+		}
+		var v Vector
+		if err := json.Unmarshal(data, &v); err != nil {
+			return nil, fmt.Errorf("error reading vector %s: %w", name, err)
+		}
+		v.Name = strings.TrimSuffix(path.Base(name), ".json")
+		vectors = append(vectors, v)
+	}
+	return vectors, nil
+}
+
+// vectorCodec returns the wallet method and the codec of the vector named name,
+// and whether the vector is of a result.
+func vectorCodec(name string) (method, *codec, bool, error) {
+	parts := strings.Split(name, "-")
+	if len(parts) < 3 {
+		return method{}, nil, false, fmt.Errorf("%w: %s", ErrVectorName, name)
+	}
+	m, ok := methods[parts[0]]
+	if !ok {
+		return method{}, nil, false, fmt.Errorf("%w: unknown method %s", ErrVectorName, parts[0])
+	}
+	switch parts[len(parts)-1] {
+	case "args":
+		return m, m.args, false, nil
+	case "result":
+		return m, m.result, true, nil
+	default:
+		return method{}, nil, false, fmt.Errorf("%w: %s is neither args nor result", ErrVectorName, name)
+	}
+}
+
+// frame writes params in the frame of a vector: a request frame of the call
+// without originator for args, a success result frame for a result.
+func frame(m method, isResult bool, params []byte) []byte {
+	if isResult {
+		return serializer.WriteResultFrame(params, nil)
+	}
+	return serializer.WriteRequestFrame(serializer.RequestFrame{Call: byte(m.call), Params: params})
+}
+
+// Check verifies the Go serializers agree with v: the args or result decoded
+// from its JSON serialize to its wire frame, and the params of its wire frame
+// deserialize to the same args or result, which encode to equivalent JSON.
+func Check(v Vector) error {
+	m, c, isResult, err := vectorCodec(v.Name)
+	if err != nil {
+		return err
+	}
+
+	expected, err := c.decodeJSON(v.JSON)
+	if err != nil {
+		return fmt.Errorf("%s: error decoding json: %w", v.Name, err)
+	}
+	params, err := c.serialize(expected)
+	if err != nil {
+		return fmt.Errorf("%s: error serializing: %w", v.Name, err)
+	}
+	if wire := frame(m, isResult, params); !bytes.Equal(wire, v.Wire) {
+		return fmt.Errorf("%w: %s: serialized to %x, expected %x", ErrMismatch, v.Name, wire, v.Wire)
+	}
+
+	if isResult {
+		params, err = serializer.ReadResultFrame(v.Wire)
+	} else {
+		var request *serializer.RequestFrame
+		if request, err = serializer.ReadRequestFrame(v.Wire); err == nil {
+			if request.Call != byte(m.call) || request.Originator != "" {
+				return fmt.Errorf("%w: %s: call %d, originator %q", ErrMismatch, v.Name, request.Call, request.Originator)
+			}
+			params = request.Params
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("%s: error reading frame: %w", v.Name, err)
+	}
+	deserialized, err := c.deserialize(params)
+	if err != nil {
+		return fmt.Errorf("%s: error deserializing: %w", v.Name, err)
+	}
+	if !reflect.DeepEqual(expected, deserialized) {
+		return fmt.Errorf("%w: %s: deserialized to %+v, expected %+v", ErrMismatch, v.Name, deserialized, expected)
+	}
+
+	marshaled, err := json.Marshal(deserialized)
+	if err != nil {
+		return fmt.Errorf("%s: error encoding json: %w", v.Name, err)
+	}
+	var got, want any
+	if err = json.Unmarshal(marshaled, &got); err == nil {
+		err = json.Unmarshal(v.JSON, &want)
+	}
+	if err != nil {
+		return fmt.Errorf("%s: %w", v.Name, err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		return fmt.Errorf("%w: %s: encoded to json %s", ErrMismatch, v.Name, marshaled)
+	}
+	return nil
+}
+
+// Generate produces the vector of the same name as v from the Go encodings of
+// the args or result decoded from its JSON.
+func Generate(v Vector) (Vector, error) {
+	m, c, isResult, err := vectorCodec(v.Name)
+	if err != nil {
+		return Vector{}, err
+	}
+	obj, err := c.decodeJSON(v.JSON)
+	if err != nil {
+		return Vector{}, fmt.Errorf("%s: error decoding json: %w", v.Name, err)
+	}
+	marshaled, err := json.MarshalIndent(obj, "", "  ")
+	if err != nil {
+		return Vector{}, fmt.Errorf("%s: error encoding json: %w", v.Name, err)
+	}
+	params, err := c.serialize(obj)
+	if err != nil {
+		return Vector{}, fmt.Errorf("%s: error serializing: %w", v.Name, err)
+	}
+	return Vector{Name: v.Name, JSON: marshaled, Wire: frame(m, isResult, params)}, nil
+}
+
+// Write writes each vector to dir, as <name>.json.
+func Write(dir string, vectors []Vector) error {
+	for _, v := range vectors {
+		data, err := json.MarshalIndent(v, "", "  ")
+		if err != nil {
+			return fmt.Errorf("%s: %w", v.Name, err)
+		}
+		if err := os.WriteFile(filepath.Join(dir, v.Name+".json"), append(data, '\n'), 0o644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
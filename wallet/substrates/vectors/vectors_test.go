@@ -0,0 +1,52 @@
+package vectors
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTSVectors(t *testing.T) {
+	vectors, err := Load(TS)
+	require.NoError(t, err)
+	require.NotEmpty(t, vectors)
+	for _, v := range vectors {
+		t.Run(v.Name, func(t *testing.T) {
+			require.NoError(t, Check(v))
+		})
+	}
+}
+
+func TestGoVectors(t *testing.T) {
+	ts, err := Load(TS)
+	require.NoError(t, err)
+	vectors, err := Load(Go)
+	require.NoError(t, err)
+	require.Len(t, vectors, len(ts), "go vectors are stale, run go generate")
+
+	for i, v := range vectors {
+		t.Run(v.Name, func(t *testing.T) {
+			require.NoError(t, Check(v))
+
+			// The committed vectors are those Generate produces now.
+			generated, err := Generate(ts[i])
+			require.NoError(t, err)
+			require.Equal(t, generated.Name, v.Name)
+			require.JSONEq(t, string(generated.JSON), string(v.JSON))
+			require.Equal(t, generated.Wire, v.Wire)
+		})
+	}
+}
+
+func TestCheckMismatch(t *testing.T) {
+	v := Vector{Name: "getHeight-simple-result", JSON: []byte(`{"height":850000}`), Wire: []byte{0, 0xfe, 0x50, 0xf8, 0x0c, 0x00}}
+	require.NoError(t, Check(v))
+
+	v.JSON = []byte(`{"height":850001}`)
+	require.ErrorIs(t, Check(v), ErrMismatch)
+
+	v.Name = "getHeight-simple"
+	require.ErrorIs(t, Check(v), ErrVectorName)
+	v.Name = "unknown-simple-args"
+	require.ErrorIs(t, Check(v), ErrVectorName)
+}
@@ -1,9 +1,22 @@
 package substrates
 
-import "context"
+import (
+	"context"
+	"io"
+)
 
 // WalletWire is an abstraction over a raw transport medium
 // where binary data can be sent to and subsequently received from a wallet.
 type WalletWire interface {
 	TransmitToWallet(ctx context.Context, message []byte) ([]byte, error)
 }
+
+// StreamingWalletWire is a WalletWire which also carries messages as streams, so
+// that chunked frames (see serializer.FrameContinuation) pass through it without
+// either side holding the whole frame in memory.
+type StreamingWalletWire interface {
+	WalletWire
+	// StreamToWallet sends the request frame read from message to the wallet and
+	// returns the stream of the result frame, which the caller must close.
+	StreamToWallet(ctx context.Context, message io.Reader) (io.ReadCloser, error)
+}
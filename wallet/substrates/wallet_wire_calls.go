@@ -33,4 +33,6 @@ const (
 	CallGetHeaderForHeight           Call = 26
 	CallGetNetwork                   Call = 27
 	CallGetVersion                   Call = 28
+	CallRelabelActions               Call = 29
+	CallRetagOutputs                 Call = 30
 )
@@ -3,8 +3,11 @@ package substrates
 import (
 	"context"
 	"encoding/hex"
+	"net/http/httptest"
+	"strconv"
 	"testing"
 
+	"github.com/bsv-blockchain/go-sdk/util"
 	tu "github.com/bsv-blockchain/go-sdk/util/test_util"
 	"github.com/bsv-blockchain/go-sdk/wallet"
 	"github.com/bsv-blockchain/go-sdk/wallet/serializer"
@@ -118,3 +121,95 @@ func TestTsCompatibility(t *testing.T) {
 		}},
 	}, *createActionArgs)
 }
+
+func TestCreateActionExtensions(t *testing.T) {
+	mock := wallet.NewTestWalletForRandomKey(t)
+	walletTransceiver := createTestWalletWire(mock)
+	strategy := &wallet.ChangeStrategy{Type: wallet.ChangeStrategySplit, Count: 3}
+
+	mock.OnCreateAction().
+		Expect(func(ctx context.Context, args wallet.CreateActionArgs, originator string) {
+			require.Equal(t, strategy, args.Options.ChangeStrategy)
+		}).
+		ReturnSuccess(&wallet.CreateActionResult{})
+
+	_, err := walletTransceiver.CreateAction(t.Context(), wallet.CreateActionArgs{
+		Description: "Test action description",
+		Options:     &wallet.CreateActionOptions{ChangeStrategy: strategy},
+	}, "")
+	require.NoError(t, err)
+}
+
+// relabelBackend relabels the actions of a TestWallet held in memory, with the
+// index of the next action to look at as cursor.
+func relabelBackend(t *testing.T, actions [][]string) *wallet.TestWallet {
+	backend := wallet.NewTestWalletForRandomKey(t)
+	backend.OnRelabelActions().Do(func(_ context.Context, args wallet.RelabelActionsArgs, _ string) (*wallet.RelabelActionsResult, error) {
+		if err := args.Validate(); err != nil {
+			return nil, err
+		}
+		start, _ := strconv.Atoi(args.Cursor)
+		limit := wallet.MaxRelabelLimit
+		if args.Limit != nil {
+			limit = int(*args.Limit)
+		}
+		result := &wallet.RelabelActionsResult{}
+		for i := start; i < len(actions); i++ {
+			if int(result.RelabeledActions) == limit {
+				result.NextCursor = strconv.Itoa(i)
+				break
+			}
+			if !args.Matches(actions[i]) {
+				continue
+			}
+			if labels, changed := args.Apply(actions[i]); changed {
+				actions[i] = labels
+				result.RelabeledActions++
+			}
+		}
+		return result, nil
+	})
+	return backend
+}
+
+func TestAdminCalls(t *testing.T) {
+	ctx := t.Context()
+	args := wallet.RelabelActionsArgs{
+		Labels:       []string{"schema:v1"},
+		AddLabels:    []string{"schema:v2"},
+		RemoveLabels: []string{"schema:v1"},
+		Limit:        util.Uint32Ptr(2),
+	}
+
+	for name, newClient := range map[string]func(wallet.Interface) wallet.AdminInterface{
+		"wire": func(w wallet.Interface) wallet.AdminInterface {
+			return createTestWalletWire(w)
+		},
+		"json-rpc": func(w wallet.Interface) wallet.AdminInterface {
+			server := httptest.NewServer(NewJSONRPCServer(w))
+			t.Cleanup(server.Close)
+			return NewJSONRPCWallet("admin.example.com", server.URL, server.Client())
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			actions := [][]string{{"schema:v1"}, {"other"}, {"schema:v1", "paid"}, {"schema:v1"}}
+			client := newClient(relabelBackend(t, actions))
+
+			result, err := client.RelabelActions(ctx, args, "admin.example.com")
+			require.NoError(t, err)
+			require.Equal(t, &wallet.RelabelActionsResult{RelabeledActions: 2, NextCursor: "3"}, result)
+
+			next := args
+			next.Cursor = result.NextCursor
+			result, err = client.RelabelActions(ctx, next, "admin.example.com")
+			require.NoError(t, err)
+			require.Equal(t, &wallet.RelabelActionsResult{RelabeledActions: 1}, result)
+			require.Equal(t, [][]string{{"schema:v2"}, {"other"}, {"paid", "schema:v2"}, {"schema:v2"}}, actions)
+
+			// Wallets without admin calls refuse them.
+			nonAdmin := struct{ wallet.Interface }{wallet.NewTestWalletForRandomKey(t)}
+			_, err = newClient(nonAdmin).RetagOutputs(ctx, wallet.RetagOutputsArgs{Basket: "tokens", AddTags: []string{"v2"}}, "admin.example.com")
+			require.ErrorContains(t, err, wallet.ErrAdminUnsupported.Error())
+		})
+	}
+}
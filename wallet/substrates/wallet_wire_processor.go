@@ -1,10 +1,14 @@
 package substrates
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
+	"io"
+	"log/slog"
 
+	"github.com/bsv-blockchain/go-sdk/internal/logging"
 	"github.com/bsv-blockchain/go-sdk/wallet"
 	"github.com/bsv-blockchain/go-sdk/wallet/serializer"
 )
@@ -12,6 +16,12 @@ import (
 // WalletWireProcessor implements the WalletWire interface
 type WalletWireProcessor struct {
 	Wallet wallet.Interface
+	// Logger receives the errors of failed calls before they are returned to the
+	// transport, slog.Default when nil.
+	Logger *slog.Logger
+	// ChunkSize is the size of the chunks of the results replied to chunked
+	// requests, serializer.DefaultFrameChunkSize when zero.
+	ChunkSize int
 }
 
 // NewWalletWireProcessor creates a new WalletWireProcessor with the given wallet interface.
@@ -30,7 +40,42 @@ func (w *WalletWireProcessor) TransmitToWallet(ctx context.Context, message []by
 	if err != nil {
 		return nil, fmt.Errorf("failed to deserialize request frame: %w", err)
 	}
+	response, err := w.process(ctx, requestFrame)
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	if err := serializer.WriteResultFrameTo(&buf, response, nil, requestFrame.Chunked, w.ChunkSize); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+var _ StreamingWalletWire = (*WalletWireProcessor)(nil)
+
+// StreamToWallet processes the request frame read from message and returns the
+// stream of its result frame, written in chunks for a chunked request.
+func (w *WalletWireProcessor) StreamToWallet(ctx context.Context, message io.Reader) (io.ReadCloser, error) {
+	requestFrame, err := serializer.ReadRequestFrameFrom(message)
+	if err != nil {
+		return nil, fmt.Errorf("failed to deserialize request frame: %w", err)
+	}
+	response, err := w.process(ctx, requestFrame)
+	if err != nil {
+		return nil, err
+	}
+	pr, pw := io.Pipe()
+	go func() {
+		pw.CloseWithError(serializer.WriteResultFrameTo(pw, response, nil, requestFrame.Chunked, w.ChunkSize))
+	}()
+	return pr, nil
+}
+
+// process calls the wallet method of the request, returning its serialized
+// result.
+func (w *WalletWireProcessor) process(ctx context.Context, requestFrame *serializer.RequestFrame) ([]byte, error) {
 	var response []byte
+	var err error
 	switch Call(requestFrame.Call) {
 	case CallCreateAction:
 		response, err = w.processCreateAction(ctx, requestFrame)
@@ -88,13 +133,19 @@ func (w *WalletWireProcessor) TransmitToWallet(ctx context.Context, message []by
 		response, err = w.processGetNetwork(ctx, requestFrame)
 	case CallGetVersion:
 		response, err = w.processGetVersion(ctx, requestFrame)
+	case CallRelabelActions:
+		response, err = w.processRelabelActions(ctx, requestFrame)
+	case CallRetagOutputs:
+		response, err = w.processRetagOutputs(ctx, requestFrame)
 	default:
 		return nil, fmt.Errorf("unknown call type: %d", requestFrame.Call)
 	}
 	if err != nil {
+		logging.OrDefault(w.Logger).DebugContext(ctx, "wallet wire call failed",
+			"call", callCodeToName[Call(requestFrame.Call)], "originator", requestFrame.Originator, "error", err)
 		return nil, fmt.Errorf("error calling %d: %w", requestFrame.Call, err)
 	}
-	return serializer.WriteResultFrame(response, nil), nil
+	return response, nil
 }
 
 func (w *WalletWireProcessor) processSignAction(ctx context.Context, requestFrame *serializer.RequestFrame) ([]byte, error) {
@@ -114,6 +165,9 @@ func (w *WalletWireProcessor) processCreateAction(ctx context.Context, requestFr
 	if err != nil {
 		return nil, fmt.Errorf("failed to deserialize create action args: %w", err)
 	}
+	if err := serializer.ApplyCreateActionExtensions(args, requestFrame.Extensions); err != nil {
+		return nil, fmt.Errorf("failed to apply create action extensions: %w", err)
+	}
 	result, err := w.Wallet.CreateAction(ctx, *args, requestFrame.Originator)
 	if err != nil {
 		return nil, fmt.Errorf("failed to process create action: %w", err)
@@ -181,6 +235,30 @@ func (w *WalletWireProcessor) processRelinquishOutput(ctx context.Context, reque
 	return serializer.SerializeRelinquishOutputResult(result)
 }
 
+func (w *WalletWireProcessor) processRelabelActions(ctx context.Context, requestFrame *serializer.RequestFrame) ([]byte, error) {
+	args, err := serializer.DeserializeRelabelActionsArgs(requestFrame.Params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to deserialize relabel actions args: %w", err)
+	}
+	result, err := adminOf(w.Wallet).RelabelActions(ctx, *args, requestFrame.Originator)
+	if err != nil {
+		return nil, fmt.Errorf("failed to process relabel actions: %w", err)
+	}
+	return serializer.SerializeRelabelActionsResult(result)
+}
+
+func (w *WalletWireProcessor) processRetagOutputs(ctx context.Context, requestFrame *serializer.RequestFrame) ([]byte, error) {
+	args, err := serializer.DeserializeRetagOutputsArgs(requestFrame.Params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to deserialize retag outputs args: %w", err)
+	}
+	result, err := adminOf(w.Wallet).RetagOutputs(ctx, *args, requestFrame.Originator)
+	if err != nil {
+		return nil, fmt.Errorf("failed to process retag outputs: %w", err)
+	}
+	return serializer.SerializeRetagOutputsResult(result)
+}
+
 func (w *WalletWireProcessor) processGetPublicKey(ctx context.Context, requestFrame *serializer.RequestFrame) ([]byte, error) {
 	args, err := serializer.DeserializeGetPublicKeyArgs(requestFrame.Params)
 	if err != nil {
@@ -294,6 +372,9 @@ func (w *WalletWireProcessor) processAcquireCertificate(ctx context.Context, req
 	if err != nil {
 		return nil, fmt.Errorf("failed to deserialize acquire certificate args: %w", err)
 	}
+	if err := args.ValidateFields(); err != nil {
+		return nil, err
+	}
 	result, err := w.Wallet.AcquireCertificate(ctx, *args, requestFrame.Originator)
 	if err != nil {
 		return nil, fmt.Errorf("failed to process acquire certificate: %w", err)
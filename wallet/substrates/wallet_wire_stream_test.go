@@ -0,0 +1,109 @@
+package substrates
+
+import (
+	"bytes"
+	"crypto/rand"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/bsv-blockchain/go-sdk/wallet"
+	"github.com/bsv-blockchain/go-sdk/wallet/serializer"
+	"github.com/stretchr/testify/require"
+)
+
+// newHTTPWireServer serves processor at the HTTP endpoints used by HTTPWalletWire.
+func newHTTPWireServer(t *testing.T, processor *WalletWireProcessor) *httptest.Server {
+	server := httptest.NewServer(newHTTPWireHandler(t, processor))
+	t.Cleanup(server.Close)
+	return server
+}
+
+// newHTTPWireHandler handles the HTTP endpoints used by HTTPWalletWire with
+// processor.
+func newHTTPWireHandler(t *testing.T, processor *WalletWireProcessor) http.Handler {
+	callNameToCode := make(map[string]Call, len(callCodeToName))
+	for code, name := range callCodeToName {
+		callNameToCode[name] = code
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		params, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		frame := serializer.WriteRequestFrame(serializer.RequestFrame{
+			Call:       byte(callNameToCode[strings.TrimPrefix(r.URL.Path, "/")]),
+			Originator: r.Header.Get("Origin"),
+			Params:     params,
+		})
+		result, err := processor.TransmitToWallet(r.Context(), frame)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		_, _ = w.Write(result)
+	})
+}
+
+func TestWalletWireChunkedFrames(t *testing.T) {
+	backend := wallet.NewTestWalletForRandomKey(t)
+	processor := NewWalletWireProcessor(backend)
+	processor.ChunkSize = 1000
+	server := newHTTPWireServer(t, processor)
+
+	encryption := wallet.EncryptionArgs{
+		ProtocolID:   wallet.Protocol{SecurityLevel: wallet.SecurityLevelEveryApp, Protocol: "chunked frames"},
+		KeyID:        "1",
+		Counterparty: wallet.Counterparty{Type: wallet.CounterpartyTypeSelf},
+	}
+	plaintext := make([]byte, 1<<20)
+	_, err := rand.Read(plaintext)
+	require.NoError(t, err)
+
+	wires := map[string]WalletWire{
+		"processor": processor,
+		"http":      NewHTTPWalletWire("", server.URL, server.Client()),
+	}
+	for name, wire := range wires {
+		t.Run(name, func(t *testing.T) {
+			ctx := t.Context()
+			transceiver := &WalletWireTransceiver{Wire: wire, ChunkSize: 4096}
+
+			encrypted, err := transceiver.Encrypt(ctx, wallet.EncryptArgs{EncryptionArgs: encryption, Plaintext: plaintext}, "example.com")
+			require.NoError(t, err)
+			decrypted, err := transceiver.Decrypt(ctx, wallet.DecryptArgs{EncryptionArgs: encryption, Ciphertext: encrypted.Ciphertext}, "example.com")
+			require.NoError(t, err)
+			require.True(t, bytes.Equal(plaintext, decrypted.Plaintext))
+
+			backend.OnGetHeight().ExpectOriginator("example.com").ReturnSuccess(&wallet.GetHeightResult{Height: 850000})
+			height, err := transceiver.GetHeight(ctx, nil, "example.com")
+			require.NoError(t, err)
+			require.Equal(t, uint32(850000), height.Height)
+		})
+	}
+}
+
+func TestWalletWireProcessorChunkedResult(t *testing.T) {
+	backend := wallet.NewTestWalletForRandomKey(t)
+	backend.OnGetHeight().ReturnSuccess(&wallet.GetHeightResult{Height: 850000})
+	processor := NewWalletWireProcessor(backend)
+
+	chunked := serializer.WriteRequestFrame(serializer.RequestFrame{Call: byte(CallGetHeight), Chunked: true})
+	result, err := processor.TransmitToWallet(t.Context(), chunked)
+	require.NoError(t, err)
+	data, err := serializer.ReadResultFrameFrom(bytes.NewReader(result), true)
+	require.NoError(t, err)
+	height, err := serializer.DeserializeGetHeightResult(data)
+	require.NoError(t, err)
+	require.Equal(t, uint32(850000), height.Height)
+
+	plain := serializer.WriteRequestFrame(serializer.RequestFrame{Call: byte(CallGetHeight)})
+	stream, err := processor.StreamToWallet(t.Context(), bytes.NewReader(plain))
+	require.NoError(t, err)
+	defer stream.Close()
+	data, err = serializer.ReadResultFrameFrom(stream, false)
+	require.NoError(t, err)
+	height, err = serializer.DeserializeGetHeightResult(data)
+	require.NoError(t, err)
+	require.Equal(t, uint32(850000), height.Height)
+}
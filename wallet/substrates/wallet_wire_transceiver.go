@@ -3,6 +3,7 @@ package substrates
 import (
 	"context"
 	"fmt"
+	"io"
 
 	"github.com/bsv-blockchain/go-sdk/wallet"
 	"github.com/bsv-blockchain/go-sdk/wallet/serializer"
@@ -12,20 +13,34 @@ import (
 // A way to make remote calls to a wallet over a wallet wire.
 type WalletWireTransceiver struct {
 	Wire WalletWire
+	// ChunkSize, when set and Wire is a StreamingWalletWire, makes the calls
+	// stream chunked frames with chunks of at most ChunkSize bytes, so that large
+	// payloads are never held whole in a frame. The wallet must support chunked
+	// frames, as WalletWireProcessor does.
+	ChunkSize int
 }
 
+var _ wallet.AdminInterface = (*WalletWireTransceiver)(nil)
+
 // NewWalletWireTransceiver creates a new WalletWireTransceiver with the given processor.
 // The transceiver will use the processor to handle wire protocol commands and responses.
 func NewWalletWireTransceiver(processor *WalletWireProcessor) *WalletWireTransceiver {
 	return &WalletWireTransceiver{Wire: processor}
 }
 
-func (t *WalletWireTransceiver) transmit(ctx context.Context, call Call, originator string, params []byte) ([]byte, error) {
-	frame := serializer.WriteRequestFrame(serializer.RequestFrame{
+// transmit sends a call to the wallet. Extensions make the frame versioned, so
+// frames without them still reach wallets which predate versioned frames.
+func (t *WalletWireTransceiver) transmit(ctx context.Context, call Call, originator string, params []byte, extensions ...serializer.Extension) ([]byte, error) {
+	requestFrame := serializer.RequestFrame{
 		Call:       byte(call),
 		Originator: originator,
 		Params:     params,
-	})
+		Extensions: extensions,
+	}
+	if wire, ok := t.Wire.(StreamingWalletWire); ok && t.ChunkSize > 0 {
+		return t.stream(ctx, wire, requestFrame)
+	}
+	frame := serializer.WriteRequestFrame(requestFrame)
 
 	result, err := t.Wire.TransmitToWallet(ctx, frame)
 	if err != nil {
@@ -35,13 +50,32 @@ func (t *WalletWireTransceiver) transmit(ctx context.Context, call Call, origina
 	return serializer.ReadResultFrame(result)
 }
 
+// stream transmits requestFrame as a chunked frame written to the wire as it is
+// read, and reads the chunked result frame from the stream replied.
+func (t *WalletWireTransceiver) stream(ctx context.Context, wire StreamingWalletWire, requestFrame serializer.RequestFrame) ([]byte, error) {
+	requestFrame.Chunked = true
+	pr, pw := io.Pipe()
+	defer pr.Close()
+	go func() {
+		pw.CloseWithError(serializer.WriteRequestFrameTo(pw, requestFrame, t.ChunkSize))
+	}()
+
+	result, err := wire.StreamToWallet(ctx, pr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to transmit call to wallet wire: %w", err)
+	}
+	defer result.Close()
+	return serializer.ReadResultFrameFrom(result, true)
+}
+
 func (t *WalletWireTransceiver) CreateAction(ctx context.Context, args wallet.CreateActionArgs, originator string) (*wallet.CreateActionResult, error) {
+	args, extensions := serializer.SplitCreateActionExtensions(args)
 	data, err := serializer.SerializeCreateActionArgs(&args)
 	if err != nil {
 		return nil, fmt.Errorf("failed to serialize create action arguments: %w", err)
 	}
 
-	resp, err := t.transmit(ctx, CallCreateAction, originator, data)
+	resp, err := t.transmit(ctx, CallCreateAction, originator, data, extensions...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to transmit create action call: %w", err)
 	}
@@ -362,3 +396,27 @@ func (t *WalletWireTransceiver) GetVersion(ctx context.Context, args any, origin
 	}
 	return serializer.DeserializeGetVersionResult(resp)
 }
+
+func (t *WalletWireTransceiver) RelabelActions(ctx context.Context, args wallet.RelabelActionsArgs, originator string) (*wallet.RelabelActionsResult, error) {
+	data, err := serializer.SerializeRelabelActionsArgs(&args)
+	if err != nil {
+		return nil, fmt.Errorf("failed to serialize relabel actions arguments: %w", err)
+	}
+	resp, err := t.transmit(ctx, CallRelabelActions, originator, data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to transmit relabel actions call: %w", err)
+	}
+	return serializer.DeserializeRelabelActionsResult(resp)
+}
+
+func (t *WalletWireTransceiver) RetagOutputs(ctx context.Context, args wallet.RetagOutputsArgs, originator string) (*wallet.RetagOutputsResult, error) {
+	data, err := serializer.SerializeRetagOutputsArgs(&args)
+	if err != nil {
+		return nil, fmt.Errorf("failed to serialize retag outputs arguments: %w", err)
+	}
+	resp, err := t.transmit(ctx, CallRetagOutputs, originator, data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to transmit retag outputs call: %w", err)
+	}
+	return serializer.DeserializeRetagOutputsResult(resp)
+}
@@ -0,0 +1,185 @@
+// Package walletgrpc carries wallet.Interface over gRPC, as an alternative to the
+// binary wire format for microservice deployments built around protobuf. The
+// service and its messages are defined in walletpb/wallet.proto; Client adapts
+// the generated client to wallet.Interface and Server adapts a wallet.Interface
+// to the generated server:
+//
+//	server := grpc.NewServer()
+//	walletpb.RegisterWalletServer(server, walletgrpc.NewServer(w))
+//
+//	conn, err := grpc.NewClient(target, opts...)
+//	w := walletgrpc.NewClient(conn)
+//
+// The originator of each call is sent in the "originator" metadata, and a
+// *wallet.Error returned by the wallet is returned by the client as well.
+package walletgrpc
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/bsv-blockchain/go-sdk/wallet"
+	"github.com/bsv-blockchain/go-sdk/wallet/substrates/walletgrpc/walletpb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// OriginatorMetadataKey is the gRPC metadata key carrying the originator of a
+// call.
+const OriginatorMetadataKey = "originator"
+
+var _ wallet.Interface = (*Client)(nil)
+
+// Client implements wallet.Interface by calling a remote wallet over gRPC.
+type Client struct {
+	client walletpb.WalletClient
+}
+
+// NewClient creates a Client calling the wallet served on conn.
+func NewClient(conn grpc.ClientConnInterface) *Client {
+	return &Client{client: walletpb.NewWalletClient(conn)}
+}
+
+func invoke[M, PR, R any](ctx context.Context, originator string, rpc func(context.Context, M, ...grpc.CallOption) (PR, error), args M, decode func(*decoder, PR) R) (*R, error) {
+	if originator != "" {
+		ctx = metadata.AppendToOutgoingContext(ctx, OriginatorMetadataKey, originator)
+	}
+	res, err := rpc(ctx, args)
+	if err != nil {
+		return nil, fromStatus(err)
+	}
+	d := &decoder{}
+	result := decode(d, res)
+	if d.err != nil {
+		return nil, fmt.Errorf("failed to decode result: %w", d.err)
+	}
+	return &result, nil
+}
+
+// fromStatus returns the wallet.Error attached to a status error, or err itself.
+func fromStatus(err error) error {
+	st, ok := status.FromError(err)
+	if !ok {
+		return err
+	}
+	for _, detail := range st.Details() {
+		if walletErr, ok := detail.(*walletpb.Error); ok {
+			return &wallet.Error{Code: byte(walletErr.GetCode()), Message: walletErr.GetMessage(), Stack: walletErr.GetStack()}
+		}
+	}
+	return err
+}
+
+func (c *Client) CreateAction(ctx context.Context, args wallet.CreateActionArgs, originator string) (*wallet.CreateActionResult, error) {
+	return invoke(ctx, originator, c.client.CreateAction, encodeCreateActionArgs(&args), (*decoder).decodeCreateActionResult)
+}
+
+func (c *Client) SignAction(ctx context.Context, args wallet.SignActionArgs, originator string) (*wallet.SignActionResult, error) {
+	return invoke(ctx, originator, c.client.SignAction, encodeSignActionArgs(&args), (*decoder).decodeSignActionResult)
+}
+
+func (c *Client) AbortAction(ctx context.Context, args wallet.AbortActionArgs, originator string) (*wallet.AbortActionResult, error) {
+	return invoke(ctx, originator, c.client.AbortAction, encodeAbortActionArgs(&args), (*decoder).decodeAbortActionResult)
+}
+
+func (c *Client) ListActions(ctx context.Context, args wallet.ListActionsArgs, originator string) (*wallet.ListActionsResult, error) {
+	return invoke(ctx, originator, c.client.ListActions, encodeListActionsArgs(&args), (*decoder).decodeListActionsResult)
+}
+
+func (c *Client) InternalizeAction(ctx context.Context, args wallet.InternalizeActionArgs, originator string) (*wallet.InternalizeActionResult, error) {
+	return invoke(ctx, originator, c.client.InternalizeAction, encodeInternalizeActionArgs(&args), (*decoder).decodeInternalizeActionResult)
+}
+
+func (c *Client) ListOutputs(ctx context.Context, args wallet.ListOutputsArgs, originator string) (*wallet.ListOutputsResult, error) {
+	return invoke(ctx, originator, c.client.ListOutputs, encodeListOutputsArgs(&args), (*decoder).decodeListOutputsResult)
+}
+
+func (c *Client) RelinquishOutput(ctx context.Context, args wallet.RelinquishOutputArgs, originator string) (*wallet.RelinquishOutputResult, error) {
+	return invoke(ctx, originator, c.client.RelinquishOutput, encodeRelinquishOutputArgs(&args), (*decoder).decodeRelinquishOutputResult)
+}
+
+func (c *Client) GetPublicKey(ctx context.Context, args wallet.GetPublicKeyArgs, originator string) (*wallet.GetPublicKeyResult, error) {
+	return invoke(ctx, originator, c.client.GetPublicKey, encodeGetPublicKeyArgs(&args), (*decoder).decodeGetPublicKeyResult)
+}
+
+func (c *Client) RevealCounterpartyKeyLinkage(ctx context.Context, args wallet.RevealCounterpartyKeyLinkageArgs, originator string) (*wallet.RevealCounterpartyKeyLinkageResult, error) {
+	return invoke(ctx, originator, c.client.RevealCounterpartyKeyLinkage, encodeRevealCounterpartyKeyLinkageArgs(&args), (*decoder).decodeRevealCounterpartyKeyLinkageResult)
+}
+
+func (c *Client) RevealSpecificKeyLinkage(ctx context.Context, args wallet.RevealSpecificKeyLinkageArgs, originator string) (*wallet.RevealSpecificKeyLinkageResult, error) {
+	return invoke(ctx, originator, c.client.RevealSpecificKeyLinkage, encodeRevealSpecificKeyLinkageArgs(&args), (*decoder).decodeRevealSpecificKeyLinkageResult)
+}
+
+func (c *Client) Encrypt(ctx context.Context, args wallet.EncryptArgs, originator string) (*wallet.EncryptResult, error) {
+	return invoke(ctx, originator, c.client.Encrypt, encodeEncryptArgs(&args), (*decoder).decodeEncryptResult)
+}
+
+func (c *Client) Decrypt(ctx context.Context, args wallet.DecryptArgs, originator string) (*wallet.DecryptResult, error) {
+	return invoke(ctx, originator, c.client.Decrypt, encodeDecryptArgs(&args), (*decoder).decodeDecryptResult)
+}
+
+func (c *Client) CreateHMAC(ctx context.Context, args wallet.CreateHMACArgs, originator string) (*wallet.CreateHMACResult, error) {
+	return invoke(ctx, originator, c.client.CreateHMAC, encodeCreateHMACArgs(&args), (*decoder).decodeCreateHMACResult)
+}
+
+func (c *Client) VerifyHMAC(ctx context.Context, args wallet.VerifyHMACArgs, originator string) (*wallet.VerifyHMACResult, error) {
+	return invoke(ctx, originator, c.client.VerifyHMAC, encodeVerifyHMACArgs(&args), (*decoder).decodeVerifyHMACResult)
+}
+
+func (c *Client) CreateSignature(ctx context.Context, args wallet.CreateSignatureArgs, originator string) (*wallet.CreateSignatureResult, error) {
+	return invoke(ctx, originator, c.client.CreateSignature, encodeCreateSignatureArgs(&args), (*decoder).decodeCreateSignatureResult)
+}
+
+func (c *Client) VerifySignature(ctx context.Context, args wallet.VerifySignatureArgs, originator string) (*wallet.VerifySignatureResult, error) {
+	return invoke(ctx, originator, c.client.VerifySignature, encodeVerifySignatureArgs(&args), (*decoder).decodeVerifySignatureResult)
+}
+
+func (c *Client) AcquireCertificate(ctx context.Context, args wallet.AcquireCertificateArgs, originator string) (*wallet.Certificate, error) {
+	return invoke(ctx, originator, c.client.AcquireCertificate, encodeAcquireCertificateArgs(&args), (*decoder).decodeCertificate)
+}
+
+func (c *Client) ListCertificates(ctx context.Context, args wallet.ListCertificatesArgs, originator string) (*wallet.ListCertificatesResult, error) {
+	return invoke(ctx, originator, c.client.ListCertificates, encodeListCertificatesArgs(&args), (*decoder).decodeListCertificatesResult)
+}
+
+func (c *Client) ProveCertificate(ctx context.Context, args wallet.ProveCertificateArgs, originator string) (*wallet.ProveCertificateResult, error) {
+	return invoke(ctx, originator, c.client.ProveCertificate, encodeProveCertificateArgs(&args), (*decoder).decodeProveCertificateResult)
+}
+
+func (c *Client) RelinquishCertificate(ctx context.Context, args wallet.RelinquishCertificateArgs, originator string) (*wallet.RelinquishCertificateResult, error) {
+	return invoke(ctx, originator, c.client.RelinquishCertificate, encodeRelinquishCertificateArgs(&args), (*decoder).decodeRelinquishCertificateResult)
+}
+
+func (c *Client) DiscoverByIdentityKey(ctx context.Context, args wallet.DiscoverByIdentityKeyArgs, originator string) (*wallet.DiscoverCertificatesResult, error) {
+	return invoke(ctx, originator, c.client.DiscoverByIdentityKey, encodeDiscoverByIdentityKeyArgs(&args), (*decoder).decodeDiscoverCertificatesResult)
+}
+
+func (c *Client) DiscoverByAttributes(ctx context.Context, args wallet.DiscoverByAttributesArgs, originator string) (*wallet.DiscoverCertificatesResult, error) {
+	return invoke(ctx, originator, c.client.DiscoverByAttributes, encodeDiscoverByAttributesArgs(&args), (*decoder).decodeDiscoverCertificatesResult)
+}
+
+func (c *Client) IsAuthenticated(ctx context.Context, _ any, originator string) (*wallet.AuthenticatedResult, error) {
+	return invoke(ctx, originator, c.client.IsAuthenticated, &emptypb.Empty{}, (*decoder).decodeAuthenticatedResult)
+}
+
+func (c *Client) WaitForAuthentication(ctx context.Context, _ any, originator string) (*wallet.AuthenticatedResult, error) {
+	return invoke(ctx, originator, c.client.WaitForAuthentication, &emptypb.Empty{}, (*decoder).decodeAuthenticatedResult)
+}
+
+func (c *Client) GetHeight(ctx context.Context, _ any, originator string) (*wallet.GetHeightResult, error) {
+	return invoke(ctx, originator, c.client.GetHeight, &emptypb.Empty{}, (*decoder).decodeGetHeightResult)
+}
+
+func (c *Client) GetHeaderForHeight(ctx context.Context, args wallet.GetHeaderArgs, originator string) (*wallet.GetHeaderResult, error) {
+	return invoke(ctx, originator, c.client.GetHeaderForHeight, encodeGetHeaderArgs(&args), (*decoder).decodeGetHeaderResult)
+}
+
+func (c *Client) GetNetwork(ctx context.Context, _ any, originator string) (*wallet.GetNetworkResult, error) {
+	return invoke(ctx, originator, c.client.GetNetwork, &emptypb.Empty{}, (*decoder).decodeGetNetworkResult)
+}
+
+func (c *Client) GetVersion(ctx context.Context, _ any, originator string) (*wallet.GetVersionResult, error) {
+	return invoke(ctx, originator, c.client.GetVersion, &emptypb.Empty{}, (*decoder).decodeGetVersionResult)
+}
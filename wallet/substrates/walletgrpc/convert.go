@@ -0,0 +1,1264 @@
+package walletgrpc
+
+import (
+	"fmt"
+
+	"github.com/bsv-blockchain/go-sdk/chainhash"
+	ec "github.com/bsv-blockchain/go-sdk/primitives/ec"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+	sighash "github.com/bsv-blockchain/go-sdk/transaction/sighash"
+	"github.com/bsv-blockchain/go-sdk/wallet"
+	"github.com/bsv-blockchain/go-sdk/wallet/substrates/walletgrpc/walletpb"
+)
+
+// Encoding wallet types into protobuf messages cannot fail, so the encode
+// functions return the message alone. Decoding can, for example on malformed
+// public keys, so the decode methods record the first failure in a decoder,
+// which is checked once the whole message is decoded.
+
+type decoder struct {
+	err error
+}
+
+func (d *decoder) fail(format string, args ...any) {
+	if d.err == nil {
+		d.err = fmt.Errorf(format, args...)
+	}
+}
+
+func encodeAll[T, M any](items []T, encode func(*T) M) []M {
+	if items == nil {
+		return nil
+	}
+	out := make([]M, len(items))
+	for i := range items {
+		out[i] = encode(&items[i])
+	}
+	return out
+}
+
+func decodeAll[M, T any](d *decoder, items []M, decode func(*decoder, M) T) []T {
+	if len(items) == 0 {
+		return nil
+	}
+	out := make([]T, len(items))
+	for i, item := range items {
+		out[i] = decode(d, item)
+	}
+	return out
+}
+
+func encodePublicKey(key *ec.PublicKey) []byte {
+	if key == nil {
+		return nil
+	}
+	return key.Compressed()
+}
+
+func (d *decoder) publicKey(b []byte) *ec.PublicKey {
+	if len(b) == 0 {
+		return nil
+	}
+	key, err := ec.PublicKeyFromBytes(b)
+	if err != nil {
+		d.fail("invalid public key: %w", err)
+	}
+	return key
+}
+
+func encodeSignature(sig *ec.Signature) []byte {
+	if sig == nil {
+		return nil
+	}
+	return sig.Serialize()
+}
+
+func (d *decoder) signature(b []byte) *ec.Signature {
+	if len(b) == 0 {
+		return nil
+	}
+	sig, err := ec.FromDER(b)
+	if err != nil {
+		d.fail("invalid signature: %w", err)
+	}
+	return sig
+}
+
+// bytes32 decodes txids and other 32 byte values. Empty bytes decode to zeros.
+func (d *decoder) bytes32(b []byte) (out [32]byte) {
+	if len(b) != 0 && len(b) != 32 {
+		d.fail("invalid length of %d bytes, want 32", len(b))
+		return out
+	}
+	copy(out[:], b)
+	return out
+}
+
+func encodeHashes(hashes []chainhash.Hash) [][]byte {
+	return encodeAll(hashes, func(h *chainhash.Hash) []byte { return h[:] })
+}
+
+func (d *decoder) hashes(b [][]byte) []chainhash.Hash {
+	return decodeAll(d, b, func(d *decoder, b []byte) chainhash.Hash { return d.bytes32(b) })
+}
+
+func encodeOutpoint(o *transaction.Outpoint) *walletpb.Outpoint {
+	if o == nil {
+		return nil
+	}
+	return &walletpb.Outpoint{Txid: o.Txid[:], Index: o.Index}
+}
+
+func (d *decoder) decodeOutpoint(m *walletpb.Outpoint) transaction.Outpoint {
+	return transaction.Outpoint{Txid: d.bytes32(m.GetTxid()), Index: m.GetIndex()}
+}
+
+func (d *decoder) outpointPtr(m *walletpb.Outpoint) *transaction.Outpoint {
+	if m == nil {
+		return nil
+	}
+	o := d.decodeOutpoint(m)
+	return &o
+}
+
+func encodeProtocol(p *wallet.Protocol) *walletpb.Protocol {
+	return &walletpb.Protocol{SecurityLevel: int32(p.SecurityLevel), Protocol: p.Protocol}
+}
+
+func (d *decoder) decodeProtocol(m *walletpb.Protocol) wallet.Protocol {
+	return wallet.Protocol{SecurityLevel: wallet.SecurityLevel(m.GetSecurityLevel()), Protocol: m.GetProtocol()}
+}
+
+func encodeCounterparty(c *wallet.Counterparty) *walletpb.Counterparty {
+	return &walletpb.Counterparty{
+		Type:         walletpb.CounterpartyType(c.Type),
+		Counterparty: encodePublicKey(c.Counterparty),
+	}
+}
+
+func (d *decoder) decodeCounterparty(m *walletpb.Counterparty) wallet.Counterparty {
+	return wallet.Counterparty{
+		Type:         wallet.CounterpartyType(m.GetType()),
+		Counterparty: d.publicKey(m.GetCounterparty()),
+	}
+}
+
+func encodeEncryptionArgs(a *wallet.EncryptionArgs) *walletpb.EncryptionArgs {
+	return &walletpb.EncryptionArgs{
+		ProtocolId:       encodeProtocol(&a.ProtocolID),
+		KeyId:            a.KeyID,
+		Counterparty:     encodeCounterparty(&a.Counterparty),
+		Privileged:       a.Privileged,
+		PrivilegedReason: a.PrivilegedReason,
+		SeekPermission:   a.SeekPermission,
+	}
+}
+
+func (d *decoder) decodeEncryptionArgs(m *walletpb.EncryptionArgs) wallet.EncryptionArgs {
+	return wallet.EncryptionArgs{
+		ProtocolID:       d.decodeProtocol(m.GetProtocolId()),
+		KeyID:            m.GetKeyId(),
+		Counterparty:     d.decodeCounterparty(m.GetCounterparty()),
+		Privileged:       m.GetPrivileged(),
+		PrivilegedReason: m.GetPrivilegedReason(),
+		SeekPermission:   m.GetSeekPermission(),
+	}
+}
+
+// Key operations
+
+func encodeGetPublicKeyArgs(a *wallet.GetPublicKeyArgs) *walletpb.GetPublicKeyArgs {
+	return &walletpb.GetPublicKeyArgs{
+		EncryptionArgs: encodeEncryptionArgs(&a.EncryptionArgs),
+		IdentityKey:    a.IdentityKey,
+		ForSelf:        a.ForSelf,
+	}
+}
+
+func (d *decoder) decodeGetPublicKeyArgs(m *walletpb.GetPublicKeyArgs) wallet.GetPublicKeyArgs {
+	return wallet.GetPublicKeyArgs{
+		EncryptionArgs: d.decodeEncryptionArgs(m.GetEncryptionArgs()),
+		IdentityKey:    m.GetIdentityKey(),
+		ForSelf:        m.ForSelf,
+	}
+}
+
+func encodeGetPublicKeyResult(r *wallet.GetPublicKeyResult) *walletpb.GetPublicKeyResult {
+	return &walletpb.GetPublicKeyResult{PublicKey: encodePublicKey(r.PublicKey)}
+}
+
+func (d *decoder) decodeGetPublicKeyResult(m *walletpb.GetPublicKeyResult) wallet.GetPublicKeyResult {
+	return wallet.GetPublicKeyResult{PublicKey: d.publicKey(m.GetPublicKey())}
+}
+
+func encodeEncryptArgs(a *wallet.EncryptArgs) *walletpb.EncryptArgs {
+	return &walletpb.EncryptArgs{EncryptionArgs: encodeEncryptionArgs(&a.EncryptionArgs), Plaintext: a.Plaintext}
+}
+
+func (d *decoder) decodeEncryptArgs(m *walletpb.EncryptArgs) wallet.EncryptArgs {
+	return wallet.EncryptArgs{EncryptionArgs: d.decodeEncryptionArgs(m.GetEncryptionArgs()), Plaintext: m.GetPlaintext()}
+}
+
+func encodeEncryptResult(r *wallet.EncryptResult) *walletpb.EncryptResult {
+	return &walletpb.EncryptResult{Ciphertext: r.Ciphertext}
+}
+
+func (d *decoder) decodeEncryptResult(m *walletpb.EncryptResult) wallet.EncryptResult {
+	return wallet.EncryptResult{Ciphertext: m.GetCiphertext()}
+}
+
+func encodeDecryptArgs(a *wallet.DecryptArgs) *walletpb.DecryptArgs {
+	return &walletpb.DecryptArgs{EncryptionArgs: encodeEncryptionArgs(&a.EncryptionArgs), Ciphertext: a.Ciphertext}
+}
+
+func (d *decoder) decodeDecryptArgs(m *walletpb.DecryptArgs) wallet.DecryptArgs {
+	return wallet.DecryptArgs{EncryptionArgs: d.decodeEncryptionArgs(m.GetEncryptionArgs()), Ciphertext: m.GetCiphertext()}
+}
+
+func encodeDecryptResult(r *wallet.DecryptResult) *walletpb.DecryptResult {
+	return &walletpb.DecryptResult{Plaintext: r.Plaintext}
+}
+
+func (d *decoder) decodeDecryptResult(m *walletpb.DecryptResult) wallet.DecryptResult {
+	return wallet.DecryptResult{Plaintext: m.GetPlaintext()}
+}
+
+func encodeCreateHMACArgs(a *wallet.CreateHMACArgs) *walletpb.CreateHMACArgs {
+	return &walletpb.CreateHMACArgs{EncryptionArgs: encodeEncryptionArgs(&a.EncryptionArgs), Data: a.Data}
+}
+
+func (d *decoder) decodeCreateHMACArgs(m *walletpb.CreateHMACArgs) wallet.CreateHMACArgs {
+	return wallet.CreateHMACArgs{EncryptionArgs: d.decodeEncryptionArgs(m.GetEncryptionArgs()), Data: m.GetData()}
+}
+
+func encodeCreateHMACResult(r *wallet.CreateHMACResult) *walletpb.CreateHMACResult {
+	return &walletpb.CreateHMACResult{Hmac: r.HMAC[:]}
+}
+
+func (d *decoder) decodeCreateHMACResult(m *walletpb.CreateHMACResult) wallet.CreateHMACResult {
+	return wallet.CreateHMACResult{HMAC: d.bytes32(m.GetHmac())}
+}
+
+func encodeVerifyHMACArgs(a *wallet.VerifyHMACArgs) *walletpb.VerifyHMACArgs {
+	return &walletpb.VerifyHMACArgs{EncryptionArgs: encodeEncryptionArgs(&a.EncryptionArgs), Data: a.Data, Hmac: a.HMAC[:]}
+}
+
+func (d *decoder) decodeVerifyHMACArgs(m *walletpb.VerifyHMACArgs) wallet.VerifyHMACArgs {
+	return wallet.VerifyHMACArgs{
+		EncryptionArgs: d.decodeEncryptionArgs(m.GetEncryptionArgs()),
+		Data:           m.GetData(),
+		HMAC:           d.bytes32(m.GetHmac()),
+	}
+}
+
+func encodeVerifyHMACResult(r *wallet.VerifyHMACResult) *walletpb.VerifyHMACResult {
+	return &walletpb.VerifyHMACResult{Valid: r.Valid}
+}
+
+func (d *decoder) decodeVerifyHMACResult(m *walletpb.VerifyHMACResult) wallet.VerifyHMACResult {
+	return wallet.VerifyHMACResult{Valid: m.GetValid()}
+}
+
+func encodeCreateSignatureArgs(a *wallet.CreateSignatureArgs) *walletpb.CreateSignatureArgs {
+	m := &walletpb.CreateSignatureArgs{
+		EncryptionArgs:     encodeEncryptionArgs(&a.EncryptionArgs),
+		Data:               a.Data,
+		HashToDirectlySign: a.HashToDirectlySign,
+	}
+	if c := a.SighashContext; c != nil {
+		m.SighashContext = &walletpb.SighashContext{
+			Tx:                  c.Tx,
+			InputIndex:          c.InputIndex,
+			SourceSatoshis:      c.SourceSatoshis,
+			SourceLockingScript: c.SourceLockingScript,
+			SighashFlag:         uint32(c.SighashFlag),
+		}
+	}
+	return m
+}
+
+func (d *decoder) decodeCreateSignatureArgs(m *walletpb.CreateSignatureArgs) wallet.CreateSignatureArgs {
+	a := wallet.CreateSignatureArgs{
+		EncryptionArgs:     d.decodeEncryptionArgs(m.GetEncryptionArgs()),
+		Data:               m.GetData(),
+		HashToDirectlySign: m.GetHashToDirectlySign(),
+	}
+	if c := m.GetSighashContext(); c != nil {
+		a.SighashContext = &wallet.SighashContext{
+			Tx:                  c.GetTx(),
+			InputIndex:          c.GetInputIndex(),
+			SourceSatoshis:      c.GetSourceSatoshis(),
+			SourceLockingScript: c.GetSourceLockingScript(),
+			SighashFlag:         sighash.Flag(c.GetSighashFlag()),
+		}
+	}
+	return a
+}
+
+func encodeCreateSignatureResult(r *wallet.CreateSignatureResult) *walletpb.CreateSignatureResult {
+	return &walletpb.CreateSignatureResult{Signature: encodeSignature(r.Signature)}
+}
+
+func (d *decoder) decodeCreateSignatureResult(m *walletpb.CreateSignatureResult) wallet.CreateSignatureResult {
+	return wallet.CreateSignatureResult{Signature: d.signature(m.GetSignature())}
+}
+
+func encodeVerifySignatureArgs(a *wallet.VerifySignatureArgs) *walletpb.VerifySignatureArgs {
+	return &walletpb.VerifySignatureArgs{
+		EncryptionArgs:       encodeEncryptionArgs(&a.EncryptionArgs),
+		Data:                 a.Data,
+		HashToDirectlyVerify: a.HashToDirectlyVerify,
+		Signature:            encodeSignature(a.Signature),
+		ForSelf:              a.ForSelf,
+	}
+}
+
+func (d *decoder) decodeVerifySignatureArgs(m *walletpb.VerifySignatureArgs) wallet.VerifySignatureArgs {
+	return wallet.VerifySignatureArgs{
+		EncryptionArgs:       d.decodeEncryptionArgs(m.GetEncryptionArgs()),
+		Data:                 m.GetData(),
+		HashToDirectlyVerify: m.GetHashToDirectlyVerify(),
+		Signature:            d.signature(m.GetSignature()),
+		ForSelf:              m.ForSelf,
+	}
+}
+
+func encodeVerifySignatureResult(r *wallet.VerifySignatureResult) *walletpb.VerifySignatureResult {
+	return &walletpb.VerifySignatureResult{Valid: r.Valid}
+}
+
+func (d *decoder) decodeVerifySignatureResult(m *walletpb.VerifySignatureResult) wallet.VerifySignatureResult {
+	return wallet.VerifySignatureResult{Valid: m.GetValid()}
+}
+
+// Actions
+
+func encodeCreateActionInput(in *wallet.CreateActionInput) *walletpb.CreateActionInput {
+	return &walletpb.CreateActionInput{
+		Outpoint:              encodeOutpoint(&in.Outpoint),
+		InputDescription:      in.InputDescription,
+		UnlockingScript:       in.UnlockingScript,
+		UnlockingScriptLength: in.UnlockingScriptLength,
+		SequenceNumber:        in.SequenceNumber,
+	}
+}
+
+func (d *decoder) decodeCreateActionInput(m *walletpb.CreateActionInput) wallet.CreateActionInput {
+	return wallet.CreateActionInput{
+		Outpoint:              d.decodeOutpoint(m.GetOutpoint()),
+		InputDescription:      m.GetInputDescription(),
+		UnlockingScript:       m.GetUnlockingScript(),
+		UnlockingScriptLength: m.GetUnlockingScriptLength(),
+		SequenceNumber:        m.SequenceNumber,
+	}
+}
+
+func encodeCreateActionOutput(out *wallet.CreateActionOutput) *walletpb.CreateActionOutput {
+	return &walletpb.CreateActionOutput{
+		LockingScript:      out.LockingScript,
+		Satoshis:           out.Satoshis,
+		OutputDescription:  out.OutputDescription,
+		Basket:             out.Basket,
+		CustomInstructions: out.CustomInstructions,
+		Tags:               out.Tags,
+	}
+}
+
+func (d *decoder) decodeCreateActionOutput(m *walletpb.CreateActionOutput) wallet.CreateActionOutput {
+	return wallet.CreateActionOutput{
+		LockingScript:      m.GetLockingScript(),
+		Satoshis:           m.GetSatoshis(),
+		OutputDescription:  m.GetOutputDescription(),
+		Basket:             m.GetBasket(),
+		CustomInstructions: m.GetCustomInstructions(),
+		Tags:               m.GetTags(),
+	}
+}
+
+func encodeCreateActionOptions(o *wallet.CreateActionOptions) *walletpb.CreateActionOptions {
+	if o == nil {
+		return nil
+	}
+	m := &walletpb.CreateActionOptions{
+		SignAndProcess:         o.SignAndProcess,
+		AcceptDelayedBroadcast: o.AcceptDelayedBroadcast,
+		TrustSelf:              string(o.TrustSelf),
+		KnownTxids:             encodeHashes(o.KnownTxids),
+		ReturnTxidOnly:         o.ReturnTXIDOnly,
+		NoSend:                 o.NoSend,
+		NoSendChange:           encodeAll(o.NoSendChange, encodeOutpoint),
+		SendWith:               encodeHashes(o.SendWith),
+		RandomizeOutputs:       o.RandomizeOutputs,
+	}
+	if s := o.ChangeStrategy; s != nil {
+		m.ChangeStrategy = &walletpb.ChangeStrategy{Type: string(s.Type), Count: s.Count, Denominations: s.Denominations}
+	}
+	return m
+}
+
+func (d *decoder) decodeCreateActionOptions(m *walletpb.CreateActionOptions) *wallet.CreateActionOptions {
+	if m == nil {
+		return nil
+	}
+	o := &wallet.CreateActionOptions{
+		SignAndProcess:         m.SignAndProcess,
+		AcceptDelayedBroadcast: m.AcceptDelayedBroadcast,
+		TrustSelf:              wallet.TrustSelf(m.GetTrustSelf()),
+		KnownTxids:             d.hashes(m.GetKnownTxids()),
+		ReturnTXIDOnly:         m.ReturnTxidOnly,
+		NoSend:                 m.NoSend,
+		NoSendChange:           decodeAll(d, m.GetNoSendChange(), (*decoder).decodeOutpoint),
+		SendWith:               d.hashes(m.GetSendWith()),
+		RandomizeOutputs:       m.RandomizeOutputs,
+	}
+	if s := m.GetChangeStrategy(); s != nil {
+		o.ChangeStrategy = &wallet.ChangeStrategy{
+			Type:          wallet.ChangeStrategyType(s.GetType()),
+			Count:         s.GetCount(),
+			Denominations: s.GetDenominations(),
+		}
+	}
+	return o
+}
+
+func encodeCreateActionArgs(a *wallet.CreateActionArgs) *walletpb.CreateActionArgs {
+	return &walletpb.CreateActionArgs{
+		Description: a.Description,
+		InputBeef:   a.InputBEEF,
+		Inputs:      encodeAll(a.Inputs, encodeCreateActionInput),
+		Outputs:     encodeAll(a.Outputs, encodeCreateActionOutput),
+		LockTime:    a.LockTime,
+		Version:     a.Version,
+		Labels:      a.Labels,
+		Options:     encodeCreateActionOptions(a.Options),
+	}
+}
+
+func (d *decoder) decodeCreateActionArgs(m *walletpb.CreateActionArgs) wallet.CreateActionArgs {
+	return wallet.CreateActionArgs{
+		Description: m.GetDescription(),
+		InputBEEF:   m.GetInputBeef(),
+		Inputs:      decodeAll(d, m.GetInputs(), (*decoder).decodeCreateActionInput),
+		Outputs:     decodeAll(d, m.GetOutputs(), (*decoder).decodeCreateActionOutput),
+		LockTime:    m.LockTime,
+		Version:     m.Version,
+		Labels:      m.GetLabels(),
+		Options:     d.decodeCreateActionOptions(m.GetOptions()),
+	}
+}
+
+func encodeSendWithResult(r *wallet.SendWithResult) *walletpb.SendWithResult {
+	return &walletpb.SendWithResult{Txid: r.Txid[:], Status: string(r.Status)}
+}
+
+func (d *decoder) decodeSendWithResult(m *walletpb.SendWithResult) wallet.SendWithResult {
+	return wallet.SendWithResult{Txid: d.bytes32(m.GetTxid()), Status: wallet.ActionResultStatus(m.GetStatus())}
+}
+
+func encodeCreateActionResult(r *wallet.CreateActionResult) *walletpb.CreateActionResult {
+	m := &walletpb.CreateActionResult{
+		Txid:            r.Txid[:],
+		Tx:              r.Tx,
+		NoSendChange:    encodeAll(r.NoSendChange, encodeOutpoint),
+		SendWithResults: encodeAll(r.SendWithResults, encodeSendWithResult),
+	}
+	if s := r.SignableTransaction; s != nil {
+		m.SignableTransaction = &walletpb.SignableTransaction{Tx: s.Tx, Reference: s.Reference}
+	}
+	return m
+}
+
+func (d *decoder) decodeCreateActionResult(m *walletpb.CreateActionResult) wallet.CreateActionResult {
+	r := wallet.CreateActionResult{
+		Txid:            d.bytes32(m.GetTxid()),
+		Tx:              m.GetTx(),
+		NoSendChange:    decodeAll(d, m.GetNoSendChange(), (*decoder).decodeOutpoint),
+		SendWithResults: decodeAll(d, m.GetSendWithResults(), (*decoder).decodeSendWithResult),
+	}
+	if s := m.GetSignableTransaction(); s != nil {
+		r.SignableTransaction = &wallet.SignableTransaction{Tx: s.GetTx(), Reference: s.GetReference()}
+	}
+	return r
+}
+
+func encodeSignActionArgs(a *wallet.SignActionArgs) *walletpb.SignActionArgs {
+	m := &walletpb.SignActionArgs{Reference: a.Reference}
+	if a.Spends != nil {
+		m.Spends = make(map[uint32]*walletpb.SignActionSpend, len(a.Spends))
+		for index, spend := range a.Spends {
+			m.Spends[index] = &walletpb.SignActionSpend{
+				UnlockingScript: spend.UnlockingScript,
+				SequenceNumber:  spend.SequenceNumber,
+			}
+		}
+	}
+	if o := a.Options; o != nil {
+		m.Options = &walletpb.SignActionOptions{
+			AcceptDelayedBroadcast: o.AcceptDelayedBroadcast,
+			ReturnTxidOnly:         o.ReturnTXIDOnly,
+			NoSend:                 o.NoSend,
+			SendWith:               encodeHashes(o.SendWith),
+		}
+	}
+	return m
+}
+
+func (d *decoder) decodeSignActionArgs(m *walletpb.SignActionArgs) wallet.SignActionArgs {
+	a := wallet.SignActionArgs{Reference: m.GetReference()}
+	if spends := m.GetSpends(); len(spends) > 0 {
+		a.Spends = make(map[uint32]wallet.SignActionSpend, len(spends))
+		for index, spend := range spends {
+			a.Spends[index] = wallet.SignActionSpend{
+				UnlockingScript: spend.GetUnlockingScript(),
+				SequenceNumber:  spend.SequenceNumber,
+			}
+		}
+	}
+	if o := m.GetOptions(); o != nil {
+		a.Options = &wallet.SignActionOptions{
+			AcceptDelayedBroadcast: o.AcceptDelayedBroadcast,
+			ReturnTXIDOnly:         o.ReturnTxidOnly,
+			NoSend:                 o.NoSend,
+			SendWith:               d.hashes(o.GetSendWith()),
+		}
+	}
+	return a
+}
+
+func encodeSignActionResult(r *wallet.SignActionResult) *walletpb.SignActionResult {
+	return &walletpb.SignActionResult{
+		Txid:            r.Txid[:],
+		Tx:              r.Tx,
+		SendWithResults: encodeAll(r.SendWithResults, encodeSendWithResult),
+	}
+}
+
+func (d *decoder) decodeSignActionResult(m *walletpb.SignActionResult) wallet.SignActionResult {
+	return wallet.SignActionResult{
+		Txid:            d.bytes32(m.GetTxid()),
+		Tx:              m.GetTx(),
+		SendWithResults: decodeAll(d, m.GetSendWithResults(), (*decoder).decodeSendWithResult),
+	}
+}
+
+func encodeAbortActionArgs(a *wallet.AbortActionArgs) *walletpb.AbortActionArgs {
+	return &walletpb.AbortActionArgs{Reference: a.Reference}
+}
+
+func (d *decoder) decodeAbortActionArgs(m *walletpb.AbortActionArgs) wallet.AbortActionArgs {
+	return wallet.AbortActionArgs{Reference: m.GetReference()}
+}
+
+func encodeAbortActionResult(r *wallet.AbortActionResult) *walletpb.AbortActionResult {
+	return &walletpb.AbortActionResult{Aborted: r.Aborted}
+}
+
+func (d *decoder) decodeAbortActionResult(m *walletpb.AbortActionResult) wallet.AbortActionResult {
+	return wallet.AbortActionResult{Aborted: m.GetAborted()}
+}
+
+func encodeListActionsArgs(a *wallet.ListActionsArgs) *walletpb.ListActionsArgs {
+	return &walletpb.ListActionsArgs{
+		Labels:                           a.Labels,
+		LabelQueryMode:                   string(a.LabelQueryMode),
+		IncludeLabels:                    a.IncludeLabels,
+		IncludeInputs:                    a.IncludeInputs,
+		IncludeInputSourceLockingScripts: a.IncludeInputSourceLockingScripts,
+		IncludeInputUnlockingScripts:     a.IncludeInputUnlockingScripts,
+		IncludeOutputs:                   a.IncludeOutputs,
+		IncludeOutputLockingScripts:      a.IncludeOutputLockingScripts,
+		Limit:                            a.Limit,
+		Offset:                           a.Offset,
+		SeekPermission:                   a.SeekPermission,
+		Cursor:                           a.Cursor,
+	}
+}
+
+func (d *decoder) decodeListActionsArgs(m *walletpb.ListActionsArgs) wallet.ListActionsArgs {
+	return wallet.ListActionsArgs{
+		Labels:                           m.GetLabels(),
+		LabelQueryMode:                   wallet.QueryMode(m.GetLabelQueryMode()),
+		IncludeLabels:                    m.IncludeLabels,
+		IncludeInputs:                    m.IncludeInputs,
+		IncludeInputSourceLockingScripts: m.IncludeInputSourceLockingScripts,
+		IncludeInputUnlockingScripts:     m.IncludeInputUnlockingScripts,
+		IncludeOutputs:                   m.IncludeOutputs,
+		IncludeOutputLockingScripts:      m.IncludeOutputLockingScripts,
+		Limit:                            m.Limit,
+		Offset:                           m.Offset,
+		SeekPermission:                   m.SeekPermission,
+		Cursor:                           m.GetCursor(),
+	}
+}
+
+func encodeActionInput(in *wallet.ActionInput) *walletpb.ActionInput {
+	return &walletpb.ActionInput{
+		SourceOutpoint:      encodeOutpoint(&in.SourceOutpoint),
+		SourceSatoshis:      in.SourceSatoshis,
+		SourceLockingScript: in.SourceLockingScript,
+		UnlockingScript:     in.UnlockingScript,
+		InputDescription:    in.InputDescription,
+		SequenceNumber:      in.SequenceNumber,
+	}
+}
+
+func (d *decoder) decodeActionInput(m *walletpb.ActionInput) wallet.ActionInput {
+	return wallet.ActionInput{
+		SourceOutpoint:      d.decodeOutpoint(m.GetSourceOutpoint()),
+		SourceSatoshis:      m.GetSourceSatoshis(),
+		SourceLockingScript: m.GetSourceLockingScript(),
+		UnlockingScript:     m.GetUnlockingScript(),
+		InputDescription:    m.GetInputDescription(),
+		SequenceNumber:      m.GetSequenceNumber(),
+	}
+}
+
+func encodeActionOutput(out *wallet.ActionOutput) *walletpb.ActionOutput {
+	return &walletpb.ActionOutput{
+		Satoshis:           out.Satoshis,
+		LockingScript:      out.LockingScript,
+		Spendable:          out.Spendable,
+		CustomInstructions: out.CustomInstructions,
+		Tags:               out.Tags,
+		OutputIndex:        out.OutputIndex,
+		OutputDescription:  out.OutputDescription,
+		Basket:             out.Basket,
+	}
+}
+
+func (d *decoder) decodeActionOutput(m *walletpb.ActionOutput) wallet.ActionOutput {
+	return wallet.ActionOutput{
+		Satoshis:           m.GetSatoshis(),
+		LockingScript:      m.GetLockingScript(),
+		Spendable:          m.GetSpendable(),
+		CustomInstructions: m.GetCustomInstructions(),
+		Tags:               m.GetTags(),
+		OutputIndex:        m.GetOutputIndex(),
+		OutputDescription:  m.GetOutputDescription(),
+		Basket:             m.GetBasket(),
+	}
+}
+
+func encodeAction(a *wallet.Action) *walletpb.Action {
+	return &walletpb.Action{
+		Txid:        a.Txid[:],
+		Satoshis:    a.Satoshis,
+		Status:      string(a.Status),
+		IsOutgoing:  a.IsOutgoing,
+		Description: a.Description,
+		Labels:      a.Labels,
+		Version:     a.Version,
+		LockTime:    a.LockTime,
+		Inputs:      encodeAll(a.Inputs, encodeActionInput),
+		Outputs:     encodeAll(a.Outputs, encodeActionOutput),
+	}
+}
+
+func (d *decoder) decodeAction(m *walletpb.Action) wallet.Action {
+	return wallet.Action{
+		Txid:        d.bytes32(m.GetTxid()),
+		Satoshis:    m.GetSatoshis(),
+		Status:      wallet.ActionStatus(m.GetStatus()),
+		IsOutgoing:  m.GetIsOutgoing(),
+		Description: m.GetDescription(),
+		Labels:      m.GetLabels(),
+		Version:     m.GetVersion(),
+		LockTime:    m.GetLockTime(),
+		Inputs:      decodeAll(d, m.GetInputs(), (*decoder).decodeActionInput),
+		Outputs:     decodeAll(d, m.GetOutputs(), (*decoder).decodeActionOutput),
+	}
+}
+
+func encodeListActionsResult(r *wallet.ListActionsResult) *walletpb.ListActionsResult {
+	return &walletpb.ListActionsResult{
+		TotalActions: r.TotalActions,
+		Actions:      encodeAll(r.Actions, encodeAction),
+		NextCursor:   r.NextCursor,
+	}
+}
+
+func (d *decoder) decodeListActionsResult(m *walletpb.ListActionsResult) wallet.ListActionsResult {
+	return wallet.ListActionsResult{
+		TotalActions: m.GetTotalActions(),
+		Actions:      decodeAll(d, m.GetActions(), (*decoder).decodeAction),
+		NextCursor:   m.GetNextCursor(),
+	}
+}
+
+func encodeInternalizeOutput(out *wallet.InternalizeOutput) *walletpb.InternalizeOutput {
+	m := &walletpb.InternalizeOutput{OutputIndex: out.OutputIndex, Protocol: string(out.Protocol)}
+	if p := out.PaymentRemittance; p != nil {
+		m.PaymentRemittance = &walletpb.Payment{
+			DerivationPrefix:  p.DerivationPrefix,
+			DerivationSuffix:  p.DerivationSuffix,
+			SenderIdentityKey: encodePublicKey(p.SenderIdentityKey),
+		}
+	}
+	if b := out.InsertionRemittance; b != nil {
+		m.InsertionRemittance = &walletpb.BasketInsertion{
+			Basket:             b.Basket,
+			CustomInstructions: b.CustomInstructions,
+			Tags:               b.Tags,
+		}
+	}
+	return m
+}
+
+func (d *decoder) decodeInternalizeOutput(m *walletpb.InternalizeOutput) wallet.InternalizeOutput {
+	out := wallet.InternalizeOutput{
+		OutputIndex: m.GetOutputIndex(),
+		Protocol:    wallet.InternalizeProtocol(m.GetProtocol()),
+	}
+	if p := m.GetPaymentRemittance(); p != nil {
+		out.PaymentRemittance = &wallet.Payment{
+			DerivationPrefix:  p.GetDerivationPrefix(),
+			DerivationSuffix:  p.GetDerivationSuffix(),
+			SenderIdentityKey: d.publicKey(p.GetSenderIdentityKey()),
+		}
+	}
+	if b := m.GetInsertionRemittance(); b != nil {
+		out.InsertionRemittance = &wallet.BasketInsertion{
+			Basket:             b.GetBasket(),
+			CustomInstructions: b.GetCustomInstructions(),
+			Tags:               b.GetTags(),
+		}
+	}
+	return out
+}
+
+func encodeInternalizeActionArgs(a *wallet.InternalizeActionArgs) *walletpb.InternalizeActionArgs {
+	return &walletpb.InternalizeActionArgs{
+		Tx:             a.Tx,
+		Description:    a.Description,
+		Labels:         a.Labels,
+		SeekPermission: a.SeekPermission,
+		Outputs:        encodeAll(a.Outputs, encodeInternalizeOutput),
+	}
+}
+
+func (d *decoder) decodeInternalizeActionArgs(m *walletpb.InternalizeActionArgs) wallet.InternalizeActionArgs {
+	return wallet.InternalizeActionArgs{
+		Tx:             m.GetTx(),
+		Description:    m.GetDescription(),
+		Labels:         m.GetLabels(),
+		SeekPermission: m.SeekPermission,
+		Outputs:        decodeAll(d, m.GetOutputs(), (*decoder).decodeInternalizeOutput),
+	}
+}
+
+func encodeInternalizeActionResult(r *wallet.InternalizeActionResult) *walletpb.InternalizeActionResult {
+	return &walletpb.InternalizeActionResult{Accepted: r.Accepted}
+}
+
+func (d *decoder) decodeInternalizeActionResult(m *walletpb.InternalizeActionResult) wallet.InternalizeActionResult {
+	return wallet.InternalizeActionResult{Accepted: m.GetAccepted()}
+}
+
+func encodeListOutputsArgs(a *wallet.ListOutputsArgs) *walletpb.ListOutputsArgs {
+	return &walletpb.ListOutputsArgs{
+		Basket:                    a.Basket,
+		Tags:                      a.Tags,
+		TagQueryMode:              string(a.TagQueryMode),
+		Include:                   string(a.Include),
+		IncludeCustomInstructions: a.IncludeCustomInstructions,
+		IncludeTags:               a.IncludeTags,
+		IncludeLabels:             a.IncludeLabels,
+		Limit:                     a.Limit,
+		Offset:                    a.Offset,
+		SeekPermission:            a.SeekPermission,
+		MinSatoshis:               a.MinSatoshis,
+		MaxSatoshis:               a.MaxSatoshis,
+		ScriptTypes:               a.ScriptTypes,
+		SortOrder:                 string(a.SortOrder),
+		Cursor:                    a.Cursor,
+	}
+}
+
+func (d *decoder) decodeListOutputsArgs(m *walletpb.ListOutputsArgs) wallet.ListOutputsArgs {
+	return wallet.ListOutputsArgs{
+		Basket:                    m.GetBasket(),
+		Tags:                      m.GetTags(),
+		TagQueryMode:              wallet.QueryMode(m.GetTagQueryMode()),
+		Include:                   wallet.OutputInclude(m.GetInclude()),
+		IncludeCustomInstructions: m.IncludeCustomInstructions,
+		IncludeTags:               m.IncludeTags,
+		IncludeLabels:             m.IncludeLabels,
+		Limit:                     m.Limit,
+		Offset:                    m.Offset,
+		SeekPermission:            m.SeekPermission,
+		MinSatoshis:               m.MinSatoshis,
+		MaxSatoshis:               m.MaxSatoshis,
+		ScriptTypes:               m.GetScriptTypes(),
+		SortOrder:                 wallet.OutputSortOrder(m.GetSortOrder()),
+		Cursor:                    m.GetCursor(),
+	}
+}
+
+func encodeOutput(out *wallet.Output) *walletpb.Output {
+	return &walletpb.Output{
+		Satoshis:           out.Satoshis,
+		LockingScript:      out.LockingScript,
+		Spendable:          out.Spendable,
+		CustomInstructions: out.CustomInstructions,
+		Tags:               out.Tags,
+		Outpoint:           encodeOutpoint(&out.Outpoint),
+		Labels:             out.Labels,
+	}
+}
+
+func (d *decoder) decodeOutput(m *walletpb.Output) wallet.Output {
+	return wallet.Output{
+		Satoshis:           m.GetSatoshis(),
+		LockingScript:      m.GetLockingScript(),
+		Spendable:          m.GetSpendable(),
+		CustomInstructions: m.GetCustomInstructions(),
+		Tags:               m.GetTags(),
+		Outpoint:           d.decodeOutpoint(m.GetOutpoint()),
+		Labels:             m.GetLabels(),
+	}
+}
+
+func encodeListOutputsResult(r *wallet.ListOutputsResult) *walletpb.ListOutputsResult {
+	return &walletpb.ListOutputsResult{
+		TotalOutputs: r.TotalOutputs,
+		Beef:         r.BEEF,
+		Outputs:      encodeAll(r.Outputs, encodeOutput),
+		NextCursor:   r.NextCursor,
+	}
+}
+
+func (d *decoder) decodeListOutputsResult(m *walletpb.ListOutputsResult) wallet.ListOutputsResult {
+	return wallet.ListOutputsResult{
+		TotalOutputs: m.GetTotalOutputs(),
+		BEEF:         m.GetBeef(),
+		Outputs:      decodeAll(d, m.GetOutputs(), (*decoder).decodeOutput),
+		NextCursor:   m.GetNextCursor(),
+	}
+}
+
+func encodeRelinquishOutputArgs(a *wallet.RelinquishOutputArgs) *walletpb.RelinquishOutputArgs {
+	return &walletpb.RelinquishOutputArgs{Basket: a.Basket, Output: encodeOutpoint(&a.Output)}
+}
+
+func (d *decoder) decodeRelinquishOutputArgs(m *walletpb.RelinquishOutputArgs) wallet.RelinquishOutputArgs {
+	return wallet.RelinquishOutputArgs{Basket: m.GetBasket(), Output: d.decodeOutpoint(m.GetOutput())}
+}
+
+func encodeRelinquishOutputResult(r *wallet.RelinquishOutputResult) *walletpb.RelinquishOutputResult {
+	return &walletpb.RelinquishOutputResult{Relinquished: r.Relinquished}
+}
+
+func (d *decoder) decodeRelinquishOutputResult(m *walletpb.RelinquishOutputResult) wallet.RelinquishOutputResult {
+	return wallet.RelinquishOutputResult{Relinquished: m.GetRelinquished()}
+}
+
+// Key linkage
+
+func encodeRevealCounterpartyKeyLinkageArgs(a *wallet.RevealCounterpartyKeyLinkageArgs) *walletpb.RevealCounterpartyKeyLinkageArgs {
+	return &walletpb.RevealCounterpartyKeyLinkageArgs{
+		Counterparty:     encodePublicKey(a.Counterparty),
+		Verifier:         encodePublicKey(a.Verifier),
+		Privileged:       a.Privileged,
+		PrivilegedReason: a.PrivilegedReason,
+	}
+}
+
+func (d *decoder) decodeRevealCounterpartyKeyLinkageArgs(m *walletpb.RevealCounterpartyKeyLinkageArgs) wallet.RevealCounterpartyKeyLinkageArgs {
+	return wallet.RevealCounterpartyKeyLinkageArgs{
+		Counterparty:     d.publicKey(m.GetCounterparty()),
+		Verifier:         d.publicKey(m.GetVerifier()),
+		Privileged:       m.Privileged,
+		PrivilegedReason: m.GetPrivilegedReason(),
+	}
+}
+
+func encodeRevealCounterpartyKeyLinkageResult(r *wallet.RevealCounterpartyKeyLinkageResult) *walletpb.RevealCounterpartyKeyLinkageResult {
+	return &walletpb.RevealCounterpartyKeyLinkageResult{
+		Prover:                encodePublicKey(r.Prover),
+		Counterparty:          encodePublicKey(r.Counterparty),
+		Verifier:              encodePublicKey(r.Verifier),
+		RevelationTime:        r.RevelationTime,
+		EncryptedLinkage:      r.EncryptedLinkage,
+		EncryptedLinkageProof: r.EncryptedLinkageProof,
+	}
+}
+
+func (d *decoder) decodeRevealCounterpartyKeyLinkageResult(m *walletpb.RevealCounterpartyKeyLinkageResult) wallet.RevealCounterpartyKeyLinkageResult {
+	return wallet.RevealCounterpartyKeyLinkageResult{
+		Prover:                d.publicKey(m.GetProver()),
+		Counterparty:          d.publicKey(m.GetCounterparty()),
+		Verifier:              d.publicKey(m.GetVerifier()),
+		RevelationTime:        m.GetRevelationTime(),
+		EncryptedLinkage:      m.GetEncryptedLinkage(),
+		EncryptedLinkageProof: m.GetEncryptedLinkageProof(),
+	}
+}
+
+func encodeRevealSpecificKeyLinkageArgs(a *wallet.RevealSpecificKeyLinkageArgs) *walletpb.RevealSpecificKeyLinkageArgs {
+	return &walletpb.RevealSpecificKeyLinkageArgs{
+		Counterparty:     encodeCounterparty(&a.Counterparty),
+		Verifier:         encodePublicKey(a.Verifier),
+		ProtocolId:       encodeProtocol(&a.ProtocolID),
+		KeyId:            a.KeyID,
+		Privileged:       a.Privileged,
+		PrivilegedReason: a.PrivilegedReason,
+	}
+}
+
+func (d *decoder) decodeRevealSpecificKeyLinkageArgs(m *walletpb.RevealSpecificKeyLinkageArgs) wallet.RevealSpecificKeyLinkageArgs {
+	return wallet.RevealSpecificKeyLinkageArgs{
+		Counterparty:     d.decodeCounterparty(m.GetCounterparty()),
+		Verifier:         d.publicKey(m.GetVerifier()),
+		ProtocolID:       d.decodeProtocol(m.GetProtocolId()),
+		KeyID:            m.GetKeyId(),
+		Privileged:       m.Privileged,
+		PrivilegedReason: m.GetPrivilegedReason(),
+	}
+}
+
+func encodeRevealSpecificKeyLinkageResult(r *wallet.RevealSpecificKeyLinkageResult) *walletpb.RevealSpecificKeyLinkageResult {
+	return &walletpb.RevealSpecificKeyLinkageResult{
+		EncryptedLinkage:      r.EncryptedLinkage,
+		EncryptedLinkageProof: r.EncryptedLinkageProof,
+		Prover:                encodePublicKey(r.Prover),
+		Verifier:              encodePublicKey(r.Verifier),
+		Counterparty:          encodePublicKey(r.Counterparty),
+		ProtocolId:            encodeProtocol(&r.ProtocolID),
+		KeyId:                 r.KeyID,
+		ProofType:             uint32(r.ProofType),
+	}
+}
+
+func (d *decoder) decodeRevealSpecificKeyLinkageResult(m *walletpb.RevealSpecificKeyLinkageResult) wallet.RevealSpecificKeyLinkageResult {
+	return wallet.RevealSpecificKeyLinkageResult{
+		EncryptedLinkage:      m.GetEncryptedLinkage(),
+		EncryptedLinkageProof: m.GetEncryptedLinkageProof(),
+		Prover:                d.publicKey(m.GetProver()),
+		Verifier:              d.publicKey(m.GetVerifier()),
+		Counterparty:          d.publicKey(m.GetCounterparty()),
+		ProtocolID:            d.decodeProtocol(m.GetProtocolId()),
+		KeyID:                 m.GetKeyId(),
+		ProofType:             byte(m.GetProofType()),
+	}
+}
+
+// Certificates
+
+func encodeCertificate(c *wallet.Certificate) *walletpb.Certificate {
+	return &walletpb.Certificate{
+		Type:               c.Type[:],
+		SerialNumber:       c.SerialNumber[:],
+		Subject:            encodePublicKey(c.Subject),
+		Certifier:          encodePublicKey(c.Certifier),
+		RevocationOutpoint: encodeOutpoint(c.RevocationOutpoint),
+		Fields:             c.Fields,
+		Signature:          encodeSignature(c.Signature),
+	}
+}
+
+func (d *decoder) decodeCertificate(m *walletpb.Certificate) wallet.Certificate {
+	return wallet.Certificate{
+		Type:               d.bytes32(m.GetType()),
+		SerialNumber:       d.bytes32(m.GetSerialNumber()),
+		Subject:            d.publicKey(m.GetSubject()),
+		Certifier:          d.publicKey(m.GetCertifier()),
+		RevocationOutpoint: d.outpointPtr(m.GetRevocationOutpoint()),
+		Fields:             m.GetFields(),
+		Signature:          d.signature(m.GetSignature()),
+	}
+}
+
+func encodeAcquireCertificateArgs(a *wallet.AcquireCertificateArgs) *walletpb.AcquireCertificateArgs {
+	m := &walletpb.AcquireCertificateArgs{
+		Type:                a.Type[:],
+		Certifier:           encodePublicKey(a.Certifier),
+		AcquisitionProtocol: string(a.AcquisitionProtocol),
+		Fields:              a.Fields,
+		RevocationOutpoint:  encodeOutpoint(a.RevocationOutpoint),
+		Signature:           encodeSignature(a.Signature),
+		CertifierUrl:        a.CertifierUrl,
+		KeyringForSubject:   a.KeyringForSubject,
+		Privileged:          a.Privileged,
+		PrivilegedReason:    a.PrivilegedReason,
+	}
+	if a.SerialNumber != nil {
+		m.SerialNumber = a.SerialNumber[:]
+	}
+	switch r := a.KeyringRevealer; {
+	case r == nil:
+	case r.Certifier:
+		m.KeyringRevealer = &walletpb.KeyringRevealer{Revealer: &walletpb.KeyringRevealer_Certifier{Certifier: true}}
+	default:
+		m.KeyringRevealer = &walletpb.KeyringRevealer{Revealer: &walletpb.KeyringRevealer_PublicKey{PublicKey: encodePublicKey(r.PubKey)}}
+	}
+	return m
+}
+
+func (d *decoder) decodeAcquireCertificateArgs(m *walletpb.AcquireCertificateArgs) wallet.AcquireCertificateArgs {
+	a := wallet.AcquireCertificateArgs{
+		Type:                d.bytes32(m.GetType()),
+		Certifier:           d.publicKey(m.GetCertifier()),
+		AcquisitionProtocol: wallet.AcquisitionProtocol(m.GetAcquisitionProtocol()),
+		Fields:              m.GetFields(),
+		RevocationOutpoint:  d.outpointPtr(m.GetRevocationOutpoint()),
+		Signature:           d.signature(m.GetSignature()),
+		CertifierUrl:        m.GetCertifierUrl(),
+		KeyringForSubject:   m.GetKeyringForSubject(),
+		Privileged:          m.Privileged,
+		PrivilegedReason:    m.GetPrivilegedReason(),
+	}
+	if len(m.GetSerialNumber()) > 0 {
+		serialNumber := wallet.SerialNumber(d.bytes32(m.GetSerialNumber()))
+		a.SerialNumber = &serialNumber
+	}
+	switch r := m.GetKeyringRevealer().GetRevealer().(type) {
+	case *walletpb.KeyringRevealer_Certifier:
+		a.KeyringRevealer = &wallet.KeyringRevealer{Certifier: r.Certifier}
+	case *walletpb.KeyringRevealer_PublicKey:
+		a.KeyringRevealer = &wallet.KeyringRevealer{PubKey: d.publicKey(r.PublicKey)}
+	}
+	return a
+}
+
+func encodeListCertificatesArgs(a *wallet.ListCertificatesArgs) *walletpb.ListCertificatesArgs {
+	m := &walletpb.ListCertificatesArgs{
+		Limit:            a.Limit,
+		Offset:           a.Offset,
+		Privileged:       a.Privileged,
+		PrivilegedReason: a.PrivilegedReason,
+	}
+	for _, certifier := range a.Certifiers {
+		m.Certifiers = append(m.Certifiers, encodePublicKey(certifier))
+	}
+	for _, certType := range a.Types {
+		m.Types = append(m.Types, certType[:])
+	}
+	return m
+}
+
+func (d *decoder) decodeListCertificatesArgs(m *walletpb.ListCertificatesArgs) wallet.ListCertificatesArgs {
+	a := wallet.ListCertificatesArgs{
+		Limit:            m.Limit,
+		Offset:           m.Offset,
+		Privileged:       m.Privileged,
+		PrivilegedReason: m.GetPrivilegedReason(),
+	}
+	for _, certifier := range m.GetCertifiers() {
+		a.Certifiers = append(a.Certifiers, d.publicKey(certifier))
+	}
+	for _, certType := range m.GetTypes() {
+		a.Types = append(a.Types, d.bytes32(certType))
+	}
+	return a
+}
+
+func encodeCertificateResult(c *wallet.CertificateResult) *walletpb.CertificateResult {
+	return &walletpb.CertificateResult{
+		Certificate: encodeCertificate(&c.Certificate),
+		Keyring:     c.Keyring,
+		Verifier:    c.Verifier,
+	}
+}
+
+func (d *decoder) decodeCertificateResult(m *walletpb.CertificateResult) wallet.CertificateResult {
+	return wallet.CertificateResult{
+		Certificate: d.decodeCertificate(m.GetCertificate()),
+		Keyring:     m.GetKeyring(),
+		Verifier:    m.GetVerifier(),
+	}
+}
+
+func encodeListCertificatesResult(r *wallet.ListCertificatesResult) *walletpb.ListCertificatesResult {
+	return &walletpb.ListCertificatesResult{
+		TotalCertificates: r.TotalCertificates,
+		Certificates:      encodeAll(r.Certificates, encodeCertificateResult),
+	}
+}
+
+func (d *decoder) decodeListCertificatesResult(m *walletpb.ListCertificatesResult) wallet.ListCertificatesResult {
+	return wallet.ListCertificatesResult{
+		TotalCertificates: m.GetTotalCertificates(),
+		Certificates:      decodeAll(d, m.GetCertificates(), (*decoder).decodeCertificateResult),
+	}
+}
+
+func encodeProveCertificateArgs(a *wallet.ProveCertificateArgs) *walletpb.ProveCertificateArgs {
+	return &walletpb.ProveCertificateArgs{
+		Certificate:      encodeCertificate(&a.Certificate),
+		FieldsToReveal:   a.FieldsToReveal,
+		Verifier:         encodePublicKey(a.Verifier),
+		Privileged:       a.Privileged,
+		PrivilegedReason: a.PrivilegedReason,
+	}
+}
+
+func (d *decoder) decodeProveCertificateArgs(m *walletpb.ProveCertificateArgs) wallet.ProveCertificateArgs {
+	return wallet.ProveCertificateArgs{
+		Certificate:      d.decodeCertificate(m.GetCertificate()),
+		FieldsToReveal:   m.GetFieldsToReveal(),
+		Verifier:         d.publicKey(m.GetVerifier()),
+		Privileged:       m.Privileged,
+		PrivilegedReason: m.GetPrivilegedReason(),
+	}
+}
+
+func encodeProveCertificateResult(r *wallet.ProveCertificateResult) *walletpb.ProveCertificateResult {
+	return &walletpb.ProveCertificateResult{KeyringForVerifier: r.KeyringForVerifier}
+}
+
+func (d *decoder) decodeProveCertificateResult(m *walletpb.ProveCertificateResult) wallet.ProveCertificateResult {
+	return wallet.ProveCertificateResult{KeyringForVerifier: m.GetKeyringForVerifier()}
+}
+
+func encodeRelinquishCertificateArgs(a *wallet.RelinquishCertificateArgs) *walletpb.RelinquishCertificateArgs {
+	return &walletpb.RelinquishCertificateArgs{
+		Type:         a.Type[:],
+		SerialNumber: a.SerialNumber[:],
+		Certifier:    encodePublicKey(a.Certifier),
+	}
+}
+
+func (d *decoder) decodeRelinquishCertificateArgs(m *walletpb.RelinquishCertificateArgs) wallet.RelinquishCertificateArgs {
+	return wallet.RelinquishCertificateArgs{
+		Type:         d.bytes32(m.GetType()),
+		SerialNumber: d.bytes32(m.GetSerialNumber()),
+		Certifier:    d.publicKey(m.GetCertifier()),
+	}
+}
+
+func encodeRelinquishCertificateResult(r *wallet.RelinquishCertificateResult) *walletpb.RelinquishCertificateResult {
+	return &walletpb.RelinquishCertificateResult{Relinquished: r.Relinquished}
+}
+
+func (d *decoder) decodeRelinquishCertificateResult(m *walletpb.RelinquishCertificateResult) wallet.RelinquishCertificateResult {
+	return wallet.RelinquishCertificateResult{Relinquished: m.GetRelinquished()}
+}
+
+func encodeIdentityCertificate(c *wallet.IdentityCertificate) *walletpb.IdentityCertificate {
+	return &walletpb.IdentityCertificate{
+		Certificate: encodeCertificate(&c.Certificate),
+		CertifierInfo: &walletpb.IdentityCertifier{
+			Name:        c.CertifierInfo.Name,
+			IconUrl:     c.CertifierInfo.IconUrl,
+			Description: c.CertifierInfo.Description,
+			Trust:       uint32(c.CertifierInfo.Trust),
+		},
+		PubliclyRevealedKeyring: c.PubliclyRevealedKeyring,
+		DecryptedFields:         c.DecryptedFields,
+	}
+}
+
+func (d *decoder) decodeIdentityCertificate(m *walletpb.IdentityCertificate) wallet.IdentityCertificate {
+	info := m.GetCertifierInfo()
+	return wallet.IdentityCertificate{
+		Certificate: d.decodeCertificate(m.GetCertificate()),
+		CertifierInfo: wallet.IdentityCertifier{
+			Name:        info.GetName(),
+			IconUrl:     info.GetIconUrl(),
+			Description: info.GetDescription(),
+			Trust:       uint8(info.GetTrust()),
+		},
+		PubliclyRevealedKeyring: m.GetPubliclyRevealedKeyring(),
+		DecryptedFields:         m.GetDecryptedFields(),
+	}
+}
+
+func encodeDiscoverByIdentityKeyArgs(a *wallet.DiscoverByIdentityKeyArgs) *walletpb.DiscoverByIdentityKeyArgs {
+	return &walletpb.DiscoverByIdentityKeyArgs{
+		IdentityKey:    encodePublicKey(a.IdentityKey),
+		Limit:          a.Limit,
+		Offset:         a.Offset,
+		SeekPermission: a.SeekPermission,
+	}
+}
+
+func (d *decoder) decodeDiscoverByIdentityKeyArgs(m *walletpb.DiscoverByIdentityKeyArgs) wallet.DiscoverByIdentityKeyArgs {
+	return wallet.DiscoverByIdentityKeyArgs{
+		IdentityKey:    d.publicKey(m.GetIdentityKey()),
+		Limit:          m.Limit,
+		Offset:         m.Offset,
+		SeekPermission: m.SeekPermission,
+	}
+}
+
+func encodeDiscoverByAttributesArgs(a *wallet.DiscoverByAttributesArgs) *walletpb.DiscoverByAttributesArgs {
+	return &walletpb.DiscoverByAttributesArgs{
+		Attributes:     a.Attributes,
+		Limit:          a.Limit,
+		Offset:         a.Offset,
+		SeekPermission: a.SeekPermission,
+	}
+}
+
+func (d *decoder) decodeDiscoverByAttributesArgs(m *walletpb.DiscoverByAttributesArgs) wallet.DiscoverByAttributesArgs {
+	return wallet.DiscoverByAttributesArgs{
+		Attributes:     m.GetAttributes(),
+		Limit:          m.Limit,
+		Offset:         m.Offset,
+		SeekPermission: m.SeekPermission,
+	}
+}
+
+func encodeDiscoverCertificatesResult(r *wallet.DiscoverCertificatesResult) *walletpb.DiscoverCertificatesResult {
+	return &walletpb.DiscoverCertificatesResult{
+		TotalCertificates: r.TotalCertificates,
+		Certificates:      encodeAll(r.Certificates, encodeIdentityCertificate),
+	}
+}
+
+func (d *decoder) decodeDiscoverCertificatesResult(m *walletpb.DiscoverCertificatesResult) wallet.DiscoverCertificatesResult {
+	return wallet.DiscoverCertificatesResult{
+		TotalCertificates: m.GetTotalCertificates(),
+		Certificates:      decodeAll(d, m.GetCertificates(), (*decoder).decodeIdentityCertificate),
+	}
+}
+
+// Authentication and chain
+
+func encodeAuthenticatedResult(r *wallet.AuthenticatedResult) *walletpb.AuthenticatedResult {
+	return &walletpb.AuthenticatedResult{Authenticated: r.Authenticated}
+}
+
+func (d *decoder) decodeAuthenticatedResult(m *walletpb.AuthenticatedResult) wallet.AuthenticatedResult {
+	return wallet.AuthenticatedResult{Authenticated: m.GetAuthenticated()}
+}
+
+func encodeGetHeightResult(r *wallet.GetHeightResult) *walletpb.GetHeightResult {
+	return &walletpb.GetHeightResult{Height: r.Height}
+}
+
+func (d *decoder) decodeGetHeightResult(m *walletpb.GetHeightResult) wallet.GetHeightResult {
+	return wallet.GetHeightResult{Height: m.GetHeight()}
+}
+
+func encodeGetHeaderArgs(a *wallet.GetHeaderArgs) *walletpb.GetHeaderArgs {
+	return &walletpb.GetHeaderArgs{Height: a.Height}
+}
+
+func (d *decoder) decodeGetHeaderArgs(m *walletpb.GetHeaderArgs) wallet.GetHeaderArgs {
+	return wallet.GetHeaderArgs{Height: m.GetHeight()}
+}
+
+func encodeGetHeaderResult(r *wallet.GetHeaderResult) *walletpb.GetHeaderResult {
+	return &walletpb.GetHeaderResult{Header: r.Header}
+}
+
+func (d *decoder) decodeGetHeaderResult(m *walletpb.GetHeaderResult) wallet.GetHeaderResult {
+	return wallet.GetHeaderResult{Header: m.GetHeader()}
+}
+
+func encodeGetNetworkResult(r *wallet.GetNetworkResult) *walletpb.GetNetworkResult {
+	return &walletpb.GetNetworkResult{Network: string(r.Network)}
+}
+
+func (d *decoder) decodeGetNetworkResult(m *walletpb.GetNetworkResult) wallet.GetNetworkResult {
+	return wallet.GetNetworkResult{Network: wallet.Network(m.GetNetwork())}
+}
+
+func encodeGetVersionResult(r *wallet.GetVersionResult) *walletpb.GetVersionResult {
+	return &walletpb.GetVersionResult{Version: r.Version}
+}
+
+func (d *decoder) decodeGetVersionResult(m *walletpb.GetVersionResult) wallet.GetVersionResult {
+	return wallet.GetVersionResult{Version: m.GetVersion()}
+}
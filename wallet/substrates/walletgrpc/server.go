@@ -0,0 +1,193 @@
+package walletgrpc
+
+import (
+	"context"
+	"errors"
+
+	"github.com/bsv-blockchain/go-sdk/wallet"
+	"github.com/bsv-blockchain/go-sdk/wallet/substrates/walletgrpc/walletpb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+var _ walletpb.WalletServer = (*Server)(nil)
+
+// Server implements the generated gRPC service by calling a wallet.Interface.
+// Register it with walletpb.RegisterWalletServer.
+type Server struct {
+	walletpb.UnimplementedWalletServer
+	wallet wallet.Interface
+}
+
+// NewServer creates a Server serving w.
+func NewServer(w wallet.Interface) *Server {
+	return &Server{wallet: w}
+}
+
+func serve[M, A, R, PR any](ctx context.Context, args M, decode func(*decoder, M) A, call func(context.Context, A, string) (*R, error), encode func(*R) PR) (PR, error) {
+	var res PR
+	d := &decoder{}
+	decoded := decode(d, args)
+	if d.err != nil {
+		return res, status.Errorf(codes.InvalidArgument, "failed to decode arguments: %v", d.err)
+	}
+	result, err := call(ctx, decoded, originator(ctx))
+	if err != nil {
+		return res, toStatus(err)
+	}
+	if result == nil {
+		result = new(R)
+	}
+	return encode(result), nil
+}
+
+// originator returns the originator sent in the metadata of the call.
+func originator(ctx context.Context) string {
+	md, _ := metadata.FromIncomingContext(ctx)
+	if values := md.Get(OriginatorMetadataKey); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
+
+// toStatus converts an error returned by the wallet to a status error, attaching
+// a *wallet.Error as a detail.
+func toStatus(err error) error {
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return status.FromContextError(err).Err()
+	}
+	var walletErr *wallet.Error
+	if errors.As(err, &walletErr) {
+		st, detailErr := status.New(codes.Unknown, walletErr.Message).WithDetails(&walletpb.Error{
+			Code:    uint32(walletErr.Code),
+			Message: walletErr.Message,
+			Stack:   walletErr.Stack,
+		})
+		if detailErr == nil {
+			return st.Err()
+		}
+	}
+	return status.Error(codes.Unknown, err.Error())
+}
+
+// noArgs decodes the empty arguments of the calls which take none.
+func (d *decoder) noArgs(*emptypb.Empty) any {
+	return nil
+}
+
+func (s *Server) CreateAction(ctx context.Context, args *walletpb.CreateActionArgs) (*walletpb.CreateActionResult, error) {
+	return serve(ctx, args, (*decoder).decodeCreateActionArgs, s.wallet.CreateAction, encodeCreateActionResult)
+}
+
+func (s *Server) SignAction(ctx context.Context, args *walletpb.SignActionArgs) (*walletpb.SignActionResult, error) {
+	return serve(ctx, args, (*decoder).decodeSignActionArgs, s.wallet.SignAction, encodeSignActionResult)
+}
+
+func (s *Server) AbortAction(ctx context.Context, args *walletpb.AbortActionArgs) (*walletpb.AbortActionResult, error) {
+	return serve(ctx, args, (*decoder).decodeAbortActionArgs, s.wallet.AbortAction, encodeAbortActionResult)
+}
+
+func (s *Server) ListActions(ctx context.Context, args *walletpb.ListActionsArgs) (*walletpb.ListActionsResult, error) {
+	return serve(ctx, args, (*decoder).decodeListActionsArgs, s.wallet.ListActions, encodeListActionsResult)
+}
+
+func (s *Server) InternalizeAction(ctx context.Context, args *walletpb.InternalizeActionArgs) (*walletpb.InternalizeActionResult, error) {
+	return serve(ctx, args, (*decoder).decodeInternalizeActionArgs, s.wallet.InternalizeAction, encodeInternalizeActionResult)
+}
+
+func (s *Server) ListOutputs(ctx context.Context, args *walletpb.ListOutputsArgs) (*walletpb.ListOutputsResult, error) {
+	return serve(ctx, args, (*decoder).decodeListOutputsArgs, s.wallet.ListOutputs, encodeListOutputsResult)
+}
+
+func (s *Server) RelinquishOutput(ctx context.Context, args *walletpb.RelinquishOutputArgs) (*walletpb.RelinquishOutputResult, error) {
+	return serve(ctx, args, (*decoder).decodeRelinquishOutputArgs, s.wallet.RelinquishOutput, encodeRelinquishOutputResult)
+}
+
+func (s *Server) GetPublicKey(ctx context.Context, args *walletpb.GetPublicKeyArgs) (*walletpb.GetPublicKeyResult, error) {
+	return serve(ctx, args, (*decoder).decodeGetPublicKeyArgs, s.wallet.GetPublicKey, encodeGetPublicKeyResult)
+}
+
+func (s *Server) RevealCounterpartyKeyLinkage(ctx context.Context, args *walletpb.RevealCounterpartyKeyLinkageArgs) (*walletpb.RevealCounterpartyKeyLinkageResult, error) {
+	return serve(ctx, args, (*decoder).decodeRevealCounterpartyKeyLinkageArgs, s.wallet.RevealCounterpartyKeyLinkage, encodeRevealCounterpartyKeyLinkageResult)
+}
+
+func (s *Server) RevealSpecificKeyLinkage(ctx context.Context, args *walletpb.RevealSpecificKeyLinkageArgs) (*walletpb.RevealSpecificKeyLinkageResult, error) {
+	return serve(ctx, args, (*decoder).decodeRevealSpecificKeyLinkageArgs, s.wallet.RevealSpecificKeyLinkage, encodeRevealSpecificKeyLinkageResult)
+}
+
+func (s *Server) Encrypt(ctx context.Context, args *walletpb.EncryptArgs) (*walletpb.EncryptResult, error) {
+	return serve(ctx, args, (*decoder).decodeEncryptArgs, s.wallet.Encrypt, encodeEncryptResult)
+}
+
+func (s *Server) Decrypt(ctx context.Context, args *walletpb.DecryptArgs) (*walletpb.DecryptResult, error) {
+	return serve(ctx, args, (*decoder).decodeDecryptArgs, s.wallet.Decrypt, encodeDecryptResult)
+}
+
+func (s *Server) CreateHMAC(ctx context.Context, args *walletpb.CreateHMACArgs) (*walletpb.CreateHMACResult, error) {
+	return serve(ctx, args, (*decoder).decodeCreateHMACArgs, s.wallet.CreateHMAC, encodeCreateHMACResult)
+}
+
+func (s *Server) VerifyHMAC(ctx context.Context, args *walletpb.VerifyHMACArgs) (*walletpb.VerifyHMACResult, error) {
+	return serve(ctx, args, (*decoder).decodeVerifyHMACArgs, s.wallet.VerifyHMAC, encodeVerifyHMACResult)
+}
+
+func (s *Server) CreateSignature(ctx context.Context, args *walletpb.CreateSignatureArgs) (*walletpb.CreateSignatureResult, error) {
+	return serve(ctx, args, (*decoder).decodeCreateSignatureArgs, s.wallet.CreateSignature, encodeCreateSignatureResult)
+}
+
+func (s *Server) VerifySignature(ctx context.Context, args *walletpb.VerifySignatureArgs) (*walletpb.VerifySignatureResult, error) {
+	return serve(ctx, args, (*decoder).decodeVerifySignatureArgs, s.wallet.VerifySignature, encodeVerifySignatureResult)
+}
+
+func (s *Server) AcquireCertificate(ctx context.Context, args *walletpb.AcquireCertificateArgs) (*walletpb.Certificate, error) {
+	return serve(ctx, args, (*decoder).decodeAcquireCertificateArgs, s.wallet.AcquireCertificate, encodeCertificate)
+}
+
+func (s *Server) ListCertificates(ctx context.Context, args *walletpb.ListCertificatesArgs) (*walletpb.ListCertificatesResult, error) {
+	return serve(ctx, args, (*decoder).decodeListCertificatesArgs, s.wallet.ListCertificates, encodeListCertificatesResult)
+}
+
+func (s *Server) ProveCertificate(ctx context.Context, args *walletpb.ProveCertificateArgs) (*walletpb.ProveCertificateResult, error) {
+	return serve(ctx, args, (*decoder).decodeProveCertificateArgs, s.wallet.ProveCertificate, encodeProveCertificateResult)
+}
+
+func (s *Server) RelinquishCertificate(ctx context.Context, args *walletpb.RelinquishCertificateArgs) (*walletpb.RelinquishCertificateResult, error) {
+	return serve(ctx, args, (*decoder).decodeRelinquishCertificateArgs, s.wallet.RelinquishCertificate, encodeRelinquishCertificateResult)
+}
+
+func (s *Server) DiscoverByIdentityKey(ctx context.Context, args *walletpb.DiscoverByIdentityKeyArgs) (*walletpb.DiscoverCertificatesResult, error) {
+	return serve(ctx, args, (*decoder).decodeDiscoverByIdentityKeyArgs, s.wallet.DiscoverByIdentityKey, encodeDiscoverCertificatesResult)
+}
+
+func (s *Server) DiscoverByAttributes(ctx context.Context, args *walletpb.DiscoverByAttributesArgs) (*walletpb.DiscoverCertificatesResult, error) {
+	return serve(ctx, args, (*decoder).decodeDiscoverByAttributesArgs, s.wallet.DiscoverByAttributes, encodeDiscoverCertificatesResult)
+}
+
+func (s *Server) IsAuthenticated(ctx context.Context, args *emptypb.Empty) (*walletpb.AuthenticatedResult, error) {
+	return serve(ctx, args, (*decoder).noArgs, s.wallet.IsAuthenticated, encodeAuthenticatedResult)
+}
+
+func (s *Server) WaitForAuthentication(ctx context.Context, args *emptypb.Empty) (*walletpb.AuthenticatedResult, error) {
+	return serve(ctx, args, (*decoder).noArgs, s.wallet.WaitForAuthentication, encodeAuthenticatedResult)
+}
+
+func (s *Server) GetHeight(ctx context.Context, args *emptypb.Empty) (*walletpb.GetHeightResult, error) {
+	return serve(ctx, args, (*decoder).noArgs, s.wallet.GetHeight, encodeGetHeightResult)
+}
+
+func (s *Server) GetHeaderForHeight(ctx context.Context, args *walletpb.GetHeaderArgs) (*walletpb.GetHeaderResult, error) {
+	return serve(ctx, args, (*decoder).decodeGetHeaderArgs, s.wallet.GetHeaderForHeight, encodeGetHeaderResult)
+}
+
+func (s *Server) GetNetwork(ctx context.Context, args *emptypb.Empty) (*walletpb.GetNetworkResult, error) {
+	return serve(ctx, args, (*decoder).noArgs, s.wallet.GetNetwork, encodeGetNetworkResult)
+}
+
+func (s *Server) GetVersion(ctx context.Context, args *emptypb.Empty) (*walletpb.GetVersionResult, error) {
+	return serve(ctx, args, (*decoder).noArgs, s.wallet.GetVersion, encodeGetVersionResult)
+}
@@ -0,0 +1,492 @@
+package walletgrpc_test
+
+import (
+	"context"
+	"errors"
+	"net"
+	"reflect"
+	"testing"
+
+	"github.com/bsv-blockchain/go-sdk/chainhash"
+	ec "github.com/bsv-blockchain/go-sdk/primitives/ec"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+	sighash "github.com/bsv-blockchain/go-sdk/transaction/sighash"
+	"github.com/bsv-blockchain/go-sdk/util"
+	"github.com/bsv-blockchain/go-sdk/wallet"
+	"github.com/bsv-blockchain/go-sdk/wallet/substrates/walletgrpc"
+	"github.com/bsv-blockchain/go-sdk/wallet/substrates/walletgrpc/walletpb"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+func newClient(t *testing.T, w wallet.Interface) *walletgrpc.Client {
+	t.Helper()
+	listener := bufconn.Listen(1 << 20)
+	server := grpc.NewServer()
+	walletpb.RegisterWalletServer(server, walletgrpc.NewServer(w))
+	go func() { _ = server.Serve(listener) }()
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufconn",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+	return walletgrpc.NewClient(conn)
+}
+
+func TestServiceCoversInterface(t *testing.T) {
+	methods := walletpb.File_wallet_proto.Services().ByName("Wallet").Methods()
+	walletType := reflect.TypeOf((*wallet.Interface)(nil)).Elem()
+	require.Equal(t, walletType.NumMethod(), methods.Len())
+	for i := range walletType.NumMethod() {
+		name := walletType.Method(i).Name
+		require.NotNil(t, methods.ByName(protoreflect.Name(name)), name)
+	}
+}
+
+func TestClient(t *testing.T) {
+	ctx := t.Context()
+	backend := wallet.NewTestWalletForRandomKey(t)
+	client := newClient(t, backend)
+
+	encryption := wallet.EncryptionArgs{
+		ProtocolID:   wallet.Protocol{SecurityLevel: wallet.SecurityLevelEveryApp, Protocol: "grpc test"},
+		KeyID:        "1",
+		Counterparty: wallet.Counterparty{Type: wallet.CounterpartyTypeSelf},
+	}
+	identity, err := client.GetPublicKey(ctx, wallet.GetPublicKeyArgs{IdentityKey: true}, "")
+	require.NoError(t, err)
+	expected, err := backend.GetPublicKey(ctx, wallet.GetPublicKeyArgs{IdentityKey: true}, "")
+	require.NoError(t, err)
+	require.True(t, expected.PublicKey.IsEqual(identity.PublicKey))
+
+	encrypted, err := client.Encrypt(ctx, wallet.EncryptArgs{EncryptionArgs: encryption, Plaintext: []byte("hello")}, "")
+	require.NoError(t, err)
+	decrypted, err := client.Decrypt(ctx, wallet.DecryptArgs{EncryptionArgs: encryption, Ciphertext: encrypted.Ciphertext}, "")
+	require.NoError(t, err)
+	require.Equal(t, []byte("hello"), []byte(decrypted.Plaintext))
+
+	signed, err := client.CreateSignature(ctx, wallet.CreateSignatureArgs{EncryptionArgs: encryption, Data: []byte("data")}, "")
+	require.NoError(t, err)
+	verified, err := client.VerifySignature(ctx, wallet.VerifySignatureArgs{EncryptionArgs: encryption, Data: []byte("data"), Signature: signed.Signature}, "")
+	require.NoError(t, err)
+	require.True(t, verified.Valid)
+
+	hmac, err := client.CreateHMAC(ctx, wallet.CreateHMACArgs{EncryptionArgs: encryption, Data: []byte("data")}, "")
+	require.NoError(t, err)
+	valid, err := client.VerifyHMAC(ctx, wallet.VerifyHMACArgs{EncryptionArgs: encryption, Data: []byte("data"), HMAC: hmac.HMAC}, "")
+	require.NoError(t, err)
+	require.True(t, valid.Valid)
+
+	t.Run("returns wallet errors", func(t *testing.T) {
+		walletErr := &wallet.Error{Code: 5, Message: "action not found", Stack: "stack"}
+		backend.OnAbortAction().ReturnError(walletErr)
+		_, err := client.AbortAction(ctx, wallet.AbortActionArgs{Reference: []byte("ref")}, "")
+		require.Equal(t, walletErr, err)
+
+		backend.OnGetHeight().ReturnError(errors.New("chain unavailable"))
+		_, err = client.GetHeight(ctx, nil, "")
+		require.ErrorContains(t, err, "chain unavailable")
+		_, ok := status.FromError(err)
+		require.True(t, ok)
+	})
+}
+
+// roundTrip calls the wallet method through the client and checks that the
+// backend receives args and the originator, and the client returns result.
+func roundTrip[A, R any](t *testing.T, mock *wallet.MockWalletMethods[A, R], call func(context.Context, A, string) (*R, error), args A, result R) {
+	t.Helper()
+	var gotArgs A
+	var gotOriginator string
+	mock.Do(func(_ context.Context, args A, originator string) (*R, error) {
+		gotArgs, gotOriginator = args, originator
+		return &result, nil
+	})
+	res, err := call(t.Context(), args, "example.com")
+	require.NoError(t, err)
+	require.Equal(t, args, gotArgs)
+	require.Equal(t, "example.com", gotOriginator)
+	require.Equal(t, &result, res)
+}
+
+func TestRoundTrip(t *testing.T) {
+	backend := wallet.NewTestWalletForRandomKey(t)
+	client := newClient(t, backend)
+
+	key, err := ec.NewPrivateKey()
+	require.NoError(t, err)
+	pubKey := key.PubKey()
+	other, err := ec.NewPrivateKey()
+	require.NoError(t, err)
+	otherKey := other.PubKey()
+	signature, err := key.Sign(make([]byte, 32))
+	require.NoError(t, err)
+	txid := chainhash.DoubleHashH([]byte("tx"))
+	outpoint := transaction.Outpoint{Txid: txid, Index: 3}
+	protocol := wallet.Protocol{SecurityLevel: wallet.SecurityLevelEveryAppAndCounterparty, Protocol: "round trip"}
+	counterparty := wallet.Counterparty{Type: wallet.CounterpartyTypeOther, Counterparty: otherKey}
+	encryption := wallet.EncryptionArgs{
+		ProtocolID:       protocol,
+		KeyID:            "key",
+		Counterparty:     counterparty,
+		Privileged:       true,
+		PrivilegedReason: "reason",
+		SeekPermission:   true,
+	}
+	certType, err := wallet.CertificateTypeFromString("grpc-test")
+	require.NoError(t, err)
+	serialNumber := wallet.SerialNumber(chainhash.HashH([]byte("serial")))
+	certificate := wallet.Certificate{
+		Type:               certType,
+		SerialNumber:       serialNumber,
+		Subject:            pubKey,
+		Certifier:          otherKey,
+		RevocationOutpoint: &outpoint,
+		Fields:             map[string]string{"name": "encrypted"},
+		Signature:          signature,
+	}
+	sendWith := []wallet.SendWithResult{{Txid: txid, Status: wallet.ActionResultStatusSending}}
+
+	t.Run("CreateAction", func(t *testing.T) {
+		roundTrip(t, backend.OnCreateAction(), client.CreateAction, wallet.CreateActionArgs{
+			Description: "description",
+			InputBEEF:   []byte("beef"),
+			Inputs: []wallet.CreateActionInput{{
+				Outpoint:              outpoint,
+				InputDescription:      "input",
+				UnlockingScriptLength: 107,
+				SequenceNumber:        util.Uint32Ptr(0),
+			}},
+			Outputs: []wallet.CreateActionOutput{{
+				LockingScript:      []byte{0x51},
+				Satoshis:           1000,
+				OutputDescription:  "output",
+				Basket:             "basket",
+				CustomInstructions: "instructions",
+				Tags:               []string{"tag"},
+			}},
+			LockTime: util.Uint32Ptr(0),
+			Version:  util.Uint32Ptr(1),
+			Labels:   []string{"label"},
+			Options: &wallet.CreateActionOptions{
+				SignAndProcess:   util.BoolPtr(false),
+				TrustSelf:        wallet.TrustSelfKnown,
+				KnownTxids:       []chainhash.Hash{txid},
+				NoSend:           util.BoolPtr(true),
+				NoSendChange:     []transaction.Outpoint{outpoint},
+				SendWith:         []chainhash.Hash{txid},
+				RandomizeOutputs: util.BoolPtr(false),
+				ChangeStrategy:   &wallet.ChangeStrategy{Type: wallet.ChangeStrategyDenominations, Denominations: []uint64{100, 1000}},
+			},
+		}, wallet.CreateActionResult{
+			Txid:                txid,
+			Tx:                  []byte("atomic beef"),
+			NoSendChange:        []transaction.Outpoint{outpoint},
+			SendWithResults:     sendWith,
+			SignableTransaction: &wallet.SignableTransaction{Tx: []byte("tx"), Reference: []byte("ref")},
+		})
+	})
+
+	t.Run("SignAction", func(t *testing.T) {
+		roundTrip(t, backend.OnSignAction(), client.SignAction, wallet.SignActionArgs{
+			Reference: []byte("ref"),
+			Spends: map[uint32]wallet.SignActionSpend{
+				0: {UnlockingScript: []byte{0x51}},
+				2: {UnlockingScript: []byte{0x52}, SequenceNumber: util.Uint32Ptr(7)},
+			},
+			Options: &wallet.SignActionOptions{ReturnTXIDOnly: util.BoolPtr(true), SendWith: []chainhash.Hash{txid}},
+		}, wallet.SignActionResult{Txid: txid, Tx: []byte("tx"), SendWithResults: sendWith})
+	})
+
+	t.Run("AbortAction", func(t *testing.T) {
+		roundTrip(t, backend.OnAbortAction(), client.AbortAction,
+			wallet.AbortActionArgs{Reference: []byte("ref")}, wallet.AbortActionResult{Aborted: true})
+	})
+
+	t.Run("ListActions", func(t *testing.T) {
+		roundTrip(t, backend.OnListActions(), client.ListActions, wallet.ListActionsArgs{
+			Labels:         []string{"label"},
+			LabelQueryMode: wallet.QueryModeAll,
+			IncludeInputs:  util.BoolPtr(true),
+			IncludeOutputs: util.BoolPtr(false),
+			Limit:          util.Uint32Ptr(5),
+			Offset:         util.Uint32Ptr(10),
+			Cursor:         "cursor",
+		}, wallet.ListActionsResult{
+			TotalActions: 1,
+			Actions: []wallet.Action{{
+				Txid:        txid,
+				Satoshis:    -500,
+				Status:      wallet.ActionStatusCompleted,
+				IsOutgoing:  true,
+				Description: "action",
+				Labels:      []string{"label"},
+				Version:     1,
+				Inputs: []wallet.ActionInput{{
+					SourceOutpoint:      outpoint,
+					SourceSatoshis:      1000,
+					SourceLockingScript: []byte{0x51},
+					UnlockingScript:     []byte{0x00},
+					InputDescription:    "input",
+					SequenceNumber:      0xffffffff,
+				}},
+				Outputs: []wallet.ActionOutput{{
+					Satoshis:          500,
+					LockingScript:     []byte{0x52},
+					Spendable:         true,
+					Tags:              []string{"tag"},
+					OutputIndex:       1,
+					OutputDescription: "output",
+					Basket:            "basket",
+				}},
+			}},
+			NextCursor: "next",
+		})
+	})
+
+	t.Run("InternalizeAction", func(t *testing.T) {
+		roundTrip(t, backend.OnInternalizeAction(), client.InternalizeAction, wallet.InternalizeActionArgs{
+			Tx:             []byte("atomic beef"),
+			Description:    "payment",
+			Labels:         []string{"label"},
+			SeekPermission: util.BoolPtr(false),
+			Outputs: []wallet.InternalizeOutput{{
+				OutputIndex: 0,
+				Protocol:    wallet.InternalizeProtocolWalletPayment,
+				PaymentRemittance: &wallet.Payment{
+					DerivationPrefix:  []byte("prefix"),
+					DerivationSuffix:  []byte("suffix"),
+					SenderIdentityKey: otherKey,
+				},
+			}, {
+				OutputIndex:         1,
+				Protocol:            wallet.InternalizeProtocolBasketInsertion,
+				InsertionRemittance: &wallet.BasketInsertion{Basket: "basket", CustomInstructions: "instructions", Tags: []string{"tag"}},
+			}},
+		}, wallet.InternalizeActionResult{Accepted: true})
+	})
+
+	t.Run("ListOutputs", func(t *testing.T) {
+		roundTrip(t, backend.OnListOutputs(), client.ListOutputs, wallet.ListOutputsArgs{
+			Basket:       "basket",
+			Tags:         []string{"tag"},
+			TagQueryMode: wallet.QueryModeAny,
+			Include:      wallet.OutputIncludeEntireTransactions,
+			IncludeTags:  util.BoolPtr(true),
+			Limit:        util.Uint32Ptr(100),
+			MinSatoshis:  util.Uint64Ptr(1),
+			MaxSatoshis:  util.Uint64Ptr(10000),
+			ScriptTypes:  []string{"P2PKH"},
+			SortOrder:    wallet.OutputSortSatoshisDescending,
+		}, wallet.ListOutputsResult{
+			TotalOutputs: 1,
+			BEEF:         []byte("beef"),
+			Outputs: []wallet.Output{{
+				Satoshis:           1000,
+				LockingScript:      []byte{0x51},
+				Spendable:          true,
+				CustomInstructions: "instructions",
+				Tags:               []string{"tag"},
+				Outpoint:           outpoint,
+				Labels:             []string{"label"},
+			}},
+			NextCursor: "next",
+		})
+	})
+
+	t.Run("RelinquishOutput", func(t *testing.T) {
+		roundTrip(t, backend.OnRelinquishOutput(), client.RelinquishOutput,
+			wallet.RelinquishOutputArgs{Basket: "basket", Output: outpoint}, wallet.RelinquishOutputResult{Relinquished: true})
+	})
+
+	t.Run("GetPublicKey", func(t *testing.T) {
+		roundTrip(t, backend.OnGetPublicKey(), client.GetPublicKey,
+			wallet.GetPublicKeyArgs{EncryptionArgs: encryption, ForSelf: util.BoolPtr(true)}, wallet.GetPublicKeyResult{PublicKey: pubKey})
+	})
+
+	t.Run("RevealCounterpartyKeyLinkage", func(t *testing.T) {
+		roundTrip(t, backend.OnRevealCounterpartyKeyLinkage(), client.RevealCounterpartyKeyLinkage, wallet.RevealCounterpartyKeyLinkageArgs{
+			Counterparty:     otherKey,
+			Verifier:         pubKey,
+			Privileged:       util.BoolPtr(true),
+			PrivilegedReason: "reason",
+		}, wallet.RevealCounterpartyKeyLinkageResult{
+			Prover:                pubKey,
+			Counterparty:          otherKey,
+			Verifier:              pubKey,
+			RevelationTime:        "2026-10-17T00:00:00Z",
+			EncryptedLinkage:      []byte("linkage"),
+			EncryptedLinkageProof: []byte("proof"),
+		})
+	})
+
+	t.Run("RevealSpecificKeyLinkage", func(t *testing.T) {
+		roundTrip(t, backend.OnRevealSpecificKeyLinkage(), client.RevealSpecificKeyLinkage, wallet.RevealSpecificKeyLinkageArgs{
+			Counterparty: counterparty,
+			Verifier:     pubKey,
+			ProtocolID:   protocol,
+			KeyID:        "key",
+		}, wallet.RevealSpecificKeyLinkageResult{
+			EncryptedLinkage:      []byte("linkage"),
+			EncryptedLinkageProof: []byte("proof"),
+			Prover:                pubKey,
+			Verifier:              pubKey,
+			Counterparty:          otherKey,
+			ProtocolID:            protocol,
+			KeyID:                 "key",
+			ProofType:             1,
+		})
+	})
+
+	t.Run("Encrypt", func(t *testing.T) {
+		roundTrip(t, backend.OnEncrypt(), client.Encrypt,
+			wallet.EncryptArgs{EncryptionArgs: encryption, Plaintext: []byte("plain")}, wallet.EncryptResult{Ciphertext: []byte("cipher")})
+	})
+
+	t.Run("Decrypt", func(t *testing.T) {
+		roundTrip(t, backend.OnDecrypt(), client.Decrypt,
+			wallet.DecryptArgs{EncryptionArgs: encryption, Ciphertext: []byte("cipher")}, wallet.DecryptResult{Plaintext: []byte("plain")})
+	})
+
+	t.Run("CreateHMAC", func(t *testing.T) {
+		roundTrip(t, backend.OnCreateHMAC(), client.CreateHMAC,
+			wallet.CreateHMACArgs{EncryptionArgs: encryption, Data: []byte("data")}, wallet.CreateHMACResult{HMAC: txid})
+	})
+
+	t.Run("VerifyHMAC", func(t *testing.T) {
+		roundTrip(t, backend.OnVerifyHMAC(), client.VerifyHMAC,
+			wallet.VerifyHMACArgs{EncryptionArgs: encryption, Data: []byte("data"), HMAC: txid}, wallet.VerifyHMACResult{Valid: true})
+	})
+
+	t.Run("CreateSignature", func(t *testing.T) {
+		roundTrip(t, backend.OnCreateSignature(), client.CreateSignature, wallet.CreateSignatureArgs{
+			EncryptionArgs: encryption,
+			SighashContext: &wallet.SighashContext{
+				Tx:                  []byte("tx"),
+				InputIndex:          1,
+				SourceSatoshis:      1000,
+				SourceLockingScript: []byte{0x51},
+				SighashFlag:         sighash.AllForkID,
+			},
+		}, wallet.CreateSignatureResult{Signature: signature})
+	})
+
+	t.Run("VerifySignature", func(t *testing.T) {
+		roundTrip(t, backend.OnVerifySignature(), client.VerifySignature, wallet.VerifySignatureArgs{
+			EncryptionArgs:       encryption,
+			HashToDirectlyVerify: txid[:],
+			Signature:            signature,
+			ForSelf:              util.BoolPtr(false),
+		}, wallet.VerifySignatureResult{Valid: true})
+	})
+
+	t.Run("AcquireCertificate", func(t *testing.T) {
+		roundTrip(t, backend.OnAcquireCertificate(), client.AcquireCertificate, wallet.AcquireCertificateArgs{
+			Type:                certType,
+			Certifier:           otherKey,
+			AcquisitionProtocol: wallet.AcquisitionProtocolDirect,
+			Fields:              map[string]string{"name": "encrypted"},
+			SerialNumber:        &serialNumber,
+			RevocationOutpoint:  &outpoint,
+			Signature:           signature,
+			KeyringRevealer:     &wallet.KeyringRevealer{PubKey: otherKey},
+			KeyringForSubject:   map[string]string{"name": "key"},
+		}, certificate)
+		roundTrip(t, backend.OnAcquireCertificate(), client.AcquireCertificate, wallet.AcquireCertificateArgs{
+			Type:                certType,
+			Certifier:           otherKey,
+			AcquisitionProtocol: wallet.AcquisitionProtocolIssuance,
+			CertifierUrl:        "https://certifier.example.com",
+			KeyringRevealer:     &wallet.KeyringRevealer{Certifier: true},
+			Privileged:          util.BoolPtr(false),
+		}, certificate)
+	})
+
+	t.Run("ListCertificates", func(t *testing.T) {
+		roundTrip(t, backend.OnListCertificates(), client.ListCertificates, wallet.ListCertificatesArgs{
+			Certifiers: []*ec.PublicKey{otherKey},
+			Types:      []wallet.CertificateType{certType},
+			Limit:      util.Uint32Ptr(10),
+		}, wallet.ListCertificatesResult{
+			TotalCertificates: 1,
+			Certificates: []wallet.CertificateResult{{
+				Certificate: certificate,
+				Keyring:     map[string]string{"name": "key"},
+				Verifier:    []byte("verifier"),
+			}},
+		})
+	})
+
+	t.Run("ProveCertificate", func(t *testing.T) {
+		roundTrip(t, backend.OnProveCertificate(), client.ProveCertificate, wallet.ProveCertificateArgs{
+			Certificate:    certificate,
+			FieldsToReveal: []string{"name"},
+			Verifier:       pubKey,
+		}, wallet.ProveCertificateResult{KeyringForVerifier: map[string]string{"name": "key"}})
+	})
+
+	t.Run("RelinquishCertificate", func(t *testing.T) {
+		roundTrip(t, backend.OnRelinquishCertificate(), client.RelinquishCertificate, wallet.RelinquishCertificateArgs{
+			Type:         certType,
+			SerialNumber: serialNumber,
+			Certifier:    otherKey,
+		}, wallet.RelinquishCertificateResult{Relinquished: true})
+	})
+
+	discovered := wallet.DiscoverCertificatesResult{
+		TotalCertificates: 1,
+		Certificates: []wallet.IdentityCertificate{{
+			Certificate:             certificate,
+			CertifierInfo:           wallet.IdentityCertifier{Name: "certifier", IconUrl: "icon", Description: "description", Trust: 5},
+			PubliclyRevealedKeyring: map[string]string{"name": "key"},
+			DecryptedFields:         map[string]string{"name": "Alice"},
+		}},
+	}
+
+	t.Run("DiscoverByIdentityKey", func(t *testing.T) {
+		roundTrip(t, backend.OnDiscoverByIdentityKey(), client.DiscoverByIdentityKey, wallet.DiscoverByIdentityKeyArgs{
+			IdentityKey:    pubKey,
+			Limit:          util.Uint32Ptr(1),
+			SeekPermission: util.BoolPtr(true),
+		}, discovered)
+	})
+
+	t.Run("DiscoverByAttributes", func(t *testing.T) {
+		roundTrip(t, backend.OnDiscoverByAttributes(), client.DiscoverByAttributes, wallet.DiscoverByAttributesArgs{
+			Attributes: map[string]string{"name": "Alice"},
+			Offset:     util.Uint32Ptr(2),
+		}, discovered)
+	})
+
+	t.Run("IsAuthenticated", func(t *testing.T) {
+		roundTrip(t, backend.OnIsAuthenticated(), client.IsAuthenticated, nil, wallet.AuthenticatedResult{Authenticated: true})
+	})
+
+	t.Run("WaitForAuthentication", func(t *testing.T) {
+		roundTrip(t, backend.OnWaitForAuthentication(), client.WaitForAuthentication, nil, wallet.AuthenticatedResult{Authenticated: true})
+	})
+
+	t.Run("GetHeight", func(t *testing.T) {
+		roundTrip(t, backend.OnGetHeight(), client.GetHeight, nil, wallet.GetHeightResult{Height: 850000})
+	})
+
+	t.Run("GetHeaderForHeight", func(t *testing.T) {
+		roundTrip(t, backend.OnGetHeaderForHeight(), client.GetHeaderForHeight,
+			wallet.GetHeaderArgs{Height: 850000}, wallet.GetHeaderResult{Header: make([]byte, 80)})
+	})
+
+	t.Run("GetNetwork", func(t *testing.T) {
+		roundTrip(t, backend.OnGetNetwork(), client.GetNetwork, nil, wallet.GetNetworkResult{Network: wallet.NetworkTestnet})
+	})
+
+	t.Run("GetVersion", func(t *testing.T) {
+		roundTrip(t, backend.OnGetVersion(), client.GetVersion, nil, wallet.GetVersionResult{Version: "go-sdk-1.0.0"})
+	})
+}
@@ -0,0 +1,6 @@
+// Package walletpb holds the protobuf messages and gRPC service of the wallet
+// interface, generated from wallet.proto. Use it through the adapters of
+// package walletgrpc.
+package walletpb
+
+//go:generate protoc --go_out=. --go_opt=paths=source_relative --go-grpc_out=. --go-grpc_opt=paths=source_relative wallet.proto
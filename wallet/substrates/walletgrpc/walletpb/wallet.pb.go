@@ -0,0 +1,5775 @@
+// Wallet interface over gRPC. Each RPC maps onto the method of wallet.Interface
+// with the same name, and each message onto the Go type with the same name.
+//
+// Public keys are 33 byte compressed keys, signatures are DER encoded and txids
+// are 32 bytes in internal byte order. Enumerations which are strings in Go,
+// such as action statuses, are carried as the same strings.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.8
+// 	protoc        (unknown)
+// source: wallet.proto
+
+package walletpb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type CounterpartyType int32
+
+const (
+	CounterpartyType_COUNTERPARTY_TYPE_UNINITIALIZED CounterpartyType = 0
+	CounterpartyType_COUNTERPARTY_TYPE_ANYONE        CounterpartyType = 1
+	CounterpartyType_COUNTERPARTY_TYPE_SELF          CounterpartyType = 2
+	CounterpartyType_COUNTERPARTY_TYPE_OTHER         CounterpartyType = 3
+)
+
+// Enum value maps for CounterpartyType.
+var (
+	CounterpartyType_name = map[int32]string{
+		0: "COUNTERPARTY_TYPE_UNINITIALIZED",
+		1: "COUNTERPARTY_TYPE_ANYONE",
+		2: "COUNTERPARTY_TYPE_SELF",
+		3: "COUNTERPARTY_TYPE_OTHER",
+	}
+	CounterpartyType_value = map[string]int32{
+		"COUNTERPARTY_TYPE_UNINITIALIZED": 0,
+		"COUNTERPARTY_TYPE_ANYONE":        1,
+		"COUNTERPARTY_TYPE_SELF":          2,
+		"COUNTERPARTY_TYPE_OTHER":         3,
+	}
+)
+
+func (x CounterpartyType) Enum() *CounterpartyType {
+	p := new(CounterpartyType)
+	*p = x
+	return p
+}
+
+func (x CounterpartyType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (CounterpartyType) Descriptor() protoreflect.EnumDescriptor {
+	return file_wallet_proto_enumTypes[0].Descriptor()
+}
+
+func (CounterpartyType) Type() protoreflect.EnumType {
+	return &file_wallet_proto_enumTypes[0]
+}
+
+func (x CounterpartyType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use CounterpartyType.Descriptor instead.
+func (CounterpartyType) EnumDescriptor() ([]byte, []int) {
+	return file_wallet_proto_rawDescGZIP(), []int{0}
+}
+
+// Error is attached to the status of failed calls as a detail when the wallet
+// failed with a wallet.Error.
+type Error struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Code          uint32                 `protobuf:"varint,1,opt,name=code,proto3" json:"code,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Stack         string                 `protobuf:"bytes,3,opt,name=stack,proto3" json:"stack,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Error) Reset() {
+	*x = Error{}
+	mi := &file_wallet_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Error) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Error) ProtoMessage() {}
+
+func (x *Error) ProtoReflect() protoreflect.Message {
+	mi := &file_wallet_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Error.ProtoReflect.Descriptor instead.
+func (*Error) Descriptor() ([]byte, []int) {
+	return file_wallet_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Error) GetCode() uint32 {
+	if x != nil {
+		return x.Code
+	}
+	return 0
+}
+
+func (x *Error) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *Error) GetStack() string {
+	if x != nil {
+		return x.Stack
+	}
+	return ""
+}
+
+type Outpoint struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Txid          []byte                 `protobuf:"bytes,1,opt,name=txid,proto3" json:"txid,omitempty"`
+	Index         uint32                 `protobuf:"varint,2,opt,name=index,proto3" json:"index,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Outpoint) Reset() {
+	*x = Outpoint{}
+	mi := &file_wallet_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Outpoint) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Outpoint) ProtoMessage() {}
+
+func (x *Outpoint) ProtoReflect() protoreflect.Message {
+	mi := &file_wallet_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Outpoint.ProtoReflect.Descriptor instead.
+func (*Outpoint) Descriptor() ([]byte, []int) {
+	return file_wallet_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Outpoint) GetTxid() []byte {
+	if x != nil {
+		return x.Txid
+	}
+	return nil
+}
+
+func (x *Outpoint) GetIndex() uint32 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
+type Protocol struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SecurityLevel int32                  `protobuf:"varint,1,opt,name=security_level,json=securityLevel,proto3" json:"security_level,omitempty"`
+	Protocol      string                 `protobuf:"bytes,2,opt,name=protocol,proto3" json:"protocol,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Protocol) Reset() {
+	*x = Protocol{}
+	mi := &file_wallet_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Protocol) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Protocol) ProtoMessage() {}
+
+func (x *Protocol) ProtoReflect() protoreflect.Message {
+	mi := &file_wallet_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Protocol.ProtoReflect.Descriptor instead.
+func (*Protocol) Descriptor() ([]byte, []int) {
+	return file_wallet_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Protocol) GetSecurityLevel() int32 {
+	if x != nil {
+		return x.SecurityLevel
+	}
+	return 0
+}
+
+func (x *Protocol) GetProtocol() string {
+	if x != nil {
+		return x.Protocol
+	}
+	return ""
+}
+
+type Counterparty struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Type  CounterpartyType       `protobuf:"varint,1,opt,name=type,proto3,enum=bsv.wallet.v1.CounterpartyType" json:"type,omitempty"`
+	// counterparty is the public key of COUNTERPARTY_TYPE_OTHER.
+	Counterparty  []byte `protobuf:"bytes,2,opt,name=counterparty,proto3" json:"counterparty,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Counterparty) Reset() {
+	*x = Counterparty{}
+	mi := &file_wallet_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Counterparty) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Counterparty) ProtoMessage() {}
+
+func (x *Counterparty) ProtoReflect() protoreflect.Message {
+	mi := &file_wallet_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Counterparty.ProtoReflect.Descriptor instead.
+func (*Counterparty) Descriptor() ([]byte, []int) {
+	return file_wallet_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *Counterparty) GetType() CounterpartyType {
+	if x != nil {
+		return x.Type
+	}
+	return CounterpartyType_COUNTERPARTY_TYPE_UNINITIALIZED
+}
+
+func (x *Counterparty) GetCounterparty() []byte {
+	if x != nil {
+		return x.Counterparty
+	}
+	return nil
+}
+
+type EncryptionArgs struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	ProtocolId       *Protocol              `protobuf:"bytes,1,opt,name=protocol_id,json=protocolId,proto3" json:"protocol_id,omitempty"`
+	KeyId            string                 `protobuf:"bytes,2,opt,name=key_id,json=keyId,proto3" json:"key_id,omitempty"`
+	Counterparty     *Counterparty          `protobuf:"bytes,3,opt,name=counterparty,proto3" json:"counterparty,omitempty"`
+	Privileged       bool                   `protobuf:"varint,4,opt,name=privileged,proto3" json:"privileged,omitempty"`
+	PrivilegedReason string                 `protobuf:"bytes,5,opt,name=privileged_reason,json=privilegedReason,proto3" json:"privileged_reason,omitempty"`
+	SeekPermission   bool                   `protobuf:"varint,6,opt,name=seek_permission,json=seekPermission,proto3" json:"seek_permission,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *EncryptionArgs) Reset() {
+	*x = EncryptionArgs{}
+	mi := &file_wallet_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EncryptionArgs) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EncryptionArgs) ProtoMessage() {}
+
+func (x *EncryptionArgs) ProtoReflect() protoreflect.Message {
+	mi := &file_wallet_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EncryptionArgs.ProtoReflect.Descriptor instead.
+func (*EncryptionArgs) Descriptor() ([]byte, []int) {
+	return file_wallet_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *EncryptionArgs) GetProtocolId() *Protocol {
+	if x != nil {
+		return x.ProtocolId
+	}
+	return nil
+}
+
+func (x *EncryptionArgs) GetKeyId() string {
+	if x != nil {
+		return x.KeyId
+	}
+	return ""
+}
+
+func (x *EncryptionArgs) GetCounterparty() *Counterparty {
+	if x != nil {
+		return x.Counterparty
+	}
+	return nil
+}
+
+func (x *EncryptionArgs) GetPrivileged() bool {
+	if x != nil {
+		return x.Privileged
+	}
+	return false
+}
+
+func (x *EncryptionArgs) GetPrivilegedReason() string {
+	if x != nil {
+		return x.PrivilegedReason
+	}
+	return ""
+}
+
+func (x *EncryptionArgs) GetSeekPermission() bool {
+	if x != nil {
+		return x.SeekPermission
+	}
+	return false
+}
+
+type CreateActionInput struct {
+	state                 protoimpl.MessageState `protogen:"open.v1"`
+	Outpoint              *Outpoint              `protobuf:"bytes,1,opt,name=outpoint,proto3" json:"outpoint,omitempty"`
+	InputDescription      string                 `protobuf:"bytes,2,opt,name=input_description,json=inputDescription,proto3" json:"input_description,omitempty"`
+	UnlockingScript       []byte                 `protobuf:"bytes,3,opt,name=unlocking_script,json=unlockingScript,proto3" json:"unlocking_script,omitempty"`
+	UnlockingScriptLength uint32                 `protobuf:"varint,4,opt,name=unlocking_script_length,json=unlockingScriptLength,proto3" json:"unlocking_script_length,omitempty"`
+	SequenceNumber        *uint32                `protobuf:"varint,5,opt,name=sequence_number,json=sequenceNumber,proto3,oneof" json:"sequence_number,omitempty"`
+	unknownFields         protoimpl.UnknownFields
+	sizeCache             protoimpl.SizeCache
+}
+
+func (x *CreateActionInput) Reset() {
+	*x = CreateActionInput{}
+	mi := &file_wallet_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateActionInput) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateActionInput) ProtoMessage() {}
+
+func (x *CreateActionInput) ProtoReflect() protoreflect.Message {
+	mi := &file_wallet_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateActionInput.ProtoReflect.Descriptor instead.
+func (*CreateActionInput) Descriptor() ([]byte, []int) {
+	return file_wallet_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *CreateActionInput) GetOutpoint() *Outpoint {
+	if x != nil {
+		return x.Outpoint
+	}
+	return nil
+}
+
+func (x *CreateActionInput) GetInputDescription() string {
+	if x != nil {
+		return x.InputDescription
+	}
+	return ""
+}
+
+func (x *CreateActionInput) GetUnlockingScript() []byte {
+	if x != nil {
+		return x.UnlockingScript
+	}
+	return nil
+}
+
+func (x *CreateActionInput) GetUnlockingScriptLength() uint32 {
+	if x != nil {
+		return x.UnlockingScriptLength
+	}
+	return 0
+}
+
+func (x *CreateActionInput) GetSequenceNumber() uint32 {
+	if x != nil && x.SequenceNumber != nil {
+		return *x.SequenceNumber
+	}
+	return 0
+}
+
+type CreateActionOutput struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	LockingScript      []byte                 `protobuf:"bytes,1,opt,name=locking_script,json=lockingScript,proto3" json:"locking_script,omitempty"`
+	Satoshis           uint64                 `protobuf:"varint,2,opt,name=satoshis,proto3" json:"satoshis,omitempty"`
+	OutputDescription  string                 `protobuf:"bytes,3,opt,name=output_description,json=outputDescription,proto3" json:"output_description,omitempty"`
+	Basket             string                 `protobuf:"bytes,4,opt,name=basket,proto3" json:"basket,omitempty"`
+	CustomInstructions string                 `protobuf:"bytes,5,opt,name=custom_instructions,json=customInstructions,proto3" json:"custom_instructions,omitempty"`
+	Tags               []string               `protobuf:"bytes,6,rep,name=tags,proto3" json:"tags,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *CreateActionOutput) Reset() {
+	*x = CreateActionOutput{}
+	mi := &file_wallet_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateActionOutput) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateActionOutput) ProtoMessage() {}
+
+func (x *CreateActionOutput) ProtoReflect() protoreflect.Message {
+	mi := &file_wallet_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateActionOutput.ProtoReflect.Descriptor instead.
+func (*CreateActionOutput) Descriptor() ([]byte, []int) {
+	return file_wallet_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *CreateActionOutput) GetLockingScript() []byte {
+	if x != nil {
+		return x.LockingScript
+	}
+	return nil
+}
+
+func (x *CreateActionOutput) GetSatoshis() uint64 {
+	if x != nil {
+		return x.Satoshis
+	}
+	return 0
+}
+
+func (x *CreateActionOutput) GetOutputDescription() string {
+	if x != nil {
+		return x.OutputDescription
+	}
+	return ""
+}
+
+func (x *CreateActionOutput) GetBasket() string {
+	if x != nil {
+		return x.Basket
+	}
+	return ""
+}
+
+func (x *CreateActionOutput) GetCustomInstructions() string {
+	if x != nil {
+		return x.CustomInstructions
+	}
+	return ""
+}
+
+func (x *CreateActionOutput) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+type ChangeStrategy struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Type          string                 `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
+	Count         uint32                 `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+	Denominations []uint64               `protobuf:"varint,3,rep,packed,name=denominations,proto3" json:"denominations,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ChangeStrategy) Reset() {
+	*x = ChangeStrategy{}
+	mi := &file_wallet_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChangeStrategy) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChangeStrategy) ProtoMessage() {}
+
+func (x *ChangeStrategy) ProtoReflect() protoreflect.Message {
+	mi := &file_wallet_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChangeStrategy.ProtoReflect.Descriptor instead.
+func (*ChangeStrategy) Descriptor() ([]byte, []int) {
+	return file_wallet_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ChangeStrategy) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *ChangeStrategy) GetCount() uint32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *ChangeStrategy) GetDenominations() []uint64 {
+	if x != nil {
+		return x.Denominations
+	}
+	return nil
+}
+
+type CreateActionOptions struct {
+	state                  protoimpl.MessageState `protogen:"open.v1"`
+	SignAndProcess         *bool                  `protobuf:"varint,1,opt,name=sign_and_process,json=signAndProcess,proto3,oneof" json:"sign_and_process,omitempty"`
+	AcceptDelayedBroadcast *bool                  `protobuf:"varint,2,opt,name=accept_delayed_broadcast,json=acceptDelayedBroadcast,proto3,oneof" json:"accept_delayed_broadcast,omitempty"`
+	TrustSelf              string                 `protobuf:"bytes,3,opt,name=trust_self,json=trustSelf,proto3" json:"trust_self,omitempty"`
+	KnownTxids             [][]byte               `protobuf:"bytes,4,rep,name=known_txids,json=knownTxids,proto3" json:"known_txids,omitempty"`
+	ReturnTxidOnly         *bool                  `protobuf:"varint,5,opt,name=return_txid_only,json=returnTxidOnly,proto3,oneof" json:"return_txid_only,omitempty"`
+	NoSend                 *bool                  `protobuf:"varint,6,opt,name=no_send,json=noSend,proto3,oneof" json:"no_send,omitempty"`
+	NoSendChange           []*Outpoint            `protobuf:"bytes,7,rep,name=no_send_change,json=noSendChange,proto3" json:"no_send_change,omitempty"`
+	SendWith               [][]byte               `protobuf:"bytes,8,rep,name=send_with,json=sendWith,proto3" json:"send_with,omitempty"`
+	RandomizeOutputs       *bool                  `protobuf:"varint,9,opt,name=randomize_outputs,json=randomizeOutputs,proto3,oneof" json:"randomize_outputs,omitempty"`
+	ChangeStrategy         *ChangeStrategy        `protobuf:"bytes,10,opt,name=change_strategy,json=changeStrategy,proto3" json:"change_strategy,omitempty"`
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *CreateActionOptions) Reset() {
+	*x = CreateActionOptions{}
+	mi := &file_wallet_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateActionOptions) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateActionOptions) ProtoMessage() {}
+
+func (x *CreateActionOptions) ProtoReflect() protoreflect.Message {
+	mi := &file_wallet_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateActionOptions.ProtoReflect.Descriptor instead.
+func (*CreateActionOptions) Descriptor() ([]byte, []int) {
+	return file_wallet_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *CreateActionOptions) GetSignAndProcess() bool {
+	if x != nil && x.SignAndProcess != nil {
+		return *x.SignAndProcess
+	}
+	return false
+}
+
+func (x *CreateActionOptions) GetAcceptDelayedBroadcast() bool {
+	if x != nil && x.AcceptDelayedBroadcast != nil {
+		return *x.AcceptDelayedBroadcast
+	}
+	return false
+}
+
+func (x *CreateActionOptions) GetTrustSelf() string {
+	if x != nil {
+		return x.TrustSelf
+	}
+	return ""
+}
+
+func (x *CreateActionOptions) GetKnownTxids() [][]byte {
+	if x != nil {
+		return x.KnownTxids
+	}
+	return nil
+}
+
+func (x *CreateActionOptions) GetReturnTxidOnly() bool {
+	if x != nil && x.ReturnTxidOnly != nil {
+		return *x.ReturnTxidOnly
+	}
+	return false
+}
+
+func (x *CreateActionOptions) GetNoSend() bool {
+	if x != nil && x.NoSend != nil {
+		return *x.NoSend
+	}
+	return false
+}
+
+func (x *CreateActionOptions) GetNoSendChange() []*Outpoint {
+	if x != nil {
+		return x.NoSendChange
+	}
+	return nil
+}
+
+func (x *CreateActionOptions) GetSendWith() [][]byte {
+	if x != nil {
+		return x.SendWith
+	}
+	return nil
+}
+
+func (x *CreateActionOptions) GetRandomizeOutputs() bool {
+	if x != nil && x.RandomizeOutputs != nil {
+		return *x.RandomizeOutputs
+	}
+	return false
+}
+
+func (x *CreateActionOptions) GetChangeStrategy() *ChangeStrategy {
+	if x != nil {
+		return x.ChangeStrategy
+	}
+	return nil
+}
+
+type CreateActionArgs struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Description   string                 `protobuf:"bytes,1,opt,name=description,proto3" json:"description,omitempty"`
+	InputBeef     []byte                 `protobuf:"bytes,2,opt,name=input_beef,json=inputBeef,proto3" json:"input_beef,omitempty"`
+	Inputs        []*CreateActionInput   `protobuf:"bytes,3,rep,name=inputs,proto3" json:"inputs,omitempty"`
+	Outputs       []*CreateActionOutput  `protobuf:"bytes,4,rep,name=outputs,proto3" json:"outputs,omitempty"`
+	LockTime      *uint32                `protobuf:"varint,5,opt,name=lock_time,json=lockTime,proto3,oneof" json:"lock_time,omitempty"`
+	Version       *uint32                `protobuf:"varint,6,opt,name=version,proto3,oneof" json:"version,omitempty"`
+	Labels        []string               `protobuf:"bytes,7,rep,name=labels,proto3" json:"labels,omitempty"`
+	Options       *CreateActionOptions   `protobuf:"bytes,8,opt,name=options,proto3" json:"options,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateActionArgs) Reset() {
+	*x = CreateActionArgs{}
+	mi := &file_wallet_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateActionArgs) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateActionArgs) ProtoMessage() {}
+
+func (x *CreateActionArgs) ProtoReflect() protoreflect.Message {
+	mi := &file_wallet_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateActionArgs.ProtoReflect.Descriptor instead.
+func (*CreateActionArgs) Descriptor() ([]byte, []int) {
+	return file_wallet_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *CreateActionArgs) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *CreateActionArgs) GetInputBeef() []byte {
+	if x != nil {
+		return x.InputBeef
+	}
+	return nil
+}
+
+func (x *CreateActionArgs) GetInputs() []*CreateActionInput {
+	if x != nil {
+		return x.Inputs
+	}
+	return nil
+}
+
+func (x *CreateActionArgs) GetOutputs() []*CreateActionOutput {
+	if x != nil {
+		return x.Outputs
+	}
+	return nil
+}
+
+func (x *CreateActionArgs) GetLockTime() uint32 {
+	if x != nil && x.LockTime != nil {
+		return *x.LockTime
+	}
+	return 0
+}
+
+func (x *CreateActionArgs) GetVersion() uint32 {
+	if x != nil && x.Version != nil {
+		return *x.Version
+	}
+	return 0
+}
+
+func (x *CreateActionArgs) GetLabels() []string {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+func (x *CreateActionArgs) GetOptions() *CreateActionOptions {
+	if x != nil {
+		return x.Options
+	}
+	return nil
+}
+
+type SendWithResult struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Txid          []byte                 `protobuf:"bytes,1,opt,name=txid,proto3" json:"txid,omitempty"`
+	Status        string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SendWithResult) Reset() {
+	*x = SendWithResult{}
+	mi := &file_wallet_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SendWithResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SendWithResult) ProtoMessage() {}
+
+func (x *SendWithResult) ProtoReflect() protoreflect.Message {
+	mi := &file_wallet_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SendWithResult.ProtoReflect.Descriptor instead.
+func (*SendWithResult) Descriptor() ([]byte, []int) {
+	return file_wallet_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *SendWithResult) GetTxid() []byte {
+	if x != nil {
+		return x.Txid
+	}
+	return nil
+}
+
+func (x *SendWithResult) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+type SignableTransaction struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Tx            []byte                 `protobuf:"bytes,1,opt,name=tx,proto3" json:"tx,omitempty"`
+	Reference     []byte                 `protobuf:"bytes,2,opt,name=reference,proto3" json:"reference,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SignableTransaction) Reset() {
+	*x = SignableTransaction{}
+	mi := &file_wallet_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SignableTransaction) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SignableTransaction) ProtoMessage() {}
+
+func (x *SignableTransaction) ProtoReflect() protoreflect.Message {
+	mi := &file_wallet_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SignableTransaction.ProtoReflect.Descriptor instead.
+func (*SignableTransaction) Descriptor() ([]byte, []int) {
+	return file_wallet_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *SignableTransaction) GetTx() []byte {
+	if x != nil {
+		return x.Tx
+	}
+	return nil
+}
+
+func (x *SignableTransaction) GetReference() []byte {
+	if x != nil {
+		return x.Reference
+	}
+	return nil
+}
+
+type CreateActionResult struct {
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	Txid                []byte                 `protobuf:"bytes,1,opt,name=txid,proto3" json:"txid,omitempty"`
+	Tx                  []byte                 `protobuf:"bytes,2,opt,name=tx,proto3" json:"tx,omitempty"`
+	NoSendChange        []*Outpoint            `protobuf:"bytes,3,rep,name=no_send_change,json=noSendChange,proto3" json:"no_send_change,omitempty"`
+	SendWithResults     []*SendWithResult      `protobuf:"bytes,4,rep,name=send_with_results,json=sendWithResults,proto3" json:"send_with_results,omitempty"`
+	SignableTransaction *SignableTransaction   `protobuf:"bytes,5,opt,name=signable_transaction,json=signableTransaction,proto3" json:"signable_transaction,omitempty"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *CreateActionResult) Reset() {
+	*x = CreateActionResult{}
+	mi := &file_wallet_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateActionResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateActionResult) ProtoMessage() {}
+
+func (x *CreateActionResult) ProtoReflect() protoreflect.Message {
+	mi := &file_wallet_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateActionResult.ProtoReflect.Descriptor instead.
+func (*CreateActionResult) Descriptor() ([]byte, []int) {
+	return file_wallet_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *CreateActionResult) GetTxid() []byte {
+	if x != nil {
+		return x.Txid
+	}
+	return nil
+}
+
+func (x *CreateActionResult) GetTx() []byte {
+	if x != nil {
+		return x.Tx
+	}
+	return nil
+}
+
+func (x *CreateActionResult) GetNoSendChange() []*Outpoint {
+	if x != nil {
+		return x.NoSendChange
+	}
+	return nil
+}
+
+func (x *CreateActionResult) GetSendWithResults() []*SendWithResult {
+	if x != nil {
+		return x.SendWithResults
+	}
+	return nil
+}
+
+func (x *CreateActionResult) GetSignableTransaction() *SignableTransaction {
+	if x != nil {
+		return x.SignableTransaction
+	}
+	return nil
+}
+
+type SignActionSpend struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	UnlockingScript []byte                 `protobuf:"bytes,1,opt,name=unlocking_script,json=unlockingScript,proto3" json:"unlocking_script,omitempty"`
+	SequenceNumber  *uint32                `protobuf:"varint,2,opt,name=sequence_number,json=sequenceNumber,proto3,oneof" json:"sequence_number,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *SignActionSpend) Reset() {
+	*x = SignActionSpend{}
+	mi := &file_wallet_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SignActionSpend) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SignActionSpend) ProtoMessage() {}
+
+func (x *SignActionSpend) ProtoReflect() protoreflect.Message {
+	mi := &file_wallet_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SignActionSpend.ProtoReflect.Descriptor instead.
+func (*SignActionSpend) Descriptor() ([]byte, []int) {
+	return file_wallet_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *SignActionSpend) GetUnlockingScript() []byte {
+	if x != nil {
+		return x.UnlockingScript
+	}
+	return nil
+}
+
+func (x *SignActionSpend) GetSequenceNumber() uint32 {
+	if x != nil && x.SequenceNumber != nil {
+		return *x.SequenceNumber
+	}
+	return 0
+}
+
+type SignActionOptions struct {
+	state                  protoimpl.MessageState `protogen:"open.v1"`
+	AcceptDelayedBroadcast *bool                  `protobuf:"varint,1,opt,name=accept_delayed_broadcast,json=acceptDelayedBroadcast,proto3,oneof" json:"accept_delayed_broadcast,omitempty"`
+	ReturnTxidOnly         *bool                  `protobuf:"varint,2,opt,name=return_txid_only,json=returnTxidOnly,proto3,oneof" json:"return_txid_only,omitempty"`
+	NoSend                 *bool                  `protobuf:"varint,3,opt,name=no_send,json=noSend,proto3,oneof" json:"no_send,omitempty"`
+	SendWith               [][]byte               `protobuf:"bytes,4,rep,name=send_with,json=sendWith,proto3" json:"send_with,omitempty"`
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *SignActionOptions) Reset() {
+	*x = SignActionOptions{}
+	mi := &file_wallet_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SignActionOptions) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SignActionOptions) ProtoMessage() {}
+
+func (x *SignActionOptions) ProtoReflect() protoreflect.Message {
+	mi := &file_wallet_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SignActionOptions.ProtoReflect.Descriptor instead.
+func (*SignActionOptions) Descriptor() ([]byte, []int) {
+	return file_wallet_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *SignActionOptions) GetAcceptDelayedBroadcast() bool {
+	if x != nil && x.AcceptDelayedBroadcast != nil {
+		return *x.AcceptDelayedBroadcast
+	}
+	return false
+}
+
+func (x *SignActionOptions) GetReturnTxidOnly() bool {
+	if x != nil && x.ReturnTxidOnly != nil {
+		return *x.ReturnTxidOnly
+	}
+	return false
+}
+
+func (x *SignActionOptions) GetNoSend() bool {
+	if x != nil && x.NoSend != nil {
+		return *x.NoSend
+	}
+	return false
+}
+
+func (x *SignActionOptions) GetSendWith() [][]byte {
+	if x != nil {
+		return x.SendWith
+	}
+	return nil
+}
+
+type SignActionArgs struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Reference []byte                 `protobuf:"bytes,1,opt,name=reference,proto3" json:"reference,omitempty"`
+	// spends are keyed by input index.
+	Spends        map[uint32]*SignActionSpend `protobuf:"bytes,2,rep,name=spends,proto3" json:"spends,omitempty" protobuf_key:"varint,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Options       *SignActionOptions          `protobuf:"bytes,3,opt,name=options,proto3" json:"options,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SignActionArgs) Reset() {
+	*x = SignActionArgs{}
+	mi := &file_wallet_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SignActionArgs) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SignActionArgs) ProtoMessage() {}
+
+func (x *SignActionArgs) ProtoReflect() protoreflect.Message {
+	mi := &file_wallet_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SignActionArgs.ProtoReflect.Descriptor instead.
+func (*SignActionArgs) Descriptor() ([]byte, []int) {
+	return file_wallet_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *SignActionArgs) GetReference() []byte {
+	if x != nil {
+		return x.Reference
+	}
+	return nil
+}
+
+func (x *SignActionArgs) GetSpends() map[uint32]*SignActionSpend {
+	if x != nil {
+		return x.Spends
+	}
+	return nil
+}
+
+func (x *SignActionArgs) GetOptions() *SignActionOptions {
+	if x != nil {
+		return x.Options
+	}
+	return nil
+}
+
+type SignActionResult struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Txid            []byte                 `protobuf:"bytes,1,opt,name=txid,proto3" json:"txid,omitempty"`
+	Tx              []byte                 `protobuf:"bytes,2,opt,name=tx,proto3" json:"tx,omitempty"`
+	SendWithResults []*SendWithResult      `protobuf:"bytes,3,rep,name=send_with_results,json=sendWithResults,proto3" json:"send_with_results,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *SignActionResult) Reset() {
+	*x = SignActionResult{}
+	mi := &file_wallet_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SignActionResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SignActionResult) ProtoMessage() {}
+
+func (x *SignActionResult) ProtoReflect() protoreflect.Message {
+	mi := &file_wallet_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SignActionResult.ProtoReflect.Descriptor instead.
+func (*SignActionResult) Descriptor() ([]byte, []int) {
+	return file_wallet_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *SignActionResult) GetTxid() []byte {
+	if x != nil {
+		return x.Txid
+	}
+	return nil
+}
+
+func (x *SignActionResult) GetTx() []byte {
+	if x != nil {
+		return x.Tx
+	}
+	return nil
+}
+
+func (x *SignActionResult) GetSendWithResults() []*SendWithResult {
+	if x != nil {
+		return x.SendWithResults
+	}
+	return nil
+}
+
+type AbortActionArgs struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Reference     []byte                 `protobuf:"bytes,1,opt,name=reference,proto3" json:"reference,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AbortActionArgs) Reset() {
+	*x = AbortActionArgs{}
+	mi := &file_wallet_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AbortActionArgs) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AbortActionArgs) ProtoMessage() {}
+
+func (x *AbortActionArgs) ProtoReflect() protoreflect.Message {
+	mi := &file_wallet_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AbortActionArgs.ProtoReflect.Descriptor instead.
+func (*AbortActionArgs) Descriptor() ([]byte, []int) {
+	return file_wallet_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *AbortActionArgs) GetReference() []byte {
+	if x != nil {
+		return x.Reference
+	}
+	return nil
+}
+
+type AbortActionResult struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Aborted       bool                   `protobuf:"varint,1,opt,name=aborted,proto3" json:"aborted,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AbortActionResult) Reset() {
+	*x = AbortActionResult{}
+	mi := &file_wallet_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AbortActionResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AbortActionResult) ProtoMessage() {}
+
+func (x *AbortActionResult) ProtoReflect() protoreflect.Message {
+	mi := &file_wallet_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AbortActionResult.ProtoReflect.Descriptor instead.
+func (*AbortActionResult) Descriptor() ([]byte, []int) {
+	return file_wallet_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *AbortActionResult) GetAborted() bool {
+	if x != nil {
+		return x.Aborted
+	}
+	return false
+}
+
+type ListActionsArgs struct {
+	state                            protoimpl.MessageState `protogen:"open.v1"`
+	Labels                           []string               `protobuf:"bytes,1,rep,name=labels,proto3" json:"labels,omitempty"`
+	LabelQueryMode                   string                 `protobuf:"bytes,2,opt,name=label_query_mode,json=labelQueryMode,proto3" json:"label_query_mode,omitempty"`
+	IncludeLabels                    *bool                  `protobuf:"varint,3,opt,name=include_labels,json=includeLabels,proto3,oneof" json:"include_labels,omitempty"`
+	IncludeInputs                    *bool                  `protobuf:"varint,4,opt,name=include_inputs,json=includeInputs,proto3,oneof" json:"include_inputs,omitempty"`
+	IncludeInputSourceLockingScripts *bool                  `protobuf:"varint,5,opt,name=include_input_source_locking_scripts,json=includeInputSourceLockingScripts,proto3,oneof" json:"include_input_source_locking_scripts,omitempty"`
+	IncludeInputUnlockingScripts     *bool                  `protobuf:"varint,6,opt,name=include_input_unlocking_scripts,json=includeInputUnlockingScripts,proto3,oneof" json:"include_input_unlocking_scripts,omitempty"`
+	IncludeOutputs                   *bool                  `protobuf:"varint,7,opt,name=include_outputs,json=includeOutputs,proto3,oneof" json:"include_outputs,omitempty"`
+	IncludeOutputLockingScripts      *bool                  `protobuf:"varint,8,opt,name=include_output_locking_scripts,json=includeOutputLockingScripts,proto3,oneof" json:"include_output_locking_scripts,omitempty"`
+	Limit                            *uint32                `protobuf:"varint,9,opt,name=limit,proto3,oneof" json:"limit,omitempty"`
+	Offset                           *uint32                `protobuf:"varint,10,opt,name=offset,proto3,oneof" json:"offset,omitempty"`
+	SeekPermission                   *bool                  `protobuf:"varint,11,opt,name=seek_permission,json=seekPermission,proto3,oneof" json:"seek_permission,omitempty"`
+	Cursor                           string                 `protobuf:"bytes,12,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	unknownFields                    protoimpl.UnknownFields
+	sizeCache                        protoimpl.SizeCache
+}
+
+func (x *ListActionsArgs) Reset() {
+	*x = ListActionsArgs{}
+	mi := &file_wallet_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListActionsArgs) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListActionsArgs) ProtoMessage() {}
+
+func (x *ListActionsArgs) ProtoReflect() protoreflect.Message {
+	mi := &file_wallet_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListActionsArgs.ProtoReflect.Descriptor instead.
+func (*ListActionsArgs) Descriptor() ([]byte, []int) {
+	return file_wallet_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *ListActionsArgs) GetLabels() []string {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+func (x *ListActionsArgs) GetLabelQueryMode() string {
+	if x != nil {
+		return x.LabelQueryMode
+	}
+	return ""
+}
+
+func (x *ListActionsArgs) GetIncludeLabels() bool {
+	if x != nil && x.IncludeLabels != nil {
+		return *x.IncludeLabels
+	}
+	return false
+}
+
+func (x *ListActionsArgs) GetIncludeInputs() bool {
+	if x != nil && x.IncludeInputs != nil {
+		return *x.IncludeInputs
+	}
+	return false
+}
+
+func (x *ListActionsArgs) GetIncludeInputSourceLockingScripts() bool {
+	if x != nil && x.IncludeInputSourceLockingScripts != nil {
+		return *x.IncludeInputSourceLockingScripts
+	}
+	return false
+}
+
+func (x *ListActionsArgs) GetIncludeInputUnlockingScripts() bool {
+	if x != nil && x.IncludeInputUnlockingScripts != nil {
+		return *x.IncludeInputUnlockingScripts
+	}
+	return false
+}
+
+func (x *ListActionsArgs) GetIncludeOutputs() bool {
+	if x != nil && x.IncludeOutputs != nil {
+		return *x.IncludeOutputs
+	}
+	return false
+}
+
+func (x *ListActionsArgs) GetIncludeOutputLockingScripts() bool {
+	if x != nil && x.IncludeOutputLockingScripts != nil {
+		return *x.IncludeOutputLockingScripts
+	}
+	return false
+}
+
+func (x *ListActionsArgs) GetLimit() uint32 {
+	if x != nil && x.Limit != nil {
+		return *x.Limit
+	}
+	return 0
+}
+
+func (x *ListActionsArgs) GetOffset() uint32 {
+	if x != nil && x.Offset != nil {
+		return *x.Offset
+	}
+	return 0
+}
+
+func (x *ListActionsArgs) GetSeekPermission() bool {
+	if x != nil && x.SeekPermission != nil {
+		return *x.SeekPermission
+	}
+	return false
+}
+
+func (x *ListActionsArgs) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+type ActionInput struct {
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	SourceOutpoint      *Outpoint              `protobuf:"bytes,1,opt,name=source_outpoint,json=sourceOutpoint,proto3" json:"source_outpoint,omitempty"`
+	SourceSatoshis      uint64                 `protobuf:"varint,2,opt,name=source_satoshis,json=sourceSatoshis,proto3" json:"source_satoshis,omitempty"`
+	SourceLockingScript []byte                 `protobuf:"bytes,3,opt,name=source_locking_script,json=sourceLockingScript,proto3" json:"source_locking_script,omitempty"`
+	UnlockingScript     []byte                 `protobuf:"bytes,4,opt,name=unlocking_script,json=unlockingScript,proto3" json:"unlocking_script,omitempty"`
+	InputDescription    string                 `protobuf:"bytes,5,opt,name=input_description,json=inputDescription,proto3" json:"input_description,omitempty"`
+	SequenceNumber      uint32                 `protobuf:"varint,6,opt,name=sequence_number,json=sequenceNumber,proto3" json:"sequence_number,omitempty"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *ActionInput) Reset() {
+	*x = ActionInput{}
+	mi := &file_wallet_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ActionInput) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ActionInput) ProtoMessage() {}
+
+func (x *ActionInput) ProtoReflect() protoreflect.Message {
+	mi := &file_wallet_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ActionInput.ProtoReflect.Descriptor instead.
+func (*ActionInput) Descriptor() ([]byte, []int) {
+	return file_wallet_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *ActionInput) GetSourceOutpoint() *Outpoint {
+	if x != nil {
+		return x.SourceOutpoint
+	}
+	return nil
+}
+
+func (x *ActionInput) GetSourceSatoshis() uint64 {
+	if x != nil {
+		return x.SourceSatoshis
+	}
+	return 0
+}
+
+func (x *ActionInput) GetSourceLockingScript() []byte {
+	if x != nil {
+		return x.SourceLockingScript
+	}
+	return nil
+}
+
+func (x *ActionInput) GetUnlockingScript() []byte {
+	if x != nil {
+		return x.UnlockingScript
+	}
+	return nil
+}
+
+func (x *ActionInput) GetInputDescription() string {
+	if x != nil {
+		return x.InputDescription
+	}
+	return ""
+}
+
+func (x *ActionInput) GetSequenceNumber() uint32 {
+	if x != nil {
+		return x.SequenceNumber
+	}
+	return 0
+}
+
+type ActionOutput struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	Satoshis           uint64                 `protobuf:"varint,1,opt,name=satoshis,proto3" json:"satoshis,omitempty"`
+	LockingScript      []byte                 `protobuf:"bytes,2,opt,name=locking_script,json=lockingScript,proto3" json:"locking_script,omitempty"`
+	Spendable          bool                   `protobuf:"varint,3,opt,name=spendable,proto3" json:"spendable,omitempty"`
+	CustomInstructions string                 `protobuf:"bytes,4,opt,name=custom_instructions,json=customInstructions,proto3" json:"custom_instructions,omitempty"`
+	Tags               []string               `protobuf:"bytes,5,rep,name=tags,proto3" json:"tags,omitempty"`
+	OutputIndex        uint32                 `protobuf:"varint,6,opt,name=output_index,json=outputIndex,proto3" json:"output_index,omitempty"`
+	OutputDescription  string                 `protobuf:"bytes,7,opt,name=output_description,json=outputDescription,proto3" json:"output_description,omitempty"`
+	Basket             string                 `protobuf:"bytes,8,opt,name=basket,proto3" json:"basket,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *ActionOutput) Reset() {
+	*x = ActionOutput{}
+	mi := &file_wallet_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ActionOutput) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ActionOutput) ProtoMessage() {}
+
+func (x *ActionOutput) ProtoReflect() protoreflect.Message {
+	mi := &file_wallet_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ActionOutput.ProtoReflect.Descriptor instead.
+func (*ActionOutput) Descriptor() ([]byte, []int) {
+	return file_wallet_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *ActionOutput) GetSatoshis() uint64 {
+	if x != nil {
+		return x.Satoshis
+	}
+	return 0
+}
+
+func (x *ActionOutput) GetLockingScript() []byte {
+	if x != nil {
+		return x.LockingScript
+	}
+	return nil
+}
+
+func (x *ActionOutput) GetSpendable() bool {
+	if x != nil {
+		return x.Spendable
+	}
+	return false
+}
+
+func (x *ActionOutput) GetCustomInstructions() string {
+	if x != nil {
+		return x.CustomInstructions
+	}
+	return ""
+}
+
+func (x *ActionOutput) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *ActionOutput) GetOutputIndex() uint32 {
+	if x != nil {
+		return x.OutputIndex
+	}
+	return 0
+}
+
+func (x *ActionOutput) GetOutputDescription() string {
+	if x != nil {
+		return x.OutputDescription
+	}
+	return ""
+}
+
+func (x *ActionOutput) GetBasket() string {
+	if x != nil {
+		return x.Basket
+	}
+	return ""
+}
+
+type Action struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Txid          []byte                 `protobuf:"bytes,1,opt,name=txid,proto3" json:"txid,omitempty"`
+	Satoshis      int64                  `protobuf:"varint,2,opt,name=satoshis,proto3" json:"satoshis,omitempty"`
+	Status        string                 `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"`
+	IsOutgoing    bool                   `protobuf:"varint,4,opt,name=is_outgoing,json=isOutgoing,proto3" json:"is_outgoing,omitempty"`
+	Description   string                 `protobuf:"bytes,5,opt,name=description,proto3" json:"description,omitempty"`
+	Labels        []string               `protobuf:"bytes,6,rep,name=labels,proto3" json:"labels,omitempty"`
+	Version       uint32                 `protobuf:"varint,7,opt,name=version,proto3" json:"version,omitempty"`
+	LockTime      uint32                 `protobuf:"varint,8,opt,name=lock_time,json=lockTime,proto3" json:"lock_time,omitempty"`
+	Inputs        []*ActionInput         `protobuf:"bytes,9,rep,name=inputs,proto3" json:"inputs,omitempty"`
+	Outputs       []*ActionOutput        `protobuf:"bytes,10,rep,name=outputs,proto3" json:"outputs,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Action) Reset() {
+	*x = Action{}
+	mi := &file_wallet_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Action) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Action) ProtoMessage() {}
+
+func (x *Action) ProtoReflect() protoreflect.Message {
+	mi := &file_wallet_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Action.ProtoReflect.Descriptor instead.
+func (*Action) Descriptor() ([]byte, []int) {
+	return file_wallet_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *Action) GetTxid() []byte {
+	if x != nil {
+		return x.Txid
+	}
+	return nil
+}
+
+func (x *Action) GetSatoshis() int64 {
+	if x != nil {
+		return x.Satoshis
+	}
+	return 0
+}
+
+func (x *Action) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *Action) GetIsOutgoing() bool {
+	if x != nil {
+		return x.IsOutgoing
+	}
+	return false
+}
+
+func (x *Action) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *Action) GetLabels() []string {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+func (x *Action) GetVersion() uint32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *Action) GetLockTime() uint32 {
+	if x != nil {
+		return x.LockTime
+	}
+	return 0
+}
+
+func (x *Action) GetInputs() []*ActionInput {
+	if x != nil {
+		return x.Inputs
+	}
+	return nil
+}
+
+func (x *Action) GetOutputs() []*ActionOutput {
+	if x != nil {
+		return x.Outputs
+	}
+	return nil
+}
+
+type ListActionsResult struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TotalActions  uint32                 `protobuf:"varint,1,opt,name=total_actions,json=totalActions,proto3" json:"total_actions,omitempty"`
+	Actions       []*Action              `protobuf:"bytes,2,rep,name=actions,proto3" json:"actions,omitempty"`
+	NextCursor    string                 `protobuf:"bytes,3,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListActionsResult) Reset() {
+	*x = ListActionsResult{}
+	mi := &file_wallet_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListActionsResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListActionsResult) ProtoMessage() {}
+
+func (x *ListActionsResult) ProtoReflect() protoreflect.Message {
+	mi := &file_wallet_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListActionsResult.ProtoReflect.Descriptor instead.
+func (*ListActionsResult) Descriptor() ([]byte, []int) {
+	return file_wallet_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *ListActionsResult) GetTotalActions() uint32 {
+	if x != nil {
+		return x.TotalActions
+	}
+	return 0
+}
+
+func (x *ListActionsResult) GetActions() []*Action {
+	if x != nil {
+		return x.Actions
+	}
+	return nil
+}
+
+func (x *ListActionsResult) GetNextCursor() string {
+	if x != nil {
+		return x.NextCursor
+	}
+	return ""
+}
+
+type Payment struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	DerivationPrefix  []byte                 `protobuf:"bytes,1,opt,name=derivation_prefix,json=derivationPrefix,proto3" json:"derivation_prefix,omitempty"`
+	DerivationSuffix  []byte                 `protobuf:"bytes,2,opt,name=derivation_suffix,json=derivationSuffix,proto3" json:"derivation_suffix,omitempty"`
+	SenderIdentityKey []byte                 `protobuf:"bytes,3,opt,name=sender_identity_key,json=senderIdentityKey,proto3" json:"sender_identity_key,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *Payment) Reset() {
+	*x = Payment{}
+	mi := &file_wallet_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Payment) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Payment) ProtoMessage() {}
+
+func (x *Payment) ProtoReflect() protoreflect.Message {
+	mi := &file_wallet_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Payment.ProtoReflect.Descriptor instead.
+func (*Payment) Descriptor() ([]byte, []int) {
+	return file_wallet_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *Payment) GetDerivationPrefix() []byte {
+	if x != nil {
+		return x.DerivationPrefix
+	}
+	return nil
+}
+
+func (x *Payment) GetDerivationSuffix() []byte {
+	if x != nil {
+		return x.DerivationSuffix
+	}
+	return nil
+}
+
+func (x *Payment) GetSenderIdentityKey() []byte {
+	if x != nil {
+		return x.SenderIdentityKey
+	}
+	return nil
+}
+
+type BasketInsertion struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	Basket             string                 `protobuf:"bytes,1,opt,name=basket,proto3" json:"basket,omitempty"`
+	CustomInstructions string                 `protobuf:"bytes,2,opt,name=custom_instructions,json=customInstructions,proto3" json:"custom_instructions,omitempty"`
+	Tags               []string               `protobuf:"bytes,3,rep,name=tags,proto3" json:"tags,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *BasketInsertion) Reset() {
+	*x = BasketInsertion{}
+	mi := &file_wallet_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BasketInsertion) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BasketInsertion) ProtoMessage() {}
+
+func (x *BasketInsertion) ProtoReflect() protoreflect.Message {
+	mi := &file_wallet_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BasketInsertion.ProtoReflect.Descriptor instead.
+func (*BasketInsertion) Descriptor() ([]byte, []int) {
+	return file_wallet_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *BasketInsertion) GetBasket() string {
+	if x != nil {
+		return x.Basket
+	}
+	return ""
+}
+
+func (x *BasketInsertion) GetCustomInstructions() string {
+	if x != nil {
+		return x.CustomInstructions
+	}
+	return ""
+}
+
+func (x *BasketInsertion) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+type InternalizeOutput struct {
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	OutputIndex         uint32                 `protobuf:"varint,1,opt,name=output_index,json=outputIndex,proto3" json:"output_index,omitempty"`
+	Protocol            string                 `protobuf:"bytes,2,opt,name=protocol,proto3" json:"protocol,omitempty"`
+	PaymentRemittance   *Payment               `protobuf:"bytes,3,opt,name=payment_remittance,json=paymentRemittance,proto3" json:"payment_remittance,omitempty"`
+	InsertionRemittance *BasketInsertion       `protobuf:"bytes,4,opt,name=insertion_remittance,json=insertionRemittance,proto3" json:"insertion_remittance,omitempty"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *InternalizeOutput) Reset() {
+	*x = InternalizeOutput{}
+	mi := &file_wallet_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InternalizeOutput) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InternalizeOutput) ProtoMessage() {}
+
+func (x *InternalizeOutput) ProtoReflect() protoreflect.Message {
+	mi := &file_wallet_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InternalizeOutput.ProtoReflect.Descriptor instead.
+func (*InternalizeOutput) Descriptor() ([]byte, []int) {
+	return file_wallet_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *InternalizeOutput) GetOutputIndex() uint32 {
+	if x != nil {
+		return x.OutputIndex
+	}
+	return 0
+}
+
+func (x *InternalizeOutput) GetProtocol() string {
+	if x != nil {
+		return x.Protocol
+	}
+	return ""
+}
+
+func (x *InternalizeOutput) GetPaymentRemittance() *Payment {
+	if x != nil {
+		return x.PaymentRemittance
+	}
+	return nil
+}
+
+func (x *InternalizeOutput) GetInsertionRemittance() *BasketInsertion {
+	if x != nil {
+		return x.InsertionRemittance
+	}
+	return nil
+}
+
+type InternalizeActionArgs struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Tx             []byte                 `protobuf:"bytes,1,opt,name=tx,proto3" json:"tx,omitempty"`
+	Description    string                 `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	Labels         []string               `protobuf:"bytes,3,rep,name=labels,proto3" json:"labels,omitempty"`
+	SeekPermission *bool                  `protobuf:"varint,4,opt,name=seek_permission,json=seekPermission,proto3,oneof" json:"seek_permission,omitempty"`
+	Outputs        []*InternalizeOutput   `protobuf:"bytes,5,rep,name=outputs,proto3" json:"outputs,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *InternalizeActionArgs) Reset() {
+	*x = InternalizeActionArgs{}
+	mi := &file_wallet_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InternalizeActionArgs) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InternalizeActionArgs) ProtoMessage() {}
+
+func (x *InternalizeActionArgs) ProtoReflect() protoreflect.Message {
+	mi := &file_wallet_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InternalizeActionArgs.ProtoReflect.Descriptor instead.
+func (*InternalizeActionArgs) Descriptor() ([]byte, []int) {
+	return file_wallet_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *InternalizeActionArgs) GetTx() []byte {
+	if x != nil {
+		return x.Tx
+	}
+	return nil
+}
+
+func (x *InternalizeActionArgs) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *InternalizeActionArgs) GetLabels() []string {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+func (x *InternalizeActionArgs) GetSeekPermission() bool {
+	if x != nil && x.SeekPermission != nil {
+		return *x.SeekPermission
+	}
+	return false
+}
+
+func (x *InternalizeActionArgs) GetOutputs() []*InternalizeOutput {
+	if x != nil {
+		return x.Outputs
+	}
+	return nil
+}
+
+type InternalizeActionResult struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Accepted      bool                   `protobuf:"varint,1,opt,name=accepted,proto3" json:"accepted,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *InternalizeActionResult) Reset() {
+	*x = InternalizeActionResult{}
+	mi := &file_wallet_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InternalizeActionResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InternalizeActionResult) ProtoMessage() {}
+
+func (x *InternalizeActionResult) ProtoReflect() protoreflect.Message {
+	mi := &file_wallet_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InternalizeActionResult.ProtoReflect.Descriptor instead.
+func (*InternalizeActionResult) Descriptor() ([]byte, []int) {
+	return file_wallet_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *InternalizeActionResult) GetAccepted() bool {
+	if x != nil {
+		return x.Accepted
+	}
+	return false
+}
+
+type ListOutputsArgs struct {
+	state                     protoimpl.MessageState `protogen:"open.v1"`
+	Basket                    string                 `protobuf:"bytes,1,opt,name=basket,proto3" json:"basket,omitempty"`
+	Tags                      []string               `protobuf:"bytes,2,rep,name=tags,proto3" json:"tags,omitempty"`
+	TagQueryMode              string                 `protobuf:"bytes,3,opt,name=tag_query_mode,json=tagQueryMode,proto3" json:"tag_query_mode,omitempty"`
+	Include                   string                 `protobuf:"bytes,4,opt,name=include,proto3" json:"include,omitempty"`
+	IncludeCustomInstructions *bool                  `protobuf:"varint,5,opt,name=include_custom_instructions,json=includeCustomInstructions,proto3,oneof" json:"include_custom_instructions,omitempty"`
+	IncludeTags               *bool                  `protobuf:"varint,6,opt,name=include_tags,json=includeTags,proto3,oneof" json:"include_tags,omitempty"`
+	IncludeLabels             *bool                  `protobuf:"varint,7,opt,name=include_labels,json=includeLabels,proto3,oneof" json:"include_labels,omitempty"`
+	Limit                     *uint32                `protobuf:"varint,8,opt,name=limit,proto3,oneof" json:"limit,omitempty"`
+	Offset                    *uint32                `protobuf:"varint,9,opt,name=offset,proto3,oneof" json:"offset,omitempty"`
+	SeekPermission            *bool                  `protobuf:"varint,10,opt,name=seek_permission,json=seekPermission,proto3,oneof" json:"seek_permission,omitempty"`
+	MinSatoshis               *uint64                `protobuf:"varint,11,opt,name=min_satoshis,json=minSatoshis,proto3,oneof" json:"min_satoshis,omitempty"`
+	MaxSatoshis               *uint64                `protobuf:"varint,12,opt,name=max_satoshis,json=maxSatoshis,proto3,oneof" json:"max_satoshis,omitempty"`
+	ScriptTypes               []string               `protobuf:"bytes,13,rep,name=script_types,json=scriptTypes,proto3" json:"script_types,omitempty"`
+	SortOrder                 string                 `protobuf:"bytes,14,opt,name=sort_order,json=sortOrder,proto3" json:"sort_order,omitempty"`
+	Cursor                    string                 `protobuf:"bytes,15,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	unknownFields             protoimpl.UnknownFields
+	sizeCache                 protoimpl.SizeCache
+}
+
+func (x *ListOutputsArgs) Reset() {
+	*x = ListOutputsArgs{}
+	mi := &file_wallet_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListOutputsArgs) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListOutputsArgs) ProtoMessage() {}
+
+func (x *ListOutputsArgs) ProtoReflect() protoreflect.Message {
+	mi := &file_wallet_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListOutputsArgs.ProtoReflect.Descriptor instead.
+func (*ListOutputsArgs) Descriptor() ([]byte, []int) {
+	return file_wallet_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *ListOutputsArgs) GetBasket() string {
+	if x != nil {
+		return x.Basket
+	}
+	return ""
+}
+
+func (x *ListOutputsArgs) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *ListOutputsArgs) GetTagQueryMode() string {
+	if x != nil {
+		return x.TagQueryMode
+	}
+	return ""
+}
+
+func (x *ListOutputsArgs) GetInclude() string {
+	if x != nil {
+		return x.Include
+	}
+	return ""
+}
+
+func (x *ListOutputsArgs) GetIncludeCustomInstructions() bool {
+	if x != nil && x.IncludeCustomInstructions != nil {
+		return *x.IncludeCustomInstructions
+	}
+	return false
+}
+
+func (x *ListOutputsArgs) GetIncludeTags() bool {
+	if x != nil && x.IncludeTags != nil {
+		return *x.IncludeTags
+	}
+	return false
+}
+
+func (x *ListOutputsArgs) GetIncludeLabels() bool {
+	if x != nil && x.IncludeLabels != nil {
+		return *x.IncludeLabels
+	}
+	return false
+}
+
+func (x *ListOutputsArgs) GetLimit() uint32 {
+	if x != nil && x.Limit != nil {
+		return *x.Limit
+	}
+	return 0
+}
+
+func (x *ListOutputsArgs) GetOffset() uint32 {
+	if x != nil && x.Offset != nil {
+		return *x.Offset
+	}
+	return 0
+}
+
+func (x *ListOutputsArgs) GetSeekPermission() bool {
+	if x != nil && x.SeekPermission != nil {
+		return *x.SeekPermission
+	}
+	return false
+}
+
+func (x *ListOutputsArgs) GetMinSatoshis() uint64 {
+	if x != nil && x.MinSatoshis != nil {
+		return *x.MinSatoshis
+	}
+	return 0
+}
+
+func (x *ListOutputsArgs) GetMaxSatoshis() uint64 {
+	if x != nil && x.MaxSatoshis != nil {
+		return *x.MaxSatoshis
+	}
+	return 0
+}
+
+func (x *ListOutputsArgs) GetScriptTypes() []string {
+	if x != nil {
+		return x.ScriptTypes
+	}
+	return nil
+}
+
+func (x *ListOutputsArgs) GetSortOrder() string {
+	if x != nil {
+		return x.SortOrder
+	}
+	return ""
+}
+
+func (x *ListOutputsArgs) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+type Output struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	Satoshis           uint64                 `protobuf:"varint,1,opt,name=satoshis,proto3" json:"satoshis,omitempty"`
+	LockingScript      []byte                 `protobuf:"bytes,2,opt,name=locking_script,json=lockingScript,proto3" json:"locking_script,omitempty"`
+	Spendable          bool                   `protobuf:"varint,3,opt,name=spendable,proto3" json:"spendable,omitempty"`
+	CustomInstructions string                 `protobuf:"bytes,4,opt,name=custom_instructions,json=customInstructions,proto3" json:"custom_instructions,omitempty"`
+	Tags               []string               `protobuf:"bytes,5,rep,name=tags,proto3" json:"tags,omitempty"`
+	Outpoint           *Outpoint              `protobuf:"bytes,6,opt,name=outpoint,proto3" json:"outpoint,omitempty"`
+	Labels             []string               `protobuf:"bytes,7,rep,name=labels,proto3" json:"labels,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *Output) Reset() {
+	*x = Output{}
+	mi := &file_wallet_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Output) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Output) ProtoMessage() {}
+
+func (x *Output) ProtoReflect() protoreflect.Message {
+	mi := &file_wallet_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Output.ProtoReflect.Descriptor instead.
+func (*Output) Descriptor() ([]byte, []int) {
+	return file_wallet_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *Output) GetSatoshis() uint64 {
+	if x != nil {
+		return x.Satoshis
+	}
+	return 0
+}
+
+func (x *Output) GetLockingScript() []byte {
+	if x != nil {
+		return x.LockingScript
+	}
+	return nil
+}
+
+func (x *Output) GetSpendable() bool {
+	if x != nil {
+		return x.Spendable
+	}
+	return false
+}
+
+func (x *Output) GetCustomInstructions() string {
+	if x != nil {
+		return x.CustomInstructions
+	}
+	return ""
+}
+
+func (x *Output) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *Output) GetOutpoint() *Outpoint {
+	if x != nil {
+		return x.Outpoint
+	}
+	return nil
+}
+
+func (x *Output) GetLabels() []string {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+type ListOutputsResult struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TotalOutputs  uint32                 `protobuf:"varint,1,opt,name=total_outputs,json=totalOutputs,proto3" json:"total_outputs,omitempty"`
+	Beef          []byte                 `protobuf:"bytes,2,opt,name=beef,proto3" json:"beef,omitempty"`
+	Outputs       []*Output              `protobuf:"bytes,3,rep,name=outputs,proto3" json:"outputs,omitempty"`
+	NextCursor    string                 `protobuf:"bytes,4,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListOutputsResult) Reset() {
+	*x = ListOutputsResult{}
+	mi := &file_wallet_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListOutputsResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListOutputsResult) ProtoMessage() {}
+
+func (x *ListOutputsResult) ProtoReflect() protoreflect.Message {
+	mi := &file_wallet_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListOutputsResult.ProtoReflect.Descriptor instead.
+func (*ListOutputsResult) Descriptor() ([]byte, []int) {
+	return file_wallet_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *ListOutputsResult) GetTotalOutputs() uint32 {
+	if x != nil {
+		return x.TotalOutputs
+	}
+	return 0
+}
+
+func (x *ListOutputsResult) GetBeef() []byte {
+	if x != nil {
+		return x.Beef
+	}
+	return nil
+}
+
+func (x *ListOutputsResult) GetOutputs() []*Output {
+	if x != nil {
+		return x.Outputs
+	}
+	return nil
+}
+
+func (x *ListOutputsResult) GetNextCursor() string {
+	if x != nil {
+		return x.NextCursor
+	}
+	return ""
+}
+
+type RelinquishOutputArgs struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Basket        string                 `protobuf:"bytes,1,opt,name=basket,proto3" json:"basket,omitempty"`
+	Output        *Outpoint              `protobuf:"bytes,2,opt,name=output,proto3" json:"output,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RelinquishOutputArgs) Reset() {
+	*x = RelinquishOutputArgs{}
+	mi := &file_wallet_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RelinquishOutputArgs) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RelinquishOutputArgs) ProtoMessage() {}
+
+func (x *RelinquishOutputArgs) ProtoReflect() protoreflect.Message {
+	mi := &file_wallet_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RelinquishOutputArgs.ProtoReflect.Descriptor instead.
+func (*RelinquishOutputArgs) Descriptor() ([]byte, []int) {
+	return file_wallet_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *RelinquishOutputArgs) GetBasket() string {
+	if x != nil {
+		return x.Basket
+	}
+	return ""
+}
+
+func (x *RelinquishOutputArgs) GetOutput() *Outpoint {
+	if x != nil {
+		return x.Output
+	}
+	return nil
+}
+
+type RelinquishOutputResult struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Relinquished  bool                   `protobuf:"varint,1,opt,name=relinquished,proto3" json:"relinquished,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RelinquishOutputResult) Reset() {
+	*x = RelinquishOutputResult{}
+	mi := &file_wallet_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RelinquishOutputResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RelinquishOutputResult) ProtoMessage() {}
+
+func (x *RelinquishOutputResult) ProtoReflect() protoreflect.Message {
+	mi := &file_wallet_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RelinquishOutputResult.ProtoReflect.Descriptor instead.
+func (*RelinquishOutputResult) Descriptor() ([]byte, []int) {
+	return file_wallet_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *RelinquishOutputResult) GetRelinquished() bool {
+	if x != nil {
+		return x.Relinquished
+	}
+	return false
+}
+
+type GetPublicKeyArgs struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	EncryptionArgs *EncryptionArgs        `protobuf:"bytes,1,opt,name=encryption_args,json=encryptionArgs,proto3" json:"encryption_args,omitempty"`
+	IdentityKey    bool                   `protobuf:"varint,2,opt,name=identity_key,json=identityKey,proto3" json:"identity_key,omitempty"`
+	ForSelf        *bool                  `protobuf:"varint,3,opt,name=for_self,json=forSelf,proto3,oneof" json:"for_self,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *GetPublicKeyArgs) Reset() {
+	*x = GetPublicKeyArgs{}
+	mi := &file_wallet_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPublicKeyArgs) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPublicKeyArgs) ProtoMessage() {}
+
+func (x *GetPublicKeyArgs) ProtoReflect() protoreflect.Message {
+	mi := &file_wallet_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPublicKeyArgs.ProtoReflect.Descriptor instead.
+func (*GetPublicKeyArgs) Descriptor() ([]byte, []int) {
+	return file_wallet_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *GetPublicKeyArgs) GetEncryptionArgs() *EncryptionArgs {
+	if x != nil {
+		return x.EncryptionArgs
+	}
+	return nil
+}
+
+func (x *GetPublicKeyArgs) GetIdentityKey() bool {
+	if x != nil {
+		return x.IdentityKey
+	}
+	return false
+}
+
+func (x *GetPublicKeyArgs) GetForSelf() bool {
+	if x != nil && x.ForSelf != nil {
+		return *x.ForSelf
+	}
+	return false
+}
+
+type GetPublicKeyResult struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PublicKey     []byte                 `protobuf:"bytes,1,opt,name=public_key,json=publicKey,proto3" json:"public_key,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPublicKeyResult) Reset() {
+	*x = GetPublicKeyResult{}
+	mi := &file_wallet_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPublicKeyResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPublicKeyResult) ProtoMessage() {}
+
+func (x *GetPublicKeyResult) ProtoReflect() protoreflect.Message {
+	mi := &file_wallet_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPublicKeyResult.ProtoReflect.Descriptor instead.
+func (*GetPublicKeyResult) Descriptor() ([]byte, []int) {
+	return file_wallet_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *GetPublicKeyResult) GetPublicKey() []byte {
+	if x != nil {
+		return x.PublicKey
+	}
+	return nil
+}
+
+type RevealCounterpartyKeyLinkageArgs struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Counterparty     []byte                 `protobuf:"bytes,1,opt,name=counterparty,proto3" json:"counterparty,omitempty"`
+	Verifier         []byte                 `protobuf:"bytes,2,opt,name=verifier,proto3" json:"verifier,omitempty"`
+	Privileged       *bool                  `protobuf:"varint,3,opt,name=privileged,proto3,oneof" json:"privileged,omitempty"`
+	PrivilegedReason string                 `protobuf:"bytes,4,opt,name=privileged_reason,json=privilegedReason,proto3" json:"privileged_reason,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *RevealCounterpartyKeyLinkageArgs) Reset() {
+	*x = RevealCounterpartyKeyLinkageArgs{}
+	mi := &file_wallet_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevealCounterpartyKeyLinkageArgs) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevealCounterpartyKeyLinkageArgs) ProtoMessage() {}
+
+func (x *RevealCounterpartyKeyLinkageArgs) ProtoReflect() protoreflect.Message {
+	mi := &file_wallet_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevealCounterpartyKeyLinkageArgs.ProtoReflect.Descriptor instead.
+func (*RevealCounterpartyKeyLinkageArgs) Descriptor() ([]byte, []int) {
+	return file_wallet_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *RevealCounterpartyKeyLinkageArgs) GetCounterparty() []byte {
+	if x != nil {
+		return x.Counterparty
+	}
+	return nil
+}
+
+func (x *RevealCounterpartyKeyLinkageArgs) GetVerifier() []byte {
+	if x != nil {
+		return x.Verifier
+	}
+	return nil
+}
+
+func (x *RevealCounterpartyKeyLinkageArgs) GetPrivileged() bool {
+	if x != nil && x.Privileged != nil {
+		return *x.Privileged
+	}
+	return false
+}
+
+func (x *RevealCounterpartyKeyLinkageArgs) GetPrivilegedReason() string {
+	if x != nil {
+		return x.PrivilegedReason
+	}
+	return ""
+}
+
+type RevealCounterpartyKeyLinkageResult struct {
+	state                 protoimpl.MessageState `protogen:"open.v1"`
+	Prover                []byte                 `protobuf:"bytes,1,opt,name=prover,proto3" json:"prover,omitempty"`
+	Counterparty          []byte                 `protobuf:"bytes,2,opt,name=counterparty,proto3" json:"counterparty,omitempty"`
+	Verifier              []byte                 `protobuf:"bytes,3,opt,name=verifier,proto3" json:"verifier,omitempty"`
+	RevelationTime        string                 `protobuf:"bytes,4,opt,name=revelation_time,json=revelationTime,proto3" json:"revelation_time,omitempty"`
+	EncryptedLinkage      []byte                 `protobuf:"bytes,5,opt,name=encrypted_linkage,json=encryptedLinkage,proto3" json:"encrypted_linkage,omitempty"`
+	EncryptedLinkageProof []byte                 `protobuf:"bytes,6,opt,name=encrypted_linkage_proof,json=encryptedLinkageProof,proto3" json:"encrypted_linkage_proof,omitempty"`
+	unknownFields         protoimpl.UnknownFields
+	sizeCache             protoimpl.SizeCache
+}
+
+func (x *RevealCounterpartyKeyLinkageResult) Reset() {
+	*x = RevealCounterpartyKeyLinkageResult{}
+	mi := &file_wallet_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevealCounterpartyKeyLinkageResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevealCounterpartyKeyLinkageResult) ProtoMessage() {}
+
+func (x *RevealCounterpartyKeyLinkageResult) ProtoReflect() protoreflect.Message {
+	mi := &file_wallet_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevealCounterpartyKeyLinkageResult.ProtoReflect.Descriptor instead.
+func (*RevealCounterpartyKeyLinkageResult) Descriptor() ([]byte, []int) {
+	return file_wallet_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *RevealCounterpartyKeyLinkageResult) GetProver() []byte {
+	if x != nil {
+		return x.Prover
+	}
+	return nil
+}
+
+func (x *RevealCounterpartyKeyLinkageResult) GetCounterparty() []byte {
+	if x != nil {
+		return x.Counterparty
+	}
+	return nil
+}
+
+func (x *RevealCounterpartyKeyLinkageResult) GetVerifier() []byte {
+	if x != nil {
+		return x.Verifier
+	}
+	return nil
+}
+
+func (x *RevealCounterpartyKeyLinkageResult) GetRevelationTime() string {
+	if x != nil {
+		return x.RevelationTime
+	}
+	return ""
+}
+
+func (x *RevealCounterpartyKeyLinkageResult) GetEncryptedLinkage() []byte {
+	if x != nil {
+		return x.EncryptedLinkage
+	}
+	return nil
+}
+
+func (x *RevealCounterpartyKeyLinkageResult) GetEncryptedLinkageProof() []byte {
+	if x != nil {
+		return x.EncryptedLinkageProof
+	}
+	return nil
+}
+
+type RevealSpecificKeyLinkageArgs struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Counterparty     *Counterparty          `protobuf:"bytes,1,opt,name=counterparty,proto3" json:"counterparty,omitempty"`
+	Verifier         []byte                 `protobuf:"bytes,2,opt,name=verifier,proto3" json:"verifier,omitempty"`
+	ProtocolId       *Protocol              `protobuf:"bytes,3,opt,name=protocol_id,json=protocolId,proto3" json:"protocol_id,omitempty"`
+	KeyId            string                 `protobuf:"bytes,4,opt,name=key_id,json=keyId,proto3" json:"key_id,omitempty"`
+	Privileged       *bool                  `protobuf:"varint,5,opt,name=privileged,proto3,oneof" json:"privileged,omitempty"`
+	PrivilegedReason string                 `protobuf:"bytes,6,opt,name=privileged_reason,json=privilegedReason,proto3" json:"privileged_reason,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *RevealSpecificKeyLinkageArgs) Reset() {
+	*x = RevealSpecificKeyLinkageArgs{}
+	mi := &file_wallet_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevealSpecificKeyLinkageArgs) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevealSpecificKeyLinkageArgs) ProtoMessage() {}
+
+func (x *RevealSpecificKeyLinkageArgs) ProtoReflect() protoreflect.Message {
+	mi := &file_wallet_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevealSpecificKeyLinkageArgs.ProtoReflect.Descriptor instead.
+func (*RevealSpecificKeyLinkageArgs) Descriptor() ([]byte, []int) {
+	return file_wallet_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *RevealSpecificKeyLinkageArgs) GetCounterparty() *Counterparty {
+	if x != nil {
+		return x.Counterparty
+	}
+	return nil
+}
+
+func (x *RevealSpecificKeyLinkageArgs) GetVerifier() []byte {
+	if x != nil {
+		return x.Verifier
+	}
+	return nil
+}
+
+func (x *RevealSpecificKeyLinkageArgs) GetProtocolId() *Protocol {
+	if x != nil {
+		return x.ProtocolId
+	}
+	return nil
+}
+
+func (x *RevealSpecificKeyLinkageArgs) GetKeyId() string {
+	if x != nil {
+		return x.KeyId
+	}
+	return ""
+}
+
+func (x *RevealSpecificKeyLinkageArgs) GetPrivileged() bool {
+	if x != nil && x.Privileged != nil {
+		return *x.Privileged
+	}
+	return false
+}
+
+func (x *RevealSpecificKeyLinkageArgs) GetPrivilegedReason() string {
+	if x != nil {
+		return x.PrivilegedReason
+	}
+	return ""
+}
+
+type RevealSpecificKeyLinkageResult struct {
+	state                 protoimpl.MessageState `protogen:"open.v1"`
+	EncryptedLinkage      []byte                 `protobuf:"bytes,1,opt,name=encrypted_linkage,json=encryptedLinkage,proto3" json:"encrypted_linkage,omitempty"`
+	EncryptedLinkageProof []byte                 `protobuf:"bytes,2,opt,name=encrypted_linkage_proof,json=encryptedLinkageProof,proto3" json:"encrypted_linkage_proof,omitempty"`
+	Prover                []byte                 `protobuf:"bytes,3,opt,name=prover,proto3" json:"prover,omitempty"`
+	Verifier              []byte                 `protobuf:"bytes,4,opt,name=verifier,proto3" json:"verifier,omitempty"`
+	Counterparty          []byte                 `protobuf:"bytes,5,opt,name=counterparty,proto3" json:"counterparty,omitempty"`
+	ProtocolId            *Protocol              `protobuf:"bytes,6,opt,name=protocol_id,json=protocolId,proto3" json:"protocol_id,omitempty"`
+	KeyId                 string                 `protobuf:"bytes,7,opt,name=key_id,json=keyId,proto3" json:"key_id,omitempty"`
+	ProofType             uint32                 `protobuf:"varint,8,opt,name=proof_type,json=proofType,proto3" json:"proof_type,omitempty"`
+	unknownFields         protoimpl.UnknownFields
+	sizeCache             protoimpl.SizeCache
+}
+
+func (x *RevealSpecificKeyLinkageResult) Reset() {
+	*x = RevealSpecificKeyLinkageResult{}
+	mi := &file_wallet_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevealSpecificKeyLinkageResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevealSpecificKeyLinkageResult) ProtoMessage() {}
+
+func (x *RevealSpecificKeyLinkageResult) ProtoReflect() protoreflect.Message {
+	mi := &file_wallet_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevealSpecificKeyLinkageResult.ProtoReflect.Descriptor instead.
+func (*RevealSpecificKeyLinkageResult) Descriptor() ([]byte, []int) {
+	return file_wallet_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *RevealSpecificKeyLinkageResult) GetEncryptedLinkage() []byte {
+	if x != nil {
+		return x.EncryptedLinkage
+	}
+	return nil
+}
+
+func (x *RevealSpecificKeyLinkageResult) GetEncryptedLinkageProof() []byte {
+	if x != nil {
+		return x.EncryptedLinkageProof
+	}
+	return nil
+}
+
+func (x *RevealSpecificKeyLinkageResult) GetProver() []byte {
+	if x != nil {
+		return x.Prover
+	}
+	return nil
+}
+
+func (x *RevealSpecificKeyLinkageResult) GetVerifier() []byte {
+	if x != nil {
+		return x.Verifier
+	}
+	return nil
+}
+
+func (x *RevealSpecificKeyLinkageResult) GetCounterparty() []byte {
+	if x != nil {
+		return x.Counterparty
+	}
+	return nil
+}
+
+func (x *RevealSpecificKeyLinkageResult) GetProtocolId() *Protocol {
+	if x != nil {
+		return x.ProtocolId
+	}
+	return nil
+}
+
+func (x *RevealSpecificKeyLinkageResult) GetKeyId() string {
+	if x != nil {
+		return x.KeyId
+	}
+	return ""
+}
+
+func (x *RevealSpecificKeyLinkageResult) GetProofType() uint32 {
+	if x != nil {
+		return x.ProofType
+	}
+	return 0
+}
+
+type EncryptArgs struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	EncryptionArgs *EncryptionArgs        `protobuf:"bytes,1,opt,name=encryption_args,json=encryptionArgs,proto3" json:"encryption_args,omitempty"`
+	Plaintext      []byte                 `protobuf:"bytes,2,opt,name=plaintext,proto3" json:"plaintext,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *EncryptArgs) Reset() {
+	*x = EncryptArgs{}
+	mi := &file_wallet_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EncryptArgs) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EncryptArgs) ProtoMessage() {}
+
+func (x *EncryptArgs) ProtoReflect() protoreflect.Message {
+	mi := &file_wallet_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EncryptArgs.ProtoReflect.Descriptor instead.
+func (*EncryptArgs) Descriptor() ([]byte, []int) {
+	return file_wallet_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *EncryptArgs) GetEncryptionArgs() *EncryptionArgs {
+	if x != nil {
+		return x.EncryptionArgs
+	}
+	return nil
+}
+
+func (x *EncryptArgs) GetPlaintext() []byte {
+	if x != nil {
+		return x.Plaintext
+	}
+	return nil
+}
+
+type EncryptResult struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Ciphertext    []byte                 `protobuf:"bytes,1,opt,name=ciphertext,proto3" json:"ciphertext,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EncryptResult) Reset() {
+	*x = EncryptResult{}
+	mi := &file_wallet_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EncryptResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EncryptResult) ProtoMessage() {}
+
+func (x *EncryptResult) ProtoReflect() protoreflect.Message {
+	mi := &file_wallet_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EncryptResult.ProtoReflect.Descriptor instead.
+func (*EncryptResult) Descriptor() ([]byte, []int) {
+	return file_wallet_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *EncryptResult) GetCiphertext() []byte {
+	if x != nil {
+		return x.Ciphertext
+	}
+	return nil
+}
+
+type DecryptArgs struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	EncryptionArgs *EncryptionArgs        `protobuf:"bytes,1,opt,name=encryption_args,json=encryptionArgs,proto3" json:"encryption_args,omitempty"`
+	Ciphertext     []byte                 `protobuf:"bytes,2,opt,name=ciphertext,proto3" json:"ciphertext,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *DecryptArgs) Reset() {
+	*x = DecryptArgs{}
+	mi := &file_wallet_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DecryptArgs) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DecryptArgs) ProtoMessage() {}
+
+func (x *DecryptArgs) ProtoReflect() protoreflect.Message {
+	mi := &file_wallet_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DecryptArgs.ProtoReflect.Descriptor instead.
+func (*DecryptArgs) Descriptor() ([]byte, []int) {
+	return file_wallet_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *DecryptArgs) GetEncryptionArgs() *EncryptionArgs {
+	if x != nil {
+		return x.EncryptionArgs
+	}
+	return nil
+}
+
+func (x *DecryptArgs) GetCiphertext() []byte {
+	if x != nil {
+		return x.Ciphertext
+	}
+	return nil
+}
+
+type DecryptResult struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Plaintext     []byte                 `protobuf:"bytes,1,opt,name=plaintext,proto3" json:"plaintext,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DecryptResult) Reset() {
+	*x = DecryptResult{}
+	mi := &file_wallet_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DecryptResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DecryptResult) ProtoMessage() {}
+
+func (x *DecryptResult) ProtoReflect() protoreflect.Message {
+	mi := &file_wallet_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DecryptResult.ProtoReflect.Descriptor instead.
+func (*DecryptResult) Descriptor() ([]byte, []int) {
+	return file_wallet_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *DecryptResult) GetPlaintext() []byte {
+	if x != nil {
+		return x.Plaintext
+	}
+	return nil
+}
+
+type CreateHMACArgs struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	EncryptionArgs *EncryptionArgs        `protobuf:"bytes,1,opt,name=encryption_args,json=encryptionArgs,proto3" json:"encryption_args,omitempty"`
+	Data           []byte                 `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *CreateHMACArgs) Reset() {
+	*x = CreateHMACArgs{}
+	mi := &file_wallet_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateHMACArgs) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateHMACArgs) ProtoMessage() {}
+
+func (x *CreateHMACArgs) ProtoReflect() protoreflect.Message {
+	mi := &file_wallet_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateHMACArgs.ProtoReflect.Descriptor instead.
+func (*CreateHMACArgs) Descriptor() ([]byte, []int) {
+	return file_wallet_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *CreateHMACArgs) GetEncryptionArgs() *EncryptionArgs {
+	if x != nil {
+		return x.EncryptionArgs
+	}
+	return nil
+}
+
+func (x *CreateHMACArgs) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+type CreateHMACResult struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Hmac          []byte                 `protobuf:"bytes,1,opt,name=hmac,proto3" json:"hmac,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateHMACResult) Reset() {
+	*x = CreateHMACResult{}
+	mi := &file_wallet_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateHMACResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateHMACResult) ProtoMessage() {}
+
+func (x *CreateHMACResult) ProtoReflect() protoreflect.Message {
+	mi := &file_wallet_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateHMACResult.ProtoReflect.Descriptor instead.
+func (*CreateHMACResult) Descriptor() ([]byte, []int) {
+	return file_wallet_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *CreateHMACResult) GetHmac() []byte {
+	if x != nil {
+		return x.Hmac
+	}
+	return nil
+}
+
+type VerifyHMACArgs struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	EncryptionArgs *EncryptionArgs        `protobuf:"bytes,1,opt,name=encryption_args,json=encryptionArgs,proto3" json:"encryption_args,omitempty"`
+	Data           []byte                 `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+	Hmac           []byte                 `protobuf:"bytes,3,opt,name=hmac,proto3" json:"hmac,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *VerifyHMACArgs) Reset() {
+	*x = VerifyHMACArgs{}
+	mi := &file_wallet_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VerifyHMACArgs) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerifyHMACArgs) ProtoMessage() {}
+
+func (x *VerifyHMACArgs) ProtoReflect() protoreflect.Message {
+	mi := &file_wallet_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VerifyHMACArgs.ProtoReflect.Descriptor instead.
+func (*VerifyHMACArgs) Descriptor() ([]byte, []int) {
+	return file_wallet_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *VerifyHMACArgs) GetEncryptionArgs() *EncryptionArgs {
+	if x != nil {
+		return x.EncryptionArgs
+	}
+	return nil
+}
+
+func (x *VerifyHMACArgs) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *VerifyHMACArgs) GetHmac() []byte {
+	if x != nil {
+		return x.Hmac
+	}
+	return nil
+}
+
+type VerifyHMACResult struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Valid         bool                   `protobuf:"varint,1,opt,name=valid,proto3" json:"valid,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VerifyHMACResult) Reset() {
+	*x = VerifyHMACResult{}
+	mi := &file_wallet_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VerifyHMACResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerifyHMACResult) ProtoMessage() {}
+
+func (x *VerifyHMACResult) ProtoReflect() protoreflect.Message {
+	mi := &file_wallet_proto_msgTypes[47]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VerifyHMACResult.ProtoReflect.Descriptor instead.
+func (*VerifyHMACResult) Descriptor() ([]byte, []int) {
+	return file_wallet_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *VerifyHMACResult) GetValid() bool {
+	if x != nil {
+		return x.Valid
+	}
+	return false
+}
+
+type SighashContext struct {
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	Tx                  []byte                 `protobuf:"bytes,1,opt,name=tx,proto3" json:"tx,omitempty"`
+	InputIndex          uint32                 `protobuf:"varint,2,opt,name=input_index,json=inputIndex,proto3" json:"input_index,omitempty"`
+	SourceSatoshis      uint64                 `protobuf:"varint,3,opt,name=source_satoshis,json=sourceSatoshis,proto3" json:"source_satoshis,omitempty"`
+	SourceLockingScript []byte                 `protobuf:"bytes,4,opt,name=source_locking_script,json=sourceLockingScript,proto3" json:"source_locking_script,omitempty"`
+	SighashFlag         uint32                 `protobuf:"varint,5,opt,name=sighash_flag,json=sighashFlag,proto3" json:"sighash_flag,omitempty"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *SighashContext) Reset() {
+	*x = SighashContext{}
+	mi := &file_wallet_proto_msgTypes[48]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SighashContext) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SighashContext) ProtoMessage() {}
+
+func (x *SighashContext) ProtoReflect() protoreflect.Message {
+	mi := &file_wallet_proto_msgTypes[48]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SighashContext.ProtoReflect.Descriptor instead.
+func (*SighashContext) Descriptor() ([]byte, []int) {
+	return file_wallet_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *SighashContext) GetTx() []byte {
+	if x != nil {
+		return x.Tx
+	}
+	return nil
+}
+
+func (x *SighashContext) GetInputIndex() uint32 {
+	if x != nil {
+		return x.InputIndex
+	}
+	return 0
+}
+
+func (x *SighashContext) GetSourceSatoshis() uint64 {
+	if x != nil {
+		return x.SourceSatoshis
+	}
+	return 0
+}
+
+func (x *SighashContext) GetSourceLockingScript() []byte {
+	if x != nil {
+		return x.SourceLockingScript
+	}
+	return nil
+}
+
+func (x *SighashContext) GetSighashFlag() uint32 {
+	if x != nil {
+		return x.SighashFlag
+	}
+	return 0
+}
+
+type CreateSignatureArgs struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	EncryptionArgs     *EncryptionArgs        `protobuf:"bytes,1,opt,name=encryption_args,json=encryptionArgs,proto3" json:"encryption_args,omitempty"`
+	Data               []byte                 `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+	HashToDirectlySign []byte                 `protobuf:"bytes,3,opt,name=hash_to_directly_sign,json=hashToDirectlySign,proto3" json:"hash_to_directly_sign,omitempty"`
+	SighashContext     *SighashContext        `protobuf:"bytes,4,opt,name=sighash_context,json=sighashContext,proto3" json:"sighash_context,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *CreateSignatureArgs) Reset() {
+	*x = CreateSignatureArgs{}
+	mi := &file_wallet_proto_msgTypes[49]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateSignatureArgs) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateSignatureArgs) ProtoMessage() {}
+
+func (x *CreateSignatureArgs) ProtoReflect() protoreflect.Message {
+	mi := &file_wallet_proto_msgTypes[49]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateSignatureArgs.ProtoReflect.Descriptor instead.
+func (*CreateSignatureArgs) Descriptor() ([]byte, []int) {
+	return file_wallet_proto_rawDescGZIP(), []int{49}
+}
+
+func (x *CreateSignatureArgs) GetEncryptionArgs() *EncryptionArgs {
+	if x != nil {
+		return x.EncryptionArgs
+	}
+	return nil
+}
+
+func (x *CreateSignatureArgs) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *CreateSignatureArgs) GetHashToDirectlySign() []byte {
+	if x != nil {
+		return x.HashToDirectlySign
+	}
+	return nil
+}
+
+func (x *CreateSignatureArgs) GetSighashContext() *SighashContext {
+	if x != nil {
+		return x.SighashContext
+	}
+	return nil
+}
+
+type CreateSignatureResult struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Signature     []byte                 `protobuf:"bytes,1,opt,name=signature,proto3" json:"signature,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateSignatureResult) Reset() {
+	*x = CreateSignatureResult{}
+	mi := &file_wallet_proto_msgTypes[50]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateSignatureResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateSignatureResult) ProtoMessage() {}
+
+func (x *CreateSignatureResult) ProtoReflect() protoreflect.Message {
+	mi := &file_wallet_proto_msgTypes[50]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateSignatureResult.ProtoReflect.Descriptor instead.
+func (*CreateSignatureResult) Descriptor() ([]byte, []int) {
+	return file_wallet_proto_rawDescGZIP(), []int{50}
+}
+
+func (x *CreateSignatureResult) GetSignature() []byte {
+	if x != nil {
+		return x.Signature
+	}
+	return nil
+}
+
+type VerifySignatureArgs struct {
+	state                protoimpl.MessageState `protogen:"open.v1"`
+	EncryptionArgs       *EncryptionArgs        `protobuf:"bytes,1,opt,name=encryption_args,json=encryptionArgs,proto3" json:"encryption_args,omitempty"`
+	Data                 []byte                 `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+	HashToDirectlyVerify []byte                 `protobuf:"bytes,3,opt,name=hash_to_directly_verify,json=hashToDirectlyVerify,proto3" json:"hash_to_directly_verify,omitempty"`
+	Signature            []byte                 `protobuf:"bytes,4,opt,name=signature,proto3" json:"signature,omitempty"`
+	ForSelf              *bool                  `protobuf:"varint,5,opt,name=for_self,json=forSelf,proto3,oneof" json:"for_self,omitempty"`
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
+}
+
+func (x *VerifySignatureArgs) Reset() {
+	*x = VerifySignatureArgs{}
+	mi := &file_wallet_proto_msgTypes[51]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VerifySignatureArgs) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerifySignatureArgs) ProtoMessage() {}
+
+func (x *VerifySignatureArgs) ProtoReflect() protoreflect.Message {
+	mi := &file_wallet_proto_msgTypes[51]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VerifySignatureArgs.ProtoReflect.Descriptor instead.
+func (*VerifySignatureArgs) Descriptor() ([]byte, []int) {
+	return file_wallet_proto_rawDescGZIP(), []int{51}
+}
+
+func (x *VerifySignatureArgs) GetEncryptionArgs() *EncryptionArgs {
+	if x != nil {
+		return x.EncryptionArgs
+	}
+	return nil
+}
+
+func (x *VerifySignatureArgs) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *VerifySignatureArgs) GetHashToDirectlyVerify() []byte {
+	if x != nil {
+		return x.HashToDirectlyVerify
+	}
+	return nil
+}
+
+func (x *VerifySignatureArgs) GetSignature() []byte {
+	if x != nil {
+		return x.Signature
+	}
+	return nil
+}
+
+func (x *VerifySignatureArgs) GetForSelf() bool {
+	if x != nil && x.ForSelf != nil {
+		return *x.ForSelf
+	}
+	return false
+}
+
+type VerifySignatureResult struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Valid         bool                   `protobuf:"varint,1,opt,name=valid,proto3" json:"valid,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VerifySignatureResult) Reset() {
+	*x = VerifySignatureResult{}
+	mi := &file_wallet_proto_msgTypes[52]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VerifySignatureResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerifySignatureResult) ProtoMessage() {}
+
+func (x *VerifySignatureResult) ProtoReflect() protoreflect.Message {
+	mi := &file_wallet_proto_msgTypes[52]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VerifySignatureResult.ProtoReflect.Descriptor instead.
+func (*VerifySignatureResult) Descriptor() ([]byte, []int) {
+	return file_wallet_proto_rawDescGZIP(), []int{52}
+}
+
+func (x *VerifySignatureResult) GetValid() bool {
+	if x != nil {
+		return x.Valid
+	}
+	return false
+}
+
+type Certificate struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	Type               []byte                 `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
+	SerialNumber       []byte                 `protobuf:"bytes,2,opt,name=serial_number,json=serialNumber,proto3" json:"serial_number,omitempty"`
+	Subject            []byte                 `protobuf:"bytes,3,opt,name=subject,proto3" json:"subject,omitempty"`
+	Certifier          []byte                 `protobuf:"bytes,4,opt,name=certifier,proto3" json:"certifier,omitempty"`
+	RevocationOutpoint *Outpoint              `protobuf:"bytes,5,opt,name=revocation_outpoint,json=revocationOutpoint,proto3" json:"revocation_outpoint,omitempty"`
+	Fields             map[string]string      `protobuf:"bytes,6,rep,name=fields,proto3" json:"fields,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Signature          []byte                 `protobuf:"bytes,7,opt,name=signature,proto3" json:"signature,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *Certificate) Reset() {
+	*x = Certificate{}
+	mi := &file_wallet_proto_msgTypes[53]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Certificate) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Certificate) ProtoMessage() {}
+
+func (x *Certificate) ProtoReflect() protoreflect.Message {
+	mi := &file_wallet_proto_msgTypes[53]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Certificate.ProtoReflect.Descriptor instead.
+func (*Certificate) Descriptor() ([]byte, []int) {
+	return file_wallet_proto_rawDescGZIP(), []int{53}
+}
+
+func (x *Certificate) GetType() []byte {
+	if x != nil {
+		return x.Type
+	}
+	return nil
+}
+
+func (x *Certificate) GetSerialNumber() []byte {
+	if x != nil {
+		return x.SerialNumber
+	}
+	return nil
+}
+
+func (x *Certificate) GetSubject() []byte {
+	if x != nil {
+		return x.Subject
+	}
+	return nil
+}
+
+func (x *Certificate) GetCertifier() []byte {
+	if x != nil {
+		return x.Certifier
+	}
+	return nil
+}
+
+func (x *Certificate) GetRevocationOutpoint() *Outpoint {
+	if x != nil {
+		return x.RevocationOutpoint
+	}
+	return nil
+}
+
+func (x *Certificate) GetFields() map[string]string {
+	if x != nil {
+		return x.Fields
+	}
+	return nil
+}
+
+func (x *Certificate) GetSignature() []byte {
+	if x != nil {
+		return x.Signature
+	}
+	return nil
+}
+
+type KeyringRevealer struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Revealer:
+	//
+	//	*KeyringRevealer_Certifier
+	//	*KeyringRevealer_PublicKey
+	Revealer      isKeyringRevealer_Revealer `protobuf_oneof:"revealer"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *KeyringRevealer) Reset() {
+	*x = KeyringRevealer{}
+	mi := &file_wallet_proto_msgTypes[54]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *KeyringRevealer) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*KeyringRevealer) ProtoMessage() {}
+
+func (x *KeyringRevealer) ProtoReflect() protoreflect.Message {
+	mi := &file_wallet_proto_msgTypes[54]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use KeyringRevealer.ProtoReflect.Descriptor instead.
+func (*KeyringRevealer) Descriptor() ([]byte, []int) {
+	return file_wallet_proto_rawDescGZIP(), []int{54}
+}
+
+func (x *KeyringRevealer) GetRevealer() isKeyringRevealer_Revealer {
+	if x != nil {
+		return x.Revealer
+	}
+	return nil
+}
+
+func (x *KeyringRevealer) GetCertifier() bool {
+	if x != nil {
+		if x, ok := x.Revealer.(*KeyringRevealer_Certifier); ok {
+			return x.Certifier
+		}
+	}
+	return false
+}
+
+func (x *KeyringRevealer) GetPublicKey() []byte {
+	if x != nil {
+		if x, ok := x.Revealer.(*KeyringRevealer_PublicKey); ok {
+			return x.PublicKey
+		}
+	}
+	return nil
+}
+
+type isKeyringRevealer_Revealer interface {
+	isKeyringRevealer_Revealer()
+}
+
+type KeyringRevealer_Certifier struct {
+	// certifier is set when the certifier reveals the keyring.
+	Certifier bool `protobuf:"varint,1,opt,name=certifier,proto3,oneof"`
+}
+
+type KeyringRevealer_PublicKey struct {
+	PublicKey []byte `protobuf:"bytes,2,opt,name=public_key,json=publicKey,proto3,oneof"`
+}
+
+func (*KeyringRevealer_Certifier) isKeyringRevealer_Revealer() {}
+
+func (*KeyringRevealer_PublicKey) isKeyringRevealer_Revealer() {}
+
+type AcquireCertificateArgs struct {
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	Type                []byte                 `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
+	Certifier           []byte                 `protobuf:"bytes,2,opt,name=certifier,proto3" json:"certifier,omitempty"`
+	AcquisitionProtocol string                 `protobuf:"bytes,3,opt,name=acquisition_protocol,json=acquisitionProtocol,proto3" json:"acquisition_protocol,omitempty"`
+	Fields              map[string]string      `protobuf:"bytes,4,rep,name=fields,proto3" json:"fields,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	SerialNumber        []byte                 `protobuf:"bytes,5,opt,name=serial_number,json=serialNumber,proto3" json:"serial_number,omitempty"`
+	RevocationOutpoint  *Outpoint              `protobuf:"bytes,6,opt,name=revocation_outpoint,json=revocationOutpoint,proto3" json:"revocation_outpoint,omitempty"`
+	Signature           []byte                 `protobuf:"bytes,7,opt,name=signature,proto3" json:"signature,omitempty"`
+	CertifierUrl        string                 `protobuf:"bytes,8,opt,name=certifier_url,json=certifierUrl,proto3" json:"certifier_url,omitempty"`
+	KeyringRevealer     *KeyringRevealer       `protobuf:"bytes,9,opt,name=keyring_revealer,json=keyringRevealer,proto3" json:"keyring_revealer,omitempty"`
+	KeyringForSubject   map[string]string      `protobuf:"bytes,10,rep,name=keyring_for_subject,json=keyringForSubject,proto3" json:"keyring_for_subject,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Privileged          *bool                  `protobuf:"varint,11,opt,name=privileged,proto3,oneof" json:"privileged,omitempty"`
+	PrivilegedReason    string                 `protobuf:"bytes,12,opt,name=privileged_reason,json=privilegedReason,proto3" json:"privileged_reason,omitempty"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *AcquireCertificateArgs) Reset() {
+	*x = AcquireCertificateArgs{}
+	mi := &file_wallet_proto_msgTypes[55]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AcquireCertificateArgs) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AcquireCertificateArgs) ProtoMessage() {}
+
+func (x *AcquireCertificateArgs) ProtoReflect() protoreflect.Message {
+	mi := &file_wallet_proto_msgTypes[55]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AcquireCertificateArgs.ProtoReflect.Descriptor instead.
+func (*AcquireCertificateArgs) Descriptor() ([]byte, []int) {
+	return file_wallet_proto_rawDescGZIP(), []int{55}
+}
+
+func (x *AcquireCertificateArgs) GetType() []byte {
+	if x != nil {
+		return x.Type
+	}
+	return nil
+}
+
+func (x *AcquireCertificateArgs) GetCertifier() []byte {
+	if x != nil {
+		return x.Certifier
+	}
+	return nil
+}
+
+func (x *AcquireCertificateArgs) GetAcquisitionProtocol() string {
+	if x != nil {
+		return x.AcquisitionProtocol
+	}
+	return ""
+}
+
+func (x *AcquireCertificateArgs) GetFields() map[string]string {
+	if x != nil {
+		return x.Fields
+	}
+	return nil
+}
+
+func (x *AcquireCertificateArgs) GetSerialNumber() []byte {
+	if x != nil {
+		return x.SerialNumber
+	}
+	return nil
+}
+
+func (x *AcquireCertificateArgs) GetRevocationOutpoint() *Outpoint {
+	if x != nil {
+		return x.RevocationOutpoint
+	}
+	return nil
+}
+
+func (x *AcquireCertificateArgs) GetSignature() []byte {
+	if x != nil {
+		return x.Signature
+	}
+	return nil
+}
+
+func (x *AcquireCertificateArgs) GetCertifierUrl() string {
+	if x != nil {
+		return x.CertifierUrl
+	}
+	return ""
+}
+
+func (x *AcquireCertificateArgs) GetKeyringRevealer() *KeyringRevealer {
+	if x != nil {
+		return x.KeyringRevealer
+	}
+	return nil
+}
+
+func (x *AcquireCertificateArgs) GetKeyringForSubject() map[string]string {
+	if x != nil {
+		return x.KeyringForSubject
+	}
+	return nil
+}
+
+func (x *AcquireCertificateArgs) GetPrivileged() bool {
+	if x != nil && x.Privileged != nil {
+		return *x.Privileged
+	}
+	return false
+}
+
+func (x *AcquireCertificateArgs) GetPrivilegedReason() string {
+	if x != nil {
+		return x.PrivilegedReason
+	}
+	return ""
+}
+
+type ListCertificatesArgs struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Certifiers       [][]byte               `protobuf:"bytes,1,rep,name=certifiers,proto3" json:"certifiers,omitempty"`
+	Types            [][]byte               `protobuf:"bytes,2,rep,name=types,proto3" json:"types,omitempty"`
+	Limit            *uint32                `protobuf:"varint,3,opt,name=limit,proto3,oneof" json:"limit,omitempty"`
+	Offset           *uint32                `protobuf:"varint,4,opt,name=offset,proto3,oneof" json:"offset,omitempty"`
+	Privileged       *bool                  `protobuf:"varint,5,opt,name=privileged,proto3,oneof" json:"privileged,omitempty"`
+	PrivilegedReason string                 `protobuf:"bytes,6,opt,name=privileged_reason,json=privilegedReason,proto3" json:"privileged_reason,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *ListCertificatesArgs) Reset() {
+	*x = ListCertificatesArgs{}
+	mi := &file_wallet_proto_msgTypes[56]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListCertificatesArgs) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCertificatesArgs) ProtoMessage() {}
+
+func (x *ListCertificatesArgs) ProtoReflect() protoreflect.Message {
+	mi := &file_wallet_proto_msgTypes[56]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCertificatesArgs.ProtoReflect.Descriptor instead.
+func (*ListCertificatesArgs) Descriptor() ([]byte, []int) {
+	return file_wallet_proto_rawDescGZIP(), []int{56}
+}
+
+func (x *ListCertificatesArgs) GetCertifiers() [][]byte {
+	if x != nil {
+		return x.Certifiers
+	}
+	return nil
+}
+
+func (x *ListCertificatesArgs) GetTypes() [][]byte {
+	if x != nil {
+		return x.Types
+	}
+	return nil
+}
+
+func (x *ListCertificatesArgs) GetLimit() uint32 {
+	if x != nil && x.Limit != nil {
+		return *x.Limit
+	}
+	return 0
+}
+
+func (x *ListCertificatesArgs) GetOffset() uint32 {
+	if x != nil && x.Offset != nil {
+		return *x.Offset
+	}
+	return 0
+}
+
+func (x *ListCertificatesArgs) GetPrivileged() bool {
+	if x != nil && x.Privileged != nil {
+		return *x.Privileged
+	}
+	return false
+}
+
+func (x *ListCertificatesArgs) GetPrivilegedReason() string {
+	if x != nil {
+		return x.PrivilegedReason
+	}
+	return ""
+}
+
+type CertificateResult struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Certificate   *Certificate           `protobuf:"bytes,1,opt,name=certificate,proto3" json:"certificate,omitempty"`
+	Keyring       map[string]string      `protobuf:"bytes,2,rep,name=keyring,proto3" json:"keyring,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Verifier      []byte                 `protobuf:"bytes,3,opt,name=verifier,proto3" json:"verifier,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CertificateResult) Reset() {
+	*x = CertificateResult{}
+	mi := &file_wallet_proto_msgTypes[57]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CertificateResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CertificateResult) ProtoMessage() {}
+
+func (x *CertificateResult) ProtoReflect() protoreflect.Message {
+	mi := &file_wallet_proto_msgTypes[57]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CertificateResult.ProtoReflect.Descriptor instead.
+func (*CertificateResult) Descriptor() ([]byte, []int) {
+	return file_wallet_proto_rawDescGZIP(), []int{57}
+}
+
+func (x *CertificateResult) GetCertificate() *Certificate {
+	if x != nil {
+		return x.Certificate
+	}
+	return nil
+}
+
+func (x *CertificateResult) GetKeyring() map[string]string {
+	if x != nil {
+		return x.Keyring
+	}
+	return nil
+}
+
+func (x *CertificateResult) GetVerifier() []byte {
+	if x != nil {
+		return x.Verifier
+	}
+	return nil
+}
+
+type ListCertificatesResult struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	TotalCertificates uint32                 `protobuf:"varint,1,opt,name=total_certificates,json=totalCertificates,proto3" json:"total_certificates,omitempty"`
+	Certificates      []*CertificateResult   `protobuf:"bytes,2,rep,name=certificates,proto3" json:"certificates,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *ListCertificatesResult) Reset() {
+	*x = ListCertificatesResult{}
+	mi := &file_wallet_proto_msgTypes[58]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListCertificatesResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCertificatesResult) ProtoMessage() {}
+
+func (x *ListCertificatesResult) ProtoReflect() protoreflect.Message {
+	mi := &file_wallet_proto_msgTypes[58]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCertificatesResult.ProtoReflect.Descriptor instead.
+func (*ListCertificatesResult) Descriptor() ([]byte, []int) {
+	return file_wallet_proto_rawDescGZIP(), []int{58}
+}
+
+func (x *ListCertificatesResult) GetTotalCertificates() uint32 {
+	if x != nil {
+		return x.TotalCertificates
+	}
+	return 0
+}
+
+func (x *ListCertificatesResult) GetCertificates() []*CertificateResult {
+	if x != nil {
+		return x.Certificates
+	}
+	return nil
+}
+
+type ProveCertificateArgs struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Certificate      *Certificate           `protobuf:"bytes,1,opt,name=certificate,proto3" json:"certificate,omitempty"`
+	FieldsToReveal   []string               `protobuf:"bytes,2,rep,name=fields_to_reveal,json=fieldsToReveal,proto3" json:"fields_to_reveal,omitempty"`
+	Verifier         []byte                 `protobuf:"bytes,3,opt,name=verifier,proto3" json:"verifier,omitempty"`
+	Privileged       *bool                  `protobuf:"varint,4,opt,name=privileged,proto3,oneof" json:"privileged,omitempty"`
+	PrivilegedReason string                 `protobuf:"bytes,5,opt,name=privileged_reason,json=privilegedReason,proto3" json:"privileged_reason,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *ProveCertificateArgs) Reset() {
+	*x = ProveCertificateArgs{}
+	mi := &file_wallet_proto_msgTypes[59]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProveCertificateArgs) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProveCertificateArgs) ProtoMessage() {}
+
+func (x *ProveCertificateArgs) ProtoReflect() protoreflect.Message {
+	mi := &file_wallet_proto_msgTypes[59]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProveCertificateArgs.ProtoReflect.Descriptor instead.
+func (*ProveCertificateArgs) Descriptor() ([]byte, []int) {
+	return file_wallet_proto_rawDescGZIP(), []int{59}
+}
+
+func (x *ProveCertificateArgs) GetCertificate() *Certificate {
+	if x != nil {
+		return x.Certificate
+	}
+	return nil
+}
+
+func (x *ProveCertificateArgs) GetFieldsToReveal() []string {
+	if x != nil {
+		return x.FieldsToReveal
+	}
+	return nil
+}
+
+func (x *ProveCertificateArgs) GetVerifier() []byte {
+	if x != nil {
+		return x.Verifier
+	}
+	return nil
+}
+
+func (x *ProveCertificateArgs) GetPrivileged() bool {
+	if x != nil && x.Privileged != nil {
+		return *x.Privileged
+	}
+	return false
+}
+
+func (x *ProveCertificateArgs) GetPrivilegedReason() string {
+	if x != nil {
+		return x.PrivilegedReason
+	}
+	return ""
+}
+
+type ProveCertificateResult struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	KeyringForVerifier map[string]string      `protobuf:"bytes,1,rep,name=keyring_for_verifier,json=keyringForVerifier,proto3" json:"keyring_for_verifier,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *ProveCertificateResult) Reset() {
+	*x = ProveCertificateResult{}
+	mi := &file_wallet_proto_msgTypes[60]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProveCertificateResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProveCertificateResult) ProtoMessage() {}
+
+func (x *ProveCertificateResult) ProtoReflect() protoreflect.Message {
+	mi := &file_wallet_proto_msgTypes[60]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProveCertificateResult.ProtoReflect.Descriptor instead.
+func (*ProveCertificateResult) Descriptor() ([]byte, []int) {
+	return file_wallet_proto_rawDescGZIP(), []int{60}
+}
+
+func (x *ProveCertificateResult) GetKeyringForVerifier() map[string]string {
+	if x != nil {
+		return x.KeyringForVerifier
+	}
+	return nil
+}
+
+type RelinquishCertificateArgs struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Type          []byte                 `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
+	SerialNumber  []byte                 `protobuf:"bytes,2,opt,name=serial_number,json=serialNumber,proto3" json:"serial_number,omitempty"`
+	Certifier     []byte                 `protobuf:"bytes,3,opt,name=certifier,proto3" json:"certifier,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RelinquishCertificateArgs) Reset() {
+	*x = RelinquishCertificateArgs{}
+	mi := &file_wallet_proto_msgTypes[61]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RelinquishCertificateArgs) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RelinquishCertificateArgs) ProtoMessage() {}
+
+func (x *RelinquishCertificateArgs) ProtoReflect() protoreflect.Message {
+	mi := &file_wallet_proto_msgTypes[61]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RelinquishCertificateArgs.ProtoReflect.Descriptor instead.
+func (*RelinquishCertificateArgs) Descriptor() ([]byte, []int) {
+	return file_wallet_proto_rawDescGZIP(), []int{61}
+}
+
+func (x *RelinquishCertificateArgs) GetType() []byte {
+	if x != nil {
+		return x.Type
+	}
+	return nil
+}
+
+func (x *RelinquishCertificateArgs) GetSerialNumber() []byte {
+	if x != nil {
+		return x.SerialNumber
+	}
+	return nil
+}
+
+func (x *RelinquishCertificateArgs) GetCertifier() []byte {
+	if x != nil {
+		return x.Certifier
+	}
+	return nil
+}
+
+type RelinquishCertificateResult struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Relinquished  bool                   `protobuf:"varint,1,opt,name=relinquished,proto3" json:"relinquished,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RelinquishCertificateResult) Reset() {
+	*x = RelinquishCertificateResult{}
+	mi := &file_wallet_proto_msgTypes[62]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RelinquishCertificateResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RelinquishCertificateResult) ProtoMessage() {}
+
+func (x *RelinquishCertificateResult) ProtoReflect() protoreflect.Message {
+	mi := &file_wallet_proto_msgTypes[62]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RelinquishCertificateResult.ProtoReflect.Descriptor instead.
+func (*RelinquishCertificateResult) Descriptor() ([]byte, []int) {
+	return file_wallet_proto_rawDescGZIP(), []int{62}
+}
+
+func (x *RelinquishCertificateResult) GetRelinquished() bool {
+	if x != nil {
+		return x.Relinquished
+	}
+	return false
+}
+
+type IdentityCertifier struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	IconUrl       string                 `protobuf:"bytes,2,opt,name=icon_url,json=iconUrl,proto3" json:"icon_url,omitempty"`
+	Description   string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	Trust         uint32                 `protobuf:"varint,4,opt,name=trust,proto3" json:"trust,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *IdentityCertifier) Reset() {
+	*x = IdentityCertifier{}
+	mi := &file_wallet_proto_msgTypes[63]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *IdentityCertifier) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IdentityCertifier) ProtoMessage() {}
+
+func (x *IdentityCertifier) ProtoReflect() protoreflect.Message {
+	mi := &file_wallet_proto_msgTypes[63]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IdentityCertifier.ProtoReflect.Descriptor instead.
+func (*IdentityCertifier) Descriptor() ([]byte, []int) {
+	return file_wallet_proto_rawDescGZIP(), []int{63}
+}
+
+func (x *IdentityCertifier) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *IdentityCertifier) GetIconUrl() string {
+	if x != nil {
+		return x.IconUrl
+	}
+	return ""
+}
+
+func (x *IdentityCertifier) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *IdentityCertifier) GetTrust() uint32 {
+	if x != nil {
+		return x.Trust
+	}
+	return 0
+}
+
+type IdentityCertificate struct {
+	state                   protoimpl.MessageState `protogen:"open.v1"`
+	Certificate             *Certificate           `protobuf:"bytes,1,opt,name=certificate,proto3" json:"certificate,omitempty"`
+	CertifierInfo           *IdentityCertifier     `protobuf:"bytes,2,opt,name=certifier_info,json=certifierInfo,proto3" json:"certifier_info,omitempty"`
+	PubliclyRevealedKeyring map[string]string      `protobuf:"bytes,3,rep,name=publicly_revealed_keyring,json=publiclyRevealedKeyring,proto3" json:"publicly_revealed_keyring,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	DecryptedFields         map[string]string      `protobuf:"bytes,4,rep,name=decrypted_fields,json=decryptedFields,proto3" json:"decrypted_fields,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields           protoimpl.UnknownFields
+	sizeCache               protoimpl.SizeCache
+}
+
+func (x *IdentityCertificate) Reset() {
+	*x = IdentityCertificate{}
+	mi := &file_wallet_proto_msgTypes[64]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *IdentityCertificate) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IdentityCertificate) ProtoMessage() {}
+
+func (x *IdentityCertificate) ProtoReflect() protoreflect.Message {
+	mi := &file_wallet_proto_msgTypes[64]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IdentityCertificate.ProtoReflect.Descriptor instead.
+func (*IdentityCertificate) Descriptor() ([]byte, []int) {
+	return file_wallet_proto_rawDescGZIP(), []int{64}
+}
+
+func (x *IdentityCertificate) GetCertificate() *Certificate {
+	if x != nil {
+		return x.Certificate
+	}
+	return nil
+}
+
+func (x *IdentityCertificate) GetCertifierInfo() *IdentityCertifier {
+	if x != nil {
+		return x.CertifierInfo
+	}
+	return nil
+}
+
+func (x *IdentityCertificate) GetPubliclyRevealedKeyring() map[string]string {
+	if x != nil {
+		return x.PubliclyRevealedKeyring
+	}
+	return nil
+}
+
+func (x *IdentityCertificate) GetDecryptedFields() map[string]string {
+	if x != nil {
+		return x.DecryptedFields
+	}
+	return nil
+}
+
+type DiscoverByIdentityKeyArgs struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	IdentityKey    []byte                 `protobuf:"bytes,1,opt,name=identity_key,json=identityKey,proto3" json:"identity_key,omitempty"`
+	Limit          *uint32                `protobuf:"varint,2,opt,name=limit,proto3,oneof" json:"limit,omitempty"`
+	Offset         *uint32                `protobuf:"varint,3,opt,name=offset,proto3,oneof" json:"offset,omitempty"`
+	SeekPermission *bool                  `protobuf:"varint,4,opt,name=seek_permission,json=seekPermission,proto3,oneof" json:"seek_permission,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *DiscoverByIdentityKeyArgs) Reset() {
+	*x = DiscoverByIdentityKeyArgs{}
+	mi := &file_wallet_proto_msgTypes[65]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DiscoverByIdentityKeyArgs) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DiscoverByIdentityKeyArgs) ProtoMessage() {}
+
+func (x *DiscoverByIdentityKeyArgs) ProtoReflect() protoreflect.Message {
+	mi := &file_wallet_proto_msgTypes[65]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DiscoverByIdentityKeyArgs.ProtoReflect.Descriptor instead.
+func (*DiscoverByIdentityKeyArgs) Descriptor() ([]byte, []int) {
+	return file_wallet_proto_rawDescGZIP(), []int{65}
+}
+
+func (x *DiscoverByIdentityKeyArgs) GetIdentityKey() []byte {
+	if x != nil {
+		return x.IdentityKey
+	}
+	return nil
+}
+
+func (x *DiscoverByIdentityKeyArgs) GetLimit() uint32 {
+	if x != nil && x.Limit != nil {
+		return *x.Limit
+	}
+	return 0
+}
+
+func (x *DiscoverByIdentityKeyArgs) GetOffset() uint32 {
+	if x != nil && x.Offset != nil {
+		return *x.Offset
+	}
+	return 0
+}
+
+func (x *DiscoverByIdentityKeyArgs) GetSeekPermission() bool {
+	if x != nil && x.SeekPermission != nil {
+		return *x.SeekPermission
+	}
+	return false
+}
+
+type DiscoverByAttributesArgs struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Attributes     map[string]string      `protobuf:"bytes,1,rep,name=attributes,proto3" json:"attributes,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Limit          *uint32                `protobuf:"varint,2,opt,name=limit,proto3,oneof" json:"limit,omitempty"`
+	Offset         *uint32                `protobuf:"varint,3,opt,name=offset,proto3,oneof" json:"offset,omitempty"`
+	SeekPermission *bool                  `protobuf:"varint,4,opt,name=seek_permission,json=seekPermission,proto3,oneof" json:"seek_permission,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *DiscoverByAttributesArgs) Reset() {
+	*x = DiscoverByAttributesArgs{}
+	mi := &file_wallet_proto_msgTypes[66]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DiscoverByAttributesArgs) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DiscoverByAttributesArgs) ProtoMessage() {}
+
+func (x *DiscoverByAttributesArgs) ProtoReflect() protoreflect.Message {
+	mi := &file_wallet_proto_msgTypes[66]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DiscoverByAttributesArgs.ProtoReflect.Descriptor instead.
+func (*DiscoverByAttributesArgs) Descriptor() ([]byte, []int) {
+	return file_wallet_proto_rawDescGZIP(), []int{66}
+}
+
+func (x *DiscoverByAttributesArgs) GetAttributes() map[string]string {
+	if x != nil {
+		return x.Attributes
+	}
+	return nil
+}
+
+func (x *DiscoverByAttributesArgs) GetLimit() uint32 {
+	if x != nil && x.Limit != nil {
+		return *x.Limit
+	}
+	return 0
+}
+
+func (x *DiscoverByAttributesArgs) GetOffset() uint32 {
+	if x != nil && x.Offset != nil {
+		return *x.Offset
+	}
+	return 0
+}
+
+func (x *DiscoverByAttributesArgs) GetSeekPermission() bool {
+	if x != nil && x.SeekPermission != nil {
+		return *x.SeekPermission
+	}
+	return false
+}
+
+type DiscoverCertificatesResult struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	TotalCertificates uint32                 `protobuf:"varint,1,opt,name=total_certificates,json=totalCertificates,proto3" json:"total_certificates,omitempty"`
+	Certificates      []*IdentityCertificate `protobuf:"bytes,2,rep,name=certificates,proto3" json:"certificates,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *DiscoverCertificatesResult) Reset() {
+	*x = DiscoverCertificatesResult{}
+	mi := &file_wallet_proto_msgTypes[67]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DiscoverCertificatesResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DiscoverCertificatesResult) ProtoMessage() {}
+
+func (x *DiscoverCertificatesResult) ProtoReflect() protoreflect.Message {
+	mi := &file_wallet_proto_msgTypes[67]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DiscoverCertificatesResult.ProtoReflect.Descriptor instead.
+func (*DiscoverCertificatesResult) Descriptor() ([]byte, []int) {
+	return file_wallet_proto_rawDescGZIP(), []int{67}
+}
+
+func (x *DiscoverCertificatesResult) GetTotalCertificates() uint32 {
+	if x != nil {
+		return x.TotalCertificates
+	}
+	return 0
+}
+
+func (x *DiscoverCertificatesResult) GetCertificates() []*IdentityCertificate {
+	if x != nil {
+		return x.Certificates
+	}
+	return nil
+}
+
+type AuthenticatedResult struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Authenticated bool                   `protobuf:"varint,1,opt,name=authenticated,proto3" json:"authenticated,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AuthenticatedResult) Reset() {
+	*x = AuthenticatedResult{}
+	mi := &file_wallet_proto_msgTypes[68]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AuthenticatedResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AuthenticatedResult) ProtoMessage() {}
+
+func (x *AuthenticatedResult) ProtoReflect() protoreflect.Message {
+	mi := &file_wallet_proto_msgTypes[68]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AuthenticatedResult.ProtoReflect.Descriptor instead.
+func (*AuthenticatedResult) Descriptor() ([]byte, []int) {
+	return file_wallet_proto_rawDescGZIP(), []int{68}
+}
+
+func (x *AuthenticatedResult) GetAuthenticated() bool {
+	if x != nil {
+		return x.Authenticated
+	}
+	return false
+}
+
+type GetHeightResult struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Height        uint32                 `protobuf:"varint,1,opt,name=height,proto3" json:"height,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetHeightResult) Reset() {
+	*x = GetHeightResult{}
+	mi := &file_wallet_proto_msgTypes[69]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetHeightResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetHeightResult) ProtoMessage() {}
+
+func (x *GetHeightResult) ProtoReflect() protoreflect.Message {
+	mi := &file_wallet_proto_msgTypes[69]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetHeightResult.ProtoReflect.Descriptor instead.
+func (*GetHeightResult) Descriptor() ([]byte, []int) {
+	return file_wallet_proto_rawDescGZIP(), []int{69}
+}
+
+func (x *GetHeightResult) GetHeight() uint32 {
+	if x != nil {
+		return x.Height
+	}
+	return 0
+}
+
+type GetHeaderArgs struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Height        uint32                 `protobuf:"varint,1,opt,name=height,proto3" json:"height,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetHeaderArgs) Reset() {
+	*x = GetHeaderArgs{}
+	mi := &file_wallet_proto_msgTypes[70]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetHeaderArgs) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetHeaderArgs) ProtoMessage() {}
+
+func (x *GetHeaderArgs) ProtoReflect() protoreflect.Message {
+	mi := &file_wallet_proto_msgTypes[70]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetHeaderArgs.ProtoReflect.Descriptor instead.
+func (*GetHeaderArgs) Descriptor() ([]byte, []int) {
+	return file_wallet_proto_rawDescGZIP(), []int{70}
+}
+
+func (x *GetHeaderArgs) GetHeight() uint32 {
+	if x != nil {
+		return x.Height
+	}
+	return 0
+}
+
+type GetHeaderResult struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Header        []byte                 `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetHeaderResult) Reset() {
+	*x = GetHeaderResult{}
+	mi := &file_wallet_proto_msgTypes[71]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetHeaderResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetHeaderResult) ProtoMessage() {}
+
+func (x *GetHeaderResult) ProtoReflect() protoreflect.Message {
+	mi := &file_wallet_proto_msgTypes[71]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetHeaderResult.ProtoReflect.Descriptor instead.
+func (*GetHeaderResult) Descriptor() ([]byte, []int) {
+	return file_wallet_proto_rawDescGZIP(), []int{71}
+}
+
+func (x *GetHeaderResult) GetHeader() []byte {
+	if x != nil {
+		return x.Header
+	}
+	return nil
+}
+
+type GetNetworkResult struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Network       string                 `protobuf:"bytes,1,opt,name=network,proto3" json:"network,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetNetworkResult) Reset() {
+	*x = GetNetworkResult{}
+	mi := &file_wallet_proto_msgTypes[72]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetNetworkResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetNetworkResult) ProtoMessage() {}
+
+func (x *GetNetworkResult) ProtoReflect() protoreflect.Message {
+	mi := &file_wallet_proto_msgTypes[72]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetNetworkResult.ProtoReflect.Descriptor instead.
+func (*GetNetworkResult) Descriptor() ([]byte, []int) {
+	return file_wallet_proto_rawDescGZIP(), []int{72}
+}
+
+func (x *GetNetworkResult) GetNetwork() string {
+	if x != nil {
+		return x.Network
+	}
+	return ""
+}
+
+type GetVersionResult struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Version       string                 `protobuf:"bytes,1,opt,name=version,proto3" json:"version,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetVersionResult) Reset() {
+	*x = GetVersionResult{}
+	mi := &file_wallet_proto_msgTypes[73]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetVersionResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetVersionResult) ProtoMessage() {}
+
+func (x *GetVersionResult) ProtoReflect() protoreflect.Message {
+	mi := &file_wallet_proto_msgTypes[73]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetVersionResult.ProtoReflect.Descriptor instead.
+func (*GetVersionResult) Descriptor() ([]byte, []int) {
+	return file_wallet_proto_rawDescGZIP(), []int{73}
+}
+
+func (x *GetVersionResult) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+var File_wallet_proto protoreflect.FileDescriptor
+
+const file_wallet_proto_rawDesc = "" +
+	"\n" +
+	"\fwallet.proto\x12\rbsv.wallet.v1\x1a\x1bgoogle/protobuf/empty.proto\"K\n" +
+	"\x05Error\x12\x12\n" +
+	"\x04code\x18\x01 \x01(\rR\x04code\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12\x14\n" +
+	"\x05stack\x18\x03 \x01(\tR\x05stack\"4\n" +
+	"\bOutpoint\x12\x12\n" +
+	"\x04txid\x18\x01 \x01(\fR\x04txid\x12\x14\n" +
+	"\x05index\x18\x02 \x01(\rR\x05index\"M\n" +
+	"\bProtocol\x12%\n" +
+	"\x0esecurity_level\x18\x01 \x01(\x05R\rsecurityLevel\x12\x1a\n" +
+	"\bprotocol\x18\x02 \x01(\tR\bprotocol\"g\n" +
+	"\fCounterparty\x123\n" +
+	"\x04type\x18\x01 \x01(\x0e2\x1f.bsv.wallet.v1.CounterpartyTypeR\x04type\x12\"\n" +
+	"\fcounterparty\x18\x02 \x01(\fR\fcounterparty\"\x98\x02\n" +
+	"\x0eEncryptionArgs\x128\n" +
+	"\vprotocol_id\x18\x01 \x01(\v2\x17.bsv.wallet.v1.ProtocolR\n" +
+	"protocolId\x12\x15\n" +
+	"\x06key_id\x18\x02 \x01(\tR\x05keyId\x12?\n" +
+	"\fcounterparty\x18\x03 \x01(\v2\x1b.bsv.wallet.v1.CounterpartyR\fcounterparty\x12\x1e\n" +
+	"\n" +
+	"privileged\x18\x04 \x01(\bR\n" +
+	"privileged\x12+\n" +
+	"\x11privileged_reason\x18\x05 \x01(\tR\x10privilegedReason\x12'\n" +
+	"\x0fseek_permission\x18\x06 \x01(\bR\x0eseekPermission\"\x9a\x02\n" +
+	"\x11CreateActionInput\x123\n" +
+	"\boutpoint\x18\x01 \x01(\v2\x17.bsv.wallet.v1.OutpointR\boutpoint\x12+\n" +
+	"\x11input_description\x18\x02 \x01(\tR\x10inputDescription\x12)\n" +
+	"\x10unlocking_script\x18\x03 \x01(\fR\x0funlockingScript\x126\n" +
+	"\x17unlocking_script_length\x18\x04 \x01(\rR\x15unlockingScriptLength\x12,\n" +
+	"\x0fsequence_number\x18\x05 \x01(\rH\x00R\x0esequenceNumber\x88\x01\x01B\x12\n" +
+	"\x10_sequence_number\"\xe3\x01\n" +
+	"\x12CreateActionOutput\x12%\n" +
+	"\x0elocking_script\x18\x01 \x01(\fR\rlockingScript\x12\x1a\n" +
+	"\bsatoshis\x18\x02 \x01(\x04R\bsatoshis\x12-\n" +
+	"\x12output_description\x18\x03 \x01(\tR\x11outputDescription\x12\x16\n" +
+	"\x06basket\x18\x04 \x01(\tR\x06basket\x12/\n" +
+	"\x13custom_instructions\x18\x05 \x01(\tR\x12customInstructions\x12\x12\n" +
+	"\x04tags\x18\x06 \x03(\tR\x04tags\"`\n" +
+	"\x0eChangeStrategy\x12\x12\n" +
+	"\x04type\x18\x01 \x01(\tR\x04type\x12\x14\n" +
+	"\x05count\x18\x02 \x01(\rR\x05count\x12$\n" +
+	"\rdenominations\x18\x03 \x03(\x04R\rdenominations\"\xcf\x04\n" +
+	"\x13CreateActionOptions\x12-\n" +
+	"\x10sign_and_process\x18\x01 \x01(\bH\x00R\x0esignAndProcess\x88\x01\x01\x12=\n" +
+	"\x18accept_delayed_broadcast\x18\x02 \x01(\bH\x01R\x16acceptDelayedBroadcast\x88\x01\x01\x12\x1d\n" +
+	"\n" +
+	"trust_self\x18\x03 \x01(\tR\ttrustSelf\x12\x1f\n" +
+	"\vknown_txids\x18\x04 \x03(\fR\n" +
+	"knownTxids\x12-\n" +
+	"\x10return_txid_only\x18\x05 \x01(\bH\x02R\x0ereturnTxidOnly\x88\x01\x01\x12\x1c\n" +
+	"\ano_send\x18\x06 \x01(\bH\x03R\x06noSend\x88\x01\x01\x12=\n" +
+	"\x0eno_send_change\x18\a \x03(\v2\x17.bsv.wallet.v1.OutpointR\fnoSendChange\x12\x1b\n" +
+	"\tsend_with\x18\b \x03(\fR\bsendWith\x120\n" +
+	"\x11randomize_outputs\x18\t \x01(\bH\x04R\x10randomizeOutputs\x88\x01\x01\x12F\n" +
+	"\x0fchange_strategy\x18\n" +
+	" \x01(\v2\x1d.bsv.wallet.v1.ChangeStrategyR\x0echangeStrategyB\x13\n" +
+	"\x11_sign_and_processB\x1b\n" +
+	"\x19_accept_delayed_broadcastB\x13\n" +
+	"\x11_return_txid_onlyB\n" +
+	"\n" +
+	"\b_no_sendB\x14\n" +
+	"\x12_randomize_outputs\"\xfb\x02\n" +
+	"\x10CreateActionArgs\x12 \n" +
+	"\vdescription\x18\x01 \x01(\tR\vdescription\x12\x1d\n" +
+	"\n" +
+	"input_beef\x18\x02 \x01(\fR\tinputBeef\x128\n" +
+	"\x06inputs\x18\x03 \x03(\v2 .bsv.wallet.v1.CreateActionInputR\x06inputs\x12;\n" +
+	"\aoutputs\x18\x04 \x03(\v2!.bsv.wallet.v1.CreateActionOutputR\aoutputs\x12 \n" +
+	"\tlock_time\x18\x05 \x01(\rH\x00R\blockTime\x88\x01\x01\x12\x1d\n" +
+	"\aversion\x18\x06 \x01(\rH\x01R\aversion\x88\x01\x01\x12\x16\n" +
+	"\x06labels\x18\a \x03(\tR\x06labels\x12<\n" +
+	"\aoptions\x18\b \x01(\v2\".bsv.wallet.v1.CreateActionOptionsR\aoptionsB\f\n" +
+	"\n" +
+	"_lock_timeB\n" +
+	"\n" +
+	"\b_version\"<\n" +
+	"\x0eSendWithResult\x12\x12\n" +
+	"\x04txid\x18\x01 \x01(\fR\x04txid\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\"C\n" +
+	"\x13SignableTransaction\x12\x0e\n" +
+	"\x02tx\x18\x01 \x01(\fR\x02tx\x12\x1c\n" +
+	"\treference\x18\x02 \x01(\fR\treference\"\x99\x02\n" +
+	"\x12CreateActionResult\x12\x12\n" +
+	"\x04txid\x18\x01 \x01(\fR\x04txid\x12\x0e\n" +
+	"\x02tx\x18\x02 \x01(\fR\x02tx\x12=\n" +
+	"\x0eno_send_change\x18\x03 \x03(\v2\x17.bsv.wallet.v1.OutpointR\fnoSendChange\x12I\n" +
+	"\x11send_with_results\x18\x04 \x03(\v2\x1d.bsv.wallet.v1.SendWithResultR\x0fsendWithResults\x12U\n" +
+	"\x14signable_transaction\x18\x05 \x01(\v2\".bsv.wallet.v1.SignableTransactionR\x13signableTransaction\"~\n" +
+	"\x0fSignActionSpend\x12)\n" +
+	"\x10unlocking_script\x18\x01 \x01(\fR\x0funlockingScript\x12,\n" +
+	"\x0fsequence_number\x18\x02 \x01(\rH\x00R\x0esequenceNumber\x88\x01\x01B\x12\n" +
+	"\x10_sequence_number\"\xfa\x01\n" +
+	"\x11SignActionOptions\x12=\n" +
+	"\x18accept_delayed_broadcast\x18\x01 \x01(\bH\x00R\x16acceptDelayedBroadcast\x88\x01\x01\x12-\n" +
+	"\x10return_txid_only\x18\x02 \x01(\bH\x01R\x0ereturnTxidOnly\x88\x01\x01\x12\x1c\n" +
+	"\ano_send\x18\x03 \x01(\bH\x02R\x06noSend\x88\x01\x01\x12\x1b\n" +
+	"\tsend_with\x18\x04 \x03(\fR\bsendWithB\x1b\n" +
+	"\x19_accept_delayed_broadcastB\x13\n" +
+	"\x11_return_txid_onlyB\n" +
+	"\n" +
+	"\b_no_send\"\x88\x02\n" +
+	"\x0eSignActionArgs\x12\x1c\n" +
+	"\treference\x18\x01 \x01(\fR\treference\x12A\n" +
+	"\x06spends\x18\x02 \x03(\v2).bsv.wallet.v1.SignActionArgs.SpendsEntryR\x06spends\x12:\n" +
+	"\aoptions\x18\x03 \x01(\v2 .bsv.wallet.v1.SignActionOptionsR\aoptions\x1aY\n" +
+	"\vSpendsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\rR\x03key\x124\n" +
+	"\x05value\x18\x02 \x01(\v2\x1e.bsv.wallet.v1.SignActionSpendR\x05value:\x028\x01\"\x81\x01\n" +
+	"\x10SignActionResult\x12\x12\n" +
+	"\x04txid\x18\x01 \x01(\fR\x04txid\x12\x0e\n" +
+	"\x02tx\x18\x02 \x01(\fR\x02tx\x12I\n" +
+	"\x11send_with_results\x18\x03 \x03(\v2\x1d.bsv.wallet.v1.SendWithResultR\x0fsendWithResults\"/\n" +
+	"\x0fAbortActionArgs\x12\x1c\n" +
+	"\treference\x18\x01 \x01(\fR\treference\"-\n" +
+	"\x11AbortActionResult\x12\x18\n" +
+	"\aaborted\x18\x01 \x01(\bR\aaborted\"\x95\x06\n" +
+	"\x0fListActionsArgs\x12\x16\n" +
+	"\x06labels\x18\x01 \x03(\tR\x06labels\x12(\n" +
+	"\x10label_query_mode\x18\x02 \x01(\tR\x0elabelQueryMode\x12*\n" +
+	"\x0einclude_labels\x18\x03 \x01(\bH\x00R\rincludeLabels\x88\x01\x01\x12*\n" +
+	"\x0einclude_inputs\x18\x04 \x01(\bH\x01R\rincludeInputs\x88\x01\x01\x12S\n" +
+	"$include_input_source_locking_scripts\x18\x05 \x01(\bH\x02R includeInputSourceLockingScripts\x88\x01\x01\x12J\n" +
+	"\x1finclude_input_unlocking_scripts\x18\x06 \x01(\bH\x03R\x1cincludeInputUnlockingScripts\x88\x01\x01\x12,\n" +
+	"\x0finclude_outputs\x18\a \x01(\bH\x04R\x0eincludeOutputs\x88\x01\x01\x12H\n" +
+	"\x1einclude_output_locking_scripts\x18\b \x01(\bH\x05R\x1bincludeOutputLockingScripts\x88\x01\x01\x12\x19\n" +
+	"\x05limit\x18\t \x01(\rH\x06R\x05limit\x88\x01\x01\x12\x1b\n" +
+	"\x06offset\x18\n" +
+	" \x01(\rH\aR\x06offset\x88\x01\x01\x12,\n" +
+	"\x0fseek_permission\x18\v \x01(\bH\bR\x0eseekPermission\x88\x01\x01\x12\x16\n" +
+	"\x06cursor\x18\f \x01(\tR\x06cursorB\x11\n" +
+	"\x0f_include_labelsB\x11\n" +
+	"\x0f_include_inputsB'\n" +
+	"%_include_input_source_locking_scriptsB\"\n" +
+	" _include_input_unlocking_scriptsB\x12\n" +
+	"\x10_include_outputsB!\n" +
+	"\x1f_include_output_locking_scriptsB\b\n" +
+	"\x06_limitB\t\n" +
+	"\a_offsetB\x12\n" +
+	"\x10_seek_permission\"\xad\x02\n" +
+	"\vActionInput\x12@\n" +
+	"\x0fsource_outpoint\x18\x01 \x01(\v2\x17.bsv.wallet.v1.OutpointR\x0esourceOutpoint\x12'\n" +
+	"\x0fsource_satoshis\x18\x02 \x01(\x04R\x0esourceSatoshis\x122\n" +
+	"\x15source_locking_script\x18\x03 \x01(\fR\x13sourceLockingScript\x12)\n" +
+	"\x10unlocking_script\x18\x04 \x01(\fR\x0funlockingScript\x12+\n" +
+	"\x11input_description\x18\x05 \x01(\tR\x10inputDescription\x12'\n" +
+	"\x0fsequence_number\x18\x06 \x01(\rR\x0esequenceNumber\"\x9e\x02\n" +
+	"\fActionOutput\x12\x1a\n" +
+	"\bsatoshis\x18\x01 \x01(\x04R\bsatoshis\x12%\n" +
+	"\x0elocking_script\x18\x02 \x01(\fR\rlockingScript\x12\x1c\n" +
+	"\tspendable\x18\x03 \x01(\bR\tspendable\x12/\n" +
+	"\x13custom_instructions\x18\x04 \x01(\tR\x12customInstructions\x12\x12\n" +
+	"\x04tags\x18\x05 \x03(\tR\x04tags\x12!\n" +
+	"\foutput_index\x18\x06 \x01(\rR\voutputIndex\x12-\n" +
+	"\x12output_description\x18\a \x01(\tR\x11outputDescription\x12\x16\n" +
+	"\x06basket\x18\b \x01(\tR\x06basket\"\xcd\x02\n" +
+	"\x06Action\x12\x12\n" +
+	"\x04txid\x18\x01 \x01(\fR\x04txid\x12\x1a\n" +
+	"\bsatoshis\x18\x02 \x01(\x03R\bsatoshis\x12\x16\n" +
+	"\x06status\x18\x03 \x01(\tR\x06status\x12\x1f\n" +
+	"\vis_outgoing\x18\x04 \x01(\bR\n" +
+	"isOutgoing\x12 \n" +
+	"\vdescription\x18\x05 \x01(\tR\vdescription\x12\x16\n" +
+	"\x06labels\x18\x06 \x03(\tR\x06labels\x12\x18\n" +
+	"\aversion\x18\a \x01(\rR\aversion\x12\x1b\n" +
+	"\tlock_time\x18\b \x01(\rR\blockTime\x122\n" +
+	"\x06inputs\x18\t \x03(\v2\x1a.bsv.wallet.v1.ActionInputR\x06inputs\x125\n" +
+	"\aoutputs\x18\n" +
+	" \x03(\v2\x1b.bsv.wallet.v1.ActionOutputR\aoutputs\"\x8a\x01\n" +
+	"\x11ListActionsResult\x12#\n" +
+	"\rtotal_actions\x18\x01 \x01(\rR\ftotalActions\x12/\n" +
+	"\aactions\x18\x02 \x03(\v2\x15.bsv.wallet.v1.ActionR\aactions\x12\x1f\n" +
+	"\vnext_cursor\x18\x03 \x01(\tR\n" +
+	"nextCursor\"\x93\x01\n" +
+	"\aPayment\x12+\n" +
+	"\x11derivation_prefix\x18\x01 \x01(\fR\x10derivationPrefix\x12+\n" +
+	"\x11derivation_suffix\x18\x02 \x01(\fR\x10derivationSuffix\x12.\n" +
+	"\x13sender_identity_key\x18\x03 \x01(\fR\x11senderIdentityKey\"n\n" +
+	"\x0fBasketInsertion\x12\x16\n" +
+	"\x06basket\x18\x01 \x01(\tR\x06basket\x12/\n" +
+	"\x13custom_instructions\x18\x02 \x01(\tR\x12customInstructions\x12\x12\n" +
+	"\x04tags\x18\x03 \x03(\tR\x04tags\"\xec\x01\n" +
+	"\x11InternalizeOutput\x12!\n" +
+	"\foutput_index\x18\x01 \x01(\rR\voutputIndex\x12\x1a\n" +
+	"\bprotocol\x18\x02 \x01(\tR\bprotocol\x12E\n" +
+	"\x12payment_remittance\x18\x03 \x01(\v2\x16.bsv.wallet.v1.PaymentR\x11paymentRemittance\x12Q\n" +
+	"\x14insertion_remittance\x18\x04 \x01(\v2\x1e.bsv.wallet.v1.BasketInsertionR\x13insertionRemittance\"\xdf\x01\n" +
+	"\x15InternalizeActionArgs\x12\x0e\n" +
+	"\x02tx\x18\x01 \x01(\fR\x02tx\x12 \n" +
+	"\vdescription\x18\x02 \x01(\tR\vdescription\x12\x16\n" +
+	"\x06labels\x18\x03 \x03(\tR\x06labels\x12,\n" +
+	"\x0fseek_permission\x18\x04 \x01(\bH\x00R\x0eseekPermission\x88\x01\x01\x12:\n" +
+	"\aoutputs\x18\x05 \x03(\v2 .bsv.wallet.v1.InternalizeOutputR\aoutputsB\x12\n" +
+	"\x10_seek_permission\"5\n" +
+	"\x17InternalizeActionResult\x12\x1a\n" +
+	"\baccepted\x18\x01 \x01(\bR\baccepted\"\xb5\x05\n" +
+	"\x0fListOutputsArgs\x12\x16\n" +
+	"\x06basket\x18\x01 \x01(\tR\x06basket\x12\x12\n" +
+	"\x04tags\x18\x02 \x03(\tR\x04tags\x12$\n" +
+	"\x0etag_query_mode\x18\x03 \x01(\tR\ftagQueryMode\x12\x18\n" +
+	"\ainclude\x18\x04 \x01(\tR\ainclude\x12C\n" +
+	"\x1binclude_custom_instructions\x18\x05 \x01(\bH\x00R\x19includeCustomInstructions\x88\x01\x01\x12&\n" +
+	"\finclude_tags\x18\x06 \x01(\bH\x01R\vincludeTags\x88\x01\x01\x12*\n" +
+	"\x0einclude_labels\x18\a \x01(\bH\x02R\rincludeLabels\x88\x01\x01\x12\x19\n" +
+	"\x05limit\x18\b \x01(\rH\x03R\x05limit\x88\x01\x01\x12\x1b\n" +
+	"\x06offset\x18\t \x01(\rH\x04R\x06offset\x88\x01\x01\x12,\n" +
+	"\x0fseek_permission\x18\n" +
+	" \x01(\bH\x05R\x0eseekPermission\x88\x01\x01\x12&\n" +
+	"\fmin_satoshis\x18\v \x01(\x04H\x06R\vminSatoshis\x88\x01\x01\x12&\n" +
+	"\fmax_satoshis\x18\f \x01(\x04H\aR\vmaxSatoshis\x88\x01\x01\x12!\n" +
+	"\fscript_types\x18\r \x03(\tR\vscriptTypes\x12\x1d\n" +
+	"\n" +
+	"sort_order\x18\x0e \x01(\tR\tsortOrder\x12\x16\n" +
+	"\x06cursor\x18\x0f \x01(\tR\x06cursorB\x1e\n" +
+	"\x1c_include_custom_instructionsB\x0f\n" +
+	"\r_include_tagsB\x11\n" +
+	"\x0f_include_labelsB\b\n" +
+	"\x06_limitB\t\n" +
+	"\a_offsetB\x12\n" +
+	"\x10_seek_permissionB\x0f\n" +
+	"\r_min_satoshisB\x0f\n" +
+	"\r_max_satoshis\"\xfb\x01\n" +
+	"\x06Output\x12\x1a\n" +
+	"\bsatoshis\x18\x01 \x01(\x04R\bsatoshis\x12%\n" +
+	"\x0elocking_script\x18\x02 \x01(\fR\rlockingScript\x12\x1c\n" +
+	"\tspendable\x18\x03 \x01(\bR\tspendable\x12/\n" +
+	"\x13custom_instructions\x18\x04 \x01(\tR\x12customInstructions\x12\x12\n" +
+	"\x04tags\x18\x05 \x03(\tR\x04tags\x123\n" +
+	"\boutpoint\x18\x06 \x01(\v2\x17.bsv.wallet.v1.OutpointR\boutpoint\x12\x16\n" +
+	"\x06labels\x18\a \x03(\tR\x06labels\"\x9e\x01\n" +
+	"\x11ListOutputsResult\x12#\n" +
+	"\rtotal_outputs\x18\x01 \x01(\rR\ftotalOutputs\x12\x12\n" +
+	"\x04beef\x18\x02 \x01(\fR\x04beef\x12/\n" +
+	"\aoutputs\x18\x03 \x03(\v2\x15.bsv.wallet.v1.OutputR\aoutputs\x12\x1f\n" +
+	"\vnext_cursor\x18\x04 \x01(\tR\n" +
+	"nextCursor\"_\n" +
+	"\x14RelinquishOutputArgs\x12\x16\n" +
+	"\x06basket\x18\x01 \x01(\tR\x06basket\x12/\n" +
+	"\x06output\x18\x02 \x01(\v2\x17.bsv.wallet.v1.OutpointR\x06output\"<\n" +
+	"\x16RelinquishOutputResult\x12\"\n" +
+	"\frelinquished\x18\x01 \x01(\bR\frelinquished\"\xaa\x01\n" +
+	"\x10GetPublicKeyArgs\x12F\n" +
+	"\x0fencryption_args\x18\x01 \x01(\v2\x1d.bsv.wallet.v1.EncryptionArgsR\x0eencryptionArgs\x12!\n" +
+	"\fidentity_key\x18\x02 \x01(\bR\videntityKey\x12\x1e\n" +
+	"\bfor_self\x18\x03 \x01(\bH\x00R\aforSelf\x88\x01\x01B\v\n" +
+	"\t_for_self\"3\n" +
+	"\x12GetPublicKeyResult\x12\x1d\n" +
+	"\n" +
+	"public_key\x18\x01 \x01(\fR\tpublicKey\"\xc3\x01\n" +
+	" RevealCounterpartyKeyLinkageArgs\x12\"\n" +
+	"\fcounterparty\x18\x01 \x01(\fR\fcounterparty\x12\x1a\n" +
+	"\bverifier\x18\x02 \x01(\fR\bverifier\x12#\n" +
+	"\n" +
+	"privileged\x18\x03 \x01(\bH\x00R\n" +
+	"privileged\x88\x01\x01\x12+\n" +
+	"\x11privileged_reason\x18\x04 \x01(\tR\x10privilegedReasonB\r\n" +
+	"\v_privileged\"\x8a\x02\n" +
+	"\"RevealCounterpartyKeyLinkageResult\x12\x16\n" +
+	"\x06prover\x18\x01 \x01(\fR\x06prover\x12\"\n" +
+	"\fcounterparty\x18\x02 \x01(\fR\fcounterparty\x12\x1a\n" +
+	"\bverifier\x18\x03 \x01(\fR\bverifier\x12'\n" +
+	"\x0frevelation_time\x18\x04 \x01(\tR\x0erevelationTime\x12+\n" +
+	"\x11encrypted_linkage\x18\x05 \x01(\fR\x10encryptedLinkage\x126\n" +
+	"\x17encrypted_linkage_proof\x18\x06 \x01(\fR\x15encryptedLinkageProof\"\xad\x02\n" +
+	"\x1cRevealSpecificKeyLinkageArgs\x12?\n" +
+	"\fcounterparty\x18\x01 \x01(\v2\x1b.bsv.wallet.v1.CounterpartyR\fcounterparty\x12\x1a\n" +
+	"\bverifier\x18\x02 \x01(\fR\bverifier\x128\n" +
+	"\vprotocol_id\x18\x03 \x01(\v2\x17.bsv.wallet.v1.ProtocolR\n" +
+	"protocolId\x12\x15\n" +
+	"\x06key_id\x18\x04 \x01(\tR\x05keyId\x12#\n" +
+	"\n" +
+	"privileged\x18\x05 \x01(\bH\x00R\n" +
+	"privileged\x88\x01\x01\x12+\n" +
+	"\x11privileged_reason\x18\x06 \x01(\tR\x10privilegedReasonB\r\n" +
+	"\v_privileged\"\xcd\x02\n" +
+	"\x1eRevealSpecificKeyLinkageResult\x12+\n" +
+	"\x11encrypted_linkage\x18\x01 \x01(\fR\x10encryptedLinkage\x126\n" +
+	"\x17encrypted_linkage_proof\x18\x02 \x01(\fR\x15encryptedLinkageProof\x12\x16\n" +
+	"\x06prover\x18\x03 \x01(\fR\x06prover\x12\x1a\n" +
+	"\bverifier\x18\x04 \x01(\fR\bverifier\x12\"\n" +
+	"\fcounterparty\x18\x05 \x01(\fR\fcounterparty\x128\n" +
+	"\vprotocol_id\x18\x06 \x01(\v2\x17.bsv.wallet.v1.ProtocolR\n" +
+	"protocolId\x12\x15\n" +
+	"\x06key_id\x18\a \x01(\tR\x05keyId\x12\x1d\n" +
+	"\n" +
+	"proof_type\x18\b \x01(\rR\tproofType\"s\n" +
+	"\vEncryptArgs\x12F\n" +
+	"\x0fencryption_args\x18\x01 \x01(\v2\x1d.bsv.wallet.v1.EncryptionArgsR\x0eencryptionArgs\x12\x1c\n" +
+	"\tplaintext\x18\x02 \x01(\fR\tplaintext\"/\n" +
+	"\rEncryptResult\x12\x1e\n" +
+	"\n" +
+	"ciphertext\x18\x01 \x01(\fR\n" +
+	"ciphertext\"u\n" +
+	"\vDecryptArgs\x12F\n" +
+	"\x0fencryption_args\x18\x01 \x01(\v2\x1d.bsv.wallet.v1.EncryptionArgsR\x0eencryptionArgs\x12\x1e\n" +
+	"\n" +
+	"ciphertext\x18\x02 \x01(\fR\n" +
+	"ciphertext\"-\n" +
+	"\rDecryptResult\x12\x1c\n" +
+	"\tplaintext\x18\x01 \x01(\fR\tplaintext\"l\n" +
+	"\x0eCreateHMACArgs\x12F\n" +
+	"\x0fencryption_args\x18\x01 \x01(\v2\x1d.bsv.wallet.v1.EncryptionArgsR\x0eencryptionArgs\x12\x12\n" +
+	"\x04data\x18\x02 \x01(\fR\x04data\"&\n" +
+	"\x10CreateHMACResult\x12\x12\n" +
+	"\x04hmac\x18\x01 \x01(\fR\x04hmac\"\x80\x01\n" +
+	"\x0eVerifyHMACArgs\x12F\n" +
+	"\x0fencryption_args\x18\x01 \x01(\v2\x1d.bsv.wallet.v1.EncryptionArgsR\x0eencryptionArgs\x12\x12\n" +
+	"\x04data\x18\x02 \x01(\fR\x04data\x12\x12\n" +
+	"\x04hmac\x18\x03 \x01(\fR\x04hmac\"(\n" +
+	"\x10VerifyHMACResult\x12\x14\n" +
+	"\x05valid\x18\x01 \x01(\bR\x05valid\"\xc1\x01\n" +
+	"\x0eSighashContext\x12\x0e\n" +
+	"\x02tx\x18\x01 \x01(\fR\x02tx\x12\x1f\n" +
+	"\vinput_index\x18\x02 \x01(\rR\n" +
+	"inputIndex\x12'\n" +
+	"\x0fsource_satoshis\x18\x03 \x01(\x04R\x0esourceSatoshis\x122\n" +
+	"\x15source_locking_script\x18\x04 \x01(\fR\x13sourceLockingScript\x12!\n" +
+	"\fsighash_flag\x18\x05 \x01(\rR\vsighashFlag\"\xec\x01\n" +
+	"\x13CreateSignatureArgs\x12F\n" +
+	"\x0fencryption_args\x18\x01 \x01(\v2\x1d.bsv.wallet.v1.EncryptionArgsR\x0eencryptionArgs\x12\x12\n" +
+	"\x04data\x18\x02 \x01(\fR\x04data\x121\n" +
+	"\x15hash_to_directly_sign\x18\x03 \x01(\fR\x12hashToDirectlySign\x12F\n" +
+	"\x0fsighash_context\x18\x04 \x01(\v2\x1d.bsv.wallet.v1.SighashContextR\x0esighashContext\"5\n" +
+	"\x15CreateSignatureResult\x12\x1c\n" +
+	"\tsignature\x18\x01 \x01(\fR\tsignature\"\xf3\x01\n" +
+	"\x13VerifySignatureArgs\x12F\n" +
+	"\x0fencryption_args\x18\x01 \x01(\v2\x1d.bsv.wallet.v1.EncryptionArgsR\x0eencryptionArgs\x12\x12\n" +
+	"\x04data\x18\x02 \x01(\fR\x04data\x125\n" +
+	"\x17hash_to_directly_verify\x18\x03 \x01(\fR\x14hashToDirectlyVerify\x12\x1c\n" +
+	"\tsignature\x18\x04 \x01(\fR\tsignature\x12\x1e\n" +
+	"\bfor_self\x18\x05 \x01(\bH\x00R\aforSelf\x88\x01\x01B\v\n" +
+	"\t_for_self\"-\n" +
+	"\x15VerifySignatureResult\x12\x14\n" +
+	"\x05valid\x18\x01 \x01(\bR\x05valid\"\xe1\x02\n" +
+	"\vCertificate\x12\x12\n" +
+	"\x04type\x18\x01 \x01(\fR\x04type\x12#\n" +
+	"\rserial_number\x18\x02 \x01(\fR\fserialNumber\x12\x18\n" +
+	"\asubject\x18\x03 \x01(\fR\asubject\x12\x1c\n" +
+	"\tcertifier\x18\x04 \x01(\fR\tcertifier\x12H\n" +
+	"\x13revocation_outpoint\x18\x05 \x01(\v2\x17.bsv.wallet.v1.OutpointR\x12revocationOutpoint\x12>\n" +
+	"\x06fields\x18\x06 \x03(\v2&.bsv.wallet.v1.Certificate.FieldsEntryR\x06fields\x12\x1c\n" +
+	"\tsignature\x18\a \x01(\fR\tsignature\x1a9\n" +
+	"\vFieldsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"^\n" +
+	"\x0fKeyringRevealer\x12\x1e\n" +
+	"\tcertifier\x18\x01 \x01(\bH\x00R\tcertifier\x12\x1f\n" +
+	"\n" +
+	"public_key\x18\x02 \x01(\fH\x00R\tpublicKeyB\n" +
+	"\n" +
+	"\brevealer\"\x95\x06\n" +
+	"\x16AcquireCertificateArgs\x12\x12\n" +
+	"\x04type\x18\x01 \x01(\fR\x04type\x12\x1c\n" +
+	"\tcertifier\x18\x02 \x01(\fR\tcertifier\x121\n" +
+	"\x14acquisition_protocol\x18\x03 \x01(\tR\x13acquisitionProtocol\x12I\n" +
+	"\x06fields\x18\x04 \x03(\v21.bsv.wallet.v1.AcquireCertificateArgs.FieldsEntryR\x06fields\x12#\n" +
+	"\rserial_number\x18\x05 \x01(\fR\fserialNumber\x12H\n" +
+	"\x13revocation_outpoint\x18\x06 \x01(\v2\x17.bsv.wallet.v1.OutpointR\x12revocationOutpoint\x12\x1c\n" +
+	"\tsignature\x18\a \x01(\fR\tsignature\x12#\n" +
+	"\rcertifier_url\x18\b \x01(\tR\fcertifierUrl\x12I\n" +
+	"\x10keyring_revealer\x18\t \x01(\v2\x1e.bsv.wallet.v1.KeyringRevealerR\x0fkeyringRevealer\x12l\n" +
+	"\x13keyring_for_subject\x18\n" +
+	" \x03(\v2<.bsv.wallet.v1.AcquireCertificateArgs.KeyringForSubjectEntryR\x11keyringForSubject\x12#\n" +
+	"\n" +
+	"privileged\x18\v \x01(\bH\x00R\n" +
+	"privileged\x88\x01\x01\x12+\n" +
+	"\x11privileged_reason\x18\f \x01(\tR\x10privilegedReason\x1a9\n" +
+	"\vFieldsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\x1aD\n" +
+	"\x16KeyringForSubjectEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01B\r\n" +
+	"\v_privileged\"\xfa\x01\n" +
+	"\x14ListCertificatesArgs\x12\x1e\n" +
+	"\n" +
+	"certifiers\x18\x01 \x03(\fR\n" +
+	"certifiers\x12\x14\n" +
+	"\x05types\x18\x02 \x03(\fR\x05types\x12\x19\n" +
+	"\x05limit\x18\x03 \x01(\rH\x00R\x05limit\x88\x01\x01\x12\x1b\n" +
+	"\x06offset\x18\x04 \x01(\rH\x01R\x06offset\x88\x01\x01\x12#\n" +
+	"\n" +
+	"privileged\x18\x05 \x01(\bH\x02R\n" +
+	"privileged\x88\x01\x01\x12+\n" +
+	"\x11privileged_reason\x18\x06 \x01(\tR\x10privilegedReasonB\b\n" +
+	"\x06_limitB\t\n" +
+	"\a_offsetB\r\n" +
+	"\v_privileged\"\xf2\x01\n" +
+	"\x11CertificateResult\x12<\n" +
+	"\vcertificate\x18\x01 \x01(\v2\x1a.bsv.wallet.v1.CertificateR\vcertificate\x12G\n" +
+	"\akeyring\x18\x02 \x03(\v2-.bsv.wallet.v1.CertificateResult.KeyringEntryR\akeyring\x12\x1a\n" +
+	"\bverifier\x18\x03 \x01(\fR\bverifier\x1a:\n" +
+	"\fKeyringEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\x8d\x01\n" +
+	"\x16ListCertificatesResult\x12-\n" +
+	"\x12total_certificates\x18\x01 \x01(\rR\x11totalCertificates\x12D\n" +
+	"\fcertificates\x18\x02 \x03(\v2 .bsv.wallet.v1.CertificateResultR\fcertificates\"\xfb\x01\n" +
+	"\x14ProveCertificateArgs\x12<\n" +
+	"\vcertificate\x18\x01 \x01(\v2\x1a.bsv.wallet.v1.CertificateR\vcertificate\x12(\n" +
+	"\x10fields_to_reveal\x18\x02 \x03(\tR\x0efieldsToReveal\x12\x1a\n" +
+	"\bverifier\x18\x03 \x01(\fR\bverifier\x12#\n" +
+	"\n" +
+	"privileged\x18\x04 \x01(\bH\x00R\n" +
+	"privileged\x88\x01\x01\x12+\n" +
+	"\x11privileged_reason\x18\x05 \x01(\tR\x10privilegedReasonB\r\n" +
+	"\v_privileged\"\xd0\x01\n" +
+	"\x16ProveCertificateResult\x12o\n" +
+	"\x14keyring_for_verifier\x18\x01 \x03(\v2=.bsv.wallet.v1.ProveCertificateResult.KeyringForVerifierEntryR\x12keyringForVerifier\x1aE\n" +
+	"\x17KeyringForVerifierEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"r\n" +
+	"\x19RelinquishCertificateArgs\x12\x12\n" +
+	"\x04type\x18\x01 \x01(\fR\x04type\x12#\n" +
+	"\rserial_number\x18\x02 \x01(\fR\fserialNumber\x12\x1c\n" +
+	"\tcertifier\x18\x03 \x01(\fR\tcertifier\"A\n" +
+	"\x1bRelinquishCertificateResult\x12\"\n" +
+	"\frelinquished\x18\x01 \x01(\bR\frelinquished\"z\n" +
+	"\x11IdentityCertifier\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x19\n" +
+	"\bicon_url\x18\x02 \x01(\tR\aiconUrl\x12 \n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription\x12\x14\n" +
+	"\x05trust\x18\x04 \x01(\rR\x05trust\"\x8d\x04\n" +
+	"\x13IdentityCertificate\x12<\n" +
+	"\vcertificate\x18\x01 \x01(\v2\x1a.bsv.wallet.v1.CertificateR\vcertificate\x12G\n" +
+	"\x0ecertifier_info\x18\x02 \x01(\v2 .bsv.wallet.v1.IdentityCertifierR\rcertifierInfo\x12{\n" +
+	"\x19publicly_revealed_keyring\x18\x03 \x03(\v2?.bsv.wallet.v1.IdentityCertificate.PubliclyRevealedKeyringEntryR\x17publiclyRevealedKeyring\x12b\n" +
+	"\x10decrypted_fields\x18\x04 \x03(\v27.bsv.wallet.v1.IdentityCertificate.DecryptedFieldsEntryR\x0fdecryptedFields\x1aJ\n" +
+	"\x1cPubliclyRevealedKeyringEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\x1aB\n" +
+	"\x14DecryptedFieldsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xcd\x01\n" +
+	"\x19DiscoverByIdentityKeyArgs\x12!\n" +
+	"\fidentity_key\x18\x01 \x01(\fR\videntityKey\x12\x19\n" +
+	"\x05limit\x18\x02 \x01(\rH\x00R\x05limit\x88\x01\x01\x12\x1b\n" +
+	"\x06offset\x18\x03 \x01(\rH\x01R\x06offset\x88\x01\x01\x12,\n" +
+	"\x0fseek_permission\x18\x04 \x01(\bH\x02R\x0eseekPermission\x88\x01\x01B\b\n" +
+	"\x06_limitB\t\n" +
+	"\a_offsetB\x12\n" +
+	"\x10_seek_permission\"\xc1\x02\n" +
+	"\x18DiscoverByAttributesArgs\x12W\n" +
+	"\n" +
+	"attributes\x18\x01 \x03(\v27.bsv.wallet.v1.DiscoverByAttributesArgs.AttributesEntryR\n" +
+	"attributes\x12\x19\n" +
+	"\x05limit\x18\x02 \x01(\rH\x00R\x05limit\x88\x01\x01\x12\x1b\n" +
+	"\x06offset\x18\x03 \x01(\rH\x01R\x06offset\x88\x01\x01\x12,\n" +
+	"\x0fseek_permission\x18\x04 \x01(\bH\x02R\x0eseekPermission\x88\x01\x01\x1a=\n" +
+	"\x0fAttributesEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01B\b\n" +
+	"\x06_limitB\t\n" +
+	"\a_offsetB\x12\n" +
+	"\x10_seek_permission\"\x93\x01\n" +
+	"\x1aDiscoverCertificatesResult\x12-\n" +
+	"\x12total_certificates\x18\x01 \x01(\rR\x11totalCertificates\x12F\n" +
+	"\fcertificates\x18\x02 \x03(\v2\".bsv.wallet.v1.IdentityCertificateR\fcertificates\";\n" +
+	"\x13AuthenticatedResult\x12$\n" +
+	"\rauthenticated\x18\x01 \x01(\bR\rauthenticated\")\n" +
+	"\x0fGetHeightResult\x12\x16\n" +
+	"\x06height\x18\x01 \x01(\rR\x06height\"'\n" +
+	"\rGetHeaderArgs\x12\x16\n" +
+	"\x06height\x18\x01 \x01(\rR\x06height\")\n" +
+	"\x0fGetHeaderResult\x12\x16\n" +
+	"\x06header\x18\x01 \x01(\fR\x06header\",\n" +
+	"\x10GetNetworkResult\x12\x18\n" +
+	"\anetwork\x18\x01 \x01(\tR\anetwork\",\n" +
+	"\x10GetVersionResult\x12\x18\n" +
+	"\aversion\x18\x01 \x01(\tR\aversion*\x8e\x01\n" +
+	"\x10CounterpartyType\x12#\n" +
+	"\x1fCOUNTERPARTY_TYPE_UNINITIALIZED\x10\x00\x12\x1c\n" +
+	"\x18COUNTERPARTY_TYPE_ANYONE\x10\x01\x12\x1a\n" +
+	"\x16COUNTERPARTY_TYPE_SELF\x10\x02\x12\x1b\n" +
+	"\x17COUNTERPARTY_TYPE_OTHER\x10\x032\xbe\x13\n" +
+	"\x06Wallet\x12R\n" +
+	"\fCreateAction\x12\x1f.bsv.wallet.v1.CreateActionArgs\x1a!.bsv.wallet.v1.CreateActionResult\x12L\n" +
+	"\n" +
+	"SignAction\x12\x1d.bsv.wallet.v1.SignActionArgs\x1a\x1f.bsv.wallet.v1.SignActionResult\x12O\n" +
+	"\vAbortAction\x12\x1e.bsv.wallet.v1.AbortActionArgs\x1a .bsv.wallet.v1.AbortActionResult\x12O\n" +
+	"\vListActions\x12\x1e.bsv.wallet.v1.ListActionsArgs\x1a .bsv.wallet.v1.ListActionsResult\x12a\n" +
+	"\x11InternalizeAction\x12$.bsv.wallet.v1.InternalizeActionArgs\x1a&.bsv.wallet.v1.InternalizeActionResult\x12O\n" +
+	"\vListOutputs\x12\x1e.bsv.wallet.v1.ListOutputsArgs\x1a .bsv.wallet.v1.ListOutputsResult\x12^\n" +
+	"\x10RelinquishOutput\x12#.bsv.wallet.v1.RelinquishOutputArgs\x1a%.bsv.wallet.v1.RelinquishOutputResult\x12R\n" +
+	"\fGetPublicKey\x12\x1f.bsv.wallet.v1.GetPublicKeyArgs\x1a!.bsv.wallet.v1.GetPublicKeyResult\x12\x82\x01\n" +
+	"\x1cRevealCounterpartyKeyLinkage\x12/.bsv.wallet.v1.RevealCounterpartyKeyLinkageArgs\x1a1.bsv.wallet.v1.RevealCounterpartyKeyLinkageResult\x12v\n" +
+	"\x18RevealSpecificKeyLinkage\x12+.bsv.wallet.v1.RevealSpecificKeyLinkageArgs\x1a-.bsv.wallet.v1.RevealSpecificKeyLinkageResult\x12C\n" +
+	"\aEncrypt\x12\x1a.bsv.wallet.v1.EncryptArgs\x1a\x1c.bsv.wallet.v1.EncryptResult\x12C\n" +
+	"\aDecrypt\x12\x1a.bsv.wallet.v1.DecryptArgs\x1a\x1c.bsv.wallet.v1.DecryptResult\x12L\n" +
+	"\n" +
+	"CreateHMAC\x12\x1d.bsv.wallet.v1.CreateHMACArgs\x1a\x1f.bsv.wallet.v1.CreateHMACResult\x12L\n" +
+	"\n" +
+	"VerifyHMAC\x12\x1d.bsv.wallet.v1.VerifyHMACArgs\x1a\x1f.bsv.wallet.v1.VerifyHMACResult\x12[\n" +
+	"\x0fCreateSignature\x12\".bsv.wallet.v1.CreateSignatureArgs\x1a$.bsv.wallet.v1.CreateSignatureResult\x12[\n" +
+	"\x0fVerifySignature\x12\".bsv.wallet.v1.VerifySignatureArgs\x1a$.bsv.wallet.v1.VerifySignatureResult\x12W\n" +
+	"\x12AcquireCertificate\x12%.bsv.wallet.v1.AcquireCertificateArgs\x1a\x1a.bsv.wallet.v1.Certificate\x12^\n" +
+	"\x10ListCertificates\x12#.bsv.wallet.v1.ListCertificatesArgs\x1a%.bsv.wallet.v1.ListCertificatesResult\x12^\n" +
+	"\x10ProveCertificate\x12#.bsv.wallet.v1.ProveCertificateArgs\x1a%.bsv.wallet.v1.ProveCertificateResult\x12m\n" +
+	"\x15RelinquishCertificate\x12(.bsv.wallet.v1.RelinquishCertificateArgs\x1a*.bsv.wallet.v1.RelinquishCertificateResult\x12l\n" +
+	"\x15DiscoverByIdentityKey\x12(.bsv.wallet.v1.DiscoverByIdentityKeyArgs\x1a).bsv.wallet.v1.DiscoverCertificatesResult\x12j\n" +
+	"\x14DiscoverByAttributes\x12'.bsv.wallet.v1.DiscoverByAttributesArgs\x1a).bsv.wallet.v1.DiscoverCertificatesResult\x12M\n" +
+	"\x0fIsAuthenticated\x12\x16.google.protobuf.Empty\x1a\".bsv.wallet.v1.AuthenticatedResult\x12S\n" +
+	"\x15WaitForAuthentication\x12\x16.google.protobuf.Empty\x1a\".bsv.wallet.v1.AuthenticatedResult\x12C\n" +
+	"\tGetHeight\x12\x16.google.protobuf.Empty\x1a\x1e.bsv.wallet.v1.GetHeightResult\x12R\n" +
+	"\x12GetHeaderForHeight\x12\x1c.bsv.wallet.v1.GetHeaderArgs\x1a\x1e.bsv.wallet.v1.GetHeaderResult\x12E\n" +
+	"\n" +
+	"GetNetwork\x12\x16.google.protobuf.Empty\x1a\x1f.bsv.wallet.v1.GetNetworkResult\x12E\n" +
+	"\n" +
+	"GetVersion\x12\x16.google.protobuf.Empty\x1a\x1f.bsv.wallet.v1.GetVersionResultBHZFgithub.com/bsv-blockchain/go-sdk/wallet/substrates/walletgrpc/walletpbb\x06proto3"
+
+var (
+	file_wallet_proto_rawDescOnce sync.Once
+	file_wallet_proto_rawDescData []byte
+)
+
+func file_wallet_proto_rawDescGZIP() []byte {
+	file_wallet_proto_rawDescOnce.Do(func() {
+		file_wallet_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_wallet_proto_rawDesc), len(file_wallet_proto_rawDesc)))
+	})
+	return file_wallet_proto_rawDescData
+}
+
+var file_wallet_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_wallet_proto_msgTypes = make([]protoimpl.MessageInfo, 83)
+var file_wallet_proto_goTypes = []any{
+	(CounterpartyType)(0),                      // 0: bsv.wallet.v1.CounterpartyType
+	(*Error)(nil),                              // 1: bsv.wallet.v1.Error
+	(*Outpoint)(nil),                           // 2: bsv.wallet.v1.Outpoint
+	(*Protocol)(nil),                           // 3: bsv.wallet.v1.Protocol
+	(*Counterparty)(nil),                       // 4: bsv.wallet.v1.Counterparty
+	(*EncryptionArgs)(nil),                     // 5: bsv.wallet.v1.EncryptionArgs
+	(*CreateActionInput)(nil),                  // 6: bsv.wallet.v1.CreateActionInput
+	(*CreateActionOutput)(nil),                 // 7: bsv.wallet.v1.CreateActionOutput
+	(*ChangeStrategy)(nil),                     // 8: bsv.wallet.v1.ChangeStrategy
+	(*CreateActionOptions)(nil),                // 9: bsv.wallet.v1.CreateActionOptions
+	(*CreateActionArgs)(nil),                   // 10: bsv.wallet.v1.CreateActionArgs
+	(*SendWithResult)(nil),                     // 11: bsv.wallet.v1.SendWithResult
+	(*SignableTransaction)(nil),                // 12: bsv.wallet.v1.SignableTransaction
+	(*CreateActionResult)(nil),                 // 13: bsv.wallet.v1.CreateActionResult
+	(*SignActionSpend)(nil),                    // 14: bsv.wallet.v1.SignActionSpend
+	(*SignActionOptions)(nil),                  // 15: bsv.wallet.v1.SignActionOptions
+	(*SignActionArgs)(nil),                     // 16: bsv.wallet.v1.SignActionArgs
+	(*SignActionResult)(nil),                   // 17: bsv.wallet.v1.SignActionResult
+	(*AbortActionArgs)(nil),                    // 18: bsv.wallet.v1.AbortActionArgs
+	(*AbortActionResult)(nil),                  // 19: bsv.wallet.v1.AbortActionResult
+	(*ListActionsArgs)(nil),                    // 20: bsv.wallet.v1.ListActionsArgs
+	(*ActionInput)(nil),                        // 21: bsv.wallet.v1.ActionInput
+	(*ActionOutput)(nil),                       // 22: bsv.wallet.v1.ActionOutput
+	(*Action)(nil),                             // 23: bsv.wallet.v1.Action
+	(*ListActionsResult)(nil),                  // 24: bsv.wallet.v1.ListActionsResult
+	(*Payment)(nil),                            // 25: bsv.wallet.v1.Payment
+	(*BasketInsertion)(nil),                    // 26: bsv.wallet.v1.BasketInsertion
+	(*InternalizeOutput)(nil),                  // 27: bsv.wallet.v1.InternalizeOutput
+	(*InternalizeActionArgs)(nil),              // 28: bsv.wallet.v1.InternalizeActionArgs
+	(*InternalizeActionResult)(nil),            // 29: bsv.wallet.v1.InternalizeActionResult
+	(*ListOutputsArgs)(nil),                    // 30: bsv.wallet.v1.ListOutputsArgs
+	(*Output)(nil),                             // 31: bsv.wallet.v1.Output
+	(*ListOutputsResult)(nil),                  // 32: bsv.wallet.v1.ListOutputsResult
+	(*RelinquishOutputArgs)(nil),               // 33: bsv.wallet.v1.RelinquishOutputArgs
+	(*RelinquishOutputResult)(nil),             // 34: bsv.wallet.v1.RelinquishOutputResult
+	(*GetPublicKeyArgs)(nil),                   // 35: bsv.wallet.v1.GetPublicKeyArgs
+	(*GetPublicKeyResult)(nil),                 // 36: bsv.wallet.v1.GetPublicKeyResult
+	(*RevealCounterpartyKeyLinkageArgs)(nil),   // 37: bsv.wallet.v1.RevealCounterpartyKeyLinkageArgs
+	(*RevealCounterpartyKeyLinkageResult)(nil), // 38: bsv.wallet.v1.RevealCounterpartyKeyLinkageResult
+	(*RevealSpecificKeyLinkageArgs)(nil),       // 39: bsv.wallet.v1.RevealSpecificKeyLinkageArgs
+	(*RevealSpecificKeyLinkageResult)(nil),     // 40: bsv.wallet.v1.RevealSpecificKeyLinkageResult
+	(*EncryptArgs)(nil),                        // 41: bsv.wallet.v1.EncryptArgs
+	(*EncryptResult)(nil),                      // 42: bsv.wallet.v1.EncryptResult
+	(*DecryptArgs)(nil),                        // 43: bsv.wallet.v1.DecryptArgs
+	(*DecryptResult)(nil),                      // 44: bsv.wallet.v1.DecryptResult
+	(*CreateHMACArgs)(nil),                     // 45: bsv.wallet.v1.CreateHMACArgs
+	(*CreateHMACResult)(nil),                   // 46: bsv.wallet.v1.CreateHMACResult
+	(*VerifyHMACArgs)(nil),                     // 47: bsv.wallet.v1.VerifyHMACArgs
+	(*VerifyHMACResult)(nil),                   // 48: bsv.wallet.v1.VerifyHMACResult
+	(*SighashContext)(nil),                     // 49: bsv.wallet.v1.SighashContext
+	(*CreateSignatureArgs)(nil),                // 50: bsv.wallet.v1.CreateSignatureArgs
+	(*CreateSignatureResult)(nil),              // 51: bsv.wallet.v1.CreateSignatureResult
+	(*VerifySignatureArgs)(nil),                // 52: bsv.wallet.v1.VerifySignatureArgs
+	(*VerifySignatureResult)(nil),              // 53: bsv.wallet.v1.VerifySignatureResult
+	(*Certificate)(nil),                        // 54: bsv.wallet.v1.Certificate
+	(*KeyringRevealer)(nil),                    // 55: bsv.wallet.v1.KeyringRevealer
+	(*AcquireCertificateArgs)(nil),             // 56: bsv.wallet.v1.AcquireCertificateArgs
+	(*ListCertificatesArgs)(nil),               // 57: bsv.wallet.v1.ListCertificatesArgs
+	(*CertificateResult)(nil),                  // 58: bsv.wallet.v1.CertificateResult
+	(*ListCertificatesResult)(nil),             // 59: bsv.wallet.v1.ListCertificatesResult
+	(*ProveCertificateArgs)(nil),               // 60: bsv.wallet.v1.ProveCertificateArgs
+	(*ProveCertificateResult)(nil),             // 61: bsv.wallet.v1.ProveCertificateResult
+	(*RelinquishCertificateArgs)(nil),          // 62: bsv.wallet.v1.RelinquishCertificateArgs
+	(*RelinquishCertificateResult)(nil),        // 63: bsv.wallet.v1.RelinquishCertificateResult
+	(*IdentityCertifier)(nil),                  // 64: bsv.wallet.v1.IdentityCertifier
+	(*IdentityCertificate)(nil),                // 65: bsv.wallet.v1.IdentityCertificate
+	(*DiscoverByIdentityKeyArgs)(nil),          // 66: bsv.wallet.v1.DiscoverByIdentityKeyArgs
+	(*DiscoverByAttributesArgs)(nil),           // 67: bsv.wallet.v1.DiscoverByAttributesArgs
+	(*DiscoverCertificatesResult)(nil),         // 68: bsv.wallet.v1.DiscoverCertificatesResult
+	(*AuthenticatedResult)(nil),                // 69: bsv.wallet.v1.AuthenticatedResult
+	(*GetHeightResult)(nil),                    // 70: bsv.wallet.v1.GetHeightResult
+	(*GetHeaderArgs)(nil),                      // 71: bsv.wallet.v1.GetHeaderArgs
+	(*GetHeaderResult)(nil),                    // 72: bsv.wallet.v1.GetHeaderResult
+	(*GetNetworkResult)(nil),                   // 73: bsv.wallet.v1.GetNetworkResult
+	(*GetVersionResult)(nil),                   // 74: bsv.wallet.v1.GetVersionResult
+	nil,                                        // 75: bsv.wallet.v1.SignActionArgs.SpendsEntry
+	nil,                                        // 76: bsv.wallet.v1.Certificate.FieldsEntry
+	nil,                                        // 77: bsv.wallet.v1.AcquireCertificateArgs.FieldsEntry
+	nil,                                        // 78: bsv.wallet.v1.AcquireCertificateArgs.KeyringForSubjectEntry
+	nil,                                        // 79: bsv.wallet.v1.CertificateResult.KeyringEntry
+	nil,                                        // 80: bsv.wallet.v1.ProveCertificateResult.KeyringForVerifierEntry
+	nil,                                        // 81: bsv.wallet.v1.IdentityCertificate.PubliclyRevealedKeyringEntry
+	nil,                                        // 82: bsv.wallet.v1.IdentityCertificate.DecryptedFieldsEntry
+	nil,                                        // 83: bsv.wallet.v1.DiscoverByAttributesArgs.AttributesEntry
+	(*emptypb.Empty)(nil),                      // 84: google.protobuf.Empty
+}
+var file_wallet_proto_depIdxs = []int32{
+	0,  // 0: bsv.wallet.v1.Counterparty.type:type_name -> bsv.wallet.v1.CounterpartyType
+	3,  // 1: bsv.wallet.v1.EncryptionArgs.protocol_id:type_name -> bsv.wallet.v1.Protocol
+	4,  // 2: bsv.wallet.v1.EncryptionArgs.counterparty:type_name -> bsv.wallet.v1.Counterparty
+	2,  // 3: bsv.wallet.v1.CreateActionInput.outpoint:type_name -> bsv.wallet.v1.Outpoint
+	2,  // 4: bsv.wallet.v1.CreateActionOptions.no_send_change:type_name -> bsv.wallet.v1.Outpoint
+	8,  // 5: bsv.wallet.v1.CreateActionOptions.change_strategy:type_name -> bsv.wallet.v1.ChangeStrategy
+	6,  // 6: bsv.wallet.v1.CreateActionArgs.inputs:type_name -> bsv.wallet.v1.CreateActionInput
+	7,  // 7: bsv.wallet.v1.CreateActionArgs.outputs:type_name -> bsv.wallet.v1.CreateActionOutput
+	9,  // 8: bsv.wallet.v1.CreateActionArgs.options:type_name -> bsv.wallet.v1.CreateActionOptions
+	2,  // 9: bsv.wallet.v1.CreateActionResult.no_send_change:type_name -> bsv.wallet.v1.Outpoint
+	11, // 10: bsv.wallet.v1.CreateActionResult.send_with_results:type_name -> bsv.wallet.v1.SendWithResult
+	12, // 11: bsv.wallet.v1.CreateActionResult.signable_transaction:type_name -> bsv.wallet.v1.SignableTransaction
+	75, // 12: bsv.wallet.v1.SignActionArgs.spends:type_name -> bsv.wallet.v1.SignActionArgs.SpendsEntry
+	15, // 13: bsv.wallet.v1.SignActionArgs.options:type_name -> bsv.wallet.v1.SignActionOptions
+	11, // 14: bsv.wallet.v1.SignActionResult.send_with_results:type_name -> bsv.wallet.v1.SendWithResult
+	2,  // 15: bsv.wallet.v1.ActionInput.source_outpoint:type_name -> bsv.wallet.v1.Outpoint
+	21, // 16: bsv.wallet.v1.Action.inputs:type_name -> bsv.wallet.v1.ActionInput
+	22, // 17: bsv.wallet.v1.Action.outputs:type_name -> bsv.wallet.v1.ActionOutput
+	23, // 18: bsv.wallet.v1.ListActionsResult.actions:type_name -> bsv.wallet.v1.Action
+	25, // 19: bsv.wallet.v1.InternalizeOutput.payment_remittance:type_name -> bsv.wallet.v1.Payment
+	26, // 20: bsv.wallet.v1.InternalizeOutput.insertion_remittance:type_name -> bsv.wallet.v1.BasketInsertion
+	27, // 21: bsv.wallet.v1.InternalizeActionArgs.outputs:type_name -> bsv.wallet.v1.InternalizeOutput
+	2,  // 22: bsv.wallet.v1.Output.outpoint:type_name -> bsv.wallet.v1.Outpoint
+	31, // 23: bsv.wallet.v1.ListOutputsResult.outputs:type_name -> bsv.wallet.v1.Output
+	2,  // 24: bsv.wallet.v1.RelinquishOutputArgs.output:type_name -> bsv.wallet.v1.Outpoint
+	5,  // 25: bsv.wallet.v1.GetPublicKeyArgs.encryption_args:type_name -> bsv.wallet.v1.EncryptionArgs
+	4,  // 26: bsv.wallet.v1.RevealSpecificKeyLinkageArgs.counterparty:type_name -> bsv.wallet.v1.Counterparty
+	3,  // 27: bsv.wallet.v1.RevealSpecificKeyLinkageArgs.protocol_id:type_name -> bsv.wallet.v1.Protocol
+	3,  // 28: bsv.wallet.v1.RevealSpecificKeyLinkageResult.protocol_id:type_name -> bsv.wallet.v1.Protocol
+	5,  // 29: bsv.wallet.v1.EncryptArgs.encryption_args:type_name -> bsv.wallet.v1.EncryptionArgs
+	5,  // 30: bsv.wallet.v1.DecryptArgs.encryption_args:type_name -> bsv.wallet.v1.EncryptionArgs
+	5,  // 31: bsv.wallet.v1.CreateHMACArgs.encryption_args:type_name -> bsv.wallet.v1.EncryptionArgs
+	5,  // 32: bsv.wallet.v1.VerifyHMACArgs.encryption_args:type_name -> bsv.wallet.v1.EncryptionArgs
+	5,  // 33: bsv.wallet.v1.CreateSignatureArgs.encryption_args:type_name -> bsv.wallet.v1.EncryptionArgs
+	49, // 34: bsv.wallet.v1.CreateSignatureArgs.sighash_context:type_name -> bsv.wallet.v1.SighashContext
+	5,  // 35: bsv.wallet.v1.VerifySignatureArgs.encryption_args:type_name -> bsv.wallet.v1.EncryptionArgs
+	2,  // 36: bsv.wallet.v1.Certificate.revocation_outpoint:type_name -> bsv.wallet.v1.Outpoint
+	76, // 37: bsv.wallet.v1.Certificate.fields:type_name -> bsv.wallet.v1.Certificate.FieldsEntry
+	77, // 38: bsv.wallet.v1.AcquireCertificateArgs.fields:type_name -> bsv.wallet.v1.AcquireCertificateArgs.FieldsEntry
+	2,  // 39: bsv.wallet.v1.AcquireCertificateArgs.revocation_outpoint:type_name -> bsv.wallet.v1.Outpoint
+	55, // 40: bsv.wallet.v1.AcquireCertificateArgs.keyring_revealer:type_name -> bsv.wallet.v1.KeyringRevealer
+	78, // 41: bsv.wallet.v1.AcquireCertificateArgs.keyring_for_subject:type_name -> bsv.wallet.v1.AcquireCertificateArgs.KeyringForSubjectEntry
+	54, // 42: bsv.wallet.v1.CertificateResult.certificate:type_name -> bsv.wallet.v1.Certificate
+	79, // 43: bsv.wallet.v1.CertificateResult.keyring:type_name -> bsv.wallet.v1.CertificateResult.KeyringEntry
+	58, // 44: bsv.wallet.v1.ListCertificatesResult.certificates:type_name -> bsv.wallet.v1.CertificateResult
+	54, // 45: bsv.wallet.v1.ProveCertificateArgs.certificate:type_name -> bsv.wallet.v1.Certificate
+	80, // 46: bsv.wallet.v1.ProveCertificateResult.keyring_for_verifier:type_name -> bsv.wallet.v1.ProveCertificateResult.KeyringForVerifierEntry
+	54, // 47: bsv.wallet.v1.IdentityCertificate.certificate:type_name -> bsv.wallet.v1.Certificate
+	64, // 48: bsv.wallet.v1.IdentityCertificate.certifier_info:type_name -> bsv.wallet.v1.IdentityCertifier
+	81, // 49: bsv.wallet.v1.IdentityCertificate.publicly_revealed_keyring:type_name -> bsv.wallet.v1.IdentityCertificate.PubliclyRevealedKeyringEntry
+	82, // 50: bsv.wallet.v1.IdentityCertificate.decrypted_fields:type_name -> bsv.wallet.v1.IdentityCertificate.DecryptedFieldsEntry
+	83, // 51: bsv.wallet.v1.DiscoverByAttributesArgs.attributes:type_name -> bsv.wallet.v1.DiscoverByAttributesArgs.AttributesEntry
+	65, // 52: bsv.wallet.v1.DiscoverCertificatesResult.certificates:type_name -> bsv.wallet.v1.IdentityCertificate
+	14, // 53: bsv.wallet.v1.SignActionArgs.SpendsEntry.value:type_name -> bsv.wallet.v1.SignActionSpend
+	10, // 54: bsv.wallet.v1.Wallet.CreateAction:input_type -> bsv.wallet.v1.CreateActionArgs
+	16, // 55: bsv.wallet.v1.Wallet.SignAction:input_type -> bsv.wallet.v1.SignActionArgs
+	18, // 56: bsv.wallet.v1.Wallet.AbortAction:input_type -> bsv.wallet.v1.AbortActionArgs
+	20, // 57: bsv.wallet.v1.Wallet.ListActions:input_type -> bsv.wallet.v1.ListActionsArgs
+	28, // 58: bsv.wallet.v1.Wallet.InternalizeAction:input_type -> bsv.wallet.v1.InternalizeActionArgs
+	30, // 59: bsv.wallet.v1.Wallet.ListOutputs:input_type -> bsv.wallet.v1.ListOutputsArgs
+	33, // 60: bsv.wallet.v1.Wallet.RelinquishOutput:input_type -> bsv.wallet.v1.RelinquishOutputArgs
+	35, // 61: bsv.wallet.v1.Wallet.GetPublicKey:input_type -> bsv.wallet.v1.GetPublicKeyArgs
+	37, // 62: bsv.wallet.v1.Wallet.RevealCounterpartyKeyLinkage:input_type -> bsv.wallet.v1.RevealCounterpartyKeyLinkageArgs
+	39, // 63: bsv.wallet.v1.Wallet.RevealSpecificKeyLinkage:input_type -> bsv.wallet.v1.RevealSpecificKeyLinkageArgs
+	41, // 64: bsv.wallet.v1.Wallet.Encrypt:input_type -> bsv.wallet.v1.EncryptArgs
+	43, // 65: bsv.wallet.v1.Wallet.Decrypt:input_type -> bsv.wallet.v1.DecryptArgs
+	45, // 66: bsv.wallet.v1.Wallet.CreateHMAC:input_type -> bsv.wallet.v1.CreateHMACArgs
+	47, // 67: bsv.wallet.v1.Wallet.VerifyHMAC:input_type -> bsv.wallet.v1.VerifyHMACArgs
+	50, // 68: bsv.wallet.v1.Wallet.CreateSignature:input_type -> bsv.wallet.v1.CreateSignatureArgs
+	52, // 69: bsv.wallet.v1.Wallet.VerifySignature:input_type -> bsv.wallet.v1.VerifySignatureArgs
+	56, // 70: bsv.wallet.v1.Wallet.AcquireCertificate:input_type -> bsv.wallet.v1.AcquireCertificateArgs
+	57, // 71: bsv.wallet.v1.Wallet.ListCertificates:input_type -> bsv.wallet.v1.ListCertificatesArgs
+	60, // 72: bsv.wallet.v1.Wallet.ProveCertificate:input_type -> bsv.wallet.v1.ProveCertificateArgs
+	62, // 73: bsv.wallet.v1.Wallet.RelinquishCertificate:input_type -> bsv.wallet.v1.RelinquishCertificateArgs
+	66, // 74: bsv.wallet.v1.Wallet.DiscoverByIdentityKey:input_type -> bsv.wallet.v1.DiscoverByIdentityKeyArgs
+	67, // 75: bsv.wallet.v1.Wallet.DiscoverByAttributes:input_type -> bsv.wallet.v1.DiscoverByAttributesArgs
+	84, // 76: bsv.wallet.v1.Wallet.IsAuthenticated:input_type -> google.protobuf.Empty
+	84, // 77: bsv.wallet.v1.Wallet.WaitForAuthentication:input_type -> google.protobuf.Empty
+	84, // 78: bsv.wallet.v1.Wallet.GetHeight:input_type -> google.protobuf.Empty
+	71, // 79: bsv.wallet.v1.Wallet.GetHeaderForHeight:input_type -> bsv.wallet.v1.GetHeaderArgs
+	84, // 80: bsv.wallet.v1.Wallet.GetNetwork:input_type -> google.protobuf.Empty
+	84, // 81: bsv.wallet.v1.Wallet.GetVersion:input_type -> google.protobuf.Empty
+	13, // 82: bsv.wallet.v1.Wallet.CreateAction:output_type -> bsv.wallet.v1.CreateActionResult
+	17, // 83: bsv.wallet.v1.Wallet.SignAction:output_type -> bsv.wallet.v1.SignActionResult
+	19, // 84: bsv.wallet.v1.Wallet.AbortAction:output_type -> bsv.wallet.v1.AbortActionResult
+	24, // 85: bsv.wallet.v1.Wallet.ListActions:output_type -> bsv.wallet.v1.ListActionsResult
+	29, // 86: bsv.wallet.v1.Wallet.InternalizeAction:output_type -> bsv.wallet.v1.InternalizeActionResult
+	32, // 87: bsv.wallet.v1.Wallet.ListOutputs:output_type -> bsv.wallet.v1.ListOutputsResult
+	34, // 88: bsv.wallet.v1.Wallet.RelinquishOutput:output_type -> bsv.wallet.v1.RelinquishOutputResult
+	36, // 89: bsv.wallet.v1.Wallet.GetPublicKey:output_type -> bsv.wallet.v1.GetPublicKeyResult
+	38, // 90: bsv.wallet.v1.Wallet.RevealCounterpartyKeyLinkage:output_type -> bsv.wallet.v1.RevealCounterpartyKeyLinkageResult
+	40, // 91: bsv.wallet.v1.Wallet.RevealSpecificKeyLinkage:output_type -> bsv.wallet.v1.RevealSpecificKeyLinkageResult
+	42, // 92: bsv.wallet.v1.Wallet.Encrypt:output_type -> bsv.wallet.v1.EncryptResult
+	44, // 93: bsv.wallet.v1.Wallet.Decrypt:output_type -> bsv.wallet.v1.DecryptResult
+	46, // 94: bsv.wallet.v1.Wallet.CreateHMAC:output_type -> bsv.wallet.v1.CreateHMACResult
+	48, // 95: bsv.wallet.v1.Wallet.VerifyHMAC:output_type -> bsv.wallet.v1.VerifyHMACResult
+	51, // 96: bsv.wallet.v1.Wallet.CreateSignature:output_type -> bsv.wallet.v1.CreateSignatureResult
+	53, // 97: bsv.wallet.v1.Wallet.VerifySignature:output_type -> bsv.wallet.v1.VerifySignatureResult
+	54, // 98: bsv.wallet.v1.Wallet.AcquireCertificate:output_type -> bsv.wallet.v1.Certificate
+	59, // 99: bsv.wallet.v1.Wallet.ListCertificates:output_type -> bsv.wallet.v1.ListCertificatesResult
+	61, // 100: bsv.wallet.v1.Wallet.ProveCertificate:output_type -> bsv.wallet.v1.ProveCertificateResult
+	63, // 101: bsv.wallet.v1.Wallet.RelinquishCertificate:output_type -> bsv.wallet.v1.RelinquishCertificateResult
+	68, // 102: bsv.wallet.v1.Wallet.DiscoverByIdentityKey:output_type -> bsv.wallet.v1.DiscoverCertificatesResult
+	68, // 103: bsv.wallet.v1.Wallet.DiscoverByAttributes:output_type -> bsv.wallet.v1.DiscoverCertificatesResult
+	69, // 104: bsv.wallet.v1.Wallet.IsAuthenticated:output_type -> bsv.wallet.v1.AuthenticatedResult
+	69, // 105: bsv.wallet.v1.Wallet.WaitForAuthentication:output_type -> bsv.wallet.v1.AuthenticatedResult
+	70, // 106: bsv.wallet.v1.Wallet.GetHeight:output_type -> bsv.wallet.v1.GetHeightResult
+	72, // 107: bsv.wallet.v1.Wallet.GetHeaderForHeight:output_type -> bsv.wallet.v1.GetHeaderResult
+	73, // 108: bsv.wallet.v1.Wallet.GetNetwork:output_type -> bsv.wallet.v1.GetNetworkResult
+	74, // 109: bsv.wallet.v1.Wallet.GetVersion:output_type -> bsv.wallet.v1.GetVersionResult
+	82, // [82:110] is the sub-list for method output_type
+	54, // [54:82] is the sub-list for method input_type
+	54, // [54:54] is the sub-list for extension type_name
+	54, // [54:54] is the sub-list for extension extendee
+	0,  // [0:54] is the sub-list for field type_name
+}
+
+func init() { file_wallet_proto_init() }
+func file_wallet_proto_init() {
+	if File_wallet_proto != nil {
+		return
+	}
+	file_wallet_proto_msgTypes[5].OneofWrappers = []any{}
+	file_wallet_proto_msgTypes[8].OneofWrappers = []any{}
+	file_wallet_proto_msgTypes[9].OneofWrappers = []any{}
+	file_wallet_proto_msgTypes[13].OneofWrappers = []any{}
+	file_wallet_proto_msgTypes[14].OneofWrappers = []any{}
+	file_wallet_proto_msgTypes[19].OneofWrappers = []any{}
+	file_wallet_proto_msgTypes[27].OneofWrappers = []any{}
+	file_wallet_proto_msgTypes[29].OneofWrappers = []any{}
+	file_wallet_proto_msgTypes[34].OneofWrappers = []any{}
+	file_wallet_proto_msgTypes[36].OneofWrappers = []any{}
+	file_wallet_proto_msgTypes[38].OneofWrappers = []any{}
+	file_wallet_proto_msgTypes[51].OneofWrappers = []any{}
+	file_wallet_proto_msgTypes[54].OneofWrappers = []any{
+		(*KeyringRevealer_Certifier)(nil),
+		(*KeyringRevealer_PublicKey)(nil),
+	}
+	file_wallet_proto_msgTypes[55].OneofWrappers = []any{}
+	file_wallet_proto_msgTypes[56].OneofWrappers = []any{}
+	file_wallet_proto_msgTypes[59].OneofWrappers = []any{}
+	file_wallet_proto_msgTypes[65].OneofWrappers = []any{}
+	file_wallet_proto_msgTypes[66].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_wallet_proto_rawDesc), len(file_wallet_proto_rawDesc)),
+			NumEnums:      1,
+			NumMessages:   83,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_wallet_proto_goTypes,
+		DependencyIndexes: file_wallet_proto_depIdxs,
+		EnumInfos:         file_wallet_proto_enumTypes,
+		MessageInfos:      file_wallet_proto_msgTypes,
+	}.Build()
+	File_wallet_proto = out.File
+	file_wallet_proto_goTypes = nil
+	file_wallet_proto_depIdxs = nil
+}
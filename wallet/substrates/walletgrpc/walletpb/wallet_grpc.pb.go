@@ -0,0 +1,1155 @@
+// Wallet interface over gRPC. Each RPC maps onto the method of wallet.Interface
+// with the same name, and each message onto the Go type with the same name.
+//
+// Public keys are 33 byte compressed keys, signatures are DER encoded and txids
+// are 32 bytes in internal byte order. Enumerations which are strings in Go,
+// such as action statuses, are carried as the same strings.
+
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: wallet.proto
+
+package walletpb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	Wallet_CreateAction_FullMethodName                 = "/bsv.wallet.v1.Wallet/CreateAction"
+	Wallet_SignAction_FullMethodName                   = "/bsv.wallet.v1.Wallet/SignAction"
+	Wallet_AbortAction_FullMethodName                  = "/bsv.wallet.v1.Wallet/AbortAction"
+	Wallet_ListActions_FullMethodName                  = "/bsv.wallet.v1.Wallet/ListActions"
+	Wallet_InternalizeAction_FullMethodName            = "/bsv.wallet.v1.Wallet/InternalizeAction"
+	Wallet_ListOutputs_FullMethodName                  = "/bsv.wallet.v1.Wallet/ListOutputs"
+	Wallet_RelinquishOutput_FullMethodName             = "/bsv.wallet.v1.Wallet/RelinquishOutput"
+	Wallet_GetPublicKey_FullMethodName                 = "/bsv.wallet.v1.Wallet/GetPublicKey"
+	Wallet_RevealCounterpartyKeyLinkage_FullMethodName = "/bsv.wallet.v1.Wallet/RevealCounterpartyKeyLinkage"
+	Wallet_RevealSpecificKeyLinkage_FullMethodName     = "/bsv.wallet.v1.Wallet/RevealSpecificKeyLinkage"
+	Wallet_Encrypt_FullMethodName                      = "/bsv.wallet.v1.Wallet/Encrypt"
+	Wallet_Decrypt_FullMethodName                      = "/bsv.wallet.v1.Wallet/Decrypt"
+	Wallet_CreateHMAC_FullMethodName                   = "/bsv.wallet.v1.Wallet/CreateHMAC"
+	Wallet_VerifyHMAC_FullMethodName                   = "/bsv.wallet.v1.Wallet/VerifyHMAC"
+	Wallet_CreateSignature_FullMethodName              = "/bsv.wallet.v1.Wallet/CreateSignature"
+	Wallet_VerifySignature_FullMethodName              = "/bsv.wallet.v1.Wallet/VerifySignature"
+	Wallet_AcquireCertificate_FullMethodName           = "/bsv.wallet.v1.Wallet/AcquireCertificate"
+	Wallet_ListCertificates_FullMethodName             = "/bsv.wallet.v1.Wallet/ListCertificates"
+	Wallet_ProveCertificate_FullMethodName             = "/bsv.wallet.v1.Wallet/ProveCertificate"
+	Wallet_RelinquishCertificate_FullMethodName        = "/bsv.wallet.v1.Wallet/RelinquishCertificate"
+	Wallet_DiscoverByIdentityKey_FullMethodName        = "/bsv.wallet.v1.Wallet/DiscoverByIdentityKey"
+	Wallet_DiscoverByAttributes_FullMethodName         = "/bsv.wallet.v1.Wallet/DiscoverByAttributes"
+	Wallet_IsAuthenticated_FullMethodName              = "/bsv.wallet.v1.Wallet/IsAuthenticated"
+	Wallet_WaitForAuthentication_FullMethodName        = "/bsv.wallet.v1.Wallet/WaitForAuthentication"
+	Wallet_GetHeight_FullMethodName                    = "/bsv.wallet.v1.Wallet/GetHeight"
+	Wallet_GetHeaderForHeight_FullMethodName           = "/bsv.wallet.v1.Wallet/GetHeaderForHeight"
+	Wallet_GetNetwork_FullMethodName                   = "/bsv.wallet.v1.Wallet/GetNetwork"
+	Wallet_GetVersion_FullMethodName                   = "/bsv.wallet.v1.Wallet/GetVersion"
+)
+
+// WalletClient is the client API for Wallet service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type WalletClient interface {
+	CreateAction(ctx context.Context, in *CreateActionArgs, opts ...grpc.CallOption) (*CreateActionResult, error)
+	SignAction(ctx context.Context, in *SignActionArgs, opts ...grpc.CallOption) (*SignActionResult, error)
+	AbortAction(ctx context.Context, in *AbortActionArgs, opts ...grpc.CallOption) (*AbortActionResult, error)
+	ListActions(ctx context.Context, in *ListActionsArgs, opts ...grpc.CallOption) (*ListActionsResult, error)
+	InternalizeAction(ctx context.Context, in *InternalizeActionArgs, opts ...grpc.CallOption) (*InternalizeActionResult, error)
+	ListOutputs(ctx context.Context, in *ListOutputsArgs, opts ...grpc.CallOption) (*ListOutputsResult, error)
+	RelinquishOutput(ctx context.Context, in *RelinquishOutputArgs, opts ...grpc.CallOption) (*RelinquishOutputResult, error)
+	GetPublicKey(ctx context.Context, in *GetPublicKeyArgs, opts ...grpc.CallOption) (*GetPublicKeyResult, error)
+	RevealCounterpartyKeyLinkage(ctx context.Context, in *RevealCounterpartyKeyLinkageArgs, opts ...grpc.CallOption) (*RevealCounterpartyKeyLinkageResult, error)
+	RevealSpecificKeyLinkage(ctx context.Context, in *RevealSpecificKeyLinkageArgs, opts ...grpc.CallOption) (*RevealSpecificKeyLinkageResult, error)
+	Encrypt(ctx context.Context, in *EncryptArgs, opts ...grpc.CallOption) (*EncryptResult, error)
+	Decrypt(ctx context.Context, in *DecryptArgs, opts ...grpc.CallOption) (*DecryptResult, error)
+	CreateHMAC(ctx context.Context, in *CreateHMACArgs, opts ...grpc.CallOption) (*CreateHMACResult, error)
+	VerifyHMAC(ctx context.Context, in *VerifyHMACArgs, opts ...grpc.CallOption) (*VerifyHMACResult, error)
+	CreateSignature(ctx context.Context, in *CreateSignatureArgs, opts ...grpc.CallOption) (*CreateSignatureResult, error)
+	VerifySignature(ctx context.Context, in *VerifySignatureArgs, opts ...grpc.CallOption) (*VerifySignatureResult, error)
+	AcquireCertificate(ctx context.Context, in *AcquireCertificateArgs, opts ...grpc.CallOption) (*Certificate, error)
+	ListCertificates(ctx context.Context, in *ListCertificatesArgs, opts ...grpc.CallOption) (*ListCertificatesResult, error)
+	ProveCertificate(ctx context.Context, in *ProveCertificateArgs, opts ...grpc.CallOption) (*ProveCertificateResult, error)
+	RelinquishCertificate(ctx context.Context, in *RelinquishCertificateArgs, opts ...grpc.CallOption) (*RelinquishCertificateResult, error)
+	DiscoverByIdentityKey(ctx context.Context, in *DiscoverByIdentityKeyArgs, opts ...grpc.CallOption) (*DiscoverCertificatesResult, error)
+	DiscoverByAttributes(ctx context.Context, in *DiscoverByAttributesArgs, opts ...grpc.CallOption) (*DiscoverCertificatesResult, error)
+	IsAuthenticated(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*AuthenticatedResult, error)
+	WaitForAuthentication(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*AuthenticatedResult, error)
+	GetHeight(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*GetHeightResult, error)
+	GetHeaderForHeight(ctx context.Context, in *GetHeaderArgs, opts ...grpc.CallOption) (*GetHeaderResult, error)
+	GetNetwork(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*GetNetworkResult, error)
+	GetVersion(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*GetVersionResult, error)
+}
+
+type walletClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewWalletClient(cc grpc.ClientConnInterface) WalletClient {
+	return &walletClient{cc}
+}
+
+func (c *walletClient) CreateAction(ctx context.Context, in *CreateActionArgs, opts ...grpc.CallOption) (*CreateActionResult, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateActionResult)
+	err := c.cc.Invoke(ctx, Wallet_CreateAction_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletClient) SignAction(ctx context.Context, in *SignActionArgs, opts ...grpc.CallOption) (*SignActionResult, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SignActionResult)
+	err := c.cc.Invoke(ctx, Wallet_SignAction_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletClient) AbortAction(ctx context.Context, in *AbortActionArgs, opts ...grpc.CallOption) (*AbortActionResult, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AbortActionResult)
+	err := c.cc.Invoke(ctx, Wallet_AbortAction_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletClient) ListActions(ctx context.Context, in *ListActionsArgs, opts ...grpc.CallOption) (*ListActionsResult, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListActionsResult)
+	err := c.cc.Invoke(ctx, Wallet_ListActions_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletClient) InternalizeAction(ctx context.Context, in *InternalizeActionArgs, opts ...grpc.CallOption) (*InternalizeActionResult, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(InternalizeActionResult)
+	err := c.cc.Invoke(ctx, Wallet_InternalizeAction_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletClient) ListOutputs(ctx context.Context, in *ListOutputsArgs, opts ...grpc.CallOption) (*ListOutputsResult, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListOutputsResult)
+	err := c.cc.Invoke(ctx, Wallet_ListOutputs_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletClient) RelinquishOutput(ctx context.Context, in *RelinquishOutputArgs, opts ...grpc.CallOption) (*RelinquishOutputResult, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RelinquishOutputResult)
+	err := c.cc.Invoke(ctx, Wallet_RelinquishOutput_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletClient) GetPublicKey(ctx context.Context, in *GetPublicKeyArgs, opts ...grpc.CallOption) (*GetPublicKeyResult, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetPublicKeyResult)
+	err := c.cc.Invoke(ctx, Wallet_GetPublicKey_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletClient) RevealCounterpartyKeyLinkage(ctx context.Context, in *RevealCounterpartyKeyLinkageArgs, opts ...grpc.CallOption) (*RevealCounterpartyKeyLinkageResult, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RevealCounterpartyKeyLinkageResult)
+	err := c.cc.Invoke(ctx, Wallet_RevealCounterpartyKeyLinkage_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletClient) RevealSpecificKeyLinkage(ctx context.Context, in *RevealSpecificKeyLinkageArgs, opts ...grpc.CallOption) (*RevealSpecificKeyLinkageResult, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RevealSpecificKeyLinkageResult)
+	err := c.cc.Invoke(ctx, Wallet_RevealSpecificKeyLinkage_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletClient) Encrypt(ctx context.Context, in *EncryptArgs, opts ...grpc.CallOption) (*EncryptResult, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(EncryptResult)
+	err := c.cc.Invoke(ctx, Wallet_Encrypt_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletClient) Decrypt(ctx context.Context, in *DecryptArgs, opts ...grpc.CallOption) (*DecryptResult, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DecryptResult)
+	err := c.cc.Invoke(ctx, Wallet_Decrypt_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletClient) CreateHMAC(ctx context.Context, in *CreateHMACArgs, opts ...grpc.CallOption) (*CreateHMACResult, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateHMACResult)
+	err := c.cc.Invoke(ctx, Wallet_CreateHMAC_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletClient) VerifyHMAC(ctx context.Context, in *VerifyHMACArgs, opts ...grpc.CallOption) (*VerifyHMACResult, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(VerifyHMACResult)
+	err := c.cc.Invoke(ctx, Wallet_VerifyHMAC_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletClient) CreateSignature(ctx context.Context, in *CreateSignatureArgs, opts ...grpc.CallOption) (*CreateSignatureResult, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateSignatureResult)
+	err := c.cc.Invoke(ctx, Wallet_CreateSignature_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletClient) VerifySignature(ctx context.Context, in *VerifySignatureArgs, opts ...grpc.CallOption) (*VerifySignatureResult, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(VerifySignatureResult)
+	err := c.cc.Invoke(ctx, Wallet_VerifySignature_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletClient) AcquireCertificate(ctx context.Context, in *AcquireCertificateArgs, opts ...grpc.CallOption) (*Certificate, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Certificate)
+	err := c.cc.Invoke(ctx, Wallet_AcquireCertificate_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletClient) ListCertificates(ctx context.Context, in *ListCertificatesArgs, opts ...grpc.CallOption) (*ListCertificatesResult, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListCertificatesResult)
+	err := c.cc.Invoke(ctx, Wallet_ListCertificates_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletClient) ProveCertificate(ctx context.Context, in *ProveCertificateArgs, opts ...grpc.CallOption) (*ProveCertificateResult, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ProveCertificateResult)
+	err := c.cc.Invoke(ctx, Wallet_ProveCertificate_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletClient) RelinquishCertificate(ctx context.Context, in *RelinquishCertificateArgs, opts ...grpc.CallOption) (*RelinquishCertificateResult, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RelinquishCertificateResult)
+	err := c.cc.Invoke(ctx, Wallet_RelinquishCertificate_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletClient) DiscoverByIdentityKey(ctx context.Context, in *DiscoverByIdentityKeyArgs, opts ...grpc.CallOption) (*DiscoverCertificatesResult, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DiscoverCertificatesResult)
+	err := c.cc.Invoke(ctx, Wallet_DiscoverByIdentityKey_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletClient) DiscoverByAttributes(ctx context.Context, in *DiscoverByAttributesArgs, opts ...grpc.CallOption) (*DiscoverCertificatesResult, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DiscoverCertificatesResult)
+	err := c.cc.Invoke(ctx, Wallet_DiscoverByAttributes_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletClient) IsAuthenticated(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*AuthenticatedResult, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AuthenticatedResult)
+	err := c.cc.Invoke(ctx, Wallet_IsAuthenticated_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletClient) WaitForAuthentication(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*AuthenticatedResult, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AuthenticatedResult)
+	err := c.cc.Invoke(ctx, Wallet_WaitForAuthentication_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletClient) GetHeight(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*GetHeightResult, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetHeightResult)
+	err := c.cc.Invoke(ctx, Wallet_GetHeight_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletClient) GetHeaderForHeight(ctx context.Context, in *GetHeaderArgs, opts ...grpc.CallOption) (*GetHeaderResult, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetHeaderResult)
+	err := c.cc.Invoke(ctx, Wallet_GetHeaderForHeight_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletClient) GetNetwork(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*GetNetworkResult, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetNetworkResult)
+	err := c.cc.Invoke(ctx, Wallet_GetNetwork_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletClient) GetVersion(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*GetVersionResult, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetVersionResult)
+	err := c.cc.Invoke(ctx, Wallet_GetVersion_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// WalletServer is the server API for Wallet service.
+// All implementations must embed UnimplementedWalletServer
+// for forward compatibility.
+type WalletServer interface {
+	CreateAction(context.Context, *CreateActionArgs) (*CreateActionResult, error)
+	SignAction(context.Context, *SignActionArgs) (*SignActionResult, error)
+	AbortAction(context.Context, *AbortActionArgs) (*AbortActionResult, error)
+	ListActions(context.Context, *ListActionsArgs) (*ListActionsResult, error)
+	InternalizeAction(context.Context, *InternalizeActionArgs) (*InternalizeActionResult, error)
+	ListOutputs(context.Context, *ListOutputsArgs) (*ListOutputsResult, error)
+	RelinquishOutput(context.Context, *RelinquishOutputArgs) (*RelinquishOutputResult, error)
+	GetPublicKey(context.Context, *GetPublicKeyArgs) (*GetPublicKeyResult, error)
+	RevealCounterpartyKeyLinkage(context.Context, *RevealCounterpartyKeyLinkageArgs) (*RevealCounterpartyKeyLinkageResult, error)
+	RevealSpecificKeyLinkage(context.Context, *RevealSpecificKeyLinkageArgs) (*RevealSpecificKeyLinkageResult, error)
+	Encrypt(context.Context, *EncryptArgs) (*EncryptResult, error)
+	Decrypt(context.Context, *DecryptArgs) (*DecryptResult, error)
+	CreateHMAC(context.Context, *CreateHMACArgs) (*CreateHMACResult, error)
+	VerifyHMAC(context.Context, *VerifyHMACArgs) (*VerifyHMACResult, error)
+	CreateSignature(context.Context, *CreateSignatureArgs) (*CreateSignatureResult, error)
+	VerifySignature(context.Context, *VerifySignatureArgs) (*VerifySignatureResult, error)
+	AcquireCertificate(context.Context, *AcquireCertificateArgs) (*Certificate, error)
+	ListCertificates(context.Context, *ListCertificatesArgs) (*ListCertificatesResult, error)
+	ProveCertificate(context.Context, *ProveCertificateArgs) (*ProveCertificateResult, error)
+	RelinquishCertificate(context.Context, *RelinquishCertificateArgs) (*RelinquishCertificateResult, error)
+	DiscoverByIdentityKey(context.Context, *DiscoverByIdentityKeyArgs) (*DiscoverCertificatesResult, error)
+	DiscoverByAttributes(context.Context, *DiscoverByAttributesArgs) (*DiscoverCertificatesResult, error)
+	IsAuthenticated(context.Context, *emptypb.Empty) (*AuthenticatedResult, error)
+	WaitForAuthentication(context.Context, *emptypb.Empty) (*AuthenticatedResult, error)
+	GetHeight(context.Context, *emptypb.Empty) (*GetHeightResult, error)
+	GetHeaderForHeight(context.Context, *GetHeaderArgs) (*GetHeaderResult, error)
+	GetNetwork(context.Context, *emptypb.Empty) (*GetNetworkResult, error)
+	GetVersion(context.Context, *emptypb.Empty) (*GetVersionResult, error)
+	mustEmbedUnimplementedWalletServer()
+}
+
+// UnimplementedWalletServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedWalletServer struct{}
+
+func (UnimplementedWalletServer) CreateAction(context.Context, *CreateActionArgs) (*CreateActionResult, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateAction not implemented")
+}
+func (UnimplementedWalletServer) SignAction(context.Context, *SignActionArgs) (*SignActionResult, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SignAction not implemented")
+}
+func (UnimplementedWalletServer) AbortAction(context.Context, *AbortActionArgs) (*AbortActionResult, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AbortAction not implemented")
+}
+func (UnimplementedWalletServer) ListActions(context.Context, *ListActionsArgs) (*ListActionsResult, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListActions not implemented")
+}
+func (UnimplementedWalletServer) InternalizeAction(context.Context, *InternalizeActionArgs) (*InternalizeActionResult, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method InternalizeAction not implemented")
+}
+func (UnimplementedWalletServer) ListOutputs(context.Context, *ListOutputsArgs) (*ListOutputsResult, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListOutputs not implemented")
+}
+func (UnimplementedWalletServer) RelinquishOutput(context.Context, *RelinquishOutputArgs) (*RelinquishOutputResult, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RelinquishOutput not implemented")
+}
+func (UnimplementedWalletServer) GetPublicKey(context.Context, *GetPublicKeyArgs) (*GetPublicKeyResult, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPublicKey not implemented")
+}
+func (UnimplementedWalletServer) RevealCounterpartyKeyLinkage(context.Context, *RevealCounterpartyKeyLinkageArgs) (*RevealCounterpartyKeyLinkageResult, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RevealCounterpartyKeyLinkage not implemented")
+}
+func (UnimplementedWalletServer) RevealSpecificKeyLinkage(context.Context, *RevealSpecificKeyLinkageArgs) (*RevealSpecificKeyLinkageResult, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RevealSpecificKeyLinkage not implemented")
+}
+func (UnimplementedWalletServer) Encrypt(context.Context, *EncryptArgs) (*EncryptResult, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Encrypt not implemented")
+}
+func (UnimplementedWalletServer) Decrypt(context.Context, *DecryptArgs) (*DecryptResult, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Decrypt not implemented")
+}
+func (UnimplementedWalletServer) CreateHMAC(context.Context, *CreateHMACArgs) (*CreateHMACResult, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateHMAC not implemented")
+}
+func (UnimplementedWalletServer) VerifyHMAC(context.Context, *VerifyHMACArgs) (*VerifyHMACResult, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method VerifyHMAC not implemented")
+}
+func (UnimplementedWalletServer) CreateSignature(context.Context, *CreateSignatureArgs) (*CreateSignatureResult, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateSignature not implemented")
+}
+func (UnimplementedWalletServer) VerifySignature(context.Context, *VerifySignatureArgs) (*VerifySignatureResult, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method VerifySignature not implemented")
+}
+func (UnimplementedWalletServer) AcquireCertificate(context.Context, *AcquireCertificateArgs) (*Certificate, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AcquireCertificate not implemented")
+}
+func (UnimplementedWalletServer) ListCertificates(context.Context, *ListCertificatesArgs) (*ListCertificatesResult, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListCertificates not implemented")
+}
+func (UnimplementedWalletServer) ProveCertificate(context.Context, *ProveCertificateArgs) (*ProveCertificateResult, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ProveCertificate not implemented")
+}
+func (UnimplementedWalletServer) RelinquishCertificate(context.Context, *RelinquishCertificateArgs) (*RelinquishCertificateResult, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RelinquishCertificate not implemented")
+}
+func (UnimplementedWalletServer) DiscoverByIdentityKey(context.Context, *DiscoverByIdentityKeyArgs) (*DiscoverCertificatesResult, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DiscoverByIdentityKey not implemented")
+}
+func (UnimplementedWalletServer) DiscoverByAttributes(context.Context, *DiscoverByAttributesArgs) (*DiscoverCertificatesResult, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DiscoverByAttributes not implemented")
+}
+func (UnimplementedWalletServer) IsAuthenticated(context.Context, *emptypb.Empty) (*AuthenticatedResult, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method IsAuthenticated not implemented")
+}
+func (UnimplementedWalletServer) WaitForAuthentication(context.Context, *emptypb.Empty) (*AuthenticatedResult, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method WaitForAuthentication not implemented")
+}
+func (UnimplementedWalletServer) GetHeight(context.Context, *emptypb.Empty) (*GetHeightResult, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetHeight not implemented")
+}
+func (UnimplementedWalletServer) GetHeaderForHeight(context.Context, *GetHeaderArgs) (*GetHeaderResult, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetHeaderForHeight not implemented")
+}
+func (UnimplementedWalletServer) GetNetwork(context.Context, *emptypb.Empty) (*GetNetworkResult, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetNetwork not implemented")
+}
+func (UnimplementedWalletServer) GetVersion(context.Context, *emptypb.Empty) (*GetVersionResult, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetVersion not implemented")
+}
+func (UnimplementedWalletServer) mustEmbedUnimplementedWalletServer() {}
+func (UnimplementedWalletServer) testEmbeddedByValue()                {}
+
+// UnsafeWalletServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to WalletServer will
+// result in compilation errors.
+type UnsafeWalletServer interface {
+	mustEmbedUnimplementedWalletServer()
+}
+
+func RegisterWalletServer(s grpc.ServiceRegistrar, srv WalletServer) {
+	// If the following call pancis, it indicates UnimplementedWalletServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&Wallet_ServiceDesc, srv)
+}
+
+func _Wallet_CreateAction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateActionArgs)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServer).CreateAction(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Wallet_CreateAction_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServer).CreateAction(ctx, req.(*CreateActionArgs))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Wallet_SignAction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SignActionArgs)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServer).SignAction(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Wallet_SignAction_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServer).SignAction(ctx, req.(*SignActionArgs))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Wallet_AbortAction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AbortActionArgs)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServer).AbortAction(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Wallet_AbortAction_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServer).AbortAction(ctx, req.(*AbortActionArgs))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Wallet_ListActions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListActionsArgs)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServer).ListActions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Wallet_ListActions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServer).ListActions(ctx, req.(*ListActionsArgs))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Wallet_InternalizeAction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(InternalizeActionArgs)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServer).InternalizeAction(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Wallet_InternalizeAction_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServer).InternalizeAction(ctx, req.(*InternalizeActionArgs))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Wallet_ListOutputs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListOutputsArgs)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServer).ListOutputs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Wallet_ListOutputs_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServer).ListOutputs(ctx, req.(*ListOutputsArgs))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Wallet_RelinquishOutput_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RelinquishOutputArgs)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServer).RelinquishOutput(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Wallet_RelinquishOutput_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServer).RelinquishOutput(ctx, req.(*RelinquishOutputArgs))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Wallet_GetPublicKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPublicKeyArgs)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServer).GetPublicKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Wallet_GetPublicKey_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServer).GetPublicKey(ctx, req.(*GetPublicKeyArgs))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Wallet_RevealCounterpartyKeyLinkage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RevealCounterpartyKeyLinkageArgs)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServer).RevealCounterpartyKeyLinkage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Wallet_RevealCounterpartyKeyLinkage_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServer).RevealCounterpartyKeyLinkage(ctx, req.(*RevealCounterpartyKeyLinkageArgs))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Wallet_RevealSpecificKeyLinkage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RevealSpecificKeyLinkageArgs)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServer).RevealSpecificKeyLinkage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Wallet_RevealSpecificKeyLinkage_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServer).RevealSpecificKeyLinkage(ctx, req.(*RevealSpecificKeyLinkageArgs))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Wallet_Encrypt_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EncryptArgs)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServer).Encrypt(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Wallet_Encrypt_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServer).Encrypt(ctx, req.(*EncryptArgs))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Wallet_Decrypt_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DecryptArgs)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServer).Decrypt(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Wallet_Decrypt_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServer).Decrypt(ctx, req.(*DecryptArgs))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Wallet_CreateHMAC_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateHMACArgs)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServer).CreateHMAC(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Wallet_CreateHMAC_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServer).CreateHMAC(ctx, req.(*CreateHMACArgs))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Wallet_VerifyHMAC_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VerifyHMACArgs)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServer).VerifyHMAC(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Wallet_VerifyHMAC_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServer).VerifyHMAC(ctx, req.(*VerifyHMACArgs))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Wallet_CreateSignature_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateSignatureArgs)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServer).CreateSignature(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Wallet_CreateSignature_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServer).CreateSignature(ctx, req.(*CreateSignatureArgs))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Wallet_VerifySignature_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VerifySignatureArgs)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServer).VerifySignature(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Wallet_VerifySignature_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServer).VerifySignature(ctx, req.(*VerifySignatureArgs))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Wallet_AcquireCertificate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AcquireCertificateArgs)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServer).AcquireCertificate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Wallet_AcquireCertificate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServer).AcquireCertificate(ctx, req.(*AcquireCertificateArgs))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Wallet_ListCertificates_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListCertificatesArgs)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServer).ListCertificates(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Wallet_ListCertificates_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServer).ListCertificates(ctx, req.(*ListCertificatesArgs))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Wallet_ProveCertificate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ProveCertificateArgs)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServer).ProveCertificate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Wallet_ProveCertificate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServer).ProveCertificate(ctx, req.(*ProveCertificateArgs))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Wallet_RelinquishCertificate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RelinquishCertificateArgs)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServer).RelinquishCertificate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Wallet_RelinquishCertificate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServer).RelinquishCertificate(ctx, req.(*RelinquishCertificateArgs))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Wallet_DiscoverByIdentityKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DiscoverByIdentityKeyArgs)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServer).DiscoverByIdentityKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Wallet_DiscoverByIdentityKey_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServer).DiscoverByIdentityKey(ctx, req.(*DiscoverByIdentityKeyArgs))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Wallet_DiscoverByAttributes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DiscoverByAttributesArgs)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServer).DiscoverByAttributes(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Wallet_DiscoverByAttributes_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServer).DiscoverByAttributes(ctx, req.(*DiscoverByAttributesArgs))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Wallet_IsAuthenticated_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServer).IsAuthenticated(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Wallet_IsAuthenticated_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServer).IsAuthenticated(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Wallet_WaitForAuthentication_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServer).WaitForAuthentication(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Wallet_WaitForAuthentication_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServer).WaitForAuthentication(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Wallet_GetHeight_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServer).GetHeight(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Wallet_GetHeight_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServer).GetHeight(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Wallet_GetHeaderForHeight_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetHeaderArgs)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServer).GetHeaderForHeight(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Wallet_GetHeaderForHeight_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServer).GetHeaderForHeight(ctx, req.(*GetHeaderArgs))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Wallet_GetNetwork_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServer).GetNetwork(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Wallet_GetNetwork_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServer).GetNetwork(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Wallet_GetVersion_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServer).GetVersion(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Wallet_GetVersion_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServer).GetVersion(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// Wallet_ServiceDesc is the grpc.ServiceDesc for Wallet service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Wallet_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "bsv.wallet.v1.Wallet",
+	HandlerType: (*WalletServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateAction",
+			Handler:    _Wallet_CreateAction_Handler,
+		},
+		{
+			MethodName: "SignAction",
+			Handler:    _Wallet_SignAction_Handler,
+		},
+		{
+			MethodName: "AbortAction",
+			Handler:    _Wallet_AbortAction_Handler,
+		},
+		{
+			MethodName: "ListActions",
+			Handler:    _Wallet_ListActions_Handler,
+		},
+		{
+			MethodName: "InternalizeAction",
+			Handler:    _Wallet_InternalizeAction_Handler,
+		},
+		{
+			MethodName: "ListOutputs",
+			Handler:    _Wallet_ListOutputs_Handler,
+		},
+		{
+			MethodName: "RelinquishOutput",
+			Handler:    _Wallet_RelinquishOutput_Handler,
+		},
+		{
+			MethodName: "GetPublicKey",
+			Handler:    _Wallet_GetPublicKey_Handler,
+		},
+		{
+			MethodName: "RevealCounterpartyKeyLinkage",
+			Handler:    _Wallet_RevealCounterpartyKeyLinkage_Handler,
+		},
+		{
+			MethodName: "RevealSpecificKeyLinkage",
+			Handler:    _Wallet_RevealSpecificKeyLinkage_Handler,
+		},
+		{
+			MethodName: "Encrypt",
+			Handler:    _Wallet_Encrypt_Handler,
+		},
+		{
+			MethodName: "Decrypt",
+			Handler:    _Wallet_Decrypt_Handler,
+		},
+		{
+			MethodName: "CreateHMAC",
+			Handler:    _Wallet_CreateHMAC_Handler,
+		},
+		{
+			MethodName: "VerifyHMAC",
+			Handler:    _Wallet_VerifyHMAC_Handler,
+		},
+		{
+			MethodName: "CreateSignature",
+			Handler:    _Wallet_CreateSignature_Handler,
+		},
+		{
+			MethodName: "VerifySignature",
+			Handler:    _Wallet_VerifySignature_Handler,
+		},
+		{
+			MethodName: "AcquireCertificate",
+			Handler:    _Wallet_AcquireCertificate_Handler,
+		},
+		{
+			MethodName: "ListCertificates",
+			Handler:    _Wallet_ListCertificates_Handler,
+		},
+		{
+			MethodName: "ProveCertificate",
+			Handler:    _Wallet_ProveCertificate_Handler,
+		},
+		{
+			MethodName: "RelinquishCertificate",
+			Handler:    _Wallet_RelinquishCertificate_Handler,
+		},
+		{
+			MethodName: "DiscoverByIdentityKey",
+			Handler:    _Wallet_DiscoverByIdentityKey_Handler,
+		},
+		{
+			MethodName: "DiscoverByAttributes",
+			Handler:    _Wallet_DiscoverByAttributes_Handler,
+		},
+		{
+			MethodName: "IsAuthenticated",
+			Handler:    _Wallet_IsAuthenticated_Handler,
+		},
+		{
+			MethodName: "WaitForAuthentication",
+			Handler:    _Wallet_WaitForAuthentication_Handler,
+		},
+		{
+			MethodName: "GetHeight",
+			Handler:    _Wallet_GetHeight_Handler,
+		},
+		{
+			MethodName: "GetHeaderForHeight",
+			Handler:    _Wallet_GetHeaderForHeight_Handler,
+		},
+		{
+			MethodName: "GetNetwork",
+			Handler:    _Wallet_GetNetwork_Handler,
+		},
+		{
+			MethodName: "GetVersion",
+			Handler:    _Wallet_GetVersion_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "wallet.proto",
+}
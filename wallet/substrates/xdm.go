@@ -0,0 +1,292 @@
+package substrates
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/bsv-blockchain/go-sdk/wallet"
+)
+
+// XDMMessageType is the type of the cross-document messages carrying wallet
+// calls between browser windows, as exchanged by the XDM substrate of the
+// TypeScript SDK.
+const XDMMessageType = "CWI"
+
+// xdmErrorCode is the code of errors replied for failures which are not
+// *wallet.Error.
+const xdmErrorCode = 1
+
+// XDMMessage is a cross-document wallet message. An invocation carries the call
+// name and its JSON arguments; the reply with the same ID carries the JSON
+// result, or status "error" with a description and code.
+type XDMMessage struct {
+	Type         string          `json:"type"`
+	IsInvocation bool            `json:"isInvocation,omitempty"`
+	ID           string          `json:"id"`
+	Call         string          `json:"call,omitempty"`
+	Args         json.RawMessage `json:"args,omitempty"`
+	Result       json.RawMessage `json:"result,omitempty"`
+	Status       string          `json:"status,omitempty"`
+	Description  string          `json:"description,omitempty"`
+	Code         int             `json:"code,omitempty"`
+}
+
+// XDMPort posts messages to the other end of a cross-document channel, such as
+// the parent window of an embedded app. In the browser, NewWindowXDMPort posts
+// with window.postMessage.
+type XDMPort interface {
+	PostMessage(msg *XDMMessage) error
+}
+
+var _ wallet.Interface = (*XDMWallet)(nil)
+
+// XDMWallet implements wallet.Interface over cross-document messaging. Each call
+// is posted through the port as an invocation and completed by the reply with
+// the same ID, which the port's listener passes to HandleMessage.
+//
+// The originator argument of the calls is not sent: the wallet receiving them
+// identifies the caller by the origin of its window.
+type XDMWallet struct {
+	port XDMPort
+
+	mu      sync.Mutex
+	pending map[string]chan *XDMMessage
+}
+
+// NewXDMWallet creates an XDMWallet posting calls through port.
+func NewXDMWallet(port XDMPort) *XDMWallet {
+	return &XDMWallet{port: port, pending: make(map[string]chan *XDMMessage)}
+}
+
+// HandleMessage completes the call replied to by msg. Messages which are not
+// replies to a pending call are ignored.
+func (w *XDMWallet) HandleMessage(msg *XDMMessage) {
+	if msg == nil || msg.Type != XDMMessageType || msg.IsInvocation {
+		return
+	}
+	w.mu.Lock()
+	reply, ok := w.pending[msg.ID]
+	delete(w.pending, msg.ID)
+	w.mu.Unlock()
+	if ok {
+		reply <- msg
+	}
+}
+
+func (w *XDMWallet) invoke(ctx context.Context, call Call, args any) (json.RawMessage, error) {
+	params, err := json.Marshal(args)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal arguments: %w", err)
+	}
+	id := make([]byte, 12)
+	if _, err := rand.Read(id); err != nil {
+		return nil, err
+	}
+	msg := &XDMMessage{
+		Type:         XDMMessageType,
+		IsInvocation: true,
+		ID:           base64.StdEncoding.EncodeToString(id),
+		Call:         callCodeToName[call],
+		Args:         params,
+	}
+
+	reply := make(chan *XDMMessage, 1)
+	w.mu.Lock()
+	w.pending[msg.ID] = reply
+	w.mu.Unlock()
+	defer func() {
+		w.mu.Lock()
+		delete(w.pending, msg.ID)
+		w.mu.Unlock()
+	}()
+
+	if err := w.port.PostMessage(msg); err != nil {
+		return nil, fmt.Errorf("failed to post message: %w", err)
+	}
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case res := <-reply:
+		if res.Status == "error" {
+			return nil, &wallet.Error{Code: byte(res.Code), Message: res.Description}
+		}
+		return res.Result, nil
+	}
+}
+
+func xdmCall[R, A any](ctx context.Context, w *XDMWallet, call Call, args A) (*R, error) {
+	// Marshal through a pointer, so that the MarshalJSON methods with pointer
+	// receivers of the argument types apply.
+	data, err := w.invoke(ctx, call, &args)
+	if err != nil {
+		return nil, err
+	}
+	var result R
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal %s result: %w", callCodeToName[call], err)
+	}
+	return &result, nil
+}
+
+func (w *XDMWallet) CreateAction(ctx context.Context, args wallet.CreateActionArgs, _ string) (*wallet.CreateActionResult, error) {
+	return xdmCall[wallet.CreateActionResult](ctx, w, CallCreateAction, args)
+}
+
+func (w *XDMWallet) SignAction(ctx context.Context, args wallet.SignActionArgs, _ string) (*wallet.SignActionResult, error) {
+	return xdmCall[wallet.SignActionResult](ctx, w, CallSignAction, args)
+}
+
+func (w *XDMWallet) AbortAction(ctx context.Context, args wallet.AbortActionArgs, _ string) (*wallet.AbortActionResult, error) {
+	return xdmCall[wallet.AbortActionResult](ctx, w, CallAbortAction, args)
+}
+
+func (w *XDMWallet) ListActions(ctx context.Context, args wallet.ListActionsArgs, _ string) (*wallet.ListActionsResult, error) {
+	return xdmCall[wallet.ListActionsResult](ctx, w, CallListActions, args)
+}
+
+func (w *XDMWallet) InternalizeAction(ctx context.Context, args wallet.InternalizeActionArgs, _ string) (*wallet.InternalizeActionResult, error) {
+	return xdmCall[wallet.InternalizeActionResult](ctx, w, CallInternalizeAction, args)
+}
+
+func (w *XDMWallet) ListOutputs(ctx context.Context, args wallet.ListOutputsArgs, _ string) (*wallet.ListOutputsResult, error) {
+	return xdmCall[wallet.ListOutputsResult](ctx, w, CallListOutputs, args)
+}
+
+func (w *XDMWallet) RelinquishOutput(ctx context.Context, args wallet.RelinquishOutputArgs, _ string) (*wallet.RelinquishOutputResult, error) {
+	return xdmCall[wallet.RelinquishOutputResult](ctx, w, CallRelinquishOutput, args)
+}
+
+func (w *XDMWallet) GetPublicKey(ctx context.Context, args wallet.GetPublicKeyArgs, _ string) (*wallet.GetPublicKeyResult, error) {
+	return xdmCall[wallet.GetPublicKeyResult](ctx, w, CallGetPublicKey, args)
+}
+
+func (w *XDMWallet) RevealCounterpartyKeyLinkage(ctx context.Context, args wallet.RevealCounterpartyKeyLinkageArgs, _ string) (*wallet.RevealCounterpartyKeyLinkageResult, error) {
+	return xdmCall[wallet.RevealCounterpartyKeyLinkageResult](ctx, w, CallRevealCounterpartyKeyLinkage, args)
+}
+
+func (w *XDMWallet) RevealSpecificKeyLinkage(ctx context.Context, args wallet.RevealSpecificKeyLinkageArgs, _ string) (*wallet.RevealSpecificKeyLinkageResult, error) {
+	return xdmCall[wallet.RevealSpecificKeyLinkageResult](ctx, w, CallRevealSpecificKeyLinkage, args)
+}
+
+func (w *XDMWallet) Encrypt(ctx context.Context, args wallet.EncryptArgs, _ string) (*wallet.EncryptResult, error) {
+	return xdmCall[wallet.EncryptResult](ctx, w, CallEncrypt, args)
+}
+
+func (w *XDMWallet) Decrypt(ctx context.Context, args wallet.DecryptArgs, _ string) (*wallet.DecryptResult, error) {
+	return xdmCall[wallet.DecryptResult](ctx, w, CallDecrypt, args)
+}
+
+func (w *XDMWallet) CreateHMAC(ctx context.Context, args wallet.CreateHMACArgs, _ string) (*wallet.CreateHMACResult, error) {
+	return xdmCall[wallet.CreateHMACResult](ctx, w, CallCreateHMAC, args)
+}
+
+func (w *XDMWallet) VerifyHMAC(ctx context.Context, args wallet.VerifyHMACArgs, _ string) (*wallet.VerifyHMACResult, error) {
+	return xdmCall[wallet.VerifyHMACResult](ctx, w, CallVerifyHMAC, args)
+}
+
+func (w *XDMWallet) CreateSignature(ctx context.Context, args wallet.CreateSignatureArgs, _ string) (*wallet.CreateSignatureResult, error) {
+	return xdmCall[wallet.CreateSignatureResult](ctx, w, CallCreateSignature, args)
+}
+
+func (w *XDMWallet) VerifySignature(ctx context.Context, args wallet.VerifySignatureArgs, _ string) (*wallet.VerifySignatureResult, error) {
+	return xdmCall[wallet.VerifySignatureResult](ctx, w, CallVerifySignature, args)
+}
+
+func (w *XDMWallet) AcquireCertificate(ctx context.Context, args wallet.AcquireCertificateArgs, _ string) (*wallet.Certificate, error) {
+	return xdmCall[wallet.Certificate](ctx, w, CallAcquireCertificate, args)
+}
+
+func (w *XDMWallet) ListCertificates(ctx context.Context, args wallet.ListCertificatesArgs, _ string) (*wallet.ListCertificatesResult, error) {
+	return xdmCall[wallet.ListCertificatesResult](ctx, w, CallListCertificates, args)
+}
+
+func (w *XDMWallet) ProveCertificate(ctx context.Context, args wallet.ProveCertificateArgs, _ string) (*wallet.ProveCertificateResult, error) {
+	return xdmCall[wallet.ProveCertificateResult](ctx, w, CallProveCertificate, args)
+}
+
+func (w *XDMWallet) RelinquishCertificate(ctx context.Context, args wallet.RelinquishCertificateArgs, _ string) (*wallet.RelinquishCertificateResult, error) {
+	return xdmCall[wallet.RelinquishCertificateResult](ctx, w, CallRelinquishCertificate, args)
+}
+
+func (w *XDMWallet) DiscoverByIdentityKey(ctx context.Context, args wallet.DiscoverByIdentityKeyArgs, _ string) (*wallet.DiscoverCertificatesResult, error) {
+	return xdmCall[wallet.DiscoverCertificatesResult](ctx, w, CallDiscoverByIdentityKey, args)
+}
+
+func (w *XDMWallet) DiscoverByAttributes(ctx context.Context, args wallet.DiscoverByAttributesArgs, _ string) (*wallet.DiscoverCertificatesResult, error) {
+	return xdmCall[wallet.DiscoverCertificatesResult](ctx, w, CallDiscoverByAttributes, args)
+}
+
+func (w *XDMWallet) IsAuthenticated(ctx context.Context, args any, _ string) (*wallet.AuthenticatedResult, error) {
+	return xdmCall[wallet.AuthenticatedResult](ctx, w, CallIsAuthenticated, args)
+}
+
+func (w *XDMWallet) WaitForAuthentication(ctx context.Context, args any, _ string) (*wallet.AuthenticatedResult, error) {
+	return xdmCall[wallet.AuthenticatedResult](ctx, w, CallWaitForAuthentication, args)
+}
+
+func (w *XDMWallet) GetHeight(ctx context.Context, args any, _ string) (*wallet.GetHeightResult, error) {
+	return xdmCall[wallet.GetHeightResult](ctx, w, CallGetHeight, args)
+}
+
+func (w *XDMWallet) GetHeaderForHeight(ctx context.Context, args wallet.GetHeaderArgs, _ string) (*wallet.GetHeaderResult, error) {
+	return xdmCall[wallet.GetHeaderResult](ctx, w, CallGetHeaderForHeight, args)
+}
+
+func (w *XDMWallet) GetNetwork(ctx context.Context, args any, _ string) (*wallet.GetNetworkResult, error) {
+	return xdmCall[wallet.GetNetworkResult](ctx, w, CallGetNetwork, args)
+}
+
+func (w *XDMWallet) GetVersion(ctx context.Context, args any, _ string) (*wallet.GetVersionResult, error) {
+	return xdmCall[wallet.GetVersionResult](ctx, w, CallGetVersion, args)
+}
+
+// XDMProcessor answers cross-document wallet invocations with a wallet, so a
+// wallet running in the browser can serve apps using the XDM substrate of
+// either SDK.
+type XDMProcessor struct {
+	handlers map[string]jsonHandler
+}
+
+// NewXDMProcessor creates an XDMProcessor calling w.
+func NewXDMProcessor(w wallet.Interface) *XDMProcessor {
+	return &XDMProcessor{handlers: jsonHandlers(w)}
+}
+
+// Process answers the invocation msg made by originator, returning the reply to
+// post back. It returns nil for messages which are not invocations.
+func (p *XDMProcessor) Process(ctx context.Context, msg *XDMMessage, originator string) *XDMMessage {
+	if msg == nil || msg.Type != XDMMessageType || !msg.IsInvocation {
+		return nil
+	}
+	reply := &XDMMessage{Type: XDMMessageType, ID: msg.ID}
+
+	handler, ok := p.handlers[msg.Call]
+	if !ok {
+		return xdmError(reply, fmt.Errorf("unknown call %q", msg.Call))
+	}
+	result, err := handler(ctx, msg.Args, originator)
+	if err != nil {
+		return xdmError(reply, err)
+	}
+	if reply.Result, err = json.Marshal(result); err != nil {
+		return xdmError(reply, fmt.Errorf("failed to marshal result: %w", err))
+	}
+	return reply
+}
+
+func xdmError(reply *XDMMessage, err error) *XDMMessage {
+	reply.Status = "error"
+	reply.Description = err.Error()
+	reply.Code = xdmErrorCode
+	var walletErr *wallet.Error
+	if errors.As(err, &walletErr) {
+		reply.Description = walletErr.Message
+		reply.Code = int(walletErr.Code)
+	}
+	return reply
+}
@@ -0,0 +1,101 @@
+//go:build js && wasm
+
+package substrates
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+	"syscall/js"
+
+	"github.com/bsv-blockchain/go-sdk/wallet"
+)
+
+// WindowXDMPort posts cross-document messages to a browser window with
+// postMessage.
+type WindowXDMPort struct {
+	target       js.Value
+	targetOrigin string
+}
+
+// NewWindowXDMPort creates a port posting to target, such as window.parent,
+// restricted to windows of targetOrigin, or "*" for any.
+func NewWindowXDMPort(target js.Value, targetOrigin string) *WindowXDMPort {
+	return &WindowXDMPort{target: target, targetOrigin: targetOrigin}
+}
+
+func (p *WindowXDMPort) PostMessage(msg *XDMMessage) error {
+	data, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+	// Post a plain object, as the TypeScript SDK does, rather than a string.
+	p.target.Call("postMessage", js.Global().Get("JSON").Call("parse", string(data)), p.targetOrigin)
+	return nil
+}
+
+// NewWindowXDMWallet returns an XDMWallet calling the wallet of the parent
+// window, the way the TypeScript SDK's XDM substrate does from an embedded app.
+// The returned function stops listening for replies.
+func NewWindowXDMWallet() (*XDMWallet, func()) {
+	window := js.Global()
+	w := NewXDMWallet(NewWindowXDMPort(window.Get("parent"), "*"))
+	stop := listenXDM(window, func(msg *XDMMessage, _ js.Value) {
+		w.HandleMessage(msg)
+	})
+	return w, stop
+}
+
+// ServeWindowXDM answers the wallet invocations posted to this window by other
+// windows with w, replying to the window each came from. The originator of a
+// call is the host of the calling window's origin. The returned function stops
+// serving.
+func ServeWindowXDM(ctx context.Context, w wallet.Interface) func() {
+	processor := NewXDMProcessor(w)
+	return listenXDM(js.Global(), func(msg *XDMMessage, event js.Value) {
+		origin := event.Get("origin").String()
+		source := event.Get("source")
+		if source.IsNull() || source.IsUndefined() {
+			return
+		}
+		go func() {
+			reply := processor.Process(ctx, msg, originatorFromOrigin(origin))
+			if reply != nil {
+				_ = NewWindowXDMPort(source, origin).PostMessage(reply)
+			}
+		}()
+	})
+}
+
+// listenXDM calls fn with the trusted cross-document wallet messages received
+// by window, until the returned function is called.
+func listenXDM(window js.Value, fn func(msg *XDMMessage, event js.Value)) func() {
+	listener := js.FuncOf(func(_ js.Value, args []js.Value) any {
+		event := args[0]
+		if !event.Get("isTrusted").Truthy() {
+			return nil
+		}
+		data := event.Get("data")
+		if data.Type() != js.TypeObject || data.Get("type").String() != XDMMessageType {
+			return nil
+		}
+		var msg XDMMessage
+		if err := json.Unmarshal([]byte(js.Global().Get("JSON").Call("stringify", data).String()), &msg); err != nil {
+			return nil
+		}
+		fn(&msg, event)
+		return nil
+	})
+	window.Call("addEventListener", "message", listener)
+	return func() {
+		window.Call("removeEventListener", "message", listener)
+		listener.Release()
+	}
+}
+
+func originatorFromOrigin(origin string) string {
+	if u, err := url.Parse(origin); err == nil && u.Host != "" {
+		return u.Host
+	}
+	return origin
+}
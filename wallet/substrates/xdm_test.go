@@ -0,0 +1,116 @@
+package substrates
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/bsv-blockchain/go-sdk/wallet"
+	"github.com/stretchr/testify/require"
+)
+
+// pipeXDMPort delivers invocations to a processor and its replies back to the
+// client, through JSON as postMessage would.
+type pipeXDMPort struct {
+	t         *testing.T
+	processor *XDMProcessor
+	client    *XDMWallet
+}
+
+func roundTripJSON(t *testing.T, msg *XDMMessage) *XDMMessage {
+	data, err := json.Marshal(msg)
+	require.NoError(t, err)
+	var out XDMMessage
+	require.NoError(t, json.Unmarshal(data, &out))
+	return &out
+}
+
+func (p *pipeXDMPort) PostMessage(msg *XDMMessage) error {
+	invocation := roundTripJSON(p.t, msg)
+	go func() {
+		reply := p.processor.Process(context.Background(), invocation, "example.com")
+		p.client.HandleMessage(roundTripJSON(p.t, reply))
+	}()
+	return nil
+}
+
+func newPipeXDMWallet(t *testing.T, w wallet.Interface) *XDMWallet {
+	port := &pipeXDMPort{t: t, processor: NewXDMProcessor(w)}
+	port.client = NewXDMWallet(port)
+	return port.client
+}
+
+func TestXDMWallet(t *testing.T) {
+	ctx := t.Context()
+	backend := wallet.NewTestWalletForRandomKey(t)
+	client := newPipeXDMWallet(t, backend)
+
+	encryption := wallet.EncryptionArgs{
+		ProtocolID:   wallet.Protocol{SecurityLevel: wallet.SecurityLevelEveryApp, Protocol: "xdm test"},
+		KeyID:        "1",
+		Counterparty: wallet.Counterparty{Type: wallet.CounterpartyTypeSelf},
+	}
+	identity, err := client.GetPublicKey(ctx, wallet.GetPublicKeyArgs{IdentityKey: true}, "")
+	require.NoError(t, err)
+	expected, err := backend.GetPublicKey(ctx, wallet.GetPublicKeyArgs{IdentityKey: true}, "")
+	require.NoError(t, err)
+	require.True(t, expected.PublicKey.IsEqual(identity.PublicKey))
+
+	encrypted, err := client.Encrypt(ctx, wallet.EncryptArgs{EncryptionArgs: encryption, Plaintext: []byte("hello")}, "")
+	require.NoError(t, err)
+	decrypted, err := client.Decrypt(ctx, wallet.DecryptArgs{EncryptionArgs: encryption, Ciphertext: encrypted.Ciphertext}, "")
+	require.NoError(t, err)
+	require.Equal(t, []byte("hello"), []byte(decrypted.Plaintext))
+
+	signed, err := client.CreateSignature(ctx, wallet.CreateSignatureArgs{EncryptionArgs: encryption, Data: []byte("data")}, "")
+	require.NoError(t, err)
+	verified, err := client.VerifySignature(ctx, wallet.VerifySignatureArgs{EncryptionArgs: encryption, Data: []byte("data"), Signature: signed.Signature}, "")
+	require.NoError(t, err)
+	require.True(t, verified.Valid)
+
+	t.Run("passes the originator from the window origin", func(t *testing.T) {
+		backend.OnGetHeight().Do(func(_ context.Context, _ any, originator string) (*wallet.GetHeightResult, error) {
+			require.Equal(t, "example.com", originator)
+			return &wallet.GetHeightResult{Height: 850000}, nil
+		})
+		height, err := client.GetHeight(ctx, nil, "ignored")
+		require.NoError(t, err)
+		require.Equal(t, uint32(850000), height.Height)
+	})
+
+	t.Run("replies with wallet errors", func(t *testing.T) {
+		backend.OnGetNetwork().ReturnError(&wallet.Error{Code: 5, Message: "no network"})
+		_, err := client.GetNetwork(ctx, nil, "")
+		var walletErr *wallet.Error
+		require.ErrorAs(t, err, &walletErr)
+		require.Equal(t, byte(5), walletErr.Code)
+		require.Equal(t, "no network", walletErr.Message)
+	})
+}
+
+func TestXDMProcessor(t *testing.T) {
+	processor := NewXDMProcessor(wallet.NewTestWalletForRandomKey(t))
+
+	require.Nil(t, processor.Process(t.Context(), &XDMMessage{Type: XDMMessageType, ID: "1"}, ""))
+	require.Nil(t, processor.Process(t.Context(), &XDMMessage{Type: "other", IsInvocation: true, ID: "1"}, ""))
+
+	reply := processor.Process(t.Context(), &XDMMessage{Type: XDMMessageType, IsInvocation: true, ID: "2", Call: "unknown"}, "")
+	require.Equal(t, "2", reply.ID)
+	require.Equal(t, "error", reply.Status)
+	require.Equal(t, xdmErrorCode, reply.Code)
+}
+
+// silentXDMPort never replies.
+type silentXDMPort struct{}
+
+func (silentXDMPort) PostMessage(*XDMMessage) error { return nil }
+
+func TestXDMWalletContext(t *testing.T) {
+	client := NewXDMWallet(silentXDMPort{})
+	ctx, cancel := context.WithTimeout(t.Context(), 10*time.Millisecond)
+	defer cancel()
+	_, err := client.GetVersion(ctx, nil, "")
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	require.Empty(t, client.pending)
+}
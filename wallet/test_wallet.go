@@ -14,6 +14,9 @@ import (
 // ensure that TestWallet is implementing wallet.Interface
 var _ Interface = &TestWallet{}
 
+// ensure that TestWallet is implementing wallet.AdminInterface
+var _ AdminInterface = &TestWallet{}
+
 type TestWalletOpts struct {
 	Name        string
 	Logger      *slog.Logger
@@ -110,6 +113,8 @@ type TestWallet struct {
 	getHeaderForHeightHandler           func(ctx context.Context, args GetHeaderArgs, originator string) (*GetHeaderResult, error)
 	getNetworkHandler                   func(ctx context.Context, args any, originator string) (*GetNetworkResult, error)
 	getVersionHandler                   func(ctx context.Context, args any, originator string) (*GetVersionResult, error)
+	relabelActionsHandler               func(ctx context.Context, args RelabelActionsArgs, originator string) (*RelabelActionsResult, error)
+	retagOutputsHandler                 func(ctx context.Context, args RetagOutputsArgs, originator string) (*RetagOutputsResult, error)
 
 	proto              Interface
 	logger             *slog.Logger
@@ -582,6 +587,62 @@ func (m *TestWallet) RelinquishOutput(ctx context.Context, args RelinquishOutput
 	return m.proto.RelinquishOutput(ctx, args, originator)
 }
 
+// OnRelabelActions returns a MockWalletMethods object that can be used to configure the behavior
+// of the RelabelActions method. This allows overriding the default implementation with custom
+// behavior for testing purposes.
+func (m *TestWallet) OnRelabelActions() *MockWalletMethods[RelabelActionsArgs, RelabelActionsResult] {
+	return &MockWalletMethods[RelabelActionsArgs, RelabelActionsResult]{
+		t: m.t,
+		setHandler: func(handler func(ctx context.Context, args RelabelActionsArgs, originator string) (*RelabelActionsResult, error)) {
+			m.relabelActionsHandler = handler
+		},
+	}
+}
+
+// RelabelActions is forwarded to the underlying wallet if it implements AdminInterface, and fails
+// with ErrAdminUnsupported otherwise.
+func (m *TestWallet) RelabelActions(ctx context.Context, args RelabelActionsArgs, originator string) (*RelabelActionsResult, error) {
+	m.logger.DebugContext(ctx, "Wallet method called", "method", "RelabelActions", "args", args, "originator", originator)
+
+	m.checkExpectations(ctx, args, originator)
+
+	if m.relabelActionsHandler != nil {
+		return m.relabelActionsHandler(ctx, args, originator)
+	}
+	if admin, ok := m.proto.(AdminInterface); ok {
+		return admin.RelabelActions(ctx, args, originator)
+	}
+	return nil, ErrAdminUnsupported
+}
+
+// OnRetagOutputs returns a MockWalletMethods object that can be used to configure the behavior
+// of the RetagOutputs method. This allows overriding the default implementation with custom
+// behavior for testing purposes.
+func (m *TestWallet) OnRetagOutputs() *MockWalletMethods[RetagOutputsArgs, RetagOutputsResult] {
+	return &MockWalletMethods[RetagOutputsArgs, RetagOutputsResult]{
+		t: m.t,
+		setHandler: func(handler func(ctx context.Context, args RetagOutputsArgs, originator string) (*RetagOutputsResult, error)) {
+			m.retagOutputsHandler = handler
+		},
+	}
+}
+
+// RetagOutputs is forwarded to the underlying wallet if it implements AdminInterface, and fails
+// with ErrAdminUnsupported otherwise.
+func (m *TestWallet) RetagOutputs(ctx context.Context, args RetagOutputsArgs, originator string) (*RetagOutputsResult, error) {
+	m.logger.DebugContext(ctx, "Wallet method called", "method", "RetagOutputs", "args", args, "originator", originator)
+
+	m.checkExpectations(ctx, args, originator)
+
+	if m.retagOutputsHandler != nil {
+		return m.retagOutputsHandler(ctx, args, originator)
+	}
+	if admin, ok := m.proto.(AdminInterface); ok {
+		return admin.RetagOutputs(ctx, args, originator)
+	}
+	return nil, ErrAdminUnsupported
+}
+
 // OnRevealCounterpartyKeyLinkage returns a MockWalletMethods object that can be used to configure the behavior
 // of the RevealCounterpartyKeyLinkage method. This allows overriding the default implementation with custom
 // behavior for testing purposes.
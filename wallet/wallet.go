@@ -7,7 +7,11 @@
 package wallet
 
 import (
+	"fmt"
+
 	ec "github.com/bsv-blockchain/go-sdk/primitives/ec"
+	"github.com/bsv-blockchain/go-sdk/script"
+	"github.com/bsv-blockchain/go-sdk/transaction"
 	sighash "github.com/bsv-blockchain/go-sdk/transaction/sighash"
 )
 
@@ -119,11 +123,46 @@ type GetPublicKeyResult struct {
 }
 
 // CreateSignatureArgs contains parameters for creating a digital signature.
-// It can sign either raw data (which will be hashed) or a pre-computed hash.
+// It can sign either raw data (which will be hashed), a pre-computed hash,
+// or the signature hash of a transaction input described by SighashContext.
 type CreateSignatureArgs struct {
 	EncryptionArgs
-	Data               BytesList `json:"data,omitempty"`
-	HashToDirectlySign BytesList `json:"hashToDirectlySign,omitempty"`
+	Data               BytesList       `json:"data,omitempty"`
+	HashToDirectlySign BytesList       `json:"hashToDirectlySign,omitempty"`
+	SighashContext     *SighashContext `json:"sighashContext,omitempty"`
+}
+
+// SighashContext describes a transaction input to be signed by CreateSignature.
+// The wallet computes the signature hash itself using the given flag, so callers
+// only need to append the flag byte to the returned DER signature to build an
+// unlocking script.
+type SighashContext struct {
+	Tx                  BytesList    `json:"tx"`
+	InputIndex          uint32       `json:"inputIndex"`
+	SourceSatoshis      uint64       `json:"sourceSatoshis"`
+	SourceLockingScript BytesList    `json:"sourceLockingScript"`
+	SighashFlag         sighash.Flag `json:"sighashFlag"`
+}
+
+// signatureHash parses the transaction, attaches the source output to the input
+// being signed and returns the digest to sign for the configured sighash flag.
+func (s *SighashContext) signatureHash() ([]byte, error) {
+	tx, err := transaction.NewTransactionFromBytes(s.Tx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse transaction: %w", err)
+	}
+	if int(s.InputIndex) >= len(tx.Inputs) {
+		return nil, fmt.Errorf("input index %d out of range, transaction has %d inputs", s.InputIndex, len(tx.Inputs))
+	}
+	if s.SighashFlag == 0 {
+		return nil, fmt.Errorf("sighash flag must be set")
+	}
+	lockingScript := script.Script(s.SourceLockingScript)
+	tx.Inputs[s.InputIndex].SetSourceTxOutput(&transaction.TransactionOutput{
+		Satoshis:      s.SourceSatoshis,
+		LockingScript: &lockingScript,
+	})
+	return tx.CalcInputSignatureHash(s.InputIndex, s.SighashFlag)
 }
 
 // CreateSignatureResult contains the result of a signature creation operation.
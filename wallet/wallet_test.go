@@ -5,8 +5,12 @@ import (
 	"testing"
 
 	ec "github.com/bsv-blockchain/go-sdk/primitives/ec"
+	"github.com/bsv-blockchain/go-sdk/script"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+	sighash "github.com/bsv-blockchain/go-sdk/transaction/sighash"
 	"github.com/bsv-blockchain/go-sdk/util"
 	"github.com/bsv-blockchain/go-sdk/wallet"
+	"github.com/bsv-blockchain/go-sdk/wallet/certtypes"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -633,3 +637,82 @@ func TestHMACCreateVerify(t *testing.T) {
 		assert.True(t, verifyResult.Valid)
 	})
 }
+
+func TestCreateSignatureWithSighashContext(t *testing.T) {
+	userKey, err := ec.NewPrivateKey()
+	require.NoError(t, err)
+	userWallet, err := wallet.NewWallet(userKey)
+	require.NoError(t, err)
+
+	const lockingScriptHex = "76a914eb0bd5edba389198e73f8efabddfc61666969ff788ac"
+	const sourceSatoshis = uint64(1000)
+
+	tx := transaction.NewTransaction()
+	err = tx.AddInputFrom("45be95d2f2c64e99518ffbbce03fb15a7758f20ee5eecf0df07938d977add71d", 0, lockingScriptHex, sourceSatoshis, nil)
+	require.NoError(t, err)
+	err = tx.PayToAddress("1AdZmoAQUw4XCsCihukoHMvNWXcsd8jDN6", 900)
+	require.NoError(t, err)
+
+	lockingScript, err := script.NewFromHex(lockingScriptHex)
+	require.NoError(t, err)
+
+	baseArgs := wallet.EncryptionArgs{
+		ProtocolID:   protocol,
+		KeyID:        keyID,
+		Counterparty: wallet.Counterparty{Type: wallet.CounterpartyTypeSelf},
+	}
+	ctx := t.Context()
+
+	sigResult, err := userWallet.CreateSignature(ctx, wallet.CreateSignatureArgs{
+		EncryptionArgs: baseArgs,
+		SighashContext: &wallet.SighashContext{
+			Tx:                  tx.Bytes(),
+			InputIndex:          0,
+			SourceSatoshis:      sourceSatoshis,
+			SourceLockingScript: lockingScript.Bytes(),
+			SighashFlag:         sighash.AllForkID,
+		},
+	}, "")
+	require.NoError(t, err)
+
+	pubKeyResult, err := userWallet.GetPublicKey(ctx, wallet.GetPublicKeyArgs{
+		EncryptionArgs: baseArgs,
+		ForSelf:        util.BoolPtr(true),
+	}, "")
+	require.NoError(t, err)
+
+	sigHash, err := tx.CalcInputSignatureHash(0, sighash.AllForkID)
+	require.NoError(t, err)
+	require.True(t, sigResult.Signature.Verify(sigHash, pubKeyResult.PublicKey))
+
+	t.Run("input index out of range", func(t *testing.T) {
+		_, err := userWallet.CreateSignature(ctx, wallet.CreateSignatureArgs{
+			EncryptionArgs: baseArgs,
+			SighashContext: &wallet.SighashContext{
+				Tx:          tx.Bytes(),
+				InputIndex:  1,
+				SighashFlag: sighash.AllForkID,
+			},
+		}, "")
+		require.Error(t, err)
+	})
+}
+
+func TestAcquireCertificateArgsValidateFields(t *testing.T) {
+	certType, err := wallet.CertificateTypeFromBase64(certtypes.EmailCert)
+	require.NoError(t, err)
+
+	args := wallet.AcquireCertificateArgs{
+		Type:                certType,
+		AcquisitionProtocol: wallet.AcquisitionProtocolIssuance,
+		Fields:              map[string]string{"email": "alice@example.com"},
+	}
+	require.NoError(t, args.ValidateFields())
+
+	args.Fields = map[string]string{}
+	require.ErrorIs(t, args.ValidateFields(), certtypes.ErrMissingField)
+
+	// Fields of direct acquisitions are encrypted.
+	args.AcquisitionProtocol = wallet.AcquisitionProtocolDirect
+	require.NoError(t, args.ValidateFields())
+}
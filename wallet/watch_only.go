@@ -0,0 +1,240 @@
+package wallet
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	bip32 "github.com/bsv-blockchain/go-sdk/compat/bip32"
+	ec "github.com/bsv-blockchain/go-sdk/primitives/ec"
+)
+
+// ErrWatchOnly is returned by watch-only wallets for operations which need the
+// root private key, such as signing and decryption.
+var ErrWatchOnly = errors.New("operation requires the private key, which a watch-only wallet does not have")
+
+var _ KeyDeriverInterface = (*WatchOnlyKeyDeriver)(nil)
+
+// WatchOnlyKeyDeriver is a KeyDeriverInterface holding only the identity public
+// key. Without the root private key it can only derive public keys shared with
+// the anyone counterparty, whose shared secret is the identity key itself; every
+// other derivation fails with ErrWatchOnly.
+type WatchOnlyKeyDeriver struct {
+	identityKey *ec.PublicKey
+}
+
+// NewWatchOnlyKeyDeriver creates a WatchOnlyKeyDeriver for identityKey.
+func NewWatchOnlyKeyDeriver(identityKey *ec.PublicKey) *WatchOnlyKeyDeriver {
+	return &WatchOnlyKeyDeriver{identityKey: identityKey}
+}
+
+func (kd *WatchOnlyKeyDeriver) IdentityKey() *ec.PublicKey {
+	return kd.identityKey
+}
+
+// DerivePublicKey derives public keys for the anyone counterparty.
+func (kd *WatchOnlyKeyDeriver) DerivePublicKey(protocol Protocol, keyID string, counterparty Counterparty, forSelf bool) (*ec.PublicKey, error) {
+	if counterparty.Type != CounterpartyTypeAnyone {
+		return nil, ErrWatchOnly
+	}
+	invoiceNumber, err := ComputeInvoiceNumber(protocol, keyID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compute invoice number: %w", err)
+	}
+	// The anyone private key is 1, so the shared secret is the identity key.
+	if forSelf {
+		return childPublicKey(kd.identityKey, kd.identityKey, invoiceNumber), nil
+	}
+	_, anyone := AnyoneKey()
+	return childPublicKey(anyone, kd.identityKey, invoiceNumber), nil
+}
+
+func (kd *WatchOnlyKeyDeriver) DerivePrivateKey(Protocol, string, Counterparty) (*ec.PrivateKey, error) {
+	return nil, ErrWatchOnly
+}
+
+func (kd *WatchOnlyKeyDeriver) DeriveSymmetricKey(Protocol, string, Counterparty) (*ec.SymmetricKey, error) {
+	return nil, ErrWatchOnly
+}
+
+func (kd *WatchOnlyKeyDeriver) RevealSpecificSecret(Counterparty, Protocol, string) ([]byte, error) {
+	return nil, ErrWatchOnly
+}
+
+func (kd *WatchOnlyKeyDeriver) RevealCounterpartySecret(Counterparty) (*ec.PublicKey, error) {
+	return nil, ErrWatchOnly
+}
+
+var _ Interface = (*WatchOnlyWallet)(nil)
+var _ AdminInterface = (*WatchOnlyWallet)(nil)
+
+// WatchOnlyWallet is a wallet of an identity whose root private key is kept
+// elsewhere, such as on an air-gapped signer. It lists outputs and actions and
+// creates unsigned actions through a backend wallet managing the identity's
+// storage, while the operations needing the private key fail with ErrWatchOnly.
+//
+// CreateAction always returns a SignableTransaction for the signer to complete;
+// the signed transaction is then processed by the backend, not by SignAction.
+type WatchOnlyWallet struct {
+	*ProtoWallet
+	backend Interface
+}
+
+// NewWatchOnlyWallet creates a watch-only wallet for identityKey, forwarding
+// the operations which need no private key to backend.
+func NewWatchOnlyWallet(identityKey *ec.PublicKey, backend Interface) (*WatchOnlyWallet, error) {
+	if identityKey == nil {
+		return nil, errors.New("identity key is required")
+	}
+	if backend == nil {
+		return nil, errors.New("backend wallet is required")
+	}
+	proto, err := NewProtoWallet(ProtoWalletArgs{
+		Type:       ProtoWalletArgsTypeKeyDeriver,
+		KeyDeriver: NewWatchOnlyKeyDeriver(identityKey),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &WatchOnlyWallet{ProtoWallet: proto, backend: backend}, nil
+}
+
+// NewWatchOnlyWalletFromXPub creates a watch-only wallet whose identity key is
+// the public key of the extended public key xpub.
+func NewWatchOnlyWalletFromXPub(xpub string, backend Interface) (*WatchOnlyWallet, error) {
+	key, err := bip32.NewKeyFromString(xpub)
+	if err != nil {
+		return nil, fmt.Errorf("invalid extended public key: %w", err)
+	}
+	if key.IsPrivate() {
+		return nil, errors.New("extended key is private, expected an extended public key")
+	}
+	identityKey, err := key.ECPubKey()
+	if err != nil {
+		return nil, fmt.Errorf("invalid extended public key: %w", err)
+	}
+	return NewWatchOnlyWallet(identityKey, backend)
+}
+
+// CreateAction creates the action through the backend without signing it,
+// returning a SignableTransaction.
+func (w *WatchOnlyWallet) CreateAction(ctx context.Context, args CreateActionArgs, originator string) (*CreateActionResult, error) {
+	var options CreateActionOptions
+	if args.Options != nil {
+		options = *args.Options
+	}
+	signAndProcess := false
+	options.SignAndProcess = &signAndProcess
+	args.Options = &options
+	return w.backend.CreateAction(ctx, args, originator)
+}
+
+// SignAction fails with ErrWatchOnly.
+func (w *WatchOnlyWallet) SignAction(context.Context, SignActionArgs, string) (*SignActionResult, error) {
+	return nil, ErrWatchOnly
+}
+
+// RevealCounterpartyKeyLinkage fails with ErrWatchOnly.
+func (w *WatchOnlyWallet) RevealCounterpartyKeyLinkage(context.Context, RevealCounterpartyKeyLinkageArgs, string) (*RevealCounterpartyKeyLinkageResult, error) {
+	return nil, ErrWatchOnly
+}
+
+// RevealSpecificKeyLinkage fails with ErrWatchOnly.
+func (w *WatchOnlyWallet) RevealSpecificKeyLinkage(context.Context, RevealSpecificKeyLinkageArgs, string) (*RevealSpecificKeyLinkageResult, error) {
+	return nil, ErrWatchOnly
+}
+
+// AcquireCertificate stores certificates acquired directly through the backend.
+// Issuance fails with ErrWatchOnly, as it needs the private key.
+func (w *WatchOnlyWallet) AcquireCertificate(ctx context.Context, args AcquireCertificateArgs, originator string) (*Certificate, error) {
+	if args.AcquisitionProtocol == AcquisitionProtocolIssuance {
+		return nil, ErrWatchOnly
+	}
+	return w.backend.AcquireCertificate(ctx, args, originator)
+}
+
+// ProveCertificate fails with ErrWatchOnly, as revealing fields to a verifier
+// needs the private key.
+func (w *WatchOnlyWallet) ProveCertificate(context.Context, ProveCertificateArgs, string) (*ProveCertificateResult, error) {
+	return nil, ErrWatchOnly
+}
+
+func (w *WatchOnlyWallet) AbortAction(ctx context.Context, args AbortActionArgs, originator string) (*AbortActionResult, error) {
+	return w.backend.AbortAction(ctx, args, originator)
+}
+
+func (w *WatchOnlyWallet) ListActions(ctx context.Context, args ListActionsArgs, originator string) (*ListActionsResult, error) {
+	return w.backend.ListActions(ctx, args, originator)
+}
+
+func (w *WatchOnlyWallet) InternalizeAction(ctx context.Context, args InternalizeActionArgs, originator string) (*InternalizeActionResult, error) {
+	return w.backend.InternalizeAction(ctx, args, originator)
+}
+
+func (w *WatchOnlyWallet) ListOutputs(ctx context.Context, args ListOutputsArgs, originator string) (*ListOutputsResult, error) {
+	return w.backend.ListOutputs(ctx, args, originator)
+}
+
+func (w *WatchOnlyWallet) RelinquishOutput(ctx context.Context, args RelinquishOutputArgs, originator string) (*RelinquishOutputResult, error) {
+	return w.backend.RelinquishOutput(ctx, args, originator)
+}
+
+// RelabelActions is forwarded to the backend if it implements AdminInterface,
+// and fails with ErrAdminUnsupported otherwise.
+func (w *WatchOnlyWallet) RelabelActions(ctx context.Context, args RelabelActionsArgs, originator string) (*RelabelActionsResult, error) {
+	admin, ok := w.backend.(AdminInterface)
+	if !ok {
+		return nil, ErrAdminUnsupported
+	}
+	return admin.RelabelActions(ctx, args, originator)
+}
+
+// RetagOutputs is forwarded to the backend if it implements AdminInterface,
+// and fails with ErrAdminUnsupported otherwise.
+func (w *WatchOnlyWallet) RetagOutputs(ctx context.Context, args RetagOutputsArgs, originator string) (*RetagOutputsResult, error) {
+	admin, ok := w.backend.(AdminInterface)
+	if !ok {
+		return nil, ErrAdminUnsupported
+	}
+	return admin.RetagOutputs(ctx, args, originator)
+}
+
+func (w *WatchOnlyWallet) ListCertificates(ctx context.Context, args ListCertificatesArgs, originator string) (*ListCertificatesResult, error) {
+	return w.backend.ListCertificates(ctx, args, originator)
+}
+
+func (w *WatchOnlyWallet) RelinquishCertificate(ctx context.Context, args RelinquishCertificateArgs, originator string) (*RelinquishCertificateResult, error) {
+	return w.backend.RelinquishCertificate(ctx, args, originator)
+}
+
+func (w *WatchOnlyWallet) DiscoverByIdentityKey(ctx context.Context, args DiscoverByIdentityKeyArgs, originator string) (*DiscoverCertificatesResult, error) {
+	return w.backend.DiscoverByIdentityKey(ctx, args, originator)
+}
+
+func (w *WatchOnlyWallet) DiscoverByAttributes(ctx context.Context, args DiscoverByAttributesArgs, originator string) (*DiscoverCertificatesResult, error) {
+	return w.backend.DiscoverByAttributes(ctx, args, originator)
+}
+
+func (w *WatchOnlyWallet) IsAuthenticated(ctx context.Context, args any, originator string) (*AuthenticatedResult, error) {
+	return w.backend.IsAuthenticated(ctx, args, originator)
+}
+
+func (w *WatchOnlyWallet) WaitForAuthentication(ctx context.Context, args any, originator string) (*AuthenticatedResult, error) {
+	return w.backend.WaitForAuthentication(ctx, args, originator)
+}
+
+func (w *WatchOnlyWallet) GetHeight(ctx context.Context, args any, originator string) (*GetHeightResult, error) {
+	return w.backend.GetHeight(ctx, args, originator)
+}
+
+func (w *WatchOnlyWallet) GetHeaderForHeight(ctx context.Context, args GetHeaderArgs, originator string) (*GetHeaderResult, error) {
+	return w.backend.GetHeaderForHeight(ctx, args, originator)
+}
+
+func (w *WatchOnlyWallet) GetNetwork(ctx context.Context, args any, originator string) (*GetNetworkResult, error) {
+	return w.backend.GetNetwork(ctx, args, originator)
+}
+
+func (w *WatchOnlyWallet) GetVersion(ctx context.Context, args any, originator string) (*GetVersionResult, error) {
+	return w.backend.GetVersion(ctx, args, originator)
+}
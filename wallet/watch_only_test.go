@@ -0,0 +1,129 @@
+package wallet_test
+
+import (
+	"context"
+	"testing"
+
+	compat "github.com/bsv-blockchain/go-sdk/compat/bip32"
+	"github.com/bsv-blockchain/go-sdk/util"
+	"github.com/bsv-blockchain/go-sdk/wallet"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWatchOnlyWallet(t *testing.T) {
+	ctx := t.Context()
+	hdKey, err := compat.GenerateHDKey(compat.RecommendedSeedLen)
+	require.NoError(t, err)
+	rootKey, err := hdKey.ECPrivKey()
+	require.NoError(t, err)
+	xpub, err := hdKey.Neuter()
+	require.NoError(t, err)
+	signer, err := wallet.NewCompletedProtoWallet(rootKey)
+	require.NoError(t, err)
+
+	backend := wallet.NewTestWalletForRandomKey(t)
+	w, err := wallet.NewWatchOnlyWalletFromXPub(xpub.String(), backend)
+	require.NoError(t, err)
+
+	t.Run("public keys", func(t *testing.T) {
+		identity, err := w.GetPublicKey(ctx, wallet.GetPublicKeyArgs{IdentityKey: true}, "")
+		require.NoError(t, err)
+		require.True(t, identity.PublicKey.IsEqual(rootKey.PubKey()))
+
+		for _, forSelf := range []bool{true, false} {
+			args := wallet.GetPublicKeyArgs{
+				EncryptionArgs: wallet.EncryptionArgs{
+					ProtocolID:   wallet.Protocol{SecurityLevel: wallet.SecurityLevelEveryApp, Protocol: "watch only"},
+					KeyID:        "1",
+					Counterparty: wallet.Counterparty{Type: wallet.CounterpartyTypeAnyone},
+				},
+				ForSelf: &forSelf,
+			}
+			watched, err := w.GetPublicKey(ctx, args, "")
+			require.NoError(t, err)
+			expected, err := signer.GetPublicKey(ctx, args, "")
+			require.NoError(t, err)
+			require.True(t, watched.PublicKey.IsEqual(expected.PublicKey))
+		}
+
+		_, err = w.GetPublicKey(ctx, wallet.GetPublicKeyArgs{
+			EncryptionArgs: wallet.EncryptionArgs{
+				ProtocolID: wallet.Protocol{SecurityLevel: wallet.SecurityLevelEveryApp, Protocol: "watch only"},
+				KeyID:      "1",
+			},
+		}, "")
+		require.ErrorIs(t, err, wallet.ErrWatchOnly)
+	})
+
+	t.Run("verifies its own public signatures", func(t *testing.T) {
+		encryption := wallet.EncryptionArgs{
+			ProtocolID:   wallet.Protocol{SecurityLevel: wallet.SecurityLevelEveryApp, Protocol: "watch only"},
+			KeyID:        "1",
+			Counterparty: wallet.Counterparty{Type: wallet.CounterpartyTypeAnyone},
+		}
+		signed, err := signer.CreateSignature(ctx, wallet.CreateSignatureArgs{EncryptionArgs: encryption, Data: []byte("data")}, "")
+		require.NoError(t, err)
+		verified, err := w.VerifySignature(ctx, wallet.VerifySignatureArgs{
+			EncryptionArgs: encryption,
+			Data:           []byte("data"),
+			Signature:      signed.Signature,
+			ForSelf:        util.BoolPtr(true),
+		}, "")
+		require.NoError(t, err)
+		require.True(t, verified.Valid)
+	})
+
+	t.Run("creates unsigned actions", func(t *testing.T) {
+		signable := &wallet.SignableTransaction{Tx: []byte{1}, Reference: []byte("ref")}
+		backend.OnCreateAction().Do(func(_ context.Context, args wallet.CreateActionArgs, _ string) (*wallet.CreateActionResult, error) {
+			require.False(t, *args.Options.SignAndProcess)
+			require.False(t, *args.Options.RandomizeOutputs)
+			return &wallet.CreateActionResult{SignableTransaction: signable}, nil
+		})
+		options := &wallet.CreateActionOptions{SignAndProcess: util.BoolPtr(true), RandomizeOutputs: util.BoolPtr(false)}
+		result, err := w.CreateAction(ctx, wallet.CreateActionArgs{Description: "unsigned", Options: options}, "")
+		require.NoError(t, err)
+		require.Equal(t, signable, result.SignableTransaction)
+		require.True(t, *options.SignAndProcess)
+
+		backend.OnListOutputs().ReturnSuccess(&wallet.ListOutputsResult{TotalOutputs: 1, Outputs: []wallet.Output{{Satoshis: 5}}})
+		outputs, err := w.ListOutputs(ctx, wallet.ListOutputsArgs{Basket: "default"}, "")
+		require.NoError(t, err)
+		require.Len(t, outputs.Outputs, 1)
+	})
+
+	t.Run("refuses private key operations", func(t *testing.T) {
+		encryption := wallet.EncryptionArgs{
+			ProtocolID:   wallet.Protocol{SecurityLevel: wallet.SecurityLevelEveryApp, Protocol: "watch only"},
+			KeyID:        "1",
+			Counterparty: wallet.Counterparty{Type: wallet.CounterpartyTypeAnyone},
+		}
+		_, err := w.CreateSignature(ctx, wallet.CreateSignatureArgs{EncryptionArgs: encryption, Data: []byte("data")}, "")
+		require.ErrorIs(t, err, wallet.ErrWatchOnly)
+		_, err = w.Encrypt(ctx, wallet.EncryptArgs{EncryptionArgs: encryption, Plaintext: []byte("data")}, "")
+		require.ErrorIs(t, err, wallet.ErrWatchOnly)
+		_, err = w.Decrypt(ctx, wallet.DecryptArgs{EncryptionArgs: encryption, Ciphertext: []byte("data")}, "")
+		require.ErrorIs(t, err, wallet.ErrWatchOnly)
+		_, err = w.CreateHMAC(ctx, wallet.CreateHMACArgs{EncryptionArgs: encryption, Data: []byte("data")}, "")
+		require.ErrorIs(t, err, wallet.ErrWatchOnly)
+		_, err = w.SignAction(ctx, wallet.SignActionArgs{Reference: []byte("ref")}, "")
+		require.ErrorIs(t, err, wallet.ErrWatchOnly)
+		_, err = w.RevealCounterpartyKeyLinkage(ctx, wallet.RevealCounterpartyKeyLinkageArgs{}, "")
+		require.ErrorIs(t, err, wallet.ErrWatchOnly)
+		_, err = w.RevealSpecificKeyLinkage(ctx, wallet.RevealSpecificKeyLinkageArgs{}, "")
+		require.ErrorIs(t, err, wallet.ErrWatchOnly)
+		_, err = w.ProveCertificate(ctx, wallet.ProveCertificateArgs{}, "")
+		require.ErrorIs(t, err, wallet.ErrWatchOnly)
+		_, err = w.AcquireCertificate(ctx, wallet.AcquireCertificateArgs{AcquisitionProtocol: wallet.AcquisitionProtocolIssuance}, "")
+		require.ErrorIs(t, err, wallet.ErrWatchOnly)
+	})
+
+	t.Run("rejects private extended keys", func(t *testing.T) {
+		_, err := wallet.NewWatchOnlyWalletFromXPub(hdKey.String(), backend)
+		require.Error(t, err)
+		_, err = wallet.NewWatchOnlyWalletFromXPub("not a key", backend)
+		require.Error(t, err)
+		_, err = wallet.NewWatchOnlyWallet(nil, backend)
+		require.Error(t, err)
+	})
+}